@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+type achievementFuncs struct {
+	storage                    achievementStorage
+	createAchievementFunc      achievement.CreateFunc
+	getAchievementFunc         achievement.GetByIDAndGameIDFunc
+	listAchievementsFunc       achievement.ListFunc
+	deleteAchievementFunc      achievement.DeleteFunc
+	listPlayerAchievementsFunc achievement.ListPlayerAchievementsFunc
+}
+
+func newAchievements(config Config, drivers storageDrivers, statisticStorage statisticStorage, questStorage questStorage) achievementFuncs {
+	var (
+		ok                         bool
+		achievementStorage         achievementStorage
+		createAchievementFunc      achievement.CreateFunc
+		getAchievementFunc         achievement.GetByIDAndGameIDFunc
+		listAchievementsFunc       achievement.ListFunc
+		deleteAchievementFunc      achievement.DeleteFunc
+		listPlayerAchievementsFunc achievement.ListPlayerAchievementsFunc
+	)
+	if config.AchievementsEnabled {
+		if achievementStorage, ok = drivers.achievementStorages[config.AchievementStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.AchievementStorage), "invalid achievement storage")
+		}
+
+		createAchievementFunc = achievement.BuildCreateFunc(
+			statistic.BuildGetStatisticByIDAndGameID(statisticStorage.GetStatisticByIDAndGameID),
+			quest.BuildGetQuestByIDAndGameIDFunc(questStorage.GetQuestByIDAndGameID),
+			achievementStorage.CreateAchievement,
+		)
+		getAchievementFunc = achievement.BuildGetByIDAndGameIDFunc(achievementStorage.GetAchievement)
+		listAchievementsFunc = achievement.BuildListFunc(achievementStorage.ListAchievements)
+		deleteAchievementFunc = achievement.BuildDeleteFunc(achievementStorage.DeleteAchievement)
+		listPlayerAchievementsFunc = achievement.BuildListPlayerAchievementsFunc(achievementStorage.ListAchievements, achievementStorage.ListAchievementUnlocks)
+	}
+
+	return achievementFuncs{
+		storage:                    achievementStorage,
+		createAchievementFunc:      createAchievementFunc,
+		getAchievementFunc:         getAchievementFunc,
+		listAchievementsFunc:       listAchievementsFunc,
+		deleteAchievementFunc:      deleteAchievementFunc,
+		listPlayerAchievementsFunc: listPlayerAchievementsFunc,
+	}
+}
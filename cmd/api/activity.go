@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/activity"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type activityFuncs struct {
+	storage            activityStorage
+	listActivitiesFunc activity.ListFunc
+}
+
+// The personal bests and rank milestones are recorded on the players' feeds as their ranks change, so the
+// submissions rejected by the bans and segments never cost the reads of the player's rank
+func newActivities(config Config, drivers storageDrivers, leaderboardStorage leaderboardStorage, upsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc) (activityFuncs, leaderboard.StorageUpsertPlayerRankValueFunc) {
+	var (
+		ok                 bool
+		activityStorage    activityStorage
+		listActivitiesFunc activity.ListFunc
+	)
+	if config.ActivityEnabled {
+		if activityStorage, ok = drivers.activityStorages[config.ActivityStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.ActivityStorage), "invalid activity storage")
+		}
+
+		listActivitiesFunc = activity.BuildListFunc(activityStorage.ListActivities)
+
+		upsertPlayerRankValueFunc = activity.BuildRecordRankActivitiesFunc(leaderboardStorage.GetPlayerRank, activityStorage.RecordActivities, upsertPlayerRankValueFunc)
+	}
+
+	return activityFuncs{
+		storage:            activityStorage,
+		listActivitiesFunc: listActivitiesFunc,
+	}, upsertPlayerRankValueFunc
+}
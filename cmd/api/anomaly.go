@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+)
+
+type anomalyFuncs struct {
+	storage             anomalyStorage
+	detectAnomaliesFunc anomaly.DetectFunc
+	listAnomaliesFunc   anomaly.ListFunc
+	dismissAnomalyFunc  anomaly.DismissFunc
+}
+
+// The anomalies are flagged by a scheduled job, registered by main, and reviewed through the admin endpoints
+func newAnomalies(config Config, drivers storageDrivers, leaderboardStorage leaderboardStorage) anomalyFuncs {
+	var (
+		ok                  bool
+		err                 error
+		anomalyStorage      anomalyStorage
+		detectAnomaliesFunc anomaly.DetectFunc
+		listAnomaliesFunc   anomaly.ListFunc
+		dismissAnomalyFunc  anomaly.DismissFunc
+	)
+	if config.AnomalyDetectionEnabled {
+		if anomalyStorage, ok = drivers.anomalyStorages[config.AnomalyStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.AnomalyStorage), "invalid anomaly storage")
+		}
+
+		detectAnomaliesFunc, err = anomaly.BuildDetectFunc(
+			anomaly.Config{
+				Method:    config.AnomalyDetectionMethod,
+				Threshold: config.AnomalyDetectionThreshold,
+				MinRanks:  config.AnomalyDetectionMinRanks,
+				MaxRanks:  config.AnomalyDetectionMaxRanks,
+			},
+			leaderboardStorage.ListLeaderboardsScheduledBetween,
+			leaderboardStorage.GetRanking,
+			anomalyStorage.ListLeaderboardAnomalies,
+			anomalyStorage.SaveAnomaly,
+		)
+		if err != nil {
+			zap.Panic(err, "invalid anomaly detection settings")
+		}
+
+		listAnomaliesFunc = anomaly.BuildListFunc(anomalyStorage.ListAnomalies)
+		dismissAnomalyFunc = anomaly.BuildDismissFunc(anomalyStorage.DismissAnomaly)
+	}
+
+	return anomalyFuncs{
+		storage:             anomalyStorage,
+		detectAnomaliesFunc: detectAnomaliesFunc,
+		listAnomaliesFunc:   listAnomaliesFunc,
+		dismissAnomalyFunc:  dismissAnomalyFunc,
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/controller/rest"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type banFuncs struct {
+	storage            banStorage
+	banPlayerFunc      ban.BanFunc
+	liftBanFunc        ban.LiftFunc
+	getBanFunc         ban.GetFunc
+	listBansFunc       ban.ListFunc
+	banDeviceFunc      ban.BanDeviceFunc
+	liftDeviceBanFunc  ban.LiftDeviceFunc
+	getDeviceBanFunc   ban.GetDeviceFunc
+	listDeviceBansFunc ban.ListDevicesFunc
+}
+
+// Banning a player moves their ranks aside and rejects their submissions, before they're recorded on the score history.
+// Banning a device rejects the submissions carrying it on their fingerprint, whatever the player
+func newBans(config Config, drivers storageDrivers, cache cacheStorage, leaderboardStorage leaderboardStorage, upsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc) (banFuncs, leaderboard.StorageUpsertPlayerRankValueFunc) {
+	var (
+		ok                 bool
+		banStorage         banStorage
+		banPlayerFunc      ban.BanFunc
+		liftBanFunc        ban.LiftFunc
+		getBanFunc         ban.GetFunc
+		listBansFunc       ban.ListFunc
+		banDeviceFunc      ban.BanDeviceFunc
+		liftDeviceBanFunc  ban.LiftDeviceFunc
+		getDeviceBanFunc   ban.GetDeviceFunc
+		listDeviceBansFunc ban.ListDevicesFunc
+	)
+	if config.BansEnabled {
+		if banStorage, ok = drivers.banStorages[config.BanStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.BanStorage), "invalid ban storage")
+		}
+
+		// The ranking pages cached on the response cache are dropped as the ranks are hidden and put back, as the bans
+		// lifted on their own are lifted outside of the API
+		invalidateRankingPagesFunc := rest.BuildInvalidateRankingPagesFunc(cache)
+		banPlayerFunc = ban.BuildBanFunc(
+			leaderboardStorage.ListLeaderboards,
+			leaderboardStorage.GetPlayerRank,
+			ban.StorageDeletePlayerRankFunc(leaderboard.BuildInvalidateCachedTopOnDeleteFunc(invalidateRankingPagesFunc, leaderboardStorage.DeletePlayerRank)),
+			banStorage.CreateBan,
+			banStorage.SetBanHiddenRanks,
+		)
+		liftBanFunc = ban.BuildLiftFunc(
+			leaderboardStorage.GetLeaderboardByIDAndGameID,
+			leaderboardStorage.GetPlayerRank,
+			leaderboard.BuildInvalidateCachedTopOnUpsertFunc(invalidateRankingPagesFunc, leaderboardStorage.UpsertPlayerRankValue),
+			banStorage.CreateBan,
+			banStorage.DeleteBan,
+		)
+		getBanFunc = ban.BuildGetFunc(banStorage.GetBan)
+		listBansFunc = ban.BuildListFunc(banStorage.ListBans)
+
+		upsertPlayerRankValueFunc = ban.BuildRejectBannedFunc(banStorage.GetBan, liftBanFunc, upsertPlayerRankValueFunc)
+
+		banDeviceFunc = ban.BuildBanDeviceFunc(banStorage.CreateDeviceBan)
+		liftDeviceBanFunc = ban.BuildLiftDeviceFunc(banStorage.DeleteDeviceBan)
+		getDeviceBanFunc = ban.BuildGetDeviceFunc(banStorage.GetDeviceBan)
+		listDeviceBansFunc = ban.BuildListDevicesFunc(banStorage.ListDeviceBans)
+
+		upsertPlayerRankValueFunc = ban.BuildRejectBannedDeviceFunc(banStorage.GetDeviceBan, upsertPlayerRankValueFunc)
+	}
+
+	return banFuncs{
+		storage:            banStorage,
+		banPlayerFunc:      banPlayerFunc,
+		liftBanFunc:        liftBanFunc,
+		getBanFunc:         getBanFunc,
+		listBansFunc:       listBansFunc,
+		banDeviceFunc:      banDeviceFunc,
+		liftDeviceBanFunc:  liftDeviceBanFunc,
+		getDeviceBanFunc:   getDeviceBanFunc,
+		listDeviceBansFunc: listDeviceBansFunc,
+	}, upsertPlayerRankValueFunc
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/kafka"
+	asyncmemory "github.com/gabapcia/gameblitz/internal/infra/async/memory"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/infra/async/nats"
+	"github.com/gabapcia/gameblitz/internal/infra/async/rabbitmq"
+	"github.com/gabapcia/gameblitz/internal/infra/async/sqs"
+)
+
+// The broker every event is published on. The memory one only logs them, for the dev mode
+func newBroker(ctx context.Context, config Config, encoder message.Encoder) (broker, error) {
+	switch config.Broker {
+	case "rabbitmq":
+		if config.RabbitURI == "" {
+			return nil, fmt.Errorf("RABBITMQ_URI is required by the %q broker", config.Broker)
+		}
+
+		return rabbitmq.NewProducer(ctx, config.RabbitURI, encoder, config.startupRetry("rabbitmq"))
+	case "kafka":
+		if config.KafkaRestProxyURL == "" {
+			return nil, fmt.Errorf("KAFKA_REST_PROXY_URL is required by the %q broker", config.Broker)
+		}
+
+		return kafka.NewProducer(ctx, kafka.Options{
+			RestProxyURL: config.KafkaRestProxyURL,
+			Timeout:      time.Duration(config.KafkaTimeout) * time.Second,
+			Encoder:      encoder,
+			Retry:        config.startupRetry("kafka"),
+		})
+	case "nats":
+		if config.NatsURL == "" {
+			return nil, fmt.Errorf("NATS_URL is required by the %q broker", config.Broker)
+		}
+
+		return nats.NewProducer(ctx, nats.Options{
+			URL:                config.NatsURL,
+			StreamReplicas:     config.NatsStreamReplicas,
+			StreamMaxAge:       time.Duration(config.NatsStreamMaxAge) * time.Second,
+			Encoder:            encoder,
+			Retry:              config.startupRetry("nats"),
+			Consumers:          config.NatsConsumers,
+			ConsumerAckWait:    time.Duration(config.NatsConsumerAckWait) * time.Second,
+			ConsumerMaxDeliver: config.NatsConsumerMaxDeliver,
+		})
+	case "sqs":
+		return sqs.NewProducer(ctx, sqs.Options{
+			Region:   config.SQSRegion,
+			Endpoint: config.SQSEndpoint,
+			Encoder:  encoder,
+			Retry:    config.startupRetry("sns"),
+		})
+	case "memory":
+		bus := asyncmemory.NewBus(encoder)
+		bus.Subscribe(logDevEvent)
+		return bus, nil
+	default:
+		return nil, fmt.Errorf("unknown broker %q", config.Broker)
+	}
+}
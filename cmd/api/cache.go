@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/infra/cache/lru"
+	"github.com/gabapcia/gameblitz/internal/infra/cache/memcached"
+	rediscache "github.com/gabapcia/gameblitz/internal/infra/cache/redis"
+)
+
+// The response cache, shared by the instances unless it's kept in memory
+func newCache(config Config) (cacheStorage, error) {
+	switch config.CacheStorage {
+	case "memcached":
+		if config.MemcachedConnStr == "" {
+			return nil, fmt.Errorf("MEMCACHED_CONN_STR is required by the %q cache storage", config.CacheStorage)
+		}
+
+		return memcached.New(config.MemcachedConnStr), nil
+	case "redis":
+		return rediscache.New(config.CacheRedisURL)
+	case "memory":
+		return lru.New(config.CacheMemorySize)
+	default:
+		return nil, fmt.Errorf("unknown storage %q", config.CacheStorage)
+	}
+}
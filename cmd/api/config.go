@@ -0,0 +1,692 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/failover"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+)
+
+type Config struct {
+	Port int `envconfig:"PORT" required:"true"`
+
+	HTTP3Enabled  bool   `envconfig:"HTTP3_ENABLED" required:"false" default:"false"`
+	HTTP3Port     int    `envconfig:"HTTP3_PORT" required:"false" default:"8443"`
+	HTTP3CertFile string `envconfig:"HTTP3_CERT_FILE" required:"false"`
+	HTTP3KeyFile  string `envconfig:"HTTP3_KEY_FILE" required:"false"`
+
+	GRPCEnabled bool `envconfig:"GRPC_ENABLED" required:"false" default:"false"`
+	GRPCPort    int  `envconfig:"GRPC_PORT" required:"false" default:"9090"`
+
+	GRPCIngestBatchSize int `envconfig:"GRPC_INGEST_BATCH_SIZE" required:"false" default:"500"`
+
+	GraphQLEnabled bool `envconfig:"GRAPHQL_ENABLED" required:"false" default:"false"`
+
+	MQTTEnabled     bool   `envconfig:"MQTT_ENABLED" required:"false" default:"false"`
+	MQTTBrokerURL   string `envconfig:"MQTT_BROKER_URL" required:"false"`
+	MQTTClientID    string `envconfig:"MQTT_CLIENT_ID" required:"false"`
+	MQTTUsername    string `envconfig:"MQTT_USERNAME" required:"false"`
+	MQTTPassword    string `envconfig:"MQTT_PASSWORD" required:"false"`
+	MQTTTopicPrefix string `envconfig:"MQTT_TOPIC_PREFIX" required:"false" default:"gameblitz"`
+	MQTTSharedGroup string `envconfig:"MQTT_SHARED_GROUP" required:"false" default:"gameblitz"`
+	MQTTQoS         byte   `envconfig:"MQTT_QOS" required:"false" default:"1"`
+
+	KeycloackCertsURI string `envconfig:"KEYCLOACK_CERTS_URI" required:"true"`
+
+	PotgresDSN string `envconfig:"POSTGRESQL_DSN" required:"false"`
+
+	MongoURI                    string            `envconfig:"MONGO_URI" required:"false"`
+	MongoDB                     string            `envconfig:"MONGO_DB" required:"false"`
+	MongoIndexes                string            `envconfig:"MONGO_INDEXES" required:"false" default:"ensure"`
+	MongoAppName                string            `envconfig:"MONGO_APP_NAME" required:"false" default:"gameblitz"`
+	MongoUsername               string            `envconfig:"MONGO_USERNAME" required:"false"`
+	MongoPassword               string            `envconfig:"MONGO_PASSWORD" required:"false"`
+	MongoAuthSource             string            `envconfig:"MONGO_AUTH_SOURCE" required:"false"`
+	MongoTLS                    bool              `envconfig:"MONGO_TLS" required:"false" default:"false"`
+	MongoTLSCAFile              string            `envconfig:"MONGO_TLS_CA_FILE" required:"false"`
+	MongoTLSCertFile            string            `envconfig:"MONGO_TLS_CERT_FILE" required:"false"`
+	MongoTLSKeyFile             string            `envconfig:"MONGO_TLS_KEY_FILE" required:"false"`
+	MongoTLSInsecureSkipVerify  bool              `envconfig:"MONGO_TLS_INSECURE_SKIP_VERIFY" required:"false" default:"false"`
+	MongoConnectTimeout         int               `envconfig:"MONGO_CONNECT_TIMEOUT" required:"false" default:"0"`
+	MongoServerSelectionTimeout int               `envconfig:"MONGO_SERVER_SELECTION_TIMEOUT" required:"false" default:"0"`
+	MongoSocketTimeout          int               `envconfig:"MONGO_SOCKET_TIMEOUT" required:"false" default:"0"`
+	MongoMaxPoolSize            uint64            `envconfig:"MONGO_MAX_POOL_SIZE" required:"false" default:"100"`
+	MongoMinPoolSize            uint64            `envconfig:"MONGO_MIN_POOL_SIZE" required:"false" default:"10"`
+	MongoMaxConnIdleTime        int               `envconfig:"MONGO_MAX_CONN_IDLE_TIME" required:"false" default:"300"`
+	MongoMaxConnecting          uint64            `envconfig:"MONGO_MAX_CONNECTING" required:"false" default:"4"`
+	MongoReadPreferences        map[string]string `envconfig:"MONGO_READ_PREFERENCES" required:"false"`
+	MongoChangeStream           bool              `envconfig:"MONGO_CHANGE_STREAM" required:"false" default:"false"`
+	MongoFallbackURI            string            `envconfig:"MONGO_FALLBACK_URI" required:"false"`
+	MongoSlowQueryThreshold     int               `envconfig:"MONGO_SLOW_QUERY_THRESHOLD" required:"false" default:"100"`
+	MongoProgressionMigration   string            `envconfig:"MONGO_PROGRESSION_MIGRATION" required:"false" default:"off"`
+	MongoProgressionCollection  string            `envconfig:"MONGO_PROGRESSION_MIGRATION_COLLECTION" required:"false" default:"playersStatisticsV2"`
+
+	DynamoDBTable       string `envconfig:"DYNAMODB_TABLE" required:"false" default:"gameblitz"`
+	DynamoDBRegion      string `envconfig:"DYNAMODB_REGION" required:"false"`
+	DynamoDBEndpoint    string `envconfig:"DYNAMODB_ENDPOINT" required:"false"`
+	DynamoDBEnsureTable bool   `envconfig:"DYNAMODB_ENSURE_TABLE" required:"false" default:"false"`
+
+	RedisAddrs               []string `envconfig:"REDIS_ADDR" required:"false"`
+	RedisUsername            string   `envconfig:"REDIS_USERNAME" required:"false"`
+	RedisPassword            string   `envconfig:"REDIS_PASSWORD" required:"false"`
+	RedisDB                  int      `envconfig:"REDIS_DB" required:"false"`
+	RedisCluster             bool     `envconfig:"REDIS_CLUSTER" required:"false" default:"false"`
+	RedisClusterMaxRedirects int      `envconfig:"REDIS_CLUSTER_MAX_REDIRECTS" required:"false" default:"3"`
+	RedisPoolSize            int      `envconfig:"REDIS_POOL_SIZE" required:"false" default:"100"`
+	RedisMinIdleConns        int      `envconfig:"REDIS_MIN_IDLE_CONNS" required:"false" default:"10"`
+	RedisMaxIdleConns        int      `envconfig:"REDIS_MAX_IDLE_CONNS" required:"false" default:"50"`
+	RedisConnMaxIdleTime     int      `envconfig:"REDIS_CONN_MAX_IDLE_TIME" required:"false" default:"300"`
+	RedisConnMaxLifetime     int      `envconfig:"REDIS_CONN_MAX_LIFETIME" required:"false" default:"1800"`
+	RedisPoolTimeout         int      `envconfig:"REDIS_POOL_TIMEOUT" required:"false" default:"4"`
+	RedisFallbackAddrs       []string `envconfig:"REDIS_FALLBACK_ADDR" required:"false"`
+	RedisGameRoutes          []string `envconfig:"REDIS_GAME_ROUTES" required:"false"`
+	RedisSlowQueryThreshold  int      `envconfig:"REDIS_SLOW_QUERY_THRESHOLD" required:"false" default:"10"`
+	RedisPipelineWindow      int      `envconfig:"REDIS_PIPELINE_WINDOW" required:"false" default:"0"`
+	RedisPipelineSize        int      `envconfig:"REDIS_PIPELINE_SIZE" required:"false" default:"100"`
+
+	FailoverCheckInterval    int `envconfig:"FAILOVER_CHECK_INTERVAL" required:"false" default:"5"`
+	FailoverFailureThreshold int `envconfig:"FAILOVER_FAILURE_THRESHOLD" required:"false" default:"3"`
+
+	CacheStorage    string `envconfig:"CACHE_STORAGE" required:"false" default:"memcached"`
+	CacheRedisURL   string `envconfig:"CACHE_REDIS_URL" required:"false"`
+	CacheMemorySize int    `envconfig:"CACHE_MEMORY_SIZE" required:"false" default:"10000"`
+
+	MemcachedConnStr                   string `envconfig:"MEMCACHED_CONN_STR" required:"false"`
+	MemcachedCacheExpiration           int    `envconfig:"MEMCACHED_EXPIRATION" required:"false" default:"60"`
+	MemcachedCacheMiddlewareExpiration int    `envconfig:"MEMCACHED_MIDDLEWARE_EXPIRATION" required:"false" default:"60"`
+
+	Broker       string `envconfig:"BROKER" required:"false" default:"rabbitmq"`
+	EventsFormat string `envconfig:"EVENTS_FORMAT" required:"false" default:"plain"`
+	EventsSource string `envconfig:"EVENTS_SOURCE" required:"false" default:"/gameblitz"`
+
+	RabbitURI string `envconfig:"RABBITMQ_URI" required:"false"`
+
+	KafkaRestProxyURL string `envconfig:"KAFKA_REST_PROXY_URL" required:"false"`
+	KafkaTimeout      int    `envconfig:"KAFKA_TIMEOUT" required:"false" default:"10"`
+
+	NatsURL                string            `envconfig:"NATS_URL" required:"false"`
+	NatsStreamReplicas     int               `envconfig:"NATS_STREAM_REPLICAS" required:"false" default:"1"`
+	NatsStreamMaxAge       int               `envconfig:"NATS_STREAM_MAX_AGE" required:"false" default:"0"`
+	NatsConsumers          map[string]string `envconfig:"NATS_CONSUMERS" required:"false"`
+	NatsConsumerAckWait    int               `envconfig:"NATS_CONSUMER_ACK_WAIT" required:"false" default:"30"`
+	NatsConsumerMaxDeliver int               `envconfig:"NATS_CONSUMER_MAX_DELIVER" required:"false" default:"0"`
+
+	SQSRegion            string `envconfig:"SQS_REGION" required:"false"`
+	SQSEndpoint          string `envconfig:"SQS_ENDPOINT" required:"false"`
+	SQSIngestionQueue    string `envconfig:"SQS_INGESTION_QUEUE" required:"false" default:"gameblitz-ingestion"`
+	SQSBatchSize         int    `envconfig:"SQS_BATCH_SIZE" required:"false" default:"10"`
+	SQSWaitTime          int    `envconfig:"SQS_WAIT_TIME" required:"false" default:"20"`
+	SQSVisibilityTimeout int    `envconfig:"SQS_VISIBILITY_TIMEOUT" required:"false" default:"30"`
+
+	MetricsEnabled        bool `envconfig:"METRICS_ENABLED" required:"false" default:"true"`
+	MetricsHealthInterval int  `envconfig:"METRICS_HEALTH_INTERVAL" required:"false" default:"15"`
+	MetricsHealthTimeout  int  `envconfig:"METRICS_HEALTH_TIMEOUT" required:"false" default:"5"`
+
+	SLOWindow  int      `envconfig:"SLO_WINDOW" required:"false" default:"600"`
+	SLOTargets []string `envconfig:"SLO_TARGETS" required:"false" default:"/api/v1/leaderboards/:leaderboardId/ranking/=50ms"`
+
+	TracingEnabled     bool    `envconfig:"TRACING_ENABLED" required:"false" default:"false"`
+	TracingEndpoint    string  `envconfig:"TRACING_ENDPOINT" required:"false"`
+	TracingInsecure    bool    `envconfig:"TRACING_INSECURE" required:"false" default:"false"`
+	TracingServiceName string  `envconfig:"TRACING_SERVICE_NAME" required:"false" default:"gameblitz"`
+	TracingSampleRatio float64 `envconfig:"TRACING_SAMPLE_RATIO" required:"false" default:"1"`
+
+	SentryDSN         string  `envconfig:"SENTRY_DSN" required:"false"`
+	SentryEnvironment string  `envconfig:"SENTRY_ENVIRONMENT" required:"false" default:"production"`
+	SentryRelease     string  `envconfig:"SENTRY_RELEASE" required:"false"`
+	SentrySampleRate  float64 `envconfig:"SENTRY_SAMPLE_RATE" required:"false" default:"1"`
+
+	LogLevel         string `envconfig:"LOG_LEVEL" required:"false" default:"info"`
+	LogDebugDuration int    `envconfig:"LOG_DEBUG_DURATION" required:"false" default:"900"`
+
+	AccessLogEnabled     bool     `envconfig:"ACCESS_LOG_ENABLED" required:"false" default:"true"`
+	AccessLogSampleRates []string `envconfig:"ACCESS_LOG_SAMPLE_RATES" required:"false"`
+
+	FingerprintRegionHeader string `envconfig:"FINGERPRINT_REGION_HEADER" required:"false"`
+
+	IngestionEnabled          bool           `envconfig:"INGESTION_ENABLED" required:"false" default:"false"`
+	IngestionBroker           string         `envconfig:"INGESTION_BROKER" required:"false" default:"rabbitmq"`
+	IngestionMaxAttempts      int            `envconfig:"INGESTION_MAX_ATTEMPTS" required:"false" default:"5"`
+	IngestionRetryDelay       int            `envconfig:"INGESTION_RETRY_DELAY" required:"false" default:"1"`
+	IngestionRetryMaxDelay    int            `envconfig:"INGESTION_RETRY_MAX_DELAY" required:"false" default:"300"`
+	IngestionBatchSize        int            `envconfig:"INGESTION_BATCH_SIZE" required:"false" default:"1"`
+	IngestionBatchWait        int            `envconfig:"INGESTION_BATCH_WAIT" required:"false" default:"100"`
+	IngestionWorkers          int            `envconfig:"INGESTION_WORKERS" required:"false" default:"1"`
+	IngestionKindWorkers      map[string]int `envconfig:"INGESTION_KIND_WORKERS" required:"false"`
+	IngestionPrefetch         int            `envconfig:"INGESTION_PREFETCH" required:"false" default:"0"`
+	DeadLetterStorage         string         `envconfig:"DEAD_LETTER_STORAGE" required:"false" default:"mongo"`
+	IngestionDedupStorage     string         `envconfig:"INGESTION_DEDUP_STORAGE" required:"false" default:"redis"`
+	IngestionDedupTTL         int            `envconfig:"INGESTION_DEDUP_TTL" required:"false" default:"86400"`
+	IngestionBacklogInterval  int            `envconfig:"INGESTION_BACKLOG_INTERVAL" required:"false" default:"30"`
+	IngestionBacklogThreshold int64          `envconfig:"INGESTION_BACKLOG_THRESHOLD" required:"false" default:"10000"`
+	IngestionBacklogAlertURL  string         `envconfig:"INGESTION_BACKLOG_ALERT_URL" required:"false"`
+
+	AdminToken   string `envconfig:"ADMIN_TOKEN" required:"false"`
+	PprofEnabled bool   `envconfig:"PPROF_ENABLED" required:"false" default:"false"`
+
+	OutboxEnabled       bool `envconfig:"OUTBOX_ENABLED" required:"false" default:"false"`
+	OutboxRelayInterval int  `envconfig:"OUTBOX_RELAY_INTERVAL" required:"false" default:"1"`
+	OutboxBatchSize     int  `envconfig:"OUTBOX_BATCH_SIZE" required:"false" default:"100"`
+	OutboxArchive       bool `envconfig:"OUTBOX_ARCHIVE" required:"false" default:"false"`
+
+	WebhooksEnabled         bool   `envconfig:"WEBHOOKS_ENABLED" required:"false" default:"false"`
+	WebhookStorage          string `envconfig:"WEBHOOK_STORAGE" required:"false" default:"mongo"`
+	WebhookTimeout          int    `envconfig:"WEBHOOK_TIMEOUT" required:"false" default:"10"`
+	WebhookMaxAttempts      int    `envconfig:"WEBHOOK_MAX_ATTEMPTS" required:"false" default:"8"`
+	WebhookRetryDelay       int    `envconfig:"WEBHOOK_RETRY_DELAY" required:"false" default:"10"`
+	WebhookRetryMaxDelay    int    `envconfig:"WEBHOOK_RETRY_MAX_DELAY" required:"false" default:"3600"`
+	WebhookDisableAfter     int    `envconfig:"WEBHOOK_DISABLE_AFTER" required:"false" default:"20"`
+	WebhookDeliveryInterval int    `envconfig:"WEBHOOK_DELIVERY_INTERVAL" required:"false" default:"1"`
+	WebhookBatchSize        int    `envconfig:"WEBHOOK_BATCH_SIZE" required:"false" default:"100"`
+
+	UsageEnabled       bool   `envconfig:"USAGE_ENABLED" required:"false" default:"false"`
+	UsageStorage       string `envconfig:"USAGE_STORAGE" required:"false" default:"mongo"`
+	UsageFlushInterval int    `envconfig:"USAGE_FLUSH_INTERVAL" required:"false" default:"60"`
+
+	QuotaEnabled                  bool   `envconfig:"QUOTA_ENABLED" required:"false" default:"false"`
+	QuotaStorage                  string `envconfig:"QUOTA_STORAGE" required:"false" default:"mongo"`
+	QuotaCacheTTL                 int    `envconfig:"QUOTA_CACHE_TTL" required:"false" default:"30"`
+	QuotaDefaultRequestsPerMinute int64  `envconfig:"QUOTA_DEFAULT_REQUESTS_PER_MINUTE" required:"false" default:"0"`
+	QuotaDefaultMaxLeaderboards   int64  `envconfig:"QUOTA_DEFAULT_MAX_LEADERBOARDS" required:"false" default:"0"`
+	QuotaDefaultMaxStatistics     int64  `envconfig:"QUOTA_DEFAULT_MAX_STATISTICS" required:"false" default:"0"`
+
+	ProfilesEnabled bool   `envconfig:"PROFILES_ENABLED" required:"false" default:"false"`
+	ProfileStorage  string `envconfig:"PROFILE_STORAGE" required:"false" default:"mongo"`
+
+	AccountLinksEnabled bool   `envconfig:"ACCOUNT_LINKS_ENABLED" required:"false" default:"false"`
+	AccountLinkStorage  string `envconfig:"ACCOUNT_LINK_STORAGE" required:"false" default:"mongo"`
+
+	BansEnabled        bool   `envconfig:"BANS_ENABLED" required:"false" default:"false"`
+	BanStorage         string `envconfig:"BAN_STORAGE" required:"false" default:"mongo"`
+	BanExpiryInterval  int    `envconfig:"BAN_EXPIRY_INTERVAL" required:"false" default:"60"`
+	BanExpiryBatchSize int    `envconfig:"BAN_EXPIRY_BATCH_SIZE" required:"false" default:"100"`
+
+	ShadowFlagsEnabled bool   `envconfig:"SHADOW_FLAGS_ENABLED" required:"false" default:"false"`
+	ShadowFlagStorage  string `envconfig:"SHADOW_FLAG_STORAGE" required:"false" default:"mongo"`
+
+	AchievementsEnabled bool   `envconfig:"ACHIEVEMENTS_ENABLED" required:"false" default:"false"`
+	AchievementStorage  string `envconfig:"ACHIEVEMENT_STORAGE" required:"false" default:"mongo"`
+
+	TitlesEnabled bool   `envconfig:"TITLES_ENABLED" required:"false" default:"false"`
+	TitleStorage  string `envconfig:"TITLE_STORAGE" required:"false" default:"mongo"`
+
+	FriendsEnabled bool   `envconfig:"FRIENDS_ENABLED" required:"false" default:"false"`
+	FriendStorage  string `envconfig:"FRIEND_STORAGE" required:"false" default:"mongo"`
+
+	PresenceEnabled   bool   `envconfig:"PRESENCE_ENABLED" required:"false" default:"false"`
+	PresenceStorage   string `envconfig:"PRESENCE_STORAGE" required:"false" default:"redis"`
+	PresenceTimeout   int    `envconfig:"PRESENCE_TIMEOUT" required:"false" default:"60"`
+	PresenceRetention int    `envconfig:"PRESENCE_RETENTION" required:"false" default:"2592000"`
+
+	SignedSubmissionsEnabled bool   `envconfig:"SIGNED_SUBMISSIONS_ENABLED" required:"false" default:"false"`
+	SubmissionSigningKey     string `envconfig:"SUBMISSION_SIGNING_KEY" required:"false"`
+	SubmissionNonceTTL       int    `envconfig:"SUBMISSION_NONCE_TTL" required:"false" default:"300"`
+	NonceStorage             string `envconfig:"NONCE_STORAGE" required:"false" default:"redis"`
+
+	SubmissionRateLimitEnabled bool   `envconfig:"SUBMISSION_RATE_LIMIT_ENABLED" required:"false" default:"false"`
+	SubmissionRateLimit        int64  `envconfig:"SUBMISSION_RATE_LIMIT" required:"false" default:"60"`
+	SubmissionRateLimitStorage string `envconfig:"SUBMISSION_RATE_LIMIT_STORAGE" required:"false" default:"redis"`
+
+	DeltaRulesEnabled bool   `envconfig:"DELTA_RULES_ENABLED" required:"false" default:"false"`
+	DeltaRuleStorage  string `envconfig:"DELTA_RULE_STORAGE" required:"false" default:"mongo"`
+	QuarantineStorage string `envconfig:"QUARANTINE_STORAGE" required:"false" default:"mongo"`
+
+	TrustEnabled        bool    `envconfig:"TRUST_ENABLED" required:"false" default:"false"`
+	TrustStorage        string  `envconfig:"TRUST_STORAGE" required:"false" default:"mongo"`
+	TrustLowThreshold   float64 `envconfig:"TRUST_LOW_THRESHOLD" required:"false" default:"30"`
+	TrustHighThreshold  float64 `envconfig:"TRUST_HIGH_THRESHOLD" required:"false" default:"80"`
+	TrustLowDeltaFactor float64 `envconfig:"TRUST_LOW_DELTA_FACTOR" required:"false" default:"0.5"`
+
+	ProofRulesEnabled bool   `envconfig:"PROOF_RULES_ENABLED" required:"false" default:"false"`
+	ProofRuleStorage  string `envconfig:"PROOF_RULE_STORAGE" required:"false" default:"mongo"`
+
+	AnomalyDetectionEnabled   bool    `envconfig:"ANOMALY_DETECTION_ENABLED" required:"false" default:"false"`
+	AnomalyDetectionInterval  int     `envconfig:"ANOMALY_DETECTION_INTERVAL" required:"false" default:"3600"`
+	AnomalyDetectionMethod    string  `envconfig:"ANOMALY_DETECTION_METHOD" required:"false" default:"Z_SCORE"`
+	AnomalyDetectionThreshold float64 `envconfig:"ANOMALY_DETECTION_THRESHOLD" required:"false" default:"3"`
+	AnomalyDetectionMinRanks  int     `envconfig:"ANOMALY_DETECTION_MIN_RANKS" required:"false" default:"30"`
+	AnomalyDetectionMaxRanks  int     `envconfig:"ANOMALY_DETECTION_MAX_RANKS" required:"false" default:"10000"`
+	AnomalyStorage            string  `envconfig:"ANOMALY_STORAGE" required:"false" default:"mongo"`
+
+	ModerationEnabled bool   `envconfig:"MODERATION_ENABLED" required:"false" default:"false"`
+	ModerationStorage string `envconfig:"MODERATION_STORAGE" required:"false" default:"mongo"`
+
+	SegmentsEnabled bool   `envconfig:"SEGMENTS_ENABLED" required:"false" default:"false"`
+	SegmentStorage  string `envconfig:"SEGMENT_STORAGE" required:"false" default:"mongo"`
+
+	LevelsEnabled bool   `envconfig:"LEVELS_ENABLED" required:"false" default:"false"`
+	LevelStorage  string `envconfig:"LEVEL_STORAGE" required:"false" default:"mongo"`
+
+	ActivityEnabled bool   `envconfig:"ACTIVITY_ENABLED" required:"false" default:"false"`
+	ActivityStorage string `envconfig:"ACTIVITY_STORAGE" required:"false" default:"mongo"`
+
+	RewardsEnabled       bool   `envconfig:"REWARDS_ENABLED" required:"false" default:"false"`
+	RewardStorage        string `envconfig:"REWARD_STORAGE" required:"false" default:"mongo"`
+	RewardGranter        string `envconfig:"REWARD_GRANTER" required:"false" default:"broker"`
+	RewardGranterURL     string `envconfig:"REWARD_GRANTER_URL" required:"false"`
+	RewardGranterSecret  string `envconfig:"REWARD_GRANTER_SECRET" required:"false"`
+	RewardGranterTimeout int    `envconfig:"REWARD_GRANTER_TIMEOUT" required:"false" default:"10"`
+	RewardMaxAttempts    int    `envconfig:"REWARD_MAX_ATTEMPTS" required:"false" default:"8"`
+	RewardRetryDelay     int    `envconfig:"REWARD_RETRY_DELAY" required:"false" default:"10"`
+	RewardRetryMaxDelay  int    `envconfig:"REWARD_RETRY_MAX_DELAY" required:"false" default:"3600"`
+	RewardGrantInterval  int    `envconfig:"REWARD_GRANT_INTERVAL" required:"false" default:"1"`
+	RewardBatchSize      int    `envconfig:"REWARD_BATCH_SIZE" required:"false" default:"100"`
+
+	EncryptionKey       string `envconfig:"ENCRYPTION_KEY" required:"false"`
+	EncryptionKeyKMS    bool   `envconfig:"ENCRYPTION_KEY_KMS" required:"false" default:"false"`
+	EncryptionKMSRegion string `envconfig:"ENCRYPTION_KMS_REGION" required:"false"`
+
+	StartupRetryWindow int `envconfig:"STARTUP_RETRY_WINDOW" required:"false" default:"60"`
+
+	LeaderboardStorage string `envconfig:"LEADERBOARD_STORAGE" required:"false" default:"redis"`
+	StatisticStorage   string `envconfig:"STATISTIC_STORAGE" required:"false" default:"mongo"`
+	QuestStorage       string `envconfig:"QUEST_STORAGE" required:"false" default:"postgres"`
+	AuditStorage       string `envconfig:"AUDIT_STORAGE" required:"false" default:"mongo"`
+
+	StatisticWriteBehindEnabled       bool   `envconfig:"STATISTIC_WRITE_BEHIND_ENABLED" required:"false" default:"false"`
+	StatisticWriteBehindPath          string `envconfig:"STATISTIC_WRITE_BEHIND_PATH" required:"false" default:"data/statistic-write-behind"`
+	StatisticWriteBehindFlushInterval int    `envconfig:"STATISTIC_WRITE_BEHIND_FLUSH_INTERVAL" required:"false" default:"1000"`
+	StatisticWriteBehindBatchSize     int    `envconfig:"STATISTIC_WRITE_BEHIND_BATCH_SIZE" required:"false" default:"500"`
+	StatisticWriteBehindMaxPending    int64  `envconfig:"STATISTIC_WRITE_BEHIND_MAX_PENDING" required:"false" default:"100000"`
+
+	RankingCacheEnabled bool   `envconfig:"RANKING_CACHE_ENABLED" required:"false" default:"false"`
+	RankingCacheStorage string `envconfig:"RANKING_CACHE_STORAGE" required:"false" default:"redis"`
+	RankingCacheDepth   int64  `envconfig:"RANKING_CACHE_DEPTH" required:"false" default:"100"`
+	RankingCacheTTL     int    `envconfig:"RANKING_CACHE_TTL" required:"false" default:"5"`
+
+	TopViewEnabled bool   `envconfig:"TOP_VIEW_ENABLED" required:"false" default:"false"`
+	TopViewStorage string `envconfig:"TOP_VIEW_STORAGE" required:"false" default:"redis"`
+	TopViewSize    int64  `envconfig:"TOP_VIEW_SIZE" required:"false" default:"100"`
+	TopViewRefresh int    `envconfig:"TOP_VIEW_REFRESH" required:"false" default:"60"`
+
+	ScoreHistoryEnabled bool   `envconfig:"SCORE_HISTORY_ENABLED" required:"false" default:"false"`
+	ScoreHistoryStorage string `envconfig:"SCORE_HISTORY_STORAGE" required:"false" default:"mongo"`
+
+	LeaderboardScheduleEventsEnabled  bool `envconfig:"LEADERBOARD_SCHEDULE_EVENTS_ENABLED" required:"false" default:"false"`
+	LeaderboardScheduleEventsInterval int  `envconfig:"LEADERBOARD_SCHEDULE_EVENTS_INTERVAL" required:"false" default:"10"`
+
+	QuestExpiryEventsEnabled  bool `envconfig:"QUEST_EXPIRY_EVENTS_ENABLED" required:"false" default:"false"`
+	QuestExpiryEventsInterval int  `envconfig:"QUEST_EXPIRY_EVENTS_INTERVAL" required:"false" default:"60"`
+
+	LiveRankingEnabled  bool `envconfig:"LIVE_RANKING_ENABLED" required:"false" default:"false"`
+	LiveRankingInterval int  `envconfig:"LIVE_RANKING_INTERVAL" required:"false" default:"500"`
+
+	LiveNotificationsEnabled bool `envconfig:"LIVE_NOTIFICATIONS_ENABLED" required:"false" default:"false"`
+
+	RealtimeBackplane string `envconfig:"REALTIME_BACKPLANE" required:"false" default:"memory"`
+	RealtimeRedisURL  string `envconfig:"REALTIME_REDIS_URL" required:"false"`
+
+	SoftDeleteRetention int `envconfig:"SOFT_DELETE_RETENTION" required:"false" default:"0"`
+	PurgeInterval       int `envconfig:"PURGE_INTERVAL" required:"false" default:"3600"`
+
+	SchedulerLockStorage string `envconfig:"SCHEDULER_LOCK_STORAGE" required:"false" default:"memory"`
+}
+
+// Checks if any domain is configured to use the given storage
+func (c Config) usesStorage(storage string) bool {
+	storages := []string{c.LeaderboardStorage, c.StatisticStorage, c.QuestStorage, c.AuditStorage, c.SchedulerLockStorage}
+	if c.IngestionEnabled {
+		storages = append(storages, c.DeadLetterStorage)
+	}
+
+	if c.IngestionEnabled && c.IngestionDedupTTL > 0 {
+		storages = append(storages, c.IngestionDedupStorage)
+	}
+
+	if c.WebhooksEnabled {
+		storages = append(storages, c.WebhookStorage)
+	}
+
+	if c.UsageEnabled {
+		storages = append(storages, c.UsageStorage)
+	}
+
+	if c.QuotaEnabled {
+		storages = append(storages, c.QuotaStorage)
+	}
+
+	if c.ProfilesEnabled {
+		storages = append(storages, c.ProfileStorage)
+	}
+
+	if c.AccountLinksEnabled {
+		storages = append(storages, c.AccountLinkStorage)
+	}
+
+	if c.BansEnabled {
+		storages = append(storages, c.BanStorage)
+	}
+
+	if c.ShadowFlagsEnabled {
+		storages = append(storages, c.ShadowFlagStorage)
+	}
+
+	if c.AchievementsEnabled {
+		storages = append(storages, c.AchievementStorage)
+	}
+
+	if c.TitlesEnabled {
+		storages = append(storages, c.TitleStorage)
+	}
+
+	if c.FriendsEnabled {
+		storages = append(storages, c.FriendStorage)
+	}
+
+	if c.RankingCacheEnabled {
+		storages = append(storages, c.RankingCacheStorage)
+	}
+
+	if c.TopViewEnabled {
+		storages = append(storages, c.TopViewStorage)
+	}
+
+	if c.PresenceEnabled {
+		storages = append(storages, c.PresenceStorage)
+	}
+
+	if c.SignedSubmissionsEnabled {
+		storages = append(storages, c.NonceStorage)
+	}
+
+	if c.SubmissionRateLimitEnabled {
+		storages = append(storages, c.SubmissionRateLimitStorage)
+	}
+
+	if c.DeltaRulesEnabled {
+		storages = append(storages, c.DeltaRuleStorage, c.QuarantineStorage)
+	}
+
+	if c.TrustEnabled {
+		storages = append(storages, c.TrustStorage)
+	}
+
+	if c.ProofRulesEnabled {
+		storages = append(storages, c.ProofRuleStorage, c.QuarantineStorage)
+	}
+
+	if c.AnomalyDetectionEnabled {
+		storages = append(storages, c.AnomalyStorage)
+	}
+
+	if c.ModerationEnabled {
+		storages = append(storages, c.ModerationStorage)
+	}
+
+	if c.SegmentsEnabled {
+		storages = append(storages, c.SegmentStorage)
+	}
+
+	if c.LevelsEnabled {
+		storages = append(storages, c.LevelStorage)
+	}
+
+	if c.ActivityEnabled {
+		storages = append(storages, c.ActivityStorage)
+	}
+
+	if c.RewardsEnabled {
+		storages = append(storages, c.RewardStorage)
+	}
+
+	if c.ScoreHistoryEnabled {
+		storages = append(storages, c.ScoreHistoryStorage)
+	}
+
+	return slices.Contains(storages, storage)
+}
+
+// Checks the settings against each other, e.g. the connection settings required by the storages in use,
+// reporting every problem found instead of failing on the first dependency that can't start
+func (c Config) Validate() error {
+	errList := make([]error, 0)
+
+	oneOf := func(key, value string, allowed ...string) {
+		if !slices.Contains(allowed, value) {
+			errList = append(errList, fmt.Errorf("%s: unknown value %q, expected one of %s", key, value, strings.Join(allowed, ", ")))
+		}
+	}
+
+	requiredBy := func(key, value, dependent string) {
+		if value == "" {
+			errList = append(errList, fmt.Errorf("%s: required by %s", key, dependent))
+		}
+	}
+
+	oneOf("LEADERBOARD_STORAGE", c.LeaderboardStorage, "redis", "postgres", "memory")
+	oneOf("STATISTIC_STORAGE", c.StatisticStorage, "mongo", "dynamodb", "postgres", "memory")
+	oneOf("QUEST_STORAGE", c.QuestStorage, "postgres", "memory")
+	oneOf("AUDIT_STORAGE", c.AuditStorage, "mongo", "dynamodb", "memory")
+	oneOf("CACHE_STORAGE", c.CacheStorage, "memcached", "redis", "memory")
+	oneOf("BROKER", c.Broker, "rabbitmq", "kafka", "nats", "sqs", "memory")
+	oneOf("MONGO_INDEXES", c.MongoIndexes, "ensure", "verify", "skip")
+	oneOf("MONGO_PROGRESSION_MIGRATION", c.MongoProgressionMigration, "off", "dual-write", "dual-read", "done")
+	oneOf("LOG_LEVEL", c.LogLevel, "debug", "info", "warn", "error")
+	oneOf("REALTIME_BACKPLANE", c.RealtimeBackplane, "memory", "redis")
+	oneOf("SCHEDULER_LOCK_STORAGE", c.SchedulerLockStorage, "redis", "mongo", "memory")
+
+	if c.IngestionEnabled {
+		oneOf("INGESTION_BROKER", c.IngestionBroker, "rabbitmq", "sqs")
+		oneOf("DEAD_LETTER_STORAGE", c.DeadLetterStorage, "mongo", "memory")
+		if c.IngestionDedupTTL > 0 {
+			oneOf("INGESTION_DEDUP_STORAGE", c.IngestionDedupStorage, "redis", "memory")
+		}
+		if c.IngestionBroker == "rabbitmq" {
+			requiredBy("RABBITMQ_URI", c.RabbitURI, "the rabbitmq INGESTION_BROKER")
+		}
+	}
+
+	if c.WebhooksEnabled {
+		oneOf("WEBHOOK_STORAGE", c.WebhookStorage, "mongo", "memory")
+	}
+
+	if c.UsageEnabled {
+		oneOf("USAGE_STORAGE", c.UsageStorage, "mongo", "memory")
+	}
+
+	if c.QuotaEnabled {
+		oneOf("QUOTA_STORAGE", c.QuotaStorage, "mongo", "memory")
+	}
+
+	if c.ProfilesEnabled {
+		oneOf("PROFILE_STORAGE", c.ProfileStorage, "mongo", "memory")
+	}
+
+	if c.AccountLinksEnabled {
+		oneOf("ACCOUNT_LINK_STORAGE", c.AccountLinkStorage, "mongo", "memory")
+	}
+
+	if c.BansEnabled {
+		oneOf("BAN_STORAGE", c.BanStorage, "mongo", "memory")
+	}
+
+	if c.ShadowFlagsEnabled {
+		oneOf("SHADOW_FLAG_STORAGE", c.ShadowFlagStorage, "mongo", "memory")
+	}
+
+	if c.AchievementsEnabled {
+		oneOf("ACHIEVEMENT_STORAGE", c.AchievementStorage, "mongo", "memory")
+	}
+
+	if c.TitlesEnabled {
+		oneOf("TITLE_STORAGE", c.TitleStorage, "mongo", "memory")
+	}
+
+	if c.FriendsEnabled {
+		oneOf("FRIEND_STORAGE", c.FriendStorage, "mongo", "memory")
+	}
+
+	if c.RankingCacheEnabled {
+		oneOf("RANKING_CACHE_STORAGE", c.RankingCacheStorage, "redis", "memory")
+	}
+
+	if c.TopViewEnabled {
+		oneOf("TOP_VIEW_STORAGE", c.TopViewStorage, "redis", "memory")
+	}
+
+	if c.PresenceEnabled {
+		oneOf("PRESENCE_STORAGE", c.PresenceStorage, "redis", "memory")
+	}
+
+	if c.SignedSubmissionsEnabled {
+		oneOf("NONCE_STORAGE", c.NonceStorage, "redis", "memory")
+		requiredBy("SUBMISSION_SIGNING_KEY", c.SubmissionSigningKey, "SIGNED_SUBMISSIONS_ENABLED")
+	}
+
+	if c.StatisticWriteBehindEnabled {
+		if c.StatisticStorage != "mongo" && c.StatisticStorage != "memory" {
+			errList = append(errList, errors.New("STATISTIC_WRITE_BEHIND_ENABLED: requires the mongo or memory STATISTIC_STORAGE"))
+		}
+
+		if c.StatisticWriteBehindFlushInterval <= 0 {
+			errList = append(errList, errors.New("STATISTIC_WRITE_BEHIND_FLUSH_INTERVAL: must be greater than zero"))
+		}
+	}
+
+	if c.SubmissionRateLimitEnabled {
+		oneOf("SUBMISSION_RATE_LIMIT_STORAGE", c.SubmissionRateLimitStorage, "redis", "memory")
+	}
+
+	if c.DeltaRulesEnabled {
+		oneOf("DELTA_RULE_STORAGE", c.DeltaRuleStorage, "mongo", "memory")
+	}
+
+	if c.ProofRulesEnabled {
+		oneOf("PROOF_RULE_STORAGE", c.ProofRuleStorage, "mongo", "memory")
+	}
+
+	if c.DeltaRulesEnabled || c.ProofRulesEnabled {
+		oneOf("QUARANTINE_STORAGE", c.QuarantineStorage, "mongo", "memory")
+	}
+
+	if c.TrustEnabled {
+		oneOf("TRUST_STORAGE", c.TrustStorage, "mongo", "memory")
+		if !c.DeltaRulesEnabled {
+			errList = append(errList, errors.New("TRUST_ENABLED: requires DELTA_RULES_ENABLED"))
+		}
+	}
+
+	if c.AnomalyDetectionEnabled {
+		oneOf("ANOMALY_STORAGE", c.AnomalyStorage, "mongo", "memory")
+		oneOf("ANOMALY_DETECTION_METHOD", c.AnomalyDetectionMethod, anomaly.Methods...)
+	}
+
+	if c.ModerationEnabled {
+		oneOf("MODERATION_STORAGE", c.ModerationStorage, "mongo", "memory")
+	}
+
+	if c.SegmentsEnabled {
+		oneOf("SEGMENT_STORAGE", c.SegmentStorage, "mongo", "memory")
+	}
+
+	if c.LevelsEnabled {
+		oneOf("LEVEL_STORAGE", c.LevelStorage, "mongo", "memory")
+	}
+
+	if c.ActivityEnabled {
+		oneOf("ACTIVITY_STORAGE", c.ActivityStorage, "mongo", "memory")
+	}
+
+	if c.RewardsEnabled {
+		oneOf("REWARD_STORAGE", c.RewardStorage, "mongo", "memory")
+		oneOf("REWARD_GRANTER", c.RewardGranter, "broker", "http")
+		if c.RewardGranter == "http" {
+			requiredBy("REWARD_GRANTER_URL", c.RewardGranterURL, "the http REWARD_GRANTER")
+		}
+	}
+
+	if c.ScoreHistoryEnabled {
+		oneOf("SCORE_HISTORY_STORAGE", c.ScoreHistoryStorage, "mongo", "postgres", "memory")
+	}
+
+	switch c.Broker {
+	case "rabbitmq":
+		requiredBy("RABBITMQ_URI", c.RabbitURI, "the rabbitmq BROKER")
+	case "kafka":
+		requiredBy("KAFKA_REST_PROXY_URL", c.KafkaRestProxyURL, "the kafka BROKER")
+	case "nats":
+		requiredBy("NATS_URL", c.NatsURL, "the nats BROKER")
+	}
+
+	switch c.CacheStorage {
+	case "memcached":
+		requiredBy("MEMCACHED_CONN_STR", c.MemcachedConnStr, "the memcached CACHE_STORAGE")
+	case "redis":
+		requiredBy("CACHE_REDIS_URL", c.CacheRedisURL, "the redis CACHE_STORAGE")
+	}
+
+	if c.RealtimeBackplane == "redis" {
+		requiredBy("REALTIME_REDIS_URL", c.RealtimeRedisURL, "the redis REALTIME_BACKPLANE")
+	}
+
+	if c.usesStorage("postgres") {
+		requiredBy("POSTGRESQL_DSN", c.PotgresDSN, "the postgres storage")
+	}
+
+	if c.usesStorage("mongo") {
+		requiredBy("MONGO_URI", c.MongoURI, "the mongo storage")
+		requiredBy("MONGO_DB", c.MongoDB, "the mongo storage")
+	}
+
+	if c.usesStorage("redis") && len(c.RedisAddrs) == 0 {
+		errList = append(errList, errors.New("REDIS_ADDR: required by the redis storage"))
+	}
+
+	if c.HTTP3Enabled {
+		requiredBy("HTTP3_CERT_FILE", c.HTTP3CertFile, "HTTP3_ENABLED")
+		requiredBy("HTTP3_KEY_FILE", c.HTTP3KeyFile, "HTTP3_ENABLED")
+	}
+
+	if c.MQTTEnabled {
+		requiredBy("MQTT_BROKER_URL", c.MQTTBrokerURL, "MQTT_ENABLED")
+		if c.MQTTQoS > 2 {
+			errList = append(errList, fmt.Errorf("MQTT_QOS: must be 0, 1 or 2, got %d", c.MQTTQoS))
+		}
+	}
+
+	if c.TracingEnabled && (c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1) {
+		errList = append(errList, fmt.Errorf("TRACING_SAMPLE_RATIO: must be between 0 and 1, got %v", c.TracingSampleRatio))
+	}
+
+	if c.SentryDSN != "" && (c.SentrySampleRate < 0 || c.SentrySampleRate > 1) {
+		errList = append(errList, fmt.Errorf("SENTRY_SAMPLE_RATE: must be between 0 and 1, got %v", c.SentrySampleRate))
+	}
+
+	return errors.Join(errList...)
+}
+
+// Retries a dependency on startup until it's ready or the retry window elapses
+func (c Config) startupRetry(dependency string) backoff.Config {
+	return backoff.Config{
+		MaxElapsedTime: time.Duration(c.StartupRetryWindow) * time.Second,
+		Notify: func(err error, wait time.Duration) {
+			zap.Error(err, "dependency not ready, retrying", "dependency", dependency, "wait", wait.String())
+		},
+	}
+}
+
+func (c Config) failoverConfig(dependency string) failover.Config {
+	return failover.Config{
+		Interval:         time.Duration(c.FailoverCheckInterval) * time.Second,
+		FailureThreshold: c.FailoverFailureThreshold,
+		OnChange: func(from, to failover.State) {
+			zap.Info("dependency availability changed", "dependency", dependency, "from", from.String(), "to", to.String())
+		},
+		OnCheckError: func(endpoint string, err error) {
+			zap.Error(err, "dependency health check failed", "dependency", dependency, "endpoint", endpoint)
+		},
+	}
+}
+
+// Logs the storage operations slower than the threshold, to catch the missing indexes and the hot keys
+func logSlowQuery(storage string) func(operation, collection string, duration time.Duration) {
+	return func(operation, collection string, duration time.Duration) {
+		zap.Info("slow storage operation", "storage", storage, "operation", operation, "collection", collection, "durationMs", duration.Milliseconds())
+	}
+}
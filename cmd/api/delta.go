@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/delta"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+	"github.com/gabapcia/gameblitz/internal/trust"
+)
+
+type deltaRuleFuncs struct {
+	setDeltaRuleFunc    delta.SetRuleFunc
+	getDeltaRuleFunc    delta.GetRuleFunc
+	removeDeltaRuleFunc delta.RemoveRuleFunc
+}
+
+// The submissions improving the player's value past the delta rule of the leaderboard are rejected or held for review,
+// after the bans and segments reject theirs. The released ones skip the rule, but are still recorded on the history
+func newDeltaRules(config Config, drivers storageDrivers, leaderboardStorage leaderboardStorage, trusts trustFuncs, holdSubmissionFunc quarantine.HoldFunc, upsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc) (deltaRuleFuncs, leaderboard.StorageUpsertPlayerRankValueFunc, leaderboard.StorageUpsertPlayerRankValueFunc) {
+	var (
+		err                              error
+		setDeltaRuleFunc                 delta.SetRuleFunc
+		getDeltaRuleFunc                 delta.GetRuleFunc
+		removeDeltaRuleFunc              delta.RemoveRuleFunc
+		releaseUpsertPlayerRankValueFunc = upsertPlayerRankValueFunc
+	)
+	if config.DeltaRulesEnabled {
+		deltaRuleStorage, ok := drivers.deltaRuleStorages[config.DeltaRuleStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.DeltaRuleStorage), "invalid delta rule storage")
+		}
+
+		setDeltaRuleFunc = delta.BuildSetRuleFunc(leaderboard.BuildGetByIDAndGameIDFunc(leaderboardStorage.GetLeaderboardByIDAndGameID), deltaRuleStorage.SetDeltaRule)
+		getDeltaRuleFunc = delta.BuildGetRuleFunc(deltaRuleStorage.GetDeltaRule)
+		removeDeltaRuleFunc = delta.BuildRemoveRuleFunc(deltaRuleStorage.DeleteDeltaRule)
+
+		upsertPlayerRankValueFunc = delta.BuildEnforceFunc(
+			deltaRuleStorage.GetDeltaRule,
+			leaderboardStorage.GetPlayerRank,
+			deltaRuleStorage.SumDeltaImprovements,
+			deltaRuleStorage.RecordDeltaImprovement,
+			holdSubmissionFunc,
+			upsertPlayerRankValueFunc,
+		)
+
+		if trusts.storage != nil {
+			upsertPlayerRankValueFunc, err = trust.BuildAssessFunc(trusts.config, trusts.storage.TrackTrustRecord, upsertPlayerRankValueFunc)
+			if err != nil {
+				zap.Panic(err, "invalid trust settings")
+			}
+		}
+	}
+
+	return deltaRuleFuncs{
+		setDeltaRuleFunc:    setDeltaRuleFunc,
+		getDeltaRuleFunc:    getDeltaRuleFunc,
+		removeDeltaRuleFunc: removeDeltaRuleFunc,
+	}, upsertPlayerRankValueFunc, releaseUpsertPlayerRankValueFunc
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	asyncmemory "github.com/gabapcia/gameblitz/internal/infra/async/memory"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Game every request is authenticated as on the dev mode
+const devGameID = "dev"
+
+// Runs the API without any infrastructure: every storage is kept in memory, the events are published on an
+// in-process bus, demo data is seeded and every request is authenticated as the dev game. Nothing survives a restart
+var devMode = flag.Bool("dev", false, "run with in-memory storage, an in-process event bus, demo data and no auth")
+
+var (
+	// Settings forced on the dev mode. They're set on the environment, so they take precedence over the config file
+	devSettings = map[string]string{
+		"LEADERBOARD_STORAGE":           "memory",
+		"STATISTIC_STORAGE":             "memory",
+		"QUEST_STORAGE":                 "memory",
+		"AUDIT_STORAGE":                 "memory",
+		"CACHE_STORAGE":                 "memory",
+		"DEAD_LETTER_STORAGE":           "memory",
+		"INGESTION_DEDUP_STORAGE":       "memory",
+		"WEBHOOK_STORAGE":               "memory",
+		"USAGE_STORAGE":                 "memory",
+		"QUOTA_STORAGE":                 "memory",
+		"PROFILE_STORAGE":               "memory",
+		"ACCOUNT_LINK_STORAGE":          "memory",
+		"BAN_STORAGE":                   "memory",
+		"SHADOW_FLAG_STORAGE":           "memory",
+		"ACHIEVEMENT_STORAGE":           "memory",
+		"TITLE_STORAGE":                 "memory",
+		"FRIEND_STORAGE":                "memory",
+		"SEGMENT_STORAGE":               "memory",
+		"DELTA_RULE_STORAGE":            "memory",
+		"QUARANTINE_STORAGE":            "memory",
+		"PROOF_RULE_STORAGE":            "memory",
+		"ANOMALY_STORAGE":               "memory",
+		"MODERATION_STORAGE":            "memory",
+		"LEVEL_STORAGE":                 "memory",
+		"ACTIVITY_STORAGE":              "memory",
+		"PRESENCE_STORAGE":              "memory",
+		"NONCE_STORAGE":                 "memory",
+		"SUBMISSION_RATE_LIMIT_STORAGE": "memory",
+		"REWARD_STORAGE":                "memory",
+		"SCORE_HISTORY_STORAGE":         "memory",
+		"REALTIME_BACKPLANE":            "memory",
+		"BROKER":                        "memory",
+		"INGESTION_ENABLED":             "false",
+		"OUTBOX_ENABLED":                "false",
+		"MONGO_CHANGE_STREAM":           "false",
+		"MQTT_ENABLED":                  "false",
+		"TRACING_ENABLED":               "false",
+		"ENCRYPTION_KEY_KMS":            "false",
+		"SENTRY_DSN":                    "",
+	}
+
+	// Settings defaulted on the dev mode when unset, as some of them are required otherwise
+	devDefaults = map[string]string{
+		"PORT":                "8080",
+		"KEYCLOACK_CERTS_URI": "http://localhost",
+		"LOG_LEVEL":           "debug",
+	}
+)
+
+func applyDevSettings() error {
+	for key, value := range devSettings {
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+
+	for key, value := range devDefaults {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Authenticates every credential as the dev game, for the controllers reading the credentials themselves, e.g. gRPC
+func devAuthenticate(ctx context.Context, credentials string) (auth.Claims, error) {
+	return auth.Claims{GameID: devGameID, Subject: "dev"}, nil
+}
+
+// Logs the events published on the in-process bus, as nothing else consumes them
+func logDevEvent(ctx context.Context, event asyncmemory.Event) {
+	zap.DebugContext(ctx, "event published", "destination", event.Destination, "routingKey", event.RoutingKey, "schema", event.Schema, "body", string(event.Body))
+}
+
+type devStorage interface {
+	CreateLeaderboard(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error)
+	UpsertPlayerRankValue(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error
+	CreateStatistic(ctx context.Context, data statistic.NewStatisticData) (statistic.Statistic, error)
+	UpdatePlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error)
+	CreateQuest(ctx context.Context, data quest.NewQuestData) (quest.Quest, error)
+}
+
+// Creates a leaderboard, a statistic and a quest on the dev game, with a few players ranked and progressing,
+// so the API can be explored right away
+func seedDevData(ctx context.Context, storage devStorage) error {
+	players := []string{"alice", "bob", "carol"}
+
+	lb, err := storage.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{
+		GameID:          devGameID,
+		Name:            "Season 1",
+		Description:     "Highest score of the season",
+		StartAt:         time.Now().UTC().Add(-time.Hour),
+		AggregationMode: leaderboard.AggregationModeMax,
+		Ordering:        leaderboard.OrderingDesc,
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, playerID := range players {
+		if err := storage.UpsertPlayerRankValue(ctx, lb, playerID, float64(1000*(i+1))); err != nil {
+			return err
+		}
+	}
+
+	goal := 100.0
+	st, err := storage.CreateStatistic(ctx, statistic.NewStatisticData{
+		GameID:          devGameID,
+		Name:            "Kills",
+		Description:     "Enemies defeated",
+		AggregationMode: statistic.AggregationModeSum,
+		Goal:            &goal,
+		Landmarks:       []float64{10, 50},
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, playerID := range players {
+		if _, _, err := storage.UpdatePlayerStatisticProgression(ctx, st, playerID, float64(20*(i+1))); err != nil {
+			return err
+		}
+	}
+
+	q, err := storage.CreateQuest(ctx, quest.NewQuestData{
+		GameID:      devGameID,
+		Name:        "First Steps",
+		Description: "Finish the tutorial and win a match",
+		Tasks: []quest.NewTaskData{
+			{
+				Name:                  "Tutorial",
+				Description:           "Finish the tutorial",
+				RequiredForCompletion: true,
+				Rule:                  `{"==": [{"var": "tutorial"}, true]}`,
+			},
+			{
+				Name:                  "First Win",
+				Description:           "Win a match",
+				DependsOn:             []int{0},
+				RequiredForCompletion: true,
+				Rule:                  `{">=": [{"var": "wins"}, 1]}`,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	zap.Info("dev data seeded", "gameId", devGameID, "leaderboardId", lb.ID, "statisticId", st.ID, "questId", q.ID)
+	return nil
+}
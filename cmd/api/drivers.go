@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/datachange"
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/dynamodb"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/mongo"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/redis"
+)
+
+// The storages of each domain, by the driver keeping them. The memory driver keeps every domain but the outbox
+type storageDrivers struct {
+	leaderboardStorages    map[string]leaderboardStorage
+	statisticStorages      map[string]statisticStorage
+	questStorages          map[string]questStorage
+	auditStorages          map[string]auditStorage
+	deadLetterStorages     map[string]deadLetterStorage
+	dedupStorages          map[string]dedupStorage
+	rankingCacheStorages   map[string]rankingCacheStorage
+	topViewStorages        map[string]topViewStorage
+	presenceStorages       map[string]presenceStorage
+	nonceStorages          map[string]nonceStorage
+	submissionRateStorages map[string]submissionRateStorage
+	bulkStatisticStorages  map[string]bulkStatisticStorage
+	webhookStorages        map[string]webhookStorage
+	usageStorages          map[string]usageStorage
+	quotaStorages          map[string]quotaStorage
+	profileStorages        map[string]profileStorage
+	accountLinkStorages    map[string]accountLinkStorage
+	banStorages            map[string]banStorage
+	shadowFlagStorages     map[string]shadowFlagStorage
+	achievementStorages    map[string]achievementStorage
+	titleStorages          map[string]titleStorage
+	friendStorages         map[string]friendStorage
+	segmentStorages        map[string]segmentStorage
+	deltaRuleStorages      map[string]deltaRuleStorage
+	quarantineStorages     map[string]quarantineStorage
+	trustStorages          map[string]trustStorage
+	proofRuleStorages      map[string]proofRuleStorage
+	anomalyStorages        map[string]anomalyStorage
+	moderationStorages     map[string]moderationStorage
+	levelStorages          map[string]levelStorage
+	activityStorages       map[string]activityStorage
+	rewardStorages         map[string]rewardStorage
+	footprintStorages      map[string]footprintStorage
+	scoreHistoryStorages   map[string]scoreHistoryStorage
+	schedulerLockStorages  map[string]schedulerLockStorage
+	outboxStorages         map[string]outboxStorage
+
+	storageWatchChangesFunc datachange.StorageWatchChangesFunc
+}
+
+// Only the drivers used by some domain are started. The returned func closes them
+func newStorageDrivers(ctx context.Context, config Config, memory memoryStorage, cipher *encryption.Cipher, storageMetrics *metrics.Storage, healthChecks map[string]metrics.CheckFunc) (storageDrivers, func()) {
+	var (
+		closers []func()
+		d       = storageDrivers{
+			leaderboardStorages:    map[string]leaderboardStorage{"memory": memory},
+			statisticStorages:      map[string]statisticStorage{"memory": memory},
+			questStorages:          map[string]questStorage{"memory": memory},
+			auditStorages:          map[string]auditStorage{"memory": memory},
+			deadLetterStorages:     map[string]deadLetterStorage{"memory": memory},
+			dedupStorages:          map[string]dedupStorage{"memory": memory},
+			rankingCacheStorages:   map[string]rankingCacheStorage{"memory": memory},
+			topViewStorages:        map[string]topViewStorage{"memory": memory},
+			presenceStorages:       map[string]presenceStorage{"memory": memory},
+			nonceStorages:          map[string]nonceStorage{"memory": memory},
+			submissionRateStorages: map[string]submissionRateStorage{"memory": memory},
+			bulkStatisticStorages:  map[string]bulkStatisticStorage{"memory": memory},
+			webhookStorages:        map[string]webhookStorage{"memory": memory},
+			usageStorages:          map[string]usageStorage{"memory": memory},
+			quotaStorages:          map[string]quotaStorage{"memory": memory},
+			profileStorages:        map[string]profileStorage{"memory": memory},
+			accountLinkStorages:    map[string]accountLinkStorage{"memory": memory},
+			banStorages:            map[string]banStorage{"memory": memory},
+			shadowFlagStorages:     map[string]shadowFlagStorage{"memory": memory},
+			achievementStorages:    map[string]achievementStorage{"memory": memory},
+			titleStorages:          map[string]titleStorage{"memory": memory},
+			friendStorages:         map[string]friendStorage{"memory": memory},
+			segmentStorages:        map[string]segmentStorage{"memory": memory},
+			deltaRuleStorages:      map[string]deltaRuleStorage{"memory": memory},
+			quarantineStorages:     map[string]quarantineStorage{"memory": memory},
+			trustStorages:          map[string]trustStorage{"memory": memory},
+			proofRuleStorages:      map[string]proofRuleStorage{"memory": memory},
+			anomalyStorages:        map[string]anomalyStorage{"memory": memory},
+			moderationStorages:     map[string]moderationStorage{"memory": memory},
+			levelStorages:          map[string]levelStorage{"memory": memory},
+			activityStorages:       map[string]activityStorage{"memory": memory},
+			rewardStorages:         map[string]rewardStorage{"memory": memory},
+			footprintStorages:      map[string]footprintStorage{"memory": memory},
+			scoreHistoryStorages:   map[string]scoreHistoryStorage{"memory": memory},
+			schedulerLockStorages:  map[string]schedulerLockStorage{"memory": memory},
+			outboxStorages:         map[string]outboxStorage{},
+		}
+	)
+
+	if config.usesStorage("redis") {
+		gameRoutes, err := redis.ParseGameRoutes(config.RedisGameRoutes)
+		if err != nil {
+			zap.Panic(err, "invalid redis game routes")
+		}
+
+		redis, err := redis.New(ctx, redis.Options{
+			Addrs:              config.RedisAddrs,
+			Username:           config.RedisUsername,
+			Password:           config.RedisPassword,
+			DB:                 config.RedisDB,
+			Cluster:            config.RedisCluster,
+			MaxRedirects:       config.RedisClusterMaxRedirects,
+			Retry:              config.startupRetry("redis"),
+			PoolSize:           config.RedisPoolSize,
+			MinIdleConns:       config.RedisMinIdleConns,
+			MaxIdleConns:       config.RedisMaxIdleConns,
+			ConnMaxIdleTime:    time.Duration(config.RedisConnMaxIdleTime) * time.Second,
+			ConnMaxLifetime:    time.Duration(config.RedisConnMaxLifetime) * time.Second,
+			PoolTimeout:        time.Duration(config.RedisPoolTimeout) * time.Second,
+			FallbackAddrs:      config.RedisFallbackAddrs,
+			Failover:           config.failoverConfig("redis"),
+			GameRoutes:         gameRoutes,
+			Metrics:            storageMetrics,
+			Tracing:            config.TracingEnabled,
+			SlowQueryThreshold: time.Duration(config.RedisSlowQueryThreshold) * time.Millisecond,
+			OnSlowQuery:        logSlowQuery("redis"),
+			PipelineWindow:     time.Duration(config.RedisPipelineWindow) * time.Millisecond,
+			PipelineSize:       config.RedisPipelineSize,
+		})
+		if err != nil {
+			zap.Panic(err, "redis startup failed")
+		}
+		closers = append(closers, func() { redis.Close() })
+		healthChecks["redis"] = redis.Ping
+
+		d.leaderboardStorages["redis"] = redis
+		d.dedupStorages["redis"] = redis
+		d.rankingCacheStorages["redis"] = redis
+		d.topViewStorages["redis"] = redis
+		d.presenceStorages["redis"] = redis
+		d.nonceStorages["redis"] = redis
+		d.submissionRateStorages["redis"] = redis
+		d.schedulerLockStorages["redis"] = redis
+	}
+
+	if config.usesStorage("mongo") {
+		mongo, err := mongo.New(ctx, mongo.Options{
+			URI:                            config.MongoURI,
+			DB:                             config.MongoDB,
+			AppName:                        config.MongoAppName,
+			Username:                       config.MongoUsername,
+			Password:                       config.MongoPassword,
+			AuthSource:                     config.MongoAuthSource,
+			TLS:                            config.MongoTLS,
+			TLSCAFile:                      config.MongoTLSCAFile,
+			TLSCertFile:                    config.MongoTLSCertFile,
+			TLSKeyFile:                     config.MongoTLSKeyFile,
+			TLSInsecureSkipVerify:          config.MongoTLSInsecureSkipVerify,
+			ConnectTimeout:                 time.Duration(config.MongoConnectTimeout) * time.Second,
+			ServerSelectionTimeout:         time.Duration(config.MongoServerSelectionTimeout) * time.Second,
+			SocketTimeout:                  time.Duration(config.MongoSocketTimeout) * time.Second,
+			MaxPoolSize:                    config.MongoMaxPoolSize,
+			MinPoolSize:                    config.MongoMinPoolSize,
+			MaxConnIdleTime:                time.Duration(config.MongoMaxConnIdleTime) * time.Second,
+			MaxConnecting:                  config.MongoMaxConnecting,
+			Retry:                          config.startupRetry("mongo"),
+			ReadPreferences:                config.MongoReadPreferences,
+			FallbackURI:                    config.MongoFallbackURI,
+			Failover:                       config.failoverConfig("mongo"),
+			Outbox:                         config.OutboxEnabled,
+			Metrics:                        storageMetrics,
+			Tracing:                        config.TracingEnabled,
+			SlowQueryThreshold:             time.Duration(config.MongoSlowQueryThreshold) * time.Millisecond,
+			OnSlowQuery:                    logSlowQuery("mongo"),
+			ProgressionMigration:           config.MongoProgressionMigration,
+			ProgressionMigrationCollection: config.MongoProgressionCollection,
+			Cipher:                         cipher,
+		})
+		if err != nil {
+			zap.Panic(err, "mongo startup failed")
+		}
+		closers = append(closers, func() { mongo.Close(context.Background()) })
+		healthChecks["mongo"] = mongo.Ping
+
+		switch config.MongoIndexes {
+		case "ensure":
+			err = mongo.EnsureIndexes(ctx)
+		case "verify":
+			err = mongo.VerifyIndexes(ctx)
+		case "skip":
+		default:
+			err = fmt.Errorf("unknown mode %q", config.MongoIndexes)
+		}
+		if err != nil {
+			zap.Panic(err, "mongo indexes setup failed")
+		}
+
+		d.statisticStorages["mongo"] = mongo
+		d.bulkStatisticStorages["mongo"] = mongo
+		d.auditStorages["mongo"] = mongo
+		d.deadLetterStorages["mongo"] = mongo
+		d.outboxStorages["mongo"] = mongo
+		d.webhookStorages["mongo"] = mongo
+		d.usageStorages["mongo"] = mongo
+		d.quotaStorages["mongo"] = mongo
+		d.profileStorages["mongo"] = mongo
+		d.accountLinkStorages["mongo"] = mongo
+		d.banStorages["mongo"] = mongo
+		d.shadowFlagStorages["mongo"] = mongo
+		d.achievementStorages["mongo"] = mongo
+		d.titleStorages["mongo"] = mongo
+		d.friendStorages["mongo"] = mongo
+		d.segmentStorages["mongo"] = mongo
+		d.deltaRuleStorages["mongo"] = mongo
+		d.quarantineStorages["mongo"] = mongo
+		d.trustStorages["mongo"] = mongo
+		d.proofRuleStorages["mongo"] = mongo
+		d.anomalyStorages["mongo"] = mongo
+		d.moderationStorages["mongo"] = mongo
+		d.levelStorages["mongo"] = mongo
+		d.activityStorages["mongo"] = mongo
+		d.rewardStorages["mongo"] = mongo
+		d.schedulerLockStorages["mongo"] = mongo
+		d.footprintStorages["mongo"] = mongo
+		d.scoreHistoryStorages["mongo"] = mongo
+
+		if config.MongoChangeStream {
+			d.storageWatchChangesFunc = mongo.WatchChanges
+		}
+	}
+
+	if config.usesStorage("dynamodb") {
+		dynamodb, err := dynamodb.New(ctx, dynamodb.Options{
+			Table:    config.DynamoDBTable,
+			Region:   config.DynamoDBRegion,
+			Endpoint: config.DynamoDBEndpoint,
+			Retry:    config.startupRetry("dynamodb"),
+			Metrics:  storageMetrics,
+			Cipher:   cipher,
+		})
+		if err != nil {
+			zap.Panic(err, "dynamodb startup failed")
+		}
+		closers = append(closers, func() { dynamodb.Close() })
+		healthChecks["dynamodb"] = dynamodb.Ping
+
+		if config.DynamoDBEnsureTable {
+			if err := dynamodb.EnsureTable(ctx); err != nil {
+				zap.Panic(err, "dynamodb table setup failed")
+			}
+		}
+
+		d.statisticStorages["dynamodb"] = dynamodb
+		d.auditStorages["dynamodb"] = dynamodb
+	}
+
+	if config.usesStorage("postgres") {
+		postgres, err := postgres.New(ctx, postgres.Options{
+			DSN:     config.PotgresDSN,
+			Outbox:  config.OutboxEnabled,
+			Metrics: storageMetrics,
+		})
+		if err != nil {
+			zap.Panic(err, "postgres startup failed")
+		}
+		closers = append(closers, func() { postgres.Close() })
+		healthChecks["postgres"] = postgres.Ping
+
+		d.leaderboardStorages["postgres"] = postgres
+		d.statisticStorages["postgres"] = postgres
+		d.questStorages["postgres"] = postgres
+		d.outboxStorages["postgres"] = postgres
+		d.scoreHistoryStorages["postgres"] = postgres
+	}
+
+	return d, func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+}
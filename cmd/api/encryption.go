@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+)
+
+// Loads the cipher the storages encrypt the personal data with, decrypting its key with AWS KMS when it's a data key.
+// Without a key the data is stored as it is, and the cipher is nil
+func newCipher(ctx context.Context, config Config) (*encryption.Cipher, error) {
+	if config.EncryptionKey == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(config.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.EncryptionKeyKMS {
+		if key, err = encryption.DecryptKeyWithKMS(ctx, config.EncryptionKMSRegion, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return encryption.New(key)
+}
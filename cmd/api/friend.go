@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/friend"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+)
+
+type friendFuncs struct {
+	storage          friendStorage
+	addFriendFunc    friend.AddFunc
+	removeFriendFunc friend.RemoveFunc
+	listFriendsFunc  friend.ListFunc
+}
+
+func newFriends(config Config, drivers storageDrivers) friendFuncs {
+	var (
+		ok               bool
+		friendStorage    friendStorage
+		addFriendFunc    friend.AddFunc
+		removeFriendFunc friend.RemoveFunc
+		listFriendsFunc  friend.ListFunc
+	)
+	if config.FriendsEnabled {
+		if friendStorage, ok = drivers.friendStorages[config.FriendStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.FriendStorage), "invalid friend storage")
+		}
+
+		addFriendFunc = friend.BuildAddFunc(friendStorage.ListFriends, friendStorage.AddFriendship)
+		removeFriendFunc = friend.BuildRemoveFunc(friendStorage.RemoveFriendship)
+		listFriendsFunc = friend.BuildListFunc(friendStorage.ListFriends)
+	}
+
+	return friendFuncs{
+		storage:          friendStorage,
+		addFriendFunc:    addFriendFunc,
+		removeFriendFunc: removeFriendFunc,
+		listFriendsFunc:  listFriendsFunc,
+	}
+}
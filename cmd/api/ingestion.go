@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/rabbitmq"
+	"github.com/gabapcia/gameblitz/internal/infra/async/sqs"
+	asyncwebhook "github.com/gabapcia/gameblitz/internal/infra/async/webhook"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+type ingestionFuncs struct {
+	listDeadLettersFunc   ingestion.ListDeadLettersFunc
+	requeueDeadLetterFunc ingestion.RequeueDeadLetterFunc
+}
+
+// The ingestion messages are consumed and their backlog monitored in the background until the context is canceled.
+// The dead letters hold the player's data, so their storage is added to the player data funcs.
+// The returned func closes the consumer and the backlog alerter
+func newIngestion(ctx context.Context, config Config, drivers storageDrivers, consumerMetrics *metrics.Consumer, healthChecks map[string]metrics.CheckFunc, leaderboardStorage leaderboardStorage, statisticStorage statisticStorage, levelStorage levelStorage, broker broker, notifierRankChange leaderboard.NotifierRankChange, upsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc, notifierPlayerStatisticProgressionUpdates statistic.NotifierPlayerProgressionUpdates, updatePlayerStatisticProgressionFunc statistic.StorageUpdatePlayerProgressionFunc, playerData *playerDataFuncs) (ingestionFuncs, func()) {
+	var (
+		closeIngestion        = func() {}
+		listDeadLettersFunc   ingestion.ListDeadLettersFunc
+		requeueDeadLetterFunc ingestion.RequeueDeadLetterFunc
+	)
+	if config.IngestionEnabled {
+		deadLetterStorage, ok := drivers.deadLetterStorages[config.DeadLetterStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.DeadLetterStorage), "invalid dead letter storage")
+		}
+
+		retryDelay := backoff.Config{
+			InitialInterval: time.Duration(config.IngestionRetryDelay) * time.Second,
+			MaxInterval:     time.Duration(config.IngestionRetryMaxDelay) * time.Second,
+		}
+
+		batchWait := time.Duration(config.IngestionBatchWait) * time.Millisecond
+
+		var (
+			consumer ingestionConsumer
+			err      error
+		)
+		switch config.IngestionBroker {
+		case "rabbitmq":
+			if config.RabbitURI == "" {
+				err = fmt.Errorf("RABBITMQ_URI is required by the %q ingestion broker", config.IngestionBroker)
+				break
+			}
+			consumer, err = rabbitmq.NewConsumer(ctx, config.RabbitURI, rabbitmq.ConsumerOptions{
+				BatchSize:   config.IngestionBatchSize,
+				BatchWait:   batchWait,
+				Workers:     config.IngestionWorkers,
+				KindWorkers: config.IngestionKindWorkers,
+				Prefetch:    config.IngestionPrefetch,
+				RetryDelay:  retryDelay,
+				Retry:       config.startupRetry("rabbitmq"),
+			})
+		case "sqs":
+			consumer, err = sqs.NewConsumer(ctx, sqs.ConsumerOptions{
+				Region:            config.SQSRegion,
+				Endpoint:          config.SQSEndpoint,
+				Queue:             config.SQSIngestionQueue,
+				BatchSize:         config.SQSBatchSize,
+				WaitTime:          time.Duration(config.SQSWaitTime) * time.Second,
+				VisibilityTimeout: time.Duration(config.SQSVisibilityTimeout) * time.Second,
+				ProcessBatchSize:  config.IngestionBatchSize,
+				ProcessBatchWait:  batchWait,
+				Workers:           config.IngestionWorkers,
+				RetryDelay:        retryDelay,
+				Retry:             config.startupRetry("sqs"),
+			})
+		default:
+			err = fmt.Errorf("unknown ingestion broker %q", config.IngestionBroker)
+		}
+		if err != nil {
+			zap.Panic(err, "ingestion consumer startup failed")
+		}
+		closeIngestion = consumer.Close
+		healthChecks["ingestion_broker"] = consumer.Ping
+
+		handleFunc := ingestion.BuildHandleFunc(
+			leaderboard.BuildGetByIDAndGameIDFunc(leaderboardStorage.GetLeaderboardByIDAndGameID),
+			leaderboard.BuildUpsertPlayerRankFunc(notifierRankChange, upsertPlayerRankValueFunc),
+			statistic.BuildGetStatisticByIDAndGameID(statisticStorage.GetStatisticByIDAndGameID),
+			statistic.BuildUpsertPlayerProgressionFunc(notifierPlayerStatisticProgressionUpdates, updatePlayerStatisticProgressionFunc),
+		)
+
+		// Without bulk writes on the statistic storage, the statistic messages are applied one by one. So are they with the
+		// submission rate limit, as a bulk write applies every change or none, failing the whole batch for a single player,
+		// and with the write-behind queue, which already writes them in bulk
+		var bulkUpsertPlayerProgressionFunc statistic.BulkUpsertPlayerProgressionFunc
+		if bulkStatisticStorage, ok := drivers.bulkStatisticStorages[config.StatisticStorage]; ok && !config.SubmissionRateLimitEnabled && !config.StatisticWriteBehindEnabled {
+			var bulkUpdatePlayerStatisticProgressionFunc statistic.StorageBulkUpdatePlayerProgressionFunc = bulkStatisticStorage.BulkUpdatePlayerStatisticProgression
+			if levelStorage != nil {
+				bulkUpdatePlayerStatisticProgressionFunc = level.BuildNotifyBulkLevelUpsFunc(broker.LevelUp, levelStorage.ListLevelCurves, bulkUpdatePlayerStatisticProgressionFunc)
+			}
+
+			bulkUpsertPlayerProgressionFunc = statistic.BuildBulkUpsertPlayerProgressionFunc(notifierPlayerStatisticProgressionUpdates, bulkUpdatePlayerStatisticProgressionFunc)
+		}
+
+		handleBatchFunc := ingestion.BuildHandleBatchFunc(
+			statistic.BuildGetStatisticByIDAndGameID(statisticStorage.GetStatisticByIDAndGameID),
+			bulkUpsertPlayerProgressionFunc,
+			handleFunc,
+		)
+
+		if config.IngestionDedupTTL > 0 {
+			dedupStorage, ok := drivers.dedupStorages[config.IngestionDedupStorage]
+			if !ok {
+				zap.Panic(fmt.Errorf("unknown storage %q", config.IngestionDedupStorage), "invalid ingestion deduplication storage")
+			}
+
+			handleBatchFunc, err = ingestion.BuildDeduplicatedHandleBatchFunc(
+				time.Duration(config.IngestionDedupTTL)*time.Second,
+				dedupStorage.ClaimMessage,
+				dedupStorage.ReleaseMessage,
+				handleBatchFunc,
+			)
+			if err != nil {
+				zap.Panic(err, "invalid ingestion setup")
+			}
+		}
+
+		processBatchFunc, err := ingestion.BuildProcessBatchFunc(config.IngestionMaxAttempts, handleBatchFunc, deadLetterStorage.CreateDeadLetter)
+		if err != nil {
+			zap.Panic(err, "invalid ingestion setup")
+		}
+		processBatchFunc = observeProcessBatch(consumerMetrics, config.IngestionBroker, processBatchFunc)
+
+		go func() {
+			for ctx.Err() == nil {
+				if err := consumer.Consume(ctx, processBatchFunc); err != nil {
+					zap.Error(err, "ingestion consumer stopped, restarting")
+					time.Sleep(5 * time.Second)
+				}
+			}
+		}()
+
+		// Without an alert URL the backlog is only exposed on the metrics
+		var notifierBacklogAlertFunc ingestion.NotifierBacklogAlertFunc
+		if config.IngestionBacklogAlertURL != "" {
+			alerter := asyncwebhook.NewAlerter(config.IngestionBacklogAlertURL, config.IngestionBroker, 0)
+			closeIngestion = func() {
+				alerter.Close()
+				consumer.Close()
+			}
+
+			notifierBacklogAlertFunc = alerter.NotifyBacklog
+		}
+
+		monitorBacklogFunc := ingestion.BuildMonitorBacklogFunc(config.IngestionBacklogThreshold, consumer.Backlog, notifierBacklogAlertFunc)
+		go func() {
+			ticker := time.NewTicker(time.Duration(config.IngestionBacklogInterval) * time.Second)
+			defer ticker.Stop()
+
+			for {
+				backlogs, err := monitorBacklogFunc(ctx)
+				if err != nil {
+					zap.Error(err, "ingestion backlog monitoring failed")
+				}
+
+				for _, backlog := range backlogs {
+					consumerMetrics.ObserveBacklog(config.IngestionBroker, backlog.Queue, backlog.Messages)
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+
+		listDeadLettersFunc = ingestion.BuildListDeadLettersFunc(deadLetterStorage.ListDeadLetters)
+		requeueDeadLetterFunc = ingestion.BuildRequeueDeadLetterFunc(deadLetterStorage.GetDeadLetter, deadLetterStorage.DeleteDeadLetter, consumer.PublishIngestion)
+		playerData.add(deadLetterStorage.ErasePlayerDeadLetters, deadLetterStorage.AnonymizePlayerDeadLetters, deadLetterStorage.CountPlayerDeadLetters)
+	}
+
+	return ingestionFuncs{
+		listDeadLettersFunc:   listDeadLettersFunc,
+		requeueDeadLetterFunc: requeueDeadLetterFunc,
+	}, closeIngestion
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+type levelFuncs struct {
+	storage              levelStorage
+	createLevelCurveFunc level.CreateFunc
+	getLevelCurveFunc    level.GetByIDAndGameIDFunc
+	listLevelCurvesFunc  level.ListFunc
+	deleteLevelCurveFunc level.DeleteFunc
+	getPlayerLevelFunc   level.GetPlayerLevelFunc
+	listPlayerLevelsFunc level.ListPlayerLevelsFunc
+}
+
+// The level-ups are published as the players earn XP, from the progression updates of the XP statistics
+func newLevels(config Config, drivers storageDrivers, statisticStorage statisticStorage, broker broker) (levelFuncs, statistic.StorageUpdatePlayerProgressionFunc) {
+	var (
+		ok                                   bool
+		levelStorage                         levelStorage
+		createLevelCurveFunc                 level.CreateFunc
+		getLevelCurveFunc                    level.GetByIDAndGameIDFunc
+		listLevelCurvesFunc                  level.ListFunc
+		deleteLevelCurveFunc                 level.DeleteFunc
+		getPlayerLevelFunc                   level.GetPlayerLevelFunc
+		listPlayerLevelsFunc                 level.ListPlayerLevelsFunc
+		updatePlayerStatisticProgressionFunc statistic.StorageUpdatePlayerProgressionFunc = statisticStorage.UpdatePlayerStatisticProgression
+	)
+	if config.LevelsEnabled {
+		if levelStorage, ok = drivers.levelStorages[config.LevelStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.LevelStorage), "invalid level storage")
+		}
+
+		getStatisticFunc := statistic.BuildGetStatisticByIDAndGameID(statisticStorage.GetStatisticByIDAndGameID)
+		getPlayerProgressionFunc := statistic.BuildGetPlayerProgression(statisticStorage.GetPlayerProgression)
+		createLevelCurveFunc = level.BuildCreateFunc(getStatisticFunc, levelStorage.CreateLevelCurve)
+		getLevelCurveFunc = level.BuildGetByIDAndGameIDFunc(levelStorage.GetLevelCurve)
+		listLevelCurvesFunc = level.BuildListFunc(levelStorage.ListLevelCurves)
+		deleteLevelCurveFunc = level.BuildDeleteFunc(levelStorage.DeleteLevelCurve)
+		getPlayerLevelFunc = level.BuildGetPlayerLevelFunc(getStatisticFunc, getPlayerProgressionFunc, levelStorage.GetLevelCurve)
+		listPlayerLevelsFunc = level.BuildListPlayerLevelsFunc(getStatisticFunc, getPlayerProgressionFunc, levelStorage.ListLevelCurves)
+
+		updatePlayerStatisticProgressionFunc = level.BuildNotifyLevelUpsFunc(broker.LevelUp, levelStorage.ListLevelCurves, updatePlayerStatisticProgressionFunc)
+	}
+
+	return levelFuncs{
+		storage:              levelStorage,
+		createLevelCurveFunc: createLevelCurveFunc,
+		getLevelCurveFunc:    getLevelCurveFunc,
+		listLevelCurvesFunc:  listLevelCurvesFunc,
+		deleteLevelCurveFunc: deleteLevelCurveFunc,
+		getPlayerLevelFunc:   getPlayerLevelFunc,
+		listPlayerLevelsFunc: listPlayerLevelsFunc,
+	}, updatePlayerStatisticProgressionFunc
+}
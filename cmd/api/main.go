@@ -2,122 +2,864 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
 	"time"
 
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/activity"
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+	"github.com/gabapcia/gameblitz/internal/audit"
 	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/controller/graphql"
+	grpcapi "github.com/gabapcia/gameblitz/internal/controller/grpc"
+	mqttapi "github.com/gabapcia/gameblitz/internal/controller/mqtt"
 	"github.com/gabapcia/gameblitz/internal/controller/rest"
-	"github.com/gabapcia/gameblitz/internal/infra/async/rabbitmq"
-	"github.com/gabapcia/gameblitz/internal/infra/cache/memcached"
+	"github.com/gabapcia/gameblitz/internal/datachange"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/infra/configfile"
 	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+	"github.com/gabapcia/gameblitz/internal/infra/sentry"
 	"github.com/gabapcia/gameblitz/internal/infra/service/keycloack"
-	"github.com/gabapcia/gameblitz/internal/infra/storage/mongo"
-	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres"
-	"github.com/gabapcia/gameblitz/internal/infra/storage/redis"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/memory"
+	"github.com/gabapcia/gameblitz/internal/infra/tracing"
 	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/merge"
+	"github.com/gabapcia/gameblitz/internal/overview"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/purge"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
 	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/reward"
+	"github.com/gabapcia/gameblitz/internal/segment"
 	"github.com/gabapcia/gameblitz/internal/statistic"
-
-	"github.com/kelseyhightower/envconfig"
+	"github.com/gabapcia/gameblitz/internal/summary"
+	"github.com/gabapcia/gameblitz/internal/title"
+	"github.com/gabapcia/gameblitz/internal/trust"
 )
 
-type Config struct {
-	Port int `envconfig:"PORT" required:"true"`
-
-	KeycloackCertsURI string `envconfig:"KEYCLOACK_CERTS_URI" required:"true"`
-
-	PotgresDSN string `envconfig:"POSTGRESQL_DSN" required:"true"`
-
-	MongoURI string `envconfig:"MONGO_URI" required:"true"`
-	MongoDB  string `envconfig:"MONGO_DB" required:"true"`
-
-	RedisAddr     string `envconfig:"REDIS_ADDR" required:"true"`
-	RedisUsername string `envconfig:"REDIS_USERNAME" required:"false"`
-	RedisPassword string `envconfig:"REDIS_PASSWORD" required:"false"`
-	RedisDB       int    `envconfig:"REDIS_DB" required:"false"`
-
-	MemcachedConnStr                   string `envconfig:"MEMCACHED_CONN_STR" required:"true"`
-	MemcachedCacheExpiration           int    `envconfig:"MEMCACHED_EXPIRATION" required:"false" default:"60"`
-	MemcachedCacheMiddlewareExpiration int    `envconfig:"MEMCACHED_MIDDLEWARE_EXPIRATION" required:"false" default:"60"`
-
-	RabbitURI string `envconfig:"RABBITMQ_URI" required:"true"`
-}
-
 func main() {
 	zap.Start()
 	defer zap.Sync()
 
+	flag.Parse()
+	if *devMode {
+		if err := applyDevSettings(); err != nil {
+			zap.Panic(err, "dev mode setup failed")
+		}
+	}
+
+	// Settings are read from the environment and, when `CONFIG_FILE` is set, from that YAML or JSON file,
+	// with the settings of its `CONFIG_PROFILE` profile merged over the common ones
 	var config Config
-	if err := envconfig.Process("", &config); err != nil {
-		zap.Panic(err, "env load failed")
+	if err := configfile.LoadProfile(os.Getenv("CONFIG_FILE"), os.Getenv("CONFIG_PROFILE"), &config); err != nil {
+		zap.Panic(err, "config load failed")
+	}
+
+	if err := zap.SetLevel(config.LogLevel, 0); err != nil {
+		zap.Panic(err, "invalid log level")
 	}
 
+	// SIGHUP toggles the debug logs, so they can be enabled during an incident without restarting.
+	// They are turned off on the next SIGHUP or once `LOG_DEBUG_DURATION` elapses
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	go func() {
+		for range hangups {
+			level, duration := "debug", time.Duration(config.LogDebugDuration)*time.Second
+			if zap.Level() == "debug" {
+				level, duration = config.LogLevel, 0
+			}
+
+			if err := zap.SetLevel(level, duration); err != nil {
+				zap.Error(err, "log level change failed")
+				continue
+			}
+
+			zap.Info("log level changed", "level", level)
+		}
+	}()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	keycloack, err := keycloack.New(ctx, config.KeycloackCertsURI)
+	telemetry, err := newMetrics(config)
 	if err != nil {
-		zap.Panic(err, "keycloack startup failed")
+		zap.Panic(err, "invalid slo targets")
+	}
+
+	// Dependencies checked by the health gauges, by the name they are labelled with
+	healthChecks := make(map[string]metrics.CheckFunc)
+
+	if config.TracingEnabled {
+		shutdownTracing, err := tracing.Start(ctx, tracing.Options{
+			Endpoint:    config.TracingEndpoint,
+			Insecure:    config.TracingInsecure,
+			ServiceName: config.TracingServiceName,
+			SampleRatio: config.TracingSampleRatio,
+		})
+		if err != nil {
+			zap.Panic(err, "tracing startup failed")
+		}
+
+		// Flushes the spans still pending, with its own context since the main one is canceled first
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := shutdownTracing(ctx); err != nil {
+				zap.Error(err, "tracing shutdown failed")
+			}
+		}()
 	}
 
-	redis := redis.New(ctx, config.RedisAddr, config.RedisUsername, config.RedisPassword, config.RedisDB)
-	defer redis.Close()
+	// The dev mode authenticates every request as the dev game, so it doesn't reach Keycloack
+	authenticateFunc := devAuthenticate
+	if !*devMode {
+		keycloack, err := keycloack.New(ctx, config.KeycloackCertsURI)
+		if err != nil {
+			zap.Panic(err, "keycloack startup failed")
+		}
+
+		authenticateFunc = auth.BuildAuthenticatorFunc(keycloack.Authenticate)
+	}
 
-	memcached := memcached.New(config.MemcachedConnStr)
-	defer memcached.Close()
+	cache, err := newCache(config)
+	if err != nil {
+		zap.Panic(err, "cache startup failed")
+	}
+	defer cache.Close()
 
-	rabbitmq, err := rabbitmq.NewProducer(ctx, config.RabbitURI)
+	encoder, err := message.NewEncoder(config.EventsFormat, config.EventsSource)
 	if err != nil {
-		zap.Panic(err, "rabbitmq startup failed")
+		zap.Panic(err, "invalid events format")
 	}
-	defer rabbitmq.Close()
 
-	mongo, err := mongo.New(ctx, config.MongoURI, config.MongoDB)
+	broker, err := newBroker(ctx, config, encoder)
 	if err != nil {
-		zap.Panic(err, "mongo startup failed")
+		zap.Panic(err, "broker startup failed")
 	}
-	defer mongo.Close(context.Background())
+	defer broker.Close()
+	healthChecks["broker"] = broker.Ping
 
-	postgres, err := postgres.New(ctx, config.PotgresDSN)
+	// The personal data is encrypted by every storage holding it, e.g. the profile display names
+	cipher, err := newCipher(ctx, config)
 	if err != nil {
-		zap.Panic(err, "postgres startup failed")
+		zap.Panic(err, "invalid encryption key")
+	}
+
+	memory := memory.New()
+	defer memory.Close()
+	memory.SetCipher(cipher)
+
+	if *devMode {
+		if err := seedDevData(ctx, memory); err != nil {
+			zap.Panic(err, "dev data seed failed")
+		}
+	}
+
+	drivers, closeDrivers := newStorageDrivers(ctx, config, memory, cipher, telemetry.storage, healthChecks)
+	defer closeDrivers()
+
+	leaderboardStorage, ok := drivers.leaderboardStorages[config.LeaderboardStorage]
+	if !ok {
+		zap.Panic(fmt.Errorf("unknown storage %q", config.LeaderboardStorage), "invalid leaderboard storage")
+	}
+
+	statisticStorage, ok := drivers.statisticStorages[config.StatisticStorage]
+	if !ok {
+		zap.Panic(fmt.Errorf("unknown storage %q", config.StatisticStorage), "invalid statistic storage")
+	}
+
+	questStorage, ok := drivers.questStorages[config.QuestStorage]
+	if !ok {
+		zap.Panic(fmt.Errorf("unknown storage %q", config.QuestStorage), "invalid quest storage")
+	}
+
+	auditStorage, ok := drivers.auditStorages[config.AuditStorage]
+	if !ok {
+		zap.Panic(fmt.Errorf("unknown storage %q", config.AuditStorage), "invalid audit storage")
+	}
+
+	leaderboardStorage, getRankingFunc := newRankingTops(config, drivers, leaderboardStorage)
+
+	upsertPlayerRankValueFunc := leaderboard.StorageUpsertPlayerRankValueFunc(leaderboardStorage.UpsertPlayerRankValue)
+
+	// With the score history, every score applied to a ranking is also recorded, so the ranking can be recomputed from it
+	var scoreHistoryStorage scoreHistoryStorage
+	if config.ScoreHistoryEnabled {
+		if scoreHistoryStorage, ok = drivers.scoreHistoryStorages[config.ScoreHistoryStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.ScoreHistoryStorage), "invalid score history storage")
+		}
+
+		upsertPlayerRankValueFunc = leaderboard.BuildRecordScoreFunc(scoreHistoryStorage.RecordScore, upsertPlayerRankValueFunc)
+	}
+
+	activities, upsertPlayerRankValueFunc := newActivities(config, drivers, leaderboardStorage, upsertPlayerRankValueFunc)
+
+	quarantines, holdSubmissionFunc := newQuarantine(config, drivers)
+
+	trusts, holdSubmissionFunc := newTrust(config, drivers, holdSubmissionFunc)
+
+	deltaRules, upsertPlayerRankValueFunc, releaseUpsertPlayerRankValueFunc := newDeltaRules(config, drivers, leaderboardStorage, trusts, holdSubmissionFunc, upsertPlayerRankValueFunc)
+
+	shadowFlags, upsertPlayerRankValueFunc, releaseUpsertPlayerRankValueFunc := newShadowFlags(config, drivers, leaderboardStorage, upsertPlayerRankValueFunc, releaseUpsertPlayerRankValueFunc)
+
+	bans, upsertPlayerRankValueFunc := newBans(config, drivers, cache, leaderboardStorage, upsertPlayerRankValueFunc)
+
+	webhooks, broker, closeWebhooks := newWebhooks(ctx, config, drivers, encoder, broker)
+	defer closeWebhooks()
+
+	usages, closeUsage := newUsage(ctx, cancel, config, drivers, telemetry.usage)
+	defer closeUsage()
+
+	quotas := newQuotas(config, drivers, leaderboardStorage, statisticStorage)
+
+	profiles := newProfiles(config, drivers)
+
+	accountLinks := newAccountLinks(config, drivers)
+
+	if drivers.storageWatchChangesFunc != nil {
+		watchDataChangesFunc := datachange.BuildWatchFunc(drivers.storageWatchChangesFunc, broker.DataChange)
+		go func() {
+			for ctx.Err() == nil {
+				if err := watchDataChangesFunc(ctx); err != nil {
+					zap.Error(err, "mongo change stream stopped, restarting")
+					time.Sleep(5 * time.Second)
+				}
+			}
+		}()
+	}
+
+	var (
+		notifierPlayerStatisticProgressionUpdates statistic.NotifierPlayerProgressionUpdates = broker.PlayerStatisticProgressionUpdates
+		notifierPlayerQuestProgressionUpdates     quest.NotifierPlayerProgressionUpdates     = broker.PlayerQuestProgressionUpdates
+		notifierQuestLifecycleEvent               quest.NotifierLifecycleEvent               = broker.QuestLifecycleEvent
+		notifierLeaderboardLifecycleEvent         leaderboard.NotifierLifecycleEvent         = broker.LeaderboardLifecycleEvent
+		notifierAchievementUnlocked               achievement.NotifierAchievementUnlocked    = broker.AchievementUnlocked
+	)
+
+	liveRankings, notifierRankChange, closeLiveRankings := newLiveRankings(ctx, config, leaderboardStorage, shadowFlags.getOwnPlayerRankFunc)
+	defer closeLiveRankings()
+
+	// The storages record the progression events on the outbox and the relays publish them
+	replayEventsFunc := newOutboxRelays(ctx, config, drivers, broker)
+	if config.OutboxEnabled {
+		notifierPlayerStatisticProgressionUpdates, notifierPlayerQuestProgressionUpdates = nil, nil
+	}
+
+	watchPlayerNotificationsFunc, notifierPlayerStatisticProgressionUpdates, notifierQuestLifecycleEvent, closeLiveNotifications := newLiveNotifications(ctx, config, notifierPlayerStatisticProgressionUpdates, notifierQuestLifecycleEvent)
+	defer closeLiveNotifications()
+
+	mqttBridge, notifierPlayerStatisticProgressionUpdates, notifierQuestLifecycleEvent := newMQTTBridge(config, notifierPlayerStatisticProgressionUpdates, notifierQuestLifecycleEvent)
+	if mqttBridge != nil {
+		defer mqttBridge.Close()
+	}
+
+	achievements := newAchievements(config, drivers, statisticStorage, questStorage)
+
+	titles := newTitles(config, drivers, statisticStorage, leaderboardStorage)
+
+	friends := newFriends(config, drivers)
+
+	presences := newPresences(config, drivers)
+
+	signedSubmissions := newSignedSubmissions(config, drivers)
+
+	segments, upsertPlayerRankValueFunc := newSegments(config, drivers, leaderboardStorage, statisticStorage, questStorage, profiles.listProfilesFunc, upsertPlayerRankValueFunc)
+
+	levels, updatePlayerStatisticProgressionFunc := newLevels(config, drivers, statisticStorage, broker)
+
+	// The player merges, made by the platform team, skip the write-behind queue and the rate limit below
+	var (
+		mergeUpsertPlayerRankValueFunc            = upsertPlayerRankValueFunc
+		mergeUpdatePlayerStatisticProgressionFunc = updatePlayerStatisticProgressionFunc
+	)
+
+	writeBehind, updatePlayerStatisticProgressionFunc, closeWriteBehind := newWriteBehind(ctx, cancel, config, drivers, levels.storage, broker, notifierPlayerStatisticProgressionUpdates, updatePlayerStatisticProgressionFunc)
+	defer closeWriteBehind()
+
+	upsertPlayerRankValueFunc, updatePlayerStatisticProgressionFunc = newSubmissionRateLimit(config, drivers, upsertPlayerRankValueFunc, updatePlayerStatisticProgressionFunc)
+
+	rewards, closeRewards := newRewards(ctx, config, drivers, broker, leaderboardStorage, questStorage, achievements.getAchievementFunc)
+	defer closeRewards()
+
+	// The rewards are queued as the leaderboards close, the quests are completed and the achievements are unlocked,
+	// after the event is published
+	if rewards.storage != nil {
+		enqueueRewardsFunc := reward.BuildEnqueueFunc(rewards.storage.ListSourceRewardRules, rewards.storage.CreateRewardGrants)
+		notifierLeaderboardLifecycleEvent = reward.BuildNotifierLeaderboardLifecycleEvent(
+			notifierLeaderboardLifecycleEvent,
+			leaderboard.BuildRankingFunc(leaderboardStorage.GetRanking),
+			rewards.storage.ListSourceRewardRules,
+			rewards.storage.CreateRewardGrants,
+		)
+		notifierQuestLifecycleEvent = reward.BuildNotifierQuestLifecycleEvent(notifierQuestLifecycleEvent, enqueueRewardsFunc)
+		notifierAchievementUnlocked = reward.BuildNotifierAchievementUnlocked(notifierAchievementUnlocked, enqueueRewardsFunc)
+	}
+
+	// The achievements are unlocked as the players reach the statistic landmarks and complete the quests,
+	// after the progression is published, and each one unlocked is published on the broker
+	if achievements.storage != nil {
+		unlockAchievementsFunc := achievement.BuildUnlockFunc(notifierAchievementUnlocked, achievements.storage.ListAchievements, achievements.storage.UnlockAchievements)
+		notifierPlayerStatisticProgressionUpdates = achievement.BuildNotifierStatisticProgressionUpdates(notifierPlayerStatisticProgressionUpdates, unlockAchievementsFunc)
+		notifierQuestLifecycleEvent = achievement.BuildNotifierQuestLifecycleEvent(notifierQuestLifecycleEvent, unlockAchievementsFunc)
+	}
+
+	// The titles are granted as the players reach the statistic landmarks and the top positions of the leaderboards,
+	// after the progression and the rank change are published
+	if titles.storage != nil {
+		grantTitlesFunc := title.BuildGrantFunc(titles.storage.ListTitles, titles.storage.GrantTitles)
+		notifierPlayerStatisticProgressionUpdates = title.BuildNotifierStatisticProgressionUpdates(notifierPlayerStatisticProgressionUpdates, grantTitlesFunc)
+		notifierRankChange = title.BuildNotifierRankChange(
+			notifierRankChange,
+			leaderboard.BuildGetByIDAndGameIDFunc(leaderboardStorage.GetLeaderboardByIDAndGameID),
+			leaderboard.BuildGetPlayerRankFunc(leaderboardStorage.GetPlayerRank),
+			titles.storage.ListTitles,
+			titles.storage.GrantTitles,
+		)
+	}
+
+	// The landmarks reached and the quests completed are recorded on the players' feeds, after the progression is published.
+	// The friends feed needs the friendships
+	var listFriendsActivitiesFunc activity.ListFriendsFunc
+	if activities.storage != nil {
+		notifierPlayerStatisticProgressionUpdates = activity.BuildNotifierStatisticProgressionUpdates(notifierPlayerStatisticProgressionUpdates, activities.storage.RecordActivities)
+		notifierQuestLifecycleEvent = activity.BuildNotifierQuestLifecycleEvent(notifierQuestLifecycleEvent, activities.storage.RecordActivities)
+
+		if friends.listFriendsFunc != nil {
+			listFriendsActivitiesFunc = activity.BuildListFriendsFunc(friends.listFriendsFunc, activities.storage.ListActivities)
+		}
+	}
+
+	// The released submissions are notified as the applied ones, so the rank change reaches the live rankings and grants the titles
+	var releaseQuarantinedSubmissionFunc quarantine.ReleaseFunc
+	if quarantines.storage != nil {
+		releaseQuarantinedSubmissionFunc = quarantine.BuildReleaseFunc(
+			leaderboard.BuildGetByIDAndGameIDFunc(leaderboardStorage.GetLeaderboardByIDAndGameID),
+			notifierRankChange,
+			quarantines.storage.GetQuarantinedSubmission,
+			quarantines.storage.DeleteQuarantinedSubmission,
+			releaseUpsertPlayerRankValueFunc,
+		)
+
+		if trusts.storage != nil {
+			releaseQuarantinedSubmissionFunc = trust.BuildRecordReleaseFunc(trusts.storage.IncrementTrustRecord, releaseQuarantinedSubmissionFunc)
+		}
+	}
+
+	proofRules := newProofRules(config, drivers, leaderboardStorage, quarantines.storage, holdSubmissionFunc)
+
+	anomalies := newAnomalies(config, drivers, leaderboardStorage)
+
+	// Restricting a quest to a segment keeps the other players from starting it
+	startQuestForPlayerFunc := quest.BuildStartQuestForPlayerFunc(notifierQuestLifecycleEvent, questStorage.StartQuestForPlayer)
+	if segments.storage != nil {
+		startQuestForPlayerFunc = segment.BuildRejectIneligibleQuestFunc(segments.storage.GetEligibility, segments.isSegmentMemberFunc, startQuestForPlayerFunc)
+	}
+
+	moderations := newModeration(config, drivers, leaderboardStorage, auditStorage, scoreHistoryStorage)
+
+	jobs := newScheduler(config, drivers)
+
+	if config.LeaderboardScheduleEventsEnabled {
+		registerJob(jobs, leaderboard.BuildNotifyScheduleJob(
+			time.Duration(config.LeaderboardScheduleEventsInterval)*time.Second,
+			leaderboard.BuildNotifyScheduleFunc(notifierLeaderboardLifecycleEvent, leaderboardStorage.ListLeaderboardsScheduledBetween),
+		))
+	}
+
+	if config.QuestExpiryEventsEnabled {
+		registerJob(jobs, quest.BuildNotifyExpiredJob(
+			time.Duration(config.QuestExpiryEventsInterval)*time.Second,
+			quest.BuildNotifyExpiredFunc(notifierQuestLifecycleEvent, questStorage.ListQuestsEndedBetween, questStorage.ListPlayersWithQuestUnfinished),
+		))
+	}
+
+	if config.SoftDeleteRetention > 0 {
+		registerJob(jobs, purge.BuildPurgeJob(
+			time.Duration(config.PurgeInterval)*time.Second,
+			purge.BuildPurgeFunc(
+				time.Duration(config.SoftDeleteRetention)*time.Hour,
+				audit.BuildRecordFunc(auditStorage.RecordAuditEntry),
+				leaderboardStorage.PurgeSoftDeletedLeaderboards,
+				statisticStorage.PurgeSoftDeletedStatistics,
+				questStorage.PurgeSoftDeletedQuests,
+			),
+		))
+	}
+
+	if anomalies.detectAnomaliesFunc != nil {
+		registerJob(jobs, anomaly.BuildDetectJob(time.Duration(config.AnomalyDetectionInterval)*time.Second, anomalies.detectAnomaliesFunc))
+	}
+
+	// Lifting a ban removes it at once, so a ban lifted concurrently by a submission puts its ranks back only once
+	if config.BansEnabled {
+		registerJob(jobs, ban.BuildExpireJob(
+			time.Duration(config.BanExpiryInterval)*time.Second,
+			config.BanExpiryBatchSize,
+			ban.BuildExpireFunc(config.BanExpiryBatchSize, bans.liftBanFunc, bans.storage.ListExpiredBans),
+		))
+	}
+
+	go jobs.Run(ctx, logScheduledJob)
+
+	// Every storage holding the player's data is erased or anonymized, including the outboxes and their archive even when they aren't relayed anymore.
+	// The dry runs count the same records
+	var playerData playerDataFuncs
+	playerData.add(leaderboardStorage.ErasePlayerRanks, leaderboardStorage.AnonymizePlayerRanks, leaderboardStorage.CountPlayerRanks)
+	playerData.add(statisticStorage.ErasePlayerStatistics, statisticStorage.AnonymizePlayerStatistics, statisticStorage.CountPlayerStatistics)
+	playerData.add(questStorage.ErasePlayerQuests, questStorage.AnonymizePlayerQuests, questStorage.CountPlayerQuests)
+	// The changes still queued on the write-behind journal go first, so none is written back to the statistic storage
+	// once it's erased or anonymized
+	if config.StatisticWriteBehindEnabled {
+		playerData.insert(1, writeBehind.erasePlayerFunc, writeBehind.anonymizePlayerFunc, writeBehind.countPlayerDataFunc)
+	}
+	for _, storage := range slices.Compact([]string{config.StatisticStorage, config.QuestStorage}) {
+		if outboxStorage, ok := drivers.outboxStorages[storage]; ok {
+			playerData.add(outboxStorage.ErasePlayerEvents, outboxStorage.AnonymizePlayerEvents, outboxStorage.CountPlayerEvents)
+		}
+	}
+	if scoreHistoryStorage != nil {
+		playerData.add(scoreHistoryStorage.ErasePlayerScores, scoreHistoryStorage.AnonymizePlayerScores, scoreHistoryStorage.CountPlayerScores)
+	}
+	if profiles.storage != nil {
+		playerData.add(profiles.storage.ErasePlayerProfile, profiles.storage.AnonymizePlayerProfile, profiles.storage.CountPlayerProfile)
+	}
+	if accountLinks.storage != nil {
+		playerData.add(accountLinks.storage.ErasePlayerAccounts, accountLinks.storage.AnonymizePlayerAccounts, accountLinks.storage.CountPlayerAccounts)
+	}
+	if bans.storage != nil {
+		playerData.add(bans.storage.ErasePlayerBan, bans.storage.AnonymizePlayerBan, bans.storage.CountPlayerBan)
+	}
+	if shadowFlags.storage != nil {
+		playerData.add(shadowFlags.storage.ErasePlayerShadowFlag, shadowFlags.storage.AnonymizePlayerShadowFlag, shadowFlags.storage.CountPlayerShadowFlag)
+	}
+	if trusts.storage != nil {
+		playerData.add(trusts.storage.ErasePlayerTrustRecord, trusts.storage.AnonymizePlayerTrustRecord, trusts.storage.CountPlayerTrustRecord)
+	}
+	if achievements.storage != nil {
+		playerData.add(achievements.storage.ErasePlayerAchievements, achievements.storage.AnonymizePlayerAchievements, achievements.storage.CountPlayerAchievements)
+	}
+	if titles.storage != nil {
+		playerData.add(titles.storage.ErasePlayerTitles, titles.storage.AnonymizePlayerTitles, titles.storage.CountPlayerTitles)
+	}
+	if friends.storage != nil {
+		playerData.add(friends.storage.ErasePlayerFriendships, friends.storage.AnonymizePlayerFriendships, friends.storage.CountPlayerFriendships)
+	}
+	if presences.storage != nil {
+		playerData.add(presences.storage.ErasePlayerPresence, presences.storage.AnonymizePlayerPresence, presences.storage.CountPlayerPresence)
+	}
+	if activities.storage != nil {
+		playerData.add(activities.storage.ErasePlayerActivities, activities.storage.AnonymizePlayerActivities, activities.storage.CountPlayerActivities)
+	}
+	if quarantines.storage != nil {
+		playerData.add(quarantines.storage.ErasePlayerQuarantinedSubmissions, quarantines.storage.AnonymizePlayerQuarantinedSubmissions, quarantines.storage.CountPlayerQuarantinedSubmissions)
+	}
+	if anomalies.storage != nil {
+		playerData.add(anomalies.storage.ErasePlayerAnomalies, anomalies.storage.AnonymizePlayerAnomalies, anomalies.storage.CountPlayerAnomalies)
+	}
+	if rewards.storage != nil {
+		playerData.add(rewards.storage.ErasePlayerRewardGrants, rewards.storage.AnonymizePlayerRewardGrants, rewards.storage.CountPlayerRewardGrants)
+	}
+
+	ingestions, closeIngestion := newIngestion(ctx, config, drivers, telemetry.consumer, healthChecks, leaderboardStorage, statisticStorage, levels.storage, broker, notifierRankChange, upsertPlayerRankValueFunc, notifierPlayerStatisticProgressionUpdates, updatePlayerStatisticProgressionFunc, &playerData)
+	defer closeIngestion()
+
+	if config.MetricsEnabled {
+		go telemetry.health.Run(
+			ctx,
+			time.Duration(config.MetricsHealthInterval)*time.Second,
+			time.Duration(config.MetricsHealthTimeout)*time.Second,
+			healthChecks,
+			func(dependency string, err error) {
+				zap.Error(err, "dependency health check failed", "dependency", dependency)
+			},
+		)
+	}
+
+	var reportErrorFunc rest.ReportErrorFunc
+	if config.SentryDSN != "" {
+		reporter, err := sentry.New(sentry.Options{
+			DSN:         config.SentryDSN,
+			Environment: config.SentryEnvironment,
+			Release:     config.SentryRelease,
+			SampleRate:  config.SentrySampleRate,
+		})
+		if err != nil {
+			zap.Panic(err, "sentry startup failed")
+		}
+		defer reporter.Flush(5 * time.Second)
+
+		reportErrorFunc = reporter.Report
+	}
+
+	accessLogSampleRates, err := rest.ParseAccessLogSampleRates(config.AccessLogSampleRates)
+	if err != nil {
+		zap.Panic(err, "invalid access log sample rates")
 	}
-	defer postgres.Close()
 
 	restConfig := rest.Config{
 		Port: config.Port,
 
-		CacheSorage:               memcached,
+		CacheSorage:               cache,
 		CacheExpiration:           time.Duration(config.MemcachedCacheExpiration) * time.Second,
 		CacheMiddlewareExpiration: time.Duration(config.MemcachedCacheMiddlewareExpiration) * time.Second,
 
+		RankingCacheEnabled: config.RankingCacheEnabled,
+
+		MetricsHandler:     telemetry.handler,
+		ObserveRequestFunc: telemetry.observeRequestFunc,
+
+		ReportSLOFunc: telemetry.reportSLOFunc,
+
+		TracingEnabled: config.TracingEnabled,
+
+		ReportErrorFunc: reportErrorFunc,
+
+		AccessLogEnabled:     config.AccessLogEnabled,
+		AccessLogSampleRates: accessLogSampleRates,
+
+		FingerprintRegionHeader: config.FingerprintRegionHeader,
+
 		// Auth
-		AuthenticateFunc: auth.BuildAuthenticatorFunc(keycloack.Authenticate),
+		AuthenticateFunc: authenticateFunc,
+
+		// Audit
+		RecordAuditEntryFunc: audit.BuildRecordFunc(auditStorage.RecordAuditEntry),
+
+		// Usage
+		RecordUsageFunc: usages.recordUsageFunc,
+
+		// Quota
+		AllowRequestFunc:          quotas.allowRequestFunc,
+		CheckLeaderboardQuotaFunc: quotas.checkLeaderboardQuotaFunc,
+		CheckStatisticQuotaFunc:   quotas.checkStatisticQuotaFunc,
+
+		// Admin
+		AdminToken:   config.AdminToken,
+		PprofEnabled: config.PprofEnabled,
+
+		GetLogLevelFunc: zap.Level,
+		SetLogLevelFunc: zap.SetLevel,
+
+		ListDeadLettersFunc:   ingestions.listDeadLettersFunc,
+		RequeueDeadLetterFunc: ingestions.requeueDeadLetterFunc,
+		ReplayEventsFunc:      replayEventsFunc,
+
+		ListWebhookDeliveriesFunc: webhooks.listWebhookDeliveriesFunc,
+
+		ReportUsageFunc: usages.reportUsageFunc,
+
+		SummarizeGameFunc: summary.BuildSummarizeFunc(leaderboardStorage.ListLeaderboards, statisticStorage.ListStatistics, questStorage.ListQuests, usages.reportUsageFunc),
+
+		GetQuotaStatusFunc: quotas.getQuotaStatusFunc,
+		SetQuotaFunc:       quotas.setQuotaFunc,
+
+		PurgeGameFunc: purge.BuildPurgeGameFunc(
+			audit.BuildRecordFunc(auditStorage.RecordAuditEntry),
+			leaderboardStorage.PurgeSoftDeletedLeaderboards,
+			statisticStorage.PurgeSoftDeletedStatistics,
+			questStorage.PurgeSoftDeletedQuests,
+		),
+		PreviewPurgeGameFunc: purge.BuildPreviewPurgeGameFunc(
+			leaderboardStorage.ListSoftDeletedLeaderboards,
+			statisticStorage.ListSoftDeletedStatistics,
+			questStorage.ListSoftDeletedQuests,
+		),
+
+		ListAuditEntriesFunc:   audit.BuildListFunc(auditStorage.ListAuditEntries),
+		ExportAuditEntriesFunc: audit.BuildExportFunc(auditStorage.ListAuditEntries),
+
+		MergePlayersFunc: merge.BuildMergePlayersFunc(
+			audit.BuildRecordFunc(auditStorage.RecordAuditEntry),
+			merge.BuildMergeRanksFunc(
+				leaderboardStorage.ListLeaderboards,
+				leaderboardStorage.GetPlayerRank,
+				mergeUpsertPlayerRankValueFunc,
+				leaderboardStorage.DeletePlayerRank,
+				leaderboardStorage.ErasePlayerRanks,
+			),
+			merge.BuildMergeStatisticsFunc(
+				statisticStorage.ListStatistics,
+				statisticStorage.GetPlayerProgression,
+				statistic.BuildUpsertPlayerProgressionFunc(notifierPlayerStatisticProgressionUpdates, mergeUpdatePlayerStatisticProgressionFunc),
+				statisticStorage.DeletePlayerProgression,
+				statisticStorage.ErasePlayerStatistics,
+			),
+			merge.BuildMergeQuestsFunc(
+				questStorage.ListQuests,
+				questStorage.GetPlayerQuestProgression,
+				questStorage.StartQuestForPlayer,
+				questStorage.UpdatePlayerQuestProgression,
+				questStorage.ErasePlayerQuests,
+			),
+		),
 
 		// Leaderboard
-		CreateLeaderboardFunc:              leaderboard.BuildCreateFunc(redis.CreateLeaderboard),
-		GetLeaderboardByIDAndGameIDFunc:    leaderboard.BuildGetByIDAndGameIDFunc(redis.GetLeaderboardByIDAndGameID),
-		DeleteLeaderboardByIDAndGameIDFunc: leaderboard.BuildSoftDeleteFunc(redis.SoftDeleteLeaderboard),
+		CreateLeaderboardFunc:              leaderboard.BuildCreateFunc(notifierLeaderboardLifecycleEvent, leaderboardStorage.CreateLeaderboard),
+		GetLeaderboardByIDAndGameIDFunc:    leaderboard.BuildGetByIDAndGameIDFunc(leaderboardStorage.GetLeaderboardByIDAndGameID),
+		DeleteLeaderboardByIDAndGameIDFunc: leaderboard.BuildSoftDeleteFunc(notifierLeaderboardLifecycleEvent, leaderboardStorage.SoftDeleteLeaderboard),
+
+		UpsertPlayerRankFunc: leaderboard.BuildUpsertPlayerRankFunc(notifierRankChange, upsertPlayerRankValueFunc),
+		RankingFunc:          leaderboard.BuildRankingFunc(getRankingFunc),
+		WatchRankingFunc:     liveRankings.watchRankingFunc,
 
-		UpsertPlayerRankFunc: leaderboard.BuildUpsertPlayerRankFunc(redis.UpsertPlayerRankValue),
-		RankingFunc:          leaderboard.BuildRankingFunc(redis.GetRanking),
+		StreamLeaderboardEventsFunc: liveRankings.streamLeaderboardEventsFunc,
+		PollPlayerRankFunc:          liveRankings.pollPlayerRankFunc,
 
 		// Quest
-		CreateQuestFunc:           quest.BuildCreateQuestFunc(postgres.CreateQuest),
-		GetQuestByIDAndGameIDFunc: quest.BuildGetQuestByIDAndGameIDFunc(postgres.GetQuestByIDAndGameID),
-		SoftDeleteQuestFunc:       quest.BuildSoftDeleteQuestFunc(postgres.SoftDeleteQuestByIDAndGameID),
+		CreateQuestFunc:           quest.BuildCreateQuestFunc(questStorage.CreateQuest),
+		GetQuestByIDAndGameIDFunc: quest.BuildGetQuestByIDAndGameIDFunc(questStorage.GetQuestByIDAndGameID),
+		SoftDeleteQuestFunc:       quest.BuildSoftDeleteQuestFunc(questStorage.SoftDeleteQuestByIDAndGameID),
 
-		StartQuestForPlayerFunc:          quest.BuildStartQuestForPlayerFunc(postgres.StartQuestForPlayer),
-		GetPlayerQuestProgressionFunc:    quest.BuildGetPlayerQuestProgression(postgres.GetPlayerQuestProgression),
-		UpdatePlayerQuestProgressionFunc: quest.BuildUpdatePlayerQuestProgressionFunc(rabbitmq.PlayerQuestProgressionUpdates, postgres.GetPlayerQuestProgression, postgres.UpdatePlayerQuestProgression),
+		StartQuestForPlayerFunc:          startQuestForPlayerFunc,
+		GetPlayerQuestProgressionFunc:    quest.BuildGetPlayerQuestProgression(questStorage.GetPlayerQuestProgression),
+		UpdatePlayerQuestProgressionFunc: quest.BuildUpdatePlayerQuestProgressionFunc(notifierQuestLifecycleEvent, notifierPlayerQuestProgressionUpdates, questStorage.GetPlayerQuestProgression, questStorage.UpdatePlayerQuestProgression),
 
 		// Statistic
-		CreateStatisticFunc:                  statistic.BuildCreateStatisticFunc(mongo.CreateStatistic),
-		GetStatisticByIDAndGameIDFunc:        statistic.BuildGetStatisticByIDAndGameID(mongo.GetStatisticByIDAndGameID),
-		SoftDeleteStatisticByIDAndGameIDFunc: statistic.BuildSoftDeleteStatistic(mongo.SoftDeleteStatistic),
+		CreateStatisticFunc:                  statistic.BuildCreateStatisticFunc(statisticStorage.CreateStatistic),
+		GetStatisticByIDAndGameIDFunc:        statistic.BuildGetStatisticByIDAndGameID(statisticStorage.GetStatisticByIDAndGameID),
+		SoftDeleteStatisticByIDAndGameIDFunc: statistic.BuildSoftDeleteStatistic(statisticStorage.SoftDeleteStatistic),
+		ListStatisticsFunc:                   statistic.BuildListStatisticsFunc(statisticStorage.ListStatistics),
+
+		UpsertPlayerStatisticProgressionFunc: statistic.BuildUpsertPlayerProgressionFunc(notifierPlayerStatisticProgressionUpdates, updatePlayerStatisticProgressionFunc),
+		GetPlayerStatisticProgressionFunc:    statistic.BuildGetPlayerProgression(statisticStorage.GetPlayerProgression),
+
+		// Webhook
+		CreateWebhookFunc:       webhooks.createWebhookFunc,
+		ListWebhooksFunc:        webhooks.listWebhooksFunc,
+		DeleteWebhookFunc:       webhooks.deleteWebhookFunc,
+		EnableWebhookFunc:       webhooks.enableWebhookFunc,
+		UpdateWebhookFilterFunc: webhooks.updateWebhookFilterFunc,
+
+		// Player Profiles
+		CreateProfileFunc: profiles.createProfileFunc,
+		GetProfileFunc:    profiles.getProfileFunc,
+		UpdateProfileFunc: profiles.updateProfileFunc,
+		DeleteProfileFunc: profiles.deleteProfileFunc,
+		ListProfilesFunc:  profiles.listProfilesFunc,
+		// Player Search
+		SearchProfilesFunc: profiles.searchProfilesFunc,
+
+		// Linked Accounts
+		LinkAccountFunc:    accountLinks.linkAccountFunc,
+		UnlinkAccountFunc:  accountLinks.unlinkAccountFunc,
+		ListAccountsFunc:   accountLinks.listAccountsFunc,
+		ResolveAccountFunc: accountLinks.resolveAccountFunc,
+
+		// Bans
+		BanPlayerFunc: bans.banPlayerFunc,
+		LiftBanFunc:   bans.liftBanFunc,
+		GetBanFunc:    bans.getBanFunc,
+		ListBansFunc:  bans.listBansFunc,
+
+		// Device bans
+		BanDeviceFunc:      bans.banDeviceFunc,
+		LiftDeviceBanFunc:  bans.liftDeviceBanFunc,
+		GetDeviceBanFunc:   bans.getDeviceBanFunc,
+		ListDeviceBansFunc: bans.listDeviceBansFunc,
+
+		// Shadow flags
+		FlagPlayerFunc:      shadowFlags.flagPlayerFunc,
+		ClearFlagFunc:       shadowFlags.clearFlagFunc,
+		GetFlagFunc:         shadowFlags.getFlagFunc,
+		ListFlagsFunc:       shadowFlags.listFlagsFunc,
+		MergeShadowRankFunc: shadowFlags.mergeShadowRankFunc,
+
+		// Achievement
+		CreateAchievementFunc:      achievements.createAchievementFunc,
+		GetAchievementFunc:         achievements.getAchievementFunc,
+		ListAchievementsFunc:       achievements.listAchievementsFunc,
+		DeleteAchievementFunc:      achievements.deleteAchievementFunc,
+		ListPlayerAchievementsFunc: achievements.listPlayerAchievementsFunc,
+
+		// Titles
+		CreateTitleFunc:       titles.createTitleFunc,
+		GetTitleFunc:          titles.getTitleFunc,
+		ListTitlesFunc:        titles.listTitlesFunc,
+		DeleteTitleFunc:       titles.deleteTitleFunc,
+		ListPlayerTitlesFunc:  titles.listPlayerTitlesFunc,
+		ListPlayersTitlesFunc: titles.listPlayersTitlesFunc,
+
+		// Friends
+		AddFriendFunc:    friends.addFriendFunc,
+		RemoveFriendFunc: friends.removeFriendFunc,
+		ListFriendsFunc:  friends.listFriendsFunc,
+
+		// Presence
+		HeartbeatFunc:     presences.heartbeatFunc,
+		GetPresenceFunc:   presences.getPresenceFunc,
+		ListPresencesFunc: presences.listPresencesFunc,
+
+		// Signed Submissions
+		IssueSubmissionNonceFunc: signedSubmissions.issueSubmissionNonceFunc,
+		VerifySubmissionFunc:     signedSubmissions.verifySubmissionFunc,
+		GetSigningKeyFunc:        signedSubmissions.getSigningKeyFunc,
+
+		// Delta Rules
+		SetDeltaRuleFunc:    deltaRules.setDeltaRuleFunc,
+		GetDeltaRuleFunc:    deltaRules.getDeltaRuleFunc,
+		RemoveDeltaRuleFunc: deltaRules.removeDeltaRuleFunc,
+
+		// Quarantine
+		ListQuarantinedSubmissionsFunc:   quarantines.listQuarantinedSubmissionsFunc,
+		ReleaseQuarantinedSubmissionFunc: releaseQuarantinedSubmissionFunc,
+		DiscardQuarantinedSubmissionFunc: quarantines.discardQuarantinedSubmissionFunc,
+
+		// Proof Rules
+		SetProofRuleFunc:    proofRules.setProofRuleFunc,
+		GetProofRuleFunc:    proofRules.getProofRuleFunc,
+		RemoveProofRuleFunc: proofRules.removeProofRuleFunc,
+		CheckProofFunc:      proofRules.checkProofFunc,
+		MergePendingFunc:    proofRules.mergePendingFunc,
+
+		// Anomalies
+		ListAnomaliesFunc:  anomalies.listAnomaliesFunc,
+		DismissAnomalyFunc: anomalies.dismissAnomalyFunc,
+
+		// Trust
+		GetTrustFunc: trusts.getTrustFunc,
+
+		// Score Moderation
+		InspectEntryFunc:          moderations.inspectEntryFunc,
+		AdjustEntryFunc:           moderations.adjustEntryFunc,
+		RemoveEntryFunc:           moderations.removeEntryFunc,
+		BulkRemoveEntriesFunc:     moderations.bulkRemoveEntriesFunc,
+		ListModerationActionsFunc: moderations.listModerationActionsFunc,
+		ListDeviceUsageFunc:       moderations.listDeviceUsageFunc,
+		RollbackScoresFunc:        moderations.rollbackScoresFunc,
+
+		// Segments
+		CreateSegmentFunc:        segments.createSegmentFunc,
+		GetSegmentFunc:           segments.getSegmentFunc,
+		ListSegmentsFunc:         segments.listSegmentsFunc,
+		DeleteSegmentFunc:        segments.deleteSegmentFunc,
+		FilterSegmentMembersFunc: segments.filterSegmentMembersFunc,
+		IsSegmentMemberFunc:      segments.isSegmentMemberFunc,
+		ListPlayerSegmentsFunc:   segments.listPlayerSegmentsFunc,
+		SetEligibilityFunc:       segments.setEligibilityFunc,
+		GetEligibilityFunc:       segments.getEligibilityFunc,
+		RemoveEligibilityFunc:    segments.removeEligibilityFunc,
+
+		// Levels
+		CreateLevelCurveFunc: levels.createLevelCurveFunc,
+		GetLevelCurveFunc:    levels.getLevelCurveFunc,
+		ListLevelCurvesFunc:  levels.listLevelCurvesFunc,
+		DeleteLevelCurveFunc: levels.deleteLevelCurveFunc,
+		GetPlayerLevelFunc:   levels.getPlayerLevelFunc,
+		ListPlayerLevelsFunc: levels.listPlayerLevelsFunc,
+
+		// Activity
+		ListActivitiesFunc:        activities.listActivitiesFunc,
+		ListFriendsActivitiesFunc: listFriendsActivitiesFunc,
+
+		// Player Overview
+		GetPlayerOverviewFunc: overview.BuildGetFunc(
+			leaderboardStorage.ListLeaderboards,
+			leaderboardStorage.GetPlayerRank,
+			statisticStorage.ListStatistics,
+			statisticStorage.GetPlayerProgression,
+			questStorage.ListQuests,
+			questStorage.GetPlayerQuestProgression,
+			achievements.listPlayerAchievementsFunc,
+		),
 
-		UpsertPlayerStatisticProgressionFunc: statistic.BuildUpsertPlayerProgressionFunc(rabbitmq.PlayerStatisticProgressionUpdates, mongo.UpdatePlayerStatisticProgression),
-		GetPlayerStatisticProgressionFunc:    statistic.BuildGetPlayerProgression(mongo.GetPlayerProgression),
+		// Reward
+		CreateRewardRuleFunc: rewards.createRewardRuleFunc,
+		ListRewardRulesFunc:  rewards.listRewardRulesFunc,
+		DeleteRewardRuleFunc: rewards.deleteRewardRuleFunc,
+		ListRewardGrantsFunc: rewards.listRewardGrantsFunc,
+		RetryRewardGrantFunc: rewards.retryRewardGrantFunc,
+
+		// Player Notifications
+		WatchPlayerNotificationsFunc: watchPlayerNotificationsFunc,
+
+		// Player Erasure
+		ErasePlayerFunc: privacy.BuildErasePlayerFunc(broker.PlayerErased, audit.BuildRecordFunc(auditStorage.RecordAuditEntry), playerData.erase...),
+
+		// Player Anonymization
+		AnonymizePlayerFunc: privacy.BuildAnonymizePlayerFunc(broker.PlayerAnonymized, audit.BuildRecordFunc(auditStorage.RecordAuditEntry), playerData.anonymize...),
+
+		// Player Erasure and Anonymization Dry Runs
+		PreviewPlayerDataFunc: privacy.BuildPreviewPlayerDataFunc(playerData.count...),
+	}
+
+	if *devMode {
+		restConfig.DevGameID = devGameID
+	}
+
+	// Served alongside the TCP listener for the mobile clients on lossy networks, where QUIC recovers faster from packet loss
+	if config.HTTP3Enabled {
+		if config.HTTP3CertFile == "" || config.HTTP3KeyFile == "" {
+			zap.Panic(errors.New("HTTP3_CERT_FILE and HTTP3_KEY_FILE are required by HTTP/3"), "invalid http3 setup")
+		}
+
+		restConfig.HTTP3Port = config.HTTP3Port
+		restConfig.HTTP3CertFile = config.HTTP3CertFile
+		restConfig.HTTP3KeyFile = config.HTTP3KeyFile
+	}
+
+	if config.GraphQLEnabled {
+		restConfig.ExecuteGraphQLFunc = graphql.BuildExecuteFunc(graphql.Config{
+			GetLeaderboardByIDAndGameIDFunc: restConfig.GetLeaderboardByIDAndGameIDFunc,
+			GetPlayerRankFunc:               leaderboard.BuildGetPlayerRankFunc(shadowFlags.getOwnPlayerRankFunc),
+
+			GetStatisticByIDAndGameIDFunc:     restConfig.GetStatisticByIDAndGameIDFunc,
+			GetPlayerStatisticProgressionFunc: restConfig.GetPlayerStatisticProgressionFunc,
+
+			GetQuestByIDAndGameIDFunc:     restConfig.GetQuestByIDAndGameIDFunc,
+			GetPlayerQuestProgressionFunc: restConfig.GetPlayerQuestProgressionFunc,
+		})
+	}
+
+	// Served alongside the REST API with the same funcs, for the internal services like the matchmaker and game servers
+	if config.GRPCEnabled {
+		grpcConfig := grpcapi.Config{
+			Port: config.GRPCPort,
+
+			AuthenticateFunc: restConfig.AuthenticateFunc,
+
+			GetLeaderboardByIDAndGameIDFunc: restConfig.GetLeaderboardByIDAndGameIDFunc,
+			UpsertPlayerRankFunc:            restConfig.UpsertPlayerRankFunc,
+			RankingFunc:                     restConfig.RankingFunc,
+			IngestBatchSize:                 config.GRPCIngestBatchSize,
+
+			GetStatisticByIDAndGameIDFunc:        restConfig.GetStatisticByIDAndGameIDFunc,
+			UpsertPlayerStatisticProgressionFunc: restConfig.UpsertPlayerStatisticProgressionFunc,
+			GetPlayerStatisticProgressionFunc:    restConfig.GetPlayerStatisticProgressionFunc,
+		}
+
+		go func() {
+			if err := grpcapi.Execute(grpcConfig); err != nil {
+				zap.Panic(err, "grpc execution failed")
+			}
+		}()
+	}
+
+	if mqttBridge != nil {
+		submissions := mqttapi.Submissions{
+			GetLeaderboardByIDAndGameIDFunc: restConfig.GetLeaderboardByIDAndGameIDFunc,
+			UpsertPlayerRankFunc:            restConfig.UpsertPlayerRankFunc,
+
+			GetStatisticByIDAndGameIDFunc:        restConfig.GetStatisticByIDAndGameIDFunc,
+			UpsertPlayerStatisticProgressionFunc: restConfig.UpsertPlayerStatisticProgressionFunc,
+		}
+
+		go func() {
+			if err := mqttBridge.Serve(ctx, submissions); err != nil {
+				zap.Panic(err, "mqtt bridge execution failed")
+			}
+		}()
 	}
+
 	if err := rest.Execute(restConfig); err != nil {
 		zap.Panic(err, "api execution failed")
 	}
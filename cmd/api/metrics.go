@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/controller/rest"
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+)
+
+// Records the messages of each consumed batch as processed, or as retried when they must be consumed again
+func observeProcessBatch(consumerMetrics *metrics.Consumer, broker string, processBatchFunc ingestion.ProcessBatchFunc) ingestion.ProcessBatchFunc {
+	return func(ctx context.Context, msgs []ingestion.Message) []error {
+		start := time.Now()
+		errs := processBatchFunc(ctx, msgs)
+
+		var retried int
+		for _, err := range errs {
+			if err != nil {
+				retried++
+			}
+		}
+
+		consumerMetrics.Observe(broker, len(msgs)-retried, retried, time.Since(start))
+		return errs
+	}
+}
+
+// The metrics exposed by the API, each left nil while the metrics are disabled
+type apiMetrics struct {
+	handler            http.Handler
+	observeRequestFunc rest.ObserveRequestFunc
+	reportSLOFunc      rest.ReportSLOFunc
+	storage            *metrics.Storage
+	consumer           *metrics.Consumer
+	health             *metrics.Health
+	usage              *metrics.Usage
+}
+
+func newMetrics(config Config) (apiMetrics, error) {
+	if !config.MetricsEnabled {
+		return apiMetrics{}, nil
+	}
+
+	sloTargets, err := metrics.ParseSLOTargets(config.SLOTargets)
+	if err != nil {
+		return apiMetrics{}, err
+	}
+
+	var (
+		registry    = metrics.NewRegistry()
+		httpMetrics = metrics.NewHTTP(registry)
+		sloMetrics  = metrics.NewSLO(registry, time.Duration(config.SLOWindow)*time.Second, sloTargets)
+		m           = apiMetrics{
+			handler:  metrics.Handler(registry),
+			storage:  metrics.NewStorage(registry),
+			consumer: metrics.NewConsumer(registry),
+			health:   metrics.NewHealth(registry),
+		}
+	)
+	m.observeRequestFunc = func(ctx context.Context, method, route string, status int, duration time.Duration) {
+		httpMetrics.Observe(ctx, method, route, status, duration)
+		sloMetrics.Observe(ctx, method, route, status, duration)
+	}
+	m.reportSLOFunc = sloMetrics.Report
+
+	// Labelled by game, so only exposed when the usage is tracked
+	if config.UsageEnabled {
+		m.usage = metrics.NewUsage(registry)
+	}
+
+	return m, nil
+}
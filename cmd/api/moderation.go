@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/moderation"
+)
+
+type moderationFuncs struct {
+	inspectEntryFunc          moderation.InspectFunc
+	adjustEntryFunc           moderation.AdjustFunc
+	removeEntryFunc           moderation.RemoveFunc
+	bulkRemoveEntriesFunc     moderation.BulkRemoveFunc
+	listModerationActionsFunc moderation.ListFunc
+	listDeviceUsageFunc       moderation.ListDeviceUsageFunc
+	rollbackScoresFunc        moderation.RollbackFunc
+}
+
+// The moderators set and remove the entries on the rankings directly, so their actions skip the score history, the
+// rate limit and the rest of the submission checks. The bulk removal finds the entries on the score history, as does
+// the device usage with the submissions' fingerprint and the rollback recomputes the entries from it
+func newModeration(config Config, drivers storageDrivers, leaderboardStorage leaderboardStorage, auditStorage auditStorage, scoreHistoryStorage scoreHistoryStorage) moderationFuncs {
+	var (
+		inspectEntryFunc          moderation.InspectFunc
+		adjustEntryFunc           moderation.AdjustFunc
+		removeEntryFunc           moderation.RemoveFunc
+		bulkRemoveEntriesFunc     moderation.BulkRemoveFunc
+		listModerationActionsFunc moderation.ListFunc
+		listDeviceUsageFunc       moderation.ListDeviceUsageFunc
+		rollbackScoresFunc        moderation.RollbackFunc
+	)
+	if config.ModerationEnabled {
+		moderationStorage, ok := drivers.moderationStorages[config.ModerationStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.ModerationStorage), "invalid moderation storage")
+		}
+
+		recordAuditEntryFunc := audit.BuildRecordFunc(auditStorage.RecordAuditEntry)
+		inspectEntryFunc = moderation.BuildInspectFunc(leaderboardStorage.GetLeaderboardByIDAndGameID, leaderboardStorage.GetPlayerRank, moderationStorage.ListEntryModerationActions)
+		adjustEntryFunc = moderation.BuildAdjustFunc(
+			recordAuditEntryFunc,
+			leaderboardStorage.GetLeaderboardByIDAndGameID,
+			leaderboardStorage.GetPlayerRank,
+			leaderboardStorage.DeletePlayerRank,
+			leaderboardStorage.UpsertPlayerRankValue,
+			moderationStorage.CreateModerationAction,
+		)
+		removeEntryFunc = moderation.BuildRemoveFunc(
+			recordAuditEntryFunc,
+			leaderboardStorage.GetLeaderboardByIDAndGameID,
+			leaderboardStorage.GetPlayerRank,
+			leaderboardStorage.DeletePlayerRank,
+			moderationStorage.CreateModerationAction,
+		)
+		listModerationActionsFunc = moderation.BuildListFunc(moderationStorage.ListModerationActions)
+
+		if scoreHistoryStorage != nil {
+			bulkRemoveEntriesFunc = moderation.BuildBulkRemoveFunc(
+				recordAuditEntryFunc,
+				scoreHistoryStorage.ListScoresBySource,
+				leaderboardStorage.GetLeaderboardByIDAndGameID,
+				leaderboardStorage.GetPlayerRank,
+				leaderboardStorage.DeletePlayerRank,
+				moderationStorage.CreateModerationAction,
+			)
+			listDeviceUsageFunc = moderation.BuildListDeviceUsageFunc(scoreHistoryStorage.ListDeviceUsage)
+			rollbackScoresFunc = moderation.BuildRollbackFunc(
+				recordAuditEntryFunc,
+				leaderboardStorage.GetLeaderboardByIDAndGameID,
+				scoreHistoryStorage.ListScores,
+				leaderboardStorage.GetPlayerRank,
+				leaderboardStorage.DeletePlayerRank,
+				leaderboardStorage.UpsertPlayerRankValue,
+				scoreHistoryStorage.DeleteScores,
+				moderationStorage.CreateModerationAction,
+			)
+		}
+	}
+
+	return moderationFuncs{
+		inspectEntryFunc:          inspectEntryFunc,
+		adjustEntryFunc:           adjustEntryFunc,
+		removeEntryFunc:           removeEntryFunc,
+		bulkRemoveEntriesFunc:     bulkRemoveEntriesFunc,
+		listModerationActionsFunc: listModerationActionsFunc,
+		listDeviceUsageFunc:       listDeviceUsageFunc,
+		rollbackScoresFunc:        rollbackScoresFunc,
+	}
+}
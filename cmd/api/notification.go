@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	mqttapi "github.com/gabapcia/gameblitz/internal/controller/mqtt"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/notification"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Without a backplane, the notifications only reach the players connected to the instance that applied the progression.
+// Like the rank changes, they are best effort. The returned func closes the backplane
+func newLiveNotifications(ctx context.Context, config Config, notifierPlayerStatisticProgressionUpdates statistic.NotifierPlayerProgressionUpdates, notifierQuestLifecycleEvent quest.NotifierLifecycleEvent) (notification.WatchFunc, statistic.NotifierPlayerProgressionUpdates, quest.NotifierLifecycleEvent, func()) {
+	var (
+		watchPlayerNotificationsFunc notification.WatchFunc
+		closeLiveNotifications       = func() {}
+	)
+	if config.LiveNotificationsEnabled {
+		playerTopic := func(gameID, playerID string) string { return gameID + "/" + playerID }
+
+		notifications, err := newRealtimeHub[notification.Notification](ctx, config, "notifications")
+		if err != nil {
+			zap.Panic(err, "realtime hub startup failed")
+		}
+		closeLiveNotifications = func() { notifications.Close() }
+
+		notifierPlayerNotification := func(ctx context.Context, n notification.Notification) error {
+			if err := notifications.Publish(ctx, playerTopic(n.GameID, n.PlayerID), n); err != nil {
+				zap.ErrorContext(ctx, err, "unable to publish the player notification", "playerId", n.PlayerID)
+			}
+
+			return nil
+		}
+
+		notifierPlayerStatisticProgressionUpdates = notification.BuildNotifierStatisticProgressionUpdates(notifierPlayerStatisticProgressionUpdates, notifierPlayerNotification)
+		notifierQuestLifecycleEvent = notification.BuildNotifierQuestLifecycleEvent(notifierQuestLifecycleEvent, notifierPlayerNotification)
+
+		watchPlayerNotificationsFunc = notification.BuildWatchFunc(func(ctx context.Context, gameID, playerID string) <-chan notification.Notification {
+			return notifications.Subscribe(ctx, playerTopic(gameID, playerID))
+		})
+	}
+
+	return watchPlayerNotificationsFunc, notifierPlayerStatisticProgressionUpdates, notifierQuestLifecycleEvent, closeLiveNotifications
+}
+
+// The notifications are republished on the players' MQTT topics, on top of the live ones.
+// The bridge is left nil while MQTT is disabled
+func newMQTTBridge(config Config, notifierPlayerStatisticProgressionUpdates statistic.NotifierPlayerProgressionUpdates, notifierQuestLifecycleEvent quest.NotifierLifecycleEvent) (*mqttapi.Bridge, statistic.NotifierPlayerProgressionUpdates, quest.NotifierLifecycleEvent) {
+	var (
+		mqttBridge *mqttapi.Bridge
+		err        error
+	)
+	if config.MQTTEnabled {
+		if config.MQTTBrokerURL == "" {
+			zap.Panic(errors.New("missing broker url"), "mqtt bridge startup failed")
+		}
+
+		// Each instance needs its own client ID, or the broker drops the previous connection
+		clientID := config.MQTTClientID
+		if clientID == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				zap.Panic(err, "mqtt bridge startup failed")
+			}
+			clientID = "gameblitz-" + hostname
+		}
+
+		mqttBridge, err = mqttapi.Connect(mqttapi.Config{
+			BrokerURL:   config.MQTTBrokerURL,
+			ClientID:    clientID,
+			Username:    config.MQTTUsername,
+			Password:    config.MQTTPassword,
+			TopicPrefix: config.MQTTTopicPrefix,
+			SharedGroup: config.MQTTSharedGroup,
+			QoS:         config.MQTTQoS,
+		})
+		if err != nil {
+			zap.Panic(err, "mqtt bridge startup failed")
+		}
+
+		notifierPlayerNotification := func(ctx context.Context, n notification.Notification) error {
+			if err := mqttBridge.NotifyPlayer(ctx, n); err != nil {
+				zap.ErrorContext(ctx, err, "unable to publish the player notification on mqtt", "playerId", n.PlayerID)
+			}
+
+			return nil
+		}
+
+		notifierPlayerStatisticProgressionUpdates = notification.BuildNotifierStatisticProgressionUpdates(notifierPlayerStatisticProgressionUpdates, notifierPlayerNotification)
+		notifierQuestLifecycleEvent = notification.BuildNotifierQuestLifecycleEvent(notifierQuestLifecycleEvent, notifierPlayerNotification)
+	}
+
+	return mqttBridge, notifierPlayerStatisticProgressionUpdates, notifierQuestLifecycleEvent
+}
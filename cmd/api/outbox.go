@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/outbox"
+)
+
+// Relays the events the storages record on their outbox to the broker until the context is canceled, and replays the archived ones
+func newOutboxRelays(ctx context.Context, config Config, drivers storageDrivers, broker broker) outbox.ReplayFunc {
+	var replayEventsFunc outbox.ReplayFunc
+	if config.OutboxEnabled {
+		relayStorages := make(map[string]outboxStorage)
+		for _, storage := range []string{config.StatisticStorage, config.QuestStorage} {
+			outboxStorage, ok := drivers.outboxStorages[storage]
+			if !ok {
+				zap.Panic(fmt.Errorf("storage %q does not support the outbox", storage), "invalid outbox setup")
+			}
+
+			relayStorages[storage] = outboxStorage
+		}
+
+		replayFuncs := make([]outbox.ReplayFunc, 0, len(relayStorages))
+		for storage, outboxStorage := range relayStorages {
+			var archiveEventFunc outbox.StorageArchiveEventFunc
+			if config.OutboxArchive {
+				archiveEventFunc = outboxStorage.ArchiveOutboxEvent
+
+				replayFuncs = append(replayFuncs, outbox.BuildReplayFunc(
+					config.OutboxBatchSize,
+					outboxStorage.ListArchivedOutboxEvents,
+					broker.PlayerStatisticProgressionUpdates,
+					broker.PlayerQuestProgressionUpdates,
+				))
+			}
+
+			relayFunc := outbox.BuildRelayFunc(
+				config.OutboxBatchSize,
+				outboxStorage.ListPendingOutboxEvents,
+				outboxStorage.DeleteOutboxEvent,
+				archiveEventFunc,
+				broker.PlayerStatisticProgressionUpdates,
+				broker.PlayerQuestProgressionUpdates,
+			)
+			go func() {
+				ticker := time.NewTicker(time.Duration(config.OutboxRelayInterval) * time.Second)
+				defer ticker.Stop()
+
+				for {
+					published, err := relayFunc(ctx)
+					if err != nil {
+						zap.Error(err, "outbox relay failed", "storage", storage, "published", published)
+					}
+
+					// A full batch means more events may be waiting, so they are relayed right away
+					if err == nil && published == config.OutboxBatchSize {
+						continue
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+		}
+
+		// Each storage archives the events of its own domains, so they are replayed one storage after the other
+		if len(replayFuncs) > 0 {
+			replayEventsFunc = func(ctx context.Context, filter outbox.ReplayFilter) (int, error) {
+				var replayed int
+				for _, replayFunc := range replayFuncs {
+					n, err := replayFunc(ctx, filter)
+					replayed += n
+					if err != nil {
+						return replayed, err
+					}
+				}
+
+				return replayed, nil
+			}
+		}
+	}
+
+	return replayEventsFunc
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/player"
+)
+
+type profileFuncs struct {
+	storage            profileStorage
+	createProfileFunc  player.CreateProfileFunc
+	getProfileFunc     player.GetProfileFunc
+	updateProfileFunc  player.UpdateProfileFunc
+	deleteProfileFunc  player.DeleteProfileFunc
+	listProfilesFunc   player.ListProfilesFunc
+	searchProfilesFunc player.SearchProfilesFunc
+}
+
+func newProfiles(config Config, drivers storageDrivers) profileFuncs {
+	var (
+		ok                 bool
+		profileStorage     profileStorage
+		createProfileFunc  player.CreateProfileFunc
+		getProfileFunc     player.GetProfileFunc
+		updateProfileFunc  player.UpdateProfileFunc
+		deleteProfileFunc  player.DeleteProfileFunc
+		listProfilesFunc   player.ListProfilesFunc
+		searchProfilesFunc player.SearchProfilesFunc
+	)
+	if config.ProfilesEnabled {
+		if profileStorage, ok = drivers.profileStorages[config.ProfileStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.ProfileStorage), "invalid profile storage")
+		}
+
+		createProfileFunc = player.BuildCreateProfileFunc(profileStorage.CreateProfile)
+		getProfileFunc = player.BuildGetProfileFunc(profileStorage.GetProfile)
+		updateProfileFunc = player.BuildUpdateProfileFunc(profileStorage.UpdateProfile)
+		deleteProfileFunc = player.BuildDeleteProfileFunc(profileStorage.DeleteProfile)
+		listProfilesFunc = player.BuildListProfilesFunc(profileStorage.ListProfiles)
+		searchProfilesFunc = player.BuildSearchProfilesFunc(profileStorage.SearchProfiles)
+	}
+
+	return profileFuncs{
+		storage:            profileStorage,
+		createProfileFunc:  createProfileFunc,
+		getProfileFunc:     getProfileFunc,
+		updateProfileFunc:  updateProfileFunc,
+		deleteProfileFunc:  deleteProfileFunc,
+		listProfilesFunc:   listProfilesFunc,
+		searchProfilesFunc: searchProfilesFunc,
+	}
+}
+
+type accountLinkFuncs struct {
+	storage            accountLinkStorage
+	linkAccountFunc    player.LinkAccountFunc
+	unlinkAccountFunc  player.UnlinkAccountFunc
+	listAccountsFunc   player.ListAccountsFunc
+	resolveAccountFunc player.ResolveAccountFunc
+}
+
+func newAccountLinks(config Config, drivers storageDrivers) accountLinkFuncs {
+	var (
+		ok                 bool
+		accountLinkStorage accountLinkStorage
+		linkAccountFunc    player.LinkAccountFunc
+		unlinkAccountFunc  player.UnlinkAccountFunc
+		listAccountsFunc   player.ListAccountsFunc
+		resolveAccountFunc player.ResolveAccountFunc
+	)
+	if config.AccountLinksEnabled {
+		if accountLinkStorage, ok = drivers.accountLinkStorages[config.AccountLinkStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.AccountLinkStorage), "invalid account link storage")
+		}
+
+		linkAccountFunc = player.BuildLinkAccountFunc(accountLinkStorage.LinkAccount)
+		unlinkAccountFunc = player.BuildUnlinkAccountFunc(accountLinkStorage.UnlinkAccount)
+		listAccountsFunc = player.BuildListAccountsFunc(accountLinkStorage.ListAccounts)
+		resolveAccountFunc = player.BuildResolveAccountFunc(accountLinkStorage.GetAccount)
+	}
+
+	return accountLinkFuncs{
+		storage:            accountLinkStorage,
+		linkAccountFunc:    linkAccountFunc,
+		unlinkAccountFunc:  unlinkAccountFunc,
+		listAccountsFunc:   listAccountsFunc,
+		resolveAccountFunc: resolveAccountFunc,
+	}
+}
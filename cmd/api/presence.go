@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/presence"
+)
+
+type presenceFuncs struct {
+	storage           presenceStorage
+	heartbeatFunc     presence.HeartbeatFunc
+	getPresenceFunc   presence.GetFunc
+	listPresencesFunc presence.ListFunc
+}
+
+func newPresences(config Config, drivers storageDrivers) presenceFuncs {
+	var (
+		ok                bool
+		err               error
+		presenceStorage   presenceStorage
+		heartbeatFunc     presence.HeartbeatFunc
+		getPresenceFunc   presence.GetFunc
+		listPresencesFunc presence.ListFunc
+	)
+	if config.PresenceEnabled {
+		if presenceStorage, ok = drivers.presenceStorages[config.PresenceStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.PresenceStorage), "invalid presence storage")
+		}
+
+		heartbeatFunc, err = presence.BuildHeartbeatFunc(
+			time.Duration(config.PresenceTimeout)*time.Second,
+			time.Duration(config.PresenceRetention)*time.Second,
+			presenceStorage.RecordHeartbeat,
+		)
+		if err != nil {
+			zap.Panic(err, "invalid presence setup")
+		}
+
+		getPresenceFunc = presence.BuildGetFunc(presenceStorage.ListPresences)
+		listPresencesFunc = presence.BuildListFunc(presenceStorage.ListPresences)
+	}
+
+	return presenceFuncs{
+		storage:           presenceStorage,
+		heartbeatFunc:     heartbeatFunc,
+		getPresenceFunc:   getPresenceFunc,
+		listPresencesFunc: listPresencesFunc,
+	}
+}
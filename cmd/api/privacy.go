@@ -0,0 +1,28 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+)
+
+// The storage funcs erasing, anonymizing and counting the player's data, one of each for every storage holding it
+type playerDataFuncs struct {
+	erase     []privacy.StorageErasePlayerFunc
+	anonymize []privacy.StorageAnonymizePlayerFunc
+	count     []privacy.StorageCountPlayerDataFunc
+}
+
+// Adds a storage holding the player's data after the ones already added
+func (f *playerDataFuncs) add(erase privacy.StorageErasePlayerFunc, anonymize privacy.StorageAnonymizePlayerFunc, count privacy.StorageCountPlayerDataFunc) {
+	f.erase = append(f.erase, erase)
+	f.anonymize = append(f.anonymize, anonymize)
+	f.count = append(f.count, count)
+}
+
+// Adds a storage holding the player's data at the given position, ahead of the ones after it
+func (f *playerDataFuncs) insert(i int, erase privacy.StorageErasePlayerFunc, anonymize privacy.StorageAnonymizePlayerFunc, count privacy.StorageCountPlayerDataFunc) {
+	f.erase = slices.Insert(f.erase, i, erase)
+	f.anonymize = slices.Insert(f.anonymize, i, anonymize)
+	f.count = slices.Insert(f.count, i, count)
+}
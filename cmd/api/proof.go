@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/proof"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+)
+
+type proofRuleFuncs struct {
+	setProofRuleFunc    proof.SetRuleFunc
+	getProofRuleFunc    proof.GetRuleFunc
+	removeProofRuleFunc proof.RemoveRuleFunc
+	checkProofFunc      proof.CheckFunc
+	mergePendingFunc    proof.MergePendingFunc
+}
+
+// The REST submissions landing on the top positions of a leaderboard with a proof rule are held for a moderator's approval,
+// before any other check, and released the same way as the ones held by the delta rules
+func newProofRules(config Config, drivers storageDrivers, leaderboardStorage leaderboardStorage, quarantineStorage quarantineStorage, holdSubmissionFunc quarantine.HoldFunc) proofRuleFuncs {
+	var (
+		setProofRuleFunc    proof.SetRuleFunc
+		getProofRuleFunc    proof.GetRuleFunc
+		removeProofRuleFunc proof.RemoveRuleFunc
+		checkProofFunc      proof.CheckFunc
+		mergePendingFunc    proof.MergePendingFunc
+	)
+	if config.ProofRulesEnabled {
+		proofRuleStorage, ok := drivers.proofRuleStorages[config.ProofRuleStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.ProofRuleStorage), "invalid proof rule storage")
+		}
+
+		setProofRuleFunc = proof.BuildSetRuleFunc(leaderboard.BuildGetByIDAndGameIDFunc(leaderboardStorage.GetLeaderboardByIDAndGameID), proofRuleStorage.SetProofRule)
+		getProofRuleFunc = proof.BuildGetRuleFunc(proofRuleStorage.GetProofRule)
+		removeProofRuleFunc = proof.BuildRemoveRuleFunc(proofRuleStorage.DeleteProofRule)
+		checkProofFunc = proof.BuildCheckFunc(
+			proofRuleStorage.GetProofRule,
+			leaderboardStorage.GetPlayerRank,
+			leaderboardStorage.GetRanking,
+			holdSubmissionFunc,
+		)
+		mergePendingFunc = proof.BuildMergePendingFunc(quarantineStorage.ListQuarantinedSubmissions, leaderboardStorage.GetPlayerRank, leaderboardStorage.GetRanking)
+	}
+
+	return proofRuleFuncs{
+		setProofRuleFunc:    setProofRuleFunc,
+		getProofRuleFunc:    getProofRuleFunc,
+		removeProofRuleFunc: removeProofRuleFunc,
+		checkProofFunc:      checkProofFunc,
+		mergePendingFunc:    mergePendingFunc,
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+)
+
+type quarantineFuncs struct {
+	storage                          quarantineStorage
+	listQuarantinedSubmissionsFunc   quarantine.ListFunc
+	discardQuarantinedSubmissionFunc quarantine.DiscardFunc
+}
+
+// The submissions held for review by the delta and proof rules share the same review list
+func newQuarantine(config Config, drivers storageDrivers) (quarantineFuncs, quarantine.HoldFunc) {
+	var (
+		ok                               bool
+		quarantineStorage                quarantineStorage
+		holdSubmissionFunc               quarantine.HoldFunc
+		listQuarantinedSubmissionsFunc   quarantine.ListFunc
+		discardQuarantinedSubmissionFunc quarantine.DiscardFunc
+	)
+	if config.DeltaRulesEnabled || config.ProofRulesEnabled {
+		if quarantineStorage, ok = drivers.quarantineStorages[config.QuarantineStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.QuarantineStorage), "invalid quarantine storage")
+		}
+
+		holdSubmissionFunc = quarantine.BuildHoldFunc(quarantineStorage.CreateQuarantinedSubmission)
+		listQuarantinedSubmissionsFunc = quarantine.BuildListFunc(quarantineStorage.ListQuarantinedSubmissions)
+		discardQuarantinedSubmissionFunc = quarantine.BuildDiscardFunc(quarantineStorage.DeleteQuarantinedSubmission)
+	}
+
+	return quarantineFuncs{
+		storage:                          quarantineStorage,
+		listQuarantinedSubmissionsFunc:   listQuarantinedSubmissionsFunc,
+		discardQuarantinedSubmissionFunc: discardQuarantinedSubmissionFunc,
+	}, holdSubmissionFunc
+}
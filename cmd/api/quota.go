@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/quota"
+)
+
+type quotaFuncs struct {
+	allowRequestFunc          quota.AllowFunc
+	checkLeaderboardQuotaFunc quota.CheckFunc
+	checkStatisticQuotaFunc   quota.CheckFunc
+	getQuotaStatusFunc        quota.StatusFunc
+	setQuotaFunc              quota.SetFunc
+}
+
+// The quotas are cached by each instance, so a change only reaches the other instances once their cached quota expires
+func newQuotas(config Config, drivers storageDrivers, leaderboardStorage leaderboardStorage, statisticStorage statisticStorage) quotaFuncs {
+	var (
+		allowRequestFunc          quota.AllowFunc
+		checkLeaderboardQuotaFunc quota.CheckFunc
+		checkStatisticQuotaFunc   quota.CheckFunc
+		getQuotaStatusFunc        quota.StatusFunc
+		setQuotaFunc              quota.SetFunc
+	)
+	if config.QuotaEnabled {
+		quotaStorage, ok := drivers.quotaStorages[config.QuotaStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.QuotaStorage), "invalid quota storage")
+		}
+
+		getQuotaFunc := quota.BuildGetFunc(quota.Quota{
+			RequestsPerMinute: config.QuotaDefaultRequestsPerMinute,
+			MaxLeaderboards:   config.QuotaDefaultMaxLeaderboards,
+			MaxStatistics:     config.QuotaDefaultMaxStatistics,
+		}, quotaStorage.GetQuota)
+
+		limiter := quota.NewLimiter(time.Duration(config.QuotaCacheTTL)*time.Second, getQuotaFunc)
+		allowRequestFunc = limiter.Allow
+
+		checkLeaderboardQuotaFunc = quota.BuildCheckLeaderboardsFunc(getQuotaFunc, leaderboardStorage.ListLeaderboards)
+		checkStatisticQuotaFunc = quota.BuildCheckStatisticsFunc(getQuotaFunc, statisticStorage.ListStatistics)
+		getQuotaStatusFunc = quota.BuildStatusFunc(getQuotaFunc, leaderboardStorage.ListLeaderboards, statisticStorage.ListStatistics)
+
+		// The instance handling the change applies it right away, the others once their cached quota expires
+		buildSetQuotaFunc := quota.BuildSetFunc(quotaStorage.SetQuota)
+		setQuotaFunc = func(ctx context.Context, q quota.Quota) (quota.Quota, error) {
+			q, err := buildSetQuotaFunc(ctx, q)
+			if err == nil {
+				limiter.Forget(q.GameID)
+			}
+
+			return q, err
+		}
+	}
+
+	return quotaFuncs{
+		allowRequestFunc:          allowRequestFunc,
+		checkLeaderboardQuotaFunc: checkLeaderboardQuotaFunc,
+		checkStatisticQuotaFunc:   checkStatisticQuotaFunc,
+		getQuotaStatusFunc:        getQuotaStatusFunc,
+		setQuotaFunc:              setQuotaFunc,
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+)
+
+// Keeps what's derived from the rankings, like their cached tops and top views, up to date on every ranking write, whichever feature
+// makes it, so the bans, moderation and rollbacks show up there right away
+type rankingTopsStorage struct {
+	leaderboardStorage
+	upsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc
+	deletePlayerRankFunc      leaderboard.StorageDeletePlayerRankFunc
+	replaceRankingFunc        leaderboard.StorageReplaceRankingFunc
+	dropGameTopsFunc          leaderboard.DropGameTopsFunc
+}
+
+func newRankingTopsStorage(storage leaderboardStorage) rankingTopsStorage {
+	return rankingTopsStorage{
+		leaderboardStorage:        storage,
+		upsertPlayerRankValueFunc: storage.UpsertPlayerRankValue,
+		deletePlayerRankFunc:      storage.DeletePlayerRank,
+		replaceRankingFunc:        storage.ReplaceRanking,
+	}
+}
+
+func (s rankingTopsStorage) UpsertPlayerRankValue(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+	return s.upsertPlayerRankValueFunc(ctx, lb, playerID, value)
+}
+
+func (s rankingTopsStorage) DeletePlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+	return s.deletePlayerRankFunc(ctx, lb, playerID)
+}
+
+func (s rankingTopsStorage) ReplaceRanking(ctx context.Context, lb leaderboard.Leaderboard, ranks []leaderboard.RebuiltRank) error {
+	return s.replaceRankingFunc(ctx, lb, ranks)
+}
+
+// The player's ranks are erased from every leaderboard of the game at once, so all their tops are dropped
+func (s rankingTopsStorage) ErasePlayerRanks(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	erasure, err := s.leaderboardStorage.ErasePlayerRanks(ctx, gameID, playerID)
+	if err != nil {
+		return erasure, err
+	}
+
+	return erasure, s.dropGameTopsFunc(ctx, gameID)
+}
+
+func (s rankingTopsStorage) AnonymizePlayerRanks(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	anonymization, err := s.leaderboardStorage.AnonymizePlayerRanks(ctx, gameID, playerID, pseudonym)
+	if err != nil {
+		return anonymization, err
+	}
+
+	return anonymization, s.dropGameTopsFunc(ctx, gameID)
+}
+
+// The top positions of each ranking are served from the cache, and dropped as soon as a ranking write may change them,
+// whether it's a submission or e.g. a ban, a moderation or a rollback. The top views are kept the same way.
+// Returns the leaderboard storage keeping them up to date and the ranking reads served from them
+func newRankingTops(config Config, drivers storageDrivers, leaderboardStorage leaderboardStorage) (leaderboardStorage, leaderboard.StorageGetRankingFunc) {
+	var (
+		err            error
+		getRankingFunc leaderboard.StorageGetRankingFunc = leaderboardStorage.GetRanking
+		rankingTops                                      = newRankingTopsStorage(leaderboardStorage)
+		dropTopFuncs   []leaderboard.DropTopFunc
+	)
+	if config.RankingCacheEnabled {
+		rankingCacheStorage, ok := drivers.rankingCacheStorages[config.RankingCacheStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.RankingCacheStorage), "invalid ranking cache storage")
+		}
+
+		rankingCacheConfig := leaderboard.CacheConfig{
+			Depth: config.RankingCacheDepth,
+			TTL:   time.Duration(config.RankingCacheTTL) * time.Second,
+		}
+
+		getRankingFunc, err = leaderboard.BuildCachedRankingFunc(rankingCacheConfig, rankingCacheStorage.GetCachedTop, rankingCacheStorage.CacheTop, getRankingFunc)
+		if err != nil {
+			zap.Panic(err, "invalid ranking cache settings")
+		}
+
+		rankingTops.upsertPlayerRankValueFunc = leaderboard.BuildInvalidateCachedTopFunc(
+			rankingCacheConfig,
+			rankingCacheStorage.GetCachedTopBound,
+			rankingCacheStorage.InvalidateCachedTop,
+			leaderboardStorage.GetPlayerRank,
+			rankingTops.upsertPlayerRankValueFunc,
+		)
+		rankingTops.deletePlayerRankFunc = leaderboard.BuildInvalidateCachedTopOnDeleteFunc(rankingCacheStorage.InvalidateCachedTop, rankingTops.deletePlayerRankFunc)
+		rankingTops.replaceRankingFunc = leaderboard.BuildInvalidateCachedTopOnReplaceFunc(rankingCacheStorage.InvalidateCachedTop, rankingTops.replaceRankingFunc)
+
+		dropTopFuncs = append(dropTopFuncs, rankingCacheStorage.InvalidateCachedTop)
+	}
+
+	// The top view of each ranking is kept up to date by every ranking write, and rebuilt from the ranking once it expires.
+	// The pages within it are served from it, and only the others go through the ranking cache
+	if config.TopViewEnabled {
+		topViewStorage, ok := drivers.topViewStorages[config.TopViewStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.TopViewStorage), "invalid top view storage")
+		}
+
+		topViewConfig := leaderboard.TopViewConfig{
+			Size:    config.TopViewSize,
+			Refresh: time.Duration(config.TopViewRefresh) * time.Second,
+		}
+
+		getRankingFunc, err = leaderboard.BuildTopViewRankingFunc(
+			topViewConfig,
+			topViewStorage.GetTopView,
+			topViewStorage.SaveTopView,
+			leaderboardStorage.GetRanking,
+			getRankingFunc,
+		)
+		if err != nil {
+			zap.Panic(err, "invalid top view settings")
+		}
+
+		rankingTops.upsertPlayerRankValueFunc = leaderboard.BuildUpdateTopViewFunc(
+			topViewConfig,
+			topViewStorage.GetTopView,
+			topViewStorage.SaveTopView,
+			topViewStorage.DropTopView,
+			leaderboardStorage.GetPlayerRank,
+			leaderboardStorage.GetRanking,
+			rankingTops.upsertPlayerRankValueFunc,
+		)
+		rankingTops.deletePlayerRankFunc = leaderboard.BuildRemoveFromTopViewFunc(
+			topViewConfig,
+			topViewStorage.GetTopView,
+			topViewStorage.SaveTopView,
+			topViewStorage.DropTopView,
+			leaderboardStorage.GetRanking,
+			rankingTops.deletePlayerRankFunc,
+		)
+		rankingTops.replaceRankingFunc = leaderboard.BuildDropTopViewOnReplaceFunc(topViewStorage.DropTopView, rankingTops.replaceRankingFunc)
+
+		dropTopFuncs = append(dropTopFuncs, topViewStorage.DropTopView)
+	}
+
+	if len(dropTopFuncs) > 0 {
+		rankingTops.dropGameTopsFunc = leaderboard.BuildDropGameTopsFunc(leaderboardStorage.ListLeaderboards, dropTopFuncs...)
+		return rankingTops, getRankingFunc
+	}
+	return leaderboardStorage, getRankingFunc
+}
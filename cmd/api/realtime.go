@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/realtime"
+	realtimeredis "github.com/gabapcia/gameblitz/internal/infra/realtime/redis"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+// Fans out the events to the live connections, on this instance only or on every instance through a backplane
+type realtimeHub[T any] interface {
+	Publish(ctx context.Context, topic string, event T) error
+	Subscribe(ctx context.Context, topic string) <-chan T
+	Close() error
+}
+
+// Hub without a backplane, reaching only the connections on this instance
+type localHub[T any] struct {
+	*realtime.Hub[T]
+}
+
+func (h localHub[T]) Publish(ctx context.Context, topic string, event T) error {
+	h.Hub.Publish(topic, event)
+	return nil
+}
+
+func (h localHub[T]) Close() error {
+	return nil
+}
+
+// The name tells apart the hubs sharing the backplane
+func newRealtimeHub[T any](ctx context.Context, config Config, name string) (realtimeHub[T], error) {
+	switch config.RealtimeBackplane {
+	case "memory":
+		return localHub[T]{realtime.NewHub[T]()}, nil
+	case "redis":
+		if config.RealtimeRedisURL == "" {
+			return nil, fmt.Errorf("REALTIME_REDIS_URL is required by the %q realtime backplane", config.RealtimeBackplane)
+		}
+
+		return realtimeredis.New[T](ctx, config.RealtimeRedisURL, name)
+	default:
+		return nil, fmt.Errorf("unknown realtime backplane %q", config.RealtimeBackplane)
+	}
+}
+
+type liveRankingFuncs struct {
+	watchRankingFunc            leaderboard.WatchRankingFunc
+	streamLeaderboardEventsFunc leaderboard.StreamEventsFunc
+	pollPlayerRankFunc          leaderboard.PollPlayerRankFunc
+}
+
+// Without a backplane, the rank changes only reach the live ranking watchers, event streams and rank polls connected to the instance that applied them.
+// Pushing them is best effort, so a backplane failure doesn't fail the rank update
+func newLiveRankings(ctx context.Context, config Config, leaderboardStorage leaderboardStorage, getOwnPlayerRankFunc leaderboard.StorageGetPlayerRankFunc) (liveRankingFuncs, leaderboard.NotifierRankChange, func()) {
+	var (
+		closeLiveRankings           = func() {}
+		notifierRankChange          leaderboard.NotifierRankChange
+		watchRankingFunc            leaderboard.WatchRankingFunc
+		streamLeaderboardEventsFunc leaderboard.StreamEventsFunc
+		pollPlayerRankFunc          leaderboard.PollPlayerRankFunc
+	)
+	if config.LiveRankingEnabled {
+		rankChanges, err := newRealtimeHub[leaderboard.RankChange](ctx, config, "rank-changes")
+		if err != nil {
+			zap.Panic(err, "realtime hub startup failed")
+		}
+		closeLiveRankings = func() { rankChanges.Close() }
+
+		notifierRankChange = func(ctx context.Context, change leaderboard.RankChange) error {
+			if err := rankChanges.Publish(ctx, change.LeaderboardID, change); err != nil {
+				zap.ErrorContext(ctx, err, "unable to publish the rank change", "leaderboardId", change.LeaderboardID)
+			}
+
+			return nil
+		}
+
+		watchRankingFunc = leaderboard.BuildWatchRankingFunc(
+			time.Duration(config.LiveRankingInterval)*time.Millisecond,
+			rankChanges.Subscribe,
+			leaderboardStorage.GetRanking,
+			leaderboardStorage.GetPlayerRank,
+		)
+		streamLeaderboardEventsFunc = leaderboard.BuildStreamEventsFunc(rankChanges.Subscribe, leaderboardStorage.GetPlayerRank)
+		pollPlayerRankFunc = leaderboard.BuildPollPlayerRankFunc(rankChanges.Subscribe, getOwnPlayerRankFunc)
+	}
+
+	return liveRankingFuncs{
+		watchRankingFunc:            watchRankingFunc,
+		streamLeaderboardEventsFunc: streamLeaderboardEventsFunc,
+		pollPlayerRankFunc:          pollPlayerRankFunc,
+	}, notifierRankChange, closeLiveRankings
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/service/economy"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/reward"
+)
+
+type rewardFuncs struct {
+	storage              rewardStorage
+	createRewardRuleFunc reward.CreateRuleFunc
+	listRewardRulesFunc  reward.ListRulesFunc
+	deleteRewardRuleFunc reward.DeleteRuleFunc
+	listRewardGrantsFunc reward.ListGrantsFunc
+	retryRewardGrantFunc reward.RetryGrantFunc
+}
+
+// The rewards are granted in the background until the context is canceled, so a slow economy service never holds a progression.
+// The returned func closes the economy service client
+func newRewards(ctx context.Context, config Config, drivers storageDrivers, broker broker, leaderboardStorage leaderboardStorage, questStorage questStorage, getAchievementFunc achievement.GetByIDAndGameIDFunc) (rewardFuncs, func()) {
+	var (
+		ok                   bool
+		closeRewards         = func() {}
+		rewardStorage        rewardStorage
+		createRewardRuleFunc reward.CreateRuleFunc
+		listRewardRulesFunc  reward.ListRulesFunc
+		deleteRewardRuleFunc reward.DeleteRuleFunc
+		listRewardGrantsFunc reward.ListGrantsFunc
+		retryRewardGrantFunc reward.RetryGrantFunc
+	)
+	if config.RewardsEnabled {
+		if rewardStorage, ok = drivers.rewardStorages[config.RewardStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.RewardStorage), "invalid reward storage")
+		}
+
+		var granter rewardGranter = broker
+		if config.RewardGranter == "http" {
+			httpGranter := economy.New(config.RewardGranterURL, config.RewardGranterSecret, time.Duration(config.RewardGranterTimeout)*time.Second)
+			closeRewards = httpGranter.Close
+
+			granter = httpGranter
+		}
+
+		retryDelay := backoff.Config{
+			InitialInterval: time.Duration(config.RewardRetryDelay) * time.Second,
+			MaxInterval:     time.Duration(config.RewardRetryMaxDelay) * time.Second,
+		}
+
+		// The lease outlives the request timeout, so a grant is only claimed again once its attempt is over
+		grantDueFunc, err := reward.BuildGrantDueFunc(
+			config.RewardMaxAttempts,
+			config.RewardBatchSize,
+			2*time.Duration(config.RewardGranterTimeout)*time.Second,
+			func(attempts int) time.Duration { return backoff.Delay(retryDelay, attempts) },
+			granter.GrantRewards,
+			rewardStorage.ClaimDueRewardGrants,
+			rewardStorage.UpdateRewardGrant,
+		)
+		if err != nil {
+			zap.Panic(err, "invalid reward setup")
+		}
+
+		go func() {
+			ticker := time.NewTicker(time.Duration(config.RewardGrantInterval) * time.Second)
+			defer ticker.Stop()
+
+			for {
+				granted, err := grantDueFunc(ctx)
+				if err != nil {
+					zap.Error(err, "reward grant failed", "granted", granted)
+				}
+
+				// A full batch means more grants may be due, so they are sent right away
+				if err == nil && granted == config.RewardBatchSize {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+
+		createRewardRuleFunc = reward.BuildCreateRuleFunc(
+			leaderboard.BuildGetByIDAndGameIDFunc(leaderboardStorage.GetLeaderboardByIDAndGameID),
+			quest.BuildGetQuestByIDAndGameIDFunc(questStorage.GetQuestByIDAndGameID),
+			getAchievementFunc,
+			rewardStorage.CreateRewardRule,
+		)
+		listRewardRulesFunc = reward.BuildListRulesFunc(rewardStorage.ListRewardRules)
+		deleteRewardRuleFunc = reward.BuildDeleteRuleFunc(rewardStorage.DeleteRewardRule)
+		listRewardGrantsFunc = reward.BuildListGrantsFunc(rewardStorage.ListRewardGrants)
+		retryRewardGrantFunc = reward.BuildRetryGrantFunc(rewardStorage.GetRewardGrant, rewardStorage.UpdateRewardGrant)
+	}
+
+	return rewardFuncs{
+		storage:              rewardStorage,
+		createRewardRuleFunc: createRewardRuleFunc,
+		listRewardRulesFunc:  listRewardRulesFunc,
+		deleteRewardRuleFunc: deleteRewardRuleFunc,
+		listRewardGrantsFunc: listRewardGrantsFunc,
+		retryRewardGrantFunc: retryRewardGrantFunc,
+	}, closeRewards
+}
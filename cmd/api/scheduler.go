@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+)
+
+// The recurring jobs run on a single instance at a time, coordinated by their locks
+func newScheduler(config Config, drivers storageDrivers) *scheduler.Scheduler {
+	schedulerLockStorage, ok := drivers.schedulerLockStorages[config.SchedulerLockStorage]
+	if !ok {
+		zap.Panic(fmt.Errorf("unknown storage %q", config.SchedulerLockStorage), "invalid scheduler lock storage")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		zap.Panic(err, "scheduler startup failed")
+	}
+
+	return scheduler.New("gameblitz-"+hostname, schedulerLockStorage.AcquireSchedulerLock, schedulerLockStorage.RecordSchedulerRun)
+}
+
+func registerJob(jobs *scheduler.Scheduler, job scheduler.Job) {
+	if err := jobs.Register(job); err != nil {
+		zap.Panic(err, "invalid scheduled job", "job", job.Name)
+	}
+}
+
+func logScheduledJob(job string, duration time.Duration, err error) {
+	if err != nil {
+		zap.Error(err, "scheduled job failed", "job", job, "durationMs", duration.Milliseconds())
+		return
+	}
+
+	zap.Debug("scheduled job finished", "job", job, "durationMs", duration.Milliseconds())
+}
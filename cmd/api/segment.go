@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/segment"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+type segmentFuncs struct {
+	storage                  segmentStorage
+	createSegmentFunc        segment.CreateFunc
+	getSegmentFunc           segment.GetByIDAndGameIDFunc
+	listSegmentsFunc         segment.ListFunc
+	deleteSegmentFunc        segment.DeleteFunc
+	filterSegmentMembersFunc segment.FilterMembersFunc
+	isSegmentMemberFunc      segment.IsMemberFunc
+	listPlayerSegmentsFunc   segment.ListPlayerSegmentsFunc
+	setEligibilityFunc       segment.SetEligibilityFunc
+	getEligibilityFunc       segment.GetEligibilityFunc
+	removeEligibilityFunc    segment.RemoveEligibilityFunc
+}
+
+// Restricting a leaderboard to a segment rejects the submissions of the other players,
+// before the bans are checked and the scores are recorded on the history
+func newSegments(config Config, drivers storageDrivers, leaderboardStorage leaderboardStorage, statisticStorage statisticStorage, questStorage questStorage, listProfilesFunc player.ListProfilesFunc, upsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc) (segmentFuncs, leaderboard.StorageUpsertPlayerRankValueFunc) {
+	var (
+		ok                       bool
+		segmentStorage           segmentStorage
+		createSegmentFunc        segment.CreateFunc
+		getSegmentFunc           segment.GetByIDAndGameIDFunc
+		listSegmentsFunc         segment.ListFunc
+		deleteSegmentFunc        segment.DeleteFunc
+		filterSegmentMembersFunc segment.FilterMembersFunc
+		isSegmentMemberFunc      segment.IsMemberFunc
+		listPlayerSegmentsFunc   segment.ListPlayerSegmentsFunc
+		setEligibilityFunc       segment.SetEligibilityFunc
+		getEligibilityFunc       segment.GetEligibilityFunc
+		removeEligibilityFunc    segment.RemoveEligibilityFunc
+	)
+	if config.SegmentsEnabled {
+		if segmentStorage, ok = drivers.segmentStorages[config.SegmentStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.SegmentStorage), "invalid segment storage")
+		}
+
+		getPlayerProgressionFunc := statistic.BuildGetPlayerProgression(statisticStorage.GetPlayerProgression)
+		createSegmentFunc = segment.BuildCreateFunc(
+			listProfilesFunc,
+			statistic.BuildGetStatisticByIDAndGameID(statisticStorage.GetStatisticByIDAndGameID),
+			segmentStorage.CreateSegment,
+		)
+		getSegmentFunc = segment.BuildGetByIDAndGameIDFunc(segmentStorage.GetSegment)
+		listSegmentsFunc = segment.BuildListFunc(segmentStorage.ListSegments)
+		deleteSegmentFunc = segment.BuildDeleteFunc(segmentStorage.DeleteSegment)
+		filterSegmentMembersFunc = segment.BuildFilterMembersFunc(listProfilesFunc, getPlayerProgressionFunc, segmentStorage.GetSegment)
+		isSegmentMemberFunc = segment.BuildIsMemberFunc(filterSegmentMembersFunc)
+		listPlayerSegmentsFunc = segment.BuildListPlayerSegmentsFunc(listProfilesFunc, getPlayerProgressionFunc, segmentStorage.ListSegments)
+		setEligibilityFunc = segment.BuildSetEligibilityFunc(
+			leaderboard.BuildGetByIDAndGameIDFunc(leaderboardStorage.GetLeaderboardByIDAndGameID),
+			quest.BuildGetQuestByIDAndGameIDFunc(questStorage.GetQuestByIDAndGameID),
+			segmentStorage.GetSegment,
+			segmentStorage.SetEligibility,
+		)
+		getEligibilityFunc = segment.BuildGetEligibilityFunc(segmentStorage.GetEligibility)
+		removeEligibilityFunc = segment.BuildRemoveEligibilityFunc(segmentStorage.DeleteEligibility)
+
+		upsertPlayerRankValueFunc = segment.BuildRejectIneligibleRankFunc(segmentStorage.GetEligibility, isSegmentMemberFunc, upsertPlayerRankValueFunc)
+	}
+
+	return segmentFuncs{
+		storage:                  segmentStorage,
+		createSegmentFunc:        createSegmentFunc,
+		getSegmentFunc:           getSegmentFunc,
+		listSegmentsFunc:         listSegmentsFunc,
+		deleteSegmentFunc:        deleteSegmentFunc,
+		filterSegmentMembersFunc: filterSegmentMembersFunc,
+		isSegmentMemberFunc:      isSegmentMemberFunc,
+		listPlayerSegmentsFunc:   listPlayerSegmentsFunc,
+		setEligibilityFunc:       setEligibilityFunc,
+		getEligibilityFunc:       getEligibilityFunc,
+		removeEligibilityFunc:    removeEligibilityFunc,
+	}, upsertPlayerRankValueFunc
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/shadow"
+)
+
+type shadowFlagFuncs struct {
+	storage              shadowFlagStorage
+	flagPlayerFunc       shadow.FlagFunc
+	clearFlagFunc        shadow.ClearFunc
+	getFlagFunc          shadow.GetFunc
+	listFlagsFunc        shadow.ListFunc
+	mergeShadowRankFunc  shadow.MergeViewerFunc
+	getOwnPlayerRankFunc leaderboard.StorageGetPlayerRankFunc
+}
+
+// Flagging a player moves their ranks to the shadow copies of the leaderboards, where their submissions go once the bans
+// and segments accept them, so the delta rules, the activity feeds and the score history never see them. The player's
+// own rank reads place the shadow ranks among the others
+func newShadowFlags(config Config, drivers storageDrivers, leaderboardStorage leaderboardStorage, upsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc, releaseUpsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc) (shadowFlagFuncs, leaderboard.StorageUpsertPlayerRankValueFunc, leaderboard.StorageUpsertPlayerRankValueFunc) {
+	var (
+		ok                   bool
+		shadowFlagStorage    shadowFlagStorage
+		flagPlayerFunc       shadow.FlagFunc
+		clearFlagFunc        shadow.ClearFunc
+		getFlagFunc          shadow.GetFunc
+		listFlagsFunc        shadow.ListFunc
+		mergeShadowRankFunc  shadow.MergeViewerFunc
+		getOwnPlayerRankFunc = leaderboard.StorageGetPlayerRankFunc(leaderboardStorage.GetPlayerRank)
+	)
+	if config.ShadowFlagsEnabled {
+		if shadowFlagStorage, ok = drivers.shadowFlagStorages[config.ShadowFlagStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.ShadowFlagStorage), "invalid shadow flag storage")
+		}
+
+		flagPlayerFunc = shadow.BuildFlagFunc(
+			leaderboardStorage.ListLeaderboards,
+			leaderboardStorage.GetPlayerRank,
+			leaderboardStorage.DeletePlayerRank,
+			shadowFlagStorage.CreateShadowFlag,
+			shadowFlagStorage.AddShadowRank,
+		)
+		clearFlagFunc = shadow.BuildClearFunc(
+			leaderboardStorage.GetLeaderboardByIDAndGameID,
+			leaderboardStorage.UpsertPlayerRankValue,
+			shadowFlagStorage.CreateShadowFlag,
+			shadowFlagStorage.DeleteShadowFlag,
+		)
+		getFlagFunc = shadow.BuildGetFunc(shadowFlagStorage.GetShadowFlag)
+		listFlagsFunc = shadow.BuildListFunc(shadowFlagStorage.ListShadowFlags)
+		mergeShadowRankFunc = shadow.BuildMergeViewerFunc(shadowFlagStorage.GetShadowFlag, leaderboardStorage.GetRanking)
+		getOwnPlayerRankFunc = shadow.BuildShowShadowRankFunc(shadowFlagStorage.GetShadowFlag, leaderboardStorage.GetRanking, getOwnPlayerRankFunc)
+
+		upsertPlayerRankValueFunc = shadow.BuildRouteFlaggedFunc(shadowFlagStorage.GetShadowFlag, leaderboardStorage.GetPlayerRank, shadowFlagStorage.SetShadowRank, upsertPlayerRankValueFunc)
+		// The submissions held for their proof before the player was flagged are released to the shadow ranks too
+		releaseUpsertPlayerRankValueFunc = shadow.BuildRouteFlaggedFunc(shadowFlagStorage.GetShadowFlag, leaderboardStorage.GetPlayerRank, shadowFlagStorage.SetShadowRank, releaseUpsertPlayerRankValueFunc)
+	}
+
+	return shadowFlagFuncs{
+		storage:              shadowFlagStorage,
+		flagPlayerFunc:       flagPlayerFunc,
+		clearFlagFunc:        clearFlagFunc,
+		getFlagFunc:          getFlagFunc,
+		listFlagsFunc:        listFlagsFunc,
+		mergeShadowRankFunc:  mergeShadowRankFunc,
+		getOwnPlayerRankFunc: getOwnPlayerRankFunc,
+	}, upsertPlayerRankValueFunc, releaseUpsertPlayerRankValueFunc
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/signing"
+)
+
+type signingFuncs struct {
+	issueSubmissionNonceFunc signing.IssueNonceFunc
+	verifySubmissionFunc     signing.VerifyFunc
+	getSigningKeyFunc        signing.GetGameKeyFunc
+}
+
+// Only the REST submissions are signed, as they are the ones sent by the game clients
+func newSignedSubmissions(config Config, drivers storageDrivers) signingFuncs {
+	var (
+		issueSubmissionNonceFunc signing.IssueNonceFunc
+		verifySubmissionFunc     signing.VerifyFunc
+		getSigningKeyFunc        signing.GetGameKeyFunc
+	)
+	if config.SignedSubmissionsEnabled {
+		nonceStorage, ok := drivers.nonceStorages[config.NonceStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.NonceStorage), "invalid nonce storage")
+		}
+
+		key, err := base64.StdEncoding.DecodeString(config.SubmissionSigningKey)
+		if err != nil {
+			zap.Panic(err, "invalid submission signing key")
+		}
+
+		issueSubmissionNonceFunc, err = signing.BuildIssueNonceFunc(time.Duration(config.SubmissionNonceTTL)*time.Second, nonceStorage.CreateNonce)
+		if err != nil {
+			zap.Panic(err, "invalid signed submissions setup")
+		}
+
+		if verifySubmissionFunc, err = signing.BuildVerifyFunc(key, nonceStorage.ConsumeNonce); err != nil {
+			zap.Panic(err, "invalid signed submissions setup")
+		}
+
+		if getSigningKeyFunc, err = signing.BuildGetGameKeyFunc(key); err != nil {
+			zap.Panic(err, "invalid signed submissions setup")
+		}
+	}
+
+	return signingFuncs{
+		issueSubmissionNonceFunc: issueSubmissionNonceFunc,
+		verifySubmissionFunc:     verifySubmissionFunc,
+		getSigningKeyFunc:        getSigningKeyFunc,
+	}
+}
@@ -0,0 +1,489 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/activity"
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/datachange"
+	"github.com/gabapcia/gameblitz/internal/delta"
+	"github.com/gabapcia/gameblitz/internal/friend"
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/moderation"
+	"github.com/gabapcia/gameblitz/internal/outbox"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/presence"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/proof"
+	"github.com/gabapcia/gameblitz/internal/purge"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/quota"
+	"github.com/gabapcia/gameblitz/internal/reward"
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+	"github.com/gabapcia/gameblitz/internal/segment"
+	"github.com/gabapcia/gameblitz/internal/shadow"
+	"github.com/gabapcia/gameblitz/internal/signing"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/title"
+	"github.com/gabapcia/gameblitz/internal/trust"
+	"github.com/gabapcia/gameblitz/internal/usage"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+type (
+	// Publishers of the domain events
+	eventPublisher interface {
+		PlayerStatisticProgressionUpdates(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error
+		PlayerQuestProgressionUpdates(ctx context.Context, progression quest.PlayerQuestProgression) error
+		DataChange(ctx context.Context, event datachange.Event) error
+		LeaderboardLifecycleEvent(ctx context.Context, event string, lb leaderboard.Leaderboard) error
+		QuestLifecycleEvent(ctx context.Context, event quest.LifecycleEvent) error
+		PlayerErased(ctx context.Context, playerID string, receipt privacy.Receipt) error
+		PlayerAnonymized(ctx context.Context, playerID string, receipt privacy.AnonymizationReceipt) error
+		AchievementUnlocked(ctx context.Context, unlocked achievement.PlayerAchievement) error
+		LevelUp(ctx context.Context, up level.LevelUp) error
+	}
+
+	// Message brokers that can publish the domain events, and the reward grants for the games' economies to apply
+	broker interface {
+		eventPublisher
+		GrantRewards(ctx context.Context, grant reward.Grant) error
+		Ping(ctx context.Context) error
+		Close()
+	}
+
+	// Services that apply the reward grants on the games' economies
+	rewardGranter interface {
+		GrantRewards(ctx context.Context, grant reward.Grant) error
+	}
+
+	// Message brokers that can deliver the ingestion messages
+	ingestionConsumer interface {
+		Consume(ctx context.Context, processBatchFunc ingestion.ProcessBatchFunc) error
+		PublishIngestion(ctx context.Context, msg ingestion.Message) error
+		Backlog(ctx context.Context) ([]ingestion.Backlog, error)
+		Ping(ctx context.Context) error
+		Close()
+	}
+
+	// Cache drivers that can hold the API responses and the resource definitions
+	cacheStorage interface {
+		Get(key string) ([]byte, error)
+		Set(key string, val []byte, exp time.Duration) error
+		Delete(key string) error
+		Reset() error
+		Close() error
+	}
+
+	// Storage drivers that can hold leaderboards and rankings
+	leaderboardStorage interface {
+		CreateLeaderboard(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error)
+		GetLeaderboardByIDAndGameID(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error)
+		SoftDeleteLeaderboard(ctx context.Context, id, gameID string) error
+		UpsertPlayerRankValue(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error
+		GetRanking(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error)
+		GetPlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error)
+		PurgeSoftDeletedLeaderboards(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error)
+		ListSoftDeletedLeaderboards(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error)
+		ListLeaderboards(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error)
+		ListLeaderboardsScheduledBetween(ctx context.Context, from, to time.Time) ([]leaderboard.Leaderboard, error)
+		ErasePlayerRanks(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerRanks(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerRanks(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+		ReplaceRanking(ctx context.Context, lb leaderboard.Leaderboard, ranks []leaderboard.RebuiltRank) error
+		DeletePlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error
+	}
+
+	// Storage drivers that can hold statistics and players progression
+	statisticStorage interface {
+		CreateStatistic(ctx context.Context, data statistic.NewStatisticData) (statistic.Statistic, error)
+		GetStatisticByIDAndGameID(ctx context.Context, id, gameID string) (statistic.Statistic, error)
+		ListStatistics(ctx context.Context, gameID string) ([]statistic.Statistic, error)
+		SoftDeleteStatistic(ctx context.Context, id, gameID string) error
+		UpdatePlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error)
+		GetPlayerProgression(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error)
+		DeletePlayerProgression(ctx context.Context, statisticID, playerID string) error
+		PurgeSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error)
+		ListSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error)
+		ErasePlayerStatistics(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerStatistics(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerStatistics(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold quests and players progression
+	questStorage interface {
+		CreateQuest(ctx context.Context, data quest.NewQuestData) (quest.Quest, error)
+		GetQuestByIDAndGameID(ctx context.Context, id, gameID string) (quest.Quest, error)
+		SoftDeleteQuestByIDAndGameID(ctx context.Context, id, gameID string) error
+		StartQuestForPlayer(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error)
+		GetPlayerQuestProgression(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error)
+		UpdatePlayerQuestProgression(ctx context.Context, q quest.Quest, tasksCompleted []string, playerID string) (quest.PlayerQuestProgression, error)
+		PurgeSoftDeletedQuests(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error)
+		ListSoftDeletedQuests(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error)
+		ListQuests(ctx context.Context, gameID string) ([]quest.Quest, error)
+		ListQuestsEndedBetween(ctx context.Context, from, to time.Time) ([]quest.Quest, error)
+		ListPlayersWithQuestUnfinished(ctx context.Context, q quest.Quest) ([]string, error)
+		ErasePlayerQuests(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerQuests(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerQuests(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can record events on an outbox, within the transaction of the state change
+	outboxStorage interface {
+		ListPendingOutboxEvents(ctx context.Context, limit int) ([]outbox.Event, error)
+		DeleteOutboxEvent(ctx context.Context, id string) error
+		ArchiveOutboxEvent(ctx context.Context, event outbox.Event) error
+		ListArchivedOutboxEvents(ctx context.Context, filter outbox.ReplayFilter, after outbox.Event, limit int) ([]outbox.Event, error)
+		ErasePlayerEvents(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerEvents(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerEvents(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the audit log
+	auditStorage interface {
+		RecordAuditEntry(ctx context.Context, data audit.NewEntryData) (audit.Entry, error)
+		ListAuditEntries(ctx context.Context, filter audit.Filter, after audit.Entry, limit int) ([]audit.Entry, error)
+	}
+
+	// Storage drivers that can hold the ingestion messages given up
+	deadLetterStorage interface {
+		CreateDeadLetter(ctx context.Context, deadLetter ingestion.DeadLetter) (ingestion.DeadLetter, error)
+		ListDeadLetters(ctx context.Context, limit int) ([]ingestion.DeadLetter, error)
+		GetDeadLetter(ctx context.Context, id string) (ingestion.DeadLetter, error)
+		DeleteDeadLetter(ctx context.Context, id string) error
+		ErasePlayerDeadLetters(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerDeadLetters(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerDeadLetters(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can track the ingestion messages already handled
+	dedupStorage interface {
+		ClaimMessage(ctx context.Context, id string, ttl time.Duration) (bool, error)
+		ReleaseMessage(ctx context.Context, id string) error
+	}
+
+	// Statistic storage drivers that can write many players progression at once
+	bulkStatisticStorage interface {
+		BulkUpdatePlayerStatisticProgression(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]statistic.PlayerProgression, []statistic.PlayerProgressionUpdates, error)
+		GetWriteBehindCheckpoint(ctx context.Context, queueID string) (int64, error)
+	}
+
+	// Storage drivers that can hold the webhooks and their deliveries
+	webhookStorage interface {
+		CreateWebhook(ctx context.Context, data webhook.NewWebhookData) (webhook.Webhook, error)
+		ListWebhooks(ctx context.Context, gameID string) ([]webhook.Webhook, error)
+		GetWebhook(ctx context.Context, id string) (webhook.Webhook, error)
+		DeleteWebhook(ctx context.Context, id, gameID string) error
+		EnableWebhook(ctx context.Context, id, gameID string) (webhook.Webhook, error)
+		UpdateWebhookFilter(ctx context.Context, id, gameID string, filter webhook.Filter) (webhook.Webhook, error)
+		RecordWebhookAttempt(ctx context.Context, id string, succeeded bool, disableAfter int) error
+		CreateDeliveries(ctx context.Context, deliveries []webhook.Delivery) error
+		ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhook.Delivery, error)
+		UpdateDelivery(ctx context.Context, delivery webhook.Delivery) error
+		ListDeliveries(ctx context.Context, webhookID string, limit int) ([]webhook.Delivery, error)
+	}
+	// Storage drivers that can hold the daily usage of the games
+	usageStorage interface {
+		AddUsage(ctx context.Context, dailyUsage []usage.DailyUsage) error
+		ListUsage(ctx context.Context, gameID string, from, to time.Time) ([]usage.DailyUsage, error)
+	}
+
+	// Storage drivers that can hold the quotas of the games
+	quotaStorage interface {
+		SetQuota(ctx context.Context, q quota.Quota) error
+		GetQuota(ctx context.Context, gameID string) (quota.Quota, error)
+	}
+
+	// Storage drivers that can hold the player profiles
+	profileStorage interface {
+		CreateProfile(ctx context.Context, profile player.Profile) error
+		GetProfile(ctx context.Context, gameID, playerID string) (player.Profile, error)
+		UpdateProfile(ctx context.Context, profile player.Profile) (player.Profile, error)
+		DeleteProfile(ctx context.Context, gameID, playerID string) error
+		ListProfiles(ctx context.Context, gameID string, playerIDs []string) ([]player.Profile, error)
+		SearchProfiles(ctx context.Context, gameID, search string, offset, limit int) ([]player.Profile, error)
+		ErasePlayerProfile(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerProfile(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerProfile(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the external platform accounts linked to the players
+	accountLinkStorage interface {
+		LinkAccount(ctx context.Context, account player.LinkedAccount) (player.LinkedAccount, error)
+		UnlinkAccount(ctx context.Context, gameID, playerID, platform, externalID string) error
+		ListAccounts(ctx context.Context, gameID, playerID string) ([]player.LinkedAccount, error)
+		GetAccount(ctx context.Context, gameID, platform, externalID string) (player.LinkedAccount, error)
+		ErasePlayerAccounts(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerAccounts(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerAccounts(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the players' bans and the ranks they hide
+	banStorage interface {
+		CreateBan(ctx context.Context, b ban.Ban) error
+		GetBan(ctx context.Context, gameID, playerID string) (ban.Ban, error)
+		SetBanHiddenRanks(ctx context.Context, gameID, playerID string, ranks []ban.HiddenRank) error
+		DeleteBan(ctx context.Context, gameID, playerID string) (ban.Ban, error)
+		ListBans(ctx context.Context, gameID string) ([]ban.Ban, error)
+		ListExpiredBans(ctx context.Context, now time.Time, limit int) ([]ban.Ban, error)
+		ErasePlayerBan(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerBan(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerBan(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+		CreateDeviceBan(ctx context.Context, b ban.DeviceBan) error
+		GetDeviceBan(ctx context.Context, gameID, deviceHash string) (ban.DeviceBan, error)
+		DeleteDeviceBan(ctx context.Context, gameID, deviceHash string) (ban.DeviceBan, error)
+		ListDeviceBans(ctx context.Context, gameID string) ([]ban.DeviceBan, error)
+	}
+
+	// Storage drivers that can hold the players' flags and their ranks on the shadow copies of the leaderboards
+	shadowFlagStorage interface {
+		CreateShadowFlag(ctx context.Context, f shadow.Flag) error
+		GetShadowFlag(ctx context.Context, gameID, playerID string) (shadow.Flag, error)
+		AddShadowRank(ctx context.Context, gameID, playerID string, rank shadow.ShadowRank) error
+		SetShadowRank(ctx context.Context, gameID, playerID string, rank shadow.ShadowRank) error
+		DeleteShadowFlag(ctx context.Context, gameID, playerID string) (shadow.Flag, error)
+		ListShadowFlags(ctx context.Context, gameID string) ([]shadow.Flag, error)
+		ErasePlayerShadowFlag(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerShadowFlag(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerShadowFlag(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the titles and their grants to the players
+	titleStorage interface {
+		CreateTitle(ctx context.Context, data title.NewTitleData) (title.Title, error)
+		GetTitle(ctx context.Context, id, gameID string) (title.Title, error)
+		ListTitles(ctx context.Context, gameID string) ([]title.Title, error)
+		DeleteTitle(ctx context.Context, id, gameID string) error
+		GrantTitles(ctx context.Context, grants []title.Grant) ([]title.Grant, error)
+		ListTitleGrants(ctx context.Context, gameID string, playerIDs []string) ([]title.Grant, error)
+		ErasePlayerTitles(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerTitles(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerTitles(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the friendships between the players
+	friendStorage interface {
+		AddFriendship(ctx context.Context, friendship friend.Friendship) (friend.Friendship, error)
+		RemoveFriendship(ctx context.Context, gameID, playerID, friendID string) error
+		ListFriends(ctx context.Context, gameID, playerID string) ([]friend.Friendship, error)
+		ErasePlayerFriendships(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerFriendships(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerFriendships(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can cache the top positions of the rankings
+	rankingCacheStorage interface {
+		GetCachedTop(ctx context.Context, lb leaderboard.Leaderboard) (leaderboard.CachedTop, bool, error)
+		CacheTop(ctx context.Context, lb leaderboard.Leaderboard, top leaderboard.CachedTop, ttl time.Duration) error
+		GetCachedTopBound(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.CachedTopBound, bool, error)
+		InvalidateCachedTop(ctx context.Context, lb leaderboard.Leaderboard) error
+	}
+
+	// Storage drivers that can keep the top views of the rankings
+	topViewStorage interface {
+		GetTopView(ctx context.Context, lb leaderboard.Leaderboard) (leaderboard.TopView, bool, error)
+		SaveTopView(ctx context.Context, lb leaderboard.Leaderboard, view leaderboard.TopView, ttl time.Duration) (bool, error)
+		DropTopView(ctx context.Context, lb leaderboard.Leaderboard) error
+	}
+
+	// Storage drivers that can track the players' heartbeats
+	presenceStorage interface {
+		RecordHeartbeat(ctx context.Context, p presence.Presence, timeout, retention time.Duration) error
+		ListPresences(ctx context.Context, gameID string, playerIDs []string) ([]presence.Presence, error)
+		ErasePlayerPresence(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerPresence(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerPresence(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the submission nonces until they are used or expire
+	nonceStorage interface {
+		CreateNonce(ctx context.Context, nonce signing.Nonce, ttl time.Duration) error
+		ConsumeNonce(ctx context.Context, gameID, playerID, nonce string) error
+	}
+
+	// Storage drivers that can count the players' submissions on each window of the rate limit
+	submissionRateStorage interface {
+		IncrementPlayerSubmissions(ctx context.Context, gameID, playerID string, window time.Time) (int64, error)
+	}
+
+	// Storage drivers that can hold the delta rules and the improvements applied within the hourly window
+	deltaRuleStorage interface {
+		SetDeltaRule(ctx context.Context, rule delta.Rule) (delta.Rule, error)
+		GetDeltaRule(ctx context.Context, gameID, leaderboardID string) (delta.Rule, error)
+		DeleteDeltaRule(ctx context.Context, gameID, leaderboardID string) error
+		RecordDeltaImprovement(ctx context.Context, i delta.Improvement) error
+		SumDeltaImprovements(ctx context.Context, gameID, leaderboardID, playerID string, since time.Time) (float64, error)
+	}
+
+	// Storage drivers that can hold the proof rules
+	proofRuleStorage interface {
+		SetProofRule(ctx context.Context, rule proof.Rule) (proof.Rule, error)
+		GetProofRule(ctx context.Context, gameID, leaderboardID string) (proof.Rule, error)
+		DeleteProofRule(ctx context.Context, gameID, leaderboardID string) error
+	}
+
+	// Storage drivers that can hold the submissions held for review
+	quarantineStorage interface {
+		CreateQuarantinedSubmission(ctx context.Context, s quarantine.Submission) (quarantine.Submission, error)
+		GetQuarantinedSubmission(ctx context.Context, gameID, id string) (quarantine.Submission, error)
+		ListQuarantinedSubmissions(ctx context.Context, gameID, leaderboardID string, limit int) ([]quarantine.Submission, error)
+		DeleteQuarantinedSubmission(ctx context.Context, gameID, id string) error
+		ErasePlayerQuarantinedSubmissions(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerQuarantinedSubmissions(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerQuarantinedSubmissions(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the players' trust records
+	trustStorage interface {
+		TrackTrustRecord(ctx context.Context, gameID, playerID string, seenAt time.Time) (trust.Record, error)
+		IncrementTrustRecord(ctx context.Context, gameID, playerID string, flagged, cleared int64, seenAt time.Time) error
+		GetTrustRecord(ctx context.Context, gameID, playerID string) (trust.Record, error)
+		ErasePlayerTrustRecord(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerTrustRecord(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerTrustRecord(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the ranks flagged as outliers of their leaderboards
+	anomalyStorage interface {
+		SaveAnomaly(ctx context.Context, a anomaly.Anomaly) (anomaly.Anomaly, error)
+		ListLeaderboardAnomalies(ctx context.Context, gameID, leaderboardID string) ([]anomaly.Anomaly, error)
+		ListAnomalies(ctx context.Context, gameID, leaderboardID string, limit int) ([]anomaly.Anomaly, error)
+		DismissAnomaly(ctx context.Context, gameID, id string) error
+		ErasePlayerAnomalies(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerAnomalies(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerAnomalies(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the actions taken by the moderators on the leaderboards entries
+	moderationStorage interface {
+		CreateModerationAction(ctx context.Context, action moderation.Action) error
+		ListEntryModerationActions(ctx context.Context, gameID, leaderboardID, playerID string) ([]moderation.Action, error)
+		ListModerationActions(ctx context.Context, gameID string) ([]moderation.Action, error)
+	}
+
+	// Storage drivers that can hold the segments and the eligibilities restricted to them
+	segmentStorage interface {
+		CreateSegment(ctx context.Context, data segment.NewSegmentData) (segment.Segment, error)
+		GetSegment(ctx context.Context, id, gameID string) (segment.Segment, error)
+		ListSegments(ctx context.Context, gameID string) ([]segment.Segment, error)
+		DeleteSegment(ctx context.Context, id, gameID string) error
+		SetEligibility(ctx context.Context, eligibility segment.Eligibility) (segment.Eligibility, error)
+		GetEligibility(ctx context.Context, gameID, resourceType, resourceID string) (segment.Eligibility, error)
+		DeleteEligibility(ctx context.Context, gameID, resourceType, resourceID string) error
+	}
+
+	// Storage drivers that can hold the level curves
+	levelStorage interface {
+		CreateLevelCurve(ctx context.Context, data level.NewCurveData) (level.Curve, error)
+		GetLevelCurve(ctx context.Context, id, gameID string) (level.Curve, error)
+		ListLevelCurves(ctx context.Context, gameID string) ([]level.Curve, error)
+		DeleteLevelCurve(ctx context.Context, id, gameID string) error
+	}
+
+	// Storage drivers that can hold the players' activity feeds
+	activityStorage interface {
+		RecordActivities(ctx context.Context, activities []activity.Activity) error
+		ListActivities(ctx context.Context, gameID string, playerIDs []string, after activity.Activity, limit int) ([]activity.Activity, error)
+		ErasePlayerActivities(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerActivities(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerActivities(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the achievements and the players' unlocks
+	achievementStorage interface {
+		CreateAchievement(ctx context.Context, data achievement.NewAchievementData) (achievement.Achievement, error)
+		GetAchievement(ctx context.Context, id, gameID string) (achievement.Achievement, error)
+		ListAchievements(ctx context.Context, gameID string) ([]achievement.Achievement, error)
+		DeleteAchievement(ctx context.Context, id, gameID string) error
+		UnlockAchievements(ctx context.Context, unlocks []achievement.Unlock) ([]achievement.Unlock, error)
+		ListAchievementUnlocks(ctx context.Context, gameID, playerID string) ([]achievement.Unlock, error)
+		ErasePlayerAchievements(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerAchievements(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerAchievements(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the reward rules and the grants queued by them
+	rewardStorage interface {
+		CreateRewardRule(ctx context.Context, data reward.NewRuleData) (reward.Rule, error)
+		ListRewardRules(ctx context.Context, gameID string) ([]reward.Rule, error)
+		ListSourceRewardRules(ctx context.Context, gameID, source, sourceID string) ([]reward.Rule, error)
+		DeleteRewardRule(ctx context.Context, id, gameID string) error
+		CreateRewardGrants(ctx context.Context, grants []reward.Grant) ([]reward.Grant, error)
+		ClaimDueRewardGrants(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]reward.Grant, error)
+		UpdateRewardGrant(ctx context.Context, grant reward.Grant) error
+		GetRewardGrant(ctx context.Context, id, gameID string) (reward.Grant, error)
+		ListRewardGrants(ctx context.Context, gameID, playerID string, limit int) ([]reward.Grant, error)
+		ErasePlayerRewardGrants(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerRewardGrants(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerRewardGrants(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Storage drivers that can hold the locks of the scheduled jobs
+	schedulerLockStorage interface {
+		AcquireSchedulerLock(ctx context.Context, job, owner string, now, until time.Time) (scheduler.Lock, bool, error)
+		RecordSchedulerRun(ctx context.Context, job, owner string, ranAt time.Time) error
+	}
+
+	// Storage drivers that can hold the scores submitted to the leaderboards
+	scoreHistoryStorage interface {
+		RecordScore(ctx context.Context, score leaderboard.Score) error
+		ListScores(ctx context.Context, leaderboardID string, after leaderboard.Score, limit int) ([]leaderboard.Score, error)
+		ListScoresBySource(ctx context.Context, gameID, source string, from, to time.Time, after leaderboard.Score, limit int) ([]leaderboard.Score, error)
+		DeleteScores(ctx context.Context, leaderboardID, playerID string, from, to time.Time) error
+		ListDeviceUsage(ctx context.Context, gameID string, filter moderation.DeviceUsageFilter, limit int) ([]moderation.DeviceUsage, error)
+		ErasePlayerScores(ctx context.Context, gameID, playerID string) (privacy.Erasure, error)
+		AnonymizePlayerScores(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error)
+		CountPlayerScores(ctx context.Context, gameID, playerID string) (privacy.DataCount, error)
+	}
+
+	// Statistic storage drivers that can measure the data each game keeps on them
+	footprintStorage interface {
+		GameFootprint(ctx context.Context, gameID string) (usage.Footprint, error)
+	}
+
+	// The in-memory storage driver, keeping every domain but the outbox
+	memoryStorage interface {
+		leaderboardStorage
+		statisticStorage
+		questStorage
+		auditStorage
+		deadLetterStorage
+		dedupStorage
+		rankingCacheStorage
+		topViewStorage
+		presenceStorage
+		nonceStorage
+		submissionRateStorage
+		bulkStatisticStorage
+		webhookStorage
+		usageStorage
+		quotaStorage
+		profileStorage
+		accountLinkStorage
+		banStorage
+		shadowFlagStorage
+		achievementStorage
+		titleStorage
+		friendStorage
+		segmentStorage
+		deltaRuleStorage
+		quarantineStorage
+		trustStorage
+		proofRuleStorage
+		anomalyStorage
+		moderationStorage
+		levelStorage
+		activityStorage
+		rewardStorage
+		footprintStorage
+		scoreHistoryStorage
+		schedulerLockStorage
+	}
+)
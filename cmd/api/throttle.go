@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/throttle"
+)
+
+// Every submission is counted against the player's rate limit before anything else checks it
+func newSubmissionRateLimit(config Config, drivers storageDrivers, upsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc, updatePlayerStatisticProgressionFunc statistic.StorageUpdatePlayerProgressionFunc) (leaderboard.StorageUpsertPlayerRankValueFunc, statistic.StorageUpdatePlayerProgressionFunc) {
+	if config.SubmissionRateLimitEnabled {
+		submissionRateStorage, ok := drivers.submissionRateStorages[config.SubmissionRateLimitStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.SubmissionRateLimitStorage), "invalid submission rate limit storage")
+		}
+
+		checkSubmissionRateFunc, err := throttle.BuildCheckFunc(config.SubmissionRateLimit, submissionRateStorage.IncrementPlayerSubmissions)
+		if err != nil {
+			zap.Panic(err, "invalid submission rate limit")
+		}
+
+		upsertPlayerRankValueFunc = throttle.BuildLimitRankFunc(checkSubmissionRateFunc, upsertPlayerRankValueFunc)
+		updatePlayerStatisticProgressionFunc = throttle.BuildLimitProgressionFunc(checkSubmissionRateFunc, updatePlayerStatisticProgressionFunc)
+	}
+
+	return upsertPlayerRankValueFunc, updatePlayerStatisticProgressionFunc
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/title"
+)
+
+type titleFuncs struct {
+	storage               titleStorage
+	createTitleFunc       title.CreateFunc
+	getTitleFunc          title.GetByIDAndGameIDFunc
+	listTitlesFunc        title.ListFunc
+	deleteTitleFunc       title.DeleteFunc
+	listPlayerTitlesFunc  title.ListPlayerTitlesFunc
+	listPlayersTitlesFunc title.ListPlayersTitlesFunc
+}
+
+func newTitles(config Config, drivers storageDrivers, statisticStorage statisticStorage, leaderboardStorage leaderboardStorage) titleFuncs {
+	var (
+		ok                    bool
+		titleStorage          titleStorage
+		createTitleFunc       title.CreateFunc
+		getTitleFunc          title.GetByIDAndGameIDFunc
+		listTitlesFunc        title.ListFunc
+		deleteTitleFunc       title.DeleteFunc
+		listPlayerTitlesFunc  title.ListPlayerTitlesFunc
+		listPlayersTitlesFunc title.ListPlayersTitlesFunc
+	)
+	if config.TitlesEnabled {
+		if titleStorage, ok = drivers.titleStorages[config.TitleStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.TitleStorage), "invalid title storage")
+		}
+
+		createTitleFunc = title.BuildCreateFunc(
+			statistic.BuildGetStatisticByIDAndGameID(statisticStorage.GetStatisticByIDAndGameID),
+			leaderboard.BuildGetByIDAndGameIDFunc(leaderboardStorage.GetLeaderboardByIDAndGameID),
+			titleStorage.CreateTitle,
+		)
+		getTitleFunc = title.BuildGetByIDAndGameIDFunc(titleStorage.GetTitle)
+		listTitlesFunc = title.BuildListFunc(titleStorage.ListTitles)
+		deleteTitleFunc = title.BuildDeleteFunc(titleStorage.DeleteTitle)
+		listPlayerTitlesFunc = title.BuildListPlayerTitlesFunc(titleStorage.ListTitles, titleStorage.ListTitleGrants)
+		listPlayersTitlesFunc = title.BuildListPlayersTitlesFunc(titleStorage.ListTitles, titleStorage.ListTitleGrants)
+	}
+
+	return titleFuncs{
+		storage:               titleStorage,
+		createTitleFunc:       createTitleFunc,
+		getTitleFunc:          getTitleFunc,
+		listTitlesFunc:        listTitlesFunc,
+		deleteTitleFunc:       deleteTitleFunc,
+		listPlayerTitlesFunc:  listPlayerTitlesFunc,
+		listPlayersTitlesFunc: listPlayersTitlesFunc,
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+	"github.com/gabapcia/gameblitz/internal/trust"
+)
+
+type trustFuncs struct {
+	storage      trustStorage
+	config       trust.Config
+	getTrustFunc trust.GetFunc
+}
+
+// The players' trust counts the submissions the delta and proof rules hold against them and the releases in their favor,
+// alongside the time since their first submission. It tightens the delta rules for the players with a low trust,
+// and lets the ones with a high trust skip them
+func newTrust(config Config, drivers storageDrivers, holdSubmissionFunc quarantine.HoldFunc) (trustFuncs, quarantine.HoldFunc) {
+	var (
+		ok           bool
+		trustStorage trustStorage
+		trustConfig  trust.Config
+		getTrustFunc trust.GetFunc
+	)
+	if config.TrustEnabled {
+		if trustStorage, ok = drivers.trustStorages[config.TrustStorage]; !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.TrustStorage), "invalid trust storage")
+		}
+
+		trustConfig = trust.Config{
+			LowThreshold:   config.TrustLowThreshold,
+			HighThreshold:  config.TrustHighThreshold,
+			LowDeltaFactor: config.TrustLowDeltaFactor,
+		}
+		getTrustFunc = trust.BuildGetFunc(trustConfig, trustStorage.GetTrustRecord)
+
+		holdSubmissionFunc = trust.BuildRecordHoldFunc(trustStorage.IncrementTrustRecord, holdSubmissionFunc)
+	}
+
+	return trustFuncs{
+		storage:      trustStorage,
+		config:       trustConfig,
+		getTrustFunc: getTrustFunc,
+	}, holdSubmissionFunc
+}
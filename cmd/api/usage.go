@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+	"github.com/gabapcia/gameblitz/internal/usage"
+)
+
+type usageFuncs struct {
+	recordUsageFunc usage.RecordFunc
+	reportUsageFunc usage.ReportFunc
+}
+
+// The usage is counted in memory and flushed in the background until the context is canceled.
+// The returned func cancels it and waits for the last flush
+func newUsage(ctx context.Context, cancel context.CancelFunc, config Config, drivers storageDrivers, usageMetrics *metrics.Usage) (usageFuncs, func()) {
+	var (
+		closeUsage      = func() {}
+		recordUsageFunc usage.RecordFunc
+		reportUsageFunc usage.ReportFunc
+	)
+	if config.UsageEnabled {
+		usageStorage, ok := drivers.usageStorages[config.UsageStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.UsageStorage), "invalid usage storage")
+		}
+
+		meter := usage.NewMeter(usageStorage.AddUsage)
+
+		usageFlushed := make(chan struct{})
+		go func() {
+			defer close(usageFlushed)
+			meter.Run(ctx, time.Duration(config.UsageFlushInterval)*time.Second, func(err error) {
+				zap.Error(err, "usage flush failed")
+			})
+		}()
+
+		// The usage counted since the last flush is written before exiting
+		closeUsage = func() {
+			cancel()
+			<-usageFlushed
+		}
+
+		recordUsageFunc = func(gameID string, scoreSubmission bool, errorCode string) {
+			meter.Record(gameID, scoreSubmission, errorCode)
+			usageMetrics.Record(gameID, scoreSubmission)
+		}
+
+		// The footprint is left empty when the statistic storage can't measure the data of the games
+		var storageGameFootprintFunc usage.StorageGameFootprintFunc
+		if footprintStorage, ok := drivers.footprintStorages[config.StatisticStorage]; ok {
+			storageGameFootprintFunc = footprintStorage.GameFootprint
+		}
+
+		reportUsageFunc = usage.BuildReportFunc(usageStorage.ListUsage, storageGameFootprintFunc)
+	}
+
+	return usageFuncs{
+		recordUsageFunc: recordUsageFunc,
+		reportUsageFunc: reportUsageFunc,
+	}, closeUsage
+}
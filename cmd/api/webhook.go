@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/datachange"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	asyncwebhook "github.com/gabapcia/gameblitz/internal/infra/async/webhook"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+// Publishes the events on the broker and queues them to the webhooks of their game. The webhooks are only
+// queued once the broker accepted the event, so a failure on either of them makes the caller retry both
+type webhookBroker struct {
+	broker
+	webhooks eventPublisher
+}
+
+func (b webhookBroker) PlayerStatisticProgressionUpdates(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+	if err := b.broker.PlayerStatisticProgressionUpdates(ctx, st, progression, updates); err != nil {
+		return err
+	}
+
+	return b.webhooks.PlayerStatisticProgressionUpdates(ctx, st, progression, updates)
+}
+
+func (b webhookBroker) PlayerQuestProgressionUpdates(ctx context.Context, progression quest.PlayerQuestProgression) error {
+	if err := b.broker.PlayerQuestProgressionUpdates(ctx, progression); err != nil {
+		return err
+	}
+
+	return b.webhooks.PlayerQuestProgressionUpdates(ctx, progression)
+}
+
+func (b webhookBroker) DataChange(ctx context.Context, event datachange.Event) error {
+	if err := b.broker.DataChange(ctx, event); err != nil {
+		return err
+	}
+
+	return b.webhooks.DataChange(ctx, event)
+}
+
+func (b webhookBroker) LeaderboardLifecycleEvent(ctx context.Context, event string, lb leaderboard.Leaderboard) error {
+	if err := b.broker.LeaderboardLifecycleEvent(ctx, event, lb); err != nil {
+		return err
+	}
+
+	return b.webhooks.LeaderboardLifecycleEvent(ctx, event, lb)
+}
+
+func (b webhookBroker) QuestLifecycleEvent(ctx context.Context, event quest.LifecycleEvent) error {
+	if err := b.broker.QuestLifecycleEvent(ctx, event); err != nil {
+		return err
+	}
+
+	return b.webhooks.QuestLifecycleEvent(ctx, event)
+}
+
+func (b webhookBroker) PlayerErased(ctx context.Context, playerID string, receipt privacy.Receipt) error {
+	if err := b.broker.PlayerErased(ctx, playerID, receipt); err != nil {
+		return err
+	}
+
+	return b.webhooks.PlayerErased(ctx, playerID, receipt)
+}
+
+func (b webhookBroker) PlayerAnonymized(ctx context.Context, playerID string, receipt privacy.AnonymizationReceipt) error {
+	if err := b.broker.PlayerAnonymized(ctx, playerID, receipt); err != nil {
+		return err
+	}
+
+	return b.webhooks.PlayerAnonymized(ctx, playerID, receipt)
+}
+
+func (b webhookBroker) AchievementUnlocked(ctx context.Context, unlocked achievement.PlayerAchievement) error {
+	if err := b.broker.AchievementUnlocked(ctx, unlocked); err != nil {
+		return err
+	}
+
+	return b.webhooks.AchievementUnlocked(ctx, unlocked)
+}
+
+func (b webhookBroker) LevelUp(ctx context.Context, up level.LevelUp) error {
+	if err := b.broker.LevelUp(ctx, up); err != nil {
+		return err
+	}
+
+	return b.webhooks.LevelUp(ctx, up)
+}
+
+type webhookFuncs struct {
+	createWebhookFunc         webhook.CreateWebhookFunc
+	listWebhooksFunc          webhook.ListWebhooksFunc
+	deleteWebhookFunc         webhook.DeleteWebhookFunc
+	enableWebhookFunc         webhook.EnableWebhookFunc
+	updateWebhookFilterFunc   webhook.UpdateWebhookFilterFunc
+	listWebhookDeliveriesFunc webhook.ListDeliveriesFunc
+}
+
+// The deliveries are sent in the background until the context is canceled, and the returned func stops their sender
+func newWebhooks(ctx context.Context, config Config, drivers storageDrivers, encoder message.Encoder, broker broker) (webhookFuncs, broker, func()) {
+	var (
+		closeWebhooks             = func() {}
+		createWebhookFunc         webhook.CreateWebhookFunc
+		listWebhooksFunc          webhook.ListWebhooksFunc
+		deleteWebhookFunc         webhook.DeleteWebhookFunc
+		enableWebhookFunc         webhook.EnableWebhookFunc
+		updateWebhookFilterFunc   webhook.UpdateWebhookFilterFunc
+		listWebhookDeliveriesFunc webhook.ListDeliveriesFunc
+	)
+	if config.WebhooksEnabled {
+		webhookStorage, ok := drivers.webhookStorages[config.WebhookStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown storage %q", config.WebhookStorage), "invalid webhook storage")
+		}
+
+		// Every event published from here on is also queued to the webhooks of its game
+		broker = webhookBroker{
+			broker:   broker,
+			webhooks: asyncwebhook.NewProducer(encoder, webhook.BuildEnqueueFunc(webhookStorage.ListWebhooks, webhookStorage.CreateDeliveries)),
+		}
+
+		timeout := time.Duration(config.WebhookTimeout) * time.Second
+		sender := asyncwebhook.NewSender(timeout)
+		closeWebhooks = sender.Close
+
+		retryDelay := backoff.Config{
+			InitialInterval: time.Duration(config.WebhookRetryDelay) * time.Second,
+			MaxInterval:     time.Duration(config.WebhookRetryMaxDelay) * time.Second,
+		}
+
+		// The lease outlives the request timeout, so a delivery is only claimed again once its attempt is over
+		deliverFunc, err := webhook.BuildDeliverFunc(
+			config.WebhookMaxAttempts,
+			config.WebhookDisableAfter,
+			config.WebhookBatchSize,
+			2*timeout,
+			func(attempts int) time.Duration { return backoff.Delay(retryDelay, attempts) },
+			sender.Post,
+			webhookStorage.ClaimDueDeliveries,
+			webhookStorage.GetWebhook,
+			webhookStorage.UpdateDelivery,
+			webhookStorage.RecordWebhookAttempt,
+		)
+		if err != nil {
+			zap.Panic(err, "invalid webhook setup")
+		}
+
+		go func() {
+			ticker := time.NewTicker(time.Duration(config.WebhookDeliveryInterval) * time.Second)
+			defer ticker.Stop()
+
+			for {
+				delivered, err := deliverFunc(ctx)
+				if err != nil {
+					zap.Error(err, "webhook delivery failed", "delivered", delivered)
+				}
+
+				// A full batch means more deliveries may be due, so they are sent right away
+				if err == nil && delivered == config.WebhookBatchSize {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+
+		createWebhookFunc = webhook.BuildCreateWebhookFunc(asyncwebhook.EventTypes, webhookStorage.CreateWebhook)
+		listWebhooksFunc = webhook.BuildListWebhooksFunc(webhookStorage.ListWebhooks)
+		deleteWebhookFunc = webhook.BuildDeleteWebhookFunc(webhookStorage.DeleteWebhook)
+		enableWebhookFunc = webhook.BuildEnableWebhookFunc(webhookStorage.EnableWebhook)
+		updateWebhookFilterFunc = webhook.BuildUpdateWebhookFilterFunc(asyncwebhook.EventTypes, webhookStorage.UpdateWebhookFilter)
+		listWebhookDeliveriesFunc = webhook.BuildListDeliveriesFunc(webhookStorage.ListDeliveries)
+	}
+
+	return webhookFuncs{
+		createWebhookFunc:         createWebhookFunc,
+		listWebhooksFunc:          listWebhooksFunc,
+		deleteWebhookFunc:         deleteWebhookFunc,
+		enableWebhookFunc:         enableWebhookFunc,
+		updateWebhookFilterFunc:   updateWebhookFilterFunc,
+		listWebhookDeliveriesFunc: listWebhookDeliveriesFunc,
+	}, broker, closeWebhooks
+}
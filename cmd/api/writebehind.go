@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/journal"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/writebehind"
+)
+
+type writeBehindFuncs struct {
+	erasePlayerFunc     privacy.StorageErasePlayerFunc
+	anonymizePlayerFunc privacy.StorageAnonymizePlayerFunc
+	countPlayerDataFunc privacy.StorageCountPlayerDataFunc
+}
+
+// The statistic updates are acknowledged once queued on the local journal, and written to the statistic storage in
+// bulk on each flush. Their completions and level-ups are published as they're written
+func newWriteBehind(ctx context.Context, cancel context.CancelFunc, config Config, drivers storageDrivers, levelStorage levelStorage, broker broker, notifierPlayerStatisticProgressionUpdates statistic.NotifierPlayerProgressionUpdates, updatePlayerStatisticProgressionFunc statistic.StorageUpdatePlayerProgressionFunc) (writeBehindFuncs, statistic.StorageUpdatePlayerProgressionFunc, func()) {
+	var (
+		closeWriteBehind               = func() {}
+		writeBehindErasePlayerFunc     privacy.StorageErasePlayerFunc
+		writeBehindAnonymizePlayerFunc privacy.StorageAnonymizePlayerFunc
+		writeBehindCountPlayerDataFunc privacy.StorageCountPlayerDataFunc
+	)
+	if config.StatisticWriteBehindEnabled {
+		bulkStatisticStorage, ok := drivers.bulkStatisticStorages[config.StatisticStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("storage %q without bulk writes", config.StatisticStorage), "invalid statistic write-behind storage")
+		}
+
+		writeBehindJournal, err := journal.New(config.StatisticWriteBehindPath)
+		if err != nil {
+			zap.Panic(err, "statistic write-behind journal startup failed")
+		}
+
+		var bulkUpdatePlayerStatisticProgressionFunc statistic.StorageBulkUpdatePlayerProgressionFunc = bulkStatisticStorage.BulkUpdatePlayerStatisticProgression
+		if levelStorage != nil {
+			bulkUpdatePlayerStatisticProgressionFunc = level.BuildNotifyBulkLevelUpsFunc(broker.LevelUp, levelStorage.ListLevelCurves, bulkUpdatePlayerStatisticProgressionFunc)
+		}
+
+		writeBehindBuffer, err := writebehind.NewBuffer(
+			writebehind.Config{
+				QueueID:    writeBehindJournal.ID(),
+				BatchSize:  config.StatisticWriteBehindBatchSize,
+				MaxPending: config.StatisticWriteBehindMaxPending,
+			},
+			writeBehindJournal.AppendStatisticChange,
+			writeBehindJournal.PeekStatisticChanges,
+			writeBehindJournal.RemoveStatisticChanges,
+			writeBehindJournal.CountStatisticChanges,
+			bulkStatisticStorage.GetWriteBehindCheckpoint,
+			notifierPlayerStatisticProgressionUpdates,
+			bulkUpdatePlayerStatisticProgressionFunc,
+			updatePlayerStatisticProgressionFunc,
+		)
+		if err != nil {
+			zap.Panic(err, "invalid statistic write-behind settings")
+		}
+
+		writeBehindFlushed := make(chan struct{})
+		go func() {
+			defer close(writeBehindFlushed)
+			writeBehindBuffer.Run(ctx, time.Duration(config.StatisticWriteBehindFlushInterval)*time.Millisecond, func(err error) {
+				zap.Error(err, "statistic write-behind flush failed")
+			})
+		}()
+
+		// The updates queued since the last flush are written before exiting. The ones a failed write kept stay on the
+		// journal, written once the API starts again
+		closeWriteBehind = func() {
+			cancel()
+			<-writeBehindFlushed
+			_ = writeBehindJournal.Close()
+		}
+
+		updatePlayerStatisticProgressionFunc = writeBehindBuffer.Update
+
+		writeBehindErasePlayerFunc = writeBehindBuffer.BuildErasePlayerFunc(writeBehindJournal.ErasePlayerStatisticChanges)
+		writeBehindAnonymizePlayerFunc = writeBehindBuffer.BuildAnonymizePlayerFunc(writeBehindJournal.AnonymizePlayerStatisticChanges)
+		writeBehindCountPlayerDataFunc = writeBehindJournal.CountPlayerStatisticChanges
+	}
+
+	return writeBehindFuncs{
+		erasePlayerFunc:     writeBehindErasePlayerFunc,
+		anonymizePlayerFunc: writeBehindAnonymizePlayerFunc,
+		countPlayerDataFunc: writeBehindCountPlayerDataFunc,
+	}, updatePlayerStatisticProgressionFunc, closeWriteBehind
+}
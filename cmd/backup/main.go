@@ -0,0 +1,429 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/backup"
+	asyncwebhook "github.com/gabapcia/gameblitz/internal/infra/async/webhook"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/dynamodb"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/mongo"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/redis"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type Config struct {
+	PotgresDSN string `envconfig:"POSTGRESQL_DSN" required:"false"`
+
+	MongoURI string `envconfig:"MONGO_URI" required:"false"`
+	MongoDB  string `envconfig:"MONGO_DB" required:"false"`
+
+	DynamoDBTable    string `envconfig:"DYNAMODB_TABLE" required:"false" default:"gameblitz"`
+	DynamoDBRegion   string `envconfig:"DYNAMODB_REGION" required:"false"`
+	DynamoDBEndpoint string `envconfig:"DYNAMODB_ENDPOINT" required:"false"`
+
+	RedisAddrs      []string `envconfig:"REDIS_ADDR" required:"false"`
+	RedisUsername   string   `envconfig:"REDIS_USERNAME" required:"false"`
+	RedisPassword   string   `envconfig:"REDIS_PASSWORD" required:"false"`
+	RedisDB         int      `envconfig:"REDIS_DB" required:"false"`
+	RedisCluster    bool     `envconfig:"REDIS_CLUSTER" required:"false" default:"false"`
+	RedisGameRoutes []string `envconfig:"REDIS_GAME_ROUTES" required:"false"`
+
+	LeaderboardStorage string `envconfig:"LEADERBOARD_STORAGE" required:"false" default:"redis"`
+	StatisticStorage   string `envconfig:"STATISTIC_STORAGE" required:"false" default:"mongo"`
+	QuestStorage       string `envconfig:"QUEST_STORAGE" required:"false" default:"postgres"`
+
+	WebhooksEnabled bool   `envconfig:"WEBHOOKS_ENABLED" required:"false" default:"false"`
+	WebhookStorage  string `envconfig:"WEBHOOK_STORAGE" required:"false" default:"mongo"`
+}
+
+// Checks if any domain is configured to use the given storage
+func (c Config) usesStorage(storage string) bool {
+	storages := []string{c.LeaderboardStorage, c.StatisticStorage, c.QuestStorage}
+	if c.WebhooksEnabled {
+		storages = append(storages, c.WebhookStorage)
+	}
+
+	return slices.Contains(storages, storage)
+}
+
+const usage = "usage: backup export <game id> <archive file> | backup import [-dry-run] <archive file> | " +
+	"backup export-definition <game id> <definition file> | backup import-definition [-dry-run] [-game-id <game id>] <definition file>"
+
+func exportGame(ctx context.Context, exportFunc backup.ExportFunc, gameID, path string) error {
+	archive, err := exportFunc(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := backup.WriteArchive(file, archive); err != nil {
+		return err
+	}
+
+	zap.Info(
+		"game exported",
+		"gameId", gameID,
+		"archive", path,
+		"leaderboards", len(archive.Leaderboards),
+		"statistics", len(archive.Statistics),
+		"quests", len(archive.Quests),
+	)
+
+	return file.Close()
+}
+
+func importGame(ctx context.Context, diffImportFunc backup.DiffImportFunc, importFunc backup.ImportFunc, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Only print the entities the import would restore")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New(usage)
+	}
+	path := flags.Arg(0)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	archive, err := backup.ReadArchive(file)
+	if err != nil {
+		return err
+	}
+
+	// Nothing is restored when any entity exists already, so the dry run reports it as the failure the import would hit
+	if *dryRun {
+		changes, err := diffImportFunc(ctx, archive)
+		printChanges(changes)
+		if err != nil {
+			return err
+		}
+
+		if existing := len(changes) - countChanges(changes, backup.ChangeCreate); existing > 0 {
+			return fmt.Errorf("%w: %d of %d entities", backup.ErrEntityAlreadyExists, existing, len(changes))
+		}
+	} else if err := importFunc(ctx, archive); err != nil {
+		return err
+	}
+
+	zap.Info(
+		"game imported",
+		"gameId", archive.Manifest.GameID,
+		"archive", path,
+		"dryRun", *dryRun,
+		"exportedAt", archive.Manifest.ExportedAt,
+		"leaderboards", len(archive.Leaderboards),
+		"ranks", len(archive.Rankings),
+		"statistics", len(archive.Statistics),
+		"playerStatistics", len(archive.PlayerStatistics),
+		"quests", len(archive.Quests),
+		"playerQuests", len(archive.PlayerQuests),
+	)
+
+	return nil
+}
+
+func exportDefinition(ctx context.Context, exportDefinitionFunc backup.ExportDefinitionFunc, gameID, path string) error {
+	definition, err := exportDefinitionFunc(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := backup.WriteDefinition(file, definition); err != nil {
+		return err
+	}
+
+	zap.Info(
+		"game definition exported",
+		"gameId", gameID,
+		"definition", path,
+		"leaderboards", len(definition.Leaderboards),
+		"statistics", len(definition.Statistics),
+		"quests", len(definition.Quests),
+		"webhooks", len(definition.Webhooks),
+	)
+
+	return file.Close()
+}
+
+// Prints one line per entity, e.g. `CREATE leaderboard <id> "<name>"`, followed by the conflicting fields
+func printChanges(changes []backup.Change) {
+	for _, change := range changes {
+		line := fmt.Sprintf("%-9s %-11s %s", change.Action, change.Entity, change.ID)
+		if change.Name != change.ID {
+			line += fmt.Sprintf(" %q", change.Name)
+		}
+
+		if len(change.Fields) > 0 {
+			line += fmt.Sprintf(" (differs on %s)", strings.Join(change.Fields, ", "))
+		}
+
+		fmt.Println(line)
+	}
+}
+
+func countChanges(changes []backup.Change, action string) int {
+	count := 0
+	for _, change := range changes {
+		if change.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+func importDefinition(ctx context.Context, diffDefinitionFunc backup.DiffDefinitionFunc, importDefinitionFunc backup.ImportDefinitionFunc, args []string) error {
+	flags := flag.NewFlagSet("import-definition", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Only print what the import would change")
+	gameID := flags.String("game-id", "", "Import into this game instead of the exported one")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New(usage)
+	}
+	path := flags.Arg(0)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	definition, err := backup.ReadDefinition(file)
+	if err != nil {
+		return err
+	}
+
+	if *gameID != "" {
+		definition = definition.ForGame(*gameID)
+	}
+
+	var changes []backup.Change
+	if *dryRun {
+		changes, err = diffDefinitionFunc(ctx, definition)
+	} else {
+		changes, err = importDefinitionFunc(ctx, definition)
+	}
+	printChanges(changes)
+	if err != nil {
+		return err
+	}
+
+	zap.Info(
+		"game definition imported",
+		"gameId", definition.Manifest.GameID,
+		"definition", path,
+		"dryRun", *dryRun,
+		"created", countChanges(changes, backup.ChangeCreate),
+		"unchanged", countChanges(changes, backup.ChangeUnchanged),
+		"conflicts", countChanges(changes, backup.ChangeConflict),
+	)
+
+	return nil
+}
+
+func main() {
+	zap.Start()
+	defer zap.Sync()
+
+	var config Config
+	if err := envconfig.Process("", &config); err != nil {
+		zap.Panic(err, "env load failed")
+	}
+
+	if len(os.Args) < 2 {
+		zap.Panic(fmt.Errorf("missing command"), usage)
+	}
+
+	ctx := context.Background()
+
+	var (
+		leaderboardStorages = map[string]leaderboardStorage{}
+		statisticStorages   = map[string]statisticStorage{}
+		questStorages       = map[string]questStorage{}
+		webhookStorages     = map[string]webhookStorage{}
+	)
+
+	if config.usesStorage("redis") {
+		gameRoutes, err := redis.ParseGameRoutes(config.RedisGameRoutes)
+		if err != nil {
+			zap.Panic(err, "invalid redis game routes")
+		}
+
+		redis, err := redis.New(ctx, redis.Options{
+			Addrs:      config.RedisAddrs,
+			Username:   config.RedisUsername,
+			Password:   config.RedisPassword,
+			DB:         config.RedisDB,
+			Cluster:    config.RedisCluster,
+			GameRoutes: gameRoutes,
+		})
+		if err != nil {
+			zap.Panic(err, "redis startup failed")
+		}
+		defer redis.Close()
+
+		leaderboardStorages["redis"] = redis
+	}
+
+	if config.usesStorage("mongo") {
+		mongo, err := mongo.New(ctx, mongo.Options{URI: config.MongoURI, DB: config.MongoDB})
+		if err != nil {
+			zap.Panic(err, "mongo startup failed")
+		}
+		defer mongo.Close(context.Background())
+
+		statisticStorages["mongo"] = mongo
+		webhookStorages["mongo"] = mongo
+	}
+
+	if config.usesStorage("dynamodb") {
+		dynamodb, err := dynamodb.New(ctx, dynamodb.Options{
+			Table:    config.DynamoDBTable,
+			Region:   config.DynamoDBRegion,
+			Endpoint: config.DynamoDBEndpoint,
+		})
+		if err != nil {
+			zap.Panic(err, "dynamodb startup failed")
+		}
+		defer dynamodb.Close()
+
+		statisticStorages["dynamodb"] = dynamodb
+	}
+
+	if config.usesStorage("postgres") {
+		postgres, err := postgres.New(ctx, postgres.Options{DSN: config.PotgresDSN})
+		if err != nil {
+			zap.Panic(err, "postgres startup failed")
+		}
+		defer postgres.Close()
+
+		leaderboardStorages["postgres"] = postgres
+		statisticStorages["postgres"] = postgres
+		questStorages["postgres"] = postgres
+	}
+
+	leaderboardStorage, ok := leaderboardStorages[config.LeaderboardStorage]
+	if !ok {
+		zap.Panic(fmt.Errorf("unknown leaderboard storage %q", config.LeaderboardStorage), "storage setup failed")
+	}
+
+	statisticStorage, ok := statisticStorages[config.StatisticStorage]
+	if !ok {
+		zap.Panic(fmt.Errorf("unknown statistic storage %q", config.StatisticStorage), "storage setup failed")
+	}
+
+	questStorage, ok := questStorages[config.QuestStorage]
+	if !ok {
+		zap.Panic(fmt.Errorf("unknown quest storage %q", config.QuestStorage), "storage setup failed")
+	}
+
+	// Webhooks are left out of the game definitions when they're disabled
+	var (
+		listWebhooksFunc  backup.StorageListWebhooksFunc
+		createWebhookFunc webhook.CreateWebhookFunc
+	)
+	if config.WebhooksEnabled {
+		webhookStorage, ok := webhookStorages[config.WebhookStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown webhook storage %q", config.WebhookStorage), "storage setup failed")
+		}
+
+		listWebhooksFunc = webhookStorage.ListWebhooks
+		createWebhookFunc = webhook.BuildCreateWebhookFunc(asyncwebhook.EventTypes, webhookStorage.CreateWebhook)
+	}
+
+	switch command := os.Args[1]; {
+	case command == "export" && len(os.Args) == 4:
+		exportFunc := backup.BuildExportFunc(
+			leaderboardStorage.ListLeaderboards,
+			leaderboardStorage.GetRanking,
+			statisticStorage.ListStatistics,
+			statisticStorage.ListPlayerStatistics,
+			questStorage.ListQuests,
+			questStorage.ListPlayerQuests,
+		)
+
+		if err := exportGame(ctx, exportFunc, os.Args[2], os.Args[3]); err != nil {
+			zap.Panic(err, "game export failed")
+		}
+	case command == "import":
+		diffImportFunc := backup.BuildDiffImportFunc(backup.BuildDiffDefinitionFunc(
+			leaderboardStorage.ListLeaderboards,
+			statisticStorage.ListStatistics,
+			questStorage.ListQuests,
+			listWebhooksFunc,
+		))
+		importFunc := backup.BuildImportFunc(
+			leaderboardStorage.RestoreLeaderboard,
+			leaderboardStorage.UpsertPlayerRankValue,
+			statisticStorage.RestoreStatistic,
+			statisticStorage.RestorePlayerStatistic,
+			questStorage.RestoreQuest,
+			questStorage.RestorePlayerQuest,
+		)
+
+		if err := importGame(ctx, diffImportFunc, importFunc, os.Args[2:]); err != nil {
+			zap.Panic(err, "game import failed")
+		}
+	case command == "export-definition" && len(os.Args) == 4:
+		exportDefinitionFunc := backup.BuildExportDefinitionFunc(
+			leaderboardStorage.ListLeaderboards,
+			statisticStorage.ListStatistics,
+			questStorage.ListQuests,
+			listWebhooksFunc,
+		)
+
+		if err := exportDefinition(ctx, exportDefinitionFunc, os.Args[2], os.Args[3]); err != nil {
+			zap.Panic(err, "game definition export failed")
+		}
+	case command == "import-definition":
+		diffDefinitionFunc := backup.BuildDiffDefinitionFunc(
+			leaderboardStorage.ListLeaderboards,
+			statisticStorage.ListStatistics,
+			questStorage.ListQuests,
+			listWebhooksFunc,
+		)
+		importDefinitionFunc := backup.BuildImportDefinitionFunc(
+			diffDefinitionFunc,
+			leaderboardStorage.RestoreLeaderboard,
+			statisticStorage.RestoreStatistic,
+			questStorage.RestoreQuest,
+			createWebhookFunc,
+		)
+
+		if err := importDefinition(ctx, diffDefinitionFunc, importDefinitionFunc, os.Args[2:]); err != nil {
+			zap.Panic(err, "game definition import failed")
+		}
+	default:
+		zap.Panic(fmt.Errorf("unknown command %q", command), usage)
+	}
+}
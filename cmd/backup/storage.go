@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+type (
+	// Storage drivers that can export and restore leaderboards and rankings
+	leaderboardStorage interface {
+		ListLeaderboards(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error)
+		RestoreLeaderboard(ctx context.Context, lb leaderboard.Leaderboard) error
+		UpsertPlayerRankValue(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error
+		GetRanking(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error)
+	}
+
+	// Storage drivers that can export and restore statistics and players progression
+	statisticStorage interface {
+		ListStatistics(ctx context.Context, gameID string) ([]statistic.Statistic, error)
+		ListPlayerStatistics(ctx context.Context, statisticID string) ([]statistic.PlayerProgression, error)
+		RestoreStatistic(ctx context.Context, st statistic.Statistic) error
+		RestorePlayerStatistic(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression) error
+	}
+
+	// Storage drivers that can export and restore quests and players progression
+	questStorage interface {
+		ListQuests(ctx context.Context, gameID string) ([]quest.Quest, error)
+		ListPlayerQuests(ctx context.Context, q quest.Quest) ([]quest.PlayerQuestProgression, error)
+		RestoreQuest(ctx context.Context, q quest.Quest) error
+		RestorePlayerQuest(ctx context.Context, progression quest.PlayerQuestProgression) error
+	}
+
+	// Storage drivers that can export and create webhooks, used by the game definition commands
+	webhookStorage interface {
+		ListWebhooks(ctx context.Context, gameID string) ([]webhook.Webhook, error)
+		CreateWebhook(ctx context.Context, data webhook.NewWebhookData) (webhook.Webhook, error)
+	}
+)
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/controller/rest"
+)
+
+const requestTimeout = 30 * time.Second
+
+// Error answered by the API, with its error code
+type apiError struct {
+	status   int
+	response rest.ErrorResponse
+}
+
+func (e apiError) Error() string {
+	if e.response.Code == "" {
+		return fmt.Sprintf("api answered %d %s", e.status, http.StatusText(e.status))
+	}
+
+	return fmt.Sprintf("api answered %d %s: %s (code %s)", e.status, http.StatusText(e.status), e.response.Message, e.response.Code)
+}
+
+// Calls the API, sending the game's token to the `/api` routes and the admin one to the `/admin` routes
+type client struct {
+	url        string
+	token      string
+	adminToken string
+	http       *http.Client
+}
+
+func newClient(config Config) *client {
+	return &client{
+		url:        strings.TrimSuffix(config.URL, "/"),
+		token:      config.Token,
+		adminToken: config.AdminToken,
+		http:       &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Sends the body as JSON and decodes the response into out. Both can be nil
+func (c *client) do(ctx context.Context, method, path string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if strings.HasPrefix(path, "/admin/") {
+		req.Header.Set("Authorization", "Bearer "+c.adminToken)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := apiError{status: resp.StatusCode}
+		// Errors raised before the handlers, e.g. by a proxy, may not be JSON
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr.response)
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gabapcia/gameblitz/internal/controller/rest"
+
+	"github.com/spf13/cobra"
+)
+
+func deadLettersCommand(api func() *client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dead-letters",
+		Aliases: []string{"dead-letter", "dlq"},
+		Short:   "Inspect and requeue the ingestion dead letters. Requires the admin token",
+	}
+
+	cmd.AddCommand(
+		listDeadLettersCommand(api),
+		requeueDeadLettersCommand(api),
+	)
+
+	return cmd
+}
+
+func listDeadLettersCommand(api func() *client) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the dead letters, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var deadLetters []rest.DeadLetter
+			if err := api().do(cmd.Context(), http.MethodGet, fmt.Sprintf("/admin/v1/dead-letters?limit=%d", limit), nil, &deadLetters); err != nil {
+				return err
+			}
+
+			return printJSON(cmd.OutOrStdout(), deadLetters)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 100, "Max number of dead letters")
+
+	return cmd
+}
+
+// Requeues every dead letter given, stopping at the first failure. The ones requeued before it are not undone
+func requeueDeadLettersCommand(api func() *client) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "requeue [dead letter id...]",
+		Short: "Publish the dead letters back to the ingestion queue",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := api()
+
+			ids := args
+			if all {
+				if len(args) > 0 {
+					return fmt.Errorf("--all can't be used with dead letter ids")
+				}
+
+				var deadLetters []rest.DeadLetter
+				if err := c.do(cmd.Context(), http.MethodGet, "/admin/v1/dead-letters?limit=1000", nil, &deadLetters); err != nil {
+					return err
+				}
+
+				for _, d := range deadLetters {
+					ids = append(ids, d.ID)
+				}
+			}
+
+			if len(ids) == 0 {
+				return fmt.Errorf("no dead letter to requeue")
+			}
+
+			for _, id := range ids {
+				if err := c.do(cmd.Context(), http.MethodPost, "/admin/v1/dead-letters/"+url.PathEscape(id)+"/requeue", nil, nil); err != nil {
+					return fmt.Errorf("requeue %s: %w", id, err)
+				}
+
+				fmt.Fprintln(cmd.OutOrStdout(), "dead letter requeued:", id)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Requeue up to 1000 of the oldest dead letters")
+
+	return cmd
+}
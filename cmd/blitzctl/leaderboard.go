@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/controller/rest"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/spf13/cobra"
+)
+
+func leaderboardsCommand(api func() *client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "leaderboards",
+		Aliases: []string{"leaderboard", "lb"},
+		Short:   "Manage the game's leaderboards",
+	}
+
+	cmd.AddCommand(
+		createLeaderboardCommand(api),
+		getLeaderboardCommand(api),
+		closeLeaderboardCommand(api),
+		exportRankingCommand(api),
+	)
+
+	return cmd
+}
+
+func createLeaderboardCommand(api func() *client) *cobra.Command {
+	var (
+		body           rest.CreateLeaderboardReq
+		startAt, endAt string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a leaderboard",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body.StartAt = time.Now().UTC()
+			if startAt != "" {
+				t, err := time.Parse(time.RFC3339, startAt)
+				if err != nil {
+					return fmt.Errorf("invalid --start-at: %w", err)
+				}
+				body.StartAt = t
+			}
+
+			if endAt != "" {
+				t, err := time.Parse(time.RFC3339, endAt)
+				if err != nil {
+					return fmt.Errorf("invalid --end-at: %w", err)
+				}
+				body.EndAt = t
+			}
+
+			var lb rest.Leaderboard
+			if err := api().do(cmd.Context(), http.MethodPost, "/api/v1/leaderboards", body, &lb); err != nil {
+				return err
+			}
+
+			return printJSON(cmd.OutOrStdout(), lb)
+		},
+	}
+
+	cmd.Flags().StringVar(&body.Name, "name", "", "Leaderboard name")
+	cmd.Flags().StringVar(&body.Description, "description", "", "Leaderboard description")
+	cmd.Flags().StringVar(&startAt, "start-at", "", "Start time, as RFC 3339. Now when unset")
+	cmd.Flags().StringVar(&endAt, "end-at", "", "End time, as RFC 3339. Never ends when unset")
+	cmd.Flags().StringVar(&body.AggregationMode, "aggregation-mode", leaderboard.AggregationModeMax, "Aggregation mode: INC, MAX or MIN")
+	cmd.Flags().StringVar(&body.Ordering, "ordering", leaderboard.OrderingDesc, "Ranking order: ASC or DESC")
+	_ = cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func getLeaderboardCommand(api func() *client) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <leaderboard id>",
+		Short: "Show a leaderboard",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var lb rest.Leaderboard
+			if err := api().do(cmd.Context(), http.MethodGet, "/api/v1/leaderboards/"+url.PathEscape(args[0]), nil, &lb); err != nil {
+				return err
+			}
+
+			return printJSON(cmd.OutOrStdout(), lb)
+		},
+	}
+}
+
+func closeLeaderboardCommand(api func() *client) *cobra.Command {
+	return &cobra.Command{
+		Use:   "close <leaderboard id>",
+		Short: "Close a leaderboard to new submissions",
+		Long: "Close a leaderboard to new submissions. The API closes a leaderboard by soft deleting it, so it's no " +
+			"longer readable and its ranking is purged once SOFT_DELETE_RETENTION passes, when set. Export the ranking first to keep it.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := api().do(cmd.Context(), http.MethodDelete, "/api/v1/leaderboards/"+url.PathEscape(args[0]), nil, nil); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "leaderboard closed:", args[0])
+			return nil
+		},
+	}
+}
+
+// Pages through the whole ranking. The ranking can change while it's exported, so a player may be
+// skipped or repeated across pages on a leaderboard still receiving submissions
+func exportRankingCommand(api func() *client) *cobra.Command {
+	var format, output string
+
+	cmd := &cobra.Command{
+		Use:   "export <leaderboard id>",
+		Short: "Export a leaderboard's ranking as CSV or JSON",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "csv" && format != "json" {
+				return fmt.Errorf("invalid --format %q: must be csv or json", format)
+			}
+
+			var (
+				c       = api()
+				ranking = make([]rest.Rank, 0)
+			)
+
+			for page := 0; ; page++ {
+				var ranks []rest.Rank
+				path := fmt.Sprintf("/api/v1/leaderboards/%s/ranking?page=%d&limit=%d", url.PathEscape(args[0]), page, leaderboard.MaxLimitNumber)
+				if err := c.do(cmd.Context(), http.MethodGet, path, nil, &ranks); err != nil {
+					return err
+				}
+
+				ranking = append(ranking, ranks...)
+				if len(ranks) < leaderboard.MaxLimitNumber {
+					break
+				}
+			}
+
+			var w io.Writer = cmd.OutOrStdout()
+			if output != "" {
+				file, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				w = file
+			}
+
+			if format == "json" {
+				return printJSON(w, ranking)
+			}
+
+			return writeRankingCSV(w, ranking)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "csv", "Output format: csv or json")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file. Stdout when unset")
+
+	return cmd
+}
+
+func writeRankingCSV(w io.Writer, ranking []rest.Rank) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"position", "playerId", "value"}); err != nil {
+		return err
+	}
+
+	for _, rank := range ranking {
+		record := []string{
+			strconv.FormatInt(rank.Position, 10),
+			rank.PlayerID,
+			strconv.FormatFloat(rank.Value, 'f', -1, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
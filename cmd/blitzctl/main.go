@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/spf13/cobra"
+)
+
+// Defaults of the global flags
+type Config struct {
+	URL        string `envconfig:"BLITZCTL_URL" required:"false" default:"http://localhost:8080"`
+	Token      string `envconfig:"BLITZCTL_TOKEN" required:"false"`
+	AdminToken string `envconfig:"BLITZCTL_ADMIN_TOKEN" required:"false"`
+}
+
+// Prints the API response as indented JSON
+func printJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func rootCommand(config *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "blitzctl",
+		Short:         "Operate the Game Blitz API",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.PersistentFlags().StringVar(&config.URL, "url", config.URL, "API base URL [BLITZCTL_URL]")
+	cmd.PersistentFlags().StringVar(&config.Token, "token", config.Token, "Game's JWT, for the game's resources [BLITZCTL_TOKEN]")
+	cmd.PersistentFlags().StringVar(&config.AdminToken, "admin-token", config.AdminToken, "Admin token, for the admin operations [BLITZCTL_ADMIN_TOKEN]")
+
+	// The client is only built once the flags are parsed
+	api := func() *client { return newClient(*config) }
+
+	cmd.AddCommand(
+		leaderboardsCommand(api),
+		statisticsCommand(api),
+		deadLettersCommand(api),
+		playersCommand(api),
+		purgeCommand(api),
+	)
+
+	return cmd
+}
+
+func main() {
+	var config Config
+	if err := envconfig.Process("", &config); err != nil {
+		fmt.Fprintln(os.Stderr, "env load failed:", err)
+		os.Exit(1)
+	}
+
+	if err := rootCommand(&config).Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
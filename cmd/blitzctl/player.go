@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/controller/rest"
+
+	"github.com/spf13/cobra"
+)
+
+func playersCommand(api func() *client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "players",
+		Aliases: []string{"player"},
+		Short:   "Manage the game's players data",
+	}
+
+	cmd.AddCommand(
+		anonymizePlayersCommand(api),
+	)
+
+	return cmd
+}
+
+// Reads one player ID per line, skipping the blank ones. `-` reads from the standard input
+func readPlayerIDs(cmd *cobra.Command, path string) ([]string, error) {
+	var r io.Reader = cmd.InOrStdin()
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	ids := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, scanner.Err()
+}
+
+// Anonymizes every player given, meant to be run by the data retention jobs. A failure doesn't stop the
+// players after it, so a single run anonymizes as many players as possible. The failed ones can be run again.
+// A dry run prints the records each anonymization would change instead
+func anonymizePlayersCommand(api func() *client) *cobra.Command {
+	var (
+		file   string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "anonymize [player id...]",
+		Short: "Replace the players IDs by pseudonyms, keeping their ranks and progression",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids := args
+			if file != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("--file can't be used with player ids")
+				}
+
+				var err error
+				if ids, err = readPlayerIDs(cmd, file); err != nil {
+					return err
+				}
+			}
+
+			if len(ids) == 0 {
+				return fmt.Errorf("no player to anonymize")
+			}
+
+			c := api()
+
+			failed := 0
+			for _, id := range ids {
+				path := "/api/v1/players/" + url.PathEscape(id) + "/anonymize"
+
+				var result any = &rest.PlayerAnonymizationReceipt{}
+				if dryRun {
+					path += "?dryRun=true"
+					result = &rest.PlayerDataPreview{}
+				}
+
+				if err := c.do(cmd.Context(), http.MethodPost, path, nil, result); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "anonymize %s: %s\n", id, err)
+					failed++
+					continue
+				}
+
+				if err := printJSON(cmd.OutOrStdout(), result); err != nil {
+					return err
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d players not anonymized", failed, len(ids))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "File with one player id per line, or - for the standard input")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the records each anonymization would change")
+
+	return cmd
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/controller/rest"
+
+	"github.com/spf13/cobra"
+)
+
+// Purges the soft deleted records of the game right away, instead of waiting for the periodic purge.
+// A dry run prints the records the purge would remove instead
+func purgeCommand(api func() *client) *cobra.Command {
+	var (
+		retention time.Duration
+		dryRun    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "purge <game id>",
+		Short: "Permanently remove the game's records soft deleted for longer than the retention. Requires the admin token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := url.Values{"retention": {retention.String()}}
+			if dryRun {
+				query.Set("dryRun", "true")
+			}
+
+			var report rest.PurgeReport
+			if err := api().do(cmd.Context(), http.MethodPost, "/admin/v1/purge/"+url.PathEscape(args[0])+"?"+query.Encode(), nil, &report); err != nil {
+				return fmt.Errorf("purge %s: %w", args[0], err)
+			}
+
+			return printJSON(cmd.OutOrStdout(), report)
+		},
+	}
+
+	cmd.Flags().DurationVar(&retention, "retention", 30*24*time.Hour, "Minimum time the records have been soft deleted for")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the records the purge would remove")
+
+	return cmd
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gabapcia/gameblitz/internal/controller/rest"
+
+	"github.com/spf13/cobra"
+)
+
+func statisticsCommand(api func() *client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "statistics",
+		Aliases: []string{"statistic", "stat"},
+		Short:   "Inspect the game's statistics",
+	}
+
+	cmd.AddCommand(
+		listStatisticsCommand(api),
+		getStatisticCommand(api),
+	)
+
+	return cmd
+}
+
+func listStatisticsCommand(api func() *client) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the game's statistics, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var statistics []rest.Statistic
+			if err := api().do(cmd.Context(), http.MethodGet, "/api/v1/statistics", nil, &statistics); err != nil {
+				return err
+			}
+
+			return printJSON(cmd.OutOrStdout(), statistics)
+		},
+	}
+}
+
+func getStatisticCommand(api func() *client) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <statistic id>",
+		Short: "Show a statistic",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var statistic rest.Statistic
+			if err := api().do(cmd.Context(), http.MethodGet, "/api/v1/statistics/"+url.PathEscape(args[0]), nil, &statistic); err != nil {
+				return err
+			}
+
+			return printJSON(cmd.OutOrStdout(), statistic)
+		},
+	}
+}
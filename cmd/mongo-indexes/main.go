@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/mongo"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type Config struct {
+	MongoURI string `envconfig:"MONGO_URI" required:"true"`
+	MongoDB  string `envconfig:"MONGO_DB" required:"true"`
+
+	VerifyOnly bool `envconfig:"VERIFY_ONLY" required:"false" default:"false"`
+}
+
+func main() {
+	zap.Start()
+	defer zap.Sync()
+
+	var config Config
+	if err := envconfig.Process("", &config); err != nil {
+		zap.Panic(err, "env load failed")
+	}
+
+	ctx := context.Background()
+
+	mongo, err := mongo.New(ctx, mongo.Options{URI: config.MongoURI, DB: config.MongoDB})
+	if err != nil {
+		zap.Panic(err, "mongo startup failed")
+	}
+	defer mongo.Close(context.Background())
+
+	if config.VerifyOnly {
+		if err := mongo.VerifyIndexes(ctx); err != nil {
+			zap.Panic(err, "mongo indexes verification failed")
+		}
+
+		zap.Info("mongo indexes verified")
+		return
+	}
+
+	if err := mongo.EnsureIndexes(ctx); err != nil {
+		zap.Panic(err, "mongo indexes creation failed")
+	}
+
+	zap.Info("mongo indexes created")
+}
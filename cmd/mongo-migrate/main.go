@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/mongo"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type Config struct {
+	MongoURI                            string `envconfig:"MONGO_URI" required:"true"`
+	MongoDB                             string `envconfig:"MONGO_DB" required:"true"`
+	MongoProgressionMigrationCollection string `envconfig:"MONGO_PROGRESSION_MIGRATION_COLLECTION" required:"false" default:"playersStatisticsV2"`
+}
+
+const usage = "usage: mongo-migrate [up|status|progression-backfill|progression-verify]"
+
+func main() {
+	zap.Start()
+	defer zap.Sync()
+
+	var config Config
+	if err := envconfig.Process("", &config); err != nil {
+		zap.Panic(err, "env load failed")
+	}
+
+	command := "up"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	ctx := context.Background()
+
+	mongo, err := mongo.New(ctx, mongo.Options{
+		URI:                            config.MongoURI,
+		DB:                             config.MongoDB,
+		ProgressionMigrationCollection: config.MongoProgressionMigrationCollection,
+	})
+	if err != nil {
+		zap.Panic(err, "mongo startup failed")
+	}
+	defer mongo.Close(context.Background())
+
+	switch command {
+	case "up":
+		applied, err := mongo.Migrate(ctx)
+		if err != nil {
+			zap.Panic(err, "mongo migration failed", "applied", applied)
+		}
+
+		zap.Info("mongo migrations applied", "applied", applied)
+	case "status":
+		status, err := mongo.MigrationStatus(ctx)
+		if err != nil {
+			zap.Panic(err, "mongo migration status failed")
+		}
+
+		for _, migration := range status {
+			zap.Info(
+				"mongo migration",
+				"version", migration.Version,
+				"description", migration.Description,
+				"applied", migration.Applied,
+				"appliedAt", migration.AppliedAt,
+			)
+		}
+	case "progression-backfill":
+		copied, err := mongo.BackfillProgressionMigration(ctx)
+		if err != nil {
+			zap.Panic(err, "progression backfill failed", "copied", copied)
+		}
+
+		zap.Info("progression backfill done", "target", config.MongoProgressionMigrationCollection, "copied", copied)
+	case "progression-verify":
+		verification, err := mongo.VerifyProgressionMigration(ctx)
+		if err != nil && verification.Consistent() {
+			zap.Panic(err, "progression verification failed")
+		}
+
+		for _, sample := range verification.Samples {
+			zap.Info("progression mismatch", "progression", sample)
+		}
+
+		kv := []any{
+			"source", verification.Source,
+			"target", verification.Target,
+			"compared", verification.Compared,
+			"missingOnTarget", verification.MissingOnTarget,
+			"missingOnSource", verification.MissingOnSource,
+			"mismatched", verification.Mismatched,
+		}
+		if err != nil {
+			zap.Panic(err, "progression collections differ", kv...)
+		}
+
+		zap.Info("progression collections match", kv...)
+	default:
+		zap.Panic(fmt.Errorf("unknown command %q", command), usage)
+	}
+}
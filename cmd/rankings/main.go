@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/mongo"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/redis"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type Config struct {
+	PotgresDSN string `envconfig:"POSTGRESQL_DSN" required:"false"`
+
+	MongoURI string `envconfig:"MONGO_URI" required:"false"`
+	MongoDB  string `envconfig:"MONGO_DB" required:"false"`
+
+	RedisAddrs      []string `envconfig:"REDIS_ADDR" required:"false"`
+	RedisUsername   string   `envconfig:"REDIS_USERNAME" required:"false"`
+	RedisPassword   string   `envconfig:"REDIS_PASSWORD" required:"false"`
+	RedisDB         int      `envconfig:"REDIS_DB" required:"false"`
+	RedisCluster    bool     `envconfig:"REDIS_CLUSTER" required:"false" default:"false"`
+	RedisGameRoutes []string `envconfig:"REDIS_GAME_ROUTES" required:"false"`
+
+	LeaderboardStorage  string `envconfig:"LEADERBOARD_STORAGE" required:"false" default:"redis"`
+	ScoreHistoryStorage string `envconfig:"SCORE_HISTORY_STORAGE" required:"false" default:"mongo"`
+
+	RankingCacheEnabled bool   `envconfig:"RANKING_CACHE_ENABLED" required:"false" default:"false"`
+	RankingCacheStorage string `envconfig:"RANKING_CACHE_STORAGE" required:"false" default:"redis"`
+
+	TopViewEnabled bool   `envconfig:"TOP_VIEW_ENABLED" required:"false" default:"false"`
+	TopViewStorage string `envconfig:"TOP_VIEW_STORAGE" required:"false" default:"redis"`
+}
+
+// Checks if any domain is configured to use the given storage
+func (c Config) usesStorage(storage string) bool {
+	storages := []string{c.LeaderboardStorage, c.ScoreHistoryStorage}
+	if c.RankingCacheEnabled {
+		storages = append(storages, c.RankingCacheStorage)
+	}
+
+	if c.TopViewEnabled {
+		storages = append(storages, c.TopViewStorage)
+	}
+
+	return slices.Contains(storages, storage)
+}
+
+const usage = "usage: rankings recompute [-dry-run] <game id> <leaderboard id>"
+
+// The dry run rebuilds the ranking from the score history the same way, but drops it instead of replacing the current one
+func recomputeRanking(
+	ctx context.Context,
+	getLeaderboardFunc leaderboard.GetByIDAndGameIDFunc,
+	storageListScoresFunc leaderboard.StorageListScoresFunc,
+	storageReplaceRankingFunc leaderboard.StorageReplaceRankingFunc,
+	args []string,
+) error {
+	flags := flag.NewFlagSet("recompute", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Only count the scores and players the ranking would be rebuilt from")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 2 {
+		return errors.New(usage)
+	}
+	gameID, leaderboardID := flags.Arg(0), flags.Arg(1)
+
+	if *dryRun {
+		storageReplaceRankingFunc = func(ctx context.Context, lb leaderboard.Leaderboard, ranks []leaderboard.RebuiltRank) error {
+			return nil
+		}
+	}
+
+	lb, err := getLeaderboardFunc(ctx, leaderboardID, gameID)
+	if err != nil {
+		return err
+	}
+
+	recomputation, err := leaderboard.BuildRecomputeRankingFunc(storageListScoresFunc, storageReplaceRankingFunc)(ctx, lb)
+	if err != nil {
+		return err
+	}
+
+	zap.Info(
+		"ranking recomputed",
+		"gameId", gameID,
+		"leaderboardId", leaderboardID,
+		"dryRun", *dryRun,
+		"aggregationMode", lb.AggregationMode,
+		"scores", recomputation.Scores,
+		"players", recomputation.Players,
+	)
+
+	return nil
+}
+
+func main() {
+	zap.Start()
+	defer zap.Sync()
+
+	var config Config
+	if err := envconfig.Process("", &config); err != nil {
+		zap.Panic(err, "env load failed")
+	}
+
+	if len(os.Args) < 2 {
+		zap.Panic(fmt.Errorf("missing command"), usage)
+	}
+
+	ctx := context.Background()
+
+	var (
+		leaderboardStorages  = map[string]leaderboardStorage{}
+		scoreHistoryStorages = map[string]scoreHistoryStorage{}
+		rankingCacheStorages = map[string]rankingCacheStorage{}
+		topViewStorages      = map[string]topViewStorage{}
+	)
+
+	if config.usesStorage("redis") {
+		gameRoutes, err := redis.ParseGameRoutes(config.RedisGameRoutes)
+		if err != nil {
+			zap.Panic(err, "invalid redis game routes")
+		}
+
+		redis, err := redis.New(ctx, redis.Options{
+			Addrs:      config.RedisAddrs,
+			Username:   config.RedisUsername,
+			Password:   config.RedisPassword,
+			DB:         config.RedisDB,
+			Cluster:    config.RedisCluster,
+			GameRoutes: gameRoutes,
+		})
+		if err != nil {
+			zap.Panic(err, "redis startup failed")
+		}
+		defer redis.Close()
+
+		leaderboardStorages["redis"] = redis
+		rankingCacheStorages["redis"] = redis
+		topViewStorages["redis"] = redis
+	}
+
+	if config.usesStorage("mongo") {
+		mongo, err := mongo.New(ctx, mongo.Options{URI: config.MongoURI, DB: config.MongoDB})
+		if err != nil {
+			zap.Panic(err, "mongo startup failed")
+		}
+		defer mongo.Close(context.Background())
+
+		scoreHistoryStorages["mongo"] = mongo
+	}
+
+	if config.usesStorage("postgres") {
+		postgres, err := postgres.New(ctx, postgres.Options{DSN: config.PotgresDSN})
+		if err != nil {
+			zap.Panic(err, "postgres startup failed")
+		}
+		defer postgres.Close()
+
+		leaderboardStorages["postgres"] = postgres
+		scoreHistoryStorages["postgres"] = postgres
+	}
+
+	leaderboardStorage, ok := leaderboardStorages[config.LeaderboardStorage]
+	if !ok {
+		zap.Panic(fmt.Errorf("unknown leaderboard storage %q", config.LeaderboardStorage), "storage setup failed")
+	}
+
+	scoreHistoryStorage, ok := scoreHistoryStorages[config.ScoreHistoryStorage]
+	if !ok {
+		zap.Panic(fmt.Errorf("unknown score history storage %q", config.ScoreHistoryStorage), "storage setup failed")
+	}
+
+	// The API instances serve the cached top positions and the top views until they're dropped, so the replaced ranking
+	// drops them too. The memory ones are only reachable from their own instance
+	replaceRankingFunc := leaderboard.StorageReplaceRankingFunc(leaderboardStorage.ReplaceRanking)
+	if config.RankingCacheEnabled {
+		rankingCacheStorage, ok := rankingCacheStorages[config.RankingCacheStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown ranking cache storage %q", config.RankingCacheStorage), "storage setup failed")
+		}
+
+		replaceRankingFunc = leaderboard.BuildInvalidateCachedTopOnReplaceFunc(rankingCacheStorage.InvalidateCachedTop, replaceRankingFunc)
+	}
+
+	if config.TopViewEnabled {
+		topViewStorage, ok := topViewStorages[config.TopViewStorage]
+		if !ok {
+			zap.Panic(fmt.Errorf("unknown top view storage %q", config.TopViewStorage), "storage setup failed")
+		}
+
+		replaceRankingFunc = leaderboard.BuildDropTopViewOnReplaceFunc(topViewStorage.DropTopView, replaceRankingFunc)
+	}
+
+	switch command := os.Args[1]; {
+	case command == "recompute":
+		getLeaderboardFunc := leaderboard.BuildGetByIDAndGameIDFunc(leaderboardStorage.GetLeaderboardByIDAndGameID)
+
+		if err := recomputeRanking(ctx, getLeaderboardFunc, scoreHistoryStorage.ListScores, replaceRankingFunc, os.Args[2:]); err != nil {
+			zap.Panic(err, "ranking recompute failed")
+		}
+	default:
+		zap.Panic(fmt.Errorf("unknown command %q", command), usage)
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type (
+	// Storage drivers that can replace a leaderboard's ranking
+	leaderboardStorage interface {
+		GetLeaderboardByIDAndGameID(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error)
+		ReplaceRanking(ctx context.Context, lb leaderboard.Leaderboard, ranks []leaderboard.RebuiltRank) error
+	}
+
+	// Storage drivers that can drop the cached top positions of a leaderboard's ranking
+	rankingCacheStorage interface {
+		InvalidateCachedTop(ctx context.Context, lb leaderboard.Leaderboard) error
+	}
+
+	// Storage drivers that can drop the top view of a leaderboard's ranking
+	topViewStorage interface {
+		DropTopView(ctx context.Context, lb leaderboard.Leaderboard) error
+	}
+
+	// Storage drivers that can list the scores submitted to a leaderboard
+	scoreHistoryStorage interface {
+		ListScores(ctx context.Context, leaderboardID string, after leaderboard.Score, limit int) ([]leaderboard.Score, error)
+	}
+)
@@ -0,0 +1,342 @@
+package achievement
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Progressions that unlock an achievement
+const (
+	TriggerStatisticLandmark = "STATISTIC_LANDMARK" // The player reaches a landmark of a statistic
+	TriggerQuestCompleted    = "QUEST_COMPLETED"    // The player completes a quest
+)
+
+var TriggerKinds = []string{
+	TriggerStatisticLandmark,
+	TriggerQuestCompleted,
+}
+
+var (
+	ErrValidationError    = errors.New("validation error")
+	ErrInvalidName        = errors.New("invalid name")
+	ErrInvalidGameID      = errors.New("invalid game id")
+	ErrInvalidTrigger     = errors.New("trigger kind must be one of STATISTIC_LANDMARK or QUEST_COMPLETED")
+	ErrMissingStatisticID = errors.New("the statistic landmark trigger requires a statistic id")
+	ErrMissingQuestID     = errors.New("the quest completed trigger requires a quest id")
+	ErrUnknownLandmark    = errors.New("the landmark is not one of the statistic's landmarks")
+
+	ErrInvalidAchievementID = errors.New("invalid achievement id")
+	ErrAchievementNotFound  = errors.New("achievement not found")
+	ErrInvalidPlayerID      = errors.New("invalid player id")
+)
+
+type (
+	// Progression that unlocks an achievement. Only the fields of its kind are set
+	Trigger struct {
+		Kind        string  // Trigger kind, one of `STATISTIC_LANDMARK` or `QUEST_COMPLETED`
+		StatisticID string  // Statistic whose landmark unlocks the achievement
+		Landmark    float64 // Landmark of the statistic that unlocks the achievement
+		QuestID     string  // Quest whose completion unlocks the achievement
+	}
+
+	NewAchievementData struct {
+		GameID      string  // Game the achievement belongs to
+		Name        string  // Achievement name
+		Description string  // Achievement details
+		Trigger     Trigger // Progression that unlocks the achievement
+	}
+
+	Achievement struct {
+		CreatedAt   time.Time // Time the achievement was created
+		UpdatedAt   time.Time // Last time the achievement was changed
+		ID          string    // Achievement ID, assigned by the storage
+		GameID      string    // Game the achievement belongs to
+		Name        string    // Achievement name
+		Description string    // Achievement details
+		Trigger     Trigger   // Progression that unlocks the achievement
+	}
+
+	// Achievement unlocked by a player, as kept on the storage
+	Unlock struct {
+		AchievementID string    // Achievement unlocked
+		GameID        string    // Game the achievement belongs to
+		PlayerID      string    // Player that unlocked it
+		UnlockedAt    time.Time // Time the player made the progression that unlocked it
+	}
+
+	// Achievement unlocked by a player, alongside its details
+	PlayerAchievement struct {
+		Achievement Achievement // Achievement unlocked
+		PlayerID    string      // Player that unlocked it
+		UnlockedAt  time.Time   // Time the player made the progression that unlocked it
+	}
+
+	// Progression made by a player, matched against the triggers of its game's achievements
+	TriggerEvent struct {
+		OccurredAt  time.Time // Time the player made the progression
+		Kind        string    // Trigger kind, one of `STATISTIC_LANDMARK` or `QUEST_COMPLETED`
+		GameID      string    // Game the player belongs to
+		PlayerID    string    // Player's ID
+		StatisticID string    // Statistic whose landmark was reached. Only set on `STATISTIC_LANDMARK`
+		Landmark    float64   // Landmark reached. Only set on `STATISTIC_LANDMARK`
+		QuestID     string    // Quest completed. Only set on `QUEST_COMPLETED`
+	}
+)
+
+func (d NewAchievementData) validate() error {
+	errList := make([]error, 0)
+
+	if d.Name == "" {
+		errList = append(errList, ErrInvalidName)
+	}
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	switch d.Trigger.Kind {
+	case TriggerStatisticLandmark:
+		if d.Trigger.StatisticID == "" {
+			errList = append(errList, ErrMissingStatisticID)
+		}
+	case TriggerQuestCompleted:
+		if d.Trigger.QuestID == "" {
+			errList = append(errList, ErrMissingQuestID)
+		}
+	default:
+		errList = append(errList, ErrInvalidTrigger)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Keeps only the fields of the trigger's kind
+func (t Trigger) normalize() Trigger {
+	switch t.Kind {
+	case TriggerStatisticLandmark:
+		return Trigger{Kind: t.Kind, StatisticID: t.StatisticID, Landmark: t.Landmark}
+	case TriggerQuestCompleted:
+		return Trigger{Kind: t.Kind, QuestID: t.QuestID}
+	}
+
+	return t
+}
+
+func (t Trigger) matches(event TriggerEvent) bool {
+	switch t.Kind {
+	case TriggerStatisticLandmark:
+		return event.Kind == t.Kind && event.StatisticID == t.StatisticID && event.Landmark == t.Landmark
+	case TriggerQuestCompleted:
+		return event.Kind == t.Kind && event.QuestID == t.QuestID
+	}
+
+	return false
+}
+
+// The statistic or quest the trigger refers to must belong to the game, and the landmark must be one of the statistic's
+func BuildCreateFunc(getStatisticFunc statistic.GetByIDAndGameIDFunc, getQuestFunc quest.GetQuestByIDAndGameIDFunc, storageCreateAchievementFunc StorageCreateAchievementFunc) CreateFunc {
+	return func(ctx context.Context, data NewAchievementData) (Achievement, error) {
+		if err := data.validate(); err != nil {
+			return Achievement{}, err
+		}
+
+		data.Trigger = data.Trigger.normalize()
+		switch data.Trigger.Kind {
+		case TriggerStatisticLandmark:
+			s, err := getStatisticFunc(ctx, data.Trigger.StatisticID, data.GameID)
+			if err != nil {
+				return Achievement{}, err
+			}
+
+			if !slices.Contains(s.Landmarks, data.Trigger.Landmark) {
+				return Achievement{}, ErrUnknownLandmark
+			}
+		case TriggerQuestCompleted:
+			if _, err := getQuestFunc(ctx, data.Trigger.QuestID, data.GameID); err != nil {
+				return Achievement{}, err
+			}
+		}
+
+		return storageCreateAchievementFunc(ctx, data)
+	}
+}
+
+func BuildGetByIDAndGameIDFunc(storageGetAchievementFunc StorageGetAchievementFunc) GetByIDAndGameIDFunc {
+	return func(ctx context.Context, id, gameID string) (Achievement, error) {
+		if id == "" {
+			return Achievement{}, ErrInvalidAchievementID
+		}
+
+		return storageGetAchievementFunc(ctx, id, gameID)
+	}
+}
+
+func BuildListFunc(storageListAchievementsFunc StorageListAchievementsFunc) ListFunc {
+	return func(ctx context.Context, gameID string) ([]Achievement, error) {
+		return storageListAchievementsFunc(ctx, gameID)
+	}
+}
+
+func BuildDeleteFunc(storageDeleteAchievementFunc StorageDeleteAchievementFunc) DeleteFunc {
+	return func(ctx context.Context, id, gameID string) error {
+		if id == "" {
+			return ErrInvalidAchievementID
+		}
+
+		return storageDeleteAchievementFunc(ctx, id, gameID)
+	}
+}
+
+func BuildListPlayerAchievementsFunc(storageListAchievementsFunc StorageListAchievementsFunc, storageListUnlocksFunc StorageListUnlocksFunc) ListPlayerAchievementsFunc {
+	return func(ctx context.Context, gameID, playerID string) ([]PlayerAchievement, error) {
+		if playerID == "" {
+			return nil, ErrInvalidPlayerID
+		}
+
+		unlocks, err := storageListUnlocksFunc(ctx, gameID, playerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(unlocks) == 0 {
+			return make([]PlayerAchievement, 0), nil
+		}
+
+		achievements, err := storageListAchievementsFunc(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		byID := make(map[string]Achievement, len(achievements))
+		for _, a := range achievements {
+			byID[a.ID] = a
+		}
+
+		unlocked := make([]PlayerAchievement, 0, len(unlocks))
+		for _, unlock := range unlocks {
+			// Deleting an achievement removes its unlocks, but one may still be listed while it's being deleted
+			a, ok := byID[unlock.AchievementID]
+			if !ok {
+				continue
+			}
+
+			unlocked = append(unlocked, PlayerAchievement{Achievement: a, PlayerID: unlock.PlayerID, UnlockedAt: unlock.UnlockedAt})
+		}
+
+		return unlocked, nil
+	}
+}
+
+// Unlocking an achievement the player already has is a no-op, so the same event can be handled again safely.
+// Each achievement just unlocked is notified once it's recorded. The notifier is optional
+func BuildUnlockFunc(notifierAchievementUnlocked NotifierAchievementUnlocked, storageListAchievementsFunc StorageListAchievementsFunc, storageUnlockAchievementsFunc StorageUnlockAchievementsFunc) UnlockFunc {
+	return func(ctx context.Context, event TriggerEvent) ([]PlayerAchievement, error) {
+		achievements, err := storageListAchievementsFunc(ctx, event.GameID)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			triggered = make(map[string]Achievement)
+			unlocks   = make([]Unlock, 0)
+		)
+		for _, a := range achievements {
+			if !a.Trigger.matches(event) {
+				continue
+			}
+
+			triggered[a.ID] = a
+			unlocks = append(unlocks, Unlock{
+				AchievementID: a.ID,
+				GameID:        event.GameID,
+				PlayerID:      event.PlayerID,
+				UnlockedAt:    event.OccurredAt,
+			})
+		}
+
+		if len(unlocks) == 0 {
+			return nil, nil
+		}
+
+		recorded, err := storageUnlockAchievementsFunc(ctx, unlocks)
+		if err != nil {
+			return nil, err
+		}
+
+		unlocked := make([]PlayerAchievement, len(recorded))
+		for i, unlock := range recorded {
+			unlocked[i] = PlayerAchievement{Achievement: triggered[unlock.AchievementID], PlayerID: unlock.PlayerID, UnlockedAt: unlock.UnlockedAt}
+
+			if notifierAchievementUnlocked != nil {
+				if err := notifierAchievementUnlocked(ctx, unlocked[i]); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		return unlocked, nil
+	}
+}
+
+// Wraps the statistic progression notifier to also unlock the achievements of the landmarks the player reached.
+// The wrapped notifier can be nil, e.g. when the updates are recorded on an outbox, and the achievements are
+// unlocked only after it succeeds
+func BuildNotifierStatisticProgressionUpdates(next statistic.NotifierPlayerProgressionUpdates, unlockFunc UnlockFunc) statistic.NotifierPlayerProgressionUpdates {
+	return func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+		if next != nil {
+			if err := next(ctx, s, progression, updates); err != nil {
+				return err
+			}
+		}
+
+		for _, landmark := range updates.LandmarksJustCompleted {
+			_, err := unlockFunc(ctx, TriggerEvent{
+				OccurredAt:  landmark.CompletedAt,
+				Kind:        TriggerStatisticLandmark,
+				GameID:      s.GameID,
+				PlayerID:    progression.PlayerID,
+				StatisticID: s.ID,
+				Landmark:    landmark.Value,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Wraps the quest lifecycle notifier to also unlock the achievements of the quests the player completed.
+// The wrapped notifier can be nil, and the achievements are unlocked only after it succeeds
+func BuildNotifierQuestLifecycleEvent(next quest.NotifierLifecycleEvent, unlockFunc UnlockFunc) quest.NotifierLifecycleEvent {
+	return func(ctx context.Context, event quest.LifecycleEvent) error {
+		if next != nil {
+			if err := next(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if event.Event != quest.EventQuestCompleted {
+			return nil
+		}
+
+		_, err := unlockFunc(ctx, TriggerEvent{
+			OccurredAt: event.OccurredAt,
+			Kind:       TriggerQuestCompleted,
+			GameID:     event.GameID,
+			PlayerID:   event.PlayerID,
+			QuestID:    event.QuestID,
+		})
+		return err
+	}
+}
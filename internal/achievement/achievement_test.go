@@ -0,0 +1,213 @@
+package achievement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCreateFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		getStatistic = func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+			return statistic.Statistic{ID: id, GameID: gameID, Landmarks: []float64{10, 100}}, nil
+		}
+		getQuest = func(ctx context.Context, id, gameID string) (quest.Quest, error) {
+			return quest.Quest{ID: id, GameID: gameID}, nil
+		}
+		create = func(ctx context.Context, data NewAchievementData) (Achievement, error) {
+			return Achievement{ID: uuid.NewString(), GameID: data.GameID, Name: data.Name, Trigger: data.Trigger}, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		a, err := BuildCreateFunc(getStatistic, getQuest, create)(ctx, NewAchievementData{
+			GameID:  "game",
+			Name:    "Hundred Kills",
+			Trigger: Trigger{Kind: TriggerStatisticLandmark, StatisticID: "kills", Landmark: 100, QuestID: "ignored"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, Trigger{Kind: TriggerStatisticLandmark, StatisticID: "kills", Landmark: 100}, a.Trigger)
+
+		a, err = BuildCreateFunc(getStatistic, getQuest, create)(ctx, NewAchievementData{
+			GameID:  "game",
+			Name:    "First Steps",
+			Trigger: Trigger{Kind: TriggerQuestCompleted, QuestID: "tutorial"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, Trigger{Kind: TriggerQuestCompleted, QuestID: "tutorial"}, a.Trigger)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildCreateFunc(nil, nil, nil)(ctx, NewAchievementData{Trigger: Trigger{Kind: "LEVEL"}})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidName)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrInvalidTrigger)
+
+		_, err = BuildCreateFunc(nil, nil, nil)(ctx, NewAchievementData{GameID: "game", Name: "a", Trigger: Trigger{Kind: TriggerQuestCompleted}})
+		assert.ErrorIs(t, err, ErrMissingQuestID)
+	})
+
+	t.Run("Unknown Landmark", func(t *testing.T) {
+		_, err := BuildCreateFunc(getStatistic, getQuest, create)(ctx, NewAchievementData{
+			GameID:  "game",
+			Name:    "Fifty Kills",
+			Trigger: Trigger{Kind: TriggerStatisticLandmark, StatisticID: "kills", Landmark: 50},
+		})
+		assert.ErrorIs(t, err, ErrUnknownLandmark)
+	})
+
+	t.Run("Quest Not Found", func(t *testing.T) {
+		getQuest := func(ctx context.Context, id, gameID string) (quest.Quest, error) {
+			return quest.Quest{}, quest.ErrQuestNotFound
+		}
+
+		_, err := BuildCreateFunc(getStatistic, getQuest, create)(ctx, NewAchievementData{
+			GameID:  "game",
+			Name:    "First Steps",
+			Trigger: Trigger{Kind: TriggerQuestCompleted, QuestID: "tutorial"},
+		})
+		assert.ErrorIs(t, err, quest.ErrQuestNotFound)
+	})
+}
+
+func TestBuildUnlockFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		now          = time.Now().UTC()
+		achievements = []Achievement{
+			{ID: "kills-10", GameID: "game", Trigger: Trigger{Kind: TriggerStatisticLandmark, StatisticID: "kills", Landmark: 10}},
+			{ID: "kills-100", GameID: "game", Trigger: Trigger{Kind: TriggerStatisticLandmark, StatisticID: "kills", Landmark: 100}},
+			{ID: "tutorial", GameID: "game", Trigger: Trigger{Kind: TriggerQuestCompleted, QuestID: "tutorial"}},
+		}
+		list = func(ctx context.Context, gameID string) ([]Achievement, error) { return achievements, nil }
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var notified []PlayerAchievement
+		unlock := BuildUnlockFunc(
+			func(ctx context.Context, unlocked PlayerAchievement) error {
+				notified = append(notified, unlocked)
+				return nil
+			},
+			list,
+			func(ctx context.Context, unlocks []Unlock) ([]Unlock, error) { return unlocks, nil },
+		)
+
+		unlocked, err := unlock(ctx, TriggerEvent{OccurredAt: now, Kind: TriggerStatisticLandmark, GameID: "game", PlayerID: "player", StatisticID: "kills", Landmark: 100})
+		assert.NoError(t, err)
+		assert.Equal(t, []PlayerAchievement{{Achievement: achievements[1], PlayerID: "player", UnlockedAt: now}}, unlocked)
+		assert.Equal(t, unlocked, notified)
+	})
+
+	t.Run("Already Unlocked", func(t *testing.T) {
+		unlock := BuildUnlockFunc(
+			func(ctx context.Context, unlocked PlayerAchievement) error {
+				t.Fail()
+				return nil
+			},
+			list,
+			func(ctx context.Context, unlocks []Unlock) ([]Unlock, error) { return nil, nil },
+		)
+
+		unlocked, err := unlock(ctx, TriggerEvent{Kind: TriggerQuestCompleted, GameID: "game", PlayerID: "player", QuestID: "tutorial"})
+		assert.NoError(t, err)
+		assert.Empty(t, unlocked)
+	})
+
+	t.Run("No Match", func(t *testing.T) {
+		unlock := BuildUnlockFunc(nil, list, func(ctx context.Context, unlocks []Unlock) ([]Unlock, error) {
+			t.Fail()
+			return nil, nil
+		})
+
+		unlocked, err := unlock(ctx, TriggerEvent{Kind: TriggerStatisticLandmark, GameID: "game", PlayerID: "player", StatisticID: "kills", Landmark: 50})
+		assert.NoError(t, err)
+		assert.Empty(t, unlocked)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		unlock := BuildUnlockFunc(nil, list, func(ctx context.Context, unlocks []Unlock) ([]Unlock, error) {
+			return nil, errors.New("any error")
+		})
+
+		_, err := unlock(ctx, TriggerEvent{Kind: TriggerQuestCompleted, GameID: "game", PlayerID: "player", QuestID: "tutorial"})
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildListPlayerAchievementsFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+		now = time.Now().UTC()
+	)
+
+	list := BuildListPlayerAchievementsFunc(
+		func(ctx context.Context, gameID string) ([]Achievement, error) {
+			return []Achievement{{ID: "a", Name: "A"}}, nil
+		},
+		func(ctx context.Context, gameID, playerID string) ([]Unlock, error) {
+			return []Unlock{{AchievementID: "a", PlayerID: playerID, UnlockedAt: now}, {AchievementID: "deleted", PlayerID: playerID}}, nil
+		},
+	)
+
+	unlocked, err := list(ctx, "game", "player")
+	assert.NoError(t, err)
+	assert.Equal(t, []PlayerAchievement{{Achievement: Achievement{ID: "a", Name: "A"}, PlayerID: "player", UnlockedAt: now}}, unlocked)
+
+	_, err = list(ctx, "game", "")
+	assert.ErrorIs(t, err, ErrInvalidPlayerID)
+}
+
+func TestBuildNotifiers(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		events []TriggerEvent
+		unlock = func(ctx context.Context, event TriggerEvent) ([]PlayerAchievement, error) {
+			events = append(events, event)
+			return nil, nil
+		}
+		now = time.Now().UTC()
+	)
+
+	t.Run("Statistic Landmarks", func(t *testing.T) {
+		events = nil
+		notify := BuildNotifierStatisticProgressionUpdates(nil, unlock)
+
+		err := notify(ctx, statistic.Statistic{ID: "kills", GameID: "game"}, statistic.PlayerProgression{PlayerID: "player"}, statistic.PlayerProgressionUpdates{
+			LandmarksJustCompleted: []statistic.PlayerProgressionUpdatesLandmark{{Value: 10, CompletedAt: now}, {Value: 100, CompletedAt: now}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []TriggerEvent{
+			{OccurredAt: now, Kind: TriggerStatisticLandmark, GameID: "game", PlayerID: "player", StatisticID: "kills", Landmark: 10},
+			{OccurredAt: now, Kind: TriggerStatisticLandmark, GameID: "game", PlayerID: "player", StatisticID: "kills", Landmark: 100},
+		}, events)
+	})
+
+	t.Run("Quest Completed", func(t *testing.T) {
+		events = nil
+		notify := BuildNotifierQuestLifecycleEvent(nil, unlock)
+
+		assert.NoError(t, notify(ctx, quest.LifecycleEvent{Event: quest.EventTaskCompleted, GameID: "game", PlayerID: "player", QuestID: "q"}))
+		assert.NoError(t, notify(ctx, quest.LifecycleEvent{OccurredAt: now, Event: quest.EventQuestCompleted, GameID: "game", PlayerID: "player", QuestID: "q"}))
+		assert.Equal(t, []TriggerEvent{{OccurredAt: now, Kind: TriggerQuestCompleted, GameID: "game", PlayerID: "player", QuestID: "q"}}, events)
+	})
+
+	t.Run("Wrapped Notifier Error", func(t *testing.T) {
+		events = nil
+		notify := BuildNotifierQuestLifecycleEvent(func(ctx context.Context, event quest.LifecycleEvent) error {
+			return errors.New("any error")
+		}, unlock)
+
+		assert.Error(t, notify(ctx, quest.LifecycleEvent{Event: quest.EventQuestCompleted}))
+		assert.Empty(t, events)
+	})
+}
@@ -0,0 +1,8 @@
+package achievement
+
+import "context"
+
+type (
+	// Notify an achievement unlocked by a player
+	NotifierAchievementUnlocked func(ctx context.Context, unlocked PlayerAchievement) error
+)
@@ -0,0 +1,23 @@
+package achievement
+
+import "context"
+
+type (
+	// Stores a new achievement, returning it with its ID
+	StorageCreateAchievementFunc func(ctx context.Context, data NewAchievementData) (Achievement, error)
+
+	// Get an achievement by its id and game id
+	StorageGetAchievementFunc func(ctx context.Context, id, gameID string) (Achievement, error)
+
+	// Lists the achievements of the game, oldest first
+	StorageListAchievementsFunc func(ctx context.Context, gameID string) ([]Achievement, error)
+
+	// Removes the achievement of the game alongside its unlocks
+	StorageDeleteAchievementFunc func(ctx context.Context, id, gameID string) error
+
+	// Records the unlocks, skipping the achievements the player already unlocked, and returns the ones recorded
+	StorageUnlockAchievementsFunc func(ctx context.Context, unlocks []Unlock) ([]Unlock, error)
+
+	// Lists the unlocks of the player, oldest first
+	StorageListUnlocksFunc func(ctx context.Context, gameID, playerID string) ([]Unlock, error)
+)
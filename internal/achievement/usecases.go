@@ -0,0 +1,23 @@
+package achievement
+
+import "context"
+
+type (
+	// Creates an achievement of the game
+	CreateFunc func(ctx context.Context, data NewAchievementData) (Achievement, error)
+
+	// Get an achievement by its id and game id
+	GetByIDAndGameIDFunc func(ctx context.Context, id, gameID string) (Achievement, error)
+
+	// Lists the achievements of the game, oldest first
+	ListFunc func(ctx context.Context, gameID string) ([]Achievement, error)
+
+	// Removes the achievement of the game alongside its unlocks
+	DeleteFunc func(ctx context.Context, id, gameID string) error
+
+	// Lists the achievements the player unlocked, oldest unlock first
+	ListPlayerAchievementsFunc func(ctx context.Context, gameID, playerID string) ([]PlayerAchievement, error)
+
+	// Unlocks the game's achievements triggered by the event for the player, returning the ones just unlocked
+	UnlockFunc func(ctx context.Context, event TriggerEvent) ([]PlayerAchievement, error)
+)
@@ -0,0 +1,257 @@
+package activity
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/friend"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+const (
+	MinLimit = 1
+	MaxLimit = 100
+)
+
+// Notable events recorded on a player's feed
+const (
+	KindPersonalBest    = "PERSONAL_BEST"    // The player beat their best value on a MAX or MIN leaderboard
+	KindLandmarkReached = "LANDMARK_REACHED" // The player reached a landmark of a statistic
+	KindQuestCompleted  = "QUEST_COMPLETED"  // The player completed a quest
+	KindRankMilestone   = "RANK_MILESTONE"   // The player entered one of the `RankMilestones` top positions of a leaderboard
+)
+
+// Top positions of a leaderboard whose entry is recorded, e.g. 10 for the top 10, from the best one
+var RankMilestones = []int64{1, 3, 10, 100}
+
+var (
+	ErrInvalidPlayerID = errors.New("invalid player id")
+	ErrInvalidLimit    = errors.New("invalid activity limit")
+	ErrInvalidCursor   = errors.New("invalid activity cursor")
+)
+
+// Notable event of a player. Only the fields of its kind are set
+type Activity struct {
+	OccurredAt    time.Time // Time the player made the progression
+	ID            string    // Activity ID, assigned by the storage
+	GameID        string    // Game the player belongs to
+	PlayerID      string    // Player's ID
+	Kind          string    // Activity kind, one of `PERSONAL_BEST`, `LANDMARK_REACHED`, `QUEST_COMPLETED` or `RANK_MILESTONE`
+	LeaderboardID string    // Leaderboard of the rank. Only set on `PERSONAL_BEST` and `RANK_MILESTONE`
+	StatisticID   string    // Statistic whose landmark was reached. Only set on `LANDMARK_REACHED`
+	QuestID       string    // Quest completed. Only set on `QUEST_COMPLETED`
+	Value         float64   // New best, landmark reached or rank value on the milestone. Not set on `QUEST_COMPLETED`
+	PreviousValue float64   // Best beaten. Only set on `PERSONAL_BEST`
+	Top           int64     // Top positions entered, one of `RankMilestones`. Only set on `RANK_MILESTONE`
+}
+
+// Opaque cursor pointing right after the activity, sent back to read the following page
+func EncodeCursor(a Activity) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(a.OccurredAt.UTC().Format(time.RFC3339Nano) + "|" + a.ID))
+}
+
+// Activity holding the position of the cursor. Only its occurrence time and ID are set
+func DecodeCursor(cursor string) (Activity, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Activity{}, ErrInvalidCursor
+	}
+
+	occurredAt, id, ok := strings.Cut(string(data), "|")
+	if !ok || id == "" {
+		return Activity{}, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, occurredAt)
+	if err != nil {
+		return Activity{}, ErrInvalidCursor
+	}
+
+	return Activity{OccurredAt: t, ID: id}, nil
+}
+
+// Best of the milestones the player entered by moving between the positions. An unranked player is past every milestone
+func rankMilestone(before leaderboard.Rank, ranked bool, after leaderboard.Rank) (int64, bool) {
+	for _, top := range RankMilestones {
+		if after.Position < top && (!ranked || before.Position >= top) {
+			return top, true
+		}
+	}
+
+	return 0, false
+}
+
+// Whether the value beats the player's previous best. Every value submitted to an INC leaderboard raises the rank, so
+// only the MAX and MIN leaderboards have personal bests
+func personalBest(lb leaderboard.Leaderboard, before, after float64) bool {
+	switch lb.AggregationMode {
+	case leaderboard.AggregationModeMax:
+		return after > before
+	case leaderboard.AggregationModeMin:
+		return after < before
+	default:
+		return false
+	}
+}
+
+// Wraps the rank update to record the personal bests and rank milestones it makes the player reach. The player's rank
+// is read before and after the update, so every submission costs two more reads. A player's first value on a
+// leaderboard isn't a personal best, as there's none to beat. The activities are recorded only after the update succeeds
+func BuildRecordRankActivitiesFunc(
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageRecordActivitiesFunc StorageRecordActivitiesFunc,
+	next leaderboard.StorageUpsertPlayerRankValueFunc,
+) leaderboard.StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		before, err := storageGetPlayerRankFunc(ctx, lb, playerID)
+		ranked := err == nil
+		if err != nil && !errors.Is(err, leaderboard.ErrPlayerRankNotFound) {
+			return err
+		}
+
+		if err := next(ctx, lb, playerID, value); err != nil {
+			return err
+		}
+
+		after, err := storageGetPlayerRankFunc(ctx, lb, playerID)
+		if err != nil {
+			return err
+		}
+
+		var (
+			occurredAt = time.Now().UTC()
+			activities = make([]Activity, 0, 2)
+		)
+		if ranked && personalBest(lb, before.Value, after.Value) {
+			activities = append(activities, Activity{
+				OccurredAt:    occurredAt,
+				GameID:        lb.GameID,
+				PlayerID:      playerID,
+				Kind:          KindPersonalBest,
+				LeaderboardID: lb.ID,
+				Value:         after.Value,
+				PreviousValue: before.Value,
+			})
+		}
+
+		if top, ok := rankMilestone(before, ranked, after); ok {
+			activities = append(activities, Activity{
+				OccurredAt:    occurredAt,
+				GameID:        lb.GameID,
+				PlayerID:      playerID,
+				Kind:          KindRankMilestone,
+				LeaderboardID: lb.ID,
+				Value:         after.Value,
+				Top:           top,
+			})
+		}
+
+		if len(activities) == 0 {
+			return nil
+		}
+
+		return storageRecordActivitiesFunc(ctx, activities)
+	}
+}
+
+// Wraps the statistic progression notifier to also record the landmarks the player reached.
+// The wrapped notifier can be nil, and the activities are recorded only after it succeeds
+func BuildNotifierStatisticProgressionUpdates(next statistic.NotifierPlayerProgressionUpdates, storageRecordActivitiesFunc StorageRecordActivitiesFunc) statistic.NotifierPlayerProgressionUpdates {
+	return func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+		if next != nil {
+			if err := next(ctx, s, progression, updates); err != nil {
+				return err
+			}
+		}
+
+		if len(updates.LandmarksJustCompleted) == 0 {
+			return nil
+		}
+
+		activities := make([]Activity, len(updates.LandmarksJustCompleted))
+		for i, landmark := range updates.LandmarksJustCompleted {
+			activities[i] = Activity{
+				OccurredAt:  landmark.CompletedAt,
+				GameID:      s.GameID,
+				PlayerID:    progression.PlayerID,
+				Kind:        KindLandmarkReached,
+				StatisticID: s.ID,
+				Value:       landmark.Value,
+			}
+		}
+
+		return storageRecordActivitiesFunc(ctx, activities)
+	}
+}
+
+// Wraps the quest lifecycle notifier to also record the quests the player completed.
+// The wrapped notifier can be nil, and the activity is recorded only after it succeeds
+func BuildNotifierQuestLifecycleEvent(next quest.NotifierLifecycleEvent, storageRecordActivitiesFunc StorageRecordActivitiesFunc) quest.NotifierLifecycleEvent {
+	return func(ctx context.Context, event quest.LifecycleEvent) error {
+		if next != nil {
+			if err := next(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if event.Event != quest.EventQuestCompleted {
+			return nil
+		}
+
+		return storageRecordActivitiesFunc(ctx, []Activity{{
+			OccurredAt: event.OccurredAt,
+			GameID:     event.GameID,
+			PlayerID:   event.PlayerID,
+			Kind:       KindQuestCompleted,
+			QuestID:    event.QuestID,
+		}})
+	}
+}
+
+func BuildListFunc(storageListActivitiesFunc StorageListActivitiesFunc) ListFunc {
+	return func(ctx context.Context, gameID, playerID string, after Activity, limit int) ([]Activity, error) {
+		if playerID == "" {
+			return nil, ErrInvalidPlayerID
+		}
+
+		if limit < MinLimit || limit > MaxLimit {
+			return nil, ErrInvalidLimit
+		}
+
+		return storageListActivitiesFunc(ctx, gameID, []string{playerID}, after, limit)
+	}
+}
+
+// The feed holds the activities of the player's current friends, including the ones recorded before they became friends
+func BuildListFriendsFunc(listFriendsFunc friend.ListFunc, storageListActivitiesFunc StorageListActivitiesFunc) ListFriendsFunc {
+	return func(ctx context.Context, gameID, playerID string, after Activity, limit int) ([]Activity, error) {
+		if playerID == "" {
+			return nil, ErrInvalidPlayerID
+		}
+
+		if limit < MinLimit || limit > MaxLimit {
+			return nil, ErrInvalidLimit
+		}
+
+		friends, err := listFriendsFunc(ctx, gameID, playerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(friends) == 0 {
+			return make([]Activity, 0), nil
+		}
+
+		friendIDs := make([]string, len(friends))
+		for i, f := range friends {
+			friendIDs[i] = f.FriendID
+		}
+
+		return storageListActivitiesFunc(ctx, gameID, friendIDs, after, limit)
+	}
+}
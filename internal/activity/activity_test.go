@@ -0,0 +1,213 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/friend"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	a := Activity{OccurredAt: time.Date(2024, 5, 1, 10, 0, 0, 123, time.UTC), ID: "activity"}
+
+	decoded, err := DecodeCursor(EncodeCursor(a))
+	assert.NoError(t, err)
+	assert.Equal(t, a, decoded)
+
+	_, err = DecodeCursor("not a cursor")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestBuildRecordRankActivitiesFunc(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		lb       = leaderboard.Leaderboard{ID: "lap", GameID: "game", AggregationMode: leaderboard.AggregationModeMin}
+		ranks    map[string]leaderboard.Rank
+		recorded []Activity
+	)
+
+	// The lower the value, the better the position, one position every 10
+	upsert := func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		rank, ok := ranks[playerID]
+		if !ok || value < rank.Value {
+			rank.Value = value
+		}
+		rank.Position = int64(value) / 10
+		ranks[playerID] = rank
+
+		return nil
+	}
+
+	record := BuildRecordRankActivitiesFunc(
+		func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			rank, ok := ranks[playerID]
+			if !ok {
+				return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+			}
+
+			return rank, nil
+		},
+		func(ctx context.Context, activities []Activity) error {
+			recorded = append(recorded, activities...)
+			return nil
+		},
+		upsert,
+	)
+
+	t.Run("First Value", func(t *testing.T) {
+		ranks, recorded = make(map[string]leaderboard.Rank), nil
+
+		err := record(ctx, lb, "player", 500)
+		assert.NoError(t, err)
+		assert.Len(t, recorded, 1)
+		assert.Equal(t, KindRankMilestone, recorded[0].Kind)
+		assert.Equal(t, int64(100), recorded[0].Top)
+	})
+
+	t.Run("Personal Best And Milestone", func(t *testing.T) {
+		ranks, recorded = map[string]leaderboard.Rank{"player": {Value: 500, Position: 50}}, nil
+
+		err := record(ctx, lb, "player", 5)
+		assert.NoError(t, err)
+		assert.Len(t, recorded, 2)
+		assert.Equal(t, KindPersonalBest, recorded[0].Kind)
+		assert.Equal(t, float64(5), recorded[0].Value)
+		assert.Equal(t, float64(500), recorded[0].PreviousValue)
+		assert.Equal(t, KindRankMilestone, recorded[1].Kind)
+		assert.Equal(t, int64(1), recorded[1].Top)
+		assert.Equal(t, "lap", recorded[1].LeaderboardID)
+	})
+
+	t.Run("Nothing Notable", func(t *testing.T) {
+		ranks, recorded = map[string]leaderboard.Rank{"player": {Value: 5, Position: 0}}, nil
+
+		err := record(ctx, lb, "player", 9)
+		assert.NoError(t, err)
+		assert.Empty(t, recorded)
+	})
+
+	t.Run("Update Error", func(t *testing.T) {
+		errUpsert := errors.New("upsert")
+
+		err := BuildRecordRankActivitiesFunc(
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+				return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+			},
+			func(ctx context.Context, activities []Activity) error {
+				t.Fatal("a failed update must not be recorded")
+				return nil
+			},
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+				return errUpsert
+			},
+		)(ctx, lb, "player", 1)
+		assert.ErrorIs(t, err, errUpsert)
+	})
+}
+
+func TestBuildNotifierStatisticProgressionUpdates(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		completedAt = time.Now().UTC()
+		recorded    []Activity
+	)
+
+	err := BuildNotifierStatisticProgressionUpdates(nil, func(ctx context.Context, activities []Activity) error {
+		recorded = activities
+		return nil
+	})(
+		ctx,
+		statistic.Statistic{ID: "kills", GameID: "game"},
+		statistic.PlayerProgression{PlayerID: "player"},
+		statistic.PlayerProgressionUpdates{LandmarksJustCompleted: []statistic.PlayerProgressionUpdatesLandmark{{Value: 10, CompletedAt: completedAt}}},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []Activity{{
+		OccurredAt:  completedAt,
+		GameID:      "game",
+		PlayerID:    "player",
+		Kind:        KindLandmarkReached,
+		StatisticID: "kills",
+		Value:       10,
+	}}, recorded)
+}
+
+func TestBuildNotifierQuestLifecycleEvent(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		recorded []Activity
+	)
+
+	notifier := BuildNotifierQuestLifecycleEvent(nil, func(ctx context.Context, activities []Activity) error {
+		recorded = append(recorded, activities...)
+		return nil
+	})
+
+	err := notifier(ctx, quest.LifecycleEvent{Event: quest.EventTaskCompleted, GameID: "game", PlayerID: "player", QuestID: "tutorial"})
+	assert.NoError(t, err)
+	assert.Empty(t, recorded)
+
+	err = notifier(ctx, quest.LifecycleEvent{Event: quest.EventQuestCompleted, GameID: "game", PlayerID: "player", QuestID: "tutorial"})
+	assert.NoError(t, err)
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, KindQuestCompleted, recorded[0].Kind)
+	assert.Equal(t, "tutorial", recorded[0].QuestID)
+}
+
+func TestBuildListFunc(t *testing.T) {
+	ctx := context.Background()
+
+	list := BuildListFunc(func(ctx context.Context, gameID string, playerIDs []string, after Activity, limit int) ([]Activity, error) {
+		assert.Equal(t, []string{"player"}, playerIDs)
+		return []Activity{{ID: "activity"}}, nil
+	})
+
+	activities, err := list(ctx, "game", "player", Activity{}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []Activity{{ID: "activity"}}, activities)
+
+	_, err = list(ctx, "game", "", Activity{}, 10)
+	assert.ErrorIs(t, err, ErrInvalidPlayerID)
+
+	_, err = list(ctx, "game", "player", Activity{}, MaxLimit+1)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
+
+func TestBuildListFriendsFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		activities, err := BuildListFriendsFunc(
+			func(ctx context.Context, gameID, playerID string) ([]friend.Friendship, error) {
+				return []friend.Friendship{{PlayerID: playerID, FriendID: "a"}, {PlayerID: playerID, FriendID: "b"}}, nil
+			},
+			func(ctx context.Context, gameID string, playerIDs []string, after Activity, limit int) ([]Activity, error) {
+				assert.Equal(t, []string{"a", "b"}, playerIDs)
+				return []Activity{{ID: "activity", PlayerID: "a"}}, nil
+			},
+		)(ctx, "game", "player", Activity{}, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []Activity{{ID: "activity", PlayerID: "a"}}, activities)
+	})
+
+	t.Run("No Friends", func(t *testing.T) {
+		activities, err := BuildListFriendsFunc(
+			func(ctx context.Context, gameID, playerID string) ([]friend.Friendship, error) {
+				return nil, nil
+			},
+			func(ctx context.Context, gameID string, playerIDs []string, after Activity, limit int) ([]Activity, error) {
+				t.Fatal("the feed of a player without friends must not be read")
+				return nil, nil
+			},
+		)(ctx, "game", "player", Activity{}, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, activities)
+	})
+}
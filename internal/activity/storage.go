@@ -0,0 +1,18 @@
+package activity
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type (
+	// Player's rank on the leaderboard. Fails with `leaderboard.ErrPlayerRankNotFound` when the player isn't ranked
+	StorageGetPlayerRankFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error)
+
+	// Appends the activities to their players' feeds, assigning their IDs
+	StorageRecordActivitiesFunc func(ctx context.Context, activities []Activity) error
+
+	// Lists the activities of the players, newest first, starting after the given activity when its ID is set
+	StorageListActivitiesFunc func(ctx context.Context, gameID string, playerIDs []string, after Activity, limit int) ([]Activity, error)
+)
@@ -0,0 +1,11 @@
+package activity
+
+import "context"
+
+type (
+	// Lists a page of the player's activities, newest first, starting after the given activity when its ID is set
+	ListFunc func(ctx context.Context, gameID, playerID string, after Activity, limit int) ([]Activity, error)
+
+	// Lists a page of the activities of the player's friends, newest first, starting after the given activity when its ID is set
+	ListFriendsFunc func(ctx context.Context, gameID, playerID string, after Activity, limit int) ([]Activity, error)
+)
@@ -0,0 +1,313 @@
+package anomaly
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+)
+
+const (
+	MethodZScore = "Z_SCORE" // Flags the values more standard deviations from the mean than the threshold
+	MethodIQR    = "IQR"     // Flags the values more interquartile ranges past the quartile than the threshold
+
+	StatusPending   = "PENDING"   // Waiting for review
+	StatusDismissed = "DISMISSED" // Reviewed and found legitimate
+
+	MinLimit = 1   // Fewest anomalies listed at once
+	MaxLimit = 100 // Most anomalies listed at once
+
+	// Ranks read at once while building a leaderboard's distribution
+	RankPageSize = 1000
+
+	// Name of the scheduled job flagging the anomalies
+	JobName = "anomaly-detection"
+)
+
+var Methods = []string{
+	MethodZScore,
+	MethodIQR,
+}
+
+var (
+	ErrValidationError  = errors.New("validation error")
+	ErrInvalidMethod    = errors.New("method must be one of Z_SCORE or IQR")
+	ErrInvalidThreshold = errors.New("threshold must be greater than zero")
+	ErrInvalidMinRanks  = errors.New("min ranks must be at least 3")
+	ErrInvalidMaxRanks  = errors.New("max ranks must not be lower than the min ranks")
+
+	ErrInvalidGameID    = errors.New("invalid game id")
+	ErrInvalidAnomalyID = errors.New("invalid anomaly id")
+	ErrInvalidLimit     = errors.New("limit must be between 1 and 100")
+	ErrAnomalyNotFound  = errors.New("anomaly not found")
+)
+
+// How the leaderboards' distributions are evaluated
+type Config struct {
+	Method    string  // One of `Z_SCORE` or `IQR`
+	Threshold float64 // Standard deviations from the mean, or interquartile ranges past the quartile, above which a value is flagged
+	MinRanks  int     // Fewest ranks a leaderboard needs for its distribution to be evaluated
+	MaxRanks  int     // Most ranks read from each leaderboard, best first
+}
+
+// Rank flagged as an outlier of its leaderboard's distribution
+type Anomaly struct {
+	DetectedAt    time.Time // Last time the value was flagged
+	ID            string    // Anomaly ID, assigned by the storage
+	GameID        string    // Game the leaderboard belongs to
+	LeaderboardID string    // Leaderboard the player is ranked on
+	PlayerID      string    // Player flagged
+	Value         float64   // Player's value when flagged
+	Method        string    // Method that flagged the value
+	Score         float64   // Standard deviations from the mean, or interquartile ranges past the quartile, of the value
+	Status        string    // One of `PENDING` or `DISMISSED`
+}
+
+func (c Config) validate() error {
+	errList := make([]error, 0)
+
+	if !slices.Contains(Methods, c.Method) {
+		errList = append(errList, ErrInvalidMethod)
+	}
+
+	if c.Threshold <= 0 {
+		errList = append(errList, ErrInvalidThreshold)
+	}
+
+	if c.MinRanks < 3 {
+		errList = append(errList, ErrInvalidMinRanks)
+	}
+
+	if c.MaxRanks < c.MinRanks {
+		errList = append(errList, ErrInvalidMaxRanks)
+	}
+
+	if err := errors.Join(errList...); err != nil {
+		return errors.Join(ErrValidationError, err)
+	}
+
+	return nil
+}
+
+// Linear interpolation between the closest ranks of the sorted values
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower, upper := int(math.Floor(pos)), int(math.Ceil(pos))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+// Scores each value on how far it is past the distribution on the better side of the leaderboard's ordering,
+// as only inflated values put a player ahead. The scores are zero when the values don't spread
+func scores(method, ordering string, values []float64) []float64 {
+	// Flipped on the ascending leaderboards, so the better values are always the higher ones
+	sign := 1.0
+	if ordering == leaderboard.OrderingAsc {
+		sign = -1
+	}
+
+	result := make([]float64, len(values))
+	switch method {
+	case MethodZScore:
+		var mean float64
+		for _, v := range values {
+			mean += v
+		}
+		mean /= float64(len(values))
+
+		var variance float64
+		for _, v := range values {
+			variance += (v - mean) * (v - mean)
+		}
+
+		stddev := math.Sqrt(variance / float64(len(values)))
+		if stddev == 0 {
+			return result
+		}
+
+		for i, v := range values {
+			result[i] = sign * (v - mean) / stddev
+		}
+	case MethodIQR:
+		sorted := slices.Clone(values)
+		slices.Sort(sorted)
+
+		q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+		iqr := q3 - q1
+		if iqr == 0 {
+			return result
+		}
+
+		fence := q3
+		if sign < 0 {
+			fence = q1
+		}
+
+		for i, v := range values {
+			result[i] = sign * (v - fence) / iqr
+		}
+	}
+
+	return result
+}
+
+// Reads the leaderboard's best ranks, up to the config's maximum
+func readRanks(ctx context.Context, config Config, storageGetRankingFunc leaderboard.StorageGetRankingFunc, lb leaderboard.Leaderboard) ([]leaderboard.Rank, error) {
+	ranks := make([]leaderboard.Rank, 0)
+	for page := int64(0); len(ranks) < config.MaxRanks; page++ {
+		ranking, err := storageGetRankingFunc(ctx, lb, page, RankPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		ranks = append(ranks, ranking...)
+		if len(ranking) < RankPageSize {
+			break
+		}
+	}
+
+	if len(ranks) > config.MaxRanks {
+		ranks = ranks[:config.MaxRanks]
+	}
+
+	return ranks, nil
+}
+
+// Flags the leaderboard's outliers, skipping the ones already flagged with the same value, whether they're pending or dismissed
+func detect(
+	ctx context.Context,
+	config Config,
+	now time.Time,
+	lb leaderboard.Leaderboard,
+	storageGetRankingFunc leaderboard.StorageGetRankingFunc,
+	storageListLeaderboardAnomaliesFunc StorageListLeaderboardAnomaliesFunc,
+	storageSaveAnomalyFunc StorageSaveAnomalyFunc,
+) (int, error) {
+	ranks, err := readRanks(ctx, config, storageGetRankingFunc, lb)
+	if err != nil || len(ranks) < config.MinRanks {
+		return 0, err
+	}
+
+	values := make([]float64, len(ranks))
+	for i, r := range ranks {
+		values[i] = r.Value
+	}
+
+	existing, err := storageListLeaderboardAnomaliesFunc(ctx, lb.GameID, lb.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	var flagged int
+	for i, score := range scores(config.Method, lb.Ordering, values) {
+		if score <= config.Threshold {
+			continue
+		}
+
+		rank := ranks[i]
+		if slices.ContainsFunc(existing, func(a Anomaly) bool { return a.PlayerID == rank.PlayerID && a.Value == rank.Value }) {
+			continue
+		}
+
+		_, err := storageSaveAnomalyFunc(ctx, Anomaly{
+			DetectedAt:    now,
+			GameID:        lb.GameID,
+			LeaderboardID: lb.ID,
+			PlayerID:      rank.PlayerID,
+			Value:         rank.Value,
+			Method:        config.Method,
+			Score:         score,
+			Status:        StatusPending,
+		})
+		if err != nil {
+			return flagged, err
+		}
+
+		flagged++
+	}
+
+	return flagged, nil
+}
+
+func BuildDetectFunc(
+	config Config,
+	storageListLeaderboardsFunc leaderboard.StorageListLeaderboardsScheduledBetweenFunc,
+	storageGetRankingFunc leaderboard.StorageGetRankingFunc,
+	storageListLeaderboardAnomaliesFunc StorageListLeaderboardAnomaliesFunc,
+	storageSaveAnomalyFunc StorageSaveAnomalyFunc,
+) (DetectFunc, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, from, to time.Time) (int, error) {
+		// Every leaderboard started by now starts within the interval from the zero time
+		leaderboards, err := storageListLeaderboardsFunc(ctx, time.Time{}, to)
+		if err != nil {
+			return 0, err
+		}
+
+		var (
+			flagged int
+			errList = make([]error, 0)
+		)
+		for _, lb := range leaderboards {
+			if !lb.EndAt.IsZero() && !lb.EndAt.After(from) {
+				continue
+			}
+
+			n, err := detect(ctx, config, to, lb, storageGetRankingFunc, storageListLeaderboardAnomaliesFunc, storageSaveAnomalyFunc)
+			if err != nil {
+				errList = append(errList, err)
+			}
+
+			flagged += n
+		}
+
+		return flagged, errors.Join(errList...)
+	}, nil
+}
+
+// Evaluates the leaderboards open at any time since the job's last run, on any instance, so a leaderboard
+// is evaluated a last time after it ends. The first run evaluates every leaderboard
+func BuildDetectJob(interval time.Duration, detectFunc DetectFunc) scheduler.Job {
+	return scheduler.Job{
+		Name:     JobName,
+		Interval: interval,
+		Func: func(ctx context.Context, run scheduler.Run) error {
+			_, err := detectFunc(ctx, run.LastRunAt, run.StartedAt)
+			return err
+		},
+	}
+}
+
+func BuildListFunc(storageListAnomaliesFunc StorageListAnomaliesFunc) ListFunc {
+	return func(ctx context.Context, gameID, leaderboardID string, limit int) ([]Anomaly, error) {
+		if gameID == "" {
+			return nil, ErrInvalidGameID
+		}
+
+		if limit < MinLimit || limit > MaxLimit {
+			return nil, ErrInvalidLimit
+		}
+
+		return storageListAnomaliesFunc(ctx, gameID, leaderboardID, limit)
+	}
+}
+
+func BuildDismissFunc(storageDismissAnomalyFunc StorageDismissAnomalyFunc) DismissFunc {
+	return func(ctx context.Context, gameID, id string) error {
+		if gameID == "" {
+			return ErrInvalidGameID
+		}
+
+		if id == "" {
+			return ErrInvalidAnomalyID
+		}
+
+		return storageDismissAnomalyFunc(ctx, gameID, id)
+	}
+}
@@ -0,0 +1,208 @@
+package anomaly
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	err := Config{Method: MethodIQR, Threshold: 1.5, MinRanks: 10, MaxRanks: 100}.validate()
+	assert.NoError(t, err)
+
+	err = Config{Method: "MAD", MinRanks: 2, MaxRanks: 1}.validate()
+	assert.ErrorIs(t, err, ErrValidationError)
+	assert.ErrorIs(t, err, ErrInvalidMethod)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+	assert.ErrorIs(t, err, ErrInvalidMinRanks)
+	assert.ErrorIs(t, err, ErrInvalidMaxRanks)
+}
+
+func TestScores(t *testing.T) {
+	values := []float64{10, 10, 10, 10, 20, 20, 20, 20}
+
+	assert.Equal(t, []float64{-1, -1, -1, -1, 1, 1, 1, 1}, scores(MethodZScore, leaderboard.OrderingDesc, values))
+	assert.Equal(t, []float64{1, 1, 1, 1, -1, -1, -1, -1}, scores(MethodZScore, leaderboard.OrderingAsc, values))
+	assert.Equal(t, []float64{-1, -1, -1, -1, 0, 0, 0, 0}, scores(MethodIQR, leaderboard.OrderingDesc, values))
+	assert.Equal(t, []float64{0, 0, 0, 0, -1, -1, -1, -1}, scores(MethodIQR, leaderboard.OrderingAsc, values))
+
+	assert.Equal(t, []float64{0, 0}, scores(MethodZScore, leaderboard.OrderingDesc, []float64{5, 5}))
+	assert.Equal(t, []float64{0, 0}, scores(MethodIQR, leaderboard.OrderingDesc, []float64{5, 5}))
+}
+
+func TestBuildDetectFunc(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		now       = time.Now()
+		anomalies []Anomaly
+		lbs       = []leaderboard.Leaderboard{
+			{ID: "open", GameID: "game", Ordering: leaderboard.OrderingDesc},
+			{ID: "ended", GameID: "game", Ordering: leaderboard.OrderingDesc, EndAt: now.Add(-2 * time.Hour)},
+			{ID: "fastest", GameID: "game", Ordering: leaderboard.OrderingAsc},
+		}
+		ranks = map[string][]leaderboard.Rank{
+			"open":    {{PlayerID: "cheater", Value: 1000}},
+			"ended":   {{PlayerID: "cheater", Value: 1000}},
+			"fastest": {},
+		}
+	)
+
+	for i := 0; i < 20; i++ {
+		ranks["open"] = append(ranks["open"], leaderboard.Rank{PlayerID: "player", Value: float64(100 + i%5)})
+		ranks["ended"] = append(ranks["ended"], leaderboard.Rank{PlayerID: "player", Value: float64(100 + i%5)})
+		ranks["fastest"] = append(ranks["fastest"], leaderboard.Rank{PlayerID: "player", Value: float64(100 + i%5)})
+	}
+	ranks["fastest"] = append(ranks["fastest"], leaderboard.Rank{PlayerID: "slowpoke", Value: 1000})
+
+	getRanking := func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+		r := ranks[lb.ID]
+		start, end := min(page*limit, int64(len(r))), min((page+1)*limit, int64(len(r)))
+		return r[start:end], nil
+	}
+
+	listLeaderboardAnomalies := func(ctx context.Context, gameID, leaderboardID string) ([]Anomaly, error) {
+		result := make([]Anomaly, 0)
+		for _, a := range anomalies {
+			if a.LeaderboardID == leaderboardID {
+				result = append(result, a)
+			}
+		}
+
+		return result, nil
+	}
+
+	save := func(ctx context.Context, a Anomaly) (Anomaly, error) {
+		for i := range anomalies {
+			if anomalies[i].LeaderboardID == a.LeaderboardID && anomalies[i].PlayerID == a.PlayerID {
+				a.ID = anomalies[i].ID
+				anomalies[i] = a
+				return a, nil
+			}
+		}
+
+		a.ID = a.LeaderboardID + "/" + a.PlayerID
+		anomalies = append(anomalies, a)
+		return a, nil
+	}
+
+	listLeaderboards := func(ctx context.Context, from, to time.Time) ([]leaderboard.Leaderboard, error) {
+		return lbs, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		anomalies = nil
+
+		detect, err := BuildDetectFunc(Config{Method: MethodZScore, Threshold: 3, MinRanks: 10, MaxRanks: 100}, listLeaderboards, getRanking, listLeaderboardAnomalies, save)
+		assert.NoError(t, err)
+
+		flagged, err := detect(ctx, now.Add(-time.Hour), now)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, flagged)
+		assert.Len(t, anomalies, 1)
+		assert.Equal(t, "open/cheater", anomalies[0].ID)
+		assert.Equal(t, 1000.0, anomalies[0].Value)
+		assert.Equal(t, StatusPending, anomalies[0].Status)
+		assert.Greater(t, anomalies[0].Score, 3.0)
+
+		flagged, err = detect(ctx, now.Add(-time.Hour), now)
+		assert.NoError(t, err)
+		assert.Zero(t, flagged)
+	})
+
+	t.Run("Dismissed", func(t *testing.T) {
+		anomalies = []Anomaly{{ID: "open/cheater", LeaderboardID: "open", PlayerID: "cheater", Value: 1000, Status: StatusDismissed}}
+
+		detect, err := BuildDetectFunc(Config{Method: MethodIQR, Threshold: 3, MinRanks: 10, MaxRanks: 100}, listLeaderboards, getRanking, listLeaderboardAnomalies, save)
+		assert.NoError(t, err)
+
+		flagged, err := detect(ctx, now.Add(-time.Hour), now)
+		assert.NoError(t, err)
+		assert.Zero(t, flagged)
+		assert.Equal(t, StatusDismissed, anomalies[0].Status)
+
+		ranks["open"][0].Value = 2000
+		defer func() { ranks["open"][0].Value = 1000 }()
+
+		flagged, err = detect(ctx, now.Add(-time.Hour), now)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, flagged)
+		assert.Equal(t, StatusPending, anomalies[0].Status)
+	})
+
+	t.Run("Ended Since Last Run", func(t *testing.T) {
+		anomalies = nil
+
+		detect, err := BuildDetectFunc(Config{Method: MethodZScore, Threshold: 3, MinRanks: 10, MaxRanks: 100}, listLeaderboards, getRanking, listLeaderboardAnomalies, save)
+		assert.NoError(t, err)
+
+		flagged, err := detect(ctx, now.Add(-3*time.Hour), now)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, flagged)
+	})
+
+	t.Run("Too Few Ranks", func(t *testing.T) {
+		anomalies = nil
+
+		detect, err := BuildDetectFunc(Config{Method: MethodZScore, Threshold: 3, MinRanks: 50, MaxRanks: 100}, listLeaderboards, getRanking, listLeaderboardAnomalies, save)
+		assert.NoError(t, err)
+
+		flagged, err := detect(ctx, now.Add(-time.Hour), now)
+		assert.NoError(t, err)
+		assert.Zero(t, flagged)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		storageErr := errors.New("any storage error")
+		detect, err := BuildDetectFunc(
+			Config{Method: MethodZScore, Threshold: 3, MinRanks: 10, MaxRanks: 100},
+			listLeaderboards,
+			func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+				return nil, storageErr
+			},
+			listLeaderboardAnomalies,
+			save,
+		)
+		assert.NoError(t, err)
+
+		_, err = detect(ctx, now.Add(-time.Hour), now)
+		assert.ErrorIs(t, err, storageErr)
+	})
+
+	t.Run("Invalid Config", func(t *testing.T) {
+		_, err := BuildDetectFunc(Config{}, listLeaderboards, getRanking, listLeaderboardAnomalies, save)
+		assert.ErrorIs(t, err, ErrValidationError)
+	})
+}
+
+func TestBuildListFunc(t *testing.T) {
+	list := BuildListFunc(func(ctx context.Context, gameID, leaderboardID string, limit int) ([]Anomaly, error) {
+		return []Anomaly{{GameID: gameID, LeaderboardID: leaderboardID}}, nil
+	})
+
+	anomalies, err := list(context.Background(), "game", "leaderboard", 10)
+	assert.NoError(t, err)
+	assert.Len(t, anomalies, 1)
+
+	_, err = list(context.Background(), "game", "", MaxLimit+1)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+
+	_, err = list(context.Background(), "", "", 10)
+	assert.ErrorIs(t, err, ErrInvalidGameID)
+}
+
+func TestBuildDismissFunc(t *testing.T) {
+	dismiss := BuildDismissFunc(func(ctx context.Context, gameID, id string) error {
+		return ErrAnomalyNotFound
+	})
+
+	err := dismiss(context.Background(), "game", "anomaly")
+	assert.ErrorIs(t, err, ErrAnomalyNotFound)
+
+	err = dismiss(context.Background(), "game", "")
+	assert.ErrorIs(t, err, ErrInvalidAnomalyID)
+}
@@ -0,0 +1,17 @@
+package anomaly
+
+import "context"
+
+type (
+	// Stores the anomaly, replacing the one of the same player on the same leaderboard while keeping its ID
+	StorageSaveAnomalyFunc func(ctx context.Context, a Anomaly) (Anomaly, error)
+
+	// Lists every anomaly of the leaderboard, pending or dismissed
+	StorageListLeaderboardAnomaliesFunc func(ctx context.Context, gameID, leaderboardID string) ([]Anomaly, error)
+
+	// Lists the game's pending anomalies, oldest first. An empty leaderboard ID lists the ones of every leaderboard
+	StorageListAnomaliesFunc func(ctx context.Context, gameID, leaderboardID string, limit int) ([]Anomaly, error)
+
+	// Marks the pending anomaly as dismissed. Fails with `ErrAnomalyNotFound` when there's no pending anomaly with the ID
+	StorageDismissAnomalyFunc func(ctx context.Context, gameID, id string) error
+)
@@ -0,0 +1,17 @@
+package anomaly
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Flags the outliers of the leaderboards open at any time within the `(from, to]` interval, returning how many were flagged
+	DetectFunc func(ctx context.Context, from, to time.Time) (int, error)
+
+	// Lists the game's anomalies waiting for review, oldest first, optionally of a single leaderboard
+	ListFunc func(ctx context.Context, gameID, leaderboardID string, limit int) ([]Anomaly, error)
+
+	// Dismisses an anomaly found legitimate. It's not flagged again until the player's value changes
+	DismissFunc func(ctx context.Context, gameID, id string) error
+)
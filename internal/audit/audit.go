@@ -0,0 +1,201 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	MinLimit = 1
+	MaxLimit = 500
+
+	// Entries a single export can hold. Larger exports must be split in narrower time ranges
+	MaxExportEntries = 100_000
+
+	// Entries read from the storage at a time while exporting
+	exportBatchSize = 500
+)
+
+var (
+	ErrEntryValidation = errors.New("invalid audit entry")
+	ErrMissingGameID   = errors.New("missing game id")
+	ErrMissingMethod   = errors.New("missing method")
+	ErrMissingRoute    = errors.New("missing route")
+
+	ErrInvalidFilter  = errors.New("invalid audit filter")
+	ErrInvalidLimit   = errors.New("invalid audit limit")
+	ErrInvalidCursor  = errors.New("invalid audit cursor")
+	ErrExportTooLarge = errors.New("audit export too large")
+)
+
+// Resources whose mutating requests are audited, named after the first segment of their routes
+var ResourceTypes = []string{"achievements", "leaderboards", "level-curves", "players", "quests", "rewards", "segments", "statistics", "titles", "webhooks"}
+
+type NewEntryData struct {
+	GameID        string            // ID of the game that performed the request
+	Actor         string            // Subject of the credential used on the request
+	Method        string            // Request method
+	Route         string            // Route pattern that handled the request
+	ResourceIDs   map[string]string // Route parameters that identify the resource affected
+	PayloadDigest string            // SHA-256 digest of the request payload
+	StatusCode    int               // Response status code
+	RequestedAt   time.Time         // Time the request was received
+}
+
+type Entry struct {
+	CreatedAt     time.Time         // Time the entry was recorded
+	ID            string            // Entry ID
+	GameID        string            // ID of the game that performed the request
+	Actor         string            // Subject of the credential used on the request
+	Method        string            // Request method
+	Route         string            // Route pattern that handled the request
+	ResourceIDs   map[string]string // Route parameters that identify the resource affected
+	PayloadDigest string            // SHA-256 digest of the request payload
+	StatusCode    int               // Response status code
+	RequestedAt   time.Time         // Time the request was received
+}
+
+// Entries matching every field set, e.g. the leaderboard deletions of a game on a given day
+type Filter struct {
+	GameID       string    // Game that performed the requests
+	Actor        string    // Subject of the credential used on the requests. Empty matches any actor
+	ResourceType string    // One of `ResourceTypes`. Empty matches any resource
+	From         time.Time // Entries recorded from this time on. Zero leaves the range open
+	To           time.Time // Entries recorded before this time. Zero leaves the range open
+}
+
+func (f Filter) validate() error {
+	if f.GameID == "" {
+		return fmt.Errorf("%w: game id is required", ErrInvalidFilter)
+	}
+
+	if f.ResourceType != "" && !slices.Contains(ResourceTypes, f.ResourceType) {
+		return fmt.Errorf("%w: unknown resource type %q", ErrInvalidFilter, f.ResourceType)
+	}
+
+	if !f.From.IsZero() && !f.To.IsZero() && !f.To.After(f.From) {
+		return fmt.Errorf("%w: the end of the range must be after its start", ErrInvalidFilter)
+	}
+
+	return nil
+}
+
+// Prefix of the routes handling the resource type, e.g. `/api/v1/leaderboards/` for `leaderboards`
+func RoutePrefix(resourceType string) string {
+	return "/api/v1/" + resourceType + "/"
+}
+
+// Opaque position after the entry on the listing, handed to the clients to fetch the next page
+func EncodeCursor(e Entry) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(e.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + e.ID))
+}
+
+// Entry holding the position of the cursor. Only its creation time and ID are set
+func DecodeCursor(cursor string) (Entry, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Entry{}, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(data), "|")
+	if !ok || id == "" {
+		return Entry{}, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return Entry{}, ErrInvalidCursor
+	}
+
+	return Entry{CreatedAt: t, ID: id}, nil
+}
+
+func (e NewEntryData) validate() error {
+	errList := make([]error, 0)
+
+	if e.GameID == "" {
+		errList = append(errList, ErrMissingGameID)
+	}
+
+	if e.Method == "" {
+		errList = append(errList, ErrMissingMethod)
+	}
+
+	if e.Route == "" {
+		errList = append(errList, ErrMissingRoute)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrEntryValidation)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Hex encoded SHA-256 digest of a request payload
+func PayloadDigest(payload []byte) string {
+	digest := sha256.Sum256(payload)
+	return hex.EncodeToString(digest[:])
+}
+
+func BuildRecordFunc(storageRecordEntryFunc StorageRecordEntryFunc) RecordFunc {
+	return func(ctx context.Context, data NewEntryData) (Entry, error) {
+		if err := data.validate(); err != nil {
+			return Entry{}, err
+		}
+
+		return storageRecordEntryFunc(ctx, data)
+	}
+}
+
+func BuildListFunc(storageListEntriesFunc StorageListEntriesFunc) ListFunc {
+	return func(ctx context.Context, filter Filter, after Entry, limit int) ([]Entry, error) {
+		if err := filter.validate(); err != nil {
+			return nil, err
+		}
+
+		if limit < MinLimit || limit > MaxLimit {
+			return nil, ErrInvalidLimit
+		}
+
+		return storageListEntriesFunc(ctx, filter, after, limit)
+	}
+}
+
+// Reads every matching entry in batches, failing before reading past `MaxExportEntries`
+func BuildExportFunc(storageListEntriesFunc StorageListEntriesFunc) ExportFunc {
+	return func(ctx context.Context, filter Filter) ([]Entry, error) {
+		if err := filter.validate(); err != nil {
+			return nil, err
+		}
+
+		var (
+			entries = make([]Entry, 0)
+			after   Entry
+		)
+		for {
+			batch, err := storageListEntriesFunc(ctx, filter, after, exportBatchSize)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(entries)+len(batch) > MaxExportEntries {
+				return nil, fmt.Errorf("%w: more than %d entries match the filter", ErrExportTooLarge, MaxExportEntries)
+			}
+
+			entries = append(entries, batch...)
+			if len(batch) < exportBatchSize {
+				return entries, nil
+			}
+
+			after = batch[len(batch)-1]
+		}
+	}
+}
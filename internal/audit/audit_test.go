@@ -0,0 +1,248 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		err := NewEntryData{
+			GameID:      uuid.NewString(),
+			Actor:       uuid.NewString(),
+			Method:      http.MethodPost,
+			Route:       "/api/v1/leaderboards/",
+			StatusCode:  http.StatusCreated,
+			RequestedAt: time.Now(),
+		}.validate()
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		err := NewEntryData{}.validate()
+
+		assert.ErrorIs(t, err, ErrEntryValidation)
+		assert.ErrorIs(t, err, ErrMissingGameID)
+		assert.ErrorIs(t, err, ErrMissingMethod)
+		assert.ErrorIs(t, err, ErrMissingRoute)
+	})
+}
+
+func TestPayloadDigest(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", PayloadDigest(nil))
+	assert.Equal(t, PayloadDigest([]byte(`{"value":1}`)), PayloadDigest([]byte(`{"value":1}`)))
+	assert.NotEqual(t, PayloadDigest([]byte(`{"value":1}`)), PayloadDigest([]byte(`{"value":2}`)))
+}
+
+func TestBuildRecordFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		entryID = uuid.NewString()
+		data    = NewEntryData{
+			GameID:        uuid.NewString(),
+			Actor:         uuid.NewString(),
+			Method:        http.MethodDelete,
+			Route:         "/api/v1/leaderboards/:leaderboardId",
+			ResourceIDs:   map[string]string{"leaderboardId": uuid.NewString()},
+			PayloadDigest: PayloadDigest(nil),
+			StatusCode:    http.StatusNoContent,
+			RequestedAt:   time.Now(),
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		recordFunc := BuildRecordFunc(func(ctx context.Context, data NewEntryData) (Entry, error) {
+			return Entry{
+				CreatedAt:     time.Now(),
+				ID:            entryID,
+				GameID:        data.GameID,
+				Actor:         data.Actor,
+				Method:        data.Method,
+				Route:         data.Route,
+				ResourceIDs:   data.ResourceIDs,
+				PayloadDigest: data.PayloadDigest,
+				StatusCode:    data.StatusCode,
+				RequestedAt:   data.RequestedAt,
+			}, nil
+		})
+
+		entry, err := recordFunc(ctx, data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, entryID, entry.ID)
+		assert.Equal(t, data.ResourceIDs, entry.ResourceIDs)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		recordFunc := BuildRecordFunc(nil)
+
+		entry, err := recordFunc(ctx, NewEntryData{})
+
+		assert.ErrorIs(t, err, ErrEntryValidation)
+		assert.Empty(t, entry.ID)
+	})
+
+	t.Run("Random Error", func(t *testing.T) {
+		recordFunc := BuildRecordFunc(func(ctx context.Context, data NewEntryData) (Entry, error) {
+			return Entry{}, errors.New("any error")
+		})
+
+		entry, err := recordFunc(ctx, data)
+
+		assert.Error(t, err)
+		assert.Empty(t, entry.ID)
+	})
+}
+
+func TestFilterValidate(t *testing.T) {
+	var (
+		gameID = uuid.NewString()
+		now    = time.Now()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		err := Filter{GameID: gameID, ResourceType: "leaderboards", From: now.Add(-time.Hour), To: now}.validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("Missing Game ID", func(t *testing.T) {
+		err := Filter{}.validate()
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+	})
+
+	t.Run("Unknown Resource Type", func(t *testing.T) {
+		err := Filter{GameID: gameID, ResourceType: "games"}.validate()
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+	})
+
+	t.Run("Invalid Range", func(t *testing.T) {
+		err := Filter{GameID: gameID, From: now, To: now}.validate()
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+	})
+}
+
+func TestCursor(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		entry := Entry{CreatedAt: time.Now().UTC(), ID: uuid.NewString()}
+
+		decoded, err := DecodeCursor(EncodeCursor(entry))
+		assert.NoError(t, err)
+		assert.True(t, entry.CreatedAt.Equal(decoded.CreatedAt))
+		assert.Equal(t, entry.ID, decoded.ID)
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		_, err := DecodeCursor("invalid")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestBuildListFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		filter = Filter{GameID: uuid.NewString()}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		entryID := uuid.NewString()
+
+		listFunc := BuildListFunc(func(ctx context.Context, filter Filter, after Entry, limit int) ([]Entry, error) {
+			return []Entry{{ID: entryID, GameID: filter.GameID}}, nil
+		})
+
+		entries, err := listFunc(ctx, filter, Entry{}, 10)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, entryID, entries[0].ID)
+	})
+
+	t.Run("Invalid Filter", func(t *testing.T) {
+		listFunc := BuildListFunc(nil)
+
+		_, err := listFunc(ctx, Filter{}, Entry{}, 10)
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		listFunc := BuildListFunc(nil)
+
+		_, err := listFunc(ctx, filter, Entry{}, MaxLimit+1)
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+	})
+
+	t.Run("Random Error", func(t *testing.T) {
+		listFunc := BuildListFunc(func(ctx context.Context, filter Filter, after Entry, limit int) ([]Entry, error) {
+			return nil, errors.New("any error")
+		})
+
+		_, err := listFunc(ctx, filter, Entry{}, 10)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildExportFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		filter = Filter{GameID: uuid.NewString()}
+	)
+
+	// Serves `total` entries in batches, checking each batch starts after the previous one
+	newStorage := func(t *testing.T, total int) StorageListEntriesFunc {
+		return func(ctx context.Context, filter Filter, after Entry, limit int) ([]Entry, error) {
+			start := 0
+			if after.ID != "" {
+				_, err := fmt.Sscan(after.ID, &start)
+				assert.NoError(t, err)
+				start++
+			}
+
+			entries := make([]Entry, 0, limit)
+			for i := start; i < total && len(entries) < limit; i++ {
+				entries = append(entries, Entry{ID: fmt.Sprint(i)})
+			}
+
+			return entries, nil
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		exportFunc := BuildExportFunc(newStorage(t, exportBatchSize+1))
+
+		entries, err := exportFunc(ctx, filter)
+		assert.NoError(t, err)
+		assert.Len(t, entries, exportBatchSize+1)
+		assert.Equal(t, fmt.Sprint(exportBatchSize), entries[exportBatchSize].ID)
+	})
+
+	t.Run("Too Large", func(t *testing.T) {
+		exportFunc := BuildExportFunc(newStorage(t, MaxExportEntries+1))
+
+		_, err := exportFunc(ctx, filter)
+		assert.ErrorIs(t, err, ErrExportTooLarge)
+	})
+
+	t.Run("Invalid Filter", func(t *testing.T) {
+		exportFunc := BuildExportFunc(nil)
+
+		_, err := exportFunc(ctx, Filter{})
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+	})
+
+	t.Run("Random Error", func(t *testing.T) {
+		exportFunc := BuildExportFunc(func(ctx context.Context, filter Filter, after Entry, limit int) ([]Entry, error) {
+			return nil, errors.New("any error")
+		})
+
+		_, err := exportFunc(ctx, filter)
+		assert.Error(t, err)
+	})
+}
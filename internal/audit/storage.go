@@ -0,0 +1,11 @@
+package audit
+
+import "context"
+
+type (
+	// Append an entry to the audit log
+	StorageRecordEntryFunc func(ctx context.Context, data NewEntryData) (Entry, error)
+
+	// List the entries matching the filter, newest first, starting after the given entry when its ID is set
+	StorageListEntriesFunc func(ctx context.Context, filter Filter, after Entry, limit int) ([]Entry, error)
+)
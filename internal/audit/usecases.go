@@ -0,0 +1,14 @@
+package audit
+
+import "context"
+
+type (
+	// Record a mutating request on the audit log
+	RecordFunc func(ctx context.Context, data NewEntryData) (Entry, error)
+
+	// List a page of the entries matching the filter, newest first, starting after the given entry when its ID is set
+	ListFunc func(ctx context.Context, filter Filter, after Entry, limit int) ([]Entry, error)
+
+	// List every entry matching the filter, newest first
+	ExportFunc func(ctx context.Context, filter Filter) ([]Entry, error)
+)
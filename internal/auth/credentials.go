@@ -10,7 +10,8 @@ var (
 )
 
 type Claims struct {
-	GameID string
+	GameID  string
+	Subject string
 }
 
 func BuildAuthenticatorFunc(serviceValidateCredentialsFunc ServiceValidateCredentialsFunc) AuthenticateFunc {
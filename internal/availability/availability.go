@@ -0,0 +1,6 @@
+package availability
+
+import "errors"
+
+// Returned by the storages when only a read replica is reachable, so writes can't be accepted
+var ErrReadOnly = errors.New("service in read-only degraded mode")
@@ -0,0 +1,534 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// The archive is a gzipped tarball holding a JSON manifest and one NDJSON file per collection
+const (
+	manifestFileName         = "manifest.json"
+	leaderboardsFileName     = "leaderboards.ndjson"
+	rankingsFileName         = "rankings.ndjson"
+	statisticsFileName       = "statistics.ndjson"
+	playerStatisticsFileName = "player_statistics.ndjson"
+	questsFileName           = "quests.ndjson"
+	playerQuestsFileName     = "player_quests.ndjson"
+)
+
+type manifestRecord struct {
+	Version    int       `json:"version"`
+	GameID     string    `json:"gameId"`
+	ExportedAt time.Time `json:"exportedAt"`
+}
+
+type leaderboardRecord struct {
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+	ID              string    `json:"id"`
+	GameID          string    `json:"gameId"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	StartAt         time.Time `json:"startAt"`
+	EndAt           time.Time `json:"endAt"`
+	AggregationMode string    `json:"aggregationMode"`
+	Ordering        string    `json:"ordering"`
+}
+
+type rankRecord struct {
+	LeaderboardID string  `json:"leaderboardId"`
+	PlayerID      string  `json:"playerId"`
+	Value         float64 `json:"value"`
+}
+
+type statisticRecord struct {
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+	ID              string    `json:"id"`
+	GameID          string    `json:"gameId"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	AggregationMode string    `json:"aggregationMode"`
+	InitialValue    *float64  `json:"initialValue"`
+	Goal            *float64  `json:"goal"`
+	Landmarks       []float64 `json:"landmarks"`
+}
+
+type playerStatisticLandmarkRecord struct {
+	Value       float64   `json:"value"`
+	Completed   bool      `json:"completed"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+type playerStatisticRecord struct {
+	StartedAt       time.Time                       `json:"startedAt"`
+	UpdatedAt       time.Time                       `json:"updatedAt"`
+	PlayerID        string                          `json:"playerId"`
+	StatisticID     string                          `json:"statisticId"`
+	CurrentValue    *float64                        `json:"currentValue"`
+	GoalValue       *float64                        `json:"goalValue"`
+	GoalCompleted   *bool                           `json:"goalCompleted"`
+	GoalCompletedAt time.Time                       `json:"goalCompletedAt"`
+	Landmarks       []playerStatisticLandmarkRecord `json:"landmarks"`
+}
+
+type taskRecord struct {
+	CreatedAt             time.Time `json:"createdAt"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+	ID                    string    `json:"id"`
+	Name                  string    `json:"name"`
+	Description           string    `json:"description"`
+	DependsOn             []string  `json:"dependsOn"`
+	RequiredForCompletion bool      `json:"requiredForCompletion"`
+	Rule                  string    `json:"rule"`
+}
+
+type questRecord struct {
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+	ID          string       `json:"id"`
+	GameID      string       `json:"gameId"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Tasks       []taskRecord `json:"tasks"`
+	EndAt       time.Time    `json:"endAt"`
+}
+
+type playerTaskRecord struct {
+	StartedAt   time.Time `json:"startedAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	TaskID      string    `json:"taskId"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+type playerQuestRecord struct {
+	StartedAt   time.Time          `json:"startedAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+	PlayerID    string             `json:"playerId"`
+	QuestID     string             `json:"questId"`
+	CompletedAt time.Time          `json:"completedAt"`
+	Tasks       []playerTaskRecord `json:"tasks"`
+}
+
+func newLeaderboardRecord(lb leaderboard.Leaderboard) leaderboardRecord {
+	return leaderboardRecord{
+		CreatedAt:       lb.CreatedAt,
+		UpdatedAt:       lb.UpdatedAt,
+		ID:              lb.ID,
+		GameID:          lb.GameID,
+		Name:            lb.Name,
+		Description:     lb.Description,
+		StartAt:         lb.StartAt,
+		EndAt:           lb.EndAt,
+		AggregationMode: lb.AggregationMode,
+		Ordering:        lb.Ordering,
+	}
+}
+
+func (r leaderboardRecord) toDomain() leaderboard.Leaderboard {
+	return leaderboard.Leaderboard{
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+		ID:              r.ID,
+		GameID:          r.GameID,
+		Name:            r.Name,
+		Description:     r.Description,
+		StartAt:         r.StartAt,
+		EndAt:           r.EndAt,
+		AggregationMode: r.AggregationMode,
+		Ordering:        r.Ordering,
+	}
+}
+
+func newStatisticRecord(st statistic.Statistic) statisticRecord {
+	return statisticRecord{
+		CreatedAt:       st.CreatedAt,
+		UpdatedAt:       st.UpdatedAt,
+		ID:              st.ID,
+		GameID:          st.GameID,
+		Name:            st.Name,
+		Description:     st.Description,
+		AggregationMode: st.AggregationMode,
+		InitialValue:    st.InitialValue,
+		Goal:            st.Goal,
+		Landmarks:       st.Landmarks,
+	}
+}
+
+func (r statisticRecord) toDomain() statistic.Statistic {
+	return statistic.Statistic{
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+		ID:              r.ID,
+		GameID:          r.GameID,
+		Name:            r.Name,
+		Description:     r.Description,
+		AggregationMode: r.AggregationMode,
+		InitialValue:    r.InitialValue,
+		Goal:            r.Goal,
+		Landmarks:       r.Landmarks,
+	}
+}
+
+func newPlayerStatisticRecord(p statistic.PlayerProgression) playerStatisticRecord {
+	landmarks := make([]playerStatisticLandmarkRecord, len(p.Landmarks))
+	for i, landmark := range p.Landmarks {
+		landmarks[i] = playerStatisticLandmarkRecord(landmark)
+	}
+
+	return playerStatisticRecord{
+		StartedAt:       p.StartedAt,
+		UpdatedAt:       p.UpdatedAt,
+		PlayerID:        p.PlayerID,
+		StatisticID:     p.StatisticID,
+		CurrentValue:    p.CurrentValue,
+		GoalValue:       p.GoalValue,
+		GoalCompleted:   p.GoalCompleted,
+		GoalCompletedAt: p.GoalCompletedAt,
+		Landmarks:       landmarks,
+	}
+}
+
+func (r playerStatisticRecord) toDomain() statistic.PlayerProgression {
+	landmarks := make([]statistic.PlayerProgressionLandmark, len(r.Landmarks))
+	for i, landmark := range r.Landmarks {
+		landmarks[i] = statistic.PlayerProgressionLandmark(landmark)
+	}
+
+	return statistic.PlayerProgression{
+		StartedAt:       r.StartedAt,
+		UpdatedAt:       r.UpdatedAt,
+		PlayerID:        r.PlayerID,
+		StatisticID:     r.StatisticID,
+		CurrentValue:    r.CurrentValue,
+		GoalValue:       r.GoalValue,
+		GoalCompleted:   r.GoalCompleted,
+		GoalCompletedAt: r.GoalCompletedAt,
+		Landmarks:       landmarks,
+	}
+}
+
+func newQuestRecord(q quest.Quest) questRecord {
+	tasks := make([]taskRecord, len(q.Tasks))
+	for i, task := range q.Tasks {
+		tasks[i] = taskRecord{
+			CreatedAt:             task.CreatedAt,
+			UpdatedAt:             task.UpdatedAt,
+			ID:                    task.ID,
+			Name:                  task.Name,
+			Description:           task.Description,
+			DependsOn:             task.DependsOn,
+			RequiredForCompletion: task.RequiredForCompletion,
+			Rule:                  task.Rule,
+		}
+	}
+
+	return questRecord{
+		CreatedAt:   q.CreatedAt,
+		UpdatedAt:   q.UpdatedAt,
+		ID:          q.ID,
+		GameID:      q.GameID,
+		Name:        q.Name,
+		Description: q.Description,
+		Tasks:       tasks,
+		EndAt:       q.EndAt,
+	}
+}
+
+func (r questRecord) toDomain() quest.Quest {
+	tasks := make([]quest.Task, len(r.Tasks))
+	for i, task := range r.Tasks {
+		tasks[i] = quest.Task{
+			CreatedAt:             task.CreatedAt,
+			UpdatedAt:             task.UpdatedAt,
+			ID:                    task.ID,
+			Name:                  task.Name,
+			Description:           task.Description,
+			DependsOn:             task.DependsOn,
+			RequiredForCompletion: task.RequiredForCompletion,
+			Rule:                  task.Rule,
+		}
+	}
+
+	return quest.Quest{
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+		ID:          r.ID,
+		GameID:      r.GameID,
+		Name:        r.Name,
+		Description: r.Description,
+		Tasks:       tasks,
+		EndAt:       r.EndAt,
+	}
+}
+
+func newPlayerQuestRecord(p quest.PlayerQuestProgression) playerQuestRecord {
+	tasks := make([]playerTaskRecord, len(p.TasksProgression))
+	for i, task := range p.TasksProgression {
+		tasks[i] = playerTaskRecord{
+			StartedAt:   task.StartedAt,
+			UpdatedAt:   task.UpdatedAt,
+			TaskID:      task.Task.ID,
+			CompletedAt: task.CompletedAt,
+		}
+	}
+
+	return playerQuestRecord{
+		StartedAt:   p.StartedAt,
+		UpdatedAt:   p.UpdatedAt,
+		PlayerID:    p.PlayerID,
+		QuestID:     p.Quest.ID,
+		CompletedAt: p.CompletedAt,
+		Tasks:       tasks,
+	}
+}
+
+// Rebuilds the progression using the quest and tasks config from the archive
+func (r playerQuestRecord) toDomain(quests map[string]quest.Quest) (quest.PlayerQuestProgression, error) {
+	q, ok := quests[r.QuestID]
+	if !ok {
+		return quest.PlayerQuestProgression{}, ErrPlayerQuestUnknownQuest
+	}
+
+	tasks := make(map[string]quest.Task, len(q.Tasks))
+	for _, task := range q.Tasks {
+		tasks[task.ID] = task
+	}
+
+	tasksProgression := make([]quest.PlayerTaskProgression, len(r.Tasks))
+	for i, taskProgression := range r.Tasks {
+		task, ok := tasks[taskProgression.TaskID]
+		if !ok {
+			return quest.PlayerQuestProgression{}, fmt.Errorf("%w: unknown task %s", ErrMalformedArchive, taskProgression.TaskID)
+		}
+
+		tasksProgression[i] = quest.PlayerTaskProgression{
+			StartedAt:   taskProgression.StartedAt,
+			UpdatedAt:   taskProgression.UpdatedAt,
+			Task:        task,
+			CompletedAt: taskProgression.CompletedAt,
+		}
+	}
+
+	return quest.PlayerQuestProgression{
+		StartedAt:        r.StartedAt,
+		UpdatedAt:        r.UpdatedAt,
+		PlayerID:         r.PlayerID,
+		Quest:            q,
+		CompletedAt:      r.CompletedAt,
+		TasksProgression: tasksProgression,
+	}, nil
+}
+
+func writeFile(tw *tar.Writer, name string, modTime time.Time, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err := tw.Write(data)
+	return err
+}
+
+func encodeNDJSON[T, R any](items []T, toRecord func(T) R) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	for _, item := range items {
+		if err := encoder.Encode(toRecord(item)); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decodeNDJSON[R any](data []byte) ([]R, error) {
+	records := make([]R, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var record R
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, errors.Join(ErrMalformedArchive, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+func mapRecords[R, T any](records []R, toDomain func(R) T) []T {
+	items := make([]T, len(records))
+	for i, record := range records {
+		items[i] = toDomain(record)
+	}
+
+	return items
+}
+
+// Writes the archive as a gzipped tarball
+func WriteArchive(w io.Writer, archive Archive) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	manifest, err := json.MarshalIndent(manifestRecord(archive.Manifest), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name   string
+		encode func() ([]byte, error)
+	}{
+		{manifestFileName, func() ([]byte, error) { return manifest, nil }},
+		{leaderboardsFileName, func() ([]byte, error) { return encodeNDJSON(archive.Leaderboards, newLeaderboardRecord) }},
+		{rankingsFileName, func() ([]byte, error) {
+			return encodeNDJSON(archive.Rankings, func(rank leaderboard.Rank) rankRecord {
+				return rankRecord{LeaderboardID: rank.LeaderboardID, PlayerID: rank.PlayerID, Value: rank.Value}
+			})
+		}},
+		{statisticsFileName, func() ([]byte, error) { return encodeNDJSON(archive.Statistics, newStatisticRecord) }},
+		{playerStatisticsFileName, func() ([]byte, error) { return encodeNDJSON(archive.PlayerStatistics, newPlayerStatisticRecord) }},
+		{questsFileName, func() ([]byte, error) { return encodeNDJSON(archive.Quests, newQuestRecord) }},
+		{playerQuestsFileName, func() ([]byte, error) { return encodeNDJSON(archive.PlayerQuests, newPlayerQuestRecord) }},
+	}
+
+	for _, file := range files {
+		data, err := file.encode()
+		if err != nil {
+			return fmt.Errorf("%s: %w", file.name, err)
+		}
+
+		if err := writeFile(tw, file.name, archive.Manifest.ExportedAt, data); err != nil {
+			return fmt.Errorf("%s: %w", file.name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+// Reads an archive written by `WriteArchive`. Fails with `ErrUnsupportedArchiveVersion`
+// when the archive was written using another layout version
+func ReadArchive(r io.Reader) (Archive, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return Archive{}, errors.Join(ErrMalformedArchive, err)
+	}
+	defer gr.Close()
+
+	files := make(map[string][]byte)
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Archive{}, errors.Join(ErrMalformedArchive, err)
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return Archive{}, errors.Join(ErrMalformedArchive, err)
+		}
+
+		files[header.Name] = data
+	}
+
+	manifestData, ok := files[manifestFileName]
+	if !ok {
+		return Archive{}, fmt.Errorf("%w: missing %s", ErrMalformedArchive, manifestFileName)
+	}
+
+	var manifest manifestRecord
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return Archive{}, errors.Join(ErrMalformedArchive, err)
+	}
+
+	if manifest.Version != ArchiveVersion {
+		return Archive{}, fmt.Errorf("%w: %d", ErrUnsupportedArchiveVersion, manifest.Version)
+	}
+
+	archive := Archive{Manifest: Manifest(manifest)}
+
+	leaderboards, err := decodeNDJSON[leaderboardRecord](files[leaderboardsFileName])
+	if err != nil {
+		return Archive{}, fmt.Errorf("%s: %w", leaderboardsFileName, err)
+	}
+	archive.Leaderboards = mapRecords(leaderboards, leaderboardRecord.toDomain)
+
+	rankings, err := decodeNDJSON[rankRecord](files[rankingsFileName])
+	if err != nil {
+		return Archive{}, fmt.Errorf("%s: %w", rankingsFileName, err)
+	}
+	archive.Rankings = mapRecords(rankings, func(r rankRecord) leaderboard.Rank {
+		return leaderboard.Rank{LeaderboardID: r.LeaderboardID, PlayerID: r.PlayerID, Value: r.Value}
+	})
+
+	statistics, err := decodeNDJSON[statisticRecord](files[statisticsFileName])
+	if err != nil {
+		return Archive{}, fmt.Errorf("%s: %w", statisticsFileName, err)
+	}
+	archive.Statistics = mapRecords(statistics, statisticRecord.toDomain)
+
+	playerStatistics, err := decodeNDJSON[playerStatisticRecord](files[playerStatisticsFileName])
+	if err != nil {
+		return Archive{}, fmt.Errorf("%s: %w", playerStatisticsFileName, err)
+	}
+	archive.PlayerStatistics = mapRecords(playerStatistics, playerStatisticRecord.toDomain)
+
+	quests, err := decodeNDJSON[questRecord](files[questsFileName])
+	if err != nil {
+		return Archive{}, fmt.Errorf("%s: %w", questsFileName, err)
+	}
+	archive.Quests = mapRecords(quests, questRecord.toDomain)
+
+	questsByID := make(map[string]quest.Quest, len(archive.Quests))
+	for _, q := range archive.Quests {
+		questsByID[q.ID] = q
+	}
+
+	playerQuests, err := decodeNDJSON[playerQuestRecord](files[playerQuestsFileName])
+	if err != nil {
+		return Archive{}, fmt.Errorf("%s: %w", playerQuestsFileName, err)
+	}
+
+	archive.PlayerQuests = make([]quest.PlayerQuestProgression, len(playerQuests))
+	for i, record := range playerQuests {
+		if archive.PlayerQuests[i], err = record.toDomain(questsByID); err != nil {
+			return Archive{}, fmt.Errorf("%s: %w", playerQuestsFileName, err)
+		}
+	}
+
+	return archive, nil
+}
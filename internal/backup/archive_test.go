@@ -0,0 +1,53 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchive(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		expected := newTestArchive()
+
+		var buf bytes.Buffer
+		err := WriteArchive(&buf, expected)
+		assert.NoError(t, err)
+
+		got, err := ReadArchive(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("Unsupported Version", func(t *testing.T) {
+		archive := newTestArchive()
+		archive.Manifest.Version = ArchiveVersion + 1
+
+		var buf bytes.Buffer
+		err := WriteArchive(&buf, archive)
+		assert.NoError(t, err)
+
+		_, err = ReadArchive(&buf)
+		assert.ErrorIs(t, err, ErrUnsupportedArchiveVersion)
+	})
+
+	t.Run("Missing Manifest", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		assert.NoError(t, tw.Close())
+		assert.NoError(t, gw.Close())
+
+		_, err := ReadArchive(&buf)
+		assert.ErrorIs(t, err, ErrMalformedArchive)
+	})
+
+	t.Run("Not An Archive", func(t *testing.T) {
+		_, err := ReadArchive(bytes.NewBufferString("any data"))
+		assert.ErrorIs(t, err, ErrMalformedArchive)
+	})
+}
@@ -0,0 +1,270 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Version of the archive layout written by the export. Bumped on every incompatible change
+const ArchiveVersion = 1
+
+// Ranking entries read from the storage on each page during the export
+const rankingPageSize = 1000
+
+var (
+	ErrMissingGameID                   = errors.New("missing game id")
+	ErrUnsupportedArchiveVersion       = errors.New("unsupported archive version")
+	ErrMalformedArchive                = errors.New("malformed archive")
+	ErrArchiveGameMismatch             = errors.New("archive entity belongs to another game")
+	ErrEntityAlreadyExists             = errors.New("entity already exists")
+	ErrPlayerQuestUnknownQuest         = errors.New("player quest progression references an unknown quest")
+	ErrPlayerStatisticUnknownStatistic = errors.New("player statistic progression references an unknown statistic")
+)
+
+type Manifest struct {
+	Version    int       // Archive layout version
+	GameID     string    // ID of the game exported
+	ExportedAt time.Time // Time the archive was created
+}
+
+// Every active entity of a game alongside the players data on them.
+// Soft deleted entities are never exported
+type Archive struct {
+	Manifest         Manifest
+	Leaderboards     []leaderboard.Leaderboard
+	Rankings         []leaderboard.Rank
+	Statistics       []statistic.Statistic
+	PlayerStatistics []statistic.PlayerProgression
+	Quests           []quest.Quest
+	PlayerQuests     []quest.PlayerQuestProgression
+}
+
+// Checks if every entity on the archive belongs to the game from the manifest
+// and if the players data only references entities from the archive
+func (a Archive) validate() error {
+	if a.Manifest.Version != ArchiveVersion {
+		return ErrUnsupportedArchiveVersion
+	}
+
+	if a.Manifest.GameID == "" {
+		return ErrMissingGameID
+	}
+
+	leaderboards := make(map[string]bool, len(a.Leaderboards))
+	for _, lb := range a.Leaderboards {
+		if lb.GameID != a.Manifest.GameID {
+			return ErrArchiveGameMismatch
+		}
+
+		leaderboards[lb.ID] = true
+	}
+
+	for _, rank := range a.Rankings {
+		if !leaderboards[rank.LeaderboardID] {
+			return ErrMalformedArchive
+		}
+	}
+
+	statistics := make(map[string]bool, len(a.Statistics))
+	for _, st := range a.Statistics {
+		if st.GameID != a.Manifest.GameID {
+			return ErrArchiveGameMismatch
+		}
+
+		statistics[st.ID] = true
+	}
+
+	for _, progression := range a.PlayerStatistics {
+		if !statistics[progression.StatisticID] {
+			return ErrPlayerStatisticUnknownStatistic
+		}
+	}
+
+	quests := make(map[string]bool, len(a.Quests))
+	for _, q := range a.Quests {
+		if q.GameID != a.Manifest.GameID {
+			return ErrArchiveGameMismatch
+		}
+
+		quests[q.ID] = true
+	}
+
+	for _, progression := range a.PlayerQuests {
+		if !quests[progression.Quest.ID] {
+			return ErrPlayerQuestUnknownQuest
+		}
+	}
+
+	return nil
+}
+
+func exportRanking(ctx context.Context, lb leaderboard.Leaderboard, storageGetRankingFunc leaderboard.StorageGetRankingFunc) ([]leaderboard.Rank, error) {
+	ranking := make([]leaderboard.Rank, 0)
+	for page := int64(0); ; page++ {
+		ranks, err := storageGetRankingFunc(ctx, lb, page, rankingPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		ranking = append(ranking, ranks...)
+		if len(ranks) < rankingPageSize {
+			return ranking, nil
+		}
+	}
+}
+
+func BuildExportFunc(
+	storageListLeaderboardsFunc StorageListLeaderboardsFunc,
+	storageGetRankingFunc leaderboard.StorageGetRankingFunc,
+	storageListStatisticsFunc StorageListStatisticsFunc,
+	storageListPlayerStatisticsFunc StorageListPlayerStatisticsFunc,
+	storageListQuestsFunc StorageListQuestsFunc,
+	storageListPlayerQuestsFunc StorageListPlayerQuestsFunc,
+) ExportFunc {
+	return func(ctx context.Context, gameID string) (Archive, error) {
+		if gameID == "" {
+			return Archive{}, ErrMissingGameID
+		}
+
+		archive := Archive{
+			Manifest: Manifest{
+				Version:    ArchiveVersion,
+				GameID:     gameID,
+				ExportedAt: time.Now().UTC(),
+			},
+			Rankings:         make([]leaderboard.Rank, 0),
+			PlayerStatistics: make([]statistic.PlayerProgression, 0),
+			PlayerQuests:     make([]quest.PlayerQuestProgression, 0),
+		}
+
+		var err error
+
+		archive.Leaderboards, err = storageListLeaderboardsFunc(ctx, gameID)
+		if err != nil {
+			return Archive{}, err
+		}
+
+		for _, lb := range archive.Leaderboards {
+			ranking, err := exportRanking(ctx, lb, storageGetRankingFunc)
+			if err != nil {
+				return Archive{}, err
+			}
+
+			archive.Rankings = append(archive.Rankings, ranking...)
+		}
+
+		archive.Statistics, err = storageListStatisticsFunc(ctx, gameID)
+		if err != nil {
+			return Archive{}, err
+		}
+
+		for _, st := range archive.Statistics {
+			progressions, err := storageListPlayerStatisticsFunc(ctx, st.ID)
+			if err != nil {
+				return Archive{}, err
+			}
+
+			archive.PlayerStatistics = append(archive.PlayerStatistics, progressions...)
+		}
+
+		archive.Quests, err = storageListQuestsFunc(ctx, gameID)
+		if err != nil {
+			return Archive{}, err
+		}
+
+		for _, q := range archive.Quests {
+			progressions, err := storageListPlayerQuestsFunc(ctx, q)
+			if err != nil {
+				return Archive{}, err
+			}
+
+			archive.PlayerQuests = append(archive.PlayerQuests, progressions...)
+		}
+
+		return archive, nil
+	}
+}
+
+// The entities are restored before the players data on them. Restoring an entity that already exists fails
+// with `ErrEntityAlreadyExists`, so the rankings, aggregated on restore, are never applied twice
+func BuildImportFunc(
+	storageRestoreLeaderboardFunc StorageRestoreLeaderboardFunc,
+	storageUpsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc,
+	storageRestoreStatisticFunc StorageRestoreStatisticFunc,
+	storageRestorePlayerStatisticFunc StorageRestorePlayerStatisticFunc,
+	storageRestoreQuestFunc StorageRestoreQuestFunc,
+	storageRestorePlayerQuestFunc StorageRestorePlayerQuestFunc,
+) ImportFunc {
+	return func(ctx context.Context, archive Archive) error {
+		if err := archive.validate(); err != nil {
+			return err
+		}
+
+		leaderboards := make(map[string]leaderboard.Leaderboard, len(archive.Leaderboards))
+		for _, lb := range archive.Leaderboards {
+			if err := storageRestoreLeaderboardFunc(ctx, lb); err != nil {
+				return err
+			}
+
+			leaderboards[lb.ID] = lb
+		}
+
+		for _, rank := range archive.Rankings {
+			if err := storageUpsertPlayerRankValueFunc(ctx, leaderboards[rank.LeaderboardID], rank.PlayerID, rank.Value); err != nil {
+				return err
+			}
+		}
+
+		statistics := make(map[string]statistic.Statistic, len(archive.Statistics))
+		for _, st := range archive.Statistics {
+			if err := storageRestoreStatisticFunc(ctx, st); err != nil {
+				return err
+			}
+
+			statistics[st.ID] = st
+		}
+
+		for _, progression := range archive.PlayerStatistics {
+			if err := storageRestorePlayerStatisticFunc(ctx, statistics[progression.StatisticID], progression); err != nil {
+				return err
+			}
+		}
+
+		for _, q := range archive.Quests {
+			if err := storageRestoreQuestFunc(ctx, q); err != nil {
+				return err
+			}
+		}
+
+		for _, progression := range archive.PlayerQuests {
+			if err := storageRestorePlayerQuestFunc(ctx, progression); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// The archive's entities are compared with the target through the definition diff. The import only creates entities,
+// so it fails on any change other than `ChangeCreate`. Soft deleted entities aren't listed, so an ID taken by one
+// is only found by the import itself
+func BuildDiffImportFunc(diffDefinitionFunc DiffDefinitionFunc) DiffImportFunc {
+	return func(ctx context.Context, archive Archive) ([]Change, error) {
+		if err := archive.validate(); err != nil {
+			return nil, err
+		}
+
+		return diffDefinitionFunc(ctx, Definition{
+			Manifest:     Manifest{Version: DefinitionVersion, GameID: archive.Manifest.GameID, ExportedAt: archive.Manifest.ExportedAt},
+			Leaderboards: archive.Leaderboards,
+			Statistics:   archive.Statistics,
+			Quests:       archive.Quests,
+		})
+	}
+}
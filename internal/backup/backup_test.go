@@ -0,0 +1,330 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestArchive() Archive {
+	var (
+		gameID = uuid.NewString()
+		goal   = float64(10)
+		value  = float64(5)
+		done   = false
+
+		lb = leaderboard.Leaderboard{
+			CreatedAt:       time.Now().UTC(),
+			UpdatedAt:       time.Now().UTC(),
+			ID:              uuid.NewString(),
+			GameID:          gameID,
+			Name:            "Leaderboard",
+			StartAt:         time.Now().UTC(),
+			AggregationMode: leaderboard.AggregationModeInc,
+			Ordering:        leaderboard.OrderingDesc,
+		}
+		st = statistic.Statistic{
+			CreatedAt:       time.Now().UTC(),
+			UpdatedAt:       time.Now().UTC(),
+			ID:              uuid.NewString(),
+			GameID:          gameID,
+			Name:            "Statistic",
+			AggregationMode: statistic.AggregationModeSum,
+			Goal:            &goal,
+			Landmarks:       []float64{5},
+		}
+		task = quest.Task{
+			CreatedAt:             time.Now().UTC(),
+			UpdatedAt:             time.Now().UTC(),
+			ID:                    uuid.NewString(),
+			Name:                  "Task",
+			DependsOn:             []string{},
+			RequiredForCompletion: true,
+			Rule:                  `{"==": [{"var": "done"}, true]}`,
+		}
+		q = quest.Quest{
+			CreatedAt: time.Now().UTC(),
+			UpdatedAt: time.Now().UTC(),
+			ID:        uuid.NewString(),
+			GameID:    gameID,
+			Name:      "Quest",
+			Tasks:     []quest.Task{task},
+		}
+	)
+
+	return Archive{
+		Manifest: Manifest{
+			Version:    ArchiveVersion,
+			GameID:     gameID,
+			ExportedAt: time.Now().UTC(),
+		},
+		Leaderboards: []leaderboard.Leaderboard{lb},
+		Rankings: []leaderboard.Rank{
+			{LeaderboardID: lb.ID, PlayerID: uuid.NewString(), Value: 10},
+			{LeaderboardID: lb.ID, PlayerID: uuid.NewString(), Value: 5},
+		},
+		Statistics: []statistic.Statistic{st},
+		PlayerStatistics: []statistic.PlayerProgression{
+			{
+				StartedAt:     time.Now().UTC(),
+				UpdatedAt:     time.Now().UTC(),
+				PlayerID:      uuid.NewString(),
+				StatisticID:   st.ID,
+				CurrentValue:  &value,
+				GoalValue:     &goal,
+				GoalCompleted: &done,
+				Landmarks: []statistic.PlayerProgressionLandmark{
+					{Value: 5, Completed: true, CompletedAt: time.Now().UTC()},
+				},
+			},
+		},
+		Quests: []quest.Quest{q},
+		PlayerQuests: []quest.PlayerQuestProgression{
+			{
+				StartedAt: time.Now().UTC(),
+				UpdatedAt: time.Now().UTC(),
+				PlayerID:  uuid.NewString(),
+				Quest:     q,
+				TasksProgression: []quest.PlayerTaskProgression{
+					{StartedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC(), Task: task},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildExportFunc(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		expected = newTestArchive()
+		gameID   = expected.Manifest.GameID
+	)
+
+	listLeaderboards := func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+		return expected.Leaderboards, nil
+	}
+	getRanking := func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+		if page > 0 {
+			return []leaderboard.Rank{}, nil
+		}
+
+		return expected.Rankings, nil
+	}
+	listStatistics := func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+		return expected.Statistics, nil
+	}
+	listPlayerStatistics := func(ctx context.Context, statisticID string) ([]statistic.PlayerProgression, error) {
+		return expected.PlayerStatistics, nil
+	}
+	listQuests := func(ctx context.Context, gameID string) ([]quest.Quest, error) {
+		return expected.Quests, nil
+	}
+	listPlayerQuests := func(ctx context.Context, q quest.Quest) ([]quest.PlayerQuestProgression, error) {
+		return expected.PlayerQuests, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		exportFunc := BuildExportFunc(listLeaderboards, getRanking, listStatistics, listPlayerStatistics, listQuests, listPlayerQuests)
+
+		archive, err := exportFunc(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, ArchiveVersion, archive.Manifest.Version)
+		assert.Equal(t, gameID, archive.Manifest.GameID)
+		assert.Equal(t, expected.Leaderboards, archive.Leaderboards)
+		assert.Equal(t, expected.Rankings, archive.Rankings)
+		assert.Equal(t, expected.Statistics, archive.Statistics)
+		assert.Equal(t, expected.PlayerStatistics, archive.PlayerStatistics)
+		assert.Equal(t, expected.Quests, archive.Quests)
+		assert.Equal(t, expected.PlayerQuests, archive.PlayerQuests)
+	})
+
+	t.Run("Ranking Pagination", func(t *testing.T) {
+		pages := make([]int64, 0)
+		paginatedRanking := func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+			pages = append(pages, page)
+			if page > 1 {
+				return []leaderboard.Rank{}, nil
+			}
+
+			return make([]leaderboard.Rank, limit), nil
+		}
+
+		exportFunc := BuildExportFunc(listLeaderboards, paginatedRanking, listStatistics, listPlayerStatistics, listQuests, listPlayerQuests)
+
+		archive, err := exportFunc(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Len(t, archive.Rankings, 2*rankingPageSize)
+		assert.Equal(t, []int64{0, 1, 2}, pages)
+	})
+
+	t.Run("Missing Game ID", func(t *testing.T) {
+		exportFunc := BuildExportFunc(listLeaderboards, getRanking, listStatistics, listPlayerStatistics, listQuests, listPlayerQuests)
+
+		_, err := exportFunc(ctx, "")
+		assert.ErrorIs(t, err, ErrMissingGameID)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		errAny := errors.New("any error")
+
+		exportFunc := BuildExportFunc(listLeaderboards, getRanking, listStatistics, func(ctx context.Context, statisticID string) ([]statistic.PlayerProgression, error) {
+			return nil, errAny
+		}, listQuests, listPlayerQuests)
+
+		_, err := exportFunc(ctx, gameID)
+		assert.ErrorIs(t, err, errAny)
+	})
+}
+
+func TestBuildImportFunc(t *testing.T) {
+	ctx := context.Background()
+
+	type restored struct {
+		leaderboards     []leaderboard.Leaderboard
+		ranks            []leaderboard.Rank
+		statistics       []statistic.Statistic
+		playerStatistics []statistic.PlayerProgression
+		quests           []quest.Quest
+		playerQuests     []quest.PlayerQuestProgression
+	}
+
+	build := func(r *restored, restoreLeaderboardErr error) ImportFunc {
+		return BuildImportFunc(
+			func(ctx context.Context, lb leaderboard.Leaderboard) error {
+				if restoreLeaderboardErr != nil {
+					return restoreLeaderboardErr
+				}
+
+				r.leaderboards = append(r.leaderboards, lb)
+				return nil
+			},
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+				r.ranks = append(r.ranks, leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Value: value})
+				return nil
+			},
+			func(ctx context.Context, st statistic.Statistic) error {
+				r.statistics = append(r.statistics, st)
+				return nil
+			},
+			func(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression) error {
+				r.playerStatistics = append(r.playerStatistics, progression)
+				return nil
+			},
+			func(ctx context.Context, q quest.Quest) error {
+				r.quests = append(r.quests, q)
+				return nil
+			},
+			func(ctx context.Context, progression quest.PlayerQuestProgression) error {
+				r.playerQuests = append(r.playerQuests, progression)
+				return nil
+			},
+		)
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			archive = newTestArchive()
+			r       restored
+		)
+
+		err := build(&r, nil)(ctx, archive)
+		assert.NoError(t, err)
+		assert.Equal(t, archive.Leaderboards, r.leaderboards)
+		assert.Equal(t, archive.Rankings, r.ranks)
+		assert.Equal(t, archive.Statistics, r.statistics)
+		assert.Equal(t, archive.PlayerStatistics, r.playerStatistics)
+		assert.Equal(t, archive.Quests, r.quests)
+		assert.Equal(t, archive.PlayerQuests, r.playerQuests)
+	})
+
+	t.Run("Entity Already Exists", func(t *testing.T) {
+		var r restored
+
+		err := build(&r, ErrEntityAlreadyExists)(ctx, newTestArchive())
+		assert.ErrorIs(t, err, ErrEntityAlreadyExists)
+		assert.Empty(t, r.ranks)
+	})
+
+	t.Run("Unsupported Version", func(t *testing.T) {
+		var (
+			archive = newTestArchive()
+			r       restored
+		)
+
+		archive.Manifest.Version = ArchiveVersion + 1
+
+		err := build(&r, nil)(ctx, archive)
+		assert.ErrorIs(t, err, ErrUnsupportedArchiveVersion)
+	})
+
+	t.Run("Game Mismatch", func(t *testing.T) {
+		var (
+			archive = newTestArchive()
+			r       restored
+		)
+
+		archive.Statistics[0].GameID = uuid.NewString()
+
+		err := build(&r, nil)(ctx, archive)
+		assert.ErrorIs(t, err, ErrArchiveGameMismatch)
+		assert.Empty(t, r.leaderboards)
+	})
+
+	t.Run("Unknown Statistic", func(t *testing.T) {
+		var (
+			archive = newTestArchive()
+			r       restored
+		)
+
+		archive.PlayerStatistics[0].StatisticID = uuid.NewString()
+
+		err := build(&r, nil)(ctx, archive)
+		assert.ErrorIs(t, err, ErrPlayerStatisticUnknownStatistic)
+	})
+}
+
+func TestBuildDiffImportFunc(t *testing.T) {
+	ctx := context.Background()
+
+	build := func(target *definitionTarget) DiffImportFunc {
+		return BuildDiffImportFunc(BuildDiffDefinitionFunc(target.listLeaderboards, target.listStatistics, target.listQuests, target.listWebhooks))
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		archive := newTestArchive()
+
+		changes, err := build(&definitionTarget{})(ctx, archive)
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Action: ChangeCreate, Entity: EntityLeaderboard, ID: archive.Leaderboards[0].ID, Name: "Leaderboard"},
+			{Action: ChangeCreate, Entity: EntityStatistic, ID: archive.Statistics[0].ID, Name: "Statistic"},
+			{Action: ChangeCreate, Entity: EntityQuest, ID: archive.Quests[0].ID, Name: "Quest"},
+		}, changes)
+	})
+
+	t.Run("Entity Already Exists", func(t *testing.T) {
+		archive := newTestArchive()
+
+		changes, err := build(&definitionTarget{leaderboards: archive.Leaderboards})(ctx, archive)
+		assert.NoError(t, err)
+		if assert.Len(t, changes, 3) {
+			assert.Equal(t, ChangeUnchanged, changes[0].Action)
+		}
+	})
+
+	t.Run("Unknown Statistic", func(t *testing.T) {
+		archive := newTestArchive()
+		archive.PlayerStatistics[0].StatisticID = uuid.NewString()
+
+		_, err := build(&definitionTarget{})(ctx, archive)
+		assert.ErrorIs(t, err, ErrPlayerStatisticUnknownStatistic)
+	})
+}
@@ -0,0 +1,501 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+// Version of the definition layout written by the export. Bumped on every incompatible change
+const DefinitionVersion = 1
+
+// What importing an entity of the definition does to the target
+const (
+	ChangeCreate    = "CREATE"    // The entity is missing and will be created
+	ChangeUnchanged = "UNCHANGED" // The entity already exists as defined
+	ChangeConflict  = "CONFLICT"  // The entity exists with another definition, which the import can't update
+)
+
+// Kinds of the entities on a definition
+const (
+	EntityLeaderboard = "leaderboard"
+	EntityStatistic   = "statistic"
+	EntityQuest       = "quest"
+	EntityWebhook     = "webhook"
+)
+
+var (
+	ErrUnsupportedDefinitionVersion = errors.New("unsupported definition version")
+	ErrDefinitionConflict           = errors.New("definition conflicts with the target")
+	ErrMissingWebhookStorage        = errors.New("definition has webhooks but no webhook storage is configured")
+)
+
+// Entities configuring a game, without any players data, to promote it between environments, e.g. from staging to
+// production. The leaderboards, statistics and quests keep their IDs, so the game clients use the same ones on every
+// environment. Webhooks are identified by their URL instead, and get a new secret on each environment
+type Definition struct {
+	Manifest     Manifest
+	Leaderboards []leaderboard.Leaderboard
+	Statistics   []statistic.Statistic
+	Quests       []quest.Quest
+	Webhooks     []webhook.Webhook // Only the URL and filter are kept
+}
+
+// Result of comparing an entity of the definition with the target
+type Change struct {
+	Action string   // One of `ChangeCreate`, `ChangeUnchanged` or `ChangeConflict`
+	Entity string   // Entity kind, e.g. `EntityLeaderboard`
+	ID     string   // Entity ID. The URL for webhooks
+	Name   string   // Entity name. The URL for webhooks
+	Fields []string // Fields that differ from the target. Only set on `ChangeConflict`
+}
+
+// The definition is a single JSON file, meant to be reviewed and kept under version control
+type webhookFilterRecord struct {
+	Events         []string `json:"events"`
+	LeaderboardIDs []string `json:"leaderboardIds"`
+	StatisticIDs   []string `json:"statisticIds"`
+	QuestIDs       []string `json:"questIds"`
+}
+
+type webhookRecord struct {
+	URL    string              `json:"url"`
+	Filter webhookFilterRecord `json:"filter"`
+}
+
+type definitionRecord struct {
+	manifestRecord
+	Leaderboards []leaderboardRecord `json:"leaderboards"`
+	Statistics   []statisticRecord   `json:"statistics"`
+	Quests       []questRecord       `json:"quests"`
+	Webhooks     []webhookRecord     `json:"webhooks"`
+}
+
+func newWebhookRecord(w webhook.Webhook) webhookRecord {
+	return webhookRecord{
+		URL: w.URL,
+		Filter: webhookFilterRecord{
+			Events:         w.Filter.Events,
+			LeaderboardIDs: w.Filter.LeaderboardIDs,
+			StatisticIDs:   w.Filter.StatisticIDs,
+			QuestIDs:       w.Filter.QuestIDs,
+		},
+	}
+}
+
+func (r webhookRecord) toDomain(gameID string) webhook.Webhook {
+	return webhook.Webhook{
+		GameID: gameID,
+		URL:    r.URL,
+		Filter: webhook.Filter{
+			Events:         r.Filter.Events,
+			LeaderboardIDs: r.Filter.LeaderboardIDs,
+			StatisticIDs:   r.Filter.StatisticIDs,
+			QuestIDs:       r.Filter.QuestIDs,
+		},
+	}
+}
+
+// Writes the definition as indented JSON
+func WriteDefinition(w io.Writer, definition Definition) error {
+	record := definitionRecord{
+		manifestRecord: manifestRecord(definition.Manifest),
+		Leaderboards:   mapRecords(definition.Leaderboards, newLeaderboardRecord),
+		Statistics:     mapRecords(definition.Statistics, newStatisticRecord),
+		Quests:         mapRecords(definition.Quests, newQuestRecord),
+		Webhooks:       mapRecords(definition.Webhooks, newWebhookRecord),
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(record)
+}
+
+// Reads a definition written by `WriteDefinition`. Fails with `ErrUnsupportedDefinitionVersion`
+// when the definition was written using another layout version
+func ReadDefinition(r io.Reader) (Definition, error) {
+	var record definitionRecord
+	if err := json.NewDecoder(r).Decode(&record); err != nil {
+		return Definition{}, errors.Join(ErrMalformedArchive, err)
+	}
+
+	if record.Version != DefinitionVersion {
+		return Definition{}, fmt.Errorf("%w: %d", ErrUnsupportedDefinitionVersion, record.Version)
+	}
+
+	return Definition{
+		Manifest:     Manifest(record.manifestRecord),
+		Leaderboards: mapRecords(record.Leaderboards, leaderboardRecord.toDomain),
+		Statistics:   mapRecords(record.Statistics, statisticRecord.toDomain),
+		Quests:       mapRecords(record.Quests, questRecord.toDomain),
+		Webhooks: mapRecords(record.Webhooks, func(r webhookRecord) webhook.Webhook {
+			return r.toDomain(record.GameID)
+		}),
+	}, nil
+}
+
+// Moves the definition to another game, e.g. when the game has another ID on the target environment
+func (d Definition) ForGame(gameID string) Definition {
+	d.Manifest.GameID = gameID
+
+	d.Leaderboards = slices.Clone(d.Leaderboards)
+	for i := range d.Leaderboards {
+		d.Leaderboards[i].GameID = gameID
+	}
+
+	d.Statistics = slices.Clone(d.Statistics)
+	for i := range d.Statistics {
+		d.Statistics[i].GameID = gameID
+	}
+
+	d.Quests = slices.Clone(d.Quests)
+	for i := range d.Quests {
+		d.Quests[i].GameID = gameID
+	}
+
+	d.Webhooks = slices.Clone(d.Webhooks)
+	for i := range d.Webhooks {
+		d.Webhooks[i].GameID = gameID
+	}
+
+	return d
+}
+
+func (d Definition) validate() error {
+	if d.Manifest.Version != DefinitionVersion {
+		return ErrUnsupportedDefinitionVersion
+	}
+
+	if d.Manifest.GameID == "" {
+		return ErrMissingGameID
+	}
+
+	for _, lb := range d.Leaderboards {
+		if lb.GameID != d.Manifest.GameID {
+			return ErrArchiveGameMismatch
+		}
+	}
+
+	for _, st := range d.Statistics {
+		if st.GameID != d.Manifest.GameID {
+			return ErrArchiveGameMismatch
+		}
+	}
+
+	for _, q := range d.Quests {
+		if q.GameID != d.Manifest.GameID {
+			return ErrArchiveGameMismatch
+		}
+	}
+
+	return nil
+}
+
+// Compares the definition with the entities the target has, matched by ID and by name. An entity with the same
+// name but another ID is a conflict too, as the storages don't allow two entities of a kind with the same name
+func diffEntities[T any](entity string, defined, existing []T, id, name func(T) string, fields func(a, b T) []string) []Change {
+	var (
+		byID   = make(map[string]T, len(existing))
+		byName = make(map[string]T, len(existing))
+	)
+	for _, e := range existing {
+		byID[id(e)] = e
+		byName[name(e)] = e
+	}
+
+	changes := make([]Change, len(defined))
+	for i, d := range defined {
+		change := Change{Action: ChangeCreate, Entity: entity, ID: id(d), Name: name(d)}
+
+		if e, ok := byID[id(d)]; ok {
+			if change.Fields = fields(d, e); len(change.Fields) > 0 {
+				change.Action = ChangeConflict
+			} else {
+				change.Action = ChangeUnchanged
+			}
+		} else if _, ok := byName[name(d)]; ok {
+			change.Action = ChangeConflict
+			change.Fields = []string{"id"}
+		}
+
+		changes[i] = change
+	}
+
+	return changes
+}
+
+type fieldCheck struct {
+	name  string
+	equal bool
+}
+
+// Lists the fields whose values differ, as named on the exported definition
+func differentFields(checks ...fieldCheck) []string {
+	fields := make([]string, 0)
+	for _, check := range checks {
+		if !check.equal {
+			fields = append(fields, check.name)
+		}
+	}
+
+	return fields
+}
+
+func leaderboardFields(a, b leaderboard.Leaderboard) []string {
+	return differentFields(
+		fieldCheck{"name", a.Name == b.Name},
+		fieldCheck{"description", a.Description == b.Description},
+		fieldCheck{"startAt", a.StartAt.Equal(b.StartAt)},
+		fieldCheck{"endAt", a.EndAt.Equal(b.EndAt)},
+		fieldCheck{"aggregationMode", a.AggregationMode == b.AggregationMode},
+		fieldCheck{"ordering", a.Ordering == b.Ordering},
+	)
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func statisticFields(a, b statistic.Statistic) []string {
+	return differentFields(
+		fieldCheck{"name", a.Name == b.Name},
+		fieldCheck{"description", a.Description == b.Description},
+		fieldCheck{"aggregationMode", a.AggregationMode == b.AggregationMode},
+		fieldCheck{"initialValue", equalPtr(a.InitialValue, b.InitialValue)},
+		fieldCheck{"goal", equalPtr(a.Goal, b.Goal)},
+		fieldCheck{"landmarks", slices.Equal(a.Landmarks, b.Landmarks)},
+	)
+}
+
+func questFields(a, b quest.Quest) []string {
+	tasksEqual := slices.EqualFunc(a.Tasks, b.Tasks, func(a, b quest.Task) bool {
+		return a.ID == b.ID &&
+			a.Name == b.Name &&
+			a.Description == b.Description &&
+			slices.Equal(a.DependsOn, b.DependsOn) &&
+			a.RequiredForCompletion == b.RequiredForCompletion &&
+			a.Rule == b.Rule
+	})
+
+	return differentFields(
+		fieldCheck{"name", a.Name == b.Name},
+		fieldCheck{"description", a.Description == b.Description},
+		fieldCheck{"tasks", tasksEqual},
+	)
+}
+
+// The lists of a filter are sets, so their order doesn't matter
+func webhookFields(a, b webhook.Webhook) []string {
+	equalSet := func(a, b []string) bool {
+		a, b = slices.Clone(a), slices.Clone(b)
+		slices.Sort(a)
+		slices.Sort(b)
+		return slices.Equal(a, b)
+	}
+
+	return differentFields(
+		fieldCheck{"filter.events", equalSet(a.Filter.Events, b.Filter.Events)},
+		fieldCheck{"filter.leaderboardIds", equalSet(a.Filter.LeaderboardIDs, b.Filter.LeaderboardIDs)},
+		fieldCheck{"filter.statisticIds", equalSet(a.Filter.StatisticIDs, b.Filter.StatisticIDs)},
+		fieldCheck{"filter.questIds", equalSet(a.Filter.QuestIDs, b.Filter.QuestIDs)},
+	)
+}
+
+// Webhooks aren't exported when the list func is nil, e.g. when they're disabled
+func BuildExportDefinitionFunc(
+	storageListLeaderboardsFunc StorageListLeaderboardsFunc,
+	storageListStatisticsFunc StorageListStatisticsFunc,
+	storageListQuestsFunc StorageListQuestsFunc,
+	storageListWebhooksFunc StorageListWebhooksFunc,
+) ExportDefinitionFunc {
+	return func(ctx context.Context, gameID string) (Definition, error) {
+		if gameID == "" {
+			return Definition{}, ErrMissingGameID
+		}
+
+		definition := Definition{
+			Manifest: Manifest{
+				Version:    DefinitionVersion,
+				GameID:     gameID,
+				ExportedAt: time.Now().UTC(),
+			},
+			Webhooks: make([]webhook.Webhook, 0),
+		}
+
+		var err error
+
+		if definition.Leaderboards, err = storageListLeaderboardsFunc(ctx, gameID); err != nil {
+			return Definition{}, err
+		}
+
+		if definition.Statistics, err = storageListStatisticsFunc(ctx, gameID); err != nil {
+			return Definition{}, err
+		}
+
+		if definition.Quests, err = storageListQuestsFunc(ctx, gameID); err != nil {
+			return Definition{}, err
+		}
+
+		if storageListWebhooksFunc != nil {
+			webhooks, err := storageListWebhooksFunc(ctx, gameID)
+			if err != nil {
+				return Definition{}, err
+			}
+
+			for _, w := range webhooks {
+				definition.Webhooks = append(definition.Webhooks, webhook.Webhook{GameID: w.GameID, URL: w.URL, Filter: w.Filter})
+			}
+		}
+
+		return definition, nil
+	}
+}
+
+// The webhooks list func can be nil when they're disabled, failing on definitions holding any
+func BuildDiffDefinitionFunc(
+	storageListLeaderboardsFunc StorageListLeaderboardsFunc,
+	storageListStatisticsFunc StorageListStatisticsFunc,
+	storageListQuestsFunc StorageListQuestsFunc,
+	storageListWebhooksFunc StorageListWebhooksFunc,
+) DiffDefinitionFunc {
+	return func(ctx context.Context, definition Definition) ([]Change, error) {
+		if err := definition.validate(); err != nil {
+			return nil, err
+		}
+
+		gameID := definition.Manifest.GameID
+
+		leaderboards, err := storageListLeaderboardsFunc(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		statistics, err := storageListStatisticsFunc(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		quests, err := storageListQuestsFunc(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		changes := make([]Change, 0, len(definition.Leaderboards)+len(definition.Statistics)+len(definition.Quests)+len(definition.Webhooks))
+		changes = append(changes, diffEntities(
+			EntityLeaderboard,
+			definition.Leaderboards,
+			leaderboards,
+			func(lb leaderboard.Leaderboard) string { return lb.ID },
+			func(lb leaderboard.Leaderboard) string { return lb.Name },
+			leaderboardFields,
+		)...)
+		changes = append(changes, diffEntities(
+			EntityStatistic,
+			definition.Statistics,
+			statistics,
+			func(st statistic.Statistic) string { return st.ID },
+			func(st statistic.Statistic) string { return st.Name },
+			statisticFields,
+		)...)
+		changes = append(changes, diffEntities(
+			EntityQuest,
+			definition.Quests,
+			quests,
+			func(q quest.Quest) string { return q.ID },
+			func(q quest.Quest) string { return q.Name },
+			questFields,
+		)...)
+
+		if len(definition.Webhooks) == 0 {
+			return changes, nil
+		}
+
+		if storageListWebhooksFunc == nil {
+			return nil, ErrMissingWebhookStorage
+		}
+
+		webhooks, err := storageListWebhooksFunc(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		url := func(w webhook.Webhook) string { return w.URL }
+		changes = append(changes, diffEntities(EntityWebhook, definition.Webhooks, webhooks, url, url, webhookFields)...)
+
+		return changes, nil
+	}
+}
+
+// Only creates the missing entities, as the storages can't update them. Nothing is written when any entity
+// conflicts with the target, and the changes are returned alongside `ErrDefinitionConflict` to tell which ones
+func BuildImportDefinitionFunc(
+	diffDefinitionFunc DiffDefinitionFunc,
+	storageRestoreLeaderboardFunc StorageRestoreLeaderboardFunc,
+	storageRestoreStatisticFunc StorageRestoreStatisticFunc,
+	storageRestoreQuestFunc StorageRestoreQuestFunc,
+	createWebhookFunc webhook.CreateWebhookFunc,
+) ImportDefinitionFunc {
+	return func(ctx context.Context, definition Definition) ([]Change, error) {
+		changes, err := diffDefinitionFunc(ctx, definition)
+		if err != nil {
+			return nil, err
+		}
+
+		create := make(map[string]bool, len(changes))
+		for _, change := range changes {
+			switch change.Action {
+			case ChangeConflict:
+				return changes, ErrDefinitionConflict
+			case ChangeCreate:
+				create[change.Entity+"/"+change.ID] = true
+			}
+		}
+
+		for _, lb := range definition.Leaderboards {
+			if create[EntityLeaderboard+"/"+lb.ID] {
+				if err := storageRestoreLeaderboardFunc(ctx, lb); err != nil {
+					return changes, err
+				}
+			}
+		}
+
+		for _, st := range definition.Statistics {
+			if create[EntityStatistic+"/"+st.ID] {
+				if err := storageRestoreStatisticFunc(ctx, st); err != nil {
+					return changes, err
+				}
+			}
+		}
+
+		for _, q := range definition.Quests {
+			if create[EntityQuest+"/"+q.ID] {
+				if err := storageRestoreQuestFunc(ctx, q); err != nil {
+					return changes, err
+				}
+			}
+		}
+
+		for _, w := range definition.Webhooks {
+			if create[EntityWebhook+"/"+w.URL] {
+				if _, err := createWebhookFunc(ctx, webhook.NewWebhookData{GameID: w.GameID, URL: w.URL, Filter: w.Filter}); err != nil {
+					return changes, err
+				}
+			}
+		}
+
+		return changes, nil
+	}
+}
@@ -0,0 +1,317 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDefinition() Definition {
+	archive := newTestArchive()
+	archive.Manifest.Version = DefinitionVersion
+
+	return Definition{
+		Manifest:     archive.Manifest,
+		Leaderboards: archive.Leaderboards,
+		Statistics:   archive.Statistics,
+		Quests:       archive.Quests,
+		Webhooks: []webhook.Webhook{
+			{
+				GameID: archive.Manifest.GameID,
+				URL:    "https://example.com/webhook",
+				Filter: webhook.Filter{
+					Events:         []string{"gameblitz.leaderboard.lifecycle", "gameblitz.statistic.player_progression"},
+					LeaderboardIDs: []string{archive.Leaderboards[0].ID},
+				},
+			},
+		},
+	}
+}
+
+// Storage holding the entities of the definition
+type definitionTarget struct {
+	leaderboards []leaderboard.Leaderboard
+	statistics   []statistic.Statistic
+	quests       []quest.Quest
+	webhooks     []webhook.Webhook
+}
+
+func (t *definitionTarget) listLeaderboards(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+	return t.leaderboards, nil
+}
+
+func (t *definitionTarget) listStatistics(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+	return t.statistics, nil
+}
+
+func (t *definitionTarget) listQuests(ctx context.Context, gameID string) ([]quest.Quest, error) {
+	return t.quests, nil
+}
+
+func (t *definitionTarget) listWebhooks(ctx context.Context, gameID string) ([]webhook.Webhook, error) {
+	return t.webhooks, nil
+}
+
+func TestDefinition(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		expected := newTestDefinition()
+
+		var buf bytes.Buffer
+		err := WriteDefinition(&buf, expected)
+		assert.NoError(t, err)
+		assert.NotContains(t, buf.String(), "secret")
+
+		got, err := ReadDefinition(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("Unsupported Version", func(t *testing.T) {
+		definition := newTestDefinition()
+		definition.Manifest.Version = DefinitionVersion + 1
+
+		var buf bytes.Buffer
+		err := WriteDefinition(&buf, definition)
+		assert.NoError(t, err)
+
+		_, err = ReadDefinition(&buf)
+		assert.ErrorIs(t, err, ErrUnsupportedDefinitionVersion)
+	})
+
+	t.Run("Not A Definition", func(t *testing.T) {
+		_, err := ReadDefinition(bytes.NewBufferString("any data"))
+		assert.ErrorIs(t, err, ErrMalformedArchive)
+	})
+
+	t.Run("For Game", func(t *testing.T) {
+		var (
+			definition = newTestDefinition()
+			gameID     = uuid.NewString()
+		)
+
+		moved := definition.ForGame(gameID)
+		assert.Equal(t, gameID, moved.Manifest.GameID)
+		assert.Equal(t, gameID, moved.Leaderboards[0].GameID)
+		assert.Equal(t, gameID, moved.Statistics[0].GameID)
+		assert.Equal(t, gameID, moved.Quests[0].GameID)
+		assert.Equal(t, gameID, moved.Webhooks[0].GameID)
+		assert.NotEqual(t, gameID, definition.Leaderboards[0].GameID)
+	})
+}
+
+func TestBuildExportDefinitionFunc(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		expected = newTestDefinition()
+		gameID   = expected.Manifest.GameID
+		target   = definitionTarget{
+			leaderboards: expected.Leaderboards,
+			statistics:   expected.Statistics,
+			quests:       expected.Quests,
+			webhooks:     []webhook.Webhook{{ID: uuid.NewString(), GameID: gameID, URL: expected.Webhooks[0].URL, Secret: "secret", Filter: expected.Webhooks[0].Filter}},
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		exportFunc := BuildExportDefinitionFunc(target.listLeaderboards, target.listStatistics, target.listQuests, target.listWebhooks)
+
+		definition, err := exportFunc(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, DefinitionVersion, definition.Manifest.Version)
+		assert.Equal(t, gameID, definition.Manifest.GameID)
+		assert.Equal(t, expected.Leaderboards, definition.Leaderboards)
+		assert.Equal(t, expected.Statistics, definition.Statistics)
+		assert.Equal(t, expected.Quests, definition.Quests)
+		assert.Equal(t, expected.Webhooks, definition.Webhooks)
+	})
+
+	t.Run("Webhooks Disabled", func(t *testing.T) {
+		exportFunc := BuildExportDefinitionFunc(target.listLeaderboards, target.listStatistics, target.listQuests, nil)
+
+		definition, err := exportFunc(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Empty(t, definition.Webhooks)
+	})
+
+	t.Run("Missing Game ID", func(t *testing.T) {
+		exportFunc := BuildExportDefinitionFunc(target.listLeaderboards, target.listStatistics, target.listQuests, target.listWebhooks)
+
+		_, err := exportFunc(ctx, "")
+		assert.ErrorIs(t, err, ErrMissingGameID)
+	})
+}
+
+func TestBuildDiffDefinitionFunc(t *testing.T) {
+	ctx := context.Background()
+
+	build := func(target *definitionTarget) DiffDefinitionFunc {
+		return BuildDiffDefinitionFunc(target.listLeaderboards, target.listStatistics, target.listQuests, target.listWebhooks)
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		definition := newTestDefinition()
+
+		changes, err := build(&definitionTarget{})(ctx, definition)
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Action: ChangeCreate, Entity: EntityLeaderboard, ID: definition.Leaderboards[0].ID, Name: "Leaderboard"},
+			{Action: ChangeCreate, Entity: EntityStatistic, ID: definition.Statistics[0].ID, Name: "Statistic"},
+			{Action: ChangeCreate, Entity: EntityQuest, ID: definition.Quests[0].ID, Name: "Quest"},
+			{Action: ChangeCreate, Entity: EntityWebhook, ID: definition.Webhooks[0].URL, Name: definition.Webhooks[0].URL},
+		}, changes)
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		var (
+			definition = newTestDefinition()
+			target     = definitionTarget{
+				leaderboards: slices.Clone(definition.Leaderboards),
+				statistics:   slices.Clone(definition.Statistics),
+				quests:       slices.Clone(definition.Quests),
+				webhooks:     slices.Clone(definition.Webhooks),
+			}
+		)
+
+		// Timestamps and the order of the filter lists don't make a difference
+		target.leaderboards[0].CreatedAt = target.leaderboards[0].CreatedAt.AddDate(0, 0, 1)
+		target.webhooks[0].Filter.Events = []string{"gameblitz.statistic.player_progression", "gameblitz.leaderboard.lifecycle"}
+
+		changes, err := build(&target)(ctx, definition)
+		assert.NoError(t, err)
+		for _, change := range changes {
+			assert.Equal(t, ChangeUnchanged, change.Action, change.Entity)
+		}
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		var (
+			definition = newTestDefinition()
+			target     = definitionTarget{
+				leaderboards: []leaderboard.Leaderboard{definition.Leaderboards[0]},
+				statistics:   []statistic.Statistic{definition.Statistics[0]},
+				quests:       []quest.Quest{definition.Quests[0]},
+				webhooks:     []webhook.Webhook{definition.Webhooks[0]},
+			}
+		)
+
+		target.leaderboards[0].Ordering = leaderboard.OrderingAsc
+		target.statistics[0].ID = uuid.NewString()
+		target.quests[0].Tasks = nil
+		target.webhooks[0].Filter = webhook.Filter{}
+
+		changes, err := build(&target)(ctx, definition)
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Action: ChangeConflict, Entity: EntityLeaderboard, ID: definition.Leaderboards[0].ID, Name: "Leaderboard", Fields: []string{"ordering"}},
+			{Action: ChangeConflict, Entity: EntityStatistic, ID: definition.Statistics[0].ID, Name: "Statistic", Fields: []string{"id"}},
+			{Action: ChangeConflict, Entity: EntityQuest, ID: definition.Quests[0].ID, Name: "Quest", Fields: []string{"tasks"}},
+			{Action: ChangeConflict, Entity: EntityWebhook, ID: definition.Webhooks[0].URL, Name: definition.Webhooks[0].URL, Fields: []string{"filter.events", "filter.leaderboardIds"}},
+		}, changes)
+	})
+
+	t.Run("Missing Webhook Storage", func(t *testing.T) {
+		target := definitionTarget{}
+		diffFunc := BuildDiffDefinitionFunc(target.listLeaderboards, target.listStatistics, target.listQuests, nil)
+
+		_, err := diffFunc(ctx, newTestDefinition())
+		assert.ErrorIs(t, err, ErrMissingWebhookStorage)
+	})
+
+	t.Run("Game Mismatch", func(t *testing.T) {
+		definition := newTestDefinition()
+		definition.Quests[0].GameID = uuid.NewString()
+
+		_, err := build(&definitionTarget{})(ctx, definition)
+		assert.ErrorIs(t, err, ErrArchiveGameMismatch)
+	})
+}
+
+func TestBuildImportDefinitionFunc(t *testing.T) {
+	ctx := context.Background()
+
+	build := func(target *definitionTarget, createWebhookErr error) ImportDefinitionFunc {
+		return BuildImportDefinitionFunc(
+			BuildDiffDefinitionFunc(target.listLeaderboards, target.listStatistics, target.listQuests, target.listWebhooks),
+			func(ctx context.Context, lb leaderboard.Leaderboard) error {
+				target.leaderboards = append(target.leaderboards, lb)
+				return nil
+			},
+			func(ctx context.Context, st statistic.Statistic) error {
+				target.statistics = append(target.statistics, st)
+				return nil
+			},
+			func(ctx context.Context, q quest.Quest) error {
+				target.quests = append(target.quests, q)
+				return nil
+			},
+			func(ctx context.Context, data webhook.NewWebhookData) (webhook.Webhook, error) {
+				if createWebhookErr != nil {
+					return webhook.Webhook{}, createWebhookErr
+				}
+
+				w := webhook.Webhook{ID: uuid.NewString(), GameID: data.GameID, URL: data.URL, Filter: data.Filter, Secret: uuid.NewString()}
+				target.webhooks = append(target.webhooks, w)
+				return w, nil
+			},
+		)
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			definition = newTestDefinition()
+			target     = definitionTarget{statistics: []statistic.Statistic{definition.Statistics[0]}}
+		)
+
+		changes, err := build(&target, nil)(ctx, definition)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 4)
+		assert.Equal(t, definition.Leaderboards, target.leaderboards)
+		assert.Equal(t, definition.Statistics, target.statistics)
+		assert.Equal(t, definition.Quests, target.quests)
+		assert.Len(t, target.webhooks, 1)
+		assert.Equal(t, definition.Webhooks[0].URL, target.webhooks[0].URL)
+
+		// Importing it again is a no-op
+		changes, err = build(&target, nil)(ctx, definition)
+		assert.NoError(t, err)
+		for _, change := range changes {
+			assert.Equal(t, ChangeUnchanged, change.Action, change.Entity)
+		}
+		assert.Len(t, target.leaderboards, 1)
+		assert.Len(t, target.webhooks, 1)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		var (
+			definition = newTestDefinition()
+			target     = definitionTarget{quests: []quest.Quest{{ID: uuid.NewString(), GameID: definition.Manifest.GameID, Name: "Quest"}}}
+		)
+
+		changes, err := build(&target, nil)(ctx, definition)
+		assert.ErrorIs(t, err, ErrDefinitionConflict)
+		assert.Len(t, changes, 4)
+		assert.Empty(t, target.leaderboards)
+		assert.Empty(t, target.statistics)
+		assert.Empty(t, target.webhooks)
+	})
+
+	t.Run("Create Webhook Error", func(t *testing.T) {
+		var (
+			target           definitionTarget
+			errCreateWebhook = errors.New("invalid webhook")
+		)
+
+		_, err := build(&target, errCreateWebhook)(ctx, newTestDefinition())
+		assert.ErrorIs(t, err, errCreateWebhook)
+	})
+}
@@ -0,0 +1,45 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+type (
+	// Lists every active leaderboard of the game
+	StorageListLeaderboardsFunc func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error)
+
+	// Stores the leaderboard keeping its ID and timestamps. Fails with `ErrEntityAlreadyExists` if the ID is in use
+	StorageRestoreLeaderboardFunc func(ctx context.Context, lb leaderboard.Leaderboard) error
+
+	// Lists every active statistic of the game
+	StorageListStatisticsFunc func(ctx context.Context, gameID string) ([]statistic.Statistic, error)
+
+	// Lists the progression of every player on the statistic
+	StorageListPlayerStatisticsFunc func(ctx context.Context, statisticID string) ([]statistic.PlayerProgression, error)
+
+	// Stores the statistic keeping its ID and timestamps. Fails with `ErrEntityAlreadyExists` if the ID is in use
+	StorageRestoreStatisticFunc func(ctx context.Context, st statistic.Statistic) error
+
+	// Stores the player progression as is. Fails with `ErrEntityAlreadyExists` if the player already has a progression
+	StorageRestorePlayerStatisticFunc func(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression) error
+
+	// Lists every active quest of the game alongside its tasks
+	StorageListQuestsFunc func(ctx context.Context, gameID string) ([]quest.Quest, error)
+
+	// Lists the progression of every player on the quest
+	StorageListPlayerQuestsFunc func(ctx context.Context, q quest.Quest) ([]quest.PlayerQuestProgression, error)
+
+	// Stores the quest and its tasks keeping their IDs and timestamps. Fails with `ErrEntityAlreadyExists` if the ID is in use
+	StorageRestoreQuestFunc func(ctx context.Context, q quest.Quest) error
+
+	// Lists the webhooks of the game
+	StorageListWebhooksFunc func(ctx context.Context, gameID string) ([]webhook.Webhook, error)
+
+	// Stores the player quest and tasks progression as is. Fails with `ErrEntityAlreadyExists` if the player already started the quest
+	StorageRestorePlayerQuestFunc func(ctx context.Context, progression quest.PlayerQuestProgression) error
+)
@@ -0,0 +1,23 @@
+package backup
+
+import "context"
+
+type (
+	// Reads every active entity of the game and the players data on them
+	ExportFunc func(ctx context.Context, gameID string) (Archive, error)
+
+	// Restores every entity from the archive keeping their IDs
+	ImportFunc func(ctx context.Context, archive Archive) error
+
+	// Compares the archive's entities with the target, without restoring anything
+	DiffImportFunc func(ctx context.Context, archive Archive) ([]Change, error)
+
+	// Reads the definition of every active entity of the game, without the players data
+	ExportDefinitionFunc func(ctx context.Context, gameID string) (Definition, error)
+
+	// Compares the definition with the entities of its game on the target, without writing anything
+	DiffDefinitionFunc func(ctx context.Context, definition Definition) ([]Change, error)
+
+	// Creates the entities of the definition missing on the target
+	ImportDefinitionFunc func(ctx context.Context, definition Definition) ([]Change, error)
+)
@@ -0,0 +1,293 @@
+package ban
+
+import (
+	"context"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+)
+
+const (
+	MaxReasonLength = 512 // Characters the reason of a ban can have
+
+	// Name of the scheduled job lifting the expired bans
+	ExpiryJobName = "ban-expiry"
+)
+
+var (
+	ErrValidationError = errors.New("validation error")
+	ErrInvalidGameID   = errors.New("invalid game id")
+	ErrInvalidPlayerID = errors.New("invalid player id")
+	ErrInvalidReason   = errors.New("reason must have up to 512 characters")
+	ErrInvalidDuration = errors.New("duration must not be negative")
+
+	ErrBanNotFound         = errors.New("ban not found")
+	ErrPlayerAlreadyBanned = errors.New("player already banned")
+	ErrPlayerBanned        = errors.New("player banned")
+)
+
+type (
+	// Player's value on a leaderboard, taken out of its ranking while the player is banned
+	HiddenRank struct {
+		LeaderboardID string  // Leaderboard the player was ranked on
+		Value         float64 // Player's value when it was hidden
+	}
+
+	NewBanData struct {
+		GameID   string        // Game the player belongs to
+		PlayerID string        // Player banned
+		Reason   string        // Why the player was banned, up to 512 characters
+		Duration time.Duration // How long the ban lasts. Zero bans the player until it's lifted
+	}
+
+	// Player kept out of the game's rankings, whose submissions are rejected
+	Ban struct {
+		CreatedAt   time.Time    // Time the player was banned
+		ExpiresAt   time.Time    // Time the ban is lifted on its own. Zero when it lasts until it's lifted
+		GameID      string       // Game the player belongs to
+		PlayerID    string       // Player banned
+		Reason      string       // Why the player was banned
+		HiddenRanks []HiddenRank // Ranks taken out of the rankings, put back once the ban is lifted
+	}
+)
+
+// Whether the ban is over at `now`. Bans without expiration never expire
+func (b Ban) Expired(now time.Time) bool {
+	return !b.ExpiresAt.IsZero() && !now.Before(b.ExpiresAt)
+}
+
+func (d NewBanData) validate() error {
+	errList := make([]error, 0)
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if d.PlayerID == "" {
+		errList = append(errList, ErrInvalidPlayerID)
+	}
+
+	if utf8.RuneCountInString(d.Reason) > MaxReasonLength {
+		errList = append(errList, ErrInvalidReason)
+	}
+
+	if d.Duration < 0 {
+		errList = append(errList, ErrInvalidDuration)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// The ban is stored before the ranks are hidden, so the player's submissions are rejected while they're moved aside.
+// The value of every rank is saved on the ban before it leaves the ranking, so a failure halfway never loses it.
+// When it fails after the ban is stored, lifting the ban and banning the player again hides the remaining ranks
+func BuildBanFunc(
+	storageListLeaderboardsFunc StorageListLeaderboardsFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageDeletePlayerRankFunc StorageDeletePlayerRankFunc,
+	storageCreateBanFunc StorageCreateBanFunc,
+	storageSetHiddenRanksFunc StorageSetHiddenRanksFunc,
+) BanFunc {
+	return func(ctx context.Context, data NewBanData) (Ban, error) {
+		if err := data.validate(); err != nil {
+			return Ban{}, err
+		}
+
+		b := Ban{
+			CreatedAt: time.Now().UTC(),
+			GameID:    data.GameID,
+			PlayerID:  data.PlayerID,
+			Reason:    data.Reason,
+		}
+		if data.Duration > 0 {
+			b.ExpiresAt = b.CreatedAt.Add(data.Duration)
+		}
+
+		if err := storageCreateBanFunc(ctx, b); err != nil {
+			return Ban{}, err
+		}
+
+		leaderboards, err := storageListLeaderboardsFunc(ctx, data.GameID)
+		if err != nil {
+			return Ban{}, err
+		}
+
+		ranked := make([]leaderboard.Leaderboard, 0)
+		for _, lb := range leaderboards {
+			rank, err := storageGetPlayerRankFunc(ctx, lb, data.PlayerID)
+			if errors.Is(err, leaderboard.ErrPlayerRankNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return Ban{}, err
+			}
+
+			ranked = append(ranked, lb)
+			b.HiddenRanks = append(b.HiddenRanks, HiddenRank{LeaderboardID: lb.ID, Value: rank.Value})
+		}
+
+		if len(ranked) == 0 {
+			return b, nil
+		}
+
+		if err := storageSetHiddenRanksFunc(ctx, data.GameID, data.PlayerID, b.HiddenRanks); err != nil {
+			return Ban{}, err
+		}
+
+		for _, lb := range ranked {
+			if err := storageDeletePlayerRankFunc(ctx, lb, data.PlayerID); err != nil {
+				return Ban{}, err
+			}
+		}
+
+		return b, nil
+	}
+}
+
+// The ban is removed before its ranks are put back. A rank is only put back when the player isn't ranked on the
+// leaderboard, so the ranks a failed ban left in place aren't counted twice, and the ones of the leaderboards deleted
+// in the meantime are dropped. When a rank can't be put back, the ban is stored again with the ranks remaining
+func BuildLiftFunc(
+	storageGetLeaderboardFunc StorageGetLeaderboardFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageUpsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc,
+	storageCreateBanFunc StorageCreateBanFunc,
+	storageDeleteBanFunc StorageDeleteBanFunc,
+) LiftFunc {
+	restore := func(ctx context.Context, b Ban, hidden HiddenRank) error {
+		lb, err := storageGetLeaderboardFunc(ctx, hidden.LeaderboardID, b.GameID)
+		if errors.Is(err, leaderboard.ErrLeaderboardNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		_, err = storageGetPlayerRankFunc(ctx, lb, b.PlayerID)
+		if err == nil {
+			return nil
+		}
+
+		if !errors.Is(err, leaderboard.ErrPlayerRankNotFound) {
+			return err
+		}
+
+		// The player has no rank, so every aggregation mode sets the value as is
+		return storageUpsertPlayerRankValueFunc(ctx, lb, b.PlayerID, hidden.Value)
+	}
+
+	return func(ctx context.Context, gameID, playerID string) (Ban, error) {
+		if playerID == "" {
+			return Ban{}, ErrInvalidPlayerID
+		}
+
+		b, err := storageDeleteBanFunc(ctx, gameID, playerID)
+		if err != nil {
+			return Ban{}, err
+		}
+
+		for i, hidden := range b.HiddenRanks {
+			if err := restore(ctx, b, hidden); err != nil {
+				remaining := b
+				remaining.HiddenRanks = b.HiddenRanks[i:]
+
+				return Ban{}, errors.Join(err, storageCreateBanFunc(ctx, remaining))
+			}
+		}
+
+		return b, nil
+	}
+}
+
+func BuildGetFunc(storageGetBanFunc StorageGetBanFunc) GetFunc {
+	return func(ctx context.Context, gameID, playerID string) (Ban, error) {
+		if playerID == "" {
+			return Ban{}, ErrInvalidPlayerID
+		}
+
+		return storageGetBanFunc(ctx, gameID, playerID)
+	}
+}
+
+func BuildListFunc(storageListBansFunc StorageListBansFunc) ListFunc {
+	return func(ctx context.Context, gameID string) ([]Ban, error) {
+		return storageListBansFunc(ctx, gameID)
+	}
+}
+
+// Lifts up to `batchSize` of the bans already expired, returning how many were lifted.
+// A ban lifted concurrently, e.g. by another instance, is skipped
+func BuildExpireFunc(batchSize int, liftFunc LiftFunc, storageListExpiredBansFunc StorageListExpiredBansFunc) ExpireFunc {
+	return func(ctx context.Context) (int, error) {
+		bans, err := storageListExpiredBansFunc(ctx, time.Now().UTC(), batchSize)
+		if err != nil {
+			return 0, err
+		}
+
+		lifted := 0
+		for _, b := range bans {
+			if _, err := liftFunc(ctx, b.GameID, b.PlayerID); err != nil {
+				if errors.Is(err, ErrBanNotFound) {
+					continue
+				}
+
+				return lifted, err
+			}
+
+			lifted++
+		}
+
+		return lifted, nil
+	}
+}
+
+// Lifts the expired bans on every interval. A full batch means more bans may have expired, so the next batch is
+// lifted right away, until one comes back short
+func BuildExpireJob(interval time.Duration, batchSize int, expireFunc ExpireFunc) scheduler.Job {
+	return scheduler.Job{
+		Name:     ExpiryJobName,
+		Interval: interval,
+		Func: func(ctx context.Context, run scheduler.Run) error {
+			for {
+				lifted, err := expireFunc(ctx)
+				if err != nil {
+					return err
+				}
+
+				if lifted < batchSize {
+					return nil
+				}
+			}
+		},
+	}
+}
+
+// Wraps the rank update to reject the submissions of the banned players with `ErrPlayerBanned`.
+// An expired ban not lifted yet is lifted before the submission is applied, so its ranks are put back first
+func BuildRejectBannedFunc(storageGetBanFunc StorageGetBanFunc, liftFunc LiftFunc, next leaderboard.StorageUpsertPlayerRankValueFunc) leaderboard.StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		b, err := storageGetBanFunc(ctx, lb.GameID, playerID)
+		switch {
+		case errors.Is(err, ErrBanNotFound):
+		case err != nil:
+			return err
+		case !b.Expired(time.Now()):
+			return ErrPlayerBanned
+		default:
+			if _, err := liftFunc(ctx, b.GameID, b.PlayerID); err != nil && !errors.Is(err, ErrBanNotFound) {
+				return err
+			}
+		}
+
+		return next(ctx, lb, playerID, value)
+	}
+}
@@ -0,0 +1,247 @@
+package ban
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBanFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		leaderboards = []leaderboard.Leaderboard{{ID: "season", GameID: "game"}, {ID: "weekly", GameID: "game"}}
+		listFunc     = func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+			return leaderboards, nil
+		}
+		getRankFunc = func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			if lb.ID == "weekly" {
+				return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+			}
+
+			return leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Value: 42}, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			created Ban
+			hidden  []HiddenRank
+			deleted []string
+		)
+
+		b, err := BuildBanFunc(
+			listFunc,
+			getRankFunc,
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+				deleted = append(deleted, lb.ID)
+				return nil
+			},
+			func(ctx context.Context, b Ban) error {
+				created = b
+				return nil
+			},
+			func(ctx context.Context, gameID, playerID string, ranks []HiddenRank) error {
+				hidden = ranks
+				return nil
+			},
+		)(ctx, NewBanData{GameID: "game", PlayerID: "cheater", Reason: "speed hack", Duration: time.Hour})
+		assert.NoError(t, err)
+		assert.Empty(t, created.HiddenRanks)
+		assert.Equal(t, []HiddenRank{{LeaderboardID: "season", Value: 42}}, hidden)
+		assert.Equal(t, []string{"season"}, deleted)
+		assert.Equal(t, hidden, b.HiddenRanks)
+		assert.Equal(t, time.Hour, b.ExpiresAt.Sub(b.CreatedAt))
+	})
+
+	t.Run("Already Banned", func(t *testing.T) {
+		_, err := BuildBanFunc(listFunc, getRankFunc, nil, func(ctx context.Context, b Ban) error {
+			return ErrPlayerAlreadyBanned
+		}, nil)(ctx, NewBanData{GameID: "game", PlayerID: "cheater"})
+		assert.ErrorIs(t, err, ErrPlayerAlreadyBanned)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildBanFunc(nil, nil, nil, nil, nil)(ctx, NewBanData{Reason: strings.Repeat("a", MaxReasonLength+1), Duration: -time.Second})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+		assert.ErrorIs(t, err, ErrInvalidReason)
+		assert.ErrorIs(t, err, ErrInvalidDuration)
+	})
+}
+
+func TestBuildLiftFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		banned = Ban{GameID: "game", PlayerID: "cheater", HiddenRanks: []HiddenRank{
+			{LeaderboardID: "season", Value: 42},
+			{LeaderboardID: "deleted", Value: 7},
+			{LeaderboardID: "weekly", Value: 3},
+		}}
+		getLeaderboardFunc = func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			if id == "deleted" {
+				return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+			}
+
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		}
+		getRankFunc = func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+		}
+		deleteFunc = func(ctx context.Context, gameID, playerID string) (Ban, error) {
+			return banned, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		restored := make(map[string]float64)
+		b, err := BuildLiftFunc(getLeaderboardFunc, getRankFunc, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			restored[lb.ID] = value
+			return nil
+		}, nil, deleteFunc)(ctx, "game", "cheater")
+		assert.NoError(t, err)
+		assert.Equal(t, banned, b)
+		assert.Equal(t, map[string]float64{"season": 42, "weekly": 3}, restored)
+	})
+
+	t.Run("Restore Failed", func(t *testing.T) {
+		var (
+			errDown   = errors.New("ranking storage down")
+			recreated Ban
+		)
+
+		_, err := BuildLiftFunc(getLeaderboardFunc, getRankFunc, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			if lb.ID == "weekly" {
+				return errDown
+			}
+
+			return nil
+		}, func(ctx context.Context, b Ban) error {
+			recreated = b
+			return nil
+		}, deleteFunc)(ctx, "game", "cheater")
+		assert.ErrorIs(t, err, errDown)
+		assert.Equal(t, []HiddenRank{{LeaderboardID: "weekly", Value: 3}}, recreated.HiddenRanks)
+	})
+
+	t.Run("Still Ranked", func(t *testing.T) {
+		_, err := BuildLiftFunc(getLeaderboardFunc, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			return leaderboard.Rank{Value: 42}, nil
+		}, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			t.Fail()
+			return nil
+		}, nil, deleteFunc)(ctx, "game", "cheater")
+		assert.NoError(t, err)
+	})
+}
+
+func TestBuildExpireFunc(t *testing.T) {
+	ctx := context.Background()
+
+	var lifted []string
+	expireFunc := BuildExpireFunc(10, func(ctx context.Context, gameID, playerID string) (Ban, error) {
+		if playerID == "gone" {
+			return Ban{}, ErrBanNotFound
+		}
+
+		lifted = append(lifted, playerID)
+		return Ban{}, nil
+	}, func(ctx context.Context, now time.Time, limit int) ([]Ban, error) {
+		return []Ban{{GameID: "game", PlayerID: "alice"}, {GameID: "game", PlayerID: "gone"}, {GameID: "game", PlayerID: "bob"}}, nil
+	})
+
+	n, err := expireFunc(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"alice", "bob"}, lifted)
+}
+
+func TestBuildExpireJob(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		batches := []int{10, 10, 3}
+
+		var calls int
+		job := BuildExpireJob(time.Minute, 10, func(ctx context.Context) (int, error) {
+			lifted := batches[calls]
+			calls++
+			return lifted, nil
+		})
+
+		assert.Equal(t, ExpiryJobName, job.Name)
+		assert.Equal(t, time.Minute, job.Interval)
+
+		err := job.Func(ctx, scheduler.Run{StartedAt: time.Now()})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		expireErr := errors.New("any storage error")
+
+		var calls int
+		job := BuildExpireJob(time.Minute, 10, func(ctx context.Context) (int, error) {
+			calls++
+			return 4, expireErr
+		})
+
+		err := job.Func(ctx, scheduler.Run{StartedAt: time.Now()})
+		assert.ErrorIs(t, err, expireErr)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestBuildRejectBannedFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+		lb  = leaderboard.Leaderboard{ID: "season", GameID: "game"}
+	)
+
+	getBan := func(b Ban, err error) StorageGetBanFunc {
+		return func(ctx context.Context, gameID, playerID string) (Ban, error) {
+			return b, err
+		}
+	}
+
+	t.Run("Not Banned", func(t *testing.T) {
+		var applied bool
+		err := BuildRejectBannedFunc(getBan(Ban{}, ErrBanNotFound), nil, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			applied = true
+			return nil
+		})(ctx, lb, "alice", 10)
+		assert.NoError(t, err)
+		assert.True(t, applied)
+	})
+
+	t.Run("Banned", func(t *testing.T) {
+		err := BuildRejectBannedFunc(getBan(Ban{ExpiresAt: time.Now().Add(time.Hour)}, nil), nil, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			t.Fail()
+			return nil
+		})(ctx, lb, "cheater", 10)
+		assert.ErrorIs(t, err, ErrPlayerBanned)
+
+		err = BuildRejectBannedFunc(getBan(Ban{}, nil), nil, nil)(ctx, lb, "cheater", 10)
+		assert.ErrorIs(t, err, ErrPlayerBanned)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		var lifted, applied bool
+		err := BuildRejectBannedFunc(getBan(Ban{GameID: "game", PlayerID: "cheater", ExpiresAt: time.Now().Add(-time.Minute)}, nil), func(ctx context.Context, gameID, playerID string) (Ban, error) {
+			lifted = true
+			return Ban{}, nil
+		}, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			applied = lifted
+			return nil
+		})(ctx, lb, "cheater", 10)
+		assert.NoError(t, err)
+		assert.True(t, applied)
+	})
+}
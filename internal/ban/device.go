@@ -0,0 +1,140 @@
+package ban
+
+import (
+	"context"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+var (
+	ErrInvalidDeviceHash = errors.New("device hash must have between 1 and 128 characters")
+
+	ErrDeviceBanNotFound   = errors.New("device ban not found")
+	ErrDeviceAlreadyBanned = errors.New("device already banned")
+	ErrDeviceBanned        = errors.New("device banned")
+)
+
+type (
+	NewDeviceBanData struct {
+		GameID     string        // Game the device is banned from
+		DeviceHash string        // Device banned, as its hash is sent on the submissions' fingerprint
+		Reason     string        // Why the device was banned, up to 512 characters
+		Duration   time.Duration // How long the ban lasts. Zero bans the device until it's lifted
+	}
+
+	// Device whose submissions are rejected, whatever the player they're sent for
+	DeviceBan struct {
+		CreatedAt  time.Time // Time the device was banned
+		ExpiresAt  time.Time // Time the ban is over. Zero when it lasts until it's lifted
+		GameID     string    // Game the device is banned from
+		DeviceHash string    // Device banned
+		Reason     string    // Why the device was banned
+	}
+)
+
+func validateDeviceHash(deviceHash string) error {
+	if n := utf8.RuneCountInString(deviceHash); n == 0 || n > leaderboard.MaxDeviceHashLength {
+		return ErrInvalidDeviceHash
+	}
+
+	return nil
+}
+
+func (d NewDeviceBanData) validate() error {
+	errList := make([]error, 0)
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if err := validateDeviceHash(d.DeviceHash); err != nil {
+		errList = append(errList, err)
+	}
+
+	if utf8.RuneCountInString(d.Reason) > MaxReasonLength {
+		errList = append(errList, ErrInvalidReason)
+	}
+
+	if d.Duration < 0 {
+		errList = append(errList, ErrInvalidDuration)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// The players that submitted from the device keep their ranks, as a device may be shared
+func BuildBanDeviceFunc(storageCreateDeviceBanFunc StorageCreateDeviceBanFunc) BanDeviceFunc {
+	return func(ctx context.Context, data NewDeviceBanData) (DeviceBan, error) {
+		if err := data.validate(); err != nil {
+			return DeviceBan{}, err
+		}
+
+		b := DeviceBan{
+			CreatedAt:  time.Now().UTC(),
+			GameID:     data.GameID,
+			DeviceHash: data.DeviceHash,
+			Reason:     data.Reason,
+		}
+		if data.Duration > 0 {
+			b.ExpiresAt = b.CreatedAt.Add(data.Duration)
+		}
+
+		if err := storageCreateDeviceBanFunc(ctx, b); err != nil {
+			return DeviceBan{}, err
+		}
+
+		return b, nil
+	}
+}
+
+func BuildLiftDeviceFunc(storageDeleteDeviceBanFunc StorageDeleteDeviceBanFunc) LiftDeviceFunc {
+	return func(ctx context.Context, gameID, deviceHash string) (DeviceBan, error) {
+		if err := validateDeviceHash(deviceHash); err != nil {
+			return DeviceBan{}, err
+		}
+
+		return storageDeleteDeviceBanFunc(ctx, gameID, deviceHash)
+	}
+}
+
+func BuildGetDeviceFunc(storageGetDeviceBanFunc StorageGetDeviceBanFunc) GetDeviceFunc {
+	return func(ctx context.Context, gameID, deviceHash string) (DeviceBan, error) {
+		if err := validateDeviceHash(deviceHash); err != nil {
+			return DeviceBan{}, err
+		}
+
+		return storageGetDeviceBanFunc(ctx, gameID, deviceHash)
+	}
+}
+
+func BuildListDevicesFunc(storageListDeviceBansFunc StorageListDeviceBansFunc) ListDevicesFunc {
+	return func(ctx context.Context, gameID string) ([]DeviceBan, error) {
+		return storageListDeviceBansFunc(ctx, gameID)
+	}
+}
+
+// Wraps the rank update to reject the submissions whose fingerprint carries a banned device with `ErrDeviceBanned`.
+// The submissions without a device hash are applied as usual
+func BuildRejectBannedDeviceFunc(storageGetDeviceBanFunc StorageGetDeviceBanFunc, next leaderboard.StorageUpsertPlayerRankValueFunc) leaderboard.StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		if deviceHash := leaderboard.FingerprintFromContext(ctx).DeviceHash; deviceHash != "" {
+			_, err := storageGetDeviceBanFunc(ctx, lb.GameID, deviceHash)
+			switch {
+			case errors.Is(err, ErrDeviceBanNotFound):
+			case err != nil:
+				return err
+			default:
+				return ErrDeviceBanned
+			}
+		}
+
+		return next(ctx, lb, playerID, value)
+	}
+}
@@ -0,0 +1,87 @@
+package ban
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBanDeviceFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var created DeviceBan
+		b, err := BuildBanDeviceFunc(func(ctx context.Context, b DeviceBan) error {
+			created = b
+			return nil
+		})(ctx, NewDeviceBanData{GameID: "game", DeviceHash: "f00d", Reason: "alt farm", Duration: time.Hour})
+		assert.NoError(t, err)
+		assert.Equal(t, created, b)
+		assert.Equal(t, time.Hour, b.ExpiresAt.Sub(b.CreatedAt))
+	})
+
+	t.Run("Already Banned", func(t *testing.T) {
+		_, err := BuildBanDeviceFunc(func(ctx context.Context, b DeviceBan) error {
+			return ErrDeviceAlreadyBanned
+		})(ctx, NewDeviceBanData{GameID: "game", DeviceHash: "f00d"})
+		assert.ErrorIs(t, err, ErrDeviceAlreadyBanned)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildBanDeviceFunc(nil)(ctx, NewDeviceBanData{GameID: "game", DeviceHash: strings.Repeat("a", leaderboard.MaxDeviceHashLength+1), Duration: -time.Second})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidDeviceHash)
+		assert.ErrorIs(t, err, ErrInvalidDuration)
+	})
+}
+
+func TestBuildLiftDeviceFunc(t *testing.T) {
+	b, err := BuildLiftDeviceFunc(func(ctx context.Context, gameID, deviceHash string) (DeviceBan, error) {
+		return DeviceBan{GameID: gameID, DeviceHash: deviceHash}, nil
+	})(context.Background(), "game", "f00d")
+	assert.NoError(t, err)
+	assert.Equal(t, DeviceBan{GameID: "game", DeviceHash: "f00d"}, b)
+
+	_, err = BuildLiftDeviceFunc(nil)(context.Background(), "game", "")
+	assert.ErrorIs(t, err, ErrInvalidDeviceHash)
+}
+
+func TestBuildRejectBannedDeviceFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+		lb  = leaderboard.Leaderboard{ID: "season", GameID: "game"}
+	)
+
+	getDeviceBan := func(ctx context.Context, gameID, deviceHash string) (DeviceBan, error) {
+		if deviceHash != "banned" {
+			return DeviceBan{}, ErrDeviceBanNotFound
+		}
+
+		return DeviceBan{GameID: gameID, DeviceHash: deviceHash}, nil
+	}
+
+	t.Run("Not Banned", func(t *testing.T) {
+		var applied int
+		upsert := BuildRejectBannedDeviceFunc(getDeviceBan, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			applied++
+			return nil
+		})
+
+		assert.NoError(t, upsert(leaderboard.WithFingerprint(ctx, leaderboard.Fingerprint{DeviceHash: "clean"}), lb, "alice", 10))
+		assert.NoError(t, upsert(ctx, lb, "alice", 10))
+		assert.Equal(t, 2, applied)
+	})
+
+	t.Run("Banned", func(t *testing.T) {
+		err := BuildRejectBannedDeviceFunc(getDeviceBan, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			t.Fail()
+			return nil
+		})(leaderboard.WithFingerprint(ctx, leaderboard.Fingerprint{DeviceHash: "banned"}), lb, "throwaway", 10)
+		assert.ErrorIs(t, err, ErrDeviceBanned)
+	})
+}
@@ -0,0 +1,52 @@
+package ban
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type (
+	// Stores the ban. Fails with `ErrPlayerAlreadyBanned` when the player is banned from the game already
+	StorageCreateBanFunc func(ctx context.Context, b Ban) error
+
+	// Player's ban. Fails with `ErrBanNotFound` when the player isn't banned
+	StorageGetBanFunc func(ctx context.Context, gameID, playerID string) (Ban, error)
+
+	// Saves the ranks the player's ban hides
+	StorageSetHiddenRanksFunc func(ctx context.Context, gameID, playerID string, ranks []HiddenRank) error
+
+	// Removes the player's ban at once, returning it. Fails with `ErrBanNotFound` when the player isn't banned
+	StorageDeleteBanFunc func(ctx context.Context, gameID, playerID string) (Ban, error)
+
+	// Lists the bans of the game, oldest first
+	StorageListBansFunc func(ctx context.Context, gameID string) ([]Ban, error)
+
+	// Lists up to `limit` bans of every game that expired by `now`, the earliest expiration first
+	StorageListExpiredBansFunc func(ctx context.Context, now time.Time, limit int) ([]Ban, error)
+
+	// Lists the leaderboards of the game that are not soft deleted
+	StorageListLeaderboardsFunc func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error)
+
+	// Leaderboard by its id and game id
+	StorageGetLeaderboardFunc func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error)
+
+	// Player's rank on the leaderboard. Fails with `leaderboard.ErrPlayerRankNotFound` when the player isn't ranked
+	StorageGetPlayerRankFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error)
+
+	// Removes the player from the leaderboard ranking
+	StorageDeletePlayerRankFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error
+
+	// Stores the device ban. Fails with `ErrDeviceAlreadyBanned` when the device is banned from the game already
+	StorageCreateDeviceBanFunc func(ctx context.Context, b DeviceBan) error
+
+	// Device's ban, as long as it hasn't expired. Fails with `ErrDeviceBanNotFound` when the device isn't banned
+	StorageGetDeviceBanFunc func(ctx context.Context, gameID, deviceHash string) (DeviceBan, error)
+
+	// Removes the device's ban, returning it. Fails with `ErrDeviceBanNotFound` when the device isn't banned
+	StorageDeleteDeviceBanFunc func(ctx context.Context, gameID, deviceHash string) (DeviceBan, error)
+
+	// Lists the device bans of the game that haven't expired, oldest first
+	StorageListDeviceBansFunc func(ctx context.Context, gameID string) ([]DeviceBan, error)
+)
@@ -0,0 +1,32 @@
+package ban
+
+import "context"
+
+type (
+	// Bans the player from the game, hiding their ranks until the ban is lifted
+	BanFunc func(ctx context.Context, data NewBanData) (Ban, error)
+
+	// Lifts the player's ban, putting back the ranks it hid. Returns the ban lifted
+	LiftFunc func(ctx context.Context, gameID, playerID string) (Ban, error)
+
+	// Get the player's ban
+	GetFunc func(ctx context.Context, gameID, playerID string) (Ban, error)
+
+	// List the bans of the game, oldest first
+	ListFunc func(ctx context.Context, gameID string) ([]Ban, error)
+
+	// Lifts the bans already expired, returning how many were lifted
+	ExpireFunc func(ctx context.Context) (int, error)
+
+	// Bans the device from the game, rejecting the submissions it sends whatever the player
+	BanDeviceFunc func(ctx context.Context, data NewDeviceBanData) (DeviceBan, error)
+
+	// Lifts the device's ban. Returns the ban lifted
+	LiftDeviceFunc func(ctx context.Context, gameID, deviceHash string) (DeviceBan, error)
+
+	// Get the device's ban
+	GetDeviceFunc func(ctx context.Context, gameID, deviceHash string) (DeviceBan, error)
+
+	// List the device bans of the game, oldest first
+	ListDevicesFunc func(ctx context.Context, gameID string) ([]DeviceBan, error)
+)
@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+const (
+	// IDs accepted on each list field, so a query can't fan out to an unbounded number of reads
+	MaxIDs = 100
+
+	maxDepth = 8
+)
+
+var (
+	ErrTooManyIDs   = errors.New("too many ids")
+	ErrUnknownError = errors.New("unknown error")
+)
+
+//go:embed schema.graphql
+var schema string
+
+type Config struct {
+	// Leaderboard
+	GetLeaderboardByIDAndGameIDFunc leaderboard.GetByIDAndGameIDFunc
+	GetPlayerRankFunc               leaderboard.GetPlayerRankFunc
+
+	// Statistic
+	GetStatisticByIDAndGameIDFunc     statistic.GetByIDAndGameIDFunc
+	GetPlayerStatisticProgressionFunc statistic.GetPlayerProgressionFunc
+
+	// Quest
+	GetQuestByIDAndGameIDFunc     quest.GetQuestByIDAndGameIDFunc
+	GetPlayerQuestProgressionFunc quest.GetPlayerQuestProgressionFunc
+}
+
+type Request struct {
+	Query         string         `json:"query"`         // GraphQL query
+	OperationName string         `json:"operationName"` // Operation to run, when the query has many
+	Variables     map[string]any `json:"variables"`     // Query variables
+}
+
+// Runs a read query on behalf of the game
+type ExecuteFunc func(ctx context.Context, gameID string, req Request) *graphqlgo.Response
+
+func BuildExecuteFunc(config Config) ExecuteFunc {
+	s := graphqlgo.MustParseSchema(schema, &queryResolver{}, graphqlgo.MaxDepth(maxDepth))
+
+	return func(ctx context.Context, gameID string, req Request) *graphqlgo.Response {
+		ctx = context.WithValue(ctx, loadersKey{}, newLoaders(config, gameID))
+		return s.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	}
+}
+
+// Keeps the domain errors the client can act on, hiding the others behind a generic one
+func resolverError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, leaderboard.ErrInvalidLeaderboardID),
+		errors.Is(err, leaderboard.ErrLeaderboardNotFound),
+		errors.Is(err, statistic.ErrInvalidStatisticID),
+		errors.Is(err, statistic.ErrStatisticNotFound),
+		errors.Is(err, quest.ErrInvalidQuestID),
+		errors.Is(err, quest.ErrQuestNotFound),
+		errors.Is(err, ErrTooManyIDs),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return err
+	default:
+		zap.ErrorContext(ctx, err, "graphql resolver error")
+		return ErrUnknownError
+	}
+}
@@ -0,0 +1,171 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildExecuteFunc(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+		now      = time.Now().UTC().Truncate(time.Second)
+	)
+
+	config := func(getLeaderboardCalls *atomic.Int32) Config {
+		goal := 100.0
+
+		return Config{
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, g string) (leaderboard.Leaderboard, error) {
+				getLeaderboardCalls.Add(1)
+				assert.Equal(t, gameID, g)
+				if id == "missing" {
+					return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+				}
+
+				return leaderboard.Leaderboard{ID: id, GameID: g, Name: "lb-" + id, StartAt: now}, nil
+			},
+			GetPlayerRankFunc: func(ctx context.Context, lb leaderboard.Leaderboard, id string) (leaderboard.Rank, error) {
+				if lb.ID == "unranked" {
+					return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+				}
+
+				return leaderboard.Rank{PlayerID: id, Position: 4, Value: 50}, nil
+			},
+			GetStatisticByIDAndGameIDFunc: func(ctx context.Context, id, g string) (statistic.Statistic, error) {
+				return statistic.Statistic{ID: id, GameID: g, Name: "kills", Goal: &goal}, nil
+			},
+			GetPlayerStatisticProgressionFunc: func(ctx context.Context, statisticID, id string) (statistic.PlayerProgression, error) {
+				current := 10.0
+				return statistic.PlayerProgression{StatisticID: statisticID, PlayerID: id, StartedAt: now, CurrentValue: &current, GoalValue: &goal}, nil
+			},
+			GetQuestByIDAndGameIDFunc: func(ctx context.Context, id, g string) (quest.Quest, error) {
+				return quest.Quest{ID: id, GameID: g, Name: "tutorial"}, nil
+			},
+			GetPlayerQuestProgressionFunc: func(ctx context.Context, q quest.Quest, id string) (quest.PlayerQuestProgression, error) {
+				return quest.PlayerQuestProgression{}, quest.ErrPlayerNotStartedTheQuest
+			},
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var getLeaderboardCalls atomic.Int32
+		executeFunc := BuildExecuteFunc(config(&getLeaderboardCalls))
+
+		resp := executeFunc(ctx, gameID, Request{
+			Query: `query($id: ID!) {
+				player(id: $id) {
+					id
+					ranks(leaderboardIds: ["a", "a", "unranked"]) { leaderboard { id name } rank { position value } }
+					statistics(statisticIds: ["s"]) { statistic { name goal } progression { currentValue goalValue goalCompleted } }
+					quests(questIds: ["q"]) { quest { name } progression { startedAt } }
+				}
+			}`,
+			Variables: map[string]any{"id": playerID},
+		})
+		assert.Empty(t, resp.Errors)
+
+		var data struct {
+			Player struct {
+				ID    string `json:"id"`
+				Ranks []struct {
+					Leaderboard struct{ ID, Name string }
+					Rank        *struct {
+						Position int
+						Value    float64
+					}
+				}
+				Statistics []struct {
+					Statistic struct {
+						Name string
+						Goal float64
+					}
+					Progression struct {
+						CurrentValue  float64
+						GoalValue     float64
+						GoalCompleted *bool
+					}
+				}
+				Quests []struct {
+					Quest       struct{ Name string }
+					Progression *struct{}
+				}
+			}
+		}
+		assert.NoError(t, json.Unmarshal(resp.Data, &data))
+
+		assert.Equal(t, playerID, data.Player.ID)
+		assert.Len(t, data.Player.Ranks, 3)
+		assert.Equal(t, "lb-a", data.Player.Ranks[0].Leaderboard.Name)
+		assert.Equal(t, 4, data.Player.Ranks[1].Rank.Position)
+		assert.Nil(t, data.Player.Ranks[2].Rank)
+		assert.Equal(t, 100.0, data.Player.Statistics[0].Statistic.Goal)
+		assert.Equal(t, 10.0, data.Player.Statistics[0].Progression.CurrentValue)
+		assert.Nil(t, data.Player.Statistics[0].Progression.GoalCompleted)
+		assert.Equal(t, "tutorial", data.Player.Quests[0].Quest.Name)
+		assert.Nil(t, data.Player.Quests[0].Progression)
+
+		// Each leaderboard is read once, however many fields need it
+		assert.Equal(t, int32(2), getLeaderboardCalls.Load())
+	})
+
+	t.Run("Leaderboard Not Found", func(t *testing.T) {
+		var getLeaderboardCalls atomic.Int32
+		executeFunc := BuildExecuteFunc(config(&getLeaderboardCalls))
+
+		resp := executeFunc(ctx, gameID, Request{
+			Query: `{ player(id: "p") { ranks(leaderboardIds: ["missing"]) { rank { position } } } }`,
+		})
+		assert.Len(t, resp.Errors, 1)
+		assert.Equal(t, leaderboard.ErrLeaderboardNotFound.Error(), resp.Errors[0].Message)
+	})
+
+	t.Run("Too Many IDs", func(t *testing.T) {
+		var getLeaderboardCalls atomic.Int32
+		executeFunc := BuildExecuteFunc(config(&getLeaderboardCalls))
+
+		// Variables come decoded from JSON
+		ids := make([]any, MaxIDs+1)
+		for i := range ids {
+			ids[i] = uuid.NewString()
+		}
+
+		resp := executeFunc(ctx, gameID, Request{
+			Query:     `query($ids: [ID!]!) { player(id: "p") { ranks(leaderboardIds: $ids) { rank { position } } } }`,
+			Variables: map[string]any{"ids": ids},
+		})
+		assert.Len(t, resp.Errors, 1)
+		assert.Equal(t, ErrTooManyIDs.Error(), resp.Errors[0].Message)
+		assert.Equal(t, int32(0), getLeaderboardCalls.Load())
+	})
+
+	t.Run("Unknown Error", func(t *testing.T) {
+		zap.Start()
+		defer zap.Sync()
+
+		c := config(new(atomic.Int32))
+		c.GetStatisticByIDAndGameIDFunc = func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+			return statistic.Statistic{}, errors.New("any error")
+		}
+		executeFunc := BuildExecuteFunc(c)
+
+		resp := executeFunc(ctx, gameID, Request{
+			Query: `{ player(id: "p") { statistics(statisticIds: ["s"]) { statistic { name } } } }`,
+		})
+		assert.Len(t, resp.Errors, 1)
+		assert.Equal(t, ErrUnknownError.Error(), resp.Errors[0].Message)
+	})
+}
@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// How long a loader waits for more keys before loading them, so the fields resolved in parallel share a batch
+const loaderWait = 2 * time.Millisecond
+
+type result[V any] struct {
+	value V
+	err   error
+	done  chan struct{}
+}
+
+// Loads the keys requested within `loaderWait` of each other in one batch, and keeps their results for the
+// rest of the query, so the same leaderboard or progression is read once however many fields need it
+type loader[K comparable, V any] struct {
+	batchFunc func(ctx context.Context, keys []K) map[K]result[V]
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending []K
+}
+
+func newLoader[K comparable, V any](batchFunc func(ctx context.Context, keys []K) map[K]result[V]) *loader[K, V] {
+	return &loader[K, V]{
+		batchFunc: batchFunc,
+		results:   make(map[K]*result[V]),
+	}
+}
+
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r, ok := l.results[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.results[key] = r
+
+		l.pending = append(l.pending, key)
+		if len(l.pending) == 1 {
+			time.AfterFunc(loaderWait, func() { l.dispatch(ctx) })
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *loader[K, V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	loaded := l.batchFunc(ctx, keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		r := l.results[key]
+		r.value, r.err = loaded[key].value, loaded[key].err
+		close(r.done)
+	}
+}
+
+// Builds a batch func out of a func that loads a single key, loading the keys of the batch concurrently
+func fanOut[K comparable, V any](loadFunc func(ctx context.Context, key K) (V, error)) func(ctx context.Context, keys []K) map[K]result[V] {
+	return func(ctx context.Context, keys []K) map[K]result[V] {
+		var (
+			mu      sync.Mutex
+			wg      sync.WaitGroup
+			results = make(map[K]result[V], len(keys))
+		)
+
+		for _, key := range keys {
+			wg.Add(1)
+			go func(key K) {
+				defer wg.Done()
+
+				value, err := loadFunc(ctx, key)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				results[key] = result[V]{value: value, err: err}
+			}(key)
+		}
+
+		wg.Wait()
+		return results
+	}
+}
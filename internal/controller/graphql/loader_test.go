@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoader(t *testing.T) {
+	t.Run("Batches And Caches Keys", func(t *testing.T) {
+		var (
+			mu      sync.Mutex
+			batches [][]int
+		)
+
+		l := newLoader(func(ctx context.Context, keys []int) map[int]result[int] {
+			mu.Lock()
+			batches = append(batches, keys)
+			mu.Unlock()
+
+			return fanOut(func(ctx context.Context, key int) (int, error) { return key * 2, nil })(ctx, keys)
+		})
+
+		var wg sync.WaitGroup
+		for _, key := range []int{1, 2, 2, 3} {
+			wg.Add(1)
+			go func(key int) {
+				defer wg.Done()
+
+				value, err := l.Load(context.Background(), key)
+				assert.NoError(t, err)
+				assert.Equal(t, key*2, value)
+			}(key)
+		}
+		wg.Wait()
+
+		value, err := l.Load(context.Background(), 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, value)
+
+		assert.Len(t, batches, 1)
+		assert.ElementsMatch(t, []int{1, 2, 3}, batches[0])
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		errExpected := errors.New("any error")
+
+		l := newLoader(fanOut(func(ctx context.Context, key string) (string, error) {
+			return "", errExpected
+		}))
+
+		_, err := l.Load(context.Background(), "a")
+		assert.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("Context Done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		l := newLoader(fanOut(func(ctx context.Context, key string) (string, error) {
+			return key, nil
+		}))
+
+		_, err := l.Load(ctx, "a")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
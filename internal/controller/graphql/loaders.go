@@ -0,0 +1,73 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+type loadersKey struct{}
+
+// A player's data on a leaderboard, statistic or quest
+type playerKey struct {
+	id       string
+	playerID string
+}
+
+// Loaders of a single query, all scoped to the game that made it
+type loaders struct {
+	leaderboards       *loader[string, leaderboard.Leaderboard]
+	playerRanks        *loader[playerKey, leaderboard.Rank]
+	statistics         *loader[string, statistic.Statistic]
+	playerProgressions *loader[playerKey, statistic.PlayerProgression]
+	quests             *loader[string, quest.Quest]
+	playerQuests       *loader[playerKey, quest.PlayerQuestProgression]
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func newLoaders(config Config, gameID string) *loaders {
+	l := new(loaders)
+
+	l.leaderboards = newLoader(fanOut(func(ctx context.Context, id string) (leaderboard.Leaderboard, error) {
+		return config.GetLeaderboardByIDAndGameIDFunc(ctx, id, gameID)
+	}))
+	l.playerRanks = newLoader(fanOut(func(ctx context.Context, key playerKey) (leaderboard.Rank, error) {
+		lb, err := l.leaderboards.Load(ctx, key.id)
+		if err != nil {
+			return leaderboard.Rank{}, err
+		}
+
+		return config.GetPlayerRankFunc(ctx, lb, key.playerID)
+	}))
+
+	l.statistics = newLoader(fanOut(func(ctx context.Context, id string) (statistic.Statistic, error) {
+		return config.GetStatisticByIDAndGameIDFunc(ctx, id, gameID)
+	}))
+	l.playerProgressions = newLoader(fanOut(func(ctx context.Context, key playerKey) (statistic.PlayerProgression, error) {
+		// Loaded first, so another game's statistic isn't read
+		if _, err := l.statistics.Load(ctx, key.id); err != nil {
+			return statistic.PlayerProgression{}, err
+		}
+
+		return config.GetPlayerStatisticProgressionFunc(ctx, key.id, key.playerID)
+	}))
+
+	l.quests = newLoader(fanOut(func(ctx context.Context, id string) (quest.Quest, error) {
+		return config.GetQuestByIDAndGameIDFunc(ctx, id, gameID)
+	}))
+	l.playerQuests = newLoader(fanOut(func(ctx context.Context, key playerKey) (quest.PlayerQuestProgression, error) {
+		q, err := l.quests.Load(ctx, key.id)
+		if err != nil {
+			return quest.PlayerQuestProgression{}, err
+		}
+
+		return config.GetPlayerQuestProgressionFunc(ctx, q, key.playerID)
+	}))
+
+	return l
+}
@@ -0,0 +1,275 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// Zero times are resolved as null
+func timeOrNil(t time.Time) *graphqlgo.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &graphqlgo.Time{Time: t}
+}
+
+type queryResolver struct{}
+
+func (*queryResolver) Player(args struct{ ID graphqlgo.ID }) *playerResolver {
+	return &playerResolver{id: string(args.ID)}
+}
+
+type playerResolver struct {
+	id string
+}
+
+func (p *playerResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(p.id)
+}
+
+func (p *playerResolver) Ranks(args struct{ LeaderboardIDs []graphqlgo.ID }) ([]*playerRankResolver, error) {
+	if len(args.LeaderboardIDs) > MaxIDs {
+		return nil, ErrTooManyIDs
+	}
+
+	ranks := make([]*playerRankResolver, len(args.LeaderboardIDs))
+	for i, id := range args.LeaderboardIDs {
+		ranks[i] = &playerRankResolver{key: playerKey{id: string(id), playerID: p.id}}
+	}
+
+	return ranks, nil
+}
+
+func (p *playerResolver) Statistics(args struct{ StatisticIDs []graphqlgo.ID }) ([]*playerStatisticResolver, error) {
+	if len(args.StatisticIDs) > MaxIDs {
+		return nil, ErrTooManyIDs
+	}
+
+	statistics := make([]*playerStatisticResolver, len(args.StatisticIDs))
+	for i, id := range args.StatisticIDs {
+		statistics[i] = &playerStatisticResolver{key: playerKey{id: string(id), playerID: p.id}}
+	}
+
+	return statistics, nil
+}
+
+func (p *playerResolver) Quests(args struct{ QuestIDs []graphqlgo.ID }) ([]*playerQuestResolver, error) {
+	if len(args.QuestIDs) > MaxIDs {
+		return nil, ErrTooManyIDs
+	}
+
+	quests := make([]*playerQuestResolver, len(args.QuestIDs))
+	for i, id := range args.QuestIDs {
+		quests[i] = &playerQuestResolver{key: playerKey{id: string(id), playerID: p.id}}
+	}
+
+	return quests, nil
+}
+
+// Leaderboards
+
+type playerRankResolver struct {
+	key playerKey
+}
+
+func (r *playerRankResolver) Leaderboard(ctx context.Context) (*leaderboardResolver, error) {
+	lb, err := loadersFromContext(ctx).leaderboards.Load(ctx, r.key.id)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	return &leaderboardResolver{lb: lb}, nil
+}
+
+func (r *playerRankResolver) Rank(ctx context.Context) (*rankResolver, error) {
+	rank, err := loadersFromContext(ctx).playerRanks.Load(ctx, r.key)
+	switch {
+	case errors.Is(err, leaderboard.ErrPlayerRankNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, resolverError(ctx, err)
+	}
+
+	return &rankResolver{rank: rank}, nil
+}
+
+type leaderboardResolver struct {
+	lb leaderboard.Leaderboard
+}
+
+func (r *leaderboardResolver) ID() graphqlgo.ID        { return graphqlgo.ID(r.lb.ID) }
+func (r *leaderboardResolver) Name() string            { return r.lb.Name }
+func (r *leaderboardResolver) Description() string     { return r.lb.Description }
+func (r *leaderboardResolver) StartAt() graphqlgo.Time { return graphqlgo.Time{Time: r.lb.StartAt} }
+func (r *leaderboardResolver) EndAt() *graphqlgo.Time  { return timeOrNil(r.lb.EndAt) }
+func (r *leaderboardResolver) AggregationMode() string { return r.lb.AggregationMode }
+func (r *leaderboardResolver) Ordering() string        { return r.lb.Ordering }
+func (r *leaderboardResolver) Closed() bool            { return r.lb.Closed() }
+
+type rankResolver struct {
+	rank leaderboard.Rank
+}
+
+func (r *rankResolver) Position() int32 { return int32(r.rank.Position) }
+func (r *rankResolver) Value() float64  { return r.rank.Value }
+
+// Statistics
+
+type playerStatisticResolver struct {
+	key playerKey
+}
+
+func (r *playerStatisticResolver) Statistic(ctx context.Context) (*statisticResolver, error) {
+	s, err := loadersFromContext(ctx).statistics.Load(ctx, r.key.id)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	return &statisticResolver{statistic: s}, nil
+}
+
+func (r *playerStatisticResolver) Progression(ctx context.Context) (*statisticProgressionResolver, error) {
+	progression, err := loadersFromContext(ctx).playerProgressions.Load(ctx, r.key)
+	switch {
+	case errors.Is(err, statistic.ErrPlayerStatisticNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, resolverError(ctx, err)
+	}
+
+	return &statisticProgressionResolver{progression: progression}, nil
+}
+
+type statisticResolver struct {
+	statistic statistic.Statistic
+}
+
+func (r *statisticResolver) ID() graphqlgo.ID        { return graphqlgo.ID(r.statistic.ID) }
+func (r *statisticResolver) Name() string            { return r.statistic.Name }
+func (r *statisticResolver) Description() string     { return r.statistic.Description }
+func (r *statisticResolver) AggregationMode() string { return r.statistic.AggregationMode }
+func (r *statisticResolver) InitialValue() *float64  { return r.statistic.InitialValue }
+func (r *statisticResolver) Goal() *float64          { return r.statistic.Goal }
+
+type statisticProgressionResolver struct {
+	progression statistic.PlayerProgression
+}
+
+func (r *statisticProgressionResolver) StartedAt() *graphqlgo.Time {
+	return timeOrNil(r.progression.StartedAt)
+}
+func (r *statisticProgressionResolver) UpdatedAt() *graphqlgo.Time {
+	return timeOrNil(r.progression.UpdatedAt)
+}
+func (r *statisticProgressionResolver) CurrentValue() *float64 { return r.progression.CurrentValue }
+func (r *statisticProgressionResolver) GoalValue() *float64    { return r.progression.GoalValue }
+func (r *statisticProgressionResolver) GoalCompleted() *bool   { return r.progression.GoalCompleted }
+func (r *statisticProgressionResolver) GoalCompletedAt() *graphqlgo.Time {
+	return timeOrNil(r.progression.GoalCompletedAt)
+}
+
+func (r *statisticProgressionResolver) Landmarks() []*statisticLandmarkResolver {
+	landmarks := make([]*statisticLandmarkResolver, len(r.progression.Landmarks))
+	for i, landmark := range r.progression.Landmarks {
+		landmarks[i] = &statisticLandmarkResolver{landmark: landmark}
+	}
+
+	return landmarks
+}
+
+type statisticLandmarkResolver struct {
+	landmark statistic.PlayerProgressionLandmark
+}
+
+func (r *statisticLandmarkResolver) Value() float64  { return r.landmark.Value }
+func (r *statisticLandmarkResolver) Completed() bool { return r.landmark.Completed }
+func (r *statisticLandmarkResolver) CompletedAt() *graphqlgo.Time {
+	return timeOrNil(r.landmark.CompletedAt)
+}
+
+// Quests
+
+type playerQuestResolver struct {
+	key playerKey
+}
+
+func (r *playerQuestResolver) Quest(ctx context.Context) (*questResolver, error) {
+	q, err := loadersFromContext(ctx).quests.Load(ctx, r.key.id)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	return &questResolver{quest: q}, nil
+}
+
+func (r *playerQuestResolver) Progression(ctx context.Context) (*questProgressionResolver, error) {
+	l := loadersFromContext(ctx)
+
+	// Some storages report a quest the player hasn't started as not found, told apart by loading the quest itself
+	if _, err := l.quests.Load(ctx, r.key.id); err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	progression, err := l.playerQuests.Load(ctx, r.key)
+	switch {
+	case errors.Is(err, quest.ErrPlayerNotStartedTheQuest), errors.Is(err, quest.ErrQuestNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, resolverError(ctx, err)
+	}
+
+	return &questProgressionResolver{progression: progression}, nil
+}
+
+type questResolver struct {
+	quest quest.Quest
+}
+
+func (r *questResolver) ID() graphqlgo.ID    { return graphqlgo.ID(r.quest.ID) }
+func (r *questResolver) Name() string        { return r.quest.Name }
+func (r *questResolver) Description() string { return r.quest.Description }
+
+type questProgressionResolver struct {
+	progression quest.PlayerQuestProgression
+}
+
+func (r *questProgressionResolver) StartedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.progression.StartedAt}
+}
+func (r *questProgressionResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.progression.UpdatedAt}
+}
+func (r *questProgressionResolver) CompletedAt() *graphqlgo.Time {
+	return timeOrNil(r.progression.CompletedAt)
+}
+
+func (r *questProgressionResolver) Tasks() []*taskProgressionResolver {
+	tasks := make([]*taskProgressionResolver, len(r.progression.TasksProgression))
+	for i, task := range r.progression.TasksProgression {
+		tasks[i] = &taskProgressionResolver{task: task}
+	}
+
+	return tasks
+}
+
+type taskProgressionResolver struct {
+	task quest.PlayerTaskProgression
+}
+
+func (r *taskProgressionResolver) TaskID() graphqlgo.ID { return graphqlgo.ID(r.task.Task.ID) }
+func (r *taskProgressionResolver) Name() string         { return r.task.Task.Name }
+func (r *taskProgressionResolver) StartedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.task.StartedAt}
+}
+func (r *taskProgressionResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.task.UpdatedAt}
+}
+func (r *taskProgressionResolver) CompletedAt() *graphqlgo.Time { return timeOrNil(r.task.CompletedAt) }
@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/availability"
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/delta"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+	"github.com/gabapcia/gameblitz/internal/segment"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/throttle"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Maps the domain errors to their status, as the REST error handler does to the HTTP ones
+func statusFromError(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	// Availability
+	case errors.Is(err, availability.ErrReadOnly):
+		return status.Error(codes.Unavailable, err.Error())
+	// Auth
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		return status.Error(codes.PermissionDenied, err.Error())
+	// Ban
+	case errors.Is(err, ban.ErrPlayerBanned),
+		errors.Is(err, ban.ErrDeviceBanned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	// Submission Rate Limit
+	case errors.Is(err, throttle.ErrSubmissionRateLimited):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	// Segment
+	case errors.Is(err, segment.ErrPlayerNotEligible):
+		return status.Error(codes.PermissionDenied, err.Error())
+	// Delta Rule
+	case errors.Is(err, delta.ErrDeltaExceeded):
+		return status.Error(codes.PermissionDenied, err.Error())
+	// Quarantine
+	case errors.Is(err, quarantine.ErrSubmissionQuarantined):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	// Statistic
+	case errors.Is(err, statistic.ErrPlayerStatisticNotFound),
+		errors.Is(err, statistic.ErrStatisticNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, statistic.ErrInvalidStatisticID):
+		return status.Error(codes.InvalidArgument, err.Error())
+	// Leaderboard
+	case errors.Is(err, leaderboard.ErrLeaderboardClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, leaderboard.ErrInvalidPageNumber),
+		errors.Is(err, leaderboard.ErrInvalidLimitNumber),
+		errors.Is(err, leaderboard.ErrInvalidLeaderboardID),
+		errors.Is(err, leaderboard.ErrInvalidFingerprint):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, leaderboard.ErrLeaderboardNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	// Unknown
+	default:
+		zap.ErrorContext(ctx, err, "unknown error")
+		return status.Error(codes.Internal, "Unknown error")
+	}
+}
+
+func errorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, statusFromError(ctx, err)
+	}
+
+	return resp, nil
+}
+
+func errorStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, ss); err != nil {
+		return statusFromError(ss.Context(), err)
+	}
+
+	return nil
+}
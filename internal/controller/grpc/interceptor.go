@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type claimsKey struct{}
+
+// Claims of the game that made the call, set by the auth interceptor
+func claimsFromContext(ctx context.Context) auth.Claims {
+	claims, _ := ctx.Value(claimsKey{}).(auth.Claims)
+	return claims
+}
+
+func recoverInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			zap.ErrorContext(ctx, fmt.Errorf("%v", r), "grpc handler panic", "method", info.FullMethod)
+			err = status.Error(codes.Internal, "Unknown error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func requestLoggerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(zap.WithFields(ctx, "method", info.FullMethod), req)
+}
+
+// Authenticates the call with the game's JWT on the `authorization` metadata, like the REST API does with the header
+func authenticate(ctx context.Context, authenticateFunc auth.AuthenticateFunc) (context.Context, error) {
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+
+	if authorization == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	claims, err := authenticateFunc(ctx, authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, claimsKey{}, claims)
+	return zap.WithFields(ctx, "gameId", claims.GameID), nil
+}
+
+// Health checks come from the orchestrator, which holds no game credentials
+func isHealthCheck(method string) bool {
+	return method == grpc_health_v1.Health_Check_FullMethodName || method == grpc_health_v1.Health_Watch_FullMethodName
+}
+
+func buildAuthInterceptor(authenticateFunc auth.AuthenticateFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isHealthCheck(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, authenticateFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Server stream carrying a context derived from the call's, as streams can't take a new one otherwise
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func recoverStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			zap.ErrorContext(ss.Context(), fmt.Errorf("%v", r), "grpc handler panic", "method", info.FullMethod)
+			err = status.Error(codes.Internal, "Unknown error")
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func requestLoggerStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: ss, ctx: zap.WithFields(ss.Context(), "method", info.FullMethod)})
+}
+
+func buildAuthStreamInterceptor(authenticateFunc auth.AuthenticateFunc) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isHealthCheck(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), authenticateFunc)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/controller/grpc/pb"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestBuildAuthInterceptor(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		var (
+			gameID      = uuid.NewString()
+			credentials = uuid.NewString()
+		)
+
+		conn := dial(t, Config{
+			AuthenticateFunc: func(ctx context.Context, c string) (auth.Claims, error) {
+				assert.Equal(t, credentials, c)
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, g string) (leaderboard.Leaderboard, error) {
+				assert.Equal(t, gameID, g)
+				return leaderboard.Leaderboard{ID: id, GameID: g}, nil
+			},
+			RankingFunc: func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+				return nil, nil
+			},
+		})
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", credentials)
+		_, err := pb.NewLeaderboardServiceClient(conn).GetRanking(ctx, &pb.GetRankingRequest{LeaderboardId: uuid.NewString()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Missing Credentials", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				assert.Fail(t, "authenticated without credentials")
+				return auth.Claims{}, nil
+			},
+		})
+
+		_, err := pb.NewLeaderboardServiceClient(conn).GetRanking(context.Background(), &pb.GetRankingRequest{LeaderboardId: uuid.NewString()})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Invalid Credentials", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{}, auth.ErrInvalidCredentials
+			},
+		})
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", uuid.NewString())
+		_, err := pb.NewLeaderboardServiceClient(conn).GetRanking(ctx, &pb.GetRankingRequest{LeaderboardId: uuid.NewString()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Health Check Without Credentials", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				assert.Fail(t, "authenticated a health check")
+				return auth.Claims{}, nil
+			},
+		})
+
+		resp, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+	})
+}
@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/gabapcia/gameblitz/internal/controller/grpc/pb"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRankingLimit    = 10
+	defaultIngestBatchSize = 500
+)
+
+type leaderboardServer struct {
+	pb.UnimplementedLeaderboardServiceServer
+
+	getLeaderboardByIDAndGameIDFunc leaderboard.GetByIDAndGameIDFunc
+	upsertPlayerRankFunc            leaderboard.UpsertPlayerRankFunc
+	rankingFunc                     leaderboard.RankingFunc
+	ingestBatchSize                 int
+}
+
+func rankToProto(r leaderboard.Rank) *pb.Rank {
+	return &pb.Rank{
+		PlayerId: r.PlayerID,
+		Position: r.Position,
+		Value:    r.Value,
+	}
+}
+
+// Client the call came from, read from the `x-device-hash`, `x-build-version` and `x-region` metadata, like the REST API
+// does with the headers. The region is expected to be set by the proxy in front of the server
+func fingerprintFromContext(ctx context.Context) (leaderboard.Fingerprint, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	get := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+
+		return ""
+	}
+
+	f := leaderboard.Fingerprint{
+		DeviceHash:   get("x-device-hash"),
+		BuildVersion: get("x-build-version"),
+		Region:       get("x-region"),
+	}
+	return f, f.Validate()
+}
+
+func (s *leaderboardServer) UpsertPlayerRank(ctx context.Context, req *pb.UpsertPlayerRankRequest) (*pb.UpsertPlayerRankResponse, error) {
+	lb, err := s.getLeaderboardByIDAndGameIDFunc(ctx, req.GetLeaderboardId(), claimsFromContext(ctx).GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint, err := fingerprintFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = leaderboard.WithFingerprint(leaderboard.WithSource(ctx, leaderboard.SourceGRPC), fingerprint)
+	if err := s.upsertPlayerRankFunc(ctx, lb, req.GetPlayerId(), req.GetValue()); err != nil {
+		return nil, err
+	}
+
+	return &pb.UpsertPlayerRankResponse{}, nil
+}
+
+func (s *leaderboardServer) GetRanking(ctx context.Context, req *pb.GetRankingRequest) (*pb.GetRankingResponse, error) {
+	lb, err := s.getLeaderboardByIDAndGameIDFunc(ctx, req.GetLeaderboardId(), claimsFromContext(ctx).GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unset fields are zero on proto3, so an unset limit falls back to the REST default
+	limit := req.GetLimit()
+	if limit == 0 {
+		limit = defaultRankingLimit
+	}
+
+	rankings, err := s.rankingFunc(ctx, lb, req.GetPage(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := make([]*pb.Rank, len(rankings))
+	for i, rank := range rankings {
+		ranks[i] = rankToProto(rank)
+	}
+
+	return &pb.GetRankingResponse{Ranks: ranks}, nil
+}
+
+// Applies the submissions as they arrive, acknowledging them every `ingestBatchSize` submissions and once the client closes its side.
+// The leaderboards are looked up once per stream, as a tournament finish submits many ranks to the same ones
+func (s *leaderboardServer) IngestPlayerRanks(stream pb.LeaderboardService_IngestPlayerRanksServer) error {
+	var (
+		ctx    = leaderboard.WithSource(stream.Context(), leaderboard.SourceGRPC)
+		gameID = claimsFromContext(ctx).GameID
+
+		leaderboards = make(map[string]leaderboard.Leaderboard)
+		ack          = &pb.IngestPlayerRanksAck{}
+	)
+
+	upsert := func(req *pb.UpsertPlayerRankRequest) error {
+		lb, ok := leaderboards[req.GetLeaderboardId()]
+		if !ok {
+			var err error
+			if lb, err = s.getLeaderboardByIDAndGameIDFunc(ctx, req.GetLeaderboardId(), gameID); err != nil {
+				return err
+			}
+
+			leaderboards[req.GetLeaderboardId()] = lb
+		}
+
+		return s.upsertPlayerRankFunc(ctx, lb, req.GetPlayerId(), req.GetValue())
+	}
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if ack.GetAccepted() == 0 && len(ack.GetErrors()) == 0 {
+				return nil
+			}
+
+			return stream.Send(ack)
+		}
+		if err != nil {
+			return err
+		}
+
+		index := ack.GetReceived()
+		ack.Received++
+
+		if err := upsert(req); err != nil {
+			st := status.Convert(statusFromError(ctx, err))
+			ack.Errors = append(ack.Errors, &pb.IngestPlayerRankError{Index: index, Code: int32(st.Code()), Message: st.Message()})
+		} else {
+			ack.Accepted++
+		}
+
+		if ack.GetAccepted()+int64(len(ack.GetErrors())) < int64(s.ingestBatchSize) {
+			continue
+		}
+
+		if err := stream.Send(ack); err != nil {
+			return err
+		}
+
+		ack = &pb.IngestPlayerRanksAck{Received: ack.GetReceived()}
+	}
+}
@@ -0,0 +1,235 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/controller/grpc/pb"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpsertPlayerRank(t *testing.T) {
+	var (
+		leaderboardID = uuid.NewString()
+		gameID        = uuid.NewString()
+		playerID      = uuid.NewString()
+		ctx           = metadata.AppendToOutgoingContext(context.Background(), "authorization", uuid.NewString())
+	)
+
+	authenticateFunc := func(ctx context.Context, credentials string) (auth.Claims, error) {
+		return auth.Claims{GameID: gameID}, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc: authenticateFunc,
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			UpsertPlayerRankFunc: func(ctx context.Context, lb leaderboard.Leaderboard, id string, value float64) error {
+				assert.Equal(t, leaderboardID, lb.ID)
+				assert.Equal(t, playerID, id)
+				assert.Equal(t, 100.0, value)
+				return nil
+			},
+		})
+
+		_, err := pb.NewLeaderboardServiceClient(conn).UpsertPlayerRank(ctx, &pb.UpsertPlayerRankRequest{LeaderboardId: leaderboardID, PlayerId: playerID, Value: 100})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fingerprint", func(t *testing.T) {
+		var fingerprint leaderboard.Fingerprint
+		conn := dial(t, Config{
+			AuthenticateFunc: authenticateFunc,
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			UpsertPlayerRankFunc: func(ctx context.Context, lb leaderboard.Leaderboard, id string, value float64) error {
+				fingerprint = leaderboard.FingerprintFromContext(ctx)
+				return nil
+			},
+		})
+
+		client := pb.NewLeaderboardServiceClient(conn)
+		req := &pb.UpsertPlayerRankRequest{LeaderboardId: leaderboardID, PlayerId: playerID, Value: 100}
+
+		_, err := client.UpsertPlayerRank(metadata.AppendToOutgoingContext(ctx, "x-device-hash", "f00d", "x-build-version", "1.4.2", "x-region", "BR"), req)
+		assert.NoError(t, err)
+		assert.Equal(t, leaderboard.Fingerprint{DeviceHash: "f00d", BuildVersion: "1.4.2", Region: "BR"}, fingerprint)
+
+		_, err = client.UpsertPlayerRank(metadata.AppendToOutgoingContext(ctx, "x-device-hash", strings.Repeat("a", leaderboard.MaxDeviceHashLength+1)), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Leaderboard Not Found", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc: authenticateFunc,
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+			},
+		})
+
+		_, err := pb.NewLeaderboardServiceClient(conn).UpsertPlayerRank(ctx, &pb.UpsertPlayerRankRequest{LeaderboardId: leaderboardID, PlayerId: playerID, Value: 100})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Leaderboard Closed", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc: authenticateFunc,
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			UpsertPlayerRankFunc: func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+				return leaderboard.ErrLeaderboardClosed
+			},
+		})
+
+		_, err := pb.NewLeaderboardServiceClient(conn).UpsertPlayerRank(ctx, &pb.UpsertPlayerRankRequest{LeaderboardId: leaderboardID, PlayerId: playerID, Value: 100})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestGetRanking(t *testing.T) {
+	var (
+		leaderboardID = uuid.NewString()
+		gameID        = uuid.NewString()
+		ctx           = metadata.AppendToOutgoingContext(context.Background(), "authorization", uuid.NewString())
+	)
+
+	authenticateFunc := func(ctx context.Context, credentials string) (auth.Claims, error) {
+		return auth.Claims{GameID: gameID}, nil
+	}
+
+	getLeaderboardFunc := func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+		return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc:                authenticateFunc,
+			GetLeaderboardByIDAndGameIDFunc: getLeaderboardFunc,
+			RankingFunc: func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+				assert.Equal(t, leaderboardID, lb.ID)
+				assert.Equal(t, int64(2), page)
+				assert.Equal(t, int64(defaultRankingLimit), limit)
+				return []leaderboard.Rank{{PlayerID: "a", Position: 20, Value: 10}}, nil
+			},
+		})
+
+		resp, err := pb.NewLeaderboardServiceClient(conn).GetRanking(ctx, &pb.GetRankingRequest{LeaderboardId: leaderboardID, Page: 2})
+		assert.NoError(t, err)
+		assert.Len(t, resp.GetRanks(), 1)
+		assert.Equal(t, "a", resp.GetRanks()[0].GetPlayerId())
+		assert.Equal(t, int64(20), resp.GetRanks()[0].GetPosition())
+		assert.Equal(t, 10.0, resp.GetRanks()[0].GetValue())
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc:                authenticateFunc,
+			GetLeaderboardByIDAndGameIDFunc: getLeaderboardFunc,
+			RankingFunc: func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+				return nil, leaderboard.ErrInvalidLimitNumber
+			},
+		})
+
+		_, err := pb.NewLeaderboardServiceClient(conn).GetRanking(ctx, &pb.GetRankingRequest{LeaderboardId: leaderboardID, Limit: 1000})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestIngestPlayerRanks(t *testing.T) {
+	var (
+		leaderboardID = uuid.NewString()
+		gameID        = uuid.NewString()
+		ctx           = metadata.AppendToOutgoingContext(context.Background(), "authorization", uuid.NewString())
+	)
+
+	authenticateFunc := func(ctx context.Context, credentials string) (auth.Claims, error) {
+		return auth.Claims{GameID: gameID}, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			lookups = 0
+			upserts = make(map[string]float64)
+		)
+
+		conn := dial(t, Config{
+			AuthenticateFunc: authenticateFunc,
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, g string) (leaderboard.Leaderboard, error) {
+				assert.Equal(t, gameID, g)
+
+				lookups++
+				if id != leaderboardID {
+					return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+				}
+
+				return leaderboard.Leaderboard{ID: id, GameID: g}, nil
+			},
+			UpsertPlayerRankFunc: func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+				upserts[playerID] = value
+				return nil
+			},
+			IngestBatchSize: 2,
+		})
+
+		stream, err := pb.NewLeaderboardServiceClient(conn).IngestPlayerRanks(ctx)
+		assert.NoError(t, err)
+
+		submissions := []*pb.UpsertPlayerRankRequest{
+			{LeaderboardId: leaderboardID, PlayerId: "player-1", Value: 10},
+			{LeaderboardId: "missing", PlayerId: "player-2", Value: 20},
+			{LeaderboardId: leaderboardID, PlayerId: "player-3", Value: 30},
+		}
+		for _, submission := range submissions {
+			assert.NoError(t, stream.Send(submission))
+		}
+		assert.NoError(t, stream.CloseSend())
+
+		ack, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), ack.GetReceived())
+		assert.Equal(t, int64(1), ack.GetAccepted())
+		assert.Len(t, ack.GetErrors(), 1)
+		assert.Equal(t, int64(1), ack.GetErrors()[0].GetIndex())
+		assert.Equal(t, int32(codes.NotFound), ack.GetErrors()[0].GetCode())
+
+		ack, err = stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), ack.GetReceived())
+		assert.Equal(t, int64(1), ack.GetAccepted())
+		assert.Empty(t, ack.GetErrors())
+
+		_, err = stream.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+
+		assert.Equal(t, map[string]float64{"player-1": 10, "player-3": 30}, upserts)
+		assert.Equal(t, 2, lookups)
+	})
+
+	t.Run("Missing Credentials", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				assert.Fail(t, "authenticated without credentials")
+				return auth.Claims{}, nil
+			},
+		})
+
+		stream, err := pb.NewLeaderboardServiceClient(conn).IngestPlayerRanks(context.Background())
+		assert.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
@@ -0,0 +1,1189 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: gameblitz.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UpsertPlayerRankRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LeaderboardId string  `protobuf:"bytes,1,opt,name=leaderboard_id,json=leaderboardId,proto3" json:"leaderboard_id,omitempty"`
+	PlayerId      string  `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Value         float64 `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *UpsertPlayerRankRequest) Reset() {
+	*x = UpsertPlayerRankRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpsertPlayerRankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertPlayerRankRequest) ProtoMessage() {}
+
+func (x *UpsertPlayerRankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertPlayerRankRequest.ProtoReflect.Descriptor instead.
+func (*UpsertPlayerRankRequest) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpsertPlayerRankRequest) GetLeaderboardId() string {
+	if x != nil {
+		return x.LeaderboardId
+	}
+	return ""
+}
+
+func (x *UpsertPlayerRankRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *UpsertPlayerRankRequest) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type UpsertPlayerRankResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpsertPlayerRankResponse) Reset() {
+	*x = UpsertPlayerRankResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpsertPlayerRankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertPlayerRankResponse) ProtoMessage() {}
+
+func (x *UpsertPlayerRankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertPlayerRankResponse.ProtoReflect.Descriptor instead.
+func (*UpsertPlayerRankResponse) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{1}
+}
+
+type IngestPlayerRankError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index   int64  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Code    int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *IngestPlayerRankError) Reset() {
+	*x = IngestPlayerRankError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestPlayerRankError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestPlayerRankError) ProtoMessage() {}
+
+func (x *IngestPlayerRankError) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestPlayerRankError.ProtoReflect.Descriptor instead.
+func (*IngestPlayerRankError) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IngestPlayerRankError) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IngestPlayerRankError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *IngestPlayerRankError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type IngestPlayerRanksAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Received int64                    `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	Accepted int64                    `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Errors   []*IngestPlayerRankError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *IngestPlayerRanksAck) Reset() {
+	*x = IngestPlayerRanksAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestPlayerRanksAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestPlayerRanksAck) ProtoMessage() {}
+
+func (x *IngestPlayerRanksAck) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestPlayerRanksAck.ProtoReflect.Descriptor instead.
+func (*IngestPlayerRanksAck) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IngestPlayerRanksAck) GetReceived() int64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *IngestPlayerRanksAck) GetAccepted() int64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *IngestPlayerRanksAck) GetErrors() []*IngestPlayerRankError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type GetRankingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LeaderboardId string `protobuf:"bytes,1,opt,name=leaderboard_id,json=leaderboardId,proto3" json:"leaderboard_id,omitempty"`
+	Page          int64  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int64  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *GetRankingRequest) Reset() {
+	*x = GetRankingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRankingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankingRequest) ProtoMessage() {}
+
+func (x *GetRankingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRankingRequest.ProtoReflect.Descriptor instead.
+func (*GetRankingRequest) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRankingRequest) GetLeaderboardId() string {
+	if x != nil {
+		return x.LeaderboardId
+	}
+	return ""
+}
+
+func (x *GetRankingRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetRankingRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Rank struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId string  `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Position int64   `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	Value    float64 `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Rank) Reset() {
+	*x = Rank{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rank) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rank) ProtoMessage() {}
+
+func (x *Rank) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rank.ProtoReflect.Descriptor instead.
+func (*Rank) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Rank) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Rank) GetPosition() int64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Rank) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type GetRankingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ranks []*Rank `protobuf:"bytes,1,rep,name=ranks,proto3" json:"ranks,omitempty"`
+}
+
+func (x *GetRankingResponse) Reset() {
+	*x = GetRankingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRankingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankingResponse) ProtoMessage() {}
+
+func (x *GetRankingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRankingResponse.ProtoReflect.Descriptor instead.
+func (*GetRankingResponse) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetRankingResponse) GetRanks() []*Rank {
+	if x != nil {
+		return x.Ranks
+	}
+	return nil
+}
+
+type UpsertPlayerProgressionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatisticId string  `protobuf:"bytes,1,opt,name=statistic_id,json=statisticId,proto3" json:"statistic_id,omitempty"`
+	PlayerId    string  `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Value       float64 `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *UpsertPlayerProgressionRequest) Reset() {
+	*x = UpsertPlayerProgressionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpsertPlayerProgressionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertPlayerProgressionRequest) ProtoMessage() {}
+
+func (x *UpsertPlayerProgressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertPlayerProgressionRequest.ProtoReflect.Descriptor instead.
+func (*UpsertPlayerProgressionRequest) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpsertPlayerProgressionRequest) GetStatisticId() string {
+	if x != nil {
+		return x.StatisticId
+	}
+	return ""
+}
+
+func (x *UpsertPlayerProgressionRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *UpsertPlayerProgressionRequest) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type UpsertPlayerProgressionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpsertPlayerProgressionResponse) Reset() {
+	*x = UpsertPlayerProgressionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpsertPlayerProgressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertPlayerProgressionResponse) ProtoMessage() {}
+
+func (x *UpsertPlayerProgressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertPlayerProgressionResponse.ProtoReflect.Descriptor instead.
+func (*UpsertPlayerProgressionResponse) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{8}
+}
+
+type GetPlayerProgressionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatisticId string `protobuf:"bytes,1,opt,name=statistic_id,json=statisticId,proto3" json:"statistic_id,omitempty"`
+	PlayerId    string `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+}
+
+func (x *GetPlayerProgressionRequest) Reset() {
+	*x = GetPlayerProgressionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPlayerProgressionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerProgressionRequest) ProtoMessage() {}
+
+func (x *GetPlayerProgressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerProgressionRequest.ProtoReflect.Descriptor instead.
+func (*GetPlayerProgressionRequest) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetPlayerProgressionRequest) GetStatisticId() string {
+	if x != nil {
+		return x.StatisticId
+	}
+	return ""
+}
+
+func (x *GetPlayerProgressionRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type PlayerProgressionLandmark struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value       float64                `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Completed   bool                   `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+}
+
+func (x *PlayerProgressionLandmark) Reset() {
+	*x = PlayerProgressionLandmark{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerProgressionLandmark) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerProgressionLandmark) ProtoMessage() {}
+
+func (x *PlayerProgressionLandmark) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerProgressionLandmark.ProtoReflect.Descriptor instead.
+func (*PlayerProgressionLandmark) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PlayerProgressionLandmark) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *PlayerProgressionLandmark) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *PlayerProgressionLandmark) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type PlayerProgression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartedAt       *timestamppb.Timestamp       `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp       `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PlayerId        string                       `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	StatisticId     string                       `protobuf:"bytes,4,opt,name=statistic_id,json=statisticId,proto3" json:"statistic_id,omitempty"`
+	CurrentValue    *float64                     `protobuf:"fixed64,5,opt,name=current_value,json=currentValue,proto3,oneof" json:"current_value,omitempty"`
+	GoalValue       *float64                     `protobuf:"fixed64,6,opt,name=goal_value,json=goalValue,proto3,oneof" json:"goal_value,omitempty"`
+	GoalCompleted   *bool                        `protobuf:"varint,7,opt,name=goal_completed,json=goalCompleted,proto3,oneof" json:"goal_completed,omitempty"`
+	GoalCompletedAt *timestamppb.Timestamp       `protobuf:"bytes,8,opt,name=goal_completed_at,json=goalCompletedAt,proto3" json:"goal_completed_at,omitempty"`
+	Landmarks       []*PlayerProgressionLandmark `protobuf:"bytes,9,rep,name=landmarks,proto3" json:"landmarks,omitempty"`
+}
+
+func (x *PlayerProgression) Reset() {
+	*x = PlayerProgression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerProgression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerProgression) ProtoMessage() {}
+
+func (x *PlayerProgression) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerProgression.ProtoReflect.Descriptor instead.
+func (*PlayerProgression) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PlayerProgression) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *PlayerProgression) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *PlayerProgression) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerProgression) GetStatisticId() string {
+	if x != nil {
+		return x.StatisticId
+	}
+	return ""
+}
+
+func (x *PlayerProgression) GetCurrentValue() float64 {
+	if x != nil && x.CurrentValue != nil {
+		return *x.CurrentValue
+	}
+	return 0
+}
+
+func (x *PlayerProgression) GetGoalValue() float64 {
+	if x != nil && x.GoalValue != nil {
+		return *x.GoalValue
+	}
+	return 0
+}
+
+func (x *PlayerProgression) GetGoalCompleted() bool {
+	if x != nil && x.GoalCompleted != nil {
+		return *x.GoalCompleted
+	}
+	return false
+}
+
+func (x *PlayerProgression) GetGoalCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GoalCompletedAt
+	}
+	return nil
+}
+
+func (x *PlayerProgression) GetLandmarks() []*PlayerProgressionLandmark {
+	if x != nil {
+		return x.Landmarks
+	}
+	return nil
+}
+
+type GetPlayerProgressionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Progression *PlayerProgression `protobuf:"bytes,1,opt,name=progression,proto3" json:"progression,omitempty"`
+}
+
+func (x *GetPlayerProgressionResponse) Reset() {
+	*x = GetPlayerProgressionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gameblitz_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPlayerProgressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerProgressionResponse) ProtoMessage() {}
+
+func (x *GetPlayerProgressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gameblitz_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerProgressionResponse.ProtoReflect.Descriptor instead.
+func (*GetPlayerProgressionResponse) Descriptor() ([]byte, []int) {
+	return file_gameblitz_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetPlayerProgressionResponse) GetProgression() *PlayerProgression {
+	if x != nil {
+		return x.Progression
+	}
+	return nil
+}
+
+var File_gameblitz_proto protoreflect.FileDescriptor
+
+var file_gameblitz_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x73, 0x0a, 0x17, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1a, 0x0a, 0x18, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x5b, 0x0a, 0x15, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x52, 0x61, 0x6e, 0x6b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x8b,
+	0x01, 0x0a, 0x14, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52,
+	0x61, 0x6e, 0x6b, 0x73, 0x41, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12,
+	0x3b, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x6e, 0x67, 0x65, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x64, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x22, 0x55, 0x0a, 0x04, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3e, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x28, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61,
+	0x6e, 0x6b, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x22, 0x76, 0x0a, 0x1e, 0x55, 0x70, 0x73,
+	0x65, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73,
+	0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x49, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x21, 0x0a, 0x1f, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5d, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0x8e, 0x01, 0x0a, 0x19, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x6e, 0x64, 0x6d, 0x61, 0x72,
+	0x6b, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0x86, 0x04, 0x0a, 0x11, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x73, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x49, 0x64,
+	0x12, 0x28, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x67, 0x6f,
+	0x61, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01,
+	0x52, 0x09, 0x67, 0x6f, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2a,
+	0x0a, 0x0e, 0x67, 0x6f, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x02, 0x52, 0x0d, 0x67, 0x6f, 0x61, 0x6c, 0x43, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x46, 0x0a, 0x11, 0x67, 0x6f,
+	0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0f, 0x67, 0x6f, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x45, 0x0a, 0x09, 0x6c, 0x61, 0x6e, 0x64, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74,
+	0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x6e, 0x64, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x09,
+	0x6c, 0x61, 0x6e, 0x64, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0d, 0x0a, 0x0b, 0x5f,
+	0x67, 0x6f, 0x61, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x67,
+	0x6f, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0x61, 0x0a,
+	0x1c, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a,
+	0x0b, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x32, 0xac, 0x02, 0x0a, 0x12, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x61, 0x0a, 0x10, 0x55, 0x70, 0x73, 0x65, 0x72,
+	0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x25, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x65, 0x72,
+	0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x61,
+	0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x47, 0x65,
+	0x74, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62,
+	0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x6b,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x11, 0x49,
+	0x6e, 0x67, 0x65, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x73,
+	0x12, 0x25, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c,
+	0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x41, 0x63, 0x6b, 0x28, 0x01, 0x30, 0x01, 0x32,
+	0xf9, 0x01, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x76, 0x0a, 0x17, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x2c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x55,
+	0x70, 0x73, 0x65, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e,
+	0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x73,
+	0x65, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a, 0x14,
+	0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3b, 0x5a, 0x39, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x61, 0x62, 0x61, 0x70, 0x63,
+	0x69, 0x61, 0x2f, 0x67, 0x61, 0x6d, 0x65, 0x62, 0x6c, 0x69, 0x74, 0x7a, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
+	0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gameblitz_proto_rawDescOnce sync.Once
+	file_gameblitz_proto_rawDescData = file_gameblitz_proto_rawDesc
+)
+
+func file_gameblitz_proto_rawDescGZIP() []byte {
+	file_gameblitz_proto_rawDescOnce.Do(func() {
+		file_gameblitz_proto_rawDescData = protoimpl.X.CompressGZIP(file_gameblitz_proto_rawDescData)
+	})
+	return file_gameblitz_proto_rawDescData
+}
+
+var file_gameblitz_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_gameblitz_proto_goTypes = []any{
+	(*UpsertPlayerRankRequest)(nil),         // 0: gameblitz.v1.UpsertPlayerRankRequest
+	(*UpsertPlayerRankResponse)(nil),        // 1: gameblitz.v1.UpsertPlayerRankResponse
+	(*IngestPlayerRankError)(nil),           // 2: gameblitz.v1.IngestPlayerRankError
+	(*IngestPlayerRanksAck)(nil),            // 3: gameblitz.v1.IngestPlayerRanksAck
+	(*GetRankingRequest)(nil),               // 4: gameblitz.v1.GetRankingRequest
+	(*Rank)(nil),                            // 5: gameblitz.v1.Rank
+	(*GetRankingResponse)(nil),              // 6: gameblitz.v1.GetRankingResponse
+	(*UpsertPlayerProgressionRequest)(nil),  // 7: gameblitz.v1.UpsertPlayerProgressionRequest
+	(*UpsertPlayerProgressionResponse)(nil), // 8: gameblitz.v1.UpsertPlayerProgressionResponse
+	(*GetPlayerProgressionRequest)(nil),     // 9: gameblitz.v1.GetPlayerProgressionRequest
+	(*PlayerProgressionLandmark)(nil),       // 10: gameblitz.v1.PlayerProgressionLandmark
+	(*PlayerProgression)(nil),               // 11: gameblitz.v1.PlayerProgression
+	(*GetPlayerProgressionResponse)(nil),    // 12: gameblitz.v1.GetPlayerProgressionResponse
+	(*timestamppb.Timestamp)(nil),           // 13: google.protobuf.Timestamp
+}
+var file_gameblitz_proto_depIdxs = []int32{
+	2,  // 0: gameblitz.v1.IngestPlayerRanksAck.errors:type_name -> gameblitz.v1.IngestPlayerRankError
+	5,  // 1: gameblitz.v1.GetRankingResponse.ranks:type_name -> gameblitz.v1.Rank
+	13, // 2: gameblitz.v1.PlayerProgressionLandmark.completed_at:type_name -> google.protobuf.Timestamp
+	13, // 3: gameblitz.v1.PlayerProgression.started_at:type_name -> google.protobuf.Timestamp
+	13, // 4: gameblitz.v1.PlayerProgression.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 5: gameblitz.v1.PlayerProgression.goal_completed_at:type_name -> google.protobuf.Timestamp
+	10, // 6: gameblitz.v1.PlayerProgression.landmarks:type_name -> gameblitz.v1.PlayerProgressionLandmark
+	11, // 7: gameblitz.v1.GetPlayerProgressionResponse.progression:type_name -> gameblitz.v1.PlayerProgression
+	0,  // 8: gameblitz.v1.LeaderboardService.UpsertPlayerRank:input_type -> gameblitz.v1.UpsertPlayerRankRequest
+	4,  // 9: gameblitz.v1.LeaderboardService.GetRanking:input_type -> gameblitz.v1.GetRankingRequest
+	0,  // 10: gameblitz.v1.LeaderboardService.IngestPlayerRanks:input_type -> gameblitz.v1.UpsertPlayerRankRequest
+	7,  // 11: gameblitz.v1.StatisticService.UpsertPlayerProgression:input_type -> gameblitz.v1.UpsertPlayerProgressionRequest
+	9,  // 12: gameblitz.v1.StatisticService.GetPlayerProgression:input_type -> gameblitz.v1.GetPlayerProgressionRequest
+	1,  // 13: gameblitz.v1.LeaderboardService.UpsertPlayerRank:output_type -> gameblitz.v1.UpsertPlayerRankResponse
+	6,  // 14: gameblitz.v1.LeaderboardService.GetRanking:output_type -> gameblitz.v1.GetRankingResponse
+	3,  // 15: gameblitz.v1.LeaderboardService.IngestPlayerRanks:output_type -> gameblitz.v1.IngestPlayerRanksAck
+	8,  // 16: gameblitz.v1.StatisticService.UpsertPlayerProgression:output_type -> gameblitz.v1.UpsertPlayerProgressionResponse
+	12, // 17: gameblitz.v1.StatisticService.GetPlayerProgression:output_type -> gameblitz.v1.GetPlayerProgressionResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_gameblitz_proto_init() }
+func file_gameblitz_proto_init() {
+	if File_gameblitz_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gameblitz_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*UpsertPlayerRankRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*UpsertPlayerRankResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*IngestPlayerRankError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*IngestPlayerRanksAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetRankingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*Rank); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*GetRankingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*UpsertPlayerProgressionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*UpsertPlayerProgressionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPlayerProgressionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*PlayerProgressionLandmark); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*PlayerProgression); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gameblitz_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPlayerProgressionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gameblitz_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gameblitz_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_gameblitz_proto_goTypes,
+		DependencyIndexes: file_gameblitz_proto_depIdxs,
+		MessageInfos:      file_gameblitz_proto_msgTypes,
+	}.Build()
+	File_gameblitz_proto = out.File
+	file_gameblitz_proto_rawDesc = nil
+	file_gameblitz_proto_goTypes = nil
+	file_gameblitz_proto_depIdxs = nil
+}
@@ -0,0 +1,332 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: gameblitz.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LeaderboardService_UpsertPlayerRank_FullMethodName  = "/gameblitz.v1.LeaderboardService/UpsertPlayerRank"
+	LeaderboardService_GetRanking_FullMethodName        = "/gameblitz.v1.LeaderboardService/GetRanking"
+	LeaderboardService_IngestPlayerRanks_FullMethodName = "/gameblitz.v1.LeaderboardService/IngestPlayerRanks"
+)
+
+// LeaderboardServiceClient is the client API for LeaderboardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LeaderboardServiceClient interface {
+	UpsertPlayerRank(ctx context.Context, in *UpsertPlayerRankRequest, opts ...grpc.CallOption) (*UpsertPlayerRankResponse, error)
+	GetRanking(ctx context.Context, in *GetRankingRequest, opts ...grpc.CallOption) (*GetRankingResponse, error)
+	IngestPlayerRanks(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[UpsertPlayerRankRequest, IngestPlayerRanksAck], error)
+}
+
+type leaderboardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaderboardServiceClient(cc grpc.ClientConnInterface) LeaderboardServiceClient {
+	return &leaderboardServiceClient{cc}
+}
+
+func (c *leaderboardServiceClient) UpsertPlayerRank(ctx context.Context, in *UpsertPlayerRankRequest, opts ...grpc.CallOption) (*UpsertPlayerRankResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertPlayerRankResponse)
+	err := c.cc.Invoke(ctx, LeaderboardService_UpsertPlayerRank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) GetRanking(ctx context.Context, in *GetRankingRequest, opts ...grpc.CallOption) (*GetRankingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRankingResponse)
+	err := c.cc.Invoke(ctx, LeaderboardService_GetRanking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) IngestPlayerRanks(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[UpsertPlayerRankRequest, IngestPlayerRanksAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LeaderboardService_ServiceDesc.Streams[0], LeaderboardService_IngestPlayerRanks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UpsertPlayerRankRequest, IngestPlayerRanksAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LeaderboardService_IngestPlayerRanksClient = grpc.BidiStreamingClient[UpsertPlayerRankRequest, IngestPlayerRanksAck]
+
+// LeaderboardServiceServer is the server API for LeaderboardService service.
+// All implementations must embed UnimplementedLeaderboardServiceServer
+// for forward compatibility.
+type LeaderboardServiceServer interface {
+	UpsertPlayerRank(context.Context, *UpsertPlayerRankRequest) (*UpsertPlayerRankResponse, error)
+	GetRanking(context.Context, *GetRankingRequest) (*GetRankingResponse, error)
+	IngestPlayerRanks(grpc.BidiStreamingServer[UpsertPlayerRankRequest, IngestPlayerRanksAck]) error
+	mustEmbedUnimplementedLeaderboardServiceServer()
+}
+
+// UnimplementedLeaderboardServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeaderboardServiceServer struct{}
+
+func (UnimplementedLeaderboardServiceServer) UpsertPlayerRank(context.Context, *UpsertPlayerRankRequest) (*UpsertPlayerRankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertPlayerRank not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) GetRanking(context.Context, *GetRankingRequest) (*GetRankingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRanking not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) IngestPlayerRanks(grpc.BidiStreamingServer[UpsertPlayerRankRequest, IngestPlayerRanksAck]) error {
+	return status.Errorf(codes.Unimplemented, "method IngestPlayerRanks not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) mustEmbedUnimplementedLeaderboardServiceServer() {}
+func (UnimplementedLeaderboardServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeLeaderboardServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaderboardServiceServer will
+// result in compilation errors.
+type UnsafeLeaderboardServiceServer interface {
+	mustEmbedUnimplementedLeaderboardServiceServer()
+}
+
+func RegisterLeaderboardServiceServer(s grpc.ServiceRegistrar, srv LeaderboardServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLeaderboardServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LeaderboardService_ServiceDesc, srv)
+}
+
+func _LeaderboardService_UpsertPlayerRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertPlayerRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).UpsertPlayerRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_UpsertPlayerRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).UpsertPlayerRank(ctx, req.(*UpsertPlayerRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_GetRanking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRankingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).GetRanking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_GetRanking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).GetRanking(ctx, req.(*GetRankingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_IngestPlayerRanks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LeaderboardServiceServer).IngestPlayerRanks(&grpc.GenericServerStream[UpsertPlayerRankRequest, IngestPlayerRanksAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LeaderboardService_IngestPlayerRanksServer = grpc.BidiStreamingServer[UpsertPlayerRankRequest, IngestPlayerRanksAck]
+
+// LeaderboardService_ServiceDesc is the grpc.ServiceDesc for LeaderboardService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LeaderboardService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gameblitz.v1.LeaderboardService",
+	HandlerType: (*LeaderboardServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpsertPlayerRank",
+			Handler:    _LeaderboardService_UpsertPlayerRank_Handler,
+		},
+		{
+			MethodName: "GetRanking",
+			Handler:    _LeaderboardService_GetRanking_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "IngestPlayerRanks",
+			Handler:       _LeaderboardService_IngestPlayerRanks_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "gameblitz.proto",
+}
+
+const (
+	StatisticService_UpsertPlayerProgression_FullMethodName = "/gameblitz.v1.StatisticService/UpsertPlayerProgression"
+	StatisticService_GetPlayerProgression_FullMethodName    = "/gameblitz.v1.StatisticService/GetPlayerProgression"
+)
+
+// StatisticServiceClient is the client API for StatisticService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StatisticServiceClient interface {
+	UpsertPlayerProgression(ctx context.Context, in *UpsertPlayerProgressionRequest, opts ...grpc.CallOption) (*UpsertPlayerProgressionResponse, error)
+	GetPlayerProgression(ctx context.Context, in *GetPlayerProgressionRequest, opts ...grpc.CallOption) (*GetPlayerProgressionResponse, error)
+}
+
+type statisticServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatisticServiceClient(cc grpc.ClientConnInterface) StatisticServiceClient {
+	return &statisticServiceClient{cc}
+}
+
+func (c *statisticServiceClient) UpsertPlayerProgression(ctx context.Context, in *UpsertPlayerProgressionRequest, opts ...grpc.CallOption) (*UpsertPlayerProgressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertPlayerProgressionResponse)
+	err := c.cc.Invoke(ctx, StatisticService_UpsertPlayerProgression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticServiceClient) GetPlayerProgression(ctx context.Context, in *GetPlayerProgressionRequest, opts ...grpc.CallOption) (*GetPlayerProgressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlayerProgressionResponse)
+	err := c.cc.Invoke(ctx, StatisticService_GetPlayerProgression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatisticServiceServer is the server API for StatisticService service.
+// All implementations must embed UnimplementedStatisticServiceServer
+// for forward compatibility.
+type StatisticServiceServer interface {
+	UpsertPlayerProgression(context.Context, *UpsertPlayerProgressionRequest) (*UpsertPlayerProgressionResponse, error)
+	GetPlayerProgression(context.Context, *GetPlayerProgressionRequest) (*GetPlayerProgressionResponse, error)
+	mustEmbedUnimplementedStatisticServiceServer()
+}
+
+// UnimplementedStatisticServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatisticServiceServer struct{}
+
+func (UnimplementedStatisticServiceServer) UpsertPlayerProgression(context.Context, *UpsertPlayerProgressionRequest) (*UpsertPlayerProgressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertPlayerProgression not implemented")
+}
+func (UnimplementedStatisticServiceServer) GetPlayerProgression(context.Context, *GetPlayerProgressionRequest) (*GetPlayerProgressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerProgression not implemented")
+}
+func (UnimplementedStatisticServiceServer) mustEmbedUnimplementedStatisticServiceServer() {}
+func (UnimplementedStatisticServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeStatisticServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatisticServiceServer will
+// result in compilation errors.
+type UnsafeStatisticServiceServer interface {
+	mustEmbedUnimplementedStatisticServiceServer()
+}
+
+func RegisterStatisticServiceServer(s grpc.ServiceRegistrar, srv StatisticServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStatisticServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatisticService_ServiceDesc, srv)
+}
+
+func _StatisticService_UpsertPlayerProgression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertPlayerProgressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticServiceServer).UpsertPlayerProgression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticService_UpsertPlayerProgression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticServiceServer).UpsertPlayerProgression(ctx, req.(*UpsertPlayerProgressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticService_GetPlayerProgression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerProgressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticServiceServer).GetPlayerProgression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticService_GetPlayerProgression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticServiceServer).GetPlayerProgression(ctx, req.(*GetPlayerProgressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StatisticService_ServiceDesc is the grpc.ServiceDesc for StatisticService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatisticService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gameblitz.v1.StatisticService",
+	HandlerType: (*StatisticServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpsertPlayerProgression",
+			Handler:    _StatisticService_UpsertPlayerProgression_Handler,
+		},
+		{
+			MethodName: "GetPlayerProgression",
+			Handler:    _StatisticService_GetPlayerProgression_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gameblitz.proto",
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/controller/grpc/pb"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type Config struct {
+	Port int
+
+	// Auth
+	AuthenticateFunc auth.AuthenticateFunc
+
+	// Leaderboard
+	GetLeaderboardByIDAndGameIDFunc leaderboard.GetByIDAndGameIDFunc
+	UpsertPlayerRankFunc            leaderboard.UpsertPlayerRankFunc
+	RankingFunc                     leaderboard.RankingFunc
+
+	// Submissions applied before each acknowledgement on the ingestion streams. 500 when unset
+	IngestBatchSize int
+
+	// Statistic
+	GetStatisticByIDAndGameIDFunc        statistic.GetByIDAndGameIDFunc
+	UpsertPlayerStatisticProgressionFunc statistic.UpsertPlayerProgressionFunc
+	GetPlayerStatisticProgressionFunc    statistic.GetPlayerProgressionFunc
+}
+
+// Serves the core operations to the internal services, sharing the same funcs as the REST API
+func Server(config Config) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			recoverInterceptor,
+			requestLoggerInterceptor,
+			errorInterceptor,
+			buildAuthInterceptor(config.AuthenticateFunc),
+		),
+		grpc.ChainStreamInterceptor(
+			recoverStreamInterceptor,
+			requestLoggerStreamInterceptor,
+			errorStreamInterceptor,
+			buildAuthStreamInterceptor(config.AuthenticateFunc),
+		),
+	)
+
+	pb.RegisterLeaderboardServiceServer(server, &leaderboardServer{
+		getLeaderboardByIDAndGameIDFunc: config.GetLeaderboardByIDAndGameIDFunc,
+		upsertPlayerRankFunc:            config.UpsertPlayerRankFunc,
+		rankingFunc:                     config.RankingFunc,
+		ingestBatchSize:                 cmp.Or(config.IngestBatchSize, defaultIngestBatchSize),
+	})
+	pb.RegisterStatisticServiceServer(server, &statisticServer{
+		getStatisticByIDAndGameIDFunc:        config.GetStatisticByIDAndGameIDFunc,
+		upsertPlayerStatisticProgressionFunc: config.UpsertPlayerStatisticProgressionFunc,
+		getPlayerStatisticProgressionFunc:    config.GetPlayerStatisticProgressionFunc,
+	})
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+
+	return server
+}
+
+func Execute(config Config) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Port))
+	if err != nil {
+		return err
+	}
+
+	return Server(config).Serve(lis)
+}
@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Serves the config in memory, returning a connection to it that's closed with the server when the test ends
+func dial(t *testing.T, config Config) *grpc.ClientConn {
+	lis := bufconn.Listen(1024 * 1024)
+
+	server := Server(config)
+	go server.Serve(lis)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	return conn
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/controller/grpc/pb"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type statisticServer struct {
+	pb.UnimplementedStatisticServiceServer
+
+	getStatisticByIDAndGameIDFunc        statistic.GetByIDAndGameIDFunc
+	upsertPlayerStatisticProgressionFunc statistic.UpsertPlayerProgressionFunc
+	getPlayerStatisticProgressionFunc    statistic.GetPlayerProgressionFunc
+}
+
+// Zero times are left unset, as the REST API omits them
+func timestampToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+func playerProgressionToProto(p statistic.PlayerProgression) *pb.PlayerProgression {
+	landmarks := make([]*pb.PlayerProgressionLandmark, len(p.Landmarks))
+	for i, landmark := range p.Landmarks {
+		landmarks[i] = &pb.PlayerProgressionLandmark{
+			Value:       landmark.Value,
+			Completed:   landmark.Completed,
+			CompletedAt: timestampToProto(landmark.CompletedAt),
+		}
+	}
+
+	return &pb.PlayerProgression{
+		StartedAt:       timestampToProto(p.StartedAt),
+		UpdatedAt:       timestampToProto(p.UpdatedAt),
+		PlayerId:        p.PlayerID,
+		StatisticId:     p.StatisticID,
+		CurrentValue:    p.CurrentValue,
+		GoalValue:       p.GoalValue,
+		GoalCompleted:   p.GoalCompleted,
+		GoalCompletedAt: timestampToProto(p.GoalCompletedAt),
+		Landmarks:       landmarks,
+	}
+}
+
+func (s *statisticServer) UpsertPlayerProgression(ctx context.Context, req *pb.UpsertPlayerProgressionRequest) (*pb.UpsertPlayerProgressionResponse, error) {
+	stat, err := s.getStatisticByIDAndGameIDFunc(ctx, req.GetStatisticId(), claimsFromContext(ctx).GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.upsertPlayerStatisticProgressionFunc(ctx, stat, req.GetPlayerId(), req.GetValue()); err != nil {
+		return nil, err
+	}
+
+	return &pb.UpsertPlayerProgressionResponse{}, nil
+}
+
+func (s *statisticServer) GetPlayerProgression(ctx context.Context, req *pb.GetPlayerProgressionRequest) (*pb.GetPlayerProgressionResponse, error) {
+	stat, err := s.getStatisticByIDAndGameIDFunc(ctx, req.GetStatisticId(), claimsFromContext(ctx).GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	playerProgression, err := s.getPlayerStatisticProgressionFunc(ctx, stat.ID, req.GetPlayerId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetPlayerProgressionResponse{Progression: playerProgressionToProto(playerProgression)}, nil
+}
@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/controller/grpc/pb"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpsertPlayerProgression(t *testing.T) {
+	var (
+		statisticID = uuid.NewString()
+		gameID      = uuid.NewString()
+		playerID    = uuid.NewString()
+		ctx         = metadata.AppendToOutgoingContext(context.Background(), "authorization", uuid.NewString())
+	)
+
+	authenticateFunc := func(ctx context.Context, credentials string) (auth.Claims, error) {
+		return auth.Claims{GameID: gameID}, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc: authenticateFunc,
+			GetStatisticByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+				return statistic.Statistic{ID: id, GameID: gameID}, nil
+			},
+			UpsertPlayerStatisticProgressionFunc: func(ctx context.Context, s statistic.Statistic, id string, value float64) error {
+				assert.Equal(t, statisticID, s.ID)
+				assert.Equal(t, playerID, id)
+				assert.Equal(t, 5.0, value)
+				return nil
+			},
+		})
+
+		_, err := pb.NewStatisticServiceClient(conn).UpsertPlayerProgression(ctx, &pb.UpsertPlayerProgressionRequest{StatisticId: statisticID, PlayerId: playerID, Value: 5})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Statistic Not Found", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc: authenticateFunc,
+			GetStatisticByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+				return statistic.Statistic{}, statistic.ErrStatisticNotFound
+			},
+		})
+
+		_, err := pb.NewStatisticServiceClient(conn).UpsertPlayerProgression(ctx, &pb.UpsertPlayerProgressionRequest{StatisticId: statisticID, PlayerId: playerID, Value: 5})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestGetPlayerProgression(t *testing.T) {
+	var (
+		statisticID = uuid.NewString()
+		gameID      = uuid.NewString()
+		playerID    = uuid.NewString()
+		ctx         = metadata.AppendToOutgoingContext(context.Background(), "authorization", uuid.NewString())
+	)
+
+	authenticateFunc := func(ctx context.Context, credentials string) (auth.Claims, error) {
+		return auth.Claims{GameID: gameID}, nil
+	}
+
+	getStatisticFunc := func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+		return statistic.Statistic{ID: id, GameID: gameID}, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			currentValue = 10.0
+			goalValue    = 20.0
+			startedAt    = time.Now().UTC().Truncate(time.Second)
+		)
+
+		conn := dial(t, Config{
+			AuthenticateFunc:              authenticateFunc,
+			GetStatisticByIDAndGameIDFunc: getStatisticFunc,
+			GetPlayerStatisticProgressionFunc: func(ctx context.Context, id, pID string) (statistic.PlayerProgression, error) {
+				assert.Equal(t, statisticID, id)
+				assert.Equal(t, playerID, pID)
+				return statistic.PlayerProgression{
+					StartedAt:    startedAt,
+					PlayerID:     pID,
+					StatisticID:  id,
+					CurrentValue: &currentValue,
+					GoalValue:    &goalValue,
+					Landmarks:    []statistic.PlayerProgressionLandmark{{Value: 5, Completed: true, CompletedAt: startedAt}},
+				}, nil
+			},
+		})
+
+		resp, err := pb.NewStatisticServiceClient(conn).GetPlayerProgression(ctx, &pb.GetPlayerProgressionRequest{StatisticId: statisticID, PlayerId: playerID})
+		assert.NoError(t, err)
+
+		progression := resp.GetProgression()
+		assert.Equal(t, startedAt, progression.GetStartedAt().AsTime())
+		assert.Nil(t, progression.GetUpdatedAt())
+		assert.Equal(t, playerID, progression.GetPlayerId())
+		assert.Equal(t, currentValue, progression.GetCurrentValue())
+		assert.Equal(t, goalValue, progression.GetGoalValue())
+		assert.Nil(t, progression.GoalCompleted)
+		assert.Len(t, progression.GetLandmarks(), 1)
+		assert.True(t, progression.GetLandmarks()[0].GetCompleted())
+	})
+
+	t.Run("Player Progression Not Found", func(t *testing.T) {
+		conn := dial(t, Config{
+			AuthenticateFunc:              authenticateFunc,
+			GetStatisticByIDAndGameIDFunc: getStatisticFunc,
+			GetPlayerStatisticProgressionFunc: func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+				return statistic.PlayerProgression{}, statistic.ErrPlayerStatisticNotFound
+			},
+		})
+
+		_, err := pb.NewStatisticServiceClient(conn).GetPlayerProgression(ctx, &pb.GetPlayerProgressionRequest{StatisticId: statisticID, PlayerId: playerID})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
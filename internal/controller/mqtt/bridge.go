@@ -0,0 +1,223 @@
+package mqtt
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/notification"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	defaultTopicPrefix = "gameblitz"
+
+	connectTimeout    = 10 * time.Second
+	publishTimeout    = 5 * time.Second
+	disconnectQuiesce = 250 // Milliseconds given to the in-flight messages when closing
+)
+
+type Config struct {
+	BrokerURL string // e.g. `tcp://mosquitto:1883` or `ssl://mosquitto:8883`
+	ClientID  string
+	Username  string
+	Password  string
+
+	// Prefix of every bridged topic. `gameblitz` when unset
+	TopicPrefix string
+	// Shared subscription group, so each submission is applied by a single instance. Every instance receives every
+	// submission when unset
+	SharedGroup string
+	// Quality of service of the subscriptions and the published notifications
+	QoS byte
+}
+
+// Funcs applying the submissions, the same ones as the REST API
+type Submissions struct {
+	// Leaderboard
+	GetLeaderboardByIDAndGameIDFunc leaderboard.GetByIDAndGameIDFunc
+	UpsertPlayerRankFunc            leaderboard.UpsertPlayerRankFunc
+
+	// Statistic
+	GetStatisticByIDAndGameIDFunc        statistic.GetByIDAndGameIDFunc
+	UpsertPlayerStatisticProgressionFunc statistic.UpsertPlayerProgressionFunc
+}
+
+// Bridges the clients that only speak MQTT, e.g. arcade cabinets and kiosks, through an external broker. The broker
+// authenticates the clients, so its ACLs must restrict each one to the topics of its own game
+type Bridge struct {
+	client paho.Client
+	prefix string
+	group  string
+	qos    byte
+
+	mu            sync.Mutex
+	subscriptions map[string]paho.MessageHandler
+}
+
+// Topic the player's notifications are published on
+func (b *Bridge) notificationsTopic(gameID, playerID string) string {
+	return fmt.Sprintf("%s/games/%s/players/%s/notifications", b.prefix, gameID, playerID)
+}
+
+// Filter matching the submissions on a collection, e.g. `gameblitz/games/+/leaderboards/+/players/+/rank`
+func (b *Bridge) submissionFilter(collection, action string) string {
+	filter := fmt.Sprintf("%s/games/+/%s/+/players/+/%s", b.prefix, collection, action)
+	if b.group != "" {
+		filter = fmt.Sprintf("$share/%s/%s", b.group, filter)
+	}
+
+	return filter
+}
+
+// IDs on a submission topic, as `<prefix>/games/<gameId>/<collection>/<id>/players/<playerId>/<action>`
+func (b *Bridge) parseSubmissionTopic(topic, collection, action string) (gameID, id, playerID string, ok bool) {
+	rest, found := strings.CutPrefix(topic, b.prefix+"/")
+	if !found {
+		return "", "", "", false
+	}
+
+	levels := strings.Split(rest, "/")
+	if len(levels) != 7 || levels[0] != "games" || levels[2] != collection || levels[4] != "players" || levels[6] != action {
+		return "", "", "", false
+	}
+
+	return levels[1], levels[3], levels[5], true
+}
+
+// Applies the submissions received on the collection's topics. The payload is the submitted value as plain text, e.g.
+// `120.5`, to keep the messages as small as possible. There's no reply, so the rejected submissions are only logged
+func (b *Bridge) messageHandler(ctx context.Context, collection, action string, submit func(ctx context.Context, gameID, id, playerID string, value float64) error) paho.MessageHandler {
+	return func(_ paho.Client, msg paho.Message) {
+		ctx := zap.WithFields(ctx, "topic", msg.Topic())
+
+		gameID, id, playerID, ok := b.parseSubmissionTopic(msg.Topic(), collection, action)
+		if !ok {
+			zap.InfoContext(ctx, "mqtt submission rejected", "reason", "invalid topic")
+			return
+		}
+
+		value, err := strconv.ParseFloat(strings.TrimSpace(string(msg.Payload())), 64)
+		if err != nil {
+			zap.InfoContext(ctx, "mqtt submission rejected", "reason", "invalid value")
+			return
+		}
+
+		if err := submit(ctx, gameID, id, playerID, value); err != nil {
+			logSubmissionError(ctx, err)
+		}
+	}
+}
+
+func (b *Bridge) subscribe(filter string, handler paho.MessageHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := wait(b.client.Subscribe(filter, b.qos, handler), connectTimeout); err != nil {
+		return err
+	}
+
+	b.subscriptions[filter] = handler
+	return nil
+}
+
+// Subscriptions are made again on every reconnection, as the broker may have dropped the session
+func (b *Bridge) resubscribe(client paho.Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for filter, handler := range b.subscriptions {
+		if err := wait(client.Subscribe(filter, b.qos, handler), connectTimeout); err != nil {
+			zap.Error(err, "mqtt resubscription failed", "filter", filter)
+		}
+	}
+}
+
+// Applies the rank and statistic submissions until the context is done
+func (b *Bridge) Serve(ctx context.Context, submissions Submissions) error {
+	rankHandler := b.messageHandler(ctx, "leaderboards", "rank", func(ctx context.Context, gameID, leaderboardID, playerID string, value float64) error {
+		lb, err := submissions.GetLeaderboardByIDAndGameIDFunc(ctx, leaderboardID, gameID)
+		if err != nil {
+			return err
+		}
+
+		return submissions.UpsertPlayerRankFunc(leaderboard.WithSource(ctx, leaderboard.SourceMQTT), lb, playerID, value)
+	})
+	if err := b.subscribe(b.submissionFilter("leaderboards", "rank"), rankHandler); err != nil {
+		return err
+	}
+
+	progressionHandler := b.messageHandler(ctx, "statistics", "progression", func(ctx context.Context, gameID, statisticID, playerID string, value float64) error {
+		stat, err := submissions.GetStatisticByIDAndGameIDFunc(ctx, statisticID, gameID)
+		if err != nil {
+			return err
+		}
+
+		return submissions.UpsertPlayerStatisticProgressionFunc(ctx, stat, playerID, value)
+	})
+	if err := b.subscribe(b.submissionFilter("statistics", "progression"), progressionHandler); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+// Publishes the notification on the player's topic. Matches `notification.NotifierPlayerNotification`
+func (b *Bridge) NotifyPlayer(ctx context.Context, n notification.Notification) error {
+	payload, err := json.Marshal(playerNotificationFromDomain(n))
+	if err != nil {
+		return err
+	}
+
+	return wait(b.client.Publish(b.notificationsTopic(n.GameID, n.PlayerID), b.qos, false, payload), publishTimeout)
+}
+
+func (b *Bridge) Close() {
+	b.client.Disconnect(disconnectQuiesce)
+}
+
+func wait(token paho.Token, timeout time.Duration) error {
+	if !token.WaitTimeout(timeout) {
+		return ErrTimeout
+	}
+
+	return token.Error()
+}
+
+// Connects to the broker, retrying in the background after a disconnection
+func Connect(config Config) (*Bridge, error) {
+	b := &Bridge{
+		prefix:        cmp.Or(config.TopicPrefix, defaultTopicPrefix),
+		group:         config.SharedGroup,
+		qos:           config.QoS,
+		subscriptions: make(map[string]paho.MessageHandler),
+	}
+
+	opts := paho.NewClientOptions().
+		AddBroker(config.BrokerURL).
+		SetClientID(config.ClientID).
+		SetUsername(config.Username).
+		SetPassword(config.Password).
+		SetAutoReconnect(true).
+		SetOnConnectHandler(b.resubscribe).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			zap.Error(err, "mqtt connection lost")
+		})
+
+	b.client = paho.NewClient(opts)
+	if err := wait(b.client.Connect(), connectTimeout); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
@@ -0,0 +1,253 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/notification"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type doneToken struct {
+	paho.Token
+	err error
+}
+
+func (t doneToken) WaitTimeout(time.Duration) bool { return true }
+
+func (t doneToken) Error() error { return t.err }
+
+type message struct {
+	paho.Message
+	topic   string
+	payload []byte
+}
+
+func (m message) Topic() string { return m.topic }
+
+func (m message) Payload() []byte { return m.payload }
+
+type published struct {
+	topic   string
+	payload string
+}
+
+type fakeClient struct {
+	paho.Client
+
+	mu            sync.Mutex
+	subscriptions map[string]paho.MessageHandler
+	published     []published
+}
+
+func (c *fakeClient) Subscribe(filter string, qos byte, handler paho.MessageHandler) paho.Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subscriptions[filter] = handler
+	return doneToken{}
+}
+
+func (c *fakeClient) Publish(topic string, qos byte, retained bool, payload any) paho.Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.published = append(c.published, published{topic: topic, payload: string(payload.([]byte))})
+	return doneToken{}
+}
+
+func (c *fakeClient) handler(filter string) paho.MessageHandler {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.subscriptions[filter]
+}
+
+func newTestBridge(group string) (*Bridge, *fakeClient) {
+	client := &fakeClient{subscriptions: make(map[string]paho.MessageHandler)}
+	return &Bridge{
+		client:        client,
+		prefix:        defaultTopicPrefix,
+		group:         group,
+		subscriptions: make(map[string]paho.MessageHandler),
+	}, client
+}
+
+func TestServe(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+		statisticID   = uuid.NewString()
+		playerID      = uuid.NewString()
+	)
+
+	// Waits for the bridge to subscribe to the filter, as it's done in the background
+	waitHandler := func(t *testing.T, client *fakeClient, filter string) paho.MessageHandler {
+		var handler paho.MessageHandler
+		assert.Eventually(t, func() bool {
+			handler = client.handler(filter)
+			return handler != nil
+		}, time.Second, time.Millisecond)
+
+		return handler
+	}
+
+	t.Run("Rank", func(t *testing.T) {
+		bridge, client := newTestBridge("")
+
+		var upserted float64
+		submissions := Submissions{
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			UpsertPlayerRankFunc: func(ctx context.Context, lb leaderboard.Leaderboard, player string, value float64) error {
+				assert.Equal(t, leaderboardID, lb.ID)
+				assert.Equal(t, gameID, lb.GameID)
+				assert.Equal(t, playerID, player)
+				upserted = value
+				return nil
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- bridge.Serve(ctx, submissions) }()
+
+		handler := waitHandler(t, client, "gameblitz/games/+/leaderboards/+/players/+/rank")
+		handler(client, message{
+			topic:   fmt.Sprintf("gameblitz/games/%s/leaderboards/%s/players/%s/rank", gameID, leaderboardID, playerID),
+			payload: []byte("120.5\n"),
+		})
+		assert.Equal(t, 120.5, upserted)
+
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("Statistic Progression", func(t *testing.T) {
+		bridge, client := newTestBridge("")
+
+		var upserted float64
+		submissions := Submissions{
+			GetStatisticByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+				return statistic.Statistic{ID: id, GameID: gameID}, nil
+			},
+			UpsertPlayerStatisticProgressionFunc: func(ctx context.Context, s statistic.Statistic, player string, value float64) error {
+				assert.Equal(t, statisticID, s.ID)
+				assert.Equal(t, gameID, s.GameID)
+				assert.Equal(t, playerID, player)
+				upserted = value
+				return nil
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go bridge.Serve(ctx, submissions)
+
+		handler := waitHandler(t, client, "gameblitz/games/+/statistics/+/players/+/progression")
+		handler(client, message{
+			topic:   fmt.Sprintf("gameblitz/games/%s/statistics/%s/players/%s/progression", gameID, statisticID, playerID),
+			payload: []byte("3"),
+		})
+		assert.Equal(t, float64(3), upserted)
+	})
+
+	t.Run("Shared Subscription", func(t *testing.T) {
+		bridge, client := newTestBridge("api")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go bridge.Serve(ctx, Submissions{})
+
+		waitHandler(t, client, "$share/api/gameblitz/games/+/leaderboards/+/players/+/rank")
+		waitHandler(t, client, "$share/api/gameblitz/games/+/statistics/+/players/+/progression")
+	})
+
+	t.Run("Invalid Submission", func(t *testing.T) {
+		zap.Start()
+
+		bridge, client := newTestBridge("")
+
+		submissions := Submissions{
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				assert.Fail(t, "applied an invalid submission")
+				return leaderboard.Leaderboard{}, nil
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go bridge.Serve(ctx, submissions)
+
+		handler := waitHandler(t, client, "gameblitz/games/+/leaderboards/+/players/+/rank")
+		handler(client, message{
+			topic:   fmt.Sprintf("gameblitz/games/%s/leaderboards/%s/players/%s/rank", gameID, leaderboardID, playerID),
+			payload: []byte("high"),
+		})
+		handler(client, message{
+			topic:   fmt.Sprintf("gameblitz/games/%s/leaderboards/%s/rank", gameID, leaderboardID),
+			payload: []byte("10"),
+		})
+	})
+
+	t.Run("Rejected Submission", func(t *testing.T) {
+		zap.Start()
+
+		bridge, client := newTestBridge("")
+
+		submissions := Submissions{
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go bridge.Serve(ctx, submissions)
+
+		handler := waitHandler(t, client, "gameblitz/games/+/leaderboards/+/players/+/rank")
+		assert.NotPanics(t, func() {
+			handler(client, message{
+				topic:   fmt.Sprintf("gameblitz/games/%s/leaderboards/%s/players/%s/rank", gameID, leaderboardID, playerID),
+				payload: []byte("10"),
+			})
+		})
+	})
+}
+
+func TestNotifyPlayer(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		playerID    = uuid.NewString()
+		statisticID = uuid.NewString()
+		completedAt = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	)
+
+	bridge, client := newTestBridge("")
+
+	err := bridge.NotifyPlayer(context.Background(), notification.Notification{
+		Event:       notification.EventStatisticGoalCompleted,
+		GameID:      gameID,
+		PlayerID:    playerID,
+		StatisticID: statisticID,
+		Value:       100,
+		CompletedAt: completedAt,
+	})
+	assert.NoError(t, err)
+
+	expected := published{
+		topic:   fmt.Sprintf("gameblitz/games/%s/players/%s/notifications", gameID, playerID),
+		payload: fmt.Sprintf(`{"type":"statistic_goal_completed","statisticId":%q,"value":100,"completedAt":"2024-05-01T12:00:00Z"}`, statisticID),
+	}
+	assert.Equal(t, []published{expected}, client.published)
+}
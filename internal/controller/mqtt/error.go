@@ -0,0 +1,38 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gabapcia/gameblitz/internal/availability"
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/delta"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+	"github.com/gabapcia/gameblitz/internal/segment"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/throttle"
+)
+
+var ErrTimeout = errors.New("mqtt broker timeout")
+
+// Rejections caused by the submission itself are expected from the clients, so only the unknown errors are logged as such
+func logSubmissionError(ctx context.Context, err error) {
+	switch {
+	case errors.Is(err, availability.ErrReadOnly),
+		errors.Is(err, ban.ErrPlayerBanned),
+		errors.Is(err, throttle.ErrSubmissionRateLimited),
+		errors.Is(err, segment.ErrPlayerNotEligible),
+		errors.Is(err, delta.ErrDeltaExceeded),
+		errors.Is(err, quarantine.ErrSubmissionQuarantined),
+		errors.Is(err, statistic.ErrStatisticNotFound),
+		errors.Is(err, statistic.ErrInvalidStatisticID),
+		errors.Is(err, leaderboard.ErrLeaderboardClosed),
+		errors.Is(err, leaderboard.ErrLeaderboardNotFound),
+		errors.Is(err, leaderboard.ErrInvalidLeaderboardID):
+		zap.InfoContext(ctx, "mqtt submission rejected", "reason", err.Error())
+	default:
+		zap.ErrorContext(ctx, err, "unknown error")
+	}
+}
@@ -0,0 +1,35 @@
+package mqtt
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/notification"
+)
+
+// Same payload as the REST API's live notifications
+type PlayerNotification struct {
+	Type        string    `json:"type"`                  // Completion kind: `statistic_landmark_completed`, `statistic_goal_completed`, `quest_task_completed` or `quest_completed`
+	StatisticID string    `json:"statisticId,omitempty"` // Statistic ID. Only set on the statistic notifications
+	QuestID     string    `json:"questId,omitempty"`     // Quest ID. Only set on the quest notifications
+	TaskID      string    `json:"taskId,omitempty"`      // Task completed. Only set on `quest_task_completed`
+	Value       *float64  `json:"value,omitempty"`       // Landmark or goal value reached. Only set on the statistic notifications
+	CompletedAt time.Time `json:"completedAt"`           // Time the player completed it
+}
+
+func playerNotificationFromDomain(n notification.Notification) PlayerNotification {
+	msg := PlayerNotification{
+		Type:        strings.ToLower(n.Event),
+		StatisticID: n.StatisticID,
+		QuestID:     n.QuestID,
+		TaskID:      n.TaskID,
+		CompletedAt: n.CompletedAt,
+	}
+
+	if n.StatisticID != "" {
+		value := n.Value
+		msg.Value = &value
+	}
+
+	return msg
+}
@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+var ErrInvalidAccessLogSampleRate = errors.New("invalid access log sample rate")
+
+// Set while the access log handles the request, so the error handler doesn't log the unknown errors twice
+const accessLogLocal = "accessLog"
+
+type accessLogEntry struct {
+	Method    string
+	Route     string
+	Path      string
+	Status    int
+	Latency   time.Duration
+	GameID    string // Empty on the requests not authenticated by a game
+	RequestID string
+	Err       error
+}
+
+func writeAccessLog(entry accessLogEntry) {
+	keysAndValues := []any{
+		"method", entry.Method,
+		"route", entry.Route,
+		"path", entry.Path,
+		"status", entry.Status,
+		"latencyMs", float64(entry.Latency.Microseconds()) / 1000,
+		"gameId", entry.GameID,
+		"requestId", entry.RequestID,
+	}
+
+	if entry.Status >= http.StatusInternalServerError {
+		zap.Error(entry.Err, "request handled", keysAndValues...)
+		return
+	}
+
+	zap.Info("request handled", keysAndValues...)
+}
+
+// Parses the sample rates written as `<route>=<rate>`, e.g. `/api/v1/statistics/:statisticId/players/:playerId=0.05`
+func ParseAccessLogSampleRates(rates []string) (map[string]float64, error) {
+	parsed := make(map[string]float64, len(rates))
+	for _, rate := range rates {
+		i := strings.LastIndex(rate, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAccessLogSampleRate, rate)
+		}
+
+		value, err := strconv.ParseFloat(rate[i+1:], 64)
+		if err != nil || value < 0 || value > 1 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAccessLogSampleRate, rate)
+		}
+
+		parsed[rate[:i]] = value
+	}
+
+	return parsed, nil
+}
+
+// Logs the requests of each route with its sample rate, from 0 to 1. Routes without a rate are always logged,
+// as are the requests that failed with a server error, whatever the rate of their route
+func buildAccessLogMiddleware(sampleRates map[string]float64, write func(accessLogEntry)) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		c.Locals(accessLogLocal, true)
+
+		route, err := nextHandled(c)
+		status := c.Response().StatusCode()
+
+		rate, ok := sampleRates[route]
+		if ok && status < http.StatusInternalServerError && rand.Float64() >= rate {
+			return nil
+		}
+
+		claims, _ := c.Locals("claims").(auth.Claims)
+		requestID, _ := c.Locals(requestid.ConfigDefault.ContextKey).(string)
+
+		write(accessLogEntry{
+			Method:    c.Method(),
+			Route:     route,
+			Path:      c.Path(),
+			Status:    status,
+			Latency:   time.Since(start),
+			GameID:    claims.GameID,
+			RequestID: requestID,
+			Err:       err,
+		})
+		return nil
+	}
+}
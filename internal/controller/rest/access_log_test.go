@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAccessLogSampleRates(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		rates, err := ParseAccessLogSampleRates([]string{"/api/v1/statistics/:statisticId/players/:playerId=0.05"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]float64{"/api/v1/statistics/:statisticId/players/:playerId": 0.05}, rates)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, rate := range []string{"/api/v1/statistics", "=0.5", "/api/v1/statistics=abc", "/api/v1/statistics=2"} {
+			_, err := ParseAccessLogSampleRates([]string{rate})
+			assert.ErrorIs(t, err, ErrInvalidAccessLogSampleRate, rate)
+		}
+	})
+}
+
+func TestBuildAccessLogMiddleware(t *testing.T) {
+	var (
+		entries []accessLogEntry
+		gameID  = uuid.NewString()
+		anyErr  = errors.New("any error")
+	)
+
+	app := fiber.New(fiber.Config{ErrorHandler: buildErrorHandler()})
+	app.Use(requestid.New())
+	app.Use(buildAccessLogMiddleware(
+		map[string]float64{"/sampled": 0, "/failed": 0},
+		func(entry accessLogEntry) { entries = append(entries, entry) },
+	))
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("claims", auth.Claims{GameID: gameID})
+		return c.Next()
+	})
+	app.Get("/leaderboards/:leaderboardId", func(c *fiber.Ctx) error { return leaderboard.ErrLeaderboardNotFound })
+	app.Get("/sampled", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+	app.Get("/failed", func(c *fiber.Ctx) error { return anyErr })
+
+	t.Run("Logged", func(t *testing.T) {
+		entries = nil
+
+		req := httptest.NewRequest(http.MethodGet, "/leaderboards/"+uuid.NewString(), nil)
+		req.Header.Set(fiber.HeaderXRequestID, "request-id")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		assert.Len(t, entries, 1)
+		assert.Equal(t, "/leaderboards/:leaderboardId", entries[0].Route)
+		assert.Equal(t, http.StatusNotFound, entries[0].Status)
+		assert.Equal(t, gameID, entries[0].GameID)
+		assert.Equal(t, "request-id", entries[0].RequestID)
+		assert.ErrorIs(t, entries[0].Err, leaderboard.ErrLeaderboardNotFound)
+	})
+
+	t.Run("Sampled Out", func(t *testing.T) {
+		entries = nil
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/sampled", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Server Error Always Logged", func(t *testing.T) {
+		entries = nil
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/failed", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		assert.Len(t, entries, 1)
+		assert.ErrorIs(t, entries[0].Err, anyErr)
+	})
+}
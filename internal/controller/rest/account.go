@@ -0,0 +1,142 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/player"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LinkAccountReq struct {
+	Platform   string `json:"platform"`   // Platform of the account, one of `STEAM`, `PSN`, `XBOX` or `DEVICE`, case insensitive
+	ExternalID string `json:"externalId"` // Account ID on the platform, up to 128 characters
+}
+
+type LinkedAccount struct {
+	LinkedAt   time.Time `json:"linkedAt"`   // Time the account was linked
+	PlayerID   string    `json:"playerId"`   // Player the account is linked to
+	Platform   string    `json:"platform"`   // Platform of the account, one of `STEAM`, `PSN`, `XBOX` or `DEVICE`
+	ExternalID string    `json:"externalId"` // Account ID on the platform
+}
+
+func linkedAccountFromDomain(a player.LinkedAccount) LinkedAccount {
+	return LinkedAccount{
+		LinkedAt:   a.LinkedAt,
+		PlayerID:   a.PlayerID,
+		Platform:   a.Platform,
+		ExternalID: a.ExternalID,
+	}
+}
+
+var (
+	ErrorResponseAccountInvalid               = ErrorResponse{Code: "21.0", Message: "Invalid linked account"}
+	ErrorResponseAccountNotFound              = ErrorResponse{Code: "21.1", Message: "Linked account not found"}
+	ErrorResponseAccountLinkedToAnotherPlayer = ErrorResponse{Code: "21.2", Message: "Account already linked to another player"}
+)
+
+// @summary Link Platform Account
+// @description Link an account of an external platform to a player of the game. An account is linked to a single
+// @description player, linking it again to the same player returns the existing link
+// @router /api/v1/players/{playerId}/accounts [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param LinkAccountReq body LinkAccountReq true "Account linked"
+// @success 201 {object} LinkedAccount
+// @failure 400,409,422,500 {object} ErrorResponse
+func buildLinkAccountHandler(linkAccountFunc player.LinkAccountFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body LinkAccountReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		account, err := linkAccountFunc(c.UserContext(), player.AccountData{
+			GameID:     claims.GameID,
+			PlayerID:   c.Params("playerId"),
+			Platform:   body.Platform,
+			ExternalID: body.ExternalID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(linkedAccountFromDomain(account))
+	}
+}
+
+// @summary List Linked Accounts
+// @description List the external platform accounts linked to a player of the game, oldest first
+// @router /api/v1/players/{playerId}/accounts [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {array} LinkedAccount
+// @failure 422,500 {object} ErrorResponse
+func buildListAccountsHandler(listAccountsFunc player.ListAccountsFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		accounts, err := listAccountsFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		data := make([]LinkedAccount, len(accounts))
+		for i, account := range accounts {
+			data[i] = linkedAccountFromDomain(account)
+		}
+
+		return c.Status(http.StatusOK).JSON(data)
+	}
+}
+
+// @summary Unlink Platform Account
+// @description Remove the link of an external platform account from a player of the game
+// @router /api/v1/players/{playerId}/accounts/{platform}/{externalId} [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param platform path string true "Platform of the account" Enums(STEAM, PSN, XBOX, DEVICE)
+// @param externalId path string true "Account ID on the platform"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildUnlinkAccountHandler(unlinkAccountFunc player.UnlinkAccountFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := unlinkAccountFunc(c.UserContext(), claims.GameID, c.Params("playerId"), c.Params("platform"), c.Params("externalId")); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary Resolve Platform Account
+// @description Find the player of the game an external platform account is linked to, e.g. to submit the scores
+// @description sent from any of the player's platforms to the same player
+// @router /api/v1/accounts/{platform}/{externalId} [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param platform path string true "Platform of the account" Enums(STEAM, PSN, XBOX, DEVICE)
+// @param externalId path string true "Account ID on the platform"
+// @success 200 {object} LinkedAccount
+// @failure 404,422,500 {object} ErrorResponse
+func buildResolveAccountHandler(resolveAccountFunc player.ResolveAccountFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		account, err := resolveAccountFunc(c.UserContext(), claims.GameID, c.Params("platform"), c.Params("externalId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(linkedAccountFromDomain(account))
+	}
+}
@@ -0,0 +1,232 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/player"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Every account is linked to the player given
+func newAccountTestConfig(gameID, playerID string) Config {
+	account := func(platform, externalID string) player.LinkedAccount {
+		return player.LinkedAccount{GameID: gameID, PlayerID: playerID, Platform: platform, ExternalID: externalID}
+	}
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		LinkAccountFunc: player.BuildLinkAccountFunc(func(ctx context.Context, a player.LinkedAccount) (player.LinkedAccount, error) {
+			if a.PlayerID != playerID {
+				return player.LinkedAccount{}, player.ErrAccountLinkedToAnotherPlayer
+			}
+
+			return a, nil
+		}),
+		UnlinkAccountFunc: player.BuildUnlinkAccountFunc(func(ctx context.Context, gameID, playerID, platform, externalID string) error {
+			return nil
+		}),
+		ListAccountsFunc: player.BuildListAccountsFunc(func(ctx context.Context, gameID, playerID string) ([]player.LinkedAccount, error) {
+			return []player.LinkedAccount{account(player.PlatformSteam, "76561197960287930")}, nil
+		}),
+		ResolveAccountFunc: player.BuildResolveAccountFunc(func(ctx context.Context, gameID, platform, externalID string) (player.LinkedAccount, error) {
+			return account(platform, externalID), nil
+		}),
+	}
+}
+
+func TestBuildLinkAccountHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newAccountTestConfig(gameID, playerID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/"+playerID+"/accounts", bytes.NewBufferString(`{"platform": "steam", "externalId": "76561197960287930"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body LinkedAccount
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, playerID, body.PlayerID)
+		assert.Equal(t, player.PlatformSteam, body.Platform)
+	})
+
+	t.Run("Linked To Another Player", func(t *testing.T) {
+		app := App(newAccountTestConfig(gameID, playerID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/"+uuid.NewString()+"/accounts", bytes.NewBufferString(`{"platform": "STEAM", "externalId": "76561197960287930"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("Invalid Account", func(t *testing.T) {
+		app := App(newAccountTestConfig(gameID, playerID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/"+playerID+"/accounts", bytes.NewBufferString(`{"platform": "ORIGIN"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseAccountInvalid.Code, body.Code)
+		assert.Contains(t, body.Details, player.ErrInvalidPlatform.Error())
+		assert.Contains(t, body.Details, player.ErrInvalidExternalID.Error())
+	})
+}
+
+func TestBuildListAccountsHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newAccountTestConfig(gameID, playerID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/"+playerID+"/accounts", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []LinkedAccount
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, player.PlatformSteam, body[0].Platform)
+		assert.Equal(t, "76561197960287930", body[0].ExternalID)
+	})
+}
+
+func TestBuildUnlinkAccountHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newAccountTestConfig(gameID, playerID))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/players/"+playerID+"/accounts/steam/76561197960287930", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newAccountTestConfig(gameID, playerID)
+		config.UnlinkAccountFunc = player.BuildUnlinkAccountFunc(func(ctx context.Context, gameID, playerID, platform, externalID string) error {
+			return player.ErrAccountNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/players/"+playerID+"/accounts/steam/76561197960287930", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildResolveAccountHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newAccountTestConfig(gameID, playerID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/STEAM/76561197960287930", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body LinkedAccount
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, playerID, body.PlayerID)
+		assert.Equal(t, player.PlatformSteam, body.Platform)
+	})
+
+	// The resolved account is on the same path for every game, so it's never cached
+	t.Run("Not Cached", func(t *testing.T) {
+		resolved := false
+
+		config := newAccountTestConfig(gameID, playerID)
+		config.ResolveAccountFunc = player.BuildResolveAccountFunc(func(ctx context.Context, gameID, platform, externalID string) (player.LinkedAccount, error) {
+			if resolved {
+				return player.LinkedAccount{}, player.ErrAccountNotFound
+			}
+
+			resolved = true
+			return player.LinkedAccount{GameID: gameID, PlayerID: playerID, Platform: platform, ExternalID: externalID}, nil
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/STEAM/76561197960287930", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/accounts/STEAM/76561197960287930", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseAccountNotFound, body)
+	})
+}
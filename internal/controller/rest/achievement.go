@@ -0,0 +1,210 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/auth"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AchievementTrigger struct {
+	Kind        string  `json:"kind" enums:"STATISTIC_LANDMARK,QUEST_COMPLETED"` // Progression that unlocks the achievement
+	StatisticID string  `json:"statisticId,omitempty"`                           // Statistic whose landmark unlocks the achievement. Only on `STATISTIC_LANDMARK`
+	Landmark    float64 `json:"landmark,omitempty"`                              // Landmark of the statistic that unlocks the achievement. Only on `STATISTIC_LANDMARK`
+	QuestID     string  `json:"questId,omitempty"`                               // Quest whose completion unlocks the achievement. Only on `QUEST_COMPLETED`
+}
+
+func (t AchievementTrigger) toDomain() achievement.Trigger {
+	return achievement.Trigger{
+		Kind:        t.Kind,
+		StatisticID: t.StatisticID,
+		Landmark:    t.Landmark,
+		QuestID:     t.QuestID,
+	}
+}
+
+func achievementTriggerFromDomain(t achievement.Trigger) AchievementTrigger {
+	return AchievementTrigger{
+		Kind:        t.Kind,
+		StatisticID: t.StatisticID,
+		Landmark:    t.Landmark,
+		QuestID:     t.QuestID,
+	}
+}
+
+type CreateAchievementReq struct {
+	Name        string             `json:"name"`        // Achievement name
+	Description string             `json:"description"` // Achievement details
+	Trigger     AchievementTrigger `json:"trigger"`     // Progression that unlocks the achievement
+}
+
+func (r CreateAchievementReq) toDomain(gameID string) achievement.NewAchievementData {
+	return achievement.NewAchievementData{
+		GameID:      gameID,
+		Name:        r.Name,
+		Description: r.Description,
+		Trigger:     r.Trigger.toDomain(),
+	}
+}
+
+type Achievement struct {
+	CreatedAt   time.Time          `json:"createdAt"`   // Time the achievement was created
+	UpdatedAt   time.Time          `json:"updatedAt"`   // Last time the achievement was changed
+	ID          string             `json:"id"`          // Achievement ID
+	GameID      string             `json:"gameId"`      // ID of the game responsible for the achievement
+	Name        string             `json:"name"`        // Achievement name
+	Description string             `json:"description"` // Achievement details
+	Trigger     AchievementTrigger `json:"trigger"`     // Progression that unlocks the achievement
+}
+
+func achievementFromDomain(a achievement.Achievement) Achievement {
+	return Achievement{
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+		ID:          a.ID,
+		GameID:      a.GameID,
+		Name:        a.Name,
+		Description: a.Description,
+		Trigger:     achievementTriggerFromDomain(a.Trigger),
+	}
+}
+
+type PlayerAchievement struct {
+	Achievement Achievement `json:"achievement"` // Achievement unlocked
+	PlayerID    string      `json:"playerId"`    // Player that unlocked it
+	UnlockedAt  time.Time   `json:"unlockedAt"`  // Time the player made the progression that unlocked it
+}
+
+var (
+	ErrorResponseAchievementInvalid         = ErrorResponse{Code: "18.0", Message: "Invalid achievement"}
+	ErrorResponseAchievementNotFound        = ErrorResponse{Code: "18.1", Message: "Achievement not found"}
+	ErrorResponseAchievementInvalidID       = ErrorResponse{Code: "18.2", Message: "Invalid achievement id"}
+	ErrorResponseAchievementUnknownLandmark = ErrorResponse{Code: "18.3", Message: "The landmark is not one of the statistic's landmarks"}
+	ErrorResponseAchievementInvalidPlayer   = ErrorResponse{Code: "18.4", Message: "Invalid player id"}
+)
+
+// @summary Create Achievement
+// @description Create an achievement, unlocked when a player reaches a landmark of a statistic or completes a quest.
+// @description The statistic or quest must belong to the game. Progressions made before the achievement was created don't unlock it
+// @router /api/v1/achievements [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param CreateAchievementReq body CreateAchievementReq true "New achievement config data"
+// @success 201 {object} Achievement
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildCreateAchievementHandler(createAchievementFunc achievement.CreateFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body CreateAchievementReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		a, err := createAchievementFunc(c.UserContext(), body.toDomain(claims.GameID))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(achievementFromDomain(a))
+	}
+}
+
+// @summary List Achievements
+// @description List the game's achievements, oldest first
+// @router /api/v1/achievements [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @success 200 {array} Achievement
+// @failure 500 {object} ErrorResponse
+func buildListAchievementsHandler(listAchievementsFunc achievement.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		achievements, err := listAchievementsFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		res := make([]Achievement, len(achievements))
+		for i, a := range achievements {
+			res[i] = achievementFromDomain(a)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Get Achievement By ID
+// @description Get an achievement by its id
+// @router /api/v1/achievements/{achievementId} [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param achievementId path string true "Achievement ID"
+// @success 200 {object} Achievement
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetAchievementHandler(getAchievementFunc achievement.GetByIDAndGameIDFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		a, err := getAchievementFunc(c.UserContext(), c.Params("achievementId"), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(achievementFromDomain(a))
+	}
+}
+
+// @summary Delete Achievement
+// @description Delete an achievement by its id, alongside the players' unlocks of it
+// @router /api/v1/achievements/{achievementId} [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param achievementId path string true "Achievement ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildDeleteAchievementHandler(deleteAchievementFunc achievement.DeleteFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := deleteAchievementFunc(c.UserContext(), c.Params("achievementId"), claims.GameID); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary List Player Achievements
+// @description List the achievements the player unlocked, in the order they were unlocked
+// @router /api/v1/players/{playerId}/achievements [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {array} PlayerAchievement
+// @failure 422,500 {object} ErrorResponse
+func buildListPlayerAchievementsHandler(listPlayerAchievementsFunc achievement.ListPlayerAchievementsFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		unlocked, err := listPlayerAchievementsFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		res := make([]PlayerAchievement, len(unlocked))
+		for i, u := range unlocked {
+			res[i] = PlayerAchievement{
+				Achievement: achievementFromDomain(u.Achievement),
+				PlayerID:    u.PlayerID,
+				UnlockedAt:  u.UnlockedAt,
+			}
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
@@ -0,0 +1,230 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// The game has a single achievement, on the landmark 10 of the statistic, unlocked by every player at the time given
+func newAchievementTestConfig(gameID, statisticID string, unlockedAt time.Time) Config {
+	tenKills := achievement.Achievement{
+		ID:      "ten-kills",
+		GameID:  gameID,
+		Name:    "Ten Kills",
+		Trigger: achievement.Trigger{Kind: achievement.TriggerStatisticLandmark, StatisticID: statisticID, Landmark: 10},
+	}
+
+	storageList := func(ctx context.Context, gameID string) ([]achievement.Achievement, error) {
+		return []achievement.Achievement{tenKills}, nil
+	}
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		CreateAchievementFunc: achievement.BuildCreateFunc(
+			func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+				return statistic.Statistic{ID: id, GameID: gameID, Landmarks: []float64{10}}, nil
+			},
+			func(ctx context.Context, id, gameID string) (quest.Quest, error) {
+				return quest.Quest{}, quest.ErrQuestNotFound
+			},
+			func(ctx context.Context, data achievement.NewAchievementData) (achievement.Achievement, error) {
+				return achievement.Achievement{ID: uuid.NewString(), GameID: data.GameID, Name: data.Name, Trigger: data.Trigger}, nil
+			},
+		),
+		GetAchievementFunc: achievement.BuildGetByIDAndGameIDFunc(func(ctx context.Context, id, gameID string) (achievement.Achievement, error) {
+			return tenKills, nil
+		}),
+		ListAchievementsFunc: achievement.BuildListFunc(storageList),
+		DeleteAchievementFunc: achievement.BuildDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			return nil
+		}),
+		ListPlayerAchievementsFunc: achievement.BuildListPlayerAchievementsFunc(storageList, func(ctx context.Context, gameID, playerID string) ([]achievement.Unlock, error) {
+			return []achievement.Unlock{{AchievementID: tenKills.ID, GameID: gameID, PlayerID: playerID, UnlockedAt: unlockedAt}}, nil
+		}),
+	}
+}
+
+func TestBuildCreateAchievementHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		statisticID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newAchievementTestConfig(gameID, statisticID, time.Now()))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/achievements", bytes.NewBufferString(`{"name": "Ten Kills", "trigger": {"kind": "STATISTIC_LANDMARK", "statisticId": "`+statisticID+`", "landmark": 10}}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body Achievement
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "Ten Kills", body.Name)
+		assert.Equal(t, AchievementTrigger{Kind: achievement.TriggerStatisticLandmark, StatisticID: statisticID, Landmark: 10}, body.Trigger)
+	})
+
+	t.Run("Unknown Landmark", func(t *testing.T) {
+		app := App(newAchievementTestConfig(gameID, statisticID, time.Now()))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/achievements", bytes.NewBufferString(`{"name": "Five Kills", "trigger": {"kind": "STATISTIC_LANDMARK", "statisticId": "`+statisticID+`", "landmark": 5}}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseAchievementUnknownLandmark, body)
+	})
+
+	t.Run("Invalid Achievement", func(t *testing.T) {
+		app := App(newAchievementTestConfig(gameID, statisticID, time.Now()))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/achievements", bytes.NewBufferString(`{"name": "Hero", "trigger": {"kind": "UNKNOWN"}}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseAchievementInvalid.Code, body.Code)
+	})
+}
+
+func TestBuildGetAchievementHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		statisticID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newAchievementTestConfig(gameID, statisticID, time.Now()))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/achievements/ten-kills", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Achievement
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "ten-kills", body.ID)
+		assert.Equal(t, gameID, body.GameID)
+	})
+}
+
+func TestBuildListAchievementsHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		statisticID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newAchievementTestConfig(gameID, statisticID, time.Now()))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/achievements", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Achievement
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "ten-kills", body[0].ID)
+	})
+}
+
+func TestBuildListPlayerAchievementsHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		playerID    = uuid.NewString()
+		statisticID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		unlockedAt := time.Now().UTC().Truncate(time.Second)
+		app := App(newAchievementTestConfig(gameID, statisticID, unlockedAt))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/"+playerID+"/achievements", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []PlayerAchievement
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "ten-kills", body[0].Achievement.ID)
+		assert.Equal(t, playerID, body[0].PlayerID)
+		assert.Equal(t, unlockedAt, body[0].UnlockedAt)
+	})
+}
+
+func TestBuildDeleteAchievementHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		statisticID = uuid.NewString()
+	)
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newAchievementTestConfig(gameID, statisticID, time.Now())
+		config.DeleteAchievementFunc = achievement.BuildDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			return achievement.ErrAchievementNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/achievements/"+uuid.NewString(), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/activity"
+	"github.com/gabapcia/gameblitz/internal/auth"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Activity struct {
+	OccurredAt    time.Time `json:"occurredAt"`                                                                 // Time the player made the progression
+	ID            string    `json:"id"`                                                                         // Activity ID
+	PlayerID      string    `json:"playerId"`                                                                   // Player's ID
+	Kind          string    `json:"kind" enums:"PERSONAL_BEST,LANDMARK_REACHED,QUEST_COMPLETED,RANK_MILESTONE"` // Activity kind
+	LeaderboardID string    `json:"leaderboardId,omitempty"`                                                    // Leaderboard of the rank. Only on `PERSONAL_BEST` and `RANK_MILESTONE`
+	StatisticID   string    `json:"statisticId,omitempty"`                                                      // Statistic whose landmark was reached. Only on `LANDMARK_REACHED`
+	QuestID       string    `json:"questId,omitempty"`                                                          // Quest completed. Only on `QUEST_COMPLETED`
+	Value         *float64  `json:"value,omitempty"`                                                            // New best, landmark reached or rank value on the milestone. Not on `QUEST_COMPLETED`
+	PreviousValue *float64  `json:"previousValue,omitempty"`                                                    // Best beaten. Only on `PERSONAL_BEST`
+	Top           int64     `json:"top,omitempty"`                                                              // Top positions entered: 1, 3, 10 or 100. Only on `RANK_MILESTONE`
+}
+
+type ActivitiesRes struct {
+	Activities []Activity `json:"activities"` // Activities of the page, newest first
+	Next       string     `json:"next"`       // Cursor of the next page, sent as `after`. Empty on the last page
+}
+
+func activityFromDomain(a activity.Activity) Activity {
+	res := Activity{
+		OccurredAt:    a.OccurredAt,
+		ID:            a.ID,
+		PlayerID:      a.PlayerID,
+		Kind:          a.Kind,
+		LeaderboardID: a.LeaderboardID,
+		StatisticID:   a.StatisticID,
+		QuestID:       a.QuestID,
+		Top:           a.Top,
+	}
+
+	if a.Kind != activity.KindQuestCompleted {
+		res.Value = &a.Value
+	}
+
+	if a.Kind == activity.KindPersonalBest {
+		res.PreviousValue = &a.PreviousValue
+	}
+
+	return res
+}
+
+var (
+	ErrorResponseActivityInvalidPlayer = ErrorResponse{Code: "29.0", Message: "Invalid activity player"}
+	ErrorResponseActivityInvalidLimit  = ErrorResponse{Code: "29.1", Message: "Invalid activities limit"}
+	ErrorResponseActivityInvalidCursor = ErrorResponse{Code: "29.2", Message: "Invalid activity cursor"}
+)
+
+// Reads a page of a feed from the `after` cursor and `limit` query parameters
+func listActivitiesPage(c *fiber.Ctx, list func(after activity.Activity, limit int) ([]activity.Activity, error)) error {
+	var (
+		after activity.Activity
+		err   error
+	)
+	if v := c.Query("after"); v != "" {
+		if after, err = activity.DecodeCursor(v); err != nil {
+			return err
+		}
+	}
+
+	limit := c.QueryInt("limit", 20)
+
+	activities, err := list(after, limit)
+	if err != nil {
+		return err
+	}
+
+	res := ActivitiesRes{Activities: make([]Activity, len(activities))}
+	for i, a := range activities {
+		res.Activities[i] = activityFromDomain(a)
+	}
+
+	if len(activities) == limit {
+		res.Next = activity.EncodeCursor(activities[len(activities)-1])
+	}
+
+	return c.Status(http.StatusOK).JSON(res)
+}
+
+// @summary List Player Activity
+// @description List the notable events of a player of the game, newest first, e.g. for a "recent activity" panel: the personal
+// @description bests beaten on the MAX and MIN leaderboards, the statistic landmarks reached, the quests completed and the
+// @description leaderboards top 1, 3, 10 and 100 entered. The pages are walked by sending the `next` cursor of a page as the
+// @description `after` of the following one
+// @router /api/v1/players/{playerId}/activity [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param limit query int false "Max number of activities" minimun(1) maximum(100) default(20)
+// @param after query string false "Cursor of the page, from the `next` of the previous one"
+// @success 200 {object} ActivitiesRes
+// @failure 422,500 {object} ErrorResponse
+func buildListPlayerActivityHandler(listActivitiesFunc activity.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		return listActivitiesPage(c, func(after activity.Activity, limit int) ([]activity.Activity, error) {
+			return listActivitiesFunc(c.UserContext(), claims.GameID, c.Params("playerId"), after, limit)
+		})
+	}
+}
+
+// @summary List Friends Activity
+// @description List the notable events of the friends of a player of the game, newest first, e.g. for a friends feed.
+// @description The feed holds the activities of the player's current friends, including the ones from before they became friends
+// @router /api/v1/players/{playerId}/friends/activity [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param limit query int false "Max number of activities" minimun(1) maximum(100) default(20)
+// @param after query string false "Cursor of the page, from the `next` of the previous one"
+// @success 200 {object} ActivitiesRes
+// @failure 422,500 {object} ErrorResponse
+func buildListFriendsActivityHandler(listFriendsActivitiesFunc activity.ListFriendsFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		return listActivitiesPage(c, func(after activity.Activity, limit int) ([]activity.Activity, error) {
+			return listFriendsActivitiesFunc(c.UserContext(), claims.GameID, c.Params("playerId"), after, limit)
+		})
+	}
+}
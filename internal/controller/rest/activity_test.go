@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/activity"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/friend"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivityHandlers(t *testing.T) {
+	var (
+		gameID     = uuid.NewString()
+		now        = time.Now().UTC()
+		activities = []activity.Activity{
+			{OccurredAt: now, ID: "3", GameID: gameID, PlayerID: "bob", Kind: activity.KindRankMilestone, LeaderboardID: "lap", Value: 60, Top: 3},
+			{OccurredAt: now, ID: "2", GameID: gameID, PlayerID: "alice", Kind: activity.KindPersonalBest, LeaderboardID: "lap", Value: 58.2, PreviousValue: 61.5},
+			{OccurredAt: now.Add(-time.Minute), ID: "1", GameID: gameID, PlayerID: "alice", Kind: activity.KindQuestCompleted, QuestID: "tutorial"},
+		}
+	)
+
+	// Newest first, as the storages list them
+	storageList := func(ctx context.Context, gameID string, playerIDs []string, after activity.Activity, limit int) ([]activity.Activity, error) {
+		page := make([]activity.Activity, 0)
+		for _, a := range activities {
+			if after.ID != "" && a.ID >= after.ID {
+				continue
+			}
+
+			if slices.Contains(playerIDs, a.PlayerID) && len(page) < limit {
+				page = append(page, a)
+			}
+		}
+
+		return page, nil
+	}
+
+	app := App(Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		ListActivitiesFunc: activity.BuildListFunc(storageList),
+		ListFriendsActivitiesFunc: activity.BuildListFriendsFunc(
+			func(ctx context.Context, gameID, playerID string) ([]friend.Friendship, error) {
+				return []friend.Friendship{{GameID: gameID, PlayerID: playerID, FriendID: "bob"}}, nil
+			},
+			storageList,
+		),
+	})
+
+	get := func(path string) (*http.Response, ActivitiesRes) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+
+		var body ActivitiesRes
+		if resp.StatusCode == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		}
+
+		return resp, body
+	}
+
+	t.Run("Player Feed", func(t *testing.T) {
+		resp, body := get("/api/v1/players/alice/activity?limit=1")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Len(t, body.Activities, 1)
+		assert.Equal(t, activity.KindPersonalBest, body.Activities[0].Kind)
+		assert.Equal(t, 61.5, *body.Activities[0].PreviousValue)
+		assert.NotEmpty(t, body.Next)
+
+		resp, body = get("/api/v1/players/alice/activity?limit=1&after=" + body.Next)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Len(t, body.Activities, 1)
+		assert.Equal(t, "tutorial", body.Activities[0].QuestID)
+		assert.Nil(t, body.Activities[0].Value)
+	})
+
+	t.Run("Friends Feed", func(t *testing.T) {
+		resp, body := get("/api/v1/players/alice/friends/activity")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Len(t, body.Activities, 1)
+		assert.Equal(t, "bob", body.Activities[0].PlayerID)
+		assert.Equal(t, int64(3), body.Activities[0].Top)
+		assert.Empty(t, body.Next)
+	})
+
+	t.Run("Invalid Page", func(t *testing.T) {
+		resp, _ := get("/api/v1/players/alice/activity?limit=1000")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		resp, _ = get("/api/v1/players/alice/activity?after=nope")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
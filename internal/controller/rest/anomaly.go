@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Anomaly struct {
+	DetectedAt    time.Time `json:"detectedAt"`    // Last time the value was flagged
+	ID            string    `json:"id"`            // Anomaly ID
+	LeaderboardID string    `json:"leaderboardId"` // Leaderboard the player is ranked on
+	PlayerID      string    `json:"playerId"`      // Player flagged
+	Value         float64   `json:"value"`         // Player's value when flagged
+	Method        string    `json:"method"`        // Method that flagged the value, `Z_SCORE` or `IQR`
+	Score         float64   `json:"score"`         // Standard deviations from the mean, or interquartile ranges past the quartile, of the value
+}
+
+func anomalyFromDomain(a anomaly.Anomaly) Anomaly {
+	return Anomaly{
+		DetectedAt:    a.DetectedAt,
+		ID:            a.ID,
+		LeaderboardID: a.LeaderboardID,
+		PlayerID:      a.PlayerID,
+		Value:         a.Value,
+		Method:        a.Method,
+		Score:         a.Score,
+	}
+}
+
+var (
+	ErrorResponseAnomalyNotFound     = ErrorResponse{Code: "33.0", Message: "Anomaly not found"}
+	ErrorResponseAnomalyInvalidID    = ErrorResponse{Code: "33.1", Message: "Invalid anomaly id"}
+	ErrorResponseAnomalyInvalidLimit = ErrorResponse{Code: "33.2", Message: "The limit must be between 1 and 100"}
+)
+
+// @summary List Anomalies
+// @description List the game's ranks flagged by the anomaly detection job and waiting for review, oldest first
+// @router /admin/v1/games/{gameId}/anomalies [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param leaderboardId query string false "Keep only the anomalies of the leaderboard"
+// @param limit query int false "Anomalies to list, from 1 to 100" default(100)
+// @success 200 {array} Anomaly
+// @failure 401,403,422,500 {object} ErrorResponse
+func buildListAnomaliesHandler(listFunc anomaly.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		anomalies, err := listFunc(c.UserContext(), c.Params("gameId"), c.Query("leaderboardId"), c.QueryInt("limit", anomaly.MaxLimit))
+		if err != nil {
+			return err
+		}
+
+		res := make([]Anomaly, len(anomalies))
+		for i, a := range anomalies {
+			res[i] = anomalyFromDomain(a)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Dismiss Anomaly
+// @description Remove a flagged rank found legitimate from the review list. It's not flagged again until the player's value changes
+// @router /admin/v1/games/{gameId}/anomalies/{anomalyId}/dismiss [POST]
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param anomalyId path string true "Anomaly ID"
+// @success 204
+// @failure 401,403,404,422,500 {object} ErrorResponse
+func buildDismissAnomalyHandler(dismissFunc anomaly.DismissFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := dismissFunc(c.UserContext(), c.Params("gameId"), c.Params("anomalyId")); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAnomalyTestConfig(adminToken string) Config {
+	return Config{
+		AdminToken: adminToken,
+		ListAnomaliesFunc: anomaly.BuildListFunc(func(ctx context.Context, gameID, leaderboardID string, limit int) ([]anomaly.Anomaly, error) {
+			return []anomaly.Anomaly{
+				{ID: "flagged", GameID: gameID, LeaderboardID: leaderboardID, PlayerID: "alice", Value: 1000, Method: anomaly.MethodZScore, Score: 4.2, Status: anomaly.StatusPending},
+			}, nil
+		}),
+		DismissAnomalyFunc: anomaly.BuildDismissFunc(func(ctx context.Context, gameID, id string) error {
+			return nil
+		}),
+	}
+}
+
+func TestBuildListAnomaliesHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newAnomalyTestConfig(adminToken))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/anomalies?leaderboardId=leaderboard", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Anomaly
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Anomaly{{ID: "flagged", LeaderboardID: "leaderboard", PlayerID: "alice", Value: 1000, Method: anomaly.MethodZScore, Score: 4.2}}, body)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		app := App(newAnomalyTestConfig(adminToken))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/anomalies?limit=0", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseAnomalyInvalidLimit.Code, body.Code)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		app := App(newAnomalyTestConfig(adminToken))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/anomalies", nil)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestBuildDismissAnomalyHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newAnomalyTestConfig(adminToken))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/games/"+gameID+"/anomalies/flagged/dismiss", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newAnomalyTestConfig(adminToken)
+		config.DismissAnomalyFunc = anomaly.BuildDismissFunc(func(ctx context.Context, gameID, id string) error {
+			return anomaly.ErrAnomalyNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/games/"+gameID+"/anomalies/flagged/dismiss", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseAnomalyNotFound.Code, body.Code)
+	})
+}
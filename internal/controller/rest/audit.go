@@ -0,0 +1,295 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var auditedMethods = []string{
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// Routes that take a POST only to carry a read, so they change nothing worth auditing
+var unauditedPaths = []string{
+	"/api/v1/graphql",
+}
+
+// Route patterns whose handler records its own audit entry. The player erasure and anonymization record the digest
+// of the player ID only, so recording their raw route parameters here would keep the data they removed
+var selfAuditedRoutes = []string{
+	privacy.ErasureAuditRoute,
+	privacy.AnonymizationAuditRoute,
+}
+
+var ErrInvalidAuditExportFormat = errors.New("invalid audit export format")
+
+type AuditEntry struct {
+	CreatedAt     time.Time         `json:"createdAt"`     // Time the entry was recorded
+	ID            string            `json:"id"`            // Entry ID
+	GameID        string            `json:"gameId"`        // ID of the game that performed the request
+	Actor         string            `json:"actor"`         // Subject of the credential used on the request
+	Method        string            `json:"method"`        // Request method
+	Route         string            `json:"route"`         // Route pattern that handled the request
+	ResourceIDs   map[string]string `json:"resourceIds"`   // Route parameters that identify the resource affected
+	PayloadDigest string            `json:"payloadDigest"` // SHA-256 digest of the request payload
+	StatusCode    int               `json:"statusCode"`    // Response status code
+	RequestedAt   time.Time         `json:"requestedAt"`   // Time the request was received
+}
+
+type AuditEntriesRes struct {
+	Entries []AuditEntry `json:"entries"` // Entries of the page, newest first
+	Next    string       `json:"next"`    // Cursor of the next page, sent as `after`. Empty on the last page
+}
+
+func auditEntryFromDomain(e audit.Entry) AuditEntry {
+	return AuditEntry{
+		CreatedAt:     e.CreatedAt,
+		ID:            e.ID,
+		GameID:        e.GameID,
+		Actor:         e.Actor,
+		Method:        e.Method,
+		Route:         e.Route,
+		ResourceIDs:   e.ResourceIDs,
+		PayloadDigest: e.PayloadDigest,
+		StatusCode:    e.StatusCode,
+		RequestedAt:   e.RequestedAt,
+	}
+}
+
+var (
+	ErrorResponseAuditInvalidFilter       = ErrorResponse{Code: "13.0", Message: "Invalid audit filter"}
+	ErrorResponseAuditInvalidLimit        = ErrorResponse{Code: "13.1", Message: "Invalid audit entries limit"}
+	ErrorResponseAuditInvalidCursor       = ErrorResponse{Code: "13.2", Message: "Invalid audit cursor"}
+	ErrorResponseAuditExportTooLarge      = ErrorResponse{Code: "13.3", Message: "Too many audit entries to export, narrow the time range"}
+	ErrorResponseAuditInvalidExportFormat = ErrorResponse{Code: "13.4", Message: "Invalid audit export format"}
+)
+
+// Columns of the CSV export
+var auditExportHeader = []string{"createdAt", "id", "gameId", "actor", "method", "route", "resourceIds", "payloadDigest", "statusCode", "requestedAt"}
+
+func buildAuditMiddleware(recordAuditEntryFunc audit.RecordFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !slices.Contains(auditedMethods, c.Method()) || slices.Contains(unauditedPaths, c.Path()) {
+			return c.Next()
+		}
+
+		var (
+			requestedAt   = time.Now()
+			payloadDigest = audit.PayloadDigest(c.Body())
+		)
+
+		if err := c.Next(); err != nil {
+			if err = c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(http.StatusInternalServerError)
+			}
+		}
+
+		var (
+			claims      = c.Locals("claims").(auth.Claims)
+			route       = c.Route()
+			resourceIDs = make(map[string]string, len(route.Params))
+		)
+		if slices.Contains(selfAuditedRoutes, route.Path) {
+			return nil
+		}
+
+		for _, param := range route.Params {
+			resourceIDs[param] = c.Params(param)
+		}
+
+		_, err := recordAuditEntryFunc(c.UserContext(), audit.NewEntryData{
+			GameID:        claims.GameID,
+			Actor:         claims.Subject,
+			Method:        c.Method(),
+			Route:         route.Path,
+			ResourceIDs:   resourceIDs,
+			PayloadDigest: payloadDigest,
+			StatusCode:    c.Response().StatusCode(),
+			RequestedAt:   requestedAt,
+		})
+		if err != nil {
+			zap.ErrorContext(c.UserContext(), err, "unable to record audit entry")
+		}
+
+		return nil
+	}
+}
+
+func auditFilterFromQuery(c *fiber.Ctx) (audit.Filter, error) {
+	filter := audit.Filter{
+		GameID:       c.Query("gameId"),
+		Actor:        c.Query("actor"),
+		ResourceType: c.Query("resourceType"),
+	}
+
+	for param, t := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		v := c.Query(param)
+		if v == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return audit.Filter{}, fmt.Errorf("%w: %s must be a RFC 3339 time", audit.ErrInvalidFilter, param)
+		}
+		*t = parsed
+	}
+
+	return filter, nil
+}
+
+// @summary List Audit Entries
+// @description List the mutating requests recorded on the audit log, newest first, e.g. to find who deleted a leaderboard.
+// @description The pages are walked by sending the `next` cursor of a page as the `after` of the following one
+// @router /admin/v1/audit [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId query string true "Game that performed the requests"
+// @param actor query string false "Subject of the credential used on the requests"
+// @param resourceType query string false "Resource affected" Enums(achievements, leaderboards, level-curves, players, quests, rewards, segments, statistics, titles, webhooks)
+// @param from query string false "Entries recorded from this time on, as RFC 3339"
+// @param to query string false "Entries recorded before this time, as RFC 3339"
+// @param limit query int false "Max number of entries" minimun(1) maximum(500) default(100)
+// @param after query string false "Cursor of the page, from the `next` of the previous one"
+// @success 200 {object} AuditEntriesRes
+// @failure 401,403,422,500 {object} ErrorResponse
+func buildListAuditEntriesHandler(listAuditEntriesFunc audit.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		filter, err := auditFilterFromQuery(c)
+		if err != nil {
+			return err
+		}
+
+		var after audit.Entry
+		if v := c.Query("after"); v != "" {
+			if after, err = audit.DecodeCursor(v); err != nil {
+				return err
+			}
+		}
+
+		limit := c.QueryInt("limit", 100)
+
+		entries, err := listAuditEntriesFunc(c.UserContext(), filter, after, limit)
+		if err != nil {
+			return err
+		}
+
+		res := AuditEntriesRes{Entries: make([]AuditEntry, len(entries))}
+		for i, e := range entries {
+			res.Entries[i] = auditEntryFromDomain(e)
+		}
+
+		if len(entries) == limit {
+			res.Next = audit.EncodeCursor(entries[len(entries)-1])
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+func writeAuditCSV(entries []audit.Entry) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		w   = csv.NewWriter(&buf)
+	)
+
+	if err := w.Write(auditExportHeader); err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		resourceIDs := make([]string, 0, len(e.ResourceIDs))
+		for param, id := range e.ResourceIDs {
+			resourceIDs = append(resourceIDs, param+"="+id)
+		}
+		slices.Sort(resourceIDs)
+
+		err := w.Write([]string{
+			e.CreatedAt.Format(time.RFC3339Nano),
+			e.ID,
+			e.GameID,
+			e.Actor,
+			e.Method,
+			e.Route,
+			strings.Join(resourceIDs, ";"),
+			e.PayloadDigest,
+			strconv.Itoa(e.StatusCode),
+			e.RequestedAt.Format(time.RFC3339Nano),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// @summary Export Audit Entries
+// @description Download every audit entry matching the filter, newest first, as a CSV or JSON attachment.
+// @description The resource IDs are written as `param=id` pairs separated by `;` on the CSV
+// @router /admin/v1/audit/export [GET]
+// @produce json,text/csv
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId query string true "Game that performed the requests"
+// @param actor query string false "Subject of the credential used on the requests"
+// @param resourceType query string false "Resource affected" Enums(achievements, leaderboards, level-curves, players, quests, rewards, segments, statistics, titles, webhooks)
+// @param from query string false "Entries recorded from this time on, as RFC 3339"
+// @param to query string false "Entries recorded before this time, as RFC 3339"
+// @param format query string false "Export format" Enums(csv, json) default(csv)
+// @success 200 {array} AuditEntry
+// @failure 401,403,422,500 {object} ErrorResponse
+func buildExportAuditEntriesHandler(exportAuditEntriesFunc audit.ExportFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		format := c.Query("format", "csv")
+		if format != "csv" && format != "json" {
+			return ErrInvalidAuditExportFormat
+		}
+
+		filter, err := auditFilterFromQuery(c)
+		if err != nil {
+			return err
+		}
+
+		entries, err := exportAuditEntriesFunc(c.UserContext(), filter)
+		if err != nil {
+			return err
+		}
+
+		c.Attachment(fmt.Sprintf("audit-%s.%s", filter.GameID, format))
+
+		if format == "json" {
+			res := make([]AuditEntry, len(entries))
+			for i, e := range entries {
+				res[i] = auditEntryFromDomain(e)
+			}
+
+			return c.Status(http.StatusOK).JSON(res)
+		}
+
+		data, err := writeAuditCSV(entries)
+		if err != nil {
+			return err
+		}
+
+		c.Set(fiber.HeaderContentType, "text/csv")
+		return c.Status(http.StatusOK).Send(data)
+	}
+}
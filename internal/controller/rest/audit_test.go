@@ -0,0 +1,454 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/controller/graphql"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/presence"
+
+	"github.com/google/uuid"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAuditMiddleware(t *testing.T) {
+	var (
+		leaderboardID = uuid.NewString()
+		gameID        = uuid.NewString()
+		subject       = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var recorded audit.NewEntryData
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			RecordAuditEntryFunc: func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				recorded = data
+				return audit.Entry{ID: uuid.NewString()}, nil
+			},
+			DeleteLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) error {
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/leaderboards/%s", leaderboardID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, gameID, recorded.GameID)
+		assert.Equal(t, subject, recorded.Actor)
+		assert.Equal(t, http.MethodDelete, recorded.Method)
+		assert.Equal(t, "/api/v1/leaderboards/:leaderboardId", recorded.Route)
+		assert.Equal(t, map[string]string{"leaderboardId": leaderboardID}, recorded.ResourceIDs)
+		assert.Equal(t, audit.PayloadDigest(nil), recorded.PayloadDigest)
+		assert.Equal(t, http.StatusNoContent, recorded.StatusCode)
+	})
+
+	t.Run("Handler Error", func(t *testing.T) {
+		var recorded audit.NewEntryData
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			RecordAuditEntryFunc: func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				recorded = data
+				return audit.Entry{ID: uuid.NewString()}, nil
+			},
+			CreateLeaderboardFunc: func(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{}, leaderboard.ErrValidationError
+			},
+		})
+
+		body := `{"name": ""}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards", bytes.NewBufferString(body))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		assert.Equal(t, http.MethodPost, recorded.Method)
+		assert.Equal(t, audit.PayloadDigest([]byte(body)), recorded.PayloadDigest)
+		assert.Equal(t, http.StatusUnprocessableEntity, recorded.StatusCode)
+	})
+
+	t.Run("Replace Requests", func(t *testing.T) {
+		var recorded audit.NewEntryData
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			RecordAuditEntryFunc: func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				recorded = data
+				return audit.Entry{ID: uuid.NewString()}, nil
+			},
+			HeartbeatFunc: func(ctx context.Context, gameID, playerID string) (presence.Presence, error) {
+				return presence.Presence{GameID: gameID, PlayerID: playerID, Online: true, LastSeenAt: time.Now()}, nil
+			},
+			GetPresenceFunc: func(ctx context.Context, gameID, playerID string) (presence.Presence, error) {
+				return presence.Presence{}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/players/alice/presence", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, http.MethodPut, recorded.Method)
+		assert.Equal(t, "/api/v1/players/:playerId/presence", recorded.Route)
+		assert.Equal(t, map[string]string{"playerId": "alice"}, recorded.ResourceIDs)
+		assert.Equal(t, http.StatusOK, recorded.StatusCode)
+	})
+
+	t.Run("Read Requests Are Not Recorded", func(t *testing.T) {
+		recorded := false
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			RecordAuditEntryFunc: func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				recorded = true
+				return audit.Entry{}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID, StartAt: time.Now()}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s", leaderboardID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.False(t, recorded)
+	})
+
+	t.Run("GraphQL Queries Are Not Recorded", func(t *testing.T) {
+		recorded := false
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			RecordAuditEntryFunc: func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				recorded = true
+				return audit.Entry{}, nil
+			},
+			ExecuteGraphQLFunc: func(ctx context.Context, gameID string, req graphql.Request) *graphqlgo.Response {
+				return &graphqlgo.Response{Data: json.RawMessage(`{}`)}
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewBufferString(`{"query":"{ __typename }"}`))
+
+		req.Header.Set("Authorization", uuid.NewString())
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.False(t, recorded)
+	})
+
+	t.Run("Record Error", func(t *testing.T) {
+		zap.Start()
+		defer zap.Sync()
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			RecordAuditEntryFunc: func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				return audit.Entry{}, errors.New("any error")
+			},
+			DeleteLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) error {
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/leaderboards/%s", leaderboardID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func TestBuildListAuditEntriesHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+		entry      = audit.Entry{
+			CreatedAt:   time.Now().UTC(),
+			ID:          uuid.NewString(),
+			GameID:      gameID,
+			Actor:       uuid.NewString(),
+			Method:      http.MethodDelete,
+			Route:       "/api/v1/leaderboards/:leaderboardId",
+			ResourceIDs: map[string]string{"leaderboardId": uuid.NewString()},
+			StatusCode:  http.StatusNoContent,
+		}
+
+		exportFunc = func(ctx context.Context, filter audit.Filter) ([]audit.Entry, error) {
+			return nil, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			requestedFilter audit.Filter
+			requestedAfter  audit.Entry
+			requestedLimit  int
+		)
+
+		app := App(Config{
+			AdminToken: adminToken,
+			ListAuditEntriesFunc: func(ctx context.Context, filter audit.Filter, after audit.Entry, limit int) ([]audit.Entry, error) {
+				requestedFilter, requestedAfter, requestedLimit = filter, after, limit
+				return []audit.Entry{entry}, nil
+			},
+			ExportAuditEntriesFunc: exportFunc,
+		})
+
+		cursor := audit.EncodeCursor(entry)
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/audit?gameId="+gameID+"&resourceType=leaderboards&from=2024-01-01T00:00:00Z&limit=1&after="+cursor, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body AuditEntriesRes
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, gameID, requestedFilter.GameID)
+		assert.Equal(t, "leaderboards", requestedFilter.ResourceType)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), requestedFilter.From)
+		assert.Equal(t, entry.ID, requestedAfter.ID)
+		assert.Equal(t, 1, requestedLimit)
+
+		assert.Len(t, body.Entries, 1)
+		assert.Equal(t, entry.ID, body.Entries[0].ID)
+		assert.Equal(t, entry.ResourceIDs, body.Entries[0].ResourceIDs)
+		assert.Equal(t, cursor, body.Next)
+	})
+
+	t.Run("Last Page", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			ListAuditEntriesFunc: func(ctx context.Context, filter audit.Filter, after audit.Entry, limit int) ([]audit.Entry, error) {
+				return []audit.Entry{entry}, nil
+			},
+			ExportAuditEntriesFunc: exportFunc,
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/audit?gameId="+gameID, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body AuditEntriesRes
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Empty(t, body.Next)
+	})
+
+	t.Run("Invalid Time", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			ListAuditEntriesFunc: func(ctx context.Context, filter audit.Filter, after audit.Entry, limit int) ([]audit.Entry, error) {
+				return nil, nil
+			},
+			ExportAuditEntriesFunc: exportFunc,
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/audit?gameId="+gameID+"&to=yesterday", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrorResponseAuditInvalidFilter, body)
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			ListAuditEntriesFunc: func(ctx context.Context, filter audit.Filter, after audit.Entry, limit int) ([]audit.Entry, error) {
+				return nil, nil
+			},
+			ExportAuditEntriesFunc: exportFunc,
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/audit?gameId="+gameID+"&after=invalid", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrorResponseAuditInvalidCursor, body)
+	})
+
+	t.Run("Random Error", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			ListAuditEntriesFunc: func(ctx context.Context, filter audit.Filter, after audit.Entry, limit int) ([]audit.Entry, error) {
+				return nil, errors.New("any error")
+			},
+			ExportAuditEntriesFunc: exportFunc,
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/audit?gameId="+gameID, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestBuildExportAuditEntriesHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+		entry      = audit.Entry{
+			CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			ID:          uuid.NewString(),
+			GameID:      gameID,
+			Actor:       "support",
+			Method:      http.MethodDelete,
+			Route:       "/api/v1/leaderboards/:leaderboardId",
+			ResourceIDs: map[string]string{"leaderboardId": "a"},
+			StatusCode:  http.StatusNoContent,
+			RequestedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+
+		listFunc = func(ctx context.Context, filter audit.Filter, after audit.Entry, limit int) ([]audit.Entry, error) {
+			return nil, nil
+		}
+		exportFunc = func(ctx context.Context, filter audit.Filter) ([]audit.Entry, error) {
+			return []audit.Entry{entry}, nil
+		}
+	)
+
+	t.Run("CSV", func(t *testing.T) {
+		app := App(Config{AdminToken: adminToken, ListAuditEntriesFunc: listFunc, ExportAuditEntriesFunc: exportFunc})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/audit/export?gameId="+gameID, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+		assert.Contains(t, resp.Header.Get("Content-Disposition"), "audit-"+gameID+".csv")
+
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{
+			auditExportHeader,
+			{"2024-01-01T00:00:00Z", entry.ID, gameID, "support", http.MethodDelete, entry.Route, "leaderboardId=a", "", "204", "2024-01-01T00:00:00Z"},
+		}, records)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		app := App(Config{AdminToken: adminToken, ListAuditEntriesFunc: listFunc, ExportAuditEntriesFunc: exportFunc})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/audit/export?format=json&gameId="+gameID, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []AuditEntry
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Len(t, body, 1)
+		assert.Equal(t, entry.ID, body[0].ID)
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		app := App(Config{AdminToken: adminToken, ListAuditEntriesFunc: listFunc, ExportAuditEntriesFunc: exportFunc})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/audit/export?format=xml&gameId="+gameID, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrorResponseAuditInvalidExportFormat, body)
+	})
+
+	t.Run("Too Large", func(t *testing.T) {
+		app := App(Config{
+			AdminToken:           adminToken,
+			ListAuditEntriesFunc: listFunc,
+			ExportAuditEntriesFunc: func(ctx context.Context, filter audit.Filter) ([]audit.Entry, error) {
+				return nil, audit.ErrExportTooLarge
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/audit/export?gameId="+gameID, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrorResponseAuditExportTooLarge, body)
+	})
+}
@@ -1,9 +1,11 @@
 package rest
 
 import (
+	"crypto/subtle"
 	"net/http"
 
 	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -11,6 +13,7 @@ import (
 var (
 	ErrorResponseMissingAuthCredentials = ErrorResponse{Code: "7.0", Message: "missing credentials"}
 	ErrorResponseInvalidAuthCredentials = ErrorResponse{Code: "7.1", Message: "invalid credentials"}
+	ErrorResponseInvalidAdminToken      = ErrorResponse{Code: "7.2", Message: "invalid admin token"}
 )
 
 func buildAuthMiddleware(authenticateFunc auth.AuthenticateFunc) fiber.Handler {
@@ -20,12 +23,42 @@ func buildAuthMiddleware(authenticateFunc auth.AuthenticateFunc) fiber.Handler {
 			return c.Status(http.StatusUnauthorized).JSON(ErrorResponseMissingAuthCredentials)
 		}
 
-		claims, err := authenticateFunc(c.Context(), authorization)
+		claims, err := authenticateFunc(c.UserContext(), authorization)
 		if err != nil {
 			return err
 		}
 
 		c.Locals("claims", claims)
+		c.SetUserContext(zap.WithFields(c.UserContext(), "gameId", claims.GameID))
+		return c.Next()
+	}
+}
+
+// Authenticates every request as the game, with or without credentials. Only meant for the dev mode
+func buildDevAuthMiddleware(gameID string) fiber.Handler {
+	claims := auth.Claims{GameID: gameID, Subject: "dev"}
+
+	return func(c *fiber.Ctx) error {
+		c.Locals("claims", claims)
+		c.SetUserContext(zap.WithFields(c.UserContext(), "gameId", claims.GameID))
+		return c.Next()
+	}
+}
+
+// Protects the operational endpoints with a static token, sent as `Bearer <token>`
+func buildAdminAuthMiddleware(token string) fiber.Handler {
+	expected := []byte("Bearer " + token)
+
+	return func(c *fiber.Ctx) error {
+		authorization := c.Request().Header.Peek("Authorization")
+		if len(authorization) == 0 {
+			return c.Status(http.StatusUnauthorized).JSON(ErrorResponseMissingAuthCredentials)
+		}
+
+		if subtle.ConstantTimeCompare(authorization, expected) != 1 {
+			return c.Status(http.StatusForbidden).JSON(ErrorResponseInvalidAdminToken)
+		}
+
 		return c.Next()
 	}
 }
@@ -94,3 +94,26 @@ func TestBuildAuthMiddleware(t *testing.T) {
 		assert.Equal(t, ErrorResponseInvalidAuthCredentials.Message, body.Message)
 	})
 }
+
+func TestBuildDevAuthMiddleware(t *testing.T) {
+	gameID := uuid.NewString()
+
+	app := fiber.New()
+	app.Get("/", buildDevAuthMiddleware(gameID), func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+		return c.Status(http.StatusOK).JSON(claims)
+	})
+
+	// Authenticated without credentials
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body auth.Claims
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	assert.NoError(t, err)
+
+	assert.Equal(t, auth.Claims{GameID: gameID, Subject: "dev"}, body)
+}
@@ -0,0 +1,284 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/ban"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BanPlayerReq struct {
+	Reason          string `json:"reason"`          // Why the player is banned, up to 512 characters
+	DurationSeconds int64  `json:"durationSeconds"` // Seconds until the ban is lifted on its own. Kept until lifted when zero
+}
+
+type BanDeviceReq struct {
+	Reason          string `json:"reason"`          // Why the device is banned, up to 512 characters
+	DurationSeconds int64  `json:"durationSeconds"` // Seconds until the ban is lifted on its own. Kept until lifted when zero
+}
+
+type DeviceBan struct {
+	CreatedAt  time.Time  `json:"createdAt"`           // Time the device was banned
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"` // Time the ban is lifted on its own. Not set when it lasts until lifted
+	DeviceHash string     `json:"deviceHash"`          // Device banned
+	Reason     string     `json:"reason"`              // Why the device was banned
+}
+
+func deviceBanFromDomain(b ban.DeviceBan) DeviceBan {
+	data := DeviceBan{
+		CreatedAt:  b.CreatedAt,
+		DeviceHash: b.DeviceHash,
+		Reason:     b.Reason,
+	}
+	if !b.ExpiresAt.IsZero() {
+		data.ExpiresAt = &b.ExpiresAt
+	}
+
+	return data
+}
+
+type HiddenRank struct {
+	LeaderboardID string  `json:"leaderboardId"` // Leaderboard the player was ranked on
+	Value         float64 `json:"value"`         // Player's value when it was hidden
+}
+
+type Ban struct {
+	CreatedAt   time.Time    `json:"createdAt"`           // Time the player was banned
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty"` // Time the ban is lifted on its own. Not set when it lasts until lifted
+	PlayerID    string       `json:"playerId"`            // Player banned
+	Reason      string       `json:"reason"`              // Why the player was banned
+	HiddenRanks []HiddenRank `json:"hiddenRanks"`         // Ranks taken out of the rankings, put back once the ban is lifted
+}
+
+func banFromDomain(b ban.Ban) Ban {
+	data := Ban{
+		CreatedAt:   b.CreatedAt,
+		PlayerID:    b.PlayerID,
+		Reason:      b.Reason,
+		HiddenRanks: make([]HiddenRank, len(b.HiddenRanks)),
+	}
+	if !b.ExpiresAt.IsZero() {
+		data.ExpiresAt = &b.ExpiresAt
+	}
+
+	for i, rank := range b.HiddenRanks {
+		data.HiddenRanks[i] = HiddenRank{LeaderboardID: rank.LeaderboardID, Value: rank.Value}
+	}
+
+	return data
+}
+
+var (
+	ErrorResponseBanInvalid             = ErrorResponse{Code: "22.0", Message: "Invalid ban"}
+	ErrorResponseBanNotFound            = ErrorResponse{Code: "22.1", Message: "Ban not found"}
+	ErrorResponseBanPlayerAlreadyBanned = ErrorResponse{Code: "22.2", Message: "Player already banned"}
+	ErrorResponseBanPlayerBanned        = ErrorResponse{Code: "22.3", Message: "Player banned"}
+	ErrorResponseBanInvalidPlayer       = ErrorResponse{Code: "22.4", Message: "Invalid player id"}
+	ErrorResponseDeviceBanNotFound      = ErrorResponse{Code: "22.5", Message: "Device ban not found"}
+	ErrorResponseDeviceAlreadyBanned    = ErrorResponse{Code: "22.6", Message: "Device already banned"}
+	ErrorResponseDeviceBanned           = ErrorResponse{Code: "22.7", Message: "Device banned"}
+	ErrorResponseBanInvalidDeviceHash   = ErrorResponse{Code: "22.8", Message: "Invalid device hash"}
+)
+
+// @summary Ban Player
+// @description Ban a player from the game. The player's ranks are taken out of the rankings right away, keeping their
+// @description values to put them back once the ban is lifted, and the player's submissions are rejected until then.
+// @description The cached ranking pages of the leaderboards the player was ranked on are dropped
+// @router /api/v1/players/{playerId}/ban [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param BanPlayerReq body BanPlayerReq true "Ban details"
+// @success 201 {object} Ban
+// @failure 400,409,422,500 {object} ErrorResponse
+func buildBanPlayerHandler(banFunc ban.BanFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body BanPlayerReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		b, err := banFunc(c.UserContext(), ban.NewBanData{
+			GameID:   claims.GameID,
+			PlayerID: c.Params("playerId"),
+			Reason:   body.Reason,
+			Duration: time.Duration(body.DurationSeconds) * time.Second,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(banFromDomain(b))
+	}
+}
+
+// @summary Get Player Ban
+// @description Get the player's ban on the game
+// @router /api/v1/players/{playerId}/ban [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {object} Ban
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetPlayerBanHandler(getBanFunc ban.GetFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		b, err := getBanFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(banFromDomain(b))
+	}
+}
+
+// @summary Lift Player Ban
+// @description Lift the player's ban on the game, putting the player's ranks back on the rankings
+// @router /api/v1/players/{playerId}/ban [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildLiftPlayerBanHandler(liftBanFunc ban.LiftFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if _, err := liftBanFunc(c.UserContext(), claims.GameID, c.Params("playerId")); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary List Bans
+// @description List the players banned from the game, oldest ban first
+// @router /api/v1/bans [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @success 200 {array} Ban
+// @failure 500 {object} ErrorResponse
+func buildListBansHandler(listBansFunc ban.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		bans, err := listBansFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		data := make([]Ban, len(bans))
+		for i, b := range bans {
+			data[i] = banFromDomain(b)
+		}
+
+		return c.Status(http.StatusOK).JSON(data)
+	}
+}
+
+// @summary Ban Device
+// @description Ban a device from the game. The submissions whose fingerprint carries the device hash are rejected until the ban
+// @description is lifted, whatever the player they're sent for. The players that submitted from the device keep their ranks,
+// @description as a device may be shared, and are banned on their own
+// @router /api/v1/devices/{deviceHash}/ban [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param deviceHash path string true "Device hash, as sent on the submissions' fingerprint"
+// @param BanDeviceReq body BanDeviceReq true "Ban details"
+// @success 201 {object} DeviceBan
+// @failure 400,409,422,500 {object} ErrorResponse
+func buildBanDeviceHandler(banDeviceFunc ban.BanDeviceFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body BanDeviceReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		b, err := banDeviceFunc(c.UserContext(), ban.NewDeviceBanData{
+			GameID:     claims.GameID,
+			DeviceHash: c.Params("deviceHash"),
+			Reason:     body.Reason,
+			Duration:   time.Duration(body.DurationSeconds) * time.Second,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(deviceBanFromDomain(b))
+	}
+}
+
+// @summary Get Device Ban
+// @description Get the device's ban on the game
+// @router /api/v1/devices/{deviceHash}/ban [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param deviceHash path string true "Device hash"
+// @success 200 {object} DeviceBan
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetDeviceBanHandler(getDeviceFunc ban.GetDeviceFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		b, err := getDeviceFunc(c.UserContext(), claims.GameID, c.Params("deviceHash"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(deviceBanFromDomain(b))
+	}
+}
+
+// @summary Lift Device Ban
+// @description Lift the device's ban on the game
+// @router /api/v1/devices/{deviceHash}/ban [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param deviceHash path string true "Device hash"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildLiftDeviceBanHandler(liftDeviceFunc ban.LiftDeviceFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if _, err := liftDeviceFunc(c.UserContext(), claims.GameID, c.Params("deviceHash")); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary List Device Bans
+// @description List the devices banned from the game, oldest ban first
+// @router /api/v1/device-bans [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @success 200 {array} DeviceBan
+// @failure 500 {object} ErrorResponse
+func buildListDeviceBansHandler(listDevicesFunc ban.ListDevicesFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		bans, err := listDevicesFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		data := make([]DeviceBan, len(bans))
+		for i, b := range bans {
+			data[i] = deviceBanFromDomain(b)
+		}
+
+		return c.Status(http.StatusOK).JSON(data)
+	}
+}
@@ -0,0 +1,508 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// The game has a single leaderboard, ranked as given, and "cheater" is banned until the ban is lifted, with a rank of
+// 42 hidden from it
+func newBanTestConfig(gameID, leaderboardID string, ranks fakeRanking) Config {
+	cheater := ban.Ban{
+		CreatedAt:   time.Now().UTC(),
+		GameID:      gameID,
+		PlayerID:    "cheater",
+		Reason:      "speed hack",
+		HiddenRanks: []ban.HiddenRank{{LeaderboardID: leaderboardID, Value: 42}},
+	}
+
+	getLeaderboard := func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+		return leaderboard.Leaderboard{ID: leaderboardID, GameID: gameID}, nil
+	}
+
+	getBan := func(ctx context.Context, gameID, playerID string) (ban.Ban, error) {
+		if playerID != cheater.PlayerID {
+			return ban.Ban{}, ban.ErrBanNotFound
+		}
+
+		return cheater, nil
+	}
+
+	createBan := func(ctx context.Context, b ban.Ban) error {
+		if b.PlayerID == cheater.PlayerID {
+			return ban.ErrPlayerAlreadyBanned
+		}
+
+		return nil
+	}
+
+	liftFunc := ban.BuildLiftFunc(getLeaderboard, ranks.GetPlayerRank, ranks.UpsertPlayerRankValue, createBan, getBan)
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: getLeaderboard,
+		UpsertPlayerRankFunc:            leaderboard.UpsertPlayerRankFunc(ban.BuildRejectBannedFunc(getBan, liftFunc, ranks.UpsertPlayerRankValue)),
+		BanPlayerFunc: ban.BuildBanFunc(
+			func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+				lb, _ := getLeaderboard(ctx, leaderboardID, gameID)
+				return []leaderboard.Leaderboard{lb}, nil
+			},
+			ranks.GetPlayerRank,
+			ranks.DeletePlayerRank,
+			createBan,
+			func(ctx context.Context, gameID, playerID string, hidden []ban.HiddenRank) error {
+				return nil
+			},
+		),
+		LiftBanFunc: liftFunc,
+		GetBanFunc:  ban.BuildGetFunc(getBan),
+		ListBansFunc: ban.BuildListFunc(func(ctx context.Context, gameID string) ([]ban.Ban, error) {
+			return []ban.Ban{cheater}, nil
+		}),
+	}
+}
+
+// "f00d" is banned until the ban is lifted. The submissions that get through are counted on the int given
+func newDeviceBanTestConfig(gameID string, applied *int) Config {
+	f00d := ban.DeviceBan{CreatedAt: time.Now().UTC(), GameID: gameID, DeviceHash: "f00d", Reason: "alt farm"}
+
+	getDeviceBan := func(ctx context.Context, gameID, deviceHash string) (ban.DeviceBan, error) {
+		if deviceHash != f00d.DeviceHash {
+			return ban.DeviceBan{}, ban.ErrDeviceBanNotFound
+		}
+
+		return f00d, nil
+	}
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		},
+		UpsertPlayerRankFunc: leaderboard.UpsertPlayerRankFunc(ban.BuildRejectBannedDeviceFunc(getDeviceBan, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			*applied++
+			return nil
+		})),
+		BanDeviceFunc: ban.BuildBanDeviceFunc(func(ctx context.Context, b ban.DeviceBan) error {
+			if b.DeviceHash == f00d.DeviceHash {
+				return ban.ErrDeviceAlreadyBanned
+			}
+
+			return nil
+		}),
+		LiftDeviceBanFunc: ban.BuildLiftDeviceFunc(getDeviceBan),
+		GetDeviceBanFunc:  ban.BuildGetDeviceFunc(getDeviceBan),
+		ListDeviceBansFunc: ban.BuildListDevicesFunc(func(ctx context.Context, gameID string) ([]ban.DeviceBan, error) {
+			return []ban.DeviceBan{f00d}, nil
+		}),
+	}
+}
+
+func TestBuildBanPlayerHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		ranks := fakeRanking{"alice": 42}
+		app := App(newBanTestConfig(gameID, leaderboardID, ranks))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/alice/ban", bytes.NewBufferString(`{"reason": "speed hack", "durationSeconds": 3600}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body Ban
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "alice", body.PlayerID)
+		assert.Equal(t, []HiddenRank{{LeaderboardID: leaderboardID, Value: 42}}, body.HiddenRanks)
+		assert.Equal(t, time.Hour, body.ExpiresAt.Sub(body.CreatedAt))
+		assert.Empty(t, ranks)
+	})
+
+	t.Run("Already Banned", func(t *testing.T) {
+		app := App(newBanTestConfig(gameID, leaderboardID, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/cheater/ban", bytes.NewBufferString(`{}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("Invalid Ban", func(t *testing.T) {
+		app := App(newBanTestConfig(gameID, leaderboardID, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/"+uuid.NewString()+"/ban", bytes.NewBufferString(`{"durationSeconds": -1}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseBanInvalid.Code, body.Code)
+		assert.Contains(t, body.Details, ban.ErrInvalidDuration.Error())
+	})
+}
+
+func TestBuildGetPlayerBanHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newBanTestConfig(gameID, leaderboardID, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/cheater/ban", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Ban
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "cheater", body.PlayerID)
+		assert.Equal(t, "speed hack", body.Reason)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newBanTestConfig(gameID, leaderboardID, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/alice/ban", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildListBansHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newBanTestConfig(gameID, leaderboardID, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/bans", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Ban
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "cheater", body[0].PlayerID)
+	})
+}
+
+func TestBuildLiftPlayerBanHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		ranks := fakeRanking{}
+		app := App(newBanTestConfig(gameID, leaderboardID, ranks))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/players/cheater/ban", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, fakeRanking{"cheater": 42}, ranks)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newBanTestConfig(gameID, leaderboardID, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/players/alice/ban", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildUpsertPlayerRankHandlerBan(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Banned", func(t *testing.T) {
+		ranks := fakeRanking{}
+		app := App(newBanTestConfig(gameID, leaderboardID, ranks))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/cheater", bytes.NewBufferString(`{"value": 100.0}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseBanPlayerBanned, body)
+		assert.Empty(t, ranks)
+	})
+
+	t.Run("Not Banned", func(t *testing.T) {
+		ranks := fakeRanking{}
+		app := App(newBanTestConfig(gameID, leaderboardID, ranks))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/alice", bytes.NewBufferString(`{"value": 100.0}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, fakeRanking{"alice": 100}, ranks)
+	})
+}
+
+func TestBuildBanDeviceHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newDeviceBanTestConfig(gameID, new(int)))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/devices/beef/ban", bytes.NewBufferString(`{"reason": "alt farm", "durationSeconds": 3600}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body DeviceBan
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "beef", body.DeviceHash)
+		assert.Equal(t, time.Hour, body.ExpiresAt.Sub(body.CreatedAt))
+	})
+
+	t.Run("Already Banned", func(t *testing.T) {
+		app := App(newDeviceBanTestConfig(gameID, new(int)))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/devices/f00d/ban", bytes.NewBufferString(`{}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("Invalid Device Hash", func(t *testing.T) {
+		app := App(newDeviceBanTestConfig(gameID, new(int)))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/devices/"+strings.Repeat("a", leaderboard.MaxDeviceHashLength+1)+"/ban", bytes.NewBufferString(`{}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func TestBuildGetDeviceBanHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newDeviceBanTestConfig(gameID, new(int)))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/devices/f00d/ban", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body DeviceBan
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "f00d", body.DeviceHash)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newDeviceBanTestConfig(gameID, new(int)))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/devices/beef/ban", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseDeviceBanNotFound, body)
+	})
+}
+
+func TestBuildListDeviceBansHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newDeviceBanTestConfig(gameID, new(int)))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/device-bans", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []DeviceBan
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "f00d", body[0].DeviceHash)
+	})
+}
+
+func TestBuildLiftDeviceBanHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newDeviceBanTestConfig(gameID, new(int)))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/devices/f00d/ban", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newDeviceBanTestConfig(gameID, new(int)))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/devices/beef/ban", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildUpsertPlayerRankHandlerDeviceBan(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Banned Device", func(t *testing.T) {
+		var applied int
+		app := App(newDeviceBanTestConfig(gameID, &applied))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/"+uuid.NewString(), bytes.NewBufferString(`{"value": 100.0}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+		req.Header.Set(HeaderDeviceHash, "f00d")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseDeviceBanned, body)
+		assert.Zero(t, applied)
+	})
+
+	t.Run("Other Device", func(t *testing.T) {
+		var applied int
+		app := App(newDeviceBanTestConfig(gameID, &applied))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/"+uuid.NewString(), bytes.NewBufferString(`{"value": 100.0}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+		req.Header.Set(HeaderDeviceHash, "beef")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, 1, applied)
+	})
+}
@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func leaderboardCacheKey(id, gameID string) string {
+	return fmt.Sprintf("GetLeaderboardMiddleware:%s:%s", id, gameID)
+}
+
+func questCacheKey(id, gameID string) string {
+	return fmt.Sprintf("GetQuestMiddleware:%s:%s", id, gameID)
+}
+
+func statisticCacheKey(id, gameID string) string {
+	return fmt.Sprintf("GetStatisticMiddleware:%s:%s", id, gameID)
+}
+
+// Removes the cached definition and the cached GET response of the resource on the request path.
+// The response keys follow the layout used by fiber's cache middleware (`<path>_<method>`)
+func invalidateCache(cache fiber.Storage, c *fiber.Ctx, definitionKey string) {
+	if cache == nil {
+		return
+	}
+
+	responseKey := c.Path() + "_" + http.MethodGet
+	for _, key := range []string{definitionKey, responseKey, responseKey + "_body"} {
+		if err := cache.Delete(key); err != nil {
+			zap.ErrorContext(c.UserContext(), err, "invalidate cache error", "key", key)
+		}
+	}
+}
+
+// Removes the cached GET responses of the leaderboard's ranking pages, so the writes made outside of the API, e.g. a
+// ban lifted on its own, show up on the next read. The query is left out of the response keys, so every page shares
+// them, with and without the trailing slash. A failed removal is only logged, as the responses still expire
+func BuildInvalidateRankingPagesFunc(cache fiber.Storage) leaderboard.StorageInvalidateCachedTopFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard) error {
+		if cache == nil {
+			return nil
+		}
+
+		path := "/api/v1/leaderboards/" + lb.ID + "/ranking"
+		for _, responseKey := range []string{path + "_" + http.MethodGet, path + "/_" + http.MethodGet} {
+			for _, key := range []string{responseKey, responseKey + "_body"} {
+				if err := cache.Delete(key); err != nil {
+					zap.ErrorContext(ctx, err, "invalidate cache error", "key", key)
+				}
+			}
+		}
+
+		return nil
+	}
+}
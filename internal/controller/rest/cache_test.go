@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/infra/cache/lru"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteHandlerInvalidatesCache(t *testing.T) {
+	var (
+		leaderboardID = uuid.NewString()
+		gameID        = uuid.NewString()
+		path          = fmt.Sprintf("/%s", leaderboardID)
+	)
+
+	authMiddleware := buildAuthMiddleware(func(ctx context.Context, credentials string) (auth.Claims, error) {
+		return auth.Claims{GameID: gameID}, nil
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		cache, _ := lru.New(10)
+		_ = cache.Set(leaderboardCacheKey(leaderboardID, gameID), []byte("{}"), 0)
+		_ = cache.Set(path+"_GET", []byte("{}"), 0)
+		_ = cache.Set(path+"_GET_body", []byte("{}"), 0)
+
+		app := fiber.New()
+		app.Delete("/:leaderboardId", authMiddleware, buildDeleteLeaderboardHandler(cache, func(ctx context.Context, id, gameID string) error {
+			return nil
+		}))
+
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		for _, key := range []string{leaderboardCacheKey(leaderboardID, gameID), path + "_GET", path + "_GET_body"} {
+			data, _ := cache.Get(key)
+			assert.Nil(t, data, key)
+		}
+	})
+
+	t.Run("Keeps Cache On Delete Error", func(t *testing.T) {
+		cache, _ := lru.New(10)
+		_ = cache.Set(leaderboardCacheKey(leaderboardID, gameID), []byte("{}"), 0)
+
+		app := fiber.New(fiber.Config{ErrorHandler: buildErrorHandler()})
+		app.Delete("/:leaderboardId", authMiddleware, buildDeleteLeaderboardHandler(cache, func(ctx context.Context, id, gameID string) error {
+			return errors.New("any error")
+		}))
+
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		data, _ := cache.Get(leaderboardCacheKey(leaderboardID, gameID))
+		assert.NotNil(t, data)
+	})
+}
+
+func TestBuildInvalidateRankingPagesFunc(t *testing.T) {
+	var (
+		leaderboardID = uuid.NewString()
+		gameID        = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		ranks := []leaderboard.Rank{{LeaderboardID: leaderboardID, PlayerID: "cheater", Position: 0, Value: 10}}
+
+		cache, _ := lru.New(10)
+		app := App(Config{
+			CacheSorage: cache,
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			RankingFunc: func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+				return ranks, nil
+			},
+		})
+
+		for _, path := range []string{"/api/v1/leaderboards/" + leaderboardID + "/ranking", "/api/v1/leaderboards/" + leaderboardID + "/ranking/"} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("Authorization", uuid.NewString())
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+
+		ranks = []leaderboard.Rank{}
+		err := BuildInvalidateRankingPagesFunc(cache)(context.Background(), leaderboard.Leaderboard{ID: leaderboardID, GameID: gameID})
+		assert.NoError(t, err)
+
+		for _, path := range []string{"/api/v1/leaderboards/" + leaderboardID + "/ranking", "/api/v1/leaderboards/" + leaderboardID + "/ranking/"} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("Authorization", uuid.NewString())
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var body []Rank
+			err = json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+
+			assert.Empty(t, body, path)
+		}
+	})
+
+	t.Run("Without Cache", func(t *testing.T) {
+		err := BuildInvalidateRankingPagesFunc(nil)(context.Background(), leaderboard.Leaderboard{ID: leaderboardID, GameID: gameID})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DeadLetter struct {
+	FailedAt  time.Time `json:"failedAt"`  // Time of the last processing failure
+	ID        string    `json:"id"`        // Dead letter ID
+	MessageID string    `json:"messageId"` // ID of the message, assigned by the sender
+	Kind      string    `json:"kind"`      // Kind of the message
+	GameID    string    `json:"gameId"`    // Game the message belongs to
+	Payload   string    `json:"payload"`   // Message payload, as received
+	Attempts  int       `json:"attempts"`  // Times the message processing failed
+	Error     string    `json:"error"`     // Error returned by the last attempt
+}
+
+func deadLetterFromDomain(d ingestion.DeadLetter) DeadLetter {
+	return DeadLetter{
+		FailedAt:  d.FailedAt,
+		ID:        d.ID,
+		MessageID: d.Message.ID,
+		Kind:      d.Message.Kind,
+		GameID:    d.Message.GameID,
+		Payload:   string(d.Message.Payload),
+		Attempts:  d.Message.Attempts,
+		Error:     d.Error,
+	}
+}
+
+var (
+	ErrorResponseDeadLetterInvalidID = ErrorResponse{Code: "8.0", Message: "Invalid dead letter ID"}
+	ErrorResponseDeadLetterNotFound  = ErrorResponse{Code: "8.1", Message: "Dead letter not found"}
+	ErrorResponseDeadLetterLimit     = ErrorResponse{Code: "8.2", Message: "Invalid dead letters limit"}
+)
+
+// @summary List Dead Letters
+// @description List the ingestion messages that failed processing, oldest first
+// @router /admin/v1/dead-letters [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param limit query int false "Max number of dead letters" minimun(1) default(100)
+// @success 200 {array} DeadLetter
+// @failure 401,403,422,500 {object} ErrorResponse
+func buildListDeadLettersHandler(listDeadLettersFunc ingestion.ListDeadLettersFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		limit := c.QueryInt("limit", 100)
+
+		deadLetters, err := listDeadLettersFunc(c.UserContext(), limit)
+		if err != nil {
+			return err
+		}
+
+		res := make([]DeadLetter, len(deadLetters))
+		for i, d := range deadLetters {
+			res[i] = deadLetterFromDomain(d)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Requeue Dead Letter
+// @description Publish the dead letter message back to the ingestion queue with its attempts reset
+// @router /admin/v1/dead-letters/{deadLetterId}/requeue [POST]
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param deadLetterId path string true "Dead letter ID"
+// @success 204
+// @failure 401,403,404,422,500 {object} ErrorResponse
+func buildRequeueDeadLetterHandler(requeueDeadLetterFunc ingestion.RequeueDeadLetterFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Params("deadLetterId")
+
+		if err := requeueDeadLetterFunc(c.UserContext(), id); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
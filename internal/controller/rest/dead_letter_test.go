@@ -0,0 +1,209 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildListDeadLettersHandler(t *testing.T) {
+	var (
+		adminToken   = uuid.NewString()
+		deadLetterID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var requestedLimit int
+
+		app := App(Config{
+			AdminToken: adminToken,
+			ListDeadLettersFunc: func(ctx context.Context, limit int) ([]ingestion.DeadLetter, error) {
+				requestedLimit = limit
+				return []ingestion.DeadLetter{{
+					ID:      deadLetterID,
+					Message: ingestion.Message{Kind: ingestion.KindPlayerRank, Payload: []byte(`{}`), Attempts: 5},
+					Error:   "any error",
+				}}, nil
+			},
+			RequeueDeadLetterFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/dead-letters?limit=10", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []DeadLetter
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 10, requestedLimit)
+		assert.Len(t, body, 1)
+		assert.Equal(t, deadLetterID, body[0].ID)
+		assert.Equal(t, `{}`, body[0].Payload)
+		assert.Equal(t, 5, body[0].Attempts)
+	})
+
+	t.Run("Invalid Admin Token", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			ListDeadLettersFunc: func(ctx context.Context, limit int) ([]ingestion.DeadLetter, error) {
+				return nil, nil
+			},
+			RequeueDeadLetterFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/dead-letters", nil)
+
+		req.Header.Set("Authorization", "Bearer "+uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseInvalidAdminToken.Code, body.Code)
+	})
+
+	t.Run("Not Mounted Without Admin Token", func(t *testing.T) {
+		var called bool
+
+		app := App(Config{
+			ListDeadLettersFunc: func(ctx context.Context, limit int) ([]ingestion.DeadLetter, error) {
+				called = true
+				return nil, nil
+			},
+			RequeueDeadLetterFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/dead-letters", nil)
+
+		_, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.False(t, called)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			ListDeadLettersFunc: func(ctx context.Context, limit int) ([]ingestion.DeadLetter, error) {
+				return nil, ingestion.ErrInvalidLimit
+			},
+			RequeueDeadLetterFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/dead-letters?limit=0", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseDeadLetterLimit.Code, body.Code)
+	})
+}
+
+func TestBuildRequeueDeadLetterHandler(t *testing.T) {
+	var (
+		adminToken   = uuid.NewString()
+		deadLetterID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var requeuedID string
+
+		app := App(Config{
+			AdminToken: adminToken,
+			ListDeadLettersFunc: func(ctx context.Context, limit int) ([]ingestion.DeadLetter, error) {
+				return nil, nil
+			},
+			RequeueDeadLetterFunc: func(ctx context.Context, id string) error {
+				requeuedID = id
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/v1/dead-letters/%s/requeue", deadLetterID), nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, deadLetterID, requeuedID)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			ListDeadLettersFunc: func(ctx context.Context, limit int) ([]ingestion.DeadLetter, error) {
+				return nil, nil
+			},
+			RequeueDeadLetterFunc: func(ctx context.Context, id string) error {
+				return ingestion.ErrDeadLetterNotFound
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/v1/dead-letters/%s/requeue", deadLetterID), nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseDeadLetterNotFound.Code, body.Code)
+	})
+
+	t.Run("Requeue Error", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			ListDeadLettersFunc: func(ctx context.Context, limit int) ([]ingestion.DeadLetter, error) {
+				return nil, nil
+			},
+			RequeueDeadLetterFunc: func(ctx context.Context, id string) error {
+				return errors.New("any error")
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/v1/dead-letters/%s/requeue", deadLetterID), nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
@@ -0,0 +1,118 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/delta"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SetDeltaRuleReq struct {
+	MaxSubmissionDelta float64 `json:"maxSubmissionDelta"`               // Most a single submission can improve the player's value. Zero for no limit
+	MaxHourlyDelta     float64 `json:"maxHourlyDelta"`                   // Most the player's value can improve within an hour. Zero for no limit
+	Action             string  `json:"action" enums:"REJECT,QUARANTINE"` // What happens to the submissions over the limits
+}
+
+type DeltaRule struct {
+	UpdatedAt          time.Time `json:"updatedAt"`                        // Last time the rule was set
+	LeaderboardID      string    `json:"leaderboardId"`                    // Leaderboard the rule applies to
+	MaxSubmissionDelta float64   `json:"maxSubmissionDelta"`               // Most a single submission can improve the player's value. Zero for no limit
+	MaxHourlyDelta     float64   `json:"maxHourlyDelta"`                   // Most the player's value can improve within an hour. Zero for no limit
+	Action             string    `json:"action" enums:"REJECT,QUARANTINE"` // What happens to the submissions over the limits
+}
+
+func deltaRuleFromDomain(r delta.Rule) DeltaRule {
+	return DeltaRule{
+		UpdatedAt:          r.UpdatedAt,
+		LeaderboardID:      r.LeaderboardID,
+		MaxSubmissionDelta: r.MaxSubmissionDelta,
+		MaxHourlyDelta:     r.MaxHourlyDelta,
+		Action:             r.Action,
+	}
+}
+
+var (
+	ErrorResponseDeltaRuleInvalid  = ErrorResponse{Code: "31.0", Message: "Invalid delta rule"}
+	ErrorResponseDeltaRuleNotFound = ErrorResponse{Code: "31.1", Message: "Delta rule not found"}
+	ErrorResponseDeltaExceeded     = ErrorResponse{Code: "31.2", Message: "The submission exceeds the leaderboard's maximum delta"}
+)
+
+// @summary Set Leaderboard Delta Rule
+// @description Set the most a player's value can improve on the leaderboard in a single submission and within an hour,
+// @description replacing its current rule. The improvement is the value itself on `INC` leaderboards, and how much it beats
+// @description the player's current value on `MAX` and `MIN` ones. The submissions over a limit are either rejected or held
+// @description for review, following the rule's action
+// @router /api/v1/leaderboards/{leaderboardId}/delta-rule [PUT]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @param SetDeltaRuleReq body SetDeltaRuleReq true "Maximum deltas of the leaderboard"
+// @success 200 {object} DeltaRule
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildSetDeltaRuleHandler(setRuleFunc delta.SetRuleFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body SetDeltaRuleReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		rule, err := setRuleFunc(c.UserContext(), delta.Rule{
+			GameID:             claims.GameID,
+			LeaderboardID:      c.Params("leaderboardId"),
+			MaxSubmissionDelta: body.MaxSubmissionDelta,
+			MaxHourlyDelta:     body.MaxHourlyDelta,
+			Action:             body.Action,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(deltaRuleFromDomain(rule))
+	}
+}
+
+// @summary Get Leaderboard Delta Rule
+// @description Get the maximum deltas of the leaderboard
+// @router /api/v1/leaderboards/{leaderboardId}/delta-rule [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @success 200 {object} DeltaRule
+// @failure 404,500 {object} ErrorResponse
+func buildGetDeltaRuleHandler(getRuleFunc delta.GetRuleFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		rule, err := getRuleFunc(c.UserContext(), claims.GameID, c.Params("leaderboardId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(deltaRuleFromDomain(rule))
+	}
+}
+
+// @summary Remove Leaderboard Delta Rule
+// @description Stop checking the improvements of the submissions to the leaderboard. The submissions already held for review are kept
+// @router /api/v1/leaderboards/{leaderboardId}/delta-rule [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @success 204
+// @failure 404,500 {object} ErrorResponse
+func buildRemoveDeltaRuleHandler(removeRuleFunc delta.RemoveRuleFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := removeRuleFunc(c.UserContext(), claims.GameID, c.Params("leaderboardId")); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,397 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/delta"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// The game has the leaderboard given, ranked as given, with a delta rule of 100 taking the action given, and the
+// "casual" leaderboard, with no rule. Both keep the highest value written, as on the `MAX` leaderboards. A submission of
+// 1000 by alice to the leaderboard given is held as "held"
+func newDeltaTestConfig(gameID, leaderboardID, action string, ranks fakeRanking) Config {
+	heldSubmission := quarantine.Submission{ID: "held", GameID: gameID, LeaderboardID: leaderboardID, PlayerID: "alice", Value: 1000, Reason: delta.ReasonMaxSubmissionDelta}
+
+	getLeaderboard := func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+		if id != leaderboardID && id != "casual" {
+			return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+		}
+
+		return leaderboard.Leaderboard{ID: id, GameID: gameID, AggregationMode: leaderboard.AggregationModeMax}, nil
+	}
+
+	getRule := func(ctx context.Context, gameID, id string) (delta.Rule, error) {
+		if id != leaderboardID {
+			return delta.Rule{}, delta.ErrRuleNotFound
+		}
+
+		return delta.Rule{GameID: gameID, LeaderboardID: id, MaxSubmissionDelta: 100, Action: action}, nil
+	}
+
+	upsertPlayerRankValue := func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		ranks[playerID] = max(ranks[playerID], value)
+		return nil
+	}
+
+	getHeld := func(ctx context.Context, gameID, id string) (quarantine.Submission, error) {
+		if id != heldSubmission.ID {
+			return quarantine.Submission{}, quarantine.ErrSubmissionNotFound
+		}
+
+		return heldSubmission, nil
+	}
+
+	deleteHeld := func(ctx context.Context, gameID, id string) error {
+		_, err := getHeld(ctx, gameID, id)
+		return err
+	}
+
+	hold := quarantine.BuildHoldFunc(func(ctx context.Context, s quarantine.Submission) (quarantine.Submission, error) {
+		s.ID = uuid.NewString()
+		return s, nil
+	})
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: getLeaderboard,
+		UpsertPlayerRankFunc:            leaderboard.BuildUpsertPlayerRankFunc(nil, delta.BuildEnforceFunc(getRule, ranks.GetPlayerRank, nil, nil, hold, upsertPlayerRankValue)),
+		SetDeltaRuleFunc: delta.BuildSetRuleFunc(getLeaderboard, func(ctx context.Context, rule delta.Rule) (delta.Rule, error) {
+			return rule, nil
+		}),
+		GetDeltaRuleFunc: delta.BuildGetRuleFunc(getRule),
+		RemoveDeltaRuleFunc: delta.BuildRemoveRuleFunc(func(ctx context.Context, gameID, leaderboardID string) error {
+			_, err := getRule(ctx, gameID, leaderboardID)
+			return err
+		}),
+		ListQuarantinedSubmissionsFunc: quarantine.BuildListFunc(func(ctx context.Context, gameID, leaderboardID string, limit int) ([]quarantine.Submission, error) {
+			return []quarantine.Submission{heldSubmission}, nil
+		}),
+		ReleaseQuarantinedSubmissionFunc: quarantine.BuildReleaseFunc(getLeaderboard, nil, getHeld, deleteHeld, upsertPlayerRankValue),
+		DiscardQuarantinedSubmissionFunc: quarantine.BuildDiscardFunc(deleteHeld),
+	}
+}
+
+func TestBuildSetDeltaRuleHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var recorded audit.NewEntryData
+
+		config := newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, fakeRanking{})
+		config.RecordAuditEntryFunc = func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+			recorded = data
+			return audit.Entry{ID: uuid.NewString()}, nil
+		}
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/leaderboards/"+leaderboardID+"/delta-rule", bytes.NewBufferString(`{"maxSubmissionDelta": 100, "action": "QUARANTINE"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body DeltaRule
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 100.0, body.MaxSubmissionDelta)
+		assert.Equal(t, delta.ActionQuarantine, body.Action)
+
+		assert.Equal(t, http.MethodPut, recorded.Method)
+		assert.Equal(t, "/api/v1/leaderboards/:leaderboardId/delta-rule", recorded.Route)
+		assert.Equal(t, map[string]string{"leaderboardId": leaderboardID}, recorded.ResourceIDs)
+		assert.Equal(t, http.StatusOK, recorded.StatusCode)
+	})
+
+	t.Run("Invalid Rule", func(t *testing.T) {
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/leaderboards/"+leaderboardID+"/delta-rule", bytes.NewBufferString(`{"maxSubmissionDelta": 100, "action": "WARN"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("Leaderboard Not Found", func(t *testing.T) {
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/leaderboards/"+uuid.NewString()+"/delta-rule", bytes.NewBufferString(`{"maxSubmissionDelta": 100, "action": "QUARANTINE"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildGetDeltaRuleHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionReject, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+leaderboardID+"/delta-rule", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body DeltaRule
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, delta.ActionReject, body.Action)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionReject, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/casual/delta-rule", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildRemoveDeltaRuleHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionReject, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/leaderboards/"+leaderboardID+"/delta-rule", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionReject, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/leaderboards/casual/delta-rule", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildUpsertPlayerRankHandlerDelta(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Within The Limit", func(t *testing.T) {
+		ranks := fakeRanking{"alice": 100}
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, ranks))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/alice", bytes.NewBufferString(`{"value": 150}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, fakeRanking{"alice": 150}, ranks)
+	})
+
+	t.Run("Quarantined", func(t *testing.T) {
+		ranks := fakeRanking{"alice": 100}
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, ranks))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/alice", bytes.NewBufferString(`{"value": 1000}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		assert.Equal(t, fakeRanking{"alice": 100}, ranks)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		ranks := fakeRanking{"alice": 100}
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionReject, ranks))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/alice", bytes.NewBufferString(`{"value": 5000}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		assert.Equal(t, fakeRanking{"alice": 100}, ranks)
+	})
+
+	t.Run("No Rule", func(t *testing.T) {
+		ranks := fakeRanking{"alice": 100}
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionReject, ranks))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/casual/ranking/alice", bytes.NewBufferString(`{"value": 5000}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, fakeRanking{"alice": 5000}, ranks)
+	})
+}
+
+func TestBuildListQuarantinedSubmissionsHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/quarantine", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []QuarantinedSubmission
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "held", body[0].ID)
+		assert.Equal(t, delta.ReasonMaxSubmissionDelta, body[0].Reason)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/quarantine?limit=1000", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func TestBuildReleaseQuarantinedSubmissionHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		ranks := fakeRanking{"alice": 100}
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, ranks))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/quarantine/held/release", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, fakeRanking{"alice": 1000}, ranks)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/quarantine/"+uuid.NewString()+"/release", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildDiscardQuarantinedSubmissionHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		ranks := fakeRanking{"alice": 100}
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, ranks))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/quarantine/held", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, fakeRanking{"alice": 100}, ranks)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newDeltaTestConfig(gameID, leaderboardID, delta.ActionQuarantine, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/quarantine/"+uuid.NewString(), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
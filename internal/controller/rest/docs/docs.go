@@ -18,43 +18,94 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/leaderboards": {
-            "post": {
-                "description": "Create a leaderboard",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/v1/audit": {
+            "get": {
+                "description": "List the mutating requests recorded on the audit log, newest first, e.g. to find who deleted a leaderboard.\nThe pages are walked by sending the ` + "`" + `next` + "`" + ` cursor of a page as the ` + "`" + `after` + "`" + ` of the following one",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create Leaderboard",
+                "summary": "List Audit Entries",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "New leaderboard config data",
-                        "name": "NewLeaderboardData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.CreateLeaderboardReq"
-                        }
+                        "type": "string",
+                        "description": "Game that performed the requests",
+                        "name": "gameId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subject of the credential used on the requests",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "achievements",
+                            "leaderboards",
+                            "level-curves",
+                            "players",
+                            "quests",
+                            "rewards",
+                            "segments",
+                            "statistics",
+                            "titles",
+                            "webhooks"
+                        ],
+                        "type": "string",
+                        "description": "Resource affected",
+                        "name": "resourceType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entries recorded from this time on, as RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entries recorded before this time, as RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Max number of entries",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from the ` + "`" + `next` + "`" + ` of the previous one",
+                        "name": "after",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.Leaderboard"
+                            "$ref": "#/definitions/rest.AuditEntriesRes"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -74,81 +125,95 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/leaderboards/{leaderboardId}": {
+        "/admin/v1/audit/export": {
             "get": {
-                "description": "Return a leaderboard by id and game id",
+                "description": "Download every audit entry matching the filter, newest first, as a CSV or JSON attachment.\nThe resource IDs are written as ` + "`" + `param=id` + "`" + ` pairs separated by ` + "`" + `;` + "`" + ` on the CSV",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
-                "summary": "Get Leaderboard",
+                "summary": "Export Audit Entries",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Leaderboard ID",
-                        "name": "leaderboardId",
-                        "in": "path",
+                        "description": "Game that performed the requests",
+                        "name": "gameId",
+                        "in": "query",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/rest.Leaderboard"
-                        }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/rest.ErrorResponse"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Subject of the credential used on the requests",
+                        "name": "actor",
+                        "in": "query"
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/rest.ErrorResponse"
-                        }
+                    {
+                        "enum": [
+                            "achievements",
+                            "leaderboards",
+                            "level-curves",
+                            "players",
+                            "quests",
+                            "rewards",
+                            "segments",
+                            "statistics",
+                            "titles",
+                            "webhooks"
+                        ],
+                        "type": "string",
+                        "description": "Resource affected",
+                        "name": "resourceType",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/rest.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete a leaderboard by id and game id",
-                "summary": "Delete Leaderboard",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "Entries recorded from this time on, as RFC 3339",
+                        "name": "from",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Leaderboard ID",
-                        "name": "leaderboardId",
-                        "in": "path",
-                        "required": true
+                        "description": "Entries recorded before this time, as RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.AuditEntry"
+                            }
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -168,40 +233,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/leaderboards/{leaderboardId}/ranking": {
+        "/admin/v1/dead-letters": {
             "get": {
-                "description": "Get the leaderboard ranking paginated",
+                "description": "List the ingestion messages that failed processing, oldest first",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Leaderboard Ranking",
+                "summary": "List Dead Letters",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Leaderboard ID",
-                        "name": "leaderboardId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 500,
                         "type": "integer",
-                        "default": 10,
-                        "description": "Number of rankings per page",
+                        "default": 100,
+                        "description": "Max number of dead letters",
                         "name": "limit",
                         "in": "query"
                     }
@@ -212,18 +262,18 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/rest.Rank"
+                                "$ref": "#/definitions/rest.DeadLetter"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -243,54 +293,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/leaderboards/{leaderboardId}/ranking/{playerId}": {
+        "/admin/v1/dead-letters/{deadLetterId}/requeue": {
             "post": {
-                "description": "Set or update a player's rank on the leaderboard",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Upsert Player Rank",
+                "description": "Publish the dead letter message back to the ingestion queue with its attempts reset",
+                "summary": "Requeue Dead Letter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Leaderboard ID",
-                        "name": "leaderboardId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Player ID",
-                        "name": "playerId",
+                        "description": "Dead letter ID",
+                        "name": "deadLetterId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Values to update the player rank",
-                        "name": "UpsertPlayerRankData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.UpsertPlayerRankReq"
-                        }
                     }
                 ],
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -316,39 +350,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/quests": {
+        "/admin/v1/events/replay": {
             "post": {
-                "description": "Create a quest and its tasks",
+                "description": "Publish again the archived domain events of a game recorded within the time range, in the order they were recorded",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create Quest",
+                "summary": "Replay Events",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "New quest config data",
-                        "name": "NewQuestData",
+                        "description": "Events to replay",
+                        "name": "ReplayEventsData",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.CreateQuestReq"
+                            "$ref": "#/definitions/rest.ReplayEventsReq"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.Quest"
+                            "$ref": "#/definitions/rest.ReplayEventsRes"
                         }
                     },
                     "400": {
@@ -357,6 +391,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -372,38 +418,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/quests/{questId}": {
+        "/admin/v1/games/{gameId}/anomalies": {
             "get": {
-                "description": "Get a quest and its tasks",
+                "description": "List the game's ranks flagged by the anomaly detection job and waiting for review, oldest first",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Quest By ID",
+                "summary": "List Anomalies",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Quest ID",
-                        "name": "questId",
+                        "description": "Game ID",
+                        "name": "gameId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep only the anomalies of the leaderboard",
+                        "name": "leaderboardId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Anomalies to list, from 1 to 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.Quest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Anomaly"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -421,22 +489,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a quest and its tasks",
-                "summary": "Delete Quest",
+            }
+        },
+        "/admin/v1/games/{gameId}/anomalies/{anomalyId}/dismiss": {
+            "post": {
+                "description": "Remove a flagged rank found legitimate from the review list. It's not flagged again until the player's value changes",
+                "summary": "Dismiss Anomaly",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Quest ID",
-                        "name": "questId",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Anomaly ID",
+                        "name": "anomalyId",
                         "in": "path",
                         "required": true
                     }
@@ -445,6 +522,18 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -466,45 +555,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/quests/{questId}/players/{playerId}": {
+        "/admin/v1/games/{gameId}/devices": {
             "get": {
-                "description": "Get a player's quest progression",
+                "description": "List the devices the game's players submitted scores from, as recorded on the score history with the submissions'\nfingerprint, the most recently seen first, up to 100. Filtered by device, it lists the accounts sharing it, and\nby player, the devices the player used, so the devices to ban can be found. The endpoint is only mounted with the\nscore history enabled",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Player Quest Progression",
+                "summary": "List Device Usage",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Quest ID",
-                        "name": "questId",
+                        "description": "Game ID",
+                        "name": "gameId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Player ID",
+                        "description": "Keep only the players that submitted from the device",
+                        "name": "deviceHash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep only the devices the player submitted from",
                         "name": "playerId",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.PlayerQuestProgression"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.DeviceUsage"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -522,25 +625,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Start a player's quest progression",
+            }
+        },
+        "/admin/v1/games/{gameId}/leaderboards/{leaderboardId}/ranking/{playerId}": {
+            "get": {
+                "description": "Get a player's entry on a leaderboard, alongside the moderation actions taken on it",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Start Player Quest Progression",
+                "summary": "Inspect Leaderboard Entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Quest ID",
-                        "name": "questId",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
                         "in": "path",
                         "required": true
                     },
@@ -553,20 +665,26 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.PlayerQuestProgression"
+                            "$ref": "#/definitions/rest.ModerationEntry"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -585,27 +703,34 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Updates a player's quest progression",
+            "put": {
+                "description": "Set the value of a player's entry on a leaderboard, whatever the leaderboard's aggregation mode, ranking the\nplayer when they aren't yet. The adjustment is recorded on the game's audit log with its reason. It's not\nrecorded on the score history, so the leaderboard's recompute brings back the value the scores add up to",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update Player Quest Progression",
+                "summary": "Adjust Leaderboard Entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Quest ID",
-                        "name": "questId",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
                         "in": "path",
                         "required": true
                     },
@@ -617,12 +742,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Player data to check",
-                        "name": "ProgressData",
+                        "description": "Value and reason",
+                        "name": "AdjustEntryReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.UpdatePlayerQuestProgressionReq"
+                            "$ref": "#/definitions/rest.AdjustEntryReq"
                         }
                     }
                 ],
@@ -630,7 +755,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.PlayerQuestProgression"
+                            "$ref": "#/definitions/rest.ModerationAction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
                         }
                     },
                     "404": {
@@ -652,45 +795,71 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/statistics": {
-            "post": {
-                "description": "Create a statistic",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Remove a player's entry from a leaderboard. The removal is recorded on the game's audit log with its reason.\nThe player's scores are kept on the score history, so the leaderboard's recompute brings the entry back",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create Statistic",
+                "summary": "Remove Leaderboard Entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "New statistic config data",
-                        "name": "NewStatisticData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.CreateStatisticReq"
-                        }
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Why the entry is removed, up to 512 characters",
+                        "name": "reason",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.Statistic"
+                            "$ref": "#/definitions/rest.ModerationAction"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -710,78 +879,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/statistics/{statisticId}": {
-            "get": {
-                "description": "Get a statistic by its id",
+        "/admin/v1/games/{gameId}/leaderboards/{leaderboardId}/rollbacks": {
+            "post": {
+                "description": "Revert the scores submitted to a leaderboard within the window, every player's or a single one's, e.g. after an\nexploit. The affected entries are rebuilt from the scores kept on the score history, the ones without any\nremoved, and the scores reverted are deleted from it. The endpoint is only mounted with the score history\nenabled, and it's rejected, before changing anything, when there are over 10000 entries to recompute. The\nrollback is recorded on the game's audit log with its reason and the entries recomputed. The entries skip the\nsubmission checks and lose their adjustments. It isn't atomic: when it fails, sending it again recomputes the\nsame entries",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Statistic By ID",
+                "summary": "Rollback Leaderboard Scores",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Statistic ID",
-                        "name": "statisticId",
+                        "description": "Game ID",
+                        "name": "gameId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Window, player and reason",
+                        "name": "RollbackScoresReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.RollbackScoresReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.Statistic"
+                            "$ref": "#/definitions/rest.ModerationAction"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete a statistic by its id",
-                "summary": "Delete Statistic",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Game's JWT authorization",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Statistic ID",
-                        "name": "statisticId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
                     "404": {
                         "description": "Not Found",
@@ -804,25 +967,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/statistics/{statisticId}/players/{playerId}": {
+        "/admin/v1/games/{gameId}/moderation-actions": {
             "get": {
-                "description": "Get the player's statistic progression",
+                "description": "List the moderation actions taken on the game's leaderboards entries, oldest first",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Player Statistic Progression By ID",
+                "summary": "List Moderation Actions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Statistic ID",
-                        "name": "statisticId",
+                        "description": "Game ID",
+                        "name": "gameId",
                         "in": "path",
                         "required": true
                     }
@@ -831,23 +994,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.PlayerStatisticProgression"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/rest.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.ModerationAction"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -859,51 +1019,56 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/v1/games/{gameId}/players/{playerId}/merge": {
             "post": {
-                "description": "Set or update a player's statistic progression",
+                "description": "Merge a player into another one of the same game, e.g. after an account was linked to the wrong player.\nEach rank of the player merged is combined with the target player's one by the leaderboard's aggregation mode,\nthe values added up on INC and the best one kept on MAX and MIN. Each statistic progression is combined by the\nstatistic's aggregation mode, completing the landmarks and goals the combined value reaches. Each quest ends up\nwith the tasks completed by either player. The player merged is left without ranks, statistic nor quest progression,\nincluding on the soft deleted leaderboards, statistics and quests, while the rest of their data is kept. The merge\nis recorded on the game's audit log. It isn't atomic: when it fails, what was combined so far is kept and retrying\nit combines the rest, adding the SUM and SUB statistic progressions combined before the failure again",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Upsert Player Statistic Progression",
+                "summary": "Merge Players",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game's JWT authorization",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Statistic ID",
-                        "name": "statisticId",
+                        "description": "Game ID",
+                        "name": "gameId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Player ID",
+                        "description": "ID of the player merged",
                         "name": "playerId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Values to update the player statistic progression",
-                        "name": "UpsertPlayerStatisticData",
+                        "description": "Player the merged player's data goes to",
+                        "name": "MergePlayersReq",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.UpsertPlayerStatisticProgressionReq"
+                            "$ref": "#/definitions/rest.MergePlayersReq"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PlayerMergeReceipt"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -911,8 +1076,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -931,54 +1102,8056 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "rest.CreateLeaderboardReq": {
-            "type": "object",
-            "properties": {
-                "aggregationMode": {
-                    "description": "Data aggregation mode",
-                    "type": "string",
-                    "enum": [
-                        "INC",
-                        "MAX",
-                        "MIN"
-                    ]
+        },
+        "/admin/v1/games/{gameId}/players/{playerId}/trust": {
+            "get": {
+                "description": "Get the player's trust score, computed from the time since their first submission checked by the delta rules,\ntheir submissions held for review and the ones released. The players with a ` + "`" + `LOW` + "`" + ` trust have stricter delta\nlimits and their submissions over them are always held for review, while the ones with a ` + "`" + `HIGH` + "`" + ` trust skip the\ndelta rules. The player has no trust record until their first submission",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Player Trust",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Trust"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/v1/games/{gameId}/quota": {
+            "get": {
+                "description": "Get the quota of the game, the default one when it has none of its own, alongside how much of it the game uses",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Game Quota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.QuotaStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the quota of the game. A zero limit means unlimited. The new rate limit takes up to ` + "`" + `QUOTA_CACHE_TTL` + "`" + `\nseconds to apply on every instance, while the leaderboard and statistic limits apply right away.\nLowering a limit below what the game already keeps deletes nothing, it just blocks new ones",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Set Game Quota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New quota",
+                        "name": "SetQuotaReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SetQuotaReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Quota"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/v1/games/{gameId}/ranking-removals": {
+            "post": {
+                "description": "Remove every entry a score was submitted to through the source within the window, on all the game's leaderboards,\ne.g. after a compromised MQTT broker or ingestion topic. The entries are found on the score history, so the\nendpoint is only mounted when it's enabled, and it's rejected, before removing anything, when there are over\n10000 entries. The removal is recorded on the game's audit log with its reason and the entries removed.\nIt isn't atomic: when it fails, the entries removed so far are recorded and sending it again removes the rest",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Bulk Remove Leaderboard Entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Source, window and reason",
+                        "name": "BulkRemoveEntriesReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.BulkRemoveEntriesReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ModerationAction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/v1/games/{gameId}/signing-key": {
+            "get": {
+                "description": "Get the key the game's clients sign their rank submissions with. Each game has its own key, derived from\n` + "`" + `SUBMISSION_SIGNING_KEY` + "`" + `, so it changes for every game when that one is rotated",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Game Signing Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SigningKey"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/v1/games/{gameId}/summary": {
+            "get": {
+                "description": "Count the game's active leaderboards, statistics and quests alongside its score submissions and most frequent\nerrors over the last 7 days, for the internal dashboards. The submissions and errors are only tracked with the usage,\nwhich is written to the storage periodically, so the latest requests may not be counted yet",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Game Summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.GameSummary"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/v1/log-level": {
+            "get": {
+                "description": "Get the minimum level logged by this instance",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Log Level",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.LogLevel"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the minimum level logged by this instance without restarting it, e.g. to enable the debug logs during an incident.\nOnly the instance handling the request is changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Set Log Level",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New log level",
+                        "name": "SetLogLevelReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SetLogLevelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.LogLevel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/v1/purge/{gameId}": {
+            "post": {
+                "description": "Permanently remove the game's leaderboards, statistics and quests soft deleted for longer than the retention,\nalongside their rankings and players progression, without waiting for the periodic purge. Each record removed\nis recorded on the audit log. Records removed before a storage failure stay removed, so the purge can be retried.\nWith ` + "`" + `dryRun` + "`" + ` set, the records are only listed",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Purge Game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum time the records have been soft deleted for, as a duration such as ` + "`" + `720h` + "`" + `",
+                        "name": "retention",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only list the records the purge would remove",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PurgeReport"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/v1/usage/{gameId}": {
+            "get": {
+                "description": "Report the requests and score submissions of the game on each day of the period, alongside the data it keeps on the storage.\nThe usage is written to the storage periodically, so the latest requests may not be reported yet",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Game Usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of the period, as ` + "`" + `YYYY-MM-DD` + "`" + `. Defaults to 29 days before ` + "`" + `to` + "`" + `",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the period, as ` + "`" + `YYYY-MM-DD` + "`" + `. Defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.UsageReport"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/v1/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "description": "List the deliveries of the webhook and their attempts, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Webhook Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin token, as ` + "`" + `Bearer \u003ctoken\u003e` + "`" + `",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Max number of deliveries",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/accounts/{platform}/{externalId}": {
+            "get": {
+                "description": "Find the player of the game an external platform account is linked to, e.g. to submit the scores\nsent from any of the player's platforms to the same player",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Resolve Platform Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "STEAM",
+                            "PSN",
+                            "XBOX",
+                            "DEVICE"
+                        ],
+                        "type": "string",
+                        "description": "Platform of the account",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account ID on the platform",
+                        "name": "externalId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.LinkedAccount"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/achievements": {
+            "get": {
+                "description": "List the game's achievements, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Achievements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Achievement"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an achievement, unlocked when a player reaches a landmark of a statistic or completes a quest.\nThe statistic or quest must belong to the game. Progressions made before the achievement was created don't unlock it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create Achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New achievement config data",
+                        "name": "CreateAchievementReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateAchievementReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Achievement"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/achievements/{achievementId}": {
+            "get": {
+                "description": "Get an achievement by its id",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Achievement By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "achievementId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Achievement"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an achievement by its id, alongside the players' unlocks of it",
+                "summary": "Delete Achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "achievementId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/bans": {
+            "get": {
+                "description": "List the players banned from the game, oldest ban first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Bans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Ban"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/device-bans": {
+            "get": {
+                "description": "List the devices banned from the game, oldest ban first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Device Bans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.DeviceBan"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/devices/{deviceHash}/ban": {
+            "post": {
+                "description": "Ban a device from the game. The submissions whose fingerprint carries the device hash are rejected until the ban\nis lifted, whatever the player they're sent for. The players that submitted from the device keep their ranks,\nas a device may be shared, and are banned on their own",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Ban Device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device hash, as sent on the submissions' fingerprint",
+                        "name": "deviceHash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ban details",
+                        "name": "BanDeviceReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.BanDeviceReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.DeviceBan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "Get the device's ban on the game",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Device Ban",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device hash",
+                        "name": "deviceHash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.DeviceBan"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Lift the device's ban on the game",
+                "summary": "Lift Device Ban",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device hash",
+                        "name": "deviceHash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/flags": {
+            "get": {
+                "description": "List the players flagged on the game, oldest flag first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Flags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.ShadowFlag"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/graphql": {
+            "post": {
+                "description": "Read the player's ranks, statistics and quests on a single query.\nErrors on a field come on the ` + "`" + `errors` + "`" + ` list, alongside the fields that could be read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "GraphQL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "GraphQL query",
+                        "name": "GraphQLData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.GraphQLReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.GraphQLRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaderboards": {
+            "post": {
+                "description": "Create a leaderboard. Fails when the game already keeps as many leaderboards as its quota allows",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create Leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New leaderboard config data",
+                        "name": "NewLeaderboardData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateLeaderboardReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Leaderboard"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaderboards/{leaderboardId}": {
+            "get": {
+                "description": "Return a leaderboard by id and game id",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Leaderboard"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a leaderboard by id and game id",
+                "summary": "Delete Leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaderboards/{leaderboardId}/delta-rule": {
+            "put": {
+                "description": "Set the most a player's value can improve on the leaderboard in a single submission and within an hour,\nreplacing its current rule. The improvement is the value itself on ` + "`" + `INC` + "`" + ` leaderboards, and how much it beats\nthe player's current value on ` + "`" + `MAX` + "`" + ` and ` + "`" + `MIN` + "`" + ` ones. The submissions over a limit are either rejected or held\nfor review, following the rule's action",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Set Leaderboard Delta Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Maximum deltas of the leaderboard",
+                        "name": "SetDeltaRuleReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SetDeltaRuleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.DeltaRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "Get the maximum deltas of the leaderboard",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Leaderboard Delta Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.DeltaRule"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop checking the improvements of the submissions to the leaderboard. The submissions already held for review are kept",
+                "summary": "Remove Leaderboard Delta Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaderboards/{leaderboardId}/eligibility": {
+            "put": {
+                "description": "Restrict the leaderboard to the members of a segment, replacing its current restriction. The submissions of\nthe other players are rejected, while the ranks they already have are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Set Leaderboard Eligibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Segment whose members are eligible",
+                        "name": "SetEligibilityReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SetEligibilityReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Eligibility"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "Get the segment the leaderboard is restricted to",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Leaderboard Eligibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Eligibility"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Open the leaderboard to every player again",
+                "summary": "Remove Leaderboard Eligibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaderboards/{leaderboardId}/events": {
+            "get": {
+                "description": "Server-Sent Events stream of the leaderboard rank changes, as ` + "`" + `rank_changed` + "`" + ` events with the player's rank.\nA ` + "`" + `closed` + "`" + ` event ends the stream once the leaderboard closes. Ended leaderboards answer with no content",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "Stream Leaderboard Events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Rank"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaderboards/{leaderboardId}/proof-rule": {
+            "put": {
+                "description": "Require a proof, e.g. a replay ID or URL, for the submissions landing on the top positions of the leaderboard,\nreplacing its current rule. The submissions with a proof are held for a moderator's approval, and the ones\nwithout it rejected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Set Leaderboard Proof Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Top positions requiring a proof",
+                        "name": "SetProofRuleReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SetProofRuleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ProofRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "Get the top positions of the leaderboard requiring a proof",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Leaderboard Proof Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ProofRule"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop requiring a proof for the top positions of the leaderboard. The submissions already waiting for approval are kept",
+                "summary": "Remove Leaderboard Proof Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaderboards/{leaderboardId}/ranking": {
+            "get": {
+                "description": "Get the leaderboard ranking paginated. With the player profiles enabled, each rank carries the player's\ndisplay name, avatar and country, when the player has a profile. With the titles enabled, each rank carries\nthe titles granted to the player. With the presence enabled, each rank carries whether the player is online\nand when it was last seen. With ` + "`" + `includePending` + "`" + `, the submissions waiting for a moderator's approval to reach the\nleaderboard's top positions are merged into the page, each at the position it would take, flagged as ` + "`" + `pending` + "`" + `.\nWith ` + "`" + `playerId` + "`" + `, the page is the one seen by the player, so the shadow rank of a flagged player is placed on it",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Leaderboard Ranking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of rankings per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Merge the submissions waiting for approval into the page",
+                        "name": "includePending",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player viewing the ranking",
+                        "name": "playerId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Rank"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaderboards/{leaderboardId}/ranking/live": {
+            "get": {
+                "description": "Upgrade to a WebSocket that pushes the leaderboard top and the player's rank whenever they change.\nThe first message is a ` + "`" + `snapshot` + "`" + `; the following ones are ` + "`" + `update` + "`" + `s with only what changed",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Watch Ranking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of ranks on the top",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID whose rank will also be watched",
+                        "name": "playerId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/rest.LiveRankingMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "426": {
+                        "description": "Upgrade Required",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaderboards/{leaderboardId}/ranking/{playerId}": {
+            "post": {
+                "description": "Set or update a player's rank on the leaderboard. The submissions of a banned player are rejected.\nWith the signed submissions enabled, the submission carries a nonce issued to the player and its signature,\nand is rejected when either is missing or invalid, or when the nonce was already used. With a delta rule set\non the leaderboard, the submissions improving the player's value past its limits are either rejected or held\nfor review, answered with ` + "`" + `202 Accepted` + "`" + `. With a proof rule set on the leaderboard, the submissions landing on its\ntop positions are rejected without a proof, and held for a moderator's approval with one. With the submission\nrate limit enabled, the player's submissions over it are rejected until the minute ends. The optional fingerprint\nheaders are recorded on the score history, with the region read from the header set by the proxy in front of the\nAPI, when configured. The submissions sent from a banned device are rejected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Upsert Player Rank",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hash of the device ID, up to 128 characters",
+                        "name": "X-Device-Hash",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version of the client build, up to 64 characters",
+                        "name": "X-Build-Version",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Values to update the player rank",
+                        "name": "UpsertPlayerRankData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.UpsertPlayerRankReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaderboards/{leaderboardId}/ranking/{playerId}/poll": {
+            "get": {
+                "description": "Wait for the player's rank to change since the cursor, for the clients that can't hold a WebSocket or an event stream.\nWithout a cursor, the current rank is returned right away. The cursor returned is sent on the next poll",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Poll Player Rank",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous poll",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 30,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 25,
+                        "description": "Seconds to wait for a change",
+                        "name": "timeout",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.RankPollRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/level-curves": {
+            "post": {
+                "description": "Create a level curve, mapping the value of a statistic, the player's XP, to their level. The statistic must\nbelong to the game and use the SUM aggregation mode. The levels are listed one by one on a TABLE, or follow\nthe LINEAR and EXPONENTIAL formulas up to their max level. A level-up is published each time a player's XP\nupdate takes them to a higher level",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create Level Curve",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New level curve config data",
+                        "name": "CreateLevelCurveReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateLevelCurveReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.LevelCurve"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "List the game's level curves, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Level Curves",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.LevelCurve"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/level-curves/{curveId}": {
+            "get": {
+                "description": "Get a level curve by its id",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Level Curve By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Level Curve ID",
+                        "name": "curveId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.LevelCurve"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a level curve by its id. The XP statistic and the players' progression on it are kept",
+                "summary": "Delete Level Curve",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Level Curve ID",
+                        "name": "curveId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/level-curves/{curveId}/players/{playerId}": {
+            "get": {
+                "description": "Get the player's level on the curve, from their current XP. A player without progression on the XP\nstatistic has its initial value as XP",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Player Level",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Level Curve ID",
+                        "name": "curveId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PlayerLevel"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players": {
+            "get": {
+                "description": "Search the players of the game by the display name of their profiles, paginated. The players with a word\nof the display name starting with the search come first, then the ones loosely matching its words",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Search Players",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 64,
+                        "minLength": 2,
+                        "type": "string",
+                        "description": "Display name, or its start, searched. Case insensitive",
+                        "name": "search",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of players per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep only the members of the segment. The page is filtered, so it may have fewer players than the limit",
+                        "name": "segmentId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Profile"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}": {
+            "delete": {
+                "description": "Erase the player's ranks, statistic and quest progression, events and dead letters across every storage of the game,\nincluding the soft deleted leaderboards, statistics and quests. The erasure is recorded on the audit log with the\ndigest of the player ID only and a ` + "`" + `gameblitz.player.erasure` + "`" + ` event is published. Responses already cached keep\nbeing served until they expire. With ` + "`" + `dryRun` + "`" + ` set, the records the erasure would remove are counted and returned\nas a ` + "`" + `PlayerDataPreview` + "`" + ` instead, without changing, recording or publishing anything",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Erase Player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only count the records the erasure would remove",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PlayerErasureReceipt"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/accounts": {
+            "get": {
+                "description": "List the external platform accounts linked to a player of the game, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Linked Accounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.LinkedAccount"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Link an account of an external platform to a player of the game. An account is linked to a single\nplayer, linking it again to the same player returns the existing link",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Link Platform Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Account linked",
+                        "name": "LinkAccountReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.LinkAccountReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.LinkedAccount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/accounts/{platform}/{externalId}": {
+            "delete": {
+                "description": "Remove the link of an external platform account from a player of the game",
+                "summary": "Unlink Platform Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "STEAM",
+                            "PSN",
+                            "XBOX",
+                            "DEVICE"
+                        ],
+                        "type": "string",
+                        "description": "Platform of the account",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account ID on the platform",
+                        "name": "externalId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/achievements": {
+            "get": {
+                "description": "List the achievements the player unlocked, in the order they were unlocked",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Player Achievements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.PlayerAchievement"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/activity": {
+            "get": {
+                "description": "List the notable events of a player of the game, newest first, e.g. for a \"recent activity\" panel: the personal\nbests beaten on the MAX and MIN leaderboards, the statistic landmarks reached, the quests completed and the\nleaderboards top 1, 3, 10 and 100 entered. The pages are walked by sending the ` + "`" + `next` + "`" + ` cursor of a page as the\n` + "`" + `after` + "`" + ` of the following one",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Player Activity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Max number of activities",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from the ` + "`" + `next` + "`" + ` of the previous one",
+                        "name": "after",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ActivitiesRes"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/anonymize": {
+            "post": {
+                "description": "Replace the player ID by a random pseudonym on the player's ranks, statistic and quest progression, events and\ndead letters across every storage of the game, including the soft deleted leaderboards, statistics and quests.\nThe data is kept, so the rankings and the statistic and quest aggregates don't change. The anonymization is recorded\non the audit log with the digest of the player ID only and a ` + "`" + `gameblitz.player.anonymization` + "`" + ` event is published.\nResponses already cached keep being served until they expire. With ` + "`" + `dryRun` + "`" + ` set, the records the anonymization\nwould change are counted and returned as a ` + "`" + `PlayerDataPreview` + "`" + ` instead, without changing, recording or publishing anything",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Anonymize Player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only count the records the anonymization would change",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PlayerAnonymizationReceipt"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/ban": {
+            "get": {
+                "description": "Get the player's ban on the game",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Player Ban",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Ban"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Ban a player from the game. The player's ranks are taken out of the rankings right away, keeping their\nvalues to put them back once the ban is lifted, and the player's submissions are rejected until then.\nThe cached ranking pages of the leaderboards the player was ranked on are dropped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Ban Player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ban details",
+                        "name": "BanPlayerReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.BanPlayerReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Ban"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Lift the player's ban on the game, putting the player's ranks back on the rankings",
+                "summary": "Lift Player Ban",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/flag": {
+            "post": {
+                "description": "Flag a player suspected of cheating on the game. The player's ranks are moved to the shadow copies of the\nleaderboards right away, and the player's submissions only reach them until the flag is cleared. The player's\nown rank reads, and the ranking pages viewed by the player, place the shadow ranks where their values would be.\nRanking pages already cached keep showing the player until they expire",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Flag Player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Flag details",
+                        "name": "FlagPlayerReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.FlagPlayerReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ShadowFlag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "Get the player's flag on the game, with their ranks on the shadow copies of the leaderboards",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Player Flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ShadowFlag"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Clear the player's flag, discarding their shadow ranks and putting back the ranks they had when flagged",
+                "summary": "Discard Player Flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/flag/merge": {
+            "post": {
+                "description": "Clear the player's flag, merging their shadow ranks back into the rankings. The shadow ranks are applied\nthrough the leaderboards' aggregation, so the submissions made once the flag is cleared are kept",
+                "summary": "Merge Player Flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/friends": {
+            "post": {
+                "description": "Make two players of the game friends of each other. Adding a friendship that already exists returns it.\nA player can have up to 1000 friends",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add Friend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Friend added",
+                        "name": "AddFriendReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.AddFriendReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Friendship"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "List the friends of a player of the game, oldest friendship first. With the presence enabled, each friend\ncarries whether it's online and when it was last seen",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Friends",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Friendship"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/friends/activity": {
+            "get": {
+                "description": "List the notable events of the friends of a player of the game, newest first, e.g. for a friends feed.\nThe feed holds the activities of the player's current friends, including the ones from before they became friends",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Friends Activity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Max number of activities",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from the ` + "`" + `next` + "`" + ` of the previous one",
+                        "name": "after",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ActivitiesRes"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/friends/{friendId}": {
+            "delete": {
+                "description": "Remove the friendship between two players of the game, for both of them",
+                "summary": "Remove Friend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Friend ID",
+                        "name": "friendId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/levels": {
+            "get": {
+                "description": "List the player's level on each of the game's curves, in the order the curves were created",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Player Levels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.PlayerLevel"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/notifications/live": {
+            "get": {
+                "description": "Upgrade to a WebSocket that pushes the player's statistic landmarks and goals, quest tasks and quests as they are completed",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Watch Player Notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PlayerNotification"
+                        }
+                    },
+                    "426": {
+                        "description": "Upgrade Required",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/overview": {
+            "get": {
+                "description": "Get the player's ranks on the open leaderboards, statistic progressions, quests in progress and achievements\nin a single response, for the game's profile screens. The parts are fetched in parallel and never cached",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Player Overview",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PlayerOverview"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/presence": {
+            "put": {
+                "description": "Mark a player of the game online. The player stays online until the presence timeout goes by without a heartbeat,\nso the game client sends one periodically while the player is playing",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Send Presence Heartbeat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Presence"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "Get whether a player of the game is online and when it was last seen",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Presence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Presence"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/profile": {
+            "get": {
+                "description": "Get the profile of a player of the game",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Player Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Profile"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the display name, avatar, country and metadata of the profile of a player of the game.\nThe fields left out are cleared",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update Player Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profile details",
+                        "name": "ProfileReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ProfileReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Profile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create the profile of a player of the game",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create Player Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profile details",
+                        "name": "ProfileReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ProfileReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Profile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the profile of a player of the game. The rest of the player's data is kept",
+                "summary": "Delete Player Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/segments": {
+            "get": {
+                "description": "List the game's segments the player is a member of, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Player Segments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Segment"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/submission-nonce": {
+            "post": {
+                "description": "Issue a single-use nonce to a player of the game. With the signed submissions enabled, each rank submission\ncarries a nonce alongside the hex encoded HMAC-SHA256, with the game's signing key, of the player ID, leaderboard ID,\nvalue and nonce joined by new lines, e.g. ` + "`" + `alice\\nleaderboard\\n120.5\\nnonce` + "`" + `. The nonce expires after ` + "`" + `SUBMISSION_NONCE_TTL` + "`" + ` seconds",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Issue Submission Nonce",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SubmissionNonce"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/players/{playerId}/titles": {
+            "get": {
+                "description": "List the titles granted to the player, in the order they were granted",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Player Titles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.PlayerTitle"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quarantine": {
+            "get": {
+                "description": "List the game's rank submissions held for review, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Quarantined Submissions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep only the submissions to the leaderboard",
+                        "name": "leaderboardId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Submissions to list, from 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.QuarantinedSubmission"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quarantine/{submissionId}": {
+            "delete": {
+                "description": "Remove a submission held for review without applying it",
+                "summary": "Discard Quarantined Submission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quarantined Submission ID",
+                        "name": "submissionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quarantine/{submissionId}/release": {
+            "post": {
+                "description": "Apply a submission held for review to its leaderboard and remove it from the review list. It's applied\neven if the leaderboard has closed since it was held, and its rank change is notified as usual",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Release Quarantined Submission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quarantined Submission ID",
+                        "name": "submissionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.QuarantinedSubmission"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quests": {
+            "post": {
+                "description": "Create a quest and its tasks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create Quest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New quest config data",
+                        "name": "NewQuestData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateQuestReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Quest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quests/{questId}": {
+            "get": {
+                "description": "Get a quest and its tasks",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Quest By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quest ID",
+                        "name": "questId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Quest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a quest and its tasks",
+                "summary": "Delete Quest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quest ID",
+                        "name": "questId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quests/{questId}/eligibility": {
+            "put": {
+                "description": "Restrict the quest to the members of a segment, replacing its current restriction. The other players can't\nstart the quest, while the ones who already started it keep progressing on it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Set Quest Eligibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quest ID",
+                        "name": "questId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Segment whose members are eligible",
+                        "name": "SetEligibilityReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SetEligibilityReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Eligibility"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "Get the segment the quest is restricted to",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Quest Eligibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quest ID",
+                        "name": "questId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Eligibility"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Open the quest to every player again",
+                "summary": "Remove Quest Eligibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quest ID",
+                        "name": "questId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quests/{questId}/players/{playerId}": {
+            "get": {
+                "description": "Get a player's quest progression",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Player Quest Progression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quest ID",
+                        "name": "questId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PlayerQuestProgression"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Start a player's quest progression",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Start Player Quest Progression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quest ID",
+                        "name": "questId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PlayerQuestProgression"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a player's quest progression",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update Player Quest Progression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quest ID",
+                        "name": "questId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Player data to check",
+                        "name": "ProgressData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.UpdatePlayerQuestProgressionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PlayerQuestProgression"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rewards/grants": {
+            "get": {
+                "description": "List the rewards granted on the game, newest first, as the audit of what was granted to each player",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Reward Grants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the rewards granted to the player",
+                        "name": "playerId",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Max number of grants",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.RewardGrant"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rewards/grants/{grantId}/retry": {
+            "post": {
+                "description": "Queue a failed reward grant to be attempted again, with its attempts reset. The economy service receives it with the same id",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Retry Reward Grant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reward Grant ID",
+                        "name": "grantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.RewardGrant"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rewards/rules": {
+            "get": {
+                "description": "List the game's reward rules, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Reward Rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.RewardRule"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a rule granting rewards to the players that finish a leaderboard on one of its positions, complete a quest or unlock an achievement.\nThe leaderboard, quest or achievement must belong to the game. The rewards are granted by the game's economy service, asynchronously",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create Reward Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New reward rule config data",
+                        "name": "CreateRewardRuleReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateRewardRuleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.RewardRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rewards/rules/{ruleId}": {
+            "delete": {
+                "description": "Delete a reward rule by its id. The rewards it already granted are kept",
+                "summary": "Delete Reward Rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reward Rule ID",
+                        "name": "ruleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/segments": {
+            "post": {
+                "description": "Create a segment, a named cohort of the game's players matched by rules on the country and creation time\nof their profiles and on the value of a statistic used as their level. Every rule set must match, and the\nstatistic must belong to the game. The membership is evaluated on every read, so it follows the players' progress",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create Segment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New segment config data",
+                        "name": "CreateSegmentReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateSegmentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Segment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "List the game's segments, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Segments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Segment"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/segments/{segmentId}": {
+            "get": {
+                "description": "Get a segment by its id",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Segment By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Segment ID",
+                        "name": "segmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Segment"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a segment by its id. The leaderboards and quests restricted to it are open to every player again",
+                "summary": "Delete Segment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Segment ID",
+                        "name": "segmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/segments/{segmentId}/players/{playerId}": {
+            "get": {
+                "description": "Check whether the player is a member of the segment. Players without a profile are never members of the\nsegments with country or install date rules, nor players without progression on the segment's statistic",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Segment Membership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Segment ID",
+                        "name": "segmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SegmentMembership"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/statistics": {
+            "get": {
+                "description": "List the game's statistics, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Statistic"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a statistic. Fails when the game already keeps as many statistics as its quota allows",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create Statistic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New statistic config data",
+                        "name": "NewStatisticData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateStatisticReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Statistic"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/statistics/{statisticId}": {
+            "get": {
+                "description": "Get a statistic by its id",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Statistic By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Statistic ID",
+                        "name": "statisticId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Statistic"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a statistic by its id",
+                "summary": "Delete Statistic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Statistic ID",
+                        "name": "statisticId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/statistics/{statisticId}/players/{playerId}": {
+            "get": {
+                "description": "Get the player's statistic progression",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Player Statistic Progression By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Statistic ID",
+                        "name": "statisticId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PlayerStatisticProgression"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Set or update a player's statistic progression. With the submission rate limit enabled, the player's\nsubmissions over it are rejected until the minute ends",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Upsert Player Statistic Progression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Statistic ID",
+                        "name": "statisticId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Values to update the player statistic progression",
+                        "name": "UpsertPlayerStatisticData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.UpsertPlayerStatisticProgressionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/titles": {
+            "get": {
+                "description": "List the game's titles, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Titles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Title"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a title, granted when a player reaches a landmark of a statistic or ranks on the top positions of a\nleaderboard. The statistic or leaderboard must belong to the game. A title is kept once granted, and\nprogressions made before the title was created don't grant it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create Title",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New title config data",
+                        "name": "CreateTitleReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateTitleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Title"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/titles/{titleId}": {
+            "get": {
+                "description": "Get a title by its id",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Title By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title ID",
+                        "name": "titleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Title"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a title by its id, alongside its grants to the players",
+                "summary": "Delete Title",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title ID",
+                        "name": "titleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks": {
+            "get": {
+                "description": "List the webhooks of the game",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List Webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.Webhook"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register an endpoint that receives the game events. Each delivery is signed with the returned secret",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New webhook data",
+                        "name": "NewWebhookData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateWebhookReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{webhookId}": {
+            "delete": {
+                "description": "Delete a webhook by its id alongside its deliveries",
+                "summary": "Delete Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{webhookId}/enable": {
+            "post": {
+                "description": "Enable a webhook disabled after failing too many times in a row",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Enable Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Webhook"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{webhookId}/filter": {
+            "put": {
+                "description": "Replace the events and resources the webhook subscribes to. Events not matching it are not queued to the webhook",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update Webhook Filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game's JWT authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New webhook filter",
+                        "name": "WebhookFilter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.WebhookFilter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/debug/slo": {
+            "get": {
+                "description": "Report the latency quantiles of each route handled by this instance over the SLO window, and whether they are under their targets",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "SLO Report",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SLOReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "rest.Achievement": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Time the achievement was created",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Achievement details",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "ID of the game responsible for the achievement",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Achievement ID",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Achievement name",
+                    "type": "string"
+                },
+                "trigger": {
+                    "description": "Progression that unlocks the achievement",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.AchievementTrigger"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "description": "Last time the achievement was changed",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.AchievementTrigger": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "description": "Progression that unlocks the achievement",
+                    "type": "string",
+                    "enum": [
+                        "STATISTIC_LANDMARK",
+                        "QUEST_COMPLETED"
+                    ]
+                },
+                "landmark": {
+                    "description": "Landmark of the statistic that unlocks the achievement. Only on ` + "`" + `STATISTIC_LANDMARK` + "`" + `",
+                    "type": "number"
+                },
+                "questId": {
+                    "description": "Quest whose completion unlocks the achievement. Only on ` + "`" + `QUEST_COMPLETED` + "`" + `",
+                    "type": "string"
+                },
+                "statisticId": {
+                    "description": "Statistic whose landmark unlocks the achievement. Only on ` + "`" + `STATISTIC_LANDMARK` + "`" + `",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ActivitiesRes": {
+            "type": "object",
+            "properties": {
+                "activities": {
+                    "description": "Activities of the page, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.Activity"
+                    }
+                },
+                "next": {
+                    "description": "Cursor of the next page, sent as ` + "`" + `after` + "`" + `. Empty on the last page",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.Activity": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Activity ID",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Activity kind",
+                    "type": "string",
+                    "enum": [
+                        "PERSONAL_BEST",
+                        "LANDMARK_REACHED",
+                        "QUEST_COMPLETED",
+                        "RANK_MILESTONE"
+                    ]
+                },
+                "leaderboardId": {
+                    "description": "Leaderboard of the rank. Only on ` + "`" + `PERSONAL_BEST` + "`" + ` and ` + "`" + `RANK_MILESTONE` + "`" + `",
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "description": "Time the player made the progression",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player's ID",
+                    "type": "string"
+                },
+                "previousValue": {
+                    "description": "Best beaten. Only on ` + "`" + `PERSONAL_BEST` + "`" + `",
+                    "type": "number"
+                },
+                "questId": {
+                    "description": "Quest completed. Only on ` + "`" + `QUEST_COMPLETED` + "`" + `",
+                    "type": "string"
+                },
+                "statisticId": {
+                    "description": "Statistic whose landmark was reached. Only on ` + "`" + `LANDMARK_REACHED` + "`" + `",
+                    "type": "string"
+                },
+                "top": {
+                    "description": "Top positions entered: 1, 3, 10 or 100. Only on ` + "`" + `RANK_MILESTONE` + "`" + `",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "New best, landmark reached or rank value on the milestone. Not on ` + "`" + `QUEST_COMPLETED` + "`" + `",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.AddFriendReq": {
+            "type": "object",
+            "properties": {
+                "friendId": {
+                    "description": "Player added as a friend",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.AdjustEntryReq": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Why the entry is adjusted, up to 512 characters",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value the entry is set to",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.Anomaly": {
+            "type": "object",
+            "properties": {
+                "detectedAt": {
+                    "description": "Last time the value was flagged",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Anomaly ID",
+                    "type": "string"
+                },
+                "leaderboardId": {
+                    "description": "Leaderboard the player is ranked on",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "Method that flagged the value, ` + "`" + `Z_SCORE` + "`" + ` or ` + "`" + `IQR` + "`" + `",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player flagged",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Standard deviations from the mean, or interquartile ranges past the quartile, of the value",
+                    "type": "number"
+                },
+                "value": {
+                    "description": "Player's value when flagged",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.AuditEntriesRes": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "description": "Entries of the page, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.AuditEntry"
+                    }
+                },
+                "next": {
+                    "description": "Cursor of the next page, sent as ` + "`" + `after` + "`" + `. Empty on the last page",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "Subject of the credential used on the request",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "Time the entry was recorded",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "ID of the game that performed the request",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Entry ID",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "Request method",
+                    "type": "string"
+                },
+                "payloadDigest": {
+                    "description": "SHA-256 digest of the request payload",
+                    "type": "string"
+                },
+                "requestedAt": {
+                    "description": "Time the request was received",
+                    "type": "string"
+                },
+                "resourceIds": {
+                    "description": "Route parameters that identify the resource affected",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "route": {
+                    "description": "Route pattern that handled the request",
+                    "type": "string"
+                },
+                "statusCode": {
+                    "description": "Response status code",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.Ban": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Time the player was banned",
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "Time the ban is lifted on its own. Not set when it lasts until lifted",
+                    "type": "string"
+                },
+                "hiddenRanks": {
+                    "description": "Ranks taken out of the rankings, put back once the ban is lifted",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.HiddenRank"
+                    }
+                },
+                "playerId": {
+                    "description": "Player banned",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Why the player was banned",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.BanDeviceReq": {
+            "type": "object",
+            "properties": {
+                "durationSeconds": {
+                    "description": "Seconds until the ban is lifted on its own. Kept until lifted when zero",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Why the device is banned, up to 512 characters",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.BanPlayerReq": {
+            "type": "object",
+            "properties": {
+                "durationSeconds": {
+                    "description": "Seconds until the ban is lifted on its own. Kept until lifted when zero",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Why the player is banned, up to 512 characters",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.BulkRemoveEntriesReq": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "Scores submitted from this time on",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Why the entries are removed, up to 512 characters",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Channel the scores were submitted through: ` + "`" + `API` + "`" + `, ` + "`" + `GRPC` + "`" + `, ` + "`" + `MQTT` + "`" + ` or ` + "`" + `INGESTION` + "`" + `",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "Scores submitted before this time",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.CreateAchievementReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Achievement details",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Achievement name",
+                    "type": "string"
+                },
+                "trigger": {
+                    "description": "Progression that unlocks the achievement",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.AchievementTrigger"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.CreateLeaderboardReq": {
+            "type": "object",
+            "properties": {
+                "aggregationMode": {
+                    "description": "Data aggregation mode",
+                    "type": "string",
+                    "enum": [
+                        "INC",
+                        "MAX",
+                        "MIN"
+                    ]
+                },
+                "description": {
+                    "description": "Leaderboard's description",
+                    "type": "string"
+                },
+                "endAt": {
+                    "description": "Time that the leaderboard will be closed for new updates",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Leaderboard's name",
+                    "type": "string"
+                },
+                "ordering": {
+                    "description": "Leaderboard ranking order",
+                    "type": "string",
+                    "enum": [
+                        "ASC",
+                        "DESC"
+                    ]
+                },
+                "startAt": {
+                    "description": "Time that the leaderboard should start working",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.CreateLevelCurveReq": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "description": "XP to go from level 1 to level 2. Only on ` + "`" + `LINEAR` + "`" + ` and ` + "`" + `EXPONENTIAL` + "`" + `",
+                    "type": "number"
+                },
+                "growth": {
+                    "description": "Multiplier of the XP of each level over the previous one, greater than 1. Only on ` + "`" + `EXPONENTIAL` + "`" + `",
+                    "type": "number"
+                },
+                "kind": {
+                    "description": "How the XP of each level is defined",
+                    "type": "string",
+                    "enum": [
+                        "TABLE",
+                        "LINEAR",
+                        "EXPONENTIAL"
+                    ]
+                },
+                "maxLevel": {
+                    "description": "Highest level. Only on ` + "`" + `LINEAR` + "`" + ` and ` + "`" + `EXPONENTIAL` + "`" + `",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Curve name, e.g. the account level or the season pass, up to 64 characters",
+                    "type": "string"
+                },
+                "statisticId": {
+                    "description": "Statistic whose value is the player's XP. Must use the ` + "`" + `SUM` + "`" + ` aggregation mode",
+                    "type": "string"
+                },
+                "thresholds": {
+                    "description": "Total XP to reach each level from level 2 on, increasing. Only on ` + "`" + `TABLE` + "`" + `",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "rest.CreateQuestReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Quest details",
+                    "type": "string"
+                },
+                "endAt": {
+                    "description": "Time that the quest expires for the players that didn't complete it. Optional",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Quest name",
+                    "type": "string"
+                },
+                "tasks": {
+                    "description": "Quest task list",
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "dependsOn": {
+                                "description": "List of array indexes of the tasks that needs to be completed before this one can be started",
+                                "type": "array",
+                                "items": {
+                                    "type": "integer"
+                                }
+                            },
+                            "description": {
+                                "description": "Task details",
+                                "type": "string"
+                            },
+                            "name": {
+                                "description": "Task name",
+                                "type": "string"
+                            },
+                            "requiredForCompletion": {
+                                "description": "Is this task required for the quest completion? Defaults to ` + "`" + `true` + "`" + `",
+                                "type": "boolean"
+                            },
+                            "rule": {
+                                "description": "Task completion logic as JsonLogic. See https://jsonlogic.com/",
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "tasksValidators": {
+                    "description": "Quest task list success validation data",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "rest.CreateRewardRuleReq": {
+            "type": "object",
+            "properties": {
+                "fromPosition": {
+                    "description": "First leaderboard position granted. Only on ` + "`" + `LEADERBOARD` + "`" + `",
+                    "type": "integer"
+                },
+                "rewards": {
+                    "description": "Rewards granted",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.Reward"
+                    }
+                },
+                "source": {
+                    "description": "Progression that grants the rewards",
+                    "type": "string",
+                    "enum": [
+                        "LEADERBOARD",
+                        "QUEST",
+                        "ACHIEVEMENT"
+                    ]
+                },
+                "sourceId": {
+                    "description": "Leaderboard, quest or achievement that grants the rewards",
+                    "type": "string"
+                },
+                "toPosition": {
+                    "description": "Last leaderboard position granted. Only on ` + "`" + `LEADERBOARD` + "`" + `",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.CreateSegmentReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Segment details",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Cohort name, up to 64 characters",
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "Conditions of the members. Every rule set must match",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.SegmentRules"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.CreateStatisticReq": {
+            "type": "object",
+            "properties": {
+                "aggregationMode": {
+                    "description": "Data aggregation mode",
+                    "type": "string",
+                    "enum": [
+                        "SUM",
+                        "SUB",
+                        "MAX",
+                        "MIN"
+                    ]
+                },
+                "description": {
+                    "description": "Statistic details",
+                    "type": "string"
+                },
+                "goal": {
+                    "description": "Goal value. nil means no goal",
+                    "type": "number"
+                },
+                "initialValue": {
+                    "description": "Initial statistic value for players. Defaults to zero on ` + "`" + `'aggregationMode' in ['SUM', 'SUB']` + "`" + `",
+                    "type": "number"
+                },
+                "landmarks": {
+                    "description": "Statistic landmarks",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "name": {
+                    "description": "Statistic name",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.CreateTitleReq": {
+            "type": "object",
+            "properties": {
+                "badgeUrl": {
+                    "description": "Badge image shown alongside the title, as an absolute http or https url. Optional",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Title details",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Title shown alongside the player's name, up to 64 characters",
+                    "type": "string"
+                },
+                "trigger": {
+                    "description": "Progression that grants the title",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.TitleTrigger"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.CreateWebhookReq": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "description": "Events the webhook receives. Every event when omitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.WebhookFilter"
+                        }
+                    ]
+                },
+                "url": {
+                    "description": "Endpoint the events are posted to",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DailySubmissions": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "description": "Day of the submissions, as ` + "`" + `YYYY-MM-DD` + "`" + ` in UTC",
+                    "type": "string"
+                },
+                "scoreSubmissions": {
+                    "description": "Rank and statistic progression updates",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.DailyUsage": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "description": "Day of the usage, as ` + "`" + `YYYY-MM-DD` + "`" + ` in UTC",
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "Requests answered with an error, by its code",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "requests": {
+                    "description": "Requests handled for the game",
+                    "type": "integer"
+                },
+                "scoreSubmissions": {
+                    "description": "Rank and statistic progression updates, also counted as requests",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.DeadLetter": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Times the message processing failed",
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Error returned by the last attempt",
+                    "type": "string"
+                },
+                "failedAt": {
+                    "description": "Time of the last processing failure",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "Game the message belongs to",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Dead letter ID",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind of the message",
+                    "type": "string"
+                },
+                "messageId": {
+                    "description": "ID of the message, assigned by the sender",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Message payload, as received",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DeltaRule": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "What happens to the submissions over the limits",
+                    "type": "string",
+                    "enum": [
+                        "REJECT",
+                        "QUARANTINE"
+                    ]
+                },
+                "leaderboardId": {
+                    "description": "Leaderboard the rule applies to",
+                    "type": "string"
+                },
+                "maxHourlyDelta": {
+                    "description": "Most the player's value can improve within an hour. Zero for no limit",
+                    "type": "number"
+                },
+                "maxSubmissionDelta": {
+                    "description": "Most a single submission can improve the player's value. Zero for no limit",
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "description": "Last time the rule was set",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DeviceBan": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Time the device was banned",
+                    "type": "string"
+                },
+                "deviceHash": {
+                    "description": "Device banned",
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "Time the ban is lifted on its own. Not set when it lasts until lifted",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Why the device was banned",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DeviceUsage": {
+            "type": "object",
+            "properties": {
+                "buildVersion": {
+                    "description": "Client build of the latest score",
+                    "type": "string"
+                },
+                "deviceHash": {
+                    "description": "Device the scores were submitted from",
+                    "type": "string"
+                },
+                "firstSeenAt": {
+                    "description": "Time of the first score",
+                    "type": "string"
+                },
+                "lastSeenAt": {
+                    "description": "Time of the latest score",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player the scores were submitted for",
+                    "type": "string"
+                },
+                "region": {
+                    "description": "Region of the latest score",
+                    "type": "string"
+                },
+                "scores": {
+                    "description": "Scores submitted",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.Eligibility": {
+            "type": "object",
+            "properties": {
+                "resourceId": {
+                    "description": "ID of the leaderboard or the quest",
+                    "type": "string"
+                },
+                "resourceType": {
+                    "description": "Resource restricted",
+                    "type": "string",
+                    "enum": [
+                        "LEADERBOARD",
+                        "QUEST"
+                    ]
+                },
+                "segmentId": {
+                    "description": "Segment whose members are eligible",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Last time the eligibility was set",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ErrorCodeCount": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Error unique code, as on ` + "`" + `ErrorResponse` + "`" + `",
+                    "type": "string"
+                },
+                "count": {
+                    "description": "Requests answered with it",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Error unique code",
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Details about the source of the error",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "description": "Error message",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.FlagPlayerReq": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Why the player is flagged, up to 512 characters",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.Friendship": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Time the players became friends",
+                    "type": "string"
+                },
+                "friendId": {
+                    "description": "Player's friend",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player whose friend it is",
+                    "type": "string"
+                },
+                "presence": {
+                    "description": "Friend's presence. Only on the friends list, left out when the friend wasn't seen within the retention",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.PresenceStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.GameSummary": {
+            "type": "object",
+            "properties": {
+                "activeLeaderboards": {
+                    "description": "Leaderboards accepting scores right now",
+                    "type": "integer"
+                },
+                "activeQuests": {
+                    "description": "Quests not deleted",
+                    "type": "integer"
+                },
+                "activeStatistics": {
+                    "description": "Statistics not deleted",
+                    "type": "integer"
+                },
+                "gameId": {
+                    "description": "Game summarized",
+                    "type": "string"
+                },
+                "submissions": {
+                    "description": "Score submissions of each of the last 7 days, oldest first, including the days without any",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.DailySubmissions"
+                    }
+                },
+                "topErrors": {
+                    "description": "Up to 5 most frequent errors answered to the game over the same days, most frequent first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ErrorCodeCount"
+                    }
+                },
+                "usageTracked": {
+                    "description": "Whether the submissions and errors are tracked. Both are empty when not",
+                    "type": "boolean"
+                }
+            }
+        },
+        "rest.GraphQLError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Error message",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path of the field that failed",
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "rest.GraphQLReq": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "description": "Operation to run, when the query has many",
+                    "type": "string"
+                },
+                "query": {
+                    "description": "GraphQL query",
+                    "type": "string"
+                },
+                "variables": {
+                    "description": "Query variables",
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "rest.GraphQLRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Query result. Null when the query couldn't run",
+                    "type": "object"
+                },
+                "errors": {
+                    "description": "Errors raised while running the query",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.GraphQLError"
+                    }
+                }
+            }
+        },
+        "rest.HiddenRank": {
+            "type": "object",
+            "properties": {
+                "leaderboardId": {
+                    "description": "Leaderboard the player was ranked on",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Player's value when it was hidden",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.Leaderboard": {
+            "type": "object",
+            "properties": {
+                "aggregationMode": {
+                    "description": "Data aggregation mode",
+                    "type": "string",
+                    "enum": [
+                        "INC",
+                        "MAX",
+                        "MIN"
+                    ]
+                },
+                "createdAt": {
+                    "description": "Time that the leaderboard was created",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Leaderboard's description",
+                    "type": "string"
+                },
+                "endAt": {
+                    "description": "Time that the leaderboard will be closed for new updates",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "The ID from the game that is responsible for the leaderboard",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Leaderboard's ID",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Leaderboard's name",
+                    "type": "string"
+                },
+                "ordering": {
+                    "description": "Leaderboard ranking order",
+                    "type": "string",
+                    "enum": [
+                        "ASC",
+                        "DESC"
+                    ]
+                },
+                "startAt": {
+                    "description": "Time that the leaderboard should start working",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Last time that the leaderboard info was updated",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.LevelCurve": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "description": "XP to go from level 1 to level 2. Only on ` + "`" + `LINEAR` + "`" + ` and ` + "`" + `EXPONENTIAL` + "`" + `",
+                    "type": "number"
+                },
+                "createdAt": {
+                    "description": "Time the curve was created",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "ID of the game responsible for the curve",
+                    "type": "string"
+                },
+                "growth": {
+                    "description": "Multiplier of the XP of each level over the previous one. Only on ` + "`" + `EXPONENTIAL` + "`" + `",
+                    "type": "number"
+                },
+                "id": {
+                    "description": "Curve ID",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "How the XP of each level is defined",
+                    "type": "string",
+                    "enum": [
+                        "TABLE",
+                        "LINEAR",
+                        "EXPONENTIAL"
+                    ]
+                },
+                "maxLevel": {
+                    "description": "Highest level",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Curve name",
+                    "type": "string"
+                },
+                "statisticId": {
+                    "description": "Statistic whose value is the player's XP",
+                    "type": "string"
+                },
+                "thresholds": {
+                    "description": "Total XP to reach each level from level 2 on. Only on ` + "`" + `TABLE` + "`" + `",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "rest.LinkAccountReq": {
+            "type": "object",
+            "properties": {
+                "externalId": {
+                    "description": "Account ID on the platform, up to 128 characters",
+                    "type": "string"
+                },
+                "platform": {
+                    "description": "Platform of the account, one of ` + "`" + `STEAM` + "`" + `, ` + "`" + `PSN` + "`" + `, ` + "`" + `XBOX` + "`" + ` or ` + "`" + `DEVICE` + "`" + `, case insensitive",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.LinkedAccount": {
+            "type": "object",
+            "properties": {
+                "externalId": {
+                    "description": "Account ID on the platform",
+                    "type": "string"
+                },
+                "linkedAt": {
+                    "description": "Time the account was linked",
+                    "type": "string"
+                },
+                "platform": {
+                    "description": "Platform of the account, one of ` + "`" + `STEAM` + "`" + `, ` + "`" + `PSN` + "`" + `, ` + "`" + `XBOX` + "`" + ` or ` + "`" + `DEVICE` + "`" + `",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player the account is linked to",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.LiveRankingMessage": {
+            "type": "object",
+            "properties": {
+                "player": {
+                    "description": "Player's rank. Null when it didn't change or the player isn't ranked yet",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Rank"
+                        }
+                    ]
+                },
+                "top": {
+                    "description": "Leaderboard top. On updates, null when it didn't change",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.Rank"
+                    }
+                },
+                "type": {
+                    "description": "Message type. ` + "`" + `snapshot` + "`" + ` for the first one, ` + "`" + `update` + "`" + ` afterwards",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.LogLevel": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "description": "Minimum level logged",
+                    "type": "string",
+                    "enum": [
+                        "debug",
+                        "info",
+                        "warn",
+                        "error"
+                    ]
+                }
+            }
+        },
+        "rest.MergePlayersReq": {
+            "type": "object",
+            "properties": {
+                "targetPlayerId": {
+                    "description": "Player kept, receiving the data of the player merged",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ModerationAction": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Time the action was taken",
+                    "type": "string"
+                },
+                "entries": {
+                    "description": "Entries removed. Only set on the bulk removals",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ModerationRemovedEntry"
+                    }
+                },
+                "from": {
+                    "description": "Start of the window whose entries were removed or scores reverted. Only set on the bulk removals and the rollbacks",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Action ID, the ID of the audit entry recording it",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "` + "`" + `ADJUST` + "`" + `, ` + "`" + `REMOVE` + "`" + `, ` + "`" + `BULK_REMOVE` + "`" + ` or ` + "`" + `ROLLBACK` + "`" + `",
+                    "type": "string"
+                },
+                "leaderboardId": {
+                    "description": "Leaderboard of the entry adjusted or removed, or of the scores reverted",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player of the entry adjusted or removed, or whose scores were reverted",
+                    "type": "string"
+                },
+                "previous": {
+                    "description": "Entry's value before the action. Not set when the player wasn't ranked",
+                    "type": "number"
+                },
+                "reason": {
+                    "description": "Why the action was taken",
+                    "type": "string"
+                },
+                "recomputed": {
+                    "description": "Entries recomputed. Only set on the rollbacks",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ModerationRecomputedEntry"
+                    }
+                },
+                "scores": {
+                    "description": "Scores reverted. Only set on the rollbacks",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Channel whose entries were removed. Only set on the bulk removals",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "End of the window whose entries were removed or scores reverted. Only set on the bulk removals and the rollbacks",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value the entry was set to. Only set on the adjustments",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.ModerationEntry": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "Actions taken on the entry, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ModerationAction"
+                    }
+                },
+                "rank": {
+                    "description": "Player's rank. Null when the player isn't ranked",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Rank"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.ModerationRecomputedEntry": {
+            "type": "object",
+            "properties": {
+                "playerId": {
+                    "description": "Player of the entry",
+                    "type": "string"
+                },
+                "previous": {
+                    "description": "Entry's value before the rollback. Not set when the player wasn't ranked",
+                    "type": "number"
+                },
+                "value": {
+                    "description": "Entry's value rebuilt from the scores kept. Not set when the entry was removed",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.ModerationRemovedEntry": {
+            "type": "object",
+            "properties": {
+                "leaderboardId": {
+                    "description": "Leaderboard the entry was on",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player of the entry",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Entry's value when it was removed",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.OverviewRank": {
+            "type": "object",
+            "properties": {
+                "leaderboard": {
+                    "description": "Leaderboard the player is ranked on",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Leaderboard"
+                        }
+                    ]
+                },
+                "rank": {
+                    "description": "Player's rank",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Rank"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.OverviewStatistic": {
+            "type": "object",
+            "properties": {
+                "progression": {
+                    "description": "Player's progression",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.PlayerStatisticProgression"
+                        }
+                    ]
+                },
+                "statistic": {
+                    "description": "Statistic the player progressed on",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Statistic"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.PlayerAchievement": {
+            "type": "object",
+            "properties": {
+                "achievement": {
+                    "description": "Achievement unlocked",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Achievement"
+                        }
+                    ]
+                },
+                "playerId": {
+                    "description": "Player that unlocked it",
+                    "type": "string"
+                },
+                "unlockedAt": {
+                    "description": "Time the player made the progression that unlocked it",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PlayerAnonymization": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Kind of the data anonymized: ` + "`" + `RANKS` + "`" + `, ` + "`" + `STATISTIC_PROGRESSION` + "`" + `, ` + "`" + `QUEST_PROGRESSION` + "`" + `, ` + "`" + `EVENT_HISTORY` + "`" + `, ` + "`" + `DEAD_LETTERS` + "`" + `, ` + "`" + `SCORE_HISTORY` + "`" + `, ` + "`" + `PROFILE` + "`" + `, ` + "`" + `ACHIEVEMENTS` + "`" + `, ` + "`" + `REWARD_GRANTS` + "`" + `, ` + "`" + `LINKED_ACCOUNTS` + "`" + `, ` + "`" + `BANS` + "`" + `, ` + "`" + `TITLES` + "`" + `, ` + "`" + `FRIENDSHIPS` + "`" + `, ` + "`" + `PRESENCE` + "`" + ` or ` + "`" + `ACTIVITY` + "`" + `",
+                    "type": "string"
+                },
+                "records": {
+                    "description": "Records now referring to the pseudonym",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.PlayerAnonymizationReceipt": {
+            "type": "object",
+            "properties": {
+                "anonymizations": {
+                    "description": "Data anonymized on each storage",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PlayerAnonymization"
+                    }
+                },
+                "anonymizedAt": {
+                    "description": "Time the anonymization completed",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "Game the player's data belonged to",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Receipt ID, the ID of the audit entry recording the anonymization",
+                    "type": "string"
+                },
+                "playerDigest": {
+                    "description": "Hex encoded SHA-256 digest of ` + "`" + `\u003cgame id\u003e/\u003cplayer id\u003e` + "`" + `",
+                    "type": "string"
+                },
+                "pseudonym": {
+                    "description": "Player ID that replaced the player's one on every storage",
+                    "type": "string"
+                },
+                "requestedBy": {
+                    "description": "Subject of the credential that requested the anonymization",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PlayerErasure": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Kind of the data erased: ` + "`" + `RANKS` + "`" + `, ` + "`" + `STATISTIC_PROGRESSION` + "`" + `, ` + "`" + `QUEST_PROGRESSION` + "`" + `, ` + "`" + `EVENT_HISTORY` + "`" + `, ` + "`" + `DEAD_LETTERS` + "`" + `, ` + "`" + `SCORE_HISTORY` + "`" + `, ` + "`" + `PROFILE` + "`" + `, ` + "`" + `ACHIEVEMENTS` + "`" + `, ` + "`" + `REWARD_GRANTS` + "`" + `, ` + "`" + `LINKED_ACCOUNTS` + "`" + `, ` + "`" + `BANS` + "`" + `, ` + "`" + `TITLES` + "`" + `, ` + "`" + `FRIENDSHIPS` + "`" + `, ` + "`" + `PRESENCE` + "`" + ` or ` + "`" + `ACTIVITY` + "`" + `",
+                    "type": "string"
+                },
+                "records": {
+                    "description": "Records removed",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.PlayerErasureReceipt": {
+            "type": "object",
+            "properties": {
+                "erasedAt": {
+                    "description": "Time the erasure completed",
+                    "type": "string"
+                },
+                "erasures": {
+                    "description": "Data removed from each storage",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PlayerErasure"
+                    }
+                },
+                "gameId": {
+                    "description": "Game the player's data belonged to",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Receipt ID, the ID of the audit entry recording the erasure",
+                    "type": "string"
+                },
+                "playerDigest": {
+                    "description": "Hex encoded SHA-256 digest of ` + "`" + `\u003cgame id\u003e/\u003cplayer id\u003e` + "`" + `",
+                    "type": "string"
+                },
+                "requestedBy": {
+                    "description": "Subject of the credential that requested the erasure",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PlayerLevel": {
+            "type": "object",
+            "properties": {
+                "curveId": {
+                    "description": "Curve the level is on",
+                    "type": "string"
+                },
+                "level": {
+                    "description": "Player's level, starting at 1",
+                    "type": "integer"
+                },
+                "levelXp": {
+                    "description": "Total XP to reach the player's level",
+                    "type": "number"
+                },
+                "nextLevelXp": {
+                    "description": "Total XP to reach the next level. Missing on the max level",
+                    "type": "number"
+                },
+                "playerId": {
+                    "description": "Player's ID",
+                    "type": "string"
+                },
+                "xp": {
+                    "description": "Player's XP",
+                    "type": "number"
+                },
+                "xpToNextLevel": {
+                    "description": "XP the player still needs to reach the next level. Missing on the max level",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.PlayerMerge": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Kind of the data merged: ` + "`" + `RANKS` + "`" + `, ` + "`" + `STATISTIC_PROGRESSION` + "`" + ` or ` + "`" + `QUEST_PROGRESSION` + "`" + `",
+                    "type": "string"
+                },
+                "records": {
+                    "description": "Records of the player merged combined into the target player's ones",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.PlayerMergeReceipt": {
+            "type": "object",
+            "properties": {
+                "gameId": {
+                    "description": "Game both players belong to",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Receipt ID, the ID of the audit entry recording the merge",
+                    "type": "string"
+                },
+                "mergedAt": {
+                    "description": "Time the merge completed",
+                    "type": "string"
+                },
+                "merges": {
+                    "description": "Data combined of each kind",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PlayerMerge"
+                    }
+                },
+                "sourcePlayerId": {
+                    "description": "Player merged, left without ranks, statistic nor quest progression",
+                    "type": "string"
+                },
+                "targetPlayerId": {
+                    "description": "Player kept, holding the data of both",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PlayerNotification": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "description": "Time the player completed it",
+                    "type": "string"
+                },
+                "questId": {
+                    "description": "Quest ID. Only set on the quest notifications",
+                    "type": "string"
+                },
+                "statisticId": {
+                    "description": "Statistic ID. Only set on the statistic notifications",
+                    "type": "string"
+                },
+                "taskId": {
+                    "description": "Task completed. Only set on ` + "`" + `quest_task_completed` + "`" + `",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Completion kind: ` + "`" + `statistic_landmark_completed` + "`" + `, ` + "`" + `statistic_goal_completed` + "`" + `, ` + "`" + `quest_task_completed` + "`" + ` or ` + "`" + `quest_completed` + "`" + `",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Landmark or goal value reached. Only set on the statistic notifications",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.PlayerOverview": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "description": "Achievements unlocked, in the order they were unlocked. Only with the achievements enabled",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PlayerAchievement"
+                    }
+                },
+                "activeQuests": {
+                    "description": "Quests started and not completed yet",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PlayerQuestProgression"
+                    }
+                },
+                "gameId": {
+                    "description": "ID of the game the player belongs to",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player's ID",
+                    "type": "string"
+                },
+                "ranks": {
+                    "description": "Ranks on the open leaderboards",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.OverviewRank"
+                    }
+                },
+                "statistics": {
+                    "description": "Progressions on the statistics",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.OverviewStatistic"
+                    }
+                }
+            }
+        },
+        "rest.PlayerQuestProgression": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "description": "Time the player completed the quest",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player's ID",
+                    "type": "string"
+                },
+                "quest": {
+                    "description": "Quest Config Data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Quest"
+                        }
+                    ]
+                },
+                "startedAt": {
+                    "description": "Time the player started the quest",
+                    "type": "string"
+                },
+                "tasksProgression": {
+                    "description": "Tasks progression",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PlayerQuestTaskProgression"
+                    }
+                },
+                "updatedAt": {
+                    "description": "Last time the player updated the quest progression",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PlayerQuestTaskProgression": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "description": "Time the player completed the task",
+                    "type": "string"
+                },
+                "startedAt": {
+                    "description": "Time the player started the task",
+                    "type": "string"
+                },
+                "task": {
+                    "description": "Task config data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Task"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "description": "Last time the player updated the task progression",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PlayerStatisticProgression": {
+            "type": "object",
+            "properties": {
+                "currentValue": {
+                    "description": "Current progression value",
+                    "type": "number"
+                },
+                "goalCompleted": {
+                    "description": "Has the player reached the goal?",
+                    "type": "boolean"
+                },
+                "goalCompletedAt": {
+                    "description": "Time the player reached the goal",
+                    "type": "string"
+                },
+                "goalValue": {
+                    "description": "Statistic's goal",
+                    "type": "number"
+                },
+                "landmarks": {
+                    "description": "Landmarks player progression",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PlayerStatisticProgressionLandmark"
+                    }
+                },
+                "playerId": {
+                    "description": "Player's ID",
+                    "type": "string"
+                },
+                "startedAt": {
+                    "description": "Time the player started the progression for the given statistic",
+                    "type": "string"
+                },
+                "statisticId": {
+                    "description": "Statistic ID",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Last time the player updated it's statistic progress",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PlayerStatisticProgressionLandmark": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "description": "Has the player reached the landmark?",
+                    "type": "boolean"
+                },
+                "completedAt": {
+                    "description": "Time the player reached the landmark",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Landmark value",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.PlayerTitle": {
+            "type": "object",
+            "properties": {
+                "grantedAt": {
+                    "description": "Time the player made the progression that granted it",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player the title was granted to",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title granted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Title"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.Presence": {
+            "type": "object",
+            "properties": {
+                "lastSeenAt": {
+                    "description": "Time of the player's last heartbeat. Left out when the player wasn't seen within the retention",
+                    "type": "string"
+                },
+                "online": {
+                    "description": "Whether the player sent a heartbeat within the presence timeout",
+                    "type": "boolean"
+                },
+                "playerId": {
+                    "description": "Player's ID",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PresenceStatus": {
+            "type": "object",
+            "properties": {
+                "lastSeenAt": {
+                    "description": "Time of the player's last heartbeat",
+                    "type": "string"
+                },
+                "online": {
+                    "description": "Whether the player sent a heartbeat within the presence timeout",
+                    "type": "boolean"
+                }
+            }
+        },
+        "rest.Profile": {
+            "type": "object",
+            "properties": {
+                "avatarUrl": {
+                    "description": "Image shown alongside the display name. Empty when none",
+                    "type": "string"
                 },
-                "description": {
-                    "description": "Leaderboard's description",
+                "country": {
+                    "description": "ISO 3166-1 alpha-2 code of the player's country. Empty when unknown",
                     "type": "string"
                 },
-                "endAt": {
-                    "description": "Time that the leaderboard will be closed for new updates",
+                "createdAt": {
+                    "description": "Time the profile was created",
                     "type": "string"
                 },
-                "name": {
-                    "description": "Leaderboard's name",
+                "displayName": {
+                    "description": "Name shown to the other players",
                     "type": "string"
                 },
-                "ordering": {
-                    "description": "Leaderboard ranking order",
-                    "type": "string",
-                    "enum": [
-                        "ASC",
-                        "DESC"
-                    ]
+                "metadata": {
+                    "description": "Game defined details of the player",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "startAt": {
-                    "description": "Time that the leaderboard should start working",
+                "playerId": {
+                    "description": "Player's ID",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Last time the profile was changed",
                     "type": "string"
                 }
             }
         },
-        "rest.CreateQuestReq": {
+        "rest.ProfileReq": {
+            "type": "object",
+            "properties": {
+                "avatarUrl": {
+                    "description": "Absolute http or https url of the image shown alongside the display name. Optional",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "ISO 3166-1 alpha-2 code of the player's country, case insensitive. Optional",
+                    "type": "string"
+                },
+                "displayName": {
+                    "description": "Name shown to the other players, up to 64 characters",
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Game defined details of the player, up to 32 keys of up to 64 characters and values of up to 256 characters. Optional",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "rest.ProofRule": {
+            "type": "object",
+            "properties": {
+                "leaderboardId": {
+                    "description": "Leaderboard the rule applies to",
+                    "type": "string"
+                },
+                "topN": {
+                    "description": "Top positions requiring a proof",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "Last time the rule was set",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PurgeReport": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "description": "Records purged of each kind of entity, including the ones without any",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "deletedBefore": {
+                    "description": "Records soft deleted before it were purged",
+                    "type": "string"
+                },
+                "dryRun": {
+                    "description": "Whether the records were only listed, without removing nor recording them on the audit log",
+                    "type": "boolean"
+                },
+                "gameId": {
+                    "description": "Game purged",
+                    "type": "string"
+                },
+                "records": {
+                    "description": "Records purged, or the ones that would be on a dry run",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PurgedRecord"
+                    }
+                }
+            }
+        },
+        "rest.PurgedRecord": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "description": "Time the entity was soft deleted",
+                    "type": "string"
+                },
+                "entity": {
+                    "description": "Kind of the entity: ` + "`" + `LEADERBOARD` + "`" + `, ` + "`" + `STATISTIC` + "`" + ` or ` + "`" + `QUEST` + "`" + `",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the entity",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.QuarantinedSubmission": {
+            "type": "object",
+            "properties": {
+                "heldAt": {
+                    "description": "Time the submission was held",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Submission ID",
+                    "type": "string"
+                },
+                "leaderboardId": {
+                    "description": "Leaderboard the value was submitted to",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player who submitted the value",
+                    "type": "string"
+                },
+                "proof": {
+                    "description": "Proof reference attached to the submission, e.g. a replay ID or URL",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Why the submission was held, e.g. ` + "`" + `MAX_SUBMISSION_DELTA` + "`" + ` or ` + "`" + `PROOF_REQUIRED` + "`" + `",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value submitted",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.Quest": {
             "type": "object",
             "properties": {
+                "createdAt": {
+                    "description": "Time that the quest was created",
+                    "type": "string"
+                },
                 "description": {
                     "description": "Quest details",
                     "type": "string"
                 },
+                "endAt": {
+                    "description": "Time that the quest expires for the players that didn't complete it",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "ID of the game responsible for the quest",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Quest ID",
+                    "type": "string"
+                },
                 "name": {
                     "description": "Quest name",
                     "type": "string"
@@ -987,330 +9160,619 @@ const docTemplate = `{
                     "description": "Quest task list",
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "dependsOn": {
-                                "description": "List of array indexes of the tasks that needs to be completed before this one can be started",
-                                "type": "array",
-                                "items": {
-                                    "type": "integer"
-                                }
-                            },
-                            "description": {
-                                "description": "Task details",
-                                "type": "string"
-                            },
-                            "name": {
-                                "description": "Task name",
-                                "type": "string"
-                            },
-                            "requiredForCompletion": {
-                                "description": "Is this task required for the quest completion? Defaults to ` + "`" + `true` + "`" + `",
-                                "type": "boolean"
-                            },
-                            "rule": {
-                                "description": "Task completion logic as JsonLogic. See https://jsonlogic.com/",
-                                "type": "string"
-                            }
+                        "$ref": "#/definitions/rest.Task"
+                    }
+                },
+                "updatedAt": {
+                    "description": "Last time that the quest was updated",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.Quota": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "description": "Whether the game has no quota of its own, so the default one applies",
+                    "type": "boolean"
+                },
+                "gameId": {
+                    "description": "Game the quota belongs to",
+                    "type": "string"
+                },
+                "maxLeaderboards": {
+                    "description": "Leaderboards the game can keep, not counting the deleted ones. Unlimited when zero",
+                    "type": "integer"
+                },
+                "maxStatistics": {
+                    "description": "Statistics the game can keep, not counting the deleted ones. Unlimited when zero",
+                    "type": "integer"
+                },
+                "requestsPerMinute": {
+                    "description": "Requests the game can send per minute, on each instance of the API. Unlimited when zero",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "Last time the quota was set. Null for the default one",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.QuotaStatus": {
+            "type": "object",
+            "properties": {
+                "leaderboards": {
+                    "description": "Leaderboards the game keeps, not counting the deleted ones",
+                    "type": "integer"
+                },
+                "quota": {
+                    "description": "Quota of the game",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Quota"
+                        }
+                    ]
+                },
+                "statistics": {
+                    "description": "Statistics the game keeps, not counting the deleted ones",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.Rank": {
+            "type": "object",
+            "properties": {
+                "pending": {
+                    "description": "Whether the rank is a submission waiting for a moderator's approval. Only on the ranking pages merging them",
+                    "type": "boolean"
+                },
+                "playerId": {
+                    "description": "Player's ID",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Player ranking position",
+                    "type": "integer"
+                },
+                "presence": {
+                    "description": "Player's presence. Only on the ranking pages, left out when the player wasn't seen within the retention",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.PresenceStatus"
+                        }
+                    ]
+                },
+                "profile": {
+                    "description": "Player's profile. Only on the ranking pages, left out when the player has none",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.RankProfile"
                         }
+                    ]
+                },
+                "titles": {
+                    "description": "Titles granted to the player, oldest first. Only on the ranking pages, left out when the player has none",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.RankTitle"
                     }
                 },
-                "tasksValidators": {
-                    "description": "Quest task list success validation data",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "value": {
+                    "description": "Player rank value",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.RankPollRes": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "description": "Whether the rank differs from the cursor's. False when the poll timed out",
+                    "type": "boolean"
+                },
+                "cursor": {
+                    "description": "Cursor to send on the next poll",
+                    "type": "string"
+                },
+                "positionDelta": {
+                    "description": "Positions climbed since the cursor, negative when the player dropped",
+                    "type": "integer"
+                },
+                "rank": {
+                    "description": "Player's current rank. Null when the player isn't ranked yet",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Rank"
+                        }
+                    ]
+                },
+                "valueDelta": {
+                    "description": "Value gained since the cursor",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.RankProfile": {
+            "type": "object",
+            "properties": {
+                "avatarUrl": {
+                    "description": "Image shown alongside the display name. Empty when none",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "ISO 3166-1 alpha-2 code of the player's country. Empty when unknown",
+                    "type": "string"
+                },
+                "displayName": {
+                    "description": "Name shown to the other players",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.RankTitle": {
+            "type": "object",
+            "properties": {
+                "badgeUrl": {
+                    "description": "Badge image shown alongside the title",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Title ID",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Title shown alongside the player's name",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ReplayEventsReq": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "Replay the events recorded from this time on",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "Game the events belong to",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "Replay the events recorded before this time",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ReplayEventsRes": {
+            "type": "object",
+            "properties": {
+                "replayed": {
+                    "description": "Number of events published again",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.Reward": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "description": "Item ID, as known by the game's economy, e.g. a currency or a skin",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "Units of the item granted",
+                    "type": "integer"
                 }
             }
         },
-        "rest.CreateStatisticReq": {
+        "rest.RewardGrant": {
             "type": "object",
             "properties": {
-                "aggregationMode": {
-                    "description": "Data aggregation mode",
-                    "type": "string",
-                    "enum": [
-                        "SUM",
-                        "SUB",
-                        "MAX",
-                        "MIN"
-                    ]
+                "attempts": {
+                    "description": "Attempts made since the grant was queued or last retried",
+                    "type": "integer"
                 },
-                "description": {
-                    "description": "Statistic details",
+                "createdAt": {
+                    "description": "Time the grant was queued",
                     "type": "string"
                 },
-                "goal": {
-                    "description": "Goal value. nil means no goal",
-                    "type": "number"
+                "gameId": {
+                    "description": "ID of the game responsible for the grant",
+                    "type": "string"
                 },
-                "initialValue": {
-                    "description": "Initial statistic value for players. Defaults to zero on ` + "`" + `'aggregationMode' in ['SUM', 'SUB']` + "`" + `",
-                    "type": "number"
+                "grantedAt": {
+                    "description": "Time the economy service accepted the grant",
+                    "type": "string"
                 },
-                "landmarks": {
-                    "description": "Statistic landmarks",
-                    "type": "array",
-                    "items": {
-                        "type": "number"
-                    }
+                "id": {
+                    "description": "Grant ID, sent to the economy service as the idempotency key",
+                    "type": "string"
                 },
-                "name": {
-                    "description": "Statistic name",
+                "lastError": {
+                    "description": "Error of the last failed attempt",
                     "type": "string"
-                }
-            }
-        },
-        "rest.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "description": "Error unique code",
+                },
+                "nextAttemptAt": {
+                    "description": "Time the grant is due to be attempted, while pending",
                     "type": "string"
                 },
-                "details": {
-                    "description": "Details about the source of the error",
+                "playerId": {
+                    "description": "Player the rewards are granted to",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Final position on the leaderboard. Only on ` + "`" + `LEADERBOARD` + "`" + `",
+                    "type": "integer"
+                },
+                "rewards": {
+                    "description": "Rewards granted",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/rest.Reward"
                     }
                 },
-                "message": {
-                    "description": "Error message",
+                "ruleId": {
+                    "description": "Rule that granted the rewards",
                     "type": "string"
-                }
-            }
-        },
-        "rest.Leaderboard": {
-            "type": "object",
-            "properties": {
-                "aggregationMode": {
-                    "description": "Data aggregation mode",
+                },
+                "source": {
+                    "description": "Progression that granted the rewards",
                     "type": "string",
                     "enum": [
-                        "INC",
-                        "MAX",
-                        "MIN"
+                        "LEADERBOARD",
+                        "QUEST",
+                        "ACHIEVEMENT"
                     ]
                 },
-                "createdAt": {
-                    "description": "Time that the leaderboard was created",
+                "sourceId": {
+                    "description": "Leaderboard, quest or achievement that granted the rewards",
                     "type": "string"
                 },
-                "description": {
-                    "description": "Leaderboard's description",
-                    "type": "string"
+                "status": {
+                    "description": "Grant status",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "GRANTED",
+                        "FAILED"
+                    ]
                 },
-                "endAt": {
-                    "description": "Time that the leaderboard will be closed for new updates",
+                "updatedAt": {
+                    "description": "Last time the grant was attempted or retried",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.RewardRule": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Time the rule was created",
                     "type": "string"
                 },
+                "fromPosition": {
+                    "description": "First leaderboard position granted. Only on ` + "`" + `LEADERBOARD` + "`" + `",
+                    "type": "integer"
+                },
                 "gameId": {
-                    "description": "The ID from the game that is responsible for the leaderboard",
+                    "description": "ID of the game responsible for the rule",
                     "type": "string"
                 },
                 "id": {
-                    "description": "Leaderboard's ID",
+                    "description": "Rule ID",
                     "type": "string"
                 },
-                "name": {
-                    "description": "Leaderboard's name",
-                    "type": "string"
+                "rewards": {
+                    "description": "Rewards granted",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.Reward"
+                    }
                 },
-                "ordering": {
-                    "description": "Leaderboard ranking order",
+                "source": {
+                    "description": "Progression that grants the rewards",
                     "type": "string",
                     "enum": [
-                        "ASC",
-                        "DESC"
+                        "LEADERBOARD",
+                        "QUEST",
+                        "ACHIEVEMENT"
                     ]
                 },
-                "startAt": {
-                    "description": "Time that the leaderboard should start working",
+                "sourceId": {
+                    "description": "Leaderboard, quest or achievement that grants the rewards",
                     "type": "string"
                 },
-                "updatedAt": {
-                    "description": "Last time that the leaderboard info was updated",
-                    "type": "string"
+                "toPosition": {
+                    "description": "Last leaderboard position granted. Only on ` + "`" + `LEADERBOARD` + "`" + `",
+                    "type": "integer"
                 }
             }
         },
-        "rest.PlayerQuestProgression": {
+        "rest.RollbackScoresReq": {
             "type": "object",
             "properties": {
-                "completedAt": {
-                    "description": "Time the player completed the quest",
+                "from": {
+                    "description": "Scores submitted from this time on",
                     "type": "string"
                 },
                 "playerId": {
-                    "description": "Player's ID",
+                    "description": "Player whose scores are reverted. Every player's when empty",
                     "type": "string"
                 },
-                "quest": {
-                    "description": "Quest Config Data",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/rest.Quest"
-                        }
-                    ]
+                "reason": {
+                    "description": "Why the scores are reverted, up to 512 characters",
+                    "type": "string"
                 },
-                "startedAt": {
-                    "description": "Time the player started the quest",
+                "to": {
+                    "description": "Scores submitted before this time",
                     "type": "string"
+                }
+            }
+        },
+        "rest.SLOReport": {
+            "type": "object",
+            "properties": {
+                "met": {
+                    "description": "Whether every route is under its target",
+                    "type": "boolean"
                 },
-                "tasksProgression": {
-                    "description": "Tasks progression",
+                "routes": {
+                    "description": "Routes ordered by pattern and method",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/rest.PlayerQuestTaskProgression"
+                        "$ref": "#/definitions/rest.SLORouteLatency"
                     }
                 },
-                "updatedAt": {
-                    "description": "Last time the player updated the quest progression",
+                "windowSeconds": {
+                    "description": "Period the quantiles are computed over, in seconds",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.SLORouteLatency": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Requests handled since the instance started",
+                    "type": "integer"
+                },
+                "met": {
+                    "description": "Whether the 99th percentile is under the target. Always true without a target",
+                    "type": "boolean"
+                },
+                "method": {
+                    "description": "Request method",
+                    "type": "string"
+                },
+                "p50Ms": {
+                    "description": "Median latency over the window, in milliseconds",
+                    "type": "number"
+                },
+                "p95Ms": {
+                    "description": "95th percentile latency over the window, in milliseconds",
+                    "type": "number"
+                },
+                "p99Ms": {
+                    "description": "99th percentile latency over the window, in milliseconds",
+                    "type": "number"
+                },
+                "route": {
+                    "description": "Route pattern, e.g. ` + "`" + `/api/v1/leaderboards/:leaderboardId` + "`" + `",
                     "type": "string"
+                },
+                "targetMs": {
+                    "description": "Latency the 99th percentile must stay under, in milliseconds. Zero without a target",
+                    "type": "number"
                 }
             }
         },
-        "rest.PlayerQuestTaskProgression": {
+        "rest.Segment": {
             "type": "object",
             "properties": {
-                "completedAt": {
-                    "description": "Time the player completed the task",
+                "createdAt": {
+                    "description": "Time the segment was created",
                     "type": "string"
                 },
-                "startedAt": {
-                    "description": "Time the player started the task",
+                "description": {
+                    "description": "Segment details",
                     "type": "string"
                 },
-                "task": {
-                    "description": "Task config data",
+                "gameId": {
+                    "description": "ID of the game responsible for the segment",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Segment ID",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Cohort name",
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "Conditions of the members",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/rest.Task"
+                            "$ref": "#/definitions/rest.SegmentRules"
                         }
                     ]
-                },
-                "updatedAt": {
-                    "description": "Last time the player updated the task progression",
-                    "type": "string"
                 }
             }
         },
-        "rest.PlayerStatisticProgression": {
+        "rest.SegmentMembership": {
             "type": "object",
             "properties": {
-                "currentValue": {
-                    "description": "Current progression value",
-                    "type": "number"
-                },
-                "goalCompleted": {
-                    "description": "Has the player reached the goal?",
+                "member": {
+                    "description": "Whether the player matches the segment's rules",
                     "type": "boolean"
                 },
-                "goalCompletedAt": {
-                    "description": "Time the player reached the goal",
+                "playerId": {
+                    "description": "Player ID",
                     "type": "string"
                 },
-                "goalValue": {
-                    "description": "Statistic's goal",
-                    "type": "number"
-                },
-                "landmarks": {
-                    "description": "Landmarks player progression",
+                "segmentId": {
+                    "description": "Segment ID",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.SegmentRules": {
+            "type": "object",
+            "properties": {
+                "countries": {
+                    "description": "ISO 3166-1 alpha-2 codes of the countries of the player's profile, any of them",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/rest.PlayerStatisticProgressionLandmark"
+                        "type": "string"
                     }
                 },
-                "playerId": {
-                    "description": "Player's ID",
+                "installedAfter": {
+                    "description": "Players whose profile was created at or after it",
                     "type": "string"
                 },
-                "startedAt": {
-                    "description": "Time the player started the progression for the given statistic",
+                "installedBefore": {
+                    "description": "Players whose profile was created before it",
                     "type": "string"
                 },
+                "maxValue": {
+                    "description": "Highest value of the statistic, inclusive",
+                    "type": "number"
+                },
+                "minValue": {
+                    "description": "Lowest value of the statistic, inclusive",
+                    "type": "number"
+                },
                 "statisticId": {
-                    "description": "Statistic ID",
+                    "description": "Statistic used as the player's level",
                     "type": "string"
+                }
+            }
+        },
+        "rest.SetDeltaRuleReq": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "What happens to the submissions over the limits",
+                    "type": "string",
+                    "enum": [
+                        "REJECT",
+                        "QUARANTINE"
+                    ]
                 },
-                "updatedAt": {
-                    "description": "Last time the player updated it's statistic progress",
+                "maxHourlyDelta": {
+                    "description": "Most the player's value can improve within an hour. Zero for no limit",
+                    "type": "number"
+                },
+                "maxSubmissionDelta": {
+                    "description": "Most a single submission can improve the player's value. Zero for no limit",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.SetEligibilityReq": {
+            "type": "object",
+            "properties": {
+                "segmentId": {
+                    "description": "Segment whose members are eligible",
                     "type": "string"
                 }
             }
         },
-        "rest.PlayerStatisticProgressionLandmark": {
+        "rest.SetLogLevelReq": {
+            "type": "object",
+            "properties": {
+                "durationSeconds": {
+                    "description": "Seconds until the previous level is restored. Kept until changed again when zero",
+                    "type": "integer"
+                },
+                "level": {
+                    "description": "Minimum level logged",
+                    "type": "string",
+                    "enum": [
+                        "debug",
+                        "info",
+                        "warn",
+                        "error"
+                    ]
+                }
+            }
+        },
+        "rest.SetProofRuleReq": {
+            "type": "object",
+            "properties": {
+                "topN": {
+                    "description": "Top positions requiring a proof, from 1 to 1000",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.SetQuotaReq": {
             "type": "object",
             "properties": {
-                "completed": {
-                    "description": "Has the player reached the landmark?",
-                    "type": "boolean"
+                "maxLeaderboards": {
+                    "description": "Leaderboards the game can keep, not counting the deleted ones. Unlimited when zero",
+                    "type": "integer"
                 },
-                "completedAt": {
-                    "description": "Time the player reached the landmark",
-                    "type": "string"
+                "maxStatistics": {
+                    "description": "Statistics the game can keep, not counting the deleted ones. Unlimited when zero",
+                    "type": "integer"
                 },
-                "value": {
-                    "description": "Landmark value",
-                    "type": "number"
+                "requestsPerMinute": {
+                    "description": "Requests the game can send per minute, on each instance of the API. Unlimited when zero",
+                    "type": "integer"
                 }
             }
         },
-        "rest.Quest": {
+        "rest.ShadowFlag": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "description": "Time that the quest was created",
-                    "type": "string"
-                },
-                "description": {
-                    "description": "Quest details",
-                    "type": "string"
-                },
-                "gameId": {
-                    "description": "ID of the game responsible for the quest",
+                "flaggedAt": {
+                    "description": "Time the player was flagged",
                     "type": "string"
                 },
-                "id": {
-                    "description": "Quest ID",
+                "playerId": {
+                    "description": "Player flagged",
                     "type": "string"
                 },
-                "name": {
-                    "description": "Quest name",
+                "reason": {
+                    "description": "Why the player was flagged",
                     "type": "string"
                 },
-                "tasks": {
-                    "description": "Quest task list",
+                "shadowRanks": {
+                    "description": "Player's ranks on the shadow copies of the leaderboards",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/rest.Task"
+                        "$ref": "#/definitions/rest.ShadowRank"
                     }
-                },
-                "updatedAt": {
-                    "description": "Last time that the quest was updated",
-                    "type": "string"
                 }
             }
         },
-        "rest.Rank": {
+        "rest.ShadowRank": {
             "type": "object",
             "properties": {
-                "playerId": {
-                    "description": "Player's ID",
+                "leaderboardId": {
+                    "description": "Leaderboard the rank is a shadow of",
                     "type": "string"
                 },
-                "position": {
-                    "description": "Player ranking position",
-                    "type": "integer"
+                "previous": {
+                    "description": "Player's value on the ranking when the shadow rank was added. Not set when the player wasn't ranked",
+                    "type": "number"
                 },
                 "value": {
-                    "description": "Player rank value",
+                    "description": "Player's value on the shadow copy",
                     "type": "number"
                 }
             }
         },
+        "rest.SigningKey": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "description": "Base64 encoded HMAC-SHA256 key the game's clients sign their submissions with",
+                    "type": "string"
+                }
+            }
+        },
         "rest.Statistic": {
             "type": "object",
             "properties": {
@@ -1365,6 +9827,19 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.SubmissionNonce": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "Time the nonce can no longer be used",
+                    "type": "string"
+                },
+                "nonce": {
+                    "description": "Nonce to sign alongside the player's next submission",
+                    "type": "string"
+                }
+            }
+        },
         "rest.Task": {
             "type": "object",
             "properties": {
@@ -1405,6 +9880,105 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.Title": {
+            "type": "object",
+            "properties": {
+                "badgeUrl": {
+                    "description": "Badge image shown alongside the title",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "Time the title was created",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Title details",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "ID of the game responsible for the title",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Title ID",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Title shown alongside the player's name",
+                    "type": "string"
+                },
+                "trigger": {
+                    "description": "Progression that grants the title",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.TitleTrigger"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "description": "Last time the title was changed",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.TitleTrigger": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "description": "Progression that grants the title",
+                    "type": "string",
+                    "enum": [
+                        "STATISTIC_LANDMARK",
+                        "RANK"
+                    ]
+                },
+                "landmark": {
+                    "description": "Landmark of the statistic that grants the title. Only on ` + "`" + `STATISTIC_LANDMARK` + "`" + `",
+                    "type": "number"
+                },
+                "leaderboardId": {
+                    "description": "Leaderboard whose top positions grant the title. Only on ` + "`" + `RANK` + "`" + `",
+                    "type": "string"
+                },
+                "statisticId": {
+                    "description": "Statistic whose landmark grants the title. Only on ` + "`" + `STATISTIC_LANDMARK` + "`" + `",
+                    "type": "string"
+                },
+                "top": {
+                    "description": "Positions of the leaderboard that grant the title, e.g. 10 for the top 10. Only on ` + "`" + `RANK` + "`" + `",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.Trust": {
+            "type": "object",
+            "properties": {
+                "cleared": {
+                    "description": "Player's held submissions released",
+                    "type": "integer"
+                },
+                "firstSeenAt": {
+                    "description": "Time the player's first submission was checked",
+                    "type": "string"
+                },
+                "flagged": {
+                    "description": "Player's submissions held for review",
+                    "type": "integer"
+                },
+                "level": {
+                    "description": "` + "`" + `LOW` + "`" + `, ` + "`" + `NORMAL` + "`" + ` or ` + "`" + `HIGH` + "`" + `",
+                    "type": "string"
+                },
+                "playerId": {
+                    "description": "Player the trust is of",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Trust score, from 0 to 100",
+                    "type": "number"
+                }
+            }
+        },
         "rest.UpdatePlayerQuestProgressionReq": {
             "type": "object",
             "properties": {
@@ -1417,6 +9991,18 @@ const docTemplate = `{
         "rest.UpsertPlayerRankReq": {
             "type": "object",
             "properties": {
+                "nonce": {
+                    "description": "Nonce issued to the player. Required with the signed submissions enabled",
+                    "type": "string"
+                },
+                "proof": {
+                    "description": "Proof of the score, e.g. a replay ID or URL. Required to reach the top positions of a leaderboard with a proof rule",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "Hex encoded HMAC-SHA256 of the submission. Required with the signed submissions enabled",
+                    "type": "string"
+                },
                 "value": {
                     "description": "Value that will be used to update the player's rank",
                     "type": "number"
@@ -1431,6 +10017,214 @@ const docTemplate = `{
                     "type": "number"
                 }
             }
+        },
+        "rest.UsageFootprint": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "description": "Size of the stored records, in bytes",
+                    "type": "integer"
+                },
+                "records": {
+                    "description": "Number of stored records",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.UsageReport": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Usage of each day with any, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.DailyUsage"
+                    }
+                },
+                "errors": {
+                    "description": "Requests answered with an error on the period, by its code",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "footprint": {
+                    "description": "Data the game currently keeps on the storage",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.UsageFootprint"
+                        }
+                    ]
+                },
+                "from": {
+                    "description": "First day of the period, as ` + "`" + `YYYY-MM-DD` + "`" + `",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "Game the usage belongs to",
+                    "type": "string"
+                },
+                "requests": {
+                    "description": "Requests handled on the period",
+                    "type": "integer"
+                },
+                "scoreSubmissions": {
+                    "description": "Score submissions handled on the period",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "Last day of the period, as ` + "`" + `YYYY-MM-DD` + "`" + `",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.Webhook": {
+            "type": "object",
+            "properties": {
+                "consecutiveFailures": {
+                    "description": "Failed attempts since the last successful one",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "Time the webhook was registered",
+                    "type": "string"
+                },
+                "disabledAt": {
+                    "description": "Time the webhook was disabled after failing too many times in a row. nil while enabled",
+                    "type": "string"
+                },
+                "filter": {
+                    "description": "Events the webhook receives",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.WebhookFilter"
+                        }
+                    ]
+                },
+                "gameId": {
+                    "description": "ID of the game responsible for the webhook",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Webhook ID",
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Key of the ` + "`" + `X-Gameblitz-Signature` + "`" + ` HMAC-SHA256 signature",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Last time the webhook was changed",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Endpoint the events are posted to",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.WebhookAttempt": {
+            "type": "object",
+            "properties": {
+                "attemptedAt": {
+                    "description": "Time the request was sent",
+                    "type": "string"
+                },
+                "durationMs": {
+                    "description": "Time waiting for the response, in milliseconds",
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Why the attempt failed. Empty when it succeeded",
+                    "type": "string"
+                },
+                "statusCode": {
+                    "description": "Response status code. Zero when no response was received",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Requests sent, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.WebhookAttempt"
+                    }
+                },
+                "createdAt": {
+                    "description": "Time the delivery was queued",
+                    "type": "string"
+                },
+                "event": {
+                    "description": "Event type, sent on the ` + "`" + `X-Gameblitz-Event` + "`" + ` header",
+                    "type": "string"
+                },
+                "gameId": {
+                    "description": "Game the event belongs to",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Delivery ID, sent on the ` + "`" + `X-Gameblitz-Delivery` + "`" + ` header",
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "description": "Time the delivery is due. nil once it is no longer pending",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Request body",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Delivery status",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "SUCCEEDED",
+                        "FAILED"
+                    ]
+                },
+                "webhookId": {
+                    "description": "Webhook the event is sent to",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.WebhookFilter": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Event types delivered, e.g. ` + "`" + `gameblitz.leaderboard.lifecycle` + "`" + `",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "leaderboardIds": {
+                    "description": "Leaderboards whose events are delivered",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "questIds": {
+                    "description": "Quests whose events are delivered",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "statisticIds": {
+                    "description": "Statistics whose events are delivered",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
         }
     }
 }`
@@ -3,14 +3,47 @@ package rest
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/activity"
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+	"github.com/gabapcia/gameblitz/internal/audit"
 	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/availability"
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/delta"
+	"github.com/gabapcia/gameblitz/internal/friend"
 	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/ingestion"
 	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/merge"
+	"github.com/gabapcia/gameblitz/internal/moderation"
+	"github.com/gabapcia/gameblitz/internal/outbox"
+	"github.com/gabapcia/gameblitz/internal/overview"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/presence"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/proof"
+	"github.com/gabapcia/gameblitz/internal/purge"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
 	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/quota"
+	"github.com/gabapcia/gameblitz/internal/reward"
+	"github.com/gabapcia/gameblitz/internal/segment"
+	"github.com/gabapcia/gameblitz/internal/shadow"
+	"github.com/gabapcia/gameblitz/internal/signing"
 	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/throttle"
+	"github.com/gabapcia/gameblitz/internal/title"
+	"github.com/gabapcia/gameblitz/internal/trust"
+	"github.com/gabapcia/gameblitz/internal/usage"
+	"github.com/gabapcia/gameblitz/internal/webhook"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -29,17 +62,335 @@ func (e ErrorResponse) withDetails(details ...string) ErrorResponse {
 var (
 	ErrorResponseInternalServerError = ErrorResponse{Code: "0.0", Message: "Unknown error"}
 	ErrorResponseInvalidRequestBody  = ErrorResponse{Code: "0.1", Message: "Invalid request body"}
+	ErrorResponseServiceReadOnly     = ErrorResponse{Code: "0.2", Message: "Service in read-only mode, try again later"}
+	ErrorResponseUpgradeRequired     = ErrorResponse{Code: "0.3", Message: "WebSocket upgrade required"}
 )
 
+// Requests that matched no route are recorded under the same route, so scanners can't create a series per path
+const unmatchedRoute = "unmatched"
+
+// Locals set by `nextHandled`, so the outer middlewares see the request as the first one handling it did
+const (
+	unmatchedLocal    = "unmatched"
+	handlerErrorLocal = "handlerError"
+)
+
+// Runs the next handlers and handles their error on the spot, so the middlewares see the status sent.
+// Returns the route pattern the request matched and the error returned by the handlers, if any
+func nextHandled(c *fiber.Ctx) (string, error) {
+	err := c.Next()
+	if err != nil {
+		// The router reports a path without a route with a `*fiber.Error`, while the handlers report theirs with the domain errors
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusNotFound {
+			c.Locals(unmatchedLocal, true)
+		}
+
+		c.Locals(handlerErrorLocal, err)
+		if err := c.App().ErrorHandler(c, err); err != nil {
+			_ = c.SendStatus(http.StatusInternalServerError)
+		}
+	} else if handlerErr, ok := c.Locals(handlerErrorLocal).(error); ok {
+		err = handlerErr
+	}
+
+	if unmatched, _ := c.Locals(unmatchedLocal).(bool); unmatched {
+		return unmatchedRoute, err
+	}
+
+	return c.Route().Path, err
+}
+
 func buildErrorHandler() fiber.ErrorHandler {
 	return func(c *fiber.Ctx, err error) error {
 		var jsonErr *json.SyntaxError
 
 		switch {
+		// Availability
+		case errors.Is(err, availability.ErrReadOnly):
+			return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponseServiceReadOnly)
 		// Auth
 		case errors.Is(err, auth.ErrInvalidCredentials):
 			validationErrorMessages := strings.Split(err.Error(), "\n")
 			return c.Status(http.StatusForbidden).JSON(ErrorResponseInvalidAuthCredentials.withDetails(validationErrorMessages...))
+		// Outbox
+		case errors.Is(err, outbox.ErrInvalidReplayFilter):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseReplayInvalidFilter.withDetails(validationErrorMessages...))
+		// Ingestion
+		case errors.Is(err, ingestion.ErrInvalidDeadLetterID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseDeadLetterInvalidID)
+		case errors.Is(err, ingestion.ErrDeadLetterNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseDeadLetterNotFound)
+		case errors.Is(err, ingestion.ErrInvalidLimit):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseDeadLetterLimit)
+		// Webhook
+		case errors.Is(err, webhook.ErrInvalidWebhookID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseWebhookInvalidID)
+		case errors.Is(err, webhook.ErrWebhookNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseWebhookNotFound)
+		case errors.Is(err, webhook.ErrInvalidWebhookURL):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseWebhookInvalidURL)
+		case errors.Is(err, webhook.ErrInvalidFilter):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseWebhookInvalidFilter)
+		case errors.Is(err, webhook.ErrInvalidLimit):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseWebhookDeliveryLimit)
+		// Audit
+		case errors.Is(err, audit.ErrInvalidFilter):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAuditInvalidFilter)
+		case errors.Is(err, audit.ErrInvalidLimit):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAuditInvalidLimit)
+		case errors.Is(err, audit.ErrInvalidCursor):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAuditInvalidCursor)
+		case errors.Is(err, audit.ErrExportTooLarge):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAuditExportTooLarge)
+		case errors.Is(err, ErrInvalidAuditExportFormat):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAuditInvalidExportFormat)
+		// Privacy
+		case errors.Is(err, privacy.ErrMissingPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponsePlayerMissingID)
+		case errors.Is(err, ErrInvalidDryRun):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponsePlayerInvalidDryRun)
+		// Linked Account
+		case errors.Is(err, player.ErrAccountNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseAccountNotFound)
+		case errors.Is(err, player.ErrAccountLinkedToAnotherPlayer):
+			return c.Status(http.StatusConflict).JSON(ErrorResponseAccountLinkedToAnotherPlayer)
+		case errors.Is(err, player.ErrAccountValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAccountInvalid.withDetails(validationErrorMessages...))
+		// Ban
+		case errors.Is(err, ban.ErrPlayerBanned):
+			return c.Status(http.StatusForbidden).JSON(ErrorResponseBanPlayerBanned)
+		case errors.Is(err, ban.ErrDeviceBanned):
+			return c.Status(http.StatusForbidden).JSON(ErrorResponseDeviceBanned)
+		case errors.Is(err, ban.ErrDeviceBanNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseDeviceBanNotFound)
+		case errors.Is(err, ban.ErrDeviceAlreadyBanned):
+			return c.Status(http.StatusConflict).JSON(ErrorResponseDeviceAlreadyBanned)
+		case errors.Is(err, ban.ErrBanNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseBanNotFound)
+		case errors.Is(err, ban.ErrPlayerAlreadyBanned):
+			return c.Status(http.StatusConflict).JSON(ErrorResponseBanPlayerAlreadyBanned)
+		case errors.Is(err, ban.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseBanInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, ban.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseBanInvalidPlayer)
+		case errors.Is(err, ban.ErrInvalidDeviceHash):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseBanInvalidDeviceHash)
+		// Shadow Flag
+		case errors.Is(err, shadow.ErrFlagNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseShadowFlagNotFound)
+		case errors.Is(err, shadow.ErrPlayerAlreadyFlagged):
+			return c.Status(http.StatusConflict).JSON(ErrorResponseShadowFlagPlayerAlreadyFlagged)
+		case errors.Is(err, shadow.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseShadowFlagInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, shadow.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseShadowFlagInvalidPlayer)
+		// Submission Rate Limit
+		case errors.Is(err, throttle.ErrSubmissionRateLimited):
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttle.RetryAfter(time.Now()).Seconds()))))
+			return c.Status(http.StatusTooManyRequests).JSON(ErrorResponseSubmissionRateLimited)
+		// Player Profile
+		case errors.Is(err, player.ErrProfileNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseProfileNotFound)
+		case errors.Is(err, player.ErrProfileAlreadyExists):
+			return c.Status(http.StatusConflict).JSON(ErrorResponseProfileAlreadyExists)
+		case errors.Is(err, player.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseProfileInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, player.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseProfileInvalidPlayer)
+		case errors.Is(err, player.ErrInvalidSearch):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseProfileInvalidSearch)
+		case errors.Is(err, player.ErrInvalidPageNumber):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseProfileSearchPageNumber)
+		case errors.Is(err, player.ErrInvalidLimitNumber):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseProfileSearchLimitNumber)
+		// Achievement
+		case errors.Is(err, achievement.ErrAchievementNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseAchievementNotFound)
+		case errors.Is(err, achievement.ErrInvalidAchievementID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAchievementInvalidID)
+		case errors.Is(err, achievement.ErrUnknownLandmark):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAchievementUnknownLandmark)
+		case errors.Is(err, achievement.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAchievementInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, achievement.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAchievementInvalidPlayer)
+		// Title
+		case errors.Is(err, title.ErrTitleNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseTitleNotFound)
+		case errors.Is(err, title.ErrInvalidTitleID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseTitleInvalidID)
+		case errors.Is(err, title.ErrUnknownLandmark):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseTitleUnknownLandmark)
+		case errors.Is(err, title.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseTitleInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, title.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseTitleInvalidPlayer)
+		// Friend
+		case errors.Is(err, friend.ErrFriendshipNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseFriendshipNotFound)
+		case errors.Is(err, friend.ErrFriendLimitReached):
+			return c.Status(http.StatusConflict).JSON(ErrorResponseFriendshipLimitReached)
+		case errors.Is(err, friend.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseFriendshipInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, friend.ErrInvalidPlayerID), errors.Is(err, friend.ErrInvalidFriendID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseFriendshipInvalidPlayer)
+		// Presence
+		case errors.Is(err, presence.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponsePresenceInvalidPlayer)
+		// Segment
+		case errors.Is(err, segment.ErrPlayerNotEligible):
+			return c.Status(http.StatusForbidden).JSON(ErrorResponseSegmentPlayerNotEligible)
+		case errors.Is(err, segment.ErrSegmentNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseSegmentNotFound)
+		case errors.Is(err, segment.ErrEligibilityNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseEligibilityNotFound)
+		case errors.Is(err, segment.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseSegmentInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, segment.ErrEligibilityValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseEligibilityInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, segment.ErrProfileRulesUnavailable):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseSegmentProfileRulesUnavailable)
+		case errors.Is(err, segment.ErrInvalidSegmentID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseSegmentInvalidID)
+		case errors.Is(err, segment.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseSegmentInvalidPlayer)
+		case errors.Is(err, segment.ErrInvalidResourceID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseEligibilityInvalid)
+		case errors.Is(err, ErrSegmentFilterUnavailable):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseSegmentFilterUnavailable)
+		// Level
+		case errors.Is(err, level.ErrCurveNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseLevelCurveNotFound)
+		case errors.Is(err, level.ErrInvalidCurveID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseLevelCurveInvalidID)
+		case errors.Is(err, level.ErrStatisticNotSum):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseLevelCurveStatisticNotSum)
+		case errors.Is(err, level.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseLevelCurveInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, level.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseLevelCurveInvalidPlayer)
+		// Activity
+		case errors.Is(err, activity.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseActivityInvalidPlayer)
+		case errors.Is(err, activity.ErrInvalidLimit):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseActivityInvalidLimit)
+		case errors.Is(err, activity.ErrInvalidCursor):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseActivityInvalidCursor)
+		// Signed Submissions
+		case errors.Is(err, signing.ErrMissingSignature):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseSigningMissingSignature)
+		case errors.Is(err, signing.ErrInvalidSignature):
+			return c.Status(http.StatusForbidden).JSON(ErrorResponseSigningInvalidSignature)
+		case errors.Is(err, signing.ErrNonceNotFound):
+			return c.Status(http.StatusForbidden).JSON(ErrorResponseSigningNonceNotFound)
+		case errors.Is(err, signing.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseSigningInvalidPlayer)
+		// Delta Rule
+		case errors.Is(err, delta.ErrDeltaExceeded):
+			return c.Status(http.StatusForbidden).JSON(ErrorResponseDeltaExceeded)
+		case errors.Is(err, delta.ErrRuleNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseDeltaRuleNotFound)
+		case errors.Is(err, delta.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseDeltaRuleInvalid.withDetails(validationErrorMessages...))
+		// Proof Rule
+		case errors.Is(err, proof.ErrProofRequired):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseProofRequired)
+		case errors.Is(err, proof.ErrInvalidProof):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseProofInvalid)
+		case errors.Is(err, proof.ErrRuleNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseProofRuleNotFound)
+		case errors.Is(err, proof.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseProofRuleInvalid.withDetails(validationErrorMessages...))
+		// Quarantine
+		case errors.Is(err, quarantine.ErrSubmissionQuarantined):
+			return c.Status(http.StatusAccepted).JSON(ErrorResponseQuarantineSubmissionHeld)
+		case errors.Is(err, quarantine.ErrSubmissionNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseQuarantineSubmissionNotFound)
+		case errors.Is(err, quarantine.ErrInvalidSubmissionID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseQuarantineInvalidSubmissionID)
+		case errors.Is(err, quarantine.ErrInvalidLimit):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseQuarantineInvalidLimit)
+		// Trust
+		case errors.Is(err, trust.ErrRecordNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseTrustNotFound)
+		case errors.Is(err, trust.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseTrustInvalidPlayer)
+		// Anomaly
+		case errors.Is(err, anomaly.ErrAnomalyNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseAnomalyNotFound)
+		case errors.Is(err, anomaly.ErrInvalidAnomalyID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAnomalyInvalidID)
+		case errors.Is(err, anomaly.ErrInvalidLimit):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseAnomalyInvalidLimit)
+		// Score Moderation
+		case errors.Is(err, moderation.ErrEntryNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseModerationEntryNotFound)
+		case errors.Is(err, moderation.ErrTooManyEntries):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseModerationTooManyEntries)
+		case errors.Is(err, moderation.ErrNoScores):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseModerationNoScores)
+		case errors.Is(err, moderation.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseModerationInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, moderation.ErrInvalidDeviceFilter):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseModerationDeviceFilter)
+		case errors.Is(err, moderation.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseModerationInvalidPlayerID)
+		// Player Overview
+		case errors.Is(err, overview.ErrInvalidPlayerID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseOverviewInvalidPlayer)
+		// Reward
+		case errors.Is(err, reward.ErrRuleNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseRewardRuleNotFound)
+		case errors.Is(err, reward.ErrInvalidRuleID):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseRewardRuleInvalidID)
+		case errors.Is(err, reward.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseRewardRuleInvalid.withDetails(validationErrorMessages...))
+		case errors.Is(err, reward.ErrGrantNotFound):
+			return c.Status(http.StatusNotFound).JSON(ErrorResponseRewardGrantNotFound)
+		case errors.Is(err, reward.ErrGrantNotFailed):
+			return c.Status(http.StatusConflict).JSON(ErrorResponseRewardGrantNotFailed)
+		case errors.Is(err, reward.ErrInvalidLimit):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseRewardGrantLimitError)
+		// Purge
+		case errors.Is(err, purge.ErrInvalidRetention):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponsePurgeInvalidRetention)
+		// Player Merge
+		case errors.Is(err, merge.ErrValidationError):
+			validationErrorMessages := strings.Split(err.Error(), "\n")
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponsePlayerMergeInvalid.withDetails(validationErrorMessages...))
+		// Quota
+		case errors.Is(err, quota.ErrInvalidQuota):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseQuotaInvalid)
+		case errors.Is(err, quota.ErrRateLimited):
+			return c.Status(http.StatusTooManyRequests).JSON(ErrorResponseQuotaRateLimited)
+		case errors.Is(err, quota.ErrLeaderboardLimitReached):
+			return c.Status(http.StatusForbidden).JSON(ErrorResponseQuotaLeaderboardLimitReached)
+		case errors.Is(err, quota.ErrStatisticLimitReached):
+			return c.Status(http.StatusForbidden).JSON(ErrorResponseQuotaStatisticLimitReached)
+		// Usage
+		case errors.Is(err, usage.ErrInvalidPeriod):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseUsageInvalidPeriod)
+		// Log Level
+		case errors.Is(err, zap.ErrInvalidLevel):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseLogLevelInvalid)
+		case errors.Is(err, ErrInvalidLogLevelDuration):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseLogLevelInvalidDuration)
 		// Statistic
 		case errors.Is(err, statistic.ErrPlayerStatisticNotFound):
 			return c.Status(http.StatusNotFound).JSON(ErrorResponsePlayerStatisticNotFound)
@@ -47,6 +398,8 @@ func buildErrorHandler() fiber.ErrorHandler {
 			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseStatisticInvalidID)
 		case errors.Is(err, statistic.ErrStatisticNotFound):
 			return c.Status(http.StatusNotFound).JSON(ErrorResponseStatisticNotFound)
+		case errors.Is(err, statistic.ErrStatisticNameAlreadyInUse):
+			return c.Status(http.StatusConflict).JSON(ErrorResponseStatisticNameInUse)
 		case errors.Is(err, statistic.ErrStatisticValidation):
 			validationErrorMessages := strings.Split(err.Error(), "\n")
 			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseStatisticInvalid.withDetails(validationErrorMessages...))
@@ -61,6 +414,8 @@ func buildErrorHandler() fiber.ErrorHandler {
 			return c.Status(http.StatusNotFound).JSON(ErrorResponsePlayerNotStartedTheQuest)
 		case errors.Is(err, quest.ErrPlayerQuestAlreadyCompleted):
 			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponsePlayerQuestAlreadyFinished)
+		case errors.Is(err, quest.ErrQuestExpired):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseQuestExpired)
 		case errors.Is(err, quest.ErrQuestValidationError):
 			validationErrorMessages := strings.Split(err.Error(), "\n")
 			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseQuestInvalid.withDetails(validationErrorMessages...))
@@ -71,6 +426,14 @@ func buildErrorHandler() fiber.ErrorHandler {
 			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseRankingPageNumber)
 		case errors.Is(err, leaderboard.ErrInvalidLimitNumber):
 			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseRankingLimitNumber)
+		case errors.Is(err, leaderboard.ErrInvalidPollTimeout):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseRankPollTimeout)
+		case errors.Is(err, leaderboard.ErrInvalidRankCursor):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseRankPollCursor)
+		case errors.Is(err, leaderboard.ErrInvalidLiveTop):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseLiveRankingTopNumber)
+		case errors.Is(err, leaderboard.ErrInvalidFingerprint):
+			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseInvalidFingerprint)
 		case errors.Is(err, leaderboard.ErrInvalidLeaderboardID):
 			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseLeaderboardInvalidID)
 		case errors.Is(err, leaderboard.ErrLeaderboardNotFound):
@@ -79,10 +442,16 @@ func buildErrorHandler() fiber.ErrorHandler {
 			validationErrorMessages := strings.Split(err.Error(), "\n")
 			return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponseLeaderboardInvalid.withDetails(validationErrorMessages...))
 		// Unknown
+		case errors.Is(err, fiber.ErrUpgradeRequired):
+			return c.Status(http.StatusUpgradeRequired).JSON(ErrorResponseUpgradeRequired)
 		case errors.As(err, &jsonErr):
 			return c.Status(http.StatusBadRequest).JSON(ErrorResponseInvalidRequestBody)
 		default:
-			zap.Error(err, "unknown error")
+			// The access log records the error alongside its request when enabled
+			if logged, _ := c.Locals(accessLogLocal).(bool); !logged {
+				zap.ErrorContext(c.UserContext(), err, "unknown error")
+			}
+
 			return c.Status(http.StatusInternalServerError).JSON(ErrorResponseInternalServerError)
 		}
 	}
@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Reports an unknown error returned while handling the request. The tags identify the request, e.g. its `route` and `game_id`
+type ReportErrorFunc func(ctx context.Context, err error, req *http.Request, tags map[string]string)
+
+// Reports the errors answered with `500 Internal Server Error`, which no domain error maps to
+func buildErrorReportMiddleware(reportErrorFunc ReportErrorFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		route, err := nextHandled(c)
+		if err == nil || c.Response().StatusCode() != http.StatusInternalServerError {
+			return nil
+		}
+
+		tags := map[string]string{"route": route}
+		if claims, ok := c.Locals("claims").(auth.Claims); ok {
+			tags["game_id"] = claims.GameID
+		}
+
+		if requestID, ok := c.Locals(requestid.ConfigDefault.ContextKey).(string); ok {
+			tags["request_id"] = requestID
+		}
+
+		if spanContext := trace.SpanContextFromContext(c.UserContext()); spanContext.IsValid() {
+			tags["trace_id"] = spanContext.TraceID().String()
+		}
+
+		// A request that can't be converted is still reported, without its details
+		req, _ := adaptor.ConvertRequest(c, true)
+
+		reportErrorFunc(c.UserContext(), err, req, tags)
+		return nil
+	}
+}
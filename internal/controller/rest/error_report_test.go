@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type reportedError struct {
+	err  error
+	path string
+	tags map[string]string
+}
+
+func TestBuildErrorReportMiddleware(t *testing.T) {
+	zap.Start()
+
+	var (
+		reported []reportedError
+		gameID   = uuid.NewString()
+		anyErr   = errors.New("any error")
+		getErr   error
+	)
+
+	app := App(Config{
+		ReportErrorFunc: func(ctx context.Context, err error, req *http.Request, tags map[string]string) {
+			reported = append(reported, reportedError{err: err, path: req.URL.Path, tags: tags})
+		},
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{}, getErr
+		},
+	})
+
+	t.Run("Unknown Error", func(t *testing.T) {
+		reported, getErr = nil, anyErr
+
+		path := "/api/v1/leaderboards/" + uuid.NewString()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", uuid.NewString())
+		req.Header.Set("X-Request-ID", "request-id")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		assert.Len(t, reported, 1)
+		assert.ErrorIs(t, reported[0].err, anyErr)
+		assert.Equal(t, path, reported[0].path)
+		assert.Equal(t, map[string]string{
+			"route":      "/api/v1/leaderboards/:leaderboardId",
+			"game_id":    gameID,
+			"request_id": "request-id",
+		}, reported[0].tags)
+	})
+
+	t.Run("Domain Error", func(t *testing.T) {
+		reported, getErr = nil, leaderboard.ErrLeaderboardNotFound
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+uuid.NewString(), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Empty(t, reported)
+	})
+}
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
+	"github.com/gabapcia/gameblitz/internal/availability"
 	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/throttle"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -54,3 +57,46 @@ func TestBuildErrorHandler(t *testing.T) {
 	assert.Equal(t, ErrorResponseInternalServerError.Code, body.Code)
 	assert.Equal(t, ErrorResponseInternalServerError.Message, body.Message)
 }
+
+func TestBuildErrorHandlerReadOnly(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: buildErrorHandler()})
+	app.Post("/", func(c *fiber.Ctx) error {
+		return availability.ErrReadOnly
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	var body ErrorResponse
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	assert.NoError(t, err)
+
+	assert.Equal(t, ErrorResponseServiceReadOnly.Code, body.Code)
+	assert.Equal(t, ErrorResponseServiceReadOnly.Message, body.Message)
+}
+
+func TestBuildErrorHandlerSubmissionRateLimited(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: buildErrorHandler()})
+	app.Post("/", func(c *fiber.Ctx) error {
+		return throttle.ErrSubmissionRateLimited
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	retryAfter, err := strconv.Atoi(resp.Header.Get(fiber.HeaderRetryAfter))
+	assert.NoError(t, err)
+	assert.True(t, retryAfter > 0 && retryAfter <= 60)
+
+	var body ErrorResponse
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	assert.NoError(t, err)
+
+	assert.Equal(t, ErrorResponseSubmissionRateLimited.Code, body.Code)
+}
@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/outbox"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReplayEventsReq struct {
+	GameID string    `json:"gameId"` // Game the events belong to
+	From   time.Time `json:"from"`   // Replay the events recorded from this time on
+	To     time.Time `json:"to"`     // Replay the events recorded before this time
+}
+
+type ReplayEventsRes struct {
+	Replayed int `json:"replayed"` // Number of events published again
+}
+
+func (r ReplayEventsReq) toDomain() outbox.ReplayFilter {
+	return outbox.ReplayFilter{
+		GameID: r.GameID,
+		From:   r.From,
+		To:     r.To,
+	}
+}
+
+var (
+	ErrorResponseReplayInvalidFilter = ErrorResponse{Code: "9.0", Message: "Invalid replay filter"}
+)
+
+// @summary Replay Events
+// @description Publish again the archived domain events of a game recorded within the time range, in the order they were recorded
+// @router /admin/v1/events/replay [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param ReplayEventsData body ReplayEventsReq true "Events to replay"
+// @success 200 {object} ReplayEventsRes
+// @failure 400,401,403,422,500 {object} ErrorResponse
+func buildReplayEventsHandler(replayEventsFunc outbox.ReplayFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body ReplayEventsReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		replayed, err := replayEventsFunc(c.UserContext(), body.toDomain())
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(ReplayEventsRes{Replayed: replayed})
+	}
+}
@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/outbox"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildReplayEventsHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+		from       = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to         = from.Add(24 * time.Hour)
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var filter outbox.ReplayFilter
+
+		app := App(Config{
+			AdminToken: adminToken,
+			ReplayEventsFunc: func(ctx context.Context, f outbox.ReplayFilter) (int, error) {
+				filter = f
+				return 3, nil
+			},
+		})
+
+		body := fmt.Sprintf(`{"gameId": "%s", "from": "%s", "to": "%s"}`, gameID, from.Format(time.RFC3339), to.Format(time.RFC3339))
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/events/replay", bytes.NewBufferString(body))
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var res ReplayEventsRes
+		err = json.NewDecoder(resp.Body).Decode(&res)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 3, res.Replayed)
+		assert.Equal(t, gameID, filter.GameID)
+		assert.True(t, from.Equal(filter.From))
+		assert.True(t, to.Equal(filter.To))
+	})
+
+	t.Run("Invalid Filter", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			ReplayEventsFunc: func(ctx context.Context, f outbox.ReplayFilter) (int, error) {
+				return 0, outbox.ErrInvalidReplayFilter
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/events/replay", bytes.NewBufferString(`{}`))
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseReplayInvalidFilter.Code, body.Code)
+	})
+
+	t.Run("Invalid Body", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			ReplayEventsFunc: func(ctx context.Context, f outbox.ReplayFilter) (int, error) {
+				return 0, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/events/replay", bytes.NewBufferString(`{`))
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
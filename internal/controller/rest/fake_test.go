@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+// Ranking of a single leaderboard by player ID, highest value first. The values written are added to the player's
+// value, as on the `INC` leaderboards
+type fakeRanking map[string]float64
+
+// The player's rank, without its position
+func (r fakeRanking) GetPlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+	value, ok := r[playerID]
+	if !ok {
+		return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+	}
+
+	return leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Value: value}, nil
+}
+
+func (r fakeRanking) GetRanking(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+	ranking := make([]leaderboard.Rank, 0, len(r))
+	for playerID, value := range r {
+		ranking = append(ranking, leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Value: value})
+	}
+
+	slices.SortFunc(ranking, func(a, b leaderboard.Rank) int { return cmp.Compare(b.Value, a.Value) })
+	for i := range ranking {
+		ranking[i].Position = int64(i)
+	}
+
+	start, end := min(page*limit, int64(len(ranking))), min((page+1)*limit, int64(len(ranking)))
+	return ranking[start:end], nil
+}
+
+func (r fakeRanking) UpsertPlayerRankValue(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+	r[playerID] += value
+	return nil
+}
+
+func (r fakeRanking) DeletePlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+	delete(r, playerID)
+	return nil
+}
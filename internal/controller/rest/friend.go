@@ -0,0 +1,135 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/friend"
+	"github.com/gabapcia/gameblitz/internal/presence"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AddFriendReq struct {
+	FriendID string `json:"friendId"` // Player added as a friend
+}
+
+type Friendship struct {
+	CreatedAt time.Time       `json:"createdAt"`          // Time the players became friends
+	PlayerID  string          `json:"playerId"`           // Player whose friend it is
+	FriendID  string          `json:"friendId"`           // Player's friend
+	Presence  *PresenceStatus `json:"presence,omitempty"` // Friend's presence. Only on the friends list, left out when the friend wasn't seen within the retention
+}
+
+func friendshipFromDomain(f friend.Friendship) Friendship {
+	return Friendship{
+		CreatedAt: f.CreatedAt,
+		PlayerID:  f.PlayerID,
+		FriendID:  f.FriendID,
+	}
+}
+
+var (
+	ErrorResponseFriendshipInvalid       = ErrorResponse{Code: "24.0", Message: "Invalid friendship"}
+	ErrorResponseFriendshipNotFound      = ErrorResponse{Code: "24.1", Message: "Friendship not found"}
+	ErrorResponseFriendshipLimitReached  = ErrorResponse{Code: "24.2", Message: "Friend limit reached"}
+	ErrorResponseFriendshipInvalidPlayer = ErrorResponse{Code: "24.3", Message: "Invalid player id"}
+)
+
+// @summary Add Friend
+// @description Make two players of the game friends of each other. Adding a friendship that already exists returns it.
+// @description A player can have up to 1000 friends
+// @router /api/v1/players/{playerId}/friends [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param AddFriendReq body AddFriendReq true "Friend added"
+// @success 201 {object} Friendship
+// @failure 400,409,422,500 {object} ErrorResponse
+func buildAddFriendHandler(addFriendFunc friend.AddFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body AddFriendReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		f, err := addFriendFunc(c.UserContext(), friend.NewFriendshipData{
+			GameID:   claims.GameID,
+			PlayerID: c.Params("playerId"),
+			FriendID: body.FriendID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(friendshipFromDomain(f))
+	}
+}
+
+// @summary List Friends
+// @description List the friends of a player of the game, oldest friendship first. With the presence enabled, each friend
+// @description carries whether it's online and when it was last seen
+// @router /api/v1/players/{playerId}/friends [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {array} Friendship
+// @failure 422,500 {object} ErrorResponse
+func buildListFriendsHandler(listFriendsFunc friend.ListFunc, listPresencesFunc presence.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		friends, err := listFriendsFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		var (
+			data      = make([]Friendship, len(friends))
+			friendIDs = make([]string, len(friends))
+		)
+		for i, f := range friends {
+			data[i] = friendshipFromDomain(f)
+			friendIDs[i] = f.FriendID
+		}
+
+		if listPresencesFunc != nil && len(friends) > 0 {
+			presences, err := listPresencesFunc(c.UserContext(), claims.GameID, friendIDs)
+			if err != nil {
+				return err
+			}
+
+			for i := range data {
+				if p, ok := presences[data[i].FriendID]; ok {
+					data[i].Presence = &PresenceStatus{Online: p.Online, LastSeenAt: p.LastSeenAt}
+				}
+			}
+		}
+
+		return c.Status(http.StatusOK).JSON(data)
+	}
+}
+
+// @summary Remove Friend
+// @description Remove the friendship between two players of the game, for both of them
+// @router /api/v1/players/{playerId}/friends/{friendId} [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param friendId path string true "Friend ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildRemoveFriendHandler(removeFriendFunc friend.RemoveFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := removeFriendFunc(c.UserContext(), claims.GameID, c.Params("playerId"), c.Params("friendId")); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
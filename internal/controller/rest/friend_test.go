@@ -0,0 +1,177 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/friend"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Every player is a friend of alice, and alice has no friends yet
+func newFriendTestConfig(gameID string) Config {
+	list := func(ctx context.Context, gameID, playerID string) ([]friend.Friendship, error) {
+		if playerID == "alice" {
+			return []friend.Friendship{}, nil
+		}
+
+		return []friend.Friendship{{GameID: gameID, PlayerID: playerID, FriendID: "alice"}}, nil
+	}
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		AddFriendFunc: friend.BuildAddFunc(list, func(ctx context.Context, f friend.Friendship) (friend.Friendship, error) {
+			return f, nil
+		}),
+		RemoveFriendFunc: friend.BuildRemoveFunc(func(ctx context.Context, gameID, playerID, friendID string) error {
+			return nil
+		}),
+		ListFriendsFunc: friend.BuildListFunc(list),
+	}
+}
+
+func TestBuildAddFriendHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newFriendTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/alice/friends", bytes.NewBufferString(`{"friendId": "bob"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body Friendship
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "alice", body.PlayerID)
+		assert.Equal(t, "bob", body.FriendID)
+	})
+
+	t.Run("Self Friendship", func(t *testing.T) {
+		app := App(newFriendTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/alice/friends", bytes.NewBufferString(`{"friendId": "alice"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseFriendshipInvalid.Code, body.Code)
+		assert.Contains(t, body.Details, friend.ErrSelfFriendship.Error())
+	})
+}
+
+func TestBuildListFriendsHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newFriendTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/bob/friends", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Friendship
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "alice", body[0].FriendID)
+	})
+
+	// The friendships are on the same path for every game, so they're never cached
+	t.Run("Not Cached", func(t *testing.T) {
+		listed := false
+
+		config := newFriendTestConfig(gameID)
+		config.ListFriendsFunc = friend.BuildListFunc(func(ctx context.Context, gameID, playerID string) ([]friend.Friendship, error) {
+			if listed {
+				return []friend.Friendship{}, nil
+			}
+
+			listed = true
+			return []friend.Friendship{{GameID: gameID, PlayerID: playerID, FriendID: "alice"}}, nil
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/bob/friends", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/players/bob/friends", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Friendship
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Empty(t, body)
+	})
+}
+
+func TestBuildRemoveFriendHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newFriendTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/players/bob/friends/alice", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newFriendTestConfig(gameID)
+		config.RemoveFriendFunc = friend.BuildRemoveFunc(func(ctx context.Context, gameID, playerID, friendID string) error {
+			return friend.ErrFriendshipNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/players/bob/friends/alice", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
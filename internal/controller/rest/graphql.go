@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/controller/graphql"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type GraphQLReq struct {
+	Query         string         `json:"query"`         // GraphQL query
+	OperationName string         `json:"operationName"` // Operation to run, when the query has many
+	Variables     map[string]any `json:"variables"`     // Query variables
+}
+
+type GraphQLError struct {
+	Message string `json:"message"`        // Error message
+	Path    []any  `json:"path,omitempty"` // Path of the field that failed
+}
+
+type GraphQLRes struct {
+	Data   json.RawMessage `json:"data" swaggertype:"object"` // Query result. Null when the query couldn't run
+	Errors []GraphQLError  `json:"errors,omitempty"`          // Errors raised while running the query
+}
+
+func (r GraphQLReq) toDomain() graphql.Request {
+	return graphql.Request{
+		Query:         r.Query,
+		OperationName: r.OperationName,
+		Variables:     r.Variables,
+	}
+}
+
+// @summary GraphQL
+// @description Read the player's ranks, statistics and quests on a single query.
+// @description Errors on a field come on the `errors` list, alongside the fields that could be read
+// @router /api/v1/graphql [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param GraphQLData body GraphQLReq true "GraphQL query"
+// @success 200 {object} GraphQLRes
+// @failure 400,500 {object} ErrorResponse
+func buildGraphQLHandler(executeGraphQLFunc graphql.ExecuteFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body GraphQLReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		claims := c.Locals("claims").(auth.Claims)
+		resp := executeGraphQLFunc(c.UserContext(), claims.GameID, body.toDomain())
+
+		res := GraphQLRes{Data: resp.Data}
+		for _, err := range resp.Errors {
+			res.Errors = append(res.Errors, GraphQLError{Message: err.Message, Path: err.Path})
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
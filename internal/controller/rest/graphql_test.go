@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/controller/graphql"
+
+	"github.com/google/uuid"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildGraphQLHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			ExecuteGraphQLFunc: func(ctx context.Context, g string, req graphql.Request) *graphqlgo.Response {
+				assert.Equal(t, gameID, g)
+				assert.Equal(t, `query($id: ID!) { player(id: $id) { id } }`, req.Query)
+				assert.Equal(t, map[string]any{"id": "p"}, req.Variables)
+
+				return &graphqlgo.Response{
+					Data:   json.RawMessage(`{"player":{"id":"p"}}`),
+					Errors: []*gqlerrors.QueryError{{Message: "leaderboard not found", Path: []any{"player", "ranks", 0}}},
+				}
+			},
+		})
+
+		body, err := json.Marshal(GraphQLReq{Query: `query($id: ID!) { player(id: $id) { id } }`, Variables: map[string]any{"id": "p"}})
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewReader(body))
+		req.Header.Set("Authorization", uuid.NewString())
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var data GraphQLRes
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `{"player":{"id":"p"}}`, string(data.Data))
+		assert.Equal(t, []GraphQLError{{Message: "leaderboard not found", Path: []any{"player", "ranks", float64(0)}}}, data.Errors)
+	})
+
+	t.Run("Invalid Body", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			ExecuteGraphQLFunc: func(ctx context.Context, gameID string, req graphql.Request) *graphqlgo.Response {
+				assert.Fail(t, "executed an invalid request")
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewBufferString(`{"query":`))
+		req.Header.Set("Authorization", uuid.NewString())
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseInvalidRequestBody.Code, data.Code)
+	})
+}
@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/quic-go/quic-go/http3"
+)
+
+// Time the clients keep the HTTP/3 endpoint advertised before checking it again
+const altSvcMaxAge = 24 * time.Hour
+
+// Advertises the HTTP/3 listener, so the clients that support it move their next requests there
+func buildAltSvcMiddleware(http3Port int) fiber.Handler {
+	altSvc := fmt.Sprintf(`h3=":%d"; ma=%d`, http3Port, int(altSvcMaxAge.Seconds()))
+
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderAltSvc, altSvc)
+		return c.Next()
+	}
+}
+
+// Serves the same app over QUIC. The requests go through the net/http adaptor, so the responses are sent once complete
+func newHTTP3Server(app *fiber.App, port int, cert tls.Certificate) *http3.Server {
+	return &http3.Server{
+		Addr:      fmt.Sprintf(":%d", port),
+		Handler:   adaptor.FiberApp(app),
+		TLSConfig: http3.ConfigureTLSConfig(&tls.Config{Certificates: []tls.Certificate{cert}}),
+	}
+}
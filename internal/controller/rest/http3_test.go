@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/stretchr/testify/assert"
+)
+
+func selfSignedCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestBuildAltSvcMiddleware(t *testing.T) {
+	app := App(Config{
+		HTTP3Port: 8443,
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{}, nil
+		},
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s", uuid.NewString()), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, `h3=":8443"; ma=86400`, resp.Header.Get("Alt-Svc"))
+}
+
+func TestNewHTTP3Server(t *testing.T) {
+	var (
+		leaderboardID = uuid.NewString()
+		gameID        = uuid.NewString()
+	)
+
+	app := App(Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID, Name: "Season"}, nil
+		},
+	})
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	server := newHTTP3Server(app, conn.LocalAddr().(*net.UDPAddr).Port, selfSignedCertificate(t))
+	go server.Serve(conn)
+	defer server.Close()
+
+	transport := &http3.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	defer transport.Close()
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://%s/api/v1/leaderboards/%s", conn.LocalAddr(), leaderboardID), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", uuid.NewString())
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, resp.ProtoMajor)
+
+	var data Leaderboard
+	err = json.NewDecoder(resp.Body).Decode(&data)
+	assert.NoError(t, err)
+
+	assert.Equal(t, leaderboardID, data.ID)
+	assert.Equal(t, "Season", data.Name)
+}
@@ -2,13 +2,13 @@ package rest
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gabapcia/gameblitz/internal/auth"
 	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
 	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quota"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -78,17 +78,17 @@ func buildGetLeaderboardMiddleware(cache fiber.Storage, expiration time.Duration
 		var (
 			id       = c.Params("leaderboardId")
 			claims   = c.Locals("claims").(auth.Claims)
-			cacheKey = fmt.Sprintf("GetLeaderboardMiddleware:%s:%s", id, claims.GameID)
+			cacheKey = leaderboardCacheKey(id, claims.GameID)
 		)
 
 		if cache != nil {
 			data, err := cache.Get(cacheKey)
 			if err != nil {
-				zap.Error(err, "get cache error")
+				zap.ErrorContext(c.UserContext(), err, "get cache error")
 			} else if data != nil {
 				var leaderboard leaderboard.Leaderboard
 				if err = json.Unmarshal(data, &leaderboard); err != nil {
-					zap.Error(err, "unmarshal cached leaderboard error")
+					zap.ErrorContext(c.UserContext(), err, "unmarshal cached leaderboard error")
 				} else {
 					c.Locals("leaderboard", leaderboard)
 					return c.Next()
@@ -96,7 +96,7 @@ func buildGetLeaderboardMiddleware(cache fiber.Storage, expiration time.Duration
 			}
 		}
 
-		leaderboard, err := getLeaderboardByIDAndGameIDFunc(c.Context(), id, claims.GameID)
+		leaderboard, err := getLeaderboardByIDAndGameIDFunc(c.UserContext(), id, claims.GameID)
 		if err != nil {
 			return err
 		}
@@ -104,10 +104,10 @@ func buildGetLeaderboardMiddleware(cache fiber.Storage, expiration time.Duration
 		if cache != nil {
 			data, err := json.Marshal(leaderboard)
 			if err != nil {
-				zap.Error(err, "marshal leaderboard cache error")
+				zap.ErrorContext(c.UserContext(), err, "marshal leaderboard cache error")
 			} else {
 				if err = cache.Set(cacheKey, data, expiration); err != nil {
-					zap.Error(err, "unable to cache leaderboard")
+					zap.ErrorContext(c.UserContext(), err, "unable to cache leaderboard")
 				}
 			}
 		}
@@ -118,15 +118,15 @@ func buildGetLeaderboardMiddleware(cache fiber.Storage, expiration time.Duration
 }
 
 // @summary Create Leaderboard
-// @description Create a leaderboard
+// @description Create a leaderboard. Fails when the game already keeps as many leaderboards as its quota allows
 // @router /api/v1/leaderboards [POST]
 // @accept json
 // @produce json
 // @param Authorization header string true "Game's JWT authorization"
 // @param NewLeaderboardData body CreateLeaderboardReq true "New leaderboard config data"
 // @success 201 {object} Leaderboard
-// @failure 400,422,500 {object} ErrorResponse
-func buildCreateLeaderboardHandler(createLeaderboardFunc leaderboard.CreateFunc) fiber.Handler {
+// @failure 400,403,422,500 {object} ErrorResponse
+func buildCreateLeaderboardHandler(checkLeaderboardQuotaFunc quota.CheckFunc, createLeaderboardFunc leaderboard.CreateFunc) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		claims := c.Locals("claims").(auth.Claims)
 
@@ -135,7 +135,13 @@ func buildCreateLeaderboardHandler(createLeaderboardFunc leaderboard.CreateFunc)
 			return err
 		}
 
-		leaderboard, err := createLeaderboardFunc(c.Context(), body.toDomain(claims.GameID))
+		if checkLeaderboardQuotaFunc != nil {
+			if err := checkLeaderboardQuotaFunc(c.UserContext(), claims.GameID); err != nil {
+				return err
+			}
+		}
+
+		leaderboard, err := createLeaderboardFunc(c.UserContext(), body.toDomain(claims.GameID))
 		if err != nil {
 			return err
 		}
@@ -159,7 +165,7 @@ func buildGetLeaderboardHandler(getLeaderboardByIDAndGameIDFunc leaderboard.GetB
 			claims = c.Locals("claims").(auth.Claims)
 		)
 
-		leaderboard, err := getLeaderboardByIDAndGameIDFunc(c.Context(), id, claims.GameID)
+		leaderboard, err := getLeaderboardByIDAndGameIDFunc(c.UserContext(), id, claims.GameID)
 		if err != nil {
 			return err
 		}
@@ -175,17 +181,18 @@ func buildGetLeaderboardHandler(getLeaderboardByIDAndGameIDFunc leaderboard.GetB
 // @param leaderboardId path string true "Leaderboard ID"
 // @success 204
 // @failure 404,422,500 {object} ErrorResponse
-func buildDeleteLeaderboardHandler(deleteLeaderboardByIDAndGameIDFunc leaderboard.SoftDeleteFunc) fiber.Handler {
+func buildDeleteLeaderboardHandler(cache fiber.Storage, deleteLeaderboardByIDAndGameIDFunc leaderboard.SoftDeleteFunc) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var (
 			id     = c.Params("leaderboardId")
 			claims = c.Locals("claims").(auth.Claims)
 		)
 
-		if err := deleteLeaderboardByIDAndGameIDFunc(c.Context(), id, claims.GameID); err != nil {
+		if err := deleteLeaderboardByIDAndGameIDFunc(c.UserContext(), id, claims.GameID); err != nil {
 			return err
 		}
 
+		invalidateCache(cache, c, leaderboardCacheKey(id, claims.GameID))
 		return c.SendStatus(http.StatusNoContent)
 	}
 }
@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Comments sent while no event is, so proxies keep the stream open and a gone client is noticed
+const leaderboardEventsHeartbeatInterval = 15 * time.Second
+
+type LeaderboardClosedEvent struct {
+	LeaderboardID string `json:"leaderboardId"` // Leaderboard's ID
+}
+
+// Writes the events of a Server-Sent Events stream. Safe for concurrent use
+type sseWriter struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+func (s *sseWriter) event(event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return s.write(fmt.Sprintf("event: %s\ndata: %s\n\n", event, b))
+}
+
+func (s *sseWriter) heartbeat() error {
+	return s.write(": heartbeat\n\n")
+}
+
+func (s *sseWriter) write(msg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.w.WriteString(msg); err != nil {
+		return err
+	}
+
+	return s.w.Flush()
+}
+
+// @summary Stream Leaderboard Events
+// @description Server-Sent Events stream of the leaderboard rank changes, as `rank_changed` events with the player's rank.
+// @description A `closed` event ends the stream once the leaderboard closes. Ended leaderboards answer with no content
+// @router /api/v1/leaderboards/{leaderboardId}/events [GET]
+// @produce text/event-stream
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @success 200 {object} Rank
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildStreamLeaderboardEventsHandler(streamEventsFunc leaderboard.StreamEventsFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		lb := c.Locals("leaderboard").(leaderboard.Leaderboard)
+
+		// EventSource clients reconnect whenever a stream ends, except when told there's no content
+		if lb.Ended() {
+			return c.SendStatus(fiber.StatusNoContent)
+		}
+
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		c.Set(fiber.HeaderConnection, "keep-alive")
+		c.Set("X-Accel-Buffering", "no")
+
+		// The stream is written once the handler returns, so nothing is taken from the request past this point
+		userCtx := c.UserContext()
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			ctx, cancel := context.WithCancel(userCtx)
+			defer cancel()
+
+			stream := &sseWriter{w: w}
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				ticker := time.NewTicker(leaderboardEventsHeartbeatInterval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						if err := stream.heartbeat(); err != nil {
+							cancel()
+							return
+						}
+					}
+				}
+			}()
+
+			err := streamEventsFunc(ctx, lb, func(event leaderboard.StreamEvent) error {
+				switch event.Event {
+				case leaderboard.EventRankChanged:
+					return stream.event(strings.ToLower(event.Event), rankFromDomain(event.Rank))
+				default:
+					return stream.event(strings.ToLower(event.Event), LeaderboardClosedEvent{LeaderboardID: lb.ID})
+				}
+			})
+			if err != nil && ctx.Err() == nil {
+				zap.ErrorContext(ctx, err, "leaderboard events stream error", "leaderboardId", lb.ID)
+			}
+
+			cancel()
+			wg.Wait()
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildStreamLeaderboardEventsHandler(t *testing.T) {
+	var (
+		leaderboardID = uuid.NewString()
+		gameID        = uuid.NewString()
+		playerID      = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			StreamLeaderboardEventsFunc: func(ctx context.Context, lb leaderboard.Leaderboard, push func(event leaderboard.StreamEvent) error) error {
+				assert.Equal(t, leaderboardID, lb.ID)
+
+				if err := push(leaderboard.StreamEvent{Event: leaderboard.EventRankChanged, Rank: leaderboard.Rank{PlayerID: playerID, Position: 1, Value: 10}}); err != nil {
+					return err
+				}
+
+				return push(leaderboard.StreamEvent{Event: leaderboard.EventClosed})
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s/events", leaderboardID), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		expected := fmt.Sprintf(
+			"event: rank_changed\ndata: {\"playerId\":%q,\"position\":1,\"value\":10}\n\nevent: closed\ndata: {\"leaderboardId\":%q}\n\n",
+			playerID,
+			leaderboardID,
+		)
+		assert.Equal(t, expected, string(body))
+	})
+
+	t.Run("Leaderboard Ended", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID, EndAt: time.Now().Add(-time.Hour)}, nil
+			},
+			StreamLeaderboardEventsFunc: func(ctx context.Context, lb leaderboard.Leaderboard, push func(event leaderboard.StreamEvent) error) error {
+				assert.Fail(t, "streamed an ended leaderboard")
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s/events", leaderboardID), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
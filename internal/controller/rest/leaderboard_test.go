@@ -103,7 +103,7 @@ func TestBuildCreateLeaderboardHandler(t *testing.T) {
 			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
 				return auth.Claims{GameID: gameID}, nil
 			},
-			CreateLeaderboardFunc: leaderboard.BuildCreateFunc(func(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
+			CreateLeaderboardFunc: leaderboard.BuildCreateFunc(nil, func(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
 				return leaderboard.Leaderboard{
 					ID:              uuid.NewString(),
 					GameID:          data.GameID,
@@ -157,7 +157,7 @@ func TestBuildCreateLeaderboardHandler(t *testing.T) {
 			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
 				return auth.Claims{GameID: gameID}, nil
 			},
-			CreateLeaderboardFunc: leaderboard.BuildCreateFunc(func(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
+			CreateLeaderboardFunc: leaderboard.BuildCreateFunc(nil, func(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
 				return leaderboard.Leaderboard{}, nil
 			}),
 		})
@@ -187,7 +187,7 @@ func TestBuildCreateLeaderboardHandler(t *testing.T) {
 			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
 				return auth.Claims{GameID: gameID}, nil
 			},
-			CreateLeaderboardFunc: leaderboard.BuildCreateFunc(func(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
+			CreateLeaderboardFunc: leaderboard.BuildCreateFunc(nil, func(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
 				return leaderboard.Leaderboard{ID: uuid.NewString()}, nil
 			}),
 		})
@@ -219,7 +219,7 @@ func TestBuildCreateLeaderboardHandler(t *testing.T) {
 			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
 				return auth.Claims{GameID: gameID}, nil
 			},
-			CreateLeaderboardFunc: leaderboard.BuildCreateFunc(func(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
+			CreateLeaderboardFunc: leaderboard.BuildCreateFunc(nil, func(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
 				return leaderboard.Leaderboard{}, errors.New("any error")
 			}),
 		})
@@ -388,7 +388,7 @@ func TestBuildDeleteLeaderboardHandler(t *testing.T) {
 			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
 				return auth.Claims{GameID: expectedGameID}, nil
 			},
-			DeleteLeaderboardByIDAndGameIDFunc: leaderboard.BuildSoftDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			DeleteLeaderboardByIDAndGameIDFunc: leaderboard.BuildSoftDeleteFunc(nil, func(ctx context.Context, id, gameID string) error {
 				return nil
 			}),
 		})
@@ -409,7 +409,7 @@ func TestBuildDeleteLeaderboardHandler(t *testing.T) {
 			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
 				return auth.Claims{GameID: expectedGameID}, nil
 			},
-			DeleteLeaderboardByIDAndGameIDFunc: leaderboard.BuildSoftDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			DeleteLeaderboardByIDAndGameIDFunc: leaderboard.BuildSoftDeleteFunc(nil, func(ctx context.Context, id, gameID string) error {
 				return leaderboard.ErrLeaderboardNotFound
 			}),
 		})
@@ -440,7 +440,7 @@ func TestBuildDeleteLeaderboardHandler(t *testing.T) {
 			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
 				return auth.Claims{GameID: expectedGameID}, nil
 			},
-			DeleteLeaderboardByIDAndGameIDFunc: leaderboard.BuildSoftDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			DeleteLeaderboardByIDAndGameIDFunc: leaderboard.BuildSoftDeleteFunc(nil, func(ctx context.Context, id, gameID string) error {
 				return errors.New("any error")
 			}),
 		})
@@ -0,0 +1,241 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/level"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CreateLevelCurveReq struct {
+	Name        string    `json:"name"`                                  // Curve name, e.g. the account level or the season pass, up to 64 characters
+	StatisticID string    `json:"statisticId"`                           // Statistic whose value is the player's XP. Must use the `SUM` aggregation mode
+	Kind        string    `json:"kind" enums:"TABLE,LINEAR,EXPONENTIAL"` // How the XP of each level is defined
+	Thresholds  []float64 `json:"thresholds,omitempty"`                  // Total XP to reach each level from level 2 on, increasing. Only on `TABLE`
+	Base        float64   `json:"base,omitempty"`                        // XP to go from level 1 to level 2. Only on `LINEAR` and `EXPONENTIAL`
+	Growth      float64   `json:"growth,omitempty"`                      // Multiplier of the XP of each level over the previous one, greater than 1. Only on `EXPONENTIAL`
+	MaxLevel    int64     `json:"maxLevel,omitempty"`                    // Highest level. Only on `LINEAR` and `EXPONENTIAL`
+}
+
+func (r CreateLevelCurveReq) toDomain(gameID string) level.NewCurveData {
+	return level.NewCurveData{
+		GameID:      gameID,
+		Name:        r.Name,
+		StatisticID: r.StatisticID,
+		Kind:        r.Kind,
+		Thresholds:  r.Thresholds,
+		Base:        r.Base,
+		Growth:      r.Growth,
+		MaxLevel:    r.MaxLevel,
+	}
+}
+
+type LevelCurve struct {
+	CreatedAt   time.Time `json:"createdAt"`                             // Time the curve was created
+	ID          string    `json:"id"`                                    // Curve ID
+	GameID      string    `json:"gameId"`                                // ID of the game responsible for the curve
+	Name        string    `json:"name"`                                  // Curve name
+	StatisticID string    `json:"statisticId"`                           // Statistic whose value is the player's XP
+	Kind        string    `json:"kind" enums:"TABLE,LINEAR,EXPONENTIAL"` // How the XP of each level is defined
+	Thresholds  []float64 `json:"thresholds,omitempty"`                  // Total XP to reach each level from level 2 on. Only on `TABLE`
+	Base        float64   `json:"base,omitempty"`                        // XP to go from level 1 to level 2. Only on `LINEAR` and `EXPONENTIAL`
+	Growth      float64   `json:"growth,omitempty"`                      // Multiplier of the XP of each level over the previous one. Only on `EXPONENTIAL`
+	MaxLevel    int64     `json:"maxLevel"`                              // Highest level
+}
+
+func levelCurveFromDomain(c level.Curve) LevelCurve {
+	maxLevel := c.MaxLevel
+	if c.Kind == level.KindTable {
+		maxLevel = int64(len(c.Thresholds)) + 1
+	}
+
+	return LevelCurve{
+		CreatedAt:   c.CreatedAt,
+		ID:          c.ID,
+		GameID:      c.GameID,
+		Name:        c.Name,
+		StatisticID: c.StatisticID,
+		Kind:        c.Kind,
+		Thresholds:  c.Thresholds,
+		Base:        c.Base,
+		Growth:      c.Growth,
+		MaxLevel:    maxLevel,
+	}
+}
+
+type PlayerLevel struct {
+	CurveID       string   `json:"curveId"`                 // Curve the level is on
+	PlayerID      string   `json:"playerId"`                // Player's ID
+	XP            float64  `json:"xp"`                      // Player's XP
+	Level         int64    `json:"level"`                   // Player's level, starting at 1
+	LevelXP       float64  `json:"levelXp"`                 // Total XP to reach the player's level
+	NextLevelXP   *float64 `json:"nextLevelXp,omitempty"`   // Total XP to reach the next level. Missing on the max level
+	XPToNextLevel *float64 `json:"xpToNextLevel,omitempty"` // XP the player still needs to reach the next level. Missing on the max level
+}
+
+func playerLevelFromDomain(l level.PlayerLevel) PlayerLevel {
+	return PlayerLevel{
+		CurveID:       l.CurveID,
+		PlayerID:      l.PlayerID,
+		XP:            l.XP,
+		Level:         l.Level,
+		LevelXP:       l.LevelXP,
+		NextLevelXP:   l.NextLevelXP,
+		XPToNextLevel: l.XPToNextLevel,
+	}
+}
+
+var (
+	ErrorResponseLevelCurveInvalid         = ErrorResponse{Code: "27.0", Message: "Invalid level curve"}
+	ErrorResponseLevelCurveNotFound        = ErrorResponse{Code: "27.1", Message: "Level curve not found"}
+	ErrorResponseLevelCurveInvalidID       = ErrorResponse{Code: "27.2", Message: "Invalid level curve id"}
+	ErrorResponseLevelCurveInvalidPlayer   = ErrorResponse{Code: "27.3", Message: "Invalid player id"}
+	ErrorResponseLevelCurveStatisticNotSum = ErrorResponse{Code: "27.4", Message: "The XP statistic must use the SUM aggregation mode"}
+)
+
+// @summary Create Level Curve
+// @description Create a level curve, mapping the value of a statistic, the player's XP, to their level. The statistic must
+// @description belong to the game and use the SUM aggregation mode. The levels are listed one by one on a TABLE, or follow
+// @description the LINEAR and EXPONENTIAL formulas up to their max level. A level-up is published each time a player's XP
+// @description update takes them to a higher level
+// @router /api/v1/level-curves [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param CreateLevelCurveReq body CreateLevelCurveReq true "New level curve config data"
+// @success 201 {object} LevelCurve
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildCreateLevelCurveHandler(createLevelCurveFunc level.CreateFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body CreateLevelCurveReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		curve, err := createLevelCurveFunc(c.UserContext(), body.toDomain(claims.GameID))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(levelCurveFromDomain(curve))
+	}
+}
+
+// @summary List Level Curves
+// @description List the game's level curves, oldest first
+// @router /api/v1/level-curves [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @success 200 {array} LevelCurve
+// @failure 500 {object} ErrorResponse
+func buildListLevelCurvesHandler(listLevelCurvesFunc level.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		curves, err := listLevelCurvesFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		res := make([]LevelCurve, len(curves))
+		for i, curve := range curves {
+			res[i] = levelCurveFromDomain(curve)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Get Level Curve By ID
+// @description Get a level curve by its id
+// @router /api/v1/level-curves/{curveId} [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param curveId path string true "Level Curve ID"
+// @success 200 {object} LevelCurve
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetLevelCurveHandler(getLevelCurveFunc level.GetByIDAndGameIDFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		curve, err := getLevelCurveFunc(c.UserContext(), c.Params("curveId"), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(levelCurveFromDomain(curve))
+	}
+}
+
+// @summary Delete Level Curve
+// @description Delete a level curve by its id. The XP statistic and the players' progression on it are kept
+// @router /api/v1/level-curves/{curveId} [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param curveId path string true "Level Curve ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildDeleteLevelCurveHandler(deleteLevelCurveFunc level.DeleteFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := deleteLevelCurveFunc(c.UserContext(), c.Params("curveId"), claims.GameID); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary Get Player Level
+// @description Get the player's level on the curve, from their current XP. A player without progression on the XP
+// @description statistic has its initial value as XP
+// @router /api/v1/level-curves/{curveId}/players/{playerId} [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param curveId path string true "Level Curve ID"
+// @param playerId path string true "Player ID"
+// @success 200 {object} PlayerLevel
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetPlayerLevelHandler(getPlayerLevelFunc level.GetPlayerLevelFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		l, err := getPlayerLevelFunc(c.UserContext(), claims.GameID, c.Params("curveId"), c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(playerLevelFromDomain(l))
+	}
+}
+
+// @summary List Player Levels
+// @description List the player's level on each of the game's curves, in the order the curves were created
+// @router /api/v1/players/{playerId}/levels [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {array} PlayerLevel
+// @failure 404,422,500 {object} ErrorResponse
+func buildListPlayerLevelsHandler(listPlayerLevelsFunc level.ListPlayerLevelsFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		levels, err := listPlayerLevelsFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		res := make([]PlayerLevel, len(levels))
+		for i, l := range levels {
+			res[i] = playerLevelFromDomain(l)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
@@ -0,0 +1,234 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Every statistic but the one given is missing, and every curve is a table on it, with the player at 180 XP
+func newLevelTestConfig(gameID, statisticID string) Config {
+	var (
+		xp    = float64(180)
+		curve = func(id string) level.Curve {
+			return level.Curve{ID: id, GameID: gameID, Name: "Account", StatisticID: statisticID, Kind: level.KindTable, Thresholds: []float64{100, 250}, MaxLevel: 3}
+		}
+	)
+
+	getStatistic := func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+		if id != statisticID {
+			return statistic.Statistic{}, statistic.ErrStatisticNotFound
+		}
+
+		return statistic.Statistic{ID: id, GameID: gameID, AggregationMode: statistic.AggregationModeSum}, nil
+	}
+	getProgression := func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+		return statistic.PlayerProgression{StatisticID: statisticID, PlayerID: playerID, CurrentValue: &xp}, nil
+	}
+	storageGet := func(ctx context.Context, id, gameID string) (level.Curve, error) {
+		return curve(id), nil
+	}
+	storageList := func(ctx context.Context, gameID string) ([]level.Curve, error) {
+		return []level.Curve{curve("curve")}, nil
+	}
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		CreateLevelCurveFunc: level.BuildCreateFunc(getStatistic, func(ctx context.Context, data level.NewCurveData) (level.Curve, error) {
+			return level.Curve{
+				ID:          uuid.NewString(),
+				GameID:      data.GameID,
+				Name:        data.Name,
+				StatisticID: data.StatisticID,
+				Kind:        data.Kind,
+				Thresholds:  data.Thresholds,
+				Base:        data.Base,
+				Growth:      data.Growth,
+				MaxLevel:    data.MaxLevel,
+			}, nil
+		}),
+		GetLevelCurveFunc:   level.BuildGetByIDAndGameIDFunc(storageGet),
+		ListLevelCurvesFunc: level.BuildListFunc(storageList),
+		DeleteLevelCurveFunc: level.BuildDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			return nil
+		}),
+		GetPlayerLevelFunc:   level.BuildGetPlayerLevelFunc(getStatistic, getProgression, storageGet),
+		ListPlayerLevelsFunc: level.BuildListPlayerLevelsFunc(getStatistic, getProgression, storageList),
+	}
+}
+
+func TestBuildCreateLevelCurveHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		statisticID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newLevelTestConfig(gameID, statisticID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/level-curves", bytes.NewBufferString(`{"name": "Account", "statisticId": "`+statisticID+`", "kind": "TABLE", "thresholds": [100, 250]}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body LevelCurve
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, gameID, body.GameID)
+		assert.Equal(t, []float64{100, 250}, body.Thresholds)
+		assert.Equal(t, int64(3), body.MaxLevel)
+	})
+
+	t.Run("Invalid Curve", func(t *testing.T) {
+		app := App(newLevelTestConfig(gameID, statisticID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/level-curves", bytes.NewBufferString(`{"name": "Account", "statisticId": "`+statisticID+`", "kind": "EXPONENTIAL", "base": 100, "growth": 1}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseLevelCurveInvalid.Code, body.Code)
+		assert.Contains(t, body.Details, level.ErrInvalidGrowth.Error())
+		assert.Contains(t, body.Details, level.ErrInvalidMaxLevel.Error())
+	})
+
+	t.Run("Statistic Not Found", func(t *testing.T) {
+		app := App(newLevelTestConfig(gameID, statisticID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/level-curves", bytes.NewBufferString(`{"name": "Account", "statisticId": "`+uuid.NewString()+`", "kind": "LINEAR", "base": 100, "maxLevel": 10}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildGetLevelCurveHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		statisticID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newLevelTestConfig(gameID, statisticID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/level-curves/curve", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body LevelCurve
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "curve", body.ID)
+		assert.Equal(t, statisticID, body.StatisticID)
+	})
+}
+
+func TestBuildDeleteLevelCurveHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		statisticID = uuid.NewString()
+	)
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newLevelTestConfig(gameID, statisticID)
+		config.DeleteLevelCurveFunc = level.BuildDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			return level.ErrCurveNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/level-curves/"+uuid.NewString(), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildGetPlayerLevelHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		statisticID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newLevelTestConfig(gameID, statisticID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/level-curves/curve/players/alice", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body PlayerLevel
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(2), body.Level)
+		assert.Equal(t, float64(70), *body.XPToNextLevel)
+	})
+}
+
+func TestBuildListPlayerLevelsHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		statisticID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newLevelTestConfig(gameID, statisticID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/alice/levels", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []PlayerLevel
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, int64(2), body[0].Level)
+		assert.Equal(t, float64(70), *body[0].XPToNextLevel)
+	})
+}
@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+const liveRankingDefaultTop = 10
+
+const (
+	LiveRankingMessageSnapshot = "snapshot"
+	LiveRankingMessageUpdate   = "update"
+)
+
+type LiveRankingMessage struct {
+	Type   string `json:"type"`   // Message type. `snapshot` for the first one, `update` afterwards
+	Top    []Rank `json:"top"`    // Leaderboard top. On updates, null when it didn't change
+	Player *Rank  `json:"player"` // Player's rank. Null when it didn't change or the player isn't ranked yet
+}
+
+func liveRankingMessageFromDomain(update leaderboard.RankingUpdate) LiveRankingMessage {
+	msg := LiveRankingMessage{Type: LiveRankingMessageUpdate}
+	if update.Snapshot {
+		msg.Type = LiveRankingMessageSnapshot
+	}
+
+	if update.Top != nil {
+		msg.Top = make([]Rank, len(update.Top))
+		for i, r := range update.Top {
+			msg.Top[i] = rankFromDomain(r)
+		}
+	}
+
+	if update.Player != nil {
+		player := rankFromDomain(*update.Player)
+		msg.Player = &player
+	}
+
+	return msg
+}
+
+var ErrorResponseLiveRankingTopNumber = ErrorResponse{Code: "2.3", Message: "invalid live top number"}
+
+// @summary Watch Ranking
+// @description Upgrade to a WebSocket that pushes the leaderboard top and the player's rank whenever they change.
+// @description The first message is a `snapshot`; the following ones are `update`s with only what changed
+// @router /api/v1/leaderboards/{leaderboardId}/ranking/live [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @param top query int false "Number of ranks on the top" default(10)
+// @param playerId query string false "Player ID whose rank will also be watched"
+// @success 101 {object} LiveRankingMessage
+// @failure 404,422,426,500 {object} ErrorResponse
+func buildWatchRankingHandler(watchRankingFunc leaderboard.WatchRankingFunc) fiber.Handler {
+	upgrade := websocket.New(func(conn *websocket.Conn) {
+		var (
+			ctx      = conn.Locals("ctx").(context.Context)
+			lb       = conn.Locals("leaderboard").(leaderboard.Leaderboard)
+			top      = int64(conn.Locals("top").(int))
+			playerID = conn.Query("playerId")
+		)
+
+		err := serveWebSocket(ctx, conn, func(ctx context.Context, write func(msg any) error) error {
+			return watchRankingFunc(ctx, lb, playerID, top, func(update leaderboard.RankingUpdate) error {
+				return write(liveRankingMessageFromDomain(update))
+			})
+		})
+		if err != nil {
+			zap.ErrorContext(ctx, err, "live ranking watch error", "leaderboardId", lb.ID)
+		}
+	})
+
+	return func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+
+		// Once upgraded there's no HTTP response left to report an invalid request
+		top := c.QueryInt("top", liveRankingDefaultTop)
+		if top < leaderboard.MinLiveTop || top > leaderboard.MaxLiveTop {
+			return leaderboard.ErrInvalidLiveTop
+		}
+
+		c.Locals("top", top)
+		c.Locals("ctx", c.UserContext())
+		return upgrade(c)
+	}
+}
@@ -0,0 +1,136 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	fasthttpws "github.com/fasthttp/websocket"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWatchRankingHandler(t *testing.T) {
+	var (
+		leaderboardID = uuid.NewString()
+		gameID        = uuid.NewString()
+		playerID      = uuid.NewString()
+	)
+
+	config := func(watchRankingFunc leaderboard.WatchRankingFunc) Config {
+		return Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			WatchRankingFunc: watchRankingFunc,
+		}
+	}
+
+	upgradeRequest := func(target string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Authorization", uuid.NewString())
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Version", "13")
+		req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		return req
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			called    = false
+			playerTop = leaderboard.Rank{PlayerID: playerID, Position: 0, Value: 100}
+		)
+
+		app := App(config(func(ctx context.Context, lb leaderboard.Leaderboard, id string, top int64, push func(update leaderboard.RankingUpdate) error) error {
+			called = true
+			assert.Equal(t, leaderboardID, lb.ID)
+			assert.Equal(t, playerID, id)
+			assert.Equal(t, int64(5), top)
+
+			if err := push(leaderboard.RankingUpdate{Snapshot: true, Top: []leaderboard.Rank{playerTop}, Player: &playerTop}); err != nil {
+				return err
+			}
+
+			playerTop.Value = 200
+			return push(leaderboard.RankingUpdate{Player: &playerTop})
+		}))
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+
+		go app.Listener(ln)
+		defer app.Shutdown()
+
+		header := http.Header{}
+		header.Set("Authorization", uuid.NewString())
+
+		url := fmt.Sprintf("ws://%s/api/v1/leaderboards/%s/ranking/live?top=5&playerId=%s", ln.Addr(), leaderboardID, playerID)
+		conn, _, err := fasthttpws.DefaultDialer.Dial(url, header)
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		var msg LiveRankingMessage
+		assert.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, LiveRankingMessageSnapshot, msg.Type)
+		assert.Equal(t, []Rank{{PlayerID: playerID, Position: 0, Value: 100}}, msg.Top)
+		assert.Equal(t, &Rank{PlayerID: playerID, Position: 0, Value: 100}, msg.Player)
+
+		msg = LiveRankingMessage{}
+		assert.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, LiveRankingMessageUpdate, msg.Type)
+		assert.Nil(t, msg.Top)
+		assert.Equal(t, &Rank{PlayerID: playerID, Position: 0, Value: 200}, msg.Player)
+
+		_, _, err = conn.ReadMessage()
+		assert.True(t, fasthttpws.IsCloseError(err, fasthttpws.CloseNormalClosure))
+		assert.True(t, called)
+	})
+
+	t.Run("Upgrade Required", func(t *testing.T) {
+		app := App(config(func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, top int64, push func(update leaderboard.RankingUpdate) error) error {
+			return nil
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s/ranking/live", leaderboardID), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUpgradeRequired, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseUpgradeRequired.Code, data.Code)
+		assert.Equal(t, ErrorResponseUpgradeRequired.Message, data.Message)
+	})
+
+	t.Run("Invalid Top", func(t *testing.T) {
+		app := App(config(func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, top int64, push func(update leaderboard.RankingUpdate) error) error {
+			return nil
+		}))
+
+		resp, err := app.Test(upgradeRequest(fmt.Sprintf("/api/v1/leaderboards/%s/ranking/live?top=%d", leaderboardID, leaderboard.MaxLiveTop+1)))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseLiveRankingTopNumber.Code, data.Code)
+		assert.Equal(t, ErrorResponseLiveRankingTopNumber.Message, data.Message)
+	})
+}
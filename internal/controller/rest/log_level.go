@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var ErrInvalidLogLevelDuration = errors.New("log level duration must not be negative")
+
+type (
+	// Current minimum level logged, e.g. `info`
+	GetLogLevelFunc func() string
+
+	// Changes the minimum level logged. With a positive duration, the previous level is restored once it elapses
+	SetLogLevelFunc func(level string, duration time.Duration) error
+)
+
+type LogLevel struct {
+	Level string `json:"level" enums:"debug,info,warn,error"` // Minimum level logged
+}
+
+type SetLogLevelReq struct {
+	Level           string `json:"level" enums:"debug,info,warn,error"` // Minimum level logged
+	DurationSeconds int    `json:"durationSeconds"`                     // Seconds until the previous level is restored. Kept until changed again when zero
+}
+
+var (
+	ErrorResponseLogLevelInvalid         = ErrorResponse{Code: "12.0", Message: "Invalid log level"}
+	ErrorResponseLogLevelInvalidDuration = ErrorResponse{Code: "12.1", Message: "Invalid log level duration"}
+)
+
+// @summary Get Log Level
+// @description Get the minimum level logged by this instance
+// @router /admin/v1/log-level [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @success 200 {object} LogLevel
+// @failure 401,403,500 {object} ErrorResponse
+func buildGetLogLevelHandler(getLogLevelFunc GetLogLevelFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(http.StatusOK).JSON(LogLevel{Level: getLogLevelFunc()})
+	}
+}
+
+// @summary Set Log Level
+// @description Change the minimum level logged by this instance without restarting it, e.g. to enable the debug logs during an incident.
+// @description Only the instance handling the request is changed
+// @router /admin/v1/log-level [PUT]
+// @accept json
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param SetLogLevelReq body SetLogLevelReq true "New log level"
+// @success 200 {object} LogLevel
+// @failure 400,401,403,422,500 {object} ErrorResponse
+func buildSetLogLevelHandler(getLogLevelFunc GetLogLevelFunc, setLogLevelFunc SetLogLevelFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body SetLogLevelReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		if body.DurationSeconds < 0 {
+			return ErrInvalidLogLevelDuration
+		}
+
+		if err := setLogLevelFunc(body.Level, time.Duration(body.DurationSeconds)*time.Second); err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(LogLevel{Level: getLogLevelFunc()})
+	}
+}
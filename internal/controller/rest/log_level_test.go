@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevelHandlers(t *testing.T) {
+	var (
+		adminToken        = uuid.NewString()
+		level             = "info"
+		requestedDuration time.Duration
+	)
+
+	app := App(Config{
+		AdminToken:      adminToken,
+		GetLogLevelFunc: func() string { return level },
+		SetLogLevelFunc: func(l string, duration time.Duration) error {
+			if l != "debug" && l != "info" {
+				return zap.ErrInvalidLevel
+			}
+
+			level, requestedDuration = l, duration
+			return nil
+		},
+	})
+
+	setLogLevel := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPut, "/admin/v1/log-level", bytes.NewBufferString(body))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/log-level", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body LogLevel
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, LogLevel{Level: "info"}, body)
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		resp := setLogLevel(`{"level":"debug","durationSeconds":600}`)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body LogLevel
+		err := json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, LogLevel{Level: "debug"}, body)
+		assert.Equal(t, 10*time.Minute, requestedDuration)
+	})
+
+	t.Run("Invalid Level", func(t *testing.T) {
+		resp := setLogLevel(`{"level":"verbose"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err := json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrorResponseLogLevelInvalid, data)
+	})
+
+	t.Run("Invalid Duration", func(t *testing.T) {
+		resp := setLogLevel(`{"level":"debug","durationSeconds":-1}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err := json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrorResponseLogLevelInvalidDuration, data)
+	})
+}
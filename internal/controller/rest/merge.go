@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/merge"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MergePlayersReq struct {
+	TargetPlayerID string `json:"targetPlayerId"` // Player kept, receiving the data of the player merged
+}
+
+type PlayerMerge struct {
+	Data    string `json:"data"`    // Kind of the data merged: `RANKS`, `STATISTIC_PROGRESSION` or `QUEST_PROGRESSION`
+	Records int64  `json:"records"` // Records of the player merged combined into the target player's ones
+}
+
+type PlayerMergeReceipt struct {
+	ID             string        `json:"id"`             // Receipt ID, the ID of the audit entry recording the merge
+	GameID         string        `json:"gameId"`         // Game both players belong to
+	SourcePlayerID string        `json:"sourcePlayerId"` // Player merged, left without ranks, statistic nor quest progression
+	TargetPlayerID string        `json:"targetPlayerId"` // Player kept, holding the data of both
+	MergedAt       time.Time     `json:"mergedAt"`       // Time the merge completed
+	Merges         []PlayerMerge `json:"merges"`         // Data combined of each kind
+}
+
+func playerMergeReceiptFromDomain(r merge.Receipt) PlayerMergeReceipt {
+	merges := make([]PlayerMerge, len(r.Merges))
+	for i, m := range r.Merges {
+		merges[i] = PlayerMerge{
+			Data:    m.Data,
+			Records: m.Records,
+		}
+	}
+
+	return PlayerMergeReceipt{
+		ID:             r.ID,
+		GameID:         r.GameID,
+		SourcePlayerID: r.SourcePlayerID,
+		TargetPlayerID: r.TargetPlayerID,
+		MergedAt:       r.MergedAt,
+		Merges:         merges,
+	}
+}
+
+var ErrorResponsePlayerMergeInvalid = ErrorResponse{Code: "28.0", Message: "Invalid player merge"}
+
+// @summary Merge Players
+// @description Merge a player into another one of the same game, e.g. after an account was linked to the wrong player.
+// @description Each rank of the player merged is combined with the target player's one by the leaderboard's aggregation mode,
+// @description the values added up on INC and the best one kept on MAX and MIN. Each statistic progression is combined by the
+// @description statistic's aggregation mode, completing the landmarks and goals the combined value reaches. Each quest ends up
+// @description with the tasks completed by either player. The player merged is left without ranks, statistic nor quest progression,
+// @description including on the soft deleted leaderboards, statistics and quests, while the rest of their data is kept. The merge
+// @description is recorded on the game's audit log. It isn't atomic: when it fails, what was combined so far is kept and retrying
+// @description it combines the rest, adding the SUM and SUB statistic progressions combined before the failure again
+// @router /admin/v1/games/{gameId}/players/{playerId}/merge [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param playerId path string true "ID of the player merged"
+// @param MergePlayersReq body MergePlayersReq true "Player the merged player's data goes to"
+// @success 200 {object} PlayerMergeReceipt
+// @failure 400,401,403,422,500 {object} ErrorResponse
+func buildMergePlayersHandler(mergePlayersFunc merge.MergePlayersFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body MergePlayersReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		receipt, err := mergePlayersFunc(c.UserContext(), c.Params("gameId"), c.Params("playerId"), body.TargetPlayerID)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(playerMergeReceiptFromDomain(receipt))
+	}
+}
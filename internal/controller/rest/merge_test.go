@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/merge"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMergePlayersHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			MergePlayersFunc: merge.BuildMergePlayersFunc(
+				func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+					return audit.Entry{ID: "entry"}, nil
+				},
+				func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (merge.Merge, error) {
+					return merge.Merge{Data: merge.DataRanks, Records: 2}, nil
+				},
+			),
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/games/"+gameID+"/players/old/merge", bytes.NewBufferString(`{"targetPlayerId": "new"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body PlayerMergeReceipt
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "entry", body.ID)
+		assert.Equal(t, gameID, body.GameID)
+		assert.Equal(t, "old", body.SourcePlayerID)
+		assert.Equal(t, "new", body.TargetPlayerID)
+		assert.Equal(t, []PlayerMerge{{Data: merge.DataRanks, Records: 2}}, body.Merges)
+	})
+
+	t.Run("Same Player", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			MergePlayersFunc: merge.BuildMergePlayersFunc(
+				func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+					return audit.Entry{ID: "entry"}, nil
+				},
+				func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (merge.Merge, error) {
+					return merge.Merge{Data: merge.DataRanks, Records: 2}, nil
+				},
+			),
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/games/"+gameID+"/players/old/merge", bytes.NewBufferString(`{"targetPlayerId": "old"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponsePlayerMergeInvalid.Code, body.Code)
+		assert.Contains(t, body.Details, merge.ErrSamePlayer.Error())
+	})
+}
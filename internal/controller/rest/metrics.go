@@ -0,0 +1,22 @@
+package rest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Records a request handled by the API. The route is the pattern the request matched, e.g. `/api/v1/leaderboards/:leaderboardId`.
+// The context holds the span of the request when it is traced
+type ObserveRequestFunc func(ctx context.Context, method, route string, status int, duration time.Duration)
+
+func buildMetricsMiddleware(observeRequestFunc ObserveRequestFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		route, _ := nextHandled(c)
+		observeRequestFunc(c.UserContext(), c.Method(), route, c.Response().StatusCode(), time.Since(start))
+		return nil
+	}
+}
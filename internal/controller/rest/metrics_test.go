@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type observedRequest struct {
+	method string
+	route  string
+	status int
+}
+
+func TestBuildMetricsMiddleware(t *testing.T) {
+	var observed []observedRequest
+
+	app := App(Config{
+		ObserveRequestFunc: func(ctx context.Context, method, route string, status int, duration time.Duration) {
+			observed = append(observed, observedRequest{method: method, route: route, status: status})
+		},
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: uuid.NewString()}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+		},
+	})
+
+	t.Run("Route", func(t *testing.T) {
+		observed = nil
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+uuid.NewString(), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		assert.Equal(t, []observedRequest{{method: http.MethodGet, route: "/api/v1/leaderboards/:leaderboardId", status: http.StatusNotFound}}, observed)
+	})
+
+	t.Run("Unmatched", func(t *testing.T) {
+		zap.Start()
+		observed = nil
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/"+uuid.NewString(), nil))
+		assert.NoError(t, err)
+
+		assert.Equal(t, []observedRequest{{method: http.MethodGet, route: unmatchedRoute, status: resp.StatusCode}}, observed)
+	})
+}
@@ -0,0 +1,349 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/moderation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdjustEntryReq struct {
+	Value  float64 `json:"value"`  // Value the entry is set to
+	Reason string  `json:"reason"` // Why the entry is adjusted, up to 512 characters
+}
+
+type BulkRemoveEntriesReq struct {
+	Source string    `json:"source"` // Channel the scores were submitted through: `API`, `GRPC`, `MQTT` or `INGESTION`
+	From   time.Time `json:"from"`   // Scores submitted from this time on
+	To     time.Time `json:"to"`     // Scores submitted before this time
+	Reason string    `json:"reason"` // Why the entries are removed, up to 512 characters
+}
+
+type RollbackScoresReq struct {
+	PlayerID string    `json:"playerId,omitempty"` // Player whose scores are reverted. Every player's when empty
+	From     time.Time `json:"from"`               // Scores submitted from this time on
+	To       time.Time `json:"to"`                 // Scores submitted before this time
+	Reason   string    `json:"reason"`             // Why the scores are reverted, up to 512 characters
+}
+
+type ModerationRemovedEntry struct {
+	LeaderboardID string  `json:"leaderboardId"` // Leaderboard the entry was on
+	PlayerID      string  `json:"playerId"`      // Player of the entry
+	Value         float64 `json:"value"`         // Entry's value when it was removed
+}
+
+type ModerationRecomputedEntry struct {
+	PlayerID string   `json:"playerId"`           // Player of the entry
+	Previous *float64 `json:"previous,omitempty"` // Entry's value before the rollback. Not set when the player wasn't ranked
+	Value    *float64 `json:"value,omitempty"`    // Entry's value rebuilt from the scores kept. Not set when the entry was removed
+}
+
+type ModerationAction struct {
+	ID            string                      `json:"id"`                      // Action ID, the ID of the audit entry recording it
+	CreatedAt     time.Time                   `json:"createdAt"`               // Time the action was taken
+	Kind          string                      `json:"kind"`                    // `ADJUST`, `REMOVE`, `BULK_REMOVE` or `ROLLBACK`
+	Reason        string                      `json:"reason"`                  // Why the action was taken
+	LeaderboardID string                      `json:"leaderboardId,omitempty"` // Leaderboard of the entry adjusted or removed, or of the scores reverted
+	PlayerID      string                      `json:"playerId,omitempty"`      // Player of the entry adjusted or removed, or whose scores were reverted
+	Previous      *float64                    `json:"previous,omitempty"`      // Entry's value before the action. Not set when the player wasn't ranked
+	Value         *float64                    `json:"value,omitempty"`         // Value the entry was set to. Only set on the adjustments
+	Source        string                      `json:"source,omitempty"`        // Channel whose entries were removed. Only set on the bulk removals
+	From          *time.Time                  `json:"from,omitempty"`          // Start of the window whose entries were removed or scores reverted. Only set on the bulk removals and the rollbacks
+	To            *time.Time                  `json:"to,omitempty"`            // End of the window whose entries were removed or scores reverted. Only set on the bulk removals and the rollbacks
+	Entries       []ModerationRemovedEntry    `json:"entries,omitempty"`       // Entries removed. Only set on the bulk removals
+	Scores        int64                       `json:"scores,omitempty"`        // Scores reverted. Only set on the rollbacks
+	Recomputed    []ModerationRecomputedEntry `json:"recomputed,omitempty"`    // Entries recomputed. Only set on the rollbacks
+}
+
+type DeviceUsage struct {
+	DeviceHash   string    `json:"deviceHash"`             // Device the scores were submitted from
+	PlayerID     string    `json:"playerId"`               // Player the scores were submitted for
+	BuildVersion string    `json:"buildVersion,omitempty"` // Client build of the latest score
+	Region       string    `json:"region,omitempty"`       // Region of the latest score
+	FirstSeenAt  time.Time `json:"firstSeenAt"`            // Time of the first score
+	LastSeenAt   time.Time `json:"lastSeenAt"`             // Time of the latest score
+	Scores       int64     `json:"scores"`                 // Scores submitted
+}
+
+type ModerationEntry struct {
+	Rank    *Rank              `json:"rank"`    // Player's rank. Null when the player isn't ranked
+	Actions []ModerationAction `json:"actions"` // Actions taken on the entry, oldest first
+}
+
+func moderationActionFromDomain(a moderation.Action) ModerationAction {
+	data := ModerationAction{
+		ID:            a.ID,
+		CreatedAt:     a.CreatedAt,
+		Kind:          a.Kind,
+		Reason:        a.Reason,
+		LeaderboardID: a.LeaderboardID,
+		PlayerID:      a.PlayerID,
+		Previous:      a.Previous,
+		Value:         a.Value,
+		Source:        a.Source,
+	}
+
+	if a.Kind == moderation.ActionBulkRemove {
+		data.From, data.To = &a.From, &a.To
+		data.Entries = make([]ModerationRemovedEntry, len(a.Entries))
+		for i, entry := range a.Entries {
+			data.Entries[i] = ModerationRemovedEntry{LeaderboardID: entry.LeaderboardID, PlayerID: entry.PlayerID, Value: entry.Value}
+		}
+	}
+
+	if a.Kind == moderation.ActionRollback {
+		data.From, data.To = &a.From, &a.To
+		data.Scores = a.Scores
+		data.Recomputed = make([]ModerationRecomputedEntry, len(a.Recomputed))
+		for i, entry := range a.Recomputed {
+			data.Recomputed[i] = ModerationRecomputedEntry{PlayerID: entry.PlayerID, Previous: entry.Previous, Value: entry.Value}
+		}
+	}
+
+	return data
+}
+
+func moderationActionsFromDomain(actions []moderation.Action) []ModerationAction {
+	data := make([]ModerationAction, len(actions))
+	for i, a := range actions {
+		data[i] = moderationActionFromDomain(a)
+	}
+
+	return data
+}
+
+var (
+	ErrorResponseModerationInvalid         = ErrorResponse{Code: "37.0", Message: "Invalid moderation action"}
+	ErrorResponseModerationEntryNotFound   = ErrorResponse{Code: "37.1", Message: "Player not ranked on the leaderboard"}
+	ErrorResponseModerationTooManyEntries  = ErrorResponse{Code: "37.2", Message: "The action would change over 10000 entries, split it in narrower windows"}
+	ErrorResponseModerationInvalidPlayerID = ErrorResponse{Code: "37.3", Message: "Invalid player id"}
+	ErrorResponseModerationDeviceFilter    = ErrorResponse{Code: "37.4", Message: "Device hash or player id must be set"}
+	ErrorResponseModerationNoScores        = ErrorResponse{Code: "37.5", Message: "No scores submitted within the window"}
+)
+
+// @summary Inspect Leaderboard Entry
+// @description Get a player's entry on a leaderboard, alongside the moderation actions taken on it
+// @router /admin/v1/games/{gameId}/leaderboards/{leaderboardId}/ranking/{playerId} [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param leaderboardId path string true "Leaderboard ID"
+// @param playerId path string true "Player ID"
+// @success 200 {object} ModerationEntry
+// @failure 401,403,404,422,500 {object} ErrorResponse
+func buildInspectEntryHandler(inspectFunc moderation.InspectFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		entry, err := inspectFunc(c.UserContext(), c.Params("gameId"), c.Params("leaderboardId"), c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		res := ModerationEntry{Actions: moderationActionsFromDomain(entry.Actions)}
+		if entry.Rank != nil {
+			res.Rank = &Rank{PlayerID: entry.Rank.PlayerID, Position: entry.Rank.Position, Value: entry.Rank.Value}
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Adjust Leaderboard Entry
+// @description Set the value of a player's entry on a leaderboard, whatever the leaderboard's aggregation mode, ranking the
+// @description player when they aren't yet. The adjustment is recorded on the game's audit log with its reason. It's not
+// @description recorded on the score history, so the leaderboard's recompute brings back the value the scores add up to
+// @router /admin/v1/games/{gameId}/leaderboards/{leaderboardId}/ranking/{playerId} [PUT]
+// @accept json
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param leaderboardId path string true "Leaderboard ID"
+// @param playerId path string true "Player ID"
+// @param AdjustEntryReq body AdjustEntryReq true "Value and reason"
+// @success 200 {object} ModerationAction
+// @failure 400,401,403,404,422,500 {object} ErrorResponse
+func buildAdjustEntryHandler(adjustFunc moderation.AdjustFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body AdjustEntryReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		action, err := adjustFunc(c.UserContext(), moderation.AdjustData{
+			GameID:        c.Params("gameId"),
+			LeaderboardID: c.Params("leaderboardId"),
+			PlayerID:      c.Params("playerId"),
+			Value:         body.Value,
+			Reason:        body.Reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(moderationActionFromDomain(action))
+	}
+}
+
+// @summary Remove Leaderboard Entry
+// @description Remove a player's entry from a leaderboard. The removal is recorded on the game's audit log with its reason.
+// @description The player's scores are kept on the score history, so the leaderboard's recompute brings the entry back
+// @router /admin/v1/games/{gameId}/leaderboards/{leaderboardId}/ranking/{playerId} [DELETE]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param leaderboardId path string true "Leaderboard ID"
+// @param playerId path string true "Player ID"
+// @param reason query string true "Why the entry is removed, up to 512 characters"
+// @success 200 {object} ModerationAction
+// @failure 401,403,404,422,500 {object} ErrorResponse
+func buildRemoveEntryHandler(removeFunc moderation.RemoveFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		action, err := removeFunc(c.UserContext(), moderation.RemoveData{
+			GameID:        c.Params("gameId"),
+			LeaderboardID: c.Params("leaderboardId"),
+			PlayerID:      c.Params("playerId"),
+			Reason:        c.Query("reason"),
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(moderationActionFromDomain(action))
+	}
+}
+
+// @summary Bulk Remove Leaderboard Entries
+// @description Remove every entry a score was submitted to through the source within the window, on all the game's leaderboards,
+// @description e.g. after a compromised MQTT broker or ingestion topic. The entries are found on the score history, so the
+// @description endpoint is only mounted when it's enabled, and it's rejected, before removing anything, when there are over
+// @description 10000 entries. The removal is recorded on the game's audit log with its reason and the entries removed.
+// @description It isn't atomic: when it fails, the entries removed so far are recorded and sending it again removes the rest
+// @router /admin/v1/games/{gameId}/ranking-removals [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param BulkRemoveEntriesReq body BulkRemoveEntriesReq true "Source, window and reason"
+// @success 200 {object} ModerationAction
+// @failure 400,401,403,422,500 {object} ErrorResponse
+func buildBulkRemoveEntriesHandler(bulkRemoveFunc moderation.BulkRemoveFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body BulkRemoveEntriesReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		action, err := bulkRemoveFunc(c.UserContext(), moderation.BulkRemoveData{
+			GameID: c.Params("gameId"),
+			Source: body.Source,
+			From:   body.From,
+			To:     body.To,
+			Reason: body.Reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(moderationActionFromDomain(action))
+	}
+}
+
+// @summary Rollback Leaderboard Scores
+// @description Revert the scores submitted to a leaderboard within the window, every player's or a single one's, e.g. after an
+// @description exploit. The affected entries are rebuilt from the scores kept on the score history, the ones without any
+// @description removed, and the scores reverted are deleted from it. The endpoint is only mounted with the score history
+// @description enabled, and it's rejected, before changing anything, when there are over 10000 entries to recompute. The
+// @description rollback is recorded on the game's audit log with its reason and the entries recomputed. The entries skip the
+// @description submission checks and lose their adjustments. It isn't atomic: when it fails, sending it again recomputes the
+// @description same entries
+// @router /admin/v1/games/{gameId}/leaderboards/{leaderboardId}/rollbacks [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param leaderboardId path string true "Leaderboard ID"
+// @param RollbackScoresReq body RollbackScoresReq true "Window, player and reason"
+// @success 200 {object} ModerationAction
+// @failure 400,401,403,404,422,500 {object} ErrorResponse
+func buildRollbackScoresHandler(rollbackFunc moderation.RollbackFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body RollbackScoresReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		action, err := rollbackFunc(c.UserContext(), moderation.RollbackData{
+			GameID:        c.Params("gameId"),
+			LeaderboardID: c.Params("leaderboardId"),
+			PlayerID:      body.PlayerID,
+			From:          body.From,
+			To:            body.To,
+			Reason:        body.Reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(moderationActionFromDomain(action))
+	}
+}
+
+// @summary List Moderation Actions
+// @description List the moderation actions taken on the game's leaderboards entries, oldest first
+// @router /admin/v1/games/{gameId}/moderation-actions [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @success 200 {array} ModerationAction
+// @failure 401,403,500 {object} ErrorResponse
+func buildListModerationActionsHandler(listFunc moderation.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		actions, err := listFunc(c.UserContext(), c.Params("gameId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(moderationActionsFromDomain(actions))
+	}
+}
+
+// @summary List Device Usage
+// @description List the devices the game's players submitted scores from, as recorded on the score history with the submissions'
+// @description fingerprint, the most recently seen first, up to 100. Filtered by device, it lists the accounts sharing it, and
+// @description by player, the devices the player used, so the devices to ban can be found. The endpoint is only mounted with the
+// @description score history enabled
+// @router /admin/v1/games/{gameId}/devices [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param deviceHash query string false "Keep only the players that submitted from the device"
+// @param playerId query string false "Keep only the devices the player submitted from"
+// @success 200 {array} DeviceUsage
+// @failure 401,403,422,500 {object} ErrorResponse
+func buildListDeviceUsageHandler(listDeviceUsageFunc moderation.ListDeviceUsageFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		usage, err := listDeviceUsageFunc(c.UserContext(), c.Params("gameId"), moderation.DeviceUsageFilter{
+			DeviceHash: c.Query("deviceHash"),
+			PlayerID:   c.Query("playerId"),
+		})
+		if err != nil {
+			return err
+		}
+
+		data := make([]DeviceUsage, len(usage))
+		for i, u := range usage {
+			data[i] = DeviceUsage{
+				DeviceHash:   u.DeviceHash,
+				PlayerID:     u.PlayerID,
+				BuildVersion: u.BuildVersion,
+				Region:       u.Region,
+				FirstSeenAt:  u.FirstSeenAt,
+				LastSeenAt:   u.LastSeenAt,
+				Scores:       u.Scores,
+			}
+		}
+
+		return c.Status(http.StatusOK).JSON(data)
+	}
+}
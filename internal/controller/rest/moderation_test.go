@@ -0,0 +1,407 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/moderation"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var moderationTestSubmittedAt = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// The game has a single leaderboard, "season", ranked as given. alice's entry was adjusted once, bob submitted through
+// MQTT at `moderationTestSubmittedAt`, and carol submitted 10 two days before it and 40 at it
+func newModerationTestConfig(adminToken string, ranks fakeRanking) Config {
+	getLeaderboard := func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+		if id != "season" {
+			return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+		}
+
+		return leaderboard.Leaderboard{ID: id, GameID: gameID, AggregationMode: leaderboard.AggregationModeMax}, nil
+	}
+
+	createAction := func(ctx context.Context, action moderation.Action) error {
+		return nil
+	}
+
+	recordAuditEntry := func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+		return audit.Entry{ID: uuid.NewString()}, nil
+	}
+
+	listActions := func(ctx context.Context, gameID string) ([]moderation.Action, error) {
+		return []moderation.Action{{ID: uuid.NewString(), GameID: gameID, Kind: moderation.ActionAdjust, LeaderboardID: "season", PlayerID: "alice", Reason: "exploit"}}, nil
+	}
+
+	return Config{
+		AdminToken: adminToken,
+		InspectEntryFunc: moderation.BuildInspectFunc(getLeaderboard, ranks.GetPlayerRank, func(ctx context.Context, gameID, leaderboardID, playerID string) ([]moderation.Action, error) {
+			return listActions(ctx, gameID)
+		}),
+		AdjustEntryFunc: moderation.BuildAdjustFunc(recordAuditEntry, getLeaderboard, ranks.GetPlayerRank, ranks.DeletePlayerRank, ranks.UpsertPlayerRankValue, createAction),
+		RemoveEntryFunc: moderation.BuildRemoveFunc(recordAuditEntry, getLeaderboard, ranks.GetPlayerRank, ranks.DeletePlayerRank, createAction),
+		BulkRemoveEntriesFunc: moderation.BuildBulkRemoveFunc(
+			recordAuditEntry,
+			func(ctx context.Context, gameID, source string, from, to time.Time, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+				if source != leaderboard.SourceMQTT || moderationTestSubmittedAt.Before(from) || !moderationTestSubmittedAt.Before(to) || !after.SubmittedAt.IsZero() {
+					return nil, nil
+				}
+
+				return []leaderboard.Score{{LeaderboardID: "season", PlayerID: "bob", SubmittedAt: moderationTestSubmittedAt, Source: source}}, nil
+			},
+			getLeaderboard,
+			ranks.GetPlayerRank,
+			ranks.DeletePlayerRank,
+			createAction,
+		),
+		ListModerationActionsFunc: moderation.BuildListFunc(listActions),
+		ListDeviceUsageFunc: moderation.BuildListDeviceUsageFunc(func(ctx context.Context, gameID string, filter moderation.DeviceUsageFilter, limit int) ([]moderation.DeviceUsage, error) {
+			return []moderation.DeviceUsage{{DeviceHash: filter.DeviceHash, PlayerID: "alice", FirstSeenAt: moderationTestSubmittedAt, LastSeenAt: moderationTestSubmittedAt, Scores: 3}}, nil
+		}),
+		RollbackScoresFunc: moderation.BuildRollbackFunc(
+			recordAuditEntry,
+			getLeaderboard,
+			func(ctx context.Context, leaderboardID string, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+				if !after.SubmittedAt.IsZero() {
+					return nil, nil
+				}
+
+				return []leaderboard.Score{
+					{LeaderboardID: leaderboardID, PlayerID: "carol", SubmittedAt: moderationTestSubmittedAt.Add(-48 * time.Hour), Value: 10},
+					{LeaderboardID: leaderboardID, PlayerID: "carol", SubmittedAt: moderationTestSubmittedAt, Value: 40},
+				}, nil
+			},
+			ranks.GetPlayerRank,
+			ranks.DeletePlayerRank,
+			ranks.UpsertPlayerRankValue,
+			func(ctx context.Context, leaderboardID, playerID string, from, to time.Time) error { return nil },
+			createAction,
+		),
+	}
+}
+
+func TestBuildAdjustEntryHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		ranks := fakeRanking{"alice": 300}
+		app := App(newModerationTestConfig(adminToken, ranks))
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/v1/games/"+gameID+"/leaderboards/season/ranking/alice", bytes.NewBufferString(`{"value": 50, "reason": "exploit"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body ModerationAction
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, moderation.ActionAdjust, body.Kind)
+		assert.Equal(t, 300.0, *body.Previous)
+		assert.Equal(t, 50.0, *body.Value)
+		assert.Nil(t, body.From)
+		assert.Equal(t, fakeRanking{"alice": 50}, ranks)
+	})
+
+	t.Run("Invalid Adjustment", func(t *testing.T) {
+		app := App(newModerationTestConfig(adminToken, fakeRanking{"alice": 300}))
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/v1/games/"+gameID+"/leaderboards/season/ranking/alice", bytes.NewBufferString(`{"value": 50}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseModerationInvalid.Code, body.Code)
+	})
+
+	t.Run("Leaderboard Not Found", func(t *testing.T) {
+		app := App(newModerationTestConfig(adminToken, fakeRanking{"alice": 300}))
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/v1/games/"+gameID+"/leaderboards/weekly/ranking/alice", bytes.NewBufferString(`{"value": 50, "reason": "exploit"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildInspectEntryHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newModerationTestConfig(adminToken, fakeRanking{"alice": 50}))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/leaderboards/season/ranking/alice", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body ModerationEntry
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, &Rank{PlayerID: "alice", Value: 50}, body.Rank)
+		assert.Len(t, body.Actions, 1)
+	})
+
+	t.Run("Not Ranked", func(t *testing.T) {
+		app := App(newModerationTestConfig(adminToken, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/leaderboards/season/ranking/alice", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body ModerationEntry
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Nil(t, body.Rank)
+		assert.Len(t, body.Actions, 1)
+	})
+}
+
+func TestBuildRemoveEntryHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		ranks := fakeRanking{"alice": 300, "bob": 200}
+		app := App(newModerationTestConfig(adminToken, ranks))
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/v1/games/"+gameID+"/leaderboards/season/ranking/alice?reason=cheating", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, fakeRanking{"bob": 200}, ranks)
+	})
+
+	t.Run("Entry Not Found", func(t *testing.T) {
+		app := App(newModerationTestConfig(adminToken, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/v1/games/"+gameID+"/leaderboards/season/ranking/alice?reason=cheating", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseModerationEntryNotFound.Code, body.Code)
+	})
+}
+
+func TestBuildBulkRemoveEntriesHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		ranks := fakeRanking{"alice": 300, "bob": 200}
+		app := App(newModerationTestConfig(adminToken, ranks))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/games/"+gameID+"/ranking-removals", bytes.NewBufferString(`{"source": "MQTT", "from": "2026-01-01T00:00:00Z", "to": "2026-01-02T00:00:00Z", "reason": "compromised broker"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body ModerationAction
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, moderation.ActionBulkRemove, body.Kind)
+		assert.Equal(t, []ModerationRemovedEntry{{LeaderboardID: "season", PlayerID: "bob", Value: 200}}, body.Entries)
+		assert.Equal(t, fakeRanking{"alice": 300}, ranks)
+	})
+
+	t.Run("Invalid Removal", func(t *testing.T) {
+		app := App(newModerationTestConfig(adminToken, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/games/"+gameID+"/ranking-removals", bytes.NewBufferString(`{"source": "FTP", "from": "2026-01-02T00:00:00Z", "to": "2026-01-01T00:00:00Z", "reason": "compromised broker"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body.Details, 3)
+	})
+}
+
+func TestBuildListModerationActionsHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newModerationTestConfig(adminToken, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/moderation-actions", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []ModerationAction
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, moderation.ActionAdjust, body[0].Kind)
+	})
+}
+
+func TestBuildListDeviceUsageHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newModerationTestConfig(adminToken, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/devices?deviceHash=f00d", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []DeviceUsage
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []DeviceUsage{{DeviceHash: "f00d", PlayerID: "alice", FirstSeenAt: moderationTestSubmittedAt, LastSeenAt: moderationTestSubmittedAt, Scores: 3}}, body)
+	})
+
+	t.Run("Missing Filter", func(t *testing.T) {
+		app := App(newModerationTestConfig(adminToken, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/devices", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseModerationDeviceFilter, body)
+	})
+}
+
+func TestBuildRollbackScoresHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		ranks := fakeRanking{"carol": 40}
+		app := App(newModerationTestConfig(adminToken, ranks))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/games/"+gameID+"/leaderboards/season/rollbacks", bytes.NewBufferString(`{"playerId": "carol", "from": "2026-01-01T00:00:00Z", "to": "2026-01-02T00:00:00Z", "reason": "exploit"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body ModerationAction
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, moderation.ActionRollback, body.Kind)
+		assert.Equal(t, int64(1), body.Scores)
+		if assert.Len(t, body.Recomputed, 1) {
+			assert.Equal(t, 40.0, *body.Recomputed[0].Previous)
+			assert.Equal(t, 10.0, *body.Recomputed[0].Value)
+		}
+		assert.Equal(t, fakeRanking{"carol": 10}, ranks)
+	})
+
+	t.Run("No Scores", func(t *testing.T) {
+		app := App(newModerationTestConfig(adminToken, fakeRanking{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/games/"+gameID+"/leaderboards/season/rollbacks", bytes.NewBufferString(`{"from": "2025-01-01T00:00:00Z", "to": "2025-01-02T00:00:00Z", "reason": "exploit"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseModerationNoScores, body)
+	})
+}
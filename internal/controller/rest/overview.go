@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/overview"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type (
+	OverviewRank struct {
+		Leaderboard Leaderboard `json:"leaderboard"` // Leaderboard the player is ranked on
+		Rank        Rank        `json:"rank"`        // Player's rank
+	}
+
+	OverviewStatistic struct {
+		Statistic   Statistic                  `json:"statistic"`   // Statistic the player progressed on
+		Progression PlayerStatisticProgression `json:"progression"` // Player's progression
+	}
+
+	PlayerOverview struct {
+		GameID       string                   `json:"gameId"`                 // ID of the game the player belongs to
+		PlayerID     string                   `json:"playerId"`               // Player's ID
+		Ranks        []OverviewRank           `json:"ranks"`                  // Ranks on the open leaderboards
+		Statistics   []OverviewStatistic      `json:"statistics"`             // Progressions on the statistics
+		ActiveQuests []PlayerQuestProgression `json:"activeQuests"`           // Quests started and not completed yet
+		Achievements []PlayerAchievement      `json:"achievements,omitempty"` // Achievements unlocked, in the order they were unlocked. Only with the achievements enabled
+	}
+)
+
+func playerOverviewFromDomain(o overview.Overview) PlayerOverview {
+	res := PlayerOverview{
+		GameID:       o.GameID,
+		PlayerID:     o.PlayerID,
+		Ranks:        make([]OverviewRank, len(o.Ranks)),
+		Statistics:   make([]OverviewStatistic, len(o.Statistics)),
+		ActiveQuests: make([]PlayerQuestProgression, len(o.ActiveQuests)),
+	}
+
+	for i, r := range o.Ranks {
+		res.Ranks[i] = OverviewRank{Leaderboard: leaderboardFromDomain(r.Leaderboard), Rank: rankFromDomain(r.Rank)}
+	}
+
+	for i, s := range o.Statistics {
+		res.Statistics[i] = OverviewStatistic{Statistic: statisticFromDomain(s.Statistic), Progression: playerStatisticProgressionFromDomain(s.Progression)}
+	}
+
+	for i, p := range o.ActiveQuests {
+		res.ActiveQuests[i] = playerQuestProgressionFromDomain(p)
+	}
+
+	if o.Achievements != nil {
+		res.Achievements = make([]PlayerAchievement, len(o.Achievements))
+		for i, u := range o.Achievements {
+			res.Achievements[i] = PlayerAchievement{
+				Achievement: achievementFromDomain(u.Achievement),
+				PlayerID:    u.PlayerID,
+				UnlockedAt:  u.UnlockedAt,
+			}
+		}
+	}
+
+	return res
+}
+
+var ErrorResponseOverviewInvalidPlayer = ErrorResponse{Code: "20.0", Message: "Invalid player id"}
+
+// @summary Player Overview
+// @description Get the player's ranks on the open leaderboards, statistic progressions, quests in progress and achievements
+// @description in a single response, for the game's profile screens. The parts are fetched in parallel and never cached
+// @router /api/v1/players/{playerId}/overview [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {object} PlayerOverview
+// @failure 422,500 {object} ErrorResponse
+func buildGetPlayerOverviewHandler(getOverviewFunc overview.GetFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		o, err := getOverviewFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(playerOverviewFromDomain(o))
+	}
+}
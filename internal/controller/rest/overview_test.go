@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/overview"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPlayerOverviewHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	app := App(Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetPlayerOverviewFunc: overview.BuildGetFunc(
+			func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+				return []leaderboard.Leaderboard{{ID: "season", GameID: gameID, StartAt: time.Now().Add(-time.Hour)}}, nil
+			},
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+				return leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Position: 1, Value: 42}, nil
+			},
+			func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+				return nil, nil
+			},
+			nil,
+			func(ctx context.Context, gameID string) ([]quest.Quest, error) {
+				return nil, nil
+			},
+			nil,
+			nil,
+		),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/players/"+playerID+"/overview", nil)
+	req.Header.Set("Authorization", uuid.NewString())
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body PlayerOverview
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	assert.NoError(t, err)
+	assert.Equal(t, playerID, body.PlayerID)
+	assert.Len(t, body.Ranks, 1)
+	assert.Equal(t, "season", body.Ranks[0].Leaderboard.ID)
+	assert.Equal(t, float64(42), body.Ranks[0].Rank.Value)
+	assert.Empty(t, body.Statistics)
+	assert.Empty(t, body.ActiveQuests)
+	assert.Nil(t, body.Achievements)
+}
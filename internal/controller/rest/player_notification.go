@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/notification"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PlayerNotification struct {
+	Type        string    `json:"type"`                  // Completion kind: `statistic_landmark_completed`, `statistic_goal_completed`, `quest_task_completed` or `quest_completed`
+	StatisticID string    `json:"statisticId,omitempty"` // Statistic ID. Only set on the statistic notifications
+	QuestID     string    `json:"questId,omitempty"`     // Quest ID. Only set on the quest notifications
+	TaskID      string    `json:"taskId,omitempty"`      // Task completed. Only set on `quest_task_completed`
+	Value       *float64  `json:"value,omitempty"`       // Landmark or goal value reached. Only set on the statistic notifications
+	CompletedAt time.Time `json:"completedAt"`           // Time the player completed it
+}
+
+func playerNotificationFromDomain(n notification.Notification) PlayerNotification {
+	msg := PlayerNotification{
+		Type:        strings.ToLower(n.Event),
+		StatisticID: n.StatisticID,
+		QuestID:     n.QuestID,
+		TaskID:      n.TaskID,
+		CompletedAt: n.CompletedAt,
+	}
+
+	if n.StatisticID != "" {
+		value := n.Value
+		msg.Value = &value
+	}
+
+	return msg
+}
+
+// @summary Watch Player Notifications
+// @description Upgrade to a WebSocket that pushes the player's statistic landmarks and goals, quest tasks and quests as they are completed
+// @router /api/v1/players/{playerId}/notifications/live [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 101 {object} PlayerNotification
+// @failure 426,500 {object} ErrorResponse
+func buildWatchPlayerNotificationsHandler(watchNotificationsFunc notification.WatchFunc) fiber.Handler {
+	upgrade := websocket.New(func(conn *websocket.Conn) {
+		var (
+			ctx      = conn.Locals("ctx").(context.Context)
+			claims   = conn.Locals("claims").(auth.Claims)
+			playerID = conn.Params("playerId")
+		)
+
+		err := serveWebSocket(ctx, conn, func(ctx context.Context, write func(msg any) error) error {
+			return watchNotificationsFunc(ctx, claims.GameID, playerID, func(n notification.Notification) error {
+				return write(playerNotificationFromDomain(n))
+			})
+		})
+		if err != nil {
+			zap.ErrorContext(ctx, err, "player notifications watch error", "playerId", playerID)
+		}
+	})
+
+	return func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+
+		c.Locals("ctx", c.UserContext())
+		return upgrade(c)
+	}
+}
@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/notification"
+
+	fasthttpws "github.com/fasthttp/websocket"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWatchPlayerNotificationsHandler(t *testing.T) {
+	var (
+		gameID      = uuid.NewString()
+		playerID    = uuid.NewString()
+		statisticID = uuid.NewString()
+		questID     = uuid.NewString()
+		completedAt = time.Now().UTC().Truncate(time.Second)
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			WatchPlayerNotificationsFunc: func(ctx context.Context, g, p string, push func(notification notification.Notification) error) error {
+				assert.Equal(t, gameID, g)
+				assert.Equal(t, playerID, p)
+
+				if err := push(notification.Notification{Event: notification.EventStatisticGoalCompleted, StatisticID: statisticID, Value: 100, CompletedAt: completedAt}); err != nil {
+					return err
+				}
+
+				return push(notification.Notification{Event: notification.EventQuestCompleted, QuestID: questID, CompletedAt: completedAt})
+			},
+		})
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+
+		go app.Listener(ln)
+		defer app.Shutdown()
+
+		header := http.Header{}
+		header.Set("Authorization", uuid.NewString())
+
+		url := fmt.Sprintf("ws://%s/api/v1/players/%s/notifications/live", ln.Addr(), playerID)
+		conn, _, err := fasthttpws.DefaultDialer.Dial(url, header)
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		var msg PlayerNotification
+		assert.NoError(t, conn.ReadJSON(&msg))
+
+		value := 100.0
+		assert.Equal(t, PlayerNotification{Type: "statistic_goal_completed", StatisticID: statisticID, Value: &value, CompletedAt: completedAt}, msg)
+
+		msg = PlayerNotification{}
+		assert.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, PlayerNotification{Type: "quest_completed", QuestID: questID, CompletedAt: completedAt}, msg)
+
+		_, _, err = conn.ReadMessage()
+		assert.True(t, fasthttpws.IsCloseError(err, fasthttpws.CloseNormalClosure))
+	})
+
+	t.Run("Upgrade Required", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			WatchPlayerNotificationsFunc: func(ctx context.Context, gameID, playerID string, push func(notification notification.Notification) error) error {
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/players/%s/notifications/live", playerID), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUpgradeRequired, resp.StatusCode)
+	})
+}
@@ -67,6 +67,7 @@ var (
 	ErrorResponsePlayerAlreadyStartedTheQuest = ErrorResponse{Code: "6.0", Message: "Player already started the quest"}
 	ErrorResponsePlayerNotStartedTheQuest     = ErrorResponse{Code: "6.1", Message: "Player not started the quest"}
 	ErrorResponsePlayerQuestAlreadyFinished   = ErrorResponse{Code: "6.2", Message: "Player already finished the quest"}
+	ErrorResponseQuestExpired                 = ErrorResponse{Code: "6.3", Message: "Quest expired"}
 )
 
 // @summary Start Player Quest Progression
@@ -85,7 +86,7 @@ func buildStartPlayerQuestHandler(startQuestForPlayerFunc quest.StartQuestForPla
 			playerID = c.Params("playerId")
 		)
 
-		progression, err := startQuestForPlayerFunc(c.Context(), quest, playerID)
+		progression, err := startQuestForPlayerFunc(c.UserContext(), quest, playerID)
 		if err != nil {
 			return err
 		}
@@ -110,7 +111,7 @@ func buildGetPlayerQuestProgressionHandler(getPlayerQuestProgressionFunc quest.G
 			playerID = c.Params("playerId")
 		)
 
-		progression, err := getPlayerQuestProgressionFunc(c.Context(), quest, playerID)
+		progression, err := getPlayerQuestProgressionFunc(c.UserContext(), quest, playerID)
 		if err != nil {
 			return err
 		}
@@ -142,7 +143,7 @@ func buildUpdatePlayerQuestProgressionHandler(updatePlayerQuestProgressionFunc q
 			return err
 		}
 
-		progression, err := updatePlayerQuestProgressionFunc(c.Context(), quest, playerID, body.Data)
+		progression, err := updatePlayerQuestProgressionFunc(c.UserContext(), quest, playerID, body.Data)
 		if err != nil {
 			return err
 		}
@@ -498,6 +498,41 @@ func TestBuildUpdatePlayerQuestProgressionHandler(t *testing.T) {
 		assert.Equal(t, ErrorResponsePlayerQuestAlreadyFinished.Message, body.Message)
 	})
 
+	t.Run("Quest Expired", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetQuestByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (quest.Quest, error) {
+				return expectedQuest, nil
+			},
+			UpdatePlayerQuestProgressionFunc: func(ctx context.Context, q quest.Quest, playerID, taskDataToCheck string) (quest.PlayerQuestProgression, error) {
+				return quest.PlayerQuestProgression{}, quest.ErrQuestExpired
+			},
+		})
+
+		data, err := json.Marshal(map[string]string{
+			"data": `{"fields": {"bool": true}}`,
+		})
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/quests/%s/players/%s", questID, playerID), bytes.NewBuffer(data))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseQuestExpired.Code, body.Code)
+		assert.Equal(t, ErrorResponseQuestExpired.Message, body.Message)
+	})
+
 	t.Run("Not Started", func(t *testing.T) {
 		app := App(Config{
 			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
@@ -82,7 +82,8 @@ var (
 )
 
 // @summary Upsert Player Statistic Progression
-// @description Set or update a player's statistic progression
+// @description Set or update a player's statistic progression. With the submission rate limit enabled, the player's
+// @description submissions over it are rejected until the minute ends
 // @router /api/v1/statistics/{statisticId}/players/{playerId} [POST]
 // @accept json
 // @produce json
@@ -91,7 +92,7 @@ var (
 // @param playerId path string true "Player ID"
 // @param UpsertPlayerStatisticData body UpsertPlayerStatisticProgressionReq true "Values to update the player statistic progression"
 // @success 204
-// @failure 400,404,422,500 {object} ErrorResponse
+// @failure 400,404,422,429,500 {object} ErrorResponse
 func buildUpsertPlayerStatisticHandler(upsertPlayerStatisticFunc statistic.UpsertPlayerProgressionFunc) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var (
@@ -104,7 +105,7 @@ func buildUpsertPlayerStatisticHandler(upsertPlayerStatisticFunc statistic.Upser
 			return err
 		}
 
-		if err := upsertPlayerStatisticFunc(c.Context(), statistic, playerID, body.Value); err != nil {
+		if err := upsertPlayerStatisticFunc(c.UserContext(), statistic, playerID, body.Value); err != nil {
 			return err
 		}
 
@@ -127,7 +128,7 @@ func buildGetPlayerStatisticHandler(getPlayerProgressionFunc statistic.GetPlayer
 			playerID  = c.Params("playerId")
 		)
 
-		playerProgression, err := getPlayerProgressionFunc(c.Context(), statistic.ID, playerID)
+		playerProgression, err := getPlayerProgressionFunc(c.UserContext(), statistic.ID, playerID)
 		if err != nil {
 			return err
 		}
@@ -12,8 +12,10 @@ import (
 
 	"github.com/gabapcia/gameblitz/internal/auth"
 	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/journal"
 	"github.com/gabapcia/gameblitz/internal/leaderboard"
 	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/writebehind"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -134,6 +136,58 @@ func TestTuildUpsertPlayerStatisticHandler(t *testing.T) {
 		assert.Equal(t, ErrorResponseInternalServerError.Code, body.Code)
 		assert.Equal(t, ErrorResponseInternalServerError.Message, body.Message)
 	})
+
+	t.Run("Queued On The Write-Behind Journal", func(t *testing.T) {
+		changes, err := journal.New(t.TempDir())
+		assert.NoError(t, err)
+		defer changes.Close()
+
+		buffer, err := writebehind.NewBuffer(
+			writebehind.Config{QueueID: changes.ID(), BatchSize: 10, MaxPending: 10},
+			changes.AppendStatisticChange,
+			changes.PeekStatisticChanges,
+			changes.RemoveStatisticChanges,
+			changes.CountStatisticChanges,
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+		assert.NoError(t, err)
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetStatisticByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+				return statistic.Statistic{ID: id, GameID: gameID}, nil
+			},
+			UpsertPlayerStatisticProgressionFunc: statistic.BuildUpsertPlayerProgressionFunc(nil, buffer.Update),
+		})
+
+		// Same length IDs, so each request's path takes the place of the previous one
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range playerIDs {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/statistics/%s/players/%s", statisticID, playerID), bytes.NewBufferString(`{"value": 100.0}`))
+
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", uuid.NewString())
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		}
+
+		entries, err := changes.PeekStatisticChanges(context.Background(), 10)
+		assert.NoError(t, err)
+
+		queued := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			queued = append(queued, entry.Change.PlayerID)
+		}
+
+		assert.Equal(t, playerIDs, queued)
+	})
 }
 
 func TestBuildGetPlayerStatisticHandler(t *testing.T) {
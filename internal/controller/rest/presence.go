@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/presence"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Presence struct {
+	PlayerID   string     `json:"playerId"`             // Player's ID
+	Online     bool       `json:"online"`               // Whether the player sent a heartbeat within the presence timeout
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"` // Time of the player's last heartbeat. Left out when the player wasn't seen within the retention
+}
+
+func presenceFromDomain(p presence.Presence) Presence {
+	data := Presence{PlayerID: p.PlayerID, Online: p.Online}
+	if !p.LastSeenAt.IsZero() {
+		data.LastSeenAt = &p.LastSeenAt
+	}
+
+	return data
+}
+
+// Presence shown alongside a player on the rankings and the friends lists
+type PresenceStatus struct {
+	Online     bool      `json:"online"`     // Whether the player sent a heartbeat within the presence timeout
+	LastSeenAt time.Time `json:"lastSeenAt"` // Time of the player's last heartbeat
+}
+
+var ErrorResponsePresenceInvalidPlayer = ErrorResponse{Code: "25.0", Message: "Invalid player id"}
+
+// @summary Send Presence Heartbeat
+// @description Mark a player of the game online. The player stays online until the presence timeout goes by without a heartbeat,
+// @description so the game client sends one periodically while the player is playing
+// @router /api/v1/players/{playerId}/presence [PUT]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {object} Presence
+// @failure 422,500 {object} ErrorResponse
+func buildHeartbeatHandler(heartbeatFunc presence.HeartbeatFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		p, err := heartbeatFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(presenceFromDomain(p))
+	}
+}
+
+// @summary Get Presence
+// @description Get whether a player of the game is online and when it was last seen
+// @router /api/v1/players/{playerId}/presence [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {object} Presence
+// @failure 422,500 {object} ErrorResponse
+func buildGetPresenceHandler(getPresenceFunc presence.GetFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		p, err := getPresenceFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(presenceFromDomain(p))
+	}
+}
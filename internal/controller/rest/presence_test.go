@@ -0,0 +1,186 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/friend"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/presence"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Only alice is online, last seen at the time given. The ranking has alice and bob, and every player is a friend of
+// alice and carol
+func newPresenceTestConfig(t *testing.T, gameID string, lastSeenAt time.Time) Config {
+	heartbeat, err := presence.BuildHeartbeatFunc(time.Minute, time.Hour, func(ctx context.Context, p presence.Presence, timeout, retention time.Duration) error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	storageList := func(ctx context.Context, gameID string, playerIDs []string) ([]presence.Presence, error) {
+		if !slices.Contains(playerIDs, "alice") {
+			return []presence.Presence{}, nil
+		}
+
+		return []presence.Presence{{GameID: gameID, PlayerID: "alice", Online: true, LastSeenAt: lastSeenAt}}, nil
+	}
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		},
+		RankingFunc: func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+			return []leaderboard.Rank{{PlayerID: "alice", Position: 0, Value: 10}, {PlayerID: "bob", Position: 1, Value: 5}}, nil
+		},
+		AddFriendFunc:    friend.BuildAddFunc(nil, nil),
+		RemoveFriendFunc: friend.BuildRemoveFunc(nil),
+		ListFriendsFunc: friend.BuildListFunc(func(ctx context.Context, gameID, playerID string) ([]friend.Friendship, error) {
+			return []friend.Friendship{{GameID: gameID, PlayerID: playerID, FriendID: "alice"}, {GameID: gameID, PlayerID: playerID, FriendID: "carol"}}, nil
+		}),
+		HeartbeatFunc:     heartbeat,
+		GetPresenceFunc:   presence.BuildGetFunc(storageList),
+		ListPresencesFunc: presence.BuildListFunc(storageList),
+	}
+}
+
+func TestBuildHeartbeatHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		var recorded audit.NewEntryData
+
+		config := newPresenceTestConfig(t, gameID, time.Now().UTC())
+		config.RecordAuditEntryFunc = func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+			recorded = data
+			return audit.Entry{ID: uuid.NewString()}, nil
+		}
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/players/alice/presence", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Presence
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "alice", body.PlayerID)
+		assert.True(t, body.Online)
+		assert.NotNil(t, body.LastSeenAt)
+
+		assert.Equal(t, http.MethodPut, recorded.Method)
+		assert.Equal(t, "/api/v1/players/:playerId/presence", recorded.Route)
+		assert.Equal(t, map[string]string{"playerId": "alice"}, recorded.ResourceIDs)
+		assert.Equal(t, http.StatusOK, recorded.StatusCode)
+	})
+}
+
+func TestBuildGetPresenceHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("Online", func(t *testing.T) {
+		lastSeenAt := time.Now().UTC().Truncate(time.Second)
+		app := App(newPresenceTestConfig(t, gameID, lastSeenAt))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/alice/presence", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Presence
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, Presence{PlayerID: "alice", Online: true, LastSeenAt: &lastSeenAt}, body)
+	})
+
+	t.Run("Not Seen", func(t *testing.T) {
+		app := App(newPresenceTestConfig(t, gameID, time.Now().UTC()))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/bob/presence", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Presence
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, Presence{PlayerID: "bob"}, body)
+	})
+}
+
+func TestBuildGetRankingHandlerPresence(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		lastSeenAt := time.Now().UTC().Truncate(time.Second)
+		app := App(newPresenceTestConfig(t, gameID, lastSeenAt))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+leaderboardID+"/ranking", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Rank
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 2)
+		assert.Equal(t, &PresenceStatus{Online: true, LastSeenAt: lastSeenAt}, body[0].Presence)
+		assert.Nil(t, body[1].Presence)
+	})
+}
+
+func TestBuildListFriendsHandlerPresence(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newPresenceTestConfig(t, gameID, time.Now().UTC()))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/bob/friends", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Friendship
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 2)
+		assert.True(t, body[0].Presence.Online)
+		assert.Nil(t, body[1].Presence)
+	})
+}
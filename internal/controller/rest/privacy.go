@@ -0,0 +1,214 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PlayerErasure struct {
+	Data    string `json:"data"`    // Kind of the data erased: `RANKS`, `STATISTIC_PROGRESSION`, `QUEST_PROGRESSION`, `EVENT_HISTORY`, `DEAD_LETTERS`, `SCORE_HISTORY`, `PROFILE`, `ACHIEVEMENTS`, `REWARD_GRANTS`, `LINKED_ACCOUNTS`, `BANS`, `TITLES`, `FRIENDSHIPS`, `PRESENCE` or `ACTIVITY`
+	Records int64  `json:"records"` // Records removed
+}
+
+type PlayerErasureReceipt struct {
+	ID           string          `json:"id"`           // Receipt ID, the ID of the audit entry recording the erasure
+	GameID       string          `json:"gameId"`       // Game the player's data belonged to
+	PlayerDigest string          `json:"playerDigest"` // Hex encoded SHA-256 digest of `<game id>/<player id>`
+	RequestedBy  string          `json:"requestedBy"`  // Subject of the credential that requested the erasure
+	ErasedAt     time.Time       `json:"erasedAt"`     // Time the erasure completed
+	Erasures     []PlayerErasure `json:"erasures"`     // Data removed from each storage
+}
+
+func playerErasureReceiptFromDomain(r privacy.Receipt) PlayerErasureReceipt {
+	erasures := make([]PlayerErasure, len(r.Erasures))
+	for i, erasure := range r.Erasures {
+		erasures[i] = PlayerErasure{
+			Data:    erasure.Data,
+			Records: erasure.Records,
+		}
+	}
+
+	return PlayerErasureReceipt{
+		ID:           r.ID,
+		GameID:       r.GameID,
+		PlayerDigest: r.PlayerDigest,
+		RequestedBy:  r.RequestedBy,
+		ErasedAt:     r.ErasedAt,
+		Erasures:     erasures,
+	}
+}
+
+type PlayerAnonymization struct {
+	Data    string `json:"data"`    // Kind of the data anonymized: `RANKS`, `STATISTIC_PROGRESSION`, `QUEST_PROGRESSION`, `EVENT_HISTORY`, `DEAD_LETTERS`, `SCORE_HISTORY`, `PROFILE`, `ACHIEVEMENTS`, `REWARD_GRANTS`, `LINKED_ACCOUNTS`, `BANS`, `TITLES`, `FRIENDSHIPS`, `PRESENCE` or `ACTIVITY`
+	Records int64  `json:"records"` // Records now referring to the pseudonym
+}
+
+type PlayerAnonymizationReceipt struct {
+	ID             string                `json:"id"`             // Receipt ID, the ID of the audit entry recording the anonymization
+	GameID         string                `json:"gameId"`         // Game the player's data belonged to
+	PlayerDigest   string                `json:"playerDigest"`   // Hex encoded SHA-256 digest of `<game id>/<player id>`
+	Pseudonym      string                `json:"pseudonym"`      // Player ID that replaced the player's one on every storage
+	RequestedBy    string                `json:"requestedBy"`    // Subject of the credential that requested the anonymization
+	AnonymizedAt   time.Time             `json:"anonymizedAt"`   // Time the anonymization completed
+	Anonymizations []PlayerAnonymization `json:"anonymizations"` // Data anonymized on each storage
+}
+
+func playerAnonymizationReceiptFromDomain(r privacy.AnonymizationReceipt) PlayerAnonymizationReceipt {
+	anonymizations := make([]PlayerAnonymization, len(r.Anonymizations))
+	for i, anonymization := range r.Anonymizations {
+		anonymizations[i] = PlayerAnonymization{
+			Data:    anonymization.Data,
+			Records: anonymization.Records,
+		}
+	}
+
+	return PlayerAnonymizationReceipt{
+		ID:             r.ID,
+		GameID:         r.GameID,
+		PlayerDigest:   r.PlayerDigest,
+		Pseudonym:      r.Pseudonym,
+		RequestedBy:    r.RequestedBy,
+		AnonymizedAt:   r.AnonymizedAt,
+		Anonymizations: anonymizations,
+	}
+}
+
+type PlayerDataCount struct {
+	Data    string `json:"data"`    // Kind of the data: `RANKS`, `STATISTIC_PROGRESSION`, `QUEST_PROGRESSION`, `EVENT_HISTORY`, `DEAD_LETTERS`, `SCORE_HISTORY`, `PROFILE`, `ACHIEVEMENTS`, `REWARD_GRANTS`, `LINKED_ACCOUNTS`, `BANS`, `TITLES`, `FRIENDSHIPS`, `PRESENCE` or `ACTIVITY`
+	Records int64  `json:"records"` // Records referring to the player
+}
+
+type PlayerDataPreview struct {
+	DryRun       bool              `json:"dryRun"`       // Always true, nothing was changed, recorded on the audit log nor published
+	GameID       string            `json:"gameId"`       // Game the player's data belongs to
+	PlayerDigest string            `json:"playerDigest"` // Hex encoded SHA-256 digest of `<game id>/<player id>`
+	Affected     []PlayerDataCount `json:"affected"`     // Records each storage would change
+}
+
+func playerDataPreviewFromDomain(p privacy.Preview) PlayerDataPreview {
+	affected := make([]PlayerDataCount, len(p.Affected))
+	for i, count := range p.Affected {
+		affected[i] = PlayerDataCount{
+			Data:    count.Data,
+			Records: count.Records,
+		}
+	}
+
+	return PlayerDataPreview{
+		DryRun:       true,
+		GameID:       p.GameID,
+		PlayerDigest: p.PlayerDigest,
+		Affected:     affected,
+	}
+}
+
+var ErrInvalidDryRun = errors.New("invalid dry run flag")
+
+var (
+	ErrorResponsePlayerMissingID     = ErrorResponse{Code: "14.0", Message: "Missing player id"}
+	ErrorResponsePlayerInvalidDryRun = ErrorResponse{Code: "14.1", Message: "Invalid dry run flag, it must be either true or false"}
+)
+
+// Reads the `dryRun` query parameter. Anything but a boolean is rejected instead of running the operation for real.
+// A dry run is rejected as well when there's nothing to preview it with
+func parseDryRun(c *fiber.Ctx, previewable bool) (bool, error) {
+	v := c.Query("dryRun")
+	if v == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(v)
+	if err != nil || (dryRun && !previewable) {
+		return false, ErrInvalidDryRun
+	}
+
+	return dryRun, nil
+}
+
+// @summary Erase Player
+// @description Erase the player's ranks, statistic and quest progression, events and dead letters across every storage of the game,
+// @description including the soft deleted leaderboards, statistics and quests. The erasure is recorded on the audit log with the
+// @description digest of the player ID only and a `gameblitz.player.erasure` event is published. Responses already cached keep
+// @description being served until they expire. With `dryRun` set, the records the erasure would remove are counted and returned
+// @description as a `PlayerDataPreview` instead, without changing, recording or publishing anything
+// @router /api/v1/players/{playerId} [DELETE]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param dryRun query bool false "Only count the records the erasure would remove"
+// @success 200 {object} PlayerErasureReceipt
+// @failure 422,500,503 {object} ErrorResponse
+func buildErasePlayerHandler(erasePlayerFunc privacy.ErasePlayerFunc, previewPlayerDataFunc privacy.PreviewPlayerDataFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		dryRun, err := parseDryRun(c, previewPlayerDataFunc != nil)
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			preview, err := previewPlayerDataFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+			if err != nil {
+				return err
+			}
+
+			return c.Status(http.StatusOK).JSON(playerDataPreviewFromDomain(preview))
+		}
+
+		receipt, err := erasePlayerFunc(c.UserContext(), claims.GameID, c.Params("playerId"), claims.Subject)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(playerErasureReceiptFromDomain(receipt))
+	}
+}
+
+// @summary Anonymize Player
+// @description Replace the player ID by a random pseudonym on the player's ranks, statistic and quest progression, events and
+// @description dead letters across every storage of the game, including the soft deleted leaderboards, statistics and quests.
+// @description The data is kept, so the rankings and the statistic and quest aggregates don't change. The anonymization is recorded
+// @description on the audit log with the digest of the player ID only and a `gameblitz.player.anonymization` event is published.
+// @description Responses already cached keep being served until they expire. With `dryRun` set, the records the anonymization
+// @description would change are counted and returned as a `PlayerDataPreview` instead, without changing, recording or publishing anything
+// @router /api/v1/players/{playerId}/anonymize [POST]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param dryRun query bool false "Only count the records the anonymization would change"
+// @success 200 {object} PlayerAnonymizationReceipt
+// @failure 422,500,503 {object} ErrorResponse
+func buildAnonymizePlayerHandler(anonymizePlayerFunc privacy.AnonymizePlayerFunc, previewPlayerDataFunc privacy.PreviewPlayerDataFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		dryRun, err := parseDryRun(c, previewPlayerDataFunc != nil)
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			preview, err := previewPlayerDataFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+			if err != nil {
+				return err
+			}
+
+			return c.Status(http.StatusOK).JSON(playerDataPreviewFromDomain(preview))
+		}
+
+		receipt, err := anonymizePlayerFunc(c.UserContext(), claims.GameID, c.Params("playerId"), claims.Subject)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(playerAnonymizationReceiptFromDomain(receipt))
+	}
+}
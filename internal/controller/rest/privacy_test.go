@@ -0,0 +1,306 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildErasePlayerHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		subject  = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		recorded := make([]audit.NewEntryData, 0)
+		recordAuditEntryFunc := func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+			recorded = append(recorded, data)
+			return audit.Entry{ID: "receipt"}, nil
+		}
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			RecordAuditEntryFunc: recordAuditEntryFunc,
+			ErasePlayerFunc: privacy.BuildErasePlayerFunc(nil, recordAuditEntryFunc, func(ctx context.Context, game, player string) (privacy.Erasure, error) {
+				assert.Equal(t, gameID, game)
+				assert.Equal(t, playerID, player)
+				return privacy.Erasure{Data: privacy.DataRanks, Records: 3}, nil
+			}),
+		})
+
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/players/%s", playerID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var data PlayerErasureReceipt
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "receipt", data.ID)
+		assert.Equal(t, gameID, data.GameID)
+		assert.Equal(t, privacy.PlayerDigest(gameID, playerID), data.PlayerDigest)
+		assert.Equal(t, subject, data.RequestedBy)
+		assert.Equal(t, []PlayerErasure{{Data: privacy.DataRanks, Records: 3}}, data.Erasures)
+
+		// Only the erasure's own entry is recorded, so the raw player ID is never kept on the audit log
+		if assert.Len(t, recorded, 1) {
+			assert.Equal(t, map[string]string{"playerId": data.PlayerDigest}, recorded[0].ResourceIDs)
+		}
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			RecordAuditEntryFunc: func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				assert.Fail(t, "recorded a dry run")
+				return audit.Entry{}, nil
+			},
+			ErasePlayerFunc: func(ctx context.Context, gameID, playerID, requestedBy string) (privacy.Receipt, error) {
+				assert.Fail(t, "ran a dry run for real")
+				return privacy.Receipt{}, nil
+			},
+			PreviewPlayerDataFunc: privacy.BuildPreviewPlayerDataFunc(func(ctx context.Context, game, player string) (privacy.DataCount, error) {
+				assert.Equal(t, gameID, game)
+				assert.Equal(t, playerID, player)
+				return privacy.DataCount{Data: privacy.DataRanks, Records: 3}, nil
+			}),
+		})
+
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/players/%s?dryRun=true", playerID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var data PlayerDataPreview
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.True(t, data.DryRun)
+		assert.Equal(t, gameID, data.GameID)
+		assert.Equal(t, privacy.PlayerDigest(gameID, playerID), data.PlayerDigest)
+		assert.Equal(t, []PlayerDataCount{{Data: privacy.DataRanks, Records: 3}}, data.Affected)
+	})
+
+	t.Run("Invalid Dry Run", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			ErasePlayerFunc: func(ctx context.Context, gameID, playerID, requestedBy string) (privacy.Receipt, error) {
+				assert.Fail(t, "ran an invalid dry run for real")
+				return privacy.Receipt{}, nil
+			},
+			PreviewPlayerDataFunc: func(ctx context.Context, gameID, playerID string) (privacy.Preview, error) {
+				return privacy.Preview{}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/players/%s?dryRun=yes", playerID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponsePlayerInvalidDryRun.Code, data.Code)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		zap.Start()
+		defer zap.Sync()
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			ErasePlayerFunc: func(ctx context.Context, gameID, playerID, requestedBy string) (privacy.Receipt, error) {
+				return privacy.Receipt{}, errors.New("any error")
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/players/%s", playerID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestBuildAnonymizePlayerHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		subject  = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			recorded   = make([]audit.NewEntryData, 0)
+			pseudonyms = make([]string, 0)
+		)
+
+		recordAuditEntryFunc := func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+			recorded = append(recorded, data)
+			return audit.Entry{ID: "receipt"}, nil
+		}
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			RecordAuditEntryFunc: recordAuditEntryFunc,
+			AnonymizePlayerFunc: privacy.BuildAnonymizePlayerFunc(nil, recordAuditEntryFunc, func(ctx context.Context, game, player, pseudonym string) (privacy.Anonymization, error) {
+				assert.Equal(t, gameID, game)
+				assert.Equal(t, playerID, player)
+				pseudonyms = append(pseudonyms, pseudonym)
+				return privacy.Anonymization{Data: privacy.DataRanks, Records: 3}, nil
+			}),
+		})
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/players/%s/anonymize", playerID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var data PlayerAnonymizationReceipt
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "receipt", data.ID)
+		assert.Equal(t, gameID, data.GameID)
+		assert.Equal(t, privacy.PlayerDigest(gameID, playerID), data.PlayerDigest)
+		assert.Equal(t, pseudonyms, []string{data.Pseudonym})
+		assert.Equal(t, subject, data.RequestedBy)
+		assert.Equal(t, []PlayerAnonymization{{Data: privacy.DataRanks, Records: 3}}, data.Anonymizations)
+
+		// Only the anonymization's own entry is recorded, so the raw player ID is never kept on the audit log
+		if assert.Len(t, recorded, 1) {
+			assert.Equal(t, privacy.AnonymizationAuditMethod, recorded[0].Method)
+			assert.Equal(t, map[string]string{"playerId": data.PlayerDigest}, recorded[0].ResourceIDs)
+		}
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			RecordAuditEntryFunc: func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				assert.Fail(t, "recorded a dry run")
+				return audit.Entry{}, nil
+			},
+			AnonymizePlayerFunc: func(ctx context.Context, gameID, playerID, requestedBy string) (privacy.AnonymizationReceipt, error) {
+				assert.Fail(t, "ran a dry run for real")
+				return privacy.AnonymizationReceipt{}, nil
+			},
+			PreviewPlayerDataFunc: privacy.BuildPreviewPlayerDataFunc(func(ctx context.Context, game, player string) (privacy.DataCount, error) {
+				assert.Equal(t, gameID, game)
+				assert.Equal(t, playerID, player)
+				return privacy.DataCount{Data: privacy.DataRanks, Records: 3}, nil
+			}),
+		})
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/players/%s/anonymize?dryRun=true", playerID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var data PlayerDataPreview
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.True(t, data.DryRun)
+		assert.Equal(t, gameID, data.GameID)
+		assert.Equal(t, privacy.PlayerDigest(gameID, playerID), data.PlayerDigest)
+		assert.Equal(t, []PlayerDataCount{{Data: privacy.DataRanks, Records: 3}}, data.Affected)
+	})
+
+	t.Run("Invalid Dry Run", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			AnonymizePlayerFunc: func(ctx context.Context, gameID, playerID, requestedBy string) (privacy.AnonymizationReceipt, error) {
+				assert.Fail(t, "ran an invalid dry run for real")
+				return privacy.AnonymizationReceipt{}, nil
+			},
+			PreviewPlayerDataFunc: func(ctx context.Context, gameID, playerID string) (privacy.Preview, error) {
+				return privacy.Preview{}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/players/%s/anonymize?dryRun=yes", playerID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponsePlayerInvalidDryRun.Code, data.Code)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		zap.Start()
+		defer zap.Sync()
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID, Subject: subject}, nil
+			},
+			AnonymizePlayerFunc: func(ctx context.Context, gameID, playerID, requestedBy string) (privacy.AnonymizationReceipt, error) {
+				return privacy.AnonymizationReceipt{}, errors.New("any error")
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/players/%s/anonymize", playerID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
@@ -0,0 +1,221 @@
+package rest
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/segment"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProfileReq struct {
+	DisplayName string            `json:"displayName"` // Name shown to the other players, up to 64 characters
+	AvatarURL   string            `json:"avatarUrl"`   // Absolute http or https url of the image shown alongside the display name. Optional
+	Country     string            `json:"country"`     // ISO 3166-1 alpha-2 code of the player's country, case insensitive. Optional
+	Metadata    map[string]string `json:"metadata"`    // Game defined details of the player, up to 32 keys of up to 64 characters and values of up to 256 characters. Optional
+}
+
+func (r ProfileReq) toDomain(gameID, playerID string) player.ProfileData {
+	return player.ProfileData{
+		GameID:      gameID,
+		PlayerID:    playerID,
+		DisplayName: r.DisplayName,
+		AvatarURL:   r.AvatarURL,
+		Country:     r.Country,
+		Metadata:    r.Metadata,
+	}
+}
+
+type Profile struct {
+	CreatedAt   time.Time         `json:"createdAt"`   // Time the profile was created
+	UpdatedAt   time.Time         `json:"updatedAt"`   // Last time the profile was changed
+	PlayerID    string            `json:"playerId"`    // Player's ID
+	DisplayName string            `json:"displayName"` // Name shown to the other players
+	AvatarURL   string            `json:"avatarUrl"`   // Image shown alongside the display name. Empty when none
+	Country     string            `json:"country"`     // ISO 3166-1 alpha-2 code of the player's country. Empty when unknown
+	Metadata    map[string]string `json:"metadata"`    // Game defined details of the player
+}
+
+func profileFromDomain(p player.Profile) Profile {
+	return Profile{
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+		PlayerID:    p.PlayerID,
+		DisplayName: p.DisplayName,
+		AvatarURL:   p.AvatarURL,
+		Country:     p.Country,
+		Metadata:    p.Metadata,
+	}
+}
+
+// Public details of a player shown alongside its rank
+type RankProfile struct {
+	DisplayName string `json:"displayName"` // Name shown to the other players
+	AvatarURL   string `json:"avatarUrl"`   // Image shown alongside the display name. Empty when none
+	Country     string `json:"country"`     // ISO 3166-1 alpha-2 code of the player's country. Empty when unknown
+}
+
+var (
+	ErrorResponseProfileInvalid       = ErrorResponse{Code: "17.0", Message: "Invalid profile"}
+	ErrorResponseProfileNotFound      = ErrorResponse{Code: "17.1", Message: "Profile not found"}
+	ErrorResponseProfileAlreadyExists = ErrorResponse{Code: "17.2", Message: "Profile already exists"}
+	ErrorResponseProfileInvalidPlayer = ErrorResponse{Code: "17.3", Message: "Invalid player id"}
+
+	ErrorResponseProfileInvalidSearch     = ErrorResponse{Code: "17.4", Message: "Search must have between 2 and 64 characters"}
+	ErrorResponseProfileSearchPageNumber  = ErrorResponse{Code: "17.5", Message: "Invalid page number"}
+	ErrorResponseProfileSearchLimitNumber = ErrorResponse{Code: "17.6", Message: "Invalid limit number"}
+)
+
+// @summary Create Player Profile
+// @description Create the profile of a player of the game
+// @router /api/v1/players/{playerId}/profile [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param ProfileReq body ProfileReq true "Profile details"
+// @success 201 {object} Profile
+// @failure 400,409,422,500 {object} ErrorResponse
+func buildCreateProfileHandler(createProfileFunc player.CreateProfileFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body ProfileReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		profile, err := createProfileFunc(c.UserContext(), body.toDomain(claims.GameID, c.Params("playerId")))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(profileFromDomain(profile))
+	}
+}
+
+// @summary Get Player Profile
+// @description Get the profile of a player of the game
+// @router /api/v1/players/{playerId}/profile [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {object} Profile
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetProfileHandler(getProfileFunc player.GetProfileFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		profile, err := getProfileFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(profileFromDomain(profile))
+	}
+}
+
+// @summary Update Player Profile
+// @description Replace the display name, avatar, country and metadata of the profile of a player of the game.
+// @description The fields left out are cleared
+// @router /api/v1/players/{playerId}/profile [PUT]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param ProfileReq body ProfileReq true "Profile details"
+// @success 200 {object} Profile
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildUpdateProfileHandler(updateProfileFunc player.UpdateProfileFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body ProfileReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		profile, err := updateProfileFunc(c.UserContext(), body.toDomain(claims.GameID, c.Params("playerId")))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(profileFromDomain(profile))
+	}
+}
+
+// @summary Delete Player Profile
+// @description Remove the profile of a player of the game. The rest of the player's data is kept
+// @router /api/v1/players/{playerId}/profile [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildDeleteProfileHandler(deleteProfileFunc player.DeleteProfileFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := deleteProfileFunc(c.UserContext(), claims.GameID, c.Params("playerId")); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary Search Players
+// @description Search the players of the game by the display name of their profiles, paginated. The players with a word
+// @description of the display name starting with the search come first, then the ones loosely matching its words
+// @router /api/v1/players [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param search query string true "Display name, or its start, searched. Case insensitive" minlength(2) maxlength(64)
+// @param page query int false "Page number" minimun(0) default(0)
+// @param limit query int false "Number of players per page" minimun(1) maximum(100) default(20)
+// @param segmentId query string false "Keep only the members of the segment. The page is filtered, so it may have fewer players than the limit"
+// @success 200 {array} Profile
+// @failure 404,422,500 {object} ErrorResponse
+func buildSearchProfilesHandler(searchProfilesFunc player.SearchProfilesFunc, filterSegmentMembersFunc segment.FilterMembersFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var (
+			claims    = c.Locals("claims").(auth.Claims)
+			page      = c.QueryInt("page", 0)
+			limit     = c.QueryInt("limit", 20)
+			segmentID = c.Query("segmentId")
+		)
+
+		if segmentID != "" && filterSegmentMembersFunc == nil {
+			return ErrSegmentFilterUnavailable
+		}
+
+		profiles, err := searchProfilesFunc(c.UserContext(), claims.GameID, c.Query("search"), page, limit)
+		if err != nil {
+			return err
+		}
+
+		if segmentID != "" {
+			playerIDs := make([]string, len(profiles))
+			for i, profile := range profiles {
+				playerIDs[i] = profile.PlayerID
+			}
+
+			members, err := filterSegmentMembersFunc(c.UserContext(), claims.GameID, segmentID, playerIDs)
+			if err != nil {
+				return err
+			}
+
+			profiles = slices.DeleteFunc(profiles, func(profile player.Profile) bool { return !slices.Contains(members, profile.PlayerID) })
+		}
+
+		data := make([]Profile, len(profiles))
+		for i, profile := range profiles {
+			data[i] = profileFromDomain(profile)
+		}
+
+		return c.Status(http.StatusOK).JSON(data)
+	}
+}
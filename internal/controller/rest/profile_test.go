@@ -0,0 +1,371 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/player"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Every player has a profile
+func newProfileTestConfig(gameID string) Config {
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		CreateProfileFunc: player.BuildCreateProfileFunc(func(ctx context.Context, profile player.Profile) error {
+			return nil
+		}),
+		GetProfileFunc: player.BuildGetProfileFunc(func(ctx context.Context, gameID, playerID string) (player.Profile, error) {
+			return player.Profile{GameID: gameID, PlayerID: playerID, DisplayName: "Player", Country: "BR"}, nil
+		}),
+		UpdateProfileFunc: player.BuildUpdateProfileFunc(func(ctx context.Context, profile player.Profile) (player.Profile, error) {
+			return profile, nil
+		}),
+		DeleteProfileFunc: player.BuildDeleteProfileFunc(func(ctx context.Context, gameID, playerID string) error {
+			return nil
+		}),
+	}
+}
+
+func TestBuildCreateProfileHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newProfileTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/"+playerID+"/profile", bytes.NewBufferString(`{"displayName": "Player", "country": "br", "metadata": {"clan": "red"}}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body Profile
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, playerID, body.PlayerID)
+		assert.Equal(t, "BR", body.Country)
+		assert.Equal(t, map[string]string{"clan": "red"}, body.Metadata)
+	})
+
+	t.Run("Already Exists", func(t *testing.T) {
+		config := newProfileTestConfig(gameID)
+		config.CreateProfileFunc = player.BuildCreateProfileFunc(func(ctx context.Context, profile player.Profile) error {
+			return player.ErrProfileAlreadyExists
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/"+playerID+"/profile", bytes.NewBufferString(`{"displayName": "Player"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestBuildGetProfileHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newProfileTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/"+playerID+"/profile", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Profile
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, playerID, body.PlayerID)
+		assert.Equal(t, "Player", body.DisplayName)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newProfileTestConfig(gameID)
+		config.GetProfileFunc = player.BuildGetProfileFunc(func(ctx context.Context, gameID, playerID string) (player.Profile, error) {
+			return player.Profile{}, player.ErrProfileNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/"+playerID+"/profile", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseProfileNotFound, body)
+	})
+}
+
+func TestBuildUpdateProfileHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var recorded audit.NewEntryData
+
+		config := newProfileTestConfig(gameID)
+		config.RecordAuditEntryFunc = func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+			recorded = data
+			return audit.Entry{ID: uuid.NewString()}, nil
+		}
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/players/"+playerID+"/profile", bytes.NewBufferString(`{"displayName": "Renamed"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Profile
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "Renamed", body.DisplayName)
+		assert.Empty(t, body.Country)
+
+		assert.Equal(t, http.MethodPut, recorded.Method)
+		assert.Equal(t, "/api/v1/players/:playerId/profile/", recorded.Route)
+		assert.Equal(t, map[string]string{"playerId": playerID}, recorded.ResourceIDs)
+		assert.Equal(t, http.StatusOK, recorded.StatusCode)
+	})
+
+	t.Run("Invalid Profile", func(t *testing.T) {
+		app := App(newProfileTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/players/"+playerID+"/profile", bytes.NewBufferString(`{"displayName": "", "avatarUrl": "avatar.png"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseProfileInvalid.Code, body.Code)
+		assert.Contains(t, body.Details, player.ErrInvalidDisplayName.Error())
+		assert.Contains(t, body.Details, player.ErrInvalidAvatarURL.Error())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newProfileTestConfig(gameID)
+		config.UpdateProfileFunc = player.BuildUpdateProfileFunc(func(ctx context.Context, profile player.Profile) (player.Profile, error) {
+			return player.Profile{}, player.ErrProfileNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/players/"+playerID+"/profile", bytes.NewBufferString(`{"displayName": "Renamed"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildDeleteProfileHandler(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newProfileTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/players/"+playerID+"/profile", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newProfileTestConfig(gameID)
+		config.DeleteProfileFunc = player.BuildDeleteProfileFunc(func(ctx context.Context, gameID, playerID string) error {
+			return player.ErrProfileNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/players/"+playerID+"/profile", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildGetRankingHandlerProfiles(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			RankingFunc: func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+				return []leaderboard.Rank{{PlayerID: "a", Position: 1, Value: 10}, {PlayerID: "b", Position: 2, Value: 5}}, nil
+			},
+			ListProfilesFunc: func(ctx context.Context, profileGameID string, playerIDs []string) (map[string]player.Profile, error) {
+				assert.Equal(t, gameID, profileGameID)
+				assert.Equal(t, []string{"a", "b"}, playerIDs)
+				return map[string]player.Profile{"a": {PlayerID: "a", DisplayName: "Player A", Country: "BR"}}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+uuid.NewString()+"/ranking", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Rank
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Rank{
+			{PlayerID: "a", Position: 1, Value: 10, Profile: &RankProfile{DisplayName: "Player A", Country: "BR"}},
+			{PlayerID: "b", Position: 2, Value: 5},
+		}, body)
+	})
+}
+
+func TestBuildSearchProfilesHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	newConfig := func(t *testing.T) Config {
+		return Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			SearchProfilesFunc: player.BuildSearchProfilesFunc(func(ctx context.Context, profileGameID, search string, offset, limit int) ([]player.Profile, error) {
+				assert.Equal(t, gameID, profileGameID)
+				return []player.Profile{{PlayerID: "a", DisplayName: search}}, nil
+			}),
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newConfig(t))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players?search=Dark+Knight&page=1&limit=5", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Profile
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "dark knight", body[0].DisplayName)
+	})
+
+	// Every search is on the same path, so they're never cached
+	t.Run("Not Cached", func(t *testing.T) {
+		app := App(newConfig(t))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players?search=Dark+Knight", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/players?search=Knight", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Profile
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "knight", body[0].DisplayName)
+	})
+
+	t.Run("Invalid Search", func(t *testing.T) {
+		for query, expected := range map[string]ErrorResponse{
+			"search=k":              ErrorResponseProfileInvalidSearch,
+			"search=knight&page=-1": ErrorResponseProfileSearchPageNumber,
+			"search=knight&limit=0": ErrorResponseProfileSearchLimitNumber,
+			"search=knight&segmentId=" + uuid.NewString(): ErrorResponseSegmentFilterUnavailable,
+		} {
+			app := App(newConfig(t))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/players?"+query, nil)
+
+			req.Header.Set("Authorization", uuid.NewString())
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+			var body ErrorResponse
+			err = json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, expected, body)
+		}
+	})
+}
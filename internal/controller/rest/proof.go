@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/proof"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SetProofRuleReq struct {
+	TopN int `json:"topN"` // Top positions requiring a proof, from 1 to 1000
+}
+
+type ProofRule struct {
+	UpdatedAt     time.Time `json:"updatedAt"`     // Last time the rule was set
+	LeaderboardID string    `json:"leaderboardId"` // Leaderboard the rule applies to
+	TopN          int       `json:"topN"`          // Top positions requiring a proof
+}
+
+func proofRuleFromDomain(r proof.Rule) ProofRule {
+	return ProofRule{
+		UpdatedAt:     r.UpdatedAt,
+		LeaderboardID: r.LeaderboardID,
+		TopN:          r.TopN,
+	}
+}
+
+var (
+	ErrorResponseProofRuleInvalid  = ErrorResponse{Code: "34.0", Message: "Invalid proof rule"}
+	ErrorResponseProofRuleNotFound = ErrorResponse{Code: "34.1", Message: "Proof rule not found"}
+	ErrorResponseProofRequired     = ErrorResponse{Code: "34.2", Message: "The submission reaches the leaderboard's top positions and requires a proof"}
+	ErrorResponseProofInvalid      = ErrorResponse{Code: "34.3", Message: "The proof must have at most 2048 characters"}
+)
+
+// @summary Set Leaderboard Proof Rule
+// @description Require a proof, e.g. a replay ID or URL, for the submissions landing on the top positions of the leaderboard,
+// @description replacing its current rule. The submissions with a proof are held for a moderator's approval, and the ones
+// @description without it rejected
+// @router /api/v1/leaderboards/{leaderboardId}/proof-rule [PUT]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @param SetProofRuleReq body SetProofRuleReq true "Top positions requiring a proof"
+// @success 200 {object} ProofRule
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildSetProofRuleHandler(setRuleFunc proof.SetRuleFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body SetProofRuleReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		rule, err := setRuleFunc(c.UserContext(), proof.Rule{
+			GameID:        claims.GameID,
+			LeaderboardID: c.Params("leaderboardId"),
+			TopN:          body.TopN,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(proofRuleFromDomain(rule))
+	}
+}
+
+// @summary Get Leaderboard Proof Rule
+// @description Get the top positions of the leaderboard requiring a proof
+// @router /api/v1/leaderboards/{leaderboardId}/proof-rule [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @success 200 {object} ProofRule
+// @failure 404,500 {object} ErrorResponse
+func buildGetProofRuleHandler(getRuleFunc proof.GetRuleFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		rule, err := getRuleFunc(c.UserContext(), claims.GameID, c.Params("leaderboardId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(proofRuleFromDomain(rule))
+	}
+}
+
+// @summary Remove Leaderboard Proof Rule
+// @description Stop requiring a proof for the top positions of the leaderboard. The submissions already waiting for approval are kept
+// @router /api/v1/leaderboards/{leaderboardId}/proof-rule [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @success 204
+// @failure 404,500 {object} ErrorResponse
+func buildRemoveProofRuleHandler(removeRuleFunc proof.RemoveRuleFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := removeRuleFunc(c.UserContext(), claims.GameID, c.Params("leaderboardId")); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
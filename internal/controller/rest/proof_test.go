@@ -0,0 +1,323 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/proof"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// The leaderboard asks for a proof on its top 2 positions, taken by alice and bob, and has a submission by dave held
+// waiting for its proof to be reviewed. The submissions held go into the slice given
+func newProofTestConfig(gameID, leaderboardID string, held *[]quarantine.Submission) Config {
+	ranks := []leaderboard.Rank{
+		{PlayerID: "alice", Position: 1, Value: 300},
+		{PlayerID: "bob", Position: 2, Value: 200},
+	}
+
+	getLeaderboard := func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+		return leaderboard.Leaderboard{ID: id, GameID: gameID, AggregationMode: leaderboard.AggregationModeMax, Ordering: leaderboard.OrderingDesc}, nil
+	}
+
+	getRanking := func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+		start, end := min(page*limit, int64(len(ranks))), min((page+1)*limit, int64(len(ranks)))
+		return ranks[start:end], nil
+	}
+
+	getPlayerRank := func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+		i := slices.IndexFunc(ranks, func(r leaderboard.Rank) bool { return r.PlayerID == playerID })
+		if i < 0 {
+			return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+		}
+
+		return ranks[i], nil
+	}
+
+	getRule := func(ctx context.Context, gameID, leaderboardID string) (proof.Rule, error) {
+		return proof.Rule{GameID: gameID, LeaderboardID: leaderboardID, TopN: 2}, nil
+	}
+
+	listHeld := func(ctx context.Context, gameID, leaderboardID string, limit int) ([]quarantine.Submission, error) {
+		return []quarantine.Submission{{ID: uuid.NewString(), GameID: gameID, LeaderboardID: leaderboardID, PlayerID: "dave", Value: 250, Reason: proof.ReasonProofRequired, Proof: "replay-1"}}, nil
+	}
+
+	hold := quarantine.BuildHoldFunc(func(ctx context.Context, s quarantine.Submission) (quarantine.Submission, error) {
+		*held = append(*held, s)
+		return s, nil
+	})
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: getLeaderboard,
+		RankingFunc:                     leaderboard.BuildRankingFunc(getRanking),
+		UpsertPlayerRankFunc: leaderboard.BuildUpsertPlayerRankFunc(nil, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			return nil
+		}),
+		SetProofRuleFunc: proof.BuildSetRuleFunc(getLeaderboard, func(ctx context.Context, rule proof.Rule) (proof.Rule, error) {
+			return rule, nil
+		}),
+		GetProofRuleFunc: proof.BuildGetRuleFunc(getRule),
+		RemoveProofRuleFunc: proof.BuildRemoveRuleFunc(func(ctx context.Context, gameID, leaderboardID string) error {
+			return nil
+		}),
+		CheckProofFunc:   proof.BuildCheckFunc(getRule, getPlayerRank, getRanking, hold),
+		MergePendingFunc: proof.BuildMergePendingFunc(listHeld, getPlayerRank, getRanking),
+	}
+}
+
+func TestBuildSetProofRuleHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var recorded audit.NewEntryData
+
+		config := newProofTestConfig(gameID, leaderboardID, &[]quarantine.Submission{})
+		config.RecordAuditEntryFunc = func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+			recorded = data
+			return audit.Entry{ID: uuid.NewString()}, nil
+		}
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/leaderboards/"+leaderboardID+"/proof-rule", bytes.NewBufferString(`{"topN": 2}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body ProofRule
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, body.TopN)
+
+		assert.Equal(t, http.MethodPut, recorded.Method)
+		assert.Equal(t, "/api/v1/leaderboards/:leaderboardId/proof-rule", recorded.Route)
+		assert.Equal(t, map[string]string{"leaderboardId": leaderboardID}, recorded.ResourceIDs)
+		assert.Equal(t, http.StatusOK, recorded.StatusCode)
+	})
+
+	t.Run("Invalid Rule", func(t *testing.T) {
+		app := App(newProofTestConfig(gameID, leaderboardID, &[]quarantine.Submission{}))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/leaderboards/"+leaderboardID+"/proof-rule", bytes.NewBufferString(`{"topN": 0}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func TestBuildGetProofRuleHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newProofTestConfig(gameID, leaderboardID, &[]quarantine.Submission{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+leaderboardID+"/proof-rule", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body ProofRule
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, body.TopN)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newProofTestConfig(gameID, leaderboardID, &[]quarantine.Submission{})
+		config.GetProofRuleFunc = proof.BuildGetRuleFunc(func(ctx context.Context, gameID, leaderboardID string) (proof.Rule, error) {
+			return proof.Rule{}, proof.ErrRuleNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+leaderboardID+"/proof-rule", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildRemoveProofRuleHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newProofTestConfig(gameID, leaderboardID, &[]quarantine.Submission{}))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/leaderboards/"+leaderboardID+"/proof-rule", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func TestBuildUpsertPlayerRankHandlerProof(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Outside The Top", func(t *testing.T) {
+		held := make([]quarantine.Submission, 0)
+		app := App(newProofTestConfig(gameID, leaderboardID, &held))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/carol", bytes.NewBufferString(`{"value": 100}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Empty(t, held)
+	})
+
+	t.Run("Proof Required", func(t *testing.T) {
+		app := App(newProofTestConfig(gameID, leaderboardID, &[]quarantine.Submission{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/dave", bytes.NewBufferString(`{"value": 250}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseProofRequired.Code, body.Code)
+	})
+
+	t.Run("Held", func(t *testing.T) {
+		held := make([]quarantine.Submission, 0)
+		app := App(newProofTestConfig(gameID, leaderboardID, &held))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/dave", bytes.NewBufferString(`{"value": 250, "proof": "replay-1"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		assert.Len(t, held, 1)
+		assert.Equal(t, quarantine.Submission{HeldAt: held[0].HeldAt, GameID: gameID, LeaderboardID: leaderboardID, PlayerID: "dave", Value: 250, Reason: proof.ReasonProofRequired, Proof: "replay-1"}, held[0])
+	})
+
+	t.Run("No Rule", func(t *testing.T) {
+		held := make([]quarantine.Submission, 0)
+
+		config := newProofTestConfig(gameID, leaderboardID, &held)
+		config.CheckProofFunc = proof.BuildCheckFunc(
+			func(ctx context.Context, gameID, leaderboardID string) (proof.Rule, error) {
+				return proof.Rule{}, proof.ErrRuleNotFound
+			},
+			nil,
+			nil,
+			nil,
+		)
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/erin", bytes.NewBufferString(`{"value": 1000}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Empty(t, held)
+	})
+}
+
+func TestBuildGetRankingHandlerPending(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Include Pending", func(t *testing.T) {
+		app := App(newProofTestConfig(gameID, leaderboardID, &[]quarantine.Submission{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+leaderboardID+"/ranking?limit=2&includePending=true", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Rank
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Rank{
+			{PlayerID: "alice", Position: 1, Value: 300},
+			{PlayerID: "dave", Position: 2, Value: 250, Pending: true},
+			{PlayerID: "bob", Position: 2, Value: 200},
+		}, body)
+	})
+
+	t.Run("Without Pending", func(t *testing.T) {
+		app := App(newProofTestConfig(gameID, leaderboardID, &[]quarantine.Submission{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+leaderboardID+"/ranking?limit=2", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Rank
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 2)
+	})
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/purge"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PurgedRecord struct {
+	Entity    string    `json:"entity"`    // Kind of the entity: `LEADERBOARD`, `STATISTIC` or `QUEST`
+	ID        string    `json:"id"`        // ID of the entity
+	DeletedAt time.Time `json:"deletedAt"` // Time the entity was soft deleted
+}
+
+type PurgeReport struct {
+	GameID        string         `json:"gameId"`        // Game purged
+	DryRun        bool           `json:"dryRun"`        // Whether the records were only listed, without removing nor recording them on the audit log
+	DeletedBefore time.Time      `json:"deletedBefore"` // Records soft deleted before it were purged
+	Counts        map[string]int `json:"counts"`        // Records purged of each kind of entity, including the ones without any
+	Records       []PurgedRecord `json:"records"`       // Records purged, or the ones that would be on a dry run
+}
+
+func purgeReportFromDomain(r purge.Report) PurgeReport {
+	records := make([]PurgedRecord, len(r.Records))
+	for i, record := range r.Records {
+		records[i] = PurgedRecord{
+			Entity:    record.Entity,
+			ID:        record.ID,
+			DeletedAt: record.DeletedAt,
+		}
+	}
+
+	return PurgeReport{
+		GameID:        r.GameID,
+		DryRun:        r.DryRun,
+		DeletedBefore: r.DeletedBefore,
+		Counts:        r.Counts(),
+		Records:       records,
+	}
+}
+
+var (
+	ErrorResponsePurgeInvalidRetention = ErrorResponse{Code: "15.0", Message: "Invalid purge retention, it must be a positive duration such as 720h"}
+)
+
+// @summary Purge Game
+// @description Permanently remove the game's leaderboards, statistics and quests soft deleted for longer than the retention,
+// @description alongside their rankings and players progression, without waiting for the periodic purge. Each record removed
+// @description is recorded on the audit log. Records removed before a storage failure stay removed, so the purge can be retried.
+// @description With `dryRun` set, the records are only listed
+// @router /admin/v1/purge/{gameId} [POST]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param retention query string true "Minimum time the records have been soft deleted for, as a duration such as `720h`"
+// @param dryRun query bool false "Only list the records the purge would remove"
+// @success 200 {object} PurgeReport
+// @failure 401,403,422,500 {object} ErrorResponse
+func buildPurgeGameHandler(purgeGameFunc, previewPurgeGameFunc purge.PurgeGameFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		dryRun, err := parseDryRun(c, previewPurgeGameFunc != nil)
+		if err != nil {
+			return err
+		}
+
+		retention, err := time.ParseDuration(c.Query("retention"))
+		if err != nil {
+			return purge.ErrInvalidRetention
+		}
+
+		run := purgeGameFunc
+		if dryRun {
+			run = previewPurgeGameFunc
+		}
+
+		report, err := run(c.UserContext(), c.Params("gameId"), retention)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(purgeReportFromDomain(report))
+	}
+}
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/purge"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPurgeGameHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+		deletedAt  = time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Second)
+		recordID   = uuid.NewString()
+	)
+
+	buildPurgeGameFunc := func(dryRun bool, called *bool) purge.PurgeGameFunc {
+		return func(ctx context.Context, gameID string, retention time.Duration) (purge.Report, error) {
+			*called = true
+			assert.Equal(t, 24*time.Hour, retention)
+
+			return purge.Report{
+				GameID:        gameID,
+				DryRun:        dryRun,
+				DeletedBefore: deletedAt.Add(time.Hour),
+				Records:       []purge.Record{{DeletedAt: deletedAt, Entity: purge.EntityQuest, ID: recordID, GameID: gameID}},
+			}, nil
+		}
+	}
+
+	purgeGame := func(app *fiber.App, query string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/admin/v1/purge/"+gameID+query, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var purged, previewed bool
+
+		app := App(Config{
+			AdminToken:           adminToken,
+			PurgeGameFunc:        buildPurgeGameFunc(false, &purged),
+			PreviewPurgeGameFunc: buildPurgeGameFunc(true, &previewed),
+		})
+
+		resp := purgeGame(app, "?retention=24h")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body PurgeReport
+		err := json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.True(t, purged)
+		assert.False(t, previewed)
+		assert.Equal(t, PurgeReport{
+			GameID:        gameID,
+			DryRun:        false,
+			DeletedBefore: deletedAt.Add(time.Hour),
+			Counts:        map[string]int{purge.EntityLeaderboard: 0, purge.EntityStatistic: 0, purge.EntityQuest: 1},
+			Records:       []PurgedRecord{{Entity: purge.EntityQuest, ID: recordID, DeletedAt: deletedAt}},
+		}, body)
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		var purged, previewed bool
+
+		app := App(Config{
+			AdminToken:           adminToken,
+			PurgeGameFunc:        buildPurgeGameFunc(false, &purged),
+			PreviewPurgeGameFunc: buildPurgeGameFunc(true, &previewed),
+		})
+
+		resp := purgeGame(app, "?retention=24h&dryRun=true")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body PurgeReport
+		err := json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.False(t, purged)
+		assert.True(t, previewed)
+		assert.True(t, body.DryRun)
+		assert.Equal(t, 1, body.Counts[purge.EntityQuest])
+	})
+
+	t.Run("Invalid Dry Run", func(t *testing.T) {
+		var purged bool
+
+		for _, config := range []Config{
+			{AdminToken: adminToken, PurgeGameFunc: buildPurgeGameFunc(false, &purged), PreviewPurgeGameFunc: buildPurgeGameFunc(true, &purged)},
+			{AdminToken: adminToken, PurgeGameFunc: buildPurgeGameFunc(false, &purged)},
+		} {
+			query := "?retention=24h&dryRun=yes"
+			if config.PreviewPurgeGameFunc == nil {
+				query = "?retention=24h&dryRun=true"
+			}
+
+			resp := purgeGame(App(config), query)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+			var data ErrorResponse
+			err := json.NewDecoder(resp.Body).Decode(&data)
+			assert.NoError(t, err)
+			assert.Equal(t, ErrorResponsePlayerInvalidDryRun, data)
+		}
+
+		assert.False(t, purged)
+	})
+
+	t.Run("Invalid Retention", func(t *testing.T) {
+		app := App(Config{
+			AdminToken:    adminToken,
+			PurgeGameFunc: purge.BuildPurgeGameFunc(nil),
+		})
+
+		for _, query := range []string{"", "?retention=invalid", "?retention=-24h", "?retention=0s"} {
+			resp := purgeGame(app, query)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+			var data ErrorResponse
+			err := json.NewDecoder(resp.Body).Decode(&data)
+			assert.NoError(t, err)
+			assert.Equal(t, ErrorResponsePurgeInvalidRetention, data)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type QuarantinedSubmission struct {
+	HeldAt        time.Time `json:"heldAt"`          // Time the submission was held
+	ID            string    `json:"id"`              // Submission ID
+	LeaderboardID string    `json:"leaderboardId"`   // Leaderboard the value was submitted to
+	PlayerID      string    `json:"playerId"`        // Player who submitted the value
+	Value         float64   `json:"value"`           // Value submitted
+	Reason        string    `json:"reason"`          // Why the submission was held, e.g. `MAX_SUBMISSION_DELTA` or `PROOF_REQUIRED`
+	Proof         string    `json:"proof,omitempty"` // Proof reference attached to the submission, e.g. a replay ID or URL
+}
+
+func quarantinedSubmissionFromDomain(s quarantine.Submission) QuarantinedSubmission {
+	return QuarantinedSubmission{
+		HeldAt:        s.HeldAt,
+		ID:            s.ID,
+		LeaderboardID: s.LeaderboardID,
+		PlayerID:      s.PlayerID,
+		Value:         s.Value,
+		Reason:        s.Reason,
+		Proof:         s.Proof,
+	}
+}
+
+var (
+	ErrorResponseQuarantineSubmissionHeld      = ErrorResponse{Code: "32.0", Message: "The submission is held for review"}
+	ErrorResponseQuarantineSubmissionNotFound  = ErrorResponse{Code: "32.1", Message: "Quarantined submission not found"}
+	ErrorResponseQuarantineInvalidSubmissionID = ErrorResponse{Code: "32.2", Message: "Invalid quarantined submission id"}
+	ErrorResponseQuarantineInvalidLimit        = ErrorResponse{Code: "32.3", Message: "The limit must be between 1 and 100"}
+)
+
+// @summary List Quarantined Submissions
+// @description List the game's rank submissions held for review, oldest first
+// @router /api/v1/quarantine [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId query string false "Keep only the submissions to the leaderboard"
+// @param limit query int false "Submissions to list, from 1 to 100" default(100)
+// @success 200 {array} QuarantinedSubmission
+// @failure 422,500 {object} ErrorResponse
+func buildListQuarantinedSubmissionsHandler(listFunc quarantine.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		submissions, err := listFunc(c.UserContext(), claims.GameID, c.Query("leaderboardId"), c.QueryInt("limit", quarantine.MaxLimit))
+		if err != nil {
+			return err
+		}
+
+		res := make([]QuarantinedSubmission, len(submissions))
+		for i, s := range submissions {
+			res[i] = quarantinedSubmissionFromDomain(s)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Release Quarantined Submission
+// @description Apply a submission held for review to its leaderboard and remove it from the review list. It's applied
+// @description even if the leaderboard has closed since it was held, and its rank change is notified as usual
+// @router /api/v1/quarantine/{submissionId}/release [POST]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param submissionId path string true "Quarantined Submission ID"
+// @success 200 {object} QuarantinedSubmission
+// @failure 404,422,500 {object} ErrorResponse
+func buildReleaseQuarantinedSubmissionHandler(releaseFunc quarantine.ReleaseFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		s, err := releaseFunc(c.UserContext(), claims.GameID, c.Params("submissionId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(quarantinedSubmissionFromDomain(s))
+	}
+}
+
+// @summary Discard Quarantined Submission
+// @description Remove a submission held for review without applying it
+// @router /api/v1/quarantine/{submissionId} [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param submissionId path string true "Quarantined Submission ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildDiscardQuarantinedSubmissionHandler(discardFunc quarantine.DiscardFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := discardFunc(c.UserContext(), claims.GameID, c.Params("submissionId")); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
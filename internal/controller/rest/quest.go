@@ -2,7 +2,6 @@ package rest
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"time"
 
@@ -22,17 +21,19 @@ type CreateQuestReq struct {
 		RequiredForCompletion *bool  `json:"requiredForCompletion"` // Is this task required for the quest completion? Defaults to `true`
 		Rule                  string `json:"rule"`                  // Task completion logic as JsonLogic. See https://jsonlogic.com/
 	} `json:"tasks"` // Quest task list
-	TasksValidators []string `json:"tasksValidators"` // Quest task list success validation data
+	TasksValidators []string  `json:"tasksValidators"` // Quest task list success validation data
+	EndAt           time.Time `json:"endAt"`           // Time that the quest expires for the players that didn't complete it. Optional
 }
 
 type Quest struct {
-	CreatedAt   time.Time `json:"createdAt"`   // Time that the quest was created
-	UpdatedAt   time.Time `json:"updatedAt"`   // Last time that the quest was updated
-	ID          string    `json:"id"`          // Quest ID
-	GameID      string    `json:"gameId"`      // ID of the game responsible for the quest
-	Name        string    `json:"name"`        // Quest name
-	Description string    `json:"description"` // Quest details
-	Tasks       []Task    `json:"tasks"`       // Quest task list
+	CreatedAt   time.Time  `json:"createdAt"`   // Time that the quest was created
+	UpdatedAt   time.Time  `json:"updatedAt"`   // Last time that the quest was updated
+	ID          string     `json:"id"`          // Quest ID
+	GameID      string     `json:"gameId"`      // ID of the game responsible for the quest
+	Name        string     `json:"name"`        // Quest name
+	Description string     `json:"description"` // Quest details
+	Tasks       []Task     `json:"tasks"`       // Quest task list
+	EndAt       *time.Time `json:"endAt"`       // Time that the quest expires for the players that didn't complete it
 }
 
 func (q CreateQuestReq) toDomain(gameID string) quest.NewQuestData {
@@ -58,6 +59,7 @@ func (q CreateQuestReq) toDomain(gameID string) quest.NewQuestData {
 		Description:     q.Description,
 		Tasks:           tasks,
 		TasksValidators: q.TasksValidators,
+		EndAt:           q.EndAt,
 	}
 }
 
@@ -67,6 +69,11 @@ func questFromDomain(q quest.Quest) Quest {
 		tasks[i] = taskFromDomain(task)
 	}
 
+	var endAt *time.Time
+	if !q.EndAt.IsZero() {
+		endAt = &q.EndAt
+	}
+
 	return Quest{
 		CreatedAt:   q.CreatedAt,
 		UpdatedAt:   q.UpdatedAt,
@@ -75,6 +82,7 @@ func questFromDomain(q quest.Quest) Quest {
 		Name:        q.Name,
 		Description: q.Description,
 		Tasks:       tasks,
+		EndAt:       endAt,
 	}
 }
 
@@ -89,17 +97,17 @@ func buildGetQuestMiddleware(cache fiber.Storage, expiration time.Duration, getQ
 		var (
 			id       = c.Params("questId")
 			claims   = c.Locals("claims").(auth.Claims)
-			cacheKey = fmt.Sprintf("GetQuestMiddleware:%s:%s", id, claims.GameID)
+			cacheKey = questCacheKey(id, claims.GameID)
 		)
 
 		if cache != nil {
 			data, err := cache.Get(cacheKey)
 			if err != nil {
-				zap.Error(err, "get cache error")
+				zap.ErrorContext(c.UserContext(), err, "get cache error")
 			} else if data != nil {
 				var quest quest.Quest
 				if err = json.Unmarshal(data, &quest); err != nil {
-					zap.Error(err, "unmarshal cached quest error")
+					zap.ErrorContext(c.UserContext(), err, "unmarshal cached quest error")
 				} else {
 					c.Locals("quest", quest)
 					return c.Next()
@@ -107,7 +115,7 @@ func buildGetQuestMiddleware(cache fiber.Storage, expiration time.Duration, getQ
 			}
 		}
 
-		quest, err := getQuestByIDAndGameIDFunc(c.Context(), id, claims.GameID)
+		quest, err := getQuestByIDAndGameIDFunc(c.UserContext(), id, claims.GameID)
 		if err != nil {
 			return err
 		}
@@ -115,10 +123,10 @@ func buildGetQuestMiddleware(cache fiber.Storage, expiration time.Duration, getQ
 		if cache != nil {
 			data, err := json.Marshal(quest)
 			if err != nil {
-				zap.Error(err, "marshal quest cache error")
+				zap.ErrorContext(c.UserContext(), err, "marshal quest cache error")
 			} else {
 				if err = cache.Set(cacheKey, data, expiration); err != nil {
-					zap.Error(err, "unable to cache quest")
+					zap.ErrorContext(c.UserContext(), err, "unable to cache quest")
 				}
 			}
 		}
@@ -146,7 +154,7 @@ func buildCreateQuestHanlder(createQuestFunc quest.CreateQuestFunc) fiber.Handle
 			return err
 		}
 
-		quest, err := createQuestFunc(c.Context(), body.toDomain(claims.GameID))
+		quest, err := createQuestFunc(c.UserContext(), body.toDomain(claims.GameID))
 		if err != nil {
 			return err
 		}
@@ -170,7 +178,7 @@ func buildGetQuestHanlder(getQuestByIDAndGameID quest.GetQuestByIDAndGameIDFunc)
 			claims  = c.Locals("claims").(auth.Claims)
 		)
 
-		quest, err := getQuestByIDAndGameID(c.Context(), questID, claims.GameID)
+		quest, err := getQuestByIDAndGameID(c.UserContext(), questID, claims.GameID)
 		if err != nil {
 			return err
 		}
@@ -186,17 +194,18 @@ func buildGetQuestHanlder(getQuestByIDAndGameID quest.GetQuestByIDAndGameIDFunc)
 // @param questId path string true "Quest ID"
 // @success 204
 // @failure 404,422,500 {object} ErrorResponse
-func buildDeleteQuestHanlder(softDeleteQuestFunc quest.SoftDeleteQuestFunc) fiber.Handler {
+func buildDeleteQuestHanlder(cache fiber.Storage, softDeleteQuestFunc quest.SoftDeleteQuestFunc) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var (
 			questID = c.Params("questId")
 			claims  = c.Locals("claims").(auth.Claims)
 		)
 
-		if err := softDeleteQuestFunc(c.Context(), questID, claims.GameID); err != nil {
+		if err := softDeleteQuestFunc(c.UserContext(), questID, claims.GameID); err != nil {
 			return err
 		}
 
+		invalidateCache(cache, c, questCacheKey(questID, claims.GameID))
 		return c.SendStatus(http.StatusNoContent)
 	}
 }
@@ -0,0 +1,136 @@
+package rest
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/quota"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SetQuotaReq struct {
+	RequestsPerMinute int64 `json:"requestsPerMinute"` // Requests the game can send per minute, on each instance of the API. Unlimited when zero
+	MaxLeaderboards   int64 `json:"maxLeaderboards"`   // Leaderboards the game can keep, not counting the deleted ones. Unlimited when zero
+	MaxStatistics     int64 `json:"maxStatistics"`     // Statistics the game can keep, not counting the deleted ones. Unlimited when zero
+}
+
+func (r SetQuotaReq) toDomain(gameID string) quota.Quota {
+	return quota.Quota{
+		GameID:            gameID,
+		RequestsPerMinute: r.RequestsPerMinute,
+		MaxLeaderboards:   r.MaxLeaderboards,
+		MaxStatistics:     r.MaxStatistics,
+	}
+}
+
+type Quota struct {
+	GameID            string     `json:"gameId"`            // Game the quota belongs to
+	RequestsPerMinute int64      `json:"requestsPerMinute"` // Requests the game can send per minute, on each instance of the API. Unlimited when zero
+	MaxLeaderboards   int64      `json:"maxLeaderboards"`   // Leaderboards the game can keep, not counting the deleted ones. Unlimited when zero
+	MaxStatistics     int64      `json:"maxStatistics"`     // Statistics the game can keep, not counting the deleted ones. Unlimited when zero
+	Default           bool       `json:"default"`           // Whether the game has no quota of its own, so the default one applies
+	UpdatedAt         *time.Time `json:"updatedAt"`         // Last time the quota was set. Null for the default one
+}
+
+func quotaFromDomain(q quota.Quota) Quota {
+	var updatedAt *time.Time
+	if !q.UpdatedAt.IsZero() {
+		updatedAt = &q.UpdatedAt
+	}
+
+	return Quota{
+		GameID:            q.GameID,
+		RequestsPerMinute: q.RequestsPerMinute,
+		MaxLeaderboards:   q.MaxLeaderboards,
+		MaxStatistics:     q.MaxStatistics,
+		Default:           q.Default,
+		UpdatedAt:         updatedAt,
+	}
+}
+
+type QuotaStatus struct {
+	Quota        Quota `json:"quota"`        // Quota of the game
+	Leaderboards int64 `json:"leaderboards"` // Leaderboards the game keeps, not counting the deleted ones
+	Statistics   int64 `json:"statistics"`   // Statistics the game keeps, not counting the deleted ones
+}
+
+var (
+	ErrorResponseQuotaInvalid                 = ErrorResponse{Code: "16.0", Message: "Invalid quota"}
+	ErrorResponseQuotaRateLimited             = ErrorResponse{Code: "16.1", Message: "Too many requests, try again later"}
+	ErrorResponseQuotaLeaderboardLimitReached = ErrorResponse{Code: "16.2", Message: "Leaderboard limit reached"}
+	ErrorResponseQuotaStatisticLimitReached   = ErrorResponse{Code: "16.3", Message: "Statistic limit reached"}
+)
+
+// Rejects the requests of the authenticated game over its rate limit, telling on `Retry-After` when to try again
+func buildRateLimitMiddleware(allowRequestFunc quota.AllowFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		retryAfter, err := allowRequestFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			if errors.Is(err, quota.ErrRateLimited) {
+				c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			}
+
+			return err
+		}
+
+		return c.Next()
+	}
+}
+
+// @summary Get Game Quota
+// @description Get the quota of the game, the default one when it has none of its own, alongside how much of it the game uses
+// @router /admin/v1/games/{gameId}/quota [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @success 200 {object} QuotaStatus
+// @failure 401,403,500 {object} ErrorResponse
+func buildGetQuotaHandler(quotaStatusFunc quota.StatusFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		status, err := quotaStatusFunc(c.UserContext(), c.Params("gameId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(QuotaStatus{
+			Quota:        quotaFromDomain(status.Quota),
+			Leaderboards: status.Leaderboards,
+			Statistics:   status.Statistics,
+		})
+	}
+}
+
+// @summary Set Game Quota
+// @description Replace the quota of the game. A zero limit means unlimited. The new rate limit takes up to `QUOTA_CACHE_TTL`
+// @description seconds to apply on every instance, while the leaderboard and statistic limits apply right away.
+// @description Lowering a limit below what the game already keeps deletes nothing, it just blocks new ones
+// @router /admin/v1/games/{gameId}/quota [PUT]
+// @accept json
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param SetQuotaReq body SetQuotaReq true "New quota"
+// @success 200 {object} Quota
+// @failure 400,401,403,422,500 {object} ErrorResponse
+func buildSetQuotaHandler(setQuotaFunc quota.SetFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body SetQuotaReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		q, err := setQuotaFunc(c.UserContext(), body.toDomain(c.Params("gameId")))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(quotaFromDomain(q))
+	}
+}
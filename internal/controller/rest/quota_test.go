@@ -0,0 +1,195 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quota"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRateLimitMiddleware(t *testing.T) {
+	var (
+		gameID  = uuid.NewString()
+		limiter = quota.NewLimiter(time.Hour, func(ctx context.Context, gameID string) (quota.Quota, error) {
+			return quota.Quota{GameID: gameID, RequestsPerMinute: 1}, nil
+		})
+	)
+
+	app := App(Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		AllowRequestFunc: limiter.Allow,
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		},
+	})
+
+	get := func() *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+uuid.NewString(), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	resp := get()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = get()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	assert.NoError(t, err)
+	assert.Greater(t, retryAfter, 0)
+	assert.LessOrEqual(t, retryAfter, 60)
+
+	var body ErrorResponse
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorResponseQuotaRateLimited, body)
+}
+
+func TestCreateQuotaLimits(t *testing.T) {
+	gameID := uuid.NewString()
+
+	app := App(Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		CheckLeaderboardQuotaFunc: func(ctx context.Context, gameID string) error {
+			return quota.ErrLeaderboardLimitReached
+		},
+		CheckStatisticQuotaFunc: func(ctx context.Context, gameID string) error {
+			return quota.ErrStatisticLimitReached
+		},
+		CreateLeaderboardFunc: func(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
+			t.Fail()
+			return leaderboard.Leaderboard{}, nil
+		},
+		CreateStatisticFunc: func(ctx context.Context, data statistic.NewStatisticData) (statistic.Statistic, error) {
+			t.Fail()
+			return statistic.Statistic{}, nil
+		},
+	})
+
+	for path, expected := range map[string]ErrorResponse{
+		"/api/v1/leaderboards": ErrorResponseQuotaLeaderboardLimitReached,
+		"/api/v1/statistics":   ErrorResponseQuotaStatisticLimitReached,
+	} {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"name": "Kills"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, body)
+	}
+}
+
+func TestQuotaHandlers(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+		stored     = make(map[string]quota.Quota)
+	)
+
+	getFunc := quota.BuildGetFunc(quota.Quota{RequestsPerMinute: 600}, func(ctx context.Context, gameID string) (quota.Quota, error) {
+		q, ok := stored[gameID]
+		if !ok {
+			return quota.Quota{}, quota.ErrQuotaNotFound
+		}
+
+		return q, nil
+	})
+
+	app := App(Config{
+		AdminToken: adminToken,
+		GetQuotaStatusFunc: quota.BuildStatusFunc(
+			getFunc,
+			func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+				return make([]leaderboard.Leaderboard, 2), nil
+			},
+			func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+				return nil, nil
+			},
+		),
+		SetQuotaFunc: quota.BuildSetFunc(func(ctx context.Context, q quota.Quota) error {
+			stored[q.GameID] = q
+			return nil
+		}),
+	})
+
+	getStatus := func() QuotaStatus {
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/quota", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body QuotaStatus
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		return body
+	}
+
+	setQuota := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPut, "/admin/v1/games/"+gameID+"/quota", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		status := getStatus()
+		assert.Equal(t, QuotaStatus{
+			Quota:        Quota{GameID: gameID, RequestsPerMinute: 600, Default: true},
+			Leaderboards: 2,
+		}, status)
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		resp := setQuota(`{"requestsPerMinute": 60, "maxLeaderboards": 5, "maxStatistics": 10}`)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Quota
+		err := json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, gameID, body.GameID)
+		assert.Equal(t, int64(60), body.RequestsPerMinute)
+		assert.Equal(t, int64(5), body.MaxLeaderboards)
+		assert.Equal(t, int64(10), body.MaxStatistics)
+		assert.False(t, body.Default)
+		assert.NotNil(t, body.UpdatedAt)
+
+		status := getStatus()
+		assert.Equal(t, body, status.Quota)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		resp := setQuota(`{"requestsPerMinute": -1}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Kept under the 30 seconds most proxies and load balancers wait on an idle request
+const rankPollDefaultTimeout = 25
+
+type RankPollRes struct {
+	Changed       bool    `json:"changed"`       // Whether the rank differs from the cursor's. False when the poll timed out
+	Rank          *Rank   `json:"rank"`          // Player's current rank. Null when the player isn't ranked yet
+	PositionDelta int64   `json:"positionDelta"` // Positions climbed since the cursor, negative when the player dropped
+	ValueDelta    float64 `json:"valueDelta"`    // Value gained since the cursor
+	Cursor        string  `json:"cursor"`        // Cursor to send on the next poll
+}
+
+func rankPollResFromDomain(delta leaderboard.RankDelta) RankPollRes {
+	res := RankPollRes{
+		Changed:       delta.Changed,
+		PositionDelta: delta.PositionDelta,
+		ValueDelta:    delta.ValueDelta,
+		Cursor:        delta.Cursor,
+	}
+
+	if delta.Rank != nil {
+		rank := rankFromDomain(*delta.Rank)
+		res.Rank = &rank
+	}
+
+	return res
+}
+
+var (
+	ErrorResponseRankPollTimeout = ErrorResponse{Code: "2.4", Message: "invalid poll timeout"}
+	ErrorResponseRankPollCursor  = ErrorResponse{Code: "2.5", Message: "invalid rank cursor"}
+)
+
+// @summary Poll Player Rank
+// @description Wait for the player's rank to change since the cursor, for the clients that can't hold a WebSocket or an event stream.
+// @description Without a cursor, the current rank is returned right away. The cursor returned is sent on the next poll
+// @router /api/v1/leaderboards/{leaderboardId}/ranking/{playerId}/poll [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @param playerId path string true "Player ID"
+// @param cursor query string false "Cursor returned by the previous poll"
+// @param timeout query int false "Seconds to wait for a change" minimum(1) maximum(30) default(25)
+// @success 200 {object} RankPollRes
+// @failure 404,422,500 {object} ErrorResponse
+func buildPollPlayerRankHandler(pollPlayerRankFunc leaderboard.PollPlayerRankFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var (
+			lb       = c.Locals("leaderboard").(leaderboard.Leaderboard)
+			playerID = c.Params("playerId")
+			cursor   = c.Query("cursor")
+			timeout  = time.Duration(c.QueryInt("timeout", rankPollDefaultTimeout)) * time.Second
+		)
+
+		delta, err := pollPlayerRankFunc(c.UserContext(), lb, playerID, cursor, timeout)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(rankPollResFromDomain(delta))
+	}
+}
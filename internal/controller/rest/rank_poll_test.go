@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPollPlayerRankHandler(t *testing.T) {
+	var (
+		leaderboardID = uuid.NewString()
+		gameID        = uuid.NewString()
+		playerID      = uuid.NewString()
+	)
+
+	config := func(pollPlayerRankFunc leaderboard.PollPlayerRankFunc) Config {
+		return Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			PollPlayerRankFunc: pollPlayerRankFunc,
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(config(func(ctx context.Context, lb leaderboard.Leaderboard, id, cursor string, timeout time.Duration) (leaderboard.RankDelta, error) {
+			assert.Equal(t, leaderboardID, lb.ID)
+			assert.Equal(t, playerID, id)
+			assert.Equal(t, "abc", cursor)
+			assert.Equal(t, 5*time.Second, timeout)
+
+			return leaderboard.RankDelta{
+				Changed:       true,
+				Rank:          &leaderboard.Rank{PlayerID: id, Position: 1, Value: 20},
+				PositionDelta: 2,
+				ValueDelta:    5,
+				Cursor:        "def",
+			}, nil
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s/ranking/%s/poll?cursor=abc&timeout=5", leaderboardID, playerID), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var data RankPollRes
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, RankPollRes{
+			Changed:       true,
+			Rank:          &Rank{PlayerID: playerID, Position: 1, Value: 20},
+			PositionDelta: 2,
+			ValueDelta:    5,
+			Cursor:        "def",
+		}, data)
+	})
+
+	t.Run("Not Ranked", func(t *testing.T) {
+		app := App(config(func(ctx context.Context, lb leaderboard.Leaderboard, id, cursor string, timeout time.Duration) (leaderboard.RankDelta, error) {
+			assert.Equal(t, time.Duration(rankPollDefaultTimeout)*time.Second, timeout)
+			return leaderboard.RankDelta{Cursor: "def"}, nil
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s/ranking/%s/poll", leaderboardID, playerID), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var data RankPollRes
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.False(t, data.Changed)
+		assert.Nil(t, data.Rank)
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		app := App(config(func(ctx context.Context, lb leaderboard.Leaderboard, id, cursor string, timeout time.Duration) (leaderboard.RankDelta, error) {
+			return leaderboard.RankDelta{}, leaderboard.ErrInvalidRankCursor
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s/ranking/%s/poll?cursor=abc", leaderboardID, playerID), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseRankPollCursor.Code, data.Code)
+	})
+
+	t.Run("Invalid Timeout", func(t *testing.T) {
+		app := App(config(func(ctx context.Context, lb leaderboard.Leaderboard, id, cursor string, timeout time.Duration) (leaderboard.RankDelta, error) {
+			return leaderboard.RankDelta{}, leaderboard.ErrInvalidPollTimeout
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s/ranking/%s/poll?timeout=60", leaderboardID, playerID), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseRankPollTimeout.Code, data.Code)
+	})
+}
@@ -3,19 +3,33 @@ package rest
 import (
 	"net/http"
 
+	"github.com/gabapcia/gameblitz/internal/auth"
 	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/presence"
+	"github.com/gabapcia/gameblitz/internal/proof"
+	"github.com/gabapcia/gameblitz/internal/shadow"
+	"github.com/gabapcia/gameblitz/internal/signing"
+	"github.com/gabapcia/gameblitz/internal/title"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type UpsertPlayerRankReq struct {
-	Value float64 `json:"value"` // Value that will be used to update the player's rank
+	Value     float64 `json:"value"`               // Value that will be used to update the player's rank
+	Nonce     string  `json:"nonce,omitempty"`     // Nonce issued to the player. Required with the signed submissions enabled
+	Signature string  `json:"signature,omitempty"` // Hex encoded HMAC-SHA256 of the submission. Required with the signed submissions enabled
+	Proof     string  `json:"proof,omitempty"`     // Proof of the score, e.g. a replay ID or URL. Required to reach the top positions of a leaderboard with a proof rule
 }
 
 type Rank struct {
-	PlayerID string  `json:"playerId"` // Player's ID
-	Position int64   `json:"position"` // Player ranking position
-	Value    float64 `json:"value"`    // Player rank value
+	PlayerID string          `json:"playerId"`           // Player's ID
+	Position int64           `json:"position"`           // Player ranking position
+	Value    float64         `json:"value"`              // Player rank value
+	Profile  *RankProfile    `json:"profile,omitempty"`  // Player's profile. Only on the ranking pages, left out when the player has none
+	Titles   []RankTitle     `json:"titles,omitempty"`   // Titles granted to the player, oldest first. Only on the ranking pages, left out when the player has none
+	Presence *PresenceStatus `json:"presence,omitempty"` // Player's presence. Only on the ranking pages, left out when the player wasn't seen within the retention
+	Pending  bool            `json:"pending,omitempty"`  // Whether the rank is a submission waiting for a moderator's approval. Only on the ranking pages merging them
 }
 
 func rankFromDomain(r leaderboard.Rank) Rank {
@@ -30,24 +44,54 @@ var (
 	ErrorResponseLeaderboardClosed  = ErrorResponse{Code: "2.0", Message: "leaderboard closed"}
 	ErrorResponseRankingPageNumber  = ErrorResponse{Code: "2.1", Message: "invalid page number"}
 	ErrorResponseRankingLimitNumber = ErrorResponse{Code: "2.2", Message: "invalid limit number"}
+	ErrorResponseInvalidFingerprint = ErrorResponse{Code: "2.6", Message: "invalid fingerprint"}
 )
 
+const (
+	HeaderDeviceHash   = "X-Device-Hash"   // Hash of the device ID the submission was sent from
+	HeaderBuildVersion = "X-Build-Version" // Version of the client build the submission was sent from
+)
+
+// Client the request came from. The region is only read when its header is set, as it's derived from the IP by a proxy
+func fingerprintFromRequest(c *fiber.Ctx, regionHeader string) (leaderboard.Fingerprint, error) {
+	f := leaderboard.Fingerprint{
+		DeviceHash:   c.Get(HeaderDeviceHash),
+		BuildVersion: c.Get(HeaderBuildVersion),
+	}
+	if regionHeader != "" {
+		f.Region = c.Get(regionHeader)
+	}
+
+	return f, f.Validate()
+}
+
 // @summary Upsert Player Rank
-// @description Set or update a player's rank on the leaderboard
+// @description Set or update a player's rank on the leaderboard. The submissions of a banned player are rejected.
+// @description With the signed submissions enabled, the submission carries a nonce issued to the player and its signature,
+// @description and is rejected when either is missing or invalid, or when the nonce was already used. With a delta rule set
+// @description on the leaderboard, the submissions improving the player's value past its limits are either rejected or held
+// @description for review, answered with `202 Accepted`. With a proof rule set on the leaderboard, the submissions landing on its
+// @description top positions are rejected without a proof, and held for a moderator's approval with one. With the submission
+// @description rate limit enabled, the player's submissions over it are rejected until the minute ends. The optional fingerprint
+// @description headers are recorded on the score history, with the region read from the header set by the proxy in front of the
+// @description API, when configured. The submissions sent from a banned device are rejected
 // @router /api/v1/leaderboards/{leaderboardId}/ranking/{playerId} [POST]
 // @accept json
 // @produce json
 // @param Authorization header string true "Game's JWT authorization"
 // @param leaderboardId path string true "Leaderboard ID"
 // @param playerId path string true "Player ID"
+// @param X-Device-Hash header string false "Hash of the device ID, up to 128 characters"
+// @param X-Build-Version header string false "Version of the client build, up to 64 characters"
 // @param UpsertPlayerRankData body UpsertPlayerRankReq true "Values to update the player rank"
 // @success 204
-// @failure 400,404,422,500 {object} ErrorResponse
-func buildUpsertPlayerRankHandler(upsertPlayerRankFunc leaderboard.UpsertPlayerRankFunc) fiber.Handler {
+// @success 202 {object} ErrorResponse
+// @failure 400,403,404,422,429,500 {object} ErrorResponse
+func buildUpsertPlayerRankHandler(upsertPlayerRankFunc leaderboard.UpsertPlayerRankFunc, verifySubmissionFunc signing.VerifyFunc, checkProofFunc proof.CheckFunc, regionHeader string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var (
-			leaderboard = c.Locals("leaderboard").(leaderboard.Leaderboard)
-			playerID    = c.Params("playerId")
+			lb       = c.Locals("leaderboard").(leaderboard.Leaderboard)
+			playerID = c.Params("playerId")
 		)
 
 		var body UpsertPlayerRankReq
@@ -55,7 +99,33 @@ func buildUpsertPlayerRankHandler(upsertPlayerRankFunc leaderboard.UpsertPlayerR
 			return err
 		}
 
-		if err := upsertPlayerRankFunc(c.Context(), leaderboard, playerID, body.Value); err != nil {
+		fingerprint, err := fingerprintFromRequest(c, regionHeader)
+		if err != nil {
+			return err
+		}
+
+		if verifySubmissionFunc != nil {
+			err := verifySubmissionFunc(c.UserContext(), signing.Submission{
+				GameID:        lb.GameID,
+				LeaderboardID: lb.ID,
+				PlayerID:      playerID,
+				Value:         body.Value,
+				Nonce:         body.Nonce,
+				Signature:     body.Signature,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if checkProofFunc != nil {
+			if err := checkProofFunc(c.UserContext(), lb, playerID, body.Value, body.Proof); err != nil {
+				return err
+			}
+		}
+
+		ctx := leaderboard.WithFingerprint(leaderboard.WithSource(c.UserContext(), leaderboard.SourceAPI), fingerprint)
+		if err := upsertPlayerRankFunc(ctx, lb, playerID, body.Value); err != nil {
 			return err
 		}
 
@@ -64,31 +134,107 @@ func buildUpsertPlayerRankHandler(upsertPlayerRankFunc leaderboard.UpsertPlayerR
 }
 
 // @summary Leaderboard Ranking
-// @description Get the leaderboard ranking paginated
+// @description Get the leaderboard ranking paginated. With the player profiles enabled, each rank carries the player's
+// @description display name, avatar and country, when the player has a profile. With the titles enabled, each rank carries
+// @description the titles granted to the player. With the presence enabled, each rank carries whether the player is online
+// @description and when it was last seen. With `includePending`, the submissions waiting for a moderator's approval to reach the
+// @description leaderboard's top positions are merged into the page, each at the position it would take, flagged as `pending`.
+// @description With `playerId`, the page is the one seen by the player, so the shadow rank of a flagged player is placed on it
 // @router /api/v1/leaderboards/{leaderboardId}/ranking [GET]
 // @produce json
 // @param Authorization header string true "Game's JWT authorization"
 // @param leaderboardId path string true "Leaderboard ID"
 // @param page query int false "Page number" minimun(0) default(0)
 // @param limit query int false "Number of rankings per page" minimun(1) maximum(500) default(10)
+// @param includePending query bool false "Merge the submissions waiting for approval into the page" default(false)
+// @param playerId query string false "Player viewing the ranking"
 // @success 200 {array} Rank
 // @failure 400,404,422,500 {object} ErrorResponse
-func buildGetRankingHandler(rankingFunc leaderboard.RankingFunc) fiber.Handler {
+func buildGetRankingHandler(
+	rankingFunc leaderboard.RankingFunc,
+	mergeShadowRankFunc shadow.MergeViewerFunc,
+	mergePendingFunc proof.MergePendingFunc,
+	listProfilesFunc player.ListProfilesFunc,
+	listPlayersTitlesFunc title.ListPlayersTitlesFunc,
+	listPresencesFunc presence.ListFunc,
+) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var (
+			claims      = c.Locals("claims").(auth.Claims)
 			leaderboard = c.Locals("leaderboard").(leaderboard.Leaderboard)
 			page        = c.QueryInt("page", 0)
 			limit       = c.QueryInt("limit", 10)
 		)
 
-		rankings, err := rankingFunc(c.Context(), leaderboard, int64(page), int64(limit))
+		ranks, err := rankingFunc(c.UserContext(), leaderboard, int64(page), int64(limit))
 		if err != nil {
 			return err
 		}
 
-		data := make([]Rank, len(rankings))
+		if viewerID := c.Query("playerId"); mergeShadowRankFunc != nil && viewerID != "" {
+			if ranks, err = mergeShadowRankFunc(c.UserContext(), leaderboard, viewerID, ranks, int64(page), int64(limit)); err != nil {
+				return err
+			}
+		}
+
+		rankings := make([]proof.Entry, len(ranks))
+		for i, rank := range ranks {
+			rankings[i] = proof.Entry{Rank: rank}
+		}
+
+		if mergePendingFunc != nil && c.QueryBool("includePending") {
+			if rankings, err = mergePendingFunc(c.UserContext(), leaderboard, ranks, int64(page), int64(limit)); err != nil {
+				return err
+			}
+		}
+
+		var (
+			data      = make([]Rank, len(rankings))
+			playerIDs = make([]string, len(rankings))
+		)
 		for i, rank := range rankings {
-			data[i] = rankFromDomain(rank)
+			data[i] = rankFromDomain(rank.Rank)
+			data[i].Pending = rank.Pending
+			playerIDs[i] = rank.PlayerID
+		}
+
+		if listProfilesFunc != nil && len(rankings) > 0 {
+			profiles, err := listProfilesFunc(c.UserContext(), claims.GameID, playerIDs)
+			if err != nil {
+				return err
+			}
+
+			for i := range data {
+				if profile, ok := profiles[data[i].PlayerID]; ok {
+					data[i].Profile = &RankProfile{DisplayName: profile.DisplayName, AvatarURL: profile.AvatarURL, Country: profile.Country}
+				}
+			}
+		}
+
+		if listPlayersTitlesFunc != nil && len(rankings) > 0 {
+			titles, err := listPlayersTitlesFunc(c.UserContext(), claims.GameID, playerIDs)
+			if err != nil {
+				return err
+			}
+
+			for i := range data {
+				for _, t := range titles[data[i].PlayerID] {
+					data[i].Titles = append(data[i].Titles, RankTitle{ID: t.Title.ID, Name: t.Title.Name, BadgeURL: t.Title.BadgeURL})
+				}
+			}
+		}
+
+		if listPresencesFunc != nil && len(rankings) > 0 {
+			presences, err := listPresencesFunc(c.UserContext(), claims.GameID, playerIDs)
+			if err != nil {
+				return err
+			}
+
+			for i := range data {
+				if p, ok := presences[data[i].PlayerID]; ok {
+					data[i].Presence = &PresenceStatus{Online: p.Online, LastSeenAt: p.LastSeenAt}
+				}
+			}
 		}
 
 		return c.Status(http.StatusOK).JSON(data)
@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gabapcia/gameblitz/internal/auth"
@@ -49,6 +50,67 @@ func TestBuildUpsertPlayerRankHandler(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 	})
 
+	t.Run("Fingerprint", func(t *testing.T) {
+		var fingerprint leaderboard.Fingerprint
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			UpsertPlayerRankFunc: func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+				fingerprint = leaderboard.FingerprintFromContext(ctx)
+				return nil
+			},
+			FingerprintRegionHeader: "CF-IPCountry",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/leaderboards/%s/ranking/%s", leaderboardID, playerID), bytes.NewBufferString(`{"value": 100.0}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+		req.Header.Set(HeaderDeviceHash, "f00d")
+		req.Header.Set(HeaderBuildVersion, "1.4.2")
+		req.Header.Set("CF-IPCountry", "BR")
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, leaderboard.Fingerprint{DeviceHash: "f00d", BuildVersion: "1.4.2", Region: "BR"}, fingerprint)
+	})
+
+	t.Run("Invalid Fingerprint", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			UpsertPlayerRankFunc: func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/leaderboards/%s/ranking/%s", leaderboardID, playerID), bytes.NewBufferString(`{"value": 100.0}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+		req.Header.Set(HeaderDeviceHash, strings.Repeat("a", leaderboard.MaxDeviceHashLength+1))
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseInvalidFingerprint, data)
+	})
+
 	t.Run("Invalid Request Body", func(t *testing.T) {
 		app := App(Config{
 			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
@@ -332,4 +394,49 @@ func TestBuildGetRankingHandler(t *testing.T) {
 		assert.Equal(t, ErrorResponseInternalServerError.Code, body.Code)
 		assert.Equal(t, ErrorResponseInternalServerError.Message, body.Message)
 	})
+
+	// The ranking cache is dropped on every write to the rankings, so the pages it serves skip the response cache
+	t.Run("Not Cached With Ranking Cache", func(t *testing.T) {
+		read := false
+
+		app := App(Config{
+			RankingCacheEnabled: true,
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			RankingFunc: func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+				if read {
+					return []leaderboard.Rank{}, nil
+				}
+
+				read = true
+				return []leaderboard.Rank{{LeaderboardID: lb.ID, PlayerID: "alice", Position: 0, Value: 10}}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s/ranking", leaderboardID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s/ranking", leaderboardID), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Rank
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Empty(t, body)
+	})
 }
@@ -0,0 +1,30 @@
+package rest
+
+import (
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Scopes the logger of the request context to the request, so every entry logged while handling it,
+// by the handlers or the usecases, carries its `requestId`, `method`, `path` and, once traced, its `traceId`.
+// The `gameId` is added once the game is authenticated
+func buildRequestLoggerMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID, _ := c.Locals(requestid.ConfigDefault.ContextKey).(string)
+
+		keysAndValues := []any{
+			"requestId", requestID,
+			"method", c.Method(),
+			"path", c.Path(),
+		}
+		if spanContext := trace.SpanContextFromContext(c.UserContext()); spanContext.IsValid() {
+			keysAndValues = append(keysAndValues, "traceId", spanContext.TraceID().String())
+		}
+
+		c.SetUserContext(zap.WithFields(c.UserContext(), keysAndValues...))
+		return c.Next()
+	}
+}
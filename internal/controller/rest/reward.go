@@ -0,0 +1,249 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/reward"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Reward struct {
+	Item     string `json:"item"`     // Item ID, as known by the game's economy, e.g. a currency or a skin
+	Quantity int64  `json:"quantity"` // Units of the item granted
+}
+
+func rewardsToDomain(rewards []Reward) []reward.Reward {
+	data := make([]reward.Reward, len(rewards))
+	for i, r := range rewards {
+		data[i] = reward.Reward{Item: r.Item, Quantity: r.Quantity}
+	}
+
+	return data
+}
+
+func rewardsFromDomain(rewards []reward.Reward) []Reward {
+	data := make([]Reward, len(rewards))
+	for i, r := range rewards {
+		data[i] = Reward{Item: r.Item, Quantity: r.Quantity}
+	}
+
+	return data
+}
+
+type CreateRewardRuleReq struct {
+	Source       string   `json:"source" enums:"LEADERBOARD,QUEST,ACHIEVEMENT"` // Progression that grants the rewards
+	SourceID     string   `json:"sourceId"`                                     // Leaderboard, quest or achievement that grants the rewards
+	FromPosition int64    `json:"fromPosition,omitempty"`                       // First leaderboard position granted. Only on `LEADERBOARD`
+	ToPosition   int64    `json:"toPosition,omitempty"`                         // Last leaderboard position granted. Only on `LEADERBOARD`
+	Rewards      []Reward `json:"rewards"`                                      // Rewards granted
+}
+
+func (r CreateRewardRuleReq) toDomain(gameID string) reward.NewRuleData {
+	return reward.NewRuleData{
+		GameID:       gameID,
+		Source:       r.Source,
+		SourceID:     r.SourceID,
+		FromPosition: r.FromPosition,
+		ToPosition:   r.ToPosition,
+		Rewards:      rewardsToDomain(r.Rewards),
+	}
+}
+
+type RewardRule struct {
+	CreatedAt    time.Time `json:"createdAt"`                                    // Time the rule was created
+	ID           string    `json:"id"`                                           // Rule ID
+	GameID       string    `json:"gameId"`                                       // ID of the game responsible for the rule
+	Source       string    `json:"source" enums:"LEADERBOARD,QUEST,ACHIEVEMENT"` // Progression that grants the rewards
+	SourceID     string    `json:"sourceId"`                                     // Leaderboard, quest or achievement that grants the rewards
+	FromPosition int64     `json:"fromPosition,omitempty"`                       // First leaderboard position granted. Only on `LEADERBOARD`
+	ToPosition   int64     `json:"toPosition,omitempty"`                         // Last leaderboard position granted. Only on `LEADERBOARD`
+	Rewards      []Reward  `json:"rewards"`                                      // Rewards granted
+}
+
+func rewardRuleFromDomain(r reward.Rule) RewardRule {
+	return RewardRule{
+		CreatedAt:    r.CreatedAt,
+		ID:           r.ID,
+		GameID:       r.GameID,
+		Source:       r.Source,
+		SourceID:     r.SourceID,
+		FromPosition: r.FromPosition,
+		ToPosition:   r.ToPosition,
+		Rewards:      rewardsFromDomain(r.Rewards),
+	}
+}
+
+type RewardGrant struct {
+	CreatedAt     time.Time  `json:"createdAt"`                                    // Time the grant was queued
+	UpdatedAt     time.Time  `json:"updatedAt"`                                    // Last time the grant was attempted or retried
+	NextAttemptAt time.Time  `json:"nextAttemptAt"`                                // Time the grant is due to be attempted, while pending
+	GrantedAt     *time.Time `json:"grantedAt,omitempty"`                          // Time the economy service accepted the grant
+	ID            string     `json:"id"`                                           // Grant ID, sent to the economy service as the idempotency key
+	GameID        string     `json:"gameId"`                                       // ID of the game responsible for the grant
+	PlayerID      string     `json:"playerId"`                                     // Player the rewards are granted to
+	RuleID        string     `json:"ruleId"`                                       // Rule that granted the rewards
+	Source        string     `json:"source" enums:"LEADERBOARD,QUEST,ACHIEVEMENT"` // Progression that granted the rewards
+	SourceID      string     `json:"sourceId"`                                     // Leaderboard, quest or achievement that granted the rewards
+	Position      int64      `json:"position,omitempty"`                           // Final position on the leaderboard. Only on `LEADERBOARD`
+	Rewards       []Reward   `json:"rewards"`                                      // Rewards granted
+	Status        string     `json:"status" enums:"PENDING,GRANTED,FAILED"`        // Grant status
+	Attempts      int        `json:"attempts"`                                     // Attempts made since the grant was queued or last retried
+	LastError     string     `json:"lastError,omitempty"`                          // Error of the last failed attempt
+}
+
+func rewardGrantFromDomain(g reward.Grant) RewardGrant {
+	grant := RewardGrant{
+		CreatedAt:     g.CreatedAt,
+		UpdatedAt:     g.UpdatedAt,
+		NextAttemptAt: g.NextAttemptAt,
+		ID:            g.ID,
+		GameID:        g.GameID,
+		PlayerID:      g.PlayerID,
+		RuleID:        g.RuleID,
+		Source:        g.Source,
+		SourceID:      g.SourceID,
+		Position:      g.Position,
+		Rewards:       rewardsFromDomain(g.Rewards),
+		Status:        g.Status,
+		Attempts:      g.Attempts,
+		LastError:     g.LastError,
+	}
+
+	if !g.GrantedAt.IsZero() {
+		grant.GrantedAt = &g.GrantedAt
+	}
+
+	return grant
+}
+
+var (
+	ErrorResponseRewardRuleInvalid     = ErrorResponse{Code: "19.0", Message: "Invalid reward rule"}
+	ErrorResponseRewardRuleNotFound    = ErrorResponse{Code: "19.1", Message: "Reward rule not found"}
+	ErrorResponseRewardRuleInvalidID   = ErrorResponse{Code: "19.2", Message: "Invalid reward rule id"}
+	ErrorResponseRewardGrantNotFound   = ErrorResponse{Code: "19.3", Message: "Reward grant not found"}
+	ErrorResponseRewardGrantNotFailed  = ErrorResponse{Code: "19.4", Message: "Only failed reward grants can be retried"}
+	ErrorResponseRewardGrantLimitError = ErrorResponse{Code: "19.5", Message: "Invalid reward grants limit"}
+)
+
+// @summary Create Reward Rule
+// @description Create a rule granting rewards to the players that finish a leaderboard on one of its positions, complete a quest or unlock an achievement.
+// @description The leaderboard, quest or achievement must belong to the game. The rewards are granted by the game's economy service, asynchronously
+// @router /api/v1/rewards/rules [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param CreateRewardRuleReq body CreateRewardRuleReq true "New reward rule config data"
+// @success 201 {object} RewardRule
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildCreateRewardRuleHandler(createRuleFunc reward.CreateRuleFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body CreateRewardRuleReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		rule, err := createRuleFunc(c.UserContext(), body.toDomain(claims.GameID))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(rewardRuleFromDomain(rule))
+	}
+}
+
+// @summary List Reward Rules
+// @description List the game's reward rules, oldest first
+// @router /api/v1/rewards/rules [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @success 200 {array} RewardRule
+// @failure 500 {object} ErrorResponse
+func buildListRewardRulesHandler(listRulesFunc reward.ListRulesFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		rules, err := listRulesFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		res := make([]RewardRule, len(rules))
+		for i, r := range rules {
+			res[i] = rewardRuleFromDomain(r)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Delete Reward Rule
+// @description Delete a reward rule by its id. The rewards it already granted are kept
+// @router /api/v1/rewards/rules/{ruleId} [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param ruleId path string true "Reward Rule ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildDeleteRewardRuleHandler(deleteRuleFunc reward.DeleteRuleFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := deleteRuleFunc(c.UserContext(), c.Params("ruleId"), claims.GameID); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary List Reward Grants
+// @description List the rewards granted on the game, newest first, as the audit of what was granted to each player
+// @router /api/v1/rewards/grants [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId query string false "Only the rewards granted to the player"
+// @param limit query int false "Max number of grants" minimum(1) maximum(500) default(100)
+// @success 200 {array} RewardGrant
+// @failure 422,500 {object} ErrorResponse
+func buildListRewardGrantsHandler(listGrantsFunc reward.ListGrantsFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		grants, err := listGrantsFunc(c.UserContext(), claims.GameID, c.Query("playerId"), c.QueryInt("limit", 100))
+		if err != nil {
+			return err
+		}
+
+		res := make([]RewardGrant, len(grants))
+		for i, g := range grants {
+			res[i] = rewardGrantFromDomain(g)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Retry Reward Grant
+// @description Queue a failed reward grant to be attempted again, with its attempts reset. The economy service receives it with the same id
+// @router /api/v1/rewards/grants/{grantId}/retry [POST]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param grantId path string true "Reward Grant ID"
+// @success 200 {object} RewardGrant
+// @failure 404,409,500 {object} ErrorResponse
+func buildRetryRewardGrantHandler(retryGrantFunc reward.RetryGrantFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		grant, err := retryGrantFunc(c.UserContext(), c.Params("grantId"), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(rewardGrantFromDomain(grant))
+	}
+}
@@ -0,0 +1,234 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/reward"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// The game has a failed and a granted grant, and a rule on the `season` leaderboard
+func newRewardTestConfig(gameID string) Config {
+	grants := map[string]reward.Grant{
+		"failed":  {ID: "failed", GameID: gameID, PlayerID: "player", Status: reward.GrantStatusFailed, Attempts: 8},
+		"granted": {ID: "granted", GameID: gameID, PlayerID: "player", Status: reward.GrantStatusGranted},
+	}
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		CreateRewardRuleFunc: reward.BuildCreateRuleFunc(
+			func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			func(ctx context.Context, id, gameID string) (quest.Quest, error) {
+				return quest.Quest{}, quest.ErrQuestNotFound
+			},
+			nil,
+			func(ctx context.Context, data reward.NewRuleData) (reward.Rule, error) {
+				return reward.Rule{ID: uuid.NewString(), GameID: data.GameID, Source: data.Source, SourceID: data.SourceID, FromPosition: data.FromPosition, ToPosition: data.ToPosition, Rewards: data.Rewards}, nil
+			},
+		),
+		ListRewardRulesFunc: reward.BuildListRulesFunc(func(ctx context.Context, gameID string) ([]reward.Rule, error) {
+			return []reward.Rule{{ID: "rule", GameID: gameID, Source: reward.SourceLeaderboard, SourceID: "season", FromPosition: 1, ToPosition: 3, Rewards: []reward.Reward{{Item: "gold", Quantity: 100}}}}, nil
+		}),
+		DeleteRewardRuleFunc: reward.BuildDeleteRuleFunc(func(ctx context.Context, id, gameID string) error {
+			return nil
+		}),
+		ListRewardGrantsFunc: reward.BuildListGrantsFunc(func(ctx context.Context, gameID, playerID string, limit int) ([]reward.Grant, error) {
+			return []reward.Grant{grants["granted"], grants["failed"]}, nil
+		}),
+		RetryRewardGrantFunc: reward.BuildRetryGrantFunc(
+			func(ctx context.Context, id, gameID string) (reward.Grant, error) {
+				grant, ok := grants[id]
+				if !ok {
+					return reward.Grant{}, reward.ErrGrantNotFound
+				}
+
+				return grant, nil
+			},
+			func(ctx context.Context, grant reward.Grant) error {
+				return nil
+			},
+		),
+	}
+}
+
+func TestBuildCreateRewardRuleHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newRewardTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/rewards/rules", bytes.NewBufferString(`{"source": "LEADERBOARD", "sourceId": "season", "fromPosition": 1, "toPosition": 3, "rewards": [{"item": "gold", "quantity": 100}]}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body RewardRule
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, gameID, body.GameID)
+		assert.Equal(t, []Reward{{Item: "gold", Quantity: 100}}, body.Rewards)
+	})
+
+	t.Run("Invalid Rule", func(t *testing.T) {
+		app := App(newRewardTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/rewards/rules", bytes.NewBufferString(`{"source": "LEADERBOARD", "sourceId": "season", "rewards": []}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseRewardRuleInvalid.Code, body.Code)
+	})
+}
+
+func TestBuildListRewardRulesHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newRewardTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/rewards/rules", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []RewardRule
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "rule", body[0].ID)
+		assert.Equal(t, []Reward{{Item: "gold", Quantity: 100}}, body[0].Rewards)
+	})
+}
+
+func TestBuildDeleteRewardRuleHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newRewardTestConfig(gameID)
+		config.DeleteRewardRuleFunc = reward.BuildDeleteRuleFunc(func(ctx context.Context, id, gameID string) error {
+			return reward.ErrRuleNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/rewards/rules/"+uuid.NewString(), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildListRewardGrantsHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newRewardTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/rewards/grants?playerId=player", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []RewardGrant
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 2)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		app := App(newRewardTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/rewards/grants?limit=0", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func TestBuildRetryRewardGrantHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newRewardTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/rewards/grants/failed/retry", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body RewardGrant
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, reward.GrantStatusPending, body.Status)
+		assert.Equal(t, 0, body.Attempts)
+	})
+
+	t.Run("Not Failed", func(t *testing.T) {
+		app := App(newRewardTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/rewards/grants/granted/retry", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newRewardTestConfig(gameID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/rewards/grants/unknown/retry", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
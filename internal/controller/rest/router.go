@@ -1,17 +1,53 @@
 package rest
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/activity"
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+	"github.com/gabapcia/gameblitz/internal/audit"
 	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/controller/graphql"
+	"github.com/gabapcia/gameblitz/internal/delta"
+	"github.com/gabapcia/gameblitz/internal/friend"
+	"github.com/gabapcia/gameblitz/internal/ingestion"
 	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/merge"
+	"github.com/gabapcia/gameblitz/internal/moderation"
+	"github.com/gabapcia/gameblitz/internal/notification"
+	"github.com/gabapcia/gameblitz/internal/outbox"
+	"github.com/gabapcia/gameblitz/internal/overview"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/presence"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/proof"
+	"github.com/gabapcia/gameblitz/internal/purge"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
 	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/quota"
+	"github.com/gabapcia/gameblitz/internal/reward"
+	"github.com/gabapcia/gameblitz/internal/segment"
+	"github.com/gabapcia/gameblitz/internal/shadow"
+	"github.com/gabapcia/gameblitz/internal/signing"
 	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/summary"
+	"github.com/gabapcia/gameblitz/internal/title"
+	"github.com/gabapcia/gameblitz/internal/trust"
+	"github.com/gabapcia/gameblitz/internal/usage"
+	"github.com/gabapcia/gameblitz/internal/webhook"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cache"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/gofiber/swagger"
 
 	_ "github.com/gabapcia/gameblitz/internal/controller/rest/docs"
@@ -20,13 +56,93 @@ import (
 type Config struct {
 	Port int
 
+	// HTTP/3, served alongside the TCP listener. It's not started when the port is zero
+	HTTP3Port     int
+	HTTP3CertFile string
+	HTTP3KeyFile  string
+
 	CacheSorage               fiber.Storage
 	CacheExpiration           time.Duration
 	CacheMiddlewareExpiration time.Duration
 
+	// Whether the rankings are served from the ranking cache, dropped on every write to them. The ranking pages skip the
+	// response cache when set, as it would keep serving them from before the write until it expires
+	RankingCacheEnabled bool
+
+	// Metrics. The endpoint is not mounted and the requests are not recorded when nil
+	MetricsHandler     http.Handler
+	ObserveRequestFunc ObserveRequestFunc
+
+	// SLO. The report is not mounted when nil
+	ReportSLOFunc ReportSLOFunc
+
+	// Tracing. Each request is traced with the global tracer provider when enabled
+	TracingEnabled bool
+
+	// Error report. The unknown errors are only logged when nil
+	ReportErrorFunc ReportErrorFunc
+
+	// Access log. The requests of the routes with a sample rate, from 0 to 1, are only logged on that ratio
+	AccessLogEnabled     bool
+	AccessLogSampleRates map[string]float64
+
+	// Header the proxy in front of the API sets with the region derived from the request's IP, e.g. `CF-IPCountry`.
+	// The submissions' fingerprint carries no region when empty
+	FingerprintRegionHeader string
+
 	// Auth
 	AuthenticateFunc auth.AuthenticateFunc
 
+	// Dev mode only. When set, every request is authenticated as the game without credentials,
+	// and the admin endpoints are mounted without a token
+	DevGameID string
+
+	// Audit
+	RecordAuditEntryFunc audit.RecordFunc
+
+	// Usage. The requests are not counted when nil
+	RecordUsageFunc usage.RecordFunc
+
+	// Quota. The requests aren't rate limited when the allow func is nil, and the limits aren't checked when the
+	// check funcs are nil
+	AllowRequestFunc          quota.AllowFunc
+	CheckLeaderboardQuotaFunc quota.CheckFunc
+	CheckStatisticQuotaFunc   quota.CheckFunc
+
+	// Admin. The endpoints are not mounted without a token
+	AdminToken string
+
+	// Serve the runtime profiles on `/admin/v1/debug/pprof`, behind the admin token
+	PprofEnabled bool
+
+	// Log level. The endpoints are not mounted when nil
+	GetLogLevelFunc GetLogLevelFunc
+	SetLogLevelFunc SetLogLevelFunc
+
+	ListDeadLettersFunc   ingestion.ListDeadLettersFunc
+	RequeueDeadLetterFunc ingestion.RequeueDeadLetterFunc
+	ReplayEventsFunc      outbox.ReplayFunc
+
+	ListWebhookDeliveriesFunc webhook.ListDeliveriesFunc
+
+	ReportUsageFunc usage.ReportFunc
+
+	SummarizeGameFunc summary.SummarizeFunc
+
+	// Quota administration. The endpoints are not mounted when nil
+	GetQuotaStatusFunc quota.StatusFunc
+	SetQuotaFunc       quota.SetFunc
+
+	// Purge. The dry runs are rejected when the preview is nil
+	PurgeGameFunc        purge.PurgeGameFunc
+	PreviewPurgeGameFunc purge.PurgeGameFunc
+
+	ListAuditEntriesFunc   audit.ListFunc
+	ExportAuditEntriesFunc audit.ExportFunc
+
+	// Player merge. The endpoint is not mounted when nil
+	MergePlayersFunc merge.MergePlayersFunc
+
 	// Leaderboard
 	CreateLeaderboardFunc              leaderboard.CreateFunc
 	GetLeaderboardByIDAndGameIDFunc    leaderboard.GetByIDAndGameIDFunc
@@ -34,6 +150,10 @@ type Config struct {
 
 	UpsertPlayerRankFunc leaderboard.UpsertPlayerRankFunc
 	RankingFunc          leaderboard.RankingFunc
+	WatchRankingFunc     leaderboard.WatchRankingFunc
+
+	StreamLeaderboardEventsFunc leaderboard.StreamEventsFunc
+	PollPlayerRankFunc          leaderboard.PollPlayerRankFunc
 
 	// Quest
 	CreateQuestFunc           quest.CreateQuestFunc
@@ -48,9 +168,164 @@ type Config struct {
 	CreateStatisticFunc                  statistic.CreateFunc
 	GetStatisticByIDAndGameIDFunc        statistic.GetByIDAndGameIDFunc
 	SoftDeleteStatisticByIDAndGameIDFunc statistic.SoftDeleteByIDAndGameIDFunc
+	ListStatisticsFunc                   statistic.ListFunc
 
 	UpsertPlayerStatisticProgressionFunc statistic.UpsertPlayerProgressionFunc
 	GetPlayerStatisticProgressionFunc    statistic.GetPlayerProgressionFunc
+
+	// Webhook. The endpoints are not mounted when nil
+	CreateWebhookFunc       webhook.CreateWebhookFunc
+	ListWebhooksFunc        webhook.ListWebhooksFunc
+	DeleteWebhookFunc       webhook.DeleteWebhookFunc
+	EnableWebhookFunc       webhook.EnableWebhookFunc
+	UpdateWebhookFilterFunc webhook.UpdateWebhookFilterFunc
+
+	// Player Profiles. The endpoints are not mounted, and the rankings aren't enriched with the profiles, when nil
+	CreateProfileFunc player.CreateProfileFunc
+	GetProfileFunc    player.GetProfileFunc
+	UpdateProfileFunc player.UpdateProfileFunc
+	DeleteProfileFunc player.DeleteProfileFunc
+	ListProfilesFunc  player.ListProfilesFunc
+
+	// Player Search. The endpoint is not mounted when nil
+	SearchProfilesFunc player.SearchProfilesFunc
+
+	// Linked Accounts. The endpoints are not mounted when nil
+	LinkAccountFunc    player.LinkAccountFunc
+	UnlinkAccountFunc  player.UnlinkAccountFunc
+	ListAccountsFunc   player.ListAccountsFunc
+	ResolveAccountFunc player.ResolveAccountFunc
+
+	// Bans. The endpoints are not mounted when nil
+	BanPlayerFunc ban.BanFunc
+	LiftBanFunc   ban.LiftFunc
+	GetBanFunc    ban.GetFunc
+	ListBansFunc  ban.ListFunc
+
+	// Device bans. The endpoints are not mounted when nil
+	BanDeviceFunc      ban.BanDeviceFunc
+	LiftDeviceBanFunc  ban.LiftDeviceFunc
+	GetDeviceBanFunc   ban.GetDeviceFunc
+	ListDeviceBansFunc ban.ListDevicesFunc
+
+	// Shadow flags. The endpoints are not mounted, and the rankings don't place the viewer's shadow ranks, when nil
+	FlagPlayerFunc      shadow.FlagFunc
+	ClearFlagFunc       shadow.ClearFunc
+	GetFlagFunc         shadow.GetFunc
+	ListFlagsFunc       shadow.ListFunc
+	MergeShadowRankFunc shadow.MergeViewerFunc
+
+	// Achievements. The endpoints are not mounted when nil
+	CreateAchievementFunc      achievement.CreateFunc
+	GetAchievementFunc         achievement.GetByIDAndGameIDFunc
+	ListAchievementsFunc       achievement.ListFunc
+	DeleteAchievementFunc      achievement.DeleteFunc
+	ListPlayerAchievementsFunc achievement.ListPlayerAchievementsFunc
+
+	// Titles. The endpoints are not mounted, and the rankings aren't enriched with the titles, when nil
+	CreateTitleFunc       title.CreateFunc
+	GetTitleFunc          title.GetByIDAndGameIDFunc
+	ListTitlesFunc        title.ListFunc
+	DeleteTitleFunc       title.DeleteFunc
+	ListPlayerTitlesFunc  title.ListPlayerTitlesFunc
+	ListPlayersTitlesFunc title.ListPlayersTitlesFunc
+
+	// Friends. The endpoints are not mounted when nil
+	AddFriendFunc    friend.AddFunc
+	RemoveFriendFunc friend.RemoveFunc
+	ListFriendsFunc  friend.ListFunc
+
+	// Presence. The endpoints are not mounted when nil. The rankings and the friends lists carry the presence when set
+	HeartbeatFunc     presence.HeartbeatFunc
+	GetPresenceFunc   presence.GetFunc
+	ListPresencesFunc presence.ListFunc
+
+	// Signed submissions. The rank submissions aren't verified, and the endpoints are not mounted, when nil
+	IssueSubmissionNonceFunc signing.IssueNonceFunc
+	VerifySubmissionFunc     signing.VerifyFunc
+	GetSigningKeyFunc        signing.GetGameKeyFunc
+
+	// Delta rules. The endpoints are not mounted when nil
+	SetDeltaRuleFunc    delta.SetRuleFunc
+	GetDeltaRuleFunc    delta.GetRuleFunc
+	RemoveDeltaRuleFunc delta.RemoveRuleFunc
+
+	// Proof rules. The endpoints are not mounted, the submissions aren't checked and the rankings don't merge the pending ones, when nil
+	SetProofRuleFunc    proof.SetRuleFunc
+	GetProofRuleFunc    proof.GetRuleFunc
+	RemoveProofRuleFunc proof.RemoveRuleFunc
+	CheckProofFunc      proof.CheckFunc
+	MergePendingFunc    proof.MergePendingFunc
+
+	// Quarantine. The endpoints are not mounted when nil
+	ListQuarantinedSubmissionsFunc   quarantine.ListFunc
+	ReleaseQuarantinedSubmissionFunc quarantine.ReleaseFunc
+	DiscardQuarantinedSubmissionFunc quarantine.DiscardFunc
+
+	// Trust. The admin endpoint is not mounted when nil
+	GetTrustFunc trust.GetFunc
+
+	// Anomalies. The admin endpoints are not mounted when nil
+	ListAnomaliesFunc  anomaly.ListFunc
+	DismissAnomalyFunc anomaly.DismissFunc
+
+	// Score Moderation. The admin endpoints are not mounted when nil, nor are the bulk removal, the device usage and the rollback when their func is nil
+	InspectEntryFunc          moderation.InspectFunc
+	AdjustEntryFunc           moderation.AdjustFunc
+	RemoveEntryFunc           moderation.RemoveFunc
+	BulkRemoveEntriesFunc     moderation.BulkRemoveFunc
+	ListModerationActionsFunc moderation.ListFunc
+	ListDeviceUsageFunc       moderation.ListDeviceUsageFunc
+	RollbackScoresFunc        moderation.RollbackFunc
+
+	// Segments. The endpoints are not mounted, and the player search rejects the segment filter, when nil
+	CreateSegmentFunc        segment.CreateFunc
+	GetSegmentFunc           segment.GetByIDAndGameIDFunc
+	ListSegmentsFunc         segment.ListFunc
+	DeleteSegmentFunc        segment.DeleteFunc
+	FilterSegmentMembersFunc segment.FilterMembersFunc
+	IsSegmentMemberFunc      segment.IsMemberFunc
+	ListPlayerSegmentsFunc   segment.ListPlayerSegmentsFunc
+	SetEligibilityFunc       segment.SetEligibilityFunc
+	GetEligibilityFunc       segment.GetEligibilityFunc
+	RemoveEligibilityFunc    segment.RemoveEligibilityFunc
+
+	// Levels. The endpoints are not mounted when nil
+	CreateLevelCurveFunc level.CreateFunc
+	GetLevelCurveFunc    level.GetByIDAndGameIDFunc
+	ListLevelCurvesFunc  level.ListFunc
+	DeleteLevelCurveFunc level.DeleteFunc
+	GetPlayerLevelFunc   level.GetPlayerLevelFunc
+	ListPlayerLevelsFunc level.ListPlayerLevelsFunc
+
+	// Activity feed. The endpoint is not mounted when nil, nor is the friends feed when its func is nil
+	ListActivitiesFunc        activity.ListFunc
+	ListFriendsActivitiesFunc activity.ListFriendsFunc
+
+	// Rewards. The endpoints are not mounted when nil
+	CreateRewardRuleFunc reward.CreateRuleFunc
+	ListRewardRulesFunc  reward.ListRulesFunc
+	DeleteRewardRuleFunc reward.DeleteRuleFunc
+	ListRewardGrantsFunc reward.ListGrantsFunc
+	RetryRewardGrantFunc reward.RetryGrantFunc
+
+	// Player Overview. The endpoint is not mounted when nil
+	GetPlayerOverviewFunc overview.GetFunc
+
+	// Player Notifications. The endpoint is not mounted when nil
+	WatchPlayerNotificationsFunc notification.WatchFunc
+
+	// Player Erasure. The endpoint is not mounted when nil
+	ErasePlayerFunc privacy.ErasePlayerFunc
+
+	// Player Anonymization. The endpoint is not mounted when nil
+	AnonymizePlayerFunc privacy.AnonymizePlayerFunc
+
+	// Player Erasure and Anonymization dry runs. The `dryRun` query parameter is rejected when nil
+	PreviewPlayerDataFunc privacy.PreviewPlayerDataFunc
+
+	// GraphQL. The endpoint is not mounted when nil
+	ExecuteGraphQLFunc graphql.ExecuteFunc
 }
 
 // @title GameBlitz API
@@ -65,51 +340,436 @@ func App(config Config) *fiber.App {
 	})
 
 	app.Use(recover.New())
+	app.Use(requestid.New())
+	if config.HTTP3Port != 0 {
+		app.Use(buildAltSvcMiddleware(config.HTTP3Port))
+	}
+	if config.ObserveRequestFunc != nil {
+		app.Use(buildMetricsMiddleware(config.ObserveRequestFunc))
+	}
+	if config.TracingEnabled {
+		app.Use(buildTracingMiddleware())
+	}
+	app.Use(buildRequestLoggerMiddleware())
+	if config.AccessLogEnabled {
+		app.Use(buildAccessLogMiddleware(config.AccessLogSampleRates, writeAccessLog))
+	}
+	if config.ReportErrorFunc != nil {
+		app.Use(buildErrorReportMiddleware(config.ReportErrorFunc))
+	}
 	app.Get("/docs/*", swagger.HandlerDefault)
+	if config.MetricsHandler != nil {
+		app.Get("/metrics", adaptor.HTTPHandler(config.MetricsHandler))
+	}
+	if config.ReportSLOFunc != nil {
+		app.Get("/debug/slo", buildSLOReportHandler(config.ReportSLOFunc))
+	}
+
+	if config.AdminToken != "" || config.DevGameID != "" {
+		adminAuthMiddleware := buildAdminAuthMiddleware(config.AdminToken)
+		if config.DevGameID != "" {
+			adminAuthMiddleware = func(c *fiber.Ctx) error { return c.Next() }
+		}
+
+		admin := app.Group("/admin/v1", adminAuthMiddleware)
+
+		// Profiles
+		if config.PprofEnabled {
+			admin.Use(pprof.New(pprof.Config{Prefix: "/admin/v1"}))
+		}
+
+		// Log Level
+		if config.GetLogLevelFunc != nil && config.SetLogLevelFunc != nil {
+			admin.Get("/log-level", buildGetLogLevelHandler(config.GetLogLevelFunc))
+			admin.Put("/log-level", buildSetLogLevelHandler(config.GetLogLevelFunc, config.SetLogLevelFunc))
+		}
 
-	api := app.Group("/api/v1", buildAuthMiddleware(config.AuthenticateFunc))
-	api.Use(cache.New(cache.Config{
+		// Dead Letters
+		if config.ListDeadLettersFunc != nil && config.RequeueDeadLetterFunc != nil {
+			deadLetters := admin.Group("/dead-letters")
+			deadLetters.Get("/", buildListDeadLettersHandler(config.ListDeadLettersFunc))
+			deadLetters.Post("/:deadLetterId/requeue", buildRequeueDeadLetterHandler(config.RequeueDeadLetterFunc))
+		}
+
+		// Events
+		if config.ReplayEventsFunc != nil {
+			admin.Post("/events/replay", buildReplayEventsHandler(config.ReplayEventsFunc))
+		}
+
+		// Webhooks
+		if config.ListWebhookDeliveriesFunc != nil {
+			admin.Get("/webhooks/:webhookId/deliveries", buildListWebhookDeliveriesHandler(config.ListWebhookDeliveriesFunc))
+		}
+
+		// Usage
+		if config.ReportUsageFunc != nil {
+			admin.Get("/usage/:gameId", buildGetUsageHandler(config.ReportUsageFunc))
+		}
+
+		// Summary
+		if config.SummarizeGameFunc != nil {
+			admin.Get("/games/:gameId/summary", buildGetGameSummaryHandler(config.SummarizeGameFunc))
+		}
+
+		// Quota
+		if config.GetQuotaStatusFunc != nil && config.SetQuotaFunc != nil {
+			admin.Get("/games/:gameId/quota", buildGetQuotaHandler(config.GetQuotaStatusFunc))
+			admin.Put("/games/:gameId/quota", buildSetQuotaHandler(config.SetQuotaFunc))
+		}
+
+		// Purge
+		if config.PurgeGameFunc != nil {
+			admin.Post("/purge/:gameId", buildPurgeGameHandler(config.PurgeGameFunc, config.PreviewPurgeGameFunc))
+		}
+
+		// Signing Key
+		if config.GetSigningKeyFunc != nil {
+			admin.Get("/games/:gameId/signing-key", buildGetSigningKeyHandler(config.GetSigningKeyFunc))
+		}
+
+		// Player Merge
+		if config.MergePlayersFunc != nil {
+			admin.Post("/games/:gameId/players/:playerId/merge", buildMergePlayersHandler(config.MergePlayersFunc))
+		}
+
+		// Trust
+		if config.GetTrustFunc != nil {
+			admin.Get("/games/:gameId/players/:playerId/trust", buildGetTrustHandler(config.GetTrustFunc))
+		}
+
+		// Anomalies
+		if config.ListAnomaliesFunc != nil && config.DismissAnomalyFunc != nil {
+			admin.Get("/games/:gameId/anomalies", buildListAnomaliesHandler(config.ListAnomaliesFunc))
+			admin.Post("/games/:gameId/anomalies/:anomalyId/dismiss", buildDismissAnomalyHandler(config.DismissAnomalyFunc))
+		}
+
+		// Score Moderation
+		if config.InspectEntryFunc != nil && config.AdjustEntryFunc != nil && config.RemoveEntryFunc != nil && config.ListModerationActionsFunc != nil {
+			entry := admin.Group("/games/:gameId/leaderboards/:leaderboardId/ranking/:playerId")
+			entry.Get("/", buildInspectEntryHandler(config.InspectEntryFunc))
+			entry.Put("/", buildAdjustEntryHandler(config.AdjustEntryFunc))
+			entry.Delete("/", buildRemoveEntryHandler(config.RemoveEntryFunc))
+			admin.Get("/games/:gameId/moderation-actions", buildListModerationActionsHandler(config.ListModerationActionsFunc))
+
+			if config.BulkRemoveEntriesFunc != nil {
+				admin.Post("/games/:gameId/ranking-removals", buildBulkRemoveEntriesHandler(config.BulkRemoveEntriesFunc))
+			}
+
+			if config.ListDeviceUsageFunc != nil {
+				admin.Get("/games/:gameId/devices", buildListDeviceUsageHandler(config.ListDeviceUsageFunc))
+			}
+
+			if config.RollbackScoresFunc != nil {
+				admin.Post("/games/:gameId/leaderboards/:leaderboardId/rollbacks", buildRollbackScoresHandler(config.RollbackScoresFunc))
+			}
+		}
+
+		// Audit
+		if config.ListAuditEntriesFunc != nil && config.ExportAuditEntriesFunc != nil {
+			auditEntries := admin.Group("/audit")
+			auditEntries.Get("/", buildListAuditEntriesHandler(config.ListAuditEntriesFunc))
+			auditEntries.Get("/export", buildExportAuditEntriesHandler(config.ExportAuditEntriesFunc))
+		}
+	}
+
+	authMiddleware := buildAuthMiddleware(config.AuthenticateFunc)
+	if config.DevGameID != "" {
+		authMiddleware = buildDevAuthMiddleware(config.DevGameID)
+	}
+
+	api := app.Group("/api/v1", authMiddleware)
+	if config.RecordAuditEntryFunc != nil {
+		api.Use(buildAuditMiddleware(config.RecordAuditEntryFunc))
+	}
+	if config.RecordUsageFunc != nil {
+		api.Use(buildUsageMiddleware(config.RecordUsageFunc))
+	}
+	// After the usage, so the rejected requests are counted with their error
+	if config.AllowRequestFunc != nil {
+		api.Use(buildRateLimitMiddleware(config.AllowRequestFunc))
+	}
+	// Only the routes opting in are cached, as most of the others are on the same path for every game
+	responseCache := cache.New(cache.Config{
+		// The rankings merging the pending submissions or the viewer's shadow ranks are not cached, as the cache keys leave the query out
+		Next: func(c *fiber.Ctx) bool {
+			return c.QueryBool("includePending") || c.Query("playerId") != ""
+		},
 		Expiration:   config.CacheExpiration,
 		Storage:      config.CacheSorage,
 		CacheControl: true,
-	}))
+	})
 
 	// Leaderboards
 	leaderboards := api.Group("/leaderboards")
-	leaderboards.Post("/", buildCreateLeaderboardHandler(config.CreateLeaderboardFunc))
-	leaderboards.Get("/:leaderboardId", buildGetLeaderboardHandler(config.GetLeaderboardByIDAndGameIDFunc))
-	leaderboards.Delete("/:leaderboardId", buildDeleteLeaderboardHandler(config.DeleteLeaderboardByIDAndGameIDFunc))
+	leaderboards.Post("/", buildCreateLeaderboardHandler(config.CheckLeaderboardQuotaFunc, config.CreateLeaderboardFunc))
+	leaderboards.Get("/:leaderboardId", responseCache, buildGetLeaderboardHandler(config.GetLeaderboardByIDAndGameIDFunc))
+	leaderboards.Delete("/:leaderboardId", buildDeleteLeaderboardHandler(config.CacheSorage, config.DeleteLeaderboardByIDAndGameIDFunc))
+	if config.StreamLeaderboardEventsFunc != nil {
+		leaderboards.Get(
+			"/:leaderboardId/events",
+			buildGetLeaderboardMiddleware(config.CacheSorage, config.CacheMiddlewareExpiration, config.GetLeaderboardByIDAndGameIDFunc),
+			buildStreamLeaderboardEventsHandler(config.StreamLeaderboardEventsFunc),
+		)
+	}
 
 	rankings := leaderboards.Group("/:leaderboardId/ranking", buildGetLeaderboardMiddleware(config.CacheSorage, config.CacheMiddlewareExpiration, config.GetLeaderboardByIDAndGameIDFunc))
-	rankings.Get("/", buildGetRankingHandler(config.RankingFunc))
-	rankings.Post("/:playerId", buildUpsertPlayerRankHandler(config.UpsertPlayerRankFunc))
+	getRankingHandler := buildGetRankingHandler(config.RankingFunc, config.MergeShadowRankFunc, config.MergePendingFunc, config.ListProfilesFunc, config.ListPlayersTitlesFunc, config.ListPresencesFunc)
+	if config.RankingCacheEnabled {
+		rankings.Get("/", getRankingHandler)
+	} else {
+		rankings.Get("/", responseCache, getRankingHandler)
+	}
+	rankings.Post("/:playerId", buildUpsertPlayerRankHandler(config.UpsertPlayerRankFunc, config.VerifySubmissionFunc, config.CheckProofFunc, config.FingerprintRegionHeader))
+	if config.WatchRankingFunc != nil {
+		rankings.Get("/live", buildWatchRankingHandler(config.WatchRankingFunc))
+	}
+	if config.PollPlayerRankFunc != nil {
+		rankings.Get("/:playerId/poll", buildPollPlayerRankHandler(config.PollPlayerRankFunc))
+	}
 
 	// Quests
 	quests := api.Group("/quests")
 	quests.Post("/", buildCreateQuestHanlder(config.CreateQuestFunc))
-	quests.Get("/:questId", buildGetQuestHanlder(config.GetQuestByIDAndGameIDFunc))
-	quests.Delete("/:questId", buildDeleteQuestHanlder(config.SoftDeleteQuestFunc))
+	quests.Get("/:questId", responseCache, buildGetQuestHanlder(config.GetQuestByIDAndGameIDFunc))
+	quests.Delete("/:questId", buildDeleteQuestHanlder(config.CacheSorage, config.SoftDeleteQuestFunc))
 
 	playerQuests := quests.Group("/:questId/players", buildGetQuestMiddleware(config.CacheSorage, config.CacheMiddlewareExpiration, config.GetQuestByIDAndGameIDFunc))
 	playerQuests.Post("/:playerId", buildStartPlayerQuestHandler(config.StartQuestForPlayerFunc))
-	playerQuests.Get("/:playerId", buildGetPlayerQuestProgressionHandler(config.GetPlayerQuestProgressionFunc))
+	playerQuests.Get("/:playerId", responseCache, buildGetPlayerQuestProgressionHandler(config.GetPlayerQuestProgressionFunc))
 	playerQuests.Patch("/:playerId", buildUpdatePlayerQuestProgressionHandler(config.UpdatePlayerQuestProgressionFunc))
 
 	// Statistic
 	statistics := api.Group("/statistics")
-	statistics.Post("/", buildCreateStatisticHandler(config.CreateStatisticFunc))
-	statistics.Get("/:statisticId", buildGetStatisticHanlder(config.GetStatisticByIDAndGameIDFunc))
-	statistics.Delete("/:statisticId", buildDeleteStatisticHanlder(config.SoftDeleteStatisticByIDAndGameIDFunc))
+	statistics.Post("/", buildCreateStatisticHandler(config.CheckStatisticQuotaFunc, config.CreateStatisticFunc))
+	statistics.Get("/", buildListStatisticsHandler(config.ListStatisticsFunc))
+	statistics.Get("/:statisticId", responseCache, buildGetStatisticHanlder(config.GetStatisticByIDAndGameIDFunc))
+	statistics.Delete("/:statisticId", buildDeleteStatisticHanlder(config.CacheSorage, config.SoftDeleteStatisticByIDAndGameIDFunc))
 
 	playerStatistics := statistics.Group("/:statisticId/players", buildGetStatisticMiddleware(config.CacheSorage, config.CacheMiddlewareExpiration, config.GetStatisticByIDAndGameIDFunc))
-	playerStatistics.Get("/:playerId", buildGetPlayerStatisticHandler(config.GetPlayerStatisticProgressionFunc))
+	playerStatistics.Get("/:playerId", responseCache, buildGetPlayerStatisticHandler(config.GetPlayerStatisticProgressionFunc))
 	playerStatistics.Post("/:playerId", buildUpsertPlayerStatisticHandler(config.UpsertPlayerStatisticProgressionFunc))
 
+	// Webhooks
+	if config.CreateWebhookFunc != nil && config.ListWebhooksFunc != nil && config.DeleteWebhookFunc != nil && config.EnableWebhookFunc != nil && config.UpdateWebhookFilterFunc != nil {
+		webhooks := api.Group("/webhooks")
+		webhooks.Post("/", buildCreateWebhookHandler(config.CreateWebhookFunc))
+		webhooks.Get("/", buildListWebhooksHandler(config.ListWebhooksFunc))
+		webhooks.Delete("/:webhookId", buildDeleteWebhookHandler(config.DeleteWebhookFunc))
+		webhooks.Post("/:webhookId/enable", buildEnableWebhookHandler(config.EnableWebhookFunc))
+		webhooks.Put("/:webhookId/filter", buildUpdateWebhookFilterHandler(config.UpdateWebhookFilterFunc))
+	}
+
+	// Player Profiles
+	if config.CreateProfileFunc != nil && config.GetProfileFunc != nil && config.UpdateProfileFunc != nil && config.DeleteProfileFunc != nil {
+		profiles := api.Group("/players/:playerId/profile")
+		profiles.Post("/", buildCreateProfileHandler(config.CreateProfileFunc))
+		profiles.Get("/", buildGetProfileHandler(config.GetProfileFunc))
+		profiles.Put("/", buildUpdateProfileHandler(config.UpdateProfileFunc))
+		profiles.Delete("/", buildDeleteProfileHandler(config.DeleteProfileFunc))
+	}
+	if config.SearchProfilesFunc != nil {
+		api.Get("/players", buildSearchProfilesHandler(config.SearchProfilesFunc, config.FilterSegmentMembersFunc))
+	}
+
+	// Linked Accounts
+	if config.LinkAccountFunc != nil && config.UnlinkAccountFunc != nil && config.ListAccountsFunc != nil && config.ResolveAccountFunc != nil {
+		accounts := api.Group("/players/:playerId/accounts")
+		accounts.Post("/", buildLinkAccountHandler(config.LinkAccountFunc))
+		accounts.Get("/", buildListAccountsHandler(config.ListAccountsFunc))
+		accounts.Delete("/:platform/:externalId", buildUnlinkAccountHandler(config.UnlinkAccountFunc))
+
+		api.Get("/accounts/:platform/:externalId", buildResolveAccountHandler(config.ResolveAccountFunc))
+	}
+
+	// Bans
+	if config.BanPlayerFunc != nil && config.LiftBanFunc != nil && config.GetBanFunc != nil && config.ListBansFunc != nil {
+		bans := api.Group("/players/:playerId/ban")
+		bans.Post("/", buildBanPlayerHandler(config.BanPlayerFunc))
+		bans.Get("/", buildGetPlayerBanHandler(config.GetBanFunc))
+		bans.Delete("/", buildLiftPlayerBanHandler(config.LiftBanFunc))
+
+		api.Get("/bans", buildListBansHandler(config.ListBansFunc))
+	}
+
+	// Device Bans
+	if config.BanDeviceFunc != nil && config.LiftDeviceBanFunc != nil && config.GetDeviceBanFunc != nil && config.ListDeviceBansFunc != nil {
+		deviceBans := api.Group("/devices/:deviceHash/ban")
+		deviceBans.Post("/", buildBanDeviceHandler(config.BanDeviceFunc))
+		deviceBans.Get("/", buildGetDeviceBanHandler(config.GetDeviceBanFunc))
+		deviceBans.Delete("/", buildLiftDeviceBanHandler(config.LiftDeviceBanFunc))
+
+		api.Get("/device-bans", buildListDeviceBansHandler(config.ListDeviceBansFunc))
+	}
+
+	// Shadow Flags
+	if config.FlagPlayerFunc != nil && config.ClearFlagFunc != nil && config.GetFlagFunc != nil && config.ListFlagsFunc != nil {
+		flags := api.Group("/players/:playerId/flag")
+		flags.Post("/", buildFlagPlayerHandler(config.FlagPlayerFunc))
+		flags.Get("/", buildGetPlayerFlagHandler(config.GetFlagFunc))
+		flags.Post("/merge", buildMergePlayerFlagHandler(config.ClearFlagFunc))
+		flags.Delete("/", buildDiscardPlayerFlagHandler(config.ClearFlagFunc))
+
+		api.Get("/flags", buildListFlagsHandler(config.ListFlagsFunc))
+	}
+
+	// Achievements
+	if config.CreateAchievementFunc != nil && config.GetAchievementFunc != nil && config.ListAchievementsFunc != nil && config.DeleteAchievementFunc != nil && config.ListPlayerAchievementsFunc != nil {
+		achievements := api.Group("/achievements")
+		achievements.Post("/", buildCreateAchievementHandler(config.CreateAchievementFunc))
+		achievements.Get("/", buildListAchievementsHandler(config.ListAchievementsFunc))
+		achievements.Get("/:achievementId", buildGetAchievementHandler(config.GetAchievementFunc))
+		achievements.Delete("/:achievementId", buildDeleteAchievementHandler(config.DeleteAchievementFunc))
+
+		api.Get("/players/:playerId/achievements", buildListPlayerAchievementsHandler(config.ListPlayerAchievementsFunc))
+	}
+
+	// Titles
+	if config.CreateTitleFunc != nil && config.GetTitleFunc != nil && config.ListTitlesFunc != nil && config.DeleteTitleFunc != nil && config.ListPlayerTitlesFunc != nil {
+		titles := api.Group("/titles")
+		titles.Post("/", buildCreateTitleHandler(config.CreateTitleFunc))
+		titles.Get("/", buildListTitlesHandler(config.ListTitlesFunc))
+		titles.Get("/:titleId", buildGetTitleHandler(config.GetTitleFunc))
+		titles.Delete("/:titleId", buildDeleteTitleHandler(config.DeleteTitleFunc))
+
+		api.Get("/players/:playerId/titles", buildListPlayerTitlesHandler(config.ListPlayerTitlesFunc))
+	}
+
+	// Friends
+	if config.AddFriendFunc != nil && config.RemoveFriendFunc != nil && config.ListFriendsFunc != nil {
+		friends := api.Group("/players/:playerId/friends")
+		friends.Post("/", buildAddFriendHandler(config.AddFriendFunc))
+		friends.Get("/", buildListFriendsHandler(config.ListFriendsFunc, config.ListPresencesFunc))
+		friends.Delete("/:friendId", buildRemoveFriendHandler(config.RemoveFriendFunc))
+	}
+
+	// Presence
+	if config.HeartbeatFunc != nil && config.GetPresenceFunc != nil {
+		api.Put("/players/:playerId/presence", buildHeartbeatHandler(config.HeartbeatFunc))
+		api.Get("/players/:playerId/presence", buildGetPresenceHandler(config.GetPresenceFunc))
+	}
+
+	// Signed Submissions
+	if config.IssueSubmissionNonceFunc != nil {
+		api.Post("/players/:playerId/submission-nonce", buildIssueSubmissionNonceHandler(config.IssueSubmissionNonceFunc))
+	}
+
+	// Delta Rules
+	if config.SetDeltaRuleFunc != nil && config.GetDeltaRuleFunc != nil && config.RemoveDeltaRuleFunc != nil {
+		leaderboards.Put("/:leaderboardId/delta-rule", buildSetDeltaRuleHandler(config.SetDeltaRuleFunc))
+		leaderboards.Get("/:leaderboardId/delta-rule", buildGetDeltaRuleHandler(config.GetDeltaRuleFunc))
+		leaderboards.Delete("/:leaderboardId/delta-rule", buildRemoveDeltaRuleHandler(config.RemoveDeltaRuleFunc))
+	}
+
+	// Proof Rules
+	if config.SetProofRuleFunc != nil && config.GetProofRuleFunc != nil && config.RemoveProofRuleFunc != nil {
+		leaderboards.Put("/:leaderboardId/proof-rule", buildSetProofRuleHandler(config.SetProofRuleFunc))
+		leaderboards.Get("/:leaderboardId/proof-rule", buildGetProofRuleHandler(config.GetProofRuleFunc))
+		leaderboards.Delete("/:leaderboardId/proof-rule", buildRemoveProofRuleHandler(config.RemoveProofRuleFunc))
+	}
+
+	// Quarantine
+	if config.ListQuarantinedSubmissionsFunc != nil && config.ReleaseQuarantinedSubmissionFunc != nil && config.DiscardQuarantinedSubmissionFunc != nil {
+		submissions := api.Group("/quarantine")
+		submissions.Get("/", buildListQuarantinedSubmissionsHandler(config.ListQuarantinedSubmissionsFunc))
+		submissions.Post("/:submissionId/release", buildReleaseQuarantinedSubmissionHandler(config.ReleaseQuarantinedSubmissionFunc))
+		submissions.Delete("/:submissionId", buildDiscardQuarantinedSubmissionHandler(config.DiscardQuarantinedSubmissionFunc))
+	}
+
+	// Segments
+	if config.CreateSegmentFunc != nil && config.GetSegmentFunc != nil && config.ListSegmentsFunc != nil && config.DeleteSegmentFunc != nil && config.IsSegmentMemberFunc != nil && config.ListPlayerSegmentsFunc != nil && config.SetEligibilityFunc != nil && config.GetEligibilityFunc != nil && config.RemoveEligibilityFunc != nil {
+		segments := api.Group("/segments")
+		segments.Post("/", buildCreateSegmentHandler(config.CreateSegmentFunc))
+		segments.Get("/", buildListSegmentsHandler(config.ListSegmentsFunc))
+		segments.Get("/:segmentId", buildGetSegmentHandler(config.GetSegmentFunc))
+		segments.Delete("/:segmentId", buildDeleteSegmentHandler(config.DeleteSegmentFunc))
+		segments.Get("/:segmentId/players/:playerId", buildGetSegmentMembershipHandler(config.IsSegmentMemberFunc))
+
+		api.Get("/players/:playerId/segments", buildListPlayerSegmentsHandler(config.ListPlayerSegmentsFunc))
+
+		leaderboards.Put("/:leaderboardId/eligibility", buildSetLeaderboardEligibilityHandler(config.SetEligibilityFunc))
+		leaderboards.Get("/:leaderboardId/eligibility", buildGetLeaderboardEligibilityHandler(config.GetEligibilityFunc))
+		leaderboards.Delete("/:leaderboardId/eligibility", buildRemoveLeaderboardEligibilityHandler(config.RemoveEligibilityFunc))
+
+		quests.Put("/:questId/eligibility", buildSetQuestEligibilityHandler(config.SetEligibilityFunc))
+		quests.Get("/:questId/eligibility", buildGetQuestEligibilityHandler(config.GetEligibilityFunc))
+		quests.Delete("/:questId/eligibility", buildRemoveQuestEligibilityHandler(config.RemoveEligibilityFunc))
+	}
+
+	// Levels
+	if config.CreateLevelCurveFunc != nil && config.GetLevelCurveFunc != nil && config.ListLevelCurvesFunc != nil && config.DeleteLevelCurveFunc != nil && config.GetPlayerLevelFunc != nil && config.ListPlayerLevelsFunc != nil {
+		levelCurves := api.Group("/level-curves")
+		levelCurves.Post("/", buildCreateLevelCurveHandler(config.CreateLevelCurveFunc))
+		levelCurves.Get("/", buildListLevelCurvesHandler(config.ListLevelCurvesFunc))
+		levelCurves.Get("/:curveId", buildGetLevelCurveHandler(config.GetLevelCurveFunc))
+		levelCurves.Delete("/:curveId", buildDeleteLevelCurveHandler(config.DeleteLevelCurveFunc))
+		levelCurves.Get("/:curveId/players/:playerId", buildGetPlayerLevelHandler(config.GetPlayerLevelFunc))
+
+		api.Get("/players/:playerId/levels", buildListPlayerLevelsHandler(config.ListPlayerLevelsFunc))
+	}
+
+	// Activity
+	if config.ListActivitiesFunc != nil {
+		api.Get("/players/:playerId/activity", buildListPlayerActivityHandler(config.ListActivitiesFunc))
+
+		if config.ListFriendsActivitiesFunc != nil {
+			api.Get("/players/:playerId/friends/activity", buildListFriendsActivityHandler(config.ListFriendsActivitiesFunc))
+		}
+	}
+
+	// Rewards
+	if config.CreateRewardRuleFunc != nil && config.ListRewardRulesFunc != nil && config.DeleteRewardRuleFunc != nil && config.ListRewardGrantsFunc != nil && config.RetryRewardGrantFunc != nil {
+		rewards := api.Group("/rewards")
+		rewards.Post("/rules", buildCreateRewardRuleHandler(config.CreateRewardRuleFunc))
+		rewards.Get("/rules", buildListRewardRulesHandler(config.ListRewardRulesFunc))
+		rewards.Delete("/rules/:ruleId", buildDeleteRewardRuleHandler(config.DeleteRewardRuleFunc))
+		rewards.Get("/grants", buildListRewardGrantsHandler(config.ListRewardGrantsFunc))
+		rewards.Post("/grants/:grantId/retry", buildRetryRewardGrantHandler(config.RetryRewardGrantFunc))
+	}
+
+	// Player Overview
+	if config.GetPlayerOverviewFunc != nil {
+		api.Get("/players/:playerId/overview", buildGetPlayerOverviewHandler(config.GetPlayerOverviewFunc))
+	}
+
+	// Player Notifications
+	if config.WatchPlayerNotificationsFunc != nil {
+		api.Get("/players/:playerId/notifications/live", buildWatchPlayerNotificationsHandler(config.WatchPlayerNotificationsFunc))
+	}
+
+	// Player Erasure
+	if config.ErasePlayerFunc != nil {
+		api.Delete("/players/:playerId", buildErasePlayerHandler(config.ErasePlayerFunc, config.PreviewPlayerDataFunc))
+	}
+
+	// Player Anonymization
+	if config.AnonymizePlayerFunc != nil {
+		api.Post("/players/:playerId/anonymize", buildAnonymizePlayerHandler(config.AnonymizePlayerFunc, config.PreviewPlayerDataFunc))
+	}
+
+	// GraphQL
+	if config.ExecuteGraphQLFunc != nil {
+		api.Post("/graphql", buildGraphQLHandler(config.ExecuteGraphQLFunc))
+	}
+
 	return app
 }
 
 func Execute(config Config) error {
 	app := App(config)
 
-	return app.Listen(fmt.Sprintf(":%d", config.Port))
+	if config.HTTP3Port == 0 {
+		return app.Listen(fmt.Sprintf(":%d", config.Port))
+	}
+
+	cert, err := tls.LoadX509KeyPair(config.HTTP3CertFile, config.HTTP3KeyFile)
+	if err != nil {
+		return err
+	}
+
+	// Either listener failing stops the API, as the clients expect both to be up
+	errs := make(chan error, 2)
+	go func() { errs <- app.Listen(fmt.Sprintf(":%d", config.Port)) }()
+	go func() { errs <- newHTTP3Server(app, config.HTTP3Port, cert).ListenAndServe() }()
+
+	return <-errs
 }
@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppPprof(t *testing.T) {
+	zap.Start()
+
+	adminToken := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(Config{AdminToken: adminToken, PprofEnabled: true})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/debug/pprof/cmdline", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Invalid Admin Token", func(t *testing.T) {
+		app := App(Config{AdminToken: adminToken, PprofEnabled: true})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/debug/pprof/cmdline", nil)
+		req.Header.Set("Authorization", "Bearer "+uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		app := App(Config{AdminToken: adminToken})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/debug/pprof/cmdline", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.NotEqual(t, http.StatusOK, resp.StatusCode)
+	})
+}
@@ -0,0 +1,407 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/segment"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SegmentRules struct {
+	Countries       []string   `json:"countries,omitempty"`       // ISO 3166-1 alpha-2 codes of the countries of the player's profile, any of them
+	StatisticID     string     `json:"statisticId,omitempty"`     // Statistic used as the player's level
+	MinValue        *float64   `json:"minValue,omitempty"`        // Lowest value of the statistic, inclusive
+	MaxValue        *float64   `json:"maxValue,omitempty"`        // Highest value of the statistic, inclusive
+	InstalledAfter  *time.Time `json:"installedAfter,omitempty"`  // Players whose profile was created at or after it
+	InstalledBefore *time.Time `json:"installedBefore,omitempty"` // Players whose profile was created before it
+}
+
+func (r SegmentRules) toDomain() segment.Rules {
+	rules := segment.Rules{
+		Countries:   r.Countries,
+		StatisticID: r.StatisticID,
+		MinValue:    r.MinValue,
+		MaxValue:    r.MaxValue,
+	}
+	if r.InstalledAfter != nil {
+		rules.InstalledAfter = *r.InstalledAfter
+	}
+	if r.InstalledBefore != nil {
+		rules.InstalledBefore = *r.InstalledBefore
+	}
+
+	return rules
+}
+
+func segmentRulesFromDomain(r segment.Rules) SegmentRules {
+	rules := SegmentRules{
+		Countries:   r.Countries,
+		StatisticID: r.StatisticID,
+		MinValue:    r.MinValue,
+		MaxValue:    r.MaxValue,
+	}
+	if !r.InstalledAfter.IsZero() {
+		rules.InstalledAfter = &r.InstalledAfter
+	}
+	if !r.InstalledBefore.IsZero() {
+		rules.InstalledBefore = &r.InstalledBefore
+	}
+
+	return rules
+}
+
+type CreateSegmentReq struct {
+	Name        string       `json:"name"`        // Cohort name, up to 64 characters
+	Description string       `json:"description"` // Segment details
+	Rules       SegmentRules `json:"rules"`       // Conditions of the members. Every rule set must match
+}
+
+func (r CreateSegmentReq) toDomain(gameID string) segment.NewSegmentData {
+	return segment.NewSegmentData{
+		GameID:      gameID,
+		Name:        r.Name,
+		Description: r.Description,
+		Rules:       r.Rules.toDomain(),
+	}
+}
+
+type Segment struct {
+	CreatedAt   time.Time    `json:"createdAt"`   // Time the segment was created
+	ID          string       `json:"id"`          // Segment ID
+	GameID      string       `json:"gameId"`      // ID of the game responsible for the segment
+	Name        string       `json:"name"`        // Cohort name
+	Description string       `json:"description"` // Segment details
+	Rules       SegmentRules `json:"rules"`       // Conditions of the members
+}
+
+func segmentFromDomain(s segment.Segment) Segment {
+	return Segment{
+		CreatedAt:   s.CreatedAt,
+		ID:          s.ID,
+		GameID:      s.GameID,
+		Name:        s.Name,
+		Description: s.Description,
+		Rules:       segmentRulesFromDomain(s.Rules),
+	}
+}
+
+type SegmentMembership struct {
+	SegmentID string `json:"segmentId"` // Segment ID
+	PlayerID  string `json:"playerId"`  // Player ID
+	Member    bool   `json:"member"`    // Whether the player matches the segment's rules
+}
+
+type SetEligibilityReq struct {
+	SegmentID string `json:"segmentId"` // Segment whose members are eligible
+}
+
+type Eligibility struct {
+	UpdatedAt    time.Time `json:"updatedAt"`                              // Last time the eligibility was set
+	ResourceType string    `json:"resourceType" enums:"LEADERBOARD,QUEST"` // Resource restricted
+	ResourceID   string    `json:"resourceId"`                             // ID of the leaderboard or the quest
+	SegmentID    string    `json:"segmentId"`                              // Segment whose members are eligible
+}
+
+func eligibilityFromDomain(e segment.Eligibility) Eligibility {
+	return Eligibility{
+		UpdatedAt:    e.UpdatedAt,
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		SegmentID:    e.SegmentID,
+	}
+}
+
+var ErrSegmentFilterUnavailable = errors.New("segment filter unavailable")
+
+var (
+	ErrorResponseSegmentInvalid                 = ErrorResponse{Code: "26.0", Message: "Invalid segment"}
+	ErrorResponseSegmentNotFound                = ErrorResponse{Code: "26.1", Message: "Segment not found"}
+	ErrorResponseSegmentInvalidID               = ErrorResponse{Code: "26.2", Message: "Invalid segment id"}
+	ErrorResponseSegmentInvalidPlayer           = ErrorResponse{Code: "26.3", Message: "Invalid player id"}
+	ErrorResponseSegmentProfileRulesUnavailable = ErrorResponse{Code: "26.4", Message: "The country and install date rules require the player profiles"}
+	ErrorResponseEligibilityInvalid             = ErrorResponse{Code: "26.5", Message: "Invalid eligibility"}
+	ErrorResponseEligibilityNotFound            = ErrorResponse{Code: "26.6", Message: "Eligibility not found"}
+	ErrorResponseSegmentPlayerNotEligible       = ErrorResponse{Code: "26.7", Message: "The player is not a member of the segment the resource is restricted to"}
+	ErrorResponseSegmentFilterUnavailable       = ErrorResponse{Code: "26.8", Message: "The segment filter requires the segments to be enabled"}
+)
+
+// @summary Create Segment
+// @description Create a segment, a named cohort of the game's players matched by rules on the country and creation time
+// @description of their profiles and on the value of a statistic used as their level. Every rule set must match, and the
+// @description statistic must belong to the game. The membership is evaluated on every read, so it follows the players' progress
+// @router /api/v1/segments [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param CreateSegmentReq body CreateSegmentReq true "New segment config data"
+// @success 201 {object} Segment
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildCreateSegmentHandler(createSegmentFunc segment.CreateFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body CreateSegmentReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		s, err := createSegmentFunc(c.UserContext(), body.toDomain(claims.GameID))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(segmentFromDomain(s))
+	}
+}
+
+// @summary List Segments
+// @description List the game's segments, oldest first
+// @router /api/v1/segments [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @success 200 {array} Segment
+// @failure 500 {object} ErrorResponse
+func buildListSegmentsHandler(listSegmentsFunc segment.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		segments, err := listSegmentsFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		res := make([]Segment, len(segments))
+		for i, s := range segments {
+			res[i] = segmentFromDomain(s)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Get Segment By ID
+// @description Get a segment by its id
+// @router /api/v1/segments/{segmentId} [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param segmentId path string true "Segment ID"
+// @success 200 {object} Segment
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetSegmentHandler(getSegmentFunc segment.GetByIDAndGameIDFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		s, err := getSegmentFunc(c.UserContext(), c.Params("segmentId"), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(segmentFromDomain(s))
+	}
+}
+
+// @summary Delete Segment
+// @description Delete a segment by its id. The leaderboards and quests restricted to it are open to every player again
+// @router /api/v1/segments/{segmentId} [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param segmentId path string true "Segment ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildDeleteSegmentHandler(deleteSegmentFunc segment.DeleteFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := deleteSegmentFunc(c.UserContext(), c.Params("segmentId"), claims.GameID); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary Get Segment Membership
+// @description Check whether the player is a member of the segment. Players without a profile are never members of the
+// @description segments with country or install date rules, nor players without progression on the segment's statistic
+// @router /api/v1/segments/{segmentId}/players/{playerId} [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param segmentId path string true "Segment ID"
+// @param playerId path string true "Player ID"
+// @success 200 {object} SegmentMembership
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetSegmentMembershipHandler(isMemberFunc segment.IsMemberFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var (
+			claims    = c.Locals("claims").(auth.Claims)
+			segmentID = c.Params("segmentId")
+			playerID  = c.Params("playerId")
+		)
+
+		member, err := isMemberFunc(c.UserContext(), claims.GameID, segmentID, playerID)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(SegmentMembership{SegmentID: segmentID, PlayerID: playerID, Member: member})
+	}
+}
+
+// @summary List Player Segments
+// @description List the game's segments the player is a member of, oldest first
+// @router /api/v1/players/{playerId}/segments [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {array} Segment
+// @failure 422,500 {object} ErrorResponse
+func buildListPlayerSegmentsHandler(listPlayerSegmentsFunc segment.ListPlayerSegmentsFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		segments, err := listPlayerSegmentsFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		res := make([]Segment, len(segments))
+		for i, s := range segments {
+			res[i] = segmentFromDomain(s)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+func setEligibility(c *fiber.Ctx, setEligibilityFunc segment.SetEligibilityFunc, resourceType, resourceID string) error {
+	claims := c.Locals("claims").(auth.Claims)
+
+	var body SetEligibilityReq
+	if err := c.BodyParser(&body); err != nil {
+		return err
+	}
+
+	e, err := setEligibilityFunc(c.UserContext(), segment.Eligibility{
+		GameID:       claims.GameID,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		SegmentID:    body.SegmentID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(eligibilityFromDomain(e))
+}
+
+func getEligibility(c *fiber.Ctx, getEligibilityFunc segment.GetEligibilityFunc, resourceType, resourceID string) error {
+	claims := c.Locals("claims").(auth.Claims)
+
+	e, err := getEligibilityFunc(c.UserContext(), claims.GameID, resourceType, resourceID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(eligibilityFromDomain(e))
+}
+
+func removeEligibility(c *fiber.Ctx, removeEligibilityFunc segment.RemoveEligibilityFunc, resourceType, resourceID string) error {
+	claims := c.Locals("claims").(auth.Claims)
+
+	if err := removeEligibilityFunc(c.UserContext(), claims.GameID, resourceType, resourceID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// @summary Set Leaderboard Eligibility
+// @description Restrict the leaderboard to the members of a segment, replacing its current restriction. The submissions of
+// @description the other players are rejected, while the ranks they already have are kept
+// @router /api/v1/leaderboards/{leaderboardId}/eligibility [PUT]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @param SetEligibilityReq body SetEligibilityReq true "Segment whose members are eligible"
+// @success 200 {object} Eligibility
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildSetLeaderboardEligibilityHandler(setEligibilityFunc segment.SetEligibilityFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return setEligibility(c, setEligibilityFunc, segment.ResourceLeaderboard, c.Params("leaderboardId"))
+	}
+}
+
+// @summary Get Leaderboard Eligibility
+// @description Get the segment the leaderboard is restricted to
+// @router /api/v1/leaderboards/{leaderboardId}/eligibility [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @success 200 {object} Eligibility
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetLeaderboardEligibilityHandler(getEligibilityFunc segment.GetEligibilityFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return getEligibility(c, getEligibilityFunc, segment.ResourceLeaderboard, c.Params("leaderboardId"))
+	}
+}
+
+// @summary Remove Leaderboard Eligibility
+// @description Open the leaderboard to every player again
+// @router /api/v1/leaderboards/{leaderboardId}/eligibility [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param leaderboardId path string true "Leaderboard ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildRemoveLeaderboardEligibilityHandler(removeEligibilityFunc segment.RemoveEligibilityFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return removeEligibility(c, removeEligibilityFunc, segment.ResourceLeaderboard, c.Params("leaderboardId"))
+	}
+}
+
+// @summary Set Quest Eligibility
+// @description Restrict the quest to the members of a segment, replacing its current restriction. The other players can't
+// @description start the quest, while the ones who already started it keep progressing on it
+// @router /api/v1/quests/{questId}/eligibility [PUT]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param questId path string true "Quest ID"
+// @param SetEligibilityReq body SetEligibilityReq true "Segment whose members are eligible"
+// @success 200 {object} Eligibility
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildSetQuestEligibilityHandler(setEligibilityFunc segment.SetEligibilityFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return setEligibility(c, setEligibilityFunc, segment.ResourceQuest, c.Params("questId"))
+	}
+}
+
+// @summary Get Quest Eligibility
+// @description Get the segment the quest is restricted to
+// @router /api/v1/quests/{questId}/eligibility [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param questId path string true "Quest ID"
+// @success 200 {object} Eligibility
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetQuestEligibilityHandler(getEligibilityFunc segment.GetEligibilityFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return getEligibility(c, getEligibilityFunc, segment.ResourceQuest, c.Params("questId"))
+	}
+}
+
+// @summary Remove Quest Eligibility
+// @description Open the quest to every player again
+// @router /api/v1/quests/{questId}/eligibility [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param questId path string true "Quest ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildRemoveQuestEligibilityHandler(removeEligibilityFunc segment.RemoveEligibilityFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return removeEligibility(c, removeEligibilityFunc, segment.ResourceQuest, c.Params("questId"))
+	}
+}
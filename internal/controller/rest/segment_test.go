@@ -0,0 +1,549 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/segment"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// The game has a single segment, of the players from Brazil, and only the leaderboard given is restricted to it. alice
+// is from Brazil and bob from Portugal. The game has no quests
+func newSegmentTestConfig(gameID, segmentID, leaderboardID string) Config {
+	var (
+		brazil   = segment.Segment{ID: segmentID, GameID: gameID, Name: "Brazil", Rules: segment.Rules{Countries: []string{"BR"}}}
+		profiles = map[string]player.Profile{
+			"alice": {PlayerID: "alice", DisplayName: "Alice", Country: "BR"},
+			"bob":   {PlayerID: "bob", DisplayName: "Bob", Country: "PT"},
+		}
+	)
+
+	listProfiles := func(ctx context.Context, gameID string, playerIDs []string) (map[string]player.Profile, error) {
+		found := make(map[string]player.Profile)
+		for _, id := range playerIDs {
+			if p, ok := profiles[id]; ok {
+				found[id] = p
+			}
+		}
+
+		return found, nil
+	}
+
+	getProgression := func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+		return statistic.PlayerProgression{}, statistic.ErrPlayerStatisticNotFound
+	}
+
+	storageGet := func(ctx context.Context, id, gameID string) (segment.Segment, error) {
+		if id != brazil.ID {
+			return segment.Segment{}, segment.ErrSegmentNotFound
+		}
+
+		return brazil, nil
+	}
+
+	storageList := func(ctx context.Context, gameID string) ([]segment.Segment, error) {
+		return []segment.Segment{brazil}, nil
+	}
+
+	storageGetEligibility := func(ctx context.Context, gameID, resourceType, resourceID string) (segment.Eligibility, error) {
+		if resourceType != segment.ResourceLeaderboard || resourceID != leaderboardID {
+			return segment.Eligibility{}, segment.ErrEligibilityNotFound
+		}
+
+		return segment.Eligibility{GameID: gameID, ResourceType: resourceType, ResourceID: resourceID, SegmentID: brazil.ID}, nil
+	}
+
+	filterMembers := segment.BuildFilterMembersFunc(listProfiles, getProgression, storageGet)
+	isMember := segment.BuildIsMemberFunc(filterMembers)
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		},
+		UpsertPlayerRankFunc: leaderboard.BuildUpsertPlayerRankFunc(nil, segment.BuildRejectIneligibleRankFunc(
+			storageGetEligibility,
+			isMember,
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+				return nil
+			},
+		)),
+		SearchProfilesFunc: player.BuildSearchProfilesFunc(func(ctx context.Context, gameID, search string, offset, limit int) ([]player.Profile, error) {
+			return []player.Profile{profiles["alice"], profiles["bob"]}, nil
+		}),
+		CreateSegmentFunc: segment.BuildCreateFunc(listProfiles, nil, func(ctx context.Context, data segment.NewSegmentData) (segment.Segment, error) {
+			return segment.Segment{ID: uuid.NewString(), GameID: data.GameID, Name: data.Name, Rules: data.Rules}, nil
+		}),
+		GetSegmentFunc:   segment.BuildGetByIDAndGameIDFunc(storageGet),
+		ListSegmentsFunc: segment.BuildListFunc(storageList),
+		DeleteSegmentFunc: segment.BuildDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			return nil
+		}),
+		FilterSegmentMembersFunc: filterMembers,
+		IsSegmentMemberFunc:      isMember,
+		ListPlayerSegmentsFunc:   segment.BuildListPlayerSegmentsFunc(listProfiles, getProgression, storageList),
+		SetEligibilityFunc: segment.BuildSetEligibilityFunc(
+			func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			func(ctx context.Context, id, gameID string) (quest.Quest, error) {
+				return quest.Quest{}, quest.ErrQuestNotFound
+			},
+			storageGet,
+			func(ctx context.Context, eligibility segment.Eligibility) (segment.Eligibility, error) {
+				return eligibility, nil
+			},
+		),
+		GetEligibilityFunc: segment.BuildGetEligibilityFunc(storageGetEligibility),
+		RemoveEligibilityFunc: segment.BuildRemoveEligibilityFunc(func(ctx context.Context, gameID, resourceType, resourceID string) error {
+			_, err := storageGetEligibility(ctx, gameID, resourceType, resourceID)
+			return err
+		}),
+	}
+}
+
+func TestBuildCreateSegmentHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/segments", bytes.NewBufferString(`{"name": "Brazil", "rules": {"countries": ["br"]}}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body Segment
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, SegmentRules{Countries: []string{"BR"}}, body.Rules)
+	})
+
+	t.Run("Invalid Segment", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/segments", bytes.NewBufferString(`{"name": "Empty"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseSegmentInvalid.Code, body.Code)
+		assert.Contains(t, body.Details, segment.ErrMissingRules.Error())
+	})
+}
+
+func TestBuildGetSegmentHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/segments/"+segmentID, nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Segment
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, segmentID, body.ID)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/segments/"+uuid.NewString(), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildDeleteSegmentHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/segments/"+segmentID, nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newSegmentTestConfig(gameID, segmentID, leaderboardID)
+		config.DeleteSegmentFunc = segment.BuildDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			return segment.ErrSegmentNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/segments/"+uuid.NewString(), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildGetSegmentMembershipHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/segments/"+segmentID+"/players/alice", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body SegmentMembership
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, SegmentMembership{SegmentID: segmentID, PlayerID: "alice", Member: true}, body)
+	})
+}
+
+func TestBuildListPlayerSegmentsHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/alice/segments", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Segment
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, segmentID, body[0].ID)
+	})
+
+	t.Run("No Segments", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/bob/segments", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Segment
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Empty(t, body)
+	})
+}
+
+func TestBuildSearchProfilesHandlerSegment(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players?search=player&segmentId="+segmentID, nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Profile
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "alice", body[0].PlayerID)
+	})
+}
+
+func TestBuildSetLeaderboardEligibilityHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var recorded audit.NewEntryData
+
+		config := newSegmentTestConfig(gameID, segmentID, leaderboardID)
+		config.RecordAuditEntryFunc = func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+			recorded = data
+			return audit.Entry{ID: uuid.NewString()}, nil
+		}
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/leaderboards/"+leaderboardID+"/eligibility", bytes.NewBufferString(`{"segmentId": "`+segmentID+`"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Eligibility
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, segment.ResourceLeaderboard, body.ResourceType)
+		assert.Equal(t, leaderboardID, body.ResourceID)
+		assert.Equal(t, segmentID, body.SegmentID)
+
+		assert.Equal(t, http.MethodPut, recorded.Method)
+		assert.Equal(t, "/api/v1/leaderboards/:leaderboardId/eligibility", recorded.Route)
+		assert.Equal(t, map[string]string{"leaderboardId": leaderboardID}, recorded.ResourceIDs)
+		assert.Equal(t, http.StatusOK, recorded.StatusCode)
+	})
+
+	t.Run("Segment Not Found", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/leaderboards/"+leaderboardID+"/eligibility", bytes.NewBufferString(`{"segmentId": "`+uuid.NewString()+`"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildGetLeaderboardEligibilityHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+leaderboardID+"/eligibility", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Eligibility
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, segmentID, body.SegmentID)
+	})
+}
+
+func TestBuildRemoveLeaderboardEligibilityHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/leaderboards/"+leaderboardID+"/eligibility", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/leaderboards/"+uuid.NewString()+"/eligibility", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildUpsertPlayerRankHandlerEligibility(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Eligible", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/alice", bytes.NewBufferString(`{"value": 10}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Not Eligible", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/bob", bytes.NewBufferString(`{"value": 10}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseSegmentPlayerNotEligible, body)
+	})
+
+	t.Run("Unrestricted Leaderboard", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+uuid.NewString()+"/ranking/bob", bytes.NewBufferString(`{"value": 10}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func TestBuildSetQuestEligibilityHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Quest Not Found", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/quests/"+uuid.NewString()+"/eligibility", bytes.NewBufferString(`{"segmentId": "`+segmentID+`"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildGetQuestEligibilityHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		segmentID     = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newSegmentTestConfig(gameID, segmentID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/quests/"+uuid.NewString()+"/eligibility", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseEligibilityNotFound, body)
+	})
+}
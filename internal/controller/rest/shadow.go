@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/shadow"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type FlagPlayerReq struct {
+	Reason string `json:"reason"` // Why the player is flagged, up to 512 characters
+}
+
+type ShadowRank struct {
+	LeaderboardID string   `json:"leaderboardId"`      // Leaderboard the rank is a shadow of
+	Value         float64  `json:"value"`              // Player's value on the shadow copy
+	Previous      *float64 `json:"previous,omitempty"` // Player's value on the ranking when the shadow rank was added. Not set when the player wasn't ranked
+}
+
+type ShadowFlag struct {
+	FlaggedAt   time.Time    `json:"flaggedAt"`   // Time the player was flagged
+	PlayerID    string       `json:"playerId"`    // Player flagged
+	Reason      string       `json:"reason"`      // Why the player was flagged
+	ShadowRanks []ShadowRank `json:"shadowRanks"` // Player's ranks on the shadow copies of the leaderboards
+}
+
+func shadowFlagFromDomain(f shadow.Flag) ShadowFlag {
+	data := ShadowFlag{
+		FlaggedAt:   f.FlaggedAt,
+		PlayerID:    f.PlayerID,
+		Reason:      f.Reason,
+		ShadowRanks: make([]ShadowRank, len(f.ShadowRanks)),
+	}
+
+	for i, rank := range f.ShadowRanks {
+		data.ShadowRanks[i] = ShadowRank{LeaderboardID: rank.LeaderboardID, Value: rank.Value, Previous: rank.Previous}
+	}
+
+	return data
+}
+
+var (
+	ErrorResponseShadowFlagInvalid              = ErrorResponse{Code: "35.0", Message: "Invalid flag"}
+	ErrorResponseShadowFlagNotFound             = ErrorResponse{Code: "35.1", Message: "Flag not found"}
+	ErrorResponseShadowFlagPlayerAlreadyFlagged = ErrorResponse{Code: "35.2", Message: "Player already flagged"}
+	ErrorResponseShadowFlagInvalidPlayer        = ErrorResponse{Code: "35.3", Message: "Invalid player id"}
+)
+
+// @summary Flag Player
+// @description Flag a player suspected of cheating on the game. The player's ranks are moved to the shadow copies of the
+// @description leaderboards right away, and the player's submissions only reach them until the flag is cleared. The player's
+// @description own rank reads, and the ranking pages viewed by the player, place the shadow ranks where their values would be.
+// @description Ranking pages already cached keep showing the player until they expire
+// @router /api/v1/players/{playerId}/flag [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @param FlagPlayerReq body FlagPlayerReq true "Flag details"
+// @success 201 {object} ShadowFlag
+// @failure 400,409,422,500 {object} ErrorResponse
+func buildFlagPlayerHandler(flagFunc shadow.FlagFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body FlagPlayerReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		f, err := flagFunc(c.UserContext(), shadow.NewFlagData{
+			GameID:   claims.GameID,
+			PlayerID: c.Params("playerId"),
+			Reason:   body.Reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(shadowFlagFromDomain(f))
+	}
+}
+
+// @summary Get Player Flag
+// @description Get the player's flag on the game, with their ranks on the shadow copies of the leaderboards
+// @router /api/v1/players/{playerId}/flag [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {object} ShadowFlag
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetPlayerFlagHandler(getFlagFunc shadow.GetFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		f, err := getFlagFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(shadowFlagFromDomain(f))
+	}
+}
+
+// @summary Merge Player Flag
+// @description Clear the player's flag, merging their shadow ranks back into the rankings. The shadow ranks are applied
+// @description through the leaderboards' aggregation, so the submissions made once the flag is cleared are kept
+// @router /api/v1/players/{playerId}/flag/merge [POST]
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildMergePlayerFlagHandler(clearFlagFunc shadow.ClearFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if _, err := clearFlagFunc(c.UserContext(), claims.GameID, c.Params("playerId"), true); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary Discard Player Flag
+// @description Clear the player's flag, discarding their shadow ranks and putting back the ranks they had when flagged
+// @router /api/v1/players/{playerId}/flag [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildDiscardPlayerFlagHandler(clearFlagFunc shadow.ClearFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if _, err := clearFlagFunc(c.UserContext(), claims.GameID, c.Params("playerId"), false); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary List Flags
+// @description List the players flagged on the game, oldest flag first
+// @router /api/v1/flags [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @success 200 {array} ShadowFlag
+// @failure 500 {object} ErrorResponse
+func buildListFlagsHandler(listFlagsFunc shadow.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		flags, err := listFlagsFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		data := make([]ShadowFlag, len(flags))
+		for i, f := range flags {
+			data[i] = shadowFlagFromDomain(f)
+		}
+
+		return c.Status(http.StatusOK).JSON(data)
+	}
+}
@@ -0,0 +1,380 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/shadow"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// The ranking has alice and bob, and suspect is flagged with a shadow rank of 250, up from the 100 it had on the
+// ranking. The values written to the ranking go into the map given
+func newShadowTestConfig(gameID, leaderboardID string, written map[string]float64) Config {
+	var (
+		ranks = []leaderboard.Rank{
+			{LeaderboardID: leaderboardID, PlayerID: "alice", Position: 0, Value: 300},
+			{LeaderboardID: leaderboardID, PlayerID: "bob", Position: 1, Value: 200},
+		}
+		previous = 100.0
+		suspect  = shadow.Flag{
+			GameID:      gameID,
+			PlayerID:    "suspect",
+			Reason:      "score spike",
+			ShadowRanks: []shadow.ShadowRank{{LeaderboardID: leaderboardID, Value: 250, Previous: &previous}},
+		}
+	)
+
+	getLeaderboard := func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+		return leaderboard.Leaderboard{ID: leaderboardID, GameID: gameID, AggregationMode: leaderboard.AggregationModeInc, Ordering: leaderboard.OrderingDesc}, nil
+	}
+
+	getRanking := func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+		start, end := min(page*limit, int64(len(ranks))), min((page+1)*limit, int64(len(ranks)))
+		return ranks[start:end], nil
+	}
+
+	getPlayerRank := func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+		i := slices.IndexFunc(ranks, func(r leaderboard.Rank) bool { return r.PlayerID == playerID })
+		if i < 0 {
+			return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+		}
+
+		return ranks[i], nil
+	}
+
+	upsertValue := func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		written[playerID] = value
+		return nil
+	}
+
+	getFlag := func(ctx context.Context, gameID, playerID string) (shadow.Flag, error) {
+		if playerID != suspect.PlayerID {
+			return shadow.Flag{}, shadow.ErrFlagNotFound
+		}
+
+		return suspect, nil
+	}
+
+	createFlag := func(ctx context.Context, f shadow.Flag) error {
+		if f.PlayerID == suspect.PlayerID {
+			return shadow.ErrPlayerAlreadyFlagged
+		}
+
+		return nil
+	}
+
+	setShadowRank := func(ctx context.Context, gameID, playerID string, rank shadow.ShadowRank) error {
+		return nil
+	}
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: getLeaderboard,
+		RankingFunc:                     leaderboard.BuildRankingFunc(getRanking),
+		UpsertPlayerRankFunc:            leaderboard.UpsertPlayerRankFunc(shadow.BuildRouteFlaggedFunc(getFlag, getPlayerRank, setShadowRank, upsertValue)),
+		FlagPlayerFunc: shadow.BuildFlagFunc(
+			func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+				lb, _ := getLeaderboard(ctx, leaderboardID, gameID)
+				return []leaderboard.Leaderboard{lb}, nil
+			},
+			getPlayerRank,
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+				return nil
+			},
+			createFlag,
+			setShadowRank,
+		),
+		ClearFlagFunc: shadow.BuildClearFunc(getLeaderboard, upsertValue, createFlag, getFlag),
+		GetFlagFunc:   shadow.BuildGetFunc(getFlag),
+		ListFlagsFunc: shadow.BuildListFunc(func(ctx context.Context, gameID string) ([]shadow.Flag, error) {
+			return []shadow.Flag{suspect}, nil
+		}),
+		MergeShadowRankFunc: shadow.BuildMergeViewerFunc(getFlag, getRanking),
+	}
+}
+
+func TestBuildFlagPlayerHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newShadowTestConfig(gameID, leaderboardID, map[string]float64{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/bob/flag", bytes.NewBufferString(`{"reason": "score spike"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body ShadowFlag
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "bob", body.PlayerID)
+		assert.Len(t, body.ShadowRanks, 1)
+	})
+
+	t.Run("Already Flagged", func(t *testing.T) {
+		app := App(newShadowTestConfig(gameID, leaderboardID, map[string]float64{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/suspect/flag", bytes.NewBufferString(`{}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("Invalid Flag", func(t *testing.T) {
+		app := App(newShadowTestConfig(gameID, leaderboardID, map[string]float64{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/"+uuid.NewString()+"/flag", bytes.NewBufferString(`{"reason": "`+strings.Repeat("a", shadow.MaxReasonLength+1)+`"}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseShadowFlagInvalid.Code, body.Code)
+	})
+}
+
+func TestBuildGetPlayerFlagHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newShadowTestConfig(gameID, leaderboardID, map[string]float64{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/suspect/flag", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body ShadowFlag
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "suspect", body.PlayerID)
+		assert.Equal(t, "score spike", body.Reason)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newShadowTestConfig(gameID, leaderboardID, map[string]float64{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/bob/flag", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildListFlagsHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newShadowTestConfig(gameID, leaderboardID, map[string]float64{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/flags", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []ShadowFlag
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "suspect", body[0].PlayerID)
+	})
+}
+
+func TestBuildMergePlayerFlagHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		written := make(map[string]float64)
+		app := App(newShadowTestConfig(gameID, leaderboardID, written))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/suspect/flag/merge", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, map[string]float64{"suspect": 250}, written)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newShadowTestConfig(gameID, leaderboardID, map[string]float64{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/bob/flag/merge", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildDiscardPlayerFlagHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		written := make(map[string]float64)
+		app := App(newShadowTestConfig(gameID, leaderboardID, written))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/players/suspect/flag", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, map[string]float64{"suspect": 100}, written)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(newShadowTestConfig(gameID, leaderboardID, map[string]float64{}))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/players/bob/flag", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildUpsertPlayerRankHandlerShadow(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Flagged", func(t *testing.T) {
+		written := make(map[string]float64)
+		app := App(newShadowTestConfig(gameID, leaderboardID, written))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/suspect", bytes.NewBufferString(`{"value": 150}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Empty(t, written)
+	})
+
+	t.Run("Not Flagged", func(t *testing.T) {
+		written := make(map[string]float64)
+		app := App(newShadowTestConfig(gameID, leaderboardID, written))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/leaderboards/"+leaderboardID+"/ranking/bob", bytes.NewBufferString(`{"value": 10}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		assert.Equal(t, map[string]float64{"bob": 10}, written)
+	})
+}
+
+func TestBuildGetRankingHandlerShadow(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Flagged Viewer", func(t *testing.T) {
+		app := App(newShadowTestConfig(gameID, leaderboardID, map[string]float64{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+leaderboardID+"/ranking?playerId=suspect", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Rank
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Rank{{PlayerID: "alice", Position: 0, Value: 300}, {PlayerID: "suspect", Position: 1, Value: 250}, {PlayerID: "bob", Position: 2, Value: 200}}, body)
+	})
+
+	t.Run("Other Viewer", func(t *testing.T) {
+		app := App(newShadowTestConfig(gameID, leaderboardID, map[string]float64{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+leaderboardID+"/ranking?playerId=bob", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Rank
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Rank{{PlayerID: "alice", Position: 0, Value: 300}, {PlayerID: "bob", Position: 1, Value: 200}}, body)
+	})
+}
@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/signing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SubmissionNonce struct {
+	Nonce     string    `json:"nonce"`     // Nonce to sign alongside the player's next submission
+	ExpiresAt time.Time `json:"expiresAt"` // Time the nonce can no longer be used
+}
+
+type SigningKey struct {
+	Key string `json:"key"` // Base64 encoded HMAC-SHA256 key the game's clients sign their submissions with
+}
+
+var (
+	ErrorResponseSigningMissingSignature = ErrorResponse{Code: "30.0", Message: "The submission must carry a nonce and its signature"}
+	ErrorResponseSigningInvalidSignature = ErrorResponse{Code: "30.1", Message: "Invalid submission signature"}
+	ErrorResponseSigningNonceNotFound    = ErrorResponse{Code: "30.2", Message: "Nonce not found, it expired, was already used or was issued to another player"}
+	ErrorResponseSigningInvalidPlayer    = ErrorResponse{Code: "30.3", Message: "Invalid player id"}
+)
+
+// @summary Issue Submission Nonce
+// @description Issue a single-use nonce to a player of the game. With the signed submissions enabled, each rank submission
+// @description carries a nonce alongside the hex encoded HMAC-SHA256, with the game's signing key, of the player ID, leaderboard ID,
+// @description value and nonce joined by new lines, e.g. `alice\nleaderboard\n120.5\nnonce`. The nonce expires after `SUBMISSION_NONCE_TTL` seconds
+// @router /api/v1/players/{playerId}/submission-nonce [POST]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 201 {object} SubmissionNonce
+// @failure 422,500 {object} ErrorResponse
+func buildIssueSubmissionNonceHandler(issueNonceFunc signing.IssueNonceFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		nonce, err := issueNonceFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(SubmissionNonce{Nonce: nonce.Value, ExpiresAt: nonce.ExpiresAt})
+	}
+}
+
+// @summary Get Game Signing Key
+// @description Get the key the game's clients sign their rank submissions with. Each game has its own key, derived from
+// @description `SUBMISSION_SIGNING_KEY`, so it changes for every game when that one is rotated
+// @router /admin/v1/games/{gameId}/signing-key [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @success 200 {object} SigningKey
+// @failure 401,403,500 {object} ErrorResponse
+func buildGetSigningKeyHandler(getGameKeyFunc signing.GetGameKeyFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key, err := getGameKeyFunc(c.UserContext(), c.Params("gameId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(SigningKey{Key: key})
+	}
+}
@@ -0,0 +1,175 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/signing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var signingTestKey = bytes.Repeat([]byte{7}, signing.MinKeySize)
+
+func newSigningTestConfig(t *testing.T, gameID, adminToken string, storageConsumeNonceFunc signing.StorageConsumeNonceFunc) Config {
+	issue, err := signing.BuildIssueNonceFunc(time.Minute, func(ctx context.Context, nonce signing.Nonce, ttl time.Duration) error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	verify, err := signing.BuildVerifyFunc(signingTestKey, storageConsumeNonceFunc)
+	assert.NoError(t, err)
+
+	getGameKey, err := signing.BuildGetGameKeyFunc(signingTestKey)
+	assert.NoError(t, err)
+
+	return Config{
+		AdminToken: adminToken,
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		},
+		UpsertPlayerRankFunc: func(ctx context.Context, leaderboard leaderboard.Leaderboard, playerID string, value float64) error {
+			return nil
+		},
+		IssueSubmissionNonceFunc: issue,
+		VerifySubmissionFunc:     verify,
+		GetSigningKeyFunc:        getGameKey,
+	}
+}
+
+func TestBuildGetSigningKeyHandler(t *testing.T) {
+	var (
+		gameID     = uuid.NewString()
+		adminToken = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSigningTestConfig(t, gameID, adminToken, nil))
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/v1/games/%s/signing-key", gameID), nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body SigningKey
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		gameKey, err := base64.StdEncoding.DecodeString(body.Key)
+		assert.NoError(t, err)
+		assert.Equal(t, signing.GameKey(signingTestKey, gameID), gameKey)
+	})
+}
+
+func TestBuildIssueSubmissionNonceHandler(t *testing.T) {
+	gameID := uuid.NewString()
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSigningTestConfig(t, gameID, uuid.NewString(), nil))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/players/alice/submission-nonce", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body SubmissionNonce
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.NotEmpty(t, body.Nonce)
+	})
+}
+
+func TestBuildUpsertPlayerRankHandlerSigned(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+		nonce         = uuid.NewString()
+		rankingPath   = fmt.Sprintf("/api/v1/leaderboards/%s/ranking/alice", leaderboardID)
+		gameKey       = signing.GameKey(signingTestKey, gameID)
+	)
+
+	consumeNonce := func(ctx context.Context, gameID, playerID, value string) error {
+		if playerID != "alice" || value != nonce {
+			return signing.ErrNonceNotFound
+		}
+
+		return nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newSigningTestConfig(t, gameID, uuid.NewString(), consumeNonce))
+
+		signature := signing.Sign(gameKey, "alice", leaderboardID, 100, nonce)
+		req := httptest.NewRequest(http.MethodPost, rankingPath, bytes.NewBufferString(fmt.Sprintf(`{"value": 100, "nonce": %q, "signature": %q}`, nonce, signature)))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Missing Signature", func(t *testing.T) {
+		app := App(newSigningTestConfig(t, gameID, uuid.NewString(), consumeNonce))
+
+		req := httptest.NewRequest(http.MethodPost, rankingPath, bytes.NewBufferString(`{"value": 100}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("Tampered Value", func(t *testing.T) {
+		app := App(newSigningTestConfig(t, gameID, uuid.NewString(), consumeNonce))
+
+		signature := signing.Sign(gameKey, "alice", leaderboardID, 100, nonce)
+		req := httptest.NewRequest(http.MethodPost, rankingPath, bytes.NewBufferString(fmt.Sprintf(`{"value": 100000, "nonce": %q, "signature": %q}`, nonce, signature)))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		app := App(newSigningTestConfig(t, gameID, uuid.NewString(), func(ctx context.Context, gameID, playerID, nonce string) error {
+			return signing.ErrNonceNotFound
+		}))
+
+		signature := signing.Sign(gameKey, "alice", leaderboardID, 100, nonce)
+		req := httptest.NewRequest(http.MethodPost, rankingPath, bytes.NewBufferString(fmt.Sprintf(`{"value": 100, "nonce": %q, "signature": %q}`, nonce, signature)))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Latency of each route over the SLO window, alongside its target
+type ReportSLOFunc func() (metrics.SLOReport, error)
+
+type SLORouteLatency struct {
+	Method   string  `json:"method"`   // Request method
+	Route    string  `json:"route"`    // Route pattern, e.g. `/api/v1/leaderboards/:leaderboardId`
+	Count    uint64  `json:"count"`    // Requests handled since the instance started
+	P50MS    float64 `json:"p50Ms"`    // Median latency over the window, in milliseconds
+	P95MS    float64 `json:"p95Ms"`    // 95th percentile latency over the window, in milliseconds
+	P99MS    float64 `json:"p99Ms"`    // 99th percentile latency over the window, in milliseconds
+	TargetMS float64 `json:"targetMs"` // Latency the 99th percentile must stay under, in milliseconds. Zero without a target
+	Met      bool    `json:"met"`      // Whether the 99th percentile is under the target. Always true without a target
+}
+
+type SLOReport struct {
+	WindowSeconds float64           `json:"windowSeconds"` // Period the quantiles are computed over, in seconds
+	Met           bool              `json:"met"`           // Whether every route is under its target
+	Routes        []SLORouteLatency `json:"routes"`        // Routes ordered by pattern and method
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func sloReportFromDomain(r metrics.SLOReport) SLOReport {
+	report := SLOReport{
+		WindowSeconds: r.Window.Seconds(),
+		Met:           true,
+		Routes:        make([]SLORouteLatency, len(r.Routes)),
+	}
+
+	for i, l := range r.Routes {
+		report.Routes[i] = SLORouteLatency{
+			Method:   l.Method,
+			Route:    l.Route,
+			Count:    l.Count,
+			P50MS:    milliseconds(l.P50),
+			P95MS:    milliseconds(l.P95),
+			P99MS:    milliseconds(l.P99),
+			TargetMS: milliseconds(l.Target),
+			Met:      l.Met(),
+		}
+		report.Met = report.Met && l.Met()
+	}
+
+	return report
+}
+
+// @summary SLO Report
+// @description Report the latency quantiles of each route handled by this instance over the SLO window, and whether they are under their targets
+// @router /debug/slo [GET]
+// @produce json
+// @success 200 {object} SLOReport
+// @failure 500 {object} ErrorResponse
+func buildSLOReportHandler(reportSLOFunc ReportSLOFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		report, err := reportSLOFunc()
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(sloReportFromDomain(report))
+	}
+}
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSLOReportHandler(t *testing.T) {
+	const rankingRoute = "/api/v1/leaderboards/:leaderboardId/ranking/"
+
+	slo := metrics.NewSLO(prometheus.NewRegistry(), time.Minute, map[string]time.Duration{rankingRoute: time.Minute})
+
+	app := App(Config{
+		ObserveRequestFunc: slo.Observe,
+		ReportSLOFunc:      slo.Report,
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: uuid.NewString()}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID, StartAt: time.Now()}, nil
+		},
+		RankingFunc: func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+			return []leaderboard.Rank{}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s/ranking", uuid.NewString()), nil)
+	req.Header.Set("Authorization", uuid.NewString())
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/debug/slo", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body SLOReport
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(60), body.WindowSeconds)
+	assert.True(t, body.Met)
+	assert.Len(t, body.Routes, 1)
+	assert.Equal(t, rankingRoute, body.Routes[0].Route)
+	assert.Equal(t, uint64(1), body.Routes[0].Count)
+	assert.Equal(t, float64(60000), body.Routes[0].TargetMS)
+	assert.True(t, body.Routes[0].Met)
+}
@@ -2,12 +2,12 @@ package rest
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gabapcia/gameblitz/internal/auth"
 	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/quota"
 	"github.com/gabapcia/gameblitz/internal/statistic"
 
 	"github.com/gofiber/fiber/v2"
@@ -66,6 +66,7 @@ var (
 	ErrorResponseStatisticInvalid   = ErrorResponse{Code: "4.0", Message: "Invalid statistic"}
 	ErrorResponseStatisticNotFound  = ErrorResponse{Code: "4.1", Message: "Statistic not found"}
 	ErrorResponseStatisticInvalidID = ErrorResponse{Code: "4.2", Message: "Invalid statistic id"}
+	ErrorResponseStatisticNameInUse = ErrorResponse{Code: "4.3", Message: "Statistic name already in use"}
 )
 
 func buildGetStatisticMiddleware(cache fiber.Storage, expiration time.Duration, getStatisticByIDAndGameIDFunc statistic.GetByIDAndGameIDFunc) fiber.Handler {
@@ -73,17 +74,17 @@ func buildGetStatisticMiddleware(cache fiber.Storage, expiration time.Duration,
 		var (
 			id       = c.Params("statisticId")
 			claims   = c.Locals("claims").(auth.Claims)
-			cacheKey = fmt.Sprintf("GetStatisticMiddleware:%s:%s", id, claims.GameID)
+			cacheKey = statisticCacheKey(id, claims.GameID)
 		)
 
 		if cache != nil {
 			data, err := cache.Get(cacheKey)
 			if err != nil {
-				zap.Error(err, "get cache error")
+				zap.ErrorContext(c.UserContext(), err, "get cache error")
 			} else if data != nil {
 				var statistic statistic.Statistic
 				if err = json.Unmarshal(data, &statistic); err != nil {
-					zap.Error(err, "unmarshal cached statistic error")
+					zap.ErrorContext(c.UserContext(), err, "unmarshal cached statistic error")
 				} else {
 					c.Locals("statistic", statistic)
 					return c.Next()
@@ -91,7 +92,7 @@ func buildGetStatisticMiddleware(cache fiber.Storage, expiration time.Duration,
 			}
 		}
 
-		statistic, err := getStatisticByIDAndGameIDFunc(c.Context(), id, claims.GameID)
+		statistic, err := getStatisticByIDAndGameIDFunc(c.UserContext(), id, claims.GameID)
 		if err != nil {
 			return err
 		}
@@ -99,10 +100,10 @@ func buildGetStatisticMiddleware(cache fiber.Storage, expiration time.Duration,
 		if cache != nil {
 			data, err := json.Marshal(statistic)
 			if err != nil {
-				zap.Error(err, "marshal statistic cache error")
+				zap.ErrorContext(c.UserContext(), err, "marshal statistic cache error")
 			} else {
 				if err = cache.Set(cacheKey, data, expiration); err != nil {
-					zap.Error(err, "unable to cache statistic")
+					zap.ErrorContext(c.UserContext(), err, "unable to cache statistic")
 				}
 			}
 		}
@@ -113,15 +114,15 @@ func buildGetStatisticMiddleware(cache fiber.Storage, expiration time.Duration,
 }
 
 // @summary Create Statistic
-// @description Create a statistic
+// @description Create a statistic. Fails when the game already keeps as many statistics as its quota allows
 // @router /api/v1/statistics [POST]
 // @accept json
 // @produce json
 // @param Authorization header string true "Game's JWT authorization"
 // @param NewStatisticData body CreateStatisticReq true "New statistic config data"
 // @success 201 {object} Statistic
-// @failure 400,422,500 {object} ErrorResponse
-func buildCreateStatisticHandler(createStatisticFunc statistic.CreateFunc) fiber.Handler {
+// @failure 400,403,409,422,500 {object} ErrorResponse
+func buildCreateStatisticHandler(checkStatisticQuotaFunc quota.CheckFunc, createStatisticFunc statistic.CreateFunc) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		claims := c.Locals("claims").(auth.Claims)
 
@@ -130,7 +131,13 @@ func buildCreateStatisticHandler(createStatisticFunc statistic.CreateFunc) fiber
 			return err
 		}
 
-		statistic, err := createStatisticFunc(c.Context(), body.toDomain(claims.GameID))
+		if checkStatisticQuotaFunc != nil {
+			if err := checkStatisticQuotaFunc(c.UserContext(), claims.GameID); err != nil {
+				return err
+			}
+		}
+
+		statistic, err := createStatisticFunc(c.UserContext(), body.toDomain(claims.GameID))
 		if err != nil {
 			return err
 		}
@@ -154,7 +161,7 @@ func buildGetStatisticHanlder(getStatisticByIDAndGameID statistic.GetByIDAndGame
 			claims      = c.Locals("claims").(auth.Claims)
 		)
 
-		statistic, err := getStatisticByIDAndGameID(c.Context(), statisticID, claims.GameID)
+		statistic, err := getStatisticByIDAndGameID(c.UserContext(), statisticID, claims.GameID)
 		if err != nil {
 			return err
 		}
@@ -163,6 +170,31 @@ func buildGetStatisticHanlder(getStatisticByIDAndGameID statistic.GetByIDAndGame
 	}
 }
 
+// @summary List Statistics
+// @description List the game's statistics, oldest first
+// @router /api/v1/statistics [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @success 200 {array} Statistic
+// @failure 500 {object} ErrorResponse
+func buildListStatisticsHandler(listStatisticsFunc statistic.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		statistics, err := listStatisticsFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		res := make([]Statistic, len(statistics))
+		for i, s := range statistics {
+			res[i] = statisticFromDomain(s)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
 // @summary Delete Statistic
 // @description Delete a statistic by its id
 // @router /api/v1/statistics/{statisticId} [DELETE]
@@ -170,17 +202,18 @@ func buildGetStatisticHanlder(getStatisticByIDAndGameID statistic.GetByIDAndGame
 // @param statisticId path string true "Statistic ID"
 // @success 204
 // @failure 404,422,500 {object} ErrorResponse
-func buildDeleteStatisticHanlder(softDeleteStatisticFunc statistic.SoftDeleteByIDAndGameIDFunc) fiber.Handler {
+func buildDeleteStatisticHanlder(cache fiber.Storage, softDeleteStatisticFunc statistic.SoftDeleteByIDAndGameIDFunc) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var (
 			questID = c.Params("statisticId")
 			claims  = c.Locals("claims").(auth.Claims)
 		)
 
-		if err := softDeleteStatisticFunc(c.Context(), questID, claims.GameID); err != nil {
+		if err := softDeleteStatisticFunc(c.UserContext(), questID, claims.GameID); err != nil {
 			return err
 		}
 
+		invalidateCache(cache, c, statisticCacheKey(questID, claims.GameID))
 		return c.SendStatus(http.StatusNoContent)
 	}
 }
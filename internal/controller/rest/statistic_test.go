@@ -160,6 +160,34 @@ func TestBuildCreateStatisticHanlder(t *testing.T) {
 		assert.Equal(t, ErrorResponseStatisticInvalid.Message, data.Message)
 	})
 
+	t.Run("Name Already In Use", func(t *testing.T) {
+		gameID := uuid.NewString()
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			CreateStatisticFunc: func(ctx context.Context, data statistic.NewStatisticData) (statistic.Statistic, error) {
+				return statistic.Statistic{}, statistic.ErrStatisticNameAlreadyInUse
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/statistics", bytes.NewBufferString("{}"))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseStatisticNameInUse.Code, data.Code)
+		assert.Equal(t, ErrorResponseStatisticNameInUse.Message, data.Message)
+	})
+
 	t.Run("Invalid Request Body", func(t *testing.T) {
 		var (
 			gameID = uuid.NewString()
@@ -336,6 +364,88 @@ func TestBuildGetStatisticHanlder(t *testing.T) {
 	})
 }
 
+func TestBuildListStatisticsHandler(t *testing.T) {
+	var (
+		statisticID = uuid.NewString()
+		gameID      = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			ListStatisticsFunc: func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+				return []statistic.Statistic{{ID: statisticID, GameID: gameID}}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/statistics", nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var data []Statistic
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Len(t, data, 1)
+		assert.Equal(t, statisticID, data[0].ID)
+		assert.Equal(t, gameID, data[0].GameID)
+	})
+
+	t.Run("Not Cached Across Games", func(t *testing.T) {
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: credentials}, nil
+			},
+			ListStatisticsFunc: func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+				return []statistic.Statistic{{ID: statisticID, GameID: gameID}}, nil
+			},
+			CacheExpiration: time.Minute,
+		})
+
+		for _, game := range []string{uuid.NewString(), uuid.NewString()} {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/statistics", nil)
+			req.Header.Set("Authorization", game)
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var data []Statistic
+			err = json.NewDecoder(resp.Body).Decode(&data)
+			assert.NoError(t, err)
+
+			assert.Len(t, data, 1)
+			assert.Equal(t, game, data[0].GameID)
+		}
+	})
+
+	t.Run("Random Error", func(t *testing.T) {
+		zap.Start()
+		defer zap.Sync()
+
+		app := App(Config{
+			AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+				return auth.Claims{GameID: gameID}, nil
+			},
+			ListStatisticsFunc: func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+				return nil, errors.New("any error")
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/statistics", nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
 func TestBuildDeleteStatisticHanlder(t *testing.T) {
 	var (
 		statisticID = uuid.NewString()
@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gabapcia/gameblitz/internal/summary"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DailySubmissions struct {
+	Day              string `json:"day"`              // Day of the submissions, as `YYYY-MM-DD` in UTC
+	ScoreSubmissions int64  `json:"scoreSubmissions"` // Rank and statistic progression updates
+}
+
+type ErrorCodeCount struct {
+	Code  string `json:"code"`  // Error unique code, as on `ErrorResponse`
+	Count int64  `json:"count"` // Requests answered with it
+}
+
+type GameSummary struct {
+	GameID             string             `json:"gameId"`             // Game summarized
+	ActiveLeaderboards int                `json:"activeLeaderboards"` // Leaderboards accepting scores right now
+	ActiveStatistics   int                `json:"activeStatistics"`   // Statistics not deleted
+	ActiveQuests       int                `json:"activeQuests"`       // Quests not deleted
+	UsageTracked       bool               `json:"usageTracked"`       // Whether the submissions and errors are tracked. Both are empty when not
+	Submissions        []DailySubmissions `json:"submissions"`        // Score submissions of each of the last 7 days, oldest first, including the days without any
+	TopErrors          []ErrorCodeCount   `json:"topErrors"`          // Up to 5 most frequent errors answered to the game over the same days, most frequent first
+}
+
+func gameSummaryFromDomain(s summary.Summary) GameSummary {
+	submissions := make([]DailySubmissions, len(s.Submissions))
+	for i, d := range s.Submissions {
+		submissions[i] = DailySubmissions{
+			Day:              d.Day.Format(usageDateLayout),
+			ScoreSubmissions: d.ScoreSubmissions,
+		}
+	}
+
+	topErrors := make([]ErrorCodeCount, len(s.TopErrors))
+	for i, e := range s.TopErrors {
+		topErrors[i] = ErrorCodeCount{
+			Code:  e.Code,
+			Count: e.Count,
+		}
+	}
+
+	return GameSummary{
+		GameID:             s.GameID,
+		ActiveLeaderboards: s.ActiveLeaderboards,
+		ActiveStatistics:   s.ActiveStatistics,
+		ActiveQuests:       s.ActiveQuests,
+		UsageTracked:       s.UsageTracked,
+		Submissions:        submissions,
+		TopErrors:          topErrors,
+	}
+}
+
+// @summary Game Summary
+// @description Count the game's active leaderboards, statistics and quests alongside its score submissions and most frequent
+// @description errors over the last 7 days, for the internal dashboards. The submissions and errors are only tracked with the usage,
+// @description which is written to the storage periodically, so the latest requests may not be counted yet
+// @router /admin/v1/games/{gameId}/summary [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @success 200 {object} GameSummary
+// @failure 401,403,500 {object} ErrorResponse
+func buildGetGameSummaryHandler(summarizeFunc summary.SummarizeFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		s, err := summarizeFunc(c.UserContext(), c.Params("gameId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(gameSummaryFromDomain(s))
+	}
+}
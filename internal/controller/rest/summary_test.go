@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/summary"
+	"github.com/gabapcia/gameblitz/internal/usage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildGetGameSummaryHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+		day        = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			SummarizeGameFunc: func(ctx context.Context, gameID string) (summary.Summary, error) {
+				return summary.Summary{
+					GameID:             gameID,
+					ActiveLeaderboards: 2,
+					ActiveStatistics:   1,
+					ActiveQuests:       3,
+					UsageTracked:       true,
+					Submissions:        []summary.DailySubmissions{{Day: day, ScoreSubmissions: 4}},
+					TopErrors:          []usage.ErrorCount{{Code: "1.2", Count: 3}},
+				}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/summary", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body GameSummary
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, GameSummary{
+			GameID:             gameID,
+			ActiveLeaderboards: 2,
+			ActiveStatistics:   1,
+			ActiveQuests:       3,
+			UsageTracked:       true,
+			Submissions:        []DailySubmissions{{Day: "2024-03-01", ScoreSubmissions: 4}},
+			TopErrors:          []ErrorCodeCount{{Code: "1.2", Count: 3}},
+		}, body)
+	})
+
+	t.Run("Usage Not Tracked", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			SummarizeGameFunc: func(ctx context.Context, gameID string) (summary.Summary, error) {
+				return summary.Summary{GameID: gameID, ActiveQuests: 1}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/summary", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, false, body["usageTracked"])
+		assert.Equal(t, []any{}, body["submissions"])
+		assert.Equal(t, []any{}, body["topErrors"])
+	})
+}
@@ -0,0 +1,3 @@
+package rest
+
+var ErrorResponseSubmissionRateLimited = ErrorResponse{Code: "36.0", Message: "Too many submissions from the player, try again later"}
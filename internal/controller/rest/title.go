@@ -0,0 +1,225 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/title"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TitleTrigger struct {
+	Kind          string  `json:"kind" enums:"STATISTIC_LANDMARK,RANK"` // Progression that grants the title
+	StatisticID   string  `json:"statisticId,omitempty"`                // Statistic whose landmark grants the title. Only on `STATISTIC_LANDMARK`
+	Landmark      float64 `json:"landmark,omitempty"`                   // Landmark of the statistic that grants the title. Only on `STATISTIC_LANDMARK`
+	LeaderboardID string  `json:"leaderboardId,omitempty"`              // Leaderboard whose top positions grant the title. Only on `RANK`
+	Top           int64   `json:"top,omitempty"`                        // Positions of the leaderboard that grant the title, e.g. 10 for the top 10. Only on `RANK`
+}
+
+func (t TitleTrigger) toDomain() title.Trigger {
+	return title.Trigger{
+		Kind:          t.Kind,
+		StatisticID:   t.StatisticID,
+		Landmark:      t.Landmark,
+		LeaderboardID: t.LeaderboardID,
+		Top:           t.Top,
+	}
+}
+
+func titleTriggerFromDomain(t title.Trigger) TitleTrigger {
+	return TitleTrigger{
+		Kind:          t.Kind,
+		StatisticID:   t.StatisticID,
+		Landmark:      t.Landmark,
+		LeaderboardID: t.LeaderboardID,
+		Top:           t.Top,
+	}
+}
+
+type CreateTitleReq struct {
+	Name        string       `json:"name"`        // Title shown alongside the player's name, up to 64 characters
+	Description string       `json:"description"` // Title details
+	BadgeURL    string       `json:"badgeUrl"`    // Badge image shown alongside the title, as an absolute http or https url. Optional
+	Trigger     TitleTrigger `json:"trigger"`     // Progression that grants the title
+}
+
+func (r CreateTitleReq) toDomain(gameID string) title.NewTitleData {
+	return title.NewTitleData{
+		GameID:      gameID,
+		Name:        r.Name,
+		Description: r.Description,
+		BadgeURL:    r.BadgeURL,
+		Trigger:     r.Trigger.toDomain(),
+	}
+}
+
+type Title struct {
+	CreatedAt   time.Time    `json:"createdAt"`          // Time the title was created
+	UpdatedAt   time.Time    `json:"updatedAt"`          // Last time the title was changed
+	ID          string       `json:"id"`                 // Title ID
+	GameID      string       `json:"gameId"`             // ID of the game responsible for the title
+	Name        string       `json:"name"`               // Title shown alongside the player's name
+	Description string       `json:"description"`        // Title details
+	BadgeURL    string       `json:"badgeUrl,omitempty"` // Badge image shown alongside the title
+	Trigger     TitleTrigger `json:"trigger"`            // Progression that grants the title
+}
+
+func titleFromDomain(t title.Title) Title {
+	return Title{
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		ID:          t.ID,
+		GameID:      t.GameID,
+		Name:        t.Name,
+		Description: t.Description,
+		BadgeURL:    t.BadgeURL,
+		Trigger:     titleTriggerFromDomain(t.Trigger),
+	}
+}
+
+type PlayerTitle struct {
+	Title     Title     `json:"title"`     // Title granted
+	PlayerID  string    `json:"playerId"`  // Player the title was granted to
+	GrantedAt time.Time `json:"grantedAt"` // Time the player made the progression that granted it
+}
+
+// Title shown alongside a player on the ranking pages
+type RankTitle struct {
+	ID       string `json:"id"`                 // Title ID
+	Name     string `json:"name"`               // Title shown alongside the player's name
+	BadgeURL string `json:"badgeUrl,omitempty"` // Badge image shown alongside the title
+}
+
+var (
+	ErrorResponseTitleInvalid         = ErrorResponse{Code: "23.0", Message: "Invalid title"}
+	ErrorResponseTitleNotFound        = ErrorResponse{Code: "23.1", Message: "Title not found"}
+	ErrorResponseTitleInvalidID       = ErrorResponse{Code: "23.2", Message: "Invalid title id"}
+	ErrorResponseTitleUnknownLandmark = ErrorResponse{Code: "23.3", Message: "The landmark is not one of the statistic's landmarks"}
+	ErrorResponseTitleInvalidPlayer   = ErrorResponse{Code: "23.4", Message: "Invalid player id"}
+)
+
+// @summary Create Title
+// @description Create a title, granted when a player reaches a landmark of a statistic or ranks on the top positions of a
+// @description leaderboard. The statistic or leaderboard must belong to the game. A title is kept once granted, and
+// @description progressions made before the title was created don't grant it
+// @router /api/v1/titles [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param CreateTitleReq body CreateTitleReq true "New title config data"
+// @success 201 {object} Title
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildCreateTitleHandler(createTitleFunc title.CreateFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body CreateTitleReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		t, err := createTitleFunc(c.UserContext(), body.toDomain(claims.GameID))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(titleFromDomain(t))
+	}
+}
+
+// @summary List Titles
+// @description List the game's titles, oldest first
+// @router /api/v1/titles [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @success 200 {array} Title
+// @failure 500 {object} ErrorResponse
+func buildListTitlesHandler(listTitlesFunc title.ListFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		titles, err := listTitlesFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		res := make([]Title, len(titles))
+		for i, t := range titles {
+			res[i] = titleFromDomain(t)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Get Title By ID
+// @description Get a title by its id
+// @router /api/v1/titles/{titleId} [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param titleId path string true "Title ID"
+// @success 200 {object} Title
+// @failure 404,422,500 {object} ErrorResponse
+func buildGetTitleHandler(getTitleFunc title.GetByIDAndGameIDFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		t, err := getTitleFunc(c.UserContext(), c.Params("titleId"), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(titleFromDomain(t))
+	}
+}
+
+// @summary Delete Title
+// @description Delete a title by its id, alongside its grants to the players
+// @router /api/v1/titles/{titleId} [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param titleId path string true "Title ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildDeleteTitleHandler(deleteTitleFunc title.DeleteFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		if err := deleteTitleFunc(c.UserContext(), c.Params("titleId"), claims.GameID); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary List Player Titles
+// @description List the titles granted to the player, in the order they were granted
+// @router /api/v1/players/{playerId}/titles [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param playerId path string true "Player ID"
+// @success 200 {array} PlayerTitle
+// @failure 422,500 {object} ErrorResponse
+func buildListPlayerTitlesHandler(listPlayerTitlesFunc title.ListPlayerTitlesFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		granted, err := listPlayerTitlesFunc(c.UserContext(), claims.GameID, c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		res := make([]PlayerTitle, len(granted))
+		for i, t := range granted {
+			res[i] = PlayerTitle{
+				Title:     titleFromDomain(t.Title),
+				PlayerID:  t.PlayerID,
+				GrantedAt: t.GrantedAt,
+			}
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
@@ -0,0 +1,220 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/title"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// The game has a single title, granted to alice, and the ranking has alice and bob
+func newTitleTestConfig(gameID, leaderboardID string) Config {
+	champion := title.Title{
+		ID:       "champion",
+		GameID:   gameID,
+		Name:     "Champion",
+		BadgeURL: "https://cdn.example.com/champion.png",
+		Trigger:  title.Trigger{Kind: title.TriggerRank, LeaderboardID: leaderboardID, Top: 1},
+	}
+
+	storageList := func(ctx context.Context, gameID string) ([]title.Title, error) {
+		return []title.Title{champion}, nil
+	}
+	storageListGrants := func(ctx context.Context, gameID string, playerIDs []string) ([]title.Grant, error) {
+		if !slices.Contains(playerIDs, "alice") {
+			return []title.Grant{}, nil
+		}
+
+		return []title.Grant{{TitleID: champion.ID, GameID: gameID, PlayerID: "alice", GrantedAt: time.Now().UTC()}}, nil
+	}
+
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		},
+		RankingFunc: func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+			return []leaderboard.Rank{{PlayerID: "alice", Position: 0, Value: 10}, {PlayerID: "bob", Position: 1, Value: 5}}, nil
+		},
+		CreateTitleFunc: title.BuildCreateFunc(
+			nil,
+			func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			func(ctx context.Context, data title.NewTitleData) (title.Title, error) {
+				return title.Title{ID: uuid.NewString(), GameID: data.GameID, Name: data.Name, BadgeURL: data.BadgeURL, Trigger: data.Trigger}, nil
+			},
+		),
+		GetTitleFunc: title.BuildGetByIDAndGameIDFunc(func(ctx context.Context, id, gameID string) (title.Title, error) {
+			return champion, nil
+		}),
+		ListTitlesFunc: title.BuildListFunc(storageList),
+		DeleteTitleFunc: title.BuildDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			return nil
+		}),
+		ListPlayerTitlesFunc:  title.BuildListPlayerTitlesFunc(storageList, storageListGrants),
+		ListPlayersTitlesFunc: title.BuildListPlayersTitlesFunc(storageList, storageListGrants),
+	}
+}
+
+func TestBuildCreateTitleHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newTitleTestConfig(gameID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/titles", bytes.NewBufferString(`{"name": "Champion", "badgeUrl": "https://cdn.example.com/champion.png", "trigger": {"kind": "RANK", "leaderboardId": "`+leaderboardID+`", "top": 1}}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var body Title
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, gameID, body.GameID)
+		assert.Equal(t, TitleTrigger{Kind: title.TriggerRank, LeaderboardID: leaderboardID, Top: 1}, body.Trigger)
+	})
+
+	t.Run("Invalid Title", func(t *testing.T) {
+		app := App(newTitleTestConfig(gameID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/titles", bytes.NewBufferString(`{"name": "Champion", "trigger": {"kind": "RANK"}}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseTitleInvalid.Code, body.Code)
+		assert.Contains(t, body.Details, title.ErrMissingLeaderboardID.Error())
+	})
+}
+
+func TestBuildGetTitleHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newTitleTestConfig(gameID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/titles/champion", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Title
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "champion", body.ID)
+	})
+}
+
+func TestBuildDeleteTitleHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("Not Found", func(t *testing.T) {
+		config := newTitleTestConfig(gameID, leaderboardID)
+		config.DeleteTitleFunc = title.BuildDeleteFunc(func(ctx context.Context, id, gameID string) error {
+			return title.ErrTitleNotFound
+		})
+		app := App(config)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/titles/"+uuid.NewString(), nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildListPlayerTitlesHandler(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newTitleTestConfig(gameID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/players/alice/titles", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []PlayerTitle
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Len(t, body, 1)
+		assert.Equal(t, "champion", body[0].Title.ID)
+	})
+}
+
+func TestBuildGetRankingHandlerTitles(t *testing.T) {
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(newTitleTestConfig(gameID, leaderboardID))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+leaderboardID+"/ranking", nil)
+
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []Rank
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Rank{
+			{PlayerID: "alice", Position: 0, Value: 10, Titles: []RankTitle{{ID: "champion", Name: "Champion", BadgeURL: "https://cdn.example.com/champion.png"}}},
+			{PlayerID: "bob", Position: 1, Value: 5},
+		}, body)
+	})
+}
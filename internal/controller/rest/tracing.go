@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gabapcia/gameblitz/internal/infra/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Reads the trace context of the caller from the request headers, e.g. `traceparent`
+type requestHeaderCarrier struct {
+	header *fasthttp.RequestHeader
+}
+
+func (c requestHeaderCarrier) Get(key string) string {
+	return string(c.header.Peek(key))
+}
+
+func (c requestHeaderCarrier) Set(key, value string) {
+	c.header.Set(key, value)
+}
+
+func (c requestHeaderCarrier) Keys() []string {
+	keys := make([]string, 0)
+	c.header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+
+	return keys
+}
+
+// Starts a span for each request, joining the caller's trace when it sends one. The handlers must call
+// the usecases with `c.UserContext()`, so the storage and broker spans are children of the request span
+func buildTracingMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), requestHeaderCarrier{header: &c.Request().Header})
+		ctx, span := tracing.Tracer().Start(ctx, c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRequestMethodKey.String(c.Method())),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+
+		route, err := nextHandled(c)
+		if err != nil {
+			span.RecordError(err)
+		}
+
+		status := c.Response().StatusCode()
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestBuildTracingMiddleware(t *testing.T) {
+	var (
+		recorder    = tracetest.NewSpanRecorder()
+		provider    = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+		traceparent = "00-" + traceID + "-00f067aa0ba902b7-01"
+		handlerSpan trace.SpanContext
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	app := App(Config{
+		TracingEnabled: true,
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: uuid.NewString()}, nil
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+		},
+	})
+
+	t.Run("Join Caller Trace", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/"+uuid.NewString(), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+		req.Header.Set("traceparent", traceparent)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+
+		span := spans[0]
+		assert.Equal(t, "GET /api/v1/leaderboards/:leaderboardId", span.Name())
+		assert.Equal(t, traceID, span.SpanContext().TraceID().String())
+		assert.True(t, span.Parent().IsRemote())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+
+		// The usecases receive the request span, so the storage and broker spans are its children
+		assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+	})
+}
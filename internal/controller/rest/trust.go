@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/trust"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Trust struct {
+	PlayerID    string    `json:"playerId"`    // Player the trust is of
+	FirstSeenAt time.Time `json:"firstSeenAt"` // Time the player's first submission was checked
+	Flagged     int64     `json:"flagged"`     // Player's submissions held for review
+	Cleared     int64     `json:"cleared"`     // Player's held submissions released
+	Score       float64   `json:"score"`       // Trust score, from 0 to 100
+	Level       string    `json:"level"`       // `LOW`, `NORMAL` or `HIGH`
+}
+
+var (
+	ErrorResponseTrustNotFound      = ErrorResponse{Code: "38.0", Message: "The player has no trust record"}
+	ErrorResponseTrustInvalidPlayer = ErrorResponse{Code: "38.1", Message: "Invalid player id"}
+)
+
+// @summary Get Player Trust
+// @description Get the player's trust score, computed from the time since their first submission checked by the delta rules,
+// @description their submissions held for review and the ones released. The players with a `LOW` trust have stricter delta
+// @description limits and their submissions over them are always held for review, while the ones with a `HIGH` trust skip the
+// @description delta rules. The player has no trust record until their first submission
+// @router /admin/v1/games/{gameId}/players/{playerId}/trust [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param playerId path string true "Player ID"
+// @success 200 {object} Trust
+// @failure 401,403,404,422,500 {object} ErrorResponse
+func buildGetTrustHandler(getFunc trust.GetFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		t, err := getFunc(c.UserContext(), c.Params("gameId"), c.Params("playerId"))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(Trust{
+			PlayerID:    t.PlayerID,
+			FirstSeenAt: t.FirstSeenAt,
+			Flagged:     t.Flagged,
+			Cleared:     t.Cleared,
+			Score:       t.Score,
+			Level:       t.Level,
+		})
+	}
+}
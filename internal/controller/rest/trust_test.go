@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/trust"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildGetTrustHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+		config     = trust.Config{LowThreshold: 30, HighThreshold: 80, LowDeltaFactor: 0.5}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			GetTrustFunc: trust.BuildGetFunc(config, func(ctx context.Context, gameID, playerID string) (trust.Record, error) {
+				return trust.Record{FirstSeenAt: time.Now().UTC(), GameID: gameID, PlayerID: playerID, Flagged: 1}, nil
+			}),
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/players/alice/trust", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body Trust
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 40.0, body.Score)
+		assert.Equal(t, trust.LevelNormal, body.Level)
+		assert.Equal(t, int64(1), body.Flagged)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		app := App(Config{
+			AdminToken: adminToken,
+			GetTrustFunc: trust.BuildGetFunc(config, func(ctx context.Context, gameID, playerID string) (trust.Record, error) {
+				return trust.Record{}, trust.ErrRecordNotFound
+			}),
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/games/"+gameID+"/players/bob/trust", nil)
+
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		var body ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, ErrorResponseTrustNotFound, body)
+	})
+}
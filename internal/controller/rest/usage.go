@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/usage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Routes whose successful `POST` requests submit a player score
+var scoreSubmissionRoutes = []string{
+	"/api/v1/leaderboards/:leaderboardId/ranking/:playerId",
+	"/api/v1/statistics/:statisticId/players/:playerId",
+}
+
+const usageDateLayout = time.DateOnly
+
+type DailyUsage struct {
+	Day              string           `json:"day"`              // Day of the usage, as `YYYY-MM-DD` in UTC
+	Requests         int64            `json:"requests"`         // Requests handled for the game
+	ScoreSubmissions int64            `json:"scoreSubmissions"` // Rank and statistic progression updates, also counted as requests
+	Errors           map[string]int64 `json:"errors,omitempty"` // Requests answered with an error, by its code
+}
+
+type UsageFootprint struct {
+	Records int64 `json:"records"` // Number of stored records
+	Bytes   int64 `json:"bytes"`   // Size of the stored records, in bytes
+}
+
+type UsageReport struct {
+	GameID           string           `json:"gameId"`           // Game the usage belongs to
+	From             string           `json:"from"`             // First day of the period, as `YYYY-MM-DD`
+	To               string           `json:"to"`               // Last day of the period, as `YYYY-MM-DD`
+	Requests         int64            `json:"requests"`         // Requests handled on the period
+	ScoreSubmissions int64            `json:"scoreSubmissions"` // Score submissions handled on the period
+	Errors           map[string]int64 `json:"errors,omitempty"` // Requests answered with an error on the period, by its code
+	Days             []DailyUsage     `json:"days"`             // Usage of each day with any, oldest first
+	Footprint        UsageFootprint   `json:"footprint"`        // Data the game currently keeps on the storage
+}
+
+func usageReportFromDomain(r usage.Report) UsageReport {
+	days := make([]DailyUsage, len(r.Days))
+	for i, d := range r.Days {
+		days[i] = DailyUsage{
+			Day:              d.Day.Format(usageDateLayout),
+			Requests:         d.Requests,
+			ScoreSubmissions: d.ScoreSubmissions,
+			Errors:           d.Errors,
+		}
+	}
+
+	return UsageReport{
+		GameID:           r.GameID,
+		From:             r.From.Format(usageDateLayout),
+		To:               r.To.Format(usageDateLayout),
+		Requests:         r.Requests,
+		ScoreSubmissions: r.ScoreSubmissions,
+		Errors:           r.Errors,
+		Days:             days,
+		Footprint: UsageFootprint{
+			Records: r.Footprint.Records,
+			Bytes:   r.Footprint.Bytes,
+		},
+	}
+}
+
+var (
+	ErrorResponseUsageInvalidPeriod = ErrorResponse{Code: "11.0", Message: "Invalid usage period"}
+)
+
+// Code of the error the request was answered with. Empty when it succeeded or the response isn't an `ErrorResponse`,
+// e.g. an event stream that failed after it started
+func responseErrorCode(c *fiber.Ctx) string {
+	if c.Response().StatusCode() < http.StatusBadRequest {
+		return ""
+	}
+
+	var errorResponse ErrorResponse
+	if err := json.Unmarshal(c.Response().Body(), &errorResponse); err != nil {
+		return ""
+	}
+
+	return errorResponse.Code
+}
+
+// Counts each request of the authenticated game, its successful score submissions and the errors it was answered with
+func buildUsageMiddleware(recordUsageFunc usage.RecordFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		route, _ := nextHandled(c)
+
+		var (
+			claims          = c.Locals("claims").(auth.Claims)
+			scoreSubmission = c.Method() == http.MethodPost &&
+				c.Response().StatusCode() < http.StatusBadRequest &&
+				slices.Contains(scoreSubmissionRoutes, route)
+		)
+
+		recordUsageFunc(claims.GameID, scoreSubmission, responseErrorCode(c))
+		return nil
+	}
+}
+
+// @summary Game Usage
+// @description Report the requests and score submissions of the game on each day of the period, alongside the data it keeps on the storage.
+// @description The usage is written to the storage periodically, so the latest requests may not be reported yet
+// @router /admin/v1/usage/{gameId} [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param gameId path string true "Game ID"
+// @param from query string false "First day of the period, as `YYYY-MM-DD`. Defaults to 29 days before `to`"
+// @param to query string false "Last day of the period, as `YYYY-MM-DD`. Defaults to today"
+// @success 200 {object} UsageReport
+// @failure 401,403,422,500 {object} ErrorResponse
+func buildGetUsageHandler(reportUsageFunc usage.ReportFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		gameID := c.Params("gameId")
+
+		to := usage.Day(time.Now())
+		if v := c.Query("to"); v != "" {
+			t, err := time.Parse(usageDateLayout, v)
+			if err != nil {
+				return usage.ErrInvalidPeriod
+			}
+			to = t
+		}
+
+		from := to.AddDate(0, 0, -29)
+		if v := c.Query("from"); v != "" {
+			t, err := time.Parse(usageDateLayout, v)
+			if err != nil {
+				return usage.ErrInvalidPeriod
+			}
+			from = t
+		}
+
+		report, err := reportUsageFunc(c.UserContext(), gameID, from, to)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(usageReportFromDomain(report))
+	}
+}
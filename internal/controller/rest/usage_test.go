@@ -0,0 +1,183 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/usage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildUsageMiddleware(t *testing.T) {
+	type record struct {
+		gameID          string
+		scoreSubmission bool
+		errorCode       string
+	}
+
+	var (
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+		records       []record
+		upsertErr     error
+	)
+
+	app := App(Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: gameID}, nil
+		},
+		RecordUsageFunc: func(gameID string, scoreSubmission bool, errorCode string) {
+			records = append(records, record{gameID: gameID, scoreSubmission: scoreSubmission, errorCode: errorCode})
+		},
+		GetLeaderboardByIDAndGameIDFunc: func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID, StartAt: time.Now()}, nil
+		},
+		UpsertPlayerRankFunc: func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			return upsertErr
+		},
+	})
+
+	submitScore := func() *http.Response {
+		body, err := json.Marshal(UpsertPlayerRankReq{Value: 10})
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/leaderboards/%s/ranking/%s", leaderboardID, uuid.NewString()), bytes.NewBuffer(body))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	t.Run("Score Submission", func(t *testing.T) {
+		records = nil
+
+		resp := submitScore()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, []record{{gameID: gameID, scoreSubmission: true}}, records)
+	})
+
+	t.Run("Rejected Score Submission", func(t *testing.T) {
+		records, upsertErr = nil, leaderboard.ErrLeaderboardClosed
+		defer func() { upsertErr = nil }()
+
+		resp := submitScore()
+		assert.NotEqual(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, []record{{gameID: gameID, scoreSubmission: false, errorCode: ErrorResponseLeaderboardClosed.Code}}, records)
+	})
+
+	t.Run("Other Requests", func(t *testing.T) {
+		records = nil
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leaderboards/%s", leaderboardID), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []record{{gameID: gameID, scoreSubmission: false}}, records)
+	})
+}
+
+func TestBuildGetUsageHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		gameID     = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var requestedFrom, requestedTo time.Time
+
+		app := App(Config{
+			AdminToken: adminToken,
+			ReportUsageFunc: usage.BuildReportFunc(
+				func(ctx context.Context, gameID string, from, to time.Time) ([]usage.DailyUsage, error) {
+					requestedFrom, requestedTo = from, to
+					return []usage.DailyUsage{{Day: from, GameID: gameID, Requests: 4, ScoreSubmissions: 1, Errors: usage.ErrorCounts{"1.2": 1}}}, nil
+				},
+				func(ctx context.Context, gameID string) (usage.Footprint, error) {
+					return usage.Footprint{Records: 3, Bytes: 1024}, nil
+				},
+			),
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/usage/"+gameID+"?from=2024-03-01&to=2024-03-31", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body UsageReport
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), requestedFrom)
+		assert.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), requestedTo)
+		assert.Equal(t, UsageReport{
+			GameID:           gameID,
+			From:             "2024-03-01",
+			To:               "2024-03-31",
+			Requests:         4,
+			ScoreSubmissions: 1,
+			Errors:           map[string]int64{"1.2": 1},
+			Days:             []DailyUsage{{Day: "2024-03-01", Requests: 4, ScoreSubmissions: 1, Errors: map[string]int64{"1.2": 1}}},
+			Footprint:        UsageFootprint{Records: 3, Bytes: 1024},
+		}, body)
+	})
+
+	t.Run("Default Period", func(t *testing.T) {
+		var requestedFrom, requestedTo time.Time
+
+		app := App(Config{
+			AdminToken: adminToken,
+			ReportUsageFunc: usage.BuildReportFunc(func(ctx context.Context, gameID string, from, to time.Time) ([]usage.DailyUsage, error) {
+				requestedFrom, requestedTo = from, to
+				return nil, nil
+			}, nil),
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/usage/"+gameID, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, usage.Day(time.Now()), requestedTo)
+		assert.Equal(t, requestedTo.AddDate(0, 0, -29), requestedFrom)
+	})
+
+	t.Run("Invalid Period", func(t *testing.T) {
+		app := App(Config{
+			AdminToken:      adminToken,
+			ReportUsageFunc: usage.BuildReportFunc(nil, nil),
+		})
+
+		for _, query := range []string{"?from=invalid", "?to=2024-13-01", "?from=2024-03-31&to=2024-03-01"} {
+			req := httptest.NewRequest(http.MethodGet, "/admin/v1/usage/"+gameID+query, nil)
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+			var data ErrorResponse
+			err = json.NewDecoder(resp.Body).Decode(&data)
+			assert.NoError(t, err)
+			assert.Equal(t, ErrorResponseUsageInvalidPeriod, data)
+		}
+	})
+}
@@ -0,0 +1,306 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Events and resources the webhook subscribes to. An empty list subscribes to all of them.
+// Resource lists only narrow the events of their own kind, e.g. `leaderboardIds` doesn't affect the quest events
+type WebhookFilter struct {
+	Events         []string `json:"events"`         // Event types delivered, e.g. `gameblitz.leaderboard.lifecycle`
+	LeaderboardIDs []string `json:"leaderboardIds"` // Leaderboards whose events are delivered
+	StatisticIDs   []string `json:"statisticIds"`   // Statistics whose events are delivered
+	QuestIDs       []string `json:"questIds"`       // Quests whose events are delivered
+}
+
+type CreateWebhookReq struct {
+	URL    string        `json:"url"`    // Endpoint the events are posted to
+	Filter WebhookFilter `json:"filter"` // Events the webhook receives. Every event when omitted
+}
+
+type Webhook struct {
+	CreatedAt           time.Time     `json:"createdAt"`           // Time the webhook was registered
+	UpdatedAt           time.Time     `json:"updatedAt"`           // Last time the webhook was changed
+	DisabledAt          *time.Time    `json:"disabledAt"`          // Time the webhook was disabled after failing too many times in a row. nil while enabled
+	ID                  string        `json:"id"`                  // Webhook ID
+	GameID              string        `json:"gameId"`              // ID of the game responsible for the webhook
+	URL                 string        `json:"url"`                 // Endpoint the events are posted to
+	Secret              string        `json:"secret"`              // Key of the `X-Gameblitz-Signature` HMAC-SHA256 signature
+	Filter              WebhookFilter `json:"filter"`              // Events the webhook receives
+	ConsecutiveFailures int           `json:"consecutiveFailures"` // Failed attempts since the last successful one
+}
+
+type WebhookAttempt struct {
+	AttemptedAt time.Time `json:"attemptedAt"` // Time the request was sent
+	DurationMS  int64     `json:"durationMs"`  // Time waiting for the response, in milliseconds
+	StatusCode  int       `json:"statusCode"`  // Response status code. Zero when no response was received
+	Error       string    `json:"error"`       // Why the attempt failed. Empty when it succeeded
+}
+
+type WebhookDelivery struct {
+	CreatedAt     time.Time        `json:"createdAt"`                               // Time the delivery was queued
+	NextAttemptAt *time.Time       `json:"nextAttemptAt"`                           // Time the delivery is due. nil once it is no longer pending
+	ID            string           `json:"id"`                                      // Delivery ID, sent on the `X-Gameblitz-Delivery` header
+	WebhookID     string           `json:"webhookId"`                               // Webhook the event is sent to
+	GameID        string           `json:"gameId"`                                  // Game the event belongs to
+	Event         string           `json:"event"`                                   // Event type, sent on the `X-Gameblitz-Event` header
+	Payload       string           `json:"payload"`                                 // Request body
+	Status        string           `json:"status" enums:"PENDING,SUCCEEDED,FAILED"` // Delivery status
+	Attempts      []WebhookAttempt `json:"attempts"`                                // Requests sent, oldest first
+}
+
+func (f WebhookFilter) toDomain() webhook.Filter {
+	return webhook.Filter{
+		Events:         f.Events,
+		LeaderboardIDs: f.LeaderboardIDs,
+		StatisticIDs:   f.StatisticIDs,
+		QuestIDs:       f.QuestIDs,
+	}
+}
+
+// Lists are never sent as null, so an empty one reads as subscribing to everything
+func webhookFilterFromDomain(f webhook.Filter) WebhookFilter {
+	nonNil := func(s []string) []string {
+		if s == nil {
+			return make([]string, 0)
+		}
+
+		return s
+	}
+
+	return WebhookFilter{
+		Events:         nonNil(f.Events),
+		LeaderboardIDs: nonNil(f.LeaderboardIDs),
+		StatisticIDs:   nonNil(f.StatisticIDs),
+		QuestIDs:       nonNil(f.QuestIDs),
+	}
+}
+
+func (r CreateWebhookReq) toDomain(gameID string) webhook.NewWebhookData {
+	return webhook.NewWebhookData{
+		GameID: gameID,
+		URL:    r.URL,
+		Filter: r.Filter.toDomain(),
+	}
+}
+
+func webhookFromDomain(w webhook.Webhook) Webhook {
+	var disabledAt *time.Time
+	if !w.DisabledAt.IsZero() {
+		disabledAt = &w.DisabledAt
+	}
+
+	return Webhook{
+		CreatedAt:           w.CreatedAt,
+		UpdatedAt:           w.UpdatedAt,
+		DisabledAt:          disabledAt,
+		ID:                  w.ID,
+		GameID:              w.GameID,
+		URL:                 w.URL,
+		Secret:              w.Secret,
+		Filter:              webhookFilterFromDomain(w.Filter),
+		ConsecutiveFailures: w.ConsecutiveFailures,
+	}
+}
+
+func webhookDeliveryFromDomain(d webhook.Delivery) WebhookDelivery {
+	var nextAttemptAt *time.Time
+	if d.Status == webhook.DeliveryStatusPending {
+		nextAttemptAt = &d.NextAttemptAt
+	}
+
+	attempts := make([]WebhookAttempt, len(d.Attempts))
+	for i, a := range d.Attempts {
+		attempts[i] = WebhookAttempt{
+			AttemptedAt: a.AttemptedAt,
+			DurationMS:  a.Duration.Milliseconds(),
+			StatusCode:  a.StatusCode,
+			Error:       a.Error,
+		}
+	}
+
+	return WebhookDelivery{
+		CreatedAt:     d.CreatedAt,
+		NextAttemptAt: nextAttemptAt,
+		ID:            d.ID,
+		WebhookID:     d.WebhookID,
+		GameID:        d.GameID,
+		Event:         d.Event,
+		Payload:       string(d.Payload),
+		Status:        d.Status,
+		Attempts:      attempts,
+	}
+}
+
+var (
+	ErrorResponseWebhookInvalidID     = ErrorResponse{Code: "10.0", Message: "Invalid webhook id"}
+	ErrorResponseWebhookNotFound      = ErrorResponse{Code: "10.1", Message: "Webhook not found"}
+	ErrorResponseWebhookInvalidURL    = ErrorResponse{Code: "10.2", Message: "Invalid webhook url"}
+	ErrorResponseWebhookDeliveryLimit = ErrorResponse{Code: "10.3", Message: "Invalid webhook deliveries limit"}
+	ErrorResponseWebhookInvalidFilter = ErrorResponse{Code: "10.4", Message: "Invalid webhook filter"}
+)
+
+// @summary Create Webhook
+// @description Register an endpoint that receives the game events. Each delivery is signed with the returned secret
+// @router /api/v1/webhooks [POST]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param NewWebhookData body CreateWebhookReq true "New webhook data"
+// @success 201 {object} Webhook
+// @failure 400,422,500 {object} ErrorResponse
+func buildCreateWebhookHandler(createWebhookFunc webhook.CreateWebhookFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		var body CreateWebhookReq
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		webhook, err := createWebhookFunc(c.UserContext(), body.toDomain(claims.GameID))
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusCreated).JSON(webhookFromDomain(webhook))
+	}
+}
+
+// @summary List Webhooks
+// @description List the webhooks of the game
+// @router /api/v1/webhooks [GET]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @success 200 {array} Webhook
+// @failure 500 {object} ErrorResponse
+func buildListWebhooksHandler(listWebhooksFunc webhook.ListWebhooksFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := c.Locals("claims").(auth.Claims)
+
+		webhooks, err := listWebhooksFunc(c.UserContext(), claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		res := make([]Webhook, len(webhooks))
+		for i, w := range webhooks {
+			res[i] = webhookFromDomain(w)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
+
+// @summary Delete Webhook
+// @description Delete a webhook by its id alongside its deliveries
+// @router /api/v1/webhooks/{webhookId} [DELETE]
+// @param Authorization header string true "Game's JWT authorization"
+// @param webhookId path string true "Webhook ID"
+// @success 204
+// @failure 404,422,500 {object} ErrorResponse
+func buildDeleteWebhookHandler(deleteWebhookFunc webhook.DeleteWebhookFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var (
+			webhookID = c.Params("webhookId")
+			claims    = c.Locals("claims").(auth.Claims)
+		)
+
+		if err := deleteWebhookFunc(c.UserContext(), webhookID, claims.GameID); err != nil {
+			return err
+		}
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+}
+
+// @summary Enable Webhook
+// @description Enable a webhook disabled after failing too many times in a row
+// @router /api/v1/webhooks/{webhookId}/enable [POST]
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param webhookId path string true "Webhook ID"
+// @success 200 {object} Webhook
+// @failure 404,422,500 {object} ErrorResponse
+func buildEnableWebhookHandler(enableWebhookFunc webhook.EnableWebhookFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var (
+			webhookID = c.Params("webhookId")
+			claims    = c.Locals("claims").(auth.Claims)
+		)
+
+		webhook, err := enableWebhookFunc(c.UserContext(), webhookID, claims.GameID)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(webhookFromDomain(webhook))
+	}
+}
+
+// @summary Update Webhook Filter
+// @description Replace the events and resources the webhook subscribes to. Events not matching it are not queued to the webhook
+// @router /api/v1/webhooks/{webhookId}/filter [PUT]
+// @accept json
+// @produce json
+// @param Authorization header string true "Game's JWT authorization"
+// @param webhookId path string true "Webhook ID"
+// @param WebhookFilter body WebhookFilter true "New webhook filter"
+// @success 200 {object} Webhook
+// @failure 400,404,422,500 {object} ErrorResponse
+func buildUpdateWebhookFilterHandler(updateWebhookFilterFunc webhook.UpdateWebhookFilterFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var (
+			webhookID = c.Params("webhookId")
+			claims    = c.Locals("claims").(auth.Claims)
+		)
+
+		var body WebhookFilter
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+
+		webhook, err := updateWebhookFilterFunc(c.UserContext(), webhookID, claims.GameID, body.toDomain())
+		if err != nil {
+			return err
+		}
+
+		return c.Status(http.StatusOK).JSON(webhookFromDomain(webhook))
+	}
+}
+
+// @summary List Webhook Deliveries
+// @description List the deliveries of the webhook and their attempts, newest first
+// @router /admin/v1/webhooks/{webhookId}/deliveries [GET]
+// @produce json
+// @param Authorization header string true "Admin token, as `Bearer <token>`"
+// @param webhookId path string true "Webhook ID"
+// @param limit query int false "Max number of deliveries" minimun(1) default(100)
+// @success 200 {array} WebhookDelivery
+// @failure 401,403,422,500 {object} ErrorResponse
+func buildListWebhookDeliveriesHandler(listDeliveriesFunc webhook.ListDeliveriesFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var (
+			webhookID = c.Params("webhookId")
+			limit     = c.QueryInt("limit", 100)
+		)
+
+		deliveries, err := listDeliveriesFunc(c.UserContext(), webhookID, limit)
+		if err != nil {
+			return err
+		}
+
+		res := make([]WebhookDelivery, len(deliveries))
+		for i, d := range deliveries {
+			res[i] = webhookDeliveryFromDomain(d)
+		}
+
+		return c.Status(http.StatusOK).JSON(res)
+	}
+}
@@ -0,0 +1,281 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/auth"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWebhookTestConfig(gameID *string) Config {
+	return Config{
+		AuthenticateFunc: func(ctx context.Context, credentials string) (auth.Claims, error) {
+			return auth.Claims{GameID: *gameID}, nil
+		},
+		CreateWebhookFunc: func(ctx context.Context, data webhook.NewWebhookData) (webhook.Webhook, error) {
+			if data.URL == "" {
+				return webhook.Webhook{}, webhook.ErrInvalidWebhookURL
+			}
+
+			return webhook.Webhook{ID: uuid.NewString(), GameID: data.GameID, URL: data.URL, Secret: "secret", Filter: data.Filter}, nil
+		},
+		ListWebhooksFunc: func(ctx context.Context, gameID string) ([]webhook.Webhook, error) {
+			return []webhook.Webhook{{ID: uuid.NewString(), GameID: gameID, DisabledAt: time.Now()}}, nil
+		},
+		DeleteWebhookFunc: func(ctx context.Context, id, gameID string) error {
+			return webhook.ErrWebhookNotFound
+		},
+		EnableWebhookFunc: func(ctx context.Context, id, gameID string) (webhook.Webhook, error) {
+			return webhook.Webhook{ID: id, GameID: gameID}, nil
+		},
+		UpdateWebhookFilterFunc: webhook.BuildUpdateWebhookFilterFunc(
+			[]string{"gameblitz.leaderboard.lifecycle"},
+			func(ctx context.Context, id, gameID string, filter webhook.Filter) (webhook.Webhook, error) {
+				return webhook.Webhook{ID: id, GameID: gameID, Filter: filter}, nil
+			},
+		),
+	}
+}
+
+func TestBuildCreateWebhookHandler(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		gameID := uuid.NewString()
+		app := App(newWebhookTestConfig(&gameID))
+
+		body, err := json.Marshal(CreateWebhookReq{URL: "https://example.com"})
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewBuffer(body))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var data Webhook
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+
+		assert.Equal(t, gameID, data.GameID)
+		assert.Equal(t, "secret", data.Secret)
+		assert.Nil(t, data.DisabledAt)
+		assert.Equal(t, WebhookFilter{Events: []string{}, LeaderboardIDs: []string{}, StatisticIDs: []string{}, QuestIDs: []string{}}, data.Filter)
+	})
+
+	t.Run("Invalid URL", func(t *testing.T) {
+		gameID := uuid.NewString()
+		app := App(newWebhookTestConfig(&gameID))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewBufferString(`{"url":""}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrorResponseWebhookInvalidURL, data)
+	})
+}
+
+func TestBuildListWebhooksHandler(t *testing.T) {
+	t.Run("Not Cached Across Games", func(t *testing.T) {
+		gameID := uuid.NewString()
+		app := App(newWebhookTestConfig(&gameID))
+
+		for _, id := range []string{uuid.NewString(), uuid.NewString()} {
+			gameID = id
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks", nil)
+			req.Header.Set("Authorization", uuid.NewString())
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var data []Webhook
+			err = json.NewDecoder(resp.Body).Decode(&data)
+			assert.NoError(t, err)
+			assert.Len(t, data, 1)
+			assert.Equal(t, id, data[0].GameID)
+			assert.NotNil(t, data[0].DisabledAt)
+		}
+	})
+}
+
+func TestBuildDeleteWebhookHandler(t *testing.T) {
+	t.Run("Not Found", func(t *testing.T) {
+		gameID := uuid.NewString()
+		app := App(newWebhookTestConfig(&gameID))
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/webhooks/"+uuid.NewString(), nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBuildEnableWebhookHandler(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		var (
+			gameID    = uuid.NewString()
+			webhookID = uuid.NewString()
+			app       = App(newWebhookTestConfig(&gameID))
+		)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/"+webhookID+"/enable", nil)
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var data Webhook
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+		assert.Equal(t, webhookID, data.ID)
+	})
+}
+
+func TestBuildUpdateWebhookFilterHandler(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		var (
+			gameID    = uuid.NewString()
+			webhookID = uuid.NewString()
+			filter    = WebhookFilter{Events: []string{"gameblitz.leaderboard.lifecycle"}, LeaderboardIDs: []string{uuid.NewString()}, StatisticIDs: []string{}, QuestIDs: []string{}}
+			recorded  audit.NewEntryData
+		)
+
+		config := newWebhookTestConfig(&gameID)
+		config.RecordAuditEntryFunc = func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+			recorded = data
+			return audit.Entry{ID: uuid.NewString()}, nil
+		}
+		app := App(config)
+
+		body, err := json.Marshal(filter)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/webhooks/"+webhookID+"/filter", bytes.NewBuffer(body))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var data Webhook
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+		assert.Equal(t, filter, data.Filter)
+
+		assert.Equal(t, http.MethodPut, recorded.Method)
+		assert.Equal(t, "/api/v1/webhooks/:webhookId/filter", recorded.Route)
+		assert.Equal(t, map[string]string{"webhookId": webhookID}, recorded.ResourceIDs)
+		assert.Equal(t, http.StatusOK, recorded.StatusCode)
+	})
+
+	t.Run("Invalid Filter", func(t *testing.T) {
+		gameID := uuid.NewString()
+		app := App(newWebhookTestConfig(&gameID))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/webhooks/"+uuid.NewString()+"/filter", bytes.NewBufferString(`{"events":["unknown"]}`))
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", uuid.NewString())
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrorResponseWebhookInvalidFilter, data)
+	})
+}
+
+func TestBuildListWebhookDeliveriesHandler(t *testing.T) {
+	var (
+		adminToken = uuid.NewString()
+		webhookID  = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var requestedLimit int
+
+		app := App(Config{
+			AdminToken: adminToken,
+			ListWebhookDeliveriesFunc: func(ctx context.Context, id string, limit int) ([]webhook.Delivery, error) {
+				requestedLimit = limit
+				return []webhook.Delivery{
+					{
+						ID:            uuid.NewString(),
+						WebhookID:     id,
+						Payload:       []byte(`{}`),
+						Status:        webhook.DeliveryStatusPending,
+						NextAttemptAt: time.Now(),
+						Attempts:      []webhook.Attempt{{StatusCode: 500, Duration: 2 * time.Second, Error: "any error"}},
+					},
+					{ID: uuid.NewString(), WebhookID: id, Status: webhook.DeliveryStatusSucceeded},
+				}, nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/webhooks/"+webhookID+"/deliveries?limit=10", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body []WebhookDelivery
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 10, requestedLimit)
+		assert.Len(t, body, 2)
+		assert.Equal(t, webhookID, body[0].WebhookID)
+		assert.NotNil(t, body[0].NextAttemptAt)
+		assert.Equal(t, int64(2000), body[0].Attempts[0].DurationMS)
+		assert.Nil(t, body[1].NextAttemptAt)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		app := App(Config{
+			AdminToken:                adminToken,
+			ListWebhookDeliveriesFunc: webhook.BuildListDeliveriesFunc(nil),
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/v1/webhooks/"+webhookID+"/deliveries?limit=0", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var data ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		assert.NoError(t, err)
+		assert.Equal(t, ErrorResponseWebhookDeliveryLimit, data)
+	})
+}
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+)
+
+const (
+	webSocketPingInterval = 30 * time.Second
+	webSocketPongWait     = 60 * time.Second
+	webSocketWriteWait    = 10 * time.Second
+)
+
+// Keeps the connection alive while `watch` writes the messages, then closes it. The `watch` error is returned only
+// when the client was still connected, as a gone client makes every write fail
+func serveWebSocket(ctx context.Context, conn *websocket.Conn, watch func(ctx context.Context, write func(msg any) error) error) error {
+	// The wrapper and the hijacked connection are released once the handler returns, so every goroutine must be done by then
+	ws := conn.Conn
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Clients aren't expected to send anything but control frames, reading is how a gone client is detected
+	ws.SetReadDeadline(time.Now().Add(webSocketPongWait))
+	ws.SetPongHandler(func(string) error {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		return ws.SetReadDeadline(time.Now().Add(webSocketPongWait))
+	})
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer cancel()
+
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(webSocketPingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(webSocketWriteWait)); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	err := watch(ctx, func(msg any) error {
+		ws.SetWriteDeadline(time.Now().Add(webSocketWriteWait))
+		return ws.WriteJSON(msg)
+	})
+
+	closeCode := websocket.CloseNormalClosure
+	if err != nil && ctx.Err() == nil {
+		closeCode = websocket.CloseInternalServerErr
+	} else {
+		err = nil
+	}
+
+	// The client has until the read deadline to acknowledge the close, which also stops the reader
+	cancel()
+	ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, ""), time.Now().Add(webSocketWriteWait))
+	ws.SetReadDeadline(time.Now().Add(webSocketWriteWait))
+
+	return err
+}
@@ -0,0 +1,28 @@
+package datachange
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	EntityStatistic = "STATISTIC"
+
+	OperationCreated = "CREATED"
+	OperationUpdated = "UPDATED"
+	OperationDeleted = "DELETED"
+)
+
+type Event struct {
+	OccurredAt time.Time // Time the change was applied on the storage
+	Entity     string    // Kind of the entity changed
+	EntityID   string    // ID of the entity changed
+	GameID     string    // ID of the game that owns the entity. Empty when unknown, e.g. on hard deletes
+	Operation  string    // Change operation
+}
+
+func BuildWatchFunc(storageWatchChangesFunc StorageWatchChangesFunc, notifierChangeFunc NotifierChangeFunc) WatchFunc {
+	return func(ctx context.Context) error {
+		return storageWatchChangesFunc(ctx, ChangeHandlerFunc(notifierChangeFunc))
+	}
+}
@@ -0,0 +1,57 @@
+package datachange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWatchFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		event = Event{
+			Entity:    EntityStatistic,
+			EntityID:  uuid.NewString(),
+			GameID:    uuid.NewString(),
+			Operation: OperationCreated,
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		notified := make([]Event, 0)
+
+		watchFunc := BuildWatchFunc(
+			func(ctx context.Context, handler ChangeHandlerFunc) error {
+				return handler(ctx, event)
+			},
+			func(ctx context.Context, event Event) error {
+				notified = append(notified, event)
+				return nil
+			},
+		)
+
+		err := watchFunc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []Event{event}, notified)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		errNotifier := errors.New("any error")
+
+		watchFunc := BuildWatchFunc(
+			func(ctx context.Context, handler ChangeHandlerFunc) error {
+				return handler(ctx, event)
+			},
+			func(ctx context.Context, event Event) error {
+				return errNotifier
+			},
+		)
+
+		err := watchFunc(ctx)
+		assert.ErrorIs(t, err, errNotifier)
+	})
+}
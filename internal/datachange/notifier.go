@@ -0,0 +1,8 @@
+package datachange
+
+import "context"
+
+type (
+	// Notify a data change
+	NotifierChangeFunc func(ctx context.Context, event Event) error
+)
@@ -0,0 +1,12 @@
+package datachange
+
+import "context"
+
+type (
+	// Function called for every change detected on the storage.
+	// The change is only acknowledged when it returns no error
+	ChangeHandlerFunc func(ctx context.Context, event Event) error
+
+	// Tails the storage changes until the context is canceled or an error happens
+	StorageWatchChangesFunc func(ctx context.Context, handler ChangeHandlerFunc) error
+)
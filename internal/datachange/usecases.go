@@ -0,0 +1,8 @@
+package datachange
+
+import "context"
+
+type (
+	// Emits every storage change as a domain event until the context is canceled or an error happens
+	WatchFunc func(ctx context.Context) error
+)
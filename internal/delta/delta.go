@@ -0,0 +1,240 @@
+package delta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+	"github.com/gabapcia/gameblitz/internal/trust"
+)
+
+const (
+	ActionReject     = "REJECT"     // The submission fails with `ErrDeltaExceeded`
+	ActionQuarantine = "QUARANTINE" // The submission is held for review
+
+	ReasonMaxSubmissionDelta = "MAX_SUBMISSION_DELTA" // The submission improves the player's value by more than the rule allows at once
+	ReasonMaxHourlyDelta     = "MAX_HOURLY_DELTA"     // The submission takes the player's improvement within the last hour past the rule's limit
+
+	// Window of the hourly limit
+	HourlyWindow = time.Hour
+)
+
+var Actions = []string{
+	ActionReject,
+	ActionQuarantine,
+}
+
+var (
+	ErrValidationError      = errors.New("validation error")
+	ErrInvalidGameID        = errors.New("invalid game id")
+	ErrInvalidLeaderboardID = errors.New("invalid leaderboard id")
+	ErrInvalidLimit         = errors.New("the maximum deltas must not be negative")
+	ErrMissingLimit         = errors.New("a rule requires a maximum delta per submission or per hour")
+	ErrInvalidAction        = errors.New("action must be one of REJECT or QUARANTINE")
+	ErrRuleNotFound         = errors.New("delta rule not found")
+	ErrDeltaExceeded        = errors.New("the submission exceeds the leaderboard's maximum delta")
+)
+
+type (
+	// Maximum plausible improvement of a player's value on a leaderboard
+	Rule struct {
+		UpdatedAt          time.Time // Last time the rule was set
+		GameID             string    // Game the leaderboard belongs to
+		LeaderboardID      string    // Leaderboard the rule applies to
+		MaxSubmissionDelta float64   // Most a single submission can improve the player's value. Zero for no limit
+		MaxHourlyDelta     float64   // Most the player's value can improve within an hour. Zero for no limit
+		Action             string    // What happens to the submissions over the limits, one of `REJECT` or `QUARANTINE`
+	}
+
+	// Player's value improvement applied to a leaderboard
+	Improvement struct {
+		AppliedAt     time.Time // Time the submission was applied
+		GameID        string    // Game the leaderboard belongs to
+		LeaderboardID string    // Leaderboard the value was submitted to
+		PlayerID      string    // Player who submitted the value
+		Delta         float64   // How much the submission improved the player's value
+	}
+)
+
+func (r Rule) validate() error {
+	errList := make([]error, 0)
+
+	if r.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if r.LeaderboardID == "" {
+		errList = append(errList, ErrInvalidLeaderboardID)
+	}
+
+	if r.MaxSubmissionDelta < 0 || r.MaxHourlyDelta < 0 {
+		errList = append(errList, ErrInvalidLimit)
+	} else if r.MaxSubmissionDelta == 0 && r.MaxHourlyDelta == 0 {
+		errList = append(errList, ErrMissingLimit)
+	}
+
+	if !slices.Contains(Actions, r.Action) {
+		errList = append(errList, ErrInvalidAction)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// How much the value improves the player's current one on the leaderboard, never negative. Increments improve by
+// themselves, and a first value on a `MAX` leaderboard is measured from zero, while on a `MIN` one it improves nothing,
+// as there's nothing to measure it from
+func improvement(lb leaderboard.Leaderboard, current *float64, value float64) float64 {
+	var delta float64
+	switch lb.AggregationMode {
+	case leaderboard.AggregationModeInc:
+		delta = value
+	case leaderboard.AggregationModeMax:
+		if current == nil {
+			delta = value
+		} else {
+			delta = value - *current
+		}
+	case leaderboard.AggregationModeMin:
+		if current != nil {
+			delta = *current - value
+		}
+	}
+
+	return math.Max(delta, 0)
+}
+
+// The leaderboard must belong to the game
+func BuildSetRuleFunc(getLeaderboardFunc leaderboard.GetByIDAndGameIDFunc, storageSetRuleFunc StorageSetRuleFunc) SetRuleFunc {
+	return func(ctx context.Context, rule Rule) (Rule, error) {
+		if err := rule.validate(); err != nil {
+			return Rule{}, err
+		}
+
+		if _, err := getLeaderboardFunc(ctx, rule.LeaderboardID, rule.GameID); err != nil {
+			return Rule{}, err
+		}
+
+		rule.UpdatedAt = time.Now().UTC()
+		return storageSetRuleFunc(ctx, rule)
+	}
+}
+
+func BuildGetRuleFunc(storageGetRuleFunc StorageGetRuleFunc) GetRuleFunc {
+	return func(ctx context.Context, gameID, leaderboardID string) (Rule, error) {
+		if leaderboardID == "" {
+			return Rule{}, ErrInvalidLeaderboardID
+		}
+
+		return storageGetRuleFunc(ctx, gameID, leaderboardID)
+	}
+}
+
+func BuildRemoveRuleFunc(storageDeleteRuleFunc StorageDeleteRuleFunc) RemoveRuleFunc {
+	return func(ctx context.Context, gameID, leaderboardID string) error {
+		if leaderboardID == "" {
+			return ErrInvalidLeaderboardID
+		}
+
+		return storageDeleteRuleFunc(ctx, gameID, leaderboardID)
+	}
+}
+
+// Wraps the rank update to check the submissions against the leaderboard's rule. The ones over its limits are rejected
+// with `ErrDeltaExceeded` or held for review, failing with `quarantine.ErrSubmissionQuarantined`, depending on its action.
+// The improvements applied are recorded to check the hourly limit, so the leaderboards without a rule cost a single read.
+// When the player's trust was assessed, the `HIGH` players skip the rule, while the `LOW` ones have its limits scaled
+// down and their submissions over them are always held for review
+func BuildEnforceFunc(
+	storageGetRuleFunc StorageGetRuleFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageSumImprovementsFunc StorageSumImprovementsFunc,
+	storageRecordImprovementFunc StorageRecordImprovementFunc,
+	holdFunc quarantine.HoldFunc,
+	next leaderboard.StorageUpsertPlayerRankValueFunc,
+) leaderboard.StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		assessment, assessed := trust.AssessmentFromContext(ctx)
+		if assessed && assessment.Level == trust.LevelHigh {
+			return next(ctx, lb, playerID, value)
+		}
+
+		rule, err := storageGetRuleFunc(ctx, lb.GameID, lb.ID)
+		if err != nil {
+			if errors.Is(err, ErrRuleNotFound) {
+				return next(ctx, lb, playerID, value)
+			}
+
+			return err
+		}
+
+		if assessed && assessment.Level == trust.LevelLow {
+			rule.MaxSubmissionDelta *= assessment.DeltaFactor
+			rule.MaxHourlyDelta *= assessment.DeltaFactor
+			rule.Action = ActionQuarantine
+		}
+
+		var current *float64
+		rank, err := storageGetPlayerRankFunc(ctx, lb, playerID)
+		switch {
+		case err == nil:
+			current = &rank.Value
+		case !errors.Is(err, leaderboard.ErrPlayerRankNotFound):
+			return err
+		}
+
+		var (
+			now    = time.Now().UTC()
+			delta  = improvement(lb, current, value)
+			reason string
+		)
+		if rule.MaxSubmissionDelta > 0 && delta > rule.MaxSubmissionDelta {
+			reason = ReasonMaxSubmissionDelta
+		} else if rule.MaxHourlyDelta > 0 && delta > 0 {
+			applied, err := storageSumImprovementsFunc(ctx, lb.GameID, lb.ID, playerID, now.Add(-HourlyWindow))
+			if err != nil {
+				return err
+			}
+
+			if applied+delta > rule.MaxHourlyDelta {
+				reason = ReasonMaxHourlyDelta
+			}
+		}
+
+		if reason != "" {
+			if rule.Action == ActionReject {
+				return fmt.Errorf("%w: %s", ErrDeltaExceeded, reason)
+			}
+
+			if _, err := holdFunc(ctx, lb, playerID, value, reason, ""); err != nil {
+				return err
+			}
+
+			return quarantine.ErrSubmissionQuarantined
+		}
+
+		if err := next(ctx, lb, playerID, value); err != nil {
+			return err
+		}
+
+		if rule.MaxHourlyDelta == 0 || delta == 0 {
+			return nil
+		}
+
+		return storageRecordImprovementFunc(ctx, Improvement{
+			AppliedAt:     now,
+			GameID:        lb.GameID,
+			LeaderboardID: lb.ID,
+			PlayerID:      playerID,
+			Delta:         delta,
+		})
+	}
+}
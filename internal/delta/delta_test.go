@@ -0,0 +1,206 @@
+package delta
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+	"github.com/gabapcia/gameblitz/internal/trust"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImprovement(t *testing.T) {
+	var (
+		incLb   = leaderboard.Leaderboard{AggregationMode: leaderboard.AggregationModeInc}
+		maxLb   = leaderboard.Leaderboard{AggregationMode: leaderboard.AggregationModeMax}
+		minLb   = leaderboard.Leaderboard{AggregationMode: leaderboard.AggregationModeMin}
+		current = 100.0
+	)
+
+	assert.Equal(t, 10.0, improvement(incLb, &current, 10))
+	assert.Equal(t, 0.0, improvement(incLb, &current, -10))
+	assert.Equal(t, 50.0, improvement(maxLb, &current, 150))
+	assert.Equal(t, 0.0, improvement(maxLb, &current, 50))
+	assert.Equal(t, 150.0, improvement(maxLb, nil, 150))
+	assert.Equal(t, 40.0, improvement(minLb, &current, 60))
+	assert.Equal(t, 0.0, improvement(minLb, &current, 160))
+	assert.Equal(t, 0.0, improvement(minLb, nil, 60))
+}
+
+func TestBuildSetRuleFunc(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		getLeaderboard = func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			if id != "leaderboard" {
+				return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+			}
+
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		}
+		setRule = BuildSetRuleFunc(getLeaderboard, func(ctx context.Context, rule Rule) (Rule, error) {
+			return rule, nil
+		})
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		rule, err := setRule(ctx, Rule{GameID: "game", LeaderboardID: "leaderboard", MaxSubmissionDelta: 100, Action: ActionQuarantine})
+		assert.NoError(t, err)
+		assert.False(t, rule.UpdatedAt.IsZero())
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := setRule(ctx, Rule{GameID: "game", LeaderboardID: "leaderboard", Action: "WARN"})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrMissingLimit)
+		assert.ErrorIs(t, err, ErrInvalidAction)
+
+		_, err = setRule(ctx, Rule{GameID: "game", LeaderboardID: "leaderboard", MaxHourlyDelta: -1, Action: ActionReject})
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+	})
+
+	t.Run("Leaderboard Not Found", func(t *testing.T) {
+		_, err := setRule(ctx, Rule{GameID: "game", LeaderboardID: "other", MaxHourlyDelta: 100, Action: ActionReject})
+		assert.ErrorIs(t, err, leaderboard.ErrLeaderboardNotFound)
+	})
+}
+
+func TestBuildEnforceFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		lb           = leaderboard.Leaderboard{ID: "leaderboard", GameID: "game", AggregationMode: leaderboard.AggregationModeMax}
+		rule         Rule
+		ranks        map[string]float64
+		improvements []Improvement
+		held         []quarantine.Submission
+	)
+
+	reset := func(r Rule) {
+		rule = r
+		ranks = map[string]float64{"alice": 100}
+		improvements = nil
+		held = nil
+	}
+
+	enforce := BuildEnforceFunc(
+		func(ctx context.Context, gameID, leaderboardID string) (Rule, error) {
+			if rule.Action == "" {
+				return Rule{}, ErrRuleNotFound
+			}
+
+			return rule, nil
+		},
+		func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			value, ok := ranks[playerID]
+			if !ok {
+				return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+			}
+
+			return leaderboard.Rank{PlayerID: playerID, Value: value}, nil
+		},
+		func(ctx context.Context, gameID, leaderboardID, playerID string, since time.Time) (float64, error) {
+			var sum float64
+			for _, i := range improvements {
+				if i.PlayerID == playerID && i.AppliedAt.After(since) {
+					sum += i.Delta
+				}
+			}
+
+			return sum, nil
+		},
+		func(ctx context.Context, i Improvement) error {
+			improvements = append(improvements, i)
+			return nil
+		},
+		func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64, reason, proof string) (quarantine.Submission, error) {
+			s := quarantine.Submission{PlayerID: playerID, Value: value, Reason: reason}
+			held = append(held, s)
+			return s, nil
+		},
+		func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			ranks[playerID] = max(ranks[playerID], value)
+			return nil
+		},
+	)
+
+	t.Run("Without Rule", func(t *testing.T) {
+		reset(Rule{})
+
+		err := enforce(ctx, lb, "alice", 1_000_000)
+		assert.NoError(t, err)
+		assert.Equal(t, 1_000_000.0, ranks["alice"])
+	})
+
+	t.Run("Reject Submission Delta", func(t *testing.T) {
+		reset(Rule{MaxSubmissionDelta: 50, Action: ActionReject})
+
+		err := enforce(ctx, lb, "alice", 151)
+		assert.ErrorIs(t, err, ErrDeltaExceeded)
+		assert.ErrorContains(t, err, ReasonMaxSubmissionDelta)
+		assert.Equal(t, 100.0, ranks["alice"])
+
+		err = enforce(ctx, lb, "alice", 150)
+		assert.NoError(t, err)
+		assert.Equal(t, 150.0, ranks["alice"])
+		assert.Empty(t, improvements)
+	})
+
+	t.Run("Quarantine Hourly Delta", func(t *testing.T) {
+		reset(Rule{MaxHourlyDelta: 50, Action: ActionQuarantine})
+
+		err := enforce(ctx, lb, "alice", 130)
+		assert.NoError(t, err)
+
+		err = enforce(ctx, lb, "alice", 130)
+		assert.NoError(t, err)
+		assert.Len(t, improvements, 1)
+
+		err = enforce(ctx, lb, "alice", 160)
+		assert.ErrorIs(t, err, quarantine.ErrSubmissionQuarantined)
+		assert.Equal(t, []quarantine.Submission{{PlayerID: "alice", Value: 160, Reason: ReasonMaxHourlyDelta}}, held)
+		assert.Equal(t, 130.0, ranks["alice"])
+
+		err = enforce(ctx, lb, "alice", 150)
+		assert.NoError(t, err)
+		assert.Equal(t, 150.0, ranks["alice"])
+	})
+
+	t.Run("Low Trust", func(t *testing.T) {
+		reset(Rule{MaxSubmissionDelta: 50, Action: ActionReject})
+		ctx := trust.WithAssessment(ctx, trust.Assessment{Level: trust.LevelLow, DeltaFactor: 0.5})
+
+		err := enforce(ctx, lb, "alice", 130)
+		assert.ErrorIs(t, err, quarantine.ErrSubmissionQuarantined)
+		assert.Equal(t, []quarantine.Submission{{PlayerID: "alice", Value: 130, Reason: ReasonMaxSubmissionDelta}}, held)
+
+		err = enforce(ctx, lb, "alice", 125)
+		assert.NoError(t, err)
+		assert.Equal(t, 125.0, ranks["alice"])
+	})
+
+	t.Run("High Trust", func(t *testing.T) {
+		reset(Rule{MaxSubmissionDelta: 50, Action: ActionReject})
+		ctx := trust.WithAssessment(ctx, trust.Assessment{Level: trust.LevelHigh, DeltaFactor: 1})
+
+		err := enforce(ctx, lb, "alice", 1_000)
+		assert.NoError(t, err)
+		assert.Equal(t, 1_000.0, ranks["alice"])
+		assert.Empty(t, held)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		storageErr := errors.New("any storage error")
+		enforce := BuildEnforceFunc(
+			func(ctx context.Context, gameID, leaderboardID string) (Rule, error) {
+				return Rule{}, storageErr
+			},
+			nil, nil, nil, nil, nil,
+		)
+
+		err := enforce(ctx, lb, "alice", 100)
+		assert.ErrorIs(t, err, storageErr)
+	})
+}
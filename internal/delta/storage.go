@@ -0,0 +1,28 @@
+package delta
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type (
+	// Stores the rule, replacing the one the leaderboard already has
+	StorageSetRuleFunc func(ctx context.Context, rule Rule) (Rule, error)
+
+	// Rule of a leaderboard of the game. Fails with `ErrRuleNotFound` when it has none
+	StorageGetRuleFunc func(ctx context.Context, gameID, leaderboardID string) (Rule, error)
+
+	// Removes the rule of a leaderboard of the game. Fails with `ErrRuleNotFound` when it has none
+	StorageDeleteRuleFunc func(ctx context.Context, gameID, leaderboardID string) error
+
+	// Player's rank on the leaderboard. Fails with `leaderboard.ErrPlayerRankNotFound` when the player isn't ranked
+	StorageGetPlayerRankFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error)
+
+	// Keeps the improvement for at least the `HourlyWindow`
+	StorageRecordImprovementFunc func(ctx context.Context, i Improvement) error
+
+	// Sum of the player's improvements on the leaderboard applied after `since`
+	StorageSumImprovementsFunc func(ctx context.Context, gameID, leaderboardID, playerID string, since time.Time) (float64, error)
+)
@@ -0,0 +1,14 @@
+package delta
+
+import "context"
+
+type (
+	// Sets the maximum deltas of a leaderboard of the game, replacing its current rule
+	SetRuleFunc func(ctx context.Context, rule Rule) (Rule, error)
+
+	// Get the maximum deltas of a leaderboard of the game
+	GetRuleFunc func(ctx context.Context, gameID, leaderboardID string) (Rule, error)
+
+	// Removes the maximum deltas of a leaderboard of the game
+	RemoveRuleFunc func(ctx context.Context, gameID, leaderboardID string) error
+)
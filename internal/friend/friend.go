@@ -0,0 +1,133 @@
+package friend
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// Most friends a player can have, so the features reading every friend of a player, e.g. a friends ranking, stay bounded
+const MaxFriends = 1000
+
+var (
+	ErrValidationError    = errors.New("validation error")
+	ErrInvalidGameID      = errors.New("invalid game id")
+	ErrInvalidPlayerID    = errors.New("invalid player id")
+	ErrInvalidFriendID    = errors.New("invalid friend id")
+	ErrSelfFriendship     = errors.New("a player can't be their own friend")
+	ErrFriendLimitReached = errors.New("friend limit reached")
+
+	ErrFriendshipNotFound = errors.New("friendship not found")
+)
+
+type (
+	// Friendship between two players of a game, as seen by one of them. Friendships are mutual,
+	// so every friendship is kept once for each player
+	Friendship struct {
+		CreatedAt time.Time // Time the players became friends
+		GameID    string    // Game the players belong to
+		PlayerID  string    // Player whose friend it is
+		FriendID  string    // Player's friend
+	}
+
+	NewFriendshipData struct {
+		GameID   string // Game the players belong to
+		PlayerID string // Player adding the friend
+		FriendID string // Player added as a friend
+	}
+)
+
+func (d NewFriendshipData) validate() error {
+	errList := make([]error, 0)
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if d.PlayerID == "" {
+		errList = append(errList, ErrInvalidPlayerID)
+	}
+
+	if d.FriendID == "" {
+		errList = append(errList, ErrInvalidFriendID)
+	}
+
+	if d.PlayerID != "" && d.PlayerID == d.FriendID {
+		errList = append(errList, ErrSelfFriendship)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Checks that the player can have one more friend. Players that are already friends pass, so adding them again doesn't fail
+func checkLimit(ctx context.Context, gameID, playerID, friendID string, storageListFriendsFunc StorageListFriendsFunc) error {
+	friends, err := storageListFriendsFunc(ctx, gameID, playerID)
+	if err != nil {
+		return err
+	}
+
+	if len(friends) < MaxFriends {
+		return nil
+	}
+
+	if slices.ContainsFunc(friends, func(f Friendship) bool { return f.FriendID == friendID }) {
+		return nil
+	}
+
+	return ErrFriendLimitReached
+}
+
+// Both players become friends of each other. Adding a friendship that already exists keeps the first one.
+// The limit is checked before the friendship is stored, so concurrent additions may go slightly above it
+func BuildAddFunc(storageListFriendsFunc StorageListFriendsFunc, storageAddFriendshipFunc StorageAddFriendshipFunc) AddFunc {
+	return func(ctx context.Context, data NewFriendshipData) (Friendship, error) {
+		if err := data.validate(); err != nil {
+			return Friendship{}, err
+		}
+
+		if err := checkLimit(ctx, data.GameID, data.PlayerID, data.FriendID, storageListFriendsFunc); err != nil {
+			return Friendship{}, err
+		}
+
+		if err := checkLimit(ctx, data.GameID, data.FriendID, data.PlayerID, storageListFriendsFunc); err != nil {
+			return Friendship{}, err
+		}
+
+		return storageAddFriendshipFunc(ctx, Friendship{
+			CreatedAt: time.Now().UTC(),
+			GameID:    data.GameID,
+			PlayerID:  data.PlayerID,
+			FriendID:  data.FriendID,
+		})
+	}
+}
+
+// Removes the friendship for both players
+func BuildRemoveFunc(storageRemoveFriendshipFunc StorageRemoveFriendshipFunc) RemoveFunc {
+	return func(ctx context.Context, gameID, playerID, friendID string) error {
+		if playerID == "" {
+			return ErrInvalidPlayerID
+		}
+
+		if friendID == "" {
+			return ErrInvalidFriendID
+		}
+
+		return storageRemoveFriendshipFunc(ctx, gameID, playerID, friendID)
+	}
+}
+
+func BuildListFunc(storageListFriendsFunc StorageListFriendsFunc) ListFunc {
+	return func(ctx context.Context, gameID, playerID string) ([]Friendship, error) {
+		if playerID == "" {
+			return nil, ErrInvalidPlayerID
+		}
+
+		return storageListFriendsFunc(ctx, gameID, playerID)
+	}
+}
@@ -0,0 +1,69 @@
+package friend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAddFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		friends = make(map[string][]Friendship)
+		list    = func(ctx context.Context, gameID, playerID string) ([]Friendship, error) {
+			return friends[playerID], nil
+		}
+		add = func(ctx context.Context, f Friendship) (Friendship, error) {
+			return f, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		f, err := BuildAddFunc(list, add)(ctx, NewFriendshipData{GameID: "game", PlayerID: "alice", FriendID: "bob"})
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", f.PlayerID)
+		assert.Equal(t, "bob", f.FriendID)
+		assert.False(t, f.CreatedAt.IsZero())
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildAddFunc(nil, nil)(ctx, NewFriendshipData{})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+		assert.ErrorIs(t, err, ErrInvalidFriendID)
+
+		_, err = BuildAddFunc(nil, nil)(ctx, NewFriendshipData{GameID: "game", PlayerID: "alice", FriendID: "alice"})
+		assert.ErrorIs(t, err, ErrSelfFriendship)
+	})
+
+	t.Run("Limit Reached", func(t *testing.T) {
+		for i := range MaxFriends {
+			friends["carol"] = append(friends["carol"], Friendship{PlayerID: "carol", FriendID: fmt.Sprint(i)})
+		}
+
+		_, err := BuildAddFunc(list, add)(ctx, NewFriendshipData{GameID: "game", PlayerID: "carol", FriendID: "bob"})
+		assert.ErrorIs(t, err, ErrFriendLimitReached)
+
+		_, err = BuildAddFunc(list, add)(ctx, NewFriendshipData{GameID: "game", PlayerID: "bob", FriendID: "carol"})
+		assert.ErrorIs(t, err, ErrFriendLimitReached)
+
+		_, err = BuildAddFunc(list, add)(ctx, NewFriendshipData{GameID: "game", PlayerID: "carol", FriendID: "0"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestBuildRemoveFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		remove = BuildRemoveFunc(func(ctx context.Context, gameID, playerID, friendID string) error {
+			return ErrFriendshipNotFound
+		})
+	)
+
+	assert.ErrorIs(t, remove(ctx, "game", "", "bob"), ErrInvalidPlayerID)
+	assert.ErrorIs(t, remove(ctx, "game", "alice", ""), ErrInvalidFriendID)
+	assert.ErrorIs(t, remove(ctx, "game", "alice", "bob"), ErrFriendshipNotFound)
+}
@@ -0,0 +1,14 @@
+package friend
+
+import "context"
+
+type (
+	// Stores the friendship for both players, keeping the first one when it already exists, and returns it as seen by the player
+	StorageAddFriendshipFunc func(ctx context.Context, friendship Friendship) (Friendship, error)
+
+	// Removes the friendship for both players
+	StorageRemoveFriendshipFunc func(ctx context.Context, gameID, playerID, friendID string) error
+
+	// Lists the player's friends, oldest friendship first
+	StorageListFriendsFunc func(ctx context.Context, gameID, playerID string) ([]Friendship, error)
+)
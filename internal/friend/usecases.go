@@ -0,0 +1,14 @@
+package friend
+
+import "context"
+
+type (
+	// Makes both players friends of each other, returning the friendship as seen by the player
+	AddFunc func(ctx context.Context, data NewFriendshipData) (Friendship, error)
+
+	// Removes the friendship between the players
+	RemoveFunc func(ctx context.Context, gameID, playerID, friendID string) error
+
+	// Lists the player's friends, oldest friendship first
+	ListFunc func(ctx context.Context, gameID, playerID string) ([]Friendship, error)
+)
@@ -0,0 +1,17 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/datachange"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+)
+
+func (p producer) DataChange(ctx context.Context, event datachange.Event) error {
+	var (
+		topic = message.DataChangeDestination
+		key   = message.DataChangeRoutingKey(event)
+	)
+
+	return p.publish(ctx, topic, key, message.SchemaDataChange, message.FromDataChange(event))
+}
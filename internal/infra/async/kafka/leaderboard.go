@@ -0,0 +1,17 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+func (p producer) LeaderboardLifecycleEvent(ctx context.Context, event string, lb leaderboard.Leaderboard) error {
+	var (
+		topic = message.LeaderboardDestination
+		key   = message.LeaderboardRoutingKey(event, lb)
+	)
+
+	return p.publish(ctx, topic, key, message.SchemaLeaderboard, message.FromLeaderboardEvent(event, lb))
+}
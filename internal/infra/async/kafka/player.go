@@ -0,0 +1,56 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/reward"
+)
+
+func (p producer) PlayerErased(ctx context.Context, playerID string, receipt privacy.Receipt) error {
+	var (
+		topic = message.PlayerDestination
+		key   = message.PlayerErasureRoutingKey(receipt.GameID, playerID)
+	)
+
+	return p.publish(ctx, topic, key, message.SchemaPlayerErasure, message.FromPlayerErasure(playerID, receipt))
+}
+
+func (p producer) PlayerAnonymized(ctx context.Context, playerID string, receipt privacy.AnonymizationReceipt) error {
+	var (
+		topic = message.PlayerDestination
+		key   = message.PlayerAnonymizationRoutingKey(receipt.GameID, playerID)
+	)
+
+	return p.publish(ctx, topic, key, message.SchemaPlayerAnonymization, message.FromPlayerAnonymization(playerID, receipt))
+}
+
+func (p producer) AchievementUnlocked(ctx context.Context, unlocked achievement.PlayerAchievement) error {
+	var (
+		topic = message.PlayerDestination
+		key   = message.AchievementUnlockedRoutingKey(unlocked.Achievement.GameID, unlocked.PlayerID, unlocked.Achievement.ID)
+	)
+
+	return p.publish(ctx, topic, key, message.SchemaPlayerAchievement, message.FromAchievementUnlocked(unlocked))
+}
+
+func (p producer) GrantRewards(ctx context.Context, grant reward.Grant) error {
+	var (
+		topic = message.PlayerDestination
+		key   = message.RewardGrantRoutingKey(grant.GameID, grant.PlayerID, grant.ID)
+	)
+
+	return p.publish(ctx, topic, key, message.SchemaRewardGrant, message.FromRewardGrant(grant))
+}
+
+func (p producer) LevelUp(ctx context.Context, up level.LevelUp) error {
+	var (
+		topic = message.PlayerDestination
+		key   = message.LevelUpRoutingKey(up.Curve.GameID, up.PlayerID, up.Curve.ID)
+	)
+
+	return p.publish(ctx, topic, key, message.SchemaPlayerLevelUp, message.FromLevelUp(up))
+}
@@ -0,0 +1,180 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/tracing"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const messagingSystem = "kafka"
+
+// Content types of the REST Proxy v2 API, see https://docs.confluent.io/platform/current/kafka-rest/api.html
+const (
+	contentTypeJSON = "application/vnd.kafka.json.v2+json"
+	acceptV2        = "application/vnd.kafka.v2+json"
+
+	defaultTimeout = 10 * time.Second
+)
+
+var ErrPublishFailed = errors.New("kafka publish failed")
+
+type Options struct {
+	RestProxyURL string          // Kafka REST Proxy base URL
+	Timeout      time.Duration   // Timeout of each request to the proxy. Defaults to 10s
+	Encoder      message.Encoder // Envelope of the published events. The zero value publishes the plain events
+	Retry        backoff.Config  // Retry policy used while the proxy is not reachable at startup
+}
+
+type (
+	record struct {
+		Key   string          `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	produceRequest struct {
+		Records []record `json:"records"`
+	}
+
+	produceOffset struct {
+		Partition *int32 `json:"partition"`
+		Offset    *int64 `json:"offset"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	}
+
+	produceResponse struct {
+		Offsets []produceOffset `json:"offsets"`
+	}
+
+	errorResponse struct {
+		ErrorCode int    `json:"error_code"`
+		Message   string `json:"message"`
+	}
+)
+
+// Publishes the events to Kafka through the REST Proxy v2 API. The topics must already exist
+// on the cluster, or the cluster must allow their automatic creation
+type producer struct {
+	client  *http.Client
+	url     string
+	encoder message.Encoder
+}
+
+func readError(res *http.Response) error {
+	body, _ := io.ReadAll(res.Body)
+
+	var data errorResponse
+	if err := json.Unmarshal(body, &data); err == nil && data.Message != "" {
+		return fmt.Errorf("%w: status %d: %s", ErrPublishFailed, res.StatusCode, data.Message)
+	}
+
+	return fmt.Errorf("%w: status %d", ErrPublishFailed, res.StatusCode)
+}
+
+// Sends a single record to the topic. Records sharing the key are written to the same partition,
+// so the events of an entity keep their order. The REST proxy v2 API has no record headers, so the trace
+// context is only sent on the request to the proxy and the consumers start traces of their own
+func (p producer) publish(ctx context.Context, topic, key, schema string, msg any) (err error) {
+	header := make(http.Header)
+
+	ctx, span := tracing.StartPublish(ctx, messagingSystem, topic, propagation.HeaderCarrier(header))
+	defer func() { tracing.End(span, err) }()
+
+	value, err := p.encoder.Encode(schema, key, msg)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(produceRequest{Records: []record{{Key: key, Value: value}}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", contentTypeJSON)
+	req.Header.Set("Accept", acceptV2)
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return readError(res)
+	}
+
+	var data produceResponse
+	if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
+		return err
+	}
+
+	for _, offset := range data.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("%w: %s", ErrPublishFailed, offset.Error)
+		}
+	}
+
+	return nil
+}
+
+// Checks the REST proxy is reachable by listing its topics
+func (p producer) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url+"/topics", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", acceptV2)
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return readError(res)
+	}
+
+	return nil
+}
+
+func (p producer) Close() {
+	p.client.CloseIdleConnections()
+}
+
+func NewProducer(ctx context.Context, opts Options) (*producer, error) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	p := &producer{
+		client:  &http.Client{Timeout: timeout},
+		url:     strings.TrimSuffix(opts.RestProxyURL, "/"),
+		encoder: opts.Encoder,
+	}
+
+	if err := backoff.Retry(ctx, opts.Retry, p.Ping); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
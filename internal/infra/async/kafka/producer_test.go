@@ -0,0 +1,93 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/datachange"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProducer(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/topics", r.URL.Path)
+			_, _ = w.Write([]byte(`[]`))
+		}))
+		defer server.Close()
+
+		p, err := NewProducer(context.Background(), Options{RestProxyURL: server.URL + "/"})
+		assert.NoError(t, err)
+		assert.Equal(t, server.URL, p.url)
+	})
+
+	t.Run("Proxy Unavailable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := NewProducer(context.Background(), Options{
+			RestProxyURL: server.URL,
+			Retry:        backoff.Config{MaxElapsedTime: time.Millisecond},
+		})
+		assert.ErrorIs(t, err, ErrPublishFailed)
+	})
+}
+
+func TestDataChange(t *testing.T) {
+	event := datachange.Event{Entity: "Leaderboard", EntityID: "1", GameID: "2", Operation: "DELETE"}
+
+	t.Run("OK", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/topics/gameblitz.datachange", r.URL.Path)
+			assert.Equal(t, contentTypeJSON, r.Header.Get("Content-Type"))
+
+			var body produceRequest
+			err := json.NewDecoder(r.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Len(t, body.Records, 1)
+			assert.Equal(t, "game.2.leaderboard.1.delete", body.Records[0].Key)
+
+			_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":1}]}`))
+		}))
+		defer server.Close()
+
+		p := producer{client: server.Client(), url: server.URL}
+
+		err := p.DataChange(context.Background(), event)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Record Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"offsets":[{"error_code":50003,"error":"any error"}]}`))
+		}))
+		defer server.Close()
+
+		p := producer{client: server.Client(), url: server.URL}
+
+		err := p.DataChange(context.Background(), event)
+		assert.ErrorIs(t, err, ErrPublishFailed)
+	})
+
+	t.Run("Request Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":40401,"message":"Topic not found."}`))
+		}))
+		defer server.Close()
+
+		p := producer{client: server.Client(), url: server.URL}
+
+		err := p.DataChange(context.Background(), event)
+		assert.ErrorIs(t, err, ErrPublishFailed)
+		assert.ErrorContains(t, err, "Topic not found.")
+	})
+}
@@ -0,0 +1,26 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/quest"
+)
+
+func (p producer) PlayerQuestProgressionUpdates(ctx context.Context, progression quest.PlayerQuestProgression) error {
+	var (
+		topic = message.QuestDestination
+		key   = message.QuestRoutingKey(progression.Quest.GameID, progression.Quest.ID)
+	)
+
+	return p.publish(ctx, topic, key, message.SchemaPlayerQuest, message.FromPlayerQuestProgression(progression))
+}
+
+func (p producer) QuestLifecycleEvent(ctx context.Context, event quest.LifecycleEvent) error {
+	var (
+		topic = message.QuestDestination
+		key   = message.QuestLifecycleRoutingKey(event)
+	)
+
+	return p.publish(ctx, topic, key, message.SchemaQuestLifecycle, message.FromQuestLifecycleEvent(event))
+}
@@ -0,0 +1,17 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+func (p producer) PlayerStatisticProgressionUpdates(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+	var (
+		topic = message.StatisticDestination
+		key   = message.StatisticRoutingKey(st.GameID, st.ID)
+	)
+
+	return p.publish(ctx, topic, key, message.SchemaPlayerStatistic, message.FromPlayerStatisticUpdates(progression, updates))
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+)
+
+// Event published on the bus, encoded as it would be sent to a broker
+type Event struct {
+	Destination string // Destination the event would be published to, e.g. `gameblitz.statistic`
+	RoutingKey  string // Routing key of the event, e.g. `game.<game id>.statistic.<statistic id>`
+	Schema      string // Schema of the event, e.g. `gameblitz.player.erasure`
+	ContentType string // Content type of the body
+	Body        []byte // Encoded event
+}
+
+// Handles an event published on the bus. It runs on the publisher goroutine, so it should return quickly
+type Handler func(ctx context.Context, event Event)
+
+// Delivers the events to the handlers subscribed on the same process, so the API runs without a broker,
+// e.g. on the dev mode. Nothing is persisted: events published without subscribers are dropped
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+	encoder  message.Encoder
+}
+
+// Adds a handler for every event published from now on
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+func (b *Bus) publish(ctx context.Context, destination, routingKey, schema string, value any) error {
+	body, err := b.encoder.Encode(schema, routingKey, value)
+	if err != nil {
+		return err
+	}
+
+	event := Event{
+		Destination: destination,
+		RoutingKey:  routingKey,
+		Schema:      schema,
+		ContentType: b.encoder.ContentType(),
+		Body:        body,
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, handler := range b.handlers {
+		handler(ctx, event)
+	}
+
+	return nil
+}
+
+// Always reachable, as it runs on the same process
+func (b *Bus) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (b *Bus) Close() {}
+
+func NewBus(encoder message.Encoder) *Bus {
+	return &Bus{encoder: encoder}
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus(t *testing.T) {
+	var (
+		ctx = context.Background()
+		lb  = leaderboard.Leaderboard{ID: uuid.NewString(), GameID: uuid.NewString()}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		bus := NewBus(message.Encoder{})
+
+		var first, second []Event
+		bus.Subscribe(func(ctx context.Context, event Event) { first = append(first, event) })
+		bus.Subscribe(func(ctx context.Context, event Event) { second = append(second, event) })
+
+		err := bus.LeaderboardLifecycleEvent(ctx, leaderboard.EventCreated, lb)
+		assert.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		if assert.Len(t, first, 1) {
+			assert.Equal(t, message.LeaderboardDestination, first[0].Destination)
+			assert.Equal(t, message.LeaderboardRoutingKey(leaderboard.EventCreated, lb), first[0].RoutingKey)
+			assert.Equal(t, message.SchemaLeaderboard, first[0].Schema)
+			assert.Equal(t, message.ContentTypeJSON, first[0].ContentType)
+
+			var body message.Leaderboard
+			err = json.Unmarshal(first[0].Body, &body)
+			assert.NoError(t, err)
+			assert.Equal(t, lb.ID, body.ID)
+			assert.Equal(t, leaderboard.EventCreated, body.Event)
+		}
+	})
+
+	t.Run("Without Subscribers", func(t *testing.T) {
+		bus := NewBus(message.Encoder{})
+
+		err := bus.LeaderboardLifecycleEvent(ctx, leaderboard.EventCreated, lb)
+		assert.NoError(t, err)
+		assert.NoError(t, bus.Ping(ctx))
+	})
+}
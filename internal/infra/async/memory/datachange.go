@@ -0,0 +1,12 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/datachange"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+)
+
+func (b *Bus) DataChange(ctx context.Context, event datachange.Event) error {
+	return b.publish(ctx, message.DataChangeDestination, message.DataChangeRoutingKey(event), message.SchemaDataChange, message.FromDataChange(event))
+}
@@ -0,0 +1,12 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+func (b *Bus) LeaderboardLifecycleEvent(ctx context.Context, event string, lb leaderboard.Leaderboard) error {
+	return b.publish(ctx, message.LeaderboardDestination, message.LeaderboardRoutingKey(event, lb), message.SchemaLeaderboard, message.FromLeaderboardEvent(event, lb))
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/reward"
+)
+
+func (b *Bus) PlayerErased(ctx context.Context, playerID string, receipt privacy.Receipt) error {
+	return b.publish(ctx, message.PlayerDestination, message.PlayerErasureRoutingKey(receipt.GameID, playerID), message.SchemaPlayerErasure, message.FromPlayerErasure(playerID, receipt))
+}
+
+func (b *Bus) PlayerAnonymized(ctx context.Context, playerID string, receipt privacy.AnonymizationReceipt) error {
+	return b.publish(ctx, message.PlayerDestination, message.PlayerAnonymizationRoutingKey(receipt.GameID, playerID), message.SchemaPlayerAnonymization, message.FromPlayerAnonymization(playerID, receipt))
+}
+
+func (b *Bus) AchievementUnlocked(ctx context.Context, unlocked achievement.PlayerAchievement) error {
+	return b.publish(ctx, message.PlayerDestination, message.AchievementUnlockedRoutingKey(unlocked.Achievement.GameID, unlocked.PlayerID, unlocked.Achievement.ID), message.SchemaPlayerAchievement, message.FromAchievementUnlocked(unlocked))
+}
+
+func (b *Bus) GrantRewards(ctx context.Context, grant reward.Grant) error {
+	return b.publish(ctx, message.PlayerDestination, message.RewardGrantRoutingKey(grant.GameID, grant.PlayerID, grant.ID), message.SchemaRewardGrant, message.FromRewardGrant(grant))
+}
+
+func (b *Bus) LevelUp(ctx context.Context, up level.LevelUp) error {
+	return b.publish(ctx, message.PlayerDestination, message.LevelUpRoutingKey(up.Curve.GameID, up.PlayerID, up.Curve.ID), message.SchemaPlayerLevelUp, message.FromLevelUp(up))
+}
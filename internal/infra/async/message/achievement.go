@@ -0,0 +1,48 @@
+package message
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+)
+
+type (
+	AchievementTrigger struct {
+		Kind        string  `json:"kind"`
+		StatisticID string  `json:"statisticId,omitempty"`
+		Landmark    float64 `json:"landmark,omitempty"`
+		QuestID     string  `json:"questId,omitempty"`
+	}
+
+	// Published on the player destination, since it's about the player's progression on the game as a whole
+	PlayerAchievement struct {
+		OccurredAt    time.Time          `json:"occurredAt"`
+		GameID        string             `json:"gameId"`
+		PlayerID      string             `json:"playerId"`
+		AchievementID string             `json:"achievementId"`
+		Name          string             `json:"name"`
+		Trigger       AchievementTrigger `json:"trigger"`
+	}
+)
+
+func FromAchievementUnlocked(unlocked achievement.PlayerAchievement) PlayerAchievement {
+	return PlayerAchievement{
+		OccurredAt:    unlocked.UnlockedAt,
+		GameID:        unlocked.Achievement.GameID,
+		PlayerID:      unlocked.PlayerID,
+		AchievementID: unlocked.Achievement.ID,
+		Name:          unlocked.Achievement.Name,
+		Trigger: AchievementTrigger{
+			Kind:        unlocked.Achievement.Trigger.Kind,
+			StatisticID: unlocked.Achievement.Trigger.StatisticID,
+			Landmark:    unlocked.Achievement.Trigger.Landmark,
+			QuestID:     unlocked.Achievement.Trigger.QuestID,
+		},
+	}
+}
+
+// Routes as `game.<game id>.player.<player id>.achievement.<achievement id>.unlocked`
+func AchievementUnlockedRoutingKey(gameID, playerID, achievementID string) string {
+	return fmt.Sprintf("game.%s.player.%s.achievement.%s.unlocked", gameID, playerID, achievementID)
+}
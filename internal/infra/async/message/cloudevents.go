@@ -0,0 +1,138 @@
+package message
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Envelopes the messages can be published on
+const (
+	FormatPlain       = "plain"       // The message itself
+	FormatCloudEvents = "cloudevents" // CloudEvents 1.0 structured JSON mode, with the message as its data
+)
+
+// Content types of the published messages
+const (
+	ContentTypeJSON        = "application/json"
+	ContentTypeCloudEvents = "application/cloudevents+json"
+)
+
+const (
+	cloudEventsSpecVersion      = "1.0"
+	cloudEventsSpecVersionField = "specversion"
+)
+
+var (
+	ErrUnknownFormat      = errors.New("unknown message format")
+	ErrInvalidCloudEvent  = errors.New("invalid cloud event")
+	ErrMissingEventSource = errors.New("cloud events require a source")
+)
+
+// Event on the CloudEvents 1.0 structured JSON mode, see https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/formats/json-format.md
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`              // Schema of the message on `data`
+	Subject         string          `json:"subject,omitempty"` // Routing key of the message
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// Encodes the published messages on the configured format
+type Encoder struct {
+	format string
+	source string
+}
+
+func newCloudEvent(source, schema, subject string, data []byte) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              uuid.NewString(),
+		Source:          source,
+		Type:            schema,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: ContentTypeJSON,
+		Data:            data,
+	}
+}
+
+// Returns the message on the `data` of the event when it is a CloudEvent, or the message itself otherwise
+func unwrapCloudEvent(name string, fields map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	if _, ok := fields[cloudEventsSpecVersionField]; !ok {
+		return fields, nil
+	}
+
+	var event struct {
+		SpecVersion string          `json:"specversion"`
+		Type        string          `json:"type"`
+		Data        json.RawMessage `json:"data"`
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCloudEvent, err)
+	}
+
+	if event.SpecVersion != cloudEventsSpecVersion {
+		return nil, fmt.Errorf("%w: unsupported spec version %q", ErrInvalidCloudEvent, event.SpecVersion)
+	}
+
+	if event.Type != name {
+		return nil, fmt.Errorf("%w: expected %s, received %s", ErrUnknownSchema, name, event.Type)
+	}
+
+	var message map[string]json.RawMessage
+	if err := json.Unmarshal(event.Data, &message); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCloudEvent, err)
+	}
+
+	return message, nil
+}
+
+// Marshals the message with `Encode`, wrapping it on a CloudEvent when configured, with the routing key as its subject
+func (e Encoder) Encode(schema, routingKey string, v any) ([]byte, error) {
+	data, err := Encode(schema, v)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.format != FormatCloudEvents {
+		return data, nil
+	}
+
+	return json.Marshal(newCloudEvent(e.source, schema, routingKey, data))
+}
+
+func (e Encoder) ContentType() string {
+	if e.format == FormatCloudEvents {
+		return ContentTypeCloudEvents
+	}
+
+	return ContentTypeJSON
+}
+
+// The zero value encodes on the plain format. The `source` identifies the service on the CloudEvents
+func NewEncoder(format, source string) (Encoder, error) {
+	switch format {
+	case FormatPlain:
+	case FormatCloudEvents:
+		if source == "" {
+			return Encoder{}, ErrMissingEventSource
+		}
+	default:
+		return Encoder{}, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+
+	return Encoder{format: format, source: source}, nil
+}
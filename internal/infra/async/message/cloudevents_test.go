@@ -0,0 +1,92 @@
+package message
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEncoder(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		encoder, err := NewEncoder(FormatCloudEvents, "/gameblitz")
+		assert.NoError(t, err)
+		assert.Equal(t, ContentTypeCloudEvents, encoder.ContentType())
+
+		encoder, err = NewEncoder(FormatPlain, "")
+		assert.NoError(t, err)
+		assert.Equal(t, ContentTypeJSON, encoder.ContentType())
+	})
+
+	t.Run("Missing Source", func(t *testing.T) {
+		_, err := NewEncoder(FormatCloudEvents, "")
+		assert.ErrorIs(t, err, ErrMissingEventSource)
+	})
+
+	t.Run("Unknown Format", func(t *testing.T) {
+		_, err := NewEncoder("xml", "/gameblitz")
+		assert.ErrorIs(t, err, ErrUnknownFormat)
+	})
+}
+
+func TestEncoderEncode(t *testing.T) {
+	msg := DataChange{Entity: "STATISTIC", EntityID: "1", Operation: "CREATED"}
+
+	t.Run("Plain", func(t *testing.T) {
+		data, err := Encoder{}.Encode(SchemaDataChange, "game.1.statistic.1", msg)
+		assert.NoError(t, err)
+
+		expected, err := Encode(SchemaDataChange, msg)
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(data))
+	})
+
+	t.Run("Cloud Events", func(t *testing.T) {
+		encoder, err := NewEncoder(FormatCloudEvents, "/gameblitz")
+		assert.NoError(t, err)
+
+		data, err := encoder.Encode(SchemaDataChange, "game.1.statistic.1", msg)
+		assert.NoError(t, err)
+
+		var event CloudEvent
+		assert.NoError(t, json.Unmarshal(data, &event))
+		assert.Equal(t, "1.0", event.SpecVersion)
+		assert.NotEmpty(t, event.ID)
+		assert.Equal(t, "/gameblitz", event.Source)
+		assert.Equal(t, SchemaDataChange, event.Type)
+		assert.Equal(t, "game.1.statistic.1", event.Subject)
+		assert.False(t, event.Time.IsZero())
+		assert.Equal(t, ContentTypeJSON, event.DataContentType)
+
+		var decoded DataChange
+		assert.NoError(t, Decode(SchemaDataChange, data, &decoded))
+		assert.Equal(t, msg, decoded)
+	})
+}
+
+func TestDecodeCloudEvent(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"specversion": "1.0", "id": "a", "source": "/game", "type": "test", "data": {"id": "1", "amount": 10, "schemaVersion": 1}}`), &msg)
+		assert.NoError(t, err)
+		assert.Equal(t, testMessage{ID: "1", Value: 10}, msg)
+	})
+
+	t.Run("Other Type", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"specversion": "1.0", "type": "other", "data": {"id": "1", "value": 10}}`), &msg)
+		assert.ErrorIs(t, err, ErrUnknownSchema)
+	})
+
+	t.Run("Unsupported Spec Version", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"specversion": "0.3", "type": "test", "data": {"id": "1", "value": 10}}`), &msg)
+		assert.ErrorIs(t, err, ErrInvalidCloudEvent)
+	})
+
+	t.Run("Invalid Data", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"specversion": "1.0", "type": "test", "data": "text"}`), &msg)
+		assert.ErrorIs(t, err, ErrInvalidCloudEvent)
+	})
+}
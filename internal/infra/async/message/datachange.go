@@ -0,0 +1,40 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/datachange"
+)
+
+// Destination (exchange, topic or subject) of the data change events
+const DataChangeDestination = "gameblitz.datachange"
+
+type DataChange struct {
+	OccurredAt time.Time `json:"occurredAt"`
+	Entity     string    `json:"entity"`
+	EntityID   string    `json:"entityId"`
+	GameID     string    `json:"gameId"`
+	Operation  string    `json:"operation"`
+}
+
+func FromDataChange(e datachange.Event) DataChange {
+	return DataChange{
+		OccurredAt: e.OccurredAt,
+		Entity:     e.Entity,
+		EntityID:   e.EntityID,
+		GameID:     e.GameID,
+		Operation:  e.Operation,
+	}
+}
+
+// Routes as `game.<game id>.<entity>.<entity id>.<operation>`. Unknown games are routed as `game._`
+func DataChangeRoutingKey(e datachange.Event) string {
+	gameID := e.GameID
+	if gameID == "" {
+		gameID = "_"
+	}
+
+	return fmt.Sprintf("game.%s.%s.%s.%s", gameID, strings.ToLower(e.Entity), e.EntityID, strings.ToLower(e.Operation))
+}
@@ -0,0 +1,42 @@
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+)
+
+// Destination (exchange, topic or subject) of the gameplay data sent by the game servers
+const IngestionDestination = "gameblitz.ingestion"
+
+type Ingestion struct {
+	ID      string          `json:"id"`
+	Kind    string          `json:"kind"`
+	GameID  string          `json:"gameId"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+func FromIngestion(msg ingestion.Message) Ingestion {
+	return Ingestion{
+		ID:      msg.ID,
+		Kind:    msg.Kind,
+		GameID:  msg.GameID,
+		Payload: msg.Payload,
+	}
+}
+
+func (m Ingestion) ToDomain(attempts int) ingestion.Message {
+	return ingestion.Message{
+		ID:       m.ID,
+		Kind:     m.Kind,
+		GameID:   m.GameID,
+		Payload:  m.Payload,
+		Attempts: attempts,
+	}
+}
+
+func IngestionRoutingKey(gameID, kind string) string {
+	return fmt.Sprintf("game.%s.%s", gameID, strings.ToLower(kind))
+}
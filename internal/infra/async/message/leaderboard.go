@@ -0,0 +1,56 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+// Destination (exchange, topic or subject) of the leaderboard lifecycle events
+const LeaderboardDestination = "gameblitz.leaderboard"
+
+type Leaderboard struct {
+	OccurredAt      time.Time  `json:"occurredAt"`
+	Event           string     `json:"event"`
+	ID              string     `json:"id"`
+	GameID          string     `json:"gameId"`
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	StartAt         *time.Time `json:"startAt"`
+	EndAt           *time.Time `json:"endAt"`
+	DeletedAt       *time.Time `json:"deletedAt"`
+	AggregationMode string     `json:"aggregationMode"`
+	Ordering        string     `json:"ordering"`
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// Deletion events only carry the leaderboard ID, game ID and deletion time
+func FromLeaderboardEvent(event string, lb leaderboard.Leaderboard) Leaderboard {
+	return Leaderboard{
+		OccurredAt:      time.Now().UTC(),
+		Event:           event,
+		ID:              lb.ID,
+		GameID:          lb.GameID,
+		Name:            lb.Name,
+		Description:     lb.Description,
+		StartAt:         optionalTime(lb.StartAt),
+		EndAt:           optionalTime(lb.EndAt),
+		DeletedAt:       optionalTime(lb.DeletedAt),
+		AggregationMode: lb.AggregationMode,
+		Ordering:        lb.Ordering,
+	}
+}
+
+// Routes as `game.<game id>.leaderboard.<leaderboard id>.<event>`
+func LeaderboardRoutingKey(event string, lb leaderboard.Leaderboard) string {
+	return fmt.Sprintf("game.%s.leaderboard.%s.%s", lb.GameID, lb.ID, strings.ToLower(event))
+}
@@ -0,0 +1,40 @@
+package message
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/level"
+)
+
+// Published on the player destination, since it's about the player's progression on the game as a whole
+type PlayerLevelUp struct {
+	OccurredAt    time.Time `json:"occurredAt"`
+	GameID        string    `json:"gameId"`
+	PlayerID      string    `json:"playerId"`
+	CurveID       string    `json:"curveId"`
+	CurveName     string    `json:"curveName"`
+	StatisticID   string    `json:"statisticId"`
+	PreviousLevel int64     `json:"previousLevel"`
+	Level         int64     `json:"level"`
+	XP            float64   `json:"xp"`
+}
+
+func FromLevelUp(up level.LevelUp) PlayerLevelUp {
+	return PlayerLevelUp{
+		OccurredAt:    up.OccurredAt,
+		GameID:        up.Curve.GameID,
+		PlayerID:      up.PlayerID,
+		CurveID:       up.Curve.ID,
+		CurveName:     up.Curve.Name,
+		StatisticID:   up.Curve.StatisticID,
+		PreviousLevel: up.PreviousLevel,
+		Level:         up.Level,
+		XP:            up.XP,
+	}
+}
+
+// Routes as `game.<game id>.player.<player id>.level.<curve id>.up`
+func LevelUpRoutingKey(gameID, playerID, curveID string) string {
+	return fmt.Sprintf("game.%s.player.%s.level.%s.up", gameID, playerID, curveID)
+}
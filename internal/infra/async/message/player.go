@@ -0,0 +1,93 @@
+package message
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+)
+
+// Destination (exchange, topic or subject) of the player privacy events
+const PlayerDestination = "gameblitz.player"
+
+type (
+	PlayerErasureData struct {
+		Data    string `json:"data"`
+		Records int64  `json:"records"`
+	}
+
+	// Carries the player ID, so the consumers can erase the copies they keep of the player's data
+	PlayerErasure struct {
+		OccurredAt   time.Time           `json:"occurredAt"`
+		GameID       string              `json:"gameId"`
+		PlayerID     string              `json:"playerId"`
+		ReceiptID    string              `json:"receiptId"`
+		PlayerDigest string              `json:"playerDigest"`
+		Erasures     []PlayerErasureData `json:"erasures"`
+	}
+
+	PlayerAnonymizationData struct {
+		Data    string `json:"data"`
+		Records int64  `json:"records"`
+	}
+
+	// Carries the player ID and its pseudonym, so the consumers can replace it on the copies they keep of the player's data
+	PlayerAnonymization struct {
+		OccurredAt     time.Time                 `json:"occurredAt"`
+		GameID         string                    `json:"gameId"`
+		PlayerID       string                    `json:"playerId"`
+		Pseudonym      string                    `json:"pseudonym"`
+		ReceiptID      string                    `json:"receiptId"`
+		PlayerDigest   string                    `json:"playerDigest"`
+		Anonymizations []PlayerAnonymizationData `json:"anonymizations"`
+	}
+)
+
+func FromPlayerErasure(playerID string, receipt privacy.Receipt) PlayerErasure {
+	erasures := make([]PlayerErasureData, len(receipt.Erasures))
+	for i, erasure := range receipt.Erasures {
+		erasures[i] = PlayerErasureData{
+			Data:    erasure.Data,
+			Records: erasure.Records,
+		}
+	}
+
+	return PlayerErasure{
+		OccurredAt:   receipt.ErasedAt,
+		GameID:       receipt.GameID,
+		PlayerID:     playerID,
+		ReceiptID:    receipt.ID,
+		PlayerDigest: receipt.PlayerDigest,
+		Erasures:     erasures,
+	}
+}
+
+// Routes as `game.<game id>.player.<player id>.erased`
+func PlayerErasureRoutingKey(gameID, playerID string) string {
+	return fmt.Sprintf("game.%s.player.%s.erased", gameID, playerID)
+}
+
+func FromPlayerAnonymization(playerID string, receipt privacy.AnonymizationReceipt) PlayerAnonymization {
+	anonymizations := make([]PlayerAnonymizationData, len(receipt.Anonymizations))
+	for i, anonymization := range receipt.Anonymizations {
+		anonymizations[i] = PlayerAnonymizationData{
+			Data:    anonymization.Data,
+			Records: anonymization.Records,
+		}
+	}
+
+	return PlayerAnonymization{
+		OccurredAt:     receipt.AnonymizedAt,
+		GameID:         receipt.GameID,
+		PlayerID:       playerID,
+		Pseudonym:      receipt.Pseudonym,
+		ReceiptID:      receipt.ID,
+		PlayerDigest:   receipt.PlayerDigest,
+		Anonymizations: anonymizations,
+	}
+}
+
+// Routes as `game.<game id>.player.<player id>.anonymized`
+func PlayerAnonymizationRoutingKey(gameID, playerID string) string {
+	return fmt.Sprintf("game.%s.player.%s.anonymized", gameID, playerID)
+}
@@ -0,0 +1,169 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/quest"
+)
+
+// Destination (exchange, topic or subject) of the quest events
+const QuestDestination = "gameblitz.quest"
+
+type (
+	Task struct {
+		CreatedAt             time.Time  `json:"createdAt"`
+		UpdatedAt             time.Time  `json:"updatedAt"`
+		DeletedAt             *time.Time `json:"deletedAt"`
+		ID                    string     `json:"id"`
+		Name                  string     `json:"name"`
+		Description           string     `json:"description"`
+		DependsOn             []string   `json:"dependsOn"`
+		RequiredForCompletion bool       `json:"requiredForCompletion"`
+	}
+
+	Quest struct {
+		CreatedAt   time.Time  `json:"createdAt"`
+		UpdatedAt   time.Time  `json:"updatedAt"`
+		DeletedAt   *time.Time `json:"deletedAt"`
+		ID          string     `json:"id"`
+		GameID      string     `json:"gameId"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		Tasks       []Task     `json:"tasks"`
+	}
+
+	PlayerTaskProgression struct {
+		StartedAt   time.Time  `json:"startedAt"`
+		UpdatedAt   time.Time  `json:"updatedAt"`
+		Task        Task       `json:"task"`
+		CompletedAt *time.Time `json:"completedAt"`
+	}
+
+	PlayerQuestProgression struct {
+		StartedAt        time.Time               `json:"startedAt"`
+		UpdatedAt        time.Time               `json:"updatedAt"`
+		PlayerID         string                  `json:"playerId"`
+		Quest            Quest                   `json:"quest"`
+		CompletedAt      *time.Time              `json:"completedAt"`
+		TasksProgression []PlayerTaskProgression `json:"tasksProgression"`
+	}
+
+	QuestLifecycle struct {
+		OccurredAt time.Time `json:"occurredAt"`
+		Event      string    `json:"event"`
+		GameID     string    `json:"gameId"`
+		PlayerID   string    `json:"playerId"`
+		QuestID    string    `json:"questId"`
+		TaskID     *string   `json:"taskId"`
+	}
+)
+
+func FromPlayerQuestProgression(p quest.PlayerQuestProgression) PlayerQuestProgression {
+	tasks := make([]Task, len(p.Quest.Tasks))
+	for i, t := range p.Quest.Tasks {
+		var deletedAt *time.Time
+		if !t.DeletedAt.IsZero() {
+			tmp := t.DeletedAt
+			deletedAt = &tmp
+		}
+
+		tasks[i] = Task{
+			CreatedAt:             t.CreatedAt,
+			UpdatedAt:             t.UpdatedAt,
+			DeletedAt:             deletedAt,
+			ID:                    t.ID,
+			Name:                  t.Name,
+			Description:           t.Description,
+			DependsOn:             t.DependsOn,
+			RequiredForCompletion: t.RequiredForCompletion,
+		}
+	}
+
+	tasksProgression := make([]PlayerTaskProgression, len(p.TasksProgression))
+	for i, tp := range p.TasksProgression {
+		var completedAt *time.Time
+		if !tp.CompletedAt.IsZero() {
+			tmp := tp.CompletedAt
+			completedAt = &tmp
+		}
+
+		var taskDeletedAt *time.Time
+		if !tp.Task.DeletedAt.IsZero() {
+			tmp := tp.Task.DeletedAt
+			taskDeletedAt = &tmp
+		}
+
+		tasksProgression[i] = PlayerTaskProgression{
+			StartedAt:   tp.StartedAt,
+			UpdatedAt:   tp.UpdatedAt,
+			CompletedAt: completedAt,
+			Task: Task{
+				CreatedAt:             tp.Task.CreatedAt,
+				UpdatedAt:             tp.Task.UpdatedAt,
+				DeletedAt:             taskDeletedAt,
+				ID:                    tp.Task.ID,
+				Name:                  tp.Task.Name,
+				Description:           tp.Task.Description,
+				DependsOn:             tp.Task.DependsOn,
+				RequiredForCompletion: tp.Task.RequiredForCompletion,
+			},
+		}
+	}
+
+	var completedAt *time.Time
+	if !p.CompletedAt.IsZero() {
+		tmp := p.CompletedAt
+		completedAt = &tmp
+	}
+
+	var questDeletedAt *time.Time
+	if !p.Quest.DeletedAt.IsZero() {
+		tmp := p.Quest.DeletedAt
+		questDeletedAt = &tmp
+	}
+
+	return PlayerQuestProgression{
+		StartedAt:   p.StartedAt,
+		UpdatedAt:   p.UpdatedAt,
+		PlayerID:    p.PlayerID,
+		CompletedAt: completedAt,
+		Quest: Quest{
+			CreatedAt:   p.Quest.CreatedAt,
+			UpdatedAt:   p.Quest.UpdatedAt,
+			DeletedAt:   questDeletedAt,
+			ID:          p.Quest.ID,
+			GameID:      p.Quest.GameID,
+			Name:        p.Quest.Name,
+			Description: p.Quest.Description,
+			Tasks:       tasks,
+		},
+		TasksProgression: tasksProgression,
+	}
+}
+
+func QuestRoutingKey(gameID, questID string) string {
+	return fmt.Sprintf("game.%s.quest.%s", gameID, questID)
+}
+
+func FromQuestLifecycleEvent(e quest.LifecycleEvent) QuestLifecycle {
+	var taskID *string
+	if e.TaskID != "" {
+		taskID = &e.TaskID
+	}
+
+	return QuestLifecycle{
+		OccurredAt: e.OccurredAt,
+		Event:      e.Event,
+		GameID:     e.GameID,
+		PlayerID:   e.PlayerID,
+		QuestID:    e.QuestID,
+		TaskID:     taskID,
+	}
+}
+
+// Routes as `game.<game id>.quest.<quest id>.player.<player id>.<event>`
+func QuestLifecycleRoutingKey(e quest.LifecycleEvent) string {
+	return fmt.Sprintf("game.%s.quest.%s.player.%s.%s", e.GameID, e.QuestID, e.PlayerID, strings.ToLower(e.Event))
+}
@@ -0,0 +1,53 @@
+package message
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/reward"
+)
+
+type (
+	Reward struct {
+		Item     string `json:"item"`
+		Quantity int64  `json:"quantity"`
+	}
+
+	// Published on the player destination for the game's economy to grant the rewards.
+	// The grant ID is the same on every attempt, so consumers can use it to deduplicate
+	RewardGrant struct {
+		OccurredAt time.Time `json:"occurredAt"`
+		GrantID    string    `json:"grantId"`
+		GameID     string    `json:"gameId"`
+		PlayerID   string    `json:"playerId"`
+		RuleID     string    `json:"ruleId"`
+		Source     string    `json:"source"`
+		SourceID   string    `json:"sourceId"`
+		Position   int64     `json:"position,omitempty"`
+		Rewards    []Reward  `json:"rewards"`
+	}
+)
+
+func FromRewardGrant(grant reward.Grant) RewardGrant {
+	rewards := make([]Reward, len(grant.Rewards))
+	for i, r := range grant.Rewards {
+		rewards[i] = Reward{Item: r.Item, Quantity: r.Quantity}
+	}
+
+	return RewardGrant{
+		OccurredAt: grant.CreatedAt,
+		GrantID:    grant.ID,
+		GameID:     grant.GameID,
+		PlayerID:   grant.PlayerID,
+		RuleID:     grant.RuleID,
+		Source:     grant.Source,
+		SourceID:   grant.SourceID,
+		Position:   grant.Position,
+		Rewards:    rewards,
+	}
+}
+
+// Routes as `game.<game id>.player.<player id>.reward.<grant id>`
+func RewardGrantRoutingKey(gameID, playerID, grantID string) string {
+	return fmt.Sprintf("game.%s.player.%s.reward.%s", gameID, playerID, grantID)
+}
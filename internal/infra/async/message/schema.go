@@ -0,0 +1,209 @@
+package message
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Schemas of the published and consumed messages
+const (
+	SchemaPlayerStatistic     = "gameblitz.statistic.player_progression"
+	SchemaPlayerQuest         = "gameblitz.quest.player_progression"
+	SchemaDataChange          = "gameblitz.datachange"
+	SchemaIngestion           = "gameblitz.ingestion"
+	SchemaLeaderboard         = "gameblitz.leaderboard.lifecycle"
+	SchemaQuestLifecycle      = "gameblitz.quest.lifecycle"
+	SchemaPlayerErasure       = "gameblitz.player.erasure"
+	SchemaPlayerAnonymization = "gameblitz.player.anonymization"
+	SchemaPlayerAchievement   = "gameblitz.player.achievement"
+	SchemaPlayerLevelUp       = "gameblitz.player.level_up"
+	SchemaRewardGrant         = "gameblitz.player.reward_grant"
+)
+
+// Fields added to every message, identifying its schema
+const (
+	schemaField        = "schema"
+	schemaVersionField = "schemaVersion"
+)
+
+// Messages published before the schemas were versioned have no version and match the first one
+const legacySchemaVersion = 1
+
+var (
+	ErrUnknownSchema            = errors.New("unknown message schema")
+	ErrUnsupportedSchemaVersion = errors.New("unsupported message schema version")
+	ErrSchemaValidation         = errors.New("message does not match its schema")
+)
+
+type (
+	// Converts the fields of a message on the previous version to the next one
+	upconverter func(fields map[string]json.RawMessage) (map[string]json.RawMessage, error)
+
+	schema struct {
+		Version      int                 // Current version, the one published
+		Required     []string            // Fields that must be present and not null
+		Upconverters map[int]upconverter // Keyed by the version they convert from
+	}
+
+	registry map[string]schema
+)
+
+// Every message schema and its version. A breaking change on a message bumps its version
+// and registers an upconverter from the previous one, so older messages can still be consumed
+var schemas = registry{
+	SchemaPlayerStatistic: {
+		Version:  1,
+		Required: []string{"startedAt", "updatedAt", "playerId", "statisticId", "landmarks", "lastUpdate"},
+	},
+	SchemaPlayerQuest: {
+		Version:  1,
+		Required: []string{"startedAt", "updatedAt", "playerId", "quest", "tasksProgression"},
+	},
+	SchemaDataChange: {
+		Version:  1,
+		Required: []string{"occurredAt", "entity", "entityId", "gameId", "operation"},
+	},
+	SchemaIngestion: {
+		Version:  1,
+		Required: []string{"id", "kind", "gameId", "payload"},
+	},
+	SchemaLeaderboard: {
+		Version:  1,
+		Required: []string{"occurredAt", "event", "id", "gameId"},
+	},
+	SchemaQuestLifecycle: {
+		Version:  1,
+		Required: []string{"occurredAt", "event", "gameId", "playerId", "questId"},
+	},
+	SchemaPlayerErasure: {
+		Version:  1,
+		Required: []string{"occurredAt", "gameId", "playerId", "receiptId", "erasures"},
+	},
+	SchemaPlayerAnonymization: {
+		Version:  1,
+		Required: []string{"occurredAt", "gameId", "playerId", "pseudonym", "receiptId", "anonymizations"},
+	},
+	SchemaPlayerAchievement: {
+		Version:  1,
+		Required: []string{"occurredAt", "gameId", "playerId", "achievementId", "name", "trigger"},
+	},
+	SchemaPlayerLevelUp: {
+		Version:  1,
+		Required: []string{"occurredAt", "gameId", "playerId", "curveId", "curveName", "statisticId", "previousLevel", "level", "xp"},
+	},
+	SchemaRewardGrant: {
+		Version:  1,
+		Required: []string{"occurredAt", "grantId", "gameId", "playerId", "ruleId", "source", "sourceId", "rewards"},
+	},
+}
+
+func (s schema) validate(fields map[string]json.RawMessage) error {
+	missing := make([]error, 0)
+	for _, field := range s.Required {
+		if value, ok := fields[field]; !ok || string(value) == "null" {
+			missing = append(missing, fmt.Errorf("missing field %q", field))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %w", ErrSchemaValidation, errors.Join(missing...))
+	}
+
+	return nil
+}
+
+func (r registry) encode(name string, v any) ([]byte, error) {
+	s, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSchema, name)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if err := s.validate(fields); err != nil {
+		return nil, err
+	}
+
+	fields[schemaField], _ = json.Marshal(name)
+	fields[schemaVersionField], _ = json.Marshal(s.Version)
+
+	return json.Marshal(fields)
+}
+
+func (r registry) decode(name string, data []byte, v any) error {
+	s, ok := r[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSchema, name)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("%w: %w", ErrSchemaValidation, err)
+	}
+
+	fields, err := unwrapCloudEvent(name, fields)
+	if err != nil {
+		return err
+	}
+
+	if raw, ok := fields[schemaField]; ok {
+		var received string
+		if err := json.Unmarshal(raw, &received); err != nil || received != name {
+			return fmt.Errorf("%w: expected %s, received %s", ErrUnknownSchema, name, raw)
+		}
+	}
+
+	version := legacySchemaVersion
+	if raw, ok := fields[schemaVersionField]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return fmt.Errorf("%w: %s", ErrUnsupportedSchemaVersion, raw)
+		}
+	}
+
+	if version > s.Version || version < legacySchemaVersion {
+		return fmt.Errorf("%w: %s version %d", ErrUnsupportedSchemaVersion, name, version)
+	}
+
+	for ; version < s.Version; version++ {
+		upconvert, ok := s.Upconverters[version]
+		if !ok {
+			return fmt.Errorf("%w: %s version %d", ErrUnsupportedSchemaVersion, name, version)
+		}
+
+		var err error
+		if fields, err = upconvert(fields); err != nil {
+			return fmt.Errorf("%w: %w", ErrSchemaValidation, err)
+		}
+	}
+
+	if err := s.validate(fields); err != nil {
+		return err
+	}
+
+	if data, err = json.Marshal(fields); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// Marshals the message tagged with the current version of its schema, after checking it matches the schema
+func Encode(name string, v any) ([]byte, error) {
+	return schemas.encode(name, v)
+}
+
+// Unmarshals the message into `v`, upconverting it when on an older version of its schema.
+// Messages on a newer version than the known one are rejected, since they can not be read safely.
+// Messages wrapped on a CloudEvent are unwrapped from its `data`, with its `type` as the schema
+func Decode(name string, data []byte, v any) error {
+	return schemas.decode(name, data, v)
+}
@@ -0,0 +1,112 @@
+package message
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMessage struct {
+	ID    string `json:"id"`
+	Value int    `json:"value"`
+}
+
+// Version 2 renamed `amount` to `value`
+var testSchemas = registry{
+	"test": {
+		Version:  2,
+		Required: []string{"id", "value"},
+		Upconverters: map[int]upconverter{
+			1: func(fields map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+				amount, ok := fields["amount"]
+				if !ok {
+					return nil, errors.New("missing amount")
+				}
+
+				fields["value"] = amount
+				delete(fields, "amount")
+				return fields, nil
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		data, err := testSchemas.encode("test", testMessage{ID: "1", Value: 10})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"id": "1", "value": 10, "schema": "test", "schemaVersion": 2}`, string(data))
+	})
+
+	t.Run("Unknown Schema", func(t *testing.T) {
+		_, err := testSchemas.encode("unknown", testMessage{})
+		assert.ErrorIs(t, err, ErrUnknownSchema)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := testSchemas.encode("test", struct {
+			ID string `json:"id"`
+		}{ID: "1"})
+		assert.ErrorIs(t, err, ErrSchemaValidation)
+	})
+
+	t.Run("Registered Schemas", func(t *testing.T) {
+		_, err := Encode(SchemaDataChange, DataChange{Entity: "STATISTIC", EntityID: "1", Operation: "CREATED"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"id": "1", "value": 10, "schema": "test", "schemaVersion": 2}`), &msg)
+		assert.NoError(t, err)
+		assert.Equal(t, testMessage{ID: "1", Value: 10}, msg)
+	})
+
+	t.Run("Upconverted", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"id": "1", "amount": 10, "schema": "test", "schemaVersion": 1}`), &msg)
+		assert.NoError(t, err)
+		assert.Equal(t, testMessage{ID: "1", Value: 10}, msg)
+	})
+
+	t.Run("Without Version", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"id": "1", "amount": 10}`), &msg)
+		assert.NoError(t, err)
+		assert.Equal(t, testMessage{ID: "1", Value: 10}, msg)
+	})
+
+	t.Run("Newer Version", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"id": "1", "value": 10, "schema": "test", "schemaVersion": 3}`), &msg)
+		assert.ErrorIs(t, err, ErrUnsupportedSchemaVersion)
+	})
+
+	t.Run("Schema Mismatch", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"id": "1", "value": 10, "schema": "other", "schemaVersion": 2}`), &msg)
+		assert.ErrorIs(t, err, ErrUnknownSchema)
+	})
+
+	t.Run("Upconverter Error", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"id": "1", "schemaVersion": 1}`), &msg)
+		assert.ErrorIs(t, err, ErrSchemaValidation)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{"id": null, "value": 10, "schemaVersion": 2}`), &msg)
+		assert.ErrorIs(t, err, ErrSchemaValidation)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		var msg testMessage
+		err := testSchemas.decode("test", []byte(`{`), &msg)
+		assert.ErrorIs(t, err, ErrSchemaValidation)
+	})
+}
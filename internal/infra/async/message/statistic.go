@@ -0,0 +1,99 @@
+package message
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Destination (exchange, topic or subject) of the statistic events
+const StatisticDestination = "gameblitz.statistic"
+
+type (
+	PlayerStatisticLandmarksUpdates struct {
+		Value       float64   `json:"value"`
+		CompletedAt time.Time `json:"completedAt"`
+	}
+
+	PlayerStatisticUpdates struct {
+		GoalJustCompleted      bool                              `json:"goalJustCompleted"`
+		GoalCompletedAt        *time.Time                        `json:"goalCompletedAt"`
+		LandmarksJustCompleted []PlayerStatisticLandmarksUpdates `json:"landmarksJustCompleted"`
+	}
+
+	PlayerStatisticLandmark struct {
+		Value       float64    `json:"value"`
+		Completed   bool       `json:"completed"`
+		CompletedAt *time.Time `json:"completedAt"`
+	}
+
+	PlayerStatistic struct {
+		StartedAt       time.Time                 `json:"startedAt"`
+		UpdatedAt       time.Time                 `json:"updatedAt"`
+		PlayerID        string                    `json:"playerId"`
+		StatisticID     string                    `json:"statisticId"`
+		CurrentValue    *float64                  `json:"currentValue"`
+		GoalValue       *float64                  `json:"goalValue"`
+		GoalCompleted   *bool                     `json:"goalCompleted"`
+		GoalCompletedAt *time.Time                `json:"goalCompletedAt"`
+		Landmarks       []PlayerStatisticLandmark `json:"landmarks"`
+		LastUpdate      PlayerStatisticUpdates    `json:"lastUpdate"`
+	}
+)
+
+func FromPlayerStatisticUpdates(progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) PlayerStatistic {
+	landmarks := make([]PlayerStatisticLandmark, len(progression.Landmarks))
+	for i, landmark := range progression.Landmarks {
+		var landmarkCompletedAt *time.Time
+		if !landmark.CompletedAt.IsZero() {
+			tmp := landmark.CompletedAt
+			landmarkCompletedAt = &tmp
+		}
+
+		landmarks[i] = PlayerStatisticLandmark{
+			Value:       landmark.Value,
+			Completed:   landmark.Completed,
+			CompletedAt: landmarkCompletedAt,
+		}
+	}
+
+	landmarksUpdates := make([]PlayerStatisticLandmarksUpdates, len(updates.LandmarksJustCompleted))
+	for i, landmark := range updates.LandmarksJustCompleted {
+		landmarksUpdates[i] = PlayerStatisticLandmarksUpdates{
+			Value:       landmark.Value,
+			CompletedAt: landmark.CompletedAt,
+		}
+	}
+
+	var progressionGoalCompletedAt *time.Time
+	if !progression.GoalCompletedAt.IsZero() {
+		progressionGoalCompletedAt = &progression.GoalCompletedAt
+	}
+
+	var updatesGoalCompletedAt *time.Time
+	if !updates.GoalCompletedAt.IsZero() {
+		updatesGoalCompletedAt = &updates.GoalCompletedAt
+	}
+
+	return PlayerStatistic{
+		StartedAt:       progression.StartedAt,
+		UpdatedAt:       progression.UpdatedAt,
+		PlayerID:        progression.PlayerID,
+		StatisticID:     progression.StatisticID,
+		CurrentValue:    progression.CurrentValue,
+		GoalValue:       progression.GoalValue,
+		GoalCompleted:   progression.GoalCompleted,
+		GoalCompletedAt: progressionGoalCompletedAt,
+		Landmarks:       landmarks,
+		LastUpdate: PlayerStatisticUpdates{
+			GoalJustCompleted:      updates.GoalJustCompleted,
+			GoalCompletedAt:        updatesGoalCompletedAt,
+			LandmarksJustCompleted: landmarksUpdates,
+		},
+	}
+}
+
+func StatisticRoutingKey(gameID, statisticID string) string {
+	return fmt.Sprintf("game.%s.statistic.%s", gameID, statisticID)
+}
@@ -0,0 +1,31 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/reward"
+)
+
+func (p producer) PlayerErased(ctx context.Context, playerID string, receipt privacy.Receipt) error {
+	return p.publish(ctx, message.PlayerDestination, message.PlayerErasureRoutingKey(receipt.GameID, playerID), message.SchemaPlayerErasure, message.FromPlayerErasure(playerID, receipt))
+}
+
+func (p producer) PlayerAnonymized(ctx context.Context, playerID string, receipt privacy.AnonymizationReceipt) error {
+	return p.publish(ctx, message.PlayerDestination, message.PlayerAnonymizationRoutingKey(receipt.GameID, playerID), message.SchemaPlayerAnonymization, message.FromPlayerAnonymization(playerID, receipt))
+}
+
+func (p producer) AchievementUnlocked(ctx context.Context, unlocked achievement.PlayerAchievement) error {
+	return p.publish(ctx, message.PlayerDestination, message.AchievementUnlockedRoutingKey(unlocked.Achievement.GameID, unlocked.PlayerID, unlocked.Achievement.ID), message.SchemaPlayerAchievement, message.FromAchievementUnlocked(unlocked))
+}
+
+func (p producer) GrantRewards(ctx context.Context, grant reward.Grant) error {
+	return p.publish(ctx, message.PlayerDestination, message.RewardGrantRoutingKey(grant.GameID, grant.PlayerID, grant.ID), message.SchemaRewardGrant, message.FromRewardGrant(grant))
+}
+
+func (p producer) LevelUp(ctx context.Context, up level.LevelUp) error {
+	return p.publish(ctx, message.PlayerDestination, message.LevelUpRoutingKey(up.Curve.GameID, up.PlayerID, up.Curve.ID), message.SchemaPlayerLevelUp, message.FromLevelUp(up))
+}
@@ -0,0 +1,161 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/tracing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type Options struct {
+	URL            string          // NATS server URL
+	StreamReplicas int             // Replicas of each stream. Defaults to 1
+	StreamMaxAge   time.Duration   // Time the events are kept on the streams. Zero keeps them until removed by the limits
+	Encoder        message.Encoder // Envelope of the published events. The zero value publishes the plain events
+	Retry          backoff.Config  // Retry policy used while the server is not reachable at startup
+
+	// Durable consumers created for the subscribers, by name, with the subject they receive, e.g. `gameblitz.statistic.>`.
+	// Their events are kept until acknowledged, even while the subscriber is offline. Optional
+	Consumers          map[string]string
+	ConsumerAckWait    time.Duration // Time a delivered event waits for the ack before redelivered. Zero keeps the server default
+	ConsumerMaxDeliver int           // Deliveries of an event before it is given up. Zero retries it forever
+}
+
+// Publishes the events to JetStream, on one stream per destination. The subject is the destination
+// followed by the routing key, e.g. `gameblitz.statistic.game.<game id>.statistic.<statistic id>`,
+// so consumers can filter it with the same wildcards used on the RabbitMQ bindings
+type producer struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	encoder message.Encoder
+}
+
+// Streams names can not hold dots, e.g. `gameblitz.statistic` is stored on `GAMEBLITZ_STATISTIC`
+func buildStreamName(destination string) string {
+	return strings.ToUpper(strings.ReplaceAll(destination, ".", "_"))
+}
+
+func buildSubject(destination, routingKey string) string {
+	return destination + "." + routingKey
+}
+
+func (p producer) ensureStreams(ctx context.Context, opts Options) error {
+	replicas := opts.StreamReplicas
+	if replicas <= 0 {
+		replicas = 1
+	}
+
+	for _, destination := range []string{message.StatisticDestination, message.QuestDestination, message.DataChangeDestination, message.LeaderboardDestination, message.PlayerDestination} {
+		_, err := p.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+			Name:     buildStreamName(destination),
+			Subjects: []string{destination + ".>"},
+			Storage:  jetstream.FileStorage,
+			Replicas: replicas,
+			MaxAge:   opts.StreamMaxAge,
+		})
+		if err != nil {
+			return fmt.Errorf("%s stream: %w", destination, err)
+		}
+	}
+
+	return nil
+}
+
+// Creates the durable consumers, on the stream that holds their subject. Each event must be acked
+// by the subscriber, and a nack or a missing ack within the ack wait makes the server redeliver it
+func (p producer) ensureConsumers(ctx context.Context, opts Options) error {
+	for name, subject := range opts.Consumers {
+		stream, err := p.js.StreamNameBySubject(ctx, subject)
+		if err != nil {
+			return fmt.Errorf("%s consumer: %w", name, err)
+		}
+
+		_, err = p.js.CreateOrUpdateConsumer(ctx, stream, jetstream.ConsumerConfig{
+			Durable:       name,
+			FilterSubject: subject,
+			AckPolicy:     jetstream.AckExplicitPolicy,
+			AckWait:       opts.ConsumerAckWait,
+			MaxDeliver:    opts.ConsumerMaxDeliver,
+		})
+		if err != nil {
+			return fmt.Errorf("%s consumer: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Waits for the stream to acknowledge the event, so it is only reported as published once stored.
+// The trace context is sent on the headers, so the subscribers join the trace of the request that changed the data
+func (p producer) publish(ctx context.Context, destination, routingKey, schema string, value any) (err error) {
+	header := nats.Header{"Content-Type": []string{p.encoder.ContentType()}}
+
+	ctx, span := tracing.StartPublish(ctx, messagingSystem, destination, headerCarrier(header))
+	defer func() { tracing.End(span, err) }()
+
+	body, err := p.encoder.Encode(schema, routingKey, value)
+	if err != nil {
+		return err
+	}
+
+	msg := &nats.Msg{
+		Subject: buildSubject(destination, routingKey),
+		Header:  header,
+		Data:    body,
+	}
+
+	_, err = p.js.PublishMsg(ctx, msg, jetstream.WithExpectStream(buildStreamName(destination)))
+	return err
+}
+
+// Round trips to the server, so a stale connection is reported too
+func (p producer) Ping(ctx context.Context) error {
+	return p.conn.FlushWithContext(ctx)
+}
+
+func (p producer) Close() {
+	p.conn.Close()
+}
+
+func NewProducer(ctx context.Context, opts Options) (*producer, error) {
+	var conn *nats.Conn
+	err := backoff.Retry(ctx, opts.Retry, func(ctx context.Context) error {
+		var err error
+		conn, err = nats.Connect(opts.URL)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	p := &producer{
+		conn:    conn,
+		js:      js,
+		encoder: opts.Encoder,
+	}
+
+	if err := p.ensureStreams(ctx, opts); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := p.ensureConsumers(ctx, opts); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
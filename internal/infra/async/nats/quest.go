@@ -0,0 +1,17 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/quest"
+)
+
+func (p producer) PlayerQuestProgressionUpdates(ctx context.Context, progression quest.PlayerQuestProgression) error {
+	routingKey := message.QuestRoutingKey(progression.Quest.GameID, progression.Quest.ID)
+	return p.publish(ctx, message.QuestDestination, routingKey, message.SchemaPlayerQuest, message.FromPlayerQuestProgression(progression))
+}
+
+func (p producer) QuestLifecycleEvent(ctx context.Context, event quest.LifecycleEvent) error {
+	return p.publish(ctx, message.QuestDestination, message.QuestLifecycleRoutingKey(event), message.SchemaQuestLifecycle, message.FromQuestLifecycleEvent(event))
+}
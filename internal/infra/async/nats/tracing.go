@@ -0,0 +1,28 @@
+package nats
+
+import (
+	"github.com/nats-io/nats.go"
+)
+
+const messagingSystem = "nats"
+
+// Carries the trace context on the message headers. Unlike the HTTP headers, their keys are case sensitive
+// and kept as written, e.g. `traceparent`
+type headerCarrier nats.Header
+
+func (c headerCarrier) Get(key string) string {
+	return nats.Header(c).Get(key)
+}
+
+func (c headerCarrier) Set(key, value string) {
+	nats.Header(c).Set(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,431 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/tracing"
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	ingestionQueue = "gameblitz.ingestion"
+
+	// Queues without consumers holding the failed messages until their expiration,
+	// when they are dead lettered back to the ingestion exchange. There is one queue per attempt,
+	// since RabbitMQ only expires the message on the head of the queue and a long delay
+	// would hold the shorter ones behind it
+	ingestionRetryQueuePrefix = "gameblitz.ingestion.retry."
+
+	// Header holding how many times the message processing already failed
+	attemptsHeader = "x-attempts"
+)
+
+var ErrConsumerClosed = errors.New("consumer channel closed")
+
+type ConsumerOptions struct {
+	BatchSize   int            // Messages processed together. Less than 2 processes each message on its own
+	BatchWait   time.Duration  // Max wait for a batch to be filled after its first message arrived
+	Workers     int            // Batches processed at the same time. A single worker keeps the delivery order
+	KindWorkers map[string]int // Message kinds processed on their own lane, by the given number of workers
+	Prefetch    int            // Deliveries sent by the broker before they are acked. Zero uses the workers times the batch size
+	RetryDelay  backoff.Config // Wait before consuming a failed message again
+	Retry       backoff.Config // Retry policy used while the broker is not reachable at startup
+}
+
+type consumer struct {
+	conn *amqp.Connection
+	ch   *amqp.Channel
+
+	batchSize   int
+	batchWait   time.Duration
+	workers     int
+	kindWorkers map[string]int
+	prefetch    int
+	retryDelay  backoff.Config
+
+	mu          sync.Mutex
+	retryQueues map[string]bool // Retry queues already declared
+}
+
+// Delivery decoded, waiting on a lane to be processed
+type pendingDelivery struct {
+	d   amqp.Delivery
+	msg ingestion.Message
+}
+
+func (c *consumer) ensureIngestionQueue() error {
+	var (
+		kind       = "topic"
+		durable    = true
+		autoDelete = false
+		internal   = false
+		exclusive  = false
+		noWait     = false
+	)
+
+	if err := c.ch.ExchangeDeclare(message.IngestionDestination, kind, durable, autoDelete, internal, noWait, nil); err != nil {
+		return err
+	}
+
+	if _, err := c.ch.QueueDeclare(ingestionQueue, durable, autoDelete, exclusive, noWait, nil); err != nil {
+		return err
+	}
+
+	return c.ch.QueueBind(ingestionQueue, "#", message.IngestionDestination, noWait, nil)
+}
+
+func (c *consumer) ensureRetryQueue(attempts int) (string, error) {
+	queue := ingestionRetryQueuePrefix + strconv.Itoa(attempts)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.retryQueues[queue] {
+		return queue, nil
+	}
+
+	var (
+		durable    = true
+		autoDelete = false
+		exclusive  = false
+		noWait     = false
+		args       = amqp.Table{"x-dead-letter-exchange": message.IngestionDestination}
+	)
+
+	if _, err := c.ch.QueueDeclare(queue, durable, autoDelete, exclusive, noWait, args); err != nil {
+		return "", err
+	}
+
+	c.retryQueues[queue] = true
+	return queue, nil
+}
+
+func attemptsFromHeaders(headers amqp.Table) int {
+	switch attempts := headers[attemptsHeader].(type) {
+	case int32:
+		return int(attempts)
+	case int64:
+		return int(attempts)
+	default:
+		return 0
+	}
+}
+
+// Messages that can not be decoded keep the raw body as payload, so they end up on the dead letters
+func messageFromDelivery(d amqp.Delivery) ingestion.Message {
+	var body message.Ingestion
+	if err := message.Decode(message.SchemaIngestion, d.Body, &body); err != nil {
+		zap.Error(err, "ingestion message decoding failed", "id", d.MessageId)
+		return ingestion.Message{ID: d.MessageId, Payload: d.Body, Attempts: attemptsFromHeaders(d.Headers)}
+	}
+
+	return body.ToDomain(attemptsFromHeaders(d.Headers))
+}
+
+// Messages published to a retry queue have no exchange, so their span is named after the queue
+func (c *consumer) publish(ctx context.Context, exchange, routingKey, expiration string, msg ingestion.Message) (err error) {
+	var (
+		mandatory   = false
+		immediate   = false
+		headers     = amqp.Table{attemptsHeader: int32(msg.Attempts)}
+		destination = exchange
+	)
+
+	if destination == "" {
+		destination = routingKey
+	}
+
+	ctx, span := tracing.StartPublish(ctx, messagingSystem, destination, headerCarrier(headers))
+	defer func() { tracing.End(span, err) }()
+
+	body, err := message.Encode(message.SchemaIngestion, message.FromIngestion(msg))
+	if err != nil {
+		return err
+	}
+
+	return c.ch.PublishWithContext(ctx, exchange, routingKey, mandatory, immediate, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    msg.ID,
+		Expiration:   expiration,
+		Headers:      headers,
+		Body:         body,
+	})
+}
+
+func (c *consumer) PublishIngestion(ctx context.Context, msg ingestion.Message) error {
+	return c.publish(ctx, message.IngestionDestination, message.IngestionRoutingKey(msg.GameID, msg.Kind), "", msg)
+}
+
+// Publishes the message to the retry queue of its attempt, to be consumed again once the backoff delay is over
+func (c *consumer) scheduleRetry(ctx context.Context, msg ingestion.Message) error {
+	queue, err := c.ensureRetryQueue(msg.Attempts)
+	if err != nil {
+		return err
+	}
+
+	delay := backoff.Delay(c.retryDelay, msg.Attempts)
+	return c.publish(ctx, "", queue, fmt.Sprint(delay.Milliseconds()), msg)
+}
+
+// Failed messages are scheduled for a delayed retry with their attempts incremented,
+// since a requeue would lose the count and redeliver the message right away
+func (c *consumer) settleDelivery(ctx context.Context, d amqp.Delivery, msg ingestion.Message, err error) {
+	if err != nil {
+		zap.Error(err, "ingestion message processing failed", "id", msg.ID, "attempts", msg.Attempts+1)
+
+		msg.Attempts++
+		if err := c.scheduleRetry(ctx, msg); err != nil {
+			zap.Error(err, "ingestion message retry failed", "id", msg.ID)
+			_ = d.Nack(false, true)
+			return
+		}
+	}
+
+	if err := d.Ack(false); err != nil {
+		zap.Error(err, "ingestion message ack failed", "id", msg.ID)
+	}
+}
+
+func (c *consumer) handleBatch(ctx context.Context, batch []pendingDelivery, processBatchFunc ingestion.ProcessBatchFunc) {
+	var (
+		msgs     = make([]ingestion.Message, len(batch))
+		carriers = make([]propagation.TextMapCarrier, len(batch))
+	)
+	for i, p := range batch {
+		msgs[i] = p.msg
+		carriers[i] = headerCarrier(p.d.Headers)
+	}
+
+	ctx, span := tracing.StartProcess(ctx, messagingSystem, ingestionQueue, carriers)
+
+	errs := processBatchFunc(ctx, msgs)
+	for i, p := range batch {
+		c.settleDelivery(ctx, p.d, p.msg, errs[i])
+	}
+
+	tracing.End(span, errors.Join(errs...))
+}
+
+// Waits for the first delivery on the lane and gathers the next ones until the batch is full or the batch wait is over.
+// The deliveries gathered when the context is canceled are left unacknowledged, so the broker delivers them again
+func (c *consumer) nextBatch(ctx context.Context, lane <-chan pendingDelivery) []pendingDelivery {
+	batch := make([]pendingDelivery, 0, c.batchSize)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case p := <-lane:
+		batch = append(batch, p)
+	}
+
+	timer := time.NewTimer(c.batchWait)
+	defer timer.Stop()
+
+	for len(batch) < c.batchSize {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+			return batch
+		case p := <-lane:
+			batch = append(batch, p)
+		}
+	}
+
+	return batch
+}
+
+func (c *consumer) work(ctx context.Context, lane <-chan pendingDelivery, processBatchFunc ingestion.ProcessBatchFunc) {
+	for {
+		batch := c.nextBatch(ctx, lane)
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.handleBatch(ctx, batch, processBatchFunc)
+	}
+}
+
+// Consumes the ingestion queue until the context is canceled. The deliveries are dispatched to lanes by their kind:
+// the kinds on `KindWorkers` have a lane of their own, while the others share the default one. A lane with a single
+// worker keeps its messages in the delivery order, apart from the retried ones
+func (c *consumer) Consume(ctx context.Context, processBatchFunc ingestion.ProcessBatchFunc) error {
+	var (
+		prefetchSize = 0
+		global       = false
+
+		consumerTag = ""
+		autoAck     = false
+		exclusive   = false
+		noLocal     = false
+		noWait      = false
+	)
+
+	if err := c.ch.Qos(c.prefetch, prefetchSize, global); err != nil {
+		return err
+	}
+
+	deliveries, err := c.ch.ConsumeWithContext(ctx, ingestionQueue, consumerTag, autoAck, exclusive, noLocal, noWait, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+
+	// Lanes hold up to the prefetch, so a busy lane never holds the deliveries of the others
+	newLane := func(workers int) chan pendingDelivery {
+		lane := make(chan pendingDelivery, c.prefetch)
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.work(ctx, lane, processBatchFunc)
+			}()
+		}
+
+		return lane
+	}
+
+	var (
+		defaultLane = newLane(c.workers)
+		lanes       = make(map[string]chan pendingDelivery, len(c.kindWorkers))
+	)
+	for kind, workers := range c.kindWorkers {
+		lanes[kind] = newLane(workers)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case d, ok := <-deliveries:
+			if !ok {
+				return ErrConsumerClosed
+			}
+
+			p := pendingDelivery{d: d, msg: messageFromDelivery(d)}
+
+			lane, ok := lanes[p.msg.Kind]
+			if !ok {
+				lane = defaultLane
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case lane <- p:
+			}
+		}
+	}
+}
+
+// Counts the ready messages of the ingestion queue and of the retry queues declared so far. The queues are
+// inspected on a channel of their own, since a failed passive declare closes the channel it was made on
+func (c *consumer) Backlog(ctx context.Context) ([]ingestion.Backlog, error) {
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	defer ch.Close()
+
+	c.mu.Lock()
+	queues := make([]string, 0, len(c.retryQueues)+1)
+	queues = append(queues, ingestionQueue)
+	for queue := range c.retryQueues {
+		queues = append(queues, queue)
+	}
+	c.mu.Unlock()
+
+	slices.Sort(queues[1:])
+
+	var (
+		durable    = true
+		autoDelete = false
+		exclusive  = false
+		noWait     = false
+	)
+
+	backlogs := make([]ingestion.Backlog, 0, len(queues))
+	for _, queue := range queues {
+		q, err := ch.QueueDeclarePassive(queue, durable, autoDelete, exclusive, noWait, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		backlogs = append(backlogs, ingestion.Backlog{Queue: queue, Messages: int64(q.Messages)})
+	}
+
+	return backlogs, nil
+}
+
+func (c *consumer) Ping(ctx context.Context) error {
+	if c.conn.IsClosed() {
+		return amqp.ErrClosed
+	}
+
+	return nil
+}
+
+func (c *consumer) Close() {
+	defer c.conn.Close()
+	defer c.ch.Close()
+}
+
+func NewConsumer(ctx context.Context, rabbitmqURI string, opts ConsumerOptions) (*consumer, error) {
+	var conn *amqp.Connection
+	err := backoff.Retry(ctx, opts.Retry, func(ctx context.Context) error {
+		var err error
+		conn, err = amqp.Dial(rabbitmqURI)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		defer conn.Close()
+		return nil, err
+	}
+
+	c := &consumer{
+		conn:        conn,
+		ch:          ch,
+		batchSize:   max(opts.BatchSize, 1),
+		batchWait:   opts.BatchWait,
+		workers:     max(opts.Workers, 1),
+		kindWorkers: make(map[string]int, len(opts.KindWorkers)),
+		prefetch:    opts.Prefetch,
+		retryDelay:  opts.RetryDelay,
+		retryQueues: make(map[string]bool),
+	}
+
+	lanesWorkers := c.workers
+	for kind, workers := range opts.KindWorkers {
+		c.kindWorkers[kind] = max(workers, 1)
+		lanesWorkers += c.kindWorkers[kind]
+	}
+
+	if c.prefetch <= 0 {
+		c.prefetch = lanesWorkers * c.batchSize
+	}
+
+	return c, c.ensureIngestionQueue()
+}
@@ -0,0 +1,23 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/datachange"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+)
+
+func (p producer) ensureDataChangeExchange(ctx context.Context) error {
+	return p.declareExchange(ctx, message.DataChangeDestination)
+}
+
+func (p producer) DataChange(ctx context.Context, event datachange.Event) error {
+	routingKey := message.DataChangeRoutingKey(event)
+
+	body, err := p.encoder.Encode(message.SchemaDataChange, routingKey, message.FromDataChange(event))
+	if err != nil {
+		return err
+	}
+
+	return p.publish(ctx, message.DataChangeDestination, routingKey, body)
+}
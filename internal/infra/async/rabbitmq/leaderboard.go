@@ -0,0 +1,23 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+func (p producer) ensureLeaderboardExchange(ctx context.Context) error {
+	return p.declareExchange(ctx, message.LeaderboardDestination)
+}
+
+func (p producer) LeaderboardLifecycleEvent(ctx context.Context, event string, lb leaderboard.Leaderboard) error {
+	routingKey := message.LeaderboardRoutingKey(event, lb)
+
+	body, err := p.encoder.Encode(message.SchemaLeaderboard, routingKey, message.FromLeaderboardEvent(event, lb))
+	if err != nil {
+		return err
+	}
+
+	return p.publish(ctx, message.LeaderboardDestination, routingKey, body)
+}
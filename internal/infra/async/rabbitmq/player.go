@@ -0,0 +1,70 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/reward"
+)
+
+func (p producer) ensurePlayerExchange(ctx context.Context) error {
+	return p.declareExchange(ctx, message.PlayerDestination)
+}
+
+func (p producer) PlayerErased(ctx context.Context, playerID string, receipt privacy.Receipt) error {
+	routingKey := message.PlayerErasureRoutingKey(receipt.GameID, playerID)
+
+	body, err := p.encoder.Encode(message.SchemaPlayerErasure, routingKey, message.FromPlayerErasure(playerID, receipt))
+	if err != nil {
+		return err
+	}
+
+	return p.publish(ctx, message.PlayerDestination, routingKey, body)
+}
+
+func (p producer) PlayerAnonymized(ctx context.Context, playerID string, receipt privacy.AnonymizationReceipt) error {
+	routingKey := message.PlayerAnonymizationRoutingKey(receipt.GameID, playerID)
+
+	body, err := p.encoder.Encode(message.SchemaPlayerAnonymization, routingKey, message.FromPlayerAnonymization(playerID, receipt))
+	if err != nil {
+		return err
+	}
+
+	return p.publish(ctx, message.PlayerDestination, routingKey, body)
+}
+
+func (p producer) AchievementUnlocked(ctx context.Context, unlocked achievement.PlayerAchievement) error {
+	routingKey := message.AchievementUnlockedRoutingKey(unlocked.Achievement.GameID, unlocked.PlayerID, unlocked.Achievement.ID)
+
+	body, err := p.encoder.Encode(message.SchemaPlayerAchievement, routingKey, message.FromAchievementUnlocked(unlocked))
+	if err != nil {
+		return err
+	}
+
+	return p.publish(ctx, message.PlayerDestination, routingKey, body)
+}
+
+func (p producer) GrantRewards(ctx context.Context, grant reward.Grant) error {
+	routingKey := message.RewardGrantRoutingKey(grant.GameID, grant.PlayerID, grant.ID)
+
+	body, err := p.encoder.Encode(message.SchemaRewardGrant, routingKey, message.FromRewardGrant(grant))
+	if err != nil {
+		return err
+	}
+
+	return p.publish(ctx, message.PlayerDestination, routingKey, body)
+}
+
+func (p producer) LevelUp(ctx context.Context, up level.LevelUp) error {
+	routingKey := message.LevelUpRoutingKey(up.Curve.GameID, up.PlayerID, up.Curve.ID)
+
+	body, err := p.encoder.Encode(message.SchemaPlayerLevelUp, routingKey, message.FromLevelUp(up))
+	if err != nil {
+		return err
+	}
+
+	return p.publish(ctx, message.PlayerDestination, routingKey, body)
+}
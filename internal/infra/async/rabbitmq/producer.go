@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/tracing"
+
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 type producer struct {
-	conn *amqp.Connection
-	ch   *amqp.Channel
+	conn    *amqp.Connection
+	ch      *amqp.Channel
+	encoder message.Encoder
 }
 
 func (p producer) declareExchange(ctx context.Context, name string) error {
@@ -34,6 +39,18 @@ func (p producer) ensureExchanges(ctx context.Context) error {
 		return fmt.Errorf("Quest Exchange: %w", err)
 	}
 
+	if err := p.ensureDataChangeExchange(ctx); err != nil {
+		return fmt.Errorf("Data Change Exchange: %w", err)
+	}
+
+	if err := p.ensureLeaderboardExchange(ctx); err != nil {
+		return fmt.Errorf("Leaderboard Exchange: %w", err)
+	}
+
+	if err := p.ensurePlayerExchange(ctx); err != nil {
+		return fmt.Errorf("Player Exchange: %w", err)
+	}
+
 	return nil
 }
 
@@ -55,13 +72,50 @@ func (p producer) getChannel() (*amqp.Channel, error) {
 	return p.ch, nil
 }
 
+// Publishes the event with the trace context on its headers, so the consumers join the trace of the request that changed the data
+func (p producer) publish(ctx context.Context, exchange, routingKey string, body []byte) (err error) {
+	var (
+		mandatory = false
+		immediate = false
+		headers   = amqp.Table{}
+	)
+
+	ctx, span := tracing.StartPublish(ctx, messagingSystem, exchange, headerCarrier(headers))
+	defer func() { tracing.End(span, err) }()
+
+	ch, err := p.getChannel()
+	if err != nil {
+		return err
+	}
+
+	return ch.PublishWithContext(ctx, exchange, routingKey, mandatory, immediate, amqp.Publishing{
+		ContentType: p.encoder.ContentType(),
+		Headers:     headers,
+		Body:        body,
+	})
+}
+
+func (p producer) Ping(ctx context.Context) error {
+	if p.conn.IsClosed() {
+		return amqp.ErrClosed
+	}
+
+	return nil
+}
+
 func (p producer) Close() {
 	defer p.conn.Close()
 	defer p.ch.Close()
 }
 
-func NewProducer(ctx context.Context, rabbitmqURI string) (*producer, error) {
-	conn, err := amqp.Dial(rabbitmqURI)
+// The `encoder` defines the envelope of the published events
+func NewProducer(ctx context.Context, rabbitmqURI string, encoder message.Encoder, retry backoff.Config) (*producer, error) {
+	var conn *amqp.Connection
+	err := backoff.Retry(ctx, retry, func(ctx context.Context) error {
+		var err error
+		conn, err = amqp.Dial(rabbitmqURI)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -73,8 +127,9 @@ func NewProducer(ctx context.Context, rabbitmqURI string) (*producer, error) {
 	}
 
 	p := &producer{
-		conn: conn,
-		ch:   ch,
+		conn:    conn,
+		ch:      ch,
+		encoder: encoder,
 	}
 	return p, p.ensureExchanges(ctx)
 }
@@ -0,0 +1,417 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/tracing"
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// Limits of a single request, set by SQS
+	maxBatchSize         = 10
+	maxWaitTime          = 20 * time.Second
+	maxVisibilityTimeout = 12 * time.Hour
+)
+
+type ConsumerOptions struct {
+	Region            string         // AWS region. Empty keeps the region from the environment
+	Endpoint          string         // Custom endpoint, e.g. LocalStack. Empty uses the AWS endpoint
+	Queue             string         // Ingestion queue name, created when it does not exist
+	BatchSize         int            // Messages received per request, up to 10
+	WaitTime          time.Duration  // Long polling wait of each receive request, up to 20 seconds
+	VisibilityTimeout time.Duration  // Time a received message stays hidden from the other consumers, extended while it waits to be processed
+	ProcessBatchSize  int            // Messages processed together, received with as many requests as needed
+	ProcessBatchWait  time.Duration  // Max wait for the process batch to be filled after its first messages arrived
+	Workers           int            // Batches received and processed at the same time
+	RetryDelay        backoff.Config // Wait before consuming a failed message again
+	Retry             backoff.Config // Retry policy used while the service is not reachable at startup
+}
+
+type consumer struct {
+	client   *sqs.Client
+	queue    string
+	queueURL string
+
+	batchSize         int32
+	waitTime          int32 // In seconds
+	visibilityTimeout int32 // In seconds
+	processBatchSize  int
+	processBatchWait  time.Duration
+	workers           int
+	retryDelay        backoff.Config
+}
+
+// Messages of a received batch still waiting to be processed. Their visibility is extended
+// while the batch is handled, so they are not redelivered to another consumer meanwhile
+type inFlight struct {
+	mu       sync.Mutex
+	messages map[string]types.Message // By receipt handle
+}
+
+func newInFlight(messages []types.Message) *inFlight {
+	f := &inFlight{messages: make(map[string]types.Message, len(messages))}
+	for _, m := range messages {
+		f.messages[aws.ToString(m.ReceiptHandle)] = m
+	}
+
+	return f
+}
+
+func (f *inFlight) remove(m types.Message) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.messages, aws.ToString(m.ReceiptHandle))
+}
+
+// SQS counts every receive, so the failed attempts are the receives before the current one
+func attemptsFromMessage(m types.Message) int {
+	count, err := strconv.Atoi(m.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+	if err != nil || count <= 0 {
+		return 0
+	}
+
+	return count - 1
+}
+
+// Messages that can not be decoded keep the raw body as payload, so they end up on the dead letters
+func messageFromSQS(m types.Message) ingestion.Message {
+	var (
+		id       = aws.ToString(m.MessageId)
+		raw      = []byte(aws.ToString(m.Body))
+		attempts = attemptsFromMessage(m)
+	)
+
+	var body message.Ingestion
+	if err := message.Decode(message.SchemaIngestion, raw, &body); err != nil {
+		zap.Error(err, "ingestion message decoding failed", "id", id)
+		return ingestion.Message{ID: id, Payload: raw, Attempts: attempts}
+	}
+
+	return body.ToDomain(attempts)
+}
+
+func clampSeconds(d, max time.Duration) int32 {
+	return int32(min(d, max) / time.Second)
+}
+
+// Keeps the messages still waiting on the batch hidden, refreshing their visibility timeout
+// at half of it until the batch is done
+func (c consumer) extendVisibility(ctx context.Context, batch *inFlight, done <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(c.visibilityTimeout) * time.Second / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		batch.mu.Lock()
+		entries := make([]types.ChangeMessageVisibilityBatchRequestEntry, 0, len(batch.messages))
+		for receiptHandle := range batch.messages {
+			entries = append(entries, types.ChangeMessageVisibilityBatchRequestEntry{
+				Id:                aws.String(strconv.Itoa(len(entries))),
+				ReceiptHandle:     aws.String(receiptHandle),
+				VisibilityTimeout: c.visibilityTimeout,
+			})
+		}
+
+		for i := 0; i < len(entries); i += maxBatchSize {
+			_, err := c.client.ChangeMessageVisibilityBatch(ctx, &sqs.ChangeMessageVisibilityBatchInput{
+				QueueUrl: aws.String(c.queueURL),
+				Entries:  entries[i:min(i+maxBatchSize, len(entries))],
+			})
+			if err != nil {
+				zap.Error(err, "ingestion message visibility extension failed")
+			}
+		}
+		batch.mu.Unlock()
+	}
+}
+
+// Hides the failed message until the backoff delay is over, when SQS delivers it again
+// with its receive count incremented
+func (c consumer) scheduleRetry(ctx context.Context, m types.Message, attempts int) error {
+	delay := backoff.Delay(c.retryDelay, attempts)
+
+	_, err := c.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(c.queueURL),
+		ReceiptHandle:     m.ReceiptHandle,
+		VisibilityTimeout: clampSeconds(delay, maxVisibilityTimeout),
+	})
+	return err
+}
+
+func (c consumer) deleteMessages(ctx context.Context, messages []types.Message) {
+	if len(messages) == 0 {
+		return
+	}
+
+	entries := make([]types.DeleteMessageBatchRequestEntry, len(messages))
+	for i, m := range messages {
+		entries[i] = types.DeleteMessageBatchRequestEntry{
+			Id:            aws.String(strconv.Itoa(i)),
+			ReceiptHandle: m.ReceiptHandle,
+		}
+	}
+
+	output, err := c.client.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
+		QueueUrl: aws.String(c.queueURL),
+		Entries:  entries,
+	})
+	if err != nil {
+		zap.Error(err, "ingestion messages deletion failed", "messages", len(messages))
+		return
+	}
+
+	for _, failed := range output.Failed {
+		zap.Info("ingestion message deletion failed", "entry", aws.ToString(failed.Id), "code", aws.ToString(failed.Code))
+	}
+}
+
+// Processes the batch, deleting the handled messages at the end. Failed messages are left on the queue
+// with a visibility timeout set to the backoff delay, since a deletion would lose them
+func (c consumer) handleBatch(ctx context.Context, messages []types.Message, processBatchFunc ingestion.ProcessBatchFunc) {
+	batch := newInFlight(messages)
+
+	done := make(chan struct{})
+	defer close(done)
+	go c.extendVisibility(ctx, batch, done)
+
+	var (
+		msgs     = make([]ingestion.Message, len(messages))
+		carriers = make([]propagation.TextMapCarrier, len(messages))
+		errs     []error
+	)
+	for i, m := range messages {
+		msgs[i] = messageFromSQS(m)
+		carriers[i] = queueAttributeCarrier(m.MessageAttributes)
+	}
+
+	ctx, span := tracing.StartProcess(ctx, messagingSystem, c.queueURL, carriers)
+	defer func() { tracing.End(span, errors.Join(errs...)) }()
+
+	errs = processBatchFunc(ctx, msgs)
+
+	processed := make([]types.Message, 0, len(messages))
+	for i, m := range messages {
+		if err := errs[i]; err != nil {
+			zap.Error(err, "ingestion message processing failed", "id", msgs[i].ID, "attempts", msgs[i].Attempts+1)
+
+			batch.remove(m)
+			if err := c.scheduleRetry(ctx, m, msgs[i].Attempts+1); err != nil {
+				zap.Error(err, "ingestion message retry failed", "id", msgs[i].ID)
+			}
+
+			continue
+		}
+
+		processed = append(processed, m)
+	}
+
+	for i := 0; i < len(processed); i += maxBatchSize {
+		c.deleteMessages(ctx, processed[i:min(i+maxBatchSize, len(processed))])
+	}
+}
+
+func (c consumer) receive(ctx context.Context, waitTime int32) ([]types.Message, error) {
+	output, err := c.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:                    aws.String(c.queueURL),
+		MaxNumberOfMessages:         c.batchSize,
+		WaitTimeSeconds:             waitTime,
+		VisibilityTimeout:           c.visibilityTimeout,
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameApproximateReceiveCount},
+		MessageAttributeNames:       otel.GetTextMapPropagator().Fields(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Messages, nil
+}
+
+// Receives messages until the process batch is full, the process batch wait is over or the queue is drained
+func (c consumer) nextBatch(ctx context.Context) ([]types.Message, error) {
+	messages, err := c.receive(ctx, c.waitTime)
+	if err != nil || len(messages) == 0 {
+		return messages, err
+	}
+
+	deadline := time.Now().Add(c.processBatchWait)
+	for len(messages) < c.processBatchSize && time.Now().Before(deadline) {
+		more, err := c.receive(ctx, clampSeconds(time.Until(deadline), maxWaitTime))
+		if err != nil {
+			// The messages already received become visible again once their timeout is over
+			return nil, err
+		}
+
+		if len(more) == 0 {
+			break
+		}
+
+		messages = append(messages, more...)
+	}
+
+	return messages, nil
+}
+
+// The trace context is sent on the message attributes, so the batch processing the message links to the request that sent it
+func (c consumer) PublishIngestion(ctx context.Context, msg ingestion.Message) (err error) {
+	attributes := make(map[string]types.MessageAttributeValue)
+
+	ctx, span := tracing.StartPublish(ctx, messagingSystem, c.queueURL, queueAttributeCarrier(attributes))
+	defer func() { tracing.End(span, err) }()
+
+	body, err := message.Encode(message.SchemaIngestion, message.FromIngestion(msg))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:          aws.String(c.queueURL),
+		MessageBody:       aws.String(string(body)),
+		MessageAttributes: attributes,
+	})
+	return err
+}
+
+func (c consumer) work(ctx context.Context, processBatchFunc ingestion.ProcessBatchFunc) error {
+	for ctx.Err() == nil {
+		messages, err := c.nextBatch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+
+		if len(messages) > 0 {
+			c.handleBatch(ctx, messages, processBatchFunc)
+		}
+	}
+
+	return nil
+}
+
+// Consumes the ingestion queue until the context is canceled, with each worker receiving its own batches.
+// A worker failure stops the others, returning its error
+func (c consumer) Consume(ctx context.Context, processBatchFunc ingestion.ProcessBatchFunc) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, c.workers)
+	for range c.workers {
+		go func() {
+			err := c.work(ctx, processBatchFunc)
+			cancel()
+			errs <- err
+		}()
+	}
+
+	var err error
+	for range c.workers {
+		err = errors.Join(err, <-errs)
+	}
+
+	return err
+}
+
+// SQS only approximates the counts. The delayed messages are the ones waiting for a retry
+func (c consumer) Backlog(ctx context.Context) ([]ingestion.Backlog, error) {
+	attributes := []types.QueueAttributeName{
+		types.QueueAttributeNameApproximateNumberOfMessages,
+		types.QueueAttributeNameApproximateNumberOfMessagesDelayed,
+	}
+
+	output, err := c.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(c.queueURL),
+		AttributeNames: attributes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var messages int64
+	for _, name := range attributes {
+		count, err := strconv.ParseInt(output.Attributes[string(name)], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		messages += count
+	}
+
+	return []ingestion.Backlog{{Queue: c.queue, Messages: messages}}, nil
+}
+
+func (c consumer) Ping(ctx context.Context) error {
+	_, err := c.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{QueueUrl: aws.String(c.queueURL)})
+	return err
+}
+
+func (c consumer) Close() {}
+
+func NewConsumer(ctx context.Context, opts ConsumerOptions) (*consumer, error) {
+	cfg, err := loadConfig(ctx, opts.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	client := sqs.NewFromConfig(cfg, func(o *sqs.Options) {
+		if opts.Endpoint != "" {
+			o.BaseEndpoint = aws.String(opts.Endpoint)
+		}
+	})
+
+	var queueURL string
+	err = backoff.Retry(ctx, opts.Retry, func(ctx context.Context) error {
+		output, err := client.CreateQueue(ctx, &sqs.CreateQueueInput{QueueName: aws.String(opts.Queue)})
+		if err != nil {
+			return err
+		}
+
+		queueURL = aws.ToString(output.QueueUrl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 || batchSize > maxBatchSize {
+		batchSize = maxBatchSize
+	}
+
+	c := &consumer{
+		client:            client,
+		queue:             opts.Queue,
+		queueURL:          queueURL,
+		batchSize:         int32(batchSize),
+		waitTime:          clampSeconds(opts.WaitTime, maxWaitTime),
+		visibilityTimeout: max(clampSeconds(opts.VisibilityTimeout, maxVisibilityTimeout), 1),
+		processBatchSize:  opts.ProcessBatchSize,
+		processBatchWait:  opts.ProcessBatchWait,
+		workers:           max(opts.Workers, 1),
+		retryDelay:        opts.RetryDelay,
+	}
+	return c, nil
+}
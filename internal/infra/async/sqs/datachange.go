@@ -0,0 +1,12 @@
+package sqs
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/datachange"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+)
+
+func (p producer) DataChange(ctx context.Context, event datachange.Event) error {
+	return p.publish(ctx, message.DataChangeDestination, message.DataChangeRoutingKey(event), message.SchemaDataChange, message.FromDataChange(event))
+}
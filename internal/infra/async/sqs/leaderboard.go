@@ -0,0 +1,12 @@
+package sqs
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+func (p producer) LeaderboardLifecycleEvent(ctx context.Context, event string, lb leaderboard.Leaderboard) error {
+	return p.publish(ctx, message.LeaderboardDestination, message.LeaderboardRoutingKey(event, lb), message.SchemaLeaderboard, message.FromLeaderboardEvent(event, lb))
+}
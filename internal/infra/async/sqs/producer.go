@@ -0,0 +1,130 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/tracing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+// Message attributes set on every published event, so the SNS subscriptions can filter them
+// with the same routing keys used on the RabbitMQ bindings, e.g. a prefix match on `game.<game id>.`
+const (
+	routingKeyAttribute  = "routingKey"
+	schemaAttribute      = "schema"
+	contentTypeAttribute = "contentType"
+)
+
+type Options struct {
+	Region   string          // AWS region. Empty keeps the region from the environment
+	Endpoint string          // Custom endpoint, e.g. LocalStack. Empty uses the AWS endpoint
+	Encoder  message.Encoder // Envelope of the published events. The zero value publishes the plain events
+	Retry    backoff.Config  // Retry policy used while the service is not reachable at startup
+}
+
+// Publishes the events to SNS, on one topic per destination. Each subscriber owns an SQS queue
+// subscribed to the topics it needs, so every one of them receives its own copy of the events
+type producer struct {
+	client  *sns.Client
+	topics  map[string]string // Topic ARN by destination
+	encoder message.Encoder
+}
+
+func loadConfig(ctx context.Context, region string) (aws.Config, error) {
+	loadOpts := make([]func(*config.LoadOptions) error, 0)
+	if region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(region))
+	}
+
+	return config.LoadDefaultConfig(ctx, loadOpts...)
+}
+
+// Topic names can not hold dots, e.g. `gameblitz.statistic` is published on `gameblitz-statistic`
+func buildTopicName(destination string) string {
+	return strings.ReplaceAll(destination, ".", "-")
+}
+
+func stringAttribute(value string) types.MessageAttributeValue {
+	return types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+}
+
+// Creates the topics when they do not exist yet, keeping their ARNs to publish the events
+func (p *producer) ensureTopics(ctx context.Context) error {
+	for _, destination := range []string{message.StatisticDestination, message.QuestDestination, message.DataChangeDestination, message.LeaderboardDestination, message.PlayerDestination} {
+		output, err := p.client.CreateTopic(ctx, &sns.CreateTopicInput{Name: aws.String(buildTopicName(destination))})
+		if err != nil {
+			return fmt.Errorf("%s topic: %w", destination, err)
+		}
+
+		p.topics[destination] = aws.ToString(output.TopicArn)
+	}
+
+	return nil
+}
+
+// The trace context is sent on the message attributes, so the subscribers join the trace of the request that changed the data
+func (p producer) publish(ctx context.Context, destination, routingKey, schema string, value any) (err error) {
+	attributes := map[string]types.MessageAttributeValue{
+		routingKeyAttribute:  stringAttribute(routingKey),
+		schemaAttribute:      stringAttribute(schema),
+		contentTypeAttribute: stringAttribute(p.encoder.ContentType()),
+	}
+
+	ctx, span := tracing.StartPublish(ctx, messagingSystem, destination, topicAttributeCarrier(attributes))
+	defer func() { tracing.End(span, err) }()
+
+	body, err := p.encoder.Encode(schema, routingKey, value)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.client.Publish(ctx, &sns.PublishInput{
+		TopicArn:          aws.String(p.topics[destination]),
+		Message:           aws.String(string(body)),
+		MessageAttributes: attributes,
+	})
+	return err
+}
+
+func (p producer) Ping(ctx context.Context) error {
+	_, err := p.client.ListTopics(ctx, &sns.ListTopicsInput{})
+	return err
+}
+
+func (p producer) Close() {}
+
+func NewProducer(ctx context.Context, opts Options) (*producer, error) {
+	cfg, err := loadConfig(ctx, opts.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	client := sns.NewFromConfig(cfg, func(o *sns.Options) {
+		if opts.Endpoint != "" {
+			o.BaseEndpoint = aws.String(opts.Endpoint)
+		}
+	})
+
+	err = backoff.Retry(ctx, opts.Retry, func(ctx context.Context) error {
+		_, err := client.ListTopics(ctx, &sns.ListTopicsInput{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &producer{
+		client:  client,
+		topics:  make(map[string]string),
+		encoder: opts.Encoder,
+	}
+	return p, p.ensureTopics(ctx)
+}
@@ -0,0 +1,13 @@
+package sqs
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+func (p producer) PlayerStatisticProgressionUpdates(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+	routingKey := message.StatisticRoutingKey(st.GameID, st.ID)
+	return p.publish(ctx, message.StatisticDestination, routingKey, message.SchemaPlayerStatistic, message.FromPlayerStatisticUpdates(progression, updates))
+}
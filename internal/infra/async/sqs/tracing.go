@@ -0,0 +1,49 @@
+package sqs
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const messagingSystem = "aws_sqs"
+
+// Carries the trace context on the attributes of the messages published to SNS
+type topicAttributeCarrier map[string]snstypes.MessageAttributeValue
+
+func (c topicAttributeCarrier) Get(key string) string {
+	return aws.ToString(c[key].StringValue)
+}
+
+func (c topicAttributeCarrier) Set(key, value string) {
+	c[key] = stringAttribute(value)
+}
+
+func (c topicAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// Carries the trace context on the attributes of the messages sent to and received from SQS
+type queueAttributeCarrier map[string]types.MessageAttributeValue
+
+func (c queueAttributeCarrier) Get(key string) string {
+	return aws.ToString(c[key].StringValue)
+}
+
+func (c queueAttributeCarrier) Set(key, value string) {
+	c[key] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+}
+
+func (c queueAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
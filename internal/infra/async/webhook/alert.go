@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+)
+
+// Status of a backlog alert
+const (
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+// Body posted for each backlog alert. `text` makes it readable by Slack compatible incoming webhooks
+type backlogAlert struct {
+	Status    string `json:"status"`
+	Broker    string `json:"broker"`
+	Queue     string `json:"queue"`
+	Messages  int64  `json:"messages"`
+	Threshold int64  `json:"threshold"`
+	Text      string `json:"text"`
+}
+
+// Posts the ingestion backlog alerts to a single operator webhook
+type alerter struct {
+	sender *sender
+	url    string
+	broker string
+}
+
+// Responses out of the 2xx range are failures, so the alert is sent again on the next measure
+func (a alerter) NotifyBacklog(ctx context.Context, alert ingestion.BacklogAlert) error {
+	body := backlogAlert{
+		Status:    AlertStatusFiring,
+		Broker:    a.broker,
+		Queue:     alert.Queue,
+		Messages:  alert.Messages,
+		Threshold: alert.Threshold,
+		Text:      fmt.Sprintf("Ingestion backlog of %s on %s is %d messages, over the threshold of %d", alert.Queue, a.broker, alert.Messages, alert.Threshold),
+	}
+	if alert.Resolved {
+		body.Status = AlertStatusResolved
+		body.Text = fmt.Sprintf("Ingestion backlog of %s on %s is back to %d messages, under the threshold of %d", alert.Queue, a.broker, alert.Messages, alert.Threshold)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	status, err := a.sender.Post(ctx, a.url, map[string]string{"Content-Type": "application/json"}, data)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status > 299 {
+		return fmt.Errorf("alert webhook responded with status %d", status)
+	}
+
+	return nil
+}
+
+func (a alerter) Close() {
+	a.sender.Close()
+}
+
+// Creates an alerter posting to `url` the backlog alerts of the queues consumed from `broker`
+func NewAlerter(url, broker string, timeout time.Duration) *alerter {
+	return &alerter{
+		sender: NewSender(timeout),
+		url:    url,
+		broker: broker,
+	}
+}
@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlerterNotifyBacklog(t *testing.T) {
+	ctx := context.Background()
+
+	alert := ingestion.BacklogAlert{
+		Backlog:   ingestion.Backlog{Queue: "gameblitz.ingestion", Messages: 20},
+		Threshold: 10,
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var received backlogAlert
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		a := NewAlerter(server.URL, "rabbitmq", 0)
+		defer a.Close()
+
+		err := a.NotifyBacklog(ctx, alert)
+		assert.NoError(t, err)
+		assert.Equal(t, AlertStatusFiring, received.Status)
+		assert.Equal(t, "rabbitmq", received.Broker)
+		assert.Equal(t, alert.Queue, received.Queue)
+		assert.Equal(t, alert.Messages, received.Messages)
+		assert.Equal(t, alert.Threshold, received.Threshold)
+		assert.NotEmpty(t, received.Text)
+
+		resolved := alert
+		resolved.Resolved = true
+
+		err = a.NotifyBacklog(ctx, resolved)
+		assert.NoError(t, err)
+		assert.Equal(t, AlertStatusResolved, received.Status)
+	})
+
+	t.Run("Error Status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		a := NewAlerter(server.URL, "rabbitmq", 0)
+		defer a.Close()
+
+		err := a.NotifyBacklog(ctx, alert)
+		assert.Error(t, err)
+	})
+}
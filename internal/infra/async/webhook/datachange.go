@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/datachange"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+func (p producer) DataChange(ctx context.Context, event datachange.Event) error {
+	var resource string
+	if event.Entity == datachange.EntityStatistic {
+		resource = webhook.ResourceStatistic
+	}
+
+	return p.publish(ctx, event.GameID, resource, event.EntityID, message.DataChangeRoutingKey(event), message.SchemaDataChange, message.FromDataChange(event))
+}
@@ -0,0 +1,13 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+func (p producer) LeaderboardLifecycleEvent(ctx context.Context, event string, lb leaderboard.Leaderboard) error {
+	return p.publish(ctx, lb.GameID, webhook.ResourceLeaderboard, lb.ID, message.LeaderboardRoutingKey(event, lb), message.SchemaLeaderboard, message.FromLeaderboardEvent(event, lb))
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+// The erasure refers to no leaderboard, statistic or quest, so it's delivered to every webhook subscribed to its type
+func (p producer) PlayerErased(ctx context.Context, playerID string, receipt privacy.Receipt) error {
+	return p.publish(ctx, receipt.GameID, "", "", message.PlayerErasureRoutingKey(receipt.GameID, playerID), message.SchemaPlayerErasure, message.FromPlayerErasure(playerID, receipt))
+}
+
+// Like the erasure, the anonymization is delivered to every webhook subscribed to its type
+func (p producer) PlayerAnonymized(ctx context.Context, playerID string, receipt privacy.AnonymizationReceipt) error {
+	return p.publish(ctx, receipt.GameID, "", "", message.PlayerAnonymizationRoutingKey(receipt.GameID, playerID), message.SchemaPlayerAnonymization, message.FromPlayerAnonymization(playerID, receipt))
+}
+
+// Achievements aren't a filterable resource, so the unlock is delivered to every webhook subscribed to its type
+func (p producer) AchievementUnlocked(ctx context.Context, unlocked achievement.PlayerAchievement) error {
+	return p.publish(ctx, unlocked.Achievement.GameID, "", "", message.AchievementUnlockedRoutingKey(unlocked.Achievement.GameID, unlocked.PlayerID, unlocked.Achievement.ID), message.SchemaPlayerAchievement, message.FromAchievementUnlocked(unlocked))
+}
+
+// The level-ups are filtered by their XP statistic, like the statistic progressions
+func (p producer) LevelUp(ctx context.Context, up level.LevelUp) error {
+	return p.publish(ctx, up.Curve.GameID, webhook.ResourceStatistic, up.Curve.StatisticID, message.LevelUpRoutingKey(up.Curve.GameID, up.PlayerID, up.Curve.ID), message.SchemaPlayerLevelUp, message.FromLevelUp(up))
+}
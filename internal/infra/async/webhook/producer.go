@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+// Event types the webhooks can subscribe to
+var EventTypes = []string{
+	message.SchemaPlayerStatistic,
+	message.SchemaPlayerQuest,
+	message.SchemaDataChange,
+	message.SchemaLeaderboard,
+	message.SchemaQuestLifecycle,
+	message.SchemaPlayerErasure,
+	message.SchemaPlayerAnonymization,
+	message.SchemaPlayerAchievement,
+	message.SchemaPlayerLevelUp,
+}
+
+// Queues the events as deliveries to the webhooks of their game, encoded the same way they are published
+// on the brokers. The event type is the schema of the message, e.g. `gameblitz.leaderboard.lifecycle`
+type producer struct {
+	encoder     message.Encoder
+	enqueueFunc webhook.EnqueueFunc
+}
+
+// The resource is the leaderboard, statistic or quest the event refers to, used to filter the webhooks subscribed to it
+func (p producer) publish(ctx context.Context, gameID, resource, resourceID, routingKey, schema string, value any) error {
+	payload, err := p.encoder.Encode(schema, routingKey, value)
+	if err != nil {
+		return err
+	}
+
+	return p.enqueueFunc(ctx, webhook.Event{
+		Type:        schema,
+		GameID:      gameID,
+		Resource:    resource,
+		ResourceID:  resourceID,
+		ContentType: p.encoder.ContentType(),
+		Payload:     payload,
+	})
+}
+
+func NewProducer(encoder message.Encoder, enqueueFunc webhook.EnqueueFunc) *producer {
+	return &producer{
+		encoder:     encoder,
+		enqueueFunc: enqueueFunc,
+	}
+}
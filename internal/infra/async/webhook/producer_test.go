@@ -0,0 +1,234 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderboardLifecycleEvent(t *testing.T) {
+	var (
+		ctx = context.Background()
+		lb  = leaderboard.Leaderboard{ID: "1", GameID: "2", Name: "Ranking"}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var enqueued webhook.Event
+		p := NewProducer(message.Encoder{}, func(ctx context.Context, event webhook.Event) error {
+			enqueued = event
+			return nil
+		})
+
+		err := p.LeaderboardLifecycleEvent(ctx, leaderboard.EventCreated, lb)
+		assert.NoError(t, err)
+		assert.Equal(t, message.SchemaLeaderboard, enqueued.Type)
+		assert.Equal(t, lb.GameID, enqueued.GameID)
+		assert.Equal(t, webhook.ResourceLeaderboard, enqueued.Resource)
+		assert.Equal(t, lb.ID, enqueued.ResourceID)
+		assert.Equal(t, message.ContentTypeJSON, enqueued.ContentType)
+
+		var data message.Leaderboard
+		err = message.Decode(message.SchemaLeaderboard, enqueued.Payload, &data)
+		assert.NoError(t, err)
+		assert.Equal(t, lb.ID, data.ID)
+	})
+
+	t.Run("Enqueue Error", func(t *testing.T) {
+		enqueueErr := errors.New("any error")
+		p := NewProducer(message.Encoder{}, func(ctx context.Context, event webhook.Event) error {
+			return enqueueErr
+		})
+
+		err := p.LeaderboardLifecycleEvent(ctx, leaderboard.EventCreated, lb)
+		assert.ErrorIs(t, err, enqueueErr)
+	})
+}
+
+func TestPlayerErased(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		receipt = privacy.Receipt{
+			ID:           "1",
+			GameID:       "2",
+			PlayerDigest: privacy.PlayerDigest("2", "3"),
+			Erasures:     []privacy.Erasure{{Data: privacy.DataRanks, Records: 1}},
+		}
+	)
+
+	var enqueued webhook.Event
+	p := NewProducer(message.Encoder{}, func(ctx context.Context, event webhook.Event) error {
+		enqueued = event
+		return nil
+	})
+
+	err := p.PlayerErased(ctx, "3", receipt)
+	assert.NoError(t, err)
+	assert.Equal(t, message.SchemaPlayerErasure, enqueued.Type)
+	assert.Equal(t, receipt.GameID, enqueued.GameID)
+	assert.Empty(t, enqueued.Resource)
+
+	var data message.PlayerErasure
+	err = message.Decode(message.SchemaPlayerErasure, enqueued.Payload, &data)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", data.PlayerID)
+	assert.Equal(t, receipt.ID, data.ReceiptID)
+	assert.Equal(t, []message.PlayerErasureData{{Data: privacy.DataRanks, Records: 1}}, data.Erasures)
+}
+
+func TestPlayerAnonymized(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		receipt = privacy.AnonymizationReceipt{
+			ID:             "1",
+			GameID:         "2",
+			PlayerDigest:   privacy.PlayerDigest("2", "3"),
+			Pseudonym:      privacy.NewPseudonym(),
+			Anonymizations: []privacy.Anonymization{{Data: privacy.DataRanks, Records: 1}},
+		}
+	)
+
+	var enqueued webhook.Event
+	p := NewProducer(message.Encoder{}, func(ctx context.Context, event webhook.Event) error {
+		enqueued = event
+		return nil
+	})
+
+	err := p.PlayerAnonymized(ctx, "3", receipt)
+	assert.NoError(t, err)
+	assert.Equal(t, message.SchemaPlayerAnonymization, enqueued.Type)
+	assert.Equal(t, receipt.GameID, enqueued.GameID)
+	assert.Empty(t, enqueued.Resource)
+
+	var data message.PlayerAnonymization
+	err = message.Decode(message.SchemaPlayerAnonymization, enqueued.Payload, &data)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", data.PlayerID)
+	assert.Equal(t, receipt.Pseudonym, data.Pseudonym)
+	assert.Equal(t, receipt.ID, data.ReceiptID)
+	assert.Equal(t, []message.PlayerAnonymizationData{{Data: privacy.DataRanks, Records: 1}}, data.Anonymizations)
+}
+
+func TestAchievementUnlocked(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		unlocked = achievement.PlayerAchievement{
+			Achievement: achievement.Achievement{
+				ID:      "1",
+				GameID:  "2",
+				Name:    "First Steps",
+				Trigger: achievement.Trigger{Kind: achievement.TriggerQuestCompleted, QuestID: "4"},
+			},
+			PlayerID: "3",
+		}
+	)
+
+	var enqueued webhook.Event
+	p := NewProducer(message.Encoder{}, func(ctx context.Context, event webhook.Event) error {
+		enqueued = event
+		return nil
+	})
+
+	err := p.AchievementUnlocked(ctx, unlocked)
+	assert.NoError(t, err)
+	assert.Equal(t, message.SchemaPlayerAchievement, enqueued.Type)
+	assert.Equal(t, unlocked.Achievement.GameID, enqueued.GameID)
+	assert.Empty(t, enqueued.Resource)
+
+	var data message.PlayerAchievement
+	err = message.Decode(message.SchemaPlayerAchievement, enqueued.Payload, &data)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", data.PlayerID)
+	assert.Equal(t, "1", data.AchievementID)
+	assert.Equal(t, message.AchievementTrigger{Kind: achievement.TriggerQuestCompleted, QuestID: "4"}, data.Trigger)
+}
+
+func TestLevelUp(t *testing.T) {
+	var (
+		ctx = context.Background()
+		up  = level.LevelUp{
+			Curve:         level.Curve{ID: "1", GameID: "2", Name: "Account", StatisticID: "4"},
+			PlayerID:      "3",
+			PreviousLevel: 1,
+			Level:         3,
+			XP:            300,
+		}
+	)
+
+	var enqueued webhook.Event
+	p := NewProducer(message.Encoder{}, func(ctx context.Context, event webhook.Event) error {
+		enqueued = event
+		return nil
+	})
+
+	err := p.LevelUp(ctx, up)
+	assert.NoError(t, err)
+	assert.Equal(t, message.SchemaPlayerLevelUp, enqueued.Type)
+	assert.Equal(t, up.Curve.GameID, enqueued.GameID)
+	assert.Equal(t, webhook.ResourceStatistic, enqueued.Resource)
+	assert.Equal(t, up.Curve.StatisticID, enqueued.ResourceID)
+
+	var data message.PlayerLevelUp
+	err = message.Decode(message.SchemaPlayerLevelUp, enqueued.Payload, &data)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", data.PlayerID)
+	assert.Equal(t, "1", data.CurveID)
+	assert.Equal(t, int64(1), data.PreviousLevel)
+	assert.Equal(t, int64(3), data.Level)
+}
+
+func TestPost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "sha256=abc", r.Header.Get(webhook.HeaderSignature))
+
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.True(t, json.Valid(body))
+
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		s := NewSender(0)
+		defer s.Close()
+
+		statusCode, err := s.Post(ctx, server.URL, map[string]string{webhook.HeaderSignature: "sha256=abc"}, []byte(`{}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, statusCode)
+	})
+
+	t.Run("Redirect", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/other", http.StatusFound)
+		}))
+		defer server.Close()
+
+		statusCode, err := NewSender(0).Post(ctx, server.URL, nil, []byte(`{}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusFound, statusCode)
+	})
+
+	t.Run("Request Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		_, err := NewSender(0).Post(ctx, server.URL, nil, []byte(`{}`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+func (p producer) PlayerQuestProgressionUpdates(ctx context.Context, progression quest.PlayerQuestProgression) error {
+	return p.publish(ctx, progression.Quest.GameID, webhook.ResourceQuest, progression.Quest.ID, message.QuestRoutingKey(progression.Quest.GameID, progression.Quest.ID), message.SchemaPlayerQuest, message.FromPlayerQuestProgression(progression))
+}
+
+func (p producer) QuestLifecycleEvent(ctx context.Context, event quest.LifecycleEvent) error {
+	return p.publish(ctx, event.GameID, webhook.ResourceQuest, event.QuestID, message.QuestLifecycleRoutingKey(event), message.SchemaQuestLifecycle, message.FromQuestLifecycleEvent(event))
+}
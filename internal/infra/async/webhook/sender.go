@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Response bodies are read up to this size, so the connection can be reused without loading large bodies
+const maxResponseBody = 64 << 10
+
+// Posts the deliveries to the webhooks over HTTP
+type sender struct {
+	client *http.Client
+}
+
+// Redirects aren't followed, so a delivery is only accepted by the registered URL
+func (s sender) Post(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseBody))
+	return res.StatusCode, nil
+}
+
+func (s sender) Close() {
+	s.client.CloseIdleConnections()
+}
+
+// Creates a sender that waits up to `timeout` for each response. Defaults to 10s
+func NewSender(timeout time.Duration) *sender {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &sender{
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
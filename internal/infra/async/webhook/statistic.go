@@ -0,0 +1,13 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+func (p producer) PlayerStatisticProgressionUpdates(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+	return p.publish(ctx, st.GameID, webhook.ResourceStatistic, st.ID, message.StatisticRoutingKey(st.GameID, st.ID), message.SchemaPlayerStatistic, message.FromPlayerStatisticUpdates(progression, updates))
+}
@@ -0,0 +1,88 @@
+package backoff
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultInitialInterval = 500 * time.Millisecond
+	defaultMaxInterval     = 30 * time.Second
+	defaultMultiplier      = 2
+)
+
+type Config struct {
+	InitialInterval time.Duration                       // Wait before the first retry. Defaults to 500ms
+	MaxInterval     time.Duration                       // Upper bound for the wait between retries. Defaults to 30s
+	Multiplier      float64                             // Growth factor of the wait between retries. Defaults to 2
+	MaxElapsedTime  time.Duration                       // Window in which retries are made. Zero means a single attempt
+	Notify          func(err error, wait time.Duration) // Called before waiting for the next attempt. Optional
+}
+
+func (c Config) withDefaults() Config {
+	if c.InitialInterval <= 0 {
+		c.InitialInterval = defaultInitialInterval
+	}
+
+	if c.MaxInterval <= 0 {
+		c.MaxInterval = defaultMaxInterval
+	}
+
+	if c.Multiplier < 1 {
+		c.Multiplier = defaultMultiplier
+	}
+
+	return c
+}
+
+// Wait before the given retry attempt, starting at 1, using the same exponential growth and
+// "full jitter" strategy as `Retry`. Meant for retries scheduled elsewhere, like a delayed redelivery
+func Delay(config Config, attempt int) time.Duration {
+	config = config.withDefaults()
+
+	interval := config.InitialInterval
+	for i := 1; i < attempt && interval < config.MaxInterval; i++ {
+		interval = min(time.Duration(float64(interval)*config.Multiplier), config.MaxInterval)
+	}
+
+	return time.Duration(rand.Int63n(int64(interval)) + 1)
+}
+
+// Calls `fn` until it succeeds, the context is canceled or the `MaxElapsedTime` window is over.
+// The wait between attempts grows exponentially and is randomized using the "full jitter" strategy,
+// so several instances starting together don't retry in lockstep.
+// Returns the last error returned by `fn`
+func Retry(ctx context.Context, config Config, fn func(ctx context.Context) error) error {
+	config = config.withDefaults()
+
+	var (
+		deadline = time.Now().Add(config.MaxElapsedTime)
+		interval = config.InitialInterval
+	)
+	for {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		wait := time.Duration(rand.Int63n(int64(interval)) + 1)
+		if time.Now().Add(wait).After(deadline) {
+			return err
+		}
+
+		if config.Notify != nil {
+			config.Notify(err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		interval = min(time.Duration(float64(interval)*config.Multiplier), config.MaxInterval)
+	}
+}
@@ -0,0 +1,97 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		attempts := 0
+		err := Retry(ctx, Config{InitialInterval: time.Millisecond, MaxElapsedTime: time.Second}, func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("any error")
+			}
+
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("Single Attempt", func(t *testing.T) {
+		attempts := 0
+		err := Retry(ctx, Config{}, func(ctx context.Context) error {
+			attempts++
+			return errors.New("any error")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("Window Exceeded", func(t *testing.T) {
+		var (
+			errAny   = errors.New("any error")
+			notified = 0
+		)
+
+		err := Retry(ctx, Config{
+			InitialInterval: time.Millisecond,
+			MaxInterval:     5 * time.Millisecond,
+			MaxElapsedTime:  50 * time.Millisecond,
+			Notify:          func(err error, wait time.Duration) { notified++ },
+		}, func(ctx context.Context) error {
+			return errAny
+		})
+		assert.ErrorIs(t, err, errAny)
+		assert.Greater(t, notified, 0)
+	})
+
+	t.Run("Context Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		attempts := 0
+		err := Retry(ctx, Config{InitialInterval: time.Hour, MaxInterval: time.Hour, MaxElapsedTime: 24 * time.Hour}, func(ctx context.Context) error {
+			attempts++
+			return errors.New("any error")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestDelay(t *testing.T) {
+	config := Config{InitialInterval: 10 * time.Millisecond, MaxInterval: 40 * time.Millisecond, Multiplier: 2}
+
+	t.Run("OK", func(t *testing.T) {
+		for attempt, upperBound := range map[int]time.Duration{
+			1: 10 * time.Millisecond,
+			2: 20 * time.Millisecond,
+			3: 40 * time.Millisecond,
+		} {
+			delay := Delay(config, attempt)
+			assert.Greater(t, delay, time.Duration(0))
+			assert.LessOrEqual(t, delay, upperBound)
+		}
+	})
+
+	t.Run("Capped By Max Interval", func(t *testing.T) {
+		delay := Delay(config, 100)
+		assert.Greater(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, config.MaxInterval)
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		delay := Delay(Config{}, 1)
+		assert.Greater(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, defaultInitialInterval)
+	})
+}
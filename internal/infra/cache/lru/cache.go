@@ -0,0 +1,77 @@
+package lru
+
+import (
+	"container/list"
+	"time"
+)
+
+func (e entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+func (c *connection) remove(element *list.Element) {
+	c.recency.Remove(element)
+	delete(c.entries, element.Value.(entry).key)
+}
+
+func (c *connection) Get(key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, nil
+	}
+
+	if element.Value.(entry).expired(time.Now()) {
+		c.remove(element)
+		return nil, nil
+	}
+
+	c.recency.MoveToFront(element)
+	return element.Value.(entry).value, nil
+}
+
+// Stores a copy of the value. A zero expiration keeps the entry until it is evicted
+func (c *connection) Set(key string, val []byte, exp time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := entry{key: key, value: append([]byte(nil), val...)}
+	if exp > 0 {
+		e.expiresAt = time.Now().Add(exp)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = e
+		c.recency.MoveToFront(element)
+		return nil
+	}
+
+	if c.recency.Len() >= c.size {
+		c.remove(c.recency.Back())
+	}
+
+	c.entries[key] = c.recency.PushFront(e)
+	return nil
+}
+
+func (c *connection) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	return nil
+}
+
+func (c *connection) Reset() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element, c.size)
+	c.recency.Init()
+	return nil
+}
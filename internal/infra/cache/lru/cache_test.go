@@ -0,0 +1,115 @@
+package lru
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		conn, err := New(1)
+		assert.NoError(t, err)
+		assert.NotNil(t, conn)
+	})
+
+	t.Run("Invalid Size", func(t *testing.T) {
+		_, err := New(0)
+		assert.ErrorIs(t, err, ErrInvalidSize)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		conn, _ := New(2)
+
+		err := conn.Set("a", []byte("value"), 0)
+		assert.NoError(t, err)
+
+		data, err := conn.Get("a")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value"), data)
+	})
+
+	t.Run("Miss", func(t *testing.T) {
+		conn, _ := New(2)
+
+		data, err := conn.Get("a")
+		assert.NoError(t, err)
+		assert.Nil(t, data)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		conn, _ := New(2)
+
+		err := conn.Set("a", []byte("value"), time.Millisecond)
+		assert.NoError(t, err)
+
+		time.Sleep(2 * time.Millisecond)
+
+		data, err := conn.Get("a")
+		assert.NoError(t, err)
+		assert.Nil(t, data)
+		assert.Empty(t, conn.entries)
+	})
+}
+
+func TestSet(t *testing.T) {
+	t.Run("Evicts Least Recently Used", func(t *testing.T) {
+		conn, _ := New(2)
+
+		_ = conn.Set("a", []byte("a"), 0)
+		_ = conn.Set("b", []byte("b"), 0)
+
+		_, _ = conn.Get("a")
+		_ = conn.Set("c", []byte("c"), 0)
+
+		data, _ := conn.Get("b")
+		assert.Nil(t, data)
+
+		data, _ = conn.Get("a")
+		assert.Equal(t, []byte("a"), data)
+
+		data, _ = conn.Get("c")
+		assert.Equal(t, []byte("c"), data)
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		conn, _ := New(1)
+
+		_ = conn.Set("a", []byte("old"), 0)
+		_ = conn.Set("a", []byte("new"), 0)
+
+		data, _ := conn.Get("a")
+		assert.Equal(t, []byte("new"), data)
+		assert.Equal(t, 1, conn.recency.Len())
+	})
+}
+
+func TestDelete(t *testing.T) {
+	conn, _ := New(2)
+
+	_ = conn.Set("a", []byte("a"), 0)
+
+	err := conn.Delete("a")
+	assert.NoError(t, err)
+
+	data, _ := conn.Get("a")
+	assert.Nil(t, data)
+
+	err = conn.Delete("a")
+	assert.NoError(t, err)
+}
+
+func TestReset(t *testing.T) {
+	conn, _ := New(2)
+
+	_ = conn.Set("a", []byte("a"), 0)
+	_ = conn.Set("b", []byte("b"), 0)
+
+	err := conn.Reset()
+	assert.NoError(t, err)
+	assert.Empty(t, conn.entries)
+	assert.Equal(t, 0, conn.recency.Len())
+}
@@ -0,0 +1,43 @@
+package lru
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrInvalidSize = errors.New("cache size must be greater than zero")
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // Zero when the entry never expires
+}
+
+type connection struct {
+	mu sync.Mutex
+
+	size    int
+	entries map[string]*list.Element
+	recency *list.List // Most recently used entries first
+}
+
+func (c *connection) Close() error {
+	return nil
+}
+
+// Creates a cache that keeps up to `size` entries in the process memory,
+// evicting the least recently used entry once it is full
+func New(size int) (*connection, error) {
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+
+	conn := &connection{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		recency: list.New(),
+	}
+	return conn, nil
+}
@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const resetScanCount = 1000
+
+func (c connection) Get(key string) ([]byte, error) {
+	data, err := c.client.Get(context.Background(), keyPrefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			err = nil
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (c connection) Set(key string, val []byte, exp time.Duration) error {
+	return c.client.Set(context.Background(), keyPrefix+key, val, exp).Err()
+}
+
+func (c connection) Delete(key string) error {
+	return c.client.Del(context.Background(), keyPrefix+key).Err()
+}
+
+// Removes only the cache keys, leaving any other data stored on the instance untouched
+func (c connection) Reset() error {
+	ctx := context.Background()
+
+	iter := c.client.Scan(ctx, 0, keyPrefix+"*", resetScanCount).Iterator()
+	for iter.Next(ctx) {
+		if err := c.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+
+	return iter.Err()
+}
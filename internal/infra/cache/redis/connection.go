@@ -0,0 +1,26 @@
+package redis
+
+import "github.com/redis/go-redis/v9"
+
+// Every cache key is stored under this prefix, so the cache can share an instance with other data
+const keyPrefix = "cache:"
+
+type connection struct {
+	client *redis.Client
+}
+
+func (c connection) Close() error {
+	return c.client.Close()
+}
+
+func New(url string) (*connection, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &connection{
+		client: redis.NewClient(opts),
+	}
+	return conn, nil
+}
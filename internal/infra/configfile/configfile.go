@@ -0,0 +1,283 @@
+package configfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidConfig  = errors.New("invalid config")
+	ErrUnknownFormat  = errors.New("unknown config file format, expected .yaml, .yml or .json")
+	ErrUnknownProfile = errors.New("unknown config profile")
+	ErrInvalidProfile = errors.New("invalid config profile")
+	ErrProfileCycle   = errors.New("config profile extends itself")
+)
+
+const (
+	// Key of the file holding the named profiles, each one a map of settings
+	profilesKey = "profiles"
+	// Key of a profile naming the profile it inherits the settings from
+	extendsKey = "extends"
+)
+
+// Implemented by the specs that check their fields against each other once loaded, e.g. a storage that
+// requires its connection settings. Every problem found should be returned, joined with `errors.Join`
+type Validator interface {
+	Validate() error
+}
+
+// Reads the settings of the file, keyed by their environment variable names. JSON and YAML files are supported
+func read(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]any)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &settings)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &settings)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return settings, nil
+}
+
+// Merges the profile over the settings at the top of the file, after the profiles it extends, the farthest one first,
+// so each profile only holds its overrides. The keys are upper cased, so a setting can be overridden in any case.
+// Without a profile, only the top settings are returned
+func resolve(settings map[string]any, profile string) (map[string]any, error) {
+	profiles := make(map[string]map[string]any)
+	if raw, ok := settings[profilesKey]; ok {
+		named, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s must map the profile names to their settings", ErrInvalidProfile, profilesKey)
+		}
+
+		for name, value := range named {
+			if profiles[name], ok = value.(map[string]any); !ok {
+				return nil, fmt.Errorf("%w: %s must be a map of settings", ErrInvalidProfile, name)
+			}
+		}
+	}
+
+	resolved := make(map[string]any, len(settings))
+	merge := func(settings map[string]any, reserved string) {
+		for key, value := range settings {
+			if key != reserved {
+				resolved[strings.ToUpper(key)] = value
+			}
+		}
+	}
+
+	merge(settings, profilesKey)
+	if profile == "" {
+		return resolved, nil
+	}
+
+	chain := make([]string, 0)
+	for name := profile; name != ""; {
+		if slices.Contains(chain, name) {
+			return nil, fmt.Errorf("%w: %s", ErrProfileCycle, strings.Join(append(chain, name), " -> "))
+		}
+
+		settings, ok := profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProfile, name)
+		}
+		chain = append(chain, name)
+
+		extends, ok := settings[extendsKey]
+		if !ok {
+			break
+		}
+
+		if name, ok = extends.(string); !ok {
+			return nil, fmt.Errorf("%w: %s must extend a profile name", ErrInvalidProfile, chain[len(chain)-1])
+		}
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		merge(profiles[chain[i]], extendsKey)
+	}
+
+	return resolved, nil
+}
+
+// Formats a setting the way envconfig parses its environment variable: lists are joined by
+// commas and maps become comma separated `key:value` pairs
+func format(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool, int, int64, uint64:
+		return fmt.Sprint(v), nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, err := format(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		pairs := make([]string, 0, len(v))
+		for key, item := range v {
+			s, err := format(item)
+			if err != nil {
+				return "", err
+			}
+			pairs = append(pairs, key+":"+s)
+		}
+		slices.Sort(pairs)
+
+		return strings.Join(pairs, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
+
+// Environment variables of the spec's fields
+func keys(t reflect.Type) []string {
+	keys := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		if key := t.Field(i).Tag.Get("envconfig"); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// Describes an envconfig error of the field without its package prefix, e.g. `PORT: invalid int "abc"`.
+// Besides the parsing errors, envconfig only fails a field when it's required and missing
+func describe(key string, err error) error {
+	var parseErr *envconfig.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("%s: invalid %s %q", key, parseErr.TypeName, parseErr.Value)
+	}
+
+	return fmt.Errorf("%s: missing required value", key)
+}
+
+// Processes each field of the spec on its own, so every invalid or missing field is reported instead of the first one
+func process(spec any) []error {
+	var (
+		v       = reflect.ValueOf(spec).Elem()
+		errList = make([]error, 0)
+	)
+
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		single := reflect.New(reflect.StructOf([]reflect.StructField{{Name: field.Name, Type: field.Type, Tag: field.Tag}}))
+		if err := envconfig.Process("", single.Interface()); err != nil {
+			errList = append(errList, describe(field.Tag.Get("envconfig"), err))
+			continue
+		}
+
+		v.Field(i).Set(single.Elem().Field(0))
+	}
+
+	return errList
+}
+
+// Loads the spec, a pointer to a struct tagged for envconfig, from the environment variables and the optional file.
+// The file holds the same settings keyed by their environment variable names, e.g. `PORT: 8080`, and an environment
+// variable always takes precedence over the file. The file settings are exported to the process environment,
+// so they're seen by anything reading it later. Every unknown, missing or invalid setting is returned at once,
+// joined with `ErrInvalidConfig`, alongside the problems found by the spec's `Validate` when it's a `Validator`
+func Load(path string, spec any) error {
+	return LoadProfile(path, "", spec)
+}
+
+// Same as `Load`, with the settings of the named profile of the file merged over its top settings. A profile
+// inherits the settings of the one it `extends`, e.g. `staging` extending `prod`, and overrides them.
+// The profiles are only read when one is named
+func LoadProfile(path, profile string, spec any) error {
+	v := reflect.ValueOf(spec)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return envconfig.ErrInvalidSpecification
+	}
+
+	if path == "" && profile != "" {
+		return fmt.Errorf("%w: %s, no config file set", ErrUnknownProfile, profile)
+	}
+
+	errList := make([]error, 0)
+	if path != "" {
+		settings, err := read(path)
+		if err != nil {
+			return err
+		}
+
+		if settings, err = resolve(settings, profile); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		known := keys(v.Elem().Type())
+		for key, value := range settings {
+			if !slices.Contains(known, key) {
+				errList = append(errList, fmt.Errorf("%s: unknown setting", key))
+				continue
+			}
+
+			s, err := format(value)
+			if err != nil {
+				errList = append(errList, fmt.Errorf("%s: %w", key, err))
+				continue
+			}
+
+			if _, ok := os.LookupEnv(key); !ok {
+				if err := os.Setenv(key, s); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	errList = append(errList, process(spec)...)
+
+	// Cross field checks are only meaningful once every field is loaded
+	if validator, ok := spec.(Validator); ok && len(errList) == 0 {
+		err := validator.Validate()
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errList = append(errList, joined.Unwrap()...)
+		} else if err != nil {
+			errList = append(errList, err)
+		}
+	}
+
+	if len(errList) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(errList, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return errors.Join(append([]error{ErrInvalidConfig}, errList...)...)
+}
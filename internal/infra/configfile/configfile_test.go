@@ -0,0 +1,258 @@
+package configfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type spec struct {
+	Port     int               `envconfig:"GAMEBLITZ_TEST_PORT" required:"true"`
+	Name     string            `envconfig:"GAMEBLITZ_TEST_NAME" required:"false" default:"gameblitz"`
+	Ratio    float64           `envconfig:"GAMEBLITZ_TEST_RATIO" required:"false" default:"1"`
+	Enabled  bool              `envconfig:"GAMEBLITZ_TEST_ENABLED" required:"false" default:"false"`
+	Addrs    []string          `envconfig:"GAMEBLITZ_TEST_ADDRS" required:"false"`
+	Prefs    map[string]string `envconfig:"GAMEBLITZ_TEST_PREFS" required:"false"`
+	Storage  string            `envconfig:"GAMEBLITZ_TEST_STORAGE" required:"false" default:"memory"`
+	Required string            `envconfig:"GAMEBLITZ_TEST_REQUIRED" required:"true"`
+}
+
+var errUnknownStorage = errors.New("GAMEBLITZ_TEST_STORAGE: unknown storage")
+
+func (s spec) Validate() error {
+	if s.Storage != "memory" {
+		return errors.Join(errUnknownStorage)
+	}
+
+	return nil
+}
+
+// Unsets the spec's environment variables, restoring them once the test ends,
+// including the ones exported from the file
+func isolate(t *testing.T) {
+	for _, key := range keys(reflect.TypeOf(spec{})) {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.yaml", `
+GAMEBLITZ_TEST_PORT: 8080
+gameblitz_test_ratio: 0.25
+GAMEBLITZ_TEST_ENABLED: true
+GAMEBLITZ_TEST_ADDRS: [a:6379, b:6379]
+GAMEBLITZ_TEST_PREFS:
+  statistics: secondary
+  audit: nearest
+GAMEBLITZ_TEST_REQUIRED: value
+`)
+
+		var s spec
+		err := Load(path, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, spec{
+			Port:     8080,
+			Name:     "gameblitz",
+			Ratio:    0.25,
+			Enabled:  true,
+			Addrs:    []string{"a:6379", "b:6379"},
+			Prefs:    map[string]string{"statistics": "secondary", "audit": "nearest"},
+			Storage:  "memory",
+			Required: "value",
+		}, s)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.json", `{"GAMEBLITZ_TEST_PORT": 1000000, "GAMEBLITZ_TEST_REQUIRED": "value"}`)
+
+		var s spec
+		err := Load(path, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, 1000000, s.Port)
+	})
+
+	t.Run("Environment Precedence", func(t *testing.T) {
+		isolate(t)
+		t.Setenv("GAMEBLITZ_TEST_PORT", "9090")
+		path := writeFile(t, "config.yml", "GAMEBLITZ_TEST_PORT: 8080\nGAMEBLITZ_TEST_REQUIRED: value\n")
+
+		var s spec
+		err := Load(path, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, 9090, s.Port)
+	})
+
+	t.Run("Environment Only", func(t *testing.T) {
+		isolate(t)
+		t.Setenv("GAMEBLITZ_TEST_PORT", "9090")
+		t.Setenv("GAMEBLITZ_TEST_REQUIRED", "value")
+
+		var s spec
+		err := Load("", &s)
+		assert.NoError(t, err)
+		assert.Equal(t, 9090, s.Port)
+	})
+
+	t.Run("Every Invalid Setting", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.yaml", "GAMEBLITZ_TEST_PORT: abc\nGAMEBLITZ_TEST_RATIO: high\nGAMEBLITZ_TEST_TYPO: 1\n")
+
+		var s spec
+		err := Load(path, &s)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		assert.EqualError(t, err, `invalid config
+GAMEBLITZ_TEST_PORT: invalid int "abc"
+GAMEBLITZ_TEST_RATIO: invalid float64 "high"
+GAMEBLITZ_TEST_REQUIRED: missing required value
+GAMEBLITZ_TEST_TYPO: unknown setting`)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.yaml", "GAMEBLITZ_TEST_PORT: 8080\nGAMEBLITZ_TEST_REQUIRED: value\nGAMEBLITZ_TEST_STORAGE: mongo\n")
+
+		var s spec
+		err := Load(path, &s)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		assert.ErrorIs(t, err, errUnknownStorage)
+	})
+
+	t.Run("Unknown Format", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.toml", "GAMEBLITZ_TEST_PORT = 8080\n")
+
+		var s spec
+		err := Load(path, &s)
+		assert.ErrorIs(t, err, ErrUnknownFormat)
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		isolate(t)
+
+		var s spec
+		err := Load(filepath.Join(t.TempDir(), "config.yaml"), &s)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestLoadProfile(t *testing.T) {
+	const file = `
+GAMEBLITZ_TEST_PORT: 8080
+GAMEBLITZ_TEST_NAME: base
+GAMEBLITZ_TEST_REQUIRED: value
+profiles:
+  prod:
+    GAMEBLITZ_TEST_NAME: prod
+    GAMEBLITZ_TEST_ADDRS: [a:6379, b:6379]
+    GAMEBLITZ_TEST_ENABLED: true
+  staging:
+    extends: prod
+    gameblitz_test_name: staging
+    GAMEBLITZ_TEST_ADDRS: [c:6379]
+  local:
+    GAMEBLITZ_TEST_PORT: 3000
+  loop-a:
+    extends: loop-b
+  loop-b:
+    extends: loop-a
+  orphan:
+    extends: missing
+`
+
+	t.Run("Inheritance", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.yaml", file)
+
+		var s spec
+		err := LoadProfile(path, "staging", &s)
+		assert.NoError(t, err)
+		assert.Equal(t, 8080, s.Port)
+		assert.Equal(t, "staging", s.Name)
+		assert.Equal(t, []string{"c:6379"}, s.Addrs)
+		assert.True(t, s.Enabled)
+	})
+
+	t.Run("Single Profile", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.yaml", file)
+
+		var s spec
+		err := LoadProfile(path, "local", &s)
+		assert.NoError(t, err)
+		assert.Equal(t, 3000, s.Port)
+		assert.Equal(t, "base", s.Name)
+		assert.False(t, s.Enabled)
+	})
+
+	t.Run("Without Profile", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.yaml", file)
+
+		var s spec
+		err := LoadProfile(path, "", &s)
+		assert.NoError(t, err)
+		assert.Equal(t, 8080, s.Port)
+		assert.Equal(t, "base", s.Name)
+		assert.Empty(t, s.Addrs)
+	})
+
+	t.Run("Environment Precedence", func(t *testing.T) {
+		isolate(t)
+		t.Setenv("GAMEBLITZ_TEST_NAME", "env")
+		path := writeFile(t, "config.yaml", file)
+
+		var s spec
+		err := LoadProfile(path, "staging", &s)
+		assert.NoError(t, err)
+		assert.Equal(t, "env", s.Name)
+	})
+
+	t.Run("Unknown Profile", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.yaml", file)
+
+		var s spec
+		err := LoadProfile(path, "qa", &s)
+		assert.ErrorIs(t, err, ErrUnknownProfile)
+
+		err = LoadProfile(path, "orphan", &s)
+		assert.ErrorIs(t, err, ErrUnknownProfile)
+
+		err = LoadProfile("", "qa", &s)
+		assert.ErrorIs(t, err, ErrUnknownProfile)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.yaml", file)
+
+		var s spec
+		err := LoadProfile(path, "loop-a", &s)
+		assert.ErrorIs(t, err, ErrProfileCycle)
+		assert.ErrorContains(t, err, "loop-a -> loop-b -> loop-a")
+	})
+
+	t.Run("Invalid Profile", func(t *testing.T) {
+		isolate(t)
+		path := writeFile(t, "config.yaml", "profiles:\n  prod: 8080\n")
+
+		var s spec
+		err := LoadProfile(path, "prod", &s)
+		assert.ErrorIs(t, err, ErrInvalidProfile)
+	})
+}
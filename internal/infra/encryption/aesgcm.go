@@ -0,0 +1,76 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// Prefix of every encrypted value, so values persisted before the encryption was enabled can still be read
+const ciphertextPrefix = "enc:v1:"
+
+var (
+	ErrInvalidKey          = errors.New("encryption key must have 16, 24 or 32 bytes")
+	ErrMalformedCiphertext = errors.New("malformed ciphertext")
+)
+
+// Encrypts single field values with AES-GCM. Each value gets a random nonce,
+// so the same plaintext results in a different ciphertext every time. A nil cipher leaves the values as they are,
+// so the storages hold one whether the encryption is enabled or not
+type Cipher struct {
+	aead     cipher.AEAD
+	indexKey []byte // HMAC key of the blind indexes, derived from the encryption key
+}
+
+// Encrypts the value, returning it encoded as a printable string
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if c == nil || plaintext == "" {
+		return plaintext, nil
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return ciphertextPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypts a value returned by `Encrypt`. Values without the encryption prefix are returned as they are
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if c == nil || !strings.HasPrefix(value, ciphertextPrefix) {
+		return value, nil
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, ciphertextPrefix))
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", ErrMalformedCiphertext
+	}
+
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrMalformedCiphertext
+	}
+
+	return string(plaintext), nil
+}
+
+func New(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{aead: aead, indexKey: deriveIndexKey(key)}, nil
+}
@@ -0,0 +1,75 @@
+package encryption
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		for _, size := range []int{16, 24, 32} {
+			_, err := New(bytes.Repeat([]byte("k"), size))
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("Invalid Key", func(t *testing.T) {
+		_, err := New([]byte("short"))
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	c, err := New(bytes.Repeat([]byte("k"), 32))
+	assert.NoError(t, err)
+
+	t.Run("OK", func(t *testing.T) {
+		encrypted, err := c.Encrypt("player display name")
+		assert.NoError(t, err)
+		assert.NotContains(t, encrypted, "player display name")
+
+		decrypted, err := c.Decrypt(encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, "player display name", decrypted)
+	})
+
+	t.Run("Random Nonce", func(t *testing.T) {
+		first, err := c.Encrypt("value")
+		assert.NoError(t, err)
+
+		second, err := c.Encrypt("value")
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("Empty Value", func(t *testing.T) {
+		encrypted, err := c.Encrypt("")
+		assert.NoError(t, err)
+		assert.Empty(t, encrypted)
+	})
+
+	t.Run("Plaintext Value", func(t *testing.T) {
+		decrypted, err := c.Decrypt("stored before the encryption")
+		assert.NoError(t, err)
+		assert.Equal(t, "stored before the encryption", decrypted)
+	})
+
+	t.Run("Wrong Key", func(t *testing.T) {
+		encrypted, err := c.Encrypt("value")
+		assert.NoError(t, err)
+
+		other, err := New(bytes.Repeat([]byte("o"), 32))
+		assert.NoError(t, err)
+
+		_, err = other.Decrypt(encrypted)
+		assert.ErrorIs(t, err, ErrMalformedCiphertext)
+	})
+
+	t.Run("Malformed Value", func(t *testing.T) {
+		_, err := c.Decrypt(ciphertextPrefix + "%%%")
+		assert.ErrorIs(t, err, ErrMalformedCiphertext)
+	})
+}
@@ -0,0 +1,58 @@
+package encryption
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// Prefix of every blind index, telling it apart from the values stored before the encryption was enabled
+const blindIndexPrefix = "idx:v1:"
+
+// Context of the HMAC deriving the blind index key, so it never matches the encryption key
+var indexKeyLabel = []byte("gameblitz blind index")
+
+func deriveIndexKey(key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(indexKeyLabel)
+	return mac.Sum(nil)
+}
+
+// Deterministic HMAC of the value, so the storages can match a field by equality without holding its plaintext.
+// The field is part of the HMAC, so the same value on two fields can't be linked. A nil cipher returns the value as it is
+func (c *Cipher) BlindIndex(field, value string) string {
+	if c == nil || value == "" {
+		return value
+	}
+
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+
+	return blindIndexPrefix + base64.RawStdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Splits a field filtered on into its blind index, stored in place of the value and matched by the filters, and the
+// value encrypted, stored alongside it to be read back with `Open`. A nil cipher keeps the value as the index and
+// encrypts nothing
+func (c *Cipher) Seal(field, value string) (index, encrypted string, err error) {
+	if c == nil {
+		return value, "", nil
+	}
+
+	if encrypted, err = c.Encrypt(value); err != nil {
+		return "", "", err
+	}
+
+	return c.BlindIndex(field, value), encrypted, nil
+}
+
+// Reads back a field split by `Seal`. A field stored without its encryption, before it was enabled, is its own value
+func (c *Cipher) Open(index, encrypted string) (string, error) {
+	if encrypted == "" {
+		return c.Decrypt(index)
+	}
+
+	return c.Decrypt(encrypted)
+}
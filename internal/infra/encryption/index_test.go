@@ -0,0 +1,75 @@
+package encryption
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlindIndex(t *testing.T) {
+	c, err := New(bytes.Repeat([]byte("k"), 32))
+	assert.NoError(t, err)
+
+	t.Run("Deterministic", func(t *testing.T) {
+		index := c.BlindIndex("actor", "alice")
+		assert.NotContains(t, index, "alice")
+		assert.Equal(t, index, c.BlindIndex("actor", "alice"))
+		assert.NotEqual(t, index, c.BlindIndex("actor", "bob"))
+	})
+
+	t.Run("Scoped To The Field", func(t *testing.T) {
+		assert.NotEqual(t, c.BlindIndex("actor", "alice"), c.BlindIndex("externalId", "alice"))
+	})
+
+	t.Run("Scoped To The Key", func(t *testing.T) {
+		other, err := New(bytes.Repeat([]byte("o"), 32))
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, c.BlindIndex("actor", "alice"), other.BlindIndex("actor", "alice"))
+	})
+
+	t.Run("Empty Value", func(t *testing.T) {
+		assert.Empty(t, c.BlindIndex("actor", ""))
+	})
+
+	t.Run("Without A Cipher", func(t *testing.T) {
+		var none *Cipher
+		assert.Equal(t, "alice", none.BlindIndex("actor", "alice"))
+	})
+}
+
+func TestSealOpen(t *testing.T) {
+	c, err := New(bytes.Repeat([]byte("k"), 32))
+	assert.NoError(t, err)
+
+	t.Run("OK", func(t *testing.T) {
+		index, encrypted, err := c.Seal("externalId", "76561198000000000")
+		assert.NoError(t, err)
+		assert.Equal(t, c.BlindIndex("externalId", "76561198000000000"), index)
+		assert.NotContains(t, encrypted, "76561198000000000")
+
+		value, err := c.Open(index, encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, "76561198000000000", value)
+	})
+
+	t.Run("Stored Before The Encryption", func(t *testing.T) {
+		value, err := c.Open("76561198000000000", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "76561198000000000", value)
+	})
+
+	t.Run("Without A Cipher", func(t *testing.T) {
+		var none *Cipher
+
+		index, encrypted, err := none.Seal("externalId", "76561198000000000")
+		assert.NoError(t, err)
+		assert.Equal(t, "76561198000000000", index)
+		assert.Empty(t, encrypted)
+
+		value, err := none.Open(index, encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, "76561198000000000", value)
+	})
+}
@@ -0,0 +1,28 @@
+package encryption
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// Decrypts a data key encrypted by AWS KMS, so only the encrypted key must be kept on the configuration
+func DecryptKeyWithKMS(ctx context.Context, region string, encryptedKey []byte) ([]byte, error) {
+	loadOpts := make([]func(*config.LoadOptions) error, 0)
+	if region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := kms.NewFromConfig(cfg).Decrypt(ctx, &kms.DecryptInput{CiphertextBlob: encryptedKey})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Plaintext, nil
+}
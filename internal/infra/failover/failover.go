@@ -0,0 +1,147 @@
+package failover
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultInterval         = 5 * time.Second
+	defaultTimeout          = 2 * time.Second
+	defaultFailureThreshold = 3
+)
+
+type State int
+
+const (
+	StatePrimary     State = iota // The primary endpoint is reachable and handles every operation
+	StateDegraded                 // Only the fallback endpoint is reachable, so only reads are handled
+	StateUnavailable              // No endpoint is reachable
+)
+
+func (s State) String() string {
+	switch s {
+	case StatePrimary:
+		return "primary"
+	case StateDegraded:
+		return "degraded"
+	default:
+		return "unavailable"
+	}
+}
+
+// Reports whether an endpoint can handle requests
+type CheckFunc func(ctx context.Context) error
+
+type Config struct {
+	Interval         time.Duration                    // Time between health checks. Defaults to 5s
+	Timeout          time.Duration                    // Timeout of each health check. Defaults to 2s
+	FailureThreshold int                              // Consecutive primary failures before failing over. Defaults to 3
+	OnChange         func(from, to State)             // Called when the state changes. Optional
+	OnCheckError     func(endpoint string, err error) // Called when a health check fails. Optional
+}
+
+func (c Config) withDefaults() Config {
+	if c.Interval <= 0 {
+		c.Interval = defaultInterval
+	}
+
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = defaultFailureThreshold
+	}
+
+	return c
+}
+
+// Tracks which endpoint must be used based on periodic health checks.
+// A single failed check doesn't trigger the failover, only `FailureThreshold` consecutive ones,
+// while a single successful check on the primary is enough to fail back to it
+type Monitor struct {
+	mu       sync.RWMutex
+	state    State
+	failures int
+
+	primary  CheckFunc
+	fallback CheckFunc
+	config   Config
+}
+
+func (m *Monitor) State() State {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.state
+}
+
+func (m *Monitor) check(ctx context.Context, endpoint string, fn CheckFunc) bool {
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+
+	err := fn(ctx)
+	if err != nil && m.config.OnCheckError != nil {
+		m.config.OnCheckError(endpoint, err)
+	}
+
+	return err == nil
+}
+
+// Runs a single health check round, returning the resulting state
+func (m *Monitor) Check(ctx context.Context) State {
+	next := StatePrimary
+	if !m.check(ctx, "primary", m.primary) {
+		next = StateUnavailable
+		if m.check(ctx, "fallback", m.fallback) {
+			next = StateDegraded
+		}
+	}
+
+	m.mu.Lock()
+	prev := m.state
+	switch {
+	case next == StatePrimary:
+		m.failures = 0
+		m.state = next
+	case m.failures+1 >= m.config.FailureThreshold:
+		m.failures++
+		m.state = next
+	default:
+		m.failures++
+	}
+	curr := m.state
+	m.mu.Unlock()
+
+	if prev != curr && m.config.OnChange != nil {
+		m.config.OnChange(prev, curr)
+	}
+
+	return curr
+}
+
+// Checks the endpoints on every interval until the context is canceled
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Check(ctx)
+		}
+	}
+}
+
+func NewMonitor(primary, fallback CheckFunc, config Config) *Monitor {
+	return &Monitor{
+		state:    StatePrimary,
+		primary:  primary,
+		fallback: fallback,
+		config:   config.withDefaults(),
+	}
+}
@@ -0,0 +1,87 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type endpoint struct {
+	healthy bool
+}
+
+func (e *endpoint) check(ctx context.Context) error {
+	if !e.healthy {
+		return errors.New("any error")
+	}
+
+	return nil
+}
+
+func TestMonitorCheck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Failover After Threshold", func(t *testing.T) {
+		var (
+			primary  = &endpoint{healthy: false}
+			fallback = &endpoint{healthy: true}
+			changes  = make([]State, 0)
+		)
+
+		monitor := NewMonitor(primary.check, fallback.check, Config{
+			FailureThreshold: 2,
+			OnChange:         func(from, to State) { changes = append(changes, to) },
+		})
+
+		assert.Equal(t, StatePrimary, monitor.Check(ctx))
+		assert.Equal(t, StateDegraded, monitor.Check(ctx))
+		assert.Equal(t, StateDegraded, monitor.State())
+		assert.Equal(t, []State{StateDegraded}, changes)
+	})
+
+	t.Run("Fail Back", func(t *testing.T) {
+		var (
+			primary  = &endpoint{healthy: false}
+			fallback = &endpoint{healthy: true}
+		)
+
+		monitor := NewMonitor(primary.check, fallback.check, Config{FailureThreshold: 1})
+		assert.Equal(t, StateDegraded, monitor.Check(ctx))
+
+		primary.healthy = true
+		assert.Equal(t, StatePrimary, monitor.Check(ctx))
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		var (
+			primary  = &endpoint{healthy: false}
+			fallback = &endpoint{healthy: true}
+		)
+
+		monitor := NewMonitor(primary.check, fallback.check, Config{FailureThreshold: 1})
+		assert.Equal(t, StateDegraded, monitor.Check(ctx))
+
+		fallback.healthy = false
+		assert.Equal(t, StateUnavailable, monitor.Check(ctx))
+	})
+
+	t.Run("Transient Failure", func(t *testing.T) {
+		var (
+			primary  = &endpoint{healthy: false}
+			fallback = &endpoint{healthy: true}
+		)
+
+		monitor := NewMonitor(primary.check, fallback.check, Config{FailureThreshold: 3})
+		assert.Equal(t, StatePrimary, monitor.Check(ctx))
+
+		primary.healthy = true
+		assert.Equal(t, StatePrimary, monitor.Check(ctx))
+
+		primary.healthy = false
+		assert.Equal(t, StatePrimary, monitor.Check(ctx))
+		assert.Equal(t, StatePrimary, monitor.Check(ctx))
+		assert.Equal(t, StateDegraded, monitor.Check(ctx))
+	})
+}
@@ -1,13 +1,69 @@
 package zap
 
 import (
+	"context"
+	"errors"
 	"os"
+	"sync"
+	"time"
 
 	"go.elastic.co/ecszap"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
-var logger *zap.SugaredLogger
+var ErrInvalidLevel = errors.New("invalid log level")
+
+var (
+	logger *zap.SugaredLogger
+
+	// Shared by the core, so changing it takes effect on the next entry logged
+	level = zap.NewAtomicLevelAt(zap.InfoLevel)
+
+	// Restores the level set before a temporary change
+	mu           sync.Mutex
+	restoreTimer *time.Timer
+	restoreLevel zapcore.Level
+)
+
+type contextKey struct{}
+
+// Returns a context whose logger adds the fields to every entry logged with it, on top of the ones already on the context.
+// The context is returned as is while the logger is not started
+func WithFields(ctx context.Context, keysAndValues ...any) context.Context {
+	l := fromContext(ctx)
+	if l == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, contextKey{}, l.With(keysAndValues...))
+}
+
+// Logger of the context, falling back to the global one
+func fromContext(ctx context.Context) *zap.SugaredLogger {
+	if l, ok := ctx.Value(contextKey{}).(*zap.SugaredLogger); ok {
+		return l
+	}
+
+	return logger
+}
+
+func DebugContext(ctx context.Context, msg string, keysAndValues ...any) {
+	fromContext(ctx).Debugw(msg, keysAndValues...)
+}
+
+func InfoContext(ctx context.Context, msg string, keysAndValues ...any) {
+	fromContext(ctx).Infow(msg, keysAndValues...)
+}
+
+func ErrorContext(ctx context.Context, err error, msg string, keysAndValues ...any) {
+	keysAndValues = append(keysAndValues, "error", err)
+	fromContext(ctx).Errorw(msg, keysAndValues...)
+}
+
+func Debug(msg string, keysAndValues ...any) {
+	logger.Debugw(msg, keysAndValues...)
+}
 
 func Info(msg string, keysAndValues ...any) {
 	logger.Infow(msg, keysAndValues...)
@@ -27,11 +83,58 @@ func Sync() error {
 	return logger.Sync()
 }
 
+// Current minimum level logged, e.g. `info`
+func Level() string {
+	return level.String()
+}
+
+// Changes the minimum level logged, one of `debug`, `info`, `warn` or `error`.
+// With a positive duration, the previous level is restored once it elapses.
+// A change made while a temporary one is pending cancels its restore, or takes it over when it is temporary too
+func SetLevel(name string, duration time.Duration) error {
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(name)); name == "" || err != nil || l < zap.DebugLevel || l > zap.ErrorLevel {
+		return ErrInvalidLevel
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	previous := level.Level()
+	if restoreTimer != nil {
+		restoreTimer.Stop()
+		restoreTimer = nil
+		previous = restoreLevel
+	}
+
+	level.SetLevel(l)
+	if duration <= 0 {
+		return nil
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(duration, func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Replaced by a later change while waiting for the lock
+		if restoreTimer != timer {
+			return
+		}
+
+		level.SetLevel(restoreLevel)
+		restoreTimer = nil
+	})
+	restoreTimer, restoreLevel = timer, previous
+
+	return nil
+}
+
 func Start() {
 	if logger != nil {
 		return
 	}
 
-	core := ecszap.NewCore(ecszap.NewDefaultEncoderConfig(), os.Stdout, zap.InfoLevel)
+	core := ecszap.NewCore(ecszap.NewDefaultEncoderConfig(), os.Stdout, level)
 	logger = zap.New(core, zap.AddCaller(), zap.AddStacktrace(zap.PanicLevel), zap.AddCallerSkip(1)).Sugar()
 }
@@ -0,0 +1,87 @@
+package zap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSetLevel(t *testing.T) {
+	defer SetLevel("info", 0)
+
+	t.Run("OK", func(t *testing.T) {
+		err := SetLevel("warn", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "warn", Level())
+	})
+
+	t.Run("Temporary", func(t *testing.T) {
+		assert.NoError(t, SetLevel("info", 0))
+
+		err := SetLevel("debug", 50*time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, "debug", Level())
+
+		// Takes over the pending restore, so the level set before both is restored
+		err = SetLevel("error", 50*time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, "error", Level())
+
+		assert.Eventually(t, func() bool { return Level() == "info" }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Permanent Cancels Restore", func(t *testing.T) {
+		assert.NoError(t, SetLevel("info", 0))
+		assert.NoError(t, SetLevel("debug", 20*time.Millisecond))
+		assert.NoError(t, SetLevel("warn", 0))
+
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, "warn", Level())
+	})
+
+	t.Run("Invalid Level", func(t *testing.T) {
+		for _, name := range []string{"", "unknown", "panic", "fatal"} {
+			err := SetLevel(name, 0)
+			assert.ErrorIs(t, err, ErrInvalidLevel, name)
+		}
+	})
+}
+
+func TestWithFields(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		previous := logger
+		defer func() { logger = previous }()
+
+		core, logs := observer.New(zap.InfoLevel)
+		logger = zap.New(core).Sugar()
+
+		ctx := WithFields(context.Background(), "requestId", "request")
+		ctx = WithFields(ctx, "gameId", "game")
+
+		InfoContext(ctx, "handled", "status", 200)
+		ErrorContext(ctx, errors.New("any error"), "failed")
+		InfoContext(context.Background(), "global")
+
+		entries := logs.AllUntimed()
+		assert.Len(t, entries, 3)
+		assert.Equal(t, map[string]any{"requestId": "request", "gameId": "game", "status": int64(200)}, entries[0].ContextMap())
+		assert.Equal(t, "game", entries[1].ContextMap()["gameId"])
+		assert.Equal(t, "any error", entries[1].ContextMap()["error"])
+		assert.Empty(t, entries[2].ContextMap())
+	})
+
+	t.Run("Not Started", func(t *testing.T) {
+		previous := logger
+		defer func() { logger = previous }()
+
+		logger = nil
+		ctx := context.Background()
+
+		assert.Equal(t, ctx, WithFields(ctx, "requestId", "request"))
+	})
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Results of a consumed message
+const (
+	ConsumerResultProcessed = "processed" // Acked, either applied or moved to the dead letters
+	ConsumerResultRetried   = "retried"   // Failed and scheduled to be consumed again
+)
+
+// Records the throughput of the ingestion consumer.
+// A nil `*Consumer` is valid and records nothing
+type Consumer struct {
+	messages *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	backlog  *prometheus.GaugeVec
+}
+
+func NewConsumer(registerer prometheus.Registerer) *Consumer {
+	c := &Consumer{
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "consumer",
+			Name:      "messages_total",
+			Help:      "Messages consumed, by result",
+		}, []string{"broker", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "consumer",
+			Name:      "batch_duration_seconds",
+			Help:      "Time processing each batch of consumed messages",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"broker"}),
+		backlog: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "consumer",
+			Name:      "backlog_messages",
+			Help:      "Messages waiting to be consumed on the last measure, by queue",
+		}, []string{"broker", "queue"}),
+	}
+
+	registerer.MustRegister(c.messages, c.duration, c.backlog)
+	return c
+}
+
+// Records a batch once each of its messages was processed or failed
+func (c *Consumer) Observe(broker string, processed, retried int, duration time.Duration) {
+	if c == nil {
+		return
+	}
+
+	c.messages.WithLabelValues(broker, ConsumerResultProcessed).Add(float64(processed))
+	c.messages.WithLabelValues(broker, ConsumerResultRetried).Add(float64(retried))
+	c.duration.WithLabelValues(broker).Observe(duration.Seconds())
+}
+
+// Records the messages waiting on a consumed queue
+func (c *Consumer) ObserveBacklog(broker, queue string, messages int64) {
+	if c == nil {
+		return
+	}
+
+	c.backlog.WithLabelValues(broker, queue).Set(float64(messages))
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumerObserve(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		c := NewConsumer(prometheus.NewRegistry())
+
+		c.Observe("rabbitmq", 8, 2, time.Second)
+		c.Observe("rabbitmq", 10, 0, time.Second)
+
+		assert.Equal(t, float64(18), testutil.ToFloat64(c.messages.WithLabelValues("rabbitmq", ConsumerResultProcessed)))
+		assert.Equal(t, float64(2), testutil.ToFloat64(c.messages.WithLabelValues("rabbitmq", ConsumerResultRetried)))
+		assert.Equal(t, 1, testutil.CollectAndCount(c.duration))
+	})
+
+	t.Run("Nil Consumer", func(t *testing.T) {
+		var c *Consumer
+
+		assert.NotPanics(t, func() {
+			c.Observe("sqs", 1, 0, time.Second)
+		})
+	})
+}
+
+func TestConsumerObserveBacklog(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		c := NewConsumer(prometheus.NewRegistry())
+
+		c.ObserveBacklog("rabbitmq", "gameblitz.ingestion", 20)
+		c.ObserveBacklog("rabbitmq", "gameblitz.ingestion", 5)
+
+		assert.Equal(t, float64(5), testutil.ToFloat64(c.backlog.WithLabelValues("rabbitmq", "gameblitz.ingestion")))
+	})
+
+	t.Run("Nil Consumer", func(t *testing.T) {
+		var c *Consumer
+
+		assert.NotPanics(t, func() {
+			c.ObserveBacklog("sqs", "gameblitz-ingestion", 1)
+		})
+	})
+}
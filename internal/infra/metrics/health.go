@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reports whether a dependency can handle requests
+type CheckFunc func(ctx context.Context) error
+
+// Tracks whether the brokers and storages the service depends on are reachable.
+// A nil `*Health` is valid and records nothing
+type Health struct {
+	up *prometheus.GaugeVec
+}
+
+func NewHealth(registerer prometheus.Registerer) *Health {
+	h := &Health{
+		up: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "dependency_up",
+			Help:      "Whether the last health check of the dependency succeeded, 1 when it did and 0 otherwise",
+		}, []string{"dependency"}),
+	}
+
+	registerer.MustRegister(h.up)
+	return h
+}
+
+// Runs the checks concurrently, each bounded by `timeout`, and updates the gauge of their dependency.
+// `onError` is called with each failed check. Optional
+func (h *Health) Check(ctx context.Context, timeout time.Duration, checks map[string]CheckFunc, onError func(dependency string, err error)) {
+	if h == nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for dependency, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			err := check(ctx)
+			if err != nil && onError != nil {
+				onError(dependency, err)
+			}
+
+			up := 0.0
+			if err == nil {
+				up = 1
+			}
+			h.up.WithLabelValues(dependency).Set(up)
+		}()
+	}
+	wg.Wait()
+}
+
+// Checks the dependencies right away and then on every interval until the context is canceled
+func (h *Health) Run(ctx context.Context, interval, timeout time.Duration, checks map[string]CheckFunc, onError func(dependency string, err error)) {
+	if h == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		h.Check(ctx, timeout, checks, onError)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
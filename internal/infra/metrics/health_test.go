@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			h         = NewHealth(prometheus.NewRegistry())
+			checkErr  = errors.New("any error")
+			failed    string
+			failedErr error
+		)
+
+		h.Check(ctx, time.Second, map[string]CheckFunc{
+			"mongo":    func(ctx context.Context) error { return nil },
+			"rabbitmq": func(ctx context.Context) error { return checkErr },
+		}, func(dependency string, err error) {
+			failed, failedErr = dependency, err
+		})
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(h.up.WithLabelValues("mongo")))
+		assert.Equal(t, float64(0), testutil.ToFloat64(h.up.WithLabelValues("rabbitmq")))
+		assert.Equal(t, "rabbitmq", failed)
+		assert.ErrorIs(t, failedErr, checkErr)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		h := NewHealth(prometheus.NewRegistry())
+
+		h.Check(ctx, time.Millisecond, map[string]CheckFunc{
+			"redis": func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}, nil)
+
+		assert.Equal(t, float64(0), testutil.ToFloat64(h.up.WithLabelValues("redis")))
+	})
+
+	t.Run("Nil Health", func(t *testing.T) {
+		var h *Health
+
+		assert.NotPanics(t, func() {
+			h.Check(ctx, time.Second, map[string]CheckFunc{"mongo": func(ctx context.Context) error { return nil }}, nil)
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Labels of every HTTP metric. The route is the pattern the request matched, e.g. `/api/v1/leaderboards/:leaderboardId`,
+// so the IDs on the path don't create a series each
+var httpLabels = []string{"method", "route", "status"}
+
+// Records the count and the latency of the requests handled by the API.
+// A nil `*HTTP` is valid and records nothing
+type HTTP struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func NewHTTP(registerer prometheus.Registerer) *HTTP {
+	h := &HTTP{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Requests handled by the API",
+		}, httpLabels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the requests handled by the API",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, httpLabels),
+	}
+
+	registerer.MustRegister(h.requests, h.duration)
+	return h
+}
+
+// Records a request once its response is written. The latency of a sampled request carries its trace ID as an exemplar,
+// so a slow bucket links to a trace that landed on it
+func (h *HTTP) Observe(ctx context.Context, method, route string, status int, duration time.Duration) {
+	if h == nil {
+		return
+	}
+
+	labels := prometheus.Labels{"method": method, "route": route, "status": strconv.Itoa(status)}
+
+	h.requests.With(labels).Inc()
+
+	observer := h.duration.With(labels)
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsSampled() {
+		observer.(prometheus.ExemplarObserver).ObserveWithExemplar(duration.Seconds(), prometheus.Labels{"trace_id": spanContext.TraceID().String()})
+		return
+	}
+
+	observer.Observe(duration.Seconds())
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHTTPObserve(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		h := NewHTTP(prometheus.NewRegistry())
+
+		h.Observe(context.Background(), "GET", "/api/v1/leaderboards/:leaderboardId", 200, time.Millisecond)
+		h.Observe(context.Background(), "GET", "/api/v1/leaderboards/:leaderboardId", 200, time.Millisecond)
+		h.Observe(context.Background(), "GET", "/api/v1/leaderboards/:leaderboardId", 404, time.Millisecond)
+
+		assert.Equal(t, float64(2), testutil.ToFloat64(h.requests.WithLabelValues("GET", "/api/v1/leaderboards/:leaderboardId", "200")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(h.requests.WithLabelValues("GET", "/api/v1/leaderboards/:leaderboardId", "404")))
+		assert.Equal(t, 2, testutil.CollectAndCount(h.duration))
+	})
+
+	t.Run("Exemplar", func(t *testing.T) {
+		h := NewHTTP(prometheus.NewRegistry())
+
+		traceID := trace.TraceID{1}
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{1},
+			TraceFlags: trace.FlagsSampled,
+		}))
+
+		h.Observe(ctx, "GET", "/api/v1/leaderboards/:leaderboardId", 200, time.Millisecond)
+
+		var data dto.Metric
+		err := h.duration.WithLabelValues("GET", "/api/v1/leaderboards/:leaderboardId", "200").(prometheus.Metric).Write(&data)
+		assert.NoError(t, err)
+
+		var exemplars []*dto.Exemplar
+		for _, bucket := range data.GetHistogram().GetBucket() {
+			if bucket.GetExemplar() != nil {
+				exemplars = append(exemplars, bucket.GetExemplar())
+			}
+		}
+
+		assert.Len(t, exemplars, 1)
+		assert.Equal(t, "trace_id", exemplars[0].GetLabel()[0].GetName())
+		assert.Equal(t, traceID.String(), exemplars[0].GetLabel()[0].GetValue())
+	})
+
+	t.Run("Nil HTTP", func(t *testing.T) {
+		var h *HTTP
+
+		assert.NotPanics(t, func() {
+			h.Observe(context.Background(), "GET", "/metrics", 200, time.Millisecond)
+		})
+	})
+}
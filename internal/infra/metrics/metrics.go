@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Every metric exposed by the service is prefixed by the namespace
+const namespace = "gameblitz"
+
+// Creates a registry with the Go runtime and process collectors already registered
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return registry
+}
+
+// Serves the metrics gathered by the registry on the Prometheus text format,
+// or on the OpenMetrics one when the scraper asks for it, which is the only one carrying the exemplars
+func Handler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry, EnableOpenMetrics: true})
+}
@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+var ErrInvalidSLOTarget = errors.New("invalid slo target")
+
+// Quantiles tracked for each route, with their allowed error
+var sloObjectives = map[float64]float64{0.5: 0.05, 0.95: 0.01, 0.99: 0.001}
+
+type (
+	// Latency of a route over the SLO window
+	RouteLatency struct {
+		Method string        // Request method
+		Route  string        // Route pattern, e.g. `/api/v1/leaderboards/:leaderboardId`
+		Count  uint64        // Requests handled since the service started
+		P50    time.Duration // Median latency over the window
+		P95    time.Duration // 95th percentile latency over the window
+		P99    time.Duration // 99th percentile latency over the window
+		Target time.Duration // Latency the 99th percentile must stay under. Zero without a target
+	}
+
+	// Latency of every route handled over the SLO window
+	SLOReport struct {
+		Window time.Duration  // Period the quantiles are computed over
+		Routes []RouteLatency // Routes ordered by pattern and method
+	}
+)
+
+// Whether the 99th percentile is under the target. Always true without a target
+func (l RouteLatency) Met() bool {
+	return l.Target == 0 || l.P99 <= l.Target
+}
+
+// Parses the targets written as `<route>=<duration>`, e.g. `/api/v1/leaderboards/:leaderboardId/ranking/=50ms`
+func ParseSLOTargets(targets []string) (map[string]time.Duration, error) {
+	parsed := make(map[string]time.Duration, len(targets))
+	for _, target := range targets {
+		i := strings.LastIndex(target, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSLOTarget, target)
+		}
+
+		value, err := time.ParseDuration(target[i+1:])
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSLOTarget, target)
+		}
+
+		parsed[target[:i]] = value
+	}
+
+	return parsed, nil
+}
+
+// Computes the latency quantiles of each route over a sliding window, so they can be checked against their targets
+// without querying Prometheus. A nil `*SLO` is valid and records nothing
+type SLO struct {
+	window  time.Duration
+	targets map[string]time.Duration
+	latency *prometheus.SummaryVec
+}
+
+func NewSLO(registerer prometheus.Registerer, window time.Duration, targets map[string]time.Duration) *SLO {
+	s := &SLO{
+		window:  window,
+		targets: targets,
+		latency: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Subsystem:  "slo",
+			Name:       "request_duration_seconds",
+			Help:       "Latency quantiles of the requests handled by the API over the SLO window",
+			Objectives: sloObjectives,
+			MaxAge:     window,
+		}, []string{"method", "route"}),
+	}
+
+	registerer.MustRegister(s.latency)
+	return s
+}
+
+// Records a request once its response is written. Matches `HTTP.Observe`, so both can record the same requests
+func (s *SLO) Observe(ctx context.Context, method, route string, status int, duration time.Duration) {
+	if s == nil {
+		return
+	}
+
+	s.latency.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// Latency of each route observed so far. Quantiles of routes without requests on the window are zero
+func (s *SLO) Report() (SLOReport, error) {
+	if s == nil {
+		return SLOReport{Routes: make([]RouteLatency, 0)}, nil
+	}
+
+	metrics := make(chan prometheus.Metric)
+	go func() {
+		s.latency.Collect(metrics)
+		close(metrics)
+	}()
+
+	var (
+		routes = make([]RouteLatency, 0)
+		errs   []error
+	)
+	for metric := range metrics {
+		var data dto.Metric
+		if err := metric.Write(&data); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		var latency RouteLatency
+		for _, label := range data.GetLabel() {
+			switch label.GetName() {
+			case "method":
+				latency.Method = label.GetValue()
+			case "route":
+				latency.Route = label.GetValue()
+			}
+		}
+
+		summary := data.GetSummary()
+		latency.Count = summary.GetSampleCount()
+		latency.Target = s.targets[latency.Route]
+
+		for _, q := range summary.GetQuantile() {
+			// Quantiles without samples on the window are reported as NaN
+			value := q.GetValue()
+			if value != value {
+				continue
+			}
+
+			d := time.Duration(value * float64(time.Second))
+			switch q.GetQuantile() {
+			case 0.5:
+				latency.P50 = d
+			case 0.95:
+				latency.P95 = d
+			case 0.99:
+				latency.P99 = d
+			}
+		}
+
+		routes = append(routes, latency)
+	}
+
+	slices.SortFunc(routes, func(a, b RouteLatency) int {
+		if c := strings.Compare(a.Route, b.Route); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Method, b.Method)
+	})
+
+	return SLOReport{Window: s.window, Routes: routes}, errors.Join(errs...)
+}
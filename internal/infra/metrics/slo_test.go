@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSLOTargets(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		targets, err := ParseSLOTargets([]string{"/api/v1/leaderboards/:leaderboardId/ranking/=50ms"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]time.Duration{"/api/v1/leaderboards/:leaderboardId/ranking/": 50 * time.Millisecond}, targets)
+	})
+
+	t.Run("Invalid Target", func(t *testing.T) {
+		for _, target := range []string{"", "=50ms", "/metrics", "/metrics=fast", "/metrics=0s", "/metrics=-1ms"} {
+			_, err := ParseSLOTargets([]string{target})
+			assert.ErrorIs(t, err, ErrInvalidSLOTarget, target)
+		}
+	})
+}
+
+func TestSLOReport(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		var (
+			ctx     = context.Background()
+			ranking = "/api/v1/leaderboards/:leaderboardId/ranking/"
+			s       = NewSLO(prometheus.NewRegistry(), time.Minute, map[string]time.Duration{ranking: 50 * time.Millisecond})
+		)
+
+		for i := 1; i <= 100; i++ {
+			s.Observe(ctx, "GET", ranking, 200, time.Duration(i)*time.Millisecond)
+		}
+		s.Observe(ctx, "GET", "/api/v1/leaderboards/:leaderboardId", 200, time.Millisecond)
+
+		report, err := s.Report()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, report.Window)
+		assert.Len(t, report.Routes, 2)
+
+		assert.Equal(t, "/api/v1/leaderboards/:leaderboardId", report.Routes[0].Route)
+		assert.Zero(t, report.Routes[0].Target)
+		assert.True(t, report.Routes[0].Met())
+
+		latency := report.Routes[1]
+		assert.Equal(t, "GET", latency.Method)
+		assert.Equal(t, uint64(100), latency.Count)
+		assert.InDelta(t, 50*time.Millisecond, latency.P50, float64(5*time.Millisecond))
+		assert.InDelta(t, 95*time.Millisecond, latency.P95, float64(2*time.Millisecond))
+		assert.InDelta(t, 99*time.Millisecond, latency.P99, float64(time.Millisecond))
+		assert.Equal(t, 50*time.Millisecond, latency.Target)
+		assert.False(t, latency.Met())
+	})
+
+	t.Run("Nil SLO", func(t *testing.T) {
+		var s *SLO
+
+		assert.NotPanics(t, func() {
+			s.Observe(context.Background(), "GET", "/metrics", 200, time.Millisecond)
+		})
+
+		report, err := s.Report()
+		assert.NoError(t, err)
+		assert.Empty(t, report.Routes)
+	})
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Labels of every storage metric. The collection holds the collection, table or key pattern the operation touched
+var storageLabels = []string{"storage", "operation", "collection"}
+
+// Records the latency, the errors and the in-flight count of the calls made to the storage drivers.
+// A nil `*Storage` is valid and records nothing, so the drivers can be used without metrics
+type Storage struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+	inFlight *prometheus.GaugeVec
+}
+
+// Reports the outcome of an operation started by `Storage.Observe`
+type FinishFunc func(err error)
+
+func NewStorage(registerer prometheus.Registerer) *Storage {
+	s := &Storage{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of the storage operations",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, storageLabels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "operation_errors_total",
+			Help:      "Storage operations that failed",
+		}, storageLabels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "operations_in_flight",
+			Help:      "Storage operations waiting for a response",
+		}, storageLabels),
+	}
+
+	registerer.MustRegister(s.duration, s.errors, s.inFlight)
+	return s
+}
+
+// Marks the start of an operation. The returned function must be called once the operation finishes
+func (s *Storage) Observe(storage, operation, collection string) FinishFunc {
+	if s == nil {
+		return func(error) {}
+	}
+
+	labels := prometheus.Labels{"storage": storage, "operation": operation, "collection": collection}
+
+	inFlight := s.inFlight.With(labels)
+	inFlight.Inc()
+
+	start := time.Now()
+	return func(err error) {
+		inFlight.Dec()
+		s.duration.With(labels).Observe(time.Since(start).Seconds())
+
+		if err != nil {
+			s.errors.With(labels).Inc()
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageObserve(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		s := NewStorage(prometheus.NewRegistry())
+
+		finish := s.Observe("mongo", "find", "statistics")
+		assert.Equal(t, float64(1), testutil.ToFloat64(s.inFlight.WithLabelValues("mongo", "find", "statistics")))
+
+		finish(nil)
+		assert.Equal(t, float64(0), testutil.ToFloat64(s.inFlight.WithLabelValues("mongo", "find", "statistics")))
+		assert.Equal(t, float64(0), testutil.ToFloat64(s.errors.WithLabelValues("mongo", "find", "statistics")))
+		assert.Equal(t, 1, testutil.CollectAndCount(s.duration))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		s := NewStorage(prometheus.NewRegistry())
+
+		s.Observe("redis", "zadd", "leaderboard:*:ranking")(errors.New("any error"))
+		assert.Equal(t, float64(1), testutil.ToFloat64(s.errors.WithLabelValues("redis", "zadd", "leaderboard:*:ranking")))
+	})
+
+	t.Run("Nil Storage", func(t *testing.T) {
+		var s *Storage
+
+		assert.NotPanics(t, func() {
+			s.Observe("mongo", "find", "statistics")(errors.New("any error"))
+		})
+	})
+}
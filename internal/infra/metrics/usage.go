@@ -0,0 +1,43 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Records the requests and score submissions handled for each game.
+// Each game creates a series, so the metrics are only meant for deployments serving a bounded number of games.
+// A nil `*Usage` is valid and records nothing
+type Usage struct {
+	requests         *prometheus.CounterVec
+	scoreSubmissions *prometheus.CounterVec
+}
+
+func NewUsage(registerer prometheus.Registerer) *Usage {
+	u := &Usage{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "usage",
+			Name:      "requests_total",
+			Help:      "Requests handled for each game",
+		}, []string{"game_id"}),
+		scoreSubmissions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "usage",
+			Name:      "score_submissions_total",
+			Help:      "Rank and statistic progression updates handled for each game",
+		}, []string{"game_id"}),
+	}
+
+	registerer.MustRegister(u.requests, u.scoreSubmissions)
+	return u
+}
+
+// Records a request of the game. The error codes are left out, since they would create a series per game and code
+func (u *Usage) Record(gameID string, scoreSubmission bool) {
+	if u == nil {
+		return
+	}
+
+	u.requests.WithLabelValues(gameID).Inc()
+	if scoreSubmission {
+		u.scoreSubmissions.WithLabelValues(gameID).Inc()
+	}
+}
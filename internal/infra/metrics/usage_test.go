@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageRecord(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		u := NewUsage(prometheus.NewRegistry())
+
+		u.Record("game", false)
+		u.Record("game", true)
+		u.Record("other", false)
+
+		assert.Equal(t, float64(2), testutil.ToFloat64(u.requests.WithLabelValues("game")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(u.scoreSubmissions.WithLabelValues("game")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(u.requests.WithLabelValues("other")))
+	})
+
+	t.Run("Nil Usage", func(t *testing.T) {
+		var u *Usage
+
+		assert.NotPanics(t, func() {
+			u.Record("game", true)
+		})
+	})
+}
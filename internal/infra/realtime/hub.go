@@ -0,0 +1,73 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+)
+
+// Events buffered for each subscriber. A subscriber that falls further behind misses the next events
+const subscriberBuffer = 64
+
+// Fans out the events published on a topic, e.g. a leaderboard ID, to the subscribers of that topic
+// on this instance. Publishing never blocks on a slow subscriber
+type Hub[T any] struct {
+	mu     sync.RWMutex
+	topics map[string]map[chan T]struct{}
+}
+
+// Sends the event to the current subscribers of the topic, dropping it for the ones whose buffer is full
+func (h *Hub[T]) Publish(topic string, event T) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.topics[topic] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Receives the events published on the topic until the context is done, when the channel is closed
+func (h *Hub[T]) Subscribe(ctx context.Context, topic string) <-chan T {
+	ch := make(chan T, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[chan T]struct{})
+	}
+	h.topics[topic][ch] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.topics[topic], ch)
+		if len(h.topics[topic]) == 0 {
+			delete(h.topics, topic)
+		}
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Subscribers on this instance, across every topic
+func (h *Hub[T]) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	total := 0
+	for _, subscribers := range h.topics {
+		total += len(subscribers)
+	}
+
+	return total
+}
+
+func NewHub[T any]() *Hub[T] {
+	return &Hub[T]{topics: make(map[string]map[chan T]struct{})}
+}
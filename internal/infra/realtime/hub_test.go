@@ -0,0 +1,63 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		var (
+			hub         = NewHub[string]()
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+		defer cancel()
+
+		first := hub.Subscribe(ctx, "a")
+		second := hub.Subscribe(ctx, "a")
+		other := hub.Subscribe(ctx, "b")
+		assert.Equal(t, 3, hub.Subscribers())
+
+		hub.Publish("a", "event")
+
+		assert.Equal(t, "event", <-first)
+		assert.Equal(t, "event", <-second)
+		assert.Len(t, other, 0)
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		var (
+			hub         = NewHub[string]()
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+
+		events := hub.Subscribe(ctx, "a")
+		cancel()
+
+		_, ok := <-events
+		assert.False(t, ok)
+		assert.Equal(t, 0, hub.Subscribers())
+
+		assert.NotPanics(t, func() {
+			hub.Publish("a", "event")
+		})
+	})
+
+	t.Run("Slow Subscriber", func(t *testing.T) {
+		var (
+			hub         = NewHub[int]()
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+		defer cancel()
+
+		events := hub.Subscribe(ctx, "a")
+		for i := range subscriberBuffer + 1 {
+			hub.Publish("a", i)
+		}
+
+		assert.Len(t, events, subscriberBuffer)
+		assert.Equal(t, 0, <-events)
+	})
+}
@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/infra/logger/zap"
+	"github.com/gabapcia/gameblitz/internal/infra/realtime"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Every channel is named under this prefix, so the backplane can share an instance with other data
+const channelPrefix = "realtime:"
+
+// Relays the events published on any instance to the subscribers of every instance through Redis Pub/Sub.
+// Each instance holds a single pattern subscription for the backplane and fans the events out to its own subscribers
+type Backplane[T any] struct {
+	hub    *realtime.Hub[T]
+	client *redis.Client
+	pubsub *redis.PubSub
+	prefix string
+}
+
+// Sends the event to the subscribers of the topic on every instance, including this one
+func (b *Backplane[T]) Publish(ctx context.Context, topic string, event T) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return b.client.Publish(ctx, b.prefix+topic, data).Err()
+}
+
+// Receives the events published on the topic by any instance until the context is done, when the channel is closed
+func (b *Backplane[T]) Subscribe(ctx context.Context, topic string) <-chan T {
+	return b.hub.Subscribe(ctx, topic)
+}
+
+// Subscribers on this instance, across every topic
+func (b *Backplane[T]) Subscribers() int {
+	return b.hub.Subscribers()
+}
+
+func (b *Backplane[T]) relay() {
+	for msg := range b.pubsub.Channel() {
+		var event T
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			zap.Error(err, "invalid realtime event", "channel", msg.Channel)
+			continue
+		}
+
+		b.hub.Publish(strings.TrimPrefix(msg.Channel, b.prefix), event)
+	}
+}
+
+func (b *Backplane[T]) Close() error {
+	if err := b.pubsub.Close(); err != nil {
+		return err
+	}
+
+	return b.client.Close()
+}
+
+// The name tells apart the backplanes sharing an instance, e.g. one per event kind. The subscription is confirmed before
+// returning, so the events published afterwards are not missed. The driver resubscribes on its own after a disconnection
+func New[T any](ctx context.Context, url, name string) (*Backplane[T], error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		client = redis.NewClient(opts)
+		prefix = channelPrefix + name + ":"
+		pubsub = client.PSubscribe(ctx, prefix+"*")
+	)
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		client.Close()
+		return nil, err
+	}
+
+	b := &Backplane[T]{
+		hub:    realtime.NewHub[T](),
+		client: client,
+		pubsub: pubsub,
+		prefix: prefix,
+	}
+	go b.relay()
+
+	return b, nil
+}
@@ -0,0 +1,67 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+var ErrMissingDSN = errors.New("sentry requires a dsn")
+
+type Options struct {
+	DSN         string  // Project DSN the errors are sent to
+	Environment string  // Environment the errors are reported from, e.g. `production`
+	Release     string  // Version of the service reporting the errors. Optional
+	SampleRate  float64 // Ratio of the errors sent, from 0 to 1
+}
+
+// Sends the errors to Sentry alongside the request that failed and the stack trace where they were reported
+type reporter struct {
+	hub *sentry.Hub
+}
+
+// Each report runs on its own scope, so the request and tags of one never leak to another
+func (r reporter) Report(ctx context.Context, err error, req *http.Request, tags map[string]string) {
+	hub := r.hub.Clone()
+	hub.WithScope(func(scope *sentry.Scope) {
+		if req != nil {
+			scope.SetRequest(req)
+		}
+
+		scope.SetTags(tags)
+		hub.CaptureException(err)
+	})
+}
+
+// Waits for the errors still being sent, up to the timeout
+func (r reporter) Flush(timeout time.Duration) bool {
+	return r.hub.Flush(timeout)
+}
+
+func newReporter(opts sentry.ClientOptions) (*reporter, error) {
+	client, err := sentry.NewClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reporter{hub: sentry.NewHub(client, sentry.NewScope())}, nil
+}
+
+// The request headers holding credentials, like `Authorization` and `Cookie`, are never sent
+func New(opts Options) (*reporter, error) {
+	if opts.DSN == "" {
+		return nil, ErrMissingDSN
+	}
+
+	return newReporter(sentry.ClientOptions{
+		Dsn:              opts.DSN,
+		Environment:      opts.Environment,
+		Release:          opts.Release,
+		SampleRate:       opts.SampleRate,
+		AttachStacktrace: true,
+		SendDefaultPII:   false,
+	})
+}
@@ -0,0 +1,60 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type transport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *transport) Flush(timeout time.Duration) bool       { return true }
+func (t *transport) Configure(options sentry.ClientOptions) {}
+
+func (t *transport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, event)
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Missing DSN", func(t *testing.T) {
+		_, err := New(Options{})
+		assert.ErrorIs(t, err, ErrMissingDSN)
+	})
+}
+
+func TestReport(t *testing.T) {
+	tr := &transport{}
+
+	r, err := newReporter(sentry.ClientOptions{Transport: tr, AttachStacktrace: true})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/api/v1/leaderboards/any", nil)
+	req.Header.Set("Authorization", "secret")
+
+	r.Report(context.Background(), errors.New("any error"), req, map[string]string{"route": "/api/v1/leaderboards/:leaderboardId"})
+	r.Report(context.Background(), errors.New("other error"), nil, map[string]string{"game_id": "any"})
+
+	assert.Len(t, tr.events, 2)
+
+	event := tr.events[0]
+	assert.Equal(t, "any error", event.Exception[0].Value)
+	assert.Equal(t, "/api/v1/leaderboards/:leaderboardId", event.Tags["route"])
+	assert.Equal(t, "GET", event.Request.Method)
+	assert.NotContains(t, event.Request.Headers, "Authorization")
+
+	// The scope of a report doesn't leak to the next one
+	assert.NotContains(t, tr.events[1].Tags, "route")
+	assert.Nil(t, tr.events[1].Request)
+}
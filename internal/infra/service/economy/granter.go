@@ -0,0 +1,87 @@
+package economy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/reward"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Response bodies are read up to this size, so they can be added to the error without loading large bodies
+const maxResponseBody = 4 << 10
+
+// Sent alongside every grant, so the economy service can drop the grants it already applied
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// Posts the reward grants to the game's economy service, which must answer with a 2xx status once they're applied
+type granter struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// The grant is posted as a `gameblitz.player.reward_grant` message. When a secret is set, it's signed the same
+// way as the webhook deliveries, so the economy service can verify it with the same code
+func (g granter) GrantRewards(ctx context.Context, grant reward.Grant) error {
+	body, err := message.Encode(message.SchemaRewardGrant, message.FromRewardGrant(grant))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderIdempotencyKey, grant.ID)
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	if g.secret != "" {
+		req.Header.Set(webhook.HeaderSignature, webhook.Sign(g.secret, now, body))
+	}
+
+	res, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseBody))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("Economy Grant Rewards: [%d] - %s", res.StatusCode, data)
+	}
+
+	return nil
+}
+
+func (g granter) Close() {
+	g.client.CloseIdleConnections()
+}
+
+// Creates a granter that waits up to `timeout` for each response. Defaults to 10s
+func New(url, secret string, timeout time.Duration) *granter {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &granter{
+		url:    url,
+		secret: secret,
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package economy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/async/message"
+	"github.com/gabapcia/gameblitz/internal/reward"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrantRewards(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		secret = "secret"
+		grant  = reward.Grant{
+			CreatedAt: time.Now().UTC(),
+			ID:        reward.GrantID("rule", "player"),
+			GameID:    "game",
+			PlayerID:  "player",
+			RuleID:    "rule",
+			Source:    reward.SourceQuest,
+			SourceID:  "tutorial",
+			Rewards:   []reward.Reward{{Item: "gold", Quantity: 100}},
+		}
+	)
+
+	t.Run("Granted", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+
+			timestamp, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+			assert.NoError(t, err)
+			assert.Equal(t, webhook.Sign(secret, time.Unix(timestamp, 0), body), r.Header.Get(webhook.HeaderSignature))
+			assert.Equal(t, grant.ID, r.Header.Get(HeaderIdempotencyKey))
+
+			var data message.RewardGrant
+			assert.NoError(t, message.Decode(message.SchemaRewardGrant, body, &data))
+			assert.Equal(t, message.FromRewardGrant(grant).Rewards, data.Rewards)
+
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		g := New(server.URL, secret, time.Second)
+		defer g.Close()
+
+		assert.NoError(t, g.GrantRewards(ctx, grant))
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get(webhook.HeaderSignature))
+			http.Error(w, "unknown item", http.StatusUnprocessableEntity)
+		}))
+		defer server.Close()
+
+		err := New(server.URL, "", time.Second).GrantRewards(ctx, grant)
+		assert.ErrorContains(t, err, "422")
+	})
+}
@@ -17,7 +17,8 @@ type claims struct {
 
 func (c claims) toDomain() auth.Claims {
 	return auth.Claims{
-		GameID: c.GameID,
+		GameID:  c.GameID,
+		Subject: c.Subject,
 	}
 }
 
@@ -0,0 +1,130 @@
+package dynamodb
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Fixed width layout, so the stored timestamps keep their order when compared as strings
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+func stringValue(s string) types.AttributeValue {
+	return &types.AttributeValueMemberS{Value: s}
+}
+
+func numberValue(f float64) types.AttributeValue {
+	return &types.AttributeValueMemberN{Value: strconv.FormatFloat(f, 'f', -1, 64)}
+}
+
+func boolValue(b bool) types.AttributeValue {
+	return &types.AttributeValueMemberBOOL{Value: b}
+}
+
+func timeValue(t time.Time) types.AttributeValue {
+	return stringValue(t.UTC().Format(timeLayout))
+}
+
+func numberListValue(fs []float64) types.AttributeValue {
+	values := make([]types.AttributeValue, len(fs))
+	for i, f := range fs {
+		values[i] = numberValue(f)
+	}
+
+	return &types.AttributeValueMemberL{Value: values}
+}
+
+func stringMapValue(m map[string]string) types.AttributeValue {
+	values := make(map[string]types.AttributeValue, len(m))
+	for k, v := range m {
+		values[k] = stringValue(v)
+	}
+
+	return &types.AttributeValueMemberM{Value: values}
+}
+
+func getString(item map[string]types.AttributeValue, name string) string {
+	if v, ok := item[name].(*types.AttributeValueMemberS); ok {
+		return v.Value
+	}
+
+	return ""
+}
+
+func numberFromValue(value types.AttributeValue) *float64 {
+	v, ok := value.(*types.AttributeValueMemberN)
+	if !ok {
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(v.Value, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &f
+}
+
+func getNumber(item map[string]types.AttributeValue, name string) *float64 {
+	return numberFromValue(item[name])
+}
+
+func getBool(item map[string]types.AttributeValue, name string) *bool {
+	if v, ok := item[name].(*types.AttributeValueMemberBOOL); ok {
+		return &v.Value
+	}
+
+	return nil
+}
+
+func getTime(item map[string]types.AttributeValue, name string) time.Time {
+	t, _ := time.Parse(timeLayout, getString(item, name))
+	return t
+}
+
+func getList(item map[string]types.AttributeValue, name string) []types.AttributeValue {
+	if v, ok := item[name].(*types.AttributeValueMemberL); ok {
+		return v.Value
+	}
+
+	return nil
+}
+
+func getNumberList(item map[string]types.AttributeValue, name string) []float64 {
+	values := getList(item, name)
+	if values == nil {
+		return nil
+	}
+
+	fs := make([]float64, 0, len(values))
+	for _, value := range values {
+		if f := numberFromValue(value); f != nil {
+			fs = append(fs, *f)
+		}
+	}
+
+	return fs
+}
+
+func getMap(item map[string]types.AttributeValue, name string) map[string]types.AttributeValue {
+	if v, ok := item[name].(*types.AttributeValueMemberM); ok {
+		return v.Value
+	}
+
+	return nil
+}
+
+func getStringMap(item map[string]types.AttributeValue, name string) map[string]string {
+	values := getMap(item, name)
+	if values == nil {
+		return nil
+	}
+
+	m := make(map[string]string, len(values))
+	for k := range values {
+		m[k] = getString(values, k)
+	}
+
+	return m
+}
@@ -0,0 +1,186 @@
+package dynamodb
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+)
+
+// Audit entries are stored on the game partition, sorted by the time they were recorded
+const auditKeyPrefix = "AUDIT#"
+
+func buildAuditKey(createdAt time.Time, entryID string) string {
+	return auditKeyPrefix + createdAt.UTC().Format(timeLayout) + "#" + entryID
+}
+
+// Field of the actor blind index
+const auditActorField = "auditActor"
+
+// The actor is stored as its blind index once the encryption is enabled, along with its encryption
+func auditEntryToItem(e audit.Entry, cipher *encryption.Cipher) (map[string]types.AttributeValue, error) {
+	actor, encryptedActor, err := cipher.Seal(auditActorField, e.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	item := map[string]types.AttributeValue{
+		partitionKey:    stringValue(buildGameKey(e.GameID)),
+		sortKey:         stringValue(buildAuditKey(e.CreatedAt, e.ID)),
+		"createdAt":     timeValue(e.CreatedAt),
+		"id":            stringValue(e.ID),
+		"gameId":        stringValue(e.GameID),
+		"actor":         stringValue(actor),
+		"method":        stringValue(e.Method),
+		"route":         stringValue(e.Route),
+		"payloadDigest": stringValue(e.PayloadDigest),
+		"statusCode":    numberValue(float64(e.StatusCode)),
+		"requestedAt":   timeValue(e.RequestedAt),
+	}
+
+	if len(e.ResourceIDs) > 0 {
+		item["resourceIds"] = stringMapValue(e.ResourceIDs)
+	}
+
+	if encryptedActor != "" {
+		item["encryptedActor"] = stringValue(encryptedActor)
+	}
+
+	return item, nil
+}
+
+func auditEntryFromItem(item map[string]types.AttributeValue, cipher *encryption.Cipher) (audit.Entry, error) {
+	actor, err := cipher.Open(getString(item, "actor"), getString(item, "encryptedActor"))
+	if err != nil {
+		return audit.Entry{}, err
+	}
+
+	entry := audit.Entry{
+		CreatedAt:     getTime(item, "createdAt"),
+		ID:            getString(item, "id"),
+		GameID:        getString(item, "gameId"),
+		Actor:         actor,
+		Method:        getString(item, "method"),
+		Route:         getString(item, "route"),
+		ResourceIDs:   getStringMap(item, "resourceIds"),
+		PayloadDigest: getString(item, "payloadDigest"),
+		RequestedAt:   getTime(item, "requestedAt"),
+	}
+
+	if statusCode := getNumber(item, "statusCode"); statusCode != nil {
+		entry.StatusCode = int(*statusCode)
+	}
+
+	return entry, nil
+}
+
+// The audit log is append only, entries are never updated or removed
+func (c connection) RecordAuditEntry(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+	entry := audit.Entry{
+		CreatedAt:     time.Now().UTC(),
+		ID:            uuid.NewString(),
+		GameID:        data.GameID,
+		Actor:         data.Actor,
+		Method:        data.Method,
+		Route:         data.Route,
+		ResourceIDs:   data.ResourceIDs,
+		PayloadDigest: data.PayloadDigest,
+		StatusCode:    data.StatusCode,
+		RequestedAt:   data.RequestedAt.UTC(),
+	}
+
+	item, err := auditEntryToItem(entry, c.cipher)
+	if err != nil {
+		return audit.Entry{}, err
+	}
+
+	_, err = c.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(c.table),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	})
+	if err != nil {
+		return audit.Entry{}, err
+	}
+
+	return entry, nil
+}
+
+// The time range is a range of sort keys on the game partition, read backwards from the entry after which the page starts.
+// The actor and the resource type are filtered after the items are read, so the query goes on until the page is filled
+func (c connection) ListAuditEntries(ctx context.Context, filter audit.Filter, after audit.Entry, limit int) ([]audit.Entry, error) {
+	var (
+		lower = auditKeyPrefix
+		upper = auditKeyPrefix + "~" // Above every timestamp
+	)
+	if !filter.From.IsZero() {
+		lower = auditKeyPrefix + filter.From.UTC().Format(timeLayout)
+	}
+	if !filter.To.IsZero() {
+		upper = auditKeyPrefix + filter.To.UTC().Format(timeLayout)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(c.table),
+		KeyConditionExpression: aws.String("PK = :pk AND SK BETWEEN :lower AND :upper"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":    stringValue(buildGameKey(filter.GameID)),
+			":lower": stringValue(lower),
+			":upper": stringValue(upper),
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int32(int32(limit)),
+	}
+
+	conditions := make([]string, 0)
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = :actor")
+		input.ExpressionAttributeValues[":actor"] = stringValue(c.cipher.BlindIndex(auditActorField, filter.Actor))
+	}
+	if filter.ResourceType != "" {
+		conditions = append(conditions, "begins_with(#route, :route)")
+		input.ExpressionAttributeNames = map[string]string{"#route": "route"}
+		input.ExpressionAttributeValues[":route"] = stringValue(audit.RoutePrefix(filter.ResourceType))
+	}
+	if len(conditions) > 0 {
+		input.FilterExpression = aws.String(strings.Join(conditions, " AND "))
+	}
+
+	if after.ID != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			partitionKey: stringValue(buildGameKey(filter.GameID)),
+			sortKey:      stringValue(buildAuditKey(after.CreatedAt, after.ID)),
+		}
+	}
+
+	entries := make([]audit.Entry, 0, limit)
+	paginator := dynamodb.NewQueryPaginator(c.client, input)
+	for paginator.HasMorePages() && len(entries) < limit {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Items {
+			entry, err := auditEntryFromItem(item, c.cipher)
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,162 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Every entity lives on a single table, identified by a partition key (`PK`) and a sort key (`SK`)
+const (
+	partitionKey = "PK"
+	sortKey      = "SK"
+
+	gameKeyPrefix = "GAME#"
+
+	tableCreationTimeout = 2 * time.Minute
+)
+
+type Options struct {
+	Table    string             // Table name
+	Region   string             // AWS region. Empty keeps the region from the environment
+	Endpoint string             // Custom endpoint, e.g. DynamoDB Local. Empty uses the AWS endpoint
+	Retry    backoff.Config     // Retry policy used while the service is not reachable at startup
+	Metrics  *metrics.Storage   // Records the latency and the errors of every request. Optional
+	Cipher   *encryption.Cipher // Encrypts the personal data, e.g. the audit entries actor. Optional
+}
+
+type connection struct {
+	client *dynamodb.Client
+	table  string
+	cipher *encryption.Cipher
+}
+
+func buildGameKey(gameID string) string {
+	return gameKeyPrefix + gameID
+}
+
+func isConditionalCheckFailed(err error) bool {
+	var conditionalCheckFailed *types.ConditionalCheckFailedException
+	return errors.As(err, &conditionalCheckFailed)
+}
+
+// Checks if the transaction was canceled because the condition of the item at the given position failed
+func isTransactionConditionalCheckFailed(err error, position int) bool {
+	var transactionCanceled *types.TransactionCanceledException
+	if !errors.As(err, &transactionCanceled) || len(transactionCanceled.CancellationReasons) <= position {
+		return false
+	}
+
+	return aws.ToString(transactionCanceled.CancellationReasons[position].Code) == "ConditionalCheckFailed"
+}
+
+// Removes the items with the given keys, resubmitting the ones not processed by the service
+func (c connection) deleteItems(ctx context.Context, keys []map[string]types.AttributeValue) error {
+	const batchSize = 25
+
+	for start := 0; start < len(keys); start += batchSize {
+		requests := make([]types.WriteRequest, 0, batchSize)
+		for _, key := range keys[start:min(start+batchSize, len(keys))] {
+			requests = append(requests, types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: key}})
+		}
+
+		for len(requests) > 0 {
+			output, err := c.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{c.table: requests},
+			})
+			if err != nil {
+				return err
+			}
+
+			requests = output.UnprocessedItems[c.table]
+		}
+	}
+
+	return nil
+}
+
+// Creates the table when it does not exist yet, waiting until it is ready to be used
+func (c connection) EnsureTable(ctx context.Context) error {
+	_, err := c.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(c.table)})
+	if err == nil {
+		return nil
+	}
+
+	var notFound *types.ResourceNotFoundException
+	if !errors.As(err, &notFound) {
+		return err
+	}
+
+	_, err = c.client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName:   aws.String(c.table),
+		BillingMode: types.BillingModePayPerRequest,
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String(partitionKey), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String(sortKey), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String(partitionKey), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String(sortKey), KeyType: types.KeyTypeRange},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	waiter := dynamodb.NewTableExistsWaiter(c.client)
+	return waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(c.table)}, tableCreationTimeout)
+}
+
+func (c connection) Ping(ctx context.Context) error {
+	_, err := c.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(c.table)})
+	return err
+}
+
+func (c connection) Close() {}
+
+func New(ctx context.Context, opts Options) (*connection, error) {
+	loadOpts := make([]func(*config.LoadOptions) error, 0)
+	if opts.Region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(opts.Region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+		if opts.Endpoint != "" {
+			o.BaseEndpoint = aws.String(opts.Endpoint)
+		}
+
+		if opts.Metrics != nil {
+			o.APIOptions = append(o.APIOptions, metricsMiddleware(opts.Metrics, opts.Table))
+		}
+	})
+
+	err = backoff.Retry(ctx, opts.Retry, func(ctx context.Context) error {
+		_, err := client.ListTables(ctx, &dynamodb.ListTablesInput{Limit: aws.Int32(1)})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &connection{
+		client: client,
+		table:  opts.Table,
+		cipher: opts.Cipher,
+	}
+
+	return conn, nil
+}
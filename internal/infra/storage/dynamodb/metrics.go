@@ -0,0 +1,23 @@
+package dynamodb
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Records every call made to the service, labelled by the operation name. The latency includes the SDK retries
+func metricsMiddleware(m *metrics.Storage, table string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("StorageMetrics", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			finish := m.Observe("dynamodb", awsmiddleware.GetOperationName(ctx), table)
+
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			finish(err)
+			return out, metadata, err
+		}), middleware.After)
+	}
+}
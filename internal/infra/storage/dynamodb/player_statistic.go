@@ -0,0 +1,567 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/backup"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Players progression are stored on the statistic partition
+const playerKeyPrefix = "PLAYER#"
+
+func buildPlayerKey(playerID string) string {
+	return playerKeyPrefix + playerID
+}
+
+func playerStatisticItemKey(statisticID, playerID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		partitionKey: stringValue(buildStatisticKey(statisticID)),
+		sortKey:      stringValue(buildPlayerKey(playerID)),
+	}
+}
+
+func newPlayerStatisticProgressionItem(st statistic.Statistic, playerID string) map[string]types.AttributeValue {
+	landmarks := make([]types.AttributeValue, len(st.Landmarks))
+	for i, landmark := range st.Landmarks {
+		landmarks[i] = &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"value":     numberValue(landmark),
+			"completed": boolValue(false),
+		}}
+	}
+
+	item := playerStatisticItemKey(st.ID, playerID)
+	item["playerId"] = stringValue(playerID)
+	item["statisticId"] = stringValue(st.ID)
+	item["landmarks"] = &types.AttributeValueMemberL{Value: landmarks}
+
+	if st.InitialValue != nil {
+		item["currentValue"] = numberValue(*st.InitialValue)
+	}
+
+	if st.Goal != nil {
+		item["goalValue"] = numberValue(*st.Goal)
+		item["goalCompleted"] = boolValue(false)
+	}
+
+	return item
+}
+
+func playerStatisticProgressionToItem(st statistic.Statistic, progression statistic.PlayerProgression) map[string]types.AttributeValue {
+	landmarks := make([]types.AttributeValue, len(progression.Landmarks))
+	for i, landmark := range progression.Landmarks {
+		data := map[string]types.AttributeValue{
+			"value":     numberValue(landmark.Value),
+			"completed": boolValue(landmark.Completed),
+		}
+
+		if landmark.Completed {
+			data["completedAt"] = timeValue(landmark.CompletedAt)
+		}
+
+		landmarks[i] = &types.AttributeValueMemberM{Value: data}
+	}
+
+	item := playerStatisticItemKey(st.ID, progression.PlayerID)
+	item["playerId"] = stringValue(progression.PlayerID)
+	item["statisticId"] = stringValue(st.ID)
+	item["landmarks"] = &types.AttributeValueMemberL{Value: landmarks}
+
+	if !progression.StartedAt.IsZero() {
+		item["startedAt"] = timeValue(progression.StartedAt)
+	}
+
+	if !progression.UpdatedAt.IsZero() {
+		item["updatedAt"] = timeValue(progression.UpdatedAt)
+	}
+
+	if progression.CurrentValue != nil {
+		item["currentValue"] = numberValue(*progression.CurrentValue)
+	}
+
+	if progression.GoalValue != nil {
+		item["goalValue"] = numberValue(*progression.GoalValue)
+	}
+
+	if progression.GoalCompleted != nil {
+		item["goalCompleted"] = boolValue(*progression.GoalCompleted)
+	}
+
+	if !progression.GoalCompletedAt.IsZero() {
+		item["goalCompletedAt"] = timeValue(progression.GoalCompletedAt)
+	}
+
+	return item
+}
+
+func playerStatisticProgressionFromItem(item map[string]types.AttributeValue) statistic.PlayerProgression {
+	var (
+		landmarksData = getList(item, "landmarks")
+		landmarks     = make([]statistic.PlayerProgressionLandmark, 0, len(landmarksData))
+	)
+	for _, data := range landmarksData {
+		landmark, ok := data.(*types.AttributeValueMemberM)
+		if !ok {
+			continue
+		}
+
+		var (
+			value     = getNumber(landmark.Value, "value")
+			completed = getBool(landmark.Value, "completed")
+		)
+		if value == nil || completed == nil {
+			continue
+		}
+
+		landmarks = append(landmarks, statistic.PlayerProgressionLandmark{
+			Value:       *value,
+			Completed:   *completed,
+			CompletedAt: getTime(landmark.Value, "completedAt"),
+		})
+	}
+
+	return statistic.PlayerProgression{
+		StartedAt:       getTime(item, "startedAt"),
+		UpdatedAt:       getTime(item, "updatedAt"),
+		PlayerID:        getString(item, "playerId"),
+		StatisticID:     getString(item, "statisticId"),
+		CurrentValue:    getNumber(item, "currentValue"),
+		GoalValue:       getNumber(item, "goalValue"),
+		GoalCompleted:   getBool(item, "goalCompleted"),
+		GoalCompletedAt: getTime(item, "goalCompletedAt"),
+		Landmarks:       landmarks,
+	}
+}
+
+// Creates the player progression unless another request already did it
+func (c connection) createPlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string) error {
+	_, err := c.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(c.table),
+		Item:                newPlayerStatisticProgressionItem(st, playerID),
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	})
+	if err != nil && !isConditionalCheckFailed(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Applies the value on the player progression with a single conditional write.
+// `MAX` and `MIN` only overwrite the current value when the new one is better than it
+func (c connection) aggregatePlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, error) {
+	var (
+		setCurrentValue string
+		condition       = "attribute_exists(PK)"
+		values          = map[string]types.AttributeValue{":value": numberValue(value)}
+	)
+	switch st.AggregationMode {
+	case statistic.AggregationModeSum:
+		setCurrentValue = "currentValue = if_not_exists(currentValue, :zero) + :value"
+		values[":zero"] = numberValue(0)
+	case statistic.AggregationModeSub:
+		setCurrentValue = "currentValue = if_not_exists(currentValue, :zero) - :value"
+		values[":zero"] = numberValue(0)
+	case statistic.AggregationModeMax:
+		setCurrentValue = "currentValue = :value"
+		condition += " AND (attribute_not_exists(currentValue) OR currentValue < :value)"
+	case statistic.AggregationModeMin:
+		setCurrentValue = "currentValue = :value"
+		condition += " AND (attribute_not_exists(currentValue) OR currentValue > :value)"
+	default:
+		return statistic.PlayerProgression{}, statistic.ErrInvalidAggregationMode
+	}
+
+	for {
+		now := time.Now()
+		values[":now"] = timeValue(now)
+
+		output, err := c.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName:                           aws.String(c.table),
+			Key:                                 playerStatisticItemKey(st.ID, playerID),
+			UpdateExpression:                    aws.String("SET startedAt = if_not_exists(startedAt, :now), updatedAt = :now, " + setCurrentValue),
+			ConditionExpression:                 aws.String(condition),
+			ReturnValues:                        types.ReturnValueAllNew,
+			ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+			ExpressionAttributeValues:           values,
+		})
+		if err == nil {
+			return playerStatisticProgressionFromItem(output.Attributes), nil
+		}
+
+		var conditionalCheckFailed *types.ConditionalCheckFailedException
+		if !errors.As(err, &conditionalCheckFailed) {
+			return statistic.PlayerProgression{}, err
+		}
+
+		// The progression does not exist yet
+		if conditionalCheckFailed.Item == nil {
+			if err := c.createPlayerStatisticProgression(ctx, st, playerID); err != nil {
+				return statistic.PlayerProgression{}, err
+			}
+
+			continue
+		}
+
+		// The current value is already better than the new one, so only the timestamps are touched
+		output, err = c.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName:        aws.String(c.table),
+			Key:              playerStatisticItemKey(st.ID, playerID),
+			UpdateExpression: aws.String("SET startedAt = if_not_exists(startedAt, :now), updatedAt = :now"),
+			ReturnValues:     types.ReturnValueAllNew,
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":now": timeValue(now),
+			},
+		})
+		if err != nil {
+			return statistic.PlayerProgression{}, err
+		}
+
+		return playerStatisticProgressionFromItem(output.Attributes), nil
+	}
+}
+
+// Marks the goal and the landmarks reached by the current value as completed.
+// The write is conditioned on them not being completed yet, so when concurrent requests reach the same
+// landmark only one of them reports it as just completed. The others reload the progression and try again
+func (c connection) completePlayerStatisticProgression(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+	var reached func(current, target float64) bool
+	switch st.AggregationMode {
+	case statistic.AggregationModeSum, statistic.AggregationModeMax:
+		reached = func(current, target float64) bool { return current >= target }
+	case statistic.AggregationModeSub, statistic.AggregationModeMin:
+		reached = func(current, target float64) bool { return current <= target }
+	default:
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, statistic.ErrInvalidAggregationMode
+	}
+
+	for {
+		updates := statistic.PlayerProgressionUpdates{
+			GoalCompletedAt:        progression.GoalCompletedAt,
+			LandmarksJustCompleted: make([]statistic.PlayerProgressionUpdatesLandmark, 0),
+		}
+
+		if progression.CurrentValue == nil {
+			return progression, updates, nil
+		}
+
+		var (
+			now          = time.Now().UTC()
+			currentValue = *progression.CurrentValue
+
+			sets       = make([]string, 0)
+			conditions = make([]string, 0)
+		)
+
+		for i, landmark := range progression.Landmarks {
+			if landmark.Completed || !reached(currentValue, landmark.Value) {
+				continue
+			}
+
+			sets = append(sets, fmt.Sprintf("landmarks[%d].#completed = :true, landmarks[%d].#completedAt = :now", i, i))
+			conditions = append(conditions, fmt.Sprintf("landmarks[%d].#completed = :false", i))
+			updates.LandmarksJustCompleted = append(updates.LandmarksJustCompleted, statistic.PlayerProgressionUpdatesLandmark{
+				Value:       landmark.Value,
+				CompletedAt: now,
+			})
+		}
+
+		if progression.GoalCompleted != nil && !*progression.GoalCompleted && reached(currentValue, *progression.GoalValue) {
+			sets = append(sets, "goalCompleted = :true, goalCompletedAt = :now")
+			conditions = append(conditions, "goalCompleted = :false")
+			updates.GoalJustCompleted = true
+			updates.GoalCompletedAt = now
+		}
+
+		if len(sets) == 0 {
+			return progression, updates, nil
+		}
+
+		output, err := c.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName:                           aws.String(c.table),
+			Key:                                 playerStatisticItemKey(st.ID, progression.PlayerID),
+			UpdateExpression:                    aws.String("SET " + strings.Join(sets, ", ")),
+			ConditionExpression:                 aws.String(strings.Join(conditions, " AND ")),
+			ReturnValues:                        types.ReturnValueAllNew,
+			ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+			ExpressionAttributeNames:            landmarkAttributeNames(len(updates.LandmarksJustCompleted)),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":now":   timeValue(now),
+				":true":  boolValue(true),
+				":false": boolValue(false),
+			},
+		})
+		if err == nil {
+			return playerStatisticProgressionFromItem(output.Attributes), updates, nil
+		}
+
+		var conditionalCheckFailed *types.ConditionalCheckFailedException
+		if !errors.As(err, &conditionalCheckFailed) || conditionalCheckFailed.Item == nil {
+			return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+		}
+
+		progression = playerStatisticProgressionFromItem(conditionalCheckFailed.Item)
+	}
+}
+
+// Landmark attributes are only referenced when at least one landmark is being completed.
+// DynamoDB rejects attribute names that are declared but not used by the expressions
+func landmarkAttributeNames(landmarksCompleted int) map[string]string {
+	if landmarksCompleted == 0 {
+		return nil
+	}
+
+	return map[string]string{
+		"#completed":   "completed",
+		"#completedAt": "completedAt",
+	}
+}
+
+func (c connection) UpdatePlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+	progression, err := c.aggregatePlayerStatisticProgression(ctx, st, playerID, value)
+	if err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+	}
+
+	return c.completePlayerStatisticProgression(ctx, st, progression)
+}
+
+func (c connection) GetPlayerProgression(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+	output, err := c.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(c.table),
+		Key:       playerStatisticItemKey(statisticID, playerID),
+	})
+	if err != nil {
+		return statistic.PlayerProgression{}, err
+	}
+
+	if output.Item == nil {
+		return statistic.PlayerProgression{}, statistic.ErrPlayerStatisticNotFound
+	}
+
+	return playerStatisticProgressionFromItem(output.Item), nil
+}
+
+// Removes the player's progression on the statistic. Nothing is removed when the player has none
+func (c connection) DeletePlayerProgression(ctx context.Context, statisticID, playerID string) error {
+	_, err := c.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(c.table),
+		Key:       playerStatisticItemKey(statisticID, playerID),
+	})
+	return err
+}
+
+// Removes every player progression stored on the statistic partition
+func (c connection) deletePlayersStatisticProgression(ctx context.Context, statisticID string) error {
+	paginator := dynamodb.NewQueryPaginator(c.client, &dynamodb.QueryInput{
+		TableName:              aws.String(c.table),
+		KeyConditionExpression: aws.String("PK = :pk"),
+		ProjectionExpression:   aws.String("PK, SK"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": stringValue(buildStatisticKey(statisticID)),
+		},
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := c.deleteItems(ctx, page.Items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c connection) ListPlayerStatistics(ctx context.Context, statisticID string) ([]statistic.PlayerProgression, error) {
+	paginator := dynamodb.NewQueryPaginator(c.client, &dynamodb.QueryInput{
+		TableName:              aws.String(c.table),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     stringValue(buildStatisticKey(statisticID)),
+			":prefix": stringValue(playerKeyPrefix),
+		},
+	})
+
+	progressions := make([]statistic.PlayerProgression, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Items {
+			progressions = append(progressions, playerStatisticProgressionFromItem(item))
+		}
+	}
+
+	return progressions, nil
+}
+
+func (c connection) RestorePlayerStatistic(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression) error {
+	_, err := c.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(c.table),
+		Item:                playerStatisticProgressionToItem(st, progression),
+		ConditionExpression: aws.String("attribute_not_exists(PK)"),
+	})
+	if err != nil {
+		if isConditionalCheckFailed(err) {
+			err = backup.ErrEntityAlreadyExists
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Erases the player's progression on every statistic of the game, including the soft deleted ones.
+// Players progression are stored on the statistic partition, so one item is deleted per statistic of the game
+func (c connection) ErasePlayerStatistics(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	paginator := dynamodb.NewQueryPaginator(c.client, &dynamodb.QueryInput{
+		TableName:              aws.String(c.table),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     stringValue(buildGameKey(gameID)),
+			":prefix": stringValue(statisticKeyPrefix),
+		},
+	})
+
+	erasure := privacy.Erasure{Data: privacy.DataStatisticProgression}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return erasure, err
+		}
+
+		for _, item := range page.Items {
+			output, err := c.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+				TableName:    aws.String(c.table),
+				Key:          playerStatisticItemKey(statisticFromItem(item).ID, playerID),
+				ReturnValues: types.ReturnValueAllOld,
+			})
+			if err != nil {
+				return erasure, err
+			}
+
+			if len(output.Attributes) > 0 {
+				erasure.Records++
+			}
+		}
+	}
+
+	return erasure, nil
+}
+
+// Moves the player's progression on every statistic of the game, including the soft deleted ones, to the pseudonym.
+// The progression is keyed by the player, so each one is copied to the pseudonym's key and removed on the same transaction
+func (c connection) AnonymizePlayerStatistics(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	paginator := dynamodb.NewQueryPaginator(c.client, &dynamodb.QueryInput{
+		TableName:              aws.String(c.table),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     stringValue(buildGameKey(gameID)),
+			":prefix": stringValue(statisticKeyPrefix),
+		},
+	})
+
+	anonymization := privacy.Anonymization{Data: privacy.DataStatisticProgression}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return anonymization, err
+		}
+
+		for _, item := range page.Items {
+			statisticID := statisticFromItem(item).ID
+
+			output, err := c.client.GetItem(ctx, &dynamodb.GetItemInput{
+				TableName: aws.String(c.table),
+				Key:       playerStatisticItemKey(statisticID, playerID),
+			})
+			if err != nil {
+				return anonymization, err
+			}
+
+			if output.Item == nil {
+				continue
+			}
+
+			anonymized := maps.Clone(output.Item)
+			maps.Copy(anonymized, playerStatisticItemKey(statisticID, pseudonym))
+			anonymized["playerId"] = stringValue(pseudonym)
+
+			_, err = c.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+				TransactItems: []types.TransactWriteItem{
+					{Put: &types.Put{
+						TableName:           aws.String(c.table),
+						Item:                anonymized,
+						ConditionExpression: aws.String("attribute_not_exists(PK)"),
+					}},
+					{Delete: &types.Delete{
+						TableName: aws.String(c.table),
+						Key:       playerStatisticItemKey(statisticID, playerID),
+					}},
+				},
+			})
+			if err != nil {
+				return anonymization, err
+			}
+
+			anonymization.Records++
+		}
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's progression on every statistic of the game, including the soft deleted ones.
+// Players progression are stored on the statistic partition, so one item is read per statistic of the game
+func (c connection) CountPlayerStatistics(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	paginator := dynamodb.NewQueryPaginator(c.client, &dynamodb.QueryInput{
+		TableName:              aws.String(c.table),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     stringValue(buildGameKey(gameID)),
+			":prefix": stringValue(statisticKeyPrefix),
+		},
+	})
+
+	count := privacy.DataCount{Data: privacy.DataStatisticProgression}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return count, err
+		}
+
+		for _, item := range page.Items {
+			output, err := c.client.GetItem(ctx, &dynamodb.GetItemInput{
+				TableName:            aws.String(c.table),
+				Key:                  playerStatisticItemKey(statisticFromItem(item).ID, playerID),
+				ProjectionExpression: aws.String("PK"),
+			})
+			if err != nil {
+				return count, err
+			}
+
+			if output.Item != nil {
+				count.Records++
+			}
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,344 @@
+package dynamodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/backup"
+	"github.com/gabapcia/gameblitz/internal/purge"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+)
+
+// Statistics are stored on the game partition. Since DynamoDB has no unique indexes,
+// each active statistic also owns a name item on the same partition that reserves its name
+const (
+	statisticKeyPrefix     = "STATISTIC#"
+	statisticNameKeyPrefix = "STATISTIC_NAME#"
+)
+
+func buildStatisticKey(statisticID string) string {
+	return statisticKeyPrefix + statisticID
+}
+
+func buildStatisticNameKey(name string) string {
+	return statisticNameKeyPrefix + name
+}
+
+func statisticItemKey(id, gameID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		partitionKey: stringValue(buildGameKey(gameID)),
+		sortKey:      stringValue(buildStatisticKey(id)),
+	}
+}
+
+func statisticNameItemKey(name, gameID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		partitionKey: stringValue(buildGameKey(gameID)),
+		sortKey:      stringValue(buildStatisticNameKey(name)),
+	}
+}
+
+func statisticToItem(s statistic.Statistic) map[string]types.AttributeValue {
+	item := statisticItemKey(s.ID, s.GameID)
+	item["createdAt"] = timeValue(s.CreatedAt)
+	item["updatedAt"] = timeValue(s.UpdatedAt)
+	item["id"] = stringValue(s.ID)
+	item["gameId"] = stringValue(s.GameID)
+	item["name"] = stringValue(s.Name)
+	item["description"] = stringValue(s.Description)
+	item["aggregationMode"] = stringValue(s.AggregationMode)
+	item["landmarks"] = numberListValue(s.Landmarks)
+
+	if s.InitialValue != nil {
+		item["initialValue"] = numberValue(*s.InitialValue)
+	}
+
+	if s.Goal != nil {
+		item["goal"] = numberValue(*s.Goal)
+	}
+
+	return item
+}
+
+func statisticFromItem(item map[string]types.AttributeValue) statistic.Statistic {
+	return statistic.Statistic{
+		CreatedAt:       getTime(item, "createdAt"),
+		UpdatedAt:       getTime(item, "updatedAt"),
+		DeletedAt:       getTime(item, "deletedAt"),
+		ID:              getString(item, "id"),
+		GameID:          getString(item, "gameId"),
+		Name:            getString(item, "name"),
+		Description:     getString(item, "description"),
+		AggregationMode: getString(item, "aggregationMode"),
+		InitialValue:    getNumber(item, "initialValue"),
+		Goal:            getNumber(item, "goal"),
+		Landmarks:       getNumberList(item, "landmarks"),
+	}
+}
+
+func (c connection) CreateStatistic(ctx context.Context, data statistic.NewStatisticData) (statistic.Statistic, error) {
+	st := statistic.Statistic{
+		CreatedAt:       time.Now().UTC(),
+		UpdatedAt:       time.Now().UTC(),
+		ID:              uuid.NewString(),
+		GameID:          data.GameID,
+		Name:            data.Name,
+		Description:     data.Description,
+		AggregationMode: data.AggregationMode,
+		InitialValue:    data.InitialValue,
+		Goal:            data.Goal,
+		Landmarks:       data.Landmarks,
+	}
+
+	nameItem := statisticNameItemKey(st.Name, st.GameID)
+	nameItem["statisticId"] = stringValue(st.ID)
+
+	_, err := c.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{Put: &types.Put{
+				TableName:           aws.String(c.table),
+				Item:                nameItem,
+				ConditionExpression: aws.String("attribute_not_exists(PK)"),
+			}},
+			{Put: &types.Put{
+				TableName:           aws.String(c.table),
+				Item:                statisticToItem(st),
+				ConditionExpression: aws.String("attribute_not_exists(PK)"),
+			}},
+		},
+	})
+	if err != nil {
+		if isTransactionConditionalCheckFailed(err, 0) {
+			err = statistic.ErrStatisticNameAlreadyInUse
+		}
+
+		return statistic.Statistic{}, err
+	}
+
+	return st, nil
+}
+
+func (c connection) GetStatisticByIDAndGameID(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return statistic.Statistic{}, statistic.ErrInvalidStatisticID
+	}
+
+	output, err := c.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(c.table),
+		Key:            statisticItemKey(id, gameID),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return statistic.Statistic{}, err
+	}
+
+	if output.Item == nil {
+		return statistic.Statistic{}, statistic.ErrStatisticNotFound
+	}
+
+	st := statisticFromItem(output.Item)
+	if !st.DeletedAt.IsZero() {
+		return statistic.Statistic{}, statistic.ErrStatisticNotFound
+	}
+
+	return st, nil
+}
+
+// Marks the statistic as deleted and releases its name to be used by new statistics
+func (c connection) SoftDeleteStatistic(ctx context.Context, id, gameID string) error {
+	st, err := c.GetStatisticByIDAndGameID(ctx, id, gameID)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{Update: &types.Update{
+				TableName:           aws.String(c.table),
+				Key:                 statisticItemKey(st.ID, st.GameID),
+				UpdateExpression:    aws.String("SET deletedAt = :now, updatedAt = :now"),
+				ConditionExpression: aws.String("attribute_exists(PK) AND attribute_not_exists(deletedAt)"),
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":now": timeValue(time.Now()),
+				},
+			}},
+			{Delete: &types.Delete{
+				TableName: aws.String(c.table),
+				Key:       statisticNameItemKey(st.Name, st.GameID),
+			}},
+		},
+	})
+	if err != nil {
+		if isTransactionConditionalCheckFailed(err, 0) {
+			err = statistic.ErrStatisticNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Finds the statistics soft deleted before the given time. The game partition is queried when a game is given,
+// otherwise the soft deleted statistics are spread across every partition, so the whole table is scanned
+func (c connection) findSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]statistic.Statistic, error) {
+	var items []map[string]types.AttributeValue
+
+	if gameID != "" {
+		paginator := dynamodb.NewQueryPaginator(c.client, &dynamodb.QueryInput{
+			TableName:              aws.String(c.table),
+			KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+			FilterExpression:       aws.String("deletedAt < :deletedBefore"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pk":            stringValue(buildGameKey(gameID)),
+				":prefix":        stringValue(statisticKeyPrefix),
+				":deletedBefore": timeValue(deletedBefore),
+			},
+		})
+
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, page.Items...)
+		}
+	} else {
+		paginator := dynamodb.NewScanPaginator(c.client, &dynamodb.ScanInput{
+			TableName:        aws.String(c.table),
+			FilterExpression: aws.String("begins_with(SK, :prefix) AND deletedAt < :deletedBefore"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":prefix":        stringValue(statisticKeyPrefix),
+				":deletedBefore": timeValue(deletedBefore),
+			},
+		})
+
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, page.Items...)
+		}
+	}
+
+	statistics := make([]statistic.Statistic, len(items))
+	for i, item := range items {
+		statistics[i] = statisticFromItem(item)
+	}
+
+	return statistics, nil
+}
+
+func softDeletedStatisticRecord(st statistic.Statistic) purge.Record {
+	return purge.Record{
+		DeletedAt: st.DeletedAt,
+		Entity:    purge.EntityStatistic,
+		ID:        st.ID,
+		GameID:    st.GameID,
+	}
+}
+
+// Removes the statistics soft deleted before the given time alongside the players progression on them
+func (c connection) PurgeSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	statistics, err := c.findSoftDeletedStatistics(ctx, gameID, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, 0, len(statistics))
+	for _, st := range statistics {
+		if err := c.deletePlayersStatisticProgression(ctx, st.ID); err != nil {
+			return records, err
+		}
+
+		if err := c.deleteItems(ctx, []map[string]types.AttributeValue{statisticItemKey(st.ID, st.GameID)}); err != nil {
+			return records, err
+		}
+
+		records = append(records, softDeletedStatisticRecord(st))
+	}
+
+	return records, nil
+}
+
+func (c connection) ListSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	statistics, err := c.findSoftDeletedStatistics(ctx, gameID, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, len(statistics))
+	for i, st := range statistics {
+		records[i] = softDeletedStatisticRecord(st)
+	}
+
+	return records, nil
+}
+
+func (c connection) ListStatistics(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+	paginator := dynamodb.NewQueryPaginator(c.client, &dynamodb.QueryInput{
+		TableName:              aws.String(c.table),
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :prefix)"),
+		FilterExpression:       aws.String("attribute_not_exists(deletedAt)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     stringValue(buildGameKey(gameID)),
+			":prefix": stringValue(statisticKeyPrefix),
+		},
+	})
+
+	statistics := make([]statistic.Statistic, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Items {
+			statistics = append(statistics, statisticFromItem(item))
+		}
+	}
+
+	return statistics, nil
+}
+
+// Stores the statistic keeping its ID, reserving its name the same way `CreateStatistic` does
+func (c connection) RestoreStatistic(ctx context.Context, st statistic.Statistic) error {
+	if _, err := uuid.Parse(st.ID); err != nil {
+		return statistic.ErrInvalidStatisticID
+	}
+
+	nameItem := statisticNameItemKey(st.Name, st.GameID)
+	nameItem["statisticId"] = stringValue(st.ID)
+
+	_, err := c.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{Put: &types.Put{
+				TableName:           aws.String(c.table),
+				Item:                nameItem,
+				ConditionExpression: aws.String("attribute_not_exists(PK)"),
+			}},
+			{Put: &types.Put{
+				TableName:           aws.String(c.table),
+				Item:                statisticToItem(st),
+				ConditionExpression: aws.String("attribute_not_exists(PK)"),
+			}},
+		},
+	})
+	if err != nil {
+		if isTransactionConditionalCheckFailed(err, 0) || isTransactionConditionalCheckFailed(err, 1) {
+			err = backup.ErrEntityAlreadyExists
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,386 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gabapcia/gameblitz/internal/writebehind"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrClosed    = errors.New("journal closed")
+	ErrCorrupted = errors.New("journal corrupted")
+)
+
+const (
+	// Records appended to each segment before the next one is started
+	defaultSegmentRecords = 10_000
+
+	segmentExtension = ".segment"
+	checkpointName   = "checkpoint"
+	idName           = "id"
+)
+
+// Segment file of the journal, named after the sequence of its first record
+type segment struct {
+	first int64
+	last  int64 // Sequence of its last record, zero while it has none
+}
+
+func (s segment) name() string {
+	return fmt.Sprintf("%020d%s", s.first, segmentExtension)
+}
+
+// Append-only segment files on a local directory, one JSON record per line. Every record is synced to the disk before
+// its append returns. Removing records only persists the last sequence removed on the checkpoint, and deletes the
+// segments left without pending records, so neither costs more as the journal grows
+type connection struct {
+	mu sync.Mutex
+
+	dir            string
+	id             string // Created along with the journal, kept for as long as its directory
+	segmentRecords int64
+	segments       []segment // Oldest first, the records being appended to the last one
+	file           *os.File  // Last segment, open for appending
+	size           int64     // Bytes on the last segment
+	pending        []writebehind.Entry
+	removed        int64 // Sequence of the last record removed
+	next           int64 // Sequence of the next record
+}
+
+// Opens the journal on the directory, creating it when missing, and loads the records it still holds
+func New(dir string) (*connection, error) {
+	return newConnection(dir, defaultSegmentRecords)
+}
+
+func newConnection(dir string, segmentRecords int64) (*connection, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	c := &connection{dir: dir, segmentRecords: segmentRecords, pending: make([]writebehind.Entry, 0), next: 1}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Reads the ID, the checkpoint and every segment back. Only the last record of the last segment may be cut short, by a crash
+// while it was appended, and it's dropped so the next append doesn't land on the same line. Anything else unreadable
+// fails the load instead of losing the records after it
+func (c *connection) load() error {
+	if err := c.loadID(); err != nil {
+		return err
+	}
+
+	if err := c.loadCheckpoint(); err != nil {
+		return err
+	}
+
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		first, err := strconv.ParseInt(strings.TrimSuffix(file.Name(), segmentExtension), 10, 64)
+		if file.IsDir() || !strings.HasSuffix(file.Name(), segmentExtension) || err != nil {
+			continue
+		}
+
+		c.segments = append(c.segments, segment{first: first})
+	}
+
+	slices.SortFunc(c.segments, func(a, b segment) int { return int(a.first - b.first) })
+
+	for i := range c.segments {
+		if err := c.loadSegment(i); err != nil {
+			return err
+		}
+	}
+
+	// Sequences keep increasing past the removed ones, even when no segment is left
+	c.next = max(c.next, c.removed+1)
+
+	if len(c.segments) == 0 {
+		return c.startSegment()
+	}
+
+	if err := c.deleteRemovedSegments(); err != nil {
+		return err
+	}
+
+	active := c.segments[len(c.segments)-1]
+	c.file, err = os.OpenFile(filepath.Join(c.dir, active.name()), os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := c.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	c.size = info.Size()
+	return nil
+}
+
+// Reads the journal ID, creating it along with the journal
+func (c *connection) loadID() error {
+	data, err := os.ReadFile(filepath.Join(c.dir, idName))
+	if err == nil {
+		c.id = strings.TrimSpace(string(data))
+		return nil
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	c.id = uuid.NewString()
+	return c.replaceFile(idName, c.id)
+}
+
+func (c *connection) loadCheckpoint() error {
+	data, err := os.ReadFile(filepath.Join(c.dir, checkpointName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if c.removed, err = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err != nil {
+		return fmt.Errorf("%w: checkpoint: %w", ErrCorrupted, err)
+	}
+
+	return nil
+}
+
+func (c *connection) loadSegment(i int) error {
+	var (
+		seg  = &c.segments[i]
+		last = i == len(c.segments)-1
+		path = filepath.Join(c.dir, seg.name())
+	)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var (
+		reader = bufio.NewReader(file)
+		offset int64
+	)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) && len(data) == 0 {
+			return nil
+		}
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		var entry writebehind.Entry
+		if err := json.Unmarshal(data, &entry); err != nil || entry.Sequence < c.next {
+			if _, peekErr := reader.Peek(1); last && errors.Is(peekErr, io.EOF) {
+				return os.Truncate(path, offset)
+			}
+
+			return fmt.Errorf("%w: %s, line %d", ErrCorrupted, seg.name(), line)
+		}
+
+		// The records erased keep their sequence alone
+		if entry.Sequence > c.removed && entry.Change.PlayerID != "" {
+			c.pending = append(c.pending, entry)
+		}
+
+		offset += int64(len(data))
+		seg.last = entry.Sequence
+		c.next = entry.Sequence + 1
+	}
+}
+
+// Starts a new segment for the next records, syncing the directory so it's still found after a crash
+func (c *connection) startSegment() error {
+	seg := segment{first: c.next}
+
+	file, err := os.OpenFile(filepath.Join(c.dir, seg.name()), os.O_CREATE|os.O_WRONLY|os.O_APPEND|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if err := c.syncDir(); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if c.file != nil {
+		if err := c.file.Close(); err != nil {
+			_ = file.Close()
+			return err
+		}
+	}
+
+	c.segments = append(c.segments, seg)
+	c.file, c.size = file, 0
+
+	return nil
+}
+
+// Replaces the file with the content through a temporary file renamed over it
+func (c *connection) replaceFile(name, content string) error {
+	tmpPath := filepath.Join(c.dir, name+".tmp")
+
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.WriteString(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, filepath.Join(c.dir, name)); err != nil {
+		return err
+	}
+
+	return c.syncDir()
+}
+
+// Rewrites the player's records of the game on every segment with `replace`, returning how many there were. A nil
+// `replace` only counts them. The last segment is opened again for appending once it's rewritten
+func (c *connection) rewritePlayerRecords(gameID, playerID string, replace func(entry writebehind.Entry) writebehind.Entry) (int64, error) {
+	var records int64
+	for i, seg := range c.segments {
+		data, err := os.ReadFile(filepath.Join(c.dir, seg.name()))
+		if err != nil {
+			return records, err
+		}
+
+		var (
+			lines   = bytes.SplitAfter(data, []byte{'\n'})
+			matched int64
+		)
+		for line, data := range lines {
+			if len(data) == 0 {
+				continue
+			}
+
+			var entry writebehind.Entry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return records, fmt.Errorf("%w: %s, line %d", ErrCorrupted, seg.name(), line+1)
+			}
+
+			if !isPlayerChange(entry, gameID, playerID) {
+				continue
+			}
+
+			matched++
+			if replace == nil {
+				continue
+			}
+
+			if lines[line], err = json.Marshal(replace(entry)); err != nil {
+				return records, err
+			}
+
+			lines[line] = append(lines[line], '\n')
+		}
+
+		records += matched
+		if matched == 0 || replace == nil {
+			continue
+		}
+
+		content := bytes.Join(lines, nil)
+		if err := c.replaceFile(seg.name(), string(content)); err != nil {
+			return records, err
+		}
+
+		if i < len(c.segments)-1 {
+			continue
+		}
+
+		// Left closed when it can't be opened again, so the next appends fail instead of landing on the replaced file
+		_ = c.file.Close()
+		c.file, err = os.OpenFile(filepath.Join(c.dir, seg.name()), os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return records, err
+		}
+
+		c.size = int64(len(content))
+	}
+
+	return records, nil
+}
+
+// Deletes the segments whose records were all removed, except the one being appended to
+func (c *connection) deleteRemovedSegments() error {
+	deleted := 0
+	for deleted < len(c.segments)-1 && c.segments[deleted].last <= c.removed {
+		if err := os.Remove(filepath.Join(c.dir, c.segments[deleted].name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		deleted++
+	}
+
+	c.segments = c.segments[deleted:]
+	return nil
+}
+
+func (c *connection) syncDir() error {
+	dir, err := os.Open(c.dir)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(dir.Sync(), dir.Close())
+}
+
+// Identifies the journal to the statistic storage, which records the last change written from it
+func (c *connection) ID() string {
+	return c.id
+}
+
+func (c *connection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return nil
+	}
+
+	err := c.file.Close()
+	c.file = nil
+	return err
+}
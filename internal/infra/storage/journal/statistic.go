@@ -0,0 +1,175 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/writebehind"
+)
+
+func (c *connection) AppendStatisticChange(ctx context.Context, change statistic.PlayerProgressionChange) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return ErrClosed
+	}
+
+	if active := c.segments[len(c.segments)-1]; active.last != 0 && active.last-active.first+1 >= c.segmentRecords {
+		if err := c.startSegment(); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(writebehind.Entry{Sequence: c.next, Change: change})
+	if err != nil {
+		return err
+	}
+
+	// The entry kept is read back from its record, so it doesn't share the change's strings, e.g. the route params
+	// of the request, which are reused once it's handled
+	var entry writebehind.Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return err
+	}
+
+	// A failed append may leave part of the record behind, so the segment is cut back to the records before it
+	if _, err := c.file.Write(append(data, '\n')); err != nil {
+		return errors.Join(err, c.file.Truncate(c.size))
+	}
+
+	if err := c.file.Sync(); err != nil {
+		return errors.Join(err, c.file.Truncate(c.size))
+	}
+
+	c.size += int64(len(data)) + 1
+	c.segments[len(c.segments)-1].last = entry.Sequence
+	c.pending = append(c.pending, entry)
+	c.next++
+
+	return nil
+}
+
+func (c *connection) PeekStatisticChanges(ctx context.Context, limit int) ([]writebehind.Entry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := make([]writebehind.Entry, min(limit, len(c.pending)))
+	copy(entries, c.pending)
+
+	return entries, nil
+}
+
+// Only the checkpoint is rewritten, and the segments left without pending records deleted, so removing the records
+// doesn't cost more as the journal grows
+func (c *connection) RemoveStatisticChanges(ctx context.Context, upTo int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return ErrClosed
+	}
+
+	upTo = min(upTo, c.next-1)
+	if upTo <= c.removed {
+		return nil
+	}
+
+	if err := c.replaceFile(checkpointName, strconv.FormatInt(upTo, 10)); err != nil {
+		return err
+	}
+
+	removed := 0
+	for removed < len(c.pending) && c.pending[removed].Sequence <= upTo {
+		removed++
+	}
+
+	c.pending, c.removed = c.pending[removed:], upTo
+	return c.deleteRemovedSegments()
+}
+
+func (c *connection) CountStatisticChanges(ctx context.Context) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return int64(len(c.pending)), nil
+}
+
+// Removes the player's changes of the game from the queue, and rewrites the segments holding any record of the
+// player, including the ones already removed, so the player ID doesn't stay on the disk. Each record is kept as its
+// sequence alone, so no sequence is used twice
+func (c *connection) ErasePlayerStatisticChanges(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return privacy.Erasure{}, ErrClosed
+	}
+
+	records, err := c.rewritePlayerRecords(gameID, playerID, func(entry writebehind.Entry) writebehind.Entry {
+		return writebehind.Entry{Sequence: entry.Sequence}
+	})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	c.pending = slices.DeleteFunc(c.pending, func(entry writebehind.Entry) bool {
+		return isPlayerChange(entry, gameID, playerID)
+	})
+
+	return privacy.Erasure{Data: privacy.DataStatisticProgression, Records: records}, nil
+}
+
+// Moves the player's changes of the game still on the journal to the pseudonym, so the ones queued are written to it
+func (c *connection) AnonymizePlayerStatisticChanges(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return privacy.Anonymization{}, ErrClosed
+	}
+
+	anonymize := func(entry writebehind.Entry) writebehind.Entry {
+		entry.Change.PlayerID = pseudonym
+		return entry
+	}
+
+	records, err := c.rewritePlayerRecords(gameID, playerID, anonymize)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	for i, entry := range c.pending {
+		if isPlayerChange(entry, gameID, playerID) {
+			c.pending[i] = anonymize(entry)
+		}
+	}
+
+	return privacy.Anonymization{Data: privacy.DataStatisticProgression, Records: records}, nil
+}
+
+// Counts the player's changes of the game still on the journal, queued or not
+func (c *connection) CountPlayerStatisticChanges(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return privacy.DataCount{}, ErrClosed
+	}
+
+	records, err := c.rewritePlayerRecords(gameID, playerID, nil)
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataStatisticProgression, Records: records}, nil
+}
+
+func isPlayerChange(entry writebehind.Entry, gameID, playerID string) bool {
+	return entry.Change.PlayerID == playerID && entry.Change.Statistic.GameID == gameID
+}
@@ -0,0 +1,297 @@
+package journal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatisticChanges(t *testing.T) {
+	var (
+		ctx = context.Background()
+		dir = filepath.Join(t.TempDir(), "journal")
+		st  = statistic.Statistic{ID: uuid.NewString(), GameID: uuid.NewString(), AggregationMode: statistic.AggregationModeSum}
+	)
+
+	conn, err := New(dir)
+	assert.NoError(t, err)
+
+	t.Run("Append", func(t *testing.T) {
+		for _, value := range []float64{1, 2, 3} {
+			assert.NoError(t, conn.AppendStatisticChange(ctx, statistic.PlayerProgressionChange{Statistic: st, PlayerID: "alice", Value: value}))
+		}
+
+		count, err := conn.CountStatisticChanges(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		entries, err := conn.PeekStatisticChanges(ctx, 2)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, int64(1), entries[0].Sequence)
+		assert.Equal(t, 2.0, entries[1].Change.Value)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		assert.NoError(t, conn.RemoveStatisticChanges(ctx, 2))
+
+		entries, err := conn.PeekStatisticChanges(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, int64(3), entries[0].Sequence)
+	})
+
+	t.Run("Reopen", func(t *testing.T) {
+		id := conn.ID()
+		assert.NotEmpty(t, id)
+
+		assert.NoError(t, conn.Close())
+		assert.ErrorIs(t, conn.AppendStatisticChange(ctx, statistic.PlayerProgressionChange{Statistic: st, PlayerID: "alice", Value: 4}), ErrClosed)
+
+		// A record cut short while it was appended
+		appendToLastSegment(t, dir, `{"Sequence":4,"Chan`)
+
+		conn, err = New(dir)
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		assert.Equal(t, id, conn.ID())
+
+		entries, err := conn.PeekStatisticChanges(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, int64(3), entries[0].Sequence)
+		assert.Equal(t, st.ID, entries[0].Change.Statistic.ID)
+		assert.Equal(t, 3.0, entries[0].Change.Value)
+
+		assert.NoError(t, conn.AppendStatisticChange(ctx, statistic.PlayerProgressionChange{Statistic: st, PlayerID: "alice", Value: 5}))
+
+		entries, err = conn.PeekStatisticChanges(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, int64(4), entries[1].Sequence)
+	})
+}
+
+func TestStatisticChangesSegments(t *testing.T) {
+	var (
+		ctx = context.Background()
+		dir = t.TempDir()
+		st  = statistic.Statistic{ID: uuid.NewString(), GameID: uuid.NewString(), AggregationMode: statistic.AggregationModeSum}
+	)
+
+	conn, err := newConnection(dir, 2)
+	assert.NoError(t, err)
+
+	for value := range 5 {
+		assert.NoError(t, conn.AppendStatisticChange(ctx, statistic.PlayerProgressionChange{Statistic: st, PlayerID: "alice", Value: float64(value)}))
+	}
+
+	t.Run("Rotated", func(t *testing.T) {
+		assert.Len(t, segmentFiles(t, dir), 3)
+	})
+
+	t.Run("Removed Segments Deleted", func(t *testing.T) {
+		assert.NoError(t, conn.RemoveStatisticChanges(ctx, 3))
+		assert.Equal(t, []string{"00000000000000000003.segment", "00000000000000000005.segment"}, segmentFiles(t, dir))
+	})
+
+	t.Run("Removed Records Skipped On Reopen", func(t *testing.T) {
+		assert.NoError(t, conn.Close())
+
+		conn, err = newConnection(dir, 2)
+		assert.NoError(t, err)
+
+		entries, err := conn.PeekStatisticChanges(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, int64(4), entries[0].Sequence)
+	})
+
+	t.Run("Sequence Kept Once Drained", func(t *testing.T) {
+		assert.NoError(t, conn.RemoveStatisticChanges(ctx, 5))
+		assert.NoError(t, conn.Close())
+
+		conn, err = newConnection(dir, 2)
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		count, err := conn.CountStatisticChanges(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, count)
+
+		assert.NoError(t, conn.AppendStatisticChange(ctx, statistic.PlayerProgressionChange{Statistic: st, PlayerID: "alice", Value: 6}))
+
+		entries, err := conn.PeekStatisticChanges(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, int64(6), entries[0].Sequence)
+	})
+}
+
+func TestPlayerStatisticChanges(t *testing.T) {
+	var (
+		ctx = context.Background()
+		dir = t.TempDir()
+		st  = statistic.Statistic{ID: uuid.NewString(), GameID: uuid.NewString(), AggregationMode: statistic.AggregationModeSum}
+	)
+
+	conn, err := newConnection(dir, 2)
+	assert.NoError(t, err)
+
+	for i, playerID := range []string{"alice", "bob", "alice", "bob", "alice"} {
+		assert.NoError(t, conn.AppendStatisticChange(ctx, statistic.PlayerProgressionChange{Statistic: st, PlayerID: playerID, Value: float64(i)}))
+	}
+
+	// Written to the statistic storage, but still on the disk
+	assert.NoError(t, conn.RemoveStatisticChanges(ctx, 1))
+
+	queued := func(t *testing.T) []string {
+		entries, err := conn.PeekStatisticChanges(ctx, 10)
+		assert.NoError(t, err)
+
+		playerIDs := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			playerIDs = append(playerIDs, entry.Change.PlayerID)
+		}
+
+		return playerIDs
+	}
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := conn.CountPlayerStatisticChanges(ctx, st.GameID, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, privacy.DataCount{Data: privacy.DataStatisticProgression, Records: 3}, count)
+
+		count, err = conn.CountPlayerStatisticChanges(ctx, uuid.NewString(), "alice")
+		assert.NoError(t, err)
+		assert.Zero(t, count.Records)
+	})
+
+	t.Run("Anonymize", func(t *testing.T) {
+		anonymization, err := conn.AnonymizePlayerStatisticChanges(ctx, st.GameID, "bob", "pseudonym")
+		assert.NoError(t, err)
+		assert.Equal(t, privacy.Anonymization{Data: privacy.DataStatisticProgression, Records: 2}, anonymization)
+		assert.Equal(t, []string{"pseudonym", "alice", "pseudonym", "alice"}, queued(t))
+	})
+
+	t.Run("Erase", func(t *testing.T) {
+		erasure, err := conn.ErasePlayerStatisticChanges(ctx, st.GameID, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, privacy.Erasure{Data: privacy.DataStatisticProgression, Records: 3}, erasure)
+		assert.Equal(t, []string{"pseudonym", "pseudonym"}, queued(t))
+
+		for _, file := range segmentFiles(t, dir) {
+			data, err := os.ReadFile(filepath.Join(dir, file))
+			assert.NoError(t, err)
+			assert.NotContains(t, string(data), "alice")
+			assert.NotContains(t, string(data), "bob")
+		}
+	})
+
+	t.Run("Appended After The Erasure", func(t *testing.T) {
+		assert.NoError(t, conn.AppendStatisticChange(ctx, statistic.PlayerProgressionChange{Statistic: st, PlayerID: "carol", Value: 6}))
+		assert.Equal(t, []string{"pseudonym", "pseudonym", "carol"}, queued(t))
+	})
+
+	t.Run("Sequences Kept On Reopen", func(t *testing.T) {
+		assert.NoError(t, conn.Close())
+
+		conn, err = newConnection(dir, 2)
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		entries, err := conn.PeekStatisticChanges(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 3)
+		assert.Equal(t, []int64{2, 4, 6}, []int64{entries[0].Sequence, entries[1].Sequence, entries[2].Sequence})
+
+		assert.NoError(t, conn.AppendStatisticChange(ctx, statistic.PlayerProgressionChange{Statistic: st, PlayerID: "carol", Value: 7}))
+
+		entries, err = conn.PeekStatisticChanges(ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), entries[3].Sequence)
+	})
+}
+
+func TestStatisticChangesCorrupted(t *testing.T) {
+	var (
+		ctx = context.Background()
+		st  = statistic.Statistic{ID: uuid.NewString(), GameID: uuid.NewString(), AggregationMode: statistic.AggregationModeSum}
+	)
+
+	setup := func(t *testing.T, segmentRecords int64) string {
+		dir := t.TempDir()
+
+		conn, err := newConnection(dir, segmentRecords)
+		assert.NoError(t, err)
+
+		for value := range 3 {
+			assert.NoError(t, conn.AppendStatisticChange(ctx, statistic.PlayerProgressionChange{Statistic: st, PlayerID: "alice", Value: float64(value)}))
+		}
+
+		assert.NoError(t, conn.Close())
+		return dir
+	}
+
+	t.Run("Within The Last Segment", func(t *testing.T) {
+		dir := setup(t, 10)
+
+		path := filepath.Join(dir, segmentFiles(t, dir)[0])
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(path, append([]byte("garbage\n"), data...), 0o644))
+
+		_, err = newConnection(dir, 10)
+		assert.ErrorIs(t, err, ErrCorrupted)
+	})
+
+	t.Run("End Of An Older Segment", func(t *testing.T) {
+		dir := setup(t, 2)
+
+		file, err := os.OpenFile(filepath.Join(dir, segmentFiles(t, dir)[0]), os.O_WRONLY|os.O_APPEND, 0o644)
+		assert.NoError(t, err)
+		_, err = file.WriteString(`{"Sequence":3,"Chan`)
+		assert.NoError(t, err)
+		assert.NoError(t, file.Close())
+
+		_, err = newConnection(dir, 2)
+		assert.ErrorIs(t, err, ErrCorrupted)
+	})
+
+	t.Run("Sequence Out Of Order", func(t *testing.T) {
+		dir := setup(t, 10)
+		appendToLastSegment(t, dir, `{"Sequence":2,"Change":{}}`+"\n"+`{"Sequence":4,"Change":{}}`+"\n")
+
+		_, err := newConnection(dir, 10)
+		assert.ErrorIs(t, err, ErrCorrupted)
+	})
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentExtension))
+	assert.NoError(t, err)
+
+	for i, file := range files {
+		files[i] = filepath.Base(file)
+	}
+
+	return files
+}
+
+func appendToLastSegment(t *testing.T, dir, data string) {
+	files := segmentFiles(t, dir)
+
+	file, err := os.OpenFile(filepath.Join(dir, files[len(files)-1]), os.O_WRONLY|os.O_APPEND, 0o644)
+	assert.NoError(t, err)
+	_, err = file.WriteString(data)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+}
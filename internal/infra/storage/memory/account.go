@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+)
+
+// Field of the external ID blind index
+const externalIDField = "externalId"
+
+// Linked account as stored, its external ID replaced by the blind index once the encryption is enabled
+type linkedAccount struct {
+	player.LinkedAccount
+	encryptedExternalID string
+}
+
+func (a linkedAccount) toDomain(cipher *encryption.Cipher) (player.LinkedAccount, error) {
+	account := a.LinkedAccount
+
+	var err error
+	if account.ExternalID, err = cipher.Open(a.ExternalID, a.encryptedExternalID); err != nil {
+		return player.LinkedAccount{}, err
+	}
+
+	return account, nil
+}
+
+// The player ID may come from a request parameter, so the account's fields are copied to outlive the request
+func (c *connection) LinkAccount(ctx context.Context, account player.LinkedAccount) (player.LinkedAccount, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	externalID, encryptedExternalID, err := c.cipher.Seal(externalIDField, account.ExternalID)
+	if err != nil {
+		return player.LinkedAccount{}, err
+	}
+
+	for _, linked := range c.linkedAccounts {
+		if linked.GameID != account.GameID || linked.Platform != account.Platform || linked.ExternalID != externalID {
+			continue
+		}
+
+		if linked.PlayerID != account.PlayerID {
+			return player.LinkedAccount{}, player.ErrAccountLinkedToAnotherPlayer
+		}
+
+		return linked.toDomain(c.cipher)
+	}
+
+	stored := linkedAccount{LinkedAccount: account, encryptedExternalID: encryptedExternalID}
+	stored.GameID = strings.Clone(account.GameID)
+	stored.PlayerID = strings.Clone(account.PlayerID)
+	stored.Platform = strings.Clone(account.Platform)
+	stored.ExternalID = strings.Clone(externalID)
+	c.linkedAccounts = append(c.linkedAccounts, stored)
+
+	return account, nil
+}
+
+func (c *connection) UnlinkAccount(ctx context.Context, gameID, playerID, platform, externalID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	externalID = c.cipher.BlindIndex(externalIDField, externalID)
+
+	i := slices.IndexFunc(c.linkedAccounts, func(a linkedAccount) bool {
+		return a.GameID == gameID && a.PlayerID == playerID && a.Platform == platform && a.ExternalID == externalID
+	})
+	if i < 0 {
+		return player.ErrAccountNotFound
+	}
+
+	c.linkedAccounts = slices.Delete(c.linkedAccounts, i, i+1)
+	return nil
+}
+
+// Accounts are appended as they are linked, so they are kept ordered by link time
+func (c *connection) ListAccounts(ctx context.Context, gameID, playerID string) ([]player.LinkedAccount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	accounts := make([]player.LinkedAccount, 0)
+	for _, a := range c.linkedAccounts {
+		if a.GameID != gameID || a.PlayerID != playerID {
+			continue
+		}
+
+		account, err := a.toDomain(c.cipher)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
+
+func (c *connection) GetAccount(ctx context.Context, gameID, platform, externalID string) (player.LinkedAccount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	externalID = c.cipher.BlindIndex(externalIDField, externalID)
+
+	for _, a := range c.linkedAccounts {
+		if a.GameID == gameID && a.Platform == platform && a.ExternalID == externalID {
+			return a.toDomain(c.cipher)
+		}
+	}
+
+	return player.LinkedAccount{}, player.ErrAccountNotFound
+}
+
+func (c *connection) removePlayerAccounts(gameID, playerID string) int64 {
+	before := len(c.linkedAccounts)
+	c.linkedAccounts = slices.DeleteFunc(c.linkedAccounts, func(a linkedAccount) bool {
+		return a.GameID == gameID && a.PlayerID == playerID
+	})
+
+	return int64(before - len(c.linkedAccounts))
+}
+
+// Erases the accounts linked to the player
+func (c *connection) ErasePlayerAccounts(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return privacy.Erasure{Data: privacy.DataLinkedAccounts, Records: c.removePlayerAccounts(gameID, playerID)}, nil
+}
+
+// The external IDs identify the player on their own, so the links are removed instead of moved to the pseudonym
+func (c *connection) AnonymizePlayerAccounts(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return privacy.Anonymization{Data: privacy.DataLinkedAccounts, Records: c.removePlayerAccounts(gameID, playerID)}, nil
+}
+
+// Counts the accounts linked to the player
+func (c *connection) CountPlayerAccounts(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataLinkedAccounts}
+	for _, a := range c.linkedAccounts {
+		if a.GameID == gameID && a.PlayerID == playerID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/player"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkedAccount(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		conn     = New()
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	steam := player.LinkedAccount{LinkedAt: time.Now().UTC(), GameID: gameID, PlayerID: playerID, Platform: player.PlatformSteam, ExternalID: "76561197960287930"}
+	device := player.LinkedAccount{LinkedAt: time.Now().UTC(), GameID: gameID, PlayerID: playerID, Platform: player.PlatformDevice, ExternalID: uuid.NewString()}
+
+	t.Run("Link", func(t *testing.T) {
+		linked, err := conn.LinkAccount(ctx, steam)
+		assert.NoError(t, err)
+		assert.Equal(t, steam, linked)
+
+		again := steam
+		again.LinkedAt = steam.LinkedAt.Add(time.Hour)
+		linked, err = conn.LinkAccount(ctx, again)
+		assert.NoError(t, err)
+		assert.Equal(t, steam, linked)
+
+		other := steam
+		other.PlayerID = uuid.NewString()
+		_, err = conn.LinkAccount(ctx, other)
+		assert.ErrorIs(t, err, player.ErrAccountLinkedToAnotherPlayer)
+
+		_, err = conn.LinkAccount(ctx, device)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Resolve", func(t *testing.T) {
+		account, err := conn.GetAccount(ctx, gameID, player.PlatformSteam, steam.ExternalID)
+		assert.NoError(t, err)
+		assert.Equal(t, playerID, account.PlayerID)
+
+		_, err = conn.GetAccount(ctx, uuid.NewString(), player.PlatformSteam, steam.ExternalID)
+		assert.ErrorIs(t, err, player.ErrAccountNotFound)
+
+		accounts, err := conn.ListAccounts(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, []player.LinkedAccount{steam, device}, accounts)
+	})
+
+	t.Run("Unlink", func(t *testing.T) {
+		assert.NoError(t, conn.UnlinkAccount(ctx, gameID, playerID, player.PlatformDevice, device.ExternalID))
+		assert.ErrorIs(t, conn.UnlinkAccount(ctx, gameID, playerID, player.PlatformDevice, device.ExternalID), player.ErrAccountNotFound)
+	})
+
+	t.Run("Privacy", func(t *testing.T) {
+		count, err := conn.CountPlayerAccounts(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count.Records)
+
+		anonymization, err := conn.AnonymizePlayerAccounts(ctx, gameID, playerID, uuid.NewString())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), anonymization.Records)
+
+		_, err = conn.GetAccount(ctx, gameID, player.PlatformSteam, steam.ExternalID)
+		assert.ErrorIs(t, err, player.ErrAccountNotFound)
+
+		_, err = conn.LinkAccount(ctx, steam)
+		assert.NoError(t, err)
+
+		erasure, err := conn.ErasePlayerAccounts(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), erasure.Records)
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		buf := newRequestBuffer()
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range playerIDs {
+			_, err := conn.LinkAccount(ctx, player.LinkedAccount{GameID: gameID, PlayerID: buf.param(playerID), Platform: player.PlatformDevice, ExternalID: playerID})
+			assert.NoError(t, err)
+		}
+
+		buf.param("dave-3333333")
+
+		for _, playerID := range playerIDs {
+			account, err := conn.GetAccount(ctx, gameID, player.PlatformDevice, playerID)
+			assert.NoError(t, err)
+			assert.Equal(t, playerID, account.PlayerID)
+		}
+	})
+}
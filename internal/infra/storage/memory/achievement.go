@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+)
+
+func (c *connection) CreateAchievement(ctx context.Context, data achievement.NewAchievementData) (achievement.Achievement, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a := achievement.Achievement{
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+		ID:          uuid.NewString(),
+		GameID:      data.GameID,
+		Name:        data.Name,
+		Description: data.Description,
+		Trigger:     data.Trigger,
+	}
+	c.achievements = append(c.achievements, a)
+
+	return a, nil
+}
+
+func (c *connection) GetAchievement(ctx context.Context, id, gameID string) (achievement.Achievement, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, a := range c.achievements {
+		if a.ID == id && a.GameID == gameID {
+			return a, nil
+		}
+	}
+
+	return achievement.Achievement{}, achievement.ErrAchievementNotFound
+}
+
+// Achievements are appended as they are created, so they are kept ordered by creation time
+func (c *connection) ListAchievements(ctx context.Context, gameID string) ([]achievement.Achievement, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	achievements := make([]achievement.Achievement, 0)
+	for _, a := range c.achievements {
+		if a.GameID == gameID {
+			achievements = append(achievements, a)
+		}
+	}
+
+	return achievements, nil
+}
+
+func (c *connection) DeleteAchievement(ctx context.Context, id, gameID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.achievements, func(a achievement.Achievement) bool { return a.ID == id && a.GameID == gameID })
+	if i < 0 {
+		return achievement.ErrAchievementNotFound
+	}
+
+	c.achievements = slices.Delete(c.achievements, i, i+1)
+	c.achievementUnlocks = slices.DeleteFunc(c.achievementUnlocks, func(unlock achievement.Unlock) bool { return unlock.AchievementID == id })
+
+	return nil
+}
+
+// The player IDs may come from a request parameter, so they're copied to outlive the request
+func (c *connection) UnlockAchievements(ctx context.Context, unlocks []achievement.Unlock) ([]achievement.Unlock, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	recorded := make([]achievement.Unlock, 0, len(unlocks))
+	for _, unlock := range unlocks {
+		unlocked := slices.ContainsFunc(c.achievementUnlocks, func(u achievement.Unlock) bool {
+			return u.AchievementID == unlock.AchievementID && u.PlayerID == unlock.PlayerID
+		})
+		if unlocked {
+			continue
+		}
+
+		recorded = append(recorded, unlock)
+
+		unlock.GameID = strings.Clone(unlock.GameID)
+		unlock.PlayerID = strings.Clone(unlock.PlayerID)
+		c.achievementUnlocks = append(c.achievementUnlocks, unlock)
+	}
+
+	return recorded, nil
+}
+
+// Unlocks are appended as they happen, so they are kept ordered by unlock time
+func (c *connection) ListAchievementUnlocks(ctx context.Context, gameID, playerID string) ([]achievement.Unlock, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	unlocks := make([]achievement.Unlock, 0)
+	for _, unlock := range c.achievementUnlocks {
+		if unlock.GameID == gameID && unlock.PlayerID == playerID {
+			unlocks = append(unlocks, unlock)
+		}
+	}
+
+	return unlocks, nil
+}
+
+// Erases the achievements the player unlocked on the game
+func (c *connection) ErasePlayerAchievements(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataAchievements}
+	c.achievementUnlocks = slices.DeleteFunc(c.achievementUnlocks, func(unlock achievement.Unlock) bool {
+		if unlock.GameID != gameID || unlock.PlayerID != playerID {
+			return false
+		}
+
+		erasure.Records++
+		return true
+	})
+
+	return erasure, nil
+}
+
+// Moves the achievements the player unlocked on the game to the pseudonym
+func (c *connection) AnonymizePlayerAchievements(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataAchievements}
+	for i, unlock := range c.achievementUnlocks {
+		if unlock.GameID == gameID && unlock.PlayerID == playerID {
+			c.achievementUnlocks[i].PlayerID = pseudonym
+			anonymization.Records++
+		}
+	}
+
+	return anonymization, nil
+}
+
+// Counts the achievements the player unlocked on the game
+func (c *connection) CountPlayerAchievements(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataAchievements}
+	for _, unlock := range c.achievementUnlocks {
+		if unlock.GameID == gameID && unlock.PlayerID == playerID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
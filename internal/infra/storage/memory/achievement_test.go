@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAchievement(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		conn     = New()
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	a, err := conn.CreateAchievement(ctx, achievement.NewAchievementData{
+		GameID:  gameID,
+		Name:    "First Steps",
+		Trigger: achievement.Trigger{Kind: achievement.TriggerQuestCompleted, QuestID: uuid.NewString()},
+	})
+	assert.NoError(t, err)
+
+	t.Run("Get And List", func(t *testing.T) {
+		stored, err := conn.GetAchievement(ctx, a.ID, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, a, stored)
+
+		_, err = conn.GetAchievement(ctx, a.ID, uuid.NewString())
+		assert.ErrorIs(t, err, achievement.ErrAchievementNotFound)
+
+		achievements, err := conn.ListAchievements(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, []achievement.Achievement{a}, achievements)
+	})
+
+	t.Run("Unlock", func(t *testing.T) {
+		unlock := achievement.Unlock{AchievementID: a.ID, GameID: gameID, PlayerID: playerID, UnlockedAt: time.Now().UTC()}
+
+		recorded, err := conn.UnlockAchievements(ctx, []achievement.Unlock{unlock})
+		assert.NoError(t, err)
+		assert.Equal(t, []achievement.Unlock{unlock}, recorded)
+
+		recorded, err = conn.UnlockAchievements(ctx, []achievement.Unlock{unlock})
+		assert.NoError(t, err)
+		assert.Empty(t, recorded)
+
+		unlocks, err := conn.ListAchievementUnlocks(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, []achievement.Unlock{unlock}, unlocks)
+
+		count, err := conn.CountPlayerAchievements(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count.Records)
+	})
+
+	t.Run("Anonymize And Erase", func(t *testing.T) {
+		pseudonym := uuid.NewString()
+
+		anonymization, err := conn.AnonymizePlayerAchievements(ctx, gameID, playerID, pseudonym)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), anonymization.Records)
+
+		erasure, err := conn.ErasePlayerAchievements(ctx, gameID, pseudonym)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), erasure.Records)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		_, err := conn.UnlockAchievements(ctx, []achievement.Unlock{{AchievementID: a.ID, GameID: gameID, PlayerID: playerID}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, conn.DeleteAchievement(ctx, a.ID, gameID))
+		assert.ErrorIs(t, conn.DeleteAchievement(ctx, a.ID, gameID), achievement.ErrAchievementNotFound)
+
+		unlocks, err := conn.ListAchievementUnlocks(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Empty(t, unlocks)
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		buf := newRequestBuffer()
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range playerIDs {
+			_, err := conn.UnlockAchievements(ctx, []achievement.Unlock{{AchievementID: a.ID, GameID: gameID, PlayerID: buf.param(playerID)}})
+			assert.NoError(t, err)
+		}
+
+		buf.param("dave-3333333")
+
+		for _, playerID := range playerIDs {
+			unlocks, err := conn.ListAchievementUnlocks(ctx, gameID, playerID)
+			assert.NoError(t, err)
+			if assert.Len(t, unlocks, 1) {
+				assert.Equal(t, playerID, unlocks[0].PlayerID)
+			}
+		}
+	})
+}
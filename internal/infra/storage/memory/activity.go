@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/activity"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+)
+
+// The player IDs may come from a request parameter, so they're copied to outlive the request
+func (c *connection) RecordActivities(ctx context.Context, activities []activity.Activity) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, a := range activities {
+		a.ID = uuid.NewString()
+		a.GameID = strings.Clone(a.GameID)
+		a.PlayerID = strings.Clone(a.PlayerID)
+		a.OccurredAt = a.OccurredAt.UTC()
+		c.activities = append(c.activities, a)
+	}
+
+	return nil
+}
+
+// Newest first, with the ID breaking the ties between activities that occurred at the same time
+func compareActivities(a, b activity.Activity) int {
+	if c := b.OccurredAt.Compare(a.OccurredAt); c != 0 {
+		return c
+	}
+
+	return strings.Compare(b.ID, a.ID)
+}
+
+func (c *connection) ListActivities(ctx context.Context, gameID string, playerIDs []string, after activity.Activity, limit int) ([]activity.Activity, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	activities := make([]activity.Activity, 0)
+	for _, a := range c.activities {
+		if a.GameID != gameID || !slices.Contains(playerIDs, a.PlayerID) {
+			continue
+		}
+
+		if after.ID != "" && compareActivities(after, a) >= 0 {
+			continue
+		}
+
+		activities = append(activities, a)
+	}
+
+	slices.SortFunc(activities, compareActivities)
+	if len(activities) > limit {
+		activities = activities[:limit]
+	}
+
+	return activities, nil
+}
+
+// Erases the player's activity feed on the game
+func (c *connection) ErasePlayerActivities(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataActivity}
+	c.activities = slices.DeleteFunc(c.activities, func(a activity.Activity) bool {
+		if a.GameID != gameID || a.PlayerID != playerID {
+			return false
+		}
+
+		erasure.Records++
+		return true
+	})
+
+	return erasure, nil
+}
+
+// Moves the player's activity feed on the game to the pseudonym
+func (c *connection) AnonymizePlayerActivities(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataActivity}
+	for i, a := range c.activities {
+		if a.GameID == gameID && a.PlayerID == playerID {
+			c.activities[i].PlayerID = pseudonym
+			anonymization.Records++
+		}
+	}
+
+	return anonymization, nil
+}
+
+// Counts the activities on the player's feed on the game
+func (c *connection) CountPlayerActivities(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataActivity}
+	for _, a := range c.activities {
+		if a.GameID == gameID && a.PlayerID == playerID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/activity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivity(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		conn     = New()
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+		friendID = uuid.NewString()
+		now      = time.Now().UTC()
+	)
+
+	err := conn.RecordActivities(ctx, []activity.Activity{
+		{OccurredAt: now.Add(-2 * time.Minute), GameID: gameID, PlayerID: playerID, Kind: activity.KindQuestCompleted, QuestID: uuid.NewString()},
+		{OccurredAt: now.Add(-time.Minute), GameID: gameID, PlayerID: friendID, Kind: activity.KindRankMilestone, LeaderboardID: uuid.NewString(), Top: 10},
+		{OccurredAt: now, GameID: gameID, PlayerID: playerID, Kind: activity.KindLandmarkReached, StatisticID: uuid.NewString(), Value: 100},
+	})
+	assert.NoError(t, err)
+
+	t.Run("List", func(t *testing.T) {
+		activities, err := conn.ListActivities(ctx, gameID, []string{playerID}, activity.Activity{}, 10)
+		assert.NoError(t, err)
+		assert.Len(t, activities, 2)
+		assert.Equal(t, activity.KindLandmarkReached, activities[0].Kind)
+		assert.NotEmpty(t, activities[0].ID)
+
+		page, err := conn.ListActivities(ctx, gameID, []string{playerID, friendID}, activity.Activity{}, 2)
+		assert.NoError(t, err)
+		assert.Len(t, page, 2)
+		assert.Equal(t, friendID, page[1].PlayerID)
+
+		page, err = conn.ListActivities(ctx, gameID, []string{playerID, friendID}, page[1], 2)
+		assert.NoError(t, err)
+		assert.Len(t, page, 1)
+		assert.Equal(t, activity.KindQuestCompleted, page[0].Kind)
+
+		activities, err = conn.ListActivities(ctx, uuid.NewString(), []string{playerID}, activity.Activity{}, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, activities)
+	})
+
+	t.Run("Anonymize And Erase", func(t *testing.T) {
+		pseudonym := uuid.NewString()
+
+		count, err := conn.CountPlayerActivities(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count.Records)
+
+		anonymization, err := conn.AnonymizePlayerActivities(ctx, gameID, playerID, pseudonym)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), anonymization.Records)
+
+		erasure, err := conn.ErasePlayerActivities(ctx, gameID, pseudonym)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), erasure.Records)
+
+		activities, err := conn.ListActivities(ctx, gameID, []string{playerID, pseudonym, friendID}, activity.Activity{}, 10)
+		assert.NoError(t, err)
+		assert.Len(t, activities, 1)
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		buf := newRequestBuffer()
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for i, playerID := range playerIDs {
+			err := conn.RecordActivities(ctx, []activity.Activity{
+				{OccurredAt: now.Add(time.Duration(i) * time.Second), GameID: gameID, PlayerID: buf.param(playerID), Kind: activity.KindQuestCompleted, QuestID: uuid.NewString()},
+			})
+			assert.NoError(t, err)
+		}
+
+		buf.param("dave-3333333")
+
+		activities, err := conn.ListActivities(ctx, gameID, playerIDs, activity.Activity{}, 10)
+		assert.NoError(t, err)
+		assert.Len(t, activities, 3)
+		for i, a := range activities {
+			assert.Equal(t, playerIDs[len(playerIDs)-1-i], a.PlayerID)
+		}
+	})
+}
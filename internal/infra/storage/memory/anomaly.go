@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+)
+
+func (c *connection) SaveAnomaly(ctx context.Context, a anomaly.Anomaly) (anomaly.Anomaly, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.anomalies, func(e anomaly.Anomaly) bool {
+		return e.GameID == a.GameID && e.LeaderboardID == a.LeaderboardID && e.PlayerID == a.PlayerID
+	})
+	if i >= 0 {
+		a.ID = c.anomalies[i].ID
+		c.anomalies[i] = a
+		return a, nil
+	}
+
+	a.ID = uuid.NewString()
+	c.anomalies = append(c.anomalies, a)
+	return a, nil
+}
+
+func (c *connection) ListLeaderboardAnomalies(ctx context.Context, gameID, leaderboardID string) ([]anomaly.Anomaly, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	anomalies := make([]anomaly.Anomaly, 0)
+	for _, a := range c.anomalies {
+		if a.GameID == gameID && a.LeaderboardID == leaderboardID {
+			anomalies = append(anomalies, a)
+		}
+	}
+
+	return anomalies, nil
+}
+
+// The anomalies are replaced in place when flagged again, so they're sorted by detection on each read
+func (c *connection) ListAnomalies(ctx context.Context, gameID, leaderboardID string, limit int) ([]anomaly.Anomaly, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	anomalies := make([]anomaly.Anomaly, 0)
+	for _, a := range c.anomalies {
+		if a.GameID == gameID && a.Status == anomaly.StatusPending && (leaderboardID == "" || a.LeaderboardID == leaderboardID) {
+			anomalies = append(anomalies, a)
+		}
+	}
+
+	slices.SortStableFunc(anomalies, func(a, b anomaly.Anomaly) int { return a.DetectedAt.Compare(b.DetectedAt) })
+	return anomalies[:min(limit, len(anomalies))], nil
+}
+
+func (c *connection) DismissAnomaly(ctx context.Context, gameID, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.anomalies, func(a anomaly.Anomaly) bool {
+		return a.GameID == gameID && a.ID == id && a.Status == anomaly.StatusPending
+	})
+	if i < 0 {
+		return anomaly.ErrAnomalyNotFound
+	}
+
+	c.anomalies[i].Status = anomaly.StatusDismissed
+	return nil
+}
+
+// Erases the player's ranks flagged on the game
+func (c *connection) ErasePlayerAnomalies(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataAnomalies}
+	c.anomalies = slices.DeleteFunc(c.anomalies, func(a anomaly.Anomaly) bool {
+		if a.GameID != gameID || a.PlayerID != playerID {
+			return false
+		}
+
+		erasure.Records++
+		return true
+	})
+
+	return erasure, nil
+}
+
+// Moves the player's ranks flagged on the game to the pseudonym
+func (c *connection) AnonymizePlayerAnomalies(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataAnomalies}
+	for i, a := range c.anomalies {
+		if a.GameID == gameID && a.PlayerID == playerID {
+			c.anomalies[i].PlayerID = pseudonym
+			anonymization.Records++
+		}
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's ranks flagged on the game
+func (c *connection) CountPlayerAnomalies(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataAnomalies}
+	for _, a := range c.anomalies {
+		if a.GameID == gameID && a.PlayerID == playerID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
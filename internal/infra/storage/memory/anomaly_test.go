@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnomaly(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		conn    = New()
+		gameID  = uuid.NewString()
+		now     = time.Now()
+		flagged anomaly.Anomaly
+	)
+
+	t.Run("Save Anomaly", func(t *testing.T) {
+		var err error
+		flagged, err = conn.SaveAnomaly(ctx, anomaly.Anomaly{DetectedAt: now, GameID: gameID, LeaderboardID: "leaderboard", PlayerID: "alice", Value: 1000, Status: anomaly.StatusPending})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, flagged.ID)
+
+		_, err = conn.SaveAnomaly(ctx, anomaly.Anomaly{DetectedAt: now.Add(-time.Hour), GameID: gameID, LeaderboardID: "other", PlayerID: "bob", Value: 10, Status: anomaly.StatusPending})
+		assert.NoError(t, err)
+
+		again, err := conn.SaveAnomaly(ctx, anomaly.Anomaly{DetectedAt: now, GameID: gameID, LeaderboardID: "leaderboard", PlayerID: "alice", Value: 2000, Status: anomaly.StatusPending})
+		assert.NoError(t, err)
+		assert.Equal(t, flagged.ID, again.ID)
+	})
+
+	t.Run("List Anomalies", func(t *testing.T) {
+		anomalies, err := conn.ListLeaderboardAnomalies(ctx, gameID, "leaderboard")
+		assert.NoError(t, err)
+		assert.Len(t, anomalies, 1)
+		assert.Equal(t, 2000.0, anomalies[0].Value)
+
+		anomalies, err = conn.ListAnomalies(ctx, gameID, "", 10)
+		assert.NoError(t, err)
+		assert.Len(t, anomalies, 2)
+		assert.Equal(t, "bob", anomalies[0].PlayerID)
+
+		anomalies, err = conn.ListAnomalies(ctx, gameID, "leaderboard", 10)
+		assert.NoError(t, err)
+		assert.Len(t, anomalies, 1)
+	})
+
+	t.Run("Dismiss Anomaly", func(t *testing.T) {
+		err := conn.DismissAnomaly(ctx, gameID, flagged.ID)
+		assert.NoError(t, err)
+
+		err = conn.DismissAnomaly(ctx, gameID, flagged.ID)
+		assert.ErrorIs(t, err, anomaly.ErrAnomalyNotFound)
+
+		anomalies, err := conn.ListAnomalies(ctx, gameID, "leaderboard", 10)
+		assert.NoError(t, err)
+		assert.Empty(t, anomalies)
+
+		anomalies, err = conn.ListLeaderboardAnomalies(ctx, gameID, "leaderboard")
+		assert.NoError(t, err)
+		assert.Equal(t, anomaly.StatusDismissed, anomalies[0].Status)
+	})
+
+	t.Run("Player Data", func(t *testing.T) {
+		count, err := conn.CountPlayerAnomalies(ctx, gameID, "alice")
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, count.Records)
+
+		anonymization, err := conn.AnonymizePlayerAnomalies(ctx, gameID, "alice", "pseudonym")
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, anonymization.Records)
+
+		erasure, err := conn.ErasePlayerAnomalies(ctx, gameID, "pseudonym")
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, erasure.Records)
+
+		erasure, err = conn.ErasePlayerAnomalies(ctx, gameID, "alice")
+		assert.NoError(t, err)
+		assert.Zero(t, erasure.Records)
+	})
+}
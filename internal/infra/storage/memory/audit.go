@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+
+	"github.com/google/uuid"
+)
+
+// Field of the actor blind index
+const auditActorField = "auditActor"
+
+// Audit entry as stored, its actor replaced by the blind index once the encryption is enabled
+type auditEntry struct {
+	audit.Entry
+	encryptedActor string
+}
+
+func (e auditEntry) toDomain(cipher *encryption.Cipher) (audit.Entry, error) {
+	entry := e.Entry
+	entry.ResourceIDs = maps.Clone(e.ResourceIDs)
+
+	var err error
+	if entry.Actor, err = cipher.Open(e.Actor, e.encryptedActor); err != nil {
+		return audit.Entry{}, err
+	}
+
+	return entry, nil
+}
+
+// Copies the resource IDs and their keys, as they come from the route parameters of the request
+func cloneResourceIDs(resourceIDs map[string]string) map[string]string {
+	if resourceIDs == nil {
+		return nil
+	}
+
+	cloned := make(map[string]string, len(resourceIDs))
+	for param, id := range resourceIDs {
+		cloned[strings.Clone(param)] = strings.Clone(id)
+	}
+
+	return cloned
+}
+
+// The audit log is append only, entries are never updated or removed. The method and the resource IDs come from the
+// request, so they're copied to outlive it
+func (c *connection) RecordAuditEntry(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	actor, encryptedActor, err := c.cipher.Seal(auditActorField, data.Actor)
+	if err != nil {
+		return audit.Entry{}, err
+	}
+
+	entry := auditEntry{
+		Entry: audit.Entry{
+			CreatedAt:     time.Now().UTC(),
+			ID:            uuid.NewString(),
+			GameID:        data.GameID,
+			Actor:         actor,
+			Method:        strings.Clone(data.Method),
+			Route:         data.Route,
+			ResourceIDs:   cloneResourceIDs(data.ResourceIDs),
+			PayloadDigest: data.PayloadDigest,
+			StatusCode:    data.StatusCode,
+			RequestedAt:   data.RequestedAt.UTC(),
+		},
+		encryptedActor: encryptedActor,
+	}
+	c.auditEntries = append(c.auditEntries, entry)
+
+	return entry.toDomain(c.cipher)
+}
+
+// Newest first, with the ID breaking the ties between entries recorded at the same time
+func compareAuditEntries(a, b audit.Entry) int {
+	if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+		return c
+	}
+
+	return strings.Compare(b.ID, a.ID)
+}
+
+// The actor on the filter is its blind index once the encryption is enabled
+func auditEntryMatches(e audit.Entry, filter audit.Filter) bool {
+	switch {
+	case e.GameID != filter.GameID:
+		return false
+	case filter.Actor != "" && e.Actor != filter.Actor:
+		return false
+	case filter.ResourceType != "" && !strings.HasPrefix(e.Route, audit.RoutePrefix(filter.ResourceType)):
+		return false
+	case !filter.From.IsZero() && e.CreatedAt.Before(filter.From):
+		return false
+	case !filter.To.IsZero() && !e.CreatedAt.Before(filter.To):
+		return false
+	default:
+		return true
+	}
+}
+
+func (c *connection) ListAuditEntries(ctx context.Context, filter audit.Filter, after audit.Entry, limit int) ([]audit.Entry, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	filter.Actor = c.cipher.BlindIndex(auditActorField, filter.Actor)
+
+	matches := make([]auditEntry, 0)
+	for _, e := range c.auditEntries {
+		if !auditEntryMatches(e.Entry, filter) {
+			continue
+		}
+
+		if after.ID != "" && compareAuditEntries(after, e.Entry) >= 0 {
+			continue
+		}
+
+		matches = append(matches, e)
+	}
+
+	slices.SortFunc(matches, func(a, b auditEntry) int { return compareAuditEntries(a.Entry, b.Entry) })
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	entries := make([]audit.Entry, len(matches))
+	for i, e := range matches {
+		var err error
+		if entries[i], err = e.toDomain(c.cipher); err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
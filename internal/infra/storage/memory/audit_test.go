@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEntries(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		actor  = uuid.NewString()
+	)
+
+	record := func(actor, route string) audit.Entry {
+		entry, err := conn.RecordAuditEntry(ctx, audit.NewEntryData{
+			GameID:      gameID,
+			Actor:       actor,
+			Method:      http.MethodDelete,
+			Route:       route,
+			StatusCode:  http.StatusNoContent,
+			RequestedAt: time.Now(),
+		})
+		assert.NoError(t, err)
+
+		return entry
+	}
+
+	var (
+		first  = record(actor, "/api/v1/leaderboards/:leaderboardId")
+		second = record(uuid.NewString(), "/api/v1/quests/:questId")
+		third  = record(actor, "/api/v1/statistics/:statisticId")
+	)
+
+	_, err := conn.RecordAuditEntry(ctx, audit.NewEntryData{GameID: uuid.NewString(), Method: http.MethodPost, Route: "/api/v1/quests/"})
+	assert.NoError(t, err)
+
+	entries, err := conn.ListAuditEntries(ctx, audit.Filter{GameID: gameID}, audit.Entry{}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []audit.Entry{third, second}, entries)
+
+	entries, err = conn.ListAuditEntries(ctx, audit.Filter{GameID: gameID}, entries[1], 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []audit.Entry{first}, entries)
+
+	entries, err = conn.ListAuditEntries(ctx, audit.Filter{GameID: gameID, Actor: actor}, audit.Entry{}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []audit.Entry{third, first}, entries)
+
+	entries, err = conn.ListAuditEntries(ctx, audit.Filter{GameID: gameID, ResourceType: "quests"}, audit.Entry{}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []audit.Entry{second}, entries)
+
+	entries, err = conn.ListAuditEntries(ctx, audit.Filter{GameID: gameID, From: second.CreatedAt, To: third.CreatedAt}, audit.Entry{}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []audit.Entry{second}, entries)
+}
+
+func TestRecordAuditEntry(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		method = newRequestBuffer()
+		buf    = newRequestBuffer()
+	)
+
+	playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+	for _, playerID := range playerIDs {
+		_, err := conn.RecordAuditEntry(ctx, audit.NewEntryData{
+			GameID:      gameID,
+			Method:      method.param(http.MethodPut),
+			Route:       "/api/v1/players/:playerId/presence",
+			ResourceIDs: map[string]string{"playerId": buf.param(playerID)},
+			StatusCode:  http.StatusOK,
+			RequestedAt: time.Now(),
+		})
+		assert.NoError(t, err)
+	}
+
+	method.param(http.MethodGet)
+	buf.param("dave-3333333")
+
+	entries, err := conn.ListAuditEntries(ctx, audit.Filter{GameID: gameID}, audit.Entry{}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	recorded := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		assert.Equal(t, http.MethodPut, entry.Method)
+		recorded = append(recorded, entry.ResourceIDs["playerId"])
+	}
+
+	assert.ElementsMatch(t, playerIDs, recorded)
+}
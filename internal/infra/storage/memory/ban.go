@@ -0,0 +1,209 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+)
+
+func (c *connection) banIndex(gameID, playerID string) int {
+	return slices.IndexFunc(c.bans, func(b ban.Ban) bool {
+		return b.GameID == gameID && b.PlayerID == playerID
+	})
+}
+
+// The player ID may come from a request parameter, so it's copied to outlive the request
+func (c *connection) CreateBan(ctx context.Context, b ban.Ban) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.banIndex(b.GameID, b.PlayerID) >= 0 {
+		return ban.ErrPlayerAlreadyBanned
+	}
+
+	b.GameID = strings.Clone(b.GameID)
+	b.PlayerID = strings.Clone(b.PlayerID)
+	b.HiddenRanks = slices.Clone(b.HiddenRanks)
+	c.bans = append(c.bans, b)
+	return nil
+}
+
+func (c *connection) GetBan(ctx context.Context, gameID, playerID string) (ban.Ban, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := c.banIndex(gameID, playerID)
+	if i < 0 {
+		return ban.Ban{}, ban.ErrBanNotFound
+	}
+
+	return c.bans[i], nil
+}
+
+func (c *connection) SetBanHiddenRanks(ctx context.Context, gameID, playerID string, ranks []ban.HiddenRank) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.banIndex(gameID, playerID)
+	if i < 0 {
+		return ban.ErrBanNotFound
+	}
+
+	c.bans[i].HiddenRanks = slices.Clone(ranks)
+	return nil
+}
+
+func (c *connection) DeleteBan(ctx context.Context, gameID, playerID string) (ban.Ban, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.banIndex(gameID, playerID)
+	if i < 0 {
+		return ban.Ban{}, ban.ErrBanNotFound
+	}
+
+	b := c.bans[i]
+	c.bans = slices.Delete(c.bans, i, i+1)
+	return b, nil
+}
+
+// Bans are appended as they are created, so they are kept ordered by creation time
+func (c *connection) ListBans(ctx context.Context, gameID string) ([]ban.Ban, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	bans := make([]ban.Ban, 0)
+	for _, b := range c.bans {
+		if b.GameID == gameID {
+			bans = append(bans, b)
+		}
+	}
+
+	return bans, nil
+}
+
+func (c *connection) ListExpiredBans(ctx context.Context, now time.Time, limit int) ([]ban.Ban, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	expired := make([]ban.Ban, 0)
+	for _, b := range c.bans {
+		if b.Expired(now) {
+			expired = append(expired, b)
+		}
+	}
+
+	slices.SortStableFunc(expired, func(a, b ban.Ban) int { return a.ExpiresAt.Compare(b.ExpiresAt) })
+	return expired[:min(limit, len(expired))], nil
+}
+
+// Erases the player's ban alongside the values of the ranks it hides
+func (c *connection) ErasePlayerBan(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataBans}
+	if i := c.banIndex(gameID, playerID); i >= 0 {
+		c.bans = slices.Delete(c.bans, i, i+1)
+		erasure.Records++
+	}
+
+	return erasure, nil
+}
+
+// Moves the player's ban to the pseudonym, so its ranks are put back under the pseudonym once it's lifted
+func (c *connection) AnonymizePlayerBan(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataBans}
+	if i := c.banIndex(gameID, playerID); i >= 0 {
+		c.bans[i].PlayerID = pseudonym
+		anonymization.Records++
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's ban
+func (c *connection) CountPlayerBan(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataBans}
+	if c.banIndex(gameID, playerID) >= 0 {
+		count.Records++
+	}
+
+	return count, nil
+}
+
+// Index of the device's ban. The expired ones are left out, as if they were removed
+func (c *connection) deviceBanIndex(gameID, deviceHash string, now time.Time) int {
+	return slices.IndexFunc(c.deviceBans, func(b ban.DeviceBan) bool {
+		return b.GameID == gameID && b.DeviceHash == deviceHash && (b.ExpiresAt.IsZero() || now.Before(b.ExpiresAt))
+	})
+}
+
+func (c *connection) CreateDeviceBan(ctx context.Context, b ban.DeviceBan) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.deviceBanIndex(b.GameID, b.DeviceHash, now) >= 0 {
+		return ban.ErrDeviceAlreadyBanned
+	}
+
+	c.deviceBans = slices.DeleteFunc(c.deviceBans, func(expired ban.DeviceBan) bool {
+		return expired.GameID == b.GameID && expired.DeviceHash == b.DeviceHash
+	})
+
+	b.DeviceHash = strings.Clone(b.DeviceHash)
+	c.deviceBans = append(c.deviceBans, b)
+	return nil
+}
+
+func (c *connection) GetDeviceBan(ctx context.Context, gameID, deviceHash string) (ban.DeviceBan, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := c.deviceBanIndex(gameID, deviceHash, time.Now())
+	if i < 0 {
+		return ban.DeviceBan{}, ban.ErrDeviceBanNotFound
+	}
+
+	return c.deviceBans[i], nil
+}
+
+func (c *connection) DeleteDeviceBan(ctx context.Context, gameID, deviceHash string) (ban.DeviceBan, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.deviceBanIndex(gameID, deviceHash, time.Now())
+	if i < 0 {
+		return ban.DeviceBan{}, ban.ErrDeviceBanNotFound
+	}
+
+	b := c.deviceBans[i]
+	c.deviceBans = slices.Delete(c.deviceBans, i, i+1)
+	return b, nil
+}
+
+func (c *connection) ListDeviceBans(ctx context.Context, gameID string) ([]ban.DeviceBan, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := time.Now()
+	bans := make([]ban.DeviceBan, 0)
+	for _, b := range c.deviceBans {
+		if b.GameID == gameID && (b.ExpiresAt.IsZero() || now.Before(b.ExpiresAt)) {
+			bans = append(bans, b)
+		}
+	}
+
+	return bans, nil
+}
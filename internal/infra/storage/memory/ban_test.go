@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBan(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		conn     = New()
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+		now      = time.Now().UTC()
+	)
+
+	permanent := ban.Ban{CreatedAt: now, GameID: gameID, PlayerID: playerID, Reason: "speed hack"}
+	suspension := ban.Ban{CreatedAt: now, ExpiresAt: now.Add(time.Hour), GameID: gameID, PlayerID: uuid.NewString()}
+
+	t.Run("Create", func(t *testing.T) {
+		assert.NoError(t, conn.CreateBan(ctx, permanent))
+		assert.NoError(t, conn.CreateBan(ctx, suspension))
+		assert.ErrorIs(t, conn.CreateBan(ctx, permanent), ban.ErrPlayerAlreadyBanned)
+	})
+
+	t.Run("Hidden Ranks", func(t *testing.T) {
+		hidden := []ban.HiddenRank{{LeaderboardID: uuid.NewString(), Value: 42}}
+		assert.NoError(t, conn.SetBanHiddenRanks(ctx, gameID, playerID, hidden))
+		assert.ErrorIs(t, conn.SetBanHiddenRanks(ctx, gameID, uuid.NewString(), hidden), ban.ErrBanNotFound)
+
+		b, err := conn.GetBan(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, hidden, b.HiddenRanks)
+		permanent.HiddenRanks = hidden
+	})
+
+	t.Run("List", func(t *testing.T) {
+		bans, err := conn.ListBans(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, []ban.Ban{permanent, suspension}, bans)
+
+		expired, err := conn.ListExpiredBans(ctx, now.Add(2*time.Hour), 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []ban.Ban{suspension}, expired)
+
+		expired, err = conn.ListExpiredBans(ctx, now, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, expired)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		b, err := conn.DeleteBan(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, permanent, b)
+
+		_, err = conn.DeleteBan(ctx, gameID, playerID)
+		assert.ErrorIs(t, err, ban.ErrBanNotFound)
+
+		_, err = conn.GetBan(ctx, gameID, playerID)
+		assert.ErrorIs(t, err, ban.ErrBanNotFound)
+	})
+
+	t.Run("Privacy", func(t *testing.T) {
+		count, err := conn.CountPlayerBan(ctx, gameID, suspension.PlayerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count.Records)
+
+		anonymization, err := conn.AnonymizePlayerBan(ctx, gameID, suspension.PlayerID, "pseudonym")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), anonymization.Records)
+
+		erasure, err := conn.ErasePlayerBan(ctx, gameID, "pseudonym")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), erasure.Records)
+
+		bans, err := conn.ListBans(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Empty(t, bans)
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		buf := newRequestBuffer()
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range playerIDs {
+			assert.NoError(t, conn.CreateBan(ctx, ban.Ban{CreatedAt: now, GameID: gameID, PlayerID: buf.param(playerID)}))
+		}
+
+		buf.param("dave-3333333")
+
+		for _, playerID := range playerIDs {
+			b, err := conn.GetBan(ctx, gameID, playerID)
+			assert.NoError(t, err)
+			assert.Equal(t, playerID, b.PlayerID)
+		}
+	})
+}
+
+func TestDeviceBan(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		now    = time.Now().UTC()
+	)
+
+	deviceBan := ban.DeviceBan{CreatedAt: now, GameID: gameID, DeviceHash: "f00d", Reason: "alt farm"}
+
+	t.Run("Create", func(t *testing.T) {
+		assert.NoError(t, conn.CreateDeviceBan(ctx, deviceBan))
+		assert.ErrorIs(t, conn.CreateDeviceBan(ctx, deviceBan), ban.ErrDeviceAlreadyBanned)
+
+		b, err := conn.GetDeviceBan(ctx, gameID, "f00d")
+		assert.NoError(t, err)
+		assert.Equal(t, deviceBan, b)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		expired := ban.DeviceBan{CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(-time.Minute), GameID: gameID, DeviceHash: "beef"}
+		assert.NoError(t, conn.CreateDeviceBan(ctx, expired))
+
+		_, err := conn.GetDeviceBan(ctx, gameID, "beef")
+		assert.ErrorIs(t, err, ban.ErrDeviceBanNotFound)
+
+		// An expired ban doesn't keep the device from being banned again
+		assert.NoError(t, conn.CreateDeviceBan(ctx, expired))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		bans, err := conn.ListDeviceBans(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, []ban.DeviceBan{deviceBan}, bans)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		b, err := conn.DeleteDeviceBan(ctx, gameID, "f00d")
+		assert.NoError(t, err)
+		assert.Equal(t, deviceBan, b)
+
+		_, err = conn.DeleteDeviceBan(ctx, gameID, "f00d")
+		assert.ErrorIs(t, err, ban.ErrDeviceBanNotFound)
+	})
+}
+
+func TestDeletePlayerRank(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		conn = New()
+	)
+
+	lb, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: uuid.NewString(), Name: "Season", AggregationMode: leaderboard.AggregationModeMax, Ordering: leaderboard.OrderingDesc})
+	assert.NoError(t, err)
+
+	assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 10))
+	assert.NoError(t, conn.DeletePlayerRank(ctx, lb, "alice"))
+	assert.NoError(t, conn.DeletePlayerRank(ctx, lb, "alice"))
+
+	_, err = conn.GetPlayerRank(ctx, lb, "alice")
+	assert.ErrorIs(t, err, leaderboard.ErrPlayerRankNotFound)
+}
@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/activity"
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/delta"
+	"github.com/gabapcia/gameblitz/internal/friend"
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/level"
+	"github.com/gabapcia/gameblitz/internal/moderation"
+	"github.com/gabapcia/gameblitz/internal/proof"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/quota"
+	"github.com/gabapcia/gameblitz/internal/reward"
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+	"github.com/gabapcia/gameblitz/internal/segment"
+	"github.com/gabapcia/gameblitz/internal/shadow"
+	"github.com/gabapcia/gameblitz/internal/signing"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/title"
+	"github.com/gabapcia/gameblitz/internal/trust"
+	"github.com/gabapcia/gameblitz/internal/usage"
+	"github.com/gabapcia/gameblitz/internal/webhook"
+)
+
+type connection struct {
+	mu     sync.RWMutex
+	cipher *encryption.Cipher
+
+	leaderboards     map[string]leaderboard.Leaderboard
+	rankings         map[string]map[string]rank
+	cachedTops       map[string]cachedTop
+	cacheGenerations map[string]int64
+	topViews         map[string]topView
+	topViewVersions  map[string]int64
+	scores           []leaderboard.Score
+
+	statistics             map[string]statistic.Statistic
+	playersStatistics      map[playerStatisticKey]statistic.PlayerProgression
+	writeBehindCheckpoints map[string]int64
+
+	quests       map[string]quest.Quest
+	playerQuests map[playerQuestKey]quest.PlayerQuestProgression
+
+	auditEntries []auditEntry
+
+	deadLetters     []ingestion.DeadLetter
+	claimedMessages map[string]time.Time // Expiration of the claim, by message ID
+
+	webhooks   []webhook.Webhook
+	deliveries []webhook.Delivery
+
+	dailyUsage map[dailyUsageKey]usage.DailyUsage
+
+	schedulerLocks map[string]scheduler.Lock
+
+	quotas map[string]quota.Quota
+
+	profiles       map[profileKey]storedProfile
+	linkedAccounts []linkedAccount
+
+	friendships []friend.Friendship
+
+	heartbeats map[presenceKey]heartbeat
+
+	nonces map[nonceKey]signing.Nonce
+
+	submissions       map[submissionKey]int64
+	submissionsWindow time.Time
+
+	bans       []ban.Ban
+	deviceBans []ban.DeviceBan
+
+	shadowFlags []shadow.Flag
+
+	achievements       []achievement.Achievement
+	achievementUnlocks []achievement.Unlock
+
+	titles      []title.Title
+	titleGrants []title.Grant
+
+	segments      []segment.Segment
+	eligibilities []segment.Eligibility
+
+	levelCurves []level.Curve
+
+	deltaRules        []delta.Rule
+	deltaImprovements []delta.Improvement
+
+	proofRules []proof.Rule
+
+	heldSubmissions []quarantine.Submission
+
+	trustRecords []trust.Record
+
+	anomalies []anomaly.Anomaly
+
+	activities []activity.Activity
+
+	rewardRules  []reward.Rule
+	rewardGrants []reward.Grant
+
+	moderationActions []moderation.Action
+}
+
+func (c *connection) Close() {}
+
+// Creates a storage that keeps all the data in the process memory.
+// Nothing is persisted, so it should only be used for local development and tests
+func New() *connection {
+	return &connection{
+		leaderboards:           make(map[string]leaderboard.Leaderboard),
+		rankings:               make(map[string]map[string]rank),
+		cachedTops:             make(map[string]cachedTop),
+		cacheGenerations:       make(map[string]int64),
+		topViews:               make(map[string]topView),
+		topViewVersions:        make(map[string]int64),
+		scores:                 make([]leaderboard.Score, 0),
+		statistics:             make(map[string]statistic.Statistic),
+		playersStatistics:      make(map[playerStatisticKey]statistic.PlayerProgression),
+		writeBehindCheckpoints: make(map[string]int64),
+		quests:                 make(map[string]quest.Quest),
+		playerQuests:           make(map[playerQuestKey]quest.PlayerQuestProgression),
+		auditEntries:           make([]auditEntry, 0),
+		deadLetters:            make([]ingestion.DeadLetter, 0),
+		claimedMessages:        make(map[string]time.Time),
+		webhooks:               make([]webhook.Webhook, 0),
+		deliveries:             make([]webhook.Delivery, 0),
+		dailyUsage:             make(map[dailyUsageKey]usage.DailyUsage),
+		schedulerLocks:         make(map[string]scheduler.Lock),
+		quotas:                 make(map[string]quota.Quota),
+		profiles:               make(map[profileKey]storedProfile),
+		linkedAccounts:         make([]linkedAccount, 0),
+		friendships:            make([]friend.Friendship, 0),
+		heartbeats:             make(map[presenceKey]heartbeat),
+		nonces:                 make(map[nonceKey]signing.Nonce),
+		submissions:            make(map[submissionKey]int64),
+		bans:                   make([]ban.Ban, 0),
+		deviceBans:             make([]ban.DeviceBan, 0),
+		shadowFlags:            make([]shadow.Flag, 0),
+		achievements:           make([]achievement.Achievement, 0),
+		achievementUnlocks:     make([]achievement.Unlock, 0),
+		titles:                 make([]title.Title, 0),
+		titleGrants:            make([]title.Grant, 0),
+		segments:               make([]segment.Segment, 0),
+		eligibilities:          make([]segment.Eligibility, 0),
+		levelCurves:            make([]level.Curve, 0),
+		deltaRules:             make([]delta.Rule, 0),
+		deltaImprovements:      make([]delta.Improvement, 0),
+		proofRules:             make([]proof.Rule, 0),
+		heldSubmissions:        make([]quarantine.Submission, 0),
+		trustRecords:           make([]trust.Record, 0),
+		anomalies:              make([]anomaly.Anomaly, 0),
+		activities:             make([]activity.Activity, 0),
+		rewardRules:            make([]reward.Rule, 0),
+		rewardGrants:           make([]reward.Grant, 0),
+		moderationActions:      make([]moderation.Action, 0),
+	}
+}
+
+// Encrypts the personal data stored from then on, e.g. the profile display names, the linked account IDs and the audit
+// entries actor, as the other storages do
+func (c *connection) SetCipher(cipher *encryption.Cipher) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cipher = cipher
+}
+
+// Checks if an entity was soft deleted before the given time and belongs to the game purged, any game when it's empty
+func softDeletedBefore(deletedAt time.Time, entityGameID, gameID string, deletedBefore time.Time) bool {
+	return !deletedAt.IsZero() && deletedAt.Before(deletedBefore) && (gameID == "" || entityGameID == gameID)
+}
@@ -0,0 +1,18 @@
+package memory
+
+import "unsafe"
+
+// Buffer shared by the route params of every request, as Fiber's, so each param written overwrites the previous ones.
+// The storages must copy the params they keep
+type requestBuffer []byte
+
+func newRequestBuffer() *requestBuffer {
+	b := make(requestBuffer, 0, 128)
+	return &b
+}
+
+// Param of the next request, sharing the buffer's bytes
+func (b *requestBuffer) param(value string) string {
+	*b = append((*b)[:0], value...)
+	return unsafe.String(unsafe.SliceData(*b), len(*b))
+}
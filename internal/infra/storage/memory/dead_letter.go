@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+)
+
+// Dead letters are appended as they fail, so they are kept ordered by failure time
+func (c *connection) CreateDeadLetter(ctx context.Context, deadLetter ingestion.DeadLetter) (ingestion.DeadLetter, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deadLetter.ID = uuid.NewString()
+	deadLetter.FailedAt = deadLetter.FailedAt.UTC()
+	deadLetter.Message.Payload = slices.Clone(deadLetter.Message.Payload)
+	c.deadLetters = append(c.deadLetters, deadLetter)
+
+	return deadLetter, nil
+}
+
+func (c *connection) ListDeadLetters(ctx context.Context, limit int) ([]ingestion.DeadLetter, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.deadLetters[:min(limit, len(c.deadLetters))]), nil
+}
+
+func (c *connection) deadLetterIndex(id string) (int, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return 0, ingestion.ErrInvalidDeadLetterID
+	}
+
+	i := slices.IndexFunc(c.deadLetters, func(d ingestion.DeadLetter) bool { return d.ID == id })
+	if i < 0 {
+		return 0, ingestion.ErrDeadLetterNotFound
+	}
+
+	return i, nil
+}
+
+func (c *connection) GetDeadLetter(ctx context.Context, id string) (ingestion.DeadLetter, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i, err := c.deadLetterIndex(id)
+	if err != nil {
+		return ingestion.DeadLetter{}, err
+	}
+
+	return c.deadLetters[i], nil
+}
+
+func (c *connection) DeleteDeadLetter(ctx context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.deadLetterIndex(id)
+	if err != nil {
+		return err
+	}
+
+	c.deadLetters = slices.Delete(c.deadLetters, i, i+1)
+	return nil
+}
+
+// Erases the dead letters of the game submitted by the player. Dead letters whose payload can't be decoded
+// can't be attributed to any player, so they are kept
+func (c *connection) ErasePlayerDeadLetters(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataDeadLetters}
+	c.deadLetters = slices.DeleteFunc(c.deadLetters, func(d ingestion.DeadLetter) bool {
+		if d.Message.GameID != gameID {
+			return false
+		}
+
+		id, err := d.Message.PlayerID()
+		if err != nil || id != playerID {
+			return false
+		}
+
+		erasure.Records++
+		return true
+	})
+
+	return erasure, nil
+}
+
+// Replaces the player ID on the payload of the game's dead letters submitted by the player, so they can still be
+// requeued. Dead letters whose payload can't be decoded can't be attributed to any player, so they are kept as is
+func (c *connection) AnonymizePlayerDeadLetters(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataDeadLetters}
+	for i, d := range c.deadLetters {
+		if d.Message.GameID != gameID {
+			continue
+		}
+
+		if id, err := d.Message.PlayerID(); err != nil || id != playerID {
+			continue
+		}
+
+		msg, err := d.Message.WithPlayerID(pseudonym)
+		if err != nil {
+			return anonymization, err
+		}
+
+		c.deadLetters[i].Message = msg
+		anonymization.Records++
+	}
+
+	return anonymization, nil
+}
+
+// Counts the dead letters of the game submitted by the player. Dead letters whose payload can't be decoded aren't counted,
+// as they are never erased nor anonymized
+func (c *connection) CountPlayerDeadLetters(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataDeadLetters}
+	for _, d := range c.deadLetters {
+		if d.Message.GameID != gameID {
+			continue
+		}
+
+		if id, err := d.Message.PlayerID(); err == nil && id == playerID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
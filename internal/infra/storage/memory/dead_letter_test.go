@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetters(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		conn = New()
+	)
+
+	first, err := conn.CreateDeadLetter(ctx, ingestion.DeadLetter{FailedAt: time.Now(), Message: ingestion.Message{ID: "a"}, Error: "any error"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, first.ID)
+
+	second, err := conn.CreateDeadLetter(ctx, ingestion.DeadLetter{FailedAt: time.Now(), Message: ingestion.Message{ID: "b"}, Error: "any error"})
+	assert.NoError(t, err)
+
+	deadLetters, err := conn.ListDeadLetters(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []ingestion.DeadLetter{first}, deadLetters)
+
+	deadLetter, err := conn.GetDeadLetter(ctx, second.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, second, deadLetter)
+
+	err = conn.DeleteDeadLetter(ctx, first.ID)
+	assert.NoError(t, err)
+
+	_, err = conn.GetDeadLetter(ctx, first.ID)
+	assert.ErrorIs(t, err, ingestion.ErrDeadLetterNotFound)
+
+	_, err = conn.GetDeadLetter(ctx, "invalid")
+	assert.ErrorIs(t, err, ingestion.ErrInvalidDeadLetterID)
+
+	err = conn.DeleteDeadLetter(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, ingestion.ErrDeadLetterNotFound)
+
+	deadLetters, err = conn.ListDeadLetters(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []ingestion.DeadLetter{second}, deadLetters)
+}
+
+func TestErasePlayerDeadLetters(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+	)
+
+	for _, msg := range []ingestion.Message{
+		{ID: "a", GameID: gameID, Payload: []byte(`{"playerId":"erased"}`)},
+		{ID: "b", GameID: gameID, Payload: []byte(`{"playerId":"kept"}`)},
+		{ID: "c", GameID: uuid.NewString(), Payload: []byte(`{"playerId":"erased"}`)},
+		{ID: "d", GameID: gameID, Payload: []byte(`not json`)},
+	} {
+		_, err := conn.CreateDeadLetter(ctx, ingestion.DeadLetter{FailedAt: time.Now(), Message: msg, Error: "any error"})
+		assert.NoError(t, err)
+	}
+
+	count, err := conn.CountPlayerDeadLetters(ctx, gameID, "erased")
+	assert.NoError(t, err)
+	assert.Equal(t, privacy.DataCount{Data: privacy.DataDeadLetters, Records: 1}, count)
+
+	erasure, err := conn.ErasePlayerDeadLetters(ctx, gameID, "erased")
+	assert.NoError(t, err)
+	assert.Equal(t, privacy.Erasure{Data: privacy.DataDeadLetters, Records: count.Records}, erasure)
+
+	deadLetters, err := conn.ListDeadLetters(ctx, 10)
+	assert.NoError(t, err)
+
+	ids := make([]string, 0, len(deadLetters))
+	for _, d := range deadLetters {
+		ids = append(ids, d.Message.ID)
+	}
+	assert.Equal(t, []string{"b", "c", "d"}, ids)
+}
+
+func TestAnonymizePlayerDeadLetters(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		conn      = New()
+		gameID    = uuid.NewString()
+		pseudonym = privacy.NewPseudonym()
+	)
+
+	for _, msg := range []ingestion.Message{
+		{ID: "a", GameID: gameID, Payload: []byte(`{"playerId":"anonymized","value":10}`)},
+		{ID: "b", GameID: gameID, Payload: []byte(`{"playerId":"kept"}`)},
+		{ID: "c", GameID: uuid.NewString(), Payload: []byte(`{"playerId":"anonymized"}`)},
+	} {
+		_, err := conn.CreateDeadLetter(ctx, ingestion.DeadLetter{FailedAt: time.Now(), Message: msg, Error: "any error"})
+		assert.NoError(t, err)
+	}
+
+	anonymization, err := conn.AnonymizePlayerDeadLetters(ctx, gameID, "anonymized", pseudonym)
+	assert.NoError(t, err)
+	assert.Equal(t, privacy.Anonymization{Data: privacy.DataDeadLetters, Records: 1}, anonymization)
+
+	deadLetters, err := conn.ListDeadLetters(ctx, 10)
+	assert.NoError(t, err)
+
+	playerIDs := make([]string, 0, len(deadLetters))
+	for _, d := range deadLetters {
+		id, err := d.Message.PlayerID()
+		assert.NoError(t, err)
+		playerIDs = append(playerIDs, id)
+	}
+	assert.Equal(t, []string{pseudonym, "kept", "anonymized"}, playerIDs)
+	assert.JSONEq(t, `{"playerId":"`+pseudonym+`","value":10}`, string(deadLetters[0].Message.Payload))
+}
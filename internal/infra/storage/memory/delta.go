@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/delta"
+)
+
+func deltaRuleIndex(rules []delta.Rule, gameID, leaderboardID string) int {
+	return slices.IndexFunc(rules, func(r delta.Rule) bool {
+		return r.GameID == gameID && r.LeaderboardID == leaderboardID
+	})
+}
+
+// The leaderboard ID may come from a request parameter, so it's copied to outlive the request
+func (c *connection) SetDeltaRule(ctx context.Context, rule delta.Rule) (delta.Rule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rule.LeaderboardID = strings.Clone(rule.LeaderboardID)
+	if i := deltaRuleIndex(c.deltaRules, rule.GameID, rule.LeaderboardID); i >= 0 {
+		c.deltaRules[i] = rule
+	} else {
+		c.deltaRules = append(c.deltaRules, rule)
+	}
+
+	return rule, nil
+}
+
+func (c *connection) GetDeltaRule(ctx context.Context, gameID, leaderboardID string) (delta.Rule, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := deltaRuleIndex(c.deltaRules, gameID, leaderboardID)
+	if i < 0 {
+		return delta.Rule{}, delta.ErrRuleNotFound
+	}
+
+	return c.deltaRules[i], nil
+}
+
+func (c *connection) DeleteDeltaRule(ctx context.Context, gameID, leaderboardID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := deltaRuleIndex(c.deltaRules, gameID, leaderboardID)
+	if i < 0 {
+		return delta.ErrRuleNotFound
+	}
+
+	c.deltaRules = slices.Delete(c.deltaRules, i, i+1)
+	return nil
+}
+
+// The improvements past the hourly window are dropped on each record, as nothing reads them anymore
+func (c *connection) RecordDeltaImprovement(ctx context.Context, i delta.Improvement) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expired := time.Now().Add(-delta.HourlyWindow)
+	c.deltaImprovements = slices.DeleteFunc(c.deltaImprovements, func(i delta.Improvement) bool {
+		return i.AppliedAt.Before(expired)
+	})
+
+	i.PlayerID = strings.Clone(i.PlayerID)
+	i.LeaderboardID = strings.Clone(i.LeaderboardID)
+	c.deltaImprovements = append(c.deltaImprovements, i)
+	return nil
+}
+
+func (c *connection) SumDeltaImprovements(ctx context.Context, gameID, leaderboardID, playerID string, since time.Time) (float64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var sum float64
+	for _, i := range c.deltaImprovements {
+		if i.GameID == gameID && i.LeaderboardID == leaderboardID && i.PlayerID == playerID && i.AppliedAt.After(since) {
+			sum += i.Delta
+		}
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/delta"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeltaRule(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+	)
+
+	t.Run("Set Rule", func(t *testing.T) {
+		_, err := conn.SetDeltaRule(ctx, delta.Rule{GameID: gameID, LeaderboardID: "leaderboard", MaxSubmissionDelta: 10, Action: delta.ActionReject})
+		assert.NoError(t, err)
+
+		_, err = conn.SetDeltaRule(ctx, delta.Rule{GameID: gameID, LeaderboardID: "leaderboard", MaxHourlyDelta: 20, Action: delta.ActionQuarantine})
+		assert.NoError(t, err)
+
+		rule, err := conn.GetDeltaRule(ctx, gameID, "leaderboard")
+		assert.NoError(t, err)
+		assert.Equal(t, delta.Rule{GameID: gameID, LeaderboardID: "leaderboard", MaxHourlyDelta: 20, Action: delta.ActionQuarantine}, rule)
+	})
+
+	t.Run("Delete Rule", func(t *testing.T) {
+		err := conn.DeleteDeltaRule(ctx, gameID, "leaderboard")
+		assert.NoError(t, err)
+
+		_, err = conn.GetDeltaRule(ctx, gameID, "leaderboard")
+		assert.ErrorIs(t, err, delta.ErrRuleNotFound)
+
+		err = conn.DeleteDeltaRule(ctx, gameID, "leaderboard")
+		assert.ErrorIs(t, err, delta.ErrRuleNotFound)
+	})
+}
+
+func TestDeltaImprovement(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		now    = time.Now()
+	)
+
+	for _, i := range []delta.Improvement{
+		{AppliedAt: now.Add(-2 * time.Hour), GameID: gameID, LeaderboardID: "leaderboard", PlayerID: "alice", Delta: 100},
+		{AppliedAt: now.Add(-time.Minute), GameID: gameID, LeaderboardID: "leaderboard", PlayerID: "alice", Delta: 10},
+		{AppliedAt: now, GameID: gameID, LeaderboardID: "leaderboard", PlayerID: "alice", Delta: 5},
+		{AppliedAt: now, GameID: gameID, LeaderboardID: "other", PlayerID: "alice", Delta: 1},
+		{AppliedAt: now, GameID: gameID, LeaderboardID: "leaderboard", PlayerID: "bob", Delta: 1},
+	} {
+		err := conn.RecordDeltaImprovement(ctx, i)
+		assert.NoError(t, err)
+	}
+
+	sum, err := conn.SumDeltaImprovements(ctx, gameID, "leaderboard", "alice", now.Add(-delta.HourlyWindow))
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, sum)
+	assert.Len(t, conn.deltaImprovements, 4)
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+	"github.com/gabapcia/gameblitz/internal/player"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptedPersonalData(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		now    = time.Now().UTC()
+	)
+
+	cipher, err := encryption.New(bytes.Repeat([]byte("k"), 32))
+	assert.NoError(t, err)
+	conn.SetCipher(cipher)
+
+	t.Run("Audit Entries", func(t *testing.T) {
+		record := func(actor string) audit.Entry {
+			entry, err := conn.RecordAuditEntry(ctx, audit.NewEntryData{GameID: gameID, Actor: actor, Method: http.MethodPost, Route: "/api/v1/quests/"})
+			assert.NoError(t, err)
+			assert.Equal(t, actor, entry.Actor)
+
+			return entry
+		}
+
+		var (
+			first  = record("alice")
+			second = record("bob")
+			third  = record("alice")
+		)
+
+		for _, e := range conn.auditEntries {
+			assert.NotContains(t, e.Actor+e.encryptedActor, "alice")
+			assert.NotContains(t, e.Actor+e.encryptedActor, "bob")
+		}
+
+		entries, err := conn.ListAuditEntries(ctx, audit.Filter{GameID: gameID, Actor: "alice"}, audit.Entry{}, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []audit.Entry{third, first}, entries)
+
+		entries, err = conn.ListAuditEntries(ctx, audit.Filter{GameID: gameID}, audit.Entry{}, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []audit.Entry{third, second, first}, entries)
+	})
+
+	t.Run("Linked Accounts", func(t *testing.T) {
+		steam := player.LinkedAccount{LinkedAt: now, GameID: gameID, PlayerID: "alice", Platform: player.PlatformSteam, ExternalID: "76561197960287930"}
+
+		linked, err := conn.LinkAccount(ctx, steam)
+		assert.NoError(t, err)
+		assert.Equal(t, steam, linked)
+
+		other := steam
+		other.PlayerID = "bob"
+		_, err = conn.LinkAccount(ctx, other)
+		assert.ErrorIs(t, err, player.ErrAccountLinkedToAnotherPlayer)
+
+		for _, a := range conn.linkedAccounts {
+			assert.NotContains(t, a.ExternalID+a.encryptedExternalID, steam.ExternalID)
+		}
+
+		account, err := conn.GetAccount(ctx, gameID, player.PlatformSteam, steam.ExternalID)
+		assert.NoError(t, err)
+		assert.Equal(t, steam, account)
+
+		accounts, err := conn.ListAccounts(ctx, gameID, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, []player.LinkedAccount{steam}, accounts)
+
+		assert.NoError(t, conn.UnlinkAccount(ctx, gameID, "alice", player.PlatformSteam, steam.ExternalID))
+	})
+
+	t.Run("Profiles", func(t *testing.T) {
+		create := func(playerID, displayName string) player.Profile {
+			profile := player.Profile{CreatedAt: now, UpdatedAt: now, GameID: gameID, PlayerID: playerID, DisplayName: displayName, Metadata: map[string]string{}}
+			assert.NoError(t, conn.CreateProfile(ctx, profile))
+
+			return profile
+		}
+
+		var (
+			bob   = create("bob", "Dark Knight")
+			alice = create("alice", "The Dark Lord")
+			_     = create("carol", "Knightfall")
+		)
+
+		for _, p := range conn.profiles {
+			assert.NotContains(t, p.DisplayName, "Dark")
+		}
+
+		profile, err := conn.GetProfile(ctx, gameID, "bob")
+		assert.NoError(t, err)
+		assert.Equal(t, bob, profile)
+
+		profiles, err := conn.SearchProfiles(ctx, gameID, "dark", 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []player.Profile{alice, bob}, profiles)
+
+		profiles, err = conn.SearchProfiles(ctx, gameID, "dark k", 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []player.Profile{bob}, profiles)
+
+		bob.DisplayName = "Night Owl"
+		_, err = conn.UpdateProfile(ctx, bob)
+		assert.NoError(t, err)
+
+		profiles, err = conn.SearchProfiles(ctx, gameID, "dark", 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []player.Profile{alice}, profiles)
+
+		_, err = conn.AnonymizePlayerProfile(ctx, gameID, "alice", "pseudonym")
+		assert.NoError(t, err)
+
+		profiles, err = conn.ListProfiles(ctx, gameID, []string{"pseudonym"})
+		assert.NoError(t, err)
+		assert.Len(t, profiles, 1)
+		assert.Equal(t, "pseudonym", profiles[0].DisplayName)
+	})
+}
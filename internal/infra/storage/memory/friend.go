@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/friend"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+)
+
+func (c *connection) friendshipIndex(gameID, playerID, friendID string) int {
+	return slices.IndexFunc(c.friendships, func(f friend.Friendship) bool {
+		return f.GameID == gameID && f.PlayerID == playerID && f.FriendID == friendID
+	})
+}
+
+// Keeps the friendship once for each player, so both find it by their own ID. The player IDs may come from request
+// parameters, so they're copied to outlive the request
+func (c *connection) AddFriendship(ctx context.Context, f friend.Friendship) (friend.Friendship, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f.GameID = strings.Clone(f.GameID)
+	f.PlayerID = strings.Clone(f.PlayerID)
+	f.FriendID = strings.Clone(f.FriendID)
+	if i := c.friendshipIndex(f.GameID, f.PlayerID, f.FriendID); i >= 0 {
+		return c.friendships[i], nil
+	}
+
+	reverse := friend.Friendship{CreatedAt: f.CreatedAt, GameID: f.GameID, PlayerID: f.FriendID, FriendID: f.PlayerID}
+	if i := c.friendshipIndex(reverse.GameID, reverse.PlayerID, reverse.FriendID); i >= 0 {
+		f.CreatedAt = c.friendships[i].CreatedAt
+	} else {
+		c.friendships = append(c.friendships, reverse)
+	}
+
+	c.friendships = append(c.friendships, f)
+	return f, nil
+}
+
+func (c *connection) RemoveFriendship(ctx context.Context, gameID, playerID, friendID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	c.friendships = slices.DeleteFunc(c.friendships, func(f friend.Friendship) bool {
+		if f.GameID != gameID || !(f.PlayerID == playerID && f.FriendID == friendID || f.PlayerID == friendID && f.FriendID == playerID) {
+			return false
+		}
+
+		removed++
+		return true
+	})
+
+	if removed == 0 {
+		return friend.ErrFriendshipNotFound
+	}
+
+	return nil
+}
+
+// Friendships are appended as they are created, so they are kept ordered by creation time
+func (c *connection) ListFriends(ctx context.Context, gameID, playerID string) ([]friend.Friendship, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	friends := make([]friend.Friendship, 0)
+	for _, f := range c.friendships {
+		if f.GameID == gameID && f.PlayerID == playerID {
+			friends = append(friends, f)
+		}
+	}
+
+	return friends, nil
+}
+
+func playerFriendship(f friend.Friendship, gameID, playerID string) bool {
+	return f.GameID == gameID && (f.PlayerID == playerID || f.FriendID == playerID)
+}
+
+// Erases the player's friendships, as kept for the player and for each of their friends
+func (c *connection) ErasePlayerFriendships(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataFriendships}
+	c.friendships = slices.DeleteFunc(c.friendships, func(f friend.Friendship) bool {
+		if !playerFriendship(f, gameID, playerID) {
+			return false
+		}
+
+		erasure.Records++
+		return true
+	})
+
+	return erasure, nil
+}
+
+// Moves the player's friendships to the pseudonym, on both sides
+func (c *connection) AnonymizePlayerFriendships(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataFriendships}
+	for i, f := range c.friendships {
+		if !playerFriendship(f, gameID, playerID) {
+			continue
+		}
+
+		if f.PlayerID == playerID {
+			c.friendships[i].PlayerID = pseudonym
+		} else {
+			c.friendships[i].FriendID = pseudonym
+		}
+		anonymization.Records++
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's friendships, as kept for the player and for each of their friends
+func (c *connection) CountPlayerFriendships(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataFriendships}
+	for _, f := range c.friendships {
+		if playerFriendship(f, gameID, playerID) {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
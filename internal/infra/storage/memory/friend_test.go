@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/friend"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFriendship(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		now    = time.Now().UTC()
+	)
+
+	aliceBob := friend.Friendship{CreatedAt: now, GameID: gameID, PlayerID: "alice", FriendID: "bob"}
+
+	t.Run("Add", func(t *testing.T) {
+		f, err := conn.AddFriendship(ctx, aliceBob)
+		assert.NoError(t, err)
+		assert.Equal(t, aliceBob, f)
+
+		f, err = conn.AddFriendship(ctx, friend.Friendship{CreatedAt: now.Add(time.Hour), GameID: gameID, PlayerID: "bob", FriendID: "alice"})
+		assert.NoError(t, err)
+		assert.Equal(t, now, f.CreatedAt)
+
+		_, err = conn.AddFriendship(ctx, friend.Friendship{CreatedAt: now, GameID: gameID, PlayerID: "carol", FriendID: "alice"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		friends, err := conn.ListFriends(ctx, gameID, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, []friend.Friendship{aliceBob, {CreatedAt: now, GameID: gameID, PlayerID: "alice", FriendID: "carol"}}, friends)
+
+		friends, err = conn.ListFriends(ctx, gameID, "bob")
+		assert.NoError(t, err)
+		assert.Equal(t, []friend.Friendship{{CreatedAt: now, GameID: gameID, PlayerID: "bob", FriendID: "alice"}}, friends)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		assert.NoError(t, conn.RemoveFriendship(ctx, gameID, "bob", "alice"))
+		assert.ErrorIs(t, conn.RemoveFriendship(ctx, gameID, "alice", "bob"), friend.ErrFriendshipNotFound)
+
+		friends, err := conn.ListFriends(ctx, gameID, "bob")
+		assert.NoError(t, err)
+		assert.Empty(t, friends)
+	})
+
+	t.Run("Privacy", func(t *testing.T) {
+		count, err := conn.CountPlayerFriendships(ctx, gameID, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count.Records)
+
+		anonymization, err := conn.AnonymizePlayerFriendships(ctx, gameID, "alice", "pseudonym")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), anonymization.Records)
+
+		friends, err := conn.ListFriends(ctx, gameID, "carol")
+		assert.NoError(t, err)
+		assert.Equal(t, "pseudonym", friends[0].FriendID)
+
+		erasure, err := conn.ErasePlayerFriendships(ctx, gameID, "pseudonym")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), erasure.Records)
+
+		friends, err = conn.ListFriends(ctx, gameID, "carol")
+		assert.NoError(t, err)
+		assert.Empty(t, friends)
+	})
+
+	t.Run("Player IDs Outlive The Request", func(t *testing.T) {
+		var (
+			playerBuf = newRequestBuffer()
+			friendBuf = newRequestBuffer()
+		)
+
+		for _, f := range [][2]string{{"alice-000000", "bob-11111111"}, {"carol-222222", "dave-3333333"}} {
+			_, err := conn.AddFriendship(ctx, friend.Friendship{GameID: gameID, PlayerID: playerBuf.param(f[0]), FriendID: friendBuf.param(f[1])})
+			assert.NoError(t, err)
+		}
+
+		playerBuf.param("erin-4444444")
+		friendBuf.param("erin-4444444")
+
+		for playerID, friendID := range map[string]string{"alice-000000": "bob-11111111", "bob-11111111": "alice-000000", "carol-222222": "dave-3333333", "dave-3333333": "carol-222222"} {
+			friends, err := conn.ListFriends(ctx, gameID, playerID)
+			assert.NoError(t, err)
+			assert.Len(t, friends, 1)
+			assert.Equal(t, playerID, friends[0].PlayerID)
+			assert.Equal(t, friendID, friends[0].FriendID)
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"context"
+	"time"
+)
+
+// Claims are kept with their expiration time, and an expired claim is replaced by the next one
+func (c *connection) ClaimMessage(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if expiresAt, ok := c.claimedMessages[id]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	c.claimedMessages[id] = now.Add(ttl)
+	return true, nil
+}
+
+func (c *connection) ReleaseMessage(ctx context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.claimedMessages, id)
+	return nil
+}
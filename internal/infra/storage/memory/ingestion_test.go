@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimMessage(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		conn = New()
+		id   = uuid.NewString()
+	)
+
+	claimed, err := conn.ClaimMessage(ctx, id, time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = conn.ClaimMessage(ctx, id, time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	err = conn.ReleaseMessage(ctx, id)
+	assert.NoError(t, err)
+
+	claimed, err = conn.ClaimMessage(ctx, id, -time.Second)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = conn.ClaimMessage(ctx, id, time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+}
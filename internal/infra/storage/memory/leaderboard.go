@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/purge"
+
+	"github.com/google/uuid"
+)
+
+func (c *connection) CreateLeaderboard(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lb := leaderboard.Leaderboard{
+		CreatedAt:       time.Now().UTC(),
+		UpdatedAt:       time.Now().UTC(),
+		ID:              uuid.NewString(),
+		GameID:          data.GameID,
+		Name:            data.Name,
+		Description:     data.Description,
+		StartAt:         data.StartAt,
+		EndAt:           data.EndAt,
+		AggregationMode: data.AggregationMode,
+		Ordering:        data.Ordering,
+	}
+	c.leaderboards[lb.ID] = lb
+
+	return lb, nil
+}
+
+func (c *connection) GetLeaderboardByIDAndGameID(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	lb, ok := c.leaderboards[id]
+	if !ok || !lb.DeletedAt.IsZero() || lb.GameID != gameID {
+		return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+	}
+
+	return lb, nil
+}
+
+func (c *connection) SoftDeleteLeaderboard(ctx context.Context, id, gameID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lb, ok := c.leaderboards[id]
+	if !ok || !lb.DeletedAt.IsZero() || lb.GameID != gameID {
+		return leaderboard.ErrLeaderboardNotFound
+	}
+
+	lb.DeletedAt = time.Now().UTC()
+	c.leaderboards[id] = lb
+
+	return nil
+}
+
+func (c *connection) PurgeSoftDeletedLeaderboards(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records := make([]purge.Record, 0)
+	for id, lb := range c.leaderboards {
+		if !softDeletedBefore(lb.DeletedAt, lb.GameID, gameID, deletedBefore) {
+			continue
+		}
+
+		delete(c.leaderboards, id)
+		delete(c.rankings, id)
+
+		records = append(records, purge.Record{
+			DeletedAt: lb.DeletedAt,
+			Entity:    purge.EntityLeaderboard,
+			ID:        id,
+			GameID:    lb.GameID,
+		})
+	}
+
+	return records, nil
+}
+
+func (c *connection) ListSoftDeletedLeaderboards(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	records := make([]purge.Record, 0)
+	for id, lb := range c.leaderboards {
+		if softDeletedBefore(lb.DeletedAt, lb.GameID, gameID, deletedBefore) {
+			records = append(records, purge.Record{
+				DeletedAt: lb.DeletedAt,
+				Entity:    purge.EntityLeaderboard,
+				ID:        id,
+				GameID:    lb.GameID,
+			})
+		}
+	}
+
+	return records, nil
+}
+
+// Lists the active leaderboards oldest first, like the Postgres driver
+func (c *connection) ListLeaderboards(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	leaderboards := make([]leaderboard.Leaderboard, 0)
+	for _, lb := range c.leaderboards {
+		if lb.GameID == gameID && lb.DeletedAt.IsZero() {
+			leaderboards = append(leaderboards, lb)
+		}
+	}
+
+	slices.SortFunc(leaderboards, func(a, b leaderboard.Leaderboard) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return leaderboards, nil
+}
+
+func (c *connection) ListLeaderboardsScheduledBetween(ctx context.Context, from, to time.Time) ([]leaderboard.Leaderboard, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	within := func(t time.Time) bool { return !t.IsZero() && t.After(from) && !t.After(to) }
+
+	leaderboards := make([]leaderboard.Leaderboard, 0)
+	for _, lb := range c.leaderboards {
+		if lb.DeletedAt.IsZero() && (within(lb.StartAt) || within(lb.EndAt)) {
+			leaderboards = append(leaderboards, lb)
+		}
+	}
+
+	return leaderboards, nil
+}
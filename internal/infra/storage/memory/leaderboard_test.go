@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/purge"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurgeSoftDeletedLeaderboards(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		gameID = uuid.NewString()
+	)
+
+	conn := New()
+
+	active, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID, AggregationMode: leaderboard.AggregationModeMax})
+	assert.NoError(t, err)
+
+	deleted, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID, AggregationMode: leaderboard.AggregationModeMax})
+	assert.NoError(t, err)
+
+	err = conn.UpsertPlayerRankValue(ctx, deleted, "a", 10)
+	assert.NoError(t, err)
+
+	err = conn.SoftDeleteLeaderboard(ctx, deleted.ID, gameID)
+	assert.NoError(t, err)
+
+	t.Run("Within Retention", func(t *testing.T) {
+		records, err := conn.PurgeSoftDeletedLeaderboards(ctx, "", time.Now().Add(-time.Hour))
+		assert.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("Other Game", func(t *testing.T) {
+		records, err := conn.PurgeSoftDeletedLeaderboards(ctx, uuid.NewString(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		records, err := conn.ListSoftDeletedLeaderboards(ctx, gameID, time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, purge.Record{DeletedAt: records[0].DeletedAt, Entity: purge.EntityLeaderboard, ID: deleted.ID, GameID: gameID}, records[0])
+		}
+		assert.Contains(t, conn.leaderboards, deleted.ID)
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		records, err := conn.PurgeSoftDeletedLeaderboards(ctx, gameID, time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Len(t, records, 1)
+		assert.Equal(t, purge.EntityLeaderboard, records[0].Entity)
+		assert.Equal(t, deleted.ID, records[0].ID)
+		assert.Equal(t, gameID, records[0].GameID)
+
+		assert.NotContains(t, conn.leaderboards, deleted.ID)
+		assert.NotContains(t, conn.rankings, deleted.ID)
+		assert.Contains(t, conn.leaderboards, active.ID)
+	})
+}
+
+func TestListLeaderboards(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		gameID = uuid.NewString()
+	)
+
+	conn := New()
+
+	first, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID})
+	assert.NoError(t, err)
+
+	second, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID})
+	assert.NoError(t, err)
+
+	deleted, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID})
+	assert.NoError(t, err)
+
+	err = conn.SoftDeleteLeaderboard(ctx, deleted.ID, gameID)
+	assert.NoError(t, err)
+
+	_, err = conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: uuid.NewString()})
+	assert.NoError(t, err)
+
+	leaderboards, err := conn.ListLeaderboards(ctx, gameID)
+	assert.NoError(t, err)
+	assert.Equal(t, []leaderboard.Leaderboard{first, second}, leaderboards)
+}
+
+func TestListLeaderboardsScheduledBetween(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		gameID = uuid.NewString()
+		now    = time.Now()
+	)
+
+	conn := New()
+
+	starting, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID, StartAt: now})
+	assert.NoError(t, err)
+
+	ending, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID, StartAt: now.Add(-time.Hour), EndAt: now})
+	assert.NoError(t, err)
+
+	_, err = conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID, StartAt: now.Add(time.Hour)})
+	assert.NoError(t, err)
+
+	deleted, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID, StartAt: now})
+	assert.NoError(t, err)
+
+	err = conn.SoftDeleteLeaderboard(ctx, deleted.ID, gameID)
+	assert.NoError(t, err)
+
+	leaderboards, err := conn.ListLeaderboardsScheduledBetween(ctx, now.Add(-time.Minute), now)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []leaderboard.Leaderboard{starting, ending}, leaderboards)
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/level"
+
+	"github.com/google/uuid"
+)
+
+func (c *connection) CreateLevelCurve(ctx context.Context, data level.NewCurveData) (level.Curve, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	curve := level.Curve{
+		CreatedAt:   time.Now().UTC(),
+		ID:          uuid.NewString(),
+		GameID:      data.GameID,
+		Name:        data.Name,
+		StatisticID: data.StatisticID,
+		Kind:        data.Kind,
+		Thresholds:  slices.Clone(data.Thresholds),
+		Base:        data.Base,
+		Growth:      data.Growth,
+		MaxLevel:    data.MaxLevel,
+	}
+	c.levelCurves = append(c.levelCurves, curve)
+
+	return curve, nil
+}
+
+func (c *connection) GetLevelCurve(ctx context.Context, id, gameID string) (level.Curve, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, curve := range c.levelCurves {
+		if curve.ID == id && curve.GameID == gameID {
+			return curve, nil
+		}
+	}
+
+	return level.Curve{}, level.ErrCurveNotFound
+}
+
+// Curves are appended as they are created, so they are kept ordered by creation time
+func (c *connection) ListLevelCurves(ctx context.Context, gameID string) ([]level.Curve, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	curves := make([]level.Curve, 0)
+	for _, curve := range c.levelCurves {
+		if curve.GameID == gameID {
+			curves = append(curves, curve)
+		}
+	}
+
+	return curves, nil
+}
+
+func (c *connection) DeleteLevelCurve(ctx context.Context, id, gameID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.levelCurves, func(curve level.Curve) bool { return curve.ID == id && curve.GameID == gameID })
+	if i < 0 {
+		return level.ErrCurveNotFound
+	}
+
+	c.levelCurves = slices.Delete(c.levelCurves, i, i+1)
+	return nil
+}
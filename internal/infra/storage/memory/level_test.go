@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/level"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelCurve(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+	)
+
+	thresholds := []float64{100, 250}
+	account, err := conn.CreateLevelCurve(ctx, level.NewCurveData{
+		GameID:      gameID,
+		Name:        "Account",
+		StatisticID: uuid.NewString(),
+		Kind:        level.KindTable,
+		Thresholds:  thresholds,
+	})
+	assert.NoError(t, err)
+
+	// The thresholds sent aren't kept by reference
+	thresholds[0] = 50
+
+	t.Run("Get And List", func(t *testing.T) {
+		stored, err := conn.GetLevelCurve(ctx, account.ID, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, account, stored)
+		assert.Equal(t, []float64{100, 250}, stored.Thresholds)
+
+		_, err = conn.GetLevelCurve(ctx, account.ID, uuid.NewString())
+		assert.ErrorIs(t, err, level.ErrCurveNotFound)
+
+		curves, err := conn.ListLevelCurves(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, []level.Curve{account}, curves)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := conn.DeleteLevelCurve(ctx, account.ID, uuid.NewString())
+		assert.ErrorIs(t, err, level.ErrCurveNotFound)
+
+		err = conn.DeleteLevelCurve(ctx, account.ID, gameID)
+		assert.NoError(t, err)
+
+		curves, err := conn.ListLevelCurves(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Empty(t, curves)
+	})
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/moderation"
+)
+
+func cloneModerationAction(action moderation.Action) moderation.Action {
+	action.Entries = slices.Clone(action.Entries)
+	action.Recomputed = slices.Clone(action.Recomputed)
+	return action
+}
+
+func (c *connection) CreateModerationAction(ctx context.Context, action moderation.Action) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	action.GameID = strings.Clone(action.GameID)
+	action.LeaderboardID = strings.Clone(action.LeaderboardID)
+	action.PlayerID = strings.Clone(action.PlayerID)
+	action.Reason = strings.Clone(action.Reason)
+	c.moderationActions = append(c.moderationActions, cloneModerationAction(action))
+	return nil
+}
+
+func (c *connection) ListEntryModerationActions(ctx context.Context, gameID, leaderboardID, playerID string) ([]moderation.Action, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	actions := make([]moderation.Action, 0)
+	for _, action := range c.moderationActions {
+		if action.GameID != gameID {
+			continue
+		}
+
+		removed := slices.ContainsFunc(action.Entries, func(e moderation.RemovedEntry) bool {
+			return e.LeaderboardID == leaderboardID && e.PlayerID == playerID
+		})
+		recomputed := action.LeaderboardID == leaderboardID && slices.ContainsFunc(action.Recomputed, func(e moderation.RecomputedEntry) bool {
+			return e.PlayerID == playerID
+		})
+		if removed || recomputed || (action.LeaderboardID == leaderboardID && action.PlayerID == playerID) {
+			actions = append(actions, cloneModerationAction(action))
+		}
+	}
+
+	return actions, nil
+}
+
+func (c *connection) ListModerationActions(ctx context.Context, gameID string) ([]moderation.Action, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	actions := make([]moderation.Action, 0)
+	for _, action := range c.moderationActions {
+		if action.GameID == gameID {
+			actions = append(actions, cloneModerationAction(action))
+		}
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/moderation"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModerationAction(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		now    = time.Now().UTC()
+		value  = 10.0
+	)
+
+	actions := []moderation.Action{
+		{ID: "1", CreatedAt: now, GameID: gameID, Kind: moderation.ActionAdjust, Reason: "exploit", LeaderboardID: "season", PlayerID: "alice", Previous: &value, Value: &value},
+		{ID: "2", CreatedAt: now, GameID: gameID, Kind: moderation.ActionRemove, Reason: "cheating", LeaderboardID: "season", PlayerID: "bob", Previous: &value},
+		{ID: "3", CreatedAt: now, GameID: gameID, Kind: moderation.ActionBulkRemove, Reason: "compromised broker", Source: "MQTT", From: now, To: now, Entries: []moderation.RemovedEntry{{LeaderboardID: "season", PlayerID: "alice", Value: 10}}},
+		{ID: "5", CreatedAt: now, GameID: gameID, Kind: moderation.ActionRollback, Reason: "exploit", LeaderboardID: "season", From: now, To: now, Scores: 2, Recomputed: []moderation.RecomputedEntry{{PlayerID: "alice", Previous: &value}}},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, action := range actions {
+			assert.NoError(t, conn.CreateModerationAction(ctx, action))
+		}
+
+		assert.NoError(t, conn.CreateModerationAction(ctx, moderation.Action{ID: "4", GameID: uuid.NewString(), LeaderboardID: "season", PlayerID: "alice"}))
+	})
+
+	t.Run("List Entry", func(t *testing.T) {
+		list, err := conn.ListEntryModerationActions(ctx, gameID, "season", "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, []moderation.Action{actions[0], actions[2], actions[3]}, list)
+
+		list, err = conn.ListEntryModerationActions(ctx, gameID, "weekly", "alice")
+		assert.NoError(t, err)
+		assert.Empty(t, list)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		list, err := conn.ListModerationActions(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, actions, list)
+	})
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/signing"
+)
+
+type nonceKey struct {
+	gameID string
+	value  string
+}
+
+func (c *connection) CreateNonce(ctx context.Context, nonce signing.Nonce, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Expired nonces are dropped on each issue, as nothing else reads them
+	now := time.Now()
+	for key, n := range c.nonces {
+		if !now.Before(n.ExpiresAt) {
+			delete(c.nonces, key)
+		}
+	}
+
+	nonce.GameID = strings.Clone(nonce.GameID)
+	nonce.PlayerID = strings.Clone(nonce.PlayerID)
+	nonce.ExpiresAt = now.Add(ttl)
+	c.nonces[nonceKey{gameID: nonce.GameID, value: nonce.Value}] = nonce
+	return nil
+}
+
+func (c *connection) ConsumeNonce(ctx context.Context, gameID, playerID, nonce string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := nonceKey{gameID: gameID, value: nonce}
+	n, ok := c.nonces[key]
+	if !ok || n.PlayerID != playerID || !time.Now().Before(n.ExpiresAt) {
+		return signing.ErrNonceNotFound
+	}
+
+	delete(c.nonces, key)
+	return nil
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/signing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonce(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+	)
+
+	t.Run("Create Nonce", func(t *testing.T) {
+		err := conn.CreateNonce(ctx, signing.Nonce{Value: "n1", GameID: gameID, PlayerID: "alice"}, time.Minute)
+		assert.NoError(t, err)
+
+		err = conn.CreateNonce(ctx, signing.Nonce{Value: "n2", GameID: gameID, PlayerID: "alice"}, time.Nanosecond)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Consume Nonce", func(t *testing.T) {
+		time.Sleep(time.Millisecond)
+
+		err := conn.ConsumeNonce(ctx, gameID, "bob", "n1")
+		assert.ErrorIs(t, err, signing.ErrNonceNotFound)
+
+		err = conn.ConsumeNonce(ctx, uuid.NewString(), "alice", "n1")
+		assert.ErrorIs(t, err, signing.ErrNonceNotFound)
+
+		err = conn.ConsumeNonce(ctx, gameID, "alice", "n2")
+		assert.ErrorIs(t, err, signing.ErrNonceNotFound)
+
+		err = conn.ConsumeNonce(ctx, gameID, "alice", "n1")
+		assert.NoError(t, err)
+
+		err = conn.ConsumeNonce(ctx, gameID, "alice", "n1")
+		assert.ErrorIs(t, err, signing.ErrNonceNotFound)
+	})
+}
@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/quest"
+)
+
+type playerQuestKey struct {
+	QuestID  string
+	PlayerID string
+}
+
+// Starts every task of the quest that is not started yet and has all its dependencies completed
+func startPlayerTasksReadyToStart(progression *quest.PlayerQuestProgression, now time.Time) {
+	completed := make(map[string]bool)
+	started := make(map[string]bool)
+	for _, taskProgression := range progression.TasksProgression {
+		started[taskProgression.Task.ID] = true
+		if !taskProgression.CompletedAt.IsZero() {
+			completed[taskProgression.Task.ID] = true
+		}
+	}
+
+	for _, task := range progression.Quest.Tasks {
+		if started[task.ID] {
+			continue
+		}
+
+		ready := true
+		for _, dependsOn := range task.DependsOn {
+			if !completed[dependsOn] {
+				ready = false
+				break
+			}
+		}
+
+		if ready {
+			progression.TasksProgression = append(progression.TasksProgression, quest.PlayerTaskProgression{
+				StartedAt: now,
+				UpdatedAt: now,
+				Task:      task,
+			})
+		}
+	}
+}
+
+// The player ID may come from a request parameter, so it's copied to outlive the request
+func (c *connection) StartQuestForPlayer(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	playerID = strings.Clone(playerID)
+
+	if stored, ok := c.quests[q.ID]; !ok || !stored.DeletedAt.IsZero() {
+		return quest.PlayerQuestProgression{}, quest.ErrQuestNotFound
+	}
+
+	key := playerQuestKey{QuestID: q.ID, PlayerID: playerID}
+	if _, ok := c.playerQuests[key]; ok {
+		return quest.PlayerQuestProgression{}, quest.ErrPlayerAlreadyStartedTheQuest
+	}
+
+	now := time.Now().UTC()
+
+	progression := quest.PlayerQuestProgression{
+		StartedAt:        now,
+		UpdatedAt:        now,
+		PlayerID:         playerID,
+		Quest:            q,
+		TasksProgression: make([]quest.PlayerTaskProgression, 0),
+	}
+	startPlayerTasksReadyToStart(&progression, now)
+
+	c.playerQuests[key] = progression
+
+	return progression, nil
+}
+
+func (c *connection) GetPlayerQuestProgression(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	progression, ok := c.playerQuests[playerQuestKey{QuestID: q.ID, PlayerID: playerID}]
+	if !ok {
+		return quest.PlayerQuestProgression{}, quest.ErrPlayerNotStartedTheQuest
+	}
+
+	progression.Quest = q
+	progression.TasksProgression = slices.Clone(progression.TasksProgression)
+
+	return progression, nil
+}
+
+func (c *connection) ListPlayersWithQuestUnfinished(ctx context.Context, q quest.Quest) ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	playerIDs := make([]string, 0)
+	for key, progression := range c.playerQuests {
+		if key.QuestID == q.ID && progression.CompletedAt.IsZero() {
+			playerIDs = append(playerIDs, key.PlayerID)
+		}
+	}
+
+	slices.Sort(playerIDs)
+	return playerIDs, nil
+}
+
+// The player ID may come from a request parameter, so it's copied to outlive the request. Even an existing
+// progression's key is replaced by the one assigned
+func (c *connection) UpdatePlayerQuestProgression(ctx context.Context, q quest.Quest, tasksCompleted []string, playerID string) (quest.PlayerQuestProgression, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	playerID = strings.Clone(playerID)
+	key := playerQuestKey{QuestID: q.ID, PlayerID: playerID}
+
+	progression, ok := c.playerQuests[key]
+	if !ok {
+		return quest.PlayerQuestProgression{}, quest.ErrPlayerNotStartedTheQuest
+	}
+
+	now := time.Now().UTC()
+
+	progression.Quest = q
+	progression.UpdatedAt = now
+	progression.TasksProgression = slices.Clone(progression.TasksProgression)
+	for i, taskProgression := range progression.TasksProgression {
+		if taskProgression.CompletedAt.IsZero() && slices.Contains(tasksCompleted, taskProgression.Task.ID) {
+			progression.TasksProgression[i].UpdatedAt = now
+			progression.TasksProgression[i].CompletedAt = now
+		}
+	}
+
+	startPlayerTasksReadyToStart(&progression, now)
+
+	if progression.CompletedAt.IsZero() {
+		questCompleted := true
+		for _, task := range q.Tasks {
+			if !task.RequiredForCompletion {
+				continue
+			}
+
+			idx := slices.IndexFunc(progression.TasksProgression, func(t quest.PlayerTaskProgression) bool { return t.Task.ID == task.ID })
+			if idx < 0 || progression.TasksProgression[idx].CompletedAt.IsZero() {
+				questCompleted = false
+				break
+			}
+		}
+
+		if questCompleted {
+			progression.CompletedAt = now
+		}
+	}
+
+	c.playerQuests[key] = progression
+
+	return progression, nil
+}
+
+// Erases the player's progression on every quest of the game, including the soft deleted ones
+func (c *connection) ErasePlayerQuests(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataQuestProgression}
+	for key := range c.playerQuests {
+		if q, ok := c.quests[key.QuestID]; key.PlayerID != playerID || !ok || q.GameID != gameID {
+			continue
+		}
+
+		delete(c.playerQuests, key)
+		erasure.Records++
+	}
+
+	return erasure, nil
+}
+
+// Moves the player's progression on every quest of the game, including the soft deleted ones, to the pseudonym
+func (c *connection) AnonymizePlayerQuests(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]playerQuestKey, 0)
+	for key := range c.playerQuests {
+		if q, ok := c.quests[key.QuestID]; key.PlayerID == playerID && ok && q.GameID == gameID {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		progression := c.playerQuests[key]
+		progression.PlayerID = pseudonym
+
+		c.playerQuests[playerQuestKey{QuestID: key.QuestID, PlayerID: pseudonym}] = progression
+		delete(c.playerQuests, key)
+	}
+
+	return privacy.Anonymization{Data: privacy.DataQuestProgression, Records: int64(len(keys))}, nil
+}
+
+// Counts the player's progression on every quest of the game, including the soft deleted ones
+func (c *connection) CountPlayerQuests(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataQuestProgression}
+	for key := range c.playerQuests {
+		if q, ok := c.quests[key.QuestID]; key.PlayerID == playerID && ok && q.GameID == gameID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/quest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePlayerQuestProgression(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		conn := New()
+
+		q, err := conn.CreateQuest(ctx, quest.NewQuestData{
+			GameID: uuid.NewString(),
+			Name:   "Quest",
+			Tasks: []quest.NewTaskData{
+				{Name: "First", RequiredForCompletion: true},
+				{Name: "Second", RequiredForCompletion: true, DependsOn: []int{0}},
+			},
+		})
+		assert.NoError(t, err)
+
+		progression, err := conn.StartQuestForPlayer(ctx, q, playerID)
+		assert.NoError(t, err)
+		assert.Len(t, progression.TasksProgression, 1)
+		assert.Equal(t, q.Tasks[0].ID, progression.TasksProgression[0].Task.ID)
+
+		_, err = conn.StartQuestForPlayer(ctx, q, playerID)
+		assert.ErrorIs(t, err, quest.ErrPlayerAlreadyStartedTheQuest)
+
+		progression, err = conn.UpdatePlayerQuestProgression(ctx, q, []string{q.Tasks[0].ID}, playerID)
+		assert.NoError(t, err)
+		assert.Len(t, progression.TasksProgression, 2)
+		assert.True(t, progression.CompletedAt.IsZero())
+
+		progression, err = conn.UpdatePlayerQuestProgression(ctx, q, []string{q.Tasks[1].ID}, playerID)
+		assert.NoError(t, err)
+		assert.False(t, progression.CompletedAt.IsZero())
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		conn := New()
+		buf := newRequestBuffer()
+
+		q, err := conn.CreateQuest(ctx, quest.NewQuestData{GameID: uuid.NewString(), Name: "Quest", Tasks: []quest.NewTaskData{{Name: "Task"}}})
+		assert.NoError(t, err)
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range playerIDs {
+			_, err := conn.StartQuestForPlayer(ctx, q, buf.param(playerID))
+			assert.NoError(t, err)
+		}
+
+		_, err = conn.UpdatePlayerQuestProgression(ctx, q, []string{q.Tasks[0].ID}, buf.param("alice-000000"))
+		assert.NoError(t, err)
+
+		buf.param("dave-3333333")
+
+		for _, playerID := range playerIDs {
+			progression, err := conn.GetPlayerQuestProgression(ctx, q, playerID)
+			assert.NoError(t, err)
+			assert.Equal(t, playerID, progression.PlayerID)
+		}
+	})
+
+	t.Run("Quest Deleted", func(t *testing.T) {
+		conn := New()
+
+		q, err := conn.CreateQuest(ctx, quest.NewQuestData{GameID: "game", Name: "Quest", Tasks: []quest.NewTaskData{{Name: "Task"}}})
+		assert.NoError(t, err)
+
+		err = conn.SoftDeleteQuestByIDAndGameID(ctx, q.ID, q.GameID)
+		assert.NoError(t, err)
+
+		_, err = conn.StartQuestForPlayer(ctx, q, playerID)
+		assert.ErrorIs(t, err, quest.ErrQuestNotFound)
+	})
+
+	t.Run("Player Not Started The Quest", func(t *testing.T) {
+		conn := New()
+
+		_, err := conn.UpdatePlayerQuestProgression(ctx, quest.Quest{ID: uuid.NewString()}, nil, playerID)
+		assert.ErrorIs(t, err, quest.ErrPlayerNotStartedTheQuest)
+	})
+}
+
+func TestListPlayersWithQuestUnfinished(t *testing.T) {
+	ctx := context.Background()
+	conn := New()
+
+	q, err := conn.CreateQuest(ctx, quest.NewQuestData{
+		GameID: uuid.NewString(),
+		Name:   "Quest",
+		Tasks:  []quest.NewTaskData{{Name: "Task", RequiredForCompletion: true}},
+		EndAt:  time.Now().Add(time.Hour),
+	})
+	assert.NoError(t, err)
+
+	for _, playerID := range []string{"bob", "alice", "carol"} {
+		_, err := conn.StartQuestForPlayer(ctx, q, playerID)
+		assert.NoError(t, err)
+	}
+
+	_, err = conn.UpdatePlayerQuestProgression(ctx, q, []string{q.Tasks[0].ID}, "carol")
+	assert.NoError(t, err)
+
+	playerIDs, err := conn.ListPlayersWithQuestUnfinished(ctx, q)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, playerIDs)
+
+	quests, err := conn.ListQuestsEndedBetween(ctx, time.Now(), q.EndAt)
+	assert.NoError(t, err)
+	if assert.Len(t, quests, 1) {
+		assert.Equal(t, q.ID, quests[0].ID)
+	}
+
+	quests, err = conn.ListQuestsEndedBetween(ctx, q.EndAt, q.EndAt.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, quests)
+}
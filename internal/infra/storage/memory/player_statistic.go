@@ -0,0 +1,268 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/writebehind"
+)
+
+type playerStatisticKey struct {
+	StatisticID string
+	PlayerID    string
+}
+
+func newPlayerStatisticProgression(st statistic.Statistic, playerID string) statistic.PlayerProgression {
+	var goalCompleted *bool
+	if st.Goal != nil {
+		completed := false
+		goalCompleted = &completed
+	}
+
+	landmarks := make([]statistic.PlayerProgressionLandmark, len(st.Landmarks))
+	for i, landmark := range st.Landmarks {
+		landmarks[i] = statistic.PlayerProgressionLandmark{Value: landmark}
+	}
+
+	return statistic.PlayerProgression{
+		PlayerID:      playerID,
+		StatisticID:   st.ID,
+		CurrentValue:  st.InitialValue,
+		GoalValue:     st.Goal,
+		GoalCompleted: goalCompleted,
+		Landmarks:     landmarks,
+	}
+}
+
+func copyPlayerStatisticProgression(p statistic.PlayerProgression) statistic.PlayerProgression {
+	if p.CurrentValue != nil {
+		currentValue := *p.CurrentValue
+		p.CurrentValue = &currentValue
+	}
+
+	if p.GoalCompleted != nil {
+		goalCompleted := *p.GoalCompleted
+		p.GoalCompleted = &goalCompleted
+	}
+
+	p.Landmarks = slices.Clone(p.Landmarks)
+
+	return p
+}
+
+// Checks whether the value reached the target on the aggregation mode direction
+func reachedFunc(aggregationMode string) (func(current, target float64) bool, error) {
+	switch aggregationMode {
+	case statistic.AggregationModeSum, statistic.AggregationModeMax:
+		return func(current, target float64) bool { return current >= target }, nil
+	case statistic.AggregationModeSub, statistic.AggregationModeMin:
+		return func(current, target float64) bool { return current <= target }, nil
+	default:
+		return nil, statistic.ErrInvalidAggregationMode
+	}
+}
+
+// Must be called holding the lock. The player ID may come from a request parameter, so it's copied to outlive the request
+func (c *connection) updatePlayerStatisticProgression(st statistic.Statistic, playerID string, value float64, reached func(current, target float64) bool) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates) {
+	var currentValue float64
+
+	playerID = strings.Clone(playerID)
+
+	key := playerStatisticKey{StatisticID: st.ID, PlayerID: playerID}
+
+	progression, ok := c.playersStatistics[key]
+	if !ok {
+		progression = newPlayerStatisticProgression(st, playerID)
+	}
+	progression = copyPlayerStatisticProgression(progression)
+
+	switch st.AggregationMode {
+	case statistic.AggregationModeSum:
+		currentValue = value
+		if progression.CurrentValue != nil {
+			currentValue = *progression.CurrentValue + value
+		}
+	case statistic.AggregationModeSub:
+		currentValue = -value
+		if progression.CurrentValue != nil {
+			currentValue = *progression.CurrentValue - value
+		}
+	case statistic.AggregationModeMax:
+		currentValue = value
+		if progression.CurrentValue != nil {
+			currentValue = max(*progression.CurrentValue, value)
+		}
+	case statistic.AggregationModeMin:
+		currentValue = value
+		if progression.CurrentValue != nil {
+			currentValue = min(*progression.CurrentValue, value)
+		}
+	}
+
+	now := time.Now().UTC()
+	if progression.StartedAt.IsZero() {
+		progression.StartedAt = now
+	}
+	progression.UpdatedAt = now
+	progression.CurrentValue = &currentValue
+
+	updates := statistic.PlayerProgressionUpdates{
+		LandmarksJustCompleted: make([]statistic.PlayerProgressionUpdatesLandmark, 0),
+	}
+
+	for i, landmark := range progression.Landmarks {
+		if landmark.Completed || !reached(currentValue, landmark.Value) {
+			continue
+		}
+
+		progression.Landmarks[i].Completed = true
+		progression.Landmarks[i].CompletedAt = now
+		updates.LandmarksJustCompleted = append(updates.LandmarksJustCompleted, statistic.PlayerProgressionUpdatesLandmark{
+			Value:       landmark.Value,
+			CompletedAt: now,
+		})
+	}
+
+	if progression.GoalCompleted != nil && !*progression.GoalCompleted && reached(currentValue, *progression.GoalValue) {
+		*progression.GoalCompleted = true
+		progression.GoalCompletedAt = now
+		updates.GoalJustCompleted = true
+	}
+	updates.GoalCompletedAt = progression.GoalCompletedAt
+
+	c.playersStatistics[key] = progression
+
+	return copyPlayerStatisticProgression(progression), updates
+}
+
+func (c *connection) UpdatePlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+	reached, err := reachedFunc(st.AggregationMode)
+	if err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	progression, updates := c.updatePlayerStatisticProgression(st, playerID, value, reached)
+	return progression, updates, nil
+}
+
+// Applies every change or none of them, returning the progressions in the same order as the changes, and records the
+// write-behind checkpoint tagged on the context along with them
+func (c *connection) BulkUpdatePlayerStatisticProgression(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]statistic.PlayerProgression, []statistic.PlayerProgressionUpdates, error) {
+	reachedFuncs := make([]func(current, target float64) bool, len(changes))
+	for i, change := range changes {
+		reached, err := reachedFunc(change.Statistic.AggregationMode)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		reachedFuncs[i] = reached
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		progressions = make([]statistic.PlayerProgression, len(changes))
+		updates      = make([]statistic.PlayerProgressionUpdates, len(changes))
+	)
+	for i, change := range changes {
+		progressions[i], updates[i] = c.updatePlayerStatisticProgression(change.Statistic, change.PlayerID, change.Value, reachedFuncs[i])
+	}
+
+	if checkpoint, ok := writebehind.CheckpointFromContext(ctx); ok {
+		c.writeBehindCheckpoints[checkpoint.QueueID] = max(c.writeBehindCheckpoints[checkpoint.QueueID], checkpoint.Sequence)
+	}
+
+	return progressions, updates, nil
+}
+
+func (c *connection) GetWriteBehindCheckpoint(ctx context.Context, queueID string) (int64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.writeBehindCheckpoints[queueID], nil
+}
+
+func (c *connection) GetPlayerProgression(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	progression, ok := c.playersStatistics[playerStatisticKey{StatisticID: statisticID, PlayerID: playerID}]
+	if !ok {
+		return statistic.PlayerProgression{}, statistic.ErrPlayerStatisticNotFound
+	}
+
+	return copyPlayerStatisticProgression(progression), nil
+}
+
+// Removes the player's progression on the statistic. Nothing is removed when the player has none
+func (c *connection) DeletePlayerProgression(ctx context.Context, statisticID, playerID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.playersStatistics, playerStatisticKey{StatisticID: statisticID, PlayerID: playerID})
+	return nil
+}
+
+// Erases the player's progression on every statistic of the game, including the soft deleted ones
+func (c *connection) ErasePlayerStatistics(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataStatisticProgression}
+	for key := range c.playersStatistics {
+		if st, ok := c.statistics[key.StatisticID]; key.PlayerID != playerID || !ok || st.GameID != gameID {
+			continue
+		}
+
+		delete(c.playersStatistics, key)
+		erasure.Records++
+	}
+
+	return erasure, nil
+}
+
+// Moves the player's progression on every statistic of the game, including the soft deleted ones, to the pseudonym
+func (c *connection) AnonymizePlayerStatistics(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]playerStatisticKey, 0)
+	for key := range c.playersStatistics {
+		if st, ok := c.statistics[key.StatisticID]; key.PlayerID == playerID && ok && st.GameID == gameID {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		progression := c.playersStatistics[key]
+		progression.PlayerID = pseudonym
+
+		c.playersStatistics[playerStatisticKey{StatisticID: key.StatisticID, PlayerID: pseudonym}] = progression
+		delete(c.playersStatistics, key)
+	}
+
+	return privacy.Anonymization{Data: privacy.DataStatisticProgression, Records: int64(len(keys))}, nil
+}
+
+// Counts the player's progression on every statistic of the game, including the soft deleted ones
+func (c *connection) CountPlayerStatistics(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataStatisticProgression}
+	for key := range c.playersStatistics {
+		if st, ok := c.statistics[key.StatisticID]; key.PlayerID == playerID && ok && st.GameID == gameID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
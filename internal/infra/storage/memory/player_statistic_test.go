@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/writebehind"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePlayerStatisticProgression(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		goal     = float64(10)
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		conn := New()
+
+		st, err := conn.CreateStatistic(ctx, statistic.NewStatisticData{
+			GameID:          uuid.NewString(),
+			Name:            "Kills",
+			AggregationMode: statistic.AggregationModeSum,
+			Goal:            &goal,
+			Landmarks:       []float64{5, 10},
+		})
+		assert.NoError(t, err)
+
+		progression, updates, err := conn.UpdatePlayerStatisticProgression(ctx, st, playerID, 6)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(6), *progression.CurrentValue)
+		assert.False(t, updates.GoalJustCompleted)
+		assert.Len(t, updates.LandmarksJustCompleted, 1)
+
+		progression, updates, err = conn.UpdatePlayerStatisticProgression(ctx, st, playerID, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(10), *progression.CurrentValue)
+		assert.True(t, *progression.GoalCompleted)
+		assert.True(t, updates.GoalJustCompleted)
+		assert.Equal(t, []statistic.PlayerProgressionUpdatesLandmark{{Value: 10, CompletedAt: progression.GoalCompletedAt}}, updates.LandmarksJustCompleted)
+
+		stored, err := conn.GetPlayerProgression(ctx, st.ID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, progression, stored)
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		conn := New()
+		buf := newRequestBuffer()
+
+		st, err := conn.CreateStatistic(ctx, statistic.NewStatisticData{GameID: uuid.NewString(), Name: "Kills", AggregationMode: statistic.AggregationModeSum})
+		assert.NoError(t, err)
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range append(playerIDs, "alice-000000") {
+			_, _, err := conn.UpdatePlayerStatisticProgression(ctx, st, buf.param(playerID), 1)
+			assert.NoError(t, err)
+		}
+
+		buf.param("dave-3333333")
+
+		for _, playerID := range playerIDs {
+			progression, err := conn.GetPlayerProgression(ctx, st.ID, playerID)
+			assert.NoError(t, err)
+			assert.Equal(t, playerID, progression.PlayerID)
+		}
+	})
+
+	t.Run("Invalid Aggregation Mode", func(t *testing.T) {
+		conn := New()
+
+		_, _, err := conn.UpdatePlayerStatisticProgression(ctx, statistic.Statistic{AggregationMode: "INVALID"}, playerID, 1)
+		assert.ErrorIs(t, err, statistic.ErrInvalidAggregationMode)
+	})
+
+	t.Run("Progression Not Found", func(t *testing.T) {
+		conn := New()
+
+		_, err := conn.GetPlayerProgression(ctx, uuid.NewString(), playerID)
+		assert.ErrorIs(t, err, statistic.ErrPlayerStatisticNotFound)
+	})
+}
+
+func TestBulkUpdatePlayerStatisticProgression(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		goal     = float64(10)
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		conn := New()
+
+		kills, err := conn.CreateStatistic(ctx, statistic.NewStatisticData{
+			GameID:          uuid.NewString(),
+			Name:            "Kills",
+			AggregationMode: statistic.AggregationModeSum,
+			Goal:            &goal,
+		})
+		assert.NoError(t, err)
+
+		deaths, err := conn.CreateStatistic(ctx, statistic.NewStatisticData{
+			GameID:          uuid.NewString(),
+			Name:            "Deaths",
+			AggregationMode: statistic.AggregationModeMin,
+		})
+		assert.NoError(t, err)
+
+		progressions, updates, err := conn.BulkUpdatePlayerStatisticProgression(ctx, []statistic.PlayerProgressionChange{
+			{Statistic: kills, PlayerID: playerID, Value: 10},
+			{Statistic: deaths, PlayerID: playerID, Value: 3},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, progressions, 2)
+		assert.Equal(t, float64(10), *progressions[0].CurrentValue)
+		assert.True(t, updates[0].GoalJustCompleted)
+		assert.Equal(t, float64(3), *progressions[1].CurrentValue)
+		assert.False(t, updates[1].GoalJustCompleted)
+
+		stored, err := conn.GetPlayerProgression(ctx, deaths.ID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, progressions[1], stored)
+	})
+
+	t.Run("Invalid Aggregation Mode", func(t *testing.T) {
+		conn := New()
+
+		st, err := conn.CreateStatistic(ctx, statistic.NewStatisticData{
+			GameID:          uuid.NewString(),
+			Name:            "Kills",
+			AggregationMode: statistic.AggregationModeSum,
+		})
+		assert.NoError(t, err)
+
+		_, _, err = conn.BulkUpdatePlayerStatisticProgression(ctx, []statistic.PlayerProgressionChange{
+			{Statistic: st, PlayerID: playerID, Value: 1},
+			{Statistic: statistic.Statistic{AggregationMode: "INVALID"}, PlayerID: playerID, Value: 1},
+		})
+		assert.ErrorIs(t, err, statistic.ErrInvalidAggregationMode)
+
+		_, err = conn.GetPlayerProgression(ctx, st.ID, playerID)
+		assert.ErrorIs(t, err, statistic.ErrPlayerStatisticNotFound)
+	})
+
+	t.Run("Write-Behind Checkpoint", func(t *testing.T) {
+		conn := New()
+
+		st, err := conn.CreateStatistic(ctx, statistic.NewStatisticData{
+			GameID:          uuid.NewString(),
+			Name:            "Kills",
+			AggregationMode: statistic.AggregationModeSum,
+		})
+		assert.NoError(t, err)
+
+		sequence, err := conn.GetWriteBehindCheckpoint(ctx, "queue")
+		assert.NoError(t, err)
+		assert.Zero(t, sequence)
+
+		_, _, err = conn.BulkUpdatePlayerStatisticProgression(writebehind.WithCheckpoint(ctx, writebehind.Checkpoint{QueueID: "queue", Sequence: 5}), []statistic.PlayerProgressionChange{
+			{Statistic: st, PlayerID: playerID, Value: 1},
+		})
+		assert.NoError(t, err)
+
+		_, _, err = conn.BulkUpdatePlayerStatisticProgression(writebehind.WithCheckpoint(ctx, writebehind.Checkpoint{QueueID: "queue", Sequence: 6}), []statistic.PlayerProgressionChange{
+			{Statistic: statistic.Statistic{AggregationMode: "INVALID"}, PlayerID: playerID, Value: 1},
+		})
+		assert.ErrorIs(t, err, statistic.ErrInvalidAggregationMode)
+
+		sequence, err = conn.GetWriteBehindCheckpoint(ctx, "queue")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), sequence)
+	})
+}
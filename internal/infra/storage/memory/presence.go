@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/presence"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+)
+
+type presenceKey struct {
+	gameID   string
+	playerID string
+}
+
+// Player's last heartbeat alongside the expiration of its online status and of the heartbeat itself
+type heartbeat struct {
+	lastSeenAt  time.Time
+	onlineUntil time.Time
+	expiresAt   time.Time
+}
+
+func (c *connection) RecordHeartbeat(ctx context.Context, p presence.Presence, timeout, retention time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	// The IDs are copied, as they may come from a buffer the caller reuses
+	c.heartbeats[presenceKey{gameID: strings.Clone(p.GameID), playerID: strings.Clone(p.PlayerID)}] = heartbeat{
+		lastSeenAt:  p.LastSeenAt,
+		onlineUntil: now.Add(timeout),
+		expiresAt:   now.Add(retention),
+	}
+	return nil
+}
+
+func (c *connection) ListPresences(ctx context.Context, gameID string, playerIDs []string) ([]presence.Presence, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var (
+		now       = time.Now()
+		presences = make([]presence.Presence, 0)
+	)
+	for _, playerID := range playerIDs {
+		h, ok := c.heartbeats[presenceKey{gameID: gameID, playerID: playerID}]
+		if !ok || !now.Before(h.expiresAt) {
+			continue
+		}
+
+		presences = append(presences, presence.Presence{
+			GameID:     gameID,
+			PlayerID:   playerID,
+			Online:     now.Before(h.onlineUntil),
+			LastSeenAt: h.lastSeenAt,
+		})
+	}
+
+	return presences, nil
+}
+
+// A heartbeat past its retention is removed too, but not counted, as it had already expired
+func (c *connection) removePresence(gameID, playerID string) int64 {
+	key := presenceKey{gameID: gameID, playerID: playerID}
+	h, ok := c.heartbeats[key]
+	delete(c.heartbeats, key)
+
+	if !ok || !time.Now().Before(h.expiresAt) {
+		return 0
+	}
+
+	return 1
+}
+
+func (c *connection) ErasePlayerPresence(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return privacy.Erasure{Data: privacy.DataPresence, Records: c.removePresence(gameID, playerID)}, nil
+}
+
+// The presence is short-lived, so it's removed instead of moved to the pseudonym
+func (c *connection) AnonymizePlayerPresence(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return privacy.Anonymization{Data: privacy.DataPresence, Records: c.removePresence(gameID, playerID)}, nil
+}
+
+func (c *connection) CountPlayerPresence(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataPresence}
+	if h, ok := c.heartbeats[presenceKey{gameID: gameID, playerID: playerID}]; ok && time.Now().Before(h.expiresAt) {
+		count.Records = 1
+	}
+
+	return count, nil
+}
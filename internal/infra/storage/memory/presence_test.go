@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/presence"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresence(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		now    = time.Now().UTC()
+	)
+
+	t.Run("Record Heartbeat", func(t *testing.T) {
+		err := conn.RecordHeartbeat(ctx, presence.Presence{GameID: gameID, PlayerID: "alice", Online: true, LastSeenAt: now}, time.Minute, time.Hour)
+		assert.NoError(t, err)
+
+		err = conn.RecordHeartbeat(ctx, presence.Presence{GameID: gameID, PlayerID: "bob", Online: true, LastSeenAt: now}, time.Nanosecond, time.Hour)
+		assert.NoError(t, err)
+
+		err = conn.RecordHeartbeat(ctx, presence.Presence{GameID: gameID, PlayerID: "carol", Online: true, LastSeenAt: now}, time.Nanosecond, time.Nanosecond)
+		assert.NoError(t, err)
+	})
+
+	t.Run("List Presences", func(t *testing.T) {
+		time.Sleep(time.Millisecond)
+
+		presences, err := conn.ListPresences(ctx, gameID, []string{"alice", "bob", "carol", "dave"})
+		assert.NoError(t, err)
+		assert.Equal(t, []presence.Presence{
+			{GameID: gameID, PlayerID: "alice", Online: true, LastSeenAt: now},
+			{GameID: gameID, PlayerID: "bob", Online: false, LastSeenAt: now},
+		}, presences)
+	})
+
+	t.Run("Privacy", func(t *testing.T) {
+		count, err := conn.CountPlayerPresence(ctx, gameID, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count.Records)
+
+		count, err = conn.CountPlayerPresence(ctx, gameID, "carol")
+		assert.NoError(t, err)
+		assert.Zero(t, count.Records)
+
+		anonymization, err := conn.AnonymizePlayerPresence(ctx, gameID, "bob", "pseudonym")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), anonymization.Records)
+
+		erasure, err := conn.ErasePlayerPresence(ctx, gameID, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), erasure.Records)
+
+		presences, err := conn.ListPresences(ctx, gameID, []string{"alice", "bob", "pseudonym"})
+		assert.NoError(t, err)
+		assert.Empty(t, presences)
+	})
+}
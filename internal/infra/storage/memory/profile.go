@@ -0,0 +1,281 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+)
+
+type profileKey struct {
+	gameID   string
+	playerID string
+}
+
+// Field of the display name search prefixes blind indexes
+const searchPrefixField = "searchPrefix"
+
+// Profile as stored. Once the encryption is enabled, its display name is encrypted and only matched by the searches
+// through the blind indexes of its search prefixes
+type storedProfile struct {
+	player.Profile
+	searchPrefixes []string
+}
+
+// The metadata is copied in and out of the storage, so the callers can't change the stored one. The player ID may
+// come from a request parameter, so it's copied to outlive the request
+func (c *connection) sealProfile(p player.Profile) (storedProfile, error) {
+	p.GameID = strings.Clone(p.GameID)
+	p.PlayerID = strings.Clone(p.PlayerID)
+	p.Metadata = maps.Clone(p.Metadata)
+	if c.cipher == nil {
+		return storedProfile{Profile: p}, nil
+	}
+
+	prefixes := player.SearchPrefixes(p.DisplayName)
+	for i, prefix := range prefixes {
+		prefixes[i] = c.cipher.BlindIndex(searchPrefixField, prefix)
+	}
+
+	var err error
+	if p.DisplayName, err = c.cipher.Encrypt(p.DisplayName); err != nil {
+		return storedProfile{}, err
+	}
+
+	return storedProfile{Profile: p, searchPrefixes: prefixes}, nil
+}
+
+func (p storedProfile) toDomain(cipher *encryption.Cipher) (player.Profile, error) {
+	profile := p.Profile
+	profile.Metadata = maps.Clone(p.Metadata)
+
+	var err error
+	if profile.DisplayName, err = cipher.Decrypt(p.DisplayName); err != nil {
+		return player.Profile{}, err
+	}
+
+	return profile, nil
+}
+
+func (c *connection) CreateProfile(ctx context.Context, profile player.Profile) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.profiles[profileKey{gameID: profile.GameID, playerID: profile.PlayerID}]; ok {
+		return player.ErrProfileAlreadyExists
+	}
+
+	stored, err := c.sealProfile(profile)
+	if err != nil {
+		return err
+	}
+
+	c.profiles[profileKey{gameID: stored.GameID, playerID: stored.PlayerID}] = stored
+	return nil
+}
+
+func (c *connection) GetProfile(ctx context.Context, gameID, playerID string) (player.Profile, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	profile, ok := c.profiles[profileKey{gameID: gameID, playerID: playerID}]
+	if !ok {
+		return player.Profile{}, player.ErrProfileNotFound
+	}
+
+	return profile.toDomain(c.cipher)
+}
+
+func (c *connection) UpdateProfile(ctx context.Context, profile player.Profile) (player.Profile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stored, ok := c.profiles[profileKey{gameID: profile.GameID, playerID: profile.PlayerID}]
+	if !ok {
+		return player.Profile{}, player.ErrProfileNotFound
+	}
+
+	profile.CreatedAt = stored.CreatedAt
+
+	stored, err := c.sealProfile(profile)
+	if err != nil {
+		return player.Profile{}, err
+	}
+
+	c.profiles[profileKey{gameID: stored.GameID, playerID: stored.PlayerID}] = stored
+
+	return profile, nil
+}
+
+func (c *connection) DeleteProfile(ctx context.Context, gameID, playerID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := profileKey{gameID: gameID, playerID: playerID}
+	if _, ok := c.profiles[key]; !ok {
+		return player.ErrProfileNotFound
+	}
+
+	delete(c.profiles, key)
+	return nil
+}
+
+func (c *connection) ListProfiles(ctx context.Context, gameID string, playerIDs []string) ([]player.Profile, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	profiles := make([]player.Profile, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		stored, ok := c.profiles[profileKey{gameID: gameID, playerID: playerID}]
+		if !ok {
+			continue
+		}
+
+		profile, err := stored.toDomain(c.cipher)
+		if err != nil {
+			return nil, err
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// How well a display name matches a search: 0 when one of its terms starts with the search,
+// 1 when every word of the search is one of its words and -1 when it doesn't match
+func searchMatch(displayName, search string) int {
+	terms := player.SearchTerms(displayName)
+	for _, term := range terms {
+		if strings.HasPrefix(term, search) {
+			return 0
+		}
+	}
+
+	if len(terms) == 0 {
+		return -1
+	}
+
+	words := strings.Fields(terms[0])
+	for _, word := range strings.Fields(search) {
+		if !slices.Contains(words, word) {
+			return -1
+		}
+	}
+
+	return 1
+}
+
+// Profiles starting with the search come first, then the ones with every word of the search,
+// each ordered by display name and player ID. Once the encryption is enabled, only the profiles starting with the search
+// are matched, by the blind index of the search, and ordered by player ID, as the other storages do
+func (c *connection) SearchProfiles(ctx context.Context, gameID, search string, offset, limit int) ([]player.Profile, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	type match struct {
+		rank    int
+		name    string // Normalized display name, left empty once it's encrypted
+		profile storedProfile
+	}
+
+	index := c.cipher.BlindIndex(searchPrefixField, player.IndexedSearch(search))
+
+	matches := make([]match, 0)
+	for key, profile := range c.profiles {
+		switch {
+		case key.gameID != gameID:
+			continue
+		case c.cipher != nil:
+			if slices.Contains(profile.searchPrefixes, index) {
+				matches = append(matches, match{profile: profile})
+			}
+		default:
+			if rank := searchMatch(profile.DisplayName, search); rank >= 0 {
+				matches = append(matches, match{rank: rank, name: player.NormalizeSearch(profile.DisplayName), profile: profile})
+			}
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b match) int {
+		return cmp.Or(
+			cmp.Compare(a.rank, b.rank),
+			cmp.Compare(a.name, b.name),
+			cmp.Compare(a.profile.PlayerID, b.profile.PlayerID),
+		)
+	})
+
+	profiles := make([]player.Profile, 0, limit)
+	for i := offset; i < len(matches) && len(profiles) < limit; i++ {
+		profile, err := matches[i].profile.toDomain(c.cipher)
+		if err != nil {
+			return nil, err
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// Erases the player's profile
+func (c *connection) ErasePlayerProfile(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataProfile}
+	key := profileKey{gameID: gameID, playerID: playerID}
+	if _, ok := c.profiles[key]; ok {
+		delete(c.profiles, key)
+		erasure.Records++
+	}
+
+	return erasure, nil
+}
+
+// Moves the player's profile to the pseudonym. The display name, avatar, country and metadata identify the player
+// on their own, so they are cleared, keeping the display name as the pseudonym
+func (c *connection) AnonymizePlayerProfile(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataProfile}
+	key := profileKey{gameID: gameID, playerID: playerID}
+	if stored, ok := c.profiles[key]; ok {
+		profile := stored.Profile
+		profile.PlayerID = pseudonym
+		profile.DisplayName = pseudonym
+		profile.AvatarURL = ""
+		profile.Country = ""
+		profile.Metadata = make(map[string]string)
+
+		anonymized, err := c.sealProfile(profile)
+		if err != nil {
+			return privacy.Anonymization{}, err
+		}
+
+		delete(c.profiles, key)
+		c.profiles[profileKey{gameID: anonymized.GameID, playerID: anonymized.PlayerID}] = anonymized
+		anonymization.Records++
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's profile
+func (c *connection) CountPlayerProfile(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataProfile}
+	if _, ok := c.profiles[profileKey{gameID: gameID, playerID: playerID}]; ok {
+		count.Records++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/player"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfile(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		conn     = New()
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+		now      = time.Now().UTC()
+	)
+
+	profile := player.Profile{
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		GameID:      gameID,
+		PlayerID:    playerID,
+		DisplayName: "Player",
+		Country:     "BR",
+		Metadata:    map[string]string{"clan": "red"},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		assert.NoError(t, conn.CreateProfile(ctx, profile))
+		assert.ErrorIs(t, conn.CreateProfile(ctx, profile), player.ErrProfileAlreadyExists)
+
+		stored, err := conn.GetProfile(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, profile, stored)
+
+		_, err = conn.GetProfile(ctx, uuid.NewString(), playerID)
+		assert.ErrorIs(t, err, player.ErrProfileNotFound)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		updated := profile
+		updated.CreatedAt = now.Add(time.Hour)
+		updated.UpdatedAt = now.Add(time.Hour)
+		updated.DisplayName = "Renamed"
+
+		stored, err := conn.UpdateProfile(ctx, updated)
+		assert.NoError(t, err)
+		assert.Equal(t, now, stored.CreatedAt)
+		assert.Equal(t, "Renamed", stored.DisplayName)
+
+		_, err = conn.UpdateProfile(ctx, player.Profile{GameID: gameID, PlayerID: uuid.NewString()})
+		assert.ErrorIs(t, err, player.ErrProfileNotFound)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		profiles, err := conn.ListProfiles(ctx, gameID, []string{playerID, uuid.NewString()})
+		assert.NoError(t, err)
+		assert.Len(t, profiles, 1)
+		assert.Equal(t, playerID, profiles[0].PlayerID)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		for id, name := range map[string]string{"a": "Knight Rider", "b": "The Dark Knight", "c": "Knightmare", "d": "Night Knight Owl"} {
+			assert.NoError(t, conn.CreateProfile(ctx, player.Profile{GameID: gameID, PlayerID: id, DisplayName: name}))
+		}
+		assert.NoError(t, conn.CreateProfile(ctx, player.Profile{GameID: uuid.NewString(), PlayerID: "e", DisplayName: "Knight"}))
+
+		names := func(profiles []player.Profile) []string {
+			data := make([]string, len(profiles))
+			for i, profile := range profiles {
+				data[i] = profile.DisplayName
+			}
+
+			return data
+		}
+
+		profiles, err := conn.SearchProfiles(ctx, gameID, "knight", 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Knight Rider", "Knightmare", "Night Knight Owl", "The Dark Knight"}, names(profiles))
+
+		profiles, err = conn.SearchProfiles(ctx, gameID, "knight", 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Knightmare", "Night Knight Owl"}, names(profiles))
+
+		profiles, err = conn.SearchProfiles(ctx, gameID, "owl night", 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Night Knight Owl"}, names(profiles))
+
+		profiles, err = conn.SearchProfiles(ctx, gameID, "dragon", 0, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, profiles)
+
+		for _, id := range []string{"a", "b", "c", "d"} {
+			assert.NoError(t, conn.DeleteProfile(ctx, gameID, id))
+		}
+	})
+
+	t.Run("Anonymize", func(t *testing.T) {
+		pseudonym := uuid.NewString()
+
+		anonymization, err := conn.AnonymizePlayerProfile(ctx, gameID, playerID, pseudonym)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), anonymization.Records)
+
+		stored, err := conn.GetProfile(ctx, gameID, pseudonym)
+		assert.NoError(t, err)
+		assert.Equal(t, pseudonym, stored.DisplayName)
+		assert.Empty(t, stored.Country)
+		assert.Empty(t, stored.Metadata)
+
+		count, err := conn.CountPlayerProfile(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Zero(t, count.Records)
+
+		erasure, err := conn.ErasePlayerProfile(ctx, gameID, pseudonym)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), erasure.Records)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, conn.CreateProfile(ctx, profile))
+		assert.NoError(t, conn.DeleteProfile(ctx, gameID, playerID))
+		assert.ErrorIs(t, conn.DeleteProfile(ctx, gameID, playerID), player.ErrProfileNotFound)
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		buf := newRequestBuffer()
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range playerIDs {
+			assert.NoError(t, conn.CreateProfile(ctx, player.Profile{GameID: gameID, PlayerID: buf.param(playerID), DisplayName: playerID}))
+		}
+
+		_, err := conn.UpdateProfile(ctx, player.Profile{GameID: gameID, PlayerID: buf.param("alice-000000"), DisplayName: "Renamed"})
+		assert.NoError(t, err)
+
+		buf.param("dave-3333333")
+
+		for _, playerID := range playerIDs {
+			stored, err := conn.GetProfile(ctx, gameID, playerID)
+			assert.NoError(t, err)
+			assert.Equal(t, playerID, stored.PlayerID)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/proof"
+)
+
+func proofRuleIndex(rules []proof.Rule, gameID, leaderboardID string) int {
+	return slices.IndexFunc(rules, func(r proof.Rule) bool {
+		return r.GameID == gameID && r.LeaderboardID == leaderboardID
+	})
+}
+
+// The leaderboard ID may come from a request parameter, so it's copied to outlive the request
+func (c *connection) SetProofRule(ctx context.Context, rule proof.Rule) (proof.Rule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rule.LeaderboardID = strings.Clone(rule.LeaderboardID)
+	if i := proofRuleIndex(c.proofRules, rule.GameID, rule.LeaderboardID); i >= 0 {
+		c.proofRules[i] = rule
+	} else {
+		c.proofRules = append(c.proofRules, rule)
+	}
+
+	return rule, nil
+}
+
+func (c *connection) GetProofRule(ctx context.Context, gameID, leaderboardID string) (proof.Rule, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := proofRuleIndex(c.proofRules, gameID, leaderboardID)
+	if i < 0 {
+		return proof.Rule{}, proof.ErrRuleNotFound
+	}
+
+	return c.proofRules[i], nil
+}
+
+func (c *connection) DeleteProofRule(ctx context.Context, gameID, leaderboardID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := proofRuleIndex(c.proofRules, gameID, leaderboardID)
+	if i < 0 {
+		return proof.ErrRuleNotFound
+	}
+
+	c.proofRules = slices.Delete(c.proofRules, i, i+1)
+	return nil
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/proof"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProofRule(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+	)
+
+	t.Run("Set Rule", func(t *testing.T) {
+		_, err := conn.SetProofRule(ctx, proof.Rule{GameID: gameID, LeaderboardID: "leaderboard", TopN: 10})
+		assert.NoError(t, err)
+
+		_, err = conn.SetProofRule(ctx, proof.Rule{GameID: gameID, LeaderboardID: "leaderboard", TopN: 3})
+		assert.NoError(t, err)
+
+		rule, err := conn.GetProofRule(ctx, gameID, "leaderboard")
+		assert.NoError(t, err)
+		assert.Equal(t, proof.Rule{GameID: gameID, LeaderboardID: "leaderboard", TopN: 3}, rule)
+	})
+
+	t.Run("Delete Rule", func(t *testing.T) {
+		err := conn.DeleteProofRule(ctx, gameID, "leaderboard")
+		assert.NoError(t, err)
+
+		_, err = conn.GetProofRule(ctx, gameID, "leaderboard")
+		assert.ErrorIs(t, err, proof.ErrRuleNotFound)
+
+		err = conn.DeleteProofRule(ctx, gameID, "leaderboard")
+		assert.ErrorIs(t, err, proof.ErrRuleNotFound)
+	})
+}
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+
+	"github.com/google/uuid"
+)
+
+// The IDs may come from request parameters, so they're copied to outlive the request
+func (c *connection) CreateQuarantinedSubmission(ctx context.Context, s quarantine.Submission) (quarantine.Submission, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s.ID = uuid.NewString()
+	s.LeaderboardID = strings.Clone(s.LeaderboardID)
+	s.PlayerID = strings.Clone(s.PlayerID)
+	c.heldSubmissions = append(c.heldSubmissions, s)
+	return s, nil
+}
+
+func (c *connection) GetQuarantinedSubmission(ctx context.Context, gameID, id string) (quarantine.Submission, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := slices.IndexFunc(c.heldSubmissions, func(s quarantine.Submission) bool { return s.GameID == gameID && s.ID == id })
+	if i < 0 {
+		return quarantine.Submission{}, quarantine.ErrSubmissionNotFound
+	}
+
+	return c.heldSubmissions[i], nil
+}
+
+// The submissions are appended as they're held, so they're kept oldest first
+func (c *connection) ListQuarantinedSubmissions(ctx context.Context, gameID, leaderboardID string, limit int) ([]quarantine.Submission, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	submissions := make([]quarantine.Submission, 0)
+	for _, s := range c.heldSubmissions {
+		if len(submissions) == limit {
+			break
+		}
+
+		if s.GameID == gameID && (leaderboardID == "" || s.LeaderboardID == leaderboardID) {
+			submissions = append(submissions, s)
+		}
+	}
+
+	return submissions, nil
+}
+
+func (c *connection) DeleteQuarantinedSubmission(ctx context.Context, gameID, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.heldSubmissions, func(s quarantine.Submission) bool { return s.GameID == gameID && s.ID == id })
+	if i < 0 {
+		return quarantine.ErrSubmissionNotFound
+	}
+
+	c.heldSubmissions = slices.Delete(c.heldSubmissions, i, i+1)
+	return nil
+}
+
+// Erases the player's submissions held for review on the game
+func (c *connection) ErasePlayerQuarantinedSubmissions(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataQuarantine}
+	c.heldSubmissions = slices.DeleteFunc(c.heldSubmissions, func(s quarantine.Submission) bool {
+		if s.GameID != gameID || s.PlayerID != playerID {
+			return false
+		}
+
+		erasure.Records++
+		return true
+	})
+
+	return erasure, nil
+}
+
+// Moves the player's submissions held for review on the game to the pseudonym
+func (c *connection) AnonymizePlayerQuarantinedSubmissions(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataQuarantine}
+	for i, s := range c.heldSubmissions {
+		if s.GameID == gameID && s.PlayerID == playerID {
+			c.heldSubmissions[i].PlayerID = pseudonym
+			anonymization.Records++
+		}
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's submissions held for review on the game
+func (c *connection) CountPlayerQuarantinedSubmissions(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataQuarantine}
+	for _, s := range c.heldSubmissions {
+		if s.GameID == gameID && s.PlayerID == playerID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
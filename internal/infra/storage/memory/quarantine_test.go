@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuarantinedSubmission(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		held   quarantine.Submission
+	)
+
+	t.Run("Create Submission", func(t *testing.T) {
+		var err error
+		held, err = conn.CreateQuarantinedSubmission(ctx, quarantine.Submission{GameID: gameID, LeaderboardID: "leaderboard", PlayerID: "alice", Value: 100})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, held.ID)
+
+		_, err = conn.CreateQuarantinedSubmission(ctx, quarantine.Submission{GameID: gameID, LeaderboardID: "other", PlayerID: "bob", Value: 10})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Get Submission", func(t *testing.T) {
+		s, err := conn.GetQuarantinedSubmission(ctx, gameID, held.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, held, s)
+
+		_, err = conn.GetQuarantinedSubmission(ctx, uuid.NewString(), held.ID)
+		assert.ErrorIs(t, err, quarantine.ErrSubmissionNotFound)
+	})
+
+	t.Run("List Submissions", func(t *testing.T) {
+		submissions, err := conn.ListQuarantinedSubmissions(ctx, gameID, "", 10)
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 2)
+		assert.Equal(t, held, submissions[0])
+
+		submissions, err = conn.ListQuarantinedSubmissions(ctx, gameID, "other", 10)
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 1)
+
+		submissions, err = conn.ListQuarantinedSubmissions(ctx, gameID, "", 1)
+		assert.NoError(t, err)
+		assert.Len(t, submissions, 1)
+	})
+
+	t.Run("Privacy", func(t *testing.T) {
+		count, err := conn.CountPlayerQuarantinedSubmissions(ctx, gameID, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, privacy.DataCount{Data: privacy.DataQuarantine, Records: 1}, count)
+
+		anonymization, err := conn.AnonymizePlayerQuarantinedSubmissions(ctx, gameID, "bob", "pseudonym")
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, anonymization.Records)
+
+		erasure, err := conn.ErasePlayerQuarantinedSubmissions(ctx, gameID, "pseudonym")
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, erasure.Records)
+	})
+
+	t.Run("Delete Submission", func(t *testing.T) {
+		err := conn.DeleteQuarantinedSubmission(ctx, gameID, held.ID)
+		assert.NoError(t, err)
+
+		err = conn.DeleteQuarantinedSubmission(ctx, gameID, held.ID)
+		assert.ErrorIs(t, err, quarantine.ErrSubmissionNotFound)
+	})
+}
@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/purge"
+	"github.com/gabapcia/gameblitz/internal/quest"
+
+	"github.com/google/uuid"
+)
+
+func (c *connection) CreateQuest(ctx context.Context, data quest.NewQuestData) (quest.Quest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now().UTC()
+
+	tasks := make([]quest.Task, len(data.Tasks))
+	for i, task := range data.Tasks {
+		tasks[i] = quest.Task{
+			CreatedAt:             now,
+			UpdatedAt:             now,
+			ID:                    uuid.NewString(),
+			Name:                  task.Name,
+			Description:           task.Description,
+			RequiredForCompletion: task.RequiredForCompletion,
+			Rule:                  task.Rule,
+		}
+	}
+
+	for i, task := range data.Tasks {
+		dependsOn := make([]string, len(task.DependsOn))
+		for j, dependsOnIndex := range task.DependsOn {
+			dependsOn[j] = tasks[dependsOnIndex].ID
+		}
+
+		tasks[i].DependsOn = dependsOn
+	}
+
+	q := quest.Quest{
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ID:          uuid.NewString(),
+		GameID:      data.GameID,
+		Name:        data.Name,
+		Description: data.Description,
+		Tasks:       tasks,
+		EndAt:       data.EndAt,
+	}
+	c.quests[q.ID] = q
+
+	return q, nil
+}
+
+func (c *connection) GetQuestByIDAndGameID(ctx context.Context, id, gameID string) (quest.Quest, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	q, ok := c.quests[id]
+	if !ok || !q.DeletedAt.IsZero() || q.GameID != gameID {
+		return quest.Quest{}, quest.ErrQuestNotFound
+	}
+
+	return q, nil
+}
+
+// Lists the active quests oldest first, like the Postgres driver
+func (c *connection) ListQuests(ctx context.Context, gameID string) ([]quest.Quest, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	quests := make([]quest.Quest, 0)
+	for _, q := range c.quests {
+		if q.GameID == gameID && q.DeletedAt.IsZero() {
+			quests = append(quests, q)
+		}
+	}
+
+	slices.SortFunc(quests, func(a, b quest.Quest) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return quests, nil
+}
+
+func (c *connection) ListQuestsEndedBetween(ctx context.Context, from, to time.Time) ([]quest.Quest, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	quests := make([]quest.Quest, 0)
+	for _, q := range c.quests {
+		if q.DeletedAt.IsZero() && !q.EndAt.IsZero() && q.EndAt.After(from) && !q.EndAt.After(to) {
+			quests = append(quests, q)
+		}
+	}
+
+	return quests, nil
+}
+
+func (c *connection) SoftDeleteQuestByIDAndGameID(ctx context.Context, id, gameID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	q, ok := c.quests[id]
+	if !ok || !q.DeletedAt.IsZero() || q.GameID != gameID {
+		return quest.ErrQuestNotFound
+	}
+
+	now := time.Now().UTC()
+
+	tasks := make([]quest.Task, len(q.Tasks))
+	for i, task := range q.Tasks {
+		task.DeletedAt = now
+		tasks[i] = task
+	}
+
+	q.DeletedAt = now
+	q.Tasks = tasks
+	c.quests[id] = q
+
+	return nil
+}
+
+func (c *connection) PurgeSoftDeletedQuests(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records := make([]purge.Record, 0)
+	for id, q := range c.quests {
+		if !softDeletedBefore(q.DeletedAt, q.GameID, gameID, deletedBefore) {
+			continue
+		}
+
+		delete(c.quests, id)
+		for key := range c.playerQuests {
+			if key.QuestID == id {
+				delete(c.playerQuests, key)
+			}
+		}
+
+		records = append(records, purge.Record{
+			DeletedAt: q.DeletedAt,
+			Entity:    purge.EntityQuest,
+			ID:        id,
+			GameID:    q.GameID,
+		})
+	}
+
+	return records, nil
+}
+
+func (c *connection) ListSoftDeletedQuests(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	records := make([]purge.Record, 0)
+	for id, q := range c.quests {
+		if softDeletedBefore(q.DeletedAt, q.GameID, gameID, deletedBefore) {
+			records = append(records, purge.Record{
+				DeletedAt: q.DeletedAt,
+				Entity:    purge.EntityQuest,
+				ID:        id,
+				GameID:    q.GameID,
+			})
+		}
+	}
+
+	return records, nil
+}
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/quota"
+)
+
+func (c *connection) SetQuota(ctx context.Context, q quota.Quota) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.quotas[q.GameID] = q
+	return nil
+}
+
+func (c *connection) GetQuota(ctx context.Context, gameID string) (quota.Quota, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	q, ok := c.quotas[gameID]
+	if !ok {
+		return quota.Quota{}, quota.ErrQuotaNotFound
+	}
+
+	return q, nil
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/quota"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuota(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+	)
+
+	_, err := conn.GetQuota(ctx, gameID)
+	assert.ErrorIs(t, err, quota.ErrQuotaNotFound)
+
+	q := quota.Quota{GameID: gameID, RequestsPerMinute: 60, MaxLeaderboards: 5, UpdatedAt: time.Now().UTC()}
+	assert.NoError(t, conn.SetQuota(ctx, q))
+
+	stored, err := conn.GetQuota(ctx, gameID)
+	assert.NoError(t, err)
+	assert.Equal(t, q, stored)
+
+	q.MaxStatistics = 10
+	assert.NoError(t, conn.SetQuota(ctx, q))
+
+	stored, err = conn.GetQuota(ctx, gameID)
+	assert.NoError(t, err)
+	assert.Equal(t, q, stored)
+}
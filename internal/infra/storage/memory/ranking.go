@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+)
+
+type rank struct {
+	PlayerID  string
+	Value     float64
+	UpdatedAt time.Time
+}
+
+// The player ID may come from a request parameter, so it's copied to outlive the request. Even an existing rank's
+// key is replaced by the one assigned
+func (c *connection) UpsertPlayerRankValue(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	playerID = strings.Clone(playerID)
+
+	ranking, ok := c.rankings[lb.ID]
+	if !ok {
+		ranking = make(map[string]rank)
+		c.rankings[lb.ID] = ranking
+	}
+
+	current, exists := ranking[playerID]
+	if !exists {
+		ranking[playerID] = rank{PlayerID: playerID, Value: value, UpdatedAt: time.Now().UTC()}
+		return nil
+	}
+
+	switch lb.AggregationMode {
+	case leaderboard.AggregationModeInc:
+		current.Value += value
+	case leaderboard.AggregationModeMax:
+		current.Value = max(current.Value, value)
+	case leaderboard.AggregationModeMin:
+		current.Value = min(current.Value, value)
+	default:
+		return leaderboard.ErrInvalidAggregationMode
+	}
+
+	current.UpdatedAt = time.Now().UTC()
+	ranking[playerID] = current
+
+	return nil
+}
+
+// Ranks of the leaderboard in the ranking order, with the earliest update first among equal values
+func (c *connection) sortedRanks(lb leaderboard.Leaderboard) ([]rank, error) {
+	var compareValues func(a, b float64) int
+	switch lb.Ordering {
+	case leaderboard.OrderingAsc:
+		compareValues = cmp.Compare[float64]
+	case leaderboard.OrderingDesc:
+		compareValues = func(a, b float64) int { return cmp.Compare(b, a) }
+	default:
+		return nil, leaderboard.ErrInvalidOrdering
+	}
+
+	ranks := make([]rank, 0, len(c.rankings[lb.ID]))
+	for _, r := range c.rankings[lb.ID] {
+		ranks = append(ranks, r)
+	}
+
+	slices.SortFunc(ranks, func(a, b rank) int {
+		if c := compareValues(a.Value, b.Value); c != 0 {
+			return c
+		}
+
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	})
+
+	return ranks, nil
+}
+
+func (c *connection) GetRanking(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ranks, err := c.sortedRanks(lb)
+	if err != nil {
+		return nil, err
+	}
+
+	start := min(page*limit, int64(len(ranks)))
+	end := min(start+limit, int64(len(ranks)))
+
+	ranking := make([]leaderboard.Rank, 0, end-start)
+	for i, r := range ranks[start:end] {
+		ranking = append(ranking, leaderboard.Rank{
+			LeaderboardID: lb.ID,
+			PlayerID:      r.PlayerID,
+			Position:      start + int64(i),
+			Value:         r.Value,
+		})
+	}
+
+	return ranking, nil
+}
+
+func (c *connection) GetPlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ranks, err := c.sortedRanks(lb)
+	if err != nil {
+		return leaderboard.Rank{}, err
+	}
+
+	position := slices.IndexFunc(ranks, func(r rank) bool { return r.PlayerID == playerID })
+	if position < 0 {
+		return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+	}
+
+	return leaderboard.Rank{
+		LeaderboardID: lb.ID,
+		PlayerID:      playerID,
+		Position:      int64(position),
+		Value:         ranks[position].Value,
+	}, nil
+}
+
+func (c *connection) DeletePlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.rankings[lb.ID], playerID)
+	return nil
+}
+
+// Erases the player's ranks on every leaderboard of the game, including the soft deleted ones
+func (c *connection) ErasePlayerRanks(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataRanks}
+	for lbID, ranking := range c.rankings {
+		if lb, ok := c.leaderboards[lbID]; !ok || lb.GameID != gameID {
+			continue
+		}
+
+		if _, ok := ranking[playerID]; ok {
+			delete(ranking, playerID)
+			erasure.Records++
+		}
+	}
+
+	return erasure, nil
+}
+
+// Moves the player's ranks on every leaderboard of the game, including the soft deleted ones, to the pseudonym.
+// The rank keeps its value and update time, so the ranking order doesn't change
+func (c *connection) AnonymizePlayerRanks(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataRanks}
+	for lbID, ranking := range c.rankings {
+		if lb, ok := c.leaderboards[lbID]; !ok || lb.GameID != gameID {
+			continue
+		}
+
+		if r, ok := ranking[playerID]; ok {
+			r.PlayerID = pseudonym
+			ranking[pseudonym] = r
+			delete(ranking, playerID)
+			anonymization.Records++
+		}
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's ranks on every leaderboard of the game, including the soft deleted ones
+func (c *connection) CountPlayerRanks(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataRanks}
+	for lbID, ranking := range c.rankings {
+		if lb, ok := c.leaderboards[lbID]; !ok || lb.GameID != gameID {
+			continue
+		}
+
+		if _, ok := ranking[playerID]; ok {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
+
+// The rebuilt ranking is built aside and swapped in under the lock, so it's never read partially rebuilt
+func (c *connection) ReplaceRanking(ctx context.Context, lb leaderboard.Leaderboard, ranks []leaderboard.RebuiltRank) error {
+	ranking := make(map[string]rank, len(ranks))
+	for _, r := range ranks {
+		ranking[r.PlayerID] = rank{PlayerID: r.PlayerID, Value: r.Value, UpdatedAt: r.UpdatedAt.UTC()}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rankings[lb.ID] = ranking
+	return nil
+}
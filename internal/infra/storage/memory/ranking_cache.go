@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type cachedTop struct {
+	Ranks     []leaderboard.Rank
+	Full      bool
+	ExpiresAt time.Time
+}
+
+// Cached top of the leaderboard, dropping it once expired. Must be called with the lock held
+func (c *connection) cachedTop(leaderboardID string) (cachedTop, bool) {
+	top, ok := c.cachedTops[leaderboardID]
+	if !ok {
+		return cachedTop{}, false
+	}
+
+	if !time.Now().Before(top.ExpiresAt) {
+		delete(c.cachedTops, leaderboardID)
+		return cachedTop{}, false
+	}
+
+	return top, true
+}
+
+func (c *connection) GetCachedTop(ctx context.Context, lb leaderboard.Leaderboard) (leaderboard.CachedTop, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	generation := c.cacheGenerations[lb.ID]
+
+	top, ok := c.cachedTop(lb.ID)
+	if !ok {
+		return leaderboard.CachedTop{Generation: generation}, false, nil
+	}
+
+	return leaderboard.CachedTop{Ranks: slices.Clone(top.Ranks), Full: top.Full, Generation: generation}, true, nil
+}
+
+func (c *connection) CacheTop(ctx context.Context, lb leaderboard.Leaderboard, top leaderboard.CachedTop, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cacheGenerations[lb.ID] != top.Generation {
+		return nil
+	}
+
+	c.cachedTops[lb.ID] = cachedTop{Ranks: slices.Clone(top.Ranks), Full: top.Full, ExpiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+func (c *connection) GetCachedTopBound(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.CachedTopBound, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	top, ok := c.cachedTop(lb.ID)
+	if !ok {
+		return leaderboard.CachedTopBound{}, false, nil
+	}
+
+	bound := leaderboard.CachedTopBound{
+		Full: top.Full && len(top.Ranks) > 0,
+		Includes: slices.ContainsFunc(top.Ranks, func(r leaderboard.Rank) bool {
+			return r.PlayerID == playerID
+		}),
+	}
+
+	if bound.Full {
+		bound.Value = top.Ranks[len(top.Ranks)-1].Value
+	}
+
+	return bound, true, nil
+}
+
+func (c *connection) InvalidateCachedTop(ctx context.Context, lb leaderboard.Leaderboard) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.cachedTops, lb.ID)
+	c.cacheGenerations[lb.ID]++
+	return nil
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedTop(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		conn = New()
+		lb   = leaderboard.Leaderboard{ID: uuid.NewString(), GameID: uuid.NewString()}
+	)
+
+	ranks := []leaderboard.Rank{
+		{LeaderboardID: lb.ID, PlayerID: "alice", Position: 0, Value: 20},
+		{LeaderboardID: lb.ID, PlayerID: "bob", Position: 1, Value: 10},
+	}
+
+	t.Run("Not Cached", func(t *testing.T) {
+		top, ok, err := conn.GetCachedTop(ctx, lb)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Zero(t, top.Generation)
+
+		_, ok, err = conn.GetCachedTopBound(ctx, lb, "alice")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Cached", func(t *testing.T) {
+		assert.NoError(t, conn.CacheTop(ctx, lb, leaderboard.CachedTop{Ranks: ranks, Full: true}, time.Minute))
+
+		cached, ok, err := conn.GetCachedTop(ctx, lb)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, leaderboard.CachedTop{Ranks: ranks, Full: true}, cached)
+
+		bound, ok, err := conn.GetCachedTopBound(ctx, lb, "alice")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, leaderboard.CachedTopBound{Full: true, Value: 10, Includes: true}, bound)
+
+		bound, _, err = conn.GetCachedTopBound(ctx, lb, "carol")
+		assert.NoError(t, err)
+		assert.False(t, bound.Includes)
+	})
+
+	t.Run("Invalidated", func(t *testing.T) {
+		assert.NoError(t, conn.InvalidateCachedTop(ctx, lb))
+
+		top, ok, err := conn.GetCachedTop(ctx, lb)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, int64(1), top.Generation)
+	})
+
+	t.Run("Stale Generation", func(t *testing.T) {
+		assert.NoError(t, conn.CacheTop(ctx, lb, leaderboard.CachedTop{Ranks: ranks, Full: true}, time.Minute))
+
+		_, ok, err := conn.GetCachedTop(ctx, lb)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		assert.NoError(t, conn.CacheTop(ctx, lb, leaderboard.CachedTop{Ranks: ranks, Full: true, Generation: 1}, time.Nanosecond))
+		time.Sleep(time.Millisecond)
+
+		_, ok, err := conn.GetCachedTop(ctx, lb)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,251 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRanking(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		lb = leaderboard.Leaderboard{
+			ID:              uuid.NewString(),
+			AggregationMode: leaderboard.AggregationModeMax,
+			Ordering:        leaderboard.OrderingAsc,
+		}
+	)
+
+	conn := New()
+	for player, value := range map[string]float64{"a": 10, "b": 30, "c": 20} {
+		err := conn.UpsertPlayerRankValue(ctx, lb, player, value)
+		assert.NoError(t, err)
+	}
+
+	t.Run("Desc", func(t *testing.T) {
+		lb := lb
+		lb.Ordering = leaderboard.OrderingDesc
+
+		ranking, err := conn.GetRanking(ctx, lb, 0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []leaderboard.Rank{
+			{LeaderboardID: lb.ID, PlayerID: "b", Position: 0, Value: 30},
+			{LeaderboardID: lb.ID, PlayerID: "c", Position: 1, Value: 20},
+		}, ranking)
+	})
+
+	t.Run("Asc Second Page", func(t *testing.T) {
+		ranking, err := conn.GetRanking(ctx, lb, 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []leaderboard.Rank{
+			{LeaderboardID: lb.ID, PlayerID: "b", Position: 2, Value: 30},
+		}, ranking)
+	})
+
+	t.Run("Page Out Of Range", func(t *testing.T) {
+		ranking, err := conn.GetRanking(ctx, lb, 5, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, ranking)
+	})
+
+	t.Run("Aggregation Keeps Max", func(t *testing.T) {
+		err := conn.UpsertPlayerRankValue(ctx, lb, "a", 5)
+		assert.NoError(t, err)
+
+		ranking, err := conn.GetRanking(ctx, lb, 0, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(10), ranking[0].Value)
+	})
+
+	t.Run("Invalid Ordering", func(t *testing.T) {
+		lb := lb
+		lb.Ordering = "INVALID"
+
+		_, err := conn.GetRanking(ctx, lb, 0, 1)
+		assert.ErrorIs(t, err, leaderboard.ErrInvalidOrdering)
+	})
+}
+
+func TestUpsertPlayerRankValue(t *testing.T) {
+	var (
+		ctx = context.Background()
+		lb  = leaderboard.Leaderboard{ID: uuid.NewString(), AggregationMode: leaderboard.AggregationModeInc, Ordering: leaderboard.OrderingDesc}
+		buf = newRequestBuffer()
+	)
+
+	conn := New()
+	for value, playerID := range []string{"alice-000000", "bob-11111111", "carol-222222", "alice-000000"} {
+		assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, buf.param(playerID), float64(value+1)))
+	}
+
+	buf.param("dave-3333333")
+
+	ranking, err := conn.GetRanking(ctx, lb, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []leaderboard.Rank{
+		{LeaderboardID: lb.ID, PlayerID: "alice-000000", Position: 0, Value: 5},
+		{LeaderboardID: lb.ID, PlayerID: "carol-222222", Position: 1, Value: 3},
+		{LeaderboardID: lb.ID, PlayerID: "bob-11111111", Position: 2, Value: 2},
+	}, ranking)
+}
+
+func TestGetPlayerRank(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		lb = leaderboard.Leaderboard{
+			ID:              uuid.NewString(),
+			AggregationMode: leaderboard.AggregationModeMax,
+			Ordering:        leaderboard.OrderingDesc,
+		}
+	)
+
+	conn := New()
+	for player, value := range map[string]float64{"a": 10, "b": 30, "c": 20} {
+		err := conn.UpsertPlayerRankValue(ctx, lb, player, value)
+		assert.NoError(t, err)
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		rank, err := conn.GetPlayerRank(ctx, lb, "c")
+		assert.NoError(t, err)
+		assert.Equal(t, leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: "c", Position: 1, Value: 20}, rank)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := conn.GetPlayerRank(ctx, lb, "d")
+		assert.ErrorIs(t, err, leaderboard.ErrPlayerRankNotFound)
+	})
+
+	t.Run("Invalid Ordering", func(t *testing.T) {
+		lb := lb
+		lb.Ordering = "INVALID"
+
+		_, err := conn.GetPlayerRank(ctx, lb, "a")
+		assert.ErrorIs(t, err, leaderboard.ErrInvalidOrdering)
+	})
+}
+
+func TestErasePlayerRanks(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		gameID = uuid.NewString()
+	)
+
+	conn := New()
+
+	active, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID, AggregationMode: leaderboard.AggregationModeMax, Ordering: leaderboard.OrderingDesc})
+	assert.NoError(t, err)
+
+	deleted, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID, AggregationMode: leaderboard.AggregationModeMax, Ordering: leaderboard.OrderingDesc})
+	assert.NoError(t, err)
+
+	otherGame, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: uuid.NewString(), AggregationMode: leaderboard.AggregationModeMax, Ordering: leaderboard.OrderingDesc})
+	assert.NoError(t, err)
+
+	for _, lb := range []leaderboard.Leaderboard{active, deleted, otherGame} {
+		err = conn.UpsertPlayerRankValue(ctx, lb, "a", 10)
+		assert.NoError(t, err)
+	}
+
+	err = conn.UpsertPlayerRankValue(ctx, active, "b", 20)
+	assert.NoError(t, err)
+
+	err = conn.SoftDeleteLeaderboard(ctx, deleted.ID, gameID)
+	assert.NoError(t, err)
+
+	count, err := conn.CountPlayerRanks(ctx, gameID, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, privacy.DataCount{Data: privacy.DataRanks, Records: 2}, count)
+
+	erasure, err := conn.ErasePlayerRanks(ctx, gameID, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, privacy.Erasure{Data: privacy.DataRanks, Records: count.Records}, erasure)
+
+	count, err = conn.CountPlayerRanks(ctx, gameID, "a")
+	assert.NoError(t, err)
+	assert.Zero(t, count.Records)
+
+	_, err = conn.GetPlayerRank(ctx, active, "a")
+	assert.ErrorIs(t, err, leaderboard.ErrPlayerRankNotFound)
+
+	rank, err := conn.GetPlayerRank(ctx, active, "b")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rank.Position)
+
+	_, err = conn.GetPlayerRank(ctx, otherGame, "a")
+	assert.NoError(t, err)
+}
+
+func TestAnonymizePlayerRanks(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		gameID    = uuid.NewString()
+		pseudonym = privacy.NewPseudonym()
+	)
+
+	conn := New()
+
+	active, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: gameID, AggregationMode: leaderboard.AggregationModeMax, Ordering: leaderboard.OrderingDesc})
+	assert.NoError(t, err)
+
+	otherGame, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: uuid.NewString(), AggregationMode: leaderboard.AggregationModeMax, Ordering: leaderboard.OrderingDesc})
+	assert.NoError(t, err)
+
+	for _, lb := range []leaderboard.Leaderboard{active, otherGame} {
+		err = conn.UpsertPlayerRankValue(ctx, lb, "a", 10)
+		assert.NoError(t, err)
+	}
+
+	err = conn.UpsertPlayerRankValue(ctx, active, "b", 20)
+	assert.NoError(t, err)
+
+	anonymization, err := conn.AnonymizePlayerRanks(ctx, gameID, "a", pseudonym)
+	assert.NoError(t, err)
+	assert.Equal(t, privacy.Anonymization{Data: privacy.DataRanks, Records: 1}, anonymization)
+
+	_, err = conn.GetPlayerRank(ctx, active, "a")
+	assert.ErrorIs(t, err, leaderboard.ErrPlayerRankNotFound)
+
+	rank, err := conn.GetPlayerRank(ctx, active, pseudonym)
+	assert.NoError(t, err)
+	assert.Equal(t, leaderboard.Rank{LeaderboardID: active.ID, PlayerID: pseudonym, Position: 1, Value: 10}, rank)
+
+	_, err = conn.GetPlayerRank(ctx, otherGame, "a")
+	assert.NoError(t, err)
+}
+
+func TestReplaceRanking(t *testing.T) {
+	var (
+		ctx = context.Background()
+		now = time.Now()
+	)
+
+	conn := New()
+
+	lb, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{GameID: uuid.NewString(), AggregationMode: leaderboard.AggregationModeInc, Ordering: leaderboard.OrderingDesc})
+	assert.NoError(t, err)
+
+	err = conn.UpsertPlayerRankValue(ctx, lb, "stale", 100)
+	assert.NoError(t, err)
+
+	err = conn.ReplaceRanking(ctx, lb, []leaderboard.RebuiltRank{
+		{UpdatedAt: now.Add(time.Second), PlayerID: "b", Value: 10},
+		{UpdatedAt: now, PlayerID: "a", Value: 10},
+	})
+	assert.NoError(t, err)
+
+	ranking, err := conn.GetRanking(ctx, lb, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []leaderboard.Rank{
+		{LeaderboardID: lb.ID, PlayerID: "a", Position: 0, Value: 10},
+		{LeaderboardID: lb.ID, PlayerID: "b", Position: 1, Value: 10},
+	}, ranking)
+}
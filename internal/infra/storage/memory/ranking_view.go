@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type topView struct {
+	Ranks     []leaderboard.Rank
+	ExpiresAt time.Time
+}
+
+// Top view of the leaderboard, dropping it once expired. Must be called with the lock held
+func (c *connection) topView(leaderboardID string) (topView, bool) {
+	view, ok := c.topViews[leaderboardID]
+	if !ok {
+		return topView{}, false
+	}
+
+	if !time.Now().Before(view.ExpiresAt) {
+		delete(c.topViews, leaderboardID)
+		return topView{}, false
+	}
+
+	return view, true
+}
+
+func (c *connection) GetTopView(ctx context.Context, lb leaderboard.Leaderboard) (leaderboard.TopView, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	version := c.topViewVersions[lb.ID]
+
+	view, ok := c.topView(lb.ID)
+	if !ok {
+		return leaderboard.TopView{Version: version}, false, nil
+	}
+
+	return leaderboard.TopView{Ranks: slices.Clone(view.Ranks), Version: version}, true, nil
+}
+
+func (c *connection) SaveTopView(ctx context.Context, lb leaderboard.Leaderboard, view leaderboard.TopView, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.topViewVersions[lb.ID] != view.Version {
+		return false, nil
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	if current, ok := c.topView(lb.ID); ok {
+		expiresAt = current.ExpiresAt
+	}
+
+	c.topViews[lb.ID] = topView{Ranks: slices.Clone(view.Ranks), ExpiresAt: expiresAt}
+	c.topViewVersions[lb.ID]++
+
+	return true, nil
+}
+
+func (c *connection) DropTopView(ctx context.Context, lb leaderboard.Leaderboard) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.topViews, lb.ID)
+	c.topViewVersions[lb.ID]++
+
+	return nil
+}
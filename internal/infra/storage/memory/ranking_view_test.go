@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopView(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		conn = New()
+		lb   = leaderboard.Leaderboard{ID: uuid.NewString(), GameID: uuid.NewString()}
+	)
+
+	ranks := []leaderboard.Rank{
+		{LeaderboardID: lb.ID, PlayerID: "alice", Position: 0, Value: 20},
+		{LeaderboardID: lb.ID, PlayerID: "bob", Position: 1, Value: 10},
+	}
+
+	t.Run("Not Built", func(t *testing.T) {
+		view, ok, err := conn.GetTopView(ctx, lb)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Zero(t, view.Version)
+	})
+
+	t.Run("Created", func(t *testing.T) {
+		saved, err := conn.SaveTopView(ctx, lb, leaderboard.TopView{Ranks: ranks}, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, saved)
+
+		view, ok, err := conn.GetTopView(ctx, lb)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, leaderboard.TopView{Ranks: ranks, Version: 1}, view)
+	})
+
+	t.Run("Updated", func(t *testing.T) {
+		saved, err := conn.SaveTopView(ctx, lb, leaderboard.TopView{Ranks: ranks[:1], Version: 1}, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, saved)
+
+		view, _, err := conn.GetTopView(ctx, lb)
+		assert.NoError(t, err)
+		assert.Equal(t, leaderboard.TopView{Ranks: ranks[:1], Version: 2}, view)
+	})
+
+	t.Run("Version Conflict", func(t *testing.T) {
+		saved, err := conn.SaveTopView(ctx, lb, leaderboard.TopView{Ranks: ranks, Version: 1}, time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, saved)
+
+		saved, err = conn.SaveTopView(ctx, lb, leaderboard.TopView{Ranks: ranks}, time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, saved)
+	})
+
+	t.Run("Dropped", func(t *testing.T) {
+		assert.NoError(t, conn.DropTopView(ctx, lb))
+
+		view, ok, err := conn.GetTopView(ctx, lb)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, int64(3), view.Version)
+
+		saved, err := conn.SaveTopView(ctx, lb, leaderboard.TopView{Ranks: ranks, Version: 2}, time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, saved)
+	})
+
+	t.Run("Expiry Kept On Update", func(t *testing.T) {
+		saved, err := conn.SaveTopView(ctx, lb, leaderboard.TopView{Ranks: ranks, Version: 3}, 50*time.Millisecond)
+		assert.NoError(t, err)
+		assert.True(t, saved)
+
+		saved, err = conn.SaveTopView(ctx, lb, leaderboard.TopView{Ranks: ranks, Version: 4}, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, saved)
+		time.Sleep(100 * time.Millisecond)
+
+		view, ok, err := conn.GetTopView(ctx, lb)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, int64(5), view.Version)
+	})
+}
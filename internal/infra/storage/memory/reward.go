@@ -0,0 +1,213 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/reward"
+
+	"github.com/google/uuid"
+)
+
+// The player ID may come from a request parameter, so it's copied to outlive the request
+func cloneGrant(g reward.Grant) reward.Grant {
+	g.GameID = strings.Clone(g.GameID)
+	g.PlayerID = strings.Clone(g.PlayerID)
+	g.Rewards = slices.Clone(g.Rewards)
+	return g
+}
+
+func (c *connection) CreateRewardRule(ctx context.Context, data reward.NewRuleData) (reward.Rule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rule := reward.Rule{
+		CreatedAt:    time.Now().UTC(),
+		ID:           uuid.NewString(),
+		GameID:       data.GameID,
+		Source:       data.Source,
+		SourceID:     data.SourceID,
+		FromPosition: data.FromPosition,
+		ToPosition:   data.ToPosition,
+		Rewards:      slices.Clone(data.Rewards),
+	}
+	c.rewardRules = append(c.rewardRules, rule)
+
+	return rule, nil
+}
+
+// Rules are appended as they are created, so they are kept ordered by creation time
+func (c *connection) ListRewardRules(ctx context.Context, gameID string) ([]reward.Rule, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	rules := make([]reward.Rule, 0)
+	for _, rule := range c.rewardRules {
+		if rule.GameID == gameID {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+func (c *connection) ListSourceRewardRules(ctx context.Context, gameID, source, sourceID string) ([]reward.Rule, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	rules := make([]reward.Rule, 0)
+	for _, rule := range c.rewardRules {
+		if rule.GameID == gameID && rule.Source == source && rule.SourceID == sourceID {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+func (c *connection) DeleteRewardRule(ctx context.Context, id, gameID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.rewardRules, func(rule reward.Rule) bool { return rule.ID == id && rule.GameID == gameID })
+	if i < 0 {
+		return reward.ErrRuleNotFound
+	}
+
+	c.rewardRules = slices.Delete(c.rewardRules, i, i+1)
+	return nil
+}
+
+func (c *connection) CreateRewardGrants(ctx context.Context, grants []reward.Grant) ([]reward.Grant, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	created := make([]reward.Grant, 0, len(grants))
+	for _, grant := range grants {
+		if slices.ContainsFunc(c.rewardGrants, func(g reward.Grant) bool { return g.ID == grant.ID }) {
+			continue
+		}
+
+		c.rewardGrants = append(c.rewardGrants, cloneGrant(grant))
+		created = append(created, grant)
+	}
+
+	return created, nil
+}
+
+func (c *connection) ClaimDueRewardGrants(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]reward.Grant, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	grants := make([]reward.Grant, 0)
+	for i := range c.rewardGrants {
+		if len(grants) == limit {
+			break
+		}
+
+		g := &c.rewardGrants[i]
+		if g.Status != reward.GrantStatusPending || g.NextAttemptAt.After(now) {
+			continue
+		}
+
+		g.NextAttemptAt = now.Add(lease)
+		grants = append(grants, cloneGrant(*g))
+	}
+
+	return grants, nil
+}
+
+func (c *connection) UpdateRewardGrant(ctx context.Context, grant reward.Grant) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.rewardGrants, func(g reward.Grant) bool { return g.ID == grant.ID })
+	if i < 0 {
+		// The player was erased alongside its grants while the grant was being sent
+		return nil
+	}
+
+	c.rewardGrants[i] = cloneGrant(grant)
+	return nil
+}
+
+func (c *connection) GetRewardGrant(ctx context.Context, id, gameID string) (reward.Grant, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, grant := range c.rewardGrants {
+		if grant.ID == id && grant.GameID == gameID {
+			return cloneGrant(grant), nil
+		}
+	}
+
+	return reward.Grant{}, reward.ErrGrantNotFound
+}
+
+// Grants are appended as they are queued, so they are read backwards to list the newest first
+func (c *connection) ListRewardGrants(ctx context.Context, gameID, playerID string, limit int) ([]reward.Grant, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	grants := make([]reward.Grant, 0)
+	for i := len(c.rewardGrants) - 1; i >= 0 && len(grants) < limit; i-- {
+		grant := c.rewardGrants[i]
+		if grant.GameID == gameID && (playerID == "" || grant.PlayerID == playerID) {
+			grants = append(grants, cloneGrant(grant))
+		}
+	}
+
+	return grants, nil
+}
+
+// Erases the rewards granted to the player on the game, including the pending ones
+func (c *connection) ErasePlayerRewardGrants(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataRewardGrants}
+	c.rewardGrants = slices.DeleteFunc(c.rewardGrants, func(grant reward.Grant) bool {
+		if grant.GameID != gameID || grant.PlayerID != playerID {
+			return false
+		}
+
+		erasure.Records++
+		return true
+	})
+
+	return erasure, nil
+}
+
+// Moves the rewards granted to the player on the game to the pseudonym
+func (c *connection) AnonymizePlayerRewardGrants(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataRewardGrants}
+	for i, grant := range c.rewardGrants {
+		if grant.GameID == gameID && grant.PlayerID == playerID {
+			c.rewardGrants[i].PlayerID = pseudonym
+			anonymization.Records++
+		}
+	}
+
+	return anonymization, nil
+}
+
+// Counts the rewards granted to the player on the game
+func (c *connection) CountPlayerRewardGrants(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataRewardGrants}
+	for _, grant := range c.rewardGrants {
+		if grant.GameID == gameID && grant.PlayerID == playerID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/reward"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReward(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		conn     = New()
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	rule, err := conn.CreateRewardRule(ctx, reward.NewRuleData{
+		GameID:   gameID,
+		Source:   reward.SourceQuest,
+		SourceID: "tutorial",
+		Rewards:  []reward.Reward{{Item: "gold", Quantity: 100}},
+	})
+	assert.NoError(t, err)
+
+	t.Run("Rules", func(t *testing.T) {
+		rules, err := conn.ListSourceRewardRules(ctx, gameID, reward.SourceQuest, "tutorial")
+		assert.NoError(t, err)
+		assert.Equal(t, []reward.Rule{rule}, rules)
+
+		rules, err = conn.ListSourceRewardRules(ctx, gameID, reward.SourceQuest, "other")
+		assert.NoError(t, err)
+		assert.Empty(t, rules)
+	})
+
+	now := time.Now().UTC()
+	grant := reward.Grant{
+		CreatedAt:     now,
+		NextAttemptAt: now,
+		ID:            reward.GrantID(rule.ID, playerID),
+		GameID:        gameID,
+		PlayerID:      playerID,
+		RuleID:        rule.ID,
+		Rewards:       rule.Rewards,
+		Status:        reward.GrantStatusPending,
+	}
+
+	t.Run("Create Grants", func(t *testing.T) {
+		created, err := conn.CreateRewardGrants(ctx, []reward.Grant{grant})
+		assert.NoError(t, err)
+		assert.Len(t, created, 1)
+
+		created, err = conn.CreateRewardGrants(ctx, []reward.Grant{grant})
+		assert.NoError(t, err)
+		assert.Empty(t, created)
+	})
+
+	t.Run("Claim", func(t *testing.T) {
+		claimed, err := conn.ClaimDueRewardGrants(ctx, now, time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+
+		claimed, err = conn.ClaimDueRewardGrants(ctx, now, time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, claimed)
+	})
+
+	t.Run("Update And List", func(t *testing.T) {
+		grant.Status = reward.GrantStatusGranted
+		assert.NoError(t, conn.UpdateRewardGrant(ctx, grant))
+
+		stored, err := conn.GetRewardGrant(ctx, grant.ID, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, reward.GrantStatusGranted, stored.Status)
+
+		grants, err := conn.ListRewardGrants(ctx, gameID, playerID, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []reward.Grant{grant}, grants)
+	})
+
+	t.Run("Erase", func(t *testing.T) {
+		count, err := conn.CountPlayerRewardGrants(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count.Records)
+
+		erasure, err := conn.ErasePlayerRewardGrants(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), erasure.Records)
+
+		_, err = conn.GetRewardGrant(ctx, grant.ID, gameID)
+		assert.ErrorIs(t, err, reward.ErrGrantNotFound)
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		buf := newRequestBuffer()
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range playerIDs {
+			id := buf.param(playerID)
+			_, err := conn.CreateRewardGrants(ctx, []reward.Grant{{
+				CreatedAt:     now,
+				NextAttemptAt: now,
+				ID:            reward.GrantID(rule.ID, id),
+				GameID:        gameID,
+				PlayerID:      id,
+				RuleID:        rule.ID,
+				Rewards:       rule.Rewards,
+				Status:        reward.GrantStatusPending,
+			}})
+			assert.NoError(t, err)
+		}
+
+		buf.param("dave-3333333")
+
+		for _, playerID := range playerIDs {
+			grants, err := conn.ListRewardGrants(ctx, gameID, playerID, 10)
+			assert.NoError(t, err)
+			if assert.Len(t, grants, 1) {
+				assert.Equal(t, playerID, grants[0].PlayerID)
+			}
+		}
+	})
+
+	t.Run("Delete Rule", func(t *testing.T) {
+		assert.NoError(t, conn.DeleteRewardRule(ctx, rule.ID, gameID))
+		assert.ErrorIs(t, conn.DeleteRewardRule(ctx, rule.ID, gameID), reward.ErrRuleNotFound)
+	})
+}
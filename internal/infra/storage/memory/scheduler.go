@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+)
+
+// The locks only exist on the process, so every instance runs the jobs by itself
+func (c *connection) AcquireSchedulerLock(ctx context.Context, job, owner string, now, until time.Time) (scheduler.Lock, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lock, ok := c.schedulerLocks[job]
+	if ok && lock.Owner != owner && lock.LockedUntil.After(now) {
+		return lock, false, nil
+	}
+
+	lock.Job = job
+	lock.Owner = owner
+	lock.LockedUntil = until
+	c.schedulerLocks[job] = lock
+
+	return lock, true, nil
+}
+
+func (c *connection) RecordSchedulerRun(ctx context.Context, job, owner string, ranAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lock, ok := c.schedulerLocks[job]
+	if !ok || lock.Owner != owner {
+		return nil
+	}
+
+	lock.LastRunAt = ranAt
+	c.schedulerLocks[job] = lock
+
+	return nil
+}
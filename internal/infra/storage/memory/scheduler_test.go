@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquireSchedulerLock(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		conn = New()
+		job  = uuid.NewString()
+		now  = time.Now().UTC()
+	)
+
+	lock, acquired, err := conn.AcquireSchedulerLock(ctx, job, "a", now, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	assert.True(t, lock.LastRunAt.IsZero())
+
+	err = conn.RecordSchedulerRun(ctx, job, "a", now)
+	assert.NoError(t, err)
+
+	// Held by another owner
+	_, acquired, err = conn.AcquireSchedulerLock(ctx, job, "b", now.Add(time.Second), now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	// Renewed by its owner
+	lock, acquired, err = conn.AcquireSchedulerLock(ctx, job, "a", now.Add(time.Second), now.Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	assert.Equal(t, now, lock.LastRunAt)
+
+	// Taken over once expired
+	lock, acquired, err = conn.AcquireSchedulerLock(ctx, job, "b", now.Add(2*time.Minute), now.Add(3*time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	assert.Equal(t, "b", lock.Owner)
+	assert.Equal(t, now, lock.LastRunAt)
+
+	// Runs are only recorded by the owner
+	err = conn.RecordSchedulerRun(ctx, job, "a", now.Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, now, conn.schedulerLocks[job].LastRunAt)
+}
@@ -0,0 +1,213 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/moderation"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+)
+
+// The score history is append only, scores are only rolled back, or removed and anonymized with their player. The
+// player ID and the fingerprint may come from the request parameters and headers, so they're copied to outlive the request
+func (c *connection) RecordScore(ctx context.Context, score leaderboard.Score) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	score.ID = uuid.NewString()
+	score.GameID = strings.Clone(score.GameID)
+	score.PlayerID = strings.Clone(score.PlayerID)
+	score.Fingerprint = leaderboard.Fingerprint{
+		DeviceHash:   strings.Clone(score.Fingerprint.DeviceHash),
+		BuildVersion: strings.Clone(score.Fingerprint.BuildVersion),
+		Region:       strings.Clone(score.Fingerprint.Region),
+	}
+	score.SubmittedAt = score.SubmittedAt.UTC()
+	c.scores = append(c.scores, score)
+
+	return nil
+}
+
+// Oldest first, with the ID breaking the ties between scores submitted at the same time
+func compareScores(a, b leaderboard.Score) int {
+	if c := a.SubmittedAt.Compare(b.SubmittedAt); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.ID, b.ID)
+}
+
+func (c *connection) ListScores(ctx context.Context, leaderboardID string, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	scores := make([]leaderboard.Score, 0)
+	for _, score := range c.scores {
+		if score.LeaderboardID != leaderboardID {
+			continue
+		}
+
+		if after.ID != "" && compareScores(score, after) <= 0 {
+			continue
+		}
+
+		scores = append(scores, score)
+	}
+
+	slices.SortFunc(scores, compareScores)
+	if len(scores) > limit {
+		scores = scores[:limit]
+	}
+
+	return scores, nil
+}
+
+func (c *connection) ListScoresBySource(ctx context.Context, gameID, source string, from, to time.Time, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	scores := make([]leaderboard.Score, 0)
+	for _, score := range c.scores {
+		if score.GameID != gameID || score.Source != source || score.SubmittedAt.Before(from) || !score.SubmittedAt.Before(to) {
+			continue
+		}
+
+		if after.ID != "" && compareScores(score, after) <= 0 {
+			continue
+		}
+
+		scores = append(scores, score)
+	}
+
+	slices.SortFunc(scores, compareScores)
+	if len(scores) > limit {
+		scores = scores[:limit]
+	}
+
+	return scores, nil
+}
+
+func (c *connection) DeleteScores(ctx context.Context, leaderboardID, playerID string, from, to time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scores = slices.DeleteFunc(c.scores, func(score leaderboard.Score) bool {
+		if score.LeaderboardID != leaderboardID || (playerID != "" && score.PlayerID != playerID) {
+			return false
+		}
+
+		return !score.SubmittedAt.Before(from) && score.SubmittedAt.Before(to)
+	})
+
+	return nil
+}
+
+func (c *connection) ListDeviceUsage(ctx context.Context, gameID string, filter moderation.DeviceUsageFilter, limit int) ([]moderation.DeviceUsage, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	type key struct{ deviceHash, playerID string }
+
+	usage := make(map[key]*moderation.DeviceUsage)
+	for _, score := range c.scores {
+		deviceHash := score.Fingerprint.DeviceHash
+		if score.GameID != gameID || deviceHash == "" {
+			continue
+		}
+
+		if (filter.DeviceHash != "" && deviceHash != filter.DeviceHash) || (filter.PlayerID != "" && score.PlayerID != filter.PlayerID) {
+			continue
+		}
+
+		u, ok := usage[key{deviceHash, score.PlayerID}]
+		if !ok {
+			u = &moderation.DeviceUsage{DeviceHash: deviceHash, PlayerID: score.PlayerID, FirstSeenAt: score.SubmittedAt}
+			usage[key{deviceHash, score.PlayerID}] = u
+		}
+
+		if score.SubmittedAt.Before(u.FirstSeenAt) {
+			u.FirstSeenAt = score.SubmittedAt
+		}
+
+		if !score.SubmittedAt.Before(u.LastSeenAt) {
+			u.LastSeenAt = score.SubmittedAt
+			u.BuildVersion = score.Fingerprint.BuildVersion
+			u.Region = score.Fingerprint.Region
+		}
+
+		u.Scores++
+	}
+
+	devices := make([]moderation.DeviceUsage, 0, len(usage))
+	for _, u := range usage {
+		devices = append(devices, *u)
+	}
+
+	slices.SortFunc(devices, func(a, b moderation.DeviceUsage) int {
+		if c := b.LastSeenAt.Compare(a.LastSeenAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.DeviceHash+a.PlayerID, b.DeviceHash+b.PlayerID)
+	})
+	if len(devices) > limit {
+		devices = devices[:limit]
+	}
+
+	return devices, nil
+}
+
+// Erases the scores the player submitted to every leaderboard of the game
+func (c *connection) ErasePlayerScores(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataScoreHistory}
+	c.scores = slices.DeleteFunc(c.scores, func(score leaderboard.Score) bool {
+		if score.GameID != gameID || score.PlayerID != playerID {
+			return false
+		}
+
+		erasure.Records++
+		return true
+	})
+
+	return erasure, nil
+}
+
+// Moves the scores the player submitted to every leaderboard of the game to the pseudonym, dropping their fingerprint
+func (c *connection) AnonymizePlayerScores(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataScoreHistory}
+	for i, score := range c.scores {
+		if score.GameID == gameID && score.PlayerID == playerID {
+			c.scores[i].PlayerID = pseudonym
+			c.scores[i].Fingerprint = leaderboard.Fingerprint{}
+			anonymization.Records++
+		}
+	}
+
+	return anonymization, nil
+}
+
+// Counts the scores the player submitted to every leaderboard of the game
+func (c *connection) CountPlayerScores(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataScoreHistory}
+	for _, score := range c.scores {
+		if score.GameID == gameID && score.PlayerID == playerID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
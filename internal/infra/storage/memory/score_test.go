@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/moderation"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScores(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		conn          = New()
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+		start         = time.Now()
+	)
+
+	for i, s := range []struct{ playerID, source string }{{"a", leaderboard.SourceMQTT}, {"b", leaderboard.SourceAPI}, {"a", leaderboard.SourceMQTT}} {
+		err := conn.RecordScore(ctx, leaderboard.Score{SubmittedAt: start.Add(time.Duration(i) * time.Second), LeaderboardID: leaderboardID, GameID: gameID, PlayerID: s.playerID, Value: float64(i), Source: s.source})
+		assert.NoError(t, err)
+	}
+
+	err := conn.RecordScore(ctx, leaderboard.Score{SubmittedAt: start, LeaderboardID: uuid.NewString(), GameID: gameID, PlayerID: "a", Value: 10})
+	assert.NoError(t, err)
+
+	first, err := conn.ListScores(ctx, leaderboardID, leaderboard.Score{}, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, first, 2) {
+		assert.Equal(t, []float64{0, 1}, []float64{first[0].Value, first[1].Value})
+	}
+
+	next, err := conn.ListScores(ctx, leaderboardID, first[1], 2)
+	assert.NoError(t, err)
+	if assert.Len(t, next, 1) {
+		assert.Equal(t, float64(2), next[0].Value)
+	}
+
+	bySource, err := conn.ListScoresBySource(ctx, gameID, leaderboard.SourceMQTT, start, start.Add(2*time.Second), leaderboard.Score{}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, bySource, 1) {
+		assert.Equal(t, float64(0), bySource[0].Value)
+	}
+
+	bySource, err = conn.ListScoresBySource(ctx, gameID, leaderboard.SourceMQTT, start, start.Add(time.Minute), bySource[0], 10)
+	assert.NoError(t, err)
+	if assert.Len(t, bySource, 1) {
+		assert.Equal(t, float64(2), bySource[0].Value)
+	}
+
+	count, err := conn.CountPlayerScores(ctx, gameID, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, privacy.DataCount{Data: privacy.DataScoreHistory, Records: 3}, count)
+
+	erasure, err := conn.ErasePlayerScores(ctx, gameID, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, privacy.Erasure{Data: privacy.DataScoreHistory, Records: count.Records}, erasure)
+
+	scores, err := conn.ListScores(ctx, leaderboardID, leaderboard.Score{}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, scores, 1) {
+		assert.Equal(t, "b", scores[0].PlayerID)
+	}
+}
+
+func TestRecordScore(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		conn          = New()
+		leaderboardID = uuid.NewString()
+		start         = time.Now()
+		buf           = newRequestBuffer()
+		deviceBuf     = newRequestBuffer()
+	)
+
+	playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+	for i, playerID := range playerIDs {
+		score := leaderboard.Score{
+			SubmittedAt:   start.Add(time.Duration(i) * time.Second),
+			LeaderboardID: leaderboardID,
+			PlayerID:      buf.param(playerID),
+			Fingerprint:   leaderboard.Fingerprint{DeviceHash: deviceBuf.param(playerID)},
+		}
+		assert.NoError(t, conn.RecordScore(ctx, score))
+	}
+
+	buf.param("dave-3333333")
+	deviceBuf.param("dave-3333333")
+
+	scores, err := conn.ListScores(ctx, leaderboardID, leaderboard.Score{}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, scores, 3) {
+		assert.Equal(t, playerIDs, []string{scores[0].PlayerID, scores[1].PlayerID, scores[2].PlayerID})
+		assert.Equal(t, playerIDs, []string{scores[0].Fingerprint.DeviceHash, scores[1].Fingerprint.DeviceHash, scores[2].Fingerprint.DeviceHash})
+	}
+}
+
+func TestDeviceUsage(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		start  = time.Now().UTC()
+	)
+
+	for i, s := range []struct{ playerID, deviceHash, buildVersion string }{{"a", "f00d", "1.0"}, {"b", "f00d", "1.0"}, {"a", "f00d", "1.1"}, {"a", "", ""}, {"a", "beef", "1.1"}} {
+		err := conn.RecordScore(ctx, leaderboard.Score{SubmittedAt: start.Add(time.Duration(i) * time.Second), LeaderboardID: uuid.NewString(), GameID: gameID, PlayerID: s.playerID, Fingerprint: leaderboard.Fingerprint{DeviceHash: s.deviceHash, BuildVersion: s.buildVersion}})
+		assert.NoError(t, err)
+	}
+
+	usage, err := conn.ListDeviceUsage(ctx, gameID, moderation.DeviceUsageFilter{DeviceHash: "f00d"}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []moderation.DeviceUsage{
+		{DeviceHash: "f00d", PlayerID: "a", BuildVersion: "1.1", FirstSeenAt: start, LastSeenAt: start.Add(2 * time.Second), Scores: 2},
+		{DeviceHash: "f00d", PlayerID: "b", BuildVersion: "1.0", FirstSeenAt: start.Add(time.Second), LastSeenAt: start.Add(time.Second), Scores: 1},
+	}, usage)
+
+	usage, err = conn.ListDeviceUsage(ctx, gameID, moderation.DeviceUsageFilter{PlayerID: "a"}, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, usage, 1) {
+		assert.Equal(t, "beef", usage[0].DeviceHash)
+	}
+
+	_, err = conn.AnonymizePlayerScores(ctx, gameID, "a", "pseudonym")
+	assert.NoError(t, err)
+
+	usage, err = conn.ListDeviceUsage(ctx, gameID, moderation.DeviceUsageFilter{DeviceHash: "f00d"}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, usage, 1) {
+		assert.Equal(t, "b", usage[0].PlayerID)
+	}
+}
+
+func TestDeleteScores(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		conn          = New()
+		gameID        = uuid.NewString()
+		leaderboardID = uuid.NewString()
+		start         = time.Now()
+	)
+
+	for i, playerID := range []string{"a", "b", "a", "b"} {
+		err := conn.RecordScore(ctx, leaderboard.Score{SubmittedAt: start.Add(time.Duration(i) * time.Second), LeaderboardID: leaderboardID, GameID: gameID, PlayerID: playerID, Value: float64(i)})
+		assert.NoError(t, err)
+	}
+
+	err := conn.DeleteScores(ctx, leaderboardID, "a", start, start.Add(time.Minute))
+	assert.NoError(t, err)
+
+	err = conn.DeleteScores(ctx, leaderboardID, "", start.Add(3*time.Second), start.Add(time.Minute))
+	assert.NoError(t, err)
+
+	scores, err := conn.ListScores(ctx, leaderboardID, leaderboard.Score{}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, scores, 1) {
+		assert.Equal(t, float64(1), scores[0].Value)
+	}
+}
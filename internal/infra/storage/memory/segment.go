@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/segment"
+
+	"github.com/google/uuid"
+)
+
+func (c *connection) CreateSegment(ctx context.Context, data segment.NewSegmentData) (segment.Segment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rules := data.Rules
+	rules.Countries = slices.Clone(rules.Countries)
+
+	s := segment.Segment{
+		CreatedAt:   time.Now().UTC(),
+		ID:          uuid.NewString(),
+		GameID:      data.GameID,
+		Name:        data.Name,
+		Description: data.Description,
+		Rules:       rules,
+	}
+	c.segments = append(c.segments, s)
+
+	return s, nil
+}
+
+func (c *connection) GetSegment(ctx context.Context, id, gameID string) (segment.Segment, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, s := range c.segments {
+		if s.ID == id && s.GameID == gameID {
+			return s, nil
+		}
+	}
+
+	return segment.Segment{}, segment.ErrSegmentNotFound
+}
+
+// Segments are appended as they are created, so they are kept ordered by creation time
+func (c *connection) ListSegments(ctx context.Context, gameID string) ([]segment.Segment, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	segments := make([]segment.Segment, 0)
+	for _, s := range c.segments {
+		if s.GameID == gameID {
+			segments = append(segments, s)
+		}
+	}
+
+	return segments, nil
+}
+
+func (c *connection) DeleteSegment(ctx context.Context, id, gameID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.segments, func(s segment.Segment) bool { return s.ID == id && s.GameID == gameID })
+	if i < 0 {
+		return segment.ErrSegmentNotFound
+	}
+
+	c.segments = slices.Delete(c.segments, i, i+1)
+	c.eligibilities = slices.DeleteFunc(c.eligibilities, func(e segment.Eligibility) bool {
+		return e.GameID == gameID && e.SegmentID == id
+	})
+
+	return nil
+}
+
+func eligibilityIndex(eligibilities []segment.Eligibility, gameID, resourceType, resourceID string) int {
+	return slices.IndexFunc(eligibilities, func(e segment.Eligibility) bool {
+		return e.GameID == gameID && e.ResourceType == resourceType && e.ResourceID == resourceID
+	})
+}
+
+// The resource ID may come from a request parameter, so it's copied to outlive the request
+func (c *connection) SetEligibility(ctx context.Context, eligibility segment.Eligibility) (segment.Eligibility, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	eligibility.ResourceID = strings.Clone(eligibility.ResourceID)
+	if i := eligibilityIndex(c.eligibilities, eligibility.GameID, eligibility.ResourceType, eligibility.ResourceID); i >= 0 {
+		c.eligibilities[i] = eligibility
+	} else {
+		c.eligibilities = append(c.eligibilities, eligibility)
+	}
+
+	return eligibility, nil
+}
+
+func (c *connection) GetEligibility(ctx context.Context, gameID, resourceType, resourceID string) (segment.Eligibility, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := eligibilityIndex(c.eligibilities, gameID, resourceType, resourceID)
+	if i < 0 {
+		return segment.Eligibility{}, segment.ErrEligibilityNotFound
+	}
+
+	return c.eligibilities[i], nil
+}
+
+func (c *connection) DeleteEligibility(ctx context.Context, gameID, resourceType, resourceID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := eligibilityIndex(c.eligibilities, gameID, resourceType, resourceID)
+	if i < 0 {
+		return segment.ErrEligibilityNotFound
+	}
+
+	c.eligibilities = slices.Delete(c.eligibilities, i, i+1)
+	return nil
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/segment"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegment(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+	)
+
+	brazil, err := conn.CreateSegment(ctx, segment.NewSegmentData{
+		GameID: gameID,
+		Name:   "Brazil",
+		Rules:  segment.Rules{Countries: []string{"BR"}},
+	})
+	assert.NoError(t, err)
+
+	t.Run("Get And List", func(t *testing.T) {
+		stored, err := conn.GetSegment(ctx, brazil.ID, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, brazil, stored)
+
+		_, err = conn.GetSegment(ctx, brazil.ID, uuid.NewString())
+		assert.ErrorIs(t, err, segment.ErrSegmentNotFound)
+
+		segments, err := conn.ListSegments(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, []segment.Segment{brazil}, segments)
+	})
+
+	t.Run("Eligibility", func(t *testing.T) {
+		eligibility := segment.Eligibility{
+			UpdatedAt:    time.Now().UTC(),
+			GameID:       gameID,
+			ResourceType: segment.ResourceLeaderboard,
+			ResourceID:   uuid.NewString(),
+			SegmentID:    uuid.NewString(),
+		}
+		_, err := conn.SetEligibility(ctx, eligibility)
+		assert.NoError(t, err)
+
+		eligibility.SegmentID = brazil.ID
+		_, err = conn.SetEligibility(ctx, eligibility)
+		assert.NoError(t, err)
+
+		stored, err := conn.GetEligibility(ctx, gameID, segment.ResourceLeaderboard, eligibility.ResourceID)
+		assert.NoError(t, err)
+		assert.Equal(t, eligibility, stored)
+
+		_, err = conn.GetEligibility(ctx, gameID, segment.ResourceQuest, eligibility.ResourceID)
+		assert.ErrorIs(t, err, segment.ErrEligibilityNotFound)
+
+		assert.NoError(t, conn.DeleteEligibility(ctx, gameID, segment.ResourceLeaderboard, eligibility.ResourceID))
+		assert.ErrorIs(t, conn.DeleteEligibility(ctx, gameID, segment.ResourceLeaderboard, eligibility.ResourceID), segment.ErrEligibilityNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		eligibility := segment.Eligibility{GameID: gameID, ResourceType: segment.ResourceQuest, ResourceID: uuid.NewString(), SegmentID: brazil.ID}
+		_, err := conn.SetEligibility(ctx, eligibility)
+		assert.NoError(t, err)
+
+		assert.NoError(t, conn.DeleteSegment(ctx, brazil.ID, gameID))
+		assert.ErrorIs(t, conn.DeleteSegment(ctx, brazil.ID, gameID), segment.ErrSegmentNotFound)
+
+		_, err = conn.GetEligibility(ctx, gameID, segment.ResourceQuest, eligibility.ResourceID)
+		assert.ErrorIs(t, err, segment.ErrEligibilityNotFound)
+	})
+}
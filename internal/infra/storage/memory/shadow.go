@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/shadow"
+)
+
+func (c *connection) shadowFlagIndex(gameID, playerID string) int {
+	return slices.IndexFunc(c.shadowFlags, func(f shadow.Flag) bool {
+		return f.GameID == gameID && f.PlayerID == playerID
+	})
+}
+
+func shadowRankIndex(f shadow.Flag, leaderboardID string) int {
+	return slices.IndexFunc(f.ShadowRanks, func(r shadow.ShadowRank) bool {
+		return r.LeaderboardID == leaderboardID
+	})
+}
+
+// The player ID may come from a request parameter, so it's copied to outlive the request
+func (c *connection) CreateShadowFlag(ctx context.Context, f shadow.Flag) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shadowFlagIndex(f.GameID, f.PlayerID) >= 0 {
+		return shadow.ErrPlayerAlreadyFlagged
+	}
+
+	f.GameID = strings.Clone(f.GameID)
+	f.PlayerID = strings.Clone(f.PlayerID)
+	f.ShadowRanks = slices.Clone(f.ShadowRanks)
+	c.shadowFlags = append(c.shadowFlags, f)
+	return nil
+}
+
+func (c *connection) GetShadowFlag(ctx context.Context, gameID, playerID string) (shadow.Flag, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := c.shadowFlagIndex(gameID, playerID)
+	if i < 0 {
+		return shadow.Flag{}, shadow.ErrFlagNotFound
+	}
+
+	f := c.shadowFlags[i]
+	f.ShadowRanks = slices.Clone(f.ShadowRanks)
+	return f, nil
+}
+
+func (c *connection) AddShadowRank(ctx context.Context, gameID, playerID string, rank shadow.ShadowRank) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.shadowFlagIndex(gameID, playerID)
+	if i < 0 {
+		return shadow.ErrFlagNotFound
+	}
+
+	if shadowRankIndex(c.shadowFlags[i], rank.LeaderboardID) < 0 {
+		c.shadowFlags[i].ShadowRanks = append(c.shadowFlags[i].ShadowRanks, rank)
+	}
+
+	return nil
+}
+
+func (c *connection) SetShadowRank(ctx context.Context, gameID, playerID string, rank shadow.ShadowRank) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.shadowFlagIndex(gameID, playerID)
+	if i < 0 {
+		return shadow.ErrFlagNotFound
+	}
+
+	if j := shadowRankIndex(c.shadowFlags[i], rank.LeaderboardID); j >= 0 {
+		c.shadowFlags[i].ShadowRanks[j].Value = rank.Value
+		return nil
+	}
+
+	c.shadowFlags[i].ShadowRanks = append(c.shadowFlags[i].ShadowRanks, rank)
+	return nil
+}
+
+func (c *connection) DeleteShadowFlag(ctx context.Context, gameID, playerID string) (shadow.Flag, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.shadowFlagIndex(gameID, playerID)
+	if i < 0 {
+		return shadow.Flag{}, shadow.ErrFlagNotFound
+	}
+
+	f := c.shadowFlags[i]
+	c.shadowFlags = slices.Delete(c.shadowFlags, i, i+1)
+	return f, nil
+}
+
+// Flags are appended as they are created, so they are kept ordered by flagging time
+func (c *connection) ListShadowFlags(ctx context.Context, gameID string) ([]shadow.Flag, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	flags := make([]shadow.Flag, 0)
+	for _, f := range c.shadowFlags {
+		if f.GameID == gameID {
+			f.ShadowRanks = slices.Clone(f.ShadowRanks)
+			flags = append(flags, f)
+		}
+	}
+
+	return flags, nil
+}
+
+// Erases the player's flag alongside their shadow ranks
+func (c *connection) ErasePlayerShadowFlag(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataShadowFlags}
+	if i := c.shadowFlagIndex(gameID, playerID); i >= 0 {
+		c.shadowFlags = slices.Delete(c.shadowFlags, i, i+1)
+		erasure.Records++
+	}
+
+	return erasure, nil
+}
+
+// Moves the player's flag to the pseudonym, so its ranks are put back under the pseudonym once it's cleared
+func (c *connection) AnonymizePlayerShadowFlag(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataShadowFlags}
+	if i := c.shadowFlagIndex(gameID, playerID); i >= 0 {
+		c.shadowFlags[i].PlayerID = pseudonym
+		anonymization.Records++
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's flag
+func (c *connection) CountPlayerShadowFlag(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataShadowFlags}
+	if c.shadowFlagIndex(gameID, playerID) >= 0 {
+		count.Records++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/shadow"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShadowFlag(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		conn     = New()
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+		previous = 10.0
+	)
+
+	flag := shadow.Flag{FlaggedAt: time.Now().UTC(), GameID: gameID, PlayerID: playerID, Reason: "score spike"}
+
+	t.Run("Create", func(t *testing.T) {
+		assert.NoError(t, conn.CreateShadowFlag(ctx, flag))
+		assert.ErrorIs(t, conn.CreateShadowFlag(ctx, flag), shadow.ErrPlayerAlreadyFlagged)
+	})
+
+	t.Run("Shadow Ranks", func(t *testing.T) {
+		assert.NoError(t, conn.AddShadowRank(ctx, gameID, playerID, shadow.ShadowRank{LeaderboardID: "season", Value: 10, Previous: &previous}))
+		assert.NoError(t, conn.AddShadowRank(ctx, gameID, playerID, shadow.ShadowRank{LeaderboardID: "season", Value: 99}))
+		assert.NoError(t, conn.SetShadowRank(ctx, gameID, playerID, shadow.ShadowRank{LeaderboardID: "season", Value: 20}))
+		assert.NoError(t, conn.SetShadowRank(ctx, gameID, playerID, shadow.ShadowRank{LeaderboardID: "weekly", Value: 5}))
+		assert.ErrorIs(t, conn.SetShadowRank(ctx, gameID, uuid.NewString(), shadow.ShadowRank{LeaderboardID: "season"}), shadow.ErrFlagNotFound)
+		assert.ErrorIs(t, conn.AddShadowRank(ctx, gameID, uuid.NewString(), shadow.ShadowRank{LeaderboardID: "season"}), shadow.ErrFlagNotFound)
+
+		f, err := conn.GetShadowFlag(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, []shadow.ShadowRank{{LeaderboardID: "season", Value: 20, Previous: &previous}, {LeaderboardID: "weekly", Value: 5}}, f.ShadowRanks)
+		flag.ShadowRanks = f.ShadowRanks
+	})
+
+	t.Run("List", func(t *testing.T) {
+		flags, err := conn.ListShadowFlags(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, []shadow.Flag{flag}, flags)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		f, err := conn.DeleteShadowFlag(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, flag, f)
+
+		_, err = conn.DeleteShadowFlag(ctx, gameID, playerID)
+		assert.ErrorIs(t, err, shadow.ErrFlagNotFound)
+	})
+
+	t.Run("Privacy", func(t *testing.T) {
+		assert.NoError(t, conn.CreateShadowFlag(ctx, flag))
+
+		count, err := conn.CountPlayerShadowFlag(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count.Records)
+
+		anonymization, err := conn.AnonymizePlayerShadowFlag(ctx, gameID, playerID, "pseudonym")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), anonymization.Records)
+
+		erasure, err := conn.ErasePlayerShadowFlag(ctx, gameID, "pseudonym")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), erasure.Records)
+
+		flags, err := conn.ListShadowFlags(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Empty(t, flags)
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		buf := newRequestBuffer()
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range playerIDs {
+			assert.NoError(t, conn.CreateShadowFlag(ctx, shadow.Flag{GameID: gameID, PlayerID: buf.param(playerID)}))
+		}
+
+		buf.param("dave-3333333")
+
+		for _, playerID := range playerIDs {
+			f, err := conn.GetShadowFlag(ctx, gameID, playerID)
+			assert.NoError(t, err)
+			assert.Equal(t, playerID, f.PlayerID)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/purge"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+)
+
+func (c *connection) CreateStatistic(ctx context.Context, data statistic.NewStatisticData) (statistic.Statistic, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, st := range c.statistics {
+		if st.GameID == data.GameID && st.Name == data.Name && st.DeletedAt.IsZero() {
+			return statistic.Statistic{}, statistic.ErrStatisticNameAlreadyInUse
+		}
+	}
+
+	st := statistic.Statistic{
+		CreatedAt:       time.Now().UTC(),
+		UpdatedAt:       time.Now().UTC(),
+		ID:              uuid.NewString(),
+		GameID:          data.GameID,
+		Name:            data.Name,
+		Description:     data.Description,
+		AggregationMode: data.AggregationMode,
+		InitialValue:    data.InitialValue,
+		Goal:            data.Goal,
+		Landmarks:       slices.Clone(data.Landmarks),
+	}
+	c.statistics[st.ID] = st
+
+	return st, nil
+}
+
+func (c *connection) GetStatisticByIDAndGameID(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	st, ok := c.statistics[id]
+	if !ok || !st.DeletedAt.IsZero() || st.GameID != gameID {
+		return statistic.Statistic{}, statistic.ErrStatisticNotFound
+	}
+
+	return st, nil
+}
+
+// Lists the statistics oldest first, like the Postgres driver
+func (c *connection) ListStatistics(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	statistics := make([]statistic.Statistic, 0)
+	for _, st := range c.statistics {
+		if st.GameID == gameID && st.DeletedAt.IsZero() {
+			statistics = append(statistics, st)
+		}
+	}
+
+	slices.SortFunc(statistics, func(a, b statistic.Statistic) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return statistics, nil
+}
+
+func (c *connection) SoftDeleteStatistic(ctx context.Context, id, gameID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	st, ok := c.statistics[id]
+	if !ok || !st.DeletedAt.IsZero() || st.GameID != gameID {
+		return statistic.ErrStatisticNotFound
+	}
+
+	st.DeletedAt = time.Now().UTC()
+	c.statistics[id] = st
+
+	return nil
+}
+
+func (c *connection) PurgeSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records := make([]purge.Record, 0)
+	for id, st := range c.statistics {
+		if !softDeletedBefore(st.DeletedAt, st.GameID, gameID, deletedBefore) {
+			continue
+		}
+
+		delete(c.statistics, id)
+		for key := range c.playersStatistics {
+			if key.StatisticID == id {
+				delete(c.playersStatistics, key)
+			}
+		}
+
+		records = append(records, purge.Record{
+			DeletedAt: st.DeletedAt,
+			Entity:    purge.EntityStatistic,
+			ID:        id,
+			GameID:    st.GameID,
+		})
+	}
+
+	return records, nil
+}
+
+func (c *connection) ListSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	records := make([]purge.Record, 0)
+	for id, st := range c.statistics {
+		if softDeletedBefore(st.DeletedAt, st.GameID, gameID, deletedBefore) {
+			records = append(records, purge.Record{
+				DeletedAt: st.DeletedAt,
+				Entity:    purge.EntityStatistic,
+				ID:        id,
+				GameID:    st.GameID,
+			})
+		}
+	}
+
+	return records, nil
+}
@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+type submissionKey struct {
+	gameID   string
+	playerID string
+}
+
+// Only the counts of the latest window are kept, so they're all dropped once a submission opens a new one.
+// A submission racing into an earlier window is counted on the latest one
+func (c *connection) IncrementPlayerSubmissions(ctx context.Context, gameID, playerID string, window time.Time) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if window.After(c.submissionsWindow) {
+		clear(c.submissions)
+		c.submissionsWindow = window
+	}
+
+	key := submissionKey{gameID: strings.Clone(gameID), playerID: strings.Clone(playerID)}
+	c.submissions[key]++
+	return c.submissions[key], nil
+}
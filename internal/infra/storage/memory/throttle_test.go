@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayerSubmissions(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+		window = time.Now().Truncate(time.Minute)
+	)
+
+	count, err := conn.IncrementPlayerSubmissions(ctx, gameID, "alice", window)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = conn.IncrementPlayerSubmissions(ctx, gameID, "alice", window)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	count, err = conn.IncrementPlayerSubmissions(ctx, uuid.NewString(), "alice", window)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = conn.IncrementPlayerSubmissions(ctx, gameID, "alice", window.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = conn.IncrementPlayerSubmissions(ctx, gameID, "alice", window)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
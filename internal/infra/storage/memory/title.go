@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/title"
+
+	"github.com/google/uuid"
+)
+
+func (c *connection) CreateTitle(ctx context.Context, data title.NewTitleData) (title.Title, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := title.Title{
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+		ID:          uuid.NewString(),
+		GameID:      data.GameID,
+		Name:        data.Name,
+		Description: data.Description,
+		BadgeURL:    data.BadgeURL,
+		Trigger:     data.Trigger,
+	}
+	c.titles = append(c.titles, t)
+
+	return t, nil
+}
+
+func (c *connection) GetTitle(ctx context.Context, id, gameID string) (title.Title, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, t := range c.titles {
+		if t.ID == id && t.GameID == gameID {
+			return t, nil
+		}
+	}
+
+	return title.Title{}, title.ErrTitleNotFound
+}
+
+// Titles are appended as they are created, so they are kept ordered by creation time
+func (c *connection) ListTitles(ctx context.Context, gameID string) ([]title.Title, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	titles := make([]title.Title, 0)
+	for _, t := range c.titles {
+		if t.GameID == gameID {
+			titles = append(titles, t)
+		}
+	}
+
+	return titles, nil
+}
+
+func (c *connection) DeleteTitle(ctx context.Context, id, gameID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.titles, func(t title.Title) bool { return t.ID == id && t.GameID == gameID })
+	if i < 0 {
+		return title.ErrTitleNotFound
+	}
+
+	c.titles = slices.Delete(c.titles, i, i+1)
+	c.titleGrants = slices.DeleteFunc(c.titleGrants, func(grant title.Grant) bool { return grant.TitleID == id })
+
+	return nil
+}
+
+// The player IDs may come from a request parameter, so they're copied to outlive the request
+func (c *connection) GrantTitles(ctx context.Context, grants []title.Grant) ([]title.Grant, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	recorded := make([]title.Grant, 0, len(grants))
+	for _, grant := range grants {
+		granted := slices.ContainsFunc(c.titleGrants, func(g title.Grant) bool {
+			return g.TitleID == grant.TitleID && g.PlayerID == grant.PlayerID
+		})
+		if granted {
+			continue
+		}
+
+		recorded = append(recorded, grant)
+
+		grant.GameID = strings.Clone(grant.GameID)
+		grant.PlayerID = strings.Clone(grant.PlayerID)
+		c.titleGrants = append(c.titleGrants, grant)
+	}
+
+	return recorded, nil
+}
+
+// Grants are appended as they happen, so they are kept ordered by grant time
+func (c *connection) ListTitleGrants(ctx context.Context, gameID string, playerIDs []string) ([]title.Grant, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	grants := make([]title.Grant, 0)
+	for _, grant := range c.titleGrants {
+		if grant.GameID == gameID && slices.Contains(playerIDs, grant.PlayerID) {
+			grants = append(grants, grant)
+		}
+	}
+
+	return grants, nil
+}
+
+// Erases the titles granted to the player on the game
+func (c *connection) ErasePlayerTitles(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataTitles}
+	c.titleGrants = slices.DeleteFunc(c.titleGrants, func(grant title.Grant) bool {
+		if grant.GameID != gameID || grant.PlayerID != playerID {
+			return false
+		}
+
+		erasure.Records++
+		return true
+	})
+
+	return erasure, nil
+}
+
+// Moves the titles granted to the player on the game to the pseudonym
+func (c *connection) AnonymizePlayerTitles(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataTitles}
+	for i, grant := range c.titleGrants {
+		if grant.GameID == gameID && grant.PlayerID == playerID {
+			c.titleGrants[i].PlayerID = pseudonym
+			anonymization.Records++
+		}
+	}
+
+	return anonymization, nil
+}
+
+// Counts the titles granted to the player on the game
+func (c *connection) CountPlayerTitles(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataTitles}
+	for _, grant := range c.titleGrants {
+		if grant.GameID == gameID && grant.PlayerID == playerID {
+			count.Records++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/title"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTitle(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		conn     = New()
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	champion, err := conn.CreateTitle(ctx, title.NewTitleData{
+		GameID:  gameID,
+		Name:    "Champion",
+		Trigger: title.Trigger{Kind: title.TriggerRank, LeaderboardID: uuid.NewString(), Top: 1},
+	})
+	assert.NoError(t, err)
+
+	t.Run("Get And List", func(t *testing.T) {
+		stored, err := conn.GetTitle(ctx, champion.ID, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, champion, stored)
+
+		_, err = conn.GetTitle(ctx, champion.ID, uuid.NewString())
+		assert.ErrorIs(t, err, title.ErrTitleNotFound)
+
+		titles, err := conn.ListTitles(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, []title.Title{champion}, titles)
+	})
+
+	t.Run("Grant", func(t *testing.T) {
+		grant := title.Grant{TitleID: champion.ID, GameID: gameID, PlayerID: playerID, GrantedAt: time.Now().UTC()}
+		other := title.Grant{TitleID: champion.ID, GameID: gameID, PlayerID: uuid.NewString(), GrantedAt: time.Now().UTC()}
+
+		recorded, err := conn.GrantTitles(ctx, []title.Grant{grant, other})
+		assert.NoError(t, err)
+		assert.Equal(t, []title.Grant{grant, other}, recorded)
+
+		recorded, err = conn.GrantTitles(ctx, []title.Grant{grant})
+		assert.NoError(t, err)
+		assert.Empty(t, recorded)
+
+		grants, err := conn.ListTitleGrants(ctx, gameID, []string{playerID})
+		assert.NoError(t, err)
+		assert.Equal(t, []title.Grant{grant}, grants)
+
+		grants, err = conn.ListTitleGrants(ctx, gameID, []string{playerID, other.PlayerID})
+		assert.NoError(t, err)
+		assert.Len(t, grants, 2)
+
+		count, err := conn.CountPlayerTitles(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count.Records)
+	})
+
+	t.Run("Anonymize And Erase", func(t *testing.T) {
+		pseudonym := uuid.NewString()
+
+		anonymization, err := conn.AnonymizePlayerTitles(ctx, gameID, playerID, pseudonym)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), anonymization.Records)
+
+		erasure, err := conn.ErasePlayerTitles(ctx, gameID, pseudonym)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), erasure.Records)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, conn.DeleteTitle(ctx, champion.ID, gameID))
+		assert.ErrorIs(t, conn.DeleteTitle(ctx, champion.ID, gameID), title.ErrTitleNotFound)
+
+		grants, err := conn.ListTitleGrants(ctx, gameID, []string{playerID})
+		assert.NoError(t, err)
+		assert.Empty(t, grants)
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		buf := newRequestBuffer()
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range playerIDs {
+			_, err := conn.GrantTitles(ctx, []title.Grant{{TitleID: champion.ID, GameID: gameID, PlayerID: buf.param(playerID)}})
+			assert.NoError(t, err)
+		}
+
+		buf.param("dave-3333333")
+
+		grants, err := conn.ListTitleGrants(ctx, gameID, playerIDs)
+		assert.NoError(t, err)
+
+		granted := make([]string, 0, len(grants))
+		for _, grant := range grants {
+			granted = append(granted, grant.PlayerID)
+		}
+
+		assert.ElementsMatch(t, playerIDs, granted)
+	})
+}
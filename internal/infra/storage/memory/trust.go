@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/trust"
+)
+
+func (c *connection) trustRecordIndex(gameID, playerID string) int {
+	return slices.IndexFunc(c.trustRecords, func(r trust.Record) bool {
+		return r.GameID == gameID && r.PlayerID == playerID
+	})
+}
+
+// Index of the player's record, created as first seen at `seenAt` when they have none. Must be called with the lock held.
+// The player ID may come from a request parameter, so it's copied to outlive the request
+func (c *connection) trackTrustRecord(gameID, playerID string, seenAt time.Time) int {
+	i := c.trustRecordIndex(gameID, playerID)
+	if i < 0 {
+		c.trustRecords = append(c.trustRecords, trust.Record{FirstSeenAt: seenAt, GameID: strings.Clone(gameID), PlayerID: strings.Clone(playerID)})
+		i = len(c.trustRecords) - 1
+	}
+
+	return i
+}
+
+func (c *connection) TrackTrustRecord(ctx context.Context, gameID, playerID string, seenAt time.Time) (trust.Record, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.trustRecords[c.trackTrustRecord(gameID, playerID, seenAt)], nil
+}
+
+func (c *connection) IncrementTrustRecord(ctx context.Context, gameID, playerID string, flagged, cleared int64, seenAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.trackTrustRecord(gameID, playerID, seenAt)
+	c.trustRecords[i].Flagged += flagged
+	c.trustRecords[i].Cleared += cleared
+	return nil
+}
+
+func (c *connection) GetTrustRecord(ctx context.Context, gameID, playerID string) (trust.Record, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := c.trustRecordIndex(gameID, playerID)
+	if i < 0 {
+		return trust.Record{}, trust.ErrRecordNotFound
+	}
+
+	return c.trustRecords[i], nil
+}
+
+// Erases the player's trust record
+func (c *connection) ErasePlayerTrustRecord(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	erasure := privacy.Erasure{Data: privacy.DataTrust}
+	if i := c.trustRecordIndex(gameID, playerID); i >= 0 {
+		c.trustRecords = slices.Delete(c.trustRecords, i, i+1)
+		erasure.Records++
+	}
+
+	return erasure, nil
+}
+
+// Moves the player's trust record to the pseudonym
+func (c *connection) AnonymizePlayerTrustRecord(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	anonymization := privacy.Anonymization{Data: privacy.DataTrust}
+	if i := c.trustRecordIndex(gameID, playerID); i >= 0 {
+		c.trustRecords[i].PlayerID = pseudonym
+		anonymization.Records++
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's trust record
+func (c *connection) CountPlayerTrustRecord(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := privacy.DataCount{Data: privacy.DataTrust}
+	if c.trustRecordIndex(gameID, playerID) >= 0 {
+		count.Records++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/trust"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrustRecord(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		conn     = New()
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+		seenAt   = time.Now().UTC()
+	)
+
+	t.Run("Track", func(t *testing.T) {
+		_, err := conn.GetTrustRecord(ctx, gameID, playerID)
+		assert.ErrorIs(t, err, trust.ErrRecordNotFound)
+
+		record, err := conn.TrackTrustRecord(ctx, gameID, playerID, seenAt)
+		assert.NoError(t, err)
+		assert.Equal(t, trust.Record{FirstSeenAt: seenAt, GameID: gameID, PlayerID: playerID}, record)
+
+		record, err = conn.TrackTrustRecord(ctx, gameID, playerID, seenAt.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, seenAt, record.FirstSeenAt)
+	})
+
+	t.Run("Increment", func(t *testing.T) {
+		assert.NoError(t, conn.IncrementTrustRecord(ctx, gameID, playerID, 2, 0, seenAt))
+		assert.NoError(t, conn.IncrementTrustRecord(ctx, gameID, playerID, 0, 1, seenAt))
+
+		record, err := conn.GetTrustRecord(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, trust.Record{FirstSeenAt: seenAt, GameID: gameID, PlayerID: playerID, Flagged: 2, Cleared: 1}, record)
+	})
+
+	t.Run("Erase", func(t *testing.T) {
+		erasure, err := conn.ErasePlayerTrustRecord(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, privacy.Erasure{Data: privacy.DataTrust, Records: 1}, erasure)
+
+		count, err := conn.CountPlayerTrustRecord(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Zero(t, count.Records)
+	})
+
+	t.Run("Player ID Outlives The Request", func(t *testing.T) {
+		buf := newRequestBuffer()
+
+		playerIDs := []string{"alice-000000", "bob-11111111", "carol-222222"}
+		for _, playerID := range playerIDs {
+			_, err := conn.TrackTrustRecord(ctx, gameID, buf.param(playerID), seenAt)
+			assert.NoError(t, err)
+		}
+
+		buf.param("dave-3333333")
+
+		for _, playerID := range playerIDs {
+			record, err := conn.GetTrustRecord(ctx, gameID, playerID)
+			assert.NoError(t, err)
+			assert.Equal(t, playerID, record.PlayerID)
+		}
+	})
+}
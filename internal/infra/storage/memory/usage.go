@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/usage"
+)
+
+type dailyUsageKey struct {
+	Day    time.Time
+	GameID string
+}
+
+func (c *connection) AddUsage(ctx context.Context, dailyUsage []usage.DailyUsage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, u := range dailyUsage {
+		key := dailyUsageKey{Day: usage.Day(u.Day), GameID: u.GameID}
+
+		stored, ok := c.dailyUsage[key]
+		if !ok {
+			stored = usage.DailyUsage{Day: key.Day, GameID: key.GameID}
+		}
+
+		stored.Requests += u.Requests
+		stored.ScoreSubmissions += u.ScoreSubmissions
+		for code, count := range u.Errors {
+			if stored.Errors == nil {
+				stored.Errors = make(usage.ErrorCounts)
+			}
+
+			stored.Errors[code] += count
+		}
+		c.dailyUsage[key] = stored
+	}
+
+	return nil
+}
+
+func (c *connection) ListUsage(ctx context.Context, gameID string, from, to time.Time) ([]usage.DailyUsage, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	dailyUsage := make([]usage.DailyUsage, 0)
+	for key, u := range c.dailyUsage {
+		if key.GameID == gameID && !key.Day.Before(from) && !key.Day.After(to) {
+			dailyUsage = append(dailyUsage, u)
+		}
+	}
+
+	slices.SortFunc(dailyUsage, func(a, b usage.DailyUsage) int { return a.Day.Compare(b.Day) })
+	return dailyUsage, nil
+}
+
+// Counts the leaderboards, statistics and quests of the game alongside the player data kept for them.
+// Records are held as Go values, so their size is estimated by their JSON encoding
+func (c *connection) GameFootprint(ctx context.Context, gameID string) (usage.Footprint, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var (
+		footprint usage.Footprint
+		err       error
+	)
+
+	add := func(record any) {
+		if err != nil {
+			return
+		}
+
+		var b []byte
+		if b, err = json.Marshal(record); err == nil {
+			footprint.Records++
+			footprint.Bytes += int64(len(b))
+		}
+	}
+
+	for id, lb := range c.leaderboards {
+		if lb.GameID != gameID {
+			continue
+		}
+
+		add(lb)
+		for _, r := range c.rankings[id] {
+			add(r)
+		}
+	}
+
+	for _, st := range c.statistics {
+		if st.GameID == gameID {
+			add(st)
+		}
+	}
+
+	for key, progression := range c.playersStatistics {
+		if c.statistics[key.StatisticID].GameID == gameID {
+			add(progression)
+		}
+	}
+
+	for _, q := range c.quests {
+		if q.GameID == gameID {
+			add(q)
+		}
+	}
+
+	for key, progression := range c.playerQuests {
+		if c.quests[key.QuestID].GameID == gameID {
+			add(progression)
+		}
+	}
+
+	return footprint, err
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/usage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		conn      = New()
+		gameID    = uuid.NewString()
+		today     = usage.Day(time.Now())
+		yesterday = today.Add(-24 * time.Hour)
+	)
+
+	t.Run("Add And List", func(t *testing.T) {
+		err := conn.AddUsage(ctx, []usage.DailyUsage{
+			{Day: today, GameID: gameID, Requests: 3, ScoreSubmissions: 1, Errors: usage.ErrorCounts{"1.2": 1}},
+			{Day: yesterday, GameID: gameID, Requests: 2},
+			{Day: today, GameID: uuid.NewString(), Requests: 7},
+		})
+		assert.NoError(t, err)
+
+		err = conn.AddUsage(ctx, []usage.DailyUsage{{Day: today, GameID: gameID, Requests: 1, ScoreSubmissions: 1, Errors: usage.ErrorCounts{"1.2": 1, "0.0": 1}}})
+		assert.NoError(t, err)
+
+		dailyUsage, err := conn.ListUsage(ctx, gameID, yesterday, today)
+		assert.NoError(t, err)
+		assert.Equal(t, []usage.DailyUsage{
+			{Day: yesterday, GameID: gameID, Requests: 2},
+			{Day: today, GameID: gameID, Requests: 4, ScoreSubmissions: 2, Errors: usage.ErrorCounts{"1.2": 2, "0.0": 1}},
+		}, dailyUsage)
+
+		dailyUsage, err = conn.ListUsage(ctx, gameID, today, today)
+		assert.NoError(t, err)
+		assert.Len(t, dailyUsage, 1)
+	})
+
+	t.Run("Game Footprint", func(t *testing.T) {
+		footprint, err := conn.GameFootprint(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Zero(t, footprint)
+
+		st, err := conn.CreateStatistic(ctx, statistic.NewStatisticData{GameID: gameID, Name: "Kills", AggregationMode: statistic.AggregationModeSum})
+		assert.NoError(t, err)
+
+		_, _, err = conn.UpdatePlayerStatisticProgression(ctx, st, uuid.NewString(), 1)
+		assert.NoError(t, err)
+
+		_, err = conn.CreateStatistic(ctx, statistic.NewStatisticData{GameID: uuid.NewString(), Name: "Kills", AggregationMode: statistic.AggregationModeSum})
+		assert.NoError(t, err)
+
+		footprint, err = conn.GameFootprint(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), footprint.Records)
+		assert.Positive(t, footprint.Bytes)
+	})
+}
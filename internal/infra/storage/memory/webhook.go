@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/webhook"
+
+	"github.com/google/uuid"
+)
+
+// Deliveries hold slices that are changed on each attempt, so they are copied in and out of the storage
+func cloneDelivery(d webhook.Delivery) webhook.Delivery {
+	d.Payload = slices.Clone(d.Payload)
+	d.Attempts = slices.Clone(d.Attempts)
+	return d
+}
+
+// Filters are replaced as a whole, but their lists are copied so the callers can't change the stored ones
+func cloneFilter(f webhook.Filter) webhook.Filter {
+	return webhook.Filter{
+		Events:         slices.Clone(f.Events),
+		LeaderboardIDs: slices.Clone(f.LeaderboardIDs),
+		StatisticIDs:   slices.Clone(f.StatisticIDs),
+		QuestIDs:       slices.Clone(f.QuestIDs),
+	}
+}
+
+func (c *connection) CreateWebhook(ctx context.Context, data webhook.NewWebhookData) (webhook.Webhook, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wh := webhook.Webhook{
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+		ID:        uuid.NewString(),
+		GameID:    data.GameID,
+		URL:       data.URL,
+		Secret:    data.Secret,
+		Filter:    cloneFilter(data.Filter),
+	}
+	c.webhooks = append(c.webhooks, wh)
+
+	return wh, nil
+}
+
+// Webhooks are appended as they are created, so they are kept ordered by creation time
+func (c *connection) ListWebhooks(ctx context.Context, gameID string) ([]webhook.Webhook, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	webhooks := make([]webhook.Webhook, 0)
+	for _, wh := range c.webhooks {
+		if wh.GameID == gameID {
+			webhooks = append(webhooks, wh)
+		}
+	}
+
+	return webhooks, nil
+}
+
+func (c *connection) webhookIndex(id string) (int, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return 0, webhook.ErrInvalidWebhookID
+	}
+
+	i := slices.IndexFunc(c.webhooks, func(wh webhook.Webhook) bool { return wh.ID == id })
+	if i < 0 {
+		return 0, webhook.ErrWebhookNotFound
+	}
+
+	return i, nil
+}
+
+func (c *connection) gameWebhookIndex(id, gameID string) (int, error) {
+	i, err := c.webhookIndex(id)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.webhooks[i].GameID != gameID {
+		return 0, webhook.ErrWebhookNotFound
+	}
+
+	return i, nil
+}
+
+func (c *connection) GetWebhook(ctx context.Context, id string) (webhook.Webhook, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i, err := c.webhookIndex(id)
+	if err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	return c.webhooks[i], nil
+}
+
+func (c *connection) DeleteWebhook(ctx context.Context, id, gameID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.gameWebhookIndex(id, gameID)
+	if err != nil {
+		return err
+	}
+
+	c.webhooks = slices.Delete(c.webhooks, i, i+1)
+	c.deliveries = slices.DeleteFunc(c.deliveries, func(d webhook.Delivery) bool { return d.WebhookID == id })
+	return nil
+}
+
+func (c *connection) EnableWebhook(ctx context.Context, id, gameID string) (webhook.Webhook, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.gameWebhookIndex(id, gameID)
+	if err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	c.webhooks[i].DisabledAt = time.Time{}
+	c.webhooks[i].ConsecutiveFailures = 0
+	c.webhooks[i].UpdatedAt = time.Now().UTC()
+
+	return c.webhooks[i], nil
+}
+
+func (c *connection) UpdateWebhookFilter(ctx context.Context, id, gameID string, filter webhook.Filter) (webhook.Webhook, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.gameWebhookIndex(id, gameID)
+	if err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	c.webhooks[i].Filter = cloneFilter(filter)
+	c.webhooks[i].UpdatedAt = time.Now().UTC()
+
+	return c.webhooks[i], nil
+}
+
+func (c *connection) RecordWebhookAttempt(ctx context.Context, id string, succeeded bool, disableAfter int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.webhookIndex(id)
+	if err != nil {
+		return err
+	}
+
+	wh := &c.webhooks[i]
+	if succeeded {
+		wh.ConsecutiveFailures = 0
+		return nil
+	}
+
+	wh.ConsecutiveFailures++
+	if wh.ConsecutiveFailures >= disableAfter && wh.DisabledAt.IsZero() {
+		wh.DisabledAt = time.Now().UTC()
+		wh.UpdatedAt = wh.DisabledAt
+	}
+
+	return nil
+}
+
+// Deliveries are appended as they are queued, so they are kept ordered by creation time
+func (c *connection) CreateDeliveries(ctx context.Context, deliveries []webhook.Delivery) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, d := range deliveries {
+		d = cloneDelivery(d)
+		d.ID = uuid.NewString()
+		c.deliveries = append(c.deliveries, d)
+	}
+
+	return nil
+}
+
+func (c *connection) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhook.Delivery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deliveries := make([]webhook.Delivery, 0)
+	for i := range c.deliveries {
+		if len(deliveries) == limit {
+			break
+		}
+
+		d := &c.deliveries[i]
+		if d.Status != webhook.DeliveryStatusPending || d.NextAttemptAt.After(now) {
+			continue
+		}
+
+		d.NextAttemptAt = now.Add(lease)
+		deliveries = append(deliveries, cloneDelivery(*d))
+	}
+
+	return deliveries, nil
+}
+
+func (c *connection) UpdateDelivery(ctx context.Context, delivery webhook.Delivery) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.deliveries, func(d webhook.Delivery) bool { return d.ID == delivery.ID })
+	if i < 0 {
+		// The webhook was removed alongside its deliveries while the delivery was being sent
+		return nil
+	}
+
+	c.deliveries[i].Status = delivery.Status
+	c.deliveries[i].NextAttemptAt = delivery.NextAttemptAt
+	c.deliveries[i].Attempts = slices.Clone(delivery.Attempts)
+	return nil
+}
+
+func (c *connection) ListDeliveries(ctx context.Context, webhookID string, limit int) ([]webhook.Delivery, error) {
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return nil, webhook.ErrInvalidWebhookID
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	deliveries := make([]webhook.Delivery, 0)
+	for i := len(c.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if c.deliveries[i].WebhookID == webhookID {
+			deliveries = append(deliveries, cloneDelivery(c.deliveries[i]))
+		}
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/webhook"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = New()
+		gameID = uuid.NewString()
+	)
+
+	wh, err := conn.CreateWebhook(ctx, webhook.NewWebhookData{GameID: gameID, URL: "https://example.com", Secret: "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", wh.Secret)
+
+	webhooks, err := conn.ListWebhooks(ctx, gameID)
+	assert.NoError(t, err)
+	assert.Equal(t, []webhook.Webhook{wh}, webhooks)
+
+	t.Run("Disable After Failures", func(t *testing.T) {
+		assert.NoError(t, conn.RecordWebhookAttempt(ctx, wh.ID, false, 2))
+		assert.NoError(t, conn.RecordWebhookAttempt(ctx, wh.ID, true, 2))
+		assert.NoError(t, conn.RecordWebhookAttempt(ctx, wh.ID, false, 2))
+
+		got, err := conn.GetWebhook(ctx, wh.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.ConsecutiveFailures)
+		assert.True(t, got.DisabledAt.IsZero())
+
+		assert.NoError(t, conn.RecordWebhookAttempt(ctx, wh.ID, false, 2))
+
+		got, err = conn.GetWebhook(ctx, wh.ID)
+		assert.NoError(t, err)
+		assert.False(t, got.DisabledAt.IsZero())
+
+		got, err = conn.EnableWebhook(ctx, wh.ID, gameID)
+		assert.NoError(t, err)
+		assert.Zero(t, got.ConsecutiveFailures)
+		assert.True(t, got.DisabledAt.IsZero())
+	})
+
+	t.Run("Update Filter", func(t *testing.T) {
+		filter := webhook.Filter{Events: []string{"gameblitz.leaderboard.lifecycle"}, LeaderboardIDs: []string{uuid.NewString()}}
+
+		_, err := conn.UpdateWebhookFilter(ctx, wh.ID, uuid.NewString(), filter)
+		assert.ErrorIs(t, err, webhook.ErrWebhookNotFound)
+
+		got, err := conn.UpdateWebhookFilter(ctx, wh.ID, gameID, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, filter, got.Filter)
+
+		filter.Events[0] = "changed"
+
+		got, err = conn.GetWebhook(ctx, wh.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "gameblitz.leaderboard.lifecycle", got.Filter.Events[0])
+	})
+
+	t.Run("Deliveries", func(t *testing.T) {
+		now := time.Now().UTC()
+
+		err := conn.CreateDeliveries(ctx, []webhook.Delivery{
+			{CreatedAt: now, NextAttemptAt: now, WebhookID: wh.ID, GameID: gameID, Event: "first", Status: webhook.DeliveryStatusPending},
+			{CreatedAt: now, NextAttemptAt: now.Add(time.Hour), WebhookID: wh.ID, GameID: gameID, Event: "second", Status: webhook.DeliveryStatusPending},
+		})
+		assert.NoError(t, err)
+
+		claimed, err := conn.ClaimDueDeliveries(ctx, now, time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+		assert.Equal(t, "first", claimed[0].Event)
+
+		// Leased until the attempt is recorded
+		claimed2, err := conn.ClaimDueDeliveries(ctx, now, time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, claimed2)
+
+		delivery := claimed[0]
+		delivery.Status = webhook.DeliveryStatusSucceeded
+		delivery.Attempts = append(delivery.Attempts, webhook.Attempt{AttemptedAt: now, StatusCode: 200})
+		assert.NoError(t, conn.UpdateDelivery(ctx, delivery))
+
+		deliveries, err := conn.ListDeliveries(ctx, wh.ID, 10)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 2)
+		assert.Equal(t, "second", deliveries[0].Event)
+		assert.Equal(t, webhook.DeliveryStatusSucceeded, deliveries[1].Status)
+		assert.Len(t, deliveries[1].Attempts, 1)
+
+		deliveries, err = conn.ListDeliveries(ctx, wh.ID, 1)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := conn.DeleteWebhook(ctx, wh.ID, uuid.NewString())
+		assert.ErrorIs(t, err, webhook.ErrWebhookNotFound)
+
+		err = conn.DeleteWebhook(ctx, wh.ID, gameID)
+		assert.NoError(t, err)
+
+		_, err = conn.GetWebhook(ctx, wh.ID)
+		assert.ErrorIs(t, err, webhook.ErrWebhookNotFound)
+
+		deliveries, err := conn.ListDeliveries(ctx, wh.ID, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		_, err := conn.GetWebhook(ctx, "invalid")
+		assert.ErrorIs(t, err, webhook.ErrInvalidWebhookID)
+	})
+}
@@ -0,0 +1,219 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const linkedAccountCollectionName = "linkedAccounts"
+
+// Field of the external ID blind index
+const externalIDField = "externalId"
+
+type LinkedAccount struct {
+	LinkedAt            time.Time `bson:"linkedAt"`
+	GameID              string    `bson:"gameId"`
+	PlayerID            string    `bson:"playerId"`
+	Platform            string    `bson:"platform"`
+	ExternalID          string    `bson:"externalId"`                    // Blind index of the external ID, once the encryption is enabled
+	EncryptedExternalID string    `bson:"encryptedExternalId,omitempty"` // External ID encrypted, read back in place of the index
+}
+
+func (a LinkedAccount) toDomain(cipher *encryption.Cipher) (player.LinkedAccount, error) {
+	externalID, err := cipher.Open(a.ExternalID, a.EncryptedExternalID)
+	if err != nil {
+		return player.LinkedAccount{}, err
+	}
+
+	return player.LinkedAccount{
+		LinkedAt:   a.LinkedAt.UTC(),
+		GameID:     a.GameID,
+		PlayerID:   a.PlayerID,
+		Platform:   a.Platform,
+		ExternalID: externalID,
+	}, nil
+}
+
+// An account is linked to a single player per game. The external IDs are matched by their blind index once the encryption
+// is enabled, which keeps the uniqueness
+var linkedAccountIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "platform", Value: 1}, {Key: "externalId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_platform_1_externalId_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}, {Key: "linkedAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1_linkedAt_1"),
+	},
+}
+
+func (c connection) GetAccount(ctx context.Context, gameID, platform, externalID string) (player.LinkedAccount, error) {
+	var data LinkedAccount
+	err := c.readCollection(linkedAccountCollectionName).FindOne(ctx, bson.M{
+		"gameId":     bson.M{"$eq": gameID},
+		"platform":   bson.M{"$eq": platform},
+		"externalId": bson.M{"$eq": c.cipher.BlindIndex(externalIDField, externalID)},
+	}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = player.ErrAccountNotFound
+		}
+
+		return player.LinkedAccount{}, err
+	}
+
+	return data.toDomain(c.cipher)
+}
+
+// The unique index settles concurrent links of the same account, the existing link is read back when it's hit
+func (c connection) LinkAccount(ctx context.Context, account player.LinkedAccount) (player.LinkedAccount, error) {
+	if err := c.writable(); err != nil {
+		return player.LinkedAccount{}, err
+	}
+
+	externalID, encryptedExternalID, err := c.cipher.Seal(externalIDField, account.ExternalID)
+	if err != nil {
+		return player.LinkedAccount{}, err
+	}
+
+	data := LinkedAccount{
+		LinkedAt:            account.LinkedAt,
+		GameID:              account.GameID,
+		PlayerID:            account.PlayerID,
+		Platform:            account.Platform,
+		ExternalID:          externalID,
+		EncryptedExternalID: encryptedExternalID,
+	}
+
+	_, err = c.client.Database(c.db).Collection(linkedAccountCollectionName).InsertOne(ctx, data)
+	if err == nil {
+		return account, nil
+	}
+
+	if !mongo.IsDuplicateKeyError(err) {
+		return player.LinkedAccount{}, err
+	}
+
+	// Read from the primary, as the link may not have reached the secondaries yet
+	var existing LinkedAccount
+	err = c.client.Database(c.db).Collection(linkedAccountCollectionName).FindOne(ctx, bson.M{
+		"gameId":     bson.M{"$eq": account.GameID},
+		"platform":   bson.M{"$eq": account.Platform},
+		"externalId": bson.M{"$eq": externalID},
+	}).Decode(&existing)
+	if err != nil {
+		return player.LinkedAccount{}, err
+	}
+
+	if existing.PlayerID != account.PlayerID {
+		return player.LinkedAccount{}, player.ErrAccountLinkedToAnotherPlayer
+	}
+
+	return existing.toDomain(c.cipher)
+}
+
+func (c connection) UnlinkAccount(ctx context.Context, gameID, playerID, platform, externalID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	result, err := c.client.Database(c.db).Collection(linkedAccountCollectionName).DeleteOne(ctx, bson.M{
+		"gameId":     bson.M{"$eq": gameID},
+		"playerId":   bson.M{"$eq": playerID},
+		"platform":   bson.M{"$eq": platform},
+		"externalId": bson.M{"$eq": c.cipher.BlindIndex(externalIDField, externalID)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return player.ErrAccountNotFound
+	}
+
+	return nil
+}
+
+func (c connection) ListAccounts(ctx context.Context, gameID, playerID string) ([]player.LinkedAccount, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "linkedAt", Value: 1}})
+
+	cursor, err := c.readCollection(linkedAccountCollectionName).Find(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []LinkedAccount
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	accounts := make([]player.LinkedAccount, len(data))
+	for i, account := range data {
+		if accounts[i], err = account.toDomain(c.cipher); err != nil {
+			return nil, err
+		}
+	}
+
+	return accounts, nil
+}
+
+func (c connection) deletePlayerAccounts(ctx context.Context, gameID, playerID string) (int64, error) {
+	if err := c.writable(); err != nil {
+		return 0, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(linkedAccountCollectionName).DeleteMany(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+// Erases the accounts linked to the player
+func (c connection) ErasePlayerAccounts(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	records, err := c.deletePlayerAccounts(ctx, gameID, playerID)
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataLinkedAccounts, Records: records}, nil
+}
+
+// The external IDs identify the player on their own, so the links are removed instead of moved to the pseudonym
+func (c connection) AnonymizePlayerAccounts(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	records, err := c.deletePlayerAccounts(ctx, gameID, playerID)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataLinkedAccounts, Records: records}, nil
+}
+
+// Counts the accounts linked to the player
+func (c connection) CountPlayerAccounts(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(linkedAccountCollectionName).CountDocuments(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataLinkedAccounts, Records: records}, nil
+}
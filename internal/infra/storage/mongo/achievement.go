@@ -0,0 +1,288 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	achievementCollectionName       = "achievements"
+	achievementUnlockCollectionName = "achievementUnlocks"
+)
+
+type (
+	AchievementTrigger struct {
+		Kind        string  `bson:"kind"`
+		StatisticID string  `bson:"statisticId,omitempty"`
+		Landmark    float64 `bson:"landmark,omitempty"`
+		QuestID     string  `bson:"questId,omitempty"`
+	}
+
+	Achievement struct {
+		CreatedAt   time.Time          `bson:"createdAt"`
+		UpdatedAt   time.Time          `bson:"updatedAt"`
+		ID          primitive.ObjectID `bson:"_id,omitempty"`
+		GameID      string             `bson:"gameId"`
+		Name        string             `bson:"name"`
+		Description string             `bson:"description"`
+		Trigger     AchievementTrigger `bson:"trigger"`
+	}
+
+	AchievementUnlock struct {
+		AchievementID string    `bson:"achievementId"`
+		GameID        string    `bson:"gameId"`
+		PlayerID      string    `bson:"playerId"`
+		UnlockedAt    time.Time `bson:"unlockedAt"`
+	}
+)
+
+func (a Achievement) toDomain() achievement.Achievement {
+	return achievement.Achievement{
+		CreatedAt:   a.CreatedAt.UTC(),
+		UpdatedAt:   a.UpdatedAt.UTC(),
+		ID:          a.ID.Hex(),
+		GameID:      a.GameID,
+		Name:        a.Name,
+		Description: a.Description,
+		Trigger: achievement.Trigger{
+			Kind:        a.Trigger.Kind,
+			StatisticID: a.Trigger.StatisticID,
+			Landmark:    a.Trigger.Landmark,
+			QuestID:     a.Trigger.QuestID,
+		},
+	}
+}
+
+func (u AchievementUnlock) toDomain() achievement.Unlock {
+	return achievement.Unlock{
+		AchievementID: u.AchievementID,
+		GameID:        u.GameID,
+		PlayerID:      u.PlayerID,
+		UnlockedAt:    u.UnlockedAt.UTC(),
+	}
+}
+
+var achievementIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_createdAt_1"),
+	},
+}
+
+// A player unlocks each achievement once
+var achievementUnlockIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "achievementId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("achievementId_1_playerId_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}, {Key: "unlockedAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1_unlockedAt_1"),
+	},
+}
+
+func (c connection) CreateAchievement(ctx context.Context, data achievement.NewAchievementData) (achievement.Achievement, error) {
+	if err := c.writable(); err != nil {
+		return achievement.Achievement{}, err
+	}
+
+	a := Achievement{
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+		GameID:      data.GameID,
+		Name:        data.Name,
+		Description: data.Description,
+		Trigger: AchievementTrigger{
+			Kind:        data.Trigger.Kind,
+			StatisticID: data.Trigger.StatisticID,
+			Landmark:    data.Trigger.Landmark,
+			QuestID:     data.Trigger.QuestID,
+		},
+	}
+
+	result, err := c.client.Database(c.db).Collection(achievementCollectionName).InsertOne(ctx, a)
+	if err != nil {
+		return achievement.Achievement{}, err
+	}
+
+	a.ID = result.InsertedID.(primitive.ObjectID)
+	return a.toDomain(), nil
+}
+
+func (c connection) GetAchievement(ctx context.Context, id, gameID string) (achievement.Achievement, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return achievement.Achievement{}, achievement.ErrAchievementNotFound
+	}
+
+	var data Achievement
+	err = c.readCollection(achievementCollectionName).FindOne(ctx, bson.M{
+		"_id":    bson.M{"$eq": objectID},
+		"gameId": bson.M{"$eq": gameID},
+	}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = achievement.ErrAchievementNotFound
+		}
+
+		return achievement.Achievement{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) ListAchievements(ctx context.Context, gameID string) ([]achievement.Achievement, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	cursor, err := c.readCollection(achievementCollectionName).Find(ctx, bson.M{"gameId": bson.M{"$eq": gameID}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Achievement
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	achievements := make([]achievement.Achievement, len(data))
+	for i, a := range data {
+		achievements[i] = a.toDomain()
+	}
+
+	return achievements, nil
+}
+
+// Also removes the achievement's unlocks
+func (c connection) DeleteAchievement(ctx context.Context, id, gameID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return achievement.ErrAchievementNotFound
+	}
+
+	result, err := c.client.Database(c.db).Collection(achievementCollectionName).DeleteOne(ctx, bson.M{
+		"_id":    bson.M{"$eq": objectID},
+		"gameId": bson.M{"$eq": gameID},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return achievement.ErrAchievementNotFound
+	}
+
+	_, err = c.client.Database(c.db).Collection(achievementUnlockCollectionName).DeleteMany(ctx, bson.M{"achievementId": bson.M{"$eq": id}})
+	return err
+}
+
+// The unique index keeps the unlocks the player already has, so only the ones inserted are returned
+func (c connection) UnlockAchievements(ctx context.Context, unlocks []achievement.Unlock) ([]achievement.Unlock, error) {
+	if err := c.writable(); err != nil {
+		return nil, err
+	}
+
+	recorded := make([]achievement.Unlock, 0, len(unlocks))
+	for _, unlock := range unlocks {
+		_, err := c.client.Database(c.db).Collection(achievementUnlockCollectionName).InsertOne(ctx, AchievementUnlock{
+			AchievementID: unlock.AchievementID,
+			GameID:        unlock.GameID,
+			PlayerID:      unlock.PlayerID,
+			UnlockedAt:    unlock.UnlockedAt,
+		})
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		recorded = append(recorded, unlock)
+	}
+
+	return recorded, nil
+}
+
+func (c connection) ListAchievementUnlocks(ctx context.Context, gameID, playerID string) ([]achievement.Unlock, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "unlockedAt", Value: 1}})
+
+	cursor, err := c.readCollection(achievementUnlockCollectionName).Find(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []AchievementUnlock
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	unlocks := make([]achievement.Unlock, len(data))
+	for i, unlock := range data {
+		unlocks[i] = unlock.toDomain()
+	}
+
+	return unlocks, nil
+}
+
+// Erases the achievements the player unlocked
+func (c connection) ErasePlayerAchievements(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(achievementUnlockCollectionName).DeleteMany(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataAchievements, Records: result.DeletedCount}, nil
+}
+
+// Moves the achievements the player unlocked to the pseudonym
+func (c connection) AnonymizePlayerAchievements(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(achievementUnlockCollectionName).UpdateMany(ctx,
+		bson.M{"gameId": bson.M{"$eq": gameID}, "playerId": bson.M{"$eq": playerID}},
+		bson.M{"$set": bson.M{"playerId": pseudonym}},
+	)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataAchievements, Records: result.ModifiedCount}, nil
+}
+
+// Counts the achievements the player unlocked
+func (c connection) CountPlayerAchievements(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(achievementUnlockCollectionName).CountDocuments(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataAchievements, Records: records}, nil
+}
@@ -0,0 +1,171 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/activity"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const activityCollectionName = "activities"
+
+type Activity struct {
+	OccurredAt    time.Time          `bson:"occurredAt"`
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	GameID        string             `bson:"gameId"`
+	PlayerID      string             `bson:"playerId"`
+	Kind          string             `bson:"kind"`
+	LeaderboardID string             `bson:"leaderboardId,omitempty"`
+	StatisticID   string             `bson:"statisticId,omitempty"`
+	QuestID       string             `bson:"questId,omitempty"`
+	Value         float64            `bson:"value,omitempty"`
+	PreviousValue float64            `bson:"previousValue,omitempty"`
+	Top           int64              `bson:"top,omitempty"`
+}
+
+func (a Activity) toDomain() activity.Activity {
+	return activity.Activity{
+		OccurredAt:    a.OccurredAt.UTC(),
+		ID:            a.ID.Hex(),
+		GameID:        a.GameID,
+		PlayerID:      a.PlayerID,
+		Kind:          a.Kind,
+		LeaderboardID: a.LeaderboardID,
+		StatisticID:   a.StatisticID,
+		QuestID:       a.QuestID,
+		Value:         a.Value,
+		PreviousValue: a.PreviousValue,
+		Top:           a.Top,
+	}
+}
+
+// The friends feeds read the activities of many players of the game at once, newest first
+var activityIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			{Key: "gameId", Value: 1},
+			{Key: "playerId", Value: 1},
+			{Key: "occurredAt", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+		Options: options.Index().SetName("gameId_1_playerId_1_occurredAt_-1__id_-1"),
+	},
+}
+
+func (c connection) RecordActivities(ctx context.Context, activities []activity.Activity) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	documents := make([]any, len(activities))
+	for i, a := range activities {
+		documents[i] = Activity{
+			OccurredAt:    a.OccurredAt.UTC(),
+			GameID:        a.GameID,
+			PlayerID:      a.PlayerID,
+			Kind:          a.Kind,
+			LeaderboardID: a.LeaderboardID,
+			StatisticID:   a.StatisticID,
+			QuestID:       a.QuestID,
+			Value:         a.Value,
+			PreviousValue: a.PreviousValue,
+			Top:           a.Top,
+		}
+	}
+
+	_, err := c.client.Database(c.db).Collection(activityCollectionName).InsertMany(ctx, documents)
+	return err
+}
+
+func (c connection) ListActivities(ctx context.Context, gameID string, playerIDs []string, after activity.Activity, limit int) ([]activity.Activity, error) {
+	query := bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$in": playerIDs},
+	}
+
+	if after.ID != "" {
+		afterID, err := primitive.ObjectIDFromHex(after.ID)
+		if err != nil {
+			return nil, activity.ErrInvalidCursor
+		}
+
+		query["$or"] = bson.A{
+			bson.M{"occurredAt": bson.M{"$lt": after.OccurredAt}},
+			bson.M{"occurredAt": after.OccurredAt, "_id": bson.M{"$lt": afterID}},
+		}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "occurredAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := c.readCollection(activityCollectionName).Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Activity
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	activities := make([]activity.Activity, len(data))
+	for i, a := range data {
+		activities[i] = a.toDomain()
+	}
+
+	return activities, nil
+}
+
+// Erases the player's activity feed
+func (c connection) ErasePlayerActivities(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(activityCollectionName).DeleteMany(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataActivity, Records: result.DeletedCount}, nil
+}
+
+// Moves the player's activity feed to the pseudonym
+func (c connection) AnonymizePlayerActivities(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(activityCollectionName).UpdateMany(ctx,
+		bson.M{"gameId": bson.M{"$eq": gameID}, "playerId": bson.M{"$eq": playerID}},
+		bson.M{"$set": bson.M{"playerId": pseudonym}},
+	)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataActivity, Records: result.ModifiedCount}, nil
+}
+
+// Counts the activities on the player's feed
+func (c connection) CountPlayerActivities(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(activityCollectionName).CountDocuments(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataActivity, Records: records}, nil
+}
@@ -0,0 +1,210 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/anomaly"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const anomalyCollectionName = "anomalies"
+
+type Anomaly struct {
+	DetectedAt    time.Time          `bson:"detectedAt"`
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	GameID        string             `bson:"gameId"`
+	LeaderboardID string             `bson:"leaderboardId"`
+	PlayerID      string             `bson:"playerId"`
+	Value         float64            `bson:"value"`
+	Method        string             `bson:"method"`
+	Score         float64            `bson:"score"`
+	Status        string             `bson:"status"`
+}
+
+func (a Anomaly) toDomain() anomaly.Anomaly {
+	return anomaly.Anomaly{
+		DetectedAt:    a.DetectedAt.UTC(),
+		ID:            a.ID.Hex(),
+		GameID:        a.GameID,
+		LeaderboardID: a.LeaderboardID,
+		PlayerID:      a.PlayerID,
+		Value:         a.Value,
+		Method:        a.Method,
+		Score:         a.Score,
+		Status:        a.Status,
+	}
+}
+
+// A player has a single anomaly per leaderboard, and the review list is read oldest first
+var anomalyIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "leaderboardId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_leaderboardId_1_playerId_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "status", Value: 1}, {Key: "detectedAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_status_1_detectedAt_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1"),
+	},
+}
+
+func decodeAnomalies(ctx context.Context, cursor *mongo.Cursor) ([]anomaly.Anomaly, error) {
+	var data []Anomaly
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	anomalies := make([]anomaly.Anomaly, len(data))
+	for i, a := range data {
+		anomalies[i] = a.toDomain()
+	}
+
+	return anomalies, nil
+}
+
+func (c connection) SaveAnomaly(ctx context.Context, a anomaly.Anomaly) (anomaly.Anomaly, error) {
+	if err := c.writable(); err != nil {
+		return anomaly.Anomaly{}, err
+	}
+
+	var (
+		filter = bson.M{
+			"gameId":        bson.M{"$eq": a.GameID},
+			"leaderboardId": bson.M{"$eq": a.LeaderboardID},
+			"playerId":      bson.M{"$eq": a.PlayerID},
+		}
+		update = bson.M{
+			"$set": bson.M{
+				"detectedAt": a.DetectedAt,
+				"value":      a.Value,
+				"method":     a.Method,
+				"score":      a.Score,
+				"status":     a.Status,
+			},
+		}
+		opts = options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	)
+
+	var data Anomaly
+	if err := c.client.Database(c.db).Collection(anomalyCollectionName).FindOneAndUpdate(ctx, filter, update, opts).Decode(&data); err != nil {
+		return anomaly.Anomaly{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) ListLeaderboardAnomalies(ctx context.Context, gameID, leaderboardID string) ([]anomaly.Anomaly, error) {
+	cursor, err := c.readCollection(anomalyCollectionName).Find(ctx, bson.M{
+		"gameId":        bson.M{"$eq": gameID},
+		"leaderboardId": bson.M{"$eq": leaderboardID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeAnomalies(ctx, cursor)
+}
+
+func (c connection) ListAnomalies(ctx context.Context, gameID, leaderboardID string, limit int) ([]anomaly.Anomaly, error) {
+	filter := bson.M{
+		"gameId": bson.M{"$eq": gameID},
+		"status": bson.M{"$eq": anomaly.StatusPending},
+	}
+	if leaderboardID != "" {
+		filter["leaderboardId"] = bson.M{"$eq": leaderboardID}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "detectedAt", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := c.readCollection(anomalyCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeAnomalies(ctx, cursor)
+}
+
+func (c connection) DismissAnomaly(ctx context.Context, gameID, id string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return anomaly.ErrAnomalyNotFound
+	}
+
+	result, err := c.client.Database(c.db).Collection(anomalyCollectionName).UpdateOne(ctx,
+		bson.M{
+			"_id":    bson.M{"$eq": objectID},
+			"gameId": bson.M{"$eq": gameID},
+			"status": bson.M{"$eq": anomaly.StatusPending},
+		},
+		bson.M{"$set": bson.M{"status": anomaly.StatusDismissed}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return anomaly.ErrAnomalyNotFound
+	}
+
+	return nil
+}
+
+// Erases the player's ranks flagged as outliers
+func (c connection) ErasePlayerAnomalies(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(anomalyCollectionName).DeleteMany(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataAnomalies, Records: result.DeletedCount}, nil
+}
+
+// Moves the player's ranks flagged as outliers to the pseudonym
+func (c connection) AnonymizePlayerAnomalies(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(anomalyCollectionName).UpdateMany(ctx,
+		bson.M{"gameId": bson.M{"$eq": gameID}, "playerId": bson.M{"$eq": playerID}},
+		bson.M{"$set": bson.M{"playerId": pseudonym}},
+	)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataAnomalies, Records: result.ModifiedCount}, nil
+}
+
+// Counts the player's ranks flagged as outliers
+func (c connection) CountPlayerAnomalies(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(anomalyCollectionName).CountDocuments(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataAnomalies, Records: records}, nil
+}
@@ -0,0 +1,164 @@
+package mongo
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const auditCollectionName = "auditLogs"
+
+type AuditEntry struct {
+	CreatedAt      time.Time          `bson:"createdAt"`
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	GameID         string             `bson:"gameId"`
+	Actor          string             `bson:"actor"`                    // Blind index of the actor, once the encryption is enabled
+	EncryptedActor string             `bson:"encryptedActor,omitempty"` // Actor encrypted, read back in place of the index
+	Method         string             `bson:"method"`
+	Route          string             `bson:"route"`
+	ResourceIDs    map[string]string  `bson:"resourceIds,omitempty"`
+	PayloadDigest  string             `bson:"payloadDigest"`
+	StatusCode     int                `bson:"statusCode"`
+	RequestedAt    time.Time          `bson:"requestedAt"`
+}
+
+// Field of the actor blind index
+const auditActorField = "auditActor"
+
+func (e AuditEntry) toDomain(cipher *encryption.Cipher) (audit.Entry, error) {
+	actor, err := cipher.Open(e.Actor, e.EncryptedActor)
+	if err != nil {
+		return audit.Entry{}, err
+	}
+
+	return audit.Entry{
+		CreatedAt:     e.CreatedAt,
+		ID:            e.ID.Hex(),
+		GameID:        e.GameID,
+		Actor:         actor,
+		Method:        e.Method,
+		Route:         e.Route,
+		ResourceIDs:   e.ResourceIDs,
+		PayloadDigest: e.PayloadDigest,
+		StatusCode:    e.StatusCode,
+		RequestedAt:   e.RequestedAt,
+	}, nil
+}
+
+func newAuditEntryFromDomain(e audit.NewEntryData, cipher *encryption.Cipher) (AuditEntry, error) {
+	actor, encryptedActor, err := cipher.Seal(auditActorField, e.Actor)
+	if err != nil {
+		return AuditEntry{}, err
+	}
+
+	return AuditEntry{
+		CreatedAt:      time.Now().UTC(),
+		GameID:         e.GameID,
+		Actor:          actor,
+		EncryptedActor: encryptedActor,
+		Method:         e.Method,
+		Route:          e.Route,
+		ResourceIDs:    e.ResourceIDs,
+		PayloadDigest:  e.PayloadDigest,
+		StatusCode:     e.StatusCode,
+		RequestedAt:    e.RequestedAt.UTC(),
+	}, nil
+}
+
+var auditIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			{Key: "gameId", Value: 1},
+			{Key: "createdAt", Value: -1},
+		},
+		Options: options.Index().SetName("gameId_1_createdAt_-1"),
+	},
+}
+
+// The audit log is append only, entries are never updated or removed
+func (c connection) RecordAuditEntry(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+	if err := c.writable(); err != nil {
+		return audit.Entry{}, err
+	}
+
+	entry, err := newAuditEntryFromDomain(data, c.cipher)
+	if err != nil {
+		return audit.Entry{}, err
+	}
+
+	cursor, err := c.client.Database(c.db).Collection(auditCollectionName).InsertOne(ctx, entry)
+	if err != nil {
+		return audit.Entry{}, err
+	}
+
+	entry.ID = cursor.InsertedID.(primitive.ObjectID)
+
+	return entry.toDomain(c.cipher)
+}
+
+// The actors are matched by their blind index once the encryption is enabled
+func (c connection) ListAuditEntries(ctx context.Context, filter audit.Filter, after audit.Entry, limit int) ([]audit.Entry, error) {
+	query := bson.M{"gameId": filter.GameID}
+
+	if filter.Actor != "" {
+		query["actor"] = c.cipher.BlindIndex(auditActorField, filter.Actor)
+	}
+
+	if filter.ResourceType != "" {
+		query["route"] = bson.M{"$regex": "^" + regexp.QuoteMeta(audit.RoutePrefix(filter.ResourceType))}
+	}
+
+	createdAt := bson.M{}
+	if !filter.From.IsZero() {
+		createdAt["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		createdAt["$lt"] = filter.To
+	}
+	if len(createdAt) > 0 {
+		query["createdAt"] = createdAt
+	}
+
+	if after.ID != "" {
+		afterID, err := primitive.ObjectIDFromHex(after.ID)
+		if err != nil {
+			return nil, audit.ErrInvalidCursor
+		}
+
+		query["$or"] = bson.A{
+			bson.M{"createdAt": bson.M{"$lt": after.CreatedAt}},
+			bson.M{"createdAt": after.CreatedAt, "_id": bson.M{"$lt": afterID}},
+		}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := c.readCollection(auditCollectionName).Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []AuditEntry
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	entries := make([]audit.Entry, len(data))
+	for i, entry := range data {
+		if entries[i], err = entry.toDomain(c.cipher); err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
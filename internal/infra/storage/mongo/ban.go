@@ -0,0 +1,367 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const banCollectionName = "bans"
+
+type HiddenRank struct {
+	LeaderboardID string  `bson:"leaderboardId"`
+	Value         float64 `bson:"value"`
+}
+
+type Ban struct {
+	CreatedAt   time.Time    `bson:"createdAt"`
+	ExpiresAt   *time.Time   `bson:"expiresAt,omitempty"` // Left out of the permanent bans, so the expiration index only holds the suspensions
+	GameID      string       `bson:"gameId"`
+	PlayerID    string       `bson:"playerId"`
+	Reason      string       `bson:"reason"`
+	HiddenRanks []HiddenRank `bson:"hiddenRanks"`
+}
+
+func banFromDomain(b ban.Ban) Ban {
+	data := Ban{
+		CreatedAt:   b.CreatedAt,
+		GameID:      b.GameID,
+		PlayerID:    b.PlayerID,
+		Reason:      b.Reason,
+		HiddenRanks: hiddenRanksFromDomain(b.HiddenRanks),
+	}
+	if !b.ExpiresAt.IsZero() {
+		data.ExpiresAt = &b.ExpiresAt
+	}
+
+	return data
+}
+
+func hiddenRanksFromDomain(ranks []ban.HiddenRank) []HiddenRank {
+	data := make([]HiddenRank, len(ranks))
+	for i, rank := range ranks {
+		data[i] = HiddenRank{LeaderboardID: rank.LeaderboardID, Value: rank.Value}
+	}
+
+	return data
+}
+
+func (b Ban) toDomain() ban.Ban {
+	var expiresAt time.Time
+	if b.ExpiresAt != nil {
+		expiresAt = b.ExpiresAt.UTC()
+	}
+
+	var hiddenRanks []ban.HiddenRank
+	for _, rank := range b.HiddenRanks {
+		hiddenRanks = append(hiddenRanks, ban.HiddenRank{LeaderboardID: rank.LeaderboardID, Value: rank.Value})
+	}
+
+	return ban.Ban{
+		CreatedAt:   b.CreatedAt.UTC(),
+		ExpiresAt:   expiresAt,
+		GameID:      b.GameID,
+		PlayerID:    b.PlayerID,
+		Reason:      b.Reason,
+		HiddenRanks: hiddenRanks,
+	}
+}
+
+func playerBanFilter(gameID, playerID string) bson.M {
+	return bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	}
+}
+
+// A player has a single ban per game. The expiration index is sparse, as only the suspensions expire
+var banIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_createdAt_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetName("expiresAt_1").SetSparse(true),
+	},
+}
+
+// The unique index settles concurrent bans of the same player
+func (c connection) CreateBan(ctx context.Context, b ban.Ban) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	_, err := c.client.Database(c.db).Collection(banCollectionName).InsertOne(ctx, banFromDomain(b))
+	if mongo.IsDuplicateKeyError(err) {
+		return ban.ErrPlayerAlreadyBanned
+	}
+
+	return err
+}
+
+// Read from the primary, as the submissions of a player just banned must be rejected right away
+func (c connection) GetBan(ctx context.Context, gameID, playerID string) (ban.Ban, error) {
+	var data Ban
+	err := c.client.Database(c.db).Collection(banCollectionName).FindOne(ctx, playerBanFilter(gameID, playerID)).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = ban.ErrBanNotFound
+		}
+
+		return ban.Ban{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) SetBanHiddenRanks(ctx context.Context, gameID, playerID string, ranks []ban.HiddenRank) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	result, err := c.client.Database(c.db).Collection(banCollectionName).UpdateOne(ctx, playerBanFilter(gameID, playerID), bson.M{
+		"$set": bson.M{"hiddenRanks": hiddenRanksFromDomain(ranks)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ban.ErrBanNotFound
+	}
+
+	return nil
+}
+
+// The ban is found and removed by a single command, so a ban lifted concurrently is only returned once
+func (c connection) DeleteBan(ctx context.Context, gameID, playerID string) (ban.Ban, error) {
+	if err := c.writable(); err != nil {
+		return ban.Ban{}, err
+	}
+
+	var data Ban
+	err := c.client.Database(c.db).Collection(banCollectionName).FindOneAndDelete(ctx, playerBanFilter(gameID, playerID)).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = ban.ErrBanNotFound
+		}
+
+		return ban.Ban{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) findBans(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]ban.Ban, error) {
+	cursor, err := c.readCollection(banCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Ban
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	bans := make([]ban.Ban, len(data))
+	for i, b := range data {
+		bans[i] = b.toDomain()
+	}
+
+	return bans, nil
+}
+
+func (c connection) ListBans(ctx context.Context, gameID string) ([]ban.Ban, error) {
+	return c.findBans(ctx, bson.M{"gameId": bson.M{"$eq": gameID}}, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+}
+
+func (c connection) ListExpiredBans(ctx context.Context, now time.Time, limit int) ([]ban.Ban, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "expiresAt", Value: 1}}).SetLimit(int64(limit))
+	return c.findBans(ctx, bson.M{"expiresAt": bson.M{"$lte": now}}, opts)
+}
+
+// Erases the player's ban alongside the values of the ranks it hides
+func (c connection) ErasePlayerBan(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(banCollectionName).DeleteOne(ctx, playerBanFilter(gameID, playerID))
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataBans, Records: result.DeletedCount}, nil
+}
+
+// Moves the player's ban to the pseudonym, so its ranks are put back under the pseudonym once it's lifted
+func (c connection) AnonymizePlayerBan(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(banCollectionName).UpdateOne(ctx, playerBanFilter(gameID, playerID), bson.M{
+		"$set": bson.M{"playerId": pseudonym},
+	})
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataBans, Records: result.ModifiedCount}, nil
+}
+
+// Counts the player's ban
+func (c connection) CountPlayerBan(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(banCollectionName).CountDocuments(ctx, playerBanFilter(gameID, playerID))
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataBans, Records: records}, nil
+}
+
+const deviceBanCollectionName = "deviceBans"
+
+type DeviceBan struct {
+	CreatedAt  time.Time  `bson:"createdAt"`
+	ExpiresAt  *time.Time `bson:"expiresAt,omitempty"` // Left out of the permanent bans, so the TTL index only removes the suspensions
+	GameID     string     `bson:"gameId"`
+	DeviceHash string     `bson:"deviceHash"`
+	Reason     string     `bson:"reason"`
+}
+
+func deviceBanFromDomain(b ban.DeviceBan) DeviceBan {
+	data := DeviceBan{CreatedAt: b.CreatedAt, GameID: b.GameID, DeviceHash: b.DeviceHash, Reason: b.Reason}
+	if !b.ExpiresAt.IsZero() {
+		data.ExpiresAt = &b.ExpiresAt
+	}
+
+	return data
+}
+
+func (b DeviceBan) toDomain() ban.DeviceBan {
+	var expiresAt time.Time
+	if b.ExpiresAt != nil {
+		expiresAt = b.ExpiresAt.UTC()
+	}
+
+	return ban.DeviceBan{CreatedAt: b.CreatedAt.UTC(), ExpiresAt: expiresAt, GameID: b.GameID, DeviceHash: b.DeviceHash, Reason: b.Reason}
+}
+
+// The TTL index removes the expired bans within a minute, so the ones it hasn't removed yet are filtered out
+func activeDeviceBanFilter(gameID string, now time.Time) bson.M {
+	return bson.M{
+		"gameId": bson.M{"$eq": gameID},
+		"$or":    bson.A{bson.M{"expiresAt": bson.M{"$exists": false}}, bson.M{"expiresAt": bson.M{"$gt": now}}},
+	}
+}
+
+func deviceBanFilter(gameID, deviceHash string, now time.Time) bson.M {
+	filter := activeDeviceBanFilter(gameID, now)
+	filter["deviceHash"] = bson.M{"$eq": deviceHash}
+	return filter
+}
+
+var deviceBanIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "deviceHash", Value: 1}},
+		Options: options.Index().SetName("gameId_1_deviceHash_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_createdAt_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetName("expiresAt_1").SetExpireAfterSeconds(0),
+	},
+}
+
+// An expired ban the TTL index hasn't removed yet is removed first, so the unique index only rejects the active ones
+func (c connection) CreateDeviceBan(ctx context.Context, b ban.DeviceBan) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	collection := c.client.Database(c.db).Collection(deviceBanCollectionName)
+	_, err := collection.DeleteOne(ctx, bson.M{
+		"gameId":     bson.M{"$eq": b.GameID},
+		"deviceHash": bson.M{"$eq": b.DeviceHash},
+		"expiresAt":  bson.M{"$lte": time.Now()},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.InsertOne(ctx, deviceBanFromDomain(b))
+	if mongo.IsDuplicateKeyError(err) {
+		return ban.ErrDeviceAlreadyBanned
+	}
+
+	return err
+}
+
+// Read from the primary, as the submissions from a device just banned must be rejected right away
+func (c connection) GetDeviceBan(ctx context.Context, gameID, deviceHash string) (ban.DeviceBan, error) {
+	var data DeviceBan
+	err := c.client.Database(c.db).Collection(deviceBanCollectionName).FindOne(ctx, deviceBanFilter(gameID, deviceHash, time.Now())).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = ban.ErrDeviceBanNotFound
+		}
+
+		return ban.DeviceBan{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) DeleteDeviceBan(ctx context.Context, gameID, deviceHash string) (ban.DeviceBan, error) {
+	if err := c.writable(); err != nil {
+		return ban.DeviceBan{}, err
+	}
+
+	var data DeviceBan
+	err := c.client.Database(c.db).Collection(deviceBanCollectionName).FindOneAndDelete(ctx, deviceBanFilter(gameID, deviceHash, time.Now())).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = ban.ErrDeviceBanNotFound
+		}
+
+		return ban.DeviceBan{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) ListDeviceBans(ctx context.Context, gameID string) ([]ban.DeviceBan, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := c.readCollection(deviceBanCollectionName).Find(ctx, activeDeviceBanFilter(gameID, time.Now()), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []DeviceBan
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	bans := make([]ban.DeviceBan, len(data))
+	for i, b := range data {
+		bans[i] = b.toDomain()
+	}
+
+	return bans, nil
+}
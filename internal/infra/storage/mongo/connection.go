@@ -2,43 +2,364 @@ package mongo
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
+	"github.com/gabapcia/gameblitz/internal/availability"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+	"github.com/gabapcia/gameblitz/internal/infra/failover"
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+var (
+	ErrInvalidTLSCA      = errors.New("no certificate found on the tls ca file")
+	ErrUnknownCollection = errors.New("unknown collection")
+	ErrInvalidPoolConfig = errors.New("invalid connection pool config")
 )
 
+type Options struct {
+	URI                            string             // MongoDB connection string
+	DB                             string             // Database name
+	AppName                        string             // Application name reported to the server. Empty keeps the URI value
+	Username                       string             // Username. Empty keeps the URI credentials
+	Password                       string             // Password
+	AuthSource                     string             // Database used to authenticate the user
+	TLS                            bool               // Enable TLS
+	TLSCAFile                      string             // PEM file with the CA used to verify the server certificate
+	TLSCertFile                    string             // PEM file with the client certificate
+	TLSKeyFile                     string             // PEM file with the client private key
+	TLSInsecureSkipVerify          bool               // Skip the server certificate verification. Never use in production
+	ConnectTimeout                 time.Duration      // Timeout for a new connection to be established. Zero keeps the driver default
+	ServerSelectionTimeout         time.Duration      // Timeout to find a suitable server for an operation. Zero keeps the driver default
+	SocketTimeout                  time.Duration      // Timeout for reads and writes on a socket. Zero keeps the driver default
+	MaxPoolSize                    uint64             // Max connections per server. Zero keeps the driver default
+	MinPoolSize                    uint64             // Min connections kept per server
+	MaxConnIdleTime                time.Duration      // Time an idle connection is kept on the pool before closed. Zero keeps them forever
+	MaxConnecting                  uint64             // Max connections being established concurrently per server. Zero keeps the driver default
+	Retry                          backoff.Config     // Retry policy used while the server is not reachable at startup
+	ReadPreferences                map[string]string  // Read preference mode for the read-only queries, by collection. Defaults to primary
+	FallbackURI                    string             // Connection string of a replica on another region, used for reads while the primary is unreachable. Optional
+	Failover                       failover.Config    // Health checks that decide when to fail over to the fallback
+	Outbox                         bool               // Record the progression events on the outbox, within the transaction that applies the change
+	Metrics                        *metrics.Storage   // Records the latency and the errors of every command. Optional
+	Tracing                        bool               // Start a span for every command
+	SlowQueryThreshold             time.Duration      // Commands taking longer are reported to `OnSlowQuery`. Zero disables the report
+	OnSlowQuery                    SlowQueryFunc      // Called for every slow command. Optional
+	ProgressionMigration           string             // Phase of the migration of the player statistic progressions. Empty is the same as `off`
+	ProgressionMigrationCollection string             // Collection the progressions are migrated to. Defaults to `DefaultProgressionMigrationCollection`
+	Cipher                         *encryption.Cipher // Encrypts the personal data, e.g. the profile display names and the linked account IDs. Optional
+}
+
+func (o Options) validatePool() error {
+	errList := make([]error, 0)
+
+	if o.MaxPoolSize > 0 && o.MinPoolSize > o.MaxPoolSize {
+		errList = append(errList, fmt.Errorf("min pool size %d greater than max pool size %d", o.MinPoolSize, o.MaxPoolSize))
+	}
+
+	if o.MaxConnIdleTime < 0 {
+		errList = append(errList, fmt.Errorf("negative max connection idle time %s", o.MaxConnIdleTime))
+	}
+
+	if len(errList) > 0 {
+		errList = append([]error{ErrInvalidPoolConfig}, errList...)
+	}
+
+	return errors.Join(errList...)
+}
+
+func (o Options) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.TLSInsecureSkipVerify,
+	}
+
+	if o.TLSCAFile != "" {
+		ca, err := os.ReadFile(o.TLSCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, ErrInvalidTLSCA
+		}
+	}
+
+	if o.TLSCertFile != "" || o.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.TLSCertFile, o.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+func (o Options) clientOptions(uri string) (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(uri)
+
+	if o.AppName != "" {
+		opts.SetAppName(o.AppName)
+	}
+
+	if o.Username != "" {
+		opts.SetAuth(options.Credential{
+			AuthSource: o.AuthSource,
+			Username:   o.Username,
+			Password:   o.Password,
+		})
+	}
+
+	if o.TLS {
+		tlsConfig, err := o.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	if o.ConnectTimeout > 0 {
+		opts.SetConnectTimeout(o.ConnectTimeout)
+	}
+
+	if o.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(o.ServerSelectionTimeout)
+	}
+
+	if o.SocketTimeout > 0 {
+		opts.SetSocketTimeout(o.SocketTimeout)
+	}
+
+	if o.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(o.MaxPoolSize)
+	}
+
+	if o.MinPoolSize > 0 {
+		opts.SetMinPoolSize(o.MinPoolSize)
+	}
+
+	if o.MaxConnIdleTime > 0 {
+		opts.SetMaxConnIdleTime(o.MaxConnIdleTime)
+	}
+
+	if o.MaxConnecting > 0 {
+		opts.SetMaxConnecting(o.MaxConnecting)
+	}
+
+	monitors := make([]*event.CommandMonitor, 0)
+	if o.Metrics != nil {
+		monitors = append(monitors, commandMonitor(o.Metrics))
+	}
+
+	if o.Tracing {
+		monitors = append(monitors, tracingMonitor(o.DB))
+	}
+
+	if o.SlowQueryThreshold > 0 && o.OnSlowQuery != nil {
+		monitors = append(monitors, slowQueryMonitor(o.SlowQueryThreshold, o.OnSlowQuery))
+	}
+
+	if len(monitors) > 0 {
+		opts.SetMonitor(joinMonitors(monitors...))
+	}
+
+	return opts, nil
+}
+
+func (o Options) readPreferences() (map[string]*readpref.ReadPref, error) {
+	prefs := make(map[string]*readpref.ReadPref)
+	for collection, modeName := range o.ReadPreferences {
+		if _, ok := indexRegistry[collection]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCollection, collection)
+		}
+
+		mode, err := readpref.ModeFromString(modeName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", collection, err)
+		}
+
+		pref, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", collection, err)
+		}
+
+		prefs[collection] = pref
+	}
+
+	return prefs, nil
+}
+
 type connection struct {
-	client *mongo.Client
-	db     string
+	client          *mongo.Client
+	db              string
+	readPreferences map[string]*readpref.ReadPref
+	outbox          bool
+	cipher          *encryption.Cipher
+
+	progressionMigration       string
+	progressionMigrationTarget string
+
+	fallback    *mongo.Client
+	monitor     *failover.Monitor
+	stopMonitor context.CancelFunc
 }
 
-func (c connection) ensureIndexes(ctx context.Context) error {
-	if err := c.ensurePlayerStatisticIndexes(ctx); err != nil {
-		return fmt.Errorf("Player Statistics: %w", err)
+func (c connection) degraded() bool {
+	return c.monitor != nil && c.monitor.State() == failover.StateDegraded
+}
+
+// Fails with `availability.ErrReadOnly` while only the fallback is reachable
+func (c connection) writable() error {
+	if c.degraded() {
+		return availability.ErrReadOnly
 	}
 
 	return nil
 }
 
+// Returns the collection configured with its read preference. Must only be used by read-only
+// queries that can handle stale data, writes and transactions always go to the primary.
+// While the primary is unreachable, the collection is read from the nearest fallback member
+func (c connection) readCollection(name string) *mongo.Collection {
+	if c.degraded() {
+		return c.fallback.Database(c.db).Collection(name, options.Collection().SetReadPreference(readpref.Nearest()))
+	}
+
+	opts := options.Collection()
+	if pref, ok := c.readPreferences[name]; ok {
+		opts.SetReadPreference(pref)
+	}
+
+	return c.client.Database(c.db).Collection(name, opts)
+}
+
+// Runs the given function inside a transaction. The transaction is committed if the function
+// returns no error and aborted otherwise. The function can be retried by the driver on transient errors,
+// so it must be idempotent and always use the provided session context
+func (c connection) withTransaction(ctx context.Context, fn func(ctx mongo.SessionContext) error) error {
+	session, err := c.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.Background())
+
+	opts := options.Transaction().
+		SetReadConcern(readconcern.Snapshot()).
+		SetWriteConcern(writeconcern.Majority())
+
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (any, error) {
+		return nil, fn(ctx)
+	}, opts)
+
+	return err
+}
+
+// Checks the primary, even while the reads failed over to the fallback
+func (c connection) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx, readpref.Primary())
+}
+
 func (c connection) Close(ctx context.Context) error {
+	if c.fallback != nil {
+		c.stopMonitor()
+		_ = c.fallback.Disconnect(ctx)
+	}
+
 	return c.client.Disconnect(ctx)
 }
 
-func New(ctx context.Context, connStr, db string) (*connection, error) {
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connStr))
+func New(ctx context.Context, opts Options) (*connection, error) {
+	if err := opts.validatePool(); err != nil {
+		return nil, err
+	}
+
+	clientOpts, err := opts.clientOptions(opts.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	readPreferences, err := opts.readPreferences()
 	if err != nil {
 		return nil, err
 	}
 
-	if err = client.Ping(ctx, readpref.PrimaryPreferred()); err != nil {
+	progressionTarget := opts.ProgressionMigrationCollection
+	if progressionTarget == "" {
+		progressionTarget = DefaultProgressionMigrationCollection
+	}
+
+	if err := validateProgressionMigration(opts.ProgressionMigration, progressionTarget); err != nil {
+		return nil, err
+	}
+
+	// The target collection is read just like the current one
+	if pref, ok := readPreferences[playerStatisticCollectionName]; ok {
+		readPreferences[progressionTarget] = pref
+	}
+
+	client, err := mongo.Connect(ctx, clientOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = backoff.Retry(ctx, opts.Retry, func(ctx context.Context) error {
+		return client.Ping(ctx, readpref.PrimaryPreferred())
+	})
+	if err != nil {
+		_ = client.Disconnect(context.Background())
 		return nil, err
 	}
 
 	conn := &connection{
-		client: client,
-		db:     db,
+		client:          client,
+		db:              opts.DB,
+		readPreferences: readPreferences,
+		outbox:          opts.Outbox,
+		cipher:          opts.Cipher,
+
+		progressionMigration:       opts.ProgressionMigration,
+		progressionMigrationTarget: progressionTarget,
 	}
-	return conn, conn.ensureIndexes(ctx)
+
+	if opts.FallbackURI != "" {
+		fallbackOpts, err := opts.clientOptions(opts.FallbackURI)
+		if err != nil {
+			_ = client.Disconnect(context.Background())
+			return nil, err
+		}
+
+		conn.fallback, err = mongo.Connect(ctx, fallbackOpts.SetReadPreference(readpref.Nearest()))
+		if err != nil {
+			_ = client.Disconnect(context.Background())
+			return nil, err
+		}
+
+		conn.monitor = failover.NewMonitor(
+			func(ctx context.Context) error { return conn.client.Ping(ctx, readpref.Primary()) },
+			func(ctx context.Context) error { return conn.fallback.Ping(ctx, readpref.Nearest()) },
+			opts.Failover,
+		)
+
+		var monitorCtx context.Context
+		monitorCtx, conn.stopMonitor = context.WithCancel(context.Background())
+		go conn.monitor.Run(monitorCtx)
+	}
+
+	return conn, nil
 }
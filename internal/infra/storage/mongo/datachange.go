@@ -0,0 +1,137 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/datachange"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	changeStreamTokenCollectionName = "changeStreamTokens"
+	changeStreamTokenID             = "dataChanges"
+)
+
+type ChangeStreamToken struct {
+	ID        string    `bson:"_id"`
+	Token     bson.Raw  `bson:"token"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+type StatisticChangeEvent struct {
+	OperationType string              `bson:"operationType"`
+	ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument      *Statistic `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields bson.M `bson:"updatedFields"`
+	} `bson:"updateDescription"`
+}
+
+func (e StatisticChangeEvent) toDomain() (datachange.Event, bool) {
+	event := datachange.Event{
+		OccurredAt: time.Unix(int64(e.ClusterTime.T), 0).UTC(),
+		Entity:     datachange.EntityStatistic,
+		EntityID:   e.DocumentKey.ID.Hex(),
+	}
+
+	if e.FullDocument != nil {
+		event.GameID = e.FullDocument.GameID
+	}
+
+	switch e.OperationType {
+	case "insert":
+		event.Operation = datachange.OperationCreated
+	case "update", "replace":
+		event.Operation = datachange.OperationUpdated
+		if _, ok := e.UpdateDescription.UpdatedFields["deletedAt"]; ok {
+			event.Operation = datachange.OperationDeleted
+		}
+	case "delete":
+		event.Operation = datachange.OperationDeleted
+	default:
+		return datachange.Event{}, false
+	}
+
+	return event, true
+}
+
+func (c connection) getChangeStreamToken(ctx context.Context) (bson.Raw, error) {
+	var data ChangeStreamToken
+	err := c.client.Database(c.db).Collection(changeStreamTokenCollectionName).FindOne(ctx, bson.M{"_id": changeStreamTokenID}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return data.Token, nil
+}
+
+func (c connection) saveChangeStreamToken(ctx context.Context, token bson.Raw) error {
+	_, err := c.client.Database(c.db).Collection(changeStreamTokenCollectionName).UpdateOne(
+		ctx,
+		bson.M{"_id": changeStreamTokenID},
+		bson.M{"$set": bson.M{"token": token, "updatedAt": time.Now().UTC()}},
+		options.Update().SetUpsert(true),
+	)
+
+	return err
+}
+
+// Tails the statistics change stream, resuming from the last change handled.
+// The resume token is only saved after the handler succeeds, so changes are delivered at least once
+func (c connection) WatchChanges(ctx context.Context, handler datachange.ChangeHandlerFunc) error {
+	token, err := c.getChangeStreamToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if token != nil {
+		opts.SetResumeAfter(token)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}}}}},
+	}
+
+	stream, err := c.client.Database(c.db).Collection(statisticCollectionName).Watch(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		var change StatisticChangeEvent
+		if err := stream.Decode(&change); err != nil {
+			return err
+		}
+
+		if event, ok := change.toDomain(); ok {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if err := c.saveChangeStreamToken(ctx, stream.ResumeToken()); err != nil {
+			return err
+		}
+	}
+
+	if errors.Is(stream.Err(), context.Canceled) {
+		return nil
+	}
+
+	return stream.Err()
+}
@@ -0,0 +1,265 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/ingestion"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const deadLetterCollectionName = "deadLetters"
+
+type (
+	DeadLetterMessage struct {
+		ID       string `bson:"id"`
+		Kind     string `bson:"kind"`
+		GameID   string `bson:"gameId"`
+		Payload  []byte `bson:"payload"`
+		Attempts int    `bson:"attempts"`
+	}
+
+	DeadLetter struct {
+		FailedAt time.Time          `bson:"failedAt"`
+		ID       primitive.ObjectID `bson:"_id,omitempty"`
+		Message  DeadLetterMessage  `bson:"message"`
+		Error    string             `bson:"error"`
+	}
+)
+
+func (d DeadLetter) toDomain() ingestion.DeadLetter {
+	return ingestion.DeadLetter{
+		FailedAt: d.FailedAt,
+		ID:       d.ID.Hex(),
+		Message: ingestion.Message{
+			ID:       d.Message.ID,
+			Kind:     d.Message.Kind,
+			GameID:   d.Message.GameID,
+			Payload:  d.Message.Payload,
+			Attempts: d.Message.Attempts,
+		},
+		Error: d.Error,
+	}
+}
+
+var deadLetterIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "failedAt", Value: 1}},
+		Options: options.Index().SetName("failedAt_1"),
+	},
+}
+
+func (c connection) CreateDeadLetter(ctx context.Context, deadLetter ingestion.DeadLetter) (ingestion.DeadLetter, error) {
+	if err := c.writable(); err != nil {
+		return ingestion.DeadLetter{}, err
+	}
+
+	data := DeadLetter{
+		FailedAt: deadLetter.FailedAt,
+		Message: DeadLetterMessage{
+			ID:       deadLetter.Message.ID,
+			Kind:     deadLetter.Message.Kind,
+			GameID:   deadLetter.Message.GameID,
+			Payload:  deadLetter.Message.Payload,
+			Attempts: deadLetter.Message.Attempts,
+		},
+		Error: deadLetter.Error,
+	}
+
+	result, err := c.client.Database(c.db).Collection(deadLetterCollectionName).InsertOne(ctx, data)
+	if err != nil {
+		return ingestion.DeadLetter{}, err
+	}
+
+	data.ID = result.InsertedID.(primitive.ObjectID)
+	return data.toDomain(), nil
+}
+
+func (c connection) ListDeadLetters(ctx context.Context, limit int) ([]ingestion.DeadLetter, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "failedAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := c.readCollection(deadLetterCollectionName).Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []DeadLetter
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	deadLetters := make([]ingestion.DeadLetter, len(data))
+	for i, deadLetter := range data {
+		deadLetters[i] = deadLetter.toDomain()
+	}
+
+	return deadLetters, nil
+}
+
+func (c connection) GetDeadLetter(ctx context.Context, id string) (ingestion.DeadLetter, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ingestion.DeadLetter{}, ingestion.ErrInvalidDeadLetterID
+	}
+
+	var data DeadLetter
+	err = c.client.Database(c.db).Collection(deadLetterCollectionName).FindOne(ctx, bson.M{"_id": objectID}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = ingestion.ErrDeadLetterNotFound
+		}
+
+		return ingestion.DeadLetter{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) DeleteDeadLetter(ctx context.Context, id string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ingestion.ErrInvalidDeadLetterID
+	}
+
+	result, err := c.client.Database(c.db).Collection(deadLetterCollectionName).DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return ingestion.ErrDeadLetterNotFound
+	}
+
+	return nil
+}
+
+// Finds the game's dead letters submitted by the player. The payload is stored encoded, so every dead letter of the game
+// is decoded instead. Dead letters whose payload can't be decoded can't be attributed to any player, so they are never found
+func findPlayerDeadLetters(ctx context.Context, collection *mongo.Collection, gameID, playerID string) ([]primitive.ObjectID, error) {
+	cursor, err := collection.Find(ctx, bson.M{"message.gameId": bson.M{"$eq": gameID}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := make([]primitive.ObjectID, 0)
+	for cursor.Next(ctx) {
+		var data DeadLetter
+		if err := cursor.Decode(&data); err != nil {
+			return nil, err
+		}
+
+		if id, err := data.toDomain().Message.PlayerID(); err == nil && id == playerID {
+			ids = append(ids, data.ID)
+		}
+	}
+
+	return ids, cursor.Err()
+}
+
+// Erases the dead letters of the game submitted by the player. Dead letters whose payload can't be decoded can't be
+// attributed to any player, so they are kept
+func (c connection) ErasePlayerDeadLetters(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	collection := c.client.Database(c.db).Collection(deadLetterCollectionName)
+
+	ids, err := findPlayerDeadLetters(ctx, collection, gameID, playerID)
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	erasure := privacy.Erasure{Data: privacy.DataDeadLetters}
+	if len(ids) == 0 {
+		return erasure, nil
+	}
+
+	result, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	erasure.Records = result.DeletedCount
+	return erasure, nil
+}
+
+// Replaces the player ID on the payload of the game's dead letters submitted by the player, so they can still be
+// requeued. The payload is stored encoded, so every dead letter of the game is decoded instead. Dead letters whose
+// payload can't be decoded can't be attributed to any player, so they are kept as is
+func (c connection) AnonymizePlayerDeadLetters(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	collection := c.client.Database(c.db).Collection(deadLetterCollectionName)
+
+	cursor, err := collection.Find(ctx, bson.M{"message.gameId": bson.M{"$eq": gameID}})
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+	defer cursor.Close(ctx)
+
+	models := make([]mongo.WriteModel, 0)
+	for cursor.Next(ctx) {
+		var data DeadLetter
+		if err := cursor.Decode(&data); err != nil {
+			return privacy.Anonymization{}, err
+		}
+
+		msg := data.toDomain().Message
+		if id, err := msg.PlayerID(); err != nil || id != playerID {
+			continue
+		}
+
+		msg, err := msg.WithPlayerID(pseudonym)
+		if err != nil {
+			return privacy.Anonymization{}, err
+		}
+
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": data.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"message.payload": msg.Payload}}),
+		)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	anonymization := privacy.Anonymization{Data: privacy.DataDeadLetters}
+	if len(models) == 0 {
+		return anonymization, nil
+	}
+
+	result, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	anonymization.Records = result.ModifiedCount
+	return anonymization, nil
+}
+
+// Counts the dead letters of the game submitted by the player, the ones that would be erased or anonymized
+func (c connection) CountPlayerDeadLetters(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	ids, err := findPlayerDeadLetters(ctx, c.readCollection(deadLetterCollectionName), gameID, playerID)
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataDeadLetters, Records: int64(len(ids))}, nil
+}
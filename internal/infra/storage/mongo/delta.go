@@ -0,0 +1,170 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/delta"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	deltaRuleCollectionName        = "deltaRules"
+	deltaImprovementCollectionName = "deltaImprovements"
+)
+
+type (
+	DeltaRule struct {
+		UpdatedAt          time.Time `bson:"updatedAt"`
+		GameID             string    `bson:"gameId"`
+		LeaderboardID      string    `bson:"leaderboardId"`
+		MaxSubmissionDelta float64   `bson:"maxSubmissionDelta"`
+		MaxHourlyDelta     float64   `bson:"maxHourlyDelta"`
+		Action             string    `bson:"action"`
+	}
+
+	DeltaImprovement struct {
+		AppliedAt     time.Time `bson:"appliedAt"`
+		GameID        string    `bson:"gameId"`
+		LeaderboardID string    `bson:"leaderboardId"`
+		PlayerID      string    `bson:"playerId"`
+		Delta         float64   `bson:"delta"`
+	}
+)
+
+func (r DeltaRule) toDomain() delta.Rule {
+	return delta.Rule{
+		UpdatedAt:          r.UpdatedAt.UTC(),
+		GameID:             r.GameID,
+		LeaderboardID:      r.LeaderboardID,
+		MaxSubmissionDelta: r.MaxSubmissionDelta,
+		MaxHourlyDelta:     r.MaxHourlyDelta,
+		Action:             r.Action,
+	}
+}
+
+// A leaderboard has a single rule
+var deltaRuleIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "leaderboardId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_leaderboardId_1").SetUnique(true),
+	},
+}
+
+// The improvements are only summed over the hourly window, so they expire right after it
+var deltaImprovementIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "leaderboardId", Value: 1}, {Key: "playerId", Value: 1}, {Key: "appliedAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_leaderboardId_1_playerId_1_appliedAt_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "appliedAt", Value: 1}},
+		Options: options.Index().SetName("appliedAt_1").SetExpireAfterSeconds(int32(delta.HourlyWindow.Seconds())),
+	},
+}
+
+func deltaRuleFilter(gameID, leaderboardID string) bson.M {
+	return bson.M{
+		"gameId":        bson.M{"$eq": gameID},
+		"leaderboardId": bson.M{"$eq": leaderboardID},
+	}
+}
+
+func (c connection) SetDeltaRule(ctx context.Context, rule delta.Rule) (delta.Rule, error) {
+	if err := c.writable(); err != nil {
+		return delta.Rule{}, err
+	}
+
+	data := DeltaRule{
+		UpdatedAt:          rule.UpdatedAt,
+		GameID:             rule.GameID,
+		LeaderboardID:      rule.LeaderboardID,
+		MaxSubmissionDelta: rule.MaxSubmissionDelta,
+		MaxHourlyDelta:     rule.MaxHourlyDelta,
+		Action:             rule.Action,
+	}
+
+	filter := deltaRuleFilter(rule.GameID, rule.LeaderboardID)
+	if _, err := c.client.Database(c.db).Collection(deltaRuleCollectionName).ReplaceOne(ctx, filter, data, options.Replace().SetUpsert(true)); err != nil {
+		return delta.Rule{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) GetDeltaRule(ctx context.Context, gameID, leaderboardID string) (delta.Rule, error) {
+	var data DeltaRule
+	if err := c.readCollection(deltaRuleCollectionName).FindOne(ctx, deltaRuleFilter(gameID, leaderboardID)).Decode(&data); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = delta.ErrRuleNotFound
+		}
+
+		return delta.Rule{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) DeleteDeltaRule(ctx context.Context, gameID, leaderboardID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	result, err := c.client.Database(c.db).Collection(deltaRuleCollectionName).DeleteOne(ctx, deltaRuleFilter(gameID, leaderboardID))
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return delta.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+func (c connection) RecordDeltaImprovement(ctx context.Context, i delta.Improvement) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	_, err := c.client.Database(c.db).Collection(deltaImprovementCollectionName).InsertOne(ctx, DeltaImprovement{
+		AppliedAt:     i.AppliedAt,
+		GameID:        i.GameID,
+		LeaderboardID: i.LeaderboardID,
+		PlayerID:      i.PlayerID,
+		Delta:         i.Delta,
+	})
+	return err
+}
+
+func (c connection) SumDeltaImprovements(ctx context.Context, gameID, leaderboardID, playerID string, since time.Time) (float64, error) {
+	cursor, err := c.readCollection(deltaImprovementCollectionName).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"gameId":        bson.M{"$eq": gameID},
+			"leaderboardId": bson.M{"$eq": leaderboardID},
+			"playerId":      bson.M{"$eq": playerID},
+			"appliedAt":     bson.M{"$gt": since},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "sum": bson.M{"$sum": "$delta"}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var data []struct {
+		Sum float64 `bson:"sum"`
+	}
+	if err := cursor.All(ctx, &data); err != nil {
+		return 0, err
+	}
+
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	return data[0].Sum, nil
+}
@@ -0,0 +1,188 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/friend"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const friendshipCollectionName = "friendships"
+
+type Friendship struct {
+	CreatedAt time.Time `bson:"createdAt"`
+	GameID    string    `bson:"gameId"`
+	PlayerID  string    `bson:"playerId"`
+	FriendID  string    `bson:"friendId"`
+}
+
+func (f Friendship) toDomain() friend.Friendship {
+	return friend.Friendship{
+		CreatedAt: f.CreatedAt.UTC(),
+		GameID:    f.GameID,
+		PlayerID:  f.PlayerID,
+		FriendID:  f.FriendID,
+	}
+}
+
+// A friendship is kept once for each player, so the friends of a player are listed by its own key.
+// The friend index finds the friendships kept for the other players, which are changed alongside the player's
+var friendshipIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}, {Key: "friendId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1_friendId_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1_createdAt_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "friendId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_friendId_1"),
+	},
+}
+
+func friendshipFilter(gameID, playerID, friendID string) bson.M {
+	return bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+		"friendId": bson.M{"$eq": friendID},
+	}
+}
+
+// Matches the player's friendships, as kept for the player and for each of their friends
+func playerFriendshipsFilter(gameID, playerID string) bson.M {
+	return bson.M{
+		"gameId": bson.M{"$eq": gameID},
+		"$or": bson.A{
+			bson.M{"playerId": bson.M{"$eq": playerID}},
+			bson.M{"friendId": bson.M{"$eq": playerID}},
+		},
+	}
+}
+
+// The friend's side is stored first, so a friendship listed by the player is always listed by the friend too.
+// The unique index keeps the first friendship, read back when it's hit
+func (c connection) AddFriendship(ctx context.Context, f friend.Friendship) (friend.Friendship, error) {
+	if err := c.writable(); err != nil {
+		return friend.Friendship{}, err
+	}
+
+	collection := c.client.Database(c.db).Collection(friendshipCollectionName)
+
+	_, err := collection.InsertOne(ctx, Friendship{CreatedAt: f.CreatedAt, GameID: f.GameID, PlayerID: f.FriendID, FriendID: f.PlayerID})
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return friend.Friendship{}, err
+	}
+
+	_, err = collection.InsertOne(ctx, Friendship{CreatedAt: f.CreatedAt, GameID: f.GameID, PlayerID: f.PlayerID, FriendID: f.FriendID})
+	if err == nil {
+		return f, nil
+	}
+
+	if !mongo.IsDuplicateKeyError(err) {
+		return friend.Friendship{}, err
+	}
+
+	// Read from the primary, as the friendship may not have reached the secondaries yet
+	var existing Friendship
+	if err := collection.FindOne(ctx, friendshipFilter(f.GameID, f.PlayerID, f.FriendID)).Decode(&existing); err != nil {
+		return friend.Friendship{}, err
+	}
+
+	return existing.toDomain(), nil
+}
+
+func (c connection) RemoveFriendship(ctx context.Context, gameID, playerID, friendID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	result, err := c.client.Database(c.db).Collection(friendshipCollectionName).DeleteMany(ctx, bson.M{
+		"$or": bson.A{friendshipFilter(gameID, playerID, friendID), friendshipFilter(gameID, friendID, playerID)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return friend.ErrFriendshipNotFound
+	}
+
+	return nil
+}
+
+func (c connection) ListFriends(ctx context.Context, gameID, playerID string) ([]friend.Friendship, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	cursor, err := c.readCollection(friendshipCollectionName).Find(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Friendship
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	friends := make([]friend.Friendship, len(data))
+	for i, f := range data {
+		friends[i] = f.toDomain()
+	}
+
+	return friends, nil
+}
+
+// Erases the player's friendships, as kept for the player and for each of their friends
+func (c connection) ErasePlayerFriendships(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(friendshipCollectionName).DeleteMany(ctx, playerFriendshipsFilter(gameID, playerID))
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataFriendships, Records: result.DeletedCount}, nil
+}
+
+// Moves the player's friendships to the pseudonym, on both sides
+func (c connection) AnonymizePlayerFriendships(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	anonymization := privacy.Anonymization{Data: privacy.DataFriendships}
+	for _, field := range []string{"playerId", "friendId"} {
+		result, err := c.client.Database(c.db).Collection(friendshipCollectionName).UpdateMany(ctx,
+			bson.M{"gameId": bson.M{"$eq": gameID}, field: bson.M{"$eq": playerID}},
+			bson.M{"$set": bson.M{field: pseudonym}},
+		)
+		if err != nil {
+			return privacy.Anonymization{}, err
+		}
+
+		anonymization.Records += result.ModifiedCount
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's friendships, as kept for the player and for each of their friends
+func (c connection) CountPlayerFriendships(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(friendshipCollectionName).CountDocuments(ctx, playerFriendshipsFilter(gameID, playerID))
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataFriendships, Records: records}, nil
+}
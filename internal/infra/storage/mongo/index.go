@@ -0,0 +1,104 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrMissingIndexes = errors.New("missing indexes")
+
+// Indexes that every collection must have, by collection name
+var indexRegistry = map[string][]mongo.IndexModel{
+	statisticCollectionName:         statisticIndexes,
+	playerStatisticCollectionName:   playerStatisticIndexes,
+	auditCollectionName:             auditIndexes,
+	outboxCollectionName:            outboxIndexes,
+	eventArchiveCollectionName:      eventArchiveIndexes,
+	deadLetterCollectionName:        deadLetterIndexes,
+	webhookCollectionName:           webhookIndexes,
+	webhookDeliveryCollectionName:   webhookDeliveryIndexes,
+	usageCollectionName:             usageIndexes,
+	scoreHistoryCollectionName:      scoreHistoryIndexes,
+	profileCollectionName:           profileIndexes,
+	linkedAccountCollectionName:     linkedAccountIndexes,
+	friendshipCollectionName:        friendshipIndexes,
+	banCollectionName:               banIndexes,
+	deviceBanCollectionName:         deviceBanIndexes,
+	achievementCollectionName:       achievementIndexes,
+	achievementUnlockCollectionName: achievementUnlockIndexes,
+	titleCollectionName:             titleIndexes,
+	titleGrantCollectionName:        titleGrantIndexes,
+	segmentCollectionName:           segmentIndexes,
+	eligibilityCollectionName:       eligibilityIndexes,
+	levelCurveCollectionName:        levelCurveIndexes,
+	activityCollectionName:          activityIndexes,
+	rewardRuleCollectionName:        rewardRuleIndexes,
+	rewardGrantCollectionName:       rewardGrantIndexes,
+	deltaRuleCollectionName:         deltaRuleIndexes,
+	deltaImprovementCollectionName:  deltaImprovementIndexes,
+	quarantineCollectionName:        quarantineIndexes,
+	anomalyCollectionName:           anomalyIndexes,
+	proofRuleCollectionName:         proofRuleIndexes,
+	shadowFlagCollectionName:        shadowFlagIndexes,
+	trustRecordCollectionName:       trustRecordIndexes,
+	moderationActionCollectionName:  moderationActionIndexes,
+}
+
+func indexName(index mongo.IndexModel) string {
+	if index.Options == nil || index.Options.Name == nil {
+		return ""
+	}
+
+	return *index.Options.Name
+}
+
+// Creates every index in the registry that doesn't exist yet, including the ones of the progression migration target
+func (c connection) EnsureIndexes(ctx context.Context) error {
+	for collection, indexes := range c.indexes() {
+		if _, err := c.client.Database(c.db).Collection(collection).Indexes().CreateMany(ctx, indexes); err != nil {
+			return fmt.Errorf("%s: %w", collection, err)
+		}
+	}
+
+	return nil
+}
+
+// Checks that every index in the registry exists. Returns `ErrMissingIndexes` listing
+// the ones that must be created, without changing the database
+func (c connection) VerifyIndexes(ctx context.Context) error {
+	errList := make([]error, 0)
+	for collection, indexes := range c.indexes() {
+		cursor, err := c.client.Database(c.db).Collection(collection).Indexes().List(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", collection, err)
+		}
+
+		var existing []bson.M
+		if err := cursor.All(ctx, &existing); err != nil {
+			return fmt.Errorf("%s: %w", collection, err)
+		}
+
+		names := make(map[string]bool)
+		for _, index := range existing {
+			if name, ok := index["name"].(string); ok {
+				names[name] = true
+			}
+		}
+
+		for _, index := range indexes {
+			if !names[indexName(index)] {
+				errList = append(errList, fmt.Errorf("%s: %s", collection, indexName(index)))
+			}
+		}
+	}
+
+	if len(errList) > 0 {
+		errList = append([]error{ErrMissingIndexes}, errList...)
+	}
+
+	return errors.Join(errList...)
+}
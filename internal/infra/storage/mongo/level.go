@@ -0,0 +1,145 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/level"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const levelCurveCollectionName = "levelCurves"
+
+type LevelCurve struct {
+	CreatedAt   time.Time          `bson:"createdAt"`
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	GameID      string             `bson:"gameId"`
+	Name        string             `bson:"name"`
+	StatisticID string             `bson:"statisticId"`
+	Kind        string             `bson:"kind"`
+	Thresholds  []float64          `bson:"thresholds,omitempty"`
+	Base        float64            `bson:"base,omitempty"`
+	Growth      float64            `bson:"growth,omitempty"`
+	MaxLevel    int64              `bson:"maxLevel,omitempty"`
+}
+
+func (c LevelCurve) toDomain() level.Curve {
+	return level.Curve{
+		CreatedAt:   c.CreatedAt.UTC(),
+		ID:          c.ID.Hex(),
+		GameID:      c.GameID,
+		Name:        c.Name,
+		StatisticID: c.StatisticID,
+		Kind:        c.Kind,
+		Thresholds:  c.Thresholds,
+		Base:        c.Base,
+		Growth:      c.Growth,
+		MaxLevel:    c.MaxLevel,
+	}
+}
+
+var levelCurveIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_createdAt_1"),
+	},
+}
+
+func (c connection) CreateLevelCurve(ctx context.Context, data level.NewCurveData) (level.Curve, error) {
+	if err := c.writable(); err != nil {
+		return level.Curve{}, err
+	}
+
+	curve := LevelCurve{
+		CreatedAt:   time.Now().UTC(),
+		GameID:      data.GameID,
+		Name:        data.Name,
+		StatisticID: data.StatisticID,
+		Kind:        data.Kind,
+		Thresholds:  data.Thresholds,
+		Base:        data.Base,
+		Growth:      data.Growth,
+		MaxLevel:    data.MaxLevel,
+	}
+
+	result, err := c.client.Database(c.db).Collection(levelCurveCollectionName).InsertOne(ctx, curve)
+	if err != nil {
+		return level.Curve{}, err
+	}
+
+	curve.ID = result.InsertedID.(primitive.ObjectID)
+	return curve.toDomain(), nil
+}
+
+func (c connection) GetLevelCurve(ctx context.Context, id, gameID string) (level.Curve, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return level.Curve{}, level.ErrCurveNotFound
+	}
+
+	var data LevelCurve
+	err = c.readCollection(levelCurveCollectionName).FindOne(ctx, bson.M{
+		"_id":    bson.M{"$eq": objectID},
+		"gameId": bson.M{"$eq": gameID},
+	}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = level.ErrCurveNotFound
+		}
+
+		return level.Curve{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) ListLevelCurves(ctx context.Context, gameID string) ([]level.Curve, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	cursor, err := c.readCollection(levelCurveCollectionName).Find(ctx, bson.M{"gameId": bson.M{"$eq": gameID}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []LevelCurve
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	curves := make([]level.Curve, len(data))
+	for i, curve := range data {
+		curves[i] = curve.toDomain()
+	}
+
+	return curves, nil
+}
+
+func (c connection) DeleteLevelCurve(ctx context.Context, id, gameID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return level.ErrCurveNotFound
+	}
+
+	result, err := c.client.Database(c.db).Collection(levelCurveCollectionName).DeleteOne(ctx, bson.M{
+		"_id":    bson.M{"$eq": objectID},
+		"gameId": bson.M{"$eq": gameID},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return level.ErrCurveNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// Records every command sent to the server. The collection is read from the command document,
+// which holds it as the value of the command name on the CRUD commands, e.g. `{"find": "statistics"}`
+func commandMonitor(m *metrics.Storage) *event.CommandMonitor {
+	var inFlight sync.Map // Finish functions by request ID
+
+	finish := func(requestID int64, err error) {
+		if fn, ok := inFlight.LoadAndDelete(requestID); ok {
+			fn.(metrics.FinishFunc)(err)
+		}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+			collection, _ := evt.Command.Lookup(evt.CommandName).StringValueOK()
+			inFlight.Store(evt.RequestID, m.Observe("mongo", evt.CommandName, collection))
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.RequestID, nil)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.RequestID, errors.New(evt.Failure))
+		},
+	}
+}
@@ -0,0 +1,262 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/player"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const migrationCollectionName = "schemaMigrations"
+
+const (
+	migrationStatusRunning = "RUNNING"
+	migrationStatusApplied = "APPLIED"
+)
+
+var (
+	ErrInvalidMigrationOrder = errors.New("migrations must have unique and increasing versions")
+	ErrMigrationInProgress   = errors.New("migration in progress")
+)
+
+// Schema change applied to the database, e.g. a field rename, a backfill or an index change.
+// Once released, a migration must never be edited, a new one must be appended instead
+type migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// Migrations applied to the database, ordered by version
+var migrations = []migration{
+	{
+		Version:     1,
+		Description: "create the registry indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for collection, indexes := range indexRegistry {
+				if _, err := db.Collection(collection).Indexes().CreateMany(ctx, indexes); err != nil {
+					return fmt.Errorf("%s: %w", collection, err)
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		Version:     2,
+		Description: "create the outbox indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(outboxCollectionName).Indexes().CreateMany(ctx, outboxIndexes)
+			return err
+		},
+	},
+	{
+		Version:     3,
+		Description: "create the dead letter indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(deadLetterCollectionName).Indexes().CreateMany(ctx, deadLetterIndexes)
+			return err
+		},
+	},
+	{
+		Version:     4,
+		Description: "create the event archive indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(eventArchiveCollectionName).Indexes().CreateMany(ctx, eventArchiveIndexes)
+			return err
+		},
+	},
+	{
+		Version:     5,
+		Description: "create the webhook indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if _, err := db.Collection(webhookCollectionName).Indexes().CreateMany(ctx, webhookIndexes); err != nil {
+				return fmt.Errorf("%s: %w", webhookCollectionName, err)
+			}
+
+			_, err := db.Collection(webhookDeliveryCollectionName).Indexes().CreateMany(ctx, webhookDeliveryIndexes)
+			return err
+		},
+	},
+	{
+		Version:     6,
+		Description: "create the usage indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(usageCollectionName).Indexes().CreateMany(ctx, usageIndexes)
+			return err
+		},
+	},
+	{
+		Version:     7,
+		Description: "backfill the profile search terms and create the profile search indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			collection := db.Collection(profileCollectionName)
+
+			cursor, err := collection.Find(ctx, bson.M{"searchTerms": bson.M{"$exists": false}})
+			if err != nil {
+				return err
+			}
+			defer cursor.Close(ctx)
+
+			for cursor.Next(ctx) {
+				var data struct {
+					ID          any    `bson:"_id"`
+					DisplayName string `bson:"displayName"`
+				}
+				if err := cursor.Decode(&data); err != nil {
+					return err
+				}
+
+				update := bson.M{"$set": bson.M{"searchTerms": player.SearchTerms(data.DisplayName)}}
+				if _, err := collection.UpdateByID(ctx, data.ID, update); err != nil {
+					return err
+				}
+			}
+			if err := cursor.Err(); err != nil {
+				return err
+			}
+
+			_, err = collection.Indexes().CreateMany(ctx, profileIndexes)
+			return err
+		},
+	},
+}
+
+type MigrationRecord struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	Status      string    `bson:"status"`
+	StartedAt   time.Time `bson:"startedAt"`
+	AppliedAt   time.Time `bson:"appliedAt,omitempty"`
+}
+
+type MigrationStatus struct {
+	Version     int       // Migration version
+	Description string    // What the migration changes
+	Applied     bool      // Whether the migration was applied to the database
+	AppliedAt   time.Time // Time the migration was applied
+}
+
+func validateMigrationOrder(migrations []migration) error {
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version <= migrations[i-1].Version {
+			return fmt.Errorf("%w: %d after %d", ErrInvalidMigrationOrder, migrations[i].Version, migrations[i-1].Version)
+		}
+	}
+
+	return nil
+}
+
+func (c connection) migrationRecords(ctx context.Context) (map[int]MigrationRecord, error) {
+	cursor, err := c.client.Database(c.db).Collection(migrationCollectionName).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	var data []MigrationRecord
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	records := make(map[int]MigrationRecord, len(data))
+	for _, record := range data {
+		records[record.Version] = record
+	}
+
+	return records, nil
+}
+
+// Applies a single migration. The record is inserted before running it and works as a lock,
+// so concurrent runners fail with `ErrMigrationInProgress` instead of applying it twice
+func (c connection) applyMigration(ctx context.Context, m migration) error {
+	collection := c.client.Database(c.db).Collection(migrationCollectionName)
+
+	_, err := collection.InsertOne(ctx, MigrationRecord{
+		Version:     m.Version,
+		Description: m.Description,
+		Status:      migrationStatusRunning,
+		StartedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = ErrMigrationInProgress
+		}
+
+		return err
+	}
+
+	if err := m.Up(ctx, c.client.Database(c.db)); err != nil {
+		// Releases the lock so the migration can be retried once fixed
+		_, _ = collection.DeleteOne(context.Background(), bson.M{"_id": m.Version})
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":    migrationStatusApplied,
+			"appliedAt": time.Now().UTC(),
+		},
+	}
+
+	_, err = collection.UpdateByID(ctx, m.Version, update)
+	return err
+}
+
+// Applies every pending migration in order, returning the versions applied
+func (c connection) Migrate(ctx context.Context) ([]int, error) {
+	if err := validateMigrationOrder(migrations); err != nil {
+		return nil, err
+	}
+
+	records, err := c.migrationRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]int, 0)
+	for _, m := range migrations {
+		record, ok := records[m.Version]
+		if ok && record.Status == migrationStatusApplied {
+			continue
+		}
+
+		if ok {
+			return applied, fmt.Errorf("%w: %d", ErrMigrationInProgress, m.Version)
+		}
+
+		if err := c.applyMigration(ctx, m); err != nil {
+			return applied, fmt.Errorf("migration %d: %w", m.Version, err)
+		}
+
+		applied = append(applied, m.Version)
+	}
+
+	return applied, nil
+}
+
+// Lists every known migration and whether it was already applied to the database
+func (c connection) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	records, err := c.migrationRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, len(migrations))
+	for i, m := range migrations {
+		record := records[m.Version]
+
+		status[i] = MigrationStatus{
+			Version:     m.Version,
+			Description: m.Description,
+			Applied:     record.Status == migrationStatusApplied,
+			AppliedAt:   record.AppliedAt,
+		}
+	}
+
+	return status, nil
+}
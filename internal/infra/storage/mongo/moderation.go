@@ -0,0 +1,165 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/moderation"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const moderationActionCollectionName = "moderationActions"
+
+type ModerationRemovedEntry struct {
+	LeaderboardID string  `bson:"leaderboardId"`
+	PlayerID      string  `bson:"playerId"`
+	Value         float64 `bson:"value"`
+}
+
+type ModerationRecomputedEntry struct {
+	PlayerID string   `bson:"playerId"`
+	Previous *float64 `bson:"previous,omitempty"`
+	Value    *float64 `bson:"value,omitempty"`
+}
+
+type ModerationAction struct {
+	ID            string                      `bson:"_id"`
+	CreatedAt     time.Time                   `bson:"createdAt"`
+	GameID        string                      `bson:"gameId"`
+	Kind          string                      `bson:"kind"`
+	Reason        string                      `bson:"reason"`
+	LeaderboardID string                      `bson:"leaderboardId,omitempty"`
+	PlayerID      string                      `bson:"playerId,omitempty"`
+	Previous      *float64                    `bson:"previous,omitempty"`
+	Value         *float64                    `bson:"value,omitempty"`
+	Source        string                      `bson:"source,omitempty"`
+	From          time.Time                   `bson:"from,omitempty"`
+	To            time.Time                   `bson:"to,omitempty"`
+	Entries       []ModerationRemovedEntry    `bson:"entries,omitempty"`
+	Scores        int64                       `bson:"scores,omitempty"`
+	Recomputed    []ModerationRecomputedEntry `bson:"recomputed,omitempty"`
+}
+
+func moderationActionFromDomain(action moderation.Action) ModerationAction {
+	data := ModerationAction{
+		ID:            action.ID,
+		CreatedAt:     action.CreatedAt,
+		GameID:        action.GameID,
+		Kind:          action.Kind,
+		Reason:        action.Reason,
+		LeaderboardID: action.LeaderboardID,
+		PlayerID:      action.PlayerID,
+		Previous:      action.Previous,
+		Value:         action.Value,
+		Source:        action.Source,
+		From:          action.From,
+		To:            action.To,
+		Scores:        action.Scores,
+	}
+
+	for _, entry := range action.Entries {
+		data.Entries = append(data.Entries, ModerationRemovedEntry{LeaderboardID: entry.LeaderboardID, PlayerID: entry.PlayerID, Value: entry.Value})
+	}
+
+	for _, entry := range action.Recomputed {
+		data.Recomputed = append(data.Recomputed, ModerationRecomputedEntry{PlayerID: entry.PlayerID, Previous: entry.Previous, Value: entry.Value})
+	}
+
+	return data
+}
+
+func (a ModerationAction) toDomain() moderation.Action {
+	action := moderation.Action{
+		ID:            a.ID,
+		CreatedAt:     a.CreatedAt.UTC(),
+		GameID:        a.GameID,
+		Kind:          a.Kind,
+		Reason:        a.Reason,
+		LeaderboardID: a.LeaderboardID,
+		PlayerID:      a.PlayerID,
+		Previous:      a.Previous,
+		Value:         a.Value,
+		Source:        a.Source,
+		Scores:        a.Scores,
+	}
+
+	if !a.From.IsZero() {
+		action.From, action.To = a.From.UTC(), a.To.UTC()
+	}
+
+	for _, entry := range a.Entries {
+		action.Entries = append(action.Entries, moderation.RemovedEntry{LeaderboardID: entry.LeaderboardID, PlayerID: entry.PlayerID, Value: entry.Value})
+	}
+
+	for _, entry := range a.Recomputed {
+		action.Recomputed = append(action.Recomputed, moderation.RecomputedEntry{PlayerID: entry.PlayerID, Previous: entry.Previous, Value: entry.Value})
+	}
+
+	return action
+}
+
+var moderationActionIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_createdAt_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "leaderboardId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_leaderboardId_1_playerId_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "entries.leaderboardId", Value: 1}, {Key: "entries.playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_entries.leaderboardId_1_entries.playerId_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "leaderboardId", Value: 1}, {Key: "recomputed.playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_leaderboardId_1_recomputed.playerId_1"),
+	},
+}
+
+func (c connection) listModerationActions(ctx context.Context, filter bson.M) ([]moderation.Action, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := c.readCollection(moderationActionCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []ModerationAction
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	actions := make([]moderation.Action, len(data))
+	for i, action := range data {
+		actions[i] = action.toDomain()
+	}
+
+	return actions, nil
+}
+
+func (c connection) CreateModerationAction(ctx context.Context, action moderation.Action) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	_, err := c.client.Database(c.db).Collection(moderationActionCollectionName).InsertOne(ctx, moderationActionFromDomain(action))
+	return err
+}
+
+func (c connection) ListEntryModerationActions(ctx context.Context, gameID, leaderboardID, playerID string) ([]moderation.Action, error) {
+	return c.listModerationActions(ctx, bson.M{
+		"gameId": bson.M{"$eq": gameID},
+		"$or": bson.A{
+			bson.M{"leaderboardId": bson.M{"$eq": leaderboardID}, "playerId": bson.M{"$eq": playerID}},
+			bson.M{"entries": bson.M{"$elemMatch": bson.M{"leaderboardId": bson.M{"$eq": leaderboardID}, "playerId": bson.M{"$eq": playerID}}}},
+			bson.M{"leaderboardId": bson.M{"$eq": leaderboardID}, "recomputed.playerId": bson.M{"$eq": playerID}},
+		},
+	})
+}
+
+func (c connection) ListModerationActions(ctx context.Context, gameID string) ([]moderation.Action, error) {
+	return c.listModerationActions(ctx, bson.M{"gameId": bson.M{"$eq": gameID}})
+}
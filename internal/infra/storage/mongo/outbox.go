@@ -0,0 +1,325 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/outbox"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	outboxCollectionName = "outbox"
+
+	// Published events kept to be replayed, with the same ID they had on the outbox
+	eventArchiveCollectionName = "eventArchive"
+)
+
+type (
+	OutboxEvent struct {
+		CreatedAt time.Time          `bson:"createdAt"`
+		ID        primitive.ObjectID `bson:"_id,omitempty"`
+		GameID    string             `bson:"gameId"`
+		Kind      string             `bson:"kind"`
+		Payload   []byte             `bson:"payload"`
+	}
+
+	ArchivedEvent struct {
+		ArchivedAt  time.Time `bson:"archivedAt"`
+		OutboxEvent `bson:",inline"`
+	}
+)
+
+func (e OutboxEvent) toDomain() outbox.Event {
+	return outbox.Event{
+		CreatedAt: e.CreatedAt,
+		ID:        e.ID.Hex(),
+		GameID:    e.GameID,
+		Kind:      e.Kind,
+		Payload:   e.Payload,
+	}
+}
+
+var outboxIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("createdAt_1"),
+	},
+}
+
+var eventArchiveIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("gameId_1_createdAt_1__id_1"),
+	},
+}
+
+// Records the event on the outbox. Must be called with the session context of the transaction
+// that applies the state change, so the event is only kept if the change is committed
+func (c connection) recordOutboxEvent(ctx mongo.SessionContext, event outbox.Event) error {
+	data := OutboxEvent{
+		CreatedAt: event.CreatedAt,
+		GameID:    event.GameID,
+		Kind:      event.Kind,
+		Payload:   event.Payload,
+	}
+
+	_, err := c.client.Database(c.db).Collection(outboxCollectionName).InsertOne(ctx, data)
+	return err
+}
+
+func (c connection) ListPendingOutboxEvents(ctx context.Context, limit int) ([]outbox.Event, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := c.client.Database(c.db).Collection(outboxCollectionName).Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []OutboxEvent
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	events := make([]outbox.Event, len(data))
+	for i, event := range data {
+		events[i] = event.toDomain()
+	}
+
+	return events, nil
+}
+
+func (c connection) DeleteOutboxEvent(ctx context.Context, id string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return outbox.ErrInvalidEventID
+	}
+
+	_, err = c.client.Database(c.db).Collection(outboxCollectionName).DeleteOne(ctx, bson.M{"_id": objectID})
+	return err
+}
+
+func (c connection) ArchiveOutboxEvent(ctx context.Context, event outbox.Event) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(event.ID)
+	if err != nil {
+		return outbox.ErrInvalidEventID
+	}
+
+	data := ArchivedEvent{
+		ArchivedAt: time.Now().UTC(),
+		OutboxEvent: OutboxEvent{
+			CreatedAt: event.CreatedAt,
+			ID:        objectID,
+			GameID:    event.GameID,
+			Kind:      event.Kind,
+			Payload:   event.Payload,
+		},
+	}
+
+	opts := options.Replace().SetUpsert(true)
+	_, err = c.client.Database(c.db).Collection(eventArchiveCollectionName).ReplaceOne(ctx, bson.M{"_id": objectID}, data, opts)
+	return err
+}
+
+func (c connection) ListArchivedOutboxEvents(ctx context.Context, filter outbox.ReplayFilter, after outbox.Event, limit int) ([]outbox.Event, error) {
+	query := bson.M{
+		"gameId":    filter.GameID,
+		"createdAt": bson.M{"$gte": filter.From, "$lt": filter.To},
+	}
+
+	if after.ID != "" {
+		afterID, err := primitive.ObjectIDFromHex(after.ID)
+		if err != nil {
+			return nil, outbox.ErrInvalidEventID
+		}
+
+		query["$or"] = bson.A{
+			bson.M{"createdAt": bson.M{"$gt": after.CreatedAt}},
+			bson.M{"createdAt": after.CreatedAt, "_id": bson.M{"$gt": afterID}},
+		}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := c.readCollection(eventArchiveCollectionName).Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []ArchivedEvent
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	events := make([]outbox.Event, len(data))
+	for i, event := range data {
+		events[i] = event.toDomain()
+	}
+
+	return events, nil
+}
+
+// Finds the game's events on the collection that refer to the player. The payload is stored encoded,
+// so it can't be queried and every event of the game is decoded instead
+func findPlayerEvents(ctx context.Context, collection *mongo.Collection, gameID, playerID string) ([]primitive.ObjectID, error) {
+	cursor, err := collection.Find(ctx, bson.M{"gameId": bson.M{"$eq": gameID}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := make([]primitive.ObjectID, 0)
+	for cursor.Next(ctx) {
+		var data OutboxEvent
+		if err := cursor.Decode(&data); err != nil {
+			return nil, err
+		}
+
+		if id, err := data.toDomain().PlayerID(); err == nil && id == playerID {
+			ids = append(ids, data.ID)
+		}
+	}
+
+	return ids, cursor.Err()
+}
+
+// Deletes the game's events on the collection that refer to the player
+func (c connection) erasePlayerEvents(ctx context.Context, collectionName, gameID, playerID string) (int64, error) {
+	collection := c.client.Database(c.db).Collection(collectionName)
+
+	ids, err := findPlayerEvents(ctx, collection, gameID, playerID)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+// Erases the player's events, both the ones still pending on the outbox and the archived ones.
+// Pending events are dropped without being relayed
+func (c connection) ErasePlayerEvents(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	erasure := privacy.Erasure{Data: privacy.DataEventHistory}
+	for _, collectionName := range []string{outboxCollectionName, eventArchiveCollectionName} {
+		records, err := c.erasePlayerEvents(ctx, collectionName, gameID, playerID)
+		erasure.Records += records
+		if err != nil {
+			return erasure, err
+		}
+	}
+
+	return erasure, nil
+}
+
+// Replaces the player ID on the game's events on the collection that refer to the player. The payload is stored
+// encoded, so it can't be queried and every event of the game is decoded instead
+func (c connection) anonymizePlayerEvents(ctx context.Context, collectionName, gameID, playerID, pseudonym string) (int64, error) {
+	collection := c.client.Database(c.db).Collection(collectionName)
+
+	cursor, err := collection.Find(ctx, bson.M{"gameId": bson.M{"$eq": gameID}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	models := make([]mongo.WriteModel, 0)
+	for cursor.Next(ctx) {
+		var data OutboxEvent
+		if err := cursor.Decode(&data); err != nil {
+			return 0, err
+		}
+
+		event := data.toDomain()
+		if id, err := event.PlayerID(); err != nil || id != playerID {
+			continue
+		}
+
+		event, err := event.WithPlayerID(pseudonym)
+		if err != nil {
+			return 0, err
+		}
+
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": data.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"payload": event.Payload}}),
+		)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return 0, err
+	}
+
+	if len(models) == 0 {
+		return 0, nil
+	}
+
+	result, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// Replaces the player ID on the player's events, both the ones still pending on the outbox and the archived ones.
+// Pending events are relayed later referring to the pseudonym
+func (c connection) AnonymizePlayerEvents(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	anonymization := privacy.Anonymization{Data: privacy.DataEventHistory}
+	for _, collectionName := range []string{outboxCollectionName, eventArchiveCollectionName} {
+		records, err := c.anonymizePlayerEvents(ctx, collectionName, gameID, playerID, pseudonym)
+		anonymization.Records += records
+		if err != nil {
+			return anonymization, err
+		}
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's events, both the ones still pending on the outbox and the archived ones
+func (c connection) CountPlayerEvents(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	count := privacy.DataCount{Data: privacy.DataEventHistory}
+	for _, collectionName := range []string{outboxCollectionName, eventArchiveCollectionName} {
+		ids, err := findPlayerEvents(ctx, c.readCollection(collectionName), gameID, playerID)
+		if err != nil {
+			return count, err
+		}
+
+		count.Records += int64(len(ids))
+	}
+
+	return count, nil
+}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"time"
 
+	"github.com/gabapcia/gameblitz/internal/backup"
+	"github.com/gabapcia/gameblitz/internal/outbox"
+	"github.com/gabapcia/gameblitz/internal/privacy"
 	"github.com/gabapcia/gameblitz/internal/statistic"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -125,21 +128,21 @@ func (p PlayerStatisticProgression) toDomain() statistic.PlayerProgression {
 	}
 }
 
-func (c connection) ensurePlayerStatisticIndexes(ctx context.Context) error {
-	_, err := c.client.Database(c.db).Collection(playerStatisticCollectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
-		{
-			Keys: bson.D{
-				{Key: "playerId", Value: 1},
-				{Key: "statisticId", Value: 1},
-			},
-			Options: options.Index().SetName("playerId_1_statisticId_1").SetUnique(true),
+var playerStatisticIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			{Key: "playerId", Value: 1},
+			{Key: "statisticId", Value: 1},
 		},
-	})
-
-	return err
+		Options: options.Index().SetName("playerId_1_statisticId_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "statisticId", Value: 1}},
+		Options: options.Index().SetName("statisticId_1"),
+	},
 }
 
-func (c connection) createPlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string) error {
+func newPlayerStatisticProgression(st statistic.Statistic, playerID string) PlayerStatisticProgression {
 	var (
 		goalValue     = st.Goal
 		goalCompleted *bool
@@ -154,7 +157,7 @@ func (c connection) createPlayerStatisticProgression(ctx context.Context, st sta
 		landmarks[i] = PlayerStatisticProgressionLandmark{Value: landmark}
 	}
 
-	data := PlayerStatisticProgression{
+	return PlayerStatisticProgression{
 		PlayerID:                 playerID,
 		StatisticID:              st.ID,
 		StatisticAggregationMode: st.AggregationMode,
@@ -163,16 +166,33 @@ func (c connection) createPlayerStatisticProgression(ctx context.Context, st sta
 		GoalCompleted:            goalCompleted,
 		Landmarks:                landmarks,
 	}
+}
+
+// Creates the progression on the main collection, copying it from the mirror one when the backfill hasn't yet
+func (c connection) createPlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string) error {
+	data := newPlayerStatisticProgression(st, playerID)
+	if mirror := c.progressionMirrorCollection(); mirror != "" {
+		mirrored, err := getPlayerStatisticProgression(ctx, c.client.Database(c.db).Collection(mirror), st.ID, playerID)
+		if err == nil {
+			data = mirrored
+		} else if !errors.Is(err, statistic.ErrPlayerStatisticNotFound) {
+			return err
+		}
+	}
+
+	if _, err := c.client.Database(c.db).Collection(c.progressionCollection()).InsertOne(ctx, data); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = ErrPlayerStatisticProgressionAlreadyCreated
+		}
 
-	if _, err := c.client.Database(c.db).Collection(playerStatisticCollectionName).InsertOne(ctx, data); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c connection) getPlayerStatisticProgression(ctx context.Context, statisticID, playerID string) (PlayerStatisticProgression, error) {
-	cursor := c.client.Database(c.db).Collection(playerStatisticCollectionName).FindOne(ctx, bson.M{
+func getPlayerStatisticProgression(ctx context.Context, collection *mongo.Collection, statisticID, playerID string) (PlayerStatisticProgression, error) {
+	cursor := collection.FindOne(ctx, bson.M{
 		"statisticId": bson.M{"$eq": statisticID},
 		"playerId":    bson.M{"$eq": playerID},
 	})
@@ -188,18 +208,15 @@ func (c connection) getPlayerStatisticProgression(ctx context.Context, statistic
 	return data, cursor.Decode(&data)
 }
 
-func (c connection) updatePlayerStatisticProgression(ctx context.Context, statisticID, playerID string, value float64) (PlayerStatisticProgression, error) {
-	data, err := c.getPlayerStatisticProgression(ctx, statisticID, playerID)
-	if err != nil {
-		return PlayerStatisticProgression{}, err
-	}
-
+// Builds the update pipeline aggregating the value on the progression, completing the goal and landmarks reached.
+// The previous document is kept on `_previousData` to tell what the update just completed
+func playerStatisticProgressionUpdate(aggregationMode string, value float64) (bson.A, error) {
 	var (
 		comparisonOp        = ""
 		aggregationOp       = ""
 		defaultCurrentValue = value
 	)
-	switch data.StatisticAggregationMode {
+	switch aggregationMode {
 	case statistic.AggregationModeSum:
 		comparisonOp = "$gte"
 		aggregationOp = "$add"
@@ -215,16 +232,11 @@ func (c connection) updatePlayerStatisticProgression(ctx context.Context, statis
 		comparisonOp = "$lte"
 		aggregationOp = "$min"
 	default:
-		return PlayerStatisticProgression{}, statistic.ErrInvalidAggregationMode
+		return nil, statistic.ErrInvalidAggregationMode
 	}
 
 	var currentValueAgg = bson.M{aggregationOp: bson.A{bson.M{"$ifNull": bson.A{"$currentValue", defaultCurrentValue}}, value}}
 
-	filter := bson.M{
-		"playerId":    bson.M{"$eq": playerID},
-		"statisticId": bson.M{"$eq": statisticID},
-	}
-
 	update := bson.A{
 		bson.M{"$set": bson.M{"_previousData": "$$ROOT"}},
 		bson.M{"$set": bson.M{
@@ -288,10 +300,33 @@ func (c connection) updatePlayerStatisticProgression(ctx context.Context, statis
 		}},
 	}
 
+	return update, nil
+}
+
+func playerStatisticProgressionFilter(statisticID, playerID string) bson.M {
+	return bson.M{
+		"playerId":    bson.M{"$eq": playerID},
+		"statisticId": bson.M{"$eq": statisticID},
+	}
+}
+
+func (c connection) updatePlayerStatisticProgression(ctx context.Context, statisticID, playerID string, value float64) (PlayerStatisticProgression, error) {
+	data, err := getPlayerStatisticProgression(ctx, c.client.Database(c.db).Collection(c.progressionCollection()), statisticID, playerID)
+	if err != nil {
+		return PlayerStatisticProgression{}, err
+	}
+
+	update, err := playerStatisticProgressionUpdate(data.StatisticAggregationMode, value)
+	if err != nil {
+		return PlayerStatisticProgression{}, err
+	}
+
+	filter := playerStatisticProgressionFilter(statisticID, playerID)
+
 	opts := options.FindOneAndUpdate().
 		SetReturnDocument(options.After)
 
-	cursor := c.client.Database(c.db).Collection(playerStatisticCollectionName).FindOneAndUpdate(ctx, filter, update, opts)
+	cursor := c.client.Database(c.db).Collection(c.progressionCollection()).FindOneAndUpdate(ctx, filter, update, opts)
 	if err := cursor.Err(); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			err = statistic.ErrPlayerStatisticNotFound
@@ -304,15 +339,49 @@ func (c connection) updatePlayerStatisticProgression(ctx context.Context, statis
 	return data, cursor.Decode(&data)
 }
 
-func (c connection) upsertPlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string, value float64) (PlayerStatisticProgression, error) {
-	progression, err := c.updatePlayerStatisticProgression(ctx, st.ID, playerID, value)
+// Records the progression updates on the outbox when enabled and the player reached the goal or any landmark
+func (c connection) recordPlayerStatisticProgressionEvent(ctx mongo.SessionContext, st statistic.Statistic, progression PlayerStatisticProgression) error {
+	updates := progression.toDomainUpdates()
+	if !c.outbox || !updates.HasCompletions() {
+		return nil
+	}
+
+	event, err := outbox.NewPlayerStatisticProgressionEvent(st, progression.toDomain(), updates)
 	if err != nil {
+		return err
+	}
+
+	return c.recordOutboxEvent(ctx, event)
+}
+
+func (c connection) upsertPlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string, value float64) (PlayerStatisticProgression, error) {
+	var progression PlayerStatisticProgression
+	err := c.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		var err error
+		progression, err = c.updatePlayerStatisticProgression(ctx, st.ID, playerID, value)
 		if errors.Is(err, statistic.ErrPlayerStatisticNotFound) {
-			if err := c.createPlayerStatisticProgression(ctx, st, playerID); err != nil && !errors.Is(err, ErrPlayerStatisticProgressionAlreadyCreated) {
-				return PlayerStatisticProgression{}, err
+			if err := c.createPlayerStatisticProgression(ctx, st, playerID); err != nil {
+				return err
 			}
 
-			return c.updatePlayerStatisticProgression(ctx, st.ID, playerID, value)
+			progression, err = c.updatePlayerStatisticProgression(ctx, st.ID, playerID, value)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := c.mirrorPlayerStatisticProgressions(ctx, progression); err != nil {
+			return err
+		}
+
+		return c.recordPlayerStatisticProgressionEvent(ctx, st, progression)
+	})
+	if err != nil {
+		// A write error aborts the transaction, so when another request creates the progression
+		// first the whole operation is retried, and this time it will find the document
+		if errors.Is(err, ErrPlayerStatisticProgressionAlreadyCreated) {
+			return c.upsertPlayerStatisticProgression(ctx, st, playerID, value)
 		}
 
 		return PlayerStatisticProgression{}, err
@@ -322,6 +391,10 @@ func (c connection) upsertPlayerStatisticProgression(ctx context.Context, st sta
 }
 
 func (c connection) UpdatePlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+	if err := c.writable(); err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+	}
+
 	playerProgression, err := c.upsertPlayerStatisticProgression(ctx, st, playerID, value)
 	if err != nil {
 		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
@@ -330,11 +403,301 @@ func (c connection) UpdatePlayerStatisticProgression(ctx context.Context, st sta
 	return playerProgression.toDomain(), playerProgression.toDomainUpdates(), nil
 }
 
+// Writes every change with a single bulk write: each progression is created when missing and then updated,
+// in order. The progressions are read back afterwards to tell what each update just completed. The write-behind
+// checkpoint tagged on the context is recorded within the same transaction
+func (c connection) bulkUpsertPlayerStatisticProgression(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]PlayerStatisticProgression, error) {
+	var (
+		models     = make([]mongo.WriteModel, 0, len(changes)*2)
+		readFilter = make(bson.A, len(changes))
+	)
+	for i, change := range changes {
+		update, err := playerStatisticProgressionUpdate(change.Statistic.AggregationMode, change.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		filter := playerStatisticProgressionFilter(change.Statistic.ID, change.PlayerID)
+		models = append(models,
+			mongo.NewUpdateOneModel().
+				SetFilter(filter).
+				SetUpdate(bson.M{"$setOnInsert": newPlayerStatisticProgression(change.Statistic, change.PlayerID)}).
+				SetUpsert(true),
+			mongo.NewUpdateOneModel().
+				SetFilter(filter).
+				SetUpdate(update),
+		)
+		readFilter[i] = filter
+	}
+
+	var progressions []PlayerStatisticProgression
+	err := c.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		collection := c.client.Database(c.db).Collection(c.progressionCollection())
+
+		if err := c.repairPlayerStatisticProgressions(ctx, readFilter); err != nil {
+			return err
+		}
+
+		if _, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(true)); err != nil {
+			return err
+		}
+
+		if err := c.saveWriteBehindCheckpoint(ctx); err != nil {
+			return err
+		}
+
+		cursor, err := collection.Find(ctx, bson.M{"$or": readFilter})
+		if err != nil {
+			return err
+		}
+
+		var data []PlayerStatisticProgression
+		if err := cursor.All(ctx, &data); err != nil {
+			return err
+		}
+
+		if err := c.mirrorPlayerStatisticProgressions(ctx, data...); err != nil {
+			return err
+		}
+
+		type key struct{ statisticID, playerID string }
+		byKey := make(map[key]PlayerStatisticProgression, len(data))
+		for _, progression := range data {
+			byKey[key{statisticID: progression.StatisticID, playerID: progression.PlayerID}] = progression
+		}
+
+		progressions = make([]PlayerStatisticProgression, len(changes))
+		for i, change := range changes {
+			progression, ok := byKey[key{statisticID: change.Statistic.ID, playerID: change.PlayerID}]
+			if !ok {
+				return statistic.ErrPlayerStatisticNotFound
+			}
+
+			if err := c.recordPlayerStatisticProgressionEvent(ctx, change.Statistic, progression); err != nil {
+				return err
+			}
+
+			progressions[i] = progression
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return progressions, nil
+}
+
+func (c connection) BulkUpdatePlayerStatisticProgression(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]statistic.PlayerProgression, []statistic.PlayerProgressionUpdates, error) {
+	if err := c.writable(); err != nil {
+		return nil, nil, err
+	}
+
+	playerProgressions, err := c.bulkUpsertPlayerStatisticProgression(ctx, changes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		progressions = make([]statistic.PlayerProgression, len(playerProgressions))
+		updates      = make([]statistic.PlayerProgressionUpdates, len(playerProgressions))
+	)
+	for i, playerProgression := range playerProgressions {
+		progressions[i] = playerProgression.toDomain()
+		updates[i] = playerProgression.toDomainUpdates()
+	}
+
+	return progressions, updates, nil
+}
+
 func (c connection) GetPlayerProgression(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
-	playerProgression, err := c.getPlayerStatisticProgression(ctx, statisticID, playerID)
+	playerProgression, err := getPlayerStatisticProgression(ctx, c.readCollection(c.progressionCollection()), statisticID, playerID)
+	if mirror := c.progressionMirrorCollection(); mirror != "" && errors.Is(err, statistic.ErrPlayerStatisticNotFound) {
+		playerProgression, err = getPlayerStatisticProgression(ctx, c.readCollection(mirror), statisticID, playerID)
+	}
+
 	if err != nil {
 		return statistic.PlayerProgression{}, err
 	}
 
 	return playerProgression.toDomain(), nil
 }
+
+func (c connection) ListPlayerStatistics(ctx context.Context, statisticID string) ([]statistic.PlayerProgression, error) {
+	data, err := c.findPlayerStatisticProgressions(ctx, bson.M{"statisticId": bson.M{"$eq": statisticID}})
+	if err != nil {
+		return nil, err
+	}
+
+	progressions := make([]statistic.PlayerProgression, len(data))
+	for i, progression := range data {
+		progressions[i] = progression.toDomain()
+	}
+
+	return progressions, nil
+}
+
+func (c connection) RestorePlayerStatistic(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	landmarks := make([]PlayerStatisticProgressionLandmark, len(progression.Landmarks))
+	for i, landmark := range progression.Landmarks {
+		landmarks[i] = PlayerStatisticProgressionLandmark(landmark)
+	}
+
+	data := PlayerStatisticProgression{
+		StartedAt:                progression.StartedAt,
+		UpdatedAt:                progression.UpdatedAt,
+		PlayerID:                 progression.PlayerID,
+		StatisticID:              st.ID,
+		StatisticAggregationMode: st.AggregationMode,
+		CurrentValue:             progression.CurrentValue,
+		GoalValue:                progression.GoalValue,
+		GoalCompleted:            progression.GoalCompleted,
+		GoalCompletedAt:          progression.GoalCompletedAt,
+		Landmarks:                landmarks,
+	}
+
+	return c.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		if _, err := c.client.Database(c.db).Collection(c.progressionCollection()).InsertOne(ctx, data); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				err = backup.ErrEntityAlreadyExists
+			}
+
+			return err
+		}
+
+		return c.mirrorPlayerStatisticProgressions(ctx, data)
+	})
+}
+
+// Removes the player's progression on the statistic, from both collections while migrating. Nothing is removed when
+// the player has none
+func (c connection) DeletePlayerProgression(ctx context.Context, statisticID, playerID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	return c.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		for _, collection := range c.progressionCollections() {
+			_, err := c.client.Database(c.db).Collection(collection).DeleteOne(ctx, bson.M{
+				"playerId":    bson.M{"$eq": playerID},
+				"statisticId": bson.M{"$eq": statisticID},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Finds the IDs of every statistic of the game, including the soft deleted ones
+func findGameStatisticIDs(ctx context.Context, collection *mongo.Collection, gameID string) ([]string, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := collection.Find(ctx, bson.M{"gameId": bson.M{"$eq": gameID}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var statistics []Statistic
+	if err := cursor.All(ctx, &statistics); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(statistics))
+	for i, st := range statistics {
+		ids[i] = st.ID.Hex()
+	}
+
+	return ids, nil
+}
+
+// Erases the player's progression on every statistic of the game, including the soft deleted ones
+func (c connection) ErasePlayerStatistics(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	erasure := privacy.Erasure{Data: privacy.DataStatisticProgression}
+
+	err := c.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		ids, err := findGameStatisticIDs(ctx, c.client.Database(c.db).Collection(statisticCollectionName), gameID)
+		if err != nil {
+			return err
+		}
+
+		for _, collection := range c.progressionCollections() {
+			result, err := c.client.Database(c.db).Collection(collection).DeleteMany(ctx, bson.M{
+				"playerId":    bson.M{"$eq": playerID},
+				"statisticId": bson.M{"$in": ids},
+			})
+			if err != nil {
+				return err
+			}
+
+			// Both collections hold a copy of the same progressions while migrating
+			erasure.Records = max(erasure.Records, result.DeletedCount)
+		}
+
+		return nil
+	})
+
+	return erasure, err
+}
+
+// Moves the player's progression on every statistic of the game, including the soft deleted ones, to the pseudonym
+func (c connection) AnonymizePlayerStatistics(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	anonymization := privacy.Anonymization{Data: privacy.DataStatisticProgression}
+
+	err := c.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		ids, err := findGameStatisticIDs(ctx, c.client.Database(c.db).Collection(statisticCollectionName), gameID)
+		if err != nil {
+			return err
+		}
+
+		for _, collection := range c.progressionCollections() {
+			result, err := c.client.Database(c.db).Collection(collection).UpdateMany(ctx, bson.M{
+				"playerId":    bson.M{"$eq": playerID},
+				"statisticId": bson.M{"$in": ids},
+			}, bson.M{"$set": bson.M{"playerId": pseudonym}})
+			if err != nil {
+				return err
+			}
+
+			// Both collections hold a copy of the same progressions while migrating
+			anonymization.Records = max(anonymization.Records, result.ModifiedCount)
+		}
+
+		return nil
+	})
+
+	return anonymization, err
+}
+
+// Counts the player's progression on every statistic of the game, including the soft deleted ones
+func (c connection) CountPlayerStatistics(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	ids, err := findGameStatisticIDs(ctx, c.readCollection(statisticCollectionName), gameID)
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	records, err := c.readCollection(c.progressionCollection()).CountDocuments(ctx, bson.M{
+		"playerId":    bson.M{"$eq": playerID},
+		"statisticId": bson.M{"$in": ids},
+	})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataStatisticProgression, Records: records}, nil
+}
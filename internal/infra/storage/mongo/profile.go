@@ -0,0 +1,360 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/encryption"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const profileCollectionName = "playerProfiles"
+
+type Profile struct {
+	CreatedAt   time.Time         `bson:"createdAt"`
+	UpdatedAt   time.Time         `bson:"updatedAt"`
+	GameID      string            `bson:"gameId"`
+	PlayerID    string            `bson:"playerId"`
+	DisplayName string            `bson:"displayName"` // Encrypted once the encryption is enabled
+	AvatarURL   string            `bson:"avatarUrl,omitempty"`
+	Country     string            `bson:"country,omitempty"`
+	Metadata    map[string]string `bson:"metadata"`
+	SearchTerms []string          `bson:"searchTerms"` // Normalized display name from each of its words on, matched by the searches' prefixes. See `sealDisplayName`
+}
+
+// Field of the display name search prefixes blind indexes
+const searchPrefixField = "searchPrefix"
+
+func (p Profile) toDomain(cipher *encryption.Cipher) (player.Profile, error) {
+	metadata := p.Metadata
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+
+	displayName, err := cipher.Decrypt(p.DisplayName)
+	if err != nil {
+		return player.Profile{}, err
+	}
+
+	return player.Profile{
+		CreatedAt:   p.CreatedAt.UTC(),
+		UpdatedAt:   p.UpdatedAt.UTC(),
+		GameID:      p.GameID,
+		PlayerID:    p.PlayerID,
+		DisplayName: displayName,
+		AvatarURL:   p.AvatarURL,
+		Country:     p.Country,
+		Metadata:    metadata,
+	}, nil
+}
+
+// Display name as stored, along with its search terms. Once the encryption is enabled, the terms can't be matched by
+// their prefixes, so the blind index of each prefix is stored instead
+func (c connection) sealDisplayName(displayName string) (string, []string, error) {
+	if c.cipher == nil {
+		return displayName, player.SearchTerms(displayName), nil
+	}
+
+	encrypted, err := c.cipher.Encrypt(displayName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	prefixes := player.SearchPrefixes(displayName)
+	for i, prefix := range prefixes {
+		prefixes[i] = c.cipher.BlindIndex(searchPrefixField, prefix)
+	}
+
+	return encrypted, prefixes, nil
+}
+
+// A player has a single profile per game. The display names are searched by the prefixes of their terms
+// and, loosely, by their words through the text index
+var profileIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "searchTerms", Value: 1}},
+		Options: options.Index().SetName("gameId_1_searchTerms_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "displayName", Value: "text"}},
+		Options: options.Index().SetName("gameId_1_displayName_text").SetDefaultLanguage("none"),
+	},
+}
+
+func (c connection) CreateProfile(ctx context.Context, profile player.Profile) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	displayName, searchTerms, err := c.sealDisplayName(profile.DisplayName)
+	if err != nil {
+		return err
+	}
+
+	data := Profile{
+		CreatedAt:   profile.CreatedAt,
+		UpdatedAt:   profile.UpdatedAt,
+		GameID:      profile.GameID,
+		PlayerID:    profile.PlayerID,
+		DisplayName: displayName,
+		AvatarURL:   profile.AvatarURL,
+		Country:     profile.Country,
+		Metadata:    profile.Metadata,
+		SearchTerms: searchTerms,
+	}
+
+	if _, err := c.client.Database(c.db).Collection(profileCollectionName).InsertOne(ctx, data); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = player.ErrProfileAlreadyExists
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (c connection) GetProfile(ctx context.Context, gameID, playerID string) (player.Profile, error) {
+	var data Profile
+	err := c.readCollection(profileCollectionName).FindOne(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = player.ErrProfileNotFound
+		}
+
+		return player.Profile{}, err
+	}
+
+	return data.toDomain(c.cipher)
+}
+
+func (c connection) UpdateProfile(ctx context.Context, profile player.Profile) (player.Profile, error) {
+	if err := c.writable(); err != nil {
+		return player.Profile{}, err
+	}
+
+	displayName, searchTerms, err := c.sealDisplayName(profile.DisplayName)
+	if err != nil {
+		return player.Profile{}, err
+	}
+
+	var (
+		filter = bson.M{
+			"gameId":   bson.M{"$eq": profile.GameID},
+			"playerId": bson.M{"$eq": profile.PlayerID},
+		}
+		update = bson.M{
+			"$set": bson.M{
+				"updatedAt":   profile.UpdatedAt,
+				"displayName": displayName,
+				"avatarUrl":   profile.AvatarURL,
+				"country":     profile.Country,
+				"metadata":    profile.Metadata,
+				"searchTerms": searchTerms,
+			},
+		}
+		opts = options.FindOneAndUpdate().SetReturnDocument(options.After)
+	)
+
+	var data Profile
+	if err := c.client.Database(c.db).Collection(profileCollectionName).FindOneAndUpdate(ctx, filter, update, opts).Decode(&data); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = player.ErrProfileNotFound
+		}
+
+		return player.Profile{}, err
+	}
+
+	return data.toDomain(c.cipher)
+}
+
+func (c connection) DeleteProfile(ctx context.Context, gameID, playerID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	result, err := c.client.Database(c.db).Collection(profileCollectionName).DeleteOne(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return player.ErrProfileNotFound
+	}
+
+	return nil
+}
+
+func (c connection) ListProfiles(ctx context.Context, gameID string, playerIDs []string) ([]player.Profile, error) {
+	return c.findProfiles(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$in": playerIDs},
+	}, options.Find())
+}
+
+func (c connection) findProfiles(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]player.Profile, error) {
+	cursor, err := c.readCollection(profileCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Profile
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	profiles := make([]player.Profile, len(data))
+	for i, profile := range data {
+		if profiles[i], err = profile.toDomain(c.cipher); err != nil {
+			return nil, err
+		}
+	}
+
+	return profiles, nil
+}
+
+// Once the encryption is enabled, the profiles with a term starting with the search are matched by the blind index of
+// the search, up to `player.MaxIndexedSearchLength` characters, and ordered by player ID. The display names are neither
+// ordered nor matched by the text index, as only their encryption is stored
+func (c connection) searchEncryptedProfiles(ctx context.Context, gameID, search string, offset, limit int) ([]player.Profile, error) {
+	filter := bson.M{
+		"gameId":      bson.M{"$eq": gameID},
+		"searchTerms": bson.M{"$eq": c.cipher.BlindIndex(searchPrefixField, player.IndexedSearch(search))},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "playerId", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	return c.findProfiles(ctx, filter, opts)
+}
+
+// Profiles with a term starting with the search come first, ordered by display name. The rest of the page is filled
+// with the profiles matched by the text index, which ignores case, diacritics and the order of the words, best scored first
+func (c connection) SearchProfiles(ctx context.Context, gameID, search string, offset, limit int) ([]player.Profile, error) {
+	if c.cipher != nil {
+		return c.searchEncryptedProfiles(ctx, gameID, search, offset, limit)
+	}
+
+	prefix := bson.M{"$regex": "^" + regexp.QuoteMeta(search)}
+
+	prefixFilter := bson.M{
+		"gameId":      bson.M{"$eq": gameID},
+		"searchTerms": prefix,
+	}
+
+	prefixed, err := c.readCollection(profileCollectionName).CountDocuments(ctx, prefixFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]player.Profile, 0, limit)
+	if int64(offset) < prefixed {
+		opts := options.Find().
+			SetSort(bson.D{{Key: "displayName", Value: 1}, {Key: "playerId", Value: 1}}).
+			SetSkip(int64(offset)).
+			SetLimit(int64(limit))
+
+		data, err := c.findProfiles(ctx, prefixFilter, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		profiles = append(profiles, data...)
+	}
+
+	if len(profiles) == limit {
+		return profiles, nil
+	}
+
+	textFilter := bson.M{
+		"gameId":      bson.M{"$eq": gameID},
+		"$text":       bson.M{"$search": search},
+		"searchTerms": bson.M{"$not": prefix},
+	}
+	opts := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "displayName", Value: 1}, {Key: "playerId", Value: 1}}).
+		SetSkip(max(int64(offset)-prefixed, 0)).
+		SetLimit(int64(limit - len(profiles)))
+
+	data, err := c.findProfiles(ctx, textFilter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(profiles, data...), nil
+}
+
+// Erases the player's profile
+func (c connection) ErasePlayerProfile(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(profileCollectionName).DeleteMany(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataProfile, Records: result.DeletedCount}, nil
+}
+
+// Moves the player's profile to the pseudonym. The display name, avatar, country and metadata identify the player
+// on their own, so they are cleared, keeping the display name as the pseudonym
+func (c connection) AnonymizePlayerProfile(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	displayName, searchTerms, err := c.sealDisplayName(pseudonym)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(profileCollectionName).UpdateMany(ctx,
+		bson.M{"gameId": bson.M{"$eq": gameID}, "playerId": bson.M{"$eq": playerID}},
+		bson.M{
+			"$set":   bson.M{"playerId": pseudonym, "displayName": displayName, "metadata": bson.M{}, "searchTerms": searchTerms},
+			"$unset": bson.M{"avatarUrl": "", "country": ""},
+		},
+	)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataProfile, Records: result.ModifiedCount}, nil
+}
+
+// Counts the player's profile
+func (c connection) CountPlayerProfile(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(profileCollectionName).CountDocuments(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataProfile, Records: records}, nil
+}
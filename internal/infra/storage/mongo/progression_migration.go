@@ -0,0 +1,359 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Phases of the online migration of the player statistic progressions to another collection, e.g. on a schema change.
+// They are meant to be rolled out in order, each one on every instance before the next one starts
+const (
+	ProgressionMigrationOff       = "off"        // Only the current collection is used
+	ProgressionMigrationDualWrite = "dual-write" // Writes go to both, reads to the current collection
+	ProgressionMigrationDualRead  = "dual-read"  // Writes go to both, reads to the target collection
+	ProgressionMigrationDone      = "done"       // Only the target collection is used
+)
+
+var ProgressionMigrationModes = []string{
+	ProgressionMigrationOff,
+	ProgressionMigrationDualWrite,
+	ProgressionMigrationDualRead,
+	ProgressionMigrationDone,
+}
+
+// Collection the progressions are migrated to when none is set
+const DefaultProgressionMigrationCollection = "playersStatisticsV2"
+
+// Progressions compared at once while verifying and copied at once while backfilling
+const progressionMigrationBatchSize = 500
+
+// Differing progressions listed on the verification
+const progressionMigrationSampleSize = 30
+
+var (
+	ErrUnknownProgressionMigration = errors.New("unknown progression migration mode")
+	ErrProgressionMigrationTarget  = errors.New("progression migration target must differ from the current collection")
+	ErrProgressionMismatch         = errors.New("progression collections differ")
+)
+
+func validateProgressionMigration(mode, target string) error {
+	if mode != "" && !slices.Contains(ProgressionMigrationModes, mode) {
+		return fmt.Errorf("%w: %s", ErrUnknownProgressionMigration, mode)
+	}
+
+	if target == playerStatisticCollectionName {
+		return ErrProgressionMigrationTarget
+	}
+
+	return nil
+}
+
+// Collection the progressions are read from and written to first, within the transactions
+func (c connection) progressionCollection() string {
+	switch c.progressionMigration {
+	case ProgressionMigrationDualRead, ProgressionMigrationDone:
+		return c.progressionMigrationTarget
+	default:
+		return playerStatisticCollectionName
+	}
+}
+
+// Collection every progression write is mirrored to. Empty when no migration is in progress
+func (c connection) progressionMirrorCollection() string {
+	switch c.progressionMigration {
+	case ProgressionMigrationDualWrite:
+		return c.progressionMigrationTarget
+	case ProgressionMigrationDualRead:
+		return playerStatisticCollectionName
+	default:
+		return ""
+	}
+}
+
+// Collections holding a copy of the progressions, the one read first at the top
+func (c connection) progressionCollections() []string {
+	if mirror := c.progressionMirrorCollection(); mirror != "" {
+		return []string{c.progressionCollection(), mirror}
+	}
+
+	return []string{c.progressionCollection()}
+}
+
+// Indexes that every collection must have, including the target of the migration while it's in progress or done
+func (c connection) indexes() map[string][]mongo.IndexModel {
+	if c.progressionMigration == "" || c.progressionMigration == ProgressionMigrationOff {
+		return indexRegistry
+	}
+
+	indexes := make(map[string][]mongo.IndexModel, len(indexRegistry)+1)
+	for collection, models := range indexRegistry {
+		indexes[collection] = models
+	}
+	indexes[c.progressionMigrationTarget] = playerStatisticIndexes
+
+	return indexes
+}
+
+// Finds the progressions matching the filter on the main collection, adding the ones only found on the mirror
+func (c connection) findPlayerStatisticProgressions(ctx context.Context, filter bson.M) ([]PlayerStatisticProgression, error) {
+	type key struct{ statisticID, playerID string }
+
+	var (
+		data  = make([]PlayerStatisticProgression, 0)
+		found = make(map[key]bool)
+	)
+	for _, collection := range c.progressionCollections() {
+		cursor, err := c.readCollection(collection).Find(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		var progressions []PlayerStatisticProgression
+		if err := cursor.All(ctx, &progressions); err != nil {
+			return nil, err
+		}
+
+		for _, progression := range progressions {
+			k := key{statisticID: progression.StatisticID, playerID: progression.PlayerID}
+			if found[k] {
+				continue
+			}
+
+			found[k] = true
+			data = append(data, progression)
+		}
+	}
+
+	return data, nil
+}
+
+// Replaces the progressions on the mirror collection with the ones just written, so both collections converge on
+// the same documents whatever the previous state of the mirror. Must run within the transaction of the write
+func (c connection) mirrorPlayerStatisticProgressions(ctx context.Context, progressions ...PlayerStatisticProgression) error {
+	mirror := c.progressionMirrorCollection()
+	if mirror == "" || len(progressions) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, len(progressions))
+	for i, progression := range progressions {
+		models[i] = mongo.NewReplaceOneModel().
+			SetFilter(playerStatisticProgressionFilter(progression.StatisticID, progression.PlayerID)).
+			SetReplacement(progression).
+			SetUpsert(true)
+	}
+
+	_, err := c.client.Database(c.db).Collection(mirror).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// Copies the progressions missing on the main collection from the mirror one, so a write never starts from scratch
+// a progression the backfill hasn't copied yet. Must run within the transaction of the write
+func (c connection) repairPlayerStatisticProgressions(ctx context.Context, filters bson.A) error {
+	mirror := c.progressionMirrorCollection()
+	if mirror == "" || len(filters) == 0 {
+		return nil
+	}
+
+	cursor, err := c.client.Database(c.db).Collection(mirror).Find(ctx, bson.M{"$or": filters})
+	if err != nil {
+		return err
+	}
+
+	var data []PlayerStatisticProgression
+	if err := cursor.All(ctx, &data); err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, len(data))
+	for i, progression := range data {
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(playerStatisticProgressionFilter(progression.StatisticID, progression.PlayerID)).
+			SetUpdate(bson.M{"$setOnInsert": progression}).
+			SetUpsert(true)
+	}
+
+	_, err = c.client.Database(c.db).Collection(c.progressionCollection()).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// Copies the progressions of the current collection missing on the target one, leaving the ones already there
+// untouched, as the dual writes keep them up to date. Creates the target indexes first, so it can run before
+// the dual writes start. Returns how many were copied
+func (c connection) BackfillProgressionMigration(ctx context.Context) (int64, error) {
+	var (
+		source = c.client.Database(c.db).Collection(playerStatisticCollectionName)
+		target = c.client.Database(c.db).Collection(c.progressionMigrationTarget)
+	)
+
+	if _, err := target.Indexes().CreateMany(ctx, playerStatisticIndexes); err != nil {
+		return 0, err
+	}
+
+	cursor, err := source.Find(ctx, bson.M{}, options.Find().SetBatchSize(progressionMigrationBatchSize))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(context.Background())
+
+	var (
+		copied int64
+		models = make([]mongo.WriteModel, 0, progressionMigrationBatchSize)
+	)
+
+	flush := func() error {
+		if len(models) == 0 {
+			return nil
+		}
+
+		result, err := target.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+		if result != nil {
+			copied += result.UpsertedCount
+		}
+		models = models[:0]
+
+		return err
+	}
+
+	for cursor.Next(ctx) {
+		var progression PlayerStatisticProgression
+		if err := cursor.Decode(&progression); err != nil {
+			return copied, err
+		}
+
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(playerStatisticProgressionFilter(progression.StatisticID, progression.PlayerID)).
+			SetUpdate(bson.M{"$setOnInsert": progression}).
+			SetUpsert(true))
+
+		if len(models) == progressionMigrationBatchSize {
+			if err := flush(); err != nil {
+				return copied, err
+			}
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return copied, err
+	}
+
+	return copied, flush()
+}
+
+// Comparison of the progressions on the current and on the target collections
+type ProgressionVerification struct {
+	Source          string   // Current collection
+	Target          string   // Collection the progressions are migrated to
+	Compared        int64    // Progressions found on either collection
+	MissingOnTarget int64    // Progressions only on the current collection
+	MissingOnSource int64    // Progressions only on the target collection
+	Mismatched      int64    // Progressions on both collections with different values
+	Samples         []string // Some of the progressions that differ, as `<problem> <statistic id>/<player id>`
+}
+
+// Whether both collections hold the same progressions
+func (v ProgressionVerification) Consistent() bool {
+	return v.MissingOnTarget == 0 && v.MissingOnSource == 0 && v.Mismatched == 0
+}
+
+func (v *ProgressionVerification) sample(problem string, progression PlayerStatisticProgression) {
+	if len(v.Samples) < progressionMigrationSampleSize {
+		v.Samples = append(v.Samples, fmt.Sprintf("%s %s/%s", problem, progression.StatisticID, progression.PlayerID))
+	}
+}
+
+func compareProgressionKeys(a, b PlayerStatisticProgression) int {
+	if n := strings.Compare(a.StatisticID, b.StatisticID); n != 0 {
+		return n
+	}
+
+	return strings.Compare(a.PlayerID, b.PlayerID)
+}
+
+// Walks both collections sorted by statistic and player at once, so neither one is loaded in memory.
+// Returns `ErrProgressionMismatch` alongside the report when they differ
+func (c connection) VerifyProgressionMigration(ctx context.Context) (ProgressionVerification, error) {
+	verification := ProgressionVerification{Source: playerStatisticCollectionName, Target: c.progressionMigrationTarget, Samples: make([]string, 0)}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "statisticId", Value: 1}, {Key: "playerId", Value: 1}}).
+		SetProjection(bson.M{"_id": 0}).
+		SetBatchSize(progressionMigrationBatchSize)
+
+	source, err := c.client.Database(c.db).Collection(verification.Source).Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return verification, err
+	}
+	defer source.Close(context.Background())
+
+	target, err := c.client.Database(c.db).Collection(verification.Target).Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return verification, err
+	}
+	defer target.Close(context.Background())
+
+	next := func(cursor *mongo.Cursor) (*PlayerStatisticProgression, error) {
+		if !cursor.Next(ctx) {
+			return nil, cursor.Err()
+		}
+
+		var progression PlayerStatisticProgression
+		return &progression, cursor.Decode(&progression)
+	}
+
+	s, err := next(source)
+	if err != nil {
+		return verification, err
+	}
+
+	t, err := next(target)
+	if err != nil {
+		return verification, err
+	}
+
+	for s != nil || t != nil {
+		verification.Compared++
+
+		switch {
+		case t == nil || (s != nil && compareProgressionKeys(*s, *t) < 0):
+			verification.MissingOnTarget++
+			verification.sample("missing on target", *s)
+			s, err = next(source)
+		case s == nil || compareProgressionKeys(*s, *t) > 0:
+			verification.MissingOnSource++
+			verification.sample("missing on source", *t)
+			t, err = next(target)
+		default:
+			if !reflect.DeepEqual(*s, *t) {
+				verification.Mismatched++
+				verification.sample("mismatched", *s)
+			}
+
+			if s, err = next(source); err == nil {
+				t, err = next(target)
+			}
+		}
+
+		if err != nil {
+			return verification, err
+		}
+	}
+
+	if !verification.Consistent() {
+		return verification, ErrProgressionMismatch
+	}
+
+	return verification, nil
+}
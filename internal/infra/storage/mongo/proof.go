@@ -0,0 +1,96 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/proof"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const proofRuleCollectionName = "proofRules"
+
+type ProofRule struct {
+	UpdatedAt     time.Time `bson:"updatedAt"`
+	GameID        string    `bson:"gameId"`
+	LeaderboardID string    `bson:"leaderboardId"`
+	TopN          int       `bson:"topN"`
+}
+
+func (r ProofRule) toDomain() proof.Rule {
+	return proof.Rule{
+		UpdatedAt:     r.UpdatedAt.UTC(),
+		GameID:        r.GameID,
+		LeaderboardID: r.LeaderboardID,
+		TopN:          r.TopN,
+	}
+}
+
+// A leaderboard has a single rule
+var proofRuleIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "leaderboardId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_leaderboardId_1").SetUnique(true),
+	},
+}
+
+func proofRuleFilter(gameID, leaderboardID string) bson.M {
+	return bson.M{
+		"gameId":        bson.M{"$eq": gameID},
+		"leaderboardId": bson.M{"$eq": leaderboardID},
+	}
+}
+
+func (c connection) SetProofRule(ctx context.Context, rule proof.Rule) (proof.Rule, error) {
+	if err := c.writable(); err != nil {
+		return proof.Rule{}, err
+	}
+
+	data := ProofRule{
+		UpdatedAt:     rule.UpdatedAt,
+		GameID:        rule.GameID,
+		LeaderboardID: rule.LeaderboardID,
+		TopN:          rule.TopN,
+	}
+
+	filter := proofRuleFilter(rule.GameID, rule.LeaderboardID)
+	if _, err := c.client.Database(c.db).Collection(proofRuleCollectionName).ReplaceOne(ctx, filter, data, options.Replace().SetUpsert(true)); err != nil {
+		return proof.Rule{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) GetProofRule(ctx context.Context, gameID, leaderboardID string) (proof.Rule, error) {
+	var data ProofRule
+	if err := c.readCollection(proofRuleCollectionName).FindOne(ctx, proofRuleFilter(gameID, leaderboardID)).Decode(&data); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = proof.ErrRuleNotFound
+		}
+
+		return proof.Rule{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) DeleteProofRule(ctx context.Context, gameID, leaderboardID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	result, err := c.client.Database(c.db).Collection(proofRuleCollectionName).DeleteOne(ctx, proofRuleFilter(gameID, leaderboardID))
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return proof.ErrRuleNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const quarantineCollectionName = "quarantinedSubmissions"
+
+type QuarantinedSubmission struct {
+	HeldAt        time.Time          `bson:"heldAt"`
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	GameID        string             `bson:"gameId"`
+	LeaderboardID string             `bson:"leaderboardId"`
+	PlayerID      string             `bson:"playerId"`
+	Value         float64            `bson:"value"`
+	Reason        string             `bson:"reason"`
+	Proof         string             `bson:"proof,omitempty"`
+}
+
+func (s QuarantinedSubmission) toDomain() quarantine.Submission {
+	return quarantine.Submission{
+		HeldAt:        s.HeldAt.UTC(),
+		ID:            s.ID.Hex(),
+		GameID:        s.GameID,
+		LeaderboardID: s.LeaderboardID,
+		PlayerID:      s.PlayerID,
+		Value:         s.Value,
+		Reason:        s.Reason,
+		Proof:         s.Proof,
+	}
+}
+
+// The review list is read oldest first, optionally narrowed to a leaderboard
+var quarantineIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "heldAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_heldAt_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "leaderboardId", Value: 1}, {Key: "heldAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_leaderboardId_1_heldAt_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1"),
+	},
+}
+
+func quarantinedSubmissionFilter(gameID, id string) (bson.M, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, quarantine.ErrSubmissionNotFound
+	}
+
+	return bson.M{
+		"_id":    bson.M{"$eq": objectID},
+		"gameId": bson.M{"$eq": gameID},
+	}, nil
+}
+
+func (c connection) CreateQuarantinedSubmission(ctx context.Context, s quarantine.Submission) (quarantine.Submission, error) {
+	if err := c.writable(); err != nil {
+		return quarantine.Submission{}, err
+	}
+
+	data := QuarantinedSubmission{
+		HeldAt:        s.HeldAt,
+		GameID:        s.GameID,
+		LeaderboardID: s.LeaderboardID,
+		PlayerID:      s.PlayerID,
+		Value:         s.Value,
+		Reason:        s.Reason,
+		Proof:         s.Proof,
+	}
+
+	result, err := c.client.Database(c.db).Collection(quarantineCollectionName).InsertOne(ctx, data)
+	if err != nil {
+		return quarantine.Submission{}, err
+	}
+
+	data.ID = result.InsertedID.(primitive.ObjectID)
+	return data.toDomain(), nil
+}
+
+func (c connection) GetQuarantinedSubmission(ctx context.Context, gameID, id string) (quarantine.Submission, error) {
+	filter, err := quarantinedSubmissionFilter(gameID, id)
+	if err != nil {
+		return quarantine.Submission{}, err
+	}
+
+	var data QuarantinedSubmission
+	if err := c.readCollection(quarantineCollectionName).FindOne(ctx, filter).Decode(&data); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = quarantine.ErrSubmissionNotFound
+		}
+
+		return quarantine.Submission{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) ListQuarantinedSubmissions(ctx context.Context, gameID, leaderboardID string, limit int) ([]quarantine.Submission, error) {
+	filter := bson.M{"gameId": bson.M{"$eq": gameID}}
+	if leaderboardID != "" {
+		filter["leaderboardId"] = bson.M{"$eq": leaderboardID}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "heldAt", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := c.readCollection(quarantineCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []QuarantinedSubmission
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	submissions := make([]quarantine.Submission, len(data))
+	for i, s := range data {
+		submissions[i] = s.toDomain()
+	}
+
+	return submissions, nil
+}
+
+func (c connection) DeleteQuarantinedSubmission(ctx context.Context, gameID, id string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	filter, err := quarantinedSubmissionFilter(gameID, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.client.Database(c.db).Collection(quarantineCollectionName).DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return quarantine.ErrSubmissionNotFound
+	}
+
+	return nil
+}
+
+// Erases the player's submissions held for review
+func (c connection) ErasePlayerQuarantinedSubmissions(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(quarantineCollectionName).DeleteMany(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataQuarantine, Records: result.DeletedCount}, nil
+}
+
+// Moves the player's submissions held for review to the pseudonym
+func (c connection) AnonymizePlayerQuarantinedSubmissions(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(quarantineCollectionName).UpdateMany(ctx,
+		bson.M{"gameId": bson.M{"$eq": gameID}, "playerId": bson.M{"$eq": playerID}},
+		bson.M{"$set": bson.M{"playerId": pseudonym}},
+	)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataQuarantine, Records: result.ModifiedCount}, nil
+}
+
+// Counts the player's submissions held for review
+func (c connection) CountPlayerQuarantinedSubmissions(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(quarantineCollectionName).CountDocuments(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataQuarantine, Records: records}, nil
+}
@@ -0,0 +1,64 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/quota"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const quotaCollectionName = "quotas"
+
+// A single document is kept per game, keyed by its ID
+type Quota struct {
+	GameID            string    `bson:"_id"`
+	RequestsPerMinute int64     `bson:"requestsPerMinute"`
+	MaxLeaderboards   int64     `bson:"maxLeaderboards"`
+	MaxStatistics     int64     `bson:"maxStatistics"`
+	UpdatedAt         time.Time `bson:"updatedAt"`
+}
+
+func (q Quota) toDomain() quota.Quota {
+	return quota.Quota{
+		GameID:            q.GameID,
+		RequestsPerMinute: q.RequestsPerMinute,
+		MaxLeaderboards:   q.MaxLeaderboards,
+		MaxStatistics:     q.MaxStatistics,
+		UpdatedAt:         q.UpdatedAt.UTC(),
+	}
+}
+
+func (c connection) SetQuota(ctx context.Context, q quota.Quota) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	data := Quota{
+		GameID:            q.GameID,
+		RequestsPerMinute: q.RequestsPerMinute,
+		MaxLeaderboards:   q.MaxLeaderboards,
+		MaxStatistics:     q.MaxStatistics,
+		UpdatedAt:         q.UpdatedAt,
+	}
+
+	_, err := c.client.Database(c.db).Collection(quotaCollectionName).ReplaceOne(ctx, bson.M{"_id": q.GameID}, data, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (c connection) GetQuota(ctx context.Context, gameID string) (quota.Quota, error) {
+	var data Quota
+	if err := c.readCollection(quotaCollectionName).FindOne(ctx, bson.M{"_id": gameID}).Decode(&data); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = quota.ErrQuotaNotFound
+		}
+
+		return quota.Quota{}, err
+	}
+
+	return data.toDomain(), nil
+}
@@ -0,0 +1,406 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/reward"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	rewardRuleCollectionName  = "rewardRules"
+	rewardGrantCollectionName = "rewardGrants"
+)
+
+type (
+	Reward struct {
+		Item     string `bson:"item"`
+		Quantity int64  `bson:"quantity"`
+	}
+
+	RewardRule struct {
+		CreatedAt    time.Time          `bson:"createdAt"`
+		ID           primitive.ObjectID `bson:"_id,omitempty"`
+		GameID       string             `bson:"gameId"`
+		Source       string             `bson:"source"`
+		SourceID     string             `bson:"sourceId"`
+		FromPosition int64              `bson:"fromPosition,omitempty"`
+		ToPosition   int64              `bson:"toPosition,omitempty"`
+		Rewards      []Reward           `bson:"rewards"`
+	}
+
+	// The grant ID is kept as the document ID, so queueing the same grant twice is rejected by the storage
+	RewardGrant struct {
+		CreatedAt     time.Time `bson:"createdAt"`
+		UpdatedAt     time.Time `bson:"updatedAt"`
+		NextAttemptAt time.Time `bson:"nextAttemptAt"`
+		GrantedAt     time.Time `bson:"grantedAt,omitempty"`
+		ID            string    `bson:"_id"`
+		GameID        string    `bson:"gameId"`
+		PlayerID      string    `bson:"playerId"`
+		RuleID        string    `bson:"ruleId"`
+		Source        string    `bson:"source"`
+		SourceID      string    `bson:"sourceId"`
+		Position      int64     `bson:"position,omitempty"`
+		Rewards       []Reward  `bson:"rewards"`
+		Status        string    `bson:"status"`
+		Attempts      int       `bson:"attempts"`
+		LastError     string    `bson:"lastError,omitempty"`
+	}
+)
+
+func newRewards(rewards []reward.Reward) []Reward {
+	data := make([]Reward, len(rewards))
+	for i, r := range rewards {
+		data[i] = Reward{Item: r.Item, Quantity: r.Quantity}
+	}
+
+	return data
+}
+
+func rewardsToDomain(data []Reward) []reward.Reward {
+	rewards := make([]reward.Reward, len(data))
+	for i, r := range data {
+		rewards[i] = reward.Reward{Item: r.Item, Quantity: r.Quantity}
+	}
+
+	return rewards
+}
+
+func (r RewardRule) toDomain() reward.Rule {
+	return reward.Rule{
+		CreatedAt:    r.CreatedAt.UTC(),
+		ID:           r.ID.Hex(),
+		GameID:       r.GameID,
+		Source:       r.Source,
+		SourceID:     r.SourceID,
+		FromPosition: r.FromPosition,
+		ToPosition:   r.ToPosition,
+		Rewards:      rewardsToDomain(r.Rewards),
+	}
+}
+
+func newRewardGrant(g reward.Grant) RewardGrant {
+	return RewardGrant{
+		CreatedAt:     g.CreatedAt,
+		UpdatedAt:     g.UpdatedAt,
+		NextAttemptAt: g.NextAttemptAt,
+		GrantedAt:     g.GrantedAt,
+		ID:            g.ID,
+		GameID:        g.GameID,
+		PlayerID:      g.PlayerID,
+		RuleID:        g.RuleID,
+		Source:        g.Source,
+		SourceID:      g.SourceID,
+		Position:      g.Position,
+		Rewards:       newRewards(g.Rewards),
+		Status:        g.Status,
+		Attempts:      g.Attempts,
+		LastError:     g.LastError,
+	}
+}
+
+func (g RewardGrant) toDomain() reward.Grant {
+	grant := reward.Grant{
+		CreatedAt:     g.CreatedAt.UTC(),
+		UpdatedAt:     g.UpdatedAt.UTC(),
+		NextAttemptAt: g.NextAttemptAt.UTC(),
+		ID:            g.ID,
+		GameID:        g.GameID,
+		PlayerID:      g.PlayerID,
+		RuleID:        g.RuleID,
+		Source:        g.Source,
+		SourceID:      g.SourceID,
+		Position:      g.Position,
+		Rewards:       rewardsToDomain(g.Rewards),
+		Status:        g.Status,
+		Attempts:      g.Attempts,
+		LastError:     g.LastError,
+	}
+
+	if !g.GrantedAt.IsZero() {
+		grant.GrantedAt = g.GrantedAt.UTC()
+	}
+
+	return grant
+}
+
+var rewardRuleIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "source", Value: 1}, {Key: "sourceId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_source_1_sourceId_1"),
+	},
+}
+
+var rewardGrantIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}},
+		Options: options.Index().SetName("status_1_nextAttemptAt_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}, {Key: "createdAt", Value: -1}},
+		Options: options.Index().SetName("gameId_1_playerId_1_createdAt_-1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "createdAt", Value: -1}},
+		Options: options.Index().SetName("gameId_1_createdAt_-1"),
+	},
+}
+
+func (c connection) CreateRewardRule(ctx context.Context, data reward.NewRuleData) (reward.Rule, error) {
+	if err := c.writable(); err != nil {
+		return reward.Rule{}, err
+	}
+
+	r := RewardRule{
+		CreatedAt:    time.Now().UTC(),
+		GameID:       data.GameID,
+		Source:       data.Source,
+		SourceID:     data.SourceID,
+		FromPosition: data.FromPosition,
+		ToPosition:   data.ToPosition,
+		Rewards:      newRewards(data.Rewards),
+	}
+
+	result, err := c.client.Database(c.db).Collection(rewardRuleCollectionName).InsertOne(ctx, r)
+	if err != nil {
+		return reward.Rule{}, err
+	}
+
+	r.ID = result.InsertedID.(primitive.ObjectID)
+	return r.toDomain(), nil
+}
+
+func (c connection) listRewardRules(ctx context.Context, filter bson.M) ([]reward.Rule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := c.readCollection(rewardRuleCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []RewardRule
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	rules := make([]reward.Rule, len(data))
+	for i, r := range data {
+		rules[i] = r.toDomain()
+	}
+
+	return rules, nil
+}
+
+func (c connection) ListRewardRules(ctx context.Context, gameID string) ([]reward.Rule, error) {
+	return c.listRewardRules(ctx, bson.M{"gameId": bson.M{"$eq": gameID}})
+}
+
+func (c connection) ListSourceRewardRules(ctx context.Context, gameID, source, sourceID string) ([]reward.Rule, error) {
+	return c.listRewardRules(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"source":   bson.M{"$eq": source},
+		"sourceId": bson.M{"$eq": sourceID},
+	})
+}
+
+// The grants already queued by the rule are kept as the audit of what was granted
+func (c connection) DeleteRewardRule(ctx context.Context, id, gameID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return reward.ErrRuleNotFound
+	}
+
+	result, err := c.client.Database(c.db).Collection(rewardRuleCollectionName).DeleteOne(ctx, bson.M{
+		"_id":    bson.M{"$eq": objectID},
+		"gameId": bson.M{"$eq": gameID},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return reward.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+// Grants already queued are kept as they are, so only the ones inserted are returned
+func (c connection) CreateRewardGrants(ctx context.Context, grants []reward.Grant) ([]reward.Grant, error) {
+	if err := c.writable(); err != nil {
+		return nil, err
+	}
+
+	created := make([]reward.Grant, 0, len(grants))
+	for _, grant := range grants {
+		_, err := c.client.Database(c.db).Collection(rewardGrantCollectionName).InsertOne(ctx, newRewardGrant(grant))
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		created = append(created, grant)
+	}
+
+	return created, nil
+}
+
+// Each grant is leased on its own with an atomic update, so concurrent callers never claim the same grant
+func (c connection) ClaimDueRewardGrants(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]reward.Grant, error) {
+	if err := c.writable(); err != nil {
+		return nil, err
+	}
+
+	var (
+		collection = c.client.Database(c.db).Collection(rewardGrantCollectionName)
+		filter     = bson.M{"status": reward.GrantStatusPending, "nextAttemptAt": bson.M{"$lte": now}}
+		update     = bson.M{"$set": bson.M{"nextAttemptAt": now.Add(lease)}}
+		opts       = options.FindOneAndUpdate().
+				SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).
+				SetReturnDocument(options.After)
+	)
+
+	grants := make([]reward.Grant, 0)
+	for len(grants) < limit {
+		var data RewardGrant
+		if err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&data); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				break
+			}
+
+			return grants, err
+		}
+
+		grants = append(grants, data.toDomain())
+	}
+
+	return grants, nil
+}
+
+func (c connection) UpdateRewardGrant(ctx context.Context, grant reward.Grant) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"updatedAt":     grant.UpdatedAt,
+			"nextAttemptAt": grant.NextAttemptAt,
+			"grantedAt":     grant.GrantedAt,
+			"status":        grant.Status,
+			"attempts":      grant.Attempts,
+			"lastError":     grant.LastError,
+		},
+	}
+
+	// The player may have been erased alongside its grants while the grant was being sent
+	_, err := c.client.Database(c.db).Collection(rewardGrantCollectionName).UpdateByID(ctx, grant.ID, update)
+	return err
+}
+
+func (c connection) GetRewardGrant(ctx context.Context, id, gameID string) (reward.Grant, error) {
+	var data RewardGrant
+	err := c.readCollection(rewardGrantCollectionName).FindOne(ctx, bson.M{
+		"_id":    bson.M{"$eq": id},
+		"gameId": bson.M{"$eq": gameID},
+	}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = reward.ErrGrantNotFound
+		}
+
+		return reward.Grant{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) ListRewardGrants(ctx context.Context, gameID, playerID string, limit int) ([]reward.Grant, error) {
+	filter := bson.M{"gameId": bson.M{"$eq": gameID}}
+	if playerID != "" {
+		filter["playerId"] = bson.M{"$eq": playerID}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := c.readCollection(rewardGrantCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []RewardGrant
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	grants := make([]reward.Grant, len(data))
+	for i, g := range data {
+		grants[i] = g.toDomain()
+	}
+
+	return grants, nil
+}
+
+// Erases the rewards granted to the player, including the pending ones
+func (c connection) ErasePlayerRewardGrants(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(rewardGrantCollectionName).DeleteMany(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataRewardGrants, Records: result.DeletedCount}, nil
+}
+
+// Moves the rewards granted to the player to the pseudonym
+func (c connection) AnonymizePlayerRewardGrants(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(rewardGrantCollectionName).UpdateMany(ctx,
+		bson.M{"gameId": bson.M{"$eq": gameID}, "playerId": bson.M{"$eq": playerID}},
+		bson.M{"$set": bson.M{"playerId": pseudonym}},
+	)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataRewardGrants, Records: result.ModifiedCount}, nil
+}
+
+// Counts the rewards granted to the player
+func (c connection) CountPlayerRewardGrants(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(rewardGrantCollectionName).CountDocuments(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataRewardGrants, Records: records}, nil
+}
@@ -0,0 +1,65 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const schedulerLockCollectionName = "scheduler_locks"
+
+type SchedulerLock struct {
+	Job         string    `bson:"_id"`
+	Owner       string    `bson:"owner"`
+	LockedUntil time.Time `bson:"lockedUntil"`
+	LastRunAt   time.Time `bson:"lastRunAt,omitempty"`
+}
+
+func (l SchedulerLock) toDomain() scheduler.Lock {
+	return scheduler.Lock{
+		Job:         l.Job,
+		Owner:       l.Owner,
+		LockedUntil: l.LockedUntil.UTC(),
+		LastRunAt:   l.LastRunAt.UTC(),
+	}
+}
+
+// A single document is kept per job, keyed by its name. It's only updated when owned or expired, and the upsert
+// of a lock held by another owner fails on the duplicated key
+func (c connection) AcquireSchedulerLock(ctx context.Context, job, owner string, now, until time.Time) (scheduler.Lock, bool, error) {
+	if err := c.writable(); err != nil {
+		return scheduler.Lock{}, false, err
+	}
+
+	var (
+		filter = bson.M{"_id": job, "$or": bson.A{bson.M{"owner": owner}, bson.M{"lockedUntil": bson.M{"$lte": now}}}}
+		update = bson.M{"$set": bson.M{"owner": owner, "lockedUntil": until}}
+		opts   = options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	)
+
+	var lock SchedulerLock
+	err := c.client.Database(c.db).Collection(schedulerLockCollectionName).FindOneAndUpdate(ctx, filter, update, opts).Decode(&lock)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return scheduler.Lock{Job: job}, false, nil
+		}
+
+		return scheduler.Lock{}, false, err
+	}
+
+	return lock.toDomain(), true, nil
+}
+
+func (c connection) RecordSchedulerRun(ctx context.Context, job, owner string, ranAt time.Time) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	_, err := c.client.Database(c.db).Collection(schedulerLockCollectionName).UpdateOne(ctx, bson.M{"_id": job, "owner": owner}, bson.M{"$set": bson.M{"lastRunAt": ranAt}})
+	return err
+}
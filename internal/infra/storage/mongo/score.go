@@ -0,0 +1,268 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/moderation"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const scoreHistoryCollectionName = "scoreHistory"
+
+type Score struct {
+	SubmittedAt   time.Time          `bson:"submittedAt"`
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	LeaderboardID string             `bson:"leaderboardId"`
+	GameID        string             `bson:"gameId"`
+	PlayerID      string             `bson:"playerId"`
+	Value         float64            `bson:"value"`
+	Source        string             `bson:"source,omitempty"`
+	DeviceHash    string             `bson:"deviceHash,omitempty"`
+	BuildVersion  string             `bson:"buildVersion,omitempty"`
+	Region        string             `bson:"region,omitempty"`
+}
+
+func (s Score) toDomain() leaderboard.Score {
+	return leaderboard.Score{
+		SubmittedAt:   s.SubmittedAt,
+		ID:            s.ID.Hex(),
+		LeaderboardID: s.LeaderboardID,
+		GameID:        s.GameID,
+		PlayerID:      s.PlayerID,
+		Value:         s.Value,
+		Source:        s.Source,
+		Fingerprint: leaderboard.Fingerprint{
+			DeviceHash:   s.DeviceHash,
+			BuildVersion: s.BuildVersion,
+			Region:       s.Region,
+		},
+	}
+}
+
+type DeviceUsage struct {
+	ID struct {
+		DeviceHash string `bson:"deviceHash"`
+		PlayerID   string `bson:"playerId"`
+	} `bson:"_id"`
+	BuildVersion string    `bson:"buildVersion"`
+	Region       string    `bson:"region"`
+	FirstSeenAt  time.Time `bson:"firstSeenAt"`
+	LastSeenAt   time.Time `bson:"lastSeenAt"`
+	Scores       int64     `bson:"scores"`
+}
+
+func (u DeviceUsage) toDomain() moderation.DeviceUsage {
+	return moderation.DeviceUsage{
+		DeviceHash:   u.ID.DeviceHash,
+		PlayerID:     u.ID.PlayerID,
+		BuildVersion: u.BuildVersion,
+		Region:       u.Region,
+		FirstSeenAt:  u.FirstSeenAt,
+		LastSeenAt:   u.LastSeenAt,
+		Scores:       u.Scores,
+	}
+}
+
+var scoreHistoryIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "leaderboardId", Value: 1}, {Key: "submittedAt", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("leaderboardId_1_submittedAt_1__id_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "source", Value: 1}, {Key: "submittedAt", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("gameId_1_source_1_submittedAt_1__id_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "deviceHash", Value: 1}},
+		Options: options.Index().SetName("gameId_1_deviceHash_1").SetPartialFilterExpression(bson.M{"deviceHash": bson.M{"$exists": true}}),
+	},
+}
+
+// The score history is append only, scores are only rolled back, or removed and anonymized with their player
+func (c connection) RecordScore(ctx context.Context, score leaderboard.Score) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	_, err := c.client.Database(c.db).Collection(scoreHistoryCollectionName).InsertOne(ctx, Score{
+		SubmittedAt:   score.SubmittedAt,
+		LeaderboardID: score.LeaderboardID,
+		GameID:        score.GameID,
+		PlayerID:      score.PlayerID,
+		Value:         score.Value,
+		Source:        score.Source,
+		DeviceHash:    score.Fingerprint.DeviceHash,
+		BuildVersion:  score.Fingerprint.BuildVersion,
+		Region:        score.Fingerprint.Region,
+	})
+	return err
+}
+
+// Pages the scores matching the query oldest first, with the ID breaking the ties between scores submitted at the same time
+func (c connection) listScores(ctx context.Context, query bson.M, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+	if after.ID != "" {
+		afterID, err := primitive.ObjectIDFromHex(after.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		query["$or"] = bson.A{
+			bson.M{"submittedAt": bson.M{"$gt": after.SubmittedAt}},
+			bson.M{"submittedAt": after.SubmittedAt, "_id": bson.M{"$gt": afterID}},
+		}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "submittedAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := c.readCollection(scoreHistoryCollectionName).Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Score
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	scores := make([]leaderboard.Score, len(data))
+	for i, score := range data {
+		scores[i] = score.toDomain()
+	}
+
+	return scores, nil
+}
+
+func (c connection) ListScores(ctx context.Context, leaderboardID string, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+	return c.listScores(ctx, bson.M{"leaderboardId": leaderboardID}, after, limit)
+}
+
+// The window bounds are matched alongside the cursor, which narrows them further
+func (c connection) ListScoresBySource(ctx context.Context, gameID, source string, from, to time.Time, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+	return c.listScores(ctx, bson.M{
+		"gameId":      bson.M{"$eq": gameID},
+		"source":      bson.M{"$eq": source},
+		"submittedAt": bson.M{"$gte": from, "$lt": to},
+	}, after, limit)
+}
+
+func (c connection) DeleteScores(ctx context.Context, leaderboardID, playerID string, from, to time.Time) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	filter := bson.M{
+		"leaderboardId": bson.M{"$eq": leaderboardID},
+		"submittedAt":   bson.M{"$gte": from, "$lt": to},
+	}
+	if playerID != "" {
+		filter["playerId"] = bson.M{"$eq": playerID}
+	}
+
+	_, err := c.client.Database(c.db).Collection(scoreHistoryCollectionName).DeleteMany(ctx, filter)
+	return err
+}
+
+// Groups the scores by device and player. The build and region are the ones of the latest score
+func (c connection) ListDeviceUsage(ctx context.Context, gameID string, filter moderation.DeviceUsageFilter, limit int) ([]moderation.DeviceUsage, error) {
+	match := bson.M{"gameId": bson.M{"$eq": gameID}, "deviceHash": bson.M{"$exists": true}}
+	if filter.DeviceHash != "" {
+		match["deviceHash"] = bson.M{"$eq": filter.DeviceHash}
+	}
+
+	if filter.PlayerID != "" {
+		match["playerId"] = bson.M{"$eq": filter.PlayerID}
+	}
+
+	cursor, err := c.readCollection(scoreHistoryCollectionName).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "submittedAt", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          bson.M{"deviceHash": "$deviceHash", "playerId": "$playerId"},
+			"buildVersion": bson.M{"$last": "$buildVersion"},
+			"region":       bson.M{"$last": "$region"},
+			"firstSeenAt":  bson.M{"$first": "$submittedAt"},
+			"lastSeenAt":   bson.M{"$last": "$submittedAt"},
+			"scores":       bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "lastSeenAt", Value: -1}, {Key: "_id.deviceHash", Value: 1}, {Key: "_id.playerId", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data []DeviceUsage
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	devices := make([]moderation.DeviceUsage, len(data))
+	for i, u := range data {
+		devices[i] = u.toDomain()
+	}
+
+	return devices, nil
+}
+
+// Erases the scores the player submitted to every leaderboard of the game
+func (c connection) ErasePlayerScores(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(scoreHistoryCollectionName).DeleteMany(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataScoreHistory, Records: result.DeletedCount}, nil
+}
+
+// Moves the scores the player submitted to every leaderboard of the game to the pseudonym, dropping their fingerprint
+func (c connection) AnonymizePlayerScores(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(scoreHistoryCollectionName).UpdateMany(ctx,
+		bson.M{"gameId": bson.M{"$eq": gameID}, "playerId": bson.M{"$eq": playerID}},
+		bson.M{
+			"$set":   bson.M{"playerId": pseudonym},
+			"$unset": bson.M{"deviceHash": "", "buildVersion": "", "region": ""},
+		},
+	)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataScoreHistory, Records: result.ModifiedCount}, nil
+}
+
+// Counts the scores the player submitted to every leaderboard of the game
+func (c connection) CountPlayerScores(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(scoreHistoryCollectionName).CountDocuments(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataScoreHistory, Records: records}, nil
+}
@@ -0,0 +1,261 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/segment"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	segmentCollectionName     = "segments"
+	eligibilityCollectionName = "eligibilities"
+)
+
+type (
+	SegmentRules struct {
+		Countries       []string  `bson:"countries,omitempty"`
+		StatisticID     string    `bson:"statisticId,omitempty"`
+		MinValue        *float64  `bson:"minValue,omitempty"`
+		MaxValue        *float64  `bson:"maxValue,omitempty"`
+		InstalledAfter  time.Time `bson:"installedAfter,omitempty"`
+		InstalledBefore time.Time `bson:"installedBefore,omitempty"`
+	}
+
+	Segment struct {
+		CreatedAt   time.Time          `bson:"createdAt"`
+		ID          primitive.ObjectID `bson:"_id,omitempty"`
+		GameID      string             `bson:"gameId"`
+		Name        string             `bson:"name"`
+		Description string             `bson:"description"`
+		Rules       SegmentRules       `bson:"rules"`
+	}
+
+	Eligibility struct {
+		UpdatedAt    time.Time `bson:"updatedAt"`
+		GameID       string    `bson:"gameId"`
+		ResourceType string    `bson:"resourceType"`
+		ResourceID   string    `bson:"resourceId"`
+		SegmentID    string    `bson:"segmentId"`
+	}
+)
+
+func (s Segment) toDomain() segment.Segment {
+	rules := segment.Rules{
+		Countries:   s.Rules.Countries,
+		StatisticID: s.Rules.StatisticID,
+		MinValue:    s.Rules.MinValue,
+		MaxValue:    s.Rules.MaxValue,
+	}
+	if !s.Rules.InstalledAfter.IsZero() {
+		rules.InstalledAfter = s.Rules.InstalledAfter.UTC()
+	}
+	if !s.Rules.InstalledBefore.IsZero() {
+		rules.InstalledBefore = s.Rules.InstalledBefore.UTC()
+	}
+
+	return segment.Segment{
+		CreatedAt:   s.CreatedAt.UTC(),
+		ID:          s.ID.Hex(),
+		GameID:      s.GameID,
+		Name:        s.Name,
+		Description: s.Description,
+		Rules:       rules,
+	}
+}
+
+func (e Eligibility) toDomain() segment.Eligibility {
+	return segment.Eligibility{
+		UpdatedAt:    e.UpdatedAt.UTC(),
+		GameID:       e.GameID,
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		SegmentID:    e.SegmentID,
+	}
+}
+
+var segmentIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_createdAt_1"),
+	},
+}
+
+// A resource is restricted to a single segment
+var eligibilityIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "resourceType", Value: 1}, {Key: "resourceId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_resourceType_1_resourceId_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "segmentId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_segmentId_1"),
+	},
+}
+
+func eligibilityFilter(gameID, resourceType, resourceID string) bson.M {
+	return bson.M{
+		"gameId":       bson.M{"$eq": gameID},
+		"resourceType": bson.M{"$eq": resourceType},
+		"resourceId":   bson.M{"$eq": resourceID},
+	}
+}
+
+func (c connection) CreateSegment(ctx context.Context, data segment.NewSegmentData) (segment.Segment, error) {
+	if err := c.writable(); err != nil {
+		return segment.Segment{}, err
+	}
+
+	s := Segment{
+		CreatedAt:   time.Now().UTC(),
+		GameID:      data.GameID,
+		Name:        data.Name,
+		Description: data.Description,
+		Rules: SegmentRules{
+			Countries:       data.Rules.Countries,
+			StatisticID:     data.Rules.StatisticID,
+			MinValue:        data.Rules.MinValue,
+			MaxValue:        data.Rules.MaxValue,
+			InstalledAfter:  data.Rules.InstalledAfter,
+			InstalledBefore: data.Rules.InstalledBefore,
+		},
+	}
+
+	result, err := c.client.Database(c.db).Collection(segmentCollectionName).InsertOne(ctx, s)
+	if err != nil {
+		return segment.Segment{}, err
+	}
+
+	s.ID = result.InsertedID.(primitive.ObjectID)
+	return s.toDomain(), nil
+}
+
+func (c connection) GetSegment(ctx context.Context, id, gameID string) (segment.Segment, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return segment.Segment{}, segment.ErrSegmentNotFound
+	}
+
+	var data Segment
+	err = c.readCollection(segmentCollectionName).FindOne(ctx, bson.M{
+		"_id":    bson.M{"$eq": objectID},
+		"gameId": bson.M{"$eq": gameID},
+	}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = segment.ErrSegmentNotFound
+		}
+
+		return segment.Segment{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) ListSegments(ctx context.Context, gameID string) ([]segment.Segment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	cursor, err := c.readCollection(segmentCollectionName).Find(ctx, bson.M{"gameId": bson.M{"$eq": gameID}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Segment
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	segments := make([]segment.Segment, len(data))
+	for i, s := range data {
+		segments[i] = s.toDomain()
+	}
+
+	return segments, nil
+}
+
+// Also removes the eligibilities restricted to the segment
+func (c connection) DeleteSegment(ctx context.Context, id, gameID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return segment.ErrSegmentNotFound
+	}
+
+	result, err := c.client.Database(c.db).Collection(segmentCollectionName).DeleteOne(ctx, bson.M{
+		"_id":    bson.M{"$eq": objectID},
+		"gameId": bson.M{"$eq": gameID},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return segment.ErrSegmentNotFound
+	}
+
+	_, err = c.client.Database(c.db).Collection(eligibilityCollectionName).DeleteMany(ctx, bson.M{
+		"gameId":    bson.M{"$eq": gameID},
+		"segmentId": bson.M{"$eq": id},
+	})
+	return err
+}
+
+func (c connection) SetEligibility(ctx context.Context, eligibility segment.Eligibility) (segment.Eligibility, error) {
+	if err := c.writable(); err != nil {
+		return segment.Eligibility{}, err
+	}
+
+	data := Eligibility{
+		UpdatedAt:    eligibility.UpdatedAt,
+		GameID:       eligibility.GameID,
+		ResourceType: eligibility.ResourceType,
+		ResourceID:   eligibility.ResourceID,
+		SegmentID:    eligibility.SegmentID,
+	}
+
+	filter := eligibilityFilter(eligibility.GameID, eligibility.ResourceType, eligibility.ResourceID)
+	if _, err := c.client.Database(c.db).Collection(eligibilityCollectionName).ReplaceOne(ctx, filter, data, options.Replace().SetUpsert(true)); err != nil {
+		return segment.Eligibility{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) GetEligibility(ctx context.Context, gameID, resourceType, resourceID string) (segment.Eligibility, error) {
+	var data Eligibility
+	if err := c.readCollection(eligibilityCollectionName).FindOne(ctx, eligibilityFilter(gameID, resourceType, resourceID)).Decode(&data); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = segment.ErrEligibilityNotFound
+		}
+
+		return segment.Eligibility{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) DeleteEligibility(ctx context.Context, gameID, resourceType, resourceID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	result, err := c.client.Database(c.db).Collection(eligibilityCollectionName).DeleteOne(ctx, eligibilityFilter(gameID, resourceType, resourceID))
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return segment.ErrEligibilityNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,272 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/shadow"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const shadowFlagCollectionName = "shadowFlags"
+
+type ShadowRank struct {
+	LeaderboardID string   `bson:"leaderboardId"`
+	Value         float64  `bson:"value"`
+	Previous      *float64 `bson:"previous,omitempty"`
+}
+
+type ShadowFlag struct {
+	FlaggedAt   time.Time    `bson:"flaggedAt"`
+	GameID      string       `bson:"gameId"`
+	PlayerID    string       `bson:"playerId"`
+	Reason      string       `bson:"reason"`
+	ShadowRanks []ShadowRank `bson:"shadowRanks"`
+}
+
+func shadowRankFromDomain(r shadow.ShadowRank) ShadowRank {
+	return ShadowRank{LeaderboardID: r.LeaderboardID, Value: r.Value, Previous: r.Previous}
+}
+
+func shadowFlagFromDomain(f shadow.Flag) ShadowFlag {
+	data := ShadowFlag{
+		FlaggedAt:   f.FlaggedAt,
+		GameID:      f.GameID,
+		PlayerID:    f.PlayerID,
+		Reason:      f.Reason,
+		ShadowRanks: make([]ShadowRank, len(f.ShadowRanks)),
+	}
+
+	for i, rank := range f.ShadowRanks {
+		data.ShadowRanks[i] = shadowRankFromDomain(rank)
+	}
+
+	return data
+}
+
+func (f ShadowFlag) toDomain() shadow.Flag {
+	var shadowRanks []shadow.ShadowRank
+	for _, rank := range f.ShadowRanks {
+		shadowRanks = append(shadowRanks, shadow.ShadowRank{LeaderboardID: rank.LeaderboardID, Value: rank.Value, Previous: rank.Previous})
+	}
+
+	return shadow.Flag{
+		FlaggedAt:   f.FlaggedAt.UTC(),
+		GameID:      f.GameID,
+		PlayerID:    f.PlayerID,
+		Reason:      f.Reason,
+		ShadowRanks: shadowRanks,
+	}
+}
+
+func playerShadowFlagFilter(gameID, playerID string) bson.M {
+	return bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	}
+}
+
+// A player has a single flag per game
+var shadowFlagIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "flaggedAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_flaggedAt_1"),
+	},
+}
+
+// The unique index settles concurrent flags of the same player
+func (c connection) CreateShadowFlag(ctx context.Context, f shadow.Flag) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	_, err := c.client.Database(c.db).Collection(shadowFlagCollectionName).InsertOne(ctx, shadowFlagFromDomain(f))
+	if mongo.IsDuplicateKeyError(err) {
+		return shadow.ErrPlayerAlreadyFlagged
+	}
+
+	return err
+}
+
+// Read from the primary, as the submissions of a player just flagged must reach the shadow copies right away
+func (c connection) GetShadowFlag(ctx context.Context, gameID, playerID string) (shadow.Flag, error) {
+	var data ShadowFlag
+	err := c.client.Database(c.db).Collection(shadowFlagCollectionName).FindOne(ctx, playerShadowFlagFilter(gameID, playerID)).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = shadow.ErrFlagNotFound
+		}
+
+		return shadow.Flag{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+// The rank is only pushed when the flag has none on its leaderboard, so concurrent additions never add it twice
+func (c connection) pushShadowRank(ctx context.Context, gameID, playerID string, rank shadow.ShadowRank) (bool, error) {
+	filter := playerShadowFlagFilter(gameID, playerID)
+	filter["shadowRanks.leaderboardId"] = bson.M{"$ne": rank.LeaderboardID}
+
+	result, err := c.client.Database(c.db).Collection(shadowFlagCollectionName).UpdateOne(ctx, filter, bson.M{
+		"$push": bson.M{"shadowRanks": shadowRankFromDomain(rank)},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// Tells a flag already holding a rank on the leaderboard apart from a missing one
+func (c connection) shadowFlagExists(ctx context.Context, gameID, playerID string) error {
+	count, err := c.client.Database(c.db).Collection(shadowFlagCollectionName).CountDocuments(ctx, playerShadowFlagFilter(gameID, playerID))
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return shadow.ErrFlagNotFound
+	}
+
+	return nil
+}
+
+func (c connection) AddShadowRank(ctx context.Context, gameID, playerID string, rank shadow.ShadowRank) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	pushed, err := c.pushShadowRank(ctx, gameID, playerID, rank)
+	if err != nil || pushed {
+		return err
+	}
+
+	return c.shadowFlagExists(ctx, gameID, playerID)
+}
+
+// The value of the rank already on the flag is set in place. Otherwise the rank is pushed, and set in place once more
+// when it was pushed concurrently in between
+func (c connection) SetShadowRank(ctx context.Context, gameID, playerID string, rank shadow.ShadowRank) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	setValue := func() (bool, error) {
+		filter := playerShadowFlagFilter(gameID, playerID)
+		filter["shadowRanks.leaderboardId"] = bson.M{"$eq": rank.LeaderboardID}
+
+		result, err := c.client.Database(c.db).Collection(shadowFlagCollectionName).UpdateOne(ctx, filter, bson.M{
+			"$set": bson.M{"shadowRanks.$.value": rank.Value},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		return result.MatchedCount > 0, nil
+	}
+
+	set, err := setValue()
+	if err != nil || set {
+		return err
+	}
+
+	pushed, err := c.pushShadowRank(ctx, gameID, playerID, rank)
+	if err != nil || pushed {
+		return err
+	}
+
+	if set, err = setValue(); err != nil || set {
+		return err
+	}
+
+	return shadow.ErrFlagNotFound
+}
+
+// The flag is found and removed by a single command, so a flag cleared concurrently is only returned once
+func (c connection) DeleteShadowFlag(ctx context.Context, gameID, playerID string) (shadow.Flag, error) {
+	if err := c.writable(); err != nil {
+		return shadow.Flag{}, err
+	}
+
+	var data ShadowFlag
+	err := c.client.Database(c.db).Collection(shadowFlagCollectionName).FindOneAndDelete(ctx, playerShadowFlagFilter(gameID, playerID)).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = shadow.ErrFlagNotFound
+		}
+
+		return shadow.Flag{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) ListShadowFlags(ctx context.Context, gameID string) ([]shadow.Flag, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "flaggedAt", Value: 1}})
+	cursor, err := c.readCollection(shadowFlagCollectionName).Find(ctx, bson.M{"gameId": bson.M{"$eq": gameID}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []ShadowFlag
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	flags := make([]shadow.Flag, len(data))
+	for i, f := range data {
+		flags[i] = f.toDomain()
+	}
+
+	return flags, nil
+}
+
+// Erases the player's flag alongside their shadow ranks
+func (c connection) ErasePlayerShadowFlag(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(shadowFlagCollectionName).DeleteOne(ctx, playerShadowFlagFilter(gameID, playerID))
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataShadowFlags, Records: result.DeletedCount}, nil
+}
+
+// Moves the player's flag to the pseudonym, so its ranks are put back under the pseudonym once it's cleared
+func (c connection) AnonymizePlayerShadowFlag(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(shadowFlagCollectionName).UpdateOne(ctx, playerShadowFlagFilter(gameID, playerID), bson.M{
+		"$set": bson.M{"playerId": pseudonym},
+	})
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataShadowFlags, Records: result.ModifiedCount}, nil
+}
+
+// Counts the player's flag
+func (c connection) CountPlayerShadowFlag(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(shadowFlagCollectionName).CountDocuments(ctx, playerShadowFlagFilter(gameID, playerID))
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataShadowFlags, Records: records}, nil
+}
@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// Reports an operation that took longer than the slow query threshold
+type SlowQueryFunc func(operation, collection string, duration time.Duration)
+
+// Reports the commands that took longer than the threshold to complete, failed or not
+func slowQueryMonitor(threshold time.Duration, onSlowQuery SlowQueryFunc) *event.CommandMonitor {
+	var inFlight sync.Map // Collections by request ID
+
+	finish := func(evt event.CommandFinishedEvent) {
+		collection, ok := inFlight.LoadAndDelete(evt.RequestID)
+		if ok && evt.Duration > threshold {
+			onSlowQuery(evt.CommandName, collection.(string), evt.Duration)
+		}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+			collection, _ := evt.Command.Lookup(evt.CommandName).StringValueOK()
+			inFlight.Store(evt.RequestID, collection)
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.CommandFinishedEvent)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.CommandFinishedEvent)
+		},
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"time"
 
+	"github.com/gabapcia/gameblitz/internal/backup"
+	"github.com/gabapcia/gameblitz/internal/purge"
 	"github.com/gabapcia/gameblitz/internal/statistic"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const statisticCollectionName = "statistics"
@@ -28,6 +31,22 @@ type Statistic struct {
 	Landmarks       []float64          `bson:"landmarks,omitempty"`
 }
 
+// Soft deleted statistics have a distinct `deletedAt`, so only the active ones must have an unique name per game
+var statisticIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			{Key: "gameId", Value: 1},
+			{Key: "name", Value: 1},
+			{Key: "deletedAt", Value: 1},
+		},
+		Options: options.Index().SetName("gameId_1_name_1_deletedAt_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "deletedAt", Value: 1}},
+		Options: options.Index().SetName("deletedAt_1").SetSparse(true),
+	},
+}
+
 func (s Statistic) toDomain() statistic.Statistic {
 	return statistic.Statistic{
 		CreatedAt:       s.CreatedAt,
@@ -59,10 +78,18 @@ func newStatisticFromDomain(s statistic.NewStatisticData) Statistic {
 }
 
 func (c connection) CreateStatistic(ctx context.Context, data statistic.NewStatisticData) (statistic.Statistic, error) {
+	if err := c.writable(); err != nil {
+		return statistic.Statistic{}, err
+	}
+
 	st := newStatisticFromDomain(data)
 
 	cursor, err := c.client.Database(c.db).Collection(statisticCollectionName).InsertOne(ctx, st)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = statistic.ErrStatisticNameAlreadyInUse
+		}
+
 		return statistic.Statistic{}, err
 	}
 
@@ -77,7 +104,7 @@ func (c connection) GetStatisticByIDAndGameID(ctx context.Context, id, gameID st
 		return statistic.Statistic{}, statistic.ErrInvalidStatisticID
 	}
 
-	cursor := c.client.Database(c.db).Collection(statisticCollectionName).FindOne(ctx, bson.M{
+	cursor := c.readCollection(statisticCollectionName).FindOne(ctx, bson.M{
 		"_id":       bson.M{"$eq": oid},
 		"gameId":    bson.M{"$eq": gameID},
 		"deletedAt": nil,
@@ -99,6 +126,10 @@ func (c connection) GetStatisticByIDAndGameID(ctx context.Context, id, gameID st
 }
 
 func (c connection) SoftDeleteStatistic(ctx context.Context, id, gameID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return statistic.ErrInvalidStatisticID
@@ -127,3 +158,156 @@ func (c connection) SoftDeleteStatistic(ctx context.Context, id, gameID string)
 
 	return nil
 }
+
+// Statistics soft deleted before the given time, the game's ones only unless it's empty
+func softDeletedStatisticsFilter(gameID string, deletedBefore time.Time) bson.M {
+	filter := bson.M{
+		"deletedAt": bson.M{"$lt": deletedBefore},
+	}
+
+	if gameID != "" {
+		filter["gameId"] = bson.M{"$eq": gameID}
+	}
+
+	return filter
+}
+
+// Removes the statistics soft deleted before the given time alongside the players progression on them
+func (c connection) PurgeSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	if err := c.writable(); err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, 0)
+
+	err := c.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		records = records[:0]
+
+		cursor, err := c.client.Database(c.db).Collection(statisticCollectionName).Find(ctx, softDeletedStatisticsFilter(gameID, deletedBefore))
+		if err != nil {
+			return err
+		}
+
+		var purged []Statistic
+		if err := cursor.All(ctx, &purged); err != nil {
+			return err
+		}
+
+		if len(purged) == 0 {
+			return nil
+		}
+
+		var (
+			oids = make([]primitive.ObjectID, len(purged))
+			ids  = make([]string, len(purged))
+		)
+
+		for i, st := range purged {
+			oids[i] = st.ID
+			ids[i] = st.ID.Hex()
+
+			records = append(records, purge.Record{
+				DeletedAt: st.DeletedAt,
+				Entity:    purge.EntityStatistic,
+				ID:        st.ID.Hex(),
+				GameID:    st.GameID,
+			})
+		}
+
+		for _, collection := range c.progressionCollections() {
+			_, err = c.client.Database(c.db).Collection(collection).DeleteMany(ctx, bson.M{"statisticId": bson.M{"$in": ids}})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = c.client.Database(c.db).Collection(statisticCollectionName).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": oids}})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (c connection) ListSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	cursor, err := c.readCollection(statisticCollectionName).Find(ctx, softDeletedStatisticsFilter(gameID, deletedBefore))
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Statistic
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, len(data))
+	for i, st := range data {
+		records[i] = purge.Record{
+			DeletedAt: st.DeletedAt,
+			Entity:    purge.EntityStatistic,
+			ID:        st.ID.Hex(),
+			GameID:    st.GameID,
+		}
+	}
+
+	return records, nil
+}
+
+func (c connection) ListStatistics(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+	cursor, err := c.readCollection(statisticCollectionName).Find(ctx, bson.M{
+		"gameId":    bson.M{"$eq": gameID},
+		"deletedAt": nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Statistic
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	statistics := make([]statistic.Statistic, len(data))
+	for i, st := range data {
+		statistics[i] = st.toDomain()
+	}
+
+	return statistics, nil
+}
+
+// Stores the statistic keeping its ID. The ID must be a valid object ID
+func (c connection) RestoreStatistic(ctx context.Context, st statistic.Statistic) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	oid, err := primitive.ObjectIDFromHex(st.ID)
+	if err != nil {
+		return statistic.ErrInvalidStatisticID
+	}
+
+	data := Statistic{
+		CreatedAt:       st.CreatedAt,
+		UpdatedAt:       st.UpdatedAt,
+		ID:              oid,
+		GameID:          st.GameID,
+		Name:            st.Name,
+		Description:     st.Description,
+		AggregationMode: st.AggregationMode,
+		InitialValue:    st.InitialValue,
+		Goal:            st.Goal,
+		Landmarks:       st.Landmarks,
+	}
+
+	if _, err := c.client.Database(c.db).Collection(statisticCollectionName).InsertOne(ctx, data); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = backup.ErrEntityAlreadyExists
+		}
+
+		return err
+	}
+
+	return nil
+}
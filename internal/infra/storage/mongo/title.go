@@ -0,0 +1,294 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/title"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	titleCollectionName      = "titles"
+	titleGrantCollectionName = "titleGrants"
+)
+
+type (
+	TitleTrigger struct {
+		Kind          string  `bson:"kind"`
+		StatisticID   string  `bson:"statisticId,omitempty"`
+		Landmark      float64 `bson:"landmark,omitempty"`
+		LeaderboardID string  `bson:"leaderboardId,omitempty"`
+		Top           int64   `bson:"top,omitempty"`
+	}
+
+	Title struct {
+		CreatedAt   time.Time          `bson:"createdAt"`
+		UpdatedAt   time.Time          `bson:"updatedAt"`
+		ID          primitive.ObjectID `bson:"_id,omitempty"`
+		GameID      string             `bson:"gameId"`
+		Name        string             `bson:"name"`
+		Description string             `bson:"description"`
+		BadgeURL    string             `bson:"badgeUrl,omitempty"`
+		Trigger     TitleTrigger       `bson:"trigger"`
+	}
+
+	TitleGrant struct {
+		TitleID   string    `bson:"titleId"`
+		GameID    string    `bson:"gameId"`
+		PlayerID  string    `bson:"playerId"`
+		GrantedAt time.Time `bson:"grantedAt"`
+	}
+)
+
+func (t Title) toDomain() title.Title {
+	return title.Title{
+		CreatedAt:   t.CreatedAt.UTC(),
+		UpdatedAt:   t.UpdatedAt.UTC(),
+		ID:          t.ID.Hex(),
+		GameID:      t.GameID,
+		Name:        t.Name,
+		Description: t.Description,
+		BadgeURL:    t.BadgeURL,
+		Trigger: title.Trigger{
+			Kind:          t.Trigger.Kind,
+			StatisticID:   t.Trigger.StatisticID,
+			Landmark:      t.Trigger.Landmark,
+			LeaderboardID: t.Trigger.LeaderboardID,
+			Top:           t.Trigger.Top,
+		},
+	}
+}
+
+func (g TitleGrant) toDomain() title.Grant {
+	return title.Grant{
+		TitleID:   g.TitleID,
+		GameID:    g.GameID,
+		PlayerID:  g.PlayerID,
+		GrantedAt: g.GrantedAt.UTC(),
+	}
+}
+
+var titleIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_createdAt_1"),
+	},
+}
+
+// A player is granted each title once
+var titleGrantIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "titleId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("titleId_1_playerId_1").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}, {Key: "grantedAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1_grantedAt_1"),
+	},
+}
+
+func (c connection) CreateTitle(ctx context.Context, data title.NewTitleData) (title.Title, error) {
+	if err := c.writable(); err != nil {
+		return title.Title{}, err
+	}
+
+	t := Title{
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+		GameID:      data.GameID,
+		Name:        data.Name,
+		Description: data.Description,
+		BadgeURL:    data.BadgeURL,
+		Trigger: TitleTrigger{
+			Kind:          data.Trigger.Kind,
+			StatisticID:   data.Trigger.StatisticID,
+			Landmark:      data.Trigger.Landmark,
+			LeaderboardID: data.Trigger.LeaderboardID,
+			Top:           data.Trigger.Top,
+		},
+	}
+
+	result, err := c.client.Database(c.db).Collection(titleCollectionName).InsertOne(ctx, t)
+	if err != nil {
+		return title.Title{}, err
+	}
+
+	t.ID = result.InsertedID.(primitive.ObjectID)
+	return t.toDomain(), nil
+}
+
+func (c connection) GetTitle(ctx context.Context, id, gameID string) (title.Title, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return title.Title{}, title.ErrTitleNotFound
+	}
+
+	var data Title
+	err = c.readCollection(titleCollectionName).FindOne(ctx, bson.M{
+		"_id":    bson.M{"$eq": objectID},
+		"gameId": bson.M{"$eq": gameID},
+	}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = title.ErrTitleNotFound
+		}
+
+		return title.Title{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) ListTitles(ctx context.Context, gameID string) ([]title.Title, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	cursor, err := c.readCollection(titleCollectionName).Find(ctx, bson.M{"gameId": bson.M{"$eq": gameID}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Title
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	titles := make([]title.Title, len(data))
+	for i, t := range data {
+		titles[i] = t.toDomain()
+	}
+
+	return titles, nil
+}
+
+// Also removes the title's grants
+func (c connection) DeleteTitle(ctx context.Context, id, gameID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return title.ErrTitleNotFound
+	}
+
+	result, err := c.client.Database(c.db).Collection(titleCollectionName).DeleteOne(ctx, bson.M{
+		"_id":    bson.M{"$eq": objectID},
+		"gameId": bson.M{"$eq": gameID},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return title.ErrTitleNotFound
+	}
+
+	_, err = c.client.Database(c.db).Collection(titleGrantCollectionName).DeleteMany(ctx, bson.M{"titleId": bson.M{"$eq": id}})
+	return err
+}
+
+// The unique index keeps the grants the player already has, so only the ones inserted are returned
+func (c connection) GrantTitles(ctx context.Context, grants []title.Grant) ([]title.Grant, error) {
+	if err := c.writable(); err != nil {
+		return nil, err
+	}
+
+	recorded := make([]title.Grant, 0, len(grants))
+	for _, grant := range grants {
+		_, err := c.client.Database(c.db).Collection(titleGrantCollectionName).InsertOne(ctx, TitleGrant{
+			TitleID:   grant.TitleID,
+			GameID:    grant.GameID,
+			PlayerID:  grant.PlayerID,
+			GrantedAt: grant.GrantedAt,
+		})
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		recorded = append(recorded, grant)
+	}
+
+	return recorded, nil
+}
+
+func (c connection) ListTitleGrants(ctx context.Context, gameID string, playerIDs []string) ([]title.Grant, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "grantedAt", Value: 1}})
+
+	cursor, err := c.readCollection(titleGrantCollectionName).Find(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$in": playerIDs},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []TitleGrant
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	grants := make([]title.Grant, len(data))
+	for i, grant := range data {
+		grants[i] = grant.toDomain()
+	}
+
+	return grants, nil
+}
+
+// Erases the titles granted to the player
+func (c connection) ErasePlayerTitles(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(titleGrantCollectionName).DeleteMany(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataTitles, Records: result.DeletedCount}, nil
+}
+
+// Moves the titles granted to the player to the pseudonym
+func (c connection) AnonymizePlayerTitles(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(titleGrantCollectionName).UpdateMany(ctx,
+		bson.M{"gameId": bson.M{"$eq": gameID}, "playerId": bson.M{"$eq": playerID}},
+		bson.M{"$set": bson.M{"playerId": pseudonym}},
+	)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataTitles, Records: result.ModifiedCount}, nil
+}
+
+// Counts the titles granted to the player
+func (c connection) CountPlayerTitles(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(titleGrantCollectionName).CountDocuments(ctx, bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataTitles, Records: records}, nil
+}
@@ -0,0 +1,66 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gabapcia/gameblitz/internal/infra/tracing"
+
+	"go.mongodb.org/mongo-driver/event"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Starts a span for every command sent to the server, as a child of the span on the operation context.
+// The command document is not recorded, since it holds the players' data
+func tracingMonitor(db string) *event.CommandMonitor {
+	var inFlight sync.Map // Spans by request ID
+
+	finish := func(requestID int64, err error) {
+		if span, ok := inFlight.LoadAndDelete(requestID); ok {
+			tracing.End(span.(trace.Span), err)
+		}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			collection, _ := evt.Command.Lookup(evt.CommandName).StringValueOK()
+			_, span := tracing.StartClient(ctx, "mongo "+evt.CommandName,
+				semconv.DBSystemMongoDB,
+				semconv.DBOperationName(evt.CommandName),
+				semconv.DBNamespace(db),
+				semconv.DBCollectionName(collection),
+			)
+
+			inFlight.Store(evt.RequestID, span)
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.RequestID, nil)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.RequestID, errors.New(evt.Failure))
+		},
+	}
+}
+
+// The client accepts a single monitor, so the metrics and the tracing ones are called in turn
+func joinMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				m.Started(ctx, evt)
+			}
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				m.Succeeded(ctx, evt)
+			}
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				m.Failed(ctx, evt)
+			}
+		},
+	}
+}
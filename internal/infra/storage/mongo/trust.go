@@ -0,0 +1,138 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/trust"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const trustRecordCollectionName = "trustRecords"
+
+type TrustRecord struct {
+	FirstSeenAt time.Time `bson:"firstSeenAt"`
+	GameID      string    `bson:"gameId"`
+	PlayerID    string    `bson:"playerId"`
+	Flagged     int64     `bson:"flagged"`
+	Cleared     int64     `bson:"cleared"`
+}
+
+func (r TrustRecord) toDomain() trust.Record {
+	return trust.Record{
+		FirstSeenAt: r.FirstSeenAt.UTC(),
+		GameID:      r.GameID,
+		PlayerID:    r.PlayerID,
+		Flagged:     r.Flagged,
+		Cleared:     r.Cleared,
+	}
+}
+
+func playerTrustRecordFilter(gameID, playerID string) bson.M {
+	return bson.M{
+		"gameId":   bson.M{"$eq": gameID},
+		"playerId": bson.M{"$eq": playerID},
+	}
+}
+
+// A player has a single record per game
+var trustRecordIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("gameId_1_playerId_1").SetUnique(true),
+	},
+}
+
+// Upserted on the unique index, so the concurrent first submissions of a player create a single record
+func (c connection) TrackTrustRecord(ctx context.Context, gameID, playerID string, seenAt time.Time) (trust.Record, error) {
+	if err := c.writable(); err != nil {
+		return trust.Record{}, err
+	}
+
+	var data TrustRecord
+	err := c.client.Database(c.db).Collection(trustRecordCollectionName).FindOneAndUpdate(ctx,
+		playerTrustRecordFilter(gameID, playerID),
+		bson.M{"$setOnInsert": bson.M{"firstSeenAt": seenAt, "flagged": 0, "cleared": 0}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&data)
+	if err != nil {
+		return trust.Record{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) IncrementTrustRecord(ctx context.Context, gameID, playerID string, flagged, cleared int64, seenAt time.Time) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	_, err := c.client.Database(c.db).Collection(trustRecordCollectionName).UpdateOne(ctx,
+		playerTrustRecordFilter(gameID, playerID),
+		bson.M{
+			"$inc":         bson.M{"flagged": flagged, "cleared": cleared},
+			"$setOnInsert": bson.M{"firstSeenAt": seenAt},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (c connection) GetTrustRecord(ctx context.Context, gameID, playerID string) (trust.Record, error) {
+	var data TrustRecord
+	err := c.readCollection(trustRecordCollectionName).FindOne(ctx, playerTrustRecordFilter(gameID, playerID)).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = trust.ErrRecordNotFound
+		}
+
+		return trust.Record{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+// Erases the player's trust record
+func (c connection) ErasePlayerTrustRecord(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(trustRecordCollectionName).DeleteOne(ctx, playerTrustRecordFilter(gameID, playerID))
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataTrust, Records: result.DeletedCount}, nil
+}
+
+// Moves the player's trust record to the pseudonym
+func (c connection) AnonymizePlayerTrustRecord(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	result, err := c.client.Database(c.db).Collection(trustRecordCollectionName).UpdateOne(ctx, playerTrustRecordFilter(gameID, playerID), bson.M{
+		"$set": bson.M{"playerId": pseudonym},
+	})
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataTrust, Records: result.ModifiedCount}, nil
+}
+
+// Counts the player's trust record
+func (c connection) CountPlayerTrustRecord(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.readCollection(trustRecordCollectionName).CountDocuments(ctx, playerTrustRecordFilter(gameID, playerID))
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataTrust, Records: records}, nil
+}
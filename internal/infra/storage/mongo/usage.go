@@ -0,0 +1,152 @@
+package mongo
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/usage"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const usageCollectionName = "usage"
+
+type DailyUsage struct {
+	Day              time.Time `bson:"day"`
+	GameID           string    `bson:"gameId"`
+	Requests         int64     `bson:"requests"`
+	ScoreSubmissions int64     `bson:"scoreSubmissions"`
+	// Keyed by `errorCodeField`, since the dots of the codes would be read as nested fields
+	Errors map[string]int64 `bson:"errors,omitempty"`
+}
+
+// Field of the error code on the `errors` document, e.g. `1_2` for `1.2`
+func errorCodeField(code string) string {
+	return strings.ReplaceAll(code, ".", "_")
+}
+
+func (u DailyUsage) toDomain() usage.DailyUsage {
+	var errorCounts usage.ErrorCounts
+	if len(u.Errors) > 0 {
+		errorCounts = make(usage.ErrorCounts, len(u.Errors))
+		for field, count := range u.Errors {
+			errorCounts[strings.ReplaceAll(field, "_", ".")] = count
+		}
+	}
+
+	return usage.DailyUsage{
+		Day:              u.Day.UTC(),
+		GameID:           u.GameID,
+		Requests:         u.Requests,
+		ScoreSubmissions: u.ScoreSubmissions,
+		Errors:           errorCounts,
+	}
+}
+
+// A single document is kept per game and day, so the counters are incremented in place
+var usageIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			{Key: "gameId", Value: 1},
+			{Key: "day", Value: 1},
+		},
+		Options: options.Index().SetName("gameId_1_day_1").SetUnique(true),
+	},
+}
+
+func (c connection) AddUsage(ctx context.Context, dailyUsage []usage.DailyUsage) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	if len(dailyUsage) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, len(dailyUsage))
+	for i, u := range dailyUsage {
+		inc := bson.M{"requests": u.Requests, "scoreSubmissions": u.ScoreSubmissions}
+		for code, count := range u.Errors {
+			inc["errors."+errorCodeField(code)] = count
+		}
+
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"gameId": u.GameID, "day": usage.Day(u.Day)}).
+			SetUpdate(bson.M{"$inc": inc}).
+			SetUpsert(true)
+	}
+
+	_, err := c.client.Database(c.db).Collection(usageCollectionName).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (c connection) ListUsage(ctx context.Context, gameID string, from, to time.Time) ([]usage.DailyUsage, error) {
+	var (
+		filter = bson.M{"gameId": gameID, "day": bson.M{"$gte": from, "$lte": to}}
+		opts   = options.Find().SetSort(bson.D{{Key: "day", Value: 1}})
+	)
+
+	cursor, err := c.readCollection(usageCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []DailyUsage
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	dailyUsage := make([]usage.DailyUsage, len(data))
+	for i, u := range data {
+		dailyUsage[i] = u.toDomain()
+	}
+
+	return dailyUsage, nil
+}
+
+type footprint struct {
+	Records int64 `bson:"records"`
+	Bytes   int64 `bson:"bytes"`
+}
+
+// Sums the BSON size of the statistics of the game and the player progressions kept for them
+func (c connection) GameFootprint(ctx context.Context, gameID string) (usage.Footprint, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"gameId": gameID}}},
+		{{Key: "$project", Value: bson.M{
+			"statisticId": bson.M{"$toString": "$_id"},
+			"size":        bson.M{"$bsonSize": "$$ROOT"},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         c.progressionCollection(),
+			"localField":   "statisticId",
+			"foreignField": "statisticId",
+			"pipeline":     bson.A{bson.M{"$project": bson.M{"_id": 0, "size": bson.M{"$bsonSize": "$$ROOT"}}}},
+			"as":           "progressions",
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     nil,
+			"records": bson.M{"$sum": bson.M{"$add": bson.A{1, bson.M{"$size": "$progressions"}}}},
+			"bytes":   bson.M{"$sum": bson.M{"$add": bson.A{"$size", bson.M{"$sum": "$progressions.size"}}}},
+		}}},
+	}
+
+	cursor, err := c.readCollection(statisticCollectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return usage.Footprint{}, err
+	}
+
+	var data []footprint
+	if err := cursor.All(ctx, &data); err != nil {
+		return usage.Footprint{}, err
+	}
+
+	if len(data) == 0 {
+		return usage.Footprint{}, nil
+	}
+
+	return usage.Footprint{Records: data[0].Records, Bytes: data[0].Bytes}, nil
+}
@@ -0,0 +1,452 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/webhook"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	webhookCollectionName         = "webhooks"
+	webhookDeliveryCollectionName = "webhookDeliveries"
+)
+
+type (
+	Webhook struct {
+		CreatedAt           time.Time          `bson:"createdAt"`
+		UpdatedAt           time.Time          `bson:"updatedAt"`
+		DisabledAt          time.Time          `bson:"disabledAt,omitempty"`
+		ID                  primitive.ObjectID `bson:"_id,omitempty"`
+		GameID              string             `bson:"gameId"`
+		URL                 string             `bson:"url"`
+		Secret              string             `bson:"secret"`
+		Filter              WebhookFilter      `bson:"filter"`
+		ConsecutiveFailures int                `bson:"consecutiveFailures"`
+	}
+
+	// Webhooks created before the filters were supported have none, subscribing to every event
+	WebhookFilter struct {
+		Events         []string `bson:"events,omitempty"`
+		LeaderboardIDs []string `bson:"leaderboardIds,omitempty"`
+		StatisticIDs   []string `bson:"statisticIds,omitempty"`
+		QuestIDs       []string `bson:"questIds,omitempty"`
+	}
+
+	WebhookAttempt struct {
+		AttemptedAt time.Time     `bson:"attemptedAt"`
+		Duration    time.Duration `bson:"duration"`
+		StatusCode  int           `bson:"statusCode"`
+		Error       string        `bson:"error"`
+	}
+
+	WebhookDelivery struct {
+		CreatedAt     time.Time          `bson:"createdAt"`
+		NextAttemptAt time.Time          `bson:"nextAttemptAt"`
+		ID            primitive.ObjectID `bson:"_id,omitempty"`
+		WebhookID     primitive.ObjectID `bson:"webhookId"`
+		GameID        string             `bson:"gameId"`
+		Event         string             `bson:"event"`
+		ContentType   string             `bson:"contentType"`
+		Payload       []byte             `bson:"payload"`
+		Status        string             `bson:"status"`
+		Attempts      []WebhookAttempt   `bson:"attempts"`
+	}
+)
+
+func newWebhookFilter(f webhook.Filter) WebhookFilter {
+	return WebhookFilter{
+		Events:         f.Events,
+		LeaderboardIDs: f.LeaderboardIDs,
+		StatisticIDs:   f.StatisticIDs,
+		QuestIDs:       f.QuestIDs,
+	}
+}
+
+func (f WebhookFilter) toDomain() webhook.Filter {
+	return webhook.Filter{
+		Events:         f.Events,
+		LeaderboardIDs: f.LeaderboardIDs,
+		StatisticIDs:   f.StatisticIDs,
+		QuestIDs:       f.QuestIDs,
+	}
+}
+
+func (w Webhook) toDomain() webhook.Webhook {
+	return webhook.Webhook{
+		CreatedAt:           w.CreatedAt,
+		UpdatedAt:           w.UpdatedAt,
+		DisabledAt:          w.DisabledAt,
+		ID:                  w.ID.Hex(),
+		GameID:              w.GameID,
+		URL:                 w.URL,
+		Secret:              w.Secret,
+		Filter:              w.Filter.toDomain(),
+		ConsecutiveFailures: w.ConsecutiveFailures,
+	}
+}
+
+func newWebhookAttempts(attempts []webhook.Attempt) []WebhookAttempt {
+	data := make([]WebhookAttempt, len(attempts))
+	for i, a := range attempts {
+		data[i] = WebhookAttempt{
+			AttemptedAt: a.AttemptedAt,
+			Duration:    a.Duration,
+			StatusCode:  a.StatusCode,
+			Error:       a.Error,
+		}
+	}
+
+	return data
+}
+
+func (d WebhookDelivery) toDomain() webhook.Delivery {
+	attempts := make([]webhook.Attempt, len(d.Attempts))
+	for i, a := range d.Attempts {
+		attempts[i] = webhook.Attempt{
+			AttemptedAt: a.AttemptedAt,
+			Duration:    a.Duration,
+			StatusCode:  a.StatusCode,
+			Error:       a.Error,
+		}
+	}
+
+	return webhook.Delivery{
+		CreatedAt:     d.CreatedAt,
+		NextAttemptAt: d.NextAttemptAt,
+		ID:            d.ID.Hex(),
+		WebhookID:     d.WebhookID.Hex(),
+		GameID:        d.GameID,
+		Event:         d.Event,
+		ContentType:   d.ContentType,
+		Payload:       d.Payload,
+		Status:        d.Status,
+		Attempts:      attempts,
+	}
+}
+
+var webhookIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("gameId_1_createdAt_1"),
+	},
+}
+
+var webhookDeliveryIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}},
+		Options: options.Index().SetName("status_1_nextAttemptAt_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "webhookId", Value: 1}, {Key: "_id", Value: -1}},
+		Options: options.Index().SetName("webhookId_1__id_-1"),
+	},
+}
+
+func (c connection) CreateWebhook(ctx context.Context, data webhook.NewWebhookData) (webhook.Webhook, error) {
+	if err := c.writable(); err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	wh := Webhook{
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+		GameID:    data.GameID,
+		URL:       data.URL,
+		Secret:    data.Secret,
+		Filter:    newWebhookFilter(data.Filter),
+	}
+
+	result, err := c.client.Database(c.db).Collection(webhookCollectionName).InsertOne(ctx, wh)
+	if err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	wh.ID = result.InsertedID.(primitive.ObjectID)
+	return wh.toDomain(), nil
+}
+
+// Read from the primary, since a webhook created or disabled must be seen by the next event
+func (c connection) ListWebhooks(ctx context.Context, gameID string) ([]webhook.Webhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	cursor, err := c.client.Database(c.db).Collection(webhookCollectionName).Find(ctx, bson.M{"gameId": gameID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []Webhook
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]webhook.Webhook, len(data))
+	for i, wh := range data {
+		webhooks[i] = wh.toDomain()
+	}
+
+	return webhooks, nil
+}
+
+func (c connection) GetWebhook(ctx context.Context, id string) (webhook.Webhook, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return webhook.Webhook{}, webhook.ErrInvalidWebhookID
+	}
+
+	var data Webhook
+	err = c.client.Database(c.db).Collection(webhookCollectionName).FindOne(ctx, bson.M{"_id": objectID}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = webhook.ErrWebhookNotFound
+		}
+
+		return webhook.Webhook{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) DeleteWebhook(ctx context.Context, id, gameID string) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return webhook.ErrInvalidWebhookID
+	}
+
+	return c.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		result, err := c.client.Database(c.db).Collection(webhookCollectionName).DeleteOne(ctx, bson.M{"_id": objectID, "gameId": gameID})
+		if err != nil {
+			return err
+		}
+
+		if result.DeletedCount == 0 {
+			return webhook.ErrWebhookNotFound
+		}
+
+		_, err = c.client.Database(c.db).Collection(webhookDeliveryCollectionName).DeleteMany(ctx, bson.M{"webhookId": objectID})
+		return err
+	})
+}
+
+func (c connection) EnableWebhook(ctx context.Context, id, gameID string) (webhook.Webhook, error) {
+	if err := c.writable(); err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return webhook.Webhook{}, webhook.ErrInvalidWebhookID
+	}
+
+	var (
+		filter = bson.M{"_id": objectID, "gameId": gameID}
+		update = bson.M{
+			"$set":   bson.M{"consecutiveFailures": 0, "updatedAt": time.Now().UTC()},
+			"$unset": bson.M{"disabledAt": ""},
+		}
+		opts = options.FindOneAndUpdate().SetReturnDocument(options.After)
+	)
+
+	var data Webhook
+	err = c.client.Database(c.db).Collection(webhookCollectionName).FindOneAndUpdate(ctx, filter, update, opts).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = webhook.ErrWebhookNotFound
+		}
+
+		return webhook.Webhook{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+func (c connection) UpdateWebhookFilter(ctx context.Context, id, gameID string, f webhook.Filter) (webhook.Webhook, error) {
+	if err := c.writable(); err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return webhook.Webhook{}, webhook.ErrInvalidWebhookID
+	}
+
+	var (
+		filter = bson.M{"_id": objectID, "gameId": gameID}
+		update = bson.M{"$set": bson.M{"filter": newWebhookFilter(f), "updatedAt": time.Now().UTC()}}
+		opts   = options.FindOneAndUpdate().SetReturnDocument(options.After)
+	)
+
+	var data Webhook
+	err = c.client.Database(c.db).Collection(webhookCollectionName).FindOneAndUpdate(ctx, filter, update, opts).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = webhook.ErrWebhookNotFound
+		}
+
+		return webhook.Webhook{}, err
+	}
+
+	return data.toDomain(), nil
+}
+
+// Failures are counted by a pipeline update, so concurrent attempts on the same webhook don't overwrite each other
+func (c connection) RecordWebhookAttempt(ctx context.Context, id string, succeeded bool, disableAfter int) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return webhook.ErrInvalidWebhookID
+	}
+
+	var update any = bson.M{"$set": bson.M{"consecutiveFailures": 0}}
+	if !succeeded {
+		now := time.Now().UTC()
+		disable := bson.M{"$and": bson.A{
+			bson.M{"$gte": bson.A{"$consecutiveFailures", disableAfter}},
+			bson.M{"$not": bson.A{"$disabledAt"}},
+		}}
+
+		update = bson.A{
+			bson.M{"$set": bson.M{"consecutiveFailures": bson.M{"$add": bson.A{"$consecutiveFailures", 1}}}},
+			bson.M{"$set": bson.M{
+				"disabledAt": bson.M{"$cond": bson.A{disable, now, "$disabledAt"}},
+				"updatedAt":  bson.M{"$cond": bson.A{disable, now, "$updatedAt"}},
+			}},
+		}
+	}
+
+	result, err := c.client.Database(c.db).Collection(webhookCollectionName).UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return webhook.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (c connection) CreateDeliveries(ctx context.Context, deliveries []webhook.Delivery) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	docs := make([]any, len(deliveries))
+	for i, d := range deliveries {
+		webhookID, err := primitive.ObjectIDFromHex(d.WebhookID)
+		if err != nil {
+			return webhook.ErrInvalidWebhookID
+		}
+
+		docs[i] = WebhookDelivery{
+			CreatedAt:     d.CreatedAt,
+			NextAttemptAt: d.NextAttemptAt,
+			WebhookID:     webhookID,
+			GameID:        d.GameID,
+			Event:         d.Event,
+			ContentType:   d.ContentType,
+			Payload:       d.Payload,
+			Status:        d.Status,
+			Attempts:      newWebhookAttempts(d.Attempts),
+		}
+	}
+
+	_, err := c.client.Database(c.db).Collection(webhookDeliveryCollectionName).InsertMany(ctx, docs)
+	return err
+}
+
+// Each delivery is leased on its own with an atomic update, so concurrent callers never claim the same delivery
+func (c connection) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhook.Delivery, error) {
+	if err := c.writable(); err != nil {
+		return nil, err
+	}
+
+	var (
+		collection = c.client.Database(c.db).Collection(webhookDeliveryCollectionName)
+		filter     = bson.M{"status": webhook.DeliveryStatusPending, "nextAttemptAt": bson.M{"$lte": now}}
+		update     = bson.M{"$set": bson.M{"nextAttemptAt": now.Add(lease)}}
+		opts       = options.FindOneAndUpdate().
+				SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).
+				SetReturnDocument(options.After)
+	)
+
+	deliveries := make([]webhook.Delivery, 0)
+	for len(deliveries) < limit {
+		var data WebhookDelivery
+		if err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&data); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				break
+			}
+
+			return deliveries, err
+		}
+
+		deliveries = append(deliveries, data.toDomain())
+	}
+
+	return deliveries, nil
+}
+
+func (c connection) UpdateDelivery(ctx context.Context, delivery webhook.Delivery) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(delivery.ID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":        delivery.Status,
+			"nextAttemptAt": delivery.NextAttemptAt,
+			"attempts":      newWebhookAttempts(delivery.Attempts),
+		},
+	}
+
+	// The webhook may have been removed alongside its deliveries while the delivery was being sent
+	_, err = c.client.Database(c.db).Collection(webhookDeliveryCollectionName).UpdateByID(ctx, objectID, update)
+	return err
+}
+
+func (c connection) ListDeliveries(ctx context.Context, webhookID string, limit int) ([]webhook.Delivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(webhookID)
+	if err != nil {
+		return nil, webhook.ErrInvalidWebhookID
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := c.readCollection(webhookDeliveryCollectionName).Find(ctx, bson.M{"webhookId": objectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []WebhookDelivery
+	if err := cursor.All(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]webhook.Delivery, len(data))
+	for i, d := range data {
+		deliveries[i] = d.toDomain()
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,58 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/writebehind"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const writeBehindCheckpointCollectionName = "writeBehindCheckpoints"
+
+// Last change of a write-behind queue written to the progressions
+type WriteBehindCheckpoint struct {
+	QueueID   string    `bson:"_id"`
+	Sequence  int64     `bson:"sequence"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+// Records the checkpoint tagged on the context, if any. Meant to run within the transaction of the bulk write, so the
+// checkpoint moves along with the changes. It never moves back
+func (c connection) saveWriteBehindCheckpoint(ctx context.Context) error {
+	checkpoint, ok := writebehind.CheckpointFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	_, err := c.client.Database(c.db).Collection(writeBehindCheckpointCollectionName).UpdateOne(
+		ctx,
+		bson.M{"_id": checkpoint.QueueID},
+		bson.M{
+			"$max": bson.M{"sequence": checkpoint.Sequence},
+			"$set": bson.M{"updatedAt": time.Now().UTC()},
+		},
+		options.Update().SetUpsert(true),
+	)
+
+	return err
+}
+
+// Always read from the primary, since a stale checkpoint would write its changes again
+func (c connection) GetWriteBehindCheckpoint(ctx context.Context, queueID string) (int64, error) {
+	var data WriteBehindCheckpoint
+	err := c.client.Database(c.db).Collection(writeBehindCheckpointCollectionName).FindOne(ctx, bson.M{"_id": bson.M{"$eq": queueID}}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return data.Sequence, nil
+}
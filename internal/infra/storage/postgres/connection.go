@@ -3,22 +3,46 @@ package postgres
 import (
 	"context"
 
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
 	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres/internal/sqlc"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html
+const uniqueViolationCode = "23505"
+
+type Options struct {
+	DSN     string           // PostgreSQL connection string
+	Outbox  bool             // Record the progression events on the outbox, within the transaction that applies the change
+	Metrics *metrics.Storage // Records the latency and the errors of every query. Optional
+}
+
 type connection struct {
 	pool    *pgxpool.Pool
 	queries *sqlc.Queries
+	outbox  bool
+}
+
+func (c connection) Ping(ctx context.Context) error {
+	return c.pool.Ping(ctx)
 }
 
 func (c connection) Close() {
 	c.pool.Close()
 }
 
-func New(ctx context.Context, connStr string) (*connection, error) {
-	pool, err := pgxpool.New(ctx, connStr)
+func New(ctx context.Context, opts Options) (*connection, error) {
+	config, err := pgxpool.ParseConfig(opts.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Metrics != nil {
+		config.ConnConfig.Tracer = metricsTracer{metrics: opts.Metrics}
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +50,7 @@ func New(ctx context.Context, connStr string) (*connection, error) {
 	conn := &connection{
 		pool:    pool,
 		queries: sqlc.New(pool),
+		outbox:  opts.Outbox,
 	}
 
 	return conn, nil
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+// Connects to the PostgreSQL container at `POSTGRES_TEST_DSN`, e.g. the docker-compose one, on a schema of its own with
+// every migration applied, dropped once the test ends. Skips the test when the DSN isn't set
+func newTestConnection(t *testing.T) *connection {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set")
+	}
+
+	var (
+		ctx    = context.Background()
+		schema = "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	)
+
+	admin, err := pgxpool.New(ctx, dsn)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(admin.Close)
+
+	_, err = admin.Exec(ctx, fmt.Sprintf("CREATE SCHEMA %q", schema))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	t.Cleanup(func() {
+		_, err := admin.Exec(context.Background(), fmt.Sprintf("DROP SCHEMA %q CASCADE", schema))
+		assert.NoError(t, err)
+	})
+
+	conn, err := New(ctx, Options{DSN: withSearchPath(dsn, schema)})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(conn.Close)
+
+	migrations, err := filepath.Glob(filepath.Join("internal", "migrations", "*.up.sql"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	slices.Sort(migrations)
+	for _, migration := range migrations {
+		data, err := os.ReadFile(migration)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		if _, err := conn.pool.Exec(ctx, string(data)); !assert.NoError(t, err, migration) {
+			t.FailNow()
+		}
+	}
+
+	return conn
+}
+
+func withSearchPath(dsn, schema string) string {
+	if !strings.Contains(dsn, "://") {
+		return dsn + " search_path=" + schema
+	}
+
+	if strings.Contains(dsn, "?") {
+		return dsn + "&search_path=" + schema
+	}
+
+	return dsn + "?search_path=" + schema
+}
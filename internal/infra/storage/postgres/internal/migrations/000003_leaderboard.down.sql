@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "idx_leaderboard_ranking_value" CASCADE;
+
+DROP TABLE IF EXISTS "leaderboard_rankings" CASCADE;
+
+DROP INDEX IF EXISTS "idx_leaderboard_created_at" CASCADE;
+DROP INDEX IF EXISTS "idx_leaderboard_deleted_at" CASCADE;
+DROP INDEX IF EXISTS "idx_leaderboard_game_id" CASCADE;
+
+DROP TABLE IF EXISTS "leaderboards" CASCADE;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS "leaderboards" (
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "deleted_at" TIMESTAMPTZ DEFAULT NULL,
+    "id" UUID NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    "game_id" VARCHAR NOT NULL,
+    "name" VARCHAR NOT NULL,
+    "description" TEXT NOT NULL DEFAULT '',
+    "start_at" TIMESTAMPTZ NOT NULL,
+    "end_at" TIMESTAMPTZ DEFAULT NULL,
+    "aggregation_mode" VARCHAR NOT NULL,
+    "ordering" VARCHAR NOT NULL,
+
+    CONSTRAINT "name_not_empty_check" CHECK (TRIM("name") <> ''),
+    CONSTRAINT "game_id_not_empty_check" CHECK (TRIM("game_id") <> ''),
+    CONSTRAINT "aggregation_mode_check" CHECK ("aggregation_mode" IN ('INC', 'MAX', 'MIN')),
+    CONSTRAINT "ordering_check" CHECK ("ordering" IN ('ASC', 'DESC'))
+);
+
+CREATE INDEX IF NOT EXISTS "idx_leaderboard_created_at" ON "leaderboards" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_leaderboard_deleted_at" ON "leaderboards" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_leaderboard_game_id" ON "leaderboards" ("game_id");
+
+CREATE TABLE IF NOT EXISTS "leaderboard_rankings" (
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "leaderboard_id" UUID NOT NULL REFERENCES "leaderboards" ("id") ON DELETE CASCADE,
+    "player_id" VARCHAR NOT NULL,
+    "value" DOUBLE PRECISION NOT NULL,
+
+    PRIMARY KEY ("leaderboard_id", "player_id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_leaderboard_ranking_value" ON "leaderboard_rankings" ("leaderboard_id", "value");
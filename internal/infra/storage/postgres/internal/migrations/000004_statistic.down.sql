@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "player_statistic_landmarks" CASCADE;
+
+DROP TABLE IF EXISTS "player_statistics" CASCADE;
+
+DROP INDEX IF EXISTS "idx_statistic_created_at" CASCADE;
+DROP INDEX IF EXISTS "idx_statistic_deleted_at" CASCADE;
+DROP INDEX IF EXISTS "idx_statistic_game_id" CASCADE;
+
+DROP TABLE IF EXISTS "statistics" CASCADE;
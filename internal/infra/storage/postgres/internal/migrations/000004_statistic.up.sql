@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS "statistics" (
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "deleted_at" TIMESTAMPTZ DEFAULT NULL,
+    "id" UUID NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    "game_id" VARCHAR NOT NULL,
+    "name" VARCHAR NOT NULL,
+    "description" TEXT NOT NULL DEFAULT '',
+    "aggregation_mode" VARCHAR NOT NULL,
+    "initial_value" DOUBLE PRECISION DEFAULT NULL,
+    "goal" DOUBLE PRECISION DEFAULT NULL,
+    "landmarks" DOUBLE PRECISION[] NOT NULL DEFAULT '{}',
+
+    CONSTRAINT "name_not_empty_check" CHECK (TRIM("name") <> ''),
+    CONSTRAINT "game_id_not_empty_check" CHECK (TRIM("game_id") <> ''),
+    CONSTRAINT "aggregation_mode_check" CHECK ("aggregation_mode" IN ('SUM', 'SUB', 'MAX', 'MIN'))
+);
+
+CREATE INDEX IF NOT EXISTS "idx_statistic_created_at" ON "statistics" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_statistic_deleted_at" ON "statistics" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_statistic_game_id" ON "statistics" ("game_id");
+
+CREATE TABLE IF NOT EXISTS "player_statistics" (
+    "started_at" TIMESTAMPTZ DEFAULT NULL,
+    "updated_at" TIMESTAMPTZ DEFAULT NULL,
+    "player_id" VARCHAR NOT NULL,
+    "statistic_id" UUID NOT NULL REFERENCES "statistics" ("id") ON DELETE CASCADE,
+    "current_value" DOUBLE PRECISION DEFAULT NULL,
+    "goal_value" DOUBLE PRECISION DEFAULT NULL,
+    "goal_completed_at" TIMESTAMPTZ DEFAULT NULL,
+
+    PRIMARY KEY ("player_id", "statistic_id")
+);
+
+CREATE TABLE IF NOT EXISTS "player_statistic_landmarks" (
+    "player_id" VARCHAR NOT NULL,
+    "statistic_id" UUID NOT NULL,
+    "value" DOUBLE PRECISION NOT NULL,
+    "completed_at" TIMESTAMPTZ DEFAULT NULL,
+
+    PRIMARY KEY ("player_id", "statistic_id", "value"),
+    FOREIGN KEY ("player_id", "statistic_id") REFERENCES "player_statistics" ("player_id", "statistic_id") ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "idx_statistic_game_id_name" CASCADE;
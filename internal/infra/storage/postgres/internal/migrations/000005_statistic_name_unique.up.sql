@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_statistic_game_id_name" ON "statistics" ("game_id", "name") WHERE "deleted_at" IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_outbox_event_created_at" CASCADE;
+
+DROP TABLE IF EXISTS "outbox_events" CASCADE;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "id" UUID NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    "kind" VARCHAR NOT NULL,
+    "payload" JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "idx_outbox_event_created_at" ON "outbox_events" ("created_at");
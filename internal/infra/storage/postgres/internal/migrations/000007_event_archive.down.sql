@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_event_archive_game_id_created_at" CASCADE;
+
+DROP TABLE IF EXISTS "event_archive" CASCADE;
+
+ALTER TABLE "outbox_events" DROP COLUMN IF EXISTS "game_id";
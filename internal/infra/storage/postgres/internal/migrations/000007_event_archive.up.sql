@@ -0,0 +1,12 @@
+ALTER TABLE "outbox_events" ADD COLUMN IF NOT EXISTS "game_id" VARCHAR NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS "event_archive" (
+    "created_at" TIMESTAMPTZ NOT NULL,
+    "id" UUID NOT NULL PRIMARY KEY,
+    "game_id" VARCHAR NOT NULL,
+    "kind" VARCHAR NOT NULL,
+    "payload" JSONB NOT NULL,
+    "archived_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_event_archive_game_id_created_at" ON "event_archive" ("game_id", "created_at", "id");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_leaderboard_end_at";
+DROP INDEX IF EXISTS "idx_leaderboard_start_at";
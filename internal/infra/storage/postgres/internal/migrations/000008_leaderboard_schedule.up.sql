@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS "idx_leaderboard_start_at" ON "leaderboards" ("start_at");
+CREATE INDEX IF NOT EXISTS "idx_leaderboard_end_at" ON "leaderboards" ("end_at");
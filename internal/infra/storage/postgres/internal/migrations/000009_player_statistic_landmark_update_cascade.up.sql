@@ -0,0 +1,3 @@
+ALTER TABLE "player_statistic_landmarks" DROP CONSTRAINT IF EXISTS "player_statistic_landmarks_player_id_statistic_id_fkey";
+ALTER TABLE "player_statistic_landmarks" ADD CONSTRAINT "player_statistic_landmarks_player_id_statistic_id_fkey"
+    FOREIGN KEY ("player_id", "statistic_id") REFERENCES "player_statistics" ("player_id", "statistic_id") ON DELETE CASCADE ON UPDATE CASCADE;
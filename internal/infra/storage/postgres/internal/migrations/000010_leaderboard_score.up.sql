@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "leaderboard_scores" (
+    "submitted_at" TIMESTAMPTZ NOT NULL,
+    "id" UUID NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    "leaderboard_id" UUID NOT NULL REFERENCES "leaderboards" ("id") ON DELETE CASCADE,
+    "game_id" VARCHAR NOT NULL,
+    "player_id" VARCHAR NOT NULL,
+    "value" DOUBLE PRECISION NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "idx_leaderboard_score_submitted_at" ON "leaderboard_scores" ("leaderboard_id", "submitted_at", "id");
+CREATE INDEX IF NOT EXISTS "idx_leaderboard_score_player_id" ON "leaderboard_scores" ("game_id", "player_id");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_leaderboard_score_source";
+
+ALTER TABLE "leaderboard_scores" DROP COLUMN IF EXISTS "source";
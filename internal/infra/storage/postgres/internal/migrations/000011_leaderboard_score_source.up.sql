@@ -0,0 +1,3 @@
+ALTER TABLE "leaderboard_scores" ADD COLUMN IF NOT EXISTS "source" VARCHAR NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS "idx_leaderboard_score_source" ON "leaderboard_scores" ("game_id", "source", "submitted_at", "id");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_leaderboard_score_device";
+
+ALTER TABLE "leaderboard_scores" DROP COLUMN IF EXISTS "region";
+ALTER TABLE "leaderboard_scores" DROP COLUMN IF EXISTS "build_version";
+ALTER TABLE "leaderboard_scores" DROP COLUMN IF EXISTS "device_hash";
@@ -0,0 +1,5 @@
+ALTER TABLE "leaderboard_scores" ADD COLUMN IF NOT EXISTS "device_hash" VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE "leaderboard_scores" ADD COLUMN IF NOT EXISTS "build_version" VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE "leaderboard_scores" ADD COLUMN IF NOT EXISTS "region" VARCHAR NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS "idx_leaderboard_score_device" ON "leaderboard_scores" ("game_id", "device_hash") WHERE "device_hash" <> '';
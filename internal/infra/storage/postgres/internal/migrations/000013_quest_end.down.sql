@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_quest_end_at";
+
+ALTER TABLE "quests" DROP COLUMN IF EXISTS "end_at";
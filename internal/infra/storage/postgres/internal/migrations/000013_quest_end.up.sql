@@ -0,0 +1,3 @@
+ALTER TABLE "quests" ADD COLUMN IF NOT EXISTS "end_at" TIMESTAMPTZ DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_quest_end_at" ON "quests" ("end_at");
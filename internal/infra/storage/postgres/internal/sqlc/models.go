@@ -9,6 +9,58 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type EventArchive struct {
+	CreatedAt  pgtype.Timestamptz
+	ID         uuid.UUID
+	GameID     string
+	Kind       string
+	Payload    []byte
+	ArchivedAt pgtype.Timestamptz
+}
+
+type Leaderboard struct {
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	DeletedAt       pgtype.Timestamptz
+	ID              uuid.UUID
+	GameID          string
+	Name            string
+	Description     string
+	StartAt         pgtype.Timestamptz
+	EndAt           pgtype.Timestamptz
+	AggregationMode string
+	Ordering        string
+}
+
+type LeaderboardRanking struct {
+	CreatedAt     pgtype.Timestamptz
+	UpdatedAt     pgtype.Timestamptz
+	LeaderboardID uuid.UUID
+	PlayerID      string
+	Value         float64
+}
+
+type LeaderboardScore struct {
+	SubmittedAt   pgtype.Timestamptz
+	ID            uuid.UUID
+	LeaderboardID uuid.UUID
+	GameID        string
+	PlayerID      string
+	Value         float64
+	Source        string
+	DeviceHash    string
+	BuildVersion  string
+	Region        string
+}
+
+type OutboxEvent struct {
+	CreatedAt pgtype.Timestamptz
+	ID        uuid.UUID
+	Kind      string
+	Payload   []byte
+	GameID    string
+}
+
 type PlayerQuest struct {
 	StartedAt   pgtype.Timestamptz
 	UpdatedAt   pgtype.Timestamptz
@@ -28,6 +80,23 @@ type PlayerQuestTask struct {
 	CompletedAt   pgtype.Timestamptz
 }
 
+type PlayerStatistic struct {
+	StartedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	PlayerID        string
+	StatisticID     uuid.UUID
+	CurrentValue    pgtype.Float8
+	GoalValue       pgtype.Float8
+	GoalCompletedAt pgtype.Timestamptz
+}
+
+type PlayerStatisticLandmark struct {
+	PlayerID    string
+	StatisticID uuid.UUID
+	Value       float64
+	CompletedAt pgtype.Timestamptz
+}
+
 type Quest struct {
 	CreatedAt   pgtype.Timestamptz
 	UpdatedAt   pgtype.Timestamptz
@@ -36,6 +105,21 @@ type Quest struct {
 	GameID      string
 	Name        string
 	Description string
+	EndAt       pgtype.Timestamptz
+}
+
+type Statistic struct {
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	DeletedAt       pgtype.Timestamptz
+	ID              uuid.UUID
+	GameID          string
+	Name            string
+	Description     string
+	AggregationMode string
+	InitialValue    pgtype.Float8
+	Goal            pgtype.Float8
+	Landmarks       []float64
 }
 
 type Task struct {
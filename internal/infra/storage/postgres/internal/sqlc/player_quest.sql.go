@@ -388,3 +388,312 @@ func (q *Queries) StartPlayerTasksThatHadTheDependenciesCompleted(ctx context.Co
 	_, err := q.db.Exec(ctx, startPlayerTasksThatHadTheDependenciesCompleted, arg.QuestID, arg.PlayerID)
 	return err
 }
+
+const listPlayerQuestsByQuestID = `-- name: ListPlayerQuestsByQuestID :many
+SELECT started_at, updated_at, id, player_id, quest_id, completed_at
+FROM "player_quests" pq
+WHERE pq."quest_id" = $1
+ORDER BY pq."started_at" ASC
+`
+
+// ListPlayerQuestsByQuestID
+//
+//	SELECT started_at, updated_at, id, player_id, quest_id, completed_at
+//	FROM "player_quests" pq
+//	WHERE pq."quest_id" = $1
+//	ORDER BY pq."started_at" ASC
+func (q *Queries) ListPlayerQuestsByQuestID(ctx context.Context, questID uuid.UUID) ([]PlayerQuest, error) {
+	rows, err := q.db.Query(ctx, listPlayerQuestsByQuestID, questID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PlayerQuest{}
+	for rows.Next() {
+		var i PlayerQuest
+		if err := rows.Scan(
+			&i.StartedAt,
+			&i.UpdatedAt,
+			&i.ID,
+			&i.PlayerID,
+			&i.QuestID,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPlayerQuestTasksByQuestID = `-- name: ListPlayerQuestTasksByQuestID :many
+SELECT pqt.started_at, pqt.updated_at, pqt.id, pqt.player_id, pqt.player_quest_id, pqt.task_id, pqt.completed_at
+FROM "player_quest_tasks" pqt
+JOIN "player_quests" pq ON pq."id" = pqt."player_quest_id"
+WHERE pq."quest_id" = $1
+`
+
+// ListPlayerQuestTasksByQuestID
+//
+//	SELECT pqt.started_at, pqt.updated_at, pqt.id, pqt.player_id, pqt.player_quest_id, pqt.task_id, pqt.completed_at
+//	FROM "player_quest_tasks" pqt
+//	JOIN "player_quests" pq ON pq."id" = pqt."player_quest_id"
+//	WHERE pq."quest_id" = $1
+func (q *Queries) ListPlayerQuestTasksByQuestID(ctx context.Context, questID uuid.UUID) ([]PlayerQuestTask, error) {
+	rows, err := q.db.Query(ctx, listPlayerQuestTasksByQuestID, questID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PlayerQuestTask{}
+	for rows.Next() {
+		var i PlayerQuestTask
+		if err := rows.Scan(
+			&i.StartedAt,
+			&i.UpdatedAt,
+			&i.ID,
+			&i.PlayerID,
+			&i.PlayerQuestID,
+			&i.TaskID,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restorePlayerQuest = `-- name: RestorePlayerQuest :one
+INSERT INTO "player_quests" ("started_at", "updated_at", "player_id", "quest_id", "completed_at")
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT ("player_id", "quest_id") DO NOTHING
+RETURNING "id"
+`
+
+type RestorePlayerQuestParams struct {
+	StartedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	PlayerID    string
+	QuestID     uuid.UUID
+	CompletedAt pgtype.Timestamptz
+}
+
+// RestorePlayerQuest
+//
+//	INSERT INTO "player_quests" ("started_at", "updated_at", "player_id", "quest_id", "completed_at")
+//	VALUES ($1, $2, $3, $4, $5)
+//	ON CONFLICT ("player_id", "quest_id") DO NOTHING
+//	RETURNING "id"
+func (q *Queries) RestorePlayerQuest(ctx context.Context, arg RestorePlayerQuestParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, restorePlayerQuest,
+		arg.StartedAt,
+		arg.UpdatedAt,
+		arg.PlayerID,
+		arg.QuestID,
+		arg.CompletedAt,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const restorePlayerQuestTask = `-- name: RestorePlayerQuestTask :exec
+INSERT INTO "player_quest_tasks" ("started_at", "updated_at", "player_id", "player_quest_id", "task_id", "completed_at")
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type RestorePlayerQuestTaskParams struct {
+	StartedAt     pgtype.Timestamptz
+	UpdatedAt     pgtype.Timestamptz
+	PlayerID      string
+	PlayerQuestID uuid.UUID
+	TaskID        uuid.UUID
+	CompletedAt   pgtype.Timestamptz
+}
+
+// RestorePlayerQuestTask
+//
+//	INSERT INTO "player_quest_tasks" ("started_at", "updated_at", "player_id", "player_quest_id", "task_id", "completed_at")
+//	VALUES ($1, $2, $3, $4, $5, $6)
+func (q *Queries) RestorePlayerQuestTask(ctx context.Context, arg RestorePlayerQuestTaskParams) error {
+	_, err := q.db.Exec(ctx, restorePlayerQuestTask,
+		arg.StartedAt,
+		arg.UpdatedAt,
+		arg.PlayerID,
+		arg.PlayerQuestID,
+		arg.TaskID,
+		arg.CompletedAt,
+	)
+	return err
+}
+
+const erasePlayerQuests = `-- name: ErasePlayerQuests :execrows
+DELETE FROM "player_quests" pq
+USING "quests" q
+WHERE
+    q."id" = pq."quest_id" AND
+    q."game_id" = $1 AND
+    pq."player_id" = $2
+`
+
+type ErasePlayerQuestsParams struct {
+	GameID   string
+	PlayerID string
+}
+
+// ErasePlayerQuests
+//
+//	DELETE FROM "player_quests" pq
+//	USING "quests" q
+//	WHERE
+//	    q."id" = pq."quest_id" AND
+//	    q."game_id" = $1 AND
+//	    pq."player_id" = $2
+func (q *Queries) ErasePlayerQuests(ctx context.Context, arg ErasePlayerQuestsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, erasePlayerQuests, arg.GameID, arg.PlayerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const anonymizePlayerQuests = `-- name: AnonymizePlayerQuests :execrows
+UPDATE "player_quests" pq
+SET "player_id" = $1
+FROM "quests" q
+WHERE
+    q."id" = pq."quest_id" AND
+    q."game_id" = $2 AND
+    pq."player_id" = $3
+`
+
+type AnonymizePlayerQuestsParams struct {
+	Pseudonym string
+	GameID    string
+	PlayerID  string
+}
+
+// AnonymizePlayerQuests
+//
+//	UPDATE "player_quests" pq
+//	SET "player_id" = $1
+//	FROM "quests" q
+//	WHERE
+//	    q."id" = pq."quest_id" AND
+//	    q."game_id" = $2 AND
+//	    pq."player_id" = $3
+func (q *Queries) AnonymizePlayerQuests(ctx context.Context, arg AnonymizePlayerQuestsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizePlayerQuests, arg.Pseudonym, arg.GameID, arg.PlayerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const anonymizePlayerQuestTasks = `-- name: AnonymizePlayerQuestTasks :execrows
+UPDATE "player_quest_tasks" pqt
+SET "player_id" = $1
+FROM "player_quests" pq, "quests" q
+WHERE
+    pq."id" = pqt."player_quest_id" AND
+    q."id" = pq."quest_id" AND
+    q."game_id" = $2 AND
+    pqt."player_id" = $3
+`
+
+type AnonymizePlayerQuestTasksParams struct {
+	Pseudonym string
+	GameID    string
+	PlayerID  string
+}
+
+// AnonymizePlayerQuestTasks
+//
+//	UPDATE "player_quest_tasks" pqt
+//	SET "player_id" = $1
+//	FROM "player_quests" pq, "quests" q
+//	WHERE
+//	    pq."id" = pqt."player_quest_id" AND
+//	    q."id" = pq."quest_id" AND
+//	    q."game_id" = $2 AND
+//	    pqt."player_id" = $3
+func (q *Queries) AnonymizePlayerQuestTasks(ctx context.Context, arg AnonymizePlayerQuestTasksParams) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizePlayerQuestTasks, arg.Pseudonym, arg.GameID, arg.PlayerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countPlayerQuests = `-- name: CountPlayerQuests :one
+SELECT COUNT(*)
+FROM "player_quests" pq
+JOIN "quests" q ON q."id" = pq."quest_id"
+WHERE
+    q."game_id" = $1 AND
+    pq."player_id" = $2
+`
+
+type CountPlayerQuestsParams struct {
+	GameID   string
+	PlayerID string
+}
+
+// CountPlayerQuests
+//
+//	SELECT COUNT(*)
+//	FROM "player_quests" pq
+//	JOIN "quests" q ON q."id" = pq."quest_id"
+//	WHERE
+//	    q."game_id" = $1 AND
+//	    pq."player_id" = $2
+func (q *Queries) CountPlayerQuests(ctx context.Context, arg CountPlayerQuestsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPlayerQuests, arg.GameID, arg.PlayerID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const listPlayersWithQuestUnfinished = `-- name: ListPlayersWithQuestUnfinished :many
+SELECT pq."player_id"
+FROM "player_quests" pq
+WHERE
+    pq."quest_id" = $1 AND
+    pq."completed_at" IS NULL
+ORDER BY pq."player_id" ASC
+`
+
+// ListPlayersWithQuestUnfinished
+//
+//	SELECT pq."player_id"
+//	FROM "player_quests" pq
+//	WHERE
+//	    pq."quest_id" = $1 AND
+//	    pq."completed_at" IS NULL
+//	ORDER BY pq."player_id" ASC
+func (q *Queries) ListPlayersWithQuestUnfinished(ctx context.Context, questID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, listPlayersWithQuestUnfinished, questID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var player_id string
+		if err := rows.Scan(&player_id); err != nil {
+			return nil, err
+		}
+		items = append(items, player_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -9,27 +9,34 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createQuest = `-- name: CreateQuest :one
-INSERT INTO "quests" ("game_id", "name", "description")
-VALUES ($1, $2, $3)
-RETURNING created_at, updated_at, deleted_at, id, game_id, name, description
+INSERT INTO "quests" ("game_id", "name", "description", "end_at")
+VALUES ($1, $2, $3, $4)
+RETURNING created_at, updated_at, deleted_at, id, game_id, name, description, end_at
 `
 
 type CreateQuestParams struct {
 	GameID      string
 	Name        string
 	Description string
+	EndAt       pgtype.Timestamptz
 }
 
 // CreateQuest
 //
-//	INSERT INTO "quests" ("game_id", "name", "description")
-//	VALUES ($1, $2, $3)
-//	RETURNING created_at, updated_at, deleted_at, id, game_id, name, description
+//	INSERT INTO "quests" ("game_id", "name", "description", "end_at")
+//	VALUES ($1, $2, $3, $4)
+//	RETURNING created_at, updated_at, deleted_at, id, game_id, name, description, end_at
 func (q *Queries) CreateQuest(ctx context.Context, arg CreateQuestParams) (Quest, error) {
-	row := q.db.QueryRow(ctx, createQuest, arg.GameID, arg.Name, arg.Description)
+	row := q.db.QueryRow(ctx, createQuest,
+		arg.GameID,
+		arg.Name,
+		arg.Description,
+		arg.EndAt,
+	)
 	var i Quest
 	err := row.Scan(
 		&i.CreatedAt,
@@ -39,12 +46,13 @@ func (q *Queries) CreateQuest(ctx context.Context, arg CreateQuestParams) (Quest
 		&i.GameID,
 		&i.Name,
 		&i.Description,
+		&i.EndAt,
 	)
 	return i, err
 }
 
 const getQuestByIDAndGameID = `-- name: GetQuestByIDAndGameID :one
-SELECT created_at, updated_at, deleted_at, id, game_id, name, description
+SELECT created_at, updated_at, deleted_at, id, game_id, name, description, end_at
 FROM "quests" q
 WHERE
     q."id" = $1 AND
@@ -60,7 +68,7 @@ type GetQuestByIDAndGameIDParams struct {
 
 // GetQuestByIDAndGameID
 //
-//	SELECT created_at, updated_at, deleted_at, id, game_id, name, description
+//	SELECT created_at, updated_at, deleted_at, id, game_id, name, description, end_at
 //	FROM "quests" q
 //	WHERE
 //	    q."id" = $1 AND
@@ -78,6 +86,7 @@ func (q *Queries) GetQuestByIDAndGameID(ctx context.Context, arg GetQuestByIDAnd
 		&i.GameID,
 		&i.Name,
 		&i.Description,
+		&i.EndAt,
 	)
 	return i, err
 }
@@ -113,3 +122,245 @@ func (q *Queries) SoftDeleteQuestByIDAndGameID(ctx context.Context, arg SoftDele
 	}
 	return result.RowsAffected(), nil
 }
+
+const purgeSoftDeletedQuests = `-- name: PurgeSoftDeletedQuests :many
+DELETE FROM "quests"
+WHERE
+    "deleted_at" IS NOT NULL AND
+    "deleted_at" < $1 AND
+    ($2::VARCHAR = '' OR "game_id" = $2)
+RETURNING "id", "game_id", "deleted_at"
+`
+
+type PurgeSoftDeletedQuestsParams struct {
+	DeletedBefore pgtype.Timestamptz
+	GameID        string
+}
+
+type PurgeSoftDeletedQuestsRow struct {
+	ID        uuid.UUID
+	GameID    string
+	DeletedAt pgtype.Timestamptz
+}
+
+// PurgeSoftDeletedQuests
+//
+//	DELETE FROM "quests"
+//	WHERE
+//	    "deleted_at" IS NOT NULL AND
+//	    "deleted_at" < $1 AND
+//	    ($2::VARCHAR = '' OR "game_id" = $2)
+//	RETURNING "id", "game_id", "deleted_at"
+func (q *Queries) PurgeSoftDeletedQuests(ctx context.Context, arg PurgeSoftDeletedQuestsParams) ([]PurgeSoftDeletedQuestsRow, error) {
+	rows, err := q.db.Query(ctx, purgeSoftDeletedQuests, arg.DeletedBefore, arg.GameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PurgeSoftDeletedQuestsRow{}
+	for rows.Next() {
+		var i PurgeSoftDeletedQuestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuestsByGameID = `-- name: ListQuestsByGameID :many
+SELECT created_at, updated_at, deleted_at, id, game_id, name, description, end_at
+FROM "quests" q
+WHERE
+    q."game_id" = $1 AND
+    q."deleted_at" IS NULL
+ORDER BY q."created_at" ASC
+`
+
+// ListQuestsByGameID
+//
+//	SELECT created_at, updated_at, deleted_at, id, game_id, name, description, end_at
+//	FROM "quests" q
+//	WHERE
+//	    q."game_id" = $1 AND
+//	    q."deleted_at" IS NULL
+//	ORDER BY q."created_at" ASC
+func (q *Queries) ListQuestsByGameID(ctx context.Context, gameID string) ([]Quest, error) {
+	rows, err := q.db.Query(ctx, listQuestsByGameID, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quest{}
+	for rows.Next() {
+		var i Quest
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ID,
+			&i.GameID,
+			&i.Name,
+			&i.Description,
+			&i.EndAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreQuest = `-- name: RestoreQuest :execrows
+INSERT INTO "quests" ("created_at", "updated_at", "id", "game_id", "name", "description", "end_at")
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT ("id") DO NOTHING
+`
+
+type RestoreQuestParams struct {
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	ID          uuid.UUID
+	GameID      string
+	Name        string
+	Description string
+	EndAt       pgtype.Timestamptz
+}
+
+// RestoreQuest
+//
+//	INSERT INTO "quests" ("created_at", "updated_at", "id", "game_id", "name", "description", "end_at")
+//	VALUES ($1, $2, $3, $4, $5, $6, $7)
+//	ON CONFLICT ("id") DO NOTHING
+func (q *Queries) RestoreQuest(ctx context.Context, arg RestoreQuestParams) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreQuest,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.GameID,
+		arg.Name,
+		arg.Description,
+		arg.EndAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listQuestsEndedBetween = `-- name: ListQuestsEndedBetween :many
+SELECT created_at, updated_at, deleted_at, id, game_id, name, description, end_at
+FROM "quests" q
+WHERE
+    q."deleted_at" IS NULL AND
+    q."end_at" > $1 AND
+    q."end_at" <= $2
+ORDER BY q."end_at" ASC
+`
+
+type ListQuestsEndedBetweenParams struct {
+	EndedFrom pgtype.Timestamptz
+	EndedTo   pgtype.Timestamptz
+}
+
+// ListQuestsEndedBetween
+//
+//	SELECT created_at, updated_at, deleted_at, id, game_id, name, description, end_at
+//	FROM "quests" q
+//	WHERE
+//	    q."deleted_at" IS NULL AND
+//	    q."end_at" > $1 AND
+//	    q."end_at" <= $2
+//	ORDER BY q."end_at" ASC
+func (q *Queries) ListQuestsEndedBetween(ctx context.Context, arg ListQuestsEndedBetweenParams) ([]Quest, error) {
+	rows, err := q.db.Query(ctx, listQuestsEndedBetween, arg.EndedFrom, arg.EndedTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quest{}
+	for rows.Next() {
+		var i Quest
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ID,
+			&i.GameID,
+			&i.Name,
+			&i.Description,
+			&i.EndAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSoftDeletedQuests = `-- name: ListSoftDeletedQuests :many
+SELECT "id", "game_id", "deleted_at"
+FROM "quests"
+WHERE
+    "deleted_at" IS NOT NULL AND
+    "deleted_at" < $1 AND
+    ($2::VARCHAR = '' OR "game_id" = $2)
+ORDER BY "deleted_at" ASC
+`
+
+type ListSoftDeletedQuestsParams struct {
+	DeletedBefore pgtype.Timestamptz
+	GameID        string
+}
+
+type ListSoftDeletedQuestsRow struct {
+	ID        uuid.UUID
+	GameID    string
+	DeletedAt pgtype.Timestamptz
+}
+
+// ListSoftDeletedQuests
+//
+//	SELECT "id", "game_id", "deleted_at"
+//	FROM "quests"
+//	WHERE
+//	    "deleted_at" IS NOT NULL AND
+//	    "deleted_at" < $1 AND
+//	    ($2::VARCHAR = '' OR "game_id" = $2)
+//	ORDER BY "deleted_at" ASC
+func (q *Queries) ListSoftDeletedQuests(ctx context.Context, arg ListSoftDeletedQuestsParams) ([]ListSoftDeletedQuestsRow, error) {
+	rows, err := q.db.Query(ctx, listSoftDeletedQuests, arg.DeletedBefore, arg.GameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSoftDeletedQuestsRow{}
+	for rows.Next() {
+		var i ListSoftDeletedQuestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createTask = `-- name: CreateTask :one
@@ -139,3 +140,37 @@ func (q *Queries) SoftDeleteTasksByQuestID(ctx context.Context, questID uuid.UUI
 	_, err := q.db.Exec(ctx, softDeleteTasksByQuestID, questID)
 	return err
 }
+
+const restoreTask = `-- name: RestoreTask :exec
+INSERT INTO "tasks" ("created_at", "updated_at", "quest_id", "id", "name", "description", "required_for_completion", "rule")
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type RestoreTaskParams struct {
+	CreatedAt             pgtype.Timestamptz
+	UpdatedAt             pgtype.Timestamptz
+	QuestID               uuid.UUID
+	ID                    uuid.UUID
+	Name                  string
+	Description           string
+	RequiredForCompletion bool
+	Rule                  string
+}
+
+// RestoreTask
+//
+//	INSERT INTO "tasks" ("created_at", "updated_at", "quest_id", "id", "name", "description", "required_for_completion", "rule")
+//	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+func (q *Queries) RestoreTask(ctx context.Context, arg RestoreTaskParams) error {
+	_, err := q.db.Exec(ctx, restoreTask,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.QuestID,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.RequiredForCompletion,
+		arg.Rule,
+	)
+	return err
+}
@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/backup"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres/internal/sqlc"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/purge"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func sqlcLeaderboardToDomain(l sqlc.Leaderboard) leaderboard.Leaderboard {
+	return leaderboard.Leaderboard{
+		CreatedAt:       l.CreatedAt.Time,
+		UpdatedAt:       l.UpdatedAt.Time,
+		DeletedAt:       l.DeletedAt.Time,
+		ID:              l.ID.String(),
+		GameID:          l.GameID,
+		Name:            l.Name,
+		Description:     l.Description,
+		StartAt:         l.StartAt.Time,
+		EndAt:           l.EndAt.Time,
+		AggregationMode: l.AggregationMode,
+		Ordering:        l.Ordering,
+	}
+}
+
+func (c connection) CreateLeaderboard(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
+	leaderboardData, err := c.queries.CreateLeaderboard(ctx, sqlc.CreateLeaderboardParams{
+		GameID:          data.GameID,
+		Name:            data.Name,
+		Description:     data.Description,
+		StartAt:         pgtype.Timestamptz{Time: data.StartAt, Valid: true},
+		EndAt:           pgtype.Timestamptz{Time: data.EndAt, Valid: !data.EndAt.IsZero()},
+		AggregationMode: data.AggregationMode,
+		Ordering:        data.Ordering,
+	})
+	if err != nil {
+		return leaderboard.Leaderboard{}, err
+	}
+
+	return sqlcLeaderboardToDomain(leaderboardData), nil
+}
+
+func (c connection) GetLeaderboardByIDAndGameID(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return leaderboard.Leaderboard{}, leaderboard.ErrInvalidLeaderboardID
+	}
+
+	leaderboardData, err := c.queries.GetLeaderboardByIDAndGameID(ctx, sqlc.GetLeaderboardByIDAndGameIDParams{
+		ID:     uid,
+		GameID: gameID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = leaderboard.ErrLeaderboardNotFound
+		}
+
+		return leaderboard.Leaderboard{}, err
+	}
+
+	return sqlcLeaderboardToDomain(leaderboardData), nil
+}
+
+func (c connection) SoftDeleteLeaderboard(ctx context.Context, id, gameID string) error {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return leaderboard.ErrInvalidLeaderboardID
+	}
+
+	affected, err := c.queries.SoftDeleteLeaderboardByIDAndGameID(ctx, sqlc.SoftDeleteLeaderboardByIDAndGameIDParams{
+		ID:     uid,
+		GameID: gameID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return leaderboard.ErrLeaderboardNotFound
+	}
+
+	return nil
+}
+
+func (c connection) PurgeSoftDeletedLeaderboards(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	rows, err := c.queries.PurgeSoftDeletedLeaderboards(ctx, sqlc.PurgeSoftDeletedLeaderboardsParams{
+		DeletedBefore: pgtype.Timestamptz{Time: deletedBefore, Valid: true},
+		GameID:        gameID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, len(rows))
+	for i, row := range rows {
+		records[i] = purge.Record{
+			DeletedAt: row.DeletedAt.Time,
+			Entity:    purge.EntityLeaderboard,
+			ID:        row.ID.String(),
+			GameID:    row.GameID,
+		}
+	}
+
+	return records, nil
+}
+
+func (c connection) ListSoftDeletedLeaderboards(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	rows, err := c.queries.ListSoftDeletedLeaderboards(ctx, sqlc.ListSoftDeletedLeaderboardsParams{
+		DeletedBefore: pgtype.Timestamptz{Time: deletedBefore, Valid: true},
+		GameID:        gameID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, len(rows))
+	for i, row := range rows {
+		records[i] = purge.Record{
+			DeletedAt: row.DeletedAt.Time,
+			Entity:    purge.EntityLeaderboard,
+			ID:        row.ID.String(),
+			GameID:    row.GameID,
+		}
+	}
+
+	return records, nil
+}
+
+func (c connection) ListLeaderboards(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+	rows, err := c.queries.ListLeaderboardsByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboards := make([]leaderboard.Leaderboard, len(rows))
+	for i, row := range rows {
+		leaderboards[i] = sqlcLeaderboardToDomain(row)
+	}
+
+	return leaderboards, nil
+}
+
+func (c connection) RestoreLeaderboard(ctx context.Context, lb leaderboard.Leaderboard) error {
+	uid, err := uuid.Parse(lb.ID)
+	if err != nil {
+		return leaderboard.ErrInvalidLeaderboardID
+	}
+
+	affected, err := c.queries.RestoreLeaderboard(ctx, sqlc.RestoreLeaderboardParams{
+		CreatedAt:       pgtype.Timestamptz{Time: lb.CreatedAt, Valid: true},
+		UpdatedAt:       pgtype.Timestamptz{Time: lb.UpdatedAt, Valid: true},
+		ID:              uid,
+		GameID:          lb.GameID,
+		Name:            lb.Name,
+		Description:     lb.Description,
+		StartAt:         pgtype.Timestamptz{Time: lb.StartAt, Valid: true},
+		EndAt:           pgtype.Timestamptz{Time: lb.EndAt, Valid: !lb.EndAt.IsZero()},
+		AggregationMode: lb.AggregationMode,
+		Ordering:        lb.Ordering,
+	})
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return backup.ErrEntityAlreadyExists
+	}
+
+	return nil
+}
+
+func (c connection) ListLeaderboardsScheduledBetween(ctx context.Context, from, to time.Time) ([]leaderboard.Leaderboard, error) {
+	rows, err := c.queries.ListLeaderboardsScheduledBetween(ctx, sqlc.ListLeaderboardsScheduledBetweenParams{
+		ScheduledFrom: pgtype.Timestamptz{Time: from, Valid: true},
+		ScheduledTo:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboards := make([]leaderboard.Leaderboard, len(rows))
+	for i, row := range rows {
+		leaderboards[i] = sqlcLeaderboardToDomain(row)
+	}
+
+	return leaderboards, nil
+}
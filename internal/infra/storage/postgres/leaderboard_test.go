@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderboard(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = newTestConnection(t)
+		gameID = uuid.NewString()
+	)
+
+	data := leaderboard.NewLeaderboardData{
+		GameID:          gameID,
+		Name:            "Weekly",
+		Description:     "Best scores of the week",
+		StartAt:         time.Now().UTC().Truncate(time.Second),
+		EndAt:           time.Now().UTC().Add(7 * 24 * time.Hour).Truncate(time.Second),
+		AggregationMode: leaderboard.AggregationModeMax,
+		Ordering:        leaderboard.OrderingDesc,
+	}
+
+	lb, err := conn.CreateLeaderboard(ctx, data)
+	assert.NoError(t, err)
+
+	t.Run("Created", func(t *testing.T) {
+		assert.NotEmpty(t, lb.ID)
+		assert.Equal(t, data.Name, lb.Name)
+		assert.Equal(t, data.AggregationMode, lb.AggregationMode)
+		assert.True(t, data.EndAt.Equal(lb.EndAt))
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		stored, err := conn.GetLeaderboardByIDAndGameID(ctx, lb.ID, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, lb.ID, stored.ID)
+		assert.Equal(t, lb.Ordering, stored.Ordering)
+	})
+
+	t.Run("Get From Another Game", func(t *testing.T) {
+		_, err := conn.GetLeaderboardByIDAndGameID(ctx, lb.ID, uuid.NewString())
+		assert.ErrorIs(t, err, leaderboard.ErrLeaderboardNotFound)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		_, err := conn.GetLeaderboardByIDAndGameID(ctx, "not an uuid", gameID)
+		assert.ErrorIs(t, err, leaderboard.ErrInvalidLeaderboardID)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		leaderboards, err := conn.ListLeaderboards(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Len(t, leaderboards, 1)
+		assert.Equal(t, lb.ID, leaderboards[0].ID)
+	})
+
+	t.Run("Soft Delete", func(t *testing.T) {
+		assert.NoError(t, conn.SoftDeleteLeaderboard(ctx, lb.ID, gameID))
+
+		_, err := conn.GetLeaderboardByIDAndGameID(ctx, lb.ID, gameID)
+		assert.ErrorIs(t, err, leaderboard.ErrLeaderboardNotFound)
+
+		assert.ErrorIs(t, conn.SoftDeleteLeaderboard(ctx, lb.ID, gameID), leaderboard.ErrLeaderboardNotFound)
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		records, err := conn.PurgeSoftDeletedLeaderboards(ctx, gameID, time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Len(t, records, 1)
+	})
+}
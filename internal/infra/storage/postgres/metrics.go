@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	// Every generated query starts with its name, e.g. `-- name: GetQuestByIDAndGameID :one`
+	queryNameRegex = regexp.MustCompile(`^-- name: (\w+)`)
+	// First table read or written by the query
+	queryTableRegex = regexp.MustCompile(`(?i)\b(?:from|into|update|join)\s+"?(\w+)"?`)
+)
+
+// The generated queries are labelled by their name, other statements (e.g. `BEGIN`) by their first keyword
+func queryOperation(sql string) string {
+	if match := queryNameRegex.FindStringSubmatch(sql); match != nil {
+		return match[1]
+	}
+
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
+func queryTable(sql string) string {
+	if match := queryTableRegex.FindStringSubmatch(sql); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
+
+type metricsFinishKey struct{}
+
+// Records every query sent to the server
+type metricsTracer struct {
+	metrics *metrics.Storage
+}
+
+func (t metricsTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	finish := t.metrics.Observe("postgres", queryOperation(data.SQL), queryTable(data.SQL))
+	return context.WithValue(ctx, metricsFinishKey{}, finish)
+}
+
+func (t metricsTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	if finish, ok := ctx.Value(metricsFinishKey{}).(metrics.FinishFunc); ok {
+		finish(data.Err)
+	}
+}
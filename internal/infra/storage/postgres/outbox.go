@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres/internal/sqlc"
+	"github.com/gabapcia/gameblitz/internal/outbox"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Records the event on the outbox. Must be called with the queries of the transaction
+// that applies the state change, so the event is only kept if the change is committed
+func recordOutboxEvent(ctx context.Context, queries *sqlc.Queries, event outbox.Event) error {
+	return queries.CreateOutboxEvent(ctx, sqlc.CreateOutboxEventParams{
+		CreatedAt: pgtype.Timestamptz{Time: event.CreatedAt, Valid: true},
+		GameID:    event.GameID,
+		Kind:      event.Kind,
+		Payload:   event.Payload,
+	})
+}
+
+func (c connection) ListPendingOutboxEvents(ctx context.Context, limit int) ([]outbox.Event, error) {
+	eventsData, err := c.queries.ListPendingOutboxEvents(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]outbox.Event, len(eventsData))
+	for i, event := range eventsData {
+		events[i] = outbox.Event{
+			CreatedAt: event.CreatedAt.Time,
+			ID:        event.ID.String(),
+			GameID:    event.GameID,
+			Kind:      event.Kind,
+			Payload:   event.Payload,
+		}
+	}
+
+	return events, nil
+}
+
+func (c connection) DeleteOutboxEvent(ctx context.Context, id string) error {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return outbox.ErrInvalidEventID
+	}
+
+	return c.queries.DeleteOutboxEvent(ctx, uid)
+}
+
+func (c connection) ArchiveOutboxEvent(ctx context.Context, event outbox.Event) error {
+	uid, err := uuid.Parse(event.ID)
+	if err != nil {
+		return outbox.ErrInvalidEventID
+	}
+
+	return c.queries.ArchiveOutboxEvent(ctx, sqlc.ArchiveOutboxEventParams{
+		CreatedAt: pgtype.Timestamptz{Time: event.CreatedAt, Valid: true},
+		ID:        uid,
+		GameID:    event.GameID,
+		Kind:      event.Kind,
+		Payload:   event.Payload,
+	})
+}
+
+func (c connection) ListArchivedOutboxEvents(ctx context.Context, filter outbox.ReplayFilter, after outbox.Event, limit int) ([]outbox.Event, error) {
+	// The zero `after` event sorts before every archived event
+	afterID := uuid.Nil
+	if after.ID != "" {
+		uid, err := uuid.Parse(after.ID)
+		if err != nil {
+			return nil, outbox.ErrInvalidEventID
+		}
+
+		afterID = uid
+	}
+
+	eventsData, err := c.queries.ListArchivedOutboxEvents(ctx, sqlc.ListArchivedOutboxEventsParams{
+		GameID:         filter.GameID,
+		FromCreatedAt:  pgtype.Timestamptz{Time: filter.From, Valid: true},
+		ToCreatedAt:    pgtype.Timestamptz{Time: filter.To, Valid: true},
+		AfterCreatedAt: pgtype.Timestamptz{Time: after.CreatedAt, Valid: true},
+		AfterID:        afterID,
+		RowLimit:       int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]outbox.Event, len(eventsData))
+	for i, event := range eventsData {
+		events[i] = outbox.Event{
+			CreatedAt: event.CreatedAt.Time,
+			ID:        event.ID.String(),
+			GameID:    event.GameID,
+			Kind:      event.Kind,
+			Payload:   event.Payload,
+		}
+	}
+
+	return events, nil
+}
+
+// Erases the player's events, both the ones still pending on the outbox and the archived ones.
+// Pending events are dropped without being relayed
+func (c connection) ErasePlayerEvents(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+	defer tx.Rollback(context.Background())
+
+	queries := c.queries.WithTx(tx)
+
+	pending, err := queries.ErasePlayerOutboxEvents(ctx, sqlc.ErasePlayerOutboxEventsParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	archived, err := queries.ErasePlayerArchivedEvents(ctx, sqlc.ErasePlayerArchivedEventsParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataEventHistory, Records: pending + archived}, nil
+}
+
+// Replaces the player ID on the player's events, both the ones still pending on the outbox and the archived ones.
+// Pending events are relayed later referring to the pseudonym
+func (c connection) AnonymizePlayerEvents(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+	defer tx.Rollback(context.Background())
+
+	queries := c.queries.WithTx(tx)
+
+	pending, err := queries.AnonymizePlayerOutboxEvents(ctx, sqlc.AnonymizePlayerOutboxEventsParams{Pseudonym: pseudonym, GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	archived, err := queries.AnonymizePlayerArchivedEvents(ctx, sqlc.AnonymizePlayerArchivedEventsParams{Pseudonym: pseudonym, GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataEventHistory, Records: pending + archived}, nil
+}
+
+// Counts the player's events, both the ones still pending on the outbox and the archived ones
+func (c connection) CountPlayerEvents(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.queries.CountPlayerEvents(ctx, sqlc.CountPlayerEventsParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataEventHistory, Records: records}, nil
+}
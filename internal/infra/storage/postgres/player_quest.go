@@ -3,12 +3,17 @@ package postgres
 import (
 	"context"
 	"errors"
+	"slices"
 
+	"github.com/gabapcia/gameblitz/internal/backup"
 	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres/internal/sqlc"
+	"github.com/gabapcia/gameblitz/internal/outbox"
+	"github.com/gabapcia/gameblitz/internal/privacy"
 	"github.com/gabapcia/gameblitz/internal/quest"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func sqcStartQuestForPlayerDataToDomain(pq sqlc.PlayerQuest, q quest.Quest, ts []sqlc.StartPlayerTasksForQuestRow) quest.PlayerQuestProgression {
@@ -91,13 +96,8 @@ func (c connection) StartQuestForPlayer(ctx context.Context, q quest.Quest, play
 	return sqcStartQuestForPlayerDataToDomain(playerQuestData, q, playerQuestTasksData), nil
 }
 
-func (c connection) GetPlayerQuestProgression(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error) {
-	questID, err := uuid.Parse(q.ID)
-	if err != nil {
-		return quest.PlayerQuestProgression{}, quest.ErrInvalidQuestID
-	}
-
-	playerQuestData, err := c.queries.GetPlayerQuest(ctx, sqlc.GetPlayerQuestParams{
+func getPlayerQuestProgression(ctx context.Context, queries *sqlc.Queries, q quest.Quest, questID uuid.UUID, playerID string) (quest.PlayerQuestProgression, error) {
+	playerQuestData, err := queries.GetPlayerQuest(ctx, sqlc.GetPlayerQuestParams{
 		PlayerID: playerID,
 		QuestID:  questID,
 	})
@@ -109,7 +109,7 @@ func (c connection) GetPlayerQuestProgression(ctx context.Context, q quest.Quest
 		return quest.PlayerQuestProgression{}, err
 	}
 
-	playerTasksData, err := c.queries.GetPlayerQuestTasks(ctx, sqlc.GetPlayerQuestTasksParams{
+	playerTasksData, err := queries.GetPlayerQuestTasks(ctx, sqlc.GetPlayerQuestTasksParams{
 		PlayerID: playerID,
 		QuestID:  playerQuestData.QuestID,
 	})
@@ -120,6 +120,15 @@ func (c connection) GetPlayerQuestProgression(ctx context.Context, q quest.Quest
 	return sqlcGetPlayerQuestDataToDomain(playerQuestData, q, playerTasksData), nil
 }
 
+func (c connection) GetPlayerQuestProgression(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error) {
+	questID, err := uuid.Parse(q.ID)
+	if err != nil {
+		return quest.PlayerQuestProgression{}, quest.ErrInvalidQuestID
+	}
+
+	return getPlayerQuestProgression(ctx, c.queries, q, questID, playerID)
+}
+
 func (c connection) UpdatePlayerQuestProgression(ctx context.Context, q quest.Quest, tc []string, playerID string) (quest.PlayerQuestProgression, error) {
 	questID, err := uuid.Parse(q.ID)
 	if err != nil {
@@ -168,9 +177,191 @@ func (c connection) UpdatePlayerQuestProgression(ctx context.Context, q quest.Qu
 		return quest.PlayerQuestProgression{}, err
 	}
 
-	if err = tx.Commit(ctx); err != nil {
+	progression, err := getPlayerQuestProgression(ctx, queries, q, questID, playerID)
+	if err != nil {
 		return quest.PlayerQuestProgression{}, err
 	}
 
-	return c.GetPlayerQuestProgression(ctx, q, playerID)
+	if c.outbox {
+		event, err := outbox.NewPlayerQuestProgressionEvent(progression)
+		if err != nil {
+			return quest.PlayerQuestProgression{}, err
+		}
+
+		if err := recordOutboxEvent(ctx, queries, event); err != nil {
+			return quest.PlayerQuestProgression{}, err
+		}
+	}
+
+	return progression, tx.Commit(ctx)
+}
+
+func (c connection) ListPlayerQuests(ctx context.Context, q quest.Quest) ([]quest.PlayerQuestProgression, error) {
+	questID, err := uuid.Parse(q.ID)
+	if err != nil {
+		return nil, quest.ErrInvalidQuestID
+	}
+
+	playerQuestsData, err := c.queries.ListPlayerQuestsByQuestID(ctx, questID)
+	if err != nil {
+		return nil, err
+	}
+
+	playerTasksData, err := c.queries.ListPlayerQuestTasksByQuestID(ctx, questID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make(map[string]quest.Task, len(q.Tasks))
+	for _, task := range q.Tasks {
+		tasks[task.ID] = task
+	}
+
+	tasksProgression := make(map[uuid.UUID][]quest.PlayerTaskProgression)
+	for _, t := range playerTasksData {
+		tasksProgression[t.PlayerQuestID] = append(tasksProgression[t.PlayerQuestID], quest.PlayerTaskProgression{
+			StartedAt:   t.StartedAt.Time,
+			UpdatedAt:   t.UpdatedAt.Time,
+			Task:        tasks[t.TaskID.String()],
+			CompletedAt: t.CompletedAt.Time,
+		})
+	}
+
+	progressions := make([]quest.PlayerQuestProgression, len(playerQuestsData))
+	for i, pq := range playerQuestsData {
+		progressions[i] = quest.PlayerQuestProgression{
+			StartedAt:        pq.StartedAt.Time,
+			UpdatedAt:        pq.UpdatedAt.Time,
+			PlayerID:         pq.PlayerID,
+			Quest:            q,
+			CompletedAt:      pq.CompletedAt.Time,
+			TasksProgression: tasksProgression[pq.ID],
+		}
+	}
+
+	return progressions, nil
+}
+
+func (c connection) ListPlayersWithQuestUnfinished(ctx context.Context, q quest.Quest) ([]string, error) {
+	questID, err := uuid.Parse(q.ID)
+	if err != nil {
+		return nil, quest.ErrInvalidQuestID
+	}
+
+	return c.queries.ListPlayersWithQuestUnfinished(ctx, questID)
+}
+
+// Stores the player quest and tasks progression as is. A task can only be stored after the tasks it depends on
+// were completed, so the completed tasks are stored first, in the order they were completed
+func (c connection) RestorePlayerQuest(ctx context.Context, progression quest.PlayerQuestProgression) error {
+	questID, err := uuid.Parse(progression.Quest.ID)
+	if err != nil {
+		return quest.ErrInvalidQuestID
+	}
+
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	queries := c.queries.WithTx(tx)
+
+	playerQuestID, err := queries.RestorePlayerQuest(ctx, sqlc.RestorePlayerQuestParams{
+		StartedAt:   pgtype.Timestamptz{Time: progression.StartedAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: progression.UpdatedAt, Valid: true},
+		PlayerID:    progression.PlayerID,
+		QuestID:     questID,
+		CompletedAt: pgtype.Timestamptz{Time: progression.CompletedAt, Valid: !progression.CompletedAt.IsZero()},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = backup.ErrEntityAlreadyExists
+		}
+
+		return err
+	}
+
+	tasksProgression := slices.Clone(progression.TasksProgression)
+	slices.SortStableFunc(tasksProgression, func(a, b quest.PlayerTaskProgression) int {
+		switch {
+		case a.CompletedAt.IsZero() && b.CompletedAt.IsZero():
+			return 0
+		case a.CompletedAt.IsZero():
+			return 1
+		case b.CompletedAt.IsZero():
+			return -1
+		default:
+			return a.CompletedAt.Compare(b.CompletedAt)
+		}
+	})
+
+	for _, taskProgression := range tasksProgression {
+		taskID, err := uuid.Parse(taskProgression.Task.ID)
+		if err != nil {
+			return quest.ErrInvalidTaskID
+		}
+
+		err = queries.RestorePlayerQuestTask(ctx, sqlc.RestorePlayerQuestTaskParams{
+			StartedAt:     pgtype.Timestamptz{Time: taskProgression.StartedAt, Valid: true},
+			UpdatedAt:     pgtype.Timestamptz{Time: taskProgression.UpdatedAt, Valid: true},
+			PlayerID:      progression.PlayerID,
+			PlayerQuestID: playerQuestID,
+			TaskID:        taskID,
+			CompletedAt:   pgtype.Timestamptz{Time: taskProgression.CompletedAt, Valid: !taskProgression.CompletedAt.IsZero()},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Erases the player's progression on every quest of the game, including the soft deleted ones.
+// The tasks progression is removed alongside the quest progression
+func (c connection) ErasePlayerQuests(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	records, err := c.queries.ErasePlayerQuests(ctx, sqlc.ErasePlayerQuestsParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataQuestProgression, Records: records}, nil
+}
+
+// Moves the player's progression on every quest of the game, including the soft deleted ones, to the pseudonym.
+// The tasks progression is moved alongside the quest progression, but only the quests are counted
+func (c connection) AnonymizePlayerQuests(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+	defer tx.Rollback(context.Background())
+
+	queries := c.queries.WithTx(tx)
+
+	if _, err := queries.AnonymizePlayerQuestTasks(ctx, sqlc.AnonymizePlayerQuestTasksParams{Pseudonym: pseudonym, GameID: gameID, PlayerID: playerID}); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	records, err := queries.AnonymizePlayerQuests(ctx, sqlc.AnonymizePlayerQuestsParams{Pseudonym: pseudonym, GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataQuestProgression, Records: records}, nil
+}
+
+// Counts the player's progression on every quest of the game, including the soft deleted ones. Only the quests are counted
+func (c connection) CountPlayerQuests(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.queries.CountPlayerQuests(ctx, sqlc.CountPlayerQuestsParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataQuestProgression, Records: records}, nil
 }
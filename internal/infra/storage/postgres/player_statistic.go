@@ -0,0 +1,307 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gabapcia/gameblitz/internal/backup"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres/internal/sqlc"
+	"github.com/gabapcia/gameblitz/internal/outbox"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func sqlcPlayerStatisticToDomain(ps sqlc.PlayerStatistic, ls []sqlc.PlayerStatisticLandmark) statistic.PlayerProgression {
+	var goalCompleted *bool
+	if ps.GoalValue.Valid {
+		completed := ps.GoalCompletedAt.Valid
+		goalCompleted = &completed
+	}
+
+	landmarks := make([]statistic.PlayerProgressionLandmark, len(ls))
+	for i, l := range ls {
+		landmarks[i] = statistic.PlayerProgressionLandmark{
+			Value:       l.Value,
+			Completed:   l.CompletedAt.Valid,
+			CompletedAt: l.CompletedAt.Time,
+		}
+	}
+
+	return statistic.PlayerProgression{
+		StartedAt:       ps.StartedAt.Time,
+		UpdatedAt:       ps.UpdatedAt.Time,
+		PlayerID:        ps.PlayerID,
+		StatisticID:     ps.StatisticID.String(),
+		CurrentValue:    float8ToPointer(ps.CurrentValue),
+		GoalValue:       float8ToPointer(ps.GoalValue),
+		GoalCompleted:   goalCompleted,
+		GoalCompletedAt: ps.GoalCompletedAt.Time,
+		Landmarks:       landmarks,
+	}
+}
+
+func createPlayerStatisticProgression(ctx context.Context, queries *sqlc.Queries, st statistic.Statistic, statisticID uuid.UUID, playerID string) error {
+	err := queries.CreatePlayerStatistic(ctx, sqlc.CreatePlayerStatisticParams{
+		PlayerID:     playerID,
+		StatisticID:  statisticID,
+		CurrentValue: pointerToFloat8(st.InitialValue),
+		GoalValue:    pointerToFloat8(st.Goal),
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(st.Landmarks) == 0 {
+		return nil
+	}
+
+	return queries.CreatePlayerStatisticLandmarks(ctx, sqlc.CreatePlayerStatisticLandmarksParams{
+		PlayerID:    playerID,
+		StatisticID: statisticID,
+		Landmarks:   st.Landmarks,
+	})
+}
+
+func (c connection) UpdatePlayerStatisticProgression(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+	statisticID, err := uuid.Parse(st.ID)
+	if err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, statistic.ErrInvalidStatisticID
+	}
+
+	var ascending bool
+	switch st.AggregationMode {
+	case statistic.AggregationModeSum, statistic.AggregationModeMax:
+		ascending = true
+	case statistic.AggregationModeSub, statistic.AggregationModeMin:
+		ascending = false
+	default:
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, statistic.ErrInvalidAggregationMode
+	}
+
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+	}
+	defer tx.Rollback(context.Background())
+
+	queries := c.queries.WithTx(tx)
+
+	if err := createPlayerStatisticProgression(ctx, queries, st, statisticID, playerID); err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+	}
+
+	_, err = queries.UpdatePlayerStatisticValue(ctx, sqlc.UpdatePlayerStatisticValueParams{
+		PlayerID:        playerID,
+		StatisticID:     statisticID,
+		AggregationMode: st.AggregationMode,
+		Value:           value,
+	})
+	if err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+	}
+
+	goalsCompleted, err := queries.MarkPlayerStatisticGoalAsCompleted(ctx, sqlc.MarkPlayerStatisticGoalAsCompletedParams{
+		PlayerID:    playerID,
+		StatisticID: statisticID,
+		Ascending:   ascending,
+	})
+	if err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+	}
+
+	landmarksCompleted, err := queries.MarkPlayerStatisticLandmarksAsCompleted(ctx, sqlc.MarkPlayerStatisticLandmarksAsCompletedParams{
+		PlayerID:    playerID,
+		StatisticID: statisticID,
+		Ascending:   ascending,
+	})
+	if err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+	}
+
+	progression, err := getPlayerStatisticProgression(ctx, queries, statisticID, playerID)
+	if err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+	}
+
+	landmarksJustCompleted := make([]statistic.PlayerProgressionUpdatesLandmark, len(landmarksCompleted))
+	for i, landmark := range landmarksCompleted {
+		landmarksJustCompleted[i] = statistic.PlayerProgressionUpdatesLandmark{
+			Value:       landmark.Value,
+			CompletedAt: landmark.CompletedAt.Time,
+		}
+	}
+
+	updates := statistic.PlayerProgressionUpdates{
+		GoalJustCompleted:      goalsCompleted > 0,
+		GoalCompletedAt:        progression.GoalCompletedAt,
+		LandmarksJustCompleted: landmarksJustCompleted,
+	}
+
+	if c.outbox && updates.HasCompletions() {
+		event, err := outbox.NewPlayerStatisticProgressionEvent(st, progression, updates)
+		if err != nil {
+			return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+		}
+
+		if err := recordOutboxEvent(ctx, queries, event); err != nil {
+			return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+		}
+	}
+
+	return progression, updates, tx.Commit(ctx)
+}
+
+func getPlayerStatisticProgression(ctx context.Context, queries *sqlc.Queries, statisticID uuid.UUID, playerID string) (statistic.PlayerProgression, error) {
+	progressionData, err := queries.GetPlayerStatistic(ctx, sqlc.GetPlayerStatisticParams{
+		PlayerID:    playerID,
+		StatisticID: statisticID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = statistic.ErrPlayerStatisticNotFound
+		}
+
+		return statistic.PlayerProgression{}, err
+	}
+
+	landmarksData, err := queries.ListPlayerStatisticLandmarks(ctx, sqlc.ListPlayerStatisticLandmarksParams{
+		PlayerID:    playerID,
+		StatisticID: statisticID,
+	})
+	if err != nil {
+		return statistic.PlayerProgression{}, err
+	}
+
+	return sqlcPlayerStatisticToDomain(progressionData, landmarksData), nil
+}
+
+func (c connection) GetPlayerProgression(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+	uid, err := uuid.Parse(statisticID)
+	if err != nil {
+		return statistic.PlayerProgression{}, statistic.ErrInvalidStatisticID
+	}
+
+	return getPlayerStatisticProgression(ctx, c.queries, uid, playerID)
+}
+
+func (c connection) ListPlayerStatistics(ctx context.Context, statisticID string) ([]statistic.PlayerProgression, error) {
+	uid, err := uuid.Parse(statisticID)
+	if err != nil {
+		return nil, statistic.ErrInvalidStatisticID
+	}
+
+	progressionsData, err := c.queries.ListPlayerStatisticsByStatisticID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	landmarksData, err := c.queries.ListPlayerStatisticLandmarksByStatisticID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	landmarksByPlayer := make(map[string][]sqlc.PlayerStatisticLandmark)
+	for _, landmark := range landmarksData {
+		landmarksByPlayer[landmark.PlayerID] = append(landmarksByPlayer[landmark.PlayerID], landmark)
+	}
+
+	progressions := make([]statistic.PlayerProgression, len(progressionsData))
+	for i, progression := range progressionsData {
+		progressions[i] = sqlcPlayerStatisticToDomain(progression, landmarksByPlayer[progression.PlayerID])
+	}
+
+	return progressions, nil
+}
+
+func (c connection) RestorePlayerStatistic(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression) error {
+	statisticID, err := uuid.Parse(st.ID)
+	if err != nil {
+		return statistic.ErrInvalidStatisticID
+	}
+
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	queries := c.queries.WithTx(tx)
+
+	affected, err := queries.RestorePlayerStatistic(ctx, sqlc.RestorePlayerStatisticParams{
+		StartedAt:       pgtype.Timestamptz{Time: progression.StartedAt, Valid: !progression.StartedAt.IsZero()},
+		UpdatedAt:       pgtype.Timestamptz{Time: progression.UpdatedAt, Valid: !progression.UpdatedAt.IsZero()},
+		PlayerID:        progression.PlayerID,
+		StatisticID:     statisticID,
+		CurrentValue:    pointerToFloat8(progression.CurrentValue),
+		GoalValue:       pointerToFloat8(progression.GoalValue),
+		GoalCompletedAt: pgtype.Timestamptz{Time: progression.GoalCompletedAt, Valid: !progression.GoalCompletedAt.IsZero()},
+	})
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return backup.ErrEntityAlreadyExists
+	}
+
+	for _, landmark := range progression.Landmarks {
+		err := queries.RestorePlayerStatisticLandmark(ctx, sqlc.RestorePlayerStatisticLandmarkParams{
+			PlayerID:    progression.PlayerID,
+			StatisticID: statisticID,
+			Value:       landmark.Value,
+			CompletedAt: pgtype.Timestamptz{Time: landmark.CompletedAt, Valid: landmark.Completed},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Removes the player's progression on the statistic, alongside the landmarks reached. Nothing is removed when the
+// player has none
+func (c connection) DeletePlayerProgression(ctx context.Context, statisticID, playerID string) error {
+	uid, err := uuid.Parse(statisticID)
+	if err != nil {
+		return statistic.ErrInvalidStatisticID
+	}
+
+	return c.queries.DeletePlayerStatistic(ctx, sqlc.DeletePlayerStatisticParams{PlayerID: playerID, StatisticID: uid})
+}
+
+// Erases the player's progression on every statistic of the game, including the soft deleted ones.
+// The landmarks reached are removed alongside the progression
+func (c connection) ErasePlayerStatistics(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	records, err := c.queries.ErasePlayerStatistics(ctx, sqlc.ErasePlayerStatisticsParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataStatisticProgression, Records: records}, nil
+}
+
+// Moves the player's progression on every statistic of the game, including the soft deleted ones, to the pseudonym.
+// The landmarks progression follows it through the update cascade of their foreign key
+func (c connection) AnonymizePlayerStatistics(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	records, err := c.queries.AnonymizePlayerStatistics(ctx, sqlc.AnonymizePlayerStatisticsParams{Pseudonym: pseudonym, GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataStatisticProgression, Records: records}, nil
+}
+
+// Counts the player's progression on every statistic of the game, including the soft deleted ones
+func (c connection) CountPlayerStatistics(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.queries.CountPlayerStatistics(ctx, sqlc.CountPlayerStatisticsParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataStatisticProgression, Records: records}, nil
+}
@@ -3,12 +3,16 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/gabapcia/gameblitz/internal/backup"
 	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres/internal/sqlc"
+	"github.com/gabapcia/gameblitz/internal/purge"
 	"github.com/gabapcia/gameblitz/internal/quest"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func sqlcQuestWithTaskViewToDomain(q sqlc.Quest, ts []sqlc.TasksWithItsDependency) quest.Quest {
@@ -26,6 +30,7 @@ func sqlcQuestWithTaskViewToDomain(q sqlc.Quest, ts []sqlc.TasksWithItsDependenc
 		Name:        q.Name,
 		Description: q.Description,
 		Tasks:       tasks,
+		EndAt:       q.EndAt.Time,
 	}
 }
 
@@ -44,6 +49,7 @@ func sqlcQuestToDomain(q sqlc.Quest, ts map[sqlc.Task][]uuid.UUID) quest.Quest {
 		Name:        q.Name,
 		Description: q.Description,
 		Tasks:       tasks,
+		EndAt:       q.EndAt.Time,
 	}
 }
 
@@ -60,6 +66,7 @@ func (c connection) CreateQuest(ctx context.Context, data quest.NewQuestData) (q
 		GameID:      data.GameID,
 		Name:        data.Name,
 		Description: data.Description,
+		EndAt:       pgtype.Timestamptz{Time: data.EndAt, Valid: !data.EndAt.IsZero()},
 	})
 	if err != nil {
 		return quest.Quest{}, err
@@ -131,3 +138,127 @@ func (c connection) SoftDeleteQuestByIDAndGameID(ctx context.Context, id, gameID
 
 	return tx.Commit(ctx)
 }
+
+func (c connection) PurgeSoftDeletedQuests(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	rows, err := c.queries.PurgeSoftDeletedQuests(ctx, sqlc.PurgeSoftDeletedQuestsParams{
+		DeletedBefore: pgtype.Timestamptz{Time: deletedBefore, Valid: true},
+		GameID:        gameID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, len(rows))
+	for i, row := range rows {
+		records[i] = purge.Record{
+			DeletedAt: row.DeletedAt.Time,
+			Entity:    purge.EntityQuest,
+			ID:        row.ID.String(),
+			GameID:    row.GameID,
+		}
+	}
+
+	return records, nil
+}
+
+func (c connection) ListSoftDeletedQuests(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	rows, err := c.queries.ListSoftDeletedQuests(ctx, sqlc.ListSoftDeletedQuestsParams{
+		DeletedBefore: pgtype.Timestamptz{Time: deletedBefore, Valid: true},
+		GameID:        gameID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, len(rows))
+	for i, row := range rows {
+		records[i] = purge.Record{
+			DeletedAt: row.DeletedAt.Time,
+			Entity:    purge.EntityQuest,
+			ID:        row.ID.String(),
+			GameID:    row.GameID,
+		}
+	}
+
+	return records, nil
+}
+
+func (c connection) ListQuests(ctx context.Context, gameID string) ([]quest.Quest, error) {
+	rows, err := c.queries.ListQuestsByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	quests := make([]quest.Quest, len(rows))
+	for i, row := range rows {
+		tasksData, err := c.queries.ListTasksByQuestID(ctx, row.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		quests[i] = sqlcQuestWithTaskViewToDomain(row, tasksData)
+	}
+
+	return quests, nil
+}
+
+func (c connection) ListQuestsEndedBetween(ctx context.Context, from, to time.Time) ([]quest.Quest, error) {
+	rows, err := c.queries.ListQuestsEndedBetween(ctx, sqlc.ListQuestsEndedBetweenParams{
+		EndedFrom: pgtype.Timestamptz{Time: from, Valid: true},
+		EndedTo:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	quests := make([]quest.Quest, len(rows))
+	for i, row := range rows {
+		tasksData, err := c.queries.ListTasksByQuestID(ctx, row.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		quests[i] = sqlcQuestWithTaskViewToDomain(row, tasksData)
+	}
+
+	return quests, nil
+}
+
+// Stores the quest and its tasks keeping their IDs. The tasks are created before their dependencies are registered
+func (c connection) RestoreQuest(ctx context.Context, q quest.Quest) error {
+	questID, err := uuid.Parse(q.ID)
+	if err != nil {
+		return quest.ErrInvalidQuestID
+	}
+
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	queries := c.queries.WithTx(tx)
+
+	affected, err := queries.RestoreQuest(ctx, sqlc.RestoreQuestParams{
+		CreatedAt:   pgtype.Timestamptz{Time: q.CreatedAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: q.UpdatedAt, Valid: true},
+		ID:          questID,
+		GameID:      q.GameID,
+		Name:        q.Name,
+		Description: q.Description,
+		EndAt:       pgtype.Timestamptz{Time: q.EndAt, Valid: !q.EndAt.IsZero()},
+	})
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return backup.ErrEntityAlreadyExists
+	}
+
+	if err := restoreQuestTasks(ctx, queries, questID, q.Tasks); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres/internal/sqlc"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (c connection) UpsertPlayerRankValue(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+	leaderboardID, err := uuid.Parse(lb.ID)
+	if err != nil {
+		return leaderboard.ErrInvalidLeaderboardID
+	}
+
+	var upsertFunc func(ctx context.Context, arg sqlc.IncrementPlayerRankValueParams) error
+	switch lb.AggregationMode {
+	case leaderboard.AggregationModeInc:
+		upsertFunc = c.queries.IncrementPlayerRankValue
+	case leaderboard.AggregationModeMax:
+		upsertFunc = func(ctx context.Context, arg sqlc.IncrementPlayerRankValueParams) error {
+			return c.queries.SetMaxPlayerRankValue(ctx, sqlc.SetMaxPlayerRankValueParams(arg))
+		}
+	case leaderboard.AggregationModeMin:
+		upsertFunc = func(ctx context.Context, arg sqlc.IncrementPlayerRankValueParams) error {
+			return c.queries.SetMinPlayerRankValue(ctx, sqlc.SetMinPlayerRankValueParams(arg))
+		}
+	default:
+		return leaderboard.ErrInvalidAggregationMode
+	}
+
+	return upsertFunc(ctx, sqlc.IncrementPlayerRankValueParams{
+		LeaderboardID: leaderboardID,
+		PlayerID:      playerID,
+		Value:         value,
+	})
+}
+
+func (c connection) GetRanking(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+	uid, err := uuid.Parse(lb.ID)
+	if err != nil {
+		return nil, leaderboard.ErrInvalidLeaderboardID
+	}
+
+	var data []sqlc.GetRankingAscRow
+	switch lb.Ordering {
+	case leaderboard.OrderingAsc:
+		data, err = c.queries.GetRankingAsc(ctx, sqlc.GetRankingAscParams{
+			LeaderboardID: uid,
+			Limit:         int32(limit),
+			Offset:        int32(page * limit),
+		})
+	case leaderboard.OrderingDesc:
+		var rows []sqlc.GetRankingDescRow
+		rows, err = c.queries.GetRankingDesc(ctx, sqlc.GetRankingDescParams{
+			LeaderboardID: uid,
+			Limit:         int32(limit),
+			Offset:        int32(page * limit),
+		})
+		for _, row := range rows {
+			data = append(data, sqlc.GetRankingAscRow(row))
+		}
+	default:
+		return nil, leaderboard.ErrInvalidOrdering
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ranking := make([]leaderboard.Rank, len(data))
+	for i, d := range data {
+		ranking[i] = leaderboard.Rank{
+			LeaderboardID: lb.ID,
+			PlayerID:      d.PlayerID,
+			Position:      page*limit + int64(i),
+			Value:         d.Value,
+		}
+	}
+
+	return ranking, nil
+}
+
+func (c connection) GetPlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+	uid, err := uuid.Parse(lb.ID)
+	if err != nil {
+		return leaderboard.Rank{}, leaderboard.ErrInvalidLeaderboardID
+	}
+
+	var data sqlc.GetPlayerRankAscRow
+	switch lb.Ordering {
+	case leaderboard.OrderingAsc:
+		data, err = c.queries.GetPlayerRankAsc(ctx, sqlc.GetPlayerRankAscParams{LeaderboardID: uid, PlayerID: playerID})
+	case leaderboard.OrderingDesc:
+		var row sqlc.GetPlayerRankDescRow
+		row, err = c.queries.GetPlayerRankDesc(ctx, sqlc.GetPlayerRankDescParams{LeaderboardID: uid, PlayerID: playerID})
+		data = sqlc.GetPlayerRankAscRow(row)
+	default:
+		return leaderboard.Rank{}, leaderboard.ErrInvalidOrdering
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = leaderboard.ErrPlayerRankNotFound
+		}
+
+		return leaderboard.Rank{}, err
+	}
+
+	return leaderboard.Rank{
+		LeaderboardID: lb.ID,
+		PlayerID:      playerID,
+		Position:      data.Position,
+		Value:         data.Value,
+	}, nil
+}
+
+func (c connection) DeletePlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+	leaderboardID, err := uuid.Parse(lb.ID)
+	if err != nil {
+		return leaderboard.ErrInvalidLeaderboardID
+	}
+
+	return c.queries.DeletePlayerRank(ctx, sqlc.DeletePlayerRankParams{LeaderboardID: leaderboardID, PlayerID: playerID})
+}
+
+// The ranking is deleted and the rebuilt ranks inserted on the same transaction,
+// so the ranking is never read half replaced
+func (c connection) ReplaceRanking(ctx context.Context, lb leaderboard.Leaderboard, ranks []leaderboard.RebuiltRank) error {
+	leaderboardID, err := uuid.Parse(lb.ID)
+	if err != nil {
+		return leaderboard.ErrInvalidLeaderboardID
+	}
+
+	params := sqlc.CreateRebuiltRanksParams{
+		LeaderboardID: leaderboardID,
+		PlayerIds:     make([]string, len(ranks)),
+		RankValues:    make([]float64, len(ranks)),
+		UpdatedAts:    make([]pgtype.Timestamptz, len(ranks)),
+	}
+	for i, rank := range ranks {
+		params.PlayerIds[i] = rank.PlayerID
+		params.RankValues[i] = rank.Value
+		params.UpdatedAts[i] = pgtype.Timestamptz{Time: rank.UpdatedAt, Valid: true}
+	}
+
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	queries := c.queries.WithTx(tx)
+
+	if err := queries.DeleteRanking(ctx, leaderboardID); err != nil {
+		return err
+	}
+
+	if err := queries.CreateRebuiltRanks(ctx, params); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Erases the player's ranks on every leaderboard of the game, including the soft deleted ones
+func (c connection) ErasePlayerRanks(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	records, err := c.queries.ErasePlayerRanks(ctx, sqlc.ErasePlayerRanksParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataRanks, Records: records}, nil
+}
+
+// Moves the player's ranks on every leaderboard of the game, including the soft deleted ones, to the pseudonym
+func (c connection) AnonymizePlayerRanks(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	records, err := c.queries.AnonymizePlayerRanks(ctx, sqlc.AnonymizePlayerRanksParams{Pseudonym: pseudonym, GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataRanks, Records: records}, nil
+}
+
+// Counts the player's ranks on every leaderboard of the game, including the soft deleted ones
+func (c connection) CountPlayerRanks(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.queries.CountPlayerRanks(ctx, sqlc.CountPlayerRanksParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataRanks, Records: records}, nil
+}
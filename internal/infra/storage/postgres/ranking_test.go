@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRanking(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		conn = newTestConnection(t)
+	)
+
+	newLeaderboard := func(t *testing.T, aggregationMode, ordering string) leaderboard.Leaderboard {
+		lb, err := conn.CreateLeaderboard(ctx, leaderboard.NewLeaderboardData{
+			GameID:          uuid.NewString(),
+			Name:            "Ranking",
+			StartAt:         time.Now().UTC(),
+			AggregationMode: aggregationMode,
+			Ordering:        ordering,
+		})
+		assert.NoError(t, err)
+
+		return lb
+	}
+
+	players := func(ranks []leaderboard.Rank) []string {
+		ids := make([]string, len(ranks))
+		for i, rank := range ranks {
+			assert.Equal(t, int64(i), rank.Position)
+			ids[i] = rank.PlayerID
+		}
+
+		return ids
+	}
+
+	t.Run("Max Keeps The Best Value", func(t *testing.T) {
+		lb := newLeaderboard(t, leaderboard.AggregationModeMax, leaderboard.OrderingDesc)
+
+		assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 20))
+		assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 10))
+
+		rank, err := conn.GetPlayerRank(ctx, lb, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, 20.0, rank.Value)
+
+		assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 30))
+
+		rank, err = conn.GetPlayerRank(ctx, lb, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, 30.0, rank.Value)
+	})
+
+	t.Run("Min Keeps The Best Value", func(t *testing.T) {
+		lb := newLeaderboard(t, leaderboard.AggregationModeMin, leaderboard.OrderingAsc)
+
+		assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 20))
+		assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 30))
+
+		rank, err := conn.GetPlayerRank(ctx, lb, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, 20.0, rank.Value)
+	})
+
+	t.Run("Inc Adds Up", func(t *testing.T) {
+		lb := newLeaderboard(t, leaderboard.AggregationModeInc, leaderboard.OrderingDesc)
+
+		assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 20))
+		assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", -5))
+
+		rank, err := conn.GetPlayerRank(ctx, lb, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, 15.0, rank.Value)
+	})
+
+	for _, tc := range []struct {
+		aggregationMode string
+		ordering        string
+		worse           float64
+	}{
+		{aggregationMode: leaderboard.AggregationModeMax, ordering: leaderboard.OrderingDesc, worse: 5},
+		{aggregationMode: leaderboard.AggregationModeMin, ordering: leaderboard.OrderingAsc, worse: 15},
+	} {
+		t.Run("Tie Kept By A Worse "+tc.aggregationMode+" Value", func(t *testing.T) {
+			lb := newLeaderboard(t, tc.aggregationMode, tc.ordering)
+
+			assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 10))
+			assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "bob", 10))
+			assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", tc.worse))
+
+			ranks, err := conn.GetRanking(ctx, lb, 0, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"alice", "bob"}, players(ranks))
+
+			rank, err := conn.GetPlayerRank(ctx, lb, "bob")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), rank.Position)
+		})
+	}
+
+	t.Run("Ranking Pages", func(t *testing.T) {
+		lb := newLeaderboard(t, leaderboard.AggregationModeMax, leaderboard.OrderingAsc)
+		for player, value := range map[string]float64{"a": 10, "b": 30, "c": 20} {
+			assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, player, value))
+		}
+
+		ranks, err := conn.GetRanking(ctx, lb, 0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "c"}, players(ranks))
+
+		ranks, err = conn.GetRanking(ctx, lb, 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []leaderboard.Rank{{LeaderboardID: lb.ID, PlayerID: "b", Position: 2, Value: 30}}, ranks)
+
+		desc := lb
+		desc.Ordering = leaderboard.OrderingDesc
+
+		rank, err := conn.GetPlayerRank(ctx, desc, "a")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), rank.Position)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		lb := newLeaderboard(t, leaderboard.AggregationModeMax, leaderboard.OrderingDesc)
+
+		assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 10))
+		assert.NoError(t, conn.DeletePlayerRank(ctx, lb, "alice"))
+
+		_, err := conn.GetPlayerRank(ctx, lb, "alice")
+		assert.ErrorIs(t, err, leaderboard.ErrPlayerRankNotFound)
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		var (
+			lb  = newLeaderboard(t, leaderboard.AggregationModeMax, leaderboard.OrderingDesc)
+			now = time.Now().UTC()
+		)
+
+		assert.NoError(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 10))
+
+		assert.NoError(t, conn.ReplaceRanking(ctx, lb, []leaderboard.RebuiltRank{
+			{UpdatedAt: now.Add(-time.Minute), PlayerID: "bob", Value: 20},
+			{UpdatedAt: now.Add(-2 * time.Minute), PlayerID: "carol", Value: 20},
+		}))
+
+		ranks, err := conn.GetRanking(ctx, lb, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"carol", "bob"}, players(ranks))
+	})
+
+	t.Run("Invalid Aggregation Mode", func(t *testing.T) {
+		lb := newLeaderboard(t, leaderboard.AggregationModeMax, leaderboard.OrderingDesc)
+		lb.AggregationMode = "INVALID"
+
+		assert.ErrorIs(t, conn.UpsertPlayerRankValue(ctx, lb, "alice", 10), leaderboard.ErrInvalidAggregationMode)
+	})
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres/internal/sqlc"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/moderation"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The score history is append only, scores are only rolled back, or removed and anonymized with their player
+func (c connection) RecordScore(ctx context.Context, score leaderboard.Score) error {
+	leaderboardID, err := uuid.Parse(score.LeaderboardID)
+	if err != nil {
+		return leaderboard.ErrInvalidLeaderboardID
+	}
+
+	return c.queries.RecordScore(ctx, sqlc.RecordScoreParams{
+		SubmittedAt:   pgtype.Timestamptz{Time: score.SubmittedAt, Valid: true},
+		LeaderboardID: leaderboardID,
+		GameID:        score.GameID,
+		PlayerID:      score.PlayerID,
+		Value:         score.Value,
+		Source:        score.Source,
+		DeviceHash:    score.Fingerprint.DeviceHash,
+		BuildVersion:  score.Fingerprint.BuildVersion,
+		Region:        score.Fingerprint.Region,
+	})
+}
+
+func scoreToDomain(score sqlc.LeaderboardScore) leaderboard.Score {
+	return leaderboard.Score{
+		SubmittedAt:   score.SubmittedAt.Time,
+		ID:            score.ID.String(),
+		LeaderboardID: score.LeaderboardID.String(),
+		GameID:        score.GameID,
+		PlayerID:      score.PlayerID,
+		Value:         score.Value,
+		Source:        score.Source,
+		Fingerprint: leaderboard.Fingerprint{
+			DeviceHash:   score.DeviceHash,
+			BuildVersion: score.BuildVersion,
+			Region:       score.Region,
+		},
+	}
+}
+
+// The zero `after` score sorts before every recorded score
+func scoreCursor(after leaderboard.Score) (uuid.UUID, error) {
+	if after.ID == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(after.ID)
+}
+
+func (c connection) ListScores(ctx context.Context, leaderboardID string, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+	uid, err := uuid.Parse(leaderboardID)
+	if err != nil {
+		return nil, leaderboard.ErrInvalidLeaderboardID
+	}
+
+	afterID, err := scoreCursor(after)
+	if err != nil {
+		return nil, err
+	}
+
+	scoresData, err := c.queries.ListScores(ctx, sqlc.ListScoresParams{
+		LeaderboardID:    uid,
+		AfterSubmittedAt: pgtype.Timestamptz{Time: after.SubmittedAt, Valid: true},
+		AfterID:          afterID,
+		RowLimit:         int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]leaderboard.Score, len(scoresData))
+	for i, score := range scoresData {
+		scores[i] = scoreToDomain(score)
+	}
+
+	return scores, nil
+}
+
+func (c connection) ListScoresBySource(ctx context.Context, gameID, source string, from, to time.Time, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+	afterID, err := scoreCursor(after)
+	if err != nil {
+		return nil, err
+	}
+
+	scoresData, err := c.queries.ListScoresBySource(ctx, sqlc.ListScoresBySourceParams{
+		GameID:           gameID,
+		Source:           source,
+		SubmittedFrom:    pgtype.Timestamptz{Time: from, Valid: true},
+		SubmittedTo:      pgtype.Timestamptz{Time: to, Valid: true},
+		AfterSubmittedAt: pgtype.Timestamptz{Time: after.SubmittedAt, Valid: true},
+		AfterID:          afterID,
+		RowLimit:         int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]leaderboard.Score, len(scoresData))
+	for i, score := range scoresData {
+		scores[i] = scoreToDomain(score)
+	}
+
+	return scores, nil
+}
+
+func (c connection) DeleteScores(ctx context.Context, leaderboardID, playerID string, from, to time.Time) error {
+	uid, err := uuid.Parse(leaderboardID)
+	if err != nil {
+		return leaderboard.ErrInvalidLeaderboardID
+	}
+
+	return c.queries.DeleteScores(ctx, sqlc.DeleteScoresParams{
+		LeaderboardID: uid,
+		SubmittedFrom: pgtype.Timestamptz{Time: from, Valid: true},
+		SubmittedTo:   pgtype.Timestamptz{Time: to, Valid: true},
+		PlayerID:      playerID,
+	})
+}
+
+// Groups the scores by device and player. The build and region are the ones of the latest score
+func (c connection) ListDeviceUsage(ctx context.Context, gameID string, filter moderation.DeviceUsageFilter, limit int) ([]moderation.DeviceUsage, error) {
+	usageData, err := c.queries.ListDeviceUsage(ctx, sqlc.ListDeviceUsageParams{
+		GameID:     gameID,
+		DeviceHash: filter.DeviceHash,
+		PlayerID:   filter.PlayerID,
+		RowLimit:   int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]moderation.DeviceUsage, len(usageData))
+	for i, u := range usageData {
+		devices[i] = moderation.DeviceUsage{
+			DeviceHash:   u.DeviceHash,
+			PlayerID:     u.PlayerID,
+			BuildVersion: u.BuildVersion,
+			Region:       u.Region,
+			FirstSeenAt:  u.FirstSeenAt.Time,
+			LastSeenAt:   u.LastSeenAt.Time,
+			Scores:       u.Scores,
+		}
+	}
+
+	return devices, nil
+}
+
+// Erases the scores the player submitted to every leaderboard of the game
+func (c connection) ErasePlayerScores(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	records, err := c.queries.ErasePlayerScores(ctx, sqlc.ErasePlayerScoresParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataScoreHistory, Records: records}, nil
+}
+
+// Moves the scores the player submitted to every leaderboard of the game to the pseudonym, dropping their fingerprint
+func (c connection) AnonymizePlayerScores(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	records, err := c.queries.AnonymizePlayerScores(ctx, sqlc.AnonymizePlayerScoresParams{Pseudonym: pseudonym, GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataScoreHistory, Records: records}, nil
+}
+
+// Counts the scores the player submitted to every leaderboard of the game
+func (c connection) CountPlayerScores(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.queries.CountPlayerScores(ctx, sqlc.CountPlayerScoresParams{GameID: gameID, PlayerID: playerID})
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataScoreHistory, Records: records}, nil
+}
@@ -0,0 +1,62 @@
+-- name: CreateLeaderboard :one
+INSERT INTO "leaderboards" ("game_id", "name", "description", "start_at", "end_at", "aggregation_mode", "ordering")
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetLeaderboardByIDAndGameID :one
+SELECT *
+FROM "leaderboards" l
+WHERE
+    l."id" = $1 AND
+    l."game_id" = $2 AND
+    l."deleted_at" IS NULL
+LIMIT 1;
+
+-- name: SoftDeleteLeaderboardByIDAndGameID :execrows
+UPDATE "leaderboards"
+SET
+    "deleted_at" = NOW()
+WHERE
+    "id" = $1 AND
+    "game_id" = $2 AND
+    "deleted_at" IS NULL;
+
+-- name: PurgeSoftDeletedLeaderboards :many
+DELETE FROM "leaderboards"
+WHERE
+    "deleted_at" IS NOT NULL AND
+    "deleted_at" < sqlc.arg(deleted_before) AND
+    (sqlc.arg(game_id)::VARCHAR = '' OR "game_id" = sqlc.arg(game_id))
+RETURNING "id", "game_id", "deleted_at";
+
+-- name: ListLeaderboardsByGameID :many
+SELECT *
+FROM "leaderboards" l
+WHERE
+    l."game_id" = $1 AND
+    l."deleted_at" IS NULL
+ORDER BY l."created_at" ASC;
+
+-- name: RestoreLeaderboard :execrows
+INSERT INTO "leaderboards" ("created_at", "updated_at", "id", "game_id", "name", "description", "start_at", "end_at", "aggregation_mode", "ordering")
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT ("id") DO NOTHING;
+
+-- name: ListLeaderboardsScheduledBetween :many
+SELECT *
+FROM "leaderboards" l
+WHERE
+    l."deleted_at" IS NULL AND (
+        (l."start_at" > @scheduled_from AND l."start_at" <= @scheduled_to) OR
+        (l."end_at" > @scheduled_from AND l."end_at" <= @scheduled_to)
+    )
+ORDER BY l."created_at" ASC;
+
+-- name: ListSoftDeletedLeaderboards :many
+SELECT "id", "game_id", "deleted_at"
+FROM "leaderboards"
+WHERE
+    "deleted_at" IS NOT NULL AND
+    "deleted_at" < sqlc.arg(deleted_before) AND
+    (sqlc.arg(game_id)::VARCHAR = '' OR "game_id" = sqlc.arg(game_id))
+ORDER BY "deleted_at" ASC;
@@ -0,0 +1,70 @@
+-- name: ArchiveOutboxEvent :exec
+INSERT INTO "event_archive" ("created_at", "id", "game_id", "kind", "payload")
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT ("id") DO NOTHING;
+
+-- name: CreateOutboxEvent :exec
+INSERT INTO "outbox_events" ("created_at", "game_id", "kind", "payload")
+VALUES ($1, $2, $3, $4);
+
+-- name: DeleteOutboxEvent :exec
+DELETE FROM "outbox_events"
+WHERE "id" = $1;
+
+-- name: ListArchivedOutboxEvents :many
+SELECT *
+FROM "event_archive" ea
+WHERE
+    ea."game_id" = sqlc.arg(game_id) AND
+    ea."created_at" >= sqlc.arg(from_created_at) AND
+    ea."created_at" < sqlc.arg(to_created_at) AND
+    (ea."created_at", ea."id") > (sqlc.arg(after_created_at), sqlc.arg(after_id)::UUID)
+ORDER BY ea."created_at" ASC, ea."id" ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListPendingOutboxEvents :many
+SELECT *
+FROM "outbox_events" oe
+ORDER BY oe."created_at" ASC, oe."id" ASC
+LIMIT $1;
+
+-- name: ErasePlayerOutboxEvents :execrows
+DELETE FROM "outbox_events"
+WHERE
+    "game_id" = sqlc.arg(game_id) AND
+    "payload"->'Progression'->>'PlayerID' = sqlc.arg(player_id)::VARCHAR;
+
+-- name: ErasePlayerArchivedEvents :execrows
+DELETE FROM "event_archive"
+WHERE
+    "game_id" = sqlc.arg(game_id) AND
+    "payload"->'Progression'->>'PlayerID' = sqlc.arg(player_id)::VARCHAR;
+
+-- name: AnonymizePlayerOutboxEvents :execrows
+UPDATE "outbox_events"
+SET "payload" = jsonb_set("payload", '{Progression,PlayerID}', to_jsonb(sqlc.arg(pseudonym)::VARCHAR))
+WHERE
+    "game_id" = sqlc.arg(game_id) AND
+    "payload"->'Progression'->>'PlayerID' = sqlc.arg(player_id)::VARCHAR;
+
+-- name: AnonymizePlayerArchivedEvents :execrows
+UPDATE "event_archive"
+SET "payload" = jsonb_set("payload", '{Progression,PlayerID}', to_jsonb(sqlc.arg(pseudonym)::VARCHAR))
+WHERE
+    "game_id" = sqlc.arg(game_id) AND
+    "payload"->'Progression'->>'PlayerID' = sqlc.arg(player_id)::VARCHAR;
+
+-- name: CountPlayerEvents :one
+SELECT (
+    SELECT COUNT(*)
+    FROM "outbox_events" oe
+    WHERE
+        oe."game_id" = sqlc.arg(game_id) AND
+        oe."payload"->'Progression'->>'PlayerID' = sqlc.arg(player_id)::VARCHAR
+) + (
+    SELECT COUNT(*)
+    FROM "event_archive" ea
+    WHERE
+        ea."game_id" = sqlc.arg(game_id) AND
+        ea."payload"->'Progression'->>'PlayerID' = sqlc.arg(player_id)::VARCHAR
+) AS "records";
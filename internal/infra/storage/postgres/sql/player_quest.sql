@@ -104,3 +104,68 @@ WHERE
 	"player_quests"."quest_id" = $1 AND 
 	"player_quests"."completed_at" IS NULL AND 
 	TRUE = ALL((SELECT "completed" FROM "completion_list"));
+
+-- name: ListPlayerQuestsByQuestID :many
+SELECT *
+FROM "player_quests" pq
+WHERE pq."quest_id" = $1
+ORDER BY pq."started_at" ASC;
+
+-- name: ListPlayerQuestTasksByQuestID :many
+SELECT pqt.*
+FROM "player_quest_tasks" pqt
+JOIN "player_quests" pq ON pq."id" = pqt."player_quest_id"
+WHERE pq."quest_id" = $1;
+
+-- name: RestorePlayerQuest :one
+INSERT INTO "player_quests" ("started_at", "updated_at", "player_id", "quest_id", "completed_at")
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT ("player_id", "quest_id") DO NOTHING
+RETURNING "id";
+
+-- name: RestorePlayerQuestTask :exec
+INSERT INTO "player_quest_tasks" ("started_at", "updated_at", "player_id", "player_quest_id", "task_id", "completed_at")
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ErasePlayerQuests :execrows
+DELETE FROM "player_quests" pq
+USING "quests" q
+WHERE
+    q."id" = pq."quest_id" AND
+    q."game_id" = $1 AND
+    pq."player_id" = $2;
+
+-- name: AnonymizePlayerQuests :execrows
+UPDATE "player_quests" pq
+SET "player_id" = sqlc.arg(pseudonym)
+FROM "quests" q
+WHERE
+    q."id" = pq."quest_id" AND
+    q."game_id" = sqlc.arg(game_id) AND
+    pq."player_id" = sqlc.arg(player_id);
+
+-- name: AnonymizePlayerQuestTasks :execrows
+UPDATE "player_quest_tasks" pqt
+SET "player_id" = sqlc.arg(pseudonym)
+FROM "player_quests" pq, "quests" q
+WHERE
+    pq."id" = pqt."player_quest_id" AND
+    q."id" = pq."quest_id" AND
+    q."game_id" = sqlc.arg(game_id) AND
+    pqt."player_id" = sqlc.arg(player_id);
+
+-- name: CountPlayerQuests :one
+SELECT COUNT(*)
+FROM "player_quests" pq
+JOIN "quests" q ON q."id" = pq."quest_id"
+WHERE
+    q."game_id" = $1 AND
+    pq."player_id" = $2;
+
+-- name: ListPlayersWithQuestUnfinished :many
+SELECT pq."player_id"
+FROM "player_quests" pq
+WHERE
+    pq."quest_id" = $1 AND
+    pq."completed_at" IS NULL
+ORDER BY pq."player_id" ASC;
@@ -0,0 +1,127 @@
+-------------------------------------
+-- Create Player Statistic Progress --
+-------------------------------------
+
+-- name: CreatePlayerStatistic :exec
+INSERT INTO "player_statistics" ("player_id", "statistic_id", "current_value", "goal_value")
+VALUES ($1, $2, $3, $4)
+ON CONFLICT ("player_id", "statistic_id") DO NOTHING;
+
+-- name: CreatePlayerStatisticLandmarks :exec
+INSERT INTO "player_statistic_landmarks" ("player_id", "statistic_id", "value")
+SELECT sqlc.arg('player_id')::VARCHAR, sqlc.arg('statistic_id')::UUID, UNNEST(sqlc.arg('landmarks')::DOUBLE PRECISION[])
+ON CONFLICT ("player_id", "statistic_id", "value") DO NOTHING;
+
+----------------------------------
+-- Get Player Statistic Progress --
+----------------------------------
+
+-- name: GetPlayerStatistic :one
+SELECT *
+FROM "player_statistics" ps
+WHERE ps."player_id" = $1 AND ps."statistic_id" = $2;
+
+-- name: ListPlayerStatisticLandmarks :many
+SELECT *
+FROM "player_statistic_landmarks" psl
+WHERE psl."player_id" = $1 AND psl."statistic_id" = $2
+ORDER BY psl."value" ASC;
+
+-------------------------------------
+-- Update Player Statistic Progress --
+-------------------------------------
+
+-- name: UpdatePlayerStatisticValue :one
+UPDATE "player_statistics"
+SET
+    "started_at" = COALESCE("started_at", NOW()),
+    "updated_at" = NOW(),
+    "current_value" = CASE sqlc.arg('aggregation_mode')::VARCHAR
+        WHEN 'SUM' THEN COALESCE("current_value", 0) + sqlc.arg('value')::DOUBLE PRECISION
+        WHEN 'SUB' THEN COALESCE("current_value", 0) - sqlc.arg('value')::DOUBLE PRECISION
+        WHEN 'MAX' THEN GREATEST("current_value", sqlc.arg('value')::DOUBLE PRECISION)
+        WHEN 'MIN' THEN LEAST("current_value", sqlc.arg('value')::DOUBLE PRECISION)
+    END
+WHERE "player_id" = $1 AND "statistic_id" = $2
+RETURNING *;
+
+-- name: MarkPlayerStatisticGoalAsCompleted :execrows
+UPDATE "player_statistics"
+SET
+    "goal_completed_at" = NOW()
+WHERE
+    "player_id" = $1 AND
+    "statistic_id" = $2 AND
+    "goal_value" IS NOT NULL AND
+    "goal_completed_at" IS NULL AND
+    CASE WHEN sqlc.arg('ascending')::BOOLEAN
+        THEN "current_value" >= "goal_value"
+        ELSE "current_value" <= "goal_value"
+    END;
+
+-- name: MarkPlayerStatisticLandmarksAsCompleted :many
+UPDATE "player_statistic_landmarks" psl
+SET
+    "completed_at" = NOW()
+FROM "player_statistics" ps
+WHERE
+    ps."player_id" = psl."player_id" AND
+    ps."statistic_id" = psl."statistic_id" AND
+    psl."player_id" = $1 AND
+    psl."statistic_id" = $2 AND
+    psl."completed_at" IS NULL AND
+    CASE WHEN sqlc.arg('ascending')::BOOLEAN
+        THEN ps."current_value" >= psl."value"
+        ELSE ps."current_value" <= psl."value"
+    END
+RETURNING psl.*;
+
+-- name: ListPlayerStatisticsByStatisticID :many
+SELECT *
+FROM "player_statistics" ps
+WHERE ps."statistic_id" = $1
+ORDER BY ps."player_id" ASC;
+
+-- name: ListPlayerStatisticLandmarksByStatisticID :many
+SELECT *
+FROM "player_statistic_landmarks" psl
+WHERE psl."statistic_id" = $1
+ORDER BY psl."player_id" ASC, psl."value" ASC;
+
+-- name: RestorePlayerStatistic :execrows
+INSERT INTO "player_statistics" ("started_at", "updated_at", "player_id", "statistic_id", "current_value", "goal_value", "goal_completed_at")
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT ("player_id", "statistic_id") DO NOTHING;
+
+-- name: RestorePlayerStatisticLandmark :exec
+INSERT INTO "player_statistic_landmarks" ("player_id", "statistic_id", "value", "completed_at")
+VALUES ($1, $2, $3, $4);
+
+-- name: DeletePlayerStatistic :exec
+DELETE FROM "player_statistics" ps
+WHERE ps."player_id" = $1 AND ps."statistic_id" = $2;
+
+-- name: ErasePlayerStatistics :execrows
+DELETE FROM "player_statistics" ps
+USING "statistics" s
+WHERE
+    s."id" = ps."statistic_id" AND
+    s."game_id" = $1 AND
+    ps."player_id" = $2;
+
+-- name: AnonymizePlayerStatistics :execrows
+UPDATE "player_statistics" ps
+SET "player_id" = sqlc.arg(pseudonym)
+FROM "statistics" s
+WHERE
+    s."id" = ps."statistic_id" AND
+    s."game_id" = sqlc.arg(game_id) AND
+    ps."player_id" = sqlc.arg(player_id);
+
+-- name: CountPlayerStatistics :one
+SELECT COUNT(*)
+FROM "player_statistics" ps
+JOIN "statistics" s ON s."id" = ps."statistic_id"
+WHERE
+    s."game_id" = $1 AND
+    ps."player_id" = $2;
@@ -1,6 +1,6 @@
 -- name: CreateQuest :one
-INSERT INTO "quests" ("game_id", "name", "description")
-VALUES ($1, $2, $3)
+INSERT INTO "quests" ("game_id", "name", "description", "end_at")
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: GetQuestByIDAndGameID :one
@@ -20,3 +20,42 @@ WHERE
     "id" = $1 AND
     "game_id" = $2
     AND "deleted_at" IS NULL;
+
+-- name: PurgeSoftDeletedQuests :many
+DELETE FROM "quests"
+WHERE
+    "deleted_at" IS NOT NULL AND
+    "deleted_at" < sqlc.arg(deleted_before) AND
+    (sqlc.arg(game_id)::VARCHAR = '' OR "game_id" = sqlc.arg(game_id))
+RETURNING "id", "game_id", "deleted_at";
+
+-- name: ListQuestsByGameID :many
+SELECT *
+FROM "quests" q
+WHERE
+    q."game_id" = $1 AND
+    q."deleted_at" IS NULL
+ORDER BY q."created_at" ASC;
+
+-- name: RestoreQuest :execrows
+INSERT INTO "quests" ("created_at", "updated_at", "id", "game_id", "name", "description", "end_at")
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT ("id") DO NOTHING;
+
+-- name: ListQuestsEndedBetween :many
+SELECT *
+FROM "quests" q
+WHERE
+    q."deleted_at" IS NULL AND
+    q."end_at" > @ended_from AND
+    q."end_at" <= @ended_to
+ORDER BY q."end_at" ASC;
+
+-- name: ListSoftDeletedQuests :many
+SELECT "id", "game_id", "deleted_at"
+FROM "quests"
+WHERE
+    "deleted_at" IS NOT NULL AND
+    "deleted_at" < sqlc.arg(deleted_before) AND
+    (sqlc.arg(game_id)::VARCHAR = '' OR "game_id" = sqlc.arg(game_id))
+ORDER BY "deleted_at" ASC;
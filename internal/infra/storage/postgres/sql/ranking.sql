@@ -0,0 +1,100 @@
+-- name: IncrementPlayerRankValue :exec
+INSERT INTO "leaderboard_rankings" ("leaderboard_id", "player_id", "value")
+VALUES ($1, $2, $3)
+ON CONFLICT ("leaderboard_id", "player_id") DO UPDATE
+SET
+    "updated_at" = NOW(),
+    "value" = "leaderboard_rankings"."value" + EXCLUDED."value";
+
+-- name: SetMaxPlayerRankValue :exec
+INSERT INTO "leaderboard_rankings" ("leaderboard_id", "player_id", "value")
+VALUES ($1, $2, $3)
+ON CONFLICT ("leaderboard_id", "player_id") DO UPDATE
+SET
+    "updated_at" = NOW(),
+    "value" = EXCLUDED."value"
+WHERE EXCLUDED."value" > "leaderboard_rankings"."value";
+
+-- name: SetMinPlayerRankValue :exec
+INSERT INTO "leaderboard_rankings" ("leaderboard_id", "player_id", "value")
+VALUES ($1, $2, $3)
+ON CONFLICT ("leaderboard_id", "player_id") DO UPDATE
+SET
+    "updated_at" = NOW(),
+    "value" = EXCLUDED."value"
+WHERE EXCLUDED."value" < "leaderboard_rankings"."value";
+
+-- name: GetRankingAsc :many
+SELECT lr."player_id", lr."value"
+FROM "leaderboard_rankings" lr
+WHERE lr."leaderboard_id" = $1
+ORDER BY lr."value" ASC, lr."updated_at" ASC
+LIMIT $2 OFFSET $3;
+
+-- name: GetRankingDesc :many
+SELECT lr."player_id", lr."value"
+FROM "leaderboard_rankings" lr
+WHERE lr."leaderboard_id" = $1
+ORDER BY lr."value" DESC, lr."updated_at" ASC
+LIMIT $2 OFFSET $3;
+
+-- name: GetPlayerRankAsc :one
+SELECT lr."value", (
+    SELECT COUNT(*)
+    FROM "leaderboard_rankings" ahead
+    WHERE
+        ahead."leaderboard_id" = lr."leaderboard_id" AND
+        (ahead."value" < lr."value" OR (ahead."value" = lr."value" AND ahead."updated_at" < lr."updated_at"))
+) AS "position"
+FROM "leaderboard_rankings" lr
+WHERE lr."leaderboard_id" = $1 AND lr."player_id" = $2;
+
+-- name: GetPlayerRankDesc :one
+SELECT lr."value", (
+    SELECT COUNT(*)
+    FROM "leaderboard_rankings" ahead
+    WHERE
+        ahead."leaderboard_id" = lr."leaderboard_id" AND
+        (ahead."value" > lr."value" OR (ahead."value" = lr."value" AND ahead."updated_at" < lr."updated_at"))
+) AS "position"
+FROM "leaderboard_rankings" lr
+WHERE lr."leaderboard_id" = $1 AND lr."player_id" = $2;
+
+-- name: ErasePlayerRanks :execrows
+DELETE FROM "leaderboard_rankings" lr
+USING "leaderboards" l
+WHERE
+    l."id" = lr."leaderboard_id" AND
+    l."game_id" = $1 AND
+    lr."player_id" = $2;
+
+-- name: AnonymizePlayerRanks :execrows
+UPDATE "leaderboard_rankings" lr
+SET "player_id" = sqlc.arg(pseudonym)
+FROM "leaderboards" l
+WHERE
+    l."id" = lr."leaderboard_id" AND
+    l."game_id" = sqlc.arg(game_id) AND
+    lr."player_id" = sqlc.arg(player_id);
+
+-- name: DeleteRanking :exec
+DELETE FROM "leaderboard_rankings"
+WHERE "leaderboard_id" = $1;
+
+-- name: CreateRebuiltRanks :exec
+INSERT INTO "leaderboard_rankings" ("leaderboard_id", "player_id", "value", "updated_at")
+SELECT sqlc.arg(leaderboard_id)::UUID, UNNEST(sqlc.arg(player_ids)::VARCHAR[]), UNNEST(sqlc.arg(rank_values)::DOUBLE PRECISION[]), UNNEST(sqlc.arg(updated_ats)::TIMESTAMPTZ[]);
+
+-- name: CountPlayerRanks :one
+SELECT COUNT(*)
+FROM "leaderboard_rankings" lr
+JOIN "leaderboards" l ON l."id" = lr."leaderboard_id"
+WHERE
+    l."game_id" = $1 AND
+    lr."player_id" = $2;
+
+-- name: DeletePlayerRank :exec
+DELETE FROM "leaderboard_rankings"
+WHERE
+    "leaderboard_id" = $1 AND
+    "player_id" = $2;
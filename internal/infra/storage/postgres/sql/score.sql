@@ -0,0 +1,65 @@
+-- name: RecordScore :exec
+INSERT INTO "leaderboard_scores" ("submitted_at", "leaderboard_id", "game_id", "player_id", "value", "source", "device_hash", "build_version", "region")
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: ListScores :many
+SELECT *
+FROM "leaderboard_scores" ls
+WHERE
+    ls."leaderboard_id" = sqlc.arg(leaderboard_id) AND
+    (ls."submitted_at", ls."id") > (sqlc.arg(after_submitted_at), sqlc.arg(after_id)::UUID)
+ORDER BY ls."submitted_at" ASC, ls."id" ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListScoresBySource :many
+SELECT *
+FROM "leaderboard_scores" ls
+WHERE
+    ls."game_id" = sqlc.arg(game_id) AND
+    ls."source" = sqlc.arg(source) AND
+    ls."submitted_at" >= sqlc.arg(submitted_from) AND
+    ls."submitted_at" < sqlc.arg(submitted_to) AND
+    (ls."submitted_at", ls."id") > (sqlc.arg(after_submitted_at), sqlc.arg(after_id)::UUID)
+ORDER BY ls."submitted_at" ASC, ls."id" ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListDeviceUsage :many
+SELECT
+    ls."device_hash",
+    ls."player_id",
+    (array_agg(ls."build_version" ORDER BY ls."submitted_at" DESC, ls."id" DESC))[1]::VARCHAR AS "build_version",
+    (array_agg(ls."region" ORDER BY ls."submitted_at" DESC, ls."id" DESC))[1]::VARCHAR AS "region",
+    MIN(ls."submitted_at")::TIMESTAMPTZ AS "first_seen_at",
+    MAX(ls."submitted_at")::TIMESTAMPTZ AS "last_seen_at",
+    COUNT(*) AS "scores"
+FROM "leaderboard_scores" ls
+WHERE
+    ls."game_id" = sqlc.arg(game_id) AND
+    ls."device_hash" <> '' AND
+    (sqlc.arg(device_hash)::VARCHAR = '' OR ls."device_hash" = sqlc.arg(device_hash)) AND
+    (sqlc.arg(player_id)::VARCHAR = '' OR ls."player_id" = sqlc.arg(player_id))
+GROUP BY ls."device_hash", ls."player_id"
+ORDER BY "last_seen_at" DESC, ls."device_hash" ASC, ls."player_id" ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: DeleteScores :exec
+DELETE FROM "leaderboard_scores"
+WHERE
+    "leaderboard_id" = sqlc.arg(leaderboard_id) AND
+    "submitted_at" >= sqlc.arg(submitted_from) AND
+    "submitted_at" < sqlc.arg(submitted_to) AND
+    (sqlc.arg(player_id)::VARCHAR = '' OR "player_id" = sqlc.arg(player_id));
+
+-- name: ErasePlayerScores :execrows
+DELETE FROM "leaderboard_scores"
+WHERE "game_id" = $1 AND "player_id" = $2;
+
+-- name: AnonymizePlayerScores :execrows
+UPDATE "leaderboard_scores"
+SET "player_id" = sqlc.arg(pseudonym), "device_hash" = '', "build_version" = '', "region" = ''
+WHERE "game_id" = sqlc.arg(game_id) AND "player_id" = sqlc.arg(player_id);
+
+-- name: CountPlayerScores :one
+SELECT COUNT(*)
+FROM "leaderboard_scores"
+WHERE "game_id" = $1 AND "player_id" = $2;
@@ -0,0 +1,52 @@
+-- name: CreateStatistic :one
+INSERT INTO "statistics" ("game_id", "name", "description", "aggregation_mode", "initial_value", "goal", "landmarks")
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetStatisticByIDAndGameID :one
+SELECT *
+FROM "statistics" s
+WHERE
+    s."id" = $1 AND
+    s."game_id" = $2 AND
+    s."deleted_at" IS NULL
+LIMIT 1;
+
+-- name: SoftDeleteStatisticByIDAndGameID :execrows
+UPDATE "statistics"
+SET
+    "deleted_at" = NOW()
+WHERE
+    "id" = $1 AND
+    "game_id" = $2 AND
+    "deleted_at" IS NULL;
+
+-- name: PurgeSoftDeletedStatistics :many
+DELETE FROM "statistics"
+WHERE
+    "deleted_at" IS NOT NULL AND
+    "deleted_at" < sqlc.arg(deleted_before) AND
+    (sqlc.arg(game_id)::VARCHAR = '' OR "game_id" = sqlc.arg(game_id))
+RETURNING "id", "game_id", "deleted_at";
+
+-- name: ListStatisticsByGameID :many
+SELECT *
+FROM "statistics" s
+WHERE
+    s."game_id" = $1 AND
+    s."deleted_at" IS NULL
+ORDER BY s."created_at" ASC;
+
+-- name: RestoreStatistic :execrows
+INSERT INTO "statistics" ("created_at", "updated_at", "id", "game_id", "name", "description", "aggregation_mode", "initial_value", "goal", "landmarks")
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT DO NOTHING;
+
+-- name: ListSoftDeletedStatistics :many
+SELECT "id", "game_id", "deleted_at"
+FROM "statistics"
+WHERE
+    "deleted_at" IS NOT NULL AND
+    "deleted_at" < sqlc.arg(deleted_before) AND
+    (sqlc.arg(game_id)::VARCHAR = '' OR "game_id" = sqlc.arg(game_id))
+ORDER BY "deleted_at" ASC;
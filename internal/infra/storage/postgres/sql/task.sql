@@ -21,3 +21,7 @@ SET
 WHERE
     "quest_id" = $1 AND
     "deleted_at" IS NULL;
+
+-- name: RestoreTask :exec
+INSERT INTO "tasks" ("created_at", "updated_at", "quest_id", "id", "name", "description", "required_for_completion", "rule")
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/backup"
+	"github.com/gabapcia/gameblitz/internal/infra/storage/postgres/internal/sqlc"
+	"github.com/gabapcia/gameblitz/internal/purge"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func float8ToPointer(f pgtype.Float8) *float64 {
+	if !f.Valid {
+		return nil
+	}
+
+	return &f.Float64
+}
+
+func pointerToFloat8(f *float64) pgtype.Float8 {
+	if f == nil {
+		return pgtype.Float8{}
+	}
+
+	return pgtype.Float8{Float64: *f, Valid: true}
+}
+
+func sqlcStatisticToDomain(s sqlc.Statistic) statistic.Statistic {
+	return statistic.Statistic{
+		CreatedAt:       s.CreatedAt.Time,
+		UpdatedAt:       s.UpdatedAt.Time,
+		DeletedAt:       s.DeletedAt.Time,
+		ID:              s.ID.String(),
+		GameID:          s.GameID,
+		Name:            s.Name,
+		Description:     s.Description,
+		AggregationMode: s.AggregationMode,
+		InitialValue:    float8ToPointer(s.InitialValue),
+		Goal:            float8ToPointer(s.Goal),
+		Landmarks:       s.Landmarks,
+	}
+}
+
+func (c connection) CreateStatistic(ctx context.Context, data statistic.NewStatisticData) (statistic.Statistic, error) {
+	landmarks := data.Landmarks
+	if landmarks == nil {
+		landmarks = make([]float64, 0)
+	}
+
+	statisticData, err := c.queries.CreateStatistic(ctx, sqlc.CreateStatisticParams{
+		GameID:          data.GameID,
+		Name:            data.Name,
+		Description:     data.Description,
+		AggregationMode: data.AggregationMode,
+		InitialValue:    pointerToFloat8(data.InitialValue),
+		Goal:            pointerToFloat8(data.Goal),
+		Landmarks:       landmarks,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			err = statistic.ErrStatisticNameAlreadyInUse
+		}
+
+		return statistic.Statistic{}, err
+	}
+
+	return sqlcStatisticToDomain(statisticData), nil
+}
+
+func (c connection) GetStatisticByIDAndGameID(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return statistic.Statistic{}, statistic.ErrInvalidStatisticID
+	}
+
+	statisticData, err := c.queries.GetStatisticByIDAndGameID(ctx, sqlc.GetStatisticByIDAndGameIDParams{
+		ID:     uid,
+		GameID: gameID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = statistic.ErrStatisticNotFound
+		}
+
+		return statistic.Statistic{}, err
+	}
+
+	return sqlcStatisticToDomain(statisticData), nil
+}
+
+func (c connection) SoftDeleteStatistic(ctx context.Context, id, gameID string) error {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return statistic.ErrInvalidStatisticID
+	}
+
+	affected, err := c.queries.SoftDeleteStatisticByIDAndGameID(ctx, sqlc.SoftDeleteStatisticByIDAndGameIDParams{
+		ID:     uid,
+		GameID: gameID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return statistic.ErrStatisticNotFound
+	}
+
+	return nil
+}
+
+func (c connection) PurgeSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	rows, err := c.queries.PurgeSoftDeletedStatistics(ctx, sqlc.PurgeSoftDeletedStatisticsParams{
+		DeletedBefore: pgtype.Timestamptz{Time: deletedBefore, Valid: true},
+		GameID:        gameID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, len(rows))
+	for i, row := range rows {
+		records[i] = purge.Record{
+			DeletedAt: row.DeletedAt.Time,
+			Entity:    purge.EntityStatistic,
+			ID:        row.ID.String(),
+			GameID:    row.GameID,
+		}
+	}
+
+	return records, nil
+}
+
+func (c connection) ListSoftDeletedStatistics(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	rows, err := c.queries.ListSoftDeletedStatistics(ctx, sqlc.ListSoftDeletedStatisticsParams{
+		DeletedBefore: pgtype.Timestamptz{Time: deletedBefore, Valid: true},
+		GameID:        gameID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, len(rows))
+	for i, row := range rows {
+		records[i] = purge.Record{
+			DeletedAt: row.DeletedAt.Time,
+			Entity:    purge.EntityStatistic,
+			ID:        row.ID.String(),
+			GameID:    row.GameID,
+		}
+	}
+
+	return records, nil
+}
+
+func (c connection) ListStatistics(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+	rows, err := c.queries.ListStatisticsByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	statistics := make([]statistic.Statistic, len(rows))
+	for i, row := range rows {
+		statistics[i] = sqlcStatisticToDomain(row)
+	}
+
+	return statistics, nil
+}
+
+// Stores the statistic keeping its ID. Fails with `backup.ErrEntityAlreadyExists` if either the ID or the name are in use
+func (c connection) RestoreStatistic(ctx context.Context, st statistic.Statistic) error {
+	uid, err := uuid.Parse(st.ID)
+	if err != nil {
+		return statistic.ErrInvalidStatisticID
+	}
+
+	landmarks := st.Landmarks
+	if landmarks == nil {
+		landmarks = make([]float64, 0)
+	}
+
+	affected, err := c.queries.RestoreStatistic(ctx, sqlc.RestoreStatisticParams{
+		CreatedAt:       pgtype.Timestamptz{Time: st.CreatedAt, Valid: true},
+		UpdatedAt:       pgtype.Timestamptz{Time: st.UpdatedAt, Valid: true},
+		ID:              uid,
+		GameID:          st.GameID,
+		Name:            st.Name,
+		Description:     st.Description,
+		AggregationMode: st.AggregationMode,
+		InitialValue:    pointerToFloat8(st.InitialValue),
+		Goal:            pointerToFloat8(st.Goal),
+		Landmarks:       landmarks,
+	})
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return backup.ErrEntityAlreadyExists
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatistic(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		conn   = newTestConnection(t)
+		gameID = uuid.NewString()
+		goal   = 30.0
+	)
+
+	st, err := conn.CreateStatistic(ctx, statistic.NewStatisticData{
+		GameID:          gameID,
+		Name:            "Kills",
+		AggregationMode: statistic.AggregationModeSum,
+		Goal:            &goal,
+		Landmarks:       []float64{10, 20},
+	})
+	assert.NoError(t, err)
+
+	t.Run("Get", func(t *testing.T) {
+		stored, err := conn.GetStatisticByIDAndGameID(ctx, st.ID, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, st.Name, stored.Name)
+		assert.Equal(t, []float64{10, 20}, stored.Landmarks)
+	})
+
+	t.Run("Name Already In Use", func(t *testing.T) {
+		_, err := conn.CreateStatistic(ctx, statistic.NewStatisticData{
+			GameID:          gameID,
+			Name:            "Kills",
+			AggregationMode: statistic.AggregationModeMax,
+		})
+		assert.ErrorIs(t, err, statistic.ErrStatisticNameAlreadyInUse)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := conn.GetStatisticByIDAndGameID(ctx, uuid.NewString(), gameID)
+		assert.ErrorIs(t, err, statistic.ErrStatisticNotFound)
+	})
+
+	t.Run("Progression", func(t *testing.T) {
+		progression, updates, err := conn.UpdatePlayerStatisticProgression(ctx, st, "alice", 15)
+		assert.NoError(t, err)
+		assert.Equal(t, 15.0, *progression.CurrentValue)
+		assert.False(t, updates.GoalJustCompleted)
+		assert.Len(t, updates.LandmarksJustCompleted, 1)
+
+		progression, updates, err = conn.UpdatePlayerStatisticProgression(ctx, st, "alice", 15)
+		assert.NoError(t, err)
+		assert.Equal(t, 30.0, *progression.CurrentValue)
+		assert.True(t, updates.GoalJustCompleted)
+		assert.Len(t, updates.LandmarksJustCompleted, 1)
+
+		stored, err := conn.GetPlayerProgression(ctx, st.ID, "alice")
+		assert.NoError(t, err)
+		assert.True(t, *stored.GoalCompleted)
+	})
+
+	t.Run("Progression Not Found", func(t *testing.T) {
+		_, err := conn.GetPlayerProgression(ctx, st.ID, "bob")
+		assert.ErrorIs(t, err, statistic.ErrPlayerStatisticNotFound)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gabapcia/gameblitz/internal/quest"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func sqlcTaskWithItsDependenciesToDomain(t sqlc.TasksWithItsDependency) quest.Task {
@@ -88,3 +89,43 @@ func createQuestTasks(ctx context.Context, queries *sqlc.Queries, questID uuid.U
 
 	return tasksCreated, nil
 }
+
+func restoreQuestTasks(ctx context.Context, queries *sqlc.Queries, questID uuid.UUID, tasks []quest.Task) error {
+	taskIDs := make(map[string]uuid.UUID, len(tasks))
+	for _, task := range tasks {
+		taskID, err := uuid.Parse(task.ID)
+		if err != nil {
+			return quest.ErrInvalidTaskID
+		}
+
+		err = queries.RestoreTask(ctx, sqlc.RestoreTaskParams{
+			CreatedAt:             pgtype.Timestamptz{Time: task.CreatedAt, Valid: true},
+			UpdatedAt:             pgtype.Timestamptz{Time: task.UpdatedAt, Valid: true},
+			QuestID:               questID,
+			ID:                    taskID,
+			Name:                  task.Name,
+			Description:           task.Description,
+			RequiredForCompletion: task.RequiredForCompletion,
+			Rule:                  task.Rule,
+		})
+		if err != nil {
+			return err
+		}
+
+		taskIDs[task.ID] = taskID
+	}
+
+	for _, task := range tasks {
+		for _, dependsOn := range task.DependsOn {
+			err := queries.RegisterTaskDependency(ctx, sqlc.RegisterTaskDependencyParams{
+				ThisTask:      taskIDs[task.ID],
+				DependsOnTask: taskIDs[dependsOn],
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
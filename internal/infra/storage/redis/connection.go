@@ -2,29 +2,351 @@ package redis
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/availability"
+	"github.com/gabapcia/gameblitz/internal/infra/backoff"
+	"github.com/gabapcia/gameblitz/internal/infra/failover"
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
 
 	"github.com/redis/go-redis/v9"
 )
 
+var (
+	ErrInvalidPoolConfig = errors.New("invalid connection pool config")
+	ErrInvalidGameRoute  = errors.New("invalid game route")
+	ErrInvalidPipeline   = errors.New("invalid pipeline config")
+)
+
+type Options struct {
+	Addrs              []string          // Redis address. On cluster mode, the seed nodes addresses
+	Username           string            // Redis username
+	Password           string            // Redis password
+	DB                 int               // Redis database. Ignored on cluster mode
+	Cluster            bool              // Connect to a Redis Cluster
+	MaxRedirects       int               // Maximum number of MOVED/ASK redirects followed before giving up on cluster mode
+	Retry              backoff.Config    // Retry policy used while the server is not reachable at startup
+	PoolSize           int               // Max connections per node. Zero keeps the driver default
+	MinIdleConns       int               // Min idle connections kept per node
+	MaxIdleConns       int               // Max idle connections kept per node. Zero keeps them all
+	ConnMaxIdleTime    time.Duration     // Time an idle connection is kept before closed. Zero keeps the driver default
+	ConnMaxLifetime    time.Duration     // Time a connection is reused before closed. Zero reuses it forever
+	PoolTimeout        time.Duration     // Time to wait for a free connection when the pool is exhausted. Zero keeps the driver default
+	FallbackAddrs      []string          // Read replica addresses on another region, used while the primary is unreachable. Optional
+	Failover           failover.Config   // Health checks that decide when to fail over to the fallback
+	GameRoutes         map[string]string // Redis URL of a dedicated instance or database, by game ID. Games without a route share the main instance
+	Metrics            *metrics.Storage  // Records the latency and the errors of every command. Optional
+	Tracing            bool              // Start a span for every command
+	SlowQueryThreshold time.Duration     // Commands taking longer are reported to `OnSlowQuery`. Zero disables the report
+	OnSlowQuery        SlowQueryFunc     // Called for every slow command. Optional
+	PipelineWindow     time.Duration     // Time a rank update waits for others to be sent along on a single pipeline. Zero sends each one on its own
+	PipelineSize       int               // Most rank updates sent on a single pipeline, sent right away once reached
+}
+
+// Parses the game routes written as `<game id>=<redis url>`
+func ParseGameRoutes(routes []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(routes))
+	for _, route := range routes {
+		gameID, url, ok := strings.Cut(route, "=")
+		if !ok || gameID == "" || url == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidGameRoute, route)
+		}
+
+		parsed[gameID] = url
+	}
+
+	return parsed, nil
+}
+
+func (o Options) validatePool() error {
+	errList := make([]error, 0)
+
+	if o.PoolSize < 0 || o.MinIdleConns < 0 || o.MaxIdleConns < 0 {
+		errList = append(errList, errors.New("negative pool size"))
+	}
+
+	if o.PoolSize > 0 && o.MinIdleConns > o.PoolSize {
+		errList = append(errList, fmt.Errorf("min idle connections %d greater than pool size %d", o.MinIdleConns, o.PoolSize))
+	}
+
+	if o.MaxIdleConns > 0 && o.MinIdleConns > o.MaxIdleConns {
+		errList = append(errList, fmt.Errorf("min idle connections %d greater than max idle connections %d", o.MinIdleConns, o.MaxIdleConns))
+	}
+
+	if o.ConnMaxIdleTime < 0 || o.ConnMaxLifetime < 0 || o.PoolTimeout < 0 {
+		errList = append(errList, errors.New("negative pool timeout"))
+	}
+
+	if len(errList) > 0 {
+		errList = append([]error{ErrInvalidPoolConfig}, errList...)
+	}
+
+	return errors.Join(errList...)
+}
+
+func (o Options) validatePipeline() error {
+	if o.PipelineWindow < 0 {
+		return fmt.Errorf("%w: negative window", ErrInvalidPipeline)
+	}
+
+	if o.PipelineWindow > 0 && o.PipelineSize < 1 {
+		return fmt.Errorf("%w: size %d lower than one", ErrInvalidPipeline, o.PipelineSize)
+	}
+
+	return nil
+}
+
 type connection struct {
-	rdb *redis.Client
+	rdb     redis.UniversalClient
+	cluster bool
+	games   map[string]redis.UniversalClient
+
+	// Batch the rank updates by instance, like the clients. Nil unless pipelining is enabled
+	rdbPipeline   *pipeline
+	gamePipelines map[string]*pipeline
+
+	fallback    redis.UniversalClient
+	monitor     *failover.Monitor
+	stopMonitor context.CancelFunc
+}
+
+// Wraps the leaderboard ID on a hash tag when running against a cluster,
+// so every key from the same leaderboard is assigned to the same slot
+func (c connection) leaderboardKeyID(leaderboardID string) string {
+	if c.cluster {
+		return "{" + leaderboardID + "}"
+	}
+
+	return leaderboardID
+}
+
+// Wraps the game ID on a hash tag when running against a cluster,
+// so every presence key from the same game is assigned to the same slot and read in a single command
+func (c connection) gameKeyID(gameID string) string {
+	if c.cluster {
+		return "{" + gameID + "}"
+	}
+
+	return gameID
+}
+
+func (c connection) degraded() bool {
+	return c.monitor != nil && c.monitor.State() == failover.StateDegraded
+}
+
+// Client that holds the game data. Games routed to a dedicated instance use it, the others share the main one
+func (c connection) writer(gameID string) redis.UniversalClient {
+	if client, ok := c.games[gameID]; ok {
+		return client
+	}
+
+	return c.rdb
+}
+
+// Pipeline batching the rank updates on the instance that holds the game data. Nil unless pipelining is enabled
+func (c connection) pipeline(gameID string) *pipeline {
+	if p, ok := c.gamePipelines[gameID]; ok {
+		return p
+	}
+
+	return c.rdbPipeline
+}
+
+// Client used by the read-only operations. While the primary is unreachable,
+// reads from the games that share the main instance go to the fallback
+func (c connection) reader(gameID string) redis.UniversalClient {
+	if client, ok := c.games[gameID]; ok {
+		return client
+	}
+
+	if c.degraded() {
+		return c.fallback
+	}
+
+	return c.rdb
+}
+
+// Fails with `availability.ErrReadOnly` while only the fallback is reachable.
+// Games routed to a dedicated instance are not affected
+func (c connection) writable(gameID string) error {
+	if _, ok := c.games[gameID]; !ok && c.degraded() {
+		return availability.ErrReadOnly
+	}
+
+	return nil
+}
+
+// Every primary client, the main one first
+func (c connection) clients() []redis.UniversalClient {
+	clients := []redis.UniversalClient{c.rdb}
+	for _, client := range c.games {
+		clients = append(clients, client)
+	}
+
+	return clients
+}
+
+// Checks the main instance and the instances dedicated to a game
+func (c connection) Ping(ctx context.Context) error {
+	errs := []error{c.rdb.Ping(ctx).Err()}
+	for _, client := range c.games {
+		errs = append(errs, client.Ping(ctx).Err())
+	}
+
+	return errors.Join(errs...)
 }
 
 func (c connection) Close() error {
+	if c.rdbPipeline != nil {
+		c.rdbPipeline.close()
+	}
+
+	for _, p := range c.gamePipelines {
+		p.close()
+	}
+
+	if c.fallback != nil {
+		c.stopMonitor()
+		_ = c.fallback.Close()
+	}
+
+	for _, client := range c.games {
+		_ = client.Close()
+	}
+
 	return c.rdb.Close()
 }
 
-func New(ctx context.Context, addr, username, password string, db int) *connection {
-	client := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Username: username,
-		Password: password,
-		DB:       db,
+func (o Options) instrument(client redis.UniversalClient) redis.UniversalClient {
+	if o.Metrics != nil {
+		client.AddHook(metricsHook{metrics: o.Metrics})
+	}
+
+	if o.Tracing {
+		client.AddHook(tracingHook{})
+	}
+
+	if o.SlowQueryThreshold > 0 && o.OnSlowQuery != nil {
+		client.AddHook(slowQueryHook{threshold: o.SlowQueryThreshold, onSlowQuery: o.OnSlowQuery})
+	}
+
+	return client
+}
+
+func newClient(opts Options, addrs []string, readOnly bool) redis.UniversalClient {
+	if opts.Cluster {
+		return opts.instrument(redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:           addrs,
+			Username:        opts.Username,
+			Password:        opts.Password,
+			MaxRedirects:    opts.MaxRedirects,
+			ReadOnly:        readOnly,
+			PoolSize:        opts.PoolSize,
+			MinIdleConns:    opts.MinIdleConns,
+			MaxIdleConns:    opts.MaxIdleConns,
+			ConnMaxIdleTime: opts.ConnMaxIdleTime,
+			ConnMaxLifetime: opts.ConnMaxLifetime,
+			PoolTimeout:     opts.PoolTimeout,
+		}))
+	}
+
+	var addr string
+	if len(addrs) > 0 {
+		addr = addrs[0]
+	}
+
+	return opts.instrument(redis.NewClient(&redis.Options{
+		Addr:            addr,
+		Username:        opts.Username,
+		Password:        opts.Password,
+		DB:              opts.DB,
+		PoolSize:        opts.PoolSize,
+		MinIdleConns:    opts.MinIdleConns,
+		MaxIdleConns:    opts.MaxIdleConns,
+		ConnMaxIdleTime: opts.ConnMaxIdleTime,
+		ConnMaxLifetime: opts.ConnMaxLifetime,
+		PoolTimeout:     opts.PoolTimeout,
+	}))
+}
+
+// Client of a game dedicated instance, keeping the pool settings of the main one
+func newGameClient(opts Options, url string) (redis.UniversalClient, error) {
+	clientOpts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	clientOpts.PoolSize = opts.PoolSize
+	clientOpts.MinIdleConns = opts.MinIdleConns
+	clientOpts.MaxIdleConns = opts.MaxIdleConns
+	clientOpts.ConnMaxIdleTime = opts.ConnMaxIdleTime
+	clientOpts.ConnMaxLifetime = opts.ConnMaxLifetime
+	clientOpts.PoolTimeout = opts.PoolTimeout
+
+	return opts.instrument(redis.NewClient(clientOpts)), nil
+}
+
+func New(ctx context.Context, opts Options) (*connection, error) {
+	if err := errors.Join(opts.validatePool(), opts.validatePipeline()); err != nil {
+		return nil, err
+	}
+
+	client := newClient(opts, opts.Addrs, false)
+
+	err := backoff.Retry(ctx, opts.Retry, func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
 	})
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 
 	conn := &connection{
-		rdb: client,
+		rdb:     client,
+		cluster: opts.Cluster,
+		games:   make(map[string]redis.UniversalClient, len(opts.GameRoutes)),
+	}
+
+	for gameID, url := range opts.GameRoutes {
+		gameClient, err := newGameClient(opts, url)
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("game %s: %w", gameID, err)
+		}
+		conn.games[gameID] = gameClient
+
+		err = backoff.Retry(ctx, opts.Retry, func(ctx context.Context) error {
+			return gameClient.Ping(ctx).Err()
+		})
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("game %s: %w", gameID, err)
+		}
+	}
+
+	if opts.PipelineWindow > 0 {
+		conn.rdbPipeline = newPipeline(conn.rdb, opts.PipelineWindow, opts.PipelineSize)
+		conn.gamePipelines = make(map[string]*pipeline, len(conn.games))
+		for gameID, gameClient := range conn.games {
+			conn.gamePipelines[gameID] = newPipeline(gameClient, opts.PipelineWindow, opts.PipelineSize)
+		}
+	}
+
+	if len(opts.FallbackAddrs) > 0 {
+		conn.fallback = newClient(opts, opts.FallbackAddrs, true)
+		conn.monitor = failover.NewMonitor(
+			func(ctx context.Context) error { return conn.rdb.Ping(ctx).Err() },
+			func(ctx context.Context) error { return conn.fallback.Ping(ctx).Err() },
+			opts.Failover,
+		)
+
+		var monitorCtx context.Context
+		monitorCtx, conn.stopMonitor = context.WithCancel(context.Background())
+		go conn.monitor.Run(monitorCtx)
 	}
 
-	return conn
+	return conn, nil
 }
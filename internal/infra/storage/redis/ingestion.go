@@ -0,0 +1,20 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func buildIngestionMessageKey(messageID string) string {
+	return fmt.Sprintf("ingestion:message:%s", messageID)
+}
+
+// Sets the message key only when it does not exist, so only one of the deliveries of a message claims it
+func (c connection) ClaimMessage(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	return c.rdb.SetNX(ctx, buildIngestionMessageKey(id), time.Now().UTC().Unix(), ttl).Result()
+}
+
+func (c connection) ReleaseMessage(ctx context.Context, id string) error {
+	return c.rdb.Del(ctx, buildIngestionMessageKey(id)).Err()
+}
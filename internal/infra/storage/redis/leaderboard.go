@@ -3,10 +3,15 @@ package redis
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/gabapcia/gameblitz/internal/backup"
 	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/purge"
 	"github.com/google/uuid"
+
+	"github.com/redis/go-redis/v9"
 )
 
 type Leaderboard struct {
@@ -69,14 +74,38 @@ func newLeaderboardFromData(data leaderboard.NewLeaderboardData) Leaderboard {
 	}
 }
 
+func newLeaderboardFromDomain(lb leaderboard.Leaderboard) Leaderboard {
+	var endAt *time.Time
+	if !lb.EndAt.IsZero() {
+		endAt = &lb.EndAt
+	}
+
+	return Leaderboard{
+		CreatedAt:       lb.CreatedAt,
+		UpdatedAt:       lb.UpdatedAt,
+		ID:              lb.ID,
+		GameID:          lb.GameID,
+		Name:            lb.Name,
+		Description:     lb.Description,
+		StartAt:         lb.StartAt,
+		EndAt:           endAt,
+		AggregationMode: lb.AggregationMode,
+		Ordering:        lb.Ordering,
+	}
+}
+
 func buildLeaderboardKey(leaderboardID string) string {
 	return fmt.Sprintf("leaderboard:%s", leaderboardID)
 }
 
 func (c connection) CreateLeaderboard(ctx context.Context, data leaderboard.NewLeaderboardData) (leaderboard.Leaderboard, error) {
+	if err := c.writable(data.GameID); err != nil {
+		return leaderboard.Leaderboard{}, err
+	}
+
 	lb := newLeaderboardFromData(data)
 
-	if err := c.rdb.HSet(ctx, buildLeaderboardKey(lb.ID), lb).Err(); err != nil {
+	if err := c.writer(lb.GameID).HSet(ctx, buildLeaderboardKey(c.leaderboardKeyID(lb.ID)), lb).Err(); err != nil {
 		return leaderboard.Leaderboard{}, err
 	}
 
@@ -84,7 +113,7 @@ func (c connection) CreateLeaderboard(ctx context.Context, data leaderboard.NewL
 }
 
 func (c connection) GetLeaderboardByIDAndGameID(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
-	cursor := c.rdb.HGetAll(ctx, buildLeaderboardKey(id))
+	cursor := c.reader(gameID).HGetAll(ctx, buildLeaderboardKey(c.leaderboardKeyID(id)))
 	if err := cursor.Err(); err != nil {
 		return leaderboard.Leaderboard{}, nil
 	}
@@ -102,9 +131,216 @@ func (c connection) GetLeaderboardByIDAndGameID(ctx context.Context, id, gameID
 }
 
 func (c connection) SoftDeleteLeaderboard(ctx context.Context, id, gameID string) error {
+	if err := c.writable(gameID); err != nil {
+		return err
+	}
+
 	if _, err := c.GetLeaderboardByIDAndGameID(ctx, id, gameID); err != nil {
 		return err
 	}
 
-	return c.rdb.HSetNX(ctx, buildLeaderboardKey(id), "deletedAt", time.Now().UTC()).Err()
+	return c.writer(gameID).HSetNX(ctx, buildLeaderboardKey(c.leaderboardKeyID(id)), "deletedAt", time.Now().UTC()).Err()
+}
+
+// Collects the key of every leaderboard hash stored on the client instance. On cluster mode, each master node is scanned
+func scanLeaderboardKeys(ctx context.Context, rdb redis.UniversalClient) ([]string, error) {
+	var (
+		mu   sync.Mutex
+		keys = make([]string, 0)
+	)
+
+	scan := func(ctx context.Context, client redis.UniversalClient) error {
+		iter := client.ScanType(ctx, 0, buildLeaderboardKey("*"), 100, "hash").Iterator()
+		for iter.Next(ctx) {
+			mu.Lock()
+			keys = append(keys, iter.Val())
+			mu.Unlock()
+		}
+
+		return iter.Err()
+	}
+
+	if cluster, ok := rdb.(*redis.ClusterClient); ok {
+		err := cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return scan(ctx, client)
+		})
+
+		return keys, err
+	}
+
+	return keys, scan(ctx, rdb)
+}
+
+// Finds the leaderboards on the instance soft deleted before the given time, the game's ones only unless it's empty.
+// Returns the keys of their hashes alongside them
+func (c connection) findSoftDeletedLeaderboards(ctx context.Context, rdb redis.UniversalClient, gameID string, deletedBefore time.Time) ([]string, []purge.Record, error) {
+	keys, err := scanLeaderboardKeys(ctx, rdb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		found   = make([]string, 0)
+		records = make([]purge.Record, 0)
+	)
+
+	for _, key := range keys {
+		var lb Leaderboard
+		if err := rdb.HGetAll(ctx, key).Scan(&lb); err != nil {
+			return nil, nil, err
+		}
+
+		if lb.DeletedAt == nil || !lb.DeletedAt.Before(deletedBefore) || (gameID != "" && lb.GameID != gameID) {
+			continue
+		}
+
+		found = append(found, key)
+		records = append(records, purge.Record{
+			DeletedAt: *lb.DeletedAt,
+			Entity:    purge.EntityLeaderboard,
+			ID:        lb.ID,
+			GameID:    lb.GameID,
+		})
+	}
+
+	return found, records, nil
+}
+
+func (c connection) purgeSoftDeletedLeaderboards(ctx context.Context, rdb redis.UniversalClient, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	keys, records, err := c.findSoftDeletedLeaderboards(ctx, rdb, gameID, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	// The keys derived from the ranking go with it, as the generation ones never expire
+	for i, key := range keys {
+		id := c.leaderboardKeyID(records[i].ID)
+		derived := []string{buildCachedTopKey(id), buildCacheGenerationKey(id), buildTopViewKey(id), buildTopViewVersionKey(id)}
+		if err := rdb.Del(ctx, append(derived, buildRankingKey(id), key)...).Err(); err != nil {
+			return records[:i], err
+		}
+	}
+
+	return records, nil
+}
+
+// Instances holding the game's leaderboards, every one of them when it's empty
+func (c connection) gameClients(gameID string, rdb func(gameID string) redis.UniversalClient) []redis.UniversalClient {
+	if gameID == "" {
+		return c.clients()
+	}
+
+	return []redis.UniversalClient{rdb(gameID)}
+}
+
+// Removes the leaderboards soft deleted before the given time alongside their rankings.
+// Every instance is purged unless a game is given, so it waits for the main one to be writable again
+func (c connection) PurgeSoftDeletedLeaderboards(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	if err := c.writable(gameID); err != nil {
+		return nil, err
+	}
+
+	records := make([]purge.Record, 0)
+	for _, rdb := range c.gameClients(gameID, c.writer) {
+		purged, err := c.purgeSoftDeletedLeaderboards(ctx, rdb, gameID, deletedBefore)
+		records = append(records, purged...)
+		if err != nil {
+			return records, err
+		}
+	}
+
+	return records, nil
+}
+
+// Lists the leaderboards `PurgeSoftDeletedLeaderboards` would remove
+func (c connection) ListSoftDeletedLeaderboards(ctx context.Context, gameID string, deletedBefore time.Time) ([]purge.Record, error) {
+	records := make([]purge.Record, 0)
+	for _, rdb := range c.gameClients(gameID, c.reader) {
+		_, found, err := c.findSoftDeletedLeaderboards(ctx, rdb, gameID, deletedBefore)
+		if err != nil {
+			return records, err
+		}
+
+		records = append(records, found...)
+	}
+
+	return records, nil
+}
+
+// Lists the active leaderboards of the game. The leaderboards aren't indexed by game, so every key on the game instance is scanned
+func (c connection) ListLeaderboards(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+	rdb := c.reader(gameID)
+
+	keys, err := scanLeaderboardKeys(ctx, rdb)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboards := make([]leaderboard.Leaderboard, 0)
+	for _, key := range keys {
+		var lb Leaderboard
+		if err := rdb.HGetAll(ctx, key).Scan(&lb); err != nil {
+			return nil, err
+		}
+
+		if lb.GameID != gameID || lb.DeletedAt != nil {
+			continue
+		}
+
+		leaderboards = append(leaderboards, lb.toDomain())
+	}
+
+	return leaderboards, nil
+}
+
+// Stores the leaderboard keeping its ID. The ID field is set first, so an existing leaderboard is never overwritten
+func (c connection) RestoreLeaderboard(ctx context.Context, lb leaderboard.Leaderboard) error {
+	if err := c.writable(lb.GameID); err != nil {
+		return err
+	}
+
+	var (
+		rdb = c.writer(lb.GameID)
+		key = buildLeaderboardKey(c.leaderboardKeyID(lb.ID))
+	)
+
+	created, err := rdb.HSetNX(ctx, key, "id", lb.ID).Result()
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		return backup.ErrEntityAlreadyExists
+	}
+
+	return rdb.HSet(ctx, key, newLeaderboardFromDomain(lb)).Err()
+}
+
+// Lists the active leaderboards starting or ending within the interval. The leaderboards aren't indexed
+// by schedule, so every key on every instance is scanned
+func (c connection) ListLeaderboardsScheduledBetween(ctx context.Context, from, to time.Time) ([]leaderboard.Leaderboard, error) {
+	within := func(t time.Time) bool { return t.After(from) && !t.After(to) }
+
+	leaderboards := make([]leaderboard.Leaderboard, 0)
+	for _, rdb := range c.clients() {
+		keys, err := scanLeaderboardKeys(ctx, rdb)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			var lb Leaderboard
+			if err := rdb.HGetAll(ctx, key).Scan(&lb); err != nil {
+				return nil, err
+			}
+
+			if lb.DeletedAt != nil || !(within(lb.StartAt) || (lb.EndAt != nil && within(*lb.EndAt))) {
+				continue
+			}
+
+			leaderboards = append(leaderboards, lb.toDomain())
+		}
+	}
+
+	return leaderboards, nil
 }
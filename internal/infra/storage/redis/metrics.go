@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gabapcia/gameblitz/internal/infra/metrics"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Replaces the IDs on the key by `*`, so every leaderboard shares the same pattern, e.g. `leaderboard:*:ranking`
+func keyPattern(key string) string {
+	segments := strings.Split(key, ":")
+	for i, segment := range segments {
+		if _, err := uuid.Parse(segment); err == nil {
+			segments[i] = "*"
+		}
+	}
+
+	return strings.Join(segments, ":")
+}
+
+func commandKeyPattern(cmd redis.Cmder) string {
+	args := cmd.Args()
+	if len(args) < 2 {
+		return ""
+	}
+
+	return keyPattern(fmt.Sprint(args[1]))
+}
+
+// A missing key is reported as `redis.Nil`, which is an expected result and not a failure
+func commandError(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+
+	return err
+}
+
+// Records every command sent to the server, labelled by the key pattern it touches
+type metricsHook struct {
+	metrics *metrics.Storage
+}
+
+func (h metricsHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h metricsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		finish := h.metrics.Observe("redis", cmd.Name(), commandKeyPattern(cmd))
+
+		err := next(ctx, cmd)
+		finish(commandError(err))
+		return err
+	}
+}
+
+// The pipeline is recorded as a single operation, labelled by the key pattern of its first command
+func (h metricsHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		var collection string
+		if len(cmds) > 0 {
+			collection = commandKeyPattern(cmds[0])
+		}
+
+		finish := h.metrics.Observe("redis", "pipeline", collection)
+
+		err := next(ctx, cmds)
+		finish(commandError(err))
+		return err
+	}
+}
@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/signing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Holds the ID of the player the nonce was issued to, expiring with the nonce
+func buildNonceKey(gameID, nonce string) string {
+	return fmt.Sprintf("nonce:%s:%s", gameID, nonce)
+}
+
+// Deletes the nonce only when it was issued to the player, so a nonce sent by another player isn't spent
+var consumeNonceScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (c connection) CreateNonce(ctx context.Context, nonce signing.Nonce, ttl time.Duration) error {
+	if err := c.writable(nonce.GameID); err != nil {
+		return err
+	}
+
+	key := buildNonceKey(c.gameKeyID(nonce.GameID), nonce.Value)
+	return c.writer(nonce.GameID).Set(ctx, key, nonce.PlayerID, ttl).Err()
+}
+
+func (c connection) ConsumeNonce(ctx context.Context, gameID, playerID, nonce string) error {
+	if err := c.writable(gameID); err != nil {
+		return err
+	}
+
+	key := buildNonceKey(c.gameKeyID(gameID), nonce)
+	deleted, err := consumeNonceScript.Run(ctx, c.writer(gameID), []string{key}, playerID).Int64()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return signing.ErrNonceNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrPipelineClosed = errors.New("pipeline closed")
+
+// Client the batches are sent through
+type pipelineClient interface {
+	Pipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error)
+}
+
+// Queues the command on the pipeline, returning it so its result is read once the pipeline runs
+type pipelinedCmdFunc func(ctx context.Context, pipe redis.Pipeliner) redis.Cmder
+
+type pipelinedWrite struct {
+	cmd  pipelinedCmdFunc
+	done chan error
+}
+
+// Writes waiting to be sent together
+type pipelineBatch struct {
+	writes []pipelinedWrite
+}
+
+// Batches the concurrent writes to an instance into a single pipeline, sent once it reaches `size` writes or
+// `window` after its first one, whichever comes first. Each writer still waits for its own result
+type pipeline struct {
+	rdb    pipelineClient
+	window time.Duration
+	size   int
+
+	mu     sync.Mutex
+	batch  *pipelineBatch
+	closed bool
+}
+
+func newPipeline(rdb pipelineClient, window time.Duration, size int) *pipeline {
+	return &pipeline{rdb: rdb, window: window, size: size}
+}
+
+// Queues the write on the current batch and waits for the batch to run. A writer whose context is canceled stops
+// waiting, but its write is still sent with the batch. Fails with `ErrPipelineClosed` once the pipeline is closed
+func (p *pipeline) write(ctx context.Context, cmd pipelinedCmdFunc) error {
+	w := pipelinedWrite{cmd: cmd, done: make(chan error, 1)}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrPipelineClosed
+	}
+
+	if p.batch == nil {
+		batch := &pipelineBatch{writes: make([]pipelinedWrite, 0, p.size)}
+		p.batch = batch
+		time.AfterFunc(p.window, func() { p.flush(batch) })
+	}
+
+	p.batch.writes = append(p.batch.writes, w)
+	if len(p.batch.writes) >= p.size {
+		batch := p.batch
+		p.batch = nil
+		p.mu.Unlock()
+
+		p.exec(batch)
+	} else {
+		p.mu.Unlock()
+	}
+
+	select {
+	case err := <-w.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Runs the batch when the window ends, unless it was already run for reaching its size
+func (p *pipeline) flush(batch *pipelineBatch) {
+	p.mu.Lock()
+	if p.batch != batch {
+		p.mu.Unlock()
+		return
+	}
+
+	p.batch = nil
+	p.mu.Unlock()
+
+	p.exec(batch)
+}
+
+// The batch runs on its own context, as it's shared by writers that may be canceled while it runs. On a cluster,
+// the commands are grouped by node, so the batch takes a round trip to each node it touches
+func (p *pipeline) exec(batch *pipelineBatch) {
+	var (
+		ctx  = context.Background()
+		cmds = make([]redis.Cmder, len(batch.writes))
+	)
+
+	_, err := p.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, w := range batch.writes {
+			cmds[i] = w.cmd(ctx, pipe)
+		}
+
+		return nil
+	})
+
+	// The commands only carry their own errors when the pipeline was sent. When it wasn't, e.g. the connection failed,
+	// none of them ran, so every writer gets the pipeline error
+	sent := err == nil || slices.ContainsFunc(cmds, func(cmd redis.Cmder) bool { return cmd.Err() != nil })
+
+	for i, w := range batch.writes {
+		if sent {
+			w.done <- cmds[i].Err()
+		} else {
+			w.done <- err
+		}
+	}
+}
+
+// Runs the batch still waiting, so its writes aren't lost once the client is closed, and rejects the writes after it
+func (p *pipeline) close() {
+	p.mu.Lock()
+	batch := p.batch
+	p.batch, p.closed = nil, true
+	p.mu.Unlock()
+
+	if batch != nil {
+		p.exec(batch)
+	}
+}
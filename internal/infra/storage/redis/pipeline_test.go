@@ -0,0 +1,159 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// Client that runs the batches without a server, for the tests only. The commands never touch the pipe
+type fakePipelineClient struct {
+	mu      sync.Mutex
+	batches []int // Commands on each batch sent
+	err     error
+}
+
+func (c *fakePipelineClient) Pipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sent := 0
+	err := fn(countingPipeliner{sent: &sent})
+	c.batches = append(c.batches, sent)
+
+	return nil, errors.Join(err, c.err)
+}
+
+func (c *fakePipelineClient) sent() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]int(nil), c.batches...)
+}
+
+// Counts the commands queued on it
+type countingPipeliner struct {
+	redis.Pipeliner
+	sent *int
+}
+
+// Command failing with the error, or succeeding when it's nil
+func fakeCmd(err error) pipelinedCmdFunc {
+	return func(ctx context.Context, pipe redis.Pipeliner) redis.Cmder {
+		*pipe.(countingPipeliner).sent++
+
+		cmd := redis.NewStatusCmd(ctx)
+		cmd.SetErr(err)
+		return cmd
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sent Once Full", func(t *testing.T) {
+		var (
+			client = &fakePipelineClient{}
+			p      = newPipeline(client, time.Hour, 3)
+			wg     sync.WaitGroup
+		)
+
+		for range 3 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, p.write(ctx, fakeCmd(nil)))
+			}()
+		}
+
+		wg.Wait()
+		assert.Equal(t, []int{3}, client.sent())
+	})
+
+	t.Run("Sent Once The Window Ends", func(t *testing.T) {
+		var (
+			client = &fakePipelineClient{}
+			p      = newPipeline(client, 10*time.Millisecond, 10)
+			start  = time.Now()
+		)
+
+		assert.NoError(t, p.write(ctx, fakeCmd(nil)))
+		assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+		assert.Equal(t, []int{1}, client.sent())
+	})
+
+	t.Run("Command Errors", func(t *testing.T) {
+		var (
+			cmdErr = errors.New("any command error")
+			p      = newPipeline(&fakePipelineClient{err: cmdErr}, time.Hour, 2)
+			errs   = make([]error, 2)
+			wg     sync.WaitGroup
+		)
+
+		for i, err := range []error{nil, cmdErr} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = p.write(ctx, fakeCmd(err))
+			}()
+		}
+
+		wg.Wait()
+		assert.NoError(t, errs[0])
+		assert.ErrorIs(t, errs[1], cmdErr)
+	})
+
+	t.Run("Pipeline Error", func(t *testing.T) {
+		var (
+			pipeErr = errors.New("any connection error")
+			p       = newPipeline(&fakePipelineClient{err: pipeErr}, time.Millisecond, 10)
+		)
+
+		assert.ErrorIs(t, p.write(ctx, fakeCmd(nil)), pipeErr)
+	})
+
+	t.Run("Context Canceled", func(t *testing.T) {
+		var (
+			client      = &fakePipelineClient{}
+			p           = newPipeline(client, time.Hour, 10)
+			ctx, cancel = context.WithCancel(ctx)
+		)
+
+		cancel()
+		assert.ErrorIs(t, p.write(ctx, fakeCmd(nil)), context.Canceled)
+		assert.Empty(t, client.sent())
+
+		// Still sent with the batch
+		p.close()
+		assert.Equal(t, []int{1}, client.sent())
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		var (
+			client = &fakePipelineClient{}
+			p      = newPipeline(client, time.Hour, 10)
+			done   = make(chan error)
+		)
+
+		go func() { done <- p.write(ctx, fakeCmd(nil)) }()
+
+		assert.Eventually(t, func() bool {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+
+			return p.batch != nil
+		}, time.Second, time.Millisecond)
+
+		p.close()
+		assert.NoError(t, <-done)
+		assert.Equal(t, []int{1}, client.sent())
+
+		assert.ErrorIs(t, p.write(ctx, fakeCmd(nil)), ErrPipelineClosed)
+		assert.Equal(t, []int{1}, client.sent())
+	})
+}
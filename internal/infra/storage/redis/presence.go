@@ -0,0 +1,126 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/presence"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Exists while the player is online, expiring once the timeout goes by without a heartbeat
+func buildPresenceOnlineKey(gameID, playerID string) string {
+	return fmt.Sprintf("presence:%s:online:%s", gameID, playerID)
+}
+
+// Holds the player's last heartbeat, expiring after the retention
+func buildPresenceLastSeenKey(gameID, playerID string) string {
+	return fmt.Sprintf("presence:%s:lastseen:%s", gameID, playerID)
+}
+
+func (c connection) presenceKeys(gameID, playerID string) []string {
+	gameID = c.gameKeyID(gameID)
+	return []string{buildPresenceOnlineKey(gameID, playerID), buildPresenceLastSeenKey(gameID, playerID)}
+}
+
+func (c connection) RecordHeartbeat(ctx context.Context, p presence.Presence, timeout, retention time.Duration) error {
+	if err := c.writable(p.GameID); err != nil {
+		return err
+	}
+
+	keys := c.presenceKeys(p.GameID, p.PlayerID)
+	_, err := c.writer(p.GameID).Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, keys[0], 1, timeout)
+		pipe.Set(ctx, keys[1], p.LastSeenAt.UTC().Format(time.RFC3339Nano), retention)
+		return nil
+	})
+	return err
+}
+
+// Reads the keys of every player in a single command, as they share the game's slot
+func (c connection) ListPresences(ctx context.Context, gameID string, playerIDs []string) ([]presence.Presence, error) {
+	keys := make([]string, 0, len(playerIDs)*2)
+	for _, playerID := range playerIDs {
+		keys = append(keys, c.presenceKeys(gameID, playerID)...)
+	}
+
+	values, err := c.reader(gameID).MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	presences := make([]presence.Presence, 0)
+	for i, playerID := range playerIDs {
+		lastSeen, ok := values[i*2+1].(string)
+		if !ok {
+			continue
+		}
+
+		lastSeenAt, err := time.Parse(time.RFC3339Nano, lastSeen)
+		if err != nil {
+			return nil, err
+		}
+
+		presences = append(presences, presence.Presence{
+			GameID:     gameID,
+			PlayerID:   playerID,
+			Online:     values[i*2] != nil,
+			LastSeenAt: lastSeenAt,
+		})
+	}
+
+	return presences, nil
+}
+
+func (c connection) deletePresence(ctx context.Context, gameID, playerID string) (int64, error) {
+	if err := c.writable(gameID); err != nil {
+		return 0, err
+	}
+
+	// The online key never outlives the last seen one, so only the latter counts as the player's record
+	var (
+		keys     = c.presenceKeys(gameID, playerID)
+		lastSeen *redis.IntCmd
+	)
+	_, err := c.writer(gameID).Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys[0])
+		lastSeen = pipe.Del(ctx, keys[1])
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return lastSeen.Val(), nil
+}
+
+func (c connection) ErasePlayerPresence(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	records, err := c.deletePresence(ctx, gameID, playerID)
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	return privacy.Erasure{Data: privacy.DataPresence, Records: records}, nil
+}
+
+// The presence is short-lived, so it's removed instead of moved to the pseudonym
+func (c connection) AnonymizePlayerPresence(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	records, err := c.deletePresence(ctx, gameID, playerID)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	return privacy.Anonymization{Data: privacy.DataPresence, Records: records}, nil
+}
+
+func (c connection) CountPlayerPresence(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	records, err := c.reader(gameID).Exists(ctx, c.presenceKeys(gameID, playerID)[1]).Result()
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	return privacy.DataCount{Data: privacy.DataPresence, Records: records}, nil
+}
@@ -2,9 +2,12 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/privacy"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -13,41 +16,56 @@ func buildRankingKey(leaderboardID string) string {
 	return fmt.Sprintf("leaderboard:%s:ranking", leaderboardID)
 }
 
-func (c connection) incrementPlayerRankValue(ctx context.Context, leaderboardID, playerID string, value float64) error {
-	cursor := c.rdb.ZIncrBy(ctx, buildRankingKey(leaderboardID), value, playerID)
-	return cursor.Err()
-}
-
-func (c connection) setMaxPlayerRankValue(ctx context.Context, leaderboardID, playerID string, value float64) error {
-	cursor := c.rdb.ZAddGT(ctx, buildRankingKey(leaderboardID), redis.Z{Score: value, Member: playerID})
-	return cursor.Err()
-}
+// Command updating the player's value as the leaderboard aggregates it, run right away on a client or queued on a pipeline.
+// Nil for an invalid aggregation mode
+func (c connection) upsertPlayerRankValueCmd(ctx context.Context, rdb redis.Cmdable, lb leaderboard.Leaderboard, playerID string, value float64) redis.Cmder {
+	key := buildRankingKey(c.leaderboardKeyID(lb.ID))
 
-func (c connection) setMinPlayerRankValue(ctx context.Context, leaderboardID, playerID string, value float64) error {
-	cursor := c.rdb.ZAddLT(ctx, buildRankingKey(leaderboardID), redis.Z{Score: value, Member: playerID})
-	return cursor.Err()
-}
-
-func (c connection) UpsertPlayerRankValue(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
 	switch lb.AggregationMode {
 	case leaderboard.AggregationModeInc:
-		return c.incrementPlayerRankValue(ctx, lb.ID, playerID, value)
+		return rdb.ZIncrBy(ctx, key, value, playerID)
 	case leaderboard.AggregationModeMax:
-		return c.setMaxPlayerRankValue(ctx, lb.ID, playerID, value)
+		return rdb.ZAddGT(ctx, key, redis.Z{Score: value, Member: playerID})
 	case leaderboard.AggregationModeMin:
-		return c.setMinPlayerRankValue(ctx, lb.ID, playerID, value)
+		return rdb.ZAddLT(ctx, key, redis.Z{Score: value, Member: playerID})
 	default:
+		return nil
+	}
+}
+
+// With pipelining enabled, the update is batched with the concurrent ones to the same instance, and returns once its
+// pipeline is sent
+func (c connection) UpsertPlayerRankValue(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+	if err := c.writable(lb.GameID); err != nil {
+		return err
+	}
+
+	if !slices.Contains(leaderboard.AggregationModes, lb.AggregationMode) {
 		return leaderboard.ErrInvalidAggregationMode
 	}
+
+	if p := c.pipeline(lb.GameID); p != nil {
+		return p.write(ctx, func(ctx context.Context, pipe redis.Pipeliner) redis.Cmder {
+			return c.upsertPlayerRankValueCmd(ctx, pipe, lb, playerID, value)
+		})
+	}
+
+	return c.upsertPlayerRankValueCmd(ctx, c.writer(lb.GameID), lb, playerID, value).Err()
 }
 
-func (c connection) GetRanking(ctx context.Context, leaderboardID, ordering string, page, limit int64) ([]leaderboard.Rank, error) {
-	var cursor *redis.ZSliceCmd
-	switch ordering {
+func (c connection) GetRanking(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+	var (
+		start  = page * limit
+		stop   = start + limit - 1
+		key    = buildRankingKey(c.leaderboardKeyID(lb.ID))
+		cursor *redis.ZSliceCmd
+	)
+
+	switch lb.Ordering {
 	case leaderboard.OrderingAsc:
-		cursor = c.rdb.ZRangeWithScores(ctx, buildRankingKey(leaderboardID), page*limit, limit-1)
+		cursor = c.reader(lb.GameID).ZRangeWithScores(ctx, key, start, stop)
 	case leaderboard.OrderingDesc:
-		cursor = c.rdb.ZRevRangeWithScores(ctx, buildRankingKey(leaderboardID), page*limit, limit-1)
+		cursor = c.reader(lb.GameID).ZRevRangeWithScores(ctx, key, start, stop)
 	default:
 		return nil, leaderboard.ErrInvalidOrdering
 	}
@@ -60,12 +78,232 @@ func (c connection) GetRanking(ctx context.Context, leaderboardID, ordering stri
 	rankingFiltered := make([]leaderboard.Rank, len(data))
 	for i, d := range data {
 		rankingFiltered[i] = leaderboard.Rank{
-			LeaderboardID: leaderboardID,
+			LeaderboardID: lb.ID,
 			PlayerID:      d.Member.(string),
-			Position:      int64(i),
+			Position:      start + int64(i),
 			Value:         d.Score,
 		}
 	}
 
 	return rankingFiltered, nil
 }
+
+func (c connection) GetPlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+	var (
+		key = buildRankingKey(c.leaderboardKeyID(lb.ID))
+		rdb = c.reader(lb.GameID)
+	)
+
+	var position *redis.IntCmd
+	switch lb.Ordering {
+	case leaderboard.OrderingAsc:
+		position = rdb.ZRank(ctx, key, playerID)
+	case leaderboard.OrderingDesc:
+		position = rdb.ZRevRank(ctx, key, playerID)
+	default:
+		return leaderboard.Rank{}, leaderboard.ErrInvalidOrdering
+	}
+
+	if err := position.Err(); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+		}
+
+		return leaderboard.Rank{}, err
+	}
+
+	value, err := rdb.ZScore(ctx, key, playerID).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+		}
+
+		return leaderboard.Rank{}, err
+	}
+
+	return leaderboard.Rank{
+		LeaderboardID: lb.ID,
+		PlayerID:      playerID,
+		Position:      position.Val(),
+		Value:         value,
+	}, nil
+}
+
+func (c connection) DeletePlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+	if err := c.writable(lb.GameID); err != nil {
+		return err
+	}
+
+	return c.writer(lb.GameID).ZRem(ctx, buildRankingKey(c.leaderboardKeyID(lb.ID)), playerID).Err()
+}
+
+// Erases the player's ranks on every leaderboard of the game, including the soft deleted ones.
+// The leaderboards aren't indexed by game, so every key on the game instance is scanned
+func (c connection) ErasePlayerRanks(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+	if err := c.writable(gameID); err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	rdb := c.writer(gameID)
+
+	keys, err := scanLeaderboardKeys(ctx, rdb)
+	if err != nil {
+		return privacy.Erasure{}, err
+	}
+
+	erasure := privacy.Erasure{Data: privacy.DataRanks}
+	for _, key := range keys {
+		var lb Leaderboard
+		if err := rdb.HGetAll(ctx, key).Scan(&lb); err != nil {
+			return erasure, err
+		}
+
+		if lb.GameID != gameID {
+			continue
+		}
+
+		removed, err := rdb.ZRem(ctx, buildRankingKey(c.leaderboardKeyID(lb.ID)), playerID).Result()
+		if err != nil {
+			return erasure, err
+		}
+
+		erasure.Records += removed
+	}
+
+	return erasure, nil
+}
+
+// Moves the player's ranks on every leaderboard of the game, including the soft deleted ones, to the pseudonym.
+// The score is kept, but players tied with the pseudonym are ordered by member, so their order among them may change.
+// The leaderboards aren't indexed by game, so every key on the game instance is scanned
+func (c connection) AnonymizePlayerRanks(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+	if err := c.writable(gameID); err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	rdb := c.writer(gameID)
+
+	keys, err := scanLeaderboardKeys(ctx, rdb)
+	if err != nil {
+		return privacy.Anonymization{}, err
+	}
+
+	anonymization := privacy.Anonymization{Data: privacy.DataRanks}
+	for _, key := range keys {
+		var lb Leaderboard
+		if err := rdb.HGetAll(ctx, key).Scan(&lb); err != nil {
+			return anonymization, err
+		}
+
+		if lb.GameID != gameID {
+			continue
+		}
+
+		rankingKey := buildRankingKey(c.leaderboardKeyID(lb.ID))
+
+		score, err := rdb.ZScore(ctx, rankingKey, playerID).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+
+			return anonymization, err
+		}
+
+		// Both commands run as a single transaction, so the rank is never seen twice or missing
+		_, err = rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.ZAdd(ctx, rankingKey, redis.Z{Score: score, Member: pseudonym})
+			pipe.ZRem(ctx, rankingKey, playerID)
+			return nil
+		})
+		if err != nil {
+			return anonymization, err
+		}
+
+		anonymization.Records++
+	}
+
+	return anonymization, nil
+}
+
+// Counts the player's ranks on every leaderboard of the game, including the soft deleted ones.
+// The leaderboards aren't indexed by game, so every key on the game instance is scanned
+func (c connection) CountPlayerRanks(ctx context.Context, gameID, playerID string) (privacy.DataCount, error) {
+	rdb := c.reader(gameID)
+
+	keys, err := scanLeaderboardKeys(ctx, rdb)
+	if err != nil {
+		return privacy.DataCount{}, err
+	}
+
+	count := privacy.DataCount{Data: privacy.DataRanks}
+	for _, key := range keys {
+		var lb Leaderboard
+		if err := rdb.HGetAll(ctx, key).Scan(&lb); err != nil {
+			return count, err
+		}
+
+		if lb.GameID != gameID {
+			continue
+		}
+
+		if err := rdb.ZScore(ctx, buildRankingKey(c.leaderboardKeyID(lb.ID)), playerID).Err(); err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+
+			return count, err
+		}
+
+		count.Records++
+	}
+
+	return count, nil
+}
+
+// Members written to the shadow ranking per command while it's rebuilt
+const rebuiltRanksBatchSize = 1000
+
+// Key the ranking is rebuilt on before it replaces the current one. It shares the leaderboard hash tag, so both keys
+// live on the same cluster slot
+func buildShadowRankingKey(leaderboardID string) string {
+	return buildRankingKey(leaderboardID) + ":shadow"
+}
+
+// The ranking is rebuilt on a shadow key, swapped in with a single `RENAME` once complete. Redis orders tied players
+// by member, so the update time of the ranks is not kept
+func (c connection) ReplaceRanking(ctx context.Context, lb leaderboard.Leaderboard, ranks []leaderboard.RebuiltRank) error {
+	if err := c.writable(lb.GameID); err != nil {
+		return err
+	}
+
+	var (
+		rdb       = c.writer(lb.GameID)
+		key       = buildRankingKey(c.leaderboardKeyID(lb.ID))
+		shadowKey = buildShadowRankingKey(c.leaderboardKeyID(lb.ID))
+	)
+
+	// A previous rebuild may have failed halfway through
+	if err := rdb.Del(ctx, shadowKey).Err(); err != nil {
+		return err
+	}
+
+	if len(ranks) == 0 {
+		return rdb.Del(ctx, key).Err()
+	}
+
+	for start := 0; start < len(ranks); start += rebuiltRanksBatchSize {
+		batch := ranks[start:min(start+rebuiltRanksBatchSize, len(ranks))]
+
+		members := make([]redis.Z, len(batch))
+		for i, rank := range batch {
+			members[i] = redis.Z{Score: rank.Value, Member: rank.PlayerID}
+		}
+
+		if err := rdb.ZAdd(ctx, shadowKey, members...).Err(); err != nil {
+			return err
+		}
+	}
+
+	return rdb.Rename(ctx, shadowKey, key).Err()
+}
@@ -0,0 +1,178 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	cachedTopRanksField  = "ranks"
+	cachedTopFullField   = "full"
+	cachedTopBoundField  = "bound"
+	cachedTopPlayerField = "player:"
+)
+
+type CachedRank struct {
+	PlayerID string  `json:"playerId"`
+	Value    float64 `json:"value"`
+}
+
+// Hash holding the cached top positions, alongside a field for each player on them and the value on the last one, so a
+// rank update is checked against them without reading the positions. It shares the leaderboard hash tag, so it lives on
+// the same cluster slot as the ranking, but not the `leaderboard:` prefix, so it's never scanned as a leaderboard
+func buildCachedTopKey(leaderboardID string) string {
+	return fmt.Sprintf("ranking-cache:%s:top", leaderboardID)
+}
+
+// Counter bumped by each invalidation of the cached top positions. It doesn't expire with them, so it's never reset
+// while a miss is being cached, and it's removed with the leaderboard once purged
+func buildCacheGenerationKey(leaderboardID string) string {
+	return fmt.Sprintf("ranking-cache:%s:generation", leaderboardID)
+}
+
+// Replaces the cached top positions only when the generation is still the one read on the miss, missing counting as zero
+var cacheTopScript = redis.NewScript(`
+if (redis.call("GET", KEYS[2]) or "0") ~= ARGV[1] then
+	return 0
+end
+redis.call("DEL", KEYS[1])
+redis.call("HSET", KEYS[1], unpack(ARGV, 3))
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+return 1
+`)
+
+func (c connection) GetCachedTop(ctx context.Context, lb leaderboard.Leaderboard) (leaderboard.CachedTop, bool, error) {
+	var (
+		generationCmd *redis.StringCmd
+		ranksCmd      *redis.StringCmd
+		id            = c.leaderboardKeyID(lb.ID)
+	)
+
+	_, err := c.reader(lb.GameID).Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		generationCmd = pipe.Get(ctx, buildCacheGenerationKey(id))
+		ranksCmd = pipe.HGet(ctx, buildCachedTopKey(id), cachedTopRanksField)
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return leaderboard.CachedTop{}, false, err
+	}
+
+	var top leaderboard.CachedTop
+	if err := generationCmd.Err(); err == nil {
+		if top.Generation, err = generationCmd.Int64(); err != nil {
+			return leaderboard.CachedTop{}, false, err
+		}
+	}
+
+	data, err := ranksCmd.Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return top, false, nil
+		}
+
+		return leaderboard.CachedTop{}, false, err
+	}
+
+	var cached []CachedRank
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return leaderboard.CachedTop{}, false, err
+	}
+
+	top.Ranks = make([]leaderboard.Rank, len(cached))
+	for i, rank := range cached {
+		top.Ranks[i] = leaderboard.Rank{
+			LeaderboardID: lb.ID,
+			PlayerID:      rank.PlayerID,
+			Position:      int64(i),
+			Value:         rank.Value,
+		}
+	}
+
+	return top, true, nil
+}
+
+// While only the fallback is reachable, nothing is cached
+func (c connection) CacheTop(ctx context.Context, lb leaderboard.Leaderboard, top leaderboard.CachedTop, ttl time.Duration) error {
+	if c.writable(lb.GameID) != nil {
+		return nil
+	}
+
+	cached := make([]CachedRank, len(top.Ranks))
+	for i, rank := range top.Ranks {
+		cached[i] = CachedRank{PlayerID: rank.PlayerID, Value: rank.Value}
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+
+	args := make([]any, 0, 2*len(top.Ranks)+8)
+	args = append(args, top.Generation, ttl.Milliseconds(), cachedTopRanksField, data)
+	for _, rank := range top.Ranks {
+		args = append(args, cachedTopPlayerField+rank.PlayerID, 1)
+	}
+
+	if full := top.Full && len(top.Ranks) > 0; full {
+		args = append(args, cachedTopFullField, 1, cachedTopBoundField, top.Ranks[len(top.Ranks)-1].Value)
+	} else {
+		args = append(args, cachedTopFullField, 0)
+	}
+
+	id := c.leaderboardKeyID(lb.ID)
+	return cacheTopScript.Run(ctx, c.writer(lb.GameID), []string{buildCachedTopKey(id), buildCacheGenerationKey(id)}, args...).Err()
+}
+
+func (c connection) GetCachedTopBound(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.CachedTopBound, bool, error) {
+	values, err := c.reader(lb.GameID).HMGet(
+		ctx,
+		buildCachedTopKey(c.leaderboardKeyID(lb.ID)),
+		cachedTopFullField,
+		cachedTopBoundField,
+		cachedTopPlayerField+playerID,
+	).Result()
+	if err != nil {
+		return leaderboard.CachedTopBound{}, false, err
+	}
+
+	if values[0] == nil {
+		return leaderboard.CachedTopBound{}, false, nil
+	}
+
+	bound := leaderboard.CachedTopBound{
+		Full:     values[0] == "1",
+		Includes: values[2] != nil,
+	}
+
+	if value, ok := values[1].(string); ok {
+		if bound.Value, err = strconv.ParseFloat(value, 64); err != nil {
+			return leaderboard.CachedTopBound{}, false, err
+		}
+	}
+
+	return bound, true, nil
+}
+
+func (c connection) InvalidateCachedTop(ctx context.Context, lb leaderboard.Leaderboard) error {
+	if err := c.writable(lb.GameID); err != nil {
+		return err
+	}
+
+	id := c.leaderboardKeyID(lb.ID)
+
+	_, err := c.writer(lb.GameID).TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, buildCacheGenerationKey(id))
+		pipe.Del(ctx, buildCachedTopKey(id))
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,127 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Top view's positions, expiring when the view is due to be rebuilt. Like the cached top, it shares the leaderboard hash
+// tag but not the `leaderboard:` prefix
+func buildTopViewKey(leaderboardID string) string {
+	return fmt.Sprintf("ranking-view:%s:top", leaderboardID)
+}
+
+// Counter bumped by each save and drop of the top view. It doesn't expire with the view, so it's never reset while the
+// view is rebuilt, and it's removed with the leaderboard once purged
+func buildTopViewVersionKey(leaderboardID string) string {
+	return fmt.Sprintf("ranking-view:%s:version", leaderboardID)
+}
+
+// Saves the view only when the version is still the one it was read at, missing counting as zero. The expiry is only
+// set when there's no view stored, so the updates don't postpone the rebuild
+var saveTopViewScript = redis.NewScript(`
+if (redis.call("GET", KEYS[1]) or "0") ~= ARGV[1] then
+	return 0
+end
+redis.call("INCR", KEYS[1])
+if redis.call("EXISTS", KEYS[2]) == 1 then
+	redis.call("SET", KEYS[2], ARGV[2], "KEEPTTL")
+else
+	redis.call("SET", KEYS[2], ARGV[2], "PX", ARGV[3])
+end
+return 1
+`)
+
+func (c connection) GetTopView(ctx context.Context, lb leaderboard.Leaderboard) (leaderboard.TopView, bool, error) {
+	id := c.leaderboardKeyID(lb.ID)
+
+	values, err := c.reader(lb.GameID).MGet(ctx, buildTopViewVersionKey(id), buildTopViewKey(id)).Result()
+	if err != nil {
+		return leaderboard.TopView{}, false, err
+	}
+
+	var view leaderboard.TopView
+	if version, ok := values[0].(string); ok {
+		if view.Version, err = strconv.ParseInt(version, 10, 64); err != nil {
+			return leaderboard.TopView{}, false, err
+		}
+	}
+
+	data, ok := values[1].(string)
+	if !ok {
+		return view, false, nil
+	}
+
+	var cached []CachedRank
+	if err := json.Unmarshal([]byte(data), &cached); err != nil {
+		return leaderboard.TopView{}, false, err
+	}
+
+	view.Ranks = make([]leaderboard.Rank, len(cached))
+	for i, rank := range cached {
+		view.Ranks[i] = leaderboard.Rank{
+			LeaderboardID: lb.ID,
+			PlayerID:      rank.PlayerID,
+			Position:      int64(i),
+			Value:         rank.Value,
+		}
+	}
+
+	return view, true, nil
+}
+
+// While only the fallback is reachable, nothing is saved
+func (c connection) SaveTopView(ctx context.Context, lb leaderboard.Leaderboard, view leaderboard.TopView, ttl time.Duration) (bool, error) {
+	if c.writable(lb.GameID) != nil {
+		return false, nil
+	}
+
+	cached := make([]CachedRank, len(view.Ranks))
+	for i, rank := range view.Ranks {
+		cached[i] = CachedRank{PlayerID: rank.PlayerID, Value: rank.Value}
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return false, err
+	}
+
+	id := c.leaderboardKeyID(lb.ID)
+
+	saved, err := saveTopViewScript.Run(
+		ctx,
+		c.writer(lb.GameID),
+		[]string{buildTopViewVersionKey(id), buildTopViewKey(id)},
+		view.Version,
+		data,
+		ttl.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return false, err
+	}
+
+	return saved == 1, nil
+}
+
+func (c connection) DropTopView(ctx context.Context, lb leaderboard.Leaderboard) error {
+	if err := c.writable(lb.GameID); err != nil {
+		return err
+	}
+
+	id := c.leaderboardKeyID(lb.ID)
+
+	_, err := c.writer(lb.GameID).TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, buildTopViewVersionKey(id))
+		pipe.Del(ctx, buildTopViewKey(id))
+		return nil
+	})
+
+	return err
+}
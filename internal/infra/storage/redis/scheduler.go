@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func buildSchedulerLockKey(job string) string {
+	return fmt.Sprintf("scheduler:{%s}:lock", job)
+}
+
+func buildSchedulerLastRunKey(job string) string {
+	return fmt.Sprintf("scheduler:{%s}:lastrun", job)
+}
+
+var (
+	// Sets the owner on the lock key unless another owner holds it, returning the last run alongside whether it was taken.
+	// The keys share a hash tag, so they live on the same cluster slot
+	acquireSchedulerLockScript = redis.NewScript(`
+local owner = redis.call("GET", KEYS[1])
+if owner and owner ~= ARGV[1] then
+	return {0, redis.call("GET", KEYS[2]) or ""}
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return {1, redis.call("GET", KEYS[2]) or ""}
+`)
+
+	// Sets the last run only while the owner holds the lock
+	recordSchedulerRunScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("SET", KEYS[2], ARGV[2])
+end
+return 0
+`)
+)
+
+// The lock key expires on its own, so a lock left by a stopped instance is taken over once it does
+func (c connection) AcquireSchedulerLock(ctx context.Context, job, owner string, now, until time.Time) (scheduler.Lock, bool, error) {
+	if err := c.writable(""); err != nil {
+		return scheduler.Lock{}, false, err
+	}
+
+	keys := []string{buildSchedulerLockKey(job), buildSchedulerLastRunKey(job)}
+	result, err := acquireSchedulerLockScript.Run(ctx, c.rdb, keys, owner, until.Sub(now).Milliseconds()).Slice()
+	if err != nil {
+		return scheduler.Lock{}, false, err
+	}
+
+	lock := scheduler.Lock{Job: job}
+	if lastRun, _ := result[1].(string); lastRun != "" {
+		if lock.LastRunAt, err = time.Parse(time.RFC3339Nano, lastRun); err != nil {
+			return scheduler.Lock{}, false, err
+		}
+	}
+
+	if acquired, _ := result[0].(int64); acquired == 0 {
+		return lock, false, nil
+	}
+
+	lock.Owner = owner
+	lock.LockedUntil = until
+	return lock, true, nil
+}
+
+func (c connection) RecordSchedulerRun(ctx context.Context, job, owner string, ranAt time.Time) error {
+	if err := c.writable(""); err != nil {
+		return err
+	}
+
+	keys := []string{buildSchedulerLockKey(job), buildSchedulerLastRunKey(job)}
+	return recordSchedulerRunScript.Run(ctx, c.rdb, keys, owner, ranAt.UTC().Format(time.RFC3339Nano)).Err()
+}
@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Reports an operation that took longer than the slow query threshold
+type SlowQueryFunc func(operation, keyPattern string, duration time.Duration)
+
+// Reports the commands that took longer than the threshold to complete, by the key pattern they touch
+type slowQueryHook struct {
+	threshold   time.Duration
+	onSlowQuery SlowQueryFunc
+}
+
+func (h slowQueryHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h slowQueryHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+
+		err := next(ctx, cmd)
+		if duration := time.Since(start); duration > h.threshold {
+			h.onSlowQuery(cmd.Name(), commandKeyPattern(cmd), duration)
+		}
+
+		return err
+	}
+}
+
+// The pipeline is reported as a single operation, labelled by the key pattern of its first command
+func (h slowQueryHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+
+		err := next(ctx, cmds)
+		if duration := time.Since(start); duration > h.threshold {
+			var pattern string
+			if len(cmds) > 0 {
+				pattern = commandKeyPattern(cmds[0])
+			}
+
+			h.onSlowQuery("pipeline", pattern, duration)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,35 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/throttle"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Holds the submissions the player sent on the window, expiring once the window ends
+func buildSubmissionsKey(gameID, playerID string, window time.Time) string {
+	return fmt.Sprintf("submissions:%s:%s:%d", gameID, playerID, window.Unix())
+}
+
+// Sets the expiration along with the first increment, so a counter is never left without one
+var incrementSubmissionsScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIREAT", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+func (c connection) IncrementPlayerSubmissions(ctx context.Context, gameID, playerID string, window time.Time) (int64, error) {
+	if err := c.writable(gameID); err != nil {
+		return 0, err
+	}
+
+	key := buildSubmissionsKey(c.gameKeyID(gameID), playerID, window)
+	expiresAt := window.Add(throttle.Window).UnixMilli()
+	return incrementSubmissionsScript.Run(ctx, c.writer(gameID), []string{key}, expiresAt).Int64()
+}
@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"context"
+	"net"
+
+	"github.com/gabapcia/gameblitz/internal/infra/tracing"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Key pattern touched by the command. The arguments are not recorded, since they hold the players' data
+const keyPatternAttribute = attribute.Key("db.redis.key_pattern")
+
+// Starts a span for every command sent to the server, as a child of the span on the command context
+type tracingHook struct{}
+
+func (h tracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h tracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := tracing.StartClient(ctx, "redis "+cmd.Name(),
+			semconv.DBSystemRedis,
+			semconv.DBOperationName(cmd.Name()),
+			keyPatternAttribute.String(commandKeyPattern(cmd)),
+		)
+
+		err := next(ctx, cmd)
+		tracing.End(span, commandError(err))
+		return err
+	}
+}
+
+// The pipeline is traced as a single span, labelled by the key pattern of its first command
+func (h tracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		var pattern string
+		if len(cmds) > 0 {
+			pattern = commandKeyPattern(cmds[0])
+		}
+
+		ctx, span := tracing.StartClient(ctx, "redis pipeline",
+			semconv.DBSystemRedis,
+			semconv.DBOperationName("pipeline"),
+			keyPatternAttribute.String(pattern),
+		)
+
+		err := next(ctx, cmds)
+		tracing.End(span, commandError(err))
+		return err
+	}
+}
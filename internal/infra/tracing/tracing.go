@@ -0,0 +1,125 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name of the tracer every span of the service is started with
+const instrumentationName = "github.com/gabapcia/gameblitz"
+
+const defaultServiceName = "gameblitz"
+
+var ErrMissingEndpoint = errors.New("tracing requires an otlp endpoint")
+
+type Options struct {
+	Endpoint    string  // OTLP/HTTP collector address, e.g. `localhost:4318`
+	Insecure    bool    // Send the spans without TLS
+	ServiceName string  // Service the spans are reported from. Defaults to `gameblitz`
+	SampleRatio float64 // Ratio of the traces started by the service that are sampled, from 0 to 1. Traces started upstream follow the caller's decision
+}
+
+// Flushes the pending spans and stops the exporter
+type ShutdownFunc func(ctx context.Context) error
+
+// Registers the tracer provider exporting the spans via OTLP/HTTP and the W3C `traceparent` propagator.
+// Until it is called, every span started by the service is a no-op
+func Start(ctx context.Context, opts Options) (ShutdownFunc, error) {
+	if opts.Endpoint == "" {
+		return nil, ErrMissingEndpoint
+	}
+
+	if opts.ServiceName == "" {
+		opts.ServiceName = defaultServiceName
+	}
+
+	exporterOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(opts.ServiceName))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Marks the span as failed when `err` is set, then ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// Starts the span of a message published on `destination`, adding its context to the message headers
+// through the carrier, so the consumer's spans join the same trace
+func StartPublish(ctx context.Context, system, destination string, carrier propagation.TextMapCarrier) (context.Context, trace.Span) {
+	ctx, span := Tracer().Start(ctx, destination+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String(system),
+			semconv.MessagingDestinationName(destination),
+			semconv.MessagingOperationTypePublish,
+		),
+	)
+
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return ctx, span
+}
+
+// Starts the span of a batch of messages consumed from `destination`, linked to the span that published each of them.
+// A batch mixes messages from several traces, so it starts a trace of its own
+func StartProcess(ctx context.Context, system, destination string, carriers []propagation.TextMapCarrier) (context.Context, trace.Span) {
+	links := make([]trace.Link, 0, len(carriers))
+	for _, carrier := range carriers {
+		spanContext := trace.SpanContextFromContext(otel.GetTextMapPropagator().Extract(ctx, carrier))
+		if spanContext.IsValid() {
+			links = append(links, trace.Link{SpanContext: spanContext})
+		}
+	}
+
+	return Tracer().Start(ctx, destination+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithNewRoot(),
+		trace.WithLinks(links...),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String(system),
+			semconv.MessagingDestinationName(destination),
+			semconv.MessagingOperationTypeDeliver,
+			semconv.MessagingBatchMessageCount(len(carriers)),
+		),
+	)
+}
+
+// Starts the span of a storage command
+func StartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func useRecorder() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return recorder
+}
+
+func TestStart(t *testing.T) {
+	t.Run("Missing Endpoint", func(t *testing.T) {
+		_, err := Start(context.Background(), Options{})
+		assert.ErrorIs(t, err, ErrMissingEndpoint)
+	})
+}
+
+func TestEnd(t *testing.T) {
+	recorder := useRecorder()
+
+	_, span := Tracer().Start(context.Background(), "any span")
+	End(span, errors.New("any error"))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "any error", spans[0].Status().Description)
+}
+
+func TestStartPublishAndProcess(t *testing.T) {
+	var (
+		recorder = useRecorder()
+		ctx      = context.Background()
+		carriers = make([]propagation.TextMapCarrier, 2)
+		traceIDs = make([]trace.TraceID, 2)
+	)
+
+	for i := range carriers {
+		carrier := propagation.MapCarrier{}
+
+		_, span := StartPublish(ctx, "rabbitmq", "gameblitz.statistic", carrier)
+		span.End()
+
+		assert.NotEmpty(t, carrier.Get("traceparent"))
+		carriers[i] = carrier
+		traceIDs[i] = span.SpanContext().TraceID()
+	}
+
+	// A message published without a trace context is not linked
+	carriers = append(carriers, propagation.MapCarrier{})
+
+	_, span := StartProcess(ctx, "rabbitmq", "gameblitz.ingestion", carriers)
+	span.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+
+	process := spans[2]
+	assert.Equal(t, "gameblitz.ingestion process", process.Name())
+	assert.Equal(t, trace.SpanKindConsumer, process.SpanKind())
+	assert.False(t, process.Parent().IsValid())
+	assert.Len(t, process.Links(), 2)
+	for i, link := range process.Links() {
+		assert.Equal(t, traceIDs[i], link.SpanContext.TraceID())
+	}
+}
@@ -0,0 +1,463 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/ban"
+	"github.com/gabapcia/gameblitz/internal/delta"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+	"github.com/gabapcia/gameblitz/internal/segment"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/throttle"
+)
+
+const (
+	KindPlayerRank      = "PLAYER_RANK"
+	KindPlayerStatistic = "PLAYER_STATISTIC"
+)
+
+var (
+	ErrUnknownMessageKind  = errors.New("unknown message kind")
+	ErrInvalidPayload      = errors.New("invalid message payload")
+	ErrInvalidDeadLetterID = errors.New("invalid dead letter id")
+	ErrDeadLetterNotFound  = errors.New("dead letter not found")
+	ErrInvalidMaxAttempts  = errors.New("max attempts must be greater than zero")
+	ErrInvalidLimit        = errors.New("limit must be greater than zero")
+	ErrInvalidDedupTTL     = errors.New("deduplication ttl must be greater than zero")
+)
+
+type (
+	// Gameplay data sent by the game servers through the broker, applied the same way as the REST requests
+	Message struct {
+		ID       string // Message ID, assigned by the sender
+		Kind     string // Kind of the message, defines the payload format
+		GameID   string // Game the message belongs to
+		Payload  []byte // JSON encoded message data
+		Attempts int    // Times the message processing already failed
+	}
+
+	// Client the game server received the submission from
+	FingerprintPayload struct {
+		DeviceHash   string `json:"deviceHash"`   // Hash of the device ID, up to 128 characters
+		BuildVersion string `json:"buildVersion"` // Version of the client build, up to 64 characters
+		Region       string `json:"region"`       // Region derived from the client's IP, up to 64 characters
+	}
+
+	PlayerRankPayload struct {
+		LeaderboardID string             `json:"leaderboardId"` // Leaderboard the player is ranked on
+		PlayerID      string             `json:"playerId"`      // Player ID
+		Value         float64            `json:"value"`         // Value aggregated on the player rank
+		Fingerprint   FingerprintPayload `json:"fingerprint"`   // Client the submission came from. Optional
+	}
+
+	PlayerStatisticPayload struct {
+		StatisticID string  `json:"statisticId"` // Statistic the player progressed on
+		PlayerID    string  `json:"playerId"`    // Player ID
+		Value       float64 `json:"value"`       // Value aggregated on the player progression
+	}
+
+	// Messages waiting to be consumed on a queue
+	Backlog struct {
+		Queue    string // Queue name
+		Messages int64  // Messages waiting, approximated by brokers that can't count them exactly
+	}
+
+	// Backlog of a queue that crossed the alert threshold
+	BacklogAlert struct {
+		Backlog
+		Threshold int64 // Messages waiting above which the queue is alerted
+		Resolved  bool  // Whether the backlog went back under the threshold
+	}
+
+	// Message given up after failing too many times, kept to be inspected and requeued
+	DeadLetter struct {
+		FailedAt time.Time // Time of the last failure
+		ID       string    // Dead letter ID, assigned by the storage
+		Message  Message   // Message that failed
+		Error    string    // Error returned by the last attempt
+	}
+)
+
+// Errors that no retry can fix, so the message goes straight to the dead letters
+func isPermanent(err error) bool {
+	return errors.Is(err, ErrUnknownMessageKind) ||
+		errors.Is(err, ErrInvalidPayload) ||
+		errors.Is(err, leaderboard.ErrInvalidLeaderboardID) ||
+		errors.Is(err, leaderboard.ErrLeaderboardNotFound) ||
+		errors.Is(err, leaderboard.ErrLeaderboardClosed) ||
+		errors.Is(err, leaderboard.ErrInvalidFingerprint) ||
+		errors.Is(err, ban.ErrPlayerBanned) ||
+		errors.Is(err, ban.ErrDeviceBanned) ||
+		errors.Is(err, throttle.ErrSubmissionRateLimited) ||
+		errors.Is(err, segment.ErrPlayerNotEligible) ||
+		errors.Is(err, delta.ErrDeltaExceeded) ||
+		errors.Is(err, statistic.ErrInvalidStatisticID) ||
+		errors.Is(err, statistic.ErrStatisticNotFound)
+}
+
+func decodePayload(msg Message, payload any) error {
+	if err := json.Unmarshal(msg.Payload, payload); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	return nil
+}
+
+// Player the message refers to. Every kind of message carries the player ID
+func (m Message) PlayerID() (string, error) {
+	var payload struct {
+		PlayerID string `json:"playerId"`
+	}
+	if err := decodePayload(m, &payload); err != nil {
+		return "", err
+	}
+
+	return payload.PlayerID, nil
+}
+
+// Copy of the message submitted by another player. Only the player ID is replaced, the rest of the payload is kept as is
+func (m Message) WithPlayerID(playerID string) (Message, error) {
+	var payload map[string]json.RawMessage
+	if err := decodePayload(m, &payload); err != nil {
+		return Message{}, err
+	}
+
+	id, err := json.Marshal(playerID)
+	if err != nil {
+		return Message{}, err
+	}
+	payload["playerId"] = id
+
+	if m.Payload, err = json.Marshal(payload); err != nil {
+		return Message{}, err
+	}
+
+	return m, nil
+}
+
+func BuildHandleFunc(
+	getLeaderboardByIDAndGameIDFunc leaderboard.GetByIDAndGameIDFunc,
+	upsertPlayerRankFunc leaderboard.UpsertPlayerRankFunc,
+	getStatisticByIDAndGameIDFunc statistic.GetByIDAndGameIDFunc,
+	upsertPlayerProgressionFunc statistic.UpsertPlayerProgressionFunc,
+) HandleFunc {
+	return func(ctx context.Context, msg Message) error {
+		switch msg.Kind {
+		case KindPlayerRank:
+			var payload PlayerRankPayload
+			if err := decodePayload(msg, &payload); err != nil {
+				return err
+			}
+
+			fingerprint := leaderboard.Fingerprint(payload.Fingerprint)
+			if err := fingerprint.Validate(); err != nil {
+				return err
+			}
+
+			lb, err := getLeaderboardByIDAndGameIDFunc(ctx, payload.LeaderboardID, msg.GameID)
+			if err != nil {
+				return err
+			}
+
+			ctx = leaderboard.WithFingerprint(leaderboard.WithSource(ctx, leaderboard.SourceIngestion), fingerprint)
+			return upsertPlayerRankFunc(ctx, lb, payload.PlayerID, payload.Value)
+		case KindPlayerStatistic:
+			var payload PlayerStatisticPayload
+			if err := decodePayload(msg, &payload); err != nil {
+				return err
+			}
+
+			st, err := getStatisticByIDAndGameIDFunc(ctx, payload.StatisticID, msg.GameID)
+			if err != nil {
+				return err
+			}
+
+			return upsertPlayerProgressionFunc(ctx, st, payload.PlayerID, payload.Value)
+		default:
+			return fmt.Errorf("%w: %s", ErrUnknownMessageKind, msg.Kind)
+		}
+	}
+}
+
+// Statistic messages are applied together with a single bulk write, while the other kinds are handled one by one.
+// The bulk upsert can be nil when the statistic storage has no bulk writes, handling every message one by one
+func BuildHandleBatchFunc(
+	getStatisticByIDAndGameIDFunc statistic.GetByIDAndGameIDFunc,
+	bulkUpsertPlayerProgressionFunc statistic.BulkUpsertPlayerProgressionFunc,
+	handleFunc HandleFunc,
+) HandleBatchFunc {
+	type statisticKey struct{ id, gameID string }
+
+	return func(ctx context.Context, msgs []Message) []error {
+		var (
+			errs       = make([]error, len(msgs))
+			changes    = make([]statistic.PlayerProgressionChange, 0, len(msgs))
+			indexes    = make([]int, 0, len(msgs)) // Message index of each change
+			statistics = make(map[statisticKey]statistic.Statistic)
+		)
+		for i, msg := range msgs {
+			if bulkUpsertPlayerProgressionFunc == nil || msg.Kind != KindPlayerStatistic {
+				errs[i] = handleFunc(ctx, msg)
+				continue
+			}
+
+			var payload PlayerStatisticPayload
+			if err := decodePayload(msg, &payload); err != nil {
+				errs[i] = err
+				continue
+			}
+
+			key := statisticKey{id: payload.StatisticID, gameID: msg.GameID}
+			st, ok := statistics[key]
+			if !ok {
+				var err error
+				if st, err = getStatisticByIDAndGameIDFunc(ctx, payload.StatisticID, msg.GameID); err != nil {
+					errs[i] = err
+					continue
+				}
+
+				statistics[key] = st
+			}
+
+			changes = append(changes, statistic.PlayerProgressionChange{Statistic: st, PlayerID: payload.PlayerID, Value: payload.Value})
+			indexes = append(indexes, i)
+		}
+
+		if len(changes) == 0 {
+			return errs
+		}
+
+		for j, err := range bulkUpsertPlayerProgressionFunc(ctx, changes) {
+			errs[indexes[j]] = err
+		}
+
+		return errs
+	}
+}
+
+// Skips the messages already handled, so a message redelivered by the broker is not applied twice.
+// The message ID is claimed for `ttl` before handling it, and released when the handling fails so the retries
+// can claim it again. Messages without an ID can not be told apart and are always handled
+func BuildDeduplicatedHandleFunc(
+	ttl time.Duration,
+	storageClaimMessageFunc StorageClaimMessageFunc,
+	storageReleaseMessageFunc StorageReleaseMessageFunc,
+	handleFunc HandleFunc,
+) (HandleFunc, error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidDedupTTL
+	}
+
+	return func(ctx context.Context, msg Message) error {
+		if msg.ID == "" {
+			return handleFunc(ctx, msg)
+		}
+
+		claimed, err := storageClaimMessageFunc(ctx, msg.ID, ttl)
+		if err != nil {
+			return err
+		}
+
+		if !claimed {
+			return nil
+		}
+
+		if err := handleFunc(ctx, msg); err != nil {
+			if releaseErr := storageReleaseMessageFunc(ctx, msg.ID); releaseErr != nil {
+				return errors.Join(err, releaseErr)
+			}
+
+			return err
+		}
+
+		return nil
+	}, nil
+}
+
+// Same as `BuildDeduplicatedHandleFunc`, claiming each message of the batch before handling the ones claimed together
+func BuildDeduplicatedHandleBatchFunc(
+	ttl time.Duration,
+	storageClaimMessageFunc StorageClaimMessageFunc,
+	storageReleaseMessageFunc StorageReleaseMessageFunc,
+	handleBatchFunc HandleBatchFunc,
+) (HandleBatchFunc, error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidDedupTTL
+	}
+
+	return func(ctx context.Context, msgs []Message) []error {
+		var (
+			errs    = make([]error, len(msgs))
+			claimed = make([]Message, 0, len(msgs))
+			indexes = make([]int, 0, len(msgs)) // Batch index of each claimed message
+		)
+		for i, msg := range msgs {
+			if msg.ID != "" {
+				ok, err := storageClaimMessageFunc(ctx, msg.ID, ttl)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+
+				if !ok {
+					continue
+				}
+			}
+
+			claimed = append(claimed, msg)
+			indexes = append(indexes, i)
+		}
+
+		if len(claimed) == 0 {
+			return errs
+		}
+
+		for j, err := range handleBatchFunc(ctx, claimed) {
+			if err != nil && claimed[j].ID != "" {
+				if releaseErr := storageReleaseMessageFunc(ctx, claimed[j].ID); releaseErr != nil {
+					err = errors.Join(err, releaseErr)
+				}
+			}
+
+			errs[indexes[j]] = err
+		}
+
+		return errs
+	}, nil
+}
+
+// Moves the failed message to the dead letters when the error is permanent or it has no attempts left,
+// returning the error only when the message must be consumed again. A submission held for review is
+// handled, so its message is consumed without a dead letter
+func giveUpOrRetry(ctx context.Context, maxAttempts int, msg Message, err error, storageCreateDeadLetterFunc StorageCreateDeadLetterFunc) error {
+	if errors.Is(err, quarantine.ErrSubmissionQuarantined) {
+		return nil
+	}
+
+	msg.Attempts++
+	if msg.Attempts < maxAttempts && !isPermanent(err) {
+		return err
+	}
+
+	_, storeErr := storageCreateDeadLetterFunc(ctx, DeadLetter{
+		FailedAt: time.Now().UTC(),
+		Message:  msg,
+		Error:    err.Error(),
+	})
+	if storeErr != nil {
+		return errors.Join(err, storeErr)
+	}
+
+	return nil
+}
+
+func BuildProcessFunc(maxAttempts int, handleFunc HandleFunc, storageCreateDeadLetterFunc StorageCreateDeadLetterFunc) (ProcessFunc, error) {
+	if maxAttempts <= 0 {
+		return nil, ErrInvalidMaxAttempts
+	}
+
+	return func(ctx context.Context, msg Message) error {
+		err := handleFunc(ctx, msg)
+		if err == nil {
+			return nil
+		}
+
+		return giveUpOrRetry(ctx, maxAttempts, msg, err, storageCreateDeadLetterFunc)
+	}, nil
+}
+
+func BuildProcessBatchFunc(maxAttempts int, handleBatchFunc HandleBatchFunc, storageCreateDeadLetterFunc StorageCreateDeadLetterFunc) (ProcessBatchFunc, error) {
+	if maxAttempts <= 0 {
+		return nil, ErrInvalidMaxAttempts
+	}
+
+	return func(ctx context.Context, msgs []Message) []error {
+		errs := handleBatchFunc(ctx, msgs)
+		for i, err := range errs {
+			if err != nil {
+				errs[i] = giveUpOrRetry(ctx, maxAttempts, msgs[i], err, storageCreateDeadLetterFunc)
+			}
+		}
+
+		return errs
+	}, nil
+}
+
+func BuildListDeadLettersFunc(storageListDeadLettersFunc StorageListDeadLettersFunc) ListDeadLettersFunc {
+	return func(ctx context.Context, limit int) ([]DeadLetter, error) {
+		if limit <= 0 {
+			return nil, ErrInvalidLimit
+		}
+
+		return storageListDeadLettersFunc(ctx, limit)
+	}
+}
+
+func BuildRequeueDeadLetterFunc(
+	storageGetDeadLetterFunc StorageGetDeadLetterFunc,
+	storageDeleteDeadLetterFunc StorageDeleteDeadLetterFunc,
+	publisherMessageFunc PublisherMessageFunc,
+) RequeueDeadLetterFunc {
+	return func(ctx context.Context, id string) error {
+		deadLetter, err := storageGetDeadLetterFunc(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		msg := deadLetter.Message
+		msg.Attempts = 0
+
+		if err := publisherMessageFunc(ctx, msg); err != nil {
+			return err
+		}
+
+		return storageDeleteDeadLetterFunc(ctx, id)
+	}
+}
+
+// Alerts once when a queue goes over the threshold and once when it goes back under it, instead of on every measure.
+// A failed alert is sent again on the next measure. Without a threshold or a notifier the backlog is only measured
+func BuildMonitorBacklogFunc(threshold int64, brokerMeasureBacklogFunc BrokerMeasureBacklogFunc, notifierBacklogAlertFunc NotifierBacklogAlertFunc) MonitorBacklogFunc {
+	var (
+		mu       sync.Mutex
+		alerting = make(map[string]bool) // Queues over the threshold, by name
+	)
+
+	return func(ctx context.Context) ([]Backlog, error) {
+		backlogs, err := brokerMeasureBacklogFunc(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if threshold <= 0 || notifierBacklogAlertFunc == nil {
+			return backlogs, nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		errs := make([]error, 0)
+		for _, backlog := range backlogs {
+			over := backlog.Messages > threshold
+			if over == alerting[backlog.Queue] {
+				continue
+			}
+
+			alert := BacklogAlert{Backlog: backlog, Threshold: threshold, Resolved: !over}
+			if err := notifierBacklogAlertFunc(ctx, alert); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", backlog.Queue, err))
+				continue
+			}
+
+			alerting[backlog.Queue] = over
+		}
+
+		return backlogs, errors.Join(errs...)
+	}
+}
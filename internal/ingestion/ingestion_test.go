@@ -0,0 +1,739 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMessage(t *testing.T, kind string, payload any) Message {
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	return Message{ID: uuid.NewString(), Kind: kind, GameID: uuid.NewString(), Payload: data}
+}
+
+func TestBuildHandleFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		getLeaderboard = func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		}
+		getStatistic = func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+			return statistic.Statistic{ID: id, GameID: gameID}, nil
+		}
+	)
+
+	t.Run("Player Rank", func(t *testing.T) {
+		var (
+			payload = PlayerRankPayload{LeaderboardID: uuid.NewString(), PlayerID: uuid.NewString(), Value: 10, Fingerprint: FingerprintPayload{DeviceHash: "f00d", Region: "BR"}}
+			msg     = newMessage(t, KindPlayerRank, payload)
+			called  bool
+		)
+
+		handleFunc := BuildHandleFunc(
+			getLeaderboard,
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+				called = true
+				assert.Equal(t, payload.LeaderboardID, lb.ID)
+				assert.Equal(t, msg.GameID, lb.GameID)
+				assert.Equal(t, payload.PlayerID, playerID)
+				assert.Equal(t, payload.Value, value)
+				assert.Equal(t, leaderboard.Fingerprint{DeviceHash: "f00d", Region: "BR"}, leaderboard.FingerprintFromContext(ctx))
+				return nil
+			},
+			getStatistic,
+			nil,
+		)
+
+		err := handleFunc(ctx, msg)
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("Player Statistic", func(t *testing.T) {
+		var (
+			payload = PlayerStatisticPayload{StatisticID: uuid.NewString(), PlayerID: uuid.NewString(), Value: 2}
+			msg     = newMessage(t, KindPlayerStatistic, payload)
+			called  bool
+		)
+
+		handleFunc := BuildHandleFunc(
+			getLeaderboard,
+			nil,
+			getStatistic,
+			func(ctx context.Context, st statistic.Statistic, playerID string, value float64) error {
+				called = true
+				assert.Equal(t, payload.StatisticID, st.ID)
+				assert.Equal(t, msg.GameID, st.GameID)
+				assert.Equal(t, payload.PlayerID, playerID)
+				assert.Equal(t, payload.Value, value)
+				return nil
+			},
+		)
+
+		err := handleFunc(ctx, msg)
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("Get Leaderboard Error", func(t *testing.T) {
+		handleFunc := BuildHandleFunc(
+			func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+			},
+			nil,
+			getStatistic,
+			nil,
+		)
+
+		err := handleFunc(ctx, newMessage(t, KindPlayerRank, PlayerRankPayload{}))
+		assert.ErrorIs(t, err, leaderboard.ErrLeaderboardNotFound)
+	})
+
+	t.Run("Invalid Fingerprint", func(t *testing.T) {
+		handleFunc := BuildHandleFunc(getLeaderboard, nil, getStatistic, nil)
+
+		err := handleFunc(ctx, newMessage(t, KindPlayerRank, PlayerRankPayload{Fingerprint: FingerprintPayload{Region: strings.Repeat("a", leaderboard.MaxRegionLength+1)}}))
+		assert.ErrorIs(t, err, leaderboard.ErrInvalidFingerprint)
+		assert.True(t, isPermanent(err))
+	})
+
+	t.Run("Invalid Payload", func(t *testing.T) {
+		handleFunc := BuildHandleFunc(getLeaderboard, nil, getStatistic, nil)
+
+		err := handleFunc(ctx, Message{Kind: KindPlayerStatistic, Payload: []byte("{")})
+		assert.ErrorIs(t, err, ErrInvalidPayload)
+	})
+
+	t.Run("Unknown Kind", func(t *testing.T) {
+		handleFunc := BuildHandleFunc(getLeaderboard, nil, getStatistic, nil)
+
+		err := handleFunc(ctx, Message{Kind: "ANY"})
+		assert.ErrorIs(t, err, ErrUnknownMessageKind)
+	})
+}
+
+func TestBuildHandleBatchFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		getStatistic = func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+			return statistic.Statistic{ID: id, GameID: gameID}, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			payload = PlayerStatisticPayload{StatisticID: uuid.NewString(), PlayerID: uuid.NewString(), Value: 2}
+			rank    = newMessage(t, KindPlayerRank, PlayerRankPayload{})
+			first   = newMessage(t, KindPlayerStatistic, payload)
+			second  = newMessage(t, KindPlayerStatistic, payload)
+			handled []string
+			lookups int
+		)
+		second.GameID = first.GameID
+
+		handleBatchFunc := BuildHandleBatchFunc(
+			func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+				lookups++
+				return getStatistic(ctx, id, gameID)
+			},
+			func(ctx context.Context, changes []statistic.PlayerProgressionChange) []error {
+				assert.Len(t, changes, 2)
+				for _, change := range changes {
+					assert.Equal(t, payload.StatisticID, change.Statistic.ID)
+					assert.Equal(t, payload.PlayerID, change.PlayerID)
+					assert.Equal(t, payload.Value, change.Value)
+				}
+				return make([]error, len(changes))
+			},
+			func(ctx context.Context, msg Message) error {
+				handled = append(handled, msg.ID)
+				return nil
+			},
+		)
+
+		errs := handleBatchFunc(ctx, []Message{first, rank, second})
+		assert.Equal(t, []error{nil, nil, nil}, errs)
+		assert.Equal(t, []string{rank.ID}, handled)
+		assert.Equal(t, 1, lookups)
+	})
+
+	t.Run("OK Without Bulk Upsert", func(t *testing.T) {
+		var (
+			msgs    = []Message{newMessage(t, KindPlayerStatistic, PlayerStatisticPayload{}), newMessage(t, KindPlayerRank, PlayerRankPayload{})}
+			handled int
+		)
+
+		handleBatchFunc := BuildHandleBatchFunc(getStatistic, nil, func(ctx context.Context, msg Message) error {
+			handled++
+			return nil
+		})
+
+		errs := handleBatchFunc(ctx, msgs)
+		assert.Equal(t, []error{nil, nil}, errs)
+		assert.Equal(t, 2, handled)
+	})
+
+	t.Run("Message Errors", func(t *testing.T) {
+		var (
+			errBulk = errors.New("any error")
+
+			handleBatchFunc = BuildHandleBatchFunc(
+				func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+					if id == "" {
+						return statistic.Statistic{}, statistic.ErrStatisticNotFound
+					}
+					return getStatistic(ctx, id, gameID)
+				},
+				func(ctx context.Context, changes []statistic.PlayerProgressionChange) []error {
+					return []error{errBulk}
+				},
+				nil,
+			)
+		)
+
+		errs := handleBatchFunc(ctx, []Message{
+			{Kind: KindPlayerStatistic, Payload: []byte("{")},
+			newMessage(t, KindPlayerStatistic, PlayerStatisticPayload{}),
+			newMessage(t, KindPlayerStatistic, PlayerStatisticPayload{StatisticID: uuid.NewString()}),
+		})
+		assert.ErrorIs(t, errs[0], ErrInvalidPayload)
+		assert.ErrorIs(t, errs[1], statistic.ErrStatisticNotFound)
+		assert.ErrorIs(t, errs[2], errBulk)
+	})
+}
+
+func TestBuildDeduplicatedHandleFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+		ttl = time.Hour
+		msg = Message{ID: uuid.NewString(), Kind: KindPlayerRank, GameID: uuid.NewString()}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var handled bool
+
+		handleFunc, err := BuildDeduplicatedHandleFunc(
+			ttl,
+			func(ctx context.Context, id string, claimTTL time.Duration) (bool, error) {
+				assert.Equal(t, msg.ID, id)
+				assert.Equal(t, ttl, claimTTL)
+				return true, nil
+			},
+			func(ctx context.Context, id string) error {
+				assert.Fail(t, "message released")
+				return nil
+			},
+			func(ctx context.Context, msg Message) error {
+				handled = true
+				return nil
+			},
+		)
+		assert.NoError(t, err)
+
+		err = handleFunc(ctx, msg)
+		assert.NoError(t, err)
+		assert.True(t, handled)
+	})
+
+	t.Run("Duplicated Message", func(t *testing.T) {
+		handleFunc, err := BuildDeduplicatedHandleFunc(
+			ttl,
+			func(ctx context.Context, id string, ttl time.Duration) (bool, error) { return false, nil },
+			nil,
+			func(ctx context.Context, msg Message) error {
+				assert.Fail(t, "message handled")
+				return nil
+			},
+		)
+		assert.NoError(t, err)
+
+		err = handleFunc(ctx, msg)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Message Without ID", func(t *testing.T) {
+		var handled bool
+
+		handleFunc, err := BuildDeduplicatedHandleFunc(
+			ttl,
+			func(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+				assert.Fail(t, "message claimed")
+				return false, nil
+			},
+			nil,
+			func(ctx context.Context, msg Message) error {
+				handled = true
+				return nil
+			},
+		)
+		assert.NoError(t, err)
+
+		err = handleFunc(ctx, Message{Kind: KindPlayerRank})
+		assert.NoError(t, err)
+		assert.True(t, handled)
+	})
+
+	t.Run("Handle Error", func(t *testing.T) {
+		var released bool
+
+		handleFunc, err := BuildDeduplicatedHandleFunc(
+			ttl,
+			func(ctx context.Context, id string, ttl time.Duration) (bool, error) { return true, nil },
+			func(ctx context.Context, id string) error {
+				released = true
+				assert.Equal(t, msg.ID, id)
+				return nil
+			},
+			func(ctx context.Context, msg Message) error { return errors.New("any error") },
+		)
+		assert.NoError(t, err)
+
+		err = handleFunc(ctx, msg)
+		assert.Error(t, err)
+		assert.True(t, released)
+	})
+
+	t.Run("Claim Error", func(t *testing.T) {
+		handleFunc, err := BuildDeduplicatedHandleFunc(
+			ttl,
+			func(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+				return false, errors.New("any error")
+			},
+			nil,
+			func(ctx context.Context, msg Message) error {
+				assert.Fail(t, "message handled")
+				return nil
+			},
+		)
+		assert.NoError(t, err)
+
+		err = handleFunc(ctx, msg)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid TTL", func(t *testing.T) {
+		_, err := BuildDeduplicatedHandleFunc(0, nil, nil, nil)
+		assert.ErrorIs(t, err, ErrInvalidDedupTTL)
+	})
+}
+
+func TestBuildDeduplicatedHandleBatchFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+		ttl = time.Hour
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			fresh     = Message{ID: uuid.NewString()}
+			duplicate = Message{ID: uuid.NewString()}
+			noID      = Message{}
+			failed    = Message{ID: uuid.NewString()}
+			errHandle = errors.New("any error")
+			released  []string
+		)
+
+		handleBatchFunc, err := BuildDeduplicatedHandleBatchFunc(
+			ttl,
+			func(ctx context.Context, id string, claimTTL time.Duration) (bool, error) {
+				assert.Equal(t, ttl, claimTTL)
+				return id != duplicate.ID, nil
+			},
+			func(ctx context.Context, id string) error {
+				released = append(released, id)
+				return nil
+			},
+			func(ctx context.Context, msgs []Message) []error {
+				assert.Equal(t, []Message{fresh, noID, failed}, msgs)
+				return []error{nil, nil, errHandle}
+			},
+		)
+		assert.NoError(t, err)
+
+		errs := handleBatchFunc(ctx, []Message{fresh, duplicate, noID, failed})
+		assert.Equal(t, []error{nil, nil, nil, errHandle}, errs)
+		assert.Equal(t, []string{failed.ID}, released)
+	})
+
+	t.Run("Claim Error", func(t *testing.T) {
+		errClaim := errors.New("any error")
+
+		handleBatchFunc, err := BuildDeduplicatedHandleBatchFunc(
+			ttl,
+			func(ctx context.Context, id string, claimTTL time.Duration) (bool, error) {
+				return false, errClaim
+			},
+			nil,
+			func(ctx context.Context, msgs []Message) []error {
+				assert.Fail(t, "batch handled")
+				return nil
+			},
+		)
+		assert.NoError(t, err)
+
+		errs := handleBatchFunc(ctx, []Message{{ID: uuid.NewString()}})
+		assert.Equal(t, []error{errClaim}, errs)
+	})
+
+	t.Run("Invalid TTL", func(t *testing.T) {
+		_, err := BuildDeduplicatedHandleBatchFunc(0, nil, nil, nil)
+		assert.ErrorIs(t, err, ErrInvalidDedupTTL)
+	})
+}
+
+func TestBuildProcessFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+		msg = Message{ID: uuid.NewString(), Kind: KindPlayerRank, GameID: uuid.NewString()}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		processFunc, err := BuildProcessFunc(
+			3,
+			func(ctx context.Context, msg Message) error { return nil },
+			func(ctx context.Context, deadLetter DeadLetter) (DeadLetter, error) {
+				assert.Fail(t, "dead letter stored")
+				return deadLetter, nil
+			},
+		)
+		assert.NoError(t, err)
+
+		err = processFunc(ctx, msg)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		processFunc, err := BuildProcessFunc(
+			3,
+			func(ctx context.Context, msg Message) error { return errors.New("any error") },
+			func(ctx context.Context, deadLetter DeadLetter) (DeadLetter, error) {
+				assert.Fail(t, "dead letter stored")
+				return deadLetter, nil
+			},
+		)
+		assert.NoError(t, err)
+
+		msg := msg
+		msg.Attempts = 1
+
+		err = processFunc(ctx, msg)
+		assert.Error(t, err)
+	})
+
+	t.Run("Max Attempts Reached", func(t *testing.T) {
+		var stored DeadLetter
+
+		processFunc, err := BuildProcessFunc(
+			3,
+			func(ctx context.Context, msg Message) error { return errors.New("any error") },
+			func(ctx context.Context, deadLetter DeadLetter) (DeadLetter, error) {
+				stored = deadLetter
+				return deadLetter, nil
+			},
+		)
+		assert.NoError(t, err)
+
+		msg := msg
+		msg.Attempts = 2
+
+		err = processFunc(ctx, msg)
+		assert.NoError(t, err)
+
+		assert.Equal(t, msg.ID, stored.Message.ID)
+		assert.Equal(t, 3, stored.Message.Attempts)
+		assert.Equal(t, "any error", stored.Error)
+		assert.False(t, stored.FailedAt.IsZero())
+	})
+
+	t.Run("Permanent Error", func(t *testing.T) {
+		var stored bool
+
+		processFunc, err := BuildProcessFunc(
+			3,
+			func(ctx context.Context, msg Message) error { return ErrInvalidPayload },
+			func(ctx context.Context, deadLetter DeadLetter) (DeadLetter, error) {
+				stored = true
+				return deadLetter, nil
+			},
+		)
+		assert.NoError(t, err)
+
+		err = processFunc(ctx, msg)
+		assert.NoError(t, err)
+		assert.True(t, stored)
+	})
+
+	t.Run("Submission Quarantined", func(t *testing.T) {
+		processFunc, err := BuildProcessFunc(
+			1,
+			func(ctx context.Context, msg Message) error { return quarantine.ErrSubmissionQuarantined },
+			func(ctx context.Context, deadLetter DeadLetter) (DeadLetter, error) {
+				assert.Fail(t, "dead letter stored")
+				return deadLetter, nil
+			},
+		)
+		assert.NoError(t, err)
+
+		err = processFunc(ctx, msg)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Store Dead Letter Error", func(t *testing.T) {
+		processFunc, err := BuildProcessFunc(
+			1,
+			func(ctx context.Context, msg Message) error { return errors.New("any error") },
+			func(ctx context.Context, deadLetter DeadLetter) (DeadLetter, error) {
+				return DeadLetter{}, errors.New("store error")
+			},
+		)
+		assert.NoError(t, err)
+
+		err = processFunc(ctx, msg)
+		assert.ErrorContains(t, err, "store error")
+	})
+
+	t.Run("Invalid Max Attempts", func(t *testing.T) {
+		_, err := BuildProcessFunc(0, nil, nil)
+		assert.ErrorIs(t, err, ErrInvalidMaxAttempts)
+	})
+}
+
+func TestBuildProcessBatchFunc(t *testing.T) {
+	var ctx = context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			ok        = Message{ID: uuid.NewString()}
+			retry     = Message{ID: uuid.NewString(), Attempts: 1}
+			exhausted = Message{ID: uuid.NewString(), Attempts: 2}
+			errHandle = errors.New("any error")
+			stored    []DeadLetter
+		)
+
+		processBatchFunc, err := BuildProcessBatchFunc(
+			3,
+			func(ctx context.Context, msgs []Message) []error {
+				return []error{nil, errHandle, errHandle}
+			},
+			func(ctx context.Context, deadLetter DeadLetter) (DeadLetter, error) {
+				stored = append(stored, deadLetter)
+				return deadLetter, nil
+			},
+		)
+		assert.NoError(t, err)
+
+		errs := processBatchFunc(ctx, []Message{ok, retry, exhausted})
+		assert.Equal(t, []error{nil, errHandle, nil}, errs)
+		assert.Len(t, stored, 1)
+		assert.Equal(t, exhausted.ID, stored[0].Message.ID)
+		assert.Equal(t, 3, stored[0].Message.Attempts)
+	})
+
+	t.Run("Invalid Max Attempts", func(t *testing.T) {
+		_, err := BuildProcessBatchFunc(0, nil, nil)
+		assert.ErrorIs(t, err, ErrInvalidMaxAttempts)
+	})
+}
+
+func TestBuildListDeadLettersFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		listFunc := BuildListDeadLettersFunc(func(ctx context.Context, limit int) ([]DeadLetter, error) {
+			assert.Equal(t, 10, limit)
+			return []DeadLetter{{ID: uuid.NewString()}}, nil
+		})
+
+		deadLetters, err := listFunc(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, deadLetters, 1)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		listFunc := BuildListDeadLettersFunc(nil)
+
+		_, err := listFunc(ctx, 0)
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+	})
+}
+
+func TestBuildRequeueDeadLetterFunc(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		deadLetter = DeadLetter{
+			ID:      uuid.NewString(),
+			Message: Message{ID: uuid.NewString(), Kind: KindPlayerRank, Attempts: 5},
+		}
+		getDeadLetter = func(ctx context.Context, id string) (DeadLetter, error) {
+			return deadLetter, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			published Message
+			deleted   string
+		)
+
+		requeueFunc := BuildRequeueDeadLetterFunc(
+			getDeadLetter,
+			func(ctx context.Context, id string) error {
+				deleted = id
+				return nil
+			},
+			func(ctx context.Context, msg Message) error {
+				published = msg
+				return nil
+			},
+		)
+
+		err := requeueFunc(ctx, deadLetter.ID)
+		assert.NoError(t, err)
+
+		assert.Equal(t, deadLetter.Message.ID, published.ID)
+		assert.Equal(t, 0, published.Attempts)
+		assert.Equal(t, deadLetter.ID, deleted)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		requeueFunc := BuildRequeueDeadLetterFunc(
+			func(ctx context.Context, id string) (DeadLetter, error) {
+				return DeadLetter{}, ErrDeadLetterNotFound
+			},
+			nil,
+			nil,
+		)
+
+		err := requeueFunc(ctx, uuid.NewString())
+		assert.ErrorIs(t, err, ErrDeadLetterNotFound)
+	})
+
+	t.Run("Publish Error", func(t *testing.T) {
+		requeueFunc := BuildRequeueDeadLetterFunc(
+			getDeadLetter,
+			func(ctx context.Context, id string) error {
+				assert.Fail(t, "dead letter deleted")
+				return nil
+			},
+			func(ctx context.Context, msg Message) error {
+				return errors.New("any error")
+			},
+		)
+
+		err := requeueFunc(ctx, deadLetter.ID)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildMonitorBacklogFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			backlogs = []Backlog{{Queue: "queue", Messages: 5}}
+			alerts   = make([]BacklogAlert, 0)
+		)
+
+		monitorFunc := BuildMonitorBacklogFunc(
+			10,
+			func(ctx context.Context) ([]Backlog, error) {
+				return backlogs, nil
+			},
+			func(ctx context.Context, alert BacklogAlert) error {
+				alerts = append(alerts, alert)
+				return nil
+			},
+		)
+
+		measured, err := monitorFunc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, backlogs, measured)
+		assert.Len(t, alerts, 0)
+
+		backlogs = []Backlog{{Queue: "queue", Messages: 20}}
+		_, err = monitorFunc(ctx)
+		assert.NoError(t, err)
+		_, err = monitorFunc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []BacklogAlert{{Backlog: backlogs[0], Threshold: 10}}, alerts)
+
+		backlogs = []Backlog{{Queue: "queue", Messages: 0}}
+		_, err = monitorFunc(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 2)
+		assert.True(t, alerts[1].Resolved)
+	})
+
+	t.Run("Alerts Disabled", func(t *testing.T) {
+		monitorFunc := BuildMonitorBacklogFunc(
+			0,
+			func(ctx context.Context) ([]Backlog, error) {
+				return []Backlog{{Queue: "queue", Messages: 20}}, nil
+			},
+			func(ctx context.Context, alert BacklogAlert) error {
+				t.Fatal("alert sent")
+				return nil
+			},
+		)
+
+		_, err := monitorFunc(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Alert Retried", func(t *testing.T) {
+		var (
+			alerts = 0
+			err    = errors.New("any error")
+		)
+
+		monitorFunc := BuildMonitorBacklogFunc(
+			10,
+			func(ctx context.Context) ([]Backlog, error) {
+				return []Backlog{{Queue: "queue", Messages: 20}}, nil
+			},
+			func(ctx context.Context, alert BacklogAlert) error {
+				alerts++
+				if alerts == 1 {
+					return err
+				}
+				return nil
+			},
+		)
+
+		_, monitorErr := monitorFunc(ctx)
+		assert.ErrorIs(t, monitorErr, err)
+
+		_, monitorErr = monitorFunc(ctx)
+		assert.NoError(t, monitorErr)
+		assert.Equal(t, 2, alerts)
+	})
+
+	t.Run("Measure Error", func(t *testing.T) {
+		err := errors.New("any error")
+
+		monitorFunc := BuildMonitorBacklogFunc(
+			10,
+			func(ctx context.Context) ([]Backlog, error) {
+				return nil, err
+			},
+			nil,
+		)
+
+		_, monitorErr := monitorFunc(ctx)
+		assert.ErrorIs(t, monitorErr, err)
+	})
+}
@@ -0,0 +1,14 @@
+package ingestion
+
+import "context"
+
+type (
+	// Sends the message back to the broker to be consumed again
+	PublisherMessageFunc func(ctx context.Context, msg Message) error
+
+	// Counts the messages waiting to be consumed on each queue the consumer reads from
+	BrokerMeasureBacklogFunc func(ctx context.Context) ([]Backlog, error)
+
+	// Notifies that the backlog of a queue went over the threshold, or back under it
+	NotifierBacklogAlertFunc func(ctx context.Context, alert BacklogAlert) error
+)
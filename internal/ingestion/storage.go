@@ -0,0 +1,26 @@
+package ingestion
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Stores a message given up, returning it with its ID
+	StorageCreateDeadLetterFunc func(ctx context.Context, deadLetter DeadLetter) (DeadLetter, error)
+
+	// Lists up to `limit` dead letters, oldest first
+	StorageListDeadLettersFunc func(ctx context.Context, limit int) ([]DeadLetter, error)
+
+	// Get a dead letter by its id
+	StorageGetDeadLetterFunc func(ctx context.Context, id string) (DeadLetter, error)
+
+	// Removes a dead letter
+	StorageDeleteDeadLetterFunc func(ctx context.Context, id string) error
+
+	// Marks the message as handled for `ttl`, returning false when it was already marked
+	StorageClaimMessageFunc func(ctx context.Context, id string, ttl time.Duration) (bool, error)
+
+	// Removes the mark from the message, so it can be handled again
+	StorageReleaseMessageFunc func(ctx context.Context, id string) error
+)
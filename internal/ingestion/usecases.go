@@ -0,0 +1,28 @@
+package ingestion
+
+import "context"
+
+type (
+	// Applies the message to the storages
+	HandleFunc func(ctx context.Context, msg Message) error
+
+	// Applies the messages to the storages, returning the error of each message in the same order
+	HandleBatchFunc func(ctx context.Context, msgs []Message) []error
+
+	// Handles the message, moving it to the dead letters once it failed too many times.
+	// An error means the message must be consumed again later
+	ProcessFunc func(ctx context.Context, msg Message) error
+
+	// Handles the messages, moving each one to the dead letters once it failed too many times.
+	// The errors are returned in the same order as the messages, an error means that message must be consumed again later
+	ProcessBatchFunc func(ctx context.Context, msgs []Message) []error
+
+	// Lists up to `limit` dead letters, oldest first
+	ListDeadLettersFunc func(ctx context.Context, limit int) ([]DeadLetter, error)
+
+	// Sends the dead letter back to be consumed, with its attempts reset, and removes it
+	RequeueDeadLetterFunc func(ctx context.Context, id string) error
+
+	// Measures the backlog of each consumed queue, alerting on the queues that crossed the threshold since the last measure
+	MonitorBacklogFunc func(ctx context.Context) ([]Backlog, error)
+)
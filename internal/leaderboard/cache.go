@@ -0,0 +1,170 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidCacheDepth = errors.New("cached positions must be from one to the max limit")
+	ErrInvalidCacheTTL   = errors.New("cache ttl must be greater than zero")
+)
+
+// How much of each ranking is cached, and for how long
+type CacheConfig struct {
+	Depth int64         // Top positions cached, up to `MaxLimitNumber`
+	TTL   time.Duration // Time the cached positions are kept, even without any rank update changing them
+}
+
+func (c CacheConfig) validate() error {
+	errList := make([]error, 0)
+
+	if c.Depth < MinLimitNumber || c.Depth > MaxLimitNumber {
+		errList = append(errList, ErrInvalidCacheDepth)
+	}
+
+	if c.TTL <= 0 {
+		errList = append(errList, ErrInvalidCacheTTL)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Top positions of a leaderboard ranking as cached
+type CachedTop struct {
+	Ranks      []Rank
+	Full       bool  // Every cached position was taken when the ranking was cached
+	Generation int64 // Bumped by each invalidation, so the positions read before one are never cached
+}
+
+// What a rank update is checked against to tell whether it changes the cached top positions
+type CachedTopBound struct {
+	Full     bool    // Every cached position was taken when the ranking was cached
+	Value    float64 // Value on the last cached position
+	Includes bool    // The player is on the cached positions
+}
+
+// Serves the ranking pages within the top cached positions from the cache, caching them from the storage on a miss.
+// They're only cached when no invalidation came since the miss, so the positions read before a rank update are never
+// cached past it. The other pages are always read from the storage
+func BuildCachedRankingFunc(
+	config CacheConfig,
+	storageGetCachedTopFunc StorageGetCachedTopFunc,
+	storageCacheTopFunc StorageCacheTopFunc,
+	storageGetRankingFunc StorageGetRankingFunc,
+) (StorageGetRankingFunc, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
+		start, end := page*limit, (page+1)*limit
+		if end > config.Depth {
+			return storageGetRankingFunc(ctx, lb, page, limit)
+		}
+
+		top, ok, err := storageGetCachedTopFunc(ctx, lb)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			if top.Ranks, err = storageGetRankingFunc(ctx, lb, 0, config.Depth); err != nil {
+				return nil, err
+			}
+
+			top.Full = int64(len(top.Ranks)) == config.Depth
+			if err := storageCacheTopFunc(ctx, lb, top, config.TTL); err != nil {
+				return nil, err
+			}
+		}
+
+		ranks := top.Ranks
+		return ranks[min(start, int64(len(ranks))):min(end, int64(len(ranks)))], nil
+	}, nil
+}
+
+// Wraps the rank update to drop the cached top positions once it may have changed them: when the player is on them,
+// they weren't all taken, or the player's value may move them onto the top. While nothing is cached, the invalidation
+// still stops the positions being cached from a read made before the update
+func BuildInvalidateCachedTopFunc(
+	config CacheConfig,
+	storageGetCachedTopBoundFunc StorageGetCachedTopBoundFunc,
+	storageInvalidateCachedTopFunc StorageInvalidateCachedTopFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	next StorageUpsertPlayerRankValueFunc,
+) StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb Leaderboard, playerID string, value float64) error {
+		unranked, err := lb.unranked(ctx, storageGetPlayerRankFunc, playerID, value)
+		if err != nil {
+			return err
+		}
+
+		if err := next(ctx, lb, playerID, value); err != nil {
+			return err
+		}
+
+		bound, ok, err := storageGetCachedTopBoundFunc(ctx, lb, playerID)
+		if err != nil {
+			return err
+		}
+
+		changed := !ok || bound.Includes || !bound.Full
+		if !changed {
+			var readBack bool
+			if changed, readBack = lb.mayReach(value, bound.Value, unranked); readBack {
+				rank, err := storageGetPlayerRankFunc(ctx, lb, playerID)
+				if err != nil {
+					return err
+				}
+
+				changed = rank.Position < config.Depth
+			}
+		}
+
+		if !changed {
+			return nil
+		}
+
+		return storageInvalidateCachedTopFunc(ctx, lb)
+	}
+}
+
+// Wraps the rank update to drop the cached top positions whatever it changes, for the writers that don't go through the
+// submissions, e.g. a ban putting back the ranks it hid
+func BuildInvalidateCachedTopOnUpsertFunc(storageInvalidateCachedTopFunc StorageInvalidateCachedTopFunc, next StorageUpsertPlayerRankValueFunc) StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb Leaderboard, playerID string, value float64) error {
+		if err := next(ctx, lb, playerID, value); err != nil {
+			return err
+		}
+
+		return storageInvalidateCachedTopFunc(ctx, lb)
+	}
+}
+
+// Wraps the rank removal to drop the cached top positions
+func BuildInvalidateCachedTopOnDeleteFunc(storageInvalidateCachedTopFunc StorageInvalidateCachedTopFunc, next StorageDeletePlayerRankFunc) StorageDeletePlayerRankFunc {
+	return func(ctx context.Context, lb Leaderboard, playerID string) error {
+		if err := next(ctx, lb, playerID); err != nil {
+			return err
+		}
+
+		return storageInvalidateCachedTopFunc(ctx, lb)
+	}
+}
+
+// Wraps the ranking replacement to drop the cached top positions
+func BuildInvalidateCachedTopOnReplaceFunc(storageInvalidateCachedTopFunc StorageInvalidateCachedTopFunc, next StorageReplaceRankingFunc) StorageReplaceRankingFunc {
+	return func(ctx context.Context, lb Leaderboard, ranks []RebuiltRank) error {
+		if err := next(ctx, lb, ranks); err != nil {
+			return err
+		}
+
+		return storageInvalidateCachedTopFunc(ctx, lb)
+	}
+}
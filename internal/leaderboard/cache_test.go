@@ -0,0 +1,266 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCachedRankingFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		lb     = Leaderboard{ID: "leaderboard", GameID: "game", AggregationMode: AggregationModeMax, Ordering: OrderingDesc}
+		config = CacheConfig{Depth: 4, TTL: time.Second}
+	)
+
+	ranking := []Rank{
+		{LeaderboardID: lb.ID, PlayerID: "a", Position: 0, Value: 50},
+		{LeaderboardID: lb.ID, PlayerID: "b", Position: 1, Value: 40},
+		{LeaderboardID: lb.ID, PlayerID: "c", Position: 2, Value: 30},
+	}
+
+	var (
+		cached      CachedTop
+		cachedOk    bool
+		generation  int64
+		storeHits   int
+		invalidated bool
+	)
+
+	getRanking, err := BuildCachedRankingFunc(
+		config,
+		func(ctx context.Context, lb Leaderboard) (CachedTop, bool, error) {
+			if !cachedOk {
+				return CachedTop{Generation: generation}, false, nil
+			}
+
+			return cached, true, nil
+		},
+		func(ctx context.Context, lb Leaderboard, top CachedTop, ttl time.Duration) error {
+			if top.Generation == generation {
+				cached, cachedOk = top, true
+			}
+
+			return nil
+		},
+		func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
+			storeHits++
+			if invalidated {
+				generation++
+			}
+
+			start := min(page*limit, int64(len(ranking)))
+			return ranking[start:min(start+limit, int64(len(ranking)))], nil
+		},
+	)
+	assert.NoError(t, err)
+
+	t.Run("Invalidated While Cached", func(t *testing.T) {
+		invalidated = true
+		defer func() { invalidated = false }()
+
+		ranks, err := getRanking(ctx, lb, 0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, ranking[:2], ranks)
+		assert.False(t, cachedOk)
+	})
+
+	t.Run("Miss", func(t *testing.T) {
+		ranks, err := getRanking(ctx, lb, 0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, ranking[:2], ranks)
+		assert.Equal(t, 2, storeHits)
+		assert.Equal(t, ranking, cached.Ranks)
+		assert.False(t, cached.Full)
+	})
+
+	t.Run("Hit", func(t *testing.T) {
+		ranks, err := getRanking(ctx, lb, 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, ranking[2:], ranks)
+		assert.Equal(t, 2, storeHits)
+	})
+
+	t.Run("Past The Cached Positions", func(t *testing.T) {
+		ranks, err := getRanking(ctx, lb, 1, 3)
+		assert.NoError(t, err)
+		assert.Empty(t, ranks)
+		assert.Equal(t, 3, storeHits)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildCachedRankingFunc(CacheConfig{Depth: MaxLimitNumber + 1}, nil, nil, nil)
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidCacheDepth)
+		assert.ErrorIs(t, err, ErrInvalidCacheTTL)
+	})
+}
+
+func TestBuildInvalidateCachedTopFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		config = CacheConfig{Depth: 10, TTL: time.Second}
+		bound  = CachedTopBound{Full: true, Value: 100}
+	)
+
+	run := func(lb Leaderboard, bound CachedTopBound, ok bool, value float64, ranked bool, position int64) (bool, error) {
+		var invalidated bool
+
+		upsert := BuildInvalidateCachedTopFunc(
+			config,
+			func(ctx context.Context, lb Leaderboard, playerID string) (CachedTopBound, bool, error) {
+				return bound, ok, nil
+			},
+			func(ctx context.Context, lb Leaderboard) error {
+				invalidated = true
+				return nil
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+				if !ranked {
+					return Rank{}, ErrPlayerRankNotFound
+				}
+
+				return Rank{PlayerID: playerID, Position: position}, nil
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string, value float64) error {
+				ranked = true
+				return nil
+			},
+		)
+
+		err := upsert(ctx, lb, "player", value)
+		return invalidated, err
+	}
+
+	var (
+		maxDesc = Leaderboard{AggregationMode: AggregationModeMax, Ordering: OrderingDesc}
+		maxAsc  = Leaderboard{AggregationMode: AggregationModeMax, Ordering: OrderingAsc}
+		minAsc  = Leaderboard{AggregationMode: AggregationModeMin, Ordering: OrderingAsc}
+		minDesc = Leaderboard{AggregationMode: AggregationModeMin, Ordering: OrderingDesc}
+		incDesc = Leaderboard{AggregationMode: AggregationModeInc, Ordering: OrderingDesc}
+	)
+
+	for _, tc := range []struct {
+		name        string
+		lb          Leaderboard
+		bound       CachedTopBound
+		ok          bool
+		value       float64
+		unranked    bool
+		position    int64
+		invalidated bool
+	}{
+		{name: "Not Cached", lb: maxDesc, bound: bound, ok: false, value: 1, invalidated: true},
+		{name: "Player On The Top", lb: maxDesc, bound: CachedTopBound{Full: true, Value: 100, Includes: true}, ok: true, value: 1, invalidated: true},
+		{name: "Top Not Full", lb: maxDesc, bound: CachedTopBound{}, ok: true, value: 1, invalidated: true},
+		{name: "Reaches The Top", lb: maxDesc, bound: bound, ok: true, value: 100, invalidated: true},
+		{name: "Below The Top", lb: maxDesc, bound: bound, ok: true, value: 99},
+		{name: "Reaches The Ascending Top", lb: minAsc, bound: bound, ok: true, value: 50, invalidated: true},
+		{name: "Below The Ascending Top", lb: minAsc, bound: bound, ok: true, value: 150},
+		{name: "Ranked Player On A MAX Ascending Top", lb: maxAsc, bound: bound, ok: true, value: 50},
+		{name: "New Player Reaches A MAX Ascending Top", lb: maxAsc, bound: bound, ok: true, value: 50, unranked: true, invalidated: true},
+		{name: "New Player Below A MAX Ascending Top", lb: maxAsc, bound: bound, ok: true, value: 150, unranked: true},
+		{name: "Ranked Player On A MIN Descending Top", lb: minDesc, bound: bound, ok: true, value: 150},
+		{name: "New Player Reaches A MIN Descending Top", lb: minDesc, bound: bound, ok: true, value: 150, unranked: true, invalidated: true},
+		{name: "New Player Below A MIN Descending Top", lb: minDesc, bound: bound, ok: true, value: 50, unranked: true},
+		{name: "Increment Into The Top", lb: incDesc, bound: bound, ok: true, value: 5, position: 9, invalidated: true},
+		{name: "Increment Below The Top", lb: incDesc, bound: bound, ok: true, value: 5, position: 10},
+		{name: "Decrement Below The Top", lb: incDesc, bound: bound, ok: true, value: -5, position: 0},
+		{name: "New Player Decrement Into The Top", lb: incDesc, bound: CachedTopBound{Full: true, Value: -10}, ok: true, value: -5, unranked: true, invalidated: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			invalidated, err := run(tc.lb, tc.bound, tc.ok, tc.value, !tc.unranked, tc.position)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.invalidated, invalidated)
+		})
+	}
+
+	t.Run("Upsert Error", func(t *testing.T) {
+		upsertErr := errors.New("any storage error")
+
+		upsert := BuildInvalidateCachedTopFunc(config, nil, nil, nil, func(ctx context.Context, lb Leaderboard, playerID string, value float64) error {
+			return upsertErr
+		})
+
+		assert.ErrorIs(t, upsert(ctx, maxDesc, "player", 1), upsertErr)
+	})
+}
+
+func TestBuildInvalidateCachedTopOnWriteFuncs(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		lb          = Leaderboard{ID: "leaderboard"}
+		invalidated int
+	)
+
+	invalidate := func(ctx context.Context, lb Leaderboard) error {
+		invalidated++
+		return nil
+	}
+
+	t.Run("Delete", func(t *testing.T) {
+		deleteRank := BuildInvalidateCachedTopOnDeleteFunc(invalidate, func(ctx context.Context, lb Leaderboard, playerID string) error {
+			return nil
+		})
+
+		assert.NoError(t, deleteRank(ctx, lb, "player"))
+		assert.Equal(t, 1, invalidated)
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		replace := BuildInvalidateCachedTopOnReplaceFunc(invalidate, func(ctx context.Context, lb Leaderboard, ranks []RebuiltRank) error {
+			return nil
+		})
+
+		assert.NoError(t, replace(ctx, lb, nil))
+		assert.Equal(t, 2, invalidated)
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		upsert := BuildInvalidateCachedTopOnUpsertFunc(invalidate, func(ctx context.Context, lb Leaderboard, playerID string, value float64) error {
+			return nil
+		})
+
+		assert.NoError(t, upsert(ctx, lb, "player", 10))
+		assert.Equal(t, 3, invalidated)
+	})
+
+	t.Run("Write Error", func(t *testing.T) {
+		writeErr := errors.New("any storage error")
+
+		deleteRank := BuildInvalidateCachedTopOnDeleteFunc(invalidate, func(ctx context.Context, lb Leaderboard, playerID string) error {
+			return writeErr
+		})
+
+		assert.ErrorIs(t, deleteRank(ctx, lb, "player"), writeErr)
+		assert.Equal(t, 3, invalidated)
+	})
+}
+
+func TestBuildDropGameTopsFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		dropErr = errors.New("any storage error")
+		dropped []string
+	)
+
+	dropGameTops := BuildDropGameTopsFunc(
+		func(ctx context.Context, gameID string) ([]Leaderboard, error) {
+			return []Leaderboard{{ID: "a", GameID: gameID}, {ID: "b", GameID: gameID}}, nil
+		},
+		func(ctx context.Context, lb Leaderboard) error {
+			dropped = append(dropped, lb.ID)
+			if lb.ID == "a" {
+				return dropErr
+			}
+
+			return nil
+		},
+	)
+
+	assert.ErrorIs(t, dropGameTops(ctx, "game"), dropErr)
+	assert.Equal(t, []string{"a", "b"}, dropped)
+}
@@ -0,0 +1,60 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Event pushed to a leaderboard stream
+type StreamEvent struct {
+	Event string // `RANK_CHANGED` or `CLOSED`
+	Rank  Rank   // Player's rank after the change. Only set on `RANK_CHANGED`
+}
+
+func BuildStreamEventsFunc(subscriberRankChanges SubscriberRankChanges, getPlayerRankFunc StorageGetPlayerRankFunc) StreamEventsFunc {
+	return func(ctx context.Context, lb Leaderboard, push func(event StreamEvent) error) error {
+		if lb.Ended() {
+			return push(StreamEvent{Event: EventClosed})
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		changes := subscriberRankChanges(ctx, lb.ID)
+
+		var closeAt <-chan time.Time
+		if !lb.EndAt.IsZero() {
+			timer := time.NewTimer(time.Until(lb.EndAt))
+			defer timer.Stop()
+
+			closeAt = timer.C
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-closeAt:
+				return push(StreamEvent{Event: EventClosed})
+			case change, ok := <-changes:
+				if !ok {
+					return nil
+				}
+
+				// The change carries the value submitted, the player's position comes from the aggregated rank
+				rank, err := getPlayerRankFunc(ctx, lb, change.PlayerID)
+				switch {
+				case errors.Is(err, ErrPlayerRankNotFound):
+					continue
+				case err != nil:
+					return err
+				}
+
+				if err := push(StreamEvent{Event: EventRankChanged, Rank: rank}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
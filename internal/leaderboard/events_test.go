@@ -0,0 +1,142 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildStreamEventsFunc(t *testing.T) {
+	var (
+		lb       = Leaderboard{ID: uuid.NewString(), GameID: uuid.NewString()}
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			lb      = lb
+			changes = make(chan RankChange, 2)
+			events  = make([]StreamEvent, 0)
+		)
+
+		lb.EndAt = time.Now().Add(50 * time.Millisecond)
+		changes <- RankChange{LeaderboardID: lb.ID, PlayerID: "unranked", Value: 1}
+		changes <- RankChange{LeaderboardID: lb.ID, PlayerID: playerID, Value: 10}
+
+		streamEventsFunc := BuildStreamEventsFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				assert.Equal(t, lb.ID, leaderboardID)
+				return changes
+			},
+			func(ctx context.Context, lb Leaderboard, id string) (Rank, error) {
+				if id != playerID {
+					return Rank{}, ErrPlayerRankNotFound
+				}
+
+				return Rank{PlayerID: id, Position: 2, Value: 30}, nil
+			},
+		)
+
+		err := streamEventsFunc(context.Background(), lb, func(event StreamEvent) error {
+			events = append(events, event)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []StreamEvent{
+			{Event: EventRankChanged, Rank: Rank{PlayerID: playerID, Position: 2, Value: 30}},
+			{Event: EventClosed},
+		}, events)
+	})
+
+	t.Run("Already Ended", func(t *testing.T) {
+		var (
+			lb     = lb
+			events = make([]StreamEvent, 0)
+		)
+
+		lb.EndAt = time.Now().Add(-time.Minute)
+
+		streamEventsFunc := BuildStreamEventsFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				assert.Fail(t, "subscribed to an ended leaderboard")
+				return nil
+			},
+			nil,
+		)
+
+		err := streamEventsFunc(context.Background(), lb, func(event StreamEvent) error {
+			events = append(events, event)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []StreamEvent{{Event: EventClosed}}, events)
+	})
+
+	t.Run("Context Done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		streamEventsFunc := BuildStreamEventsFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				cancel()
+				return make(chan RankChange)
+			},
+			nil,
+		)
+
+		err := streamEventsFunc(ctx, lb, func(event StreamEvent) error {
+			assert.Fail(t, "pushed after the context was done")
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Get Player Rank Error", func(t *testing.T) {
+		var (
+			changes     = make(chan RankChange, 1)
+			errExpected = errors.New("any error")
+		)
+
+		changes <- RankChange{LeaderboardID: lb.ID, PlayerID: playerID, Value: 10}
+
+		streamEventsFunc := BuildStreamEventsFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				return changes
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+				return Rank{}, errExpected
+			},
+		)
+
+		err := streamEventsFunc(context.Background(), lb, func(event StreamEvent) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("Push Error", func(t *testing.T) {
+		var (
+			changes     = make(chan RankChange, 1)
+			errExpected = errors.New("any error")
+		)
+
+		changes <- RankChange{LeaderboardID: lb.ID, PlayerID: playerID, Value: 10}
+
+		streamEventsFunc := BuildStreamEventsFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				return changes
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+				return Rank{PlayerID: playerID}, nil
+			},
+		)
+
+		err := streamEventsFunc(context.Background(), lb, func(event StreamEvent) error {
+			return errExpected
+		})
+		assert.ErrorIs(t, err, errExpected)
+	})
+}
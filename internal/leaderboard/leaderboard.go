@@ -5,6 +5,8 @@ import (
 	"errors"
 	"slices"
 	"time"
+
+	"github.com/gabapcia/gameblitz/internal/scheduler"
 )
 
 var (
@@ -27,6 +29,16 @@ const (
 
 	OrderingAsc  = "ASC"
 	OrderingDesc = "DESC"
+
+	EventCreated = "CREATED"
+	EventOpened  = "OPENED"
+	EventClosed  = "CLOSED"
+	EventDeleted = "DELETED"
+
+	EventRankChanged = "RANK_CHANGED"
+
+	// Name of the scheduled job notifying the leaderboards opened and closed
+	ScheduleEventsJobName = "leaderboard-schedule-events"
 )
 
 var (
@@ -104,13 +116,33 @@ func (l Leaderboard) Closed() bool {
 	return !l.DeletedAt.IsZero() || now.Before(l.StartAt) || (!l.EndAt.IsZero() && now.After(l.EndAt))
 }
 
-func BuildCreateFunc(storageCreateFunc StorageCreateLeaderboardFunc) CreateFunc {
+// Unlike `Closed`, a leaderboard that didn't start yet is not ended, as it will still be open
+func (l Leaderboard) Ended() bool {
+	return !l.DeletedAt.IsZero() || (!l.EndAt.IsZero() && !time.Now().Before(l.EndAt))
+}
+
+// Checks if the time is within the `(from, to]` interval
+func within(t, from, to time.Time) bool {
+	return !t.IsZero() && t.After(from) && !t.After(to)
+}
+
+// The notifier can be nil when the lifecycle events are not published
+func BuildCreateFunc(notifierLifecycleEvent NotifierLifecycleEvent, storageCreateFunc StorageCreateLeaderboardFunc) CreateFunc {
 	return func(ctx context.Context, data NewLeaderboardData) (Leaderboard, error) {
 		if err := data.validate(); err != nil {
 			return Leaderboard{}, err
 		}
 
-		return storageCreateFunc(ctx, data)
+		lb, err := storageCreateFunc(ctx, data)
+		if err != nil {
+			return Leaderboard{}, err
+		}
+
+		if notifierLifecycleEvent != nil {
+			return lb, notifierLifecycleEvent(ctx, EventCreated, lb)
+		}
+
+		return lb, nil
 	}
 }
 
@@ -120,8 +152,60 @@ func BuildGetByIDAndGameIDFunc(storageGetFunc StorageGetLeaderboardByIDAndGameID
 	}
 }
 
-func BuildSoftDeleteFunc(storageSoftDeleteFunc StorageSoftDeleteLeaderboardFunc) SoftDeleteFunc {
+// The notifier can be nil when the lifecycle events are not published
+func BuildSoftDeleteFunc(notifierLifecycleEvent NotifierLifecycleEvent, storageSoftDeleteFunc StorageSoftDeleteLeaderboardFunc) SoftDeleteFunc {
 	return func(ctx context.Context, id, gameID string) error {
-		return storageSoftDeleteFunc(ctx, id, gameID)
+		if err := storageSoftDeleteFunc(ctx, id, gameID); err != nil {
+			return err
+		}
+
+		if notifierLifecycleEvent != nil {
+			return notifierLifecycleEvent(ctx, EventDeleted, Leaderboard{DeletedAt: time.Now().UTC(), ID: id, GameID: gameID})
+		}
+
+		return nil
+	}
+}
+
+// Leaderboards open and close on their schedule, without any request, so the events
+// are notified by checking the leaderboards that started or ended within the interval
+func BuildNotifyScheduleFunc(notifierLifecycleEvent NotifierLifecycleEvent, storageListScheduledFunc StorageListLeaderboardsScheduledBetweenFunc) NotifyScheduleFunc {
+	return func(ctx context.Context, from, to time.Time) error {
+		leaderboards, err := storageListScheduledFunc(ctx, from, to)
+		if err != nil {
+			return err
+		}
+
+		for _, lb := range leaderboards {
+			if within(lb.StartAt, from, to) {
+				if err := notifierLifecycleEvent(ctx, EventOpened, lb); err != nil {
+					return err
+				}
+			}
+
+			if within(lb.EndAt, from, to) {
+				if err := notifierLifecycleEvent(ctx, EventClosed, lb); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// Notifies the leaderboards opened or closed since the job's last run, on any instance. The first run only marks
+// the start, as the leaderboards scheduled before it are not notified
+func BuildNotifyScheduleJob(interval time.Duration, notifyScheduleFunc NotifyScheduleFunc) scheduler.Job {
+	return scheduler.Job{
+		Name:     ScheduleEventsJobName,
+		Interval: interval,
+		Func: func(ctx context.Context, run scheduler.Run) error {
+			if run.LastRunAt.IsZero() {
+				return nil
+			}
+
+			return notifyScheduleFunc(ctx, run.LastRunAt, run.StartedAt)
+		},
 	}
 }
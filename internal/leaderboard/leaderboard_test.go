@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -82,6 +84,28 @@ func TestLeaderboardClosed(t *testing.T) {
 	})
 }
 
+func TestLeaderboardEnded(t *testing.T) {
+	t.Run("Leaderboard Deleted", func(t *testing.T) {
+		isEnded := Leaderboard{DeletedAt: time.Now()}.Ended()
+		assert.Equal(t, true, isEnded)
+	})
+
+	t.Run("Leaderboard Not Started", func(t *testing.T) {
+		isEnded := Leaderboard{StartAt: time.Now().Add(24 * time.Hour)}.Ended()
+		assert.Equal(t, false, isEnded)
+	})
+
+	t.Run("Leaderboard Ended", func(t *testing.T) {
+		isEnded := Leaderboard{EndAt: time.Now().Add(-24 * time.Hour)}.Ended()
+		assert.Equal(t, true, isEnded)
+	})
+
+	t.Run("Leaderboard Without End", func(t *testing.T) {
+		isEnded := Leaderboard{StartAt: time.Now().Add(-24 * time.Hour)}.Ended()
+		assert.Equal(t, false, isEnded)
+	})
+}
+
 func TestBuildCreateFunc(t *testing.T) {
 	var (
 		ctx          = context.Background()
@@ -98,7 +122,7 @@ func TestBuildCreateFunc(t *testing.T) {
 	)
 
 	t.Run("OK", func(t *testing.T) {
-		createFunc := BuildCreateFunc(func(ctx context.Context, data NewLeaderboardData) (Leaderboard, error) {
+		createFunc := BuildCreateFunc(nil, func(ctx context.Context, data NewLeaderboardData) (Leaderboard, error) {
 			return Leaderboard{
 				ID:              expectedID,
 				GameID:          data.GameID,
@@ -117,8 +141,44 @@ func TestBuildCreateFunc(t *testing.T) {
 		assert.Equal(t, expectedID, leaderboard.ID)
 	})
 
+	t.Run("OK With Notifier", func(t *testing.T) {
+		var notified Leaderboard
+
+		createFunc := BuildCreateFunc(
+			func(ctx context.Context, event string, lb Leaderboard) error {
+				assert.Equal(t, EventCreated, event)
+				notified = lb
+				return nil
+			},
+			func(ctx context.Context, data NewLeaderboardData) (Leaderboard, error) {
+				return Leaderboard{ID: expectedID, GameID: data.GameID}, nil
+			},
+		)
+
+		leaderboard, err := createFunc(ctx, expectedData)
+
+		assert.NoError(t, err)
+		assert.Equal(t, leaderboard, notified)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		createFunc := BuildCreateFunc(
+			func(ctx context.Context, event string, lb Leaderboard) error {
+				return errors.New("any error")
+			},
+			func(ctx context.Context, data NewLeaderboardData) (Leaderboard, error) {
+				return Leaderboard{ID: expectedID, GameID: data.GameID}, nil
+			},
+		)
+
+		leaderboard, err := createFunc(ctx, expectedData)
+
+		assert.Error(t, err)
+		assert.Equal(t, expectedID, leaderboard.ID)
+	})
+
 	t.Run("Validation Error", func(t *testing.T) {
-		createFunc := BuildCreateFunc(func(ctx context.Context, data NewLeaderboardData) (Leaderboard, error) {
+		createFunc := BuildCreateFunc(nil, func(ctx context.Context, data NewLeaderboardData) (Leaderboard, error) {
 			return Leaderboard{}, nil
 		})
 
@@ -129,7 +189,7 @@ func TestBuildCreateFunc(t *testing.T) {
 	})
 
 	t.Run("Random Error", func(t *testing.T) {
-		createFunc := BuildCreateFunc(func(ctx context.Context, data NewLeaderboardData) (Leaderboard, error) {
+		createFunc := BuildCreateFunc(nil, func(ctx context.Context, data NewLeaderboardData) (Leaderboard, error) {
 			return Leaderboard{}, errors.New("any error")
 		})
 
@@ -212,7 +272,7 @@ func TestBuildSoftDeleteFunc(t *testing.T) {
 	)
 
 	t.Run("OK", func(t *testing.T) {
-		softDeleteFunc := BuildSoftDeleteFunc(func(ctx context.Context, id, gameID string) error {
+		softDeleteFunc := BuildSoftDeleteFunc(nil, func(ctx context.Context, id, gameID string) error {
 			return nil
 		})
 
@@ -221,8 +281,46 @@ func TestBuildSoftDeleteFunc(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("OK With Notifier", func(t *testing.T) {
+		var notified Leaderboard
+
+		softDeleteFunc := BuildSoftDeleteFunc(
+			func(ctx context.Context, event string, lb Leaderboard) error {
+				assert.Equal(t, EventDeleted, event)
+				notified = lb
+				return nil
+			},
+			func(ctx context.Context, id, gameID string) error {
+				return nil
+			},
+		)
+
+		err := softDeleteFunc(ctx, leaderboardID, gameID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, leaderboardID, notified.ID)
+		assert.Equal(t, gameID, notified.GameID)
+		assert.False(t, notified.DeletedAt.IsZero())
+	})
+
+	t.Run("Not Found With Notifier", func(t *testing.T) {
+		softDeleteFunc := BuildSoftDeleteFunc(
+			func(ctx context.Context, event string, lb Leaderboard) error {
+				assert.Fail(t, "event notified")
+				return nil
+			},
+			func(ctx context.Context, id, gameID string) error {
+				return ErrLeaderboardNotFound
+			},
+		)
+
+		err := softDeleteFunc(ctx, leaderboardID, gameID)
+
+		assert.ErrorIs(t, err, ErrLeaderboardNotFound)
+	})
+
 	t.Run("Invalid Leaderboard ID", func(t *testing.T) {
-		softDeleteFunc := BuildSoftDeleteFunc(func(ctx context.Context, id, gameID string) error {
+		softDeleteFunc := BuildSoftDeleteFunc(nil, func(ctx context.Context, id, gameID string) error {
 			return ErrInvalidLeaderboardID
 		})
 
@@ -232,7 +330,7 @@ func TestBuildSoftDeleteFunc(t *testing.T) {
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
-		softDeleteFunc := BuildSoftDeleteFunc(func(ctx context.Context, id, gameID string) error {
+		softDeleteFunc := BuildSoftDeleteFunc(nil, func(ctx context.Context, id, gameID string) error {
 			return ErrLeaderboardNotFound
 		})
 
@@ -242,7 +340,7 @@ func TestBuildSoftDeleteFunc(t *testing.T) {
 	})
 
 	t.Run("Random Error", func(t *testing.T) {
-		softDeleteFunc := BuildSoftDeleteFunc(func(ctx context.Context, id, gameID string) error {
+		softDeleteFunc := BuildSoftDeleteFunc(nil, func(ctx context.Context, id, gameID string) error {
 			return errors.New("any error")
 		})
 
@@ -251,3 +349,101 @@ func TestBuildSoftDeleteFunc(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestBuildNotifyScheduleFunc(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		to   = time.Now()
+		from = to.Add(-time.Minute)
+
+		opened = Leaderboard{ID: uuid.NewString(), StartAt: to.Add(-time.Second)}
+		closed = Leaderboard{ID: uuid.NewString(), StartAt: to.Add(-time.Hour), EndAt: to}
+		both   = Leaderboard{ID: uuid.NewString(), StartAt: from.Add(time.Second), EndAt: to.Add(-time.Second)}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		notified := make([]string, 0)
+
+		notifyScheduleFunc := BuildNotifyScheduleFunc(
+			func(ctx context.Context, event string, lb Leaderboard) error {
+				notified = append(notified, event+" "+lb.ID)
+				return nil
+			},
+			func(ctx context.Context, scheduledFrom, scheduledTo time.Time) ([]Leaderboard, error) {
+				assert.Equal(t, from, scheduledFrom)
+				assert.Equal(t, to, scheduledTo)
+				return []Leaderboard{opened, closed, both}, nil
+			},
+		)
+
+		err := notifyScheduleFunc(ctx, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			EventOpened + " " + opened.ID,
+			EventClosed + " " + closed.ID,
+			EventOpened + " " + both.ID,
+			EventClosed + " " + both.ID,
+		}, notified)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		notifyScheduleFunc := BuildNotifyScheduleFunc(nil, func(ctx context.Context, from, to time.Time) ([]Leaderboard, error) {
+			return nil, errors.New("any error")
+		})
+
+		err := notifyScheduleFunc(ctx, from, to)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		notifyScheduleFunc := BuildNotifyScheduleFunc(
+			func(ctx context.Context, event string, lb Leaderboard) error {
+				return errors.New("any error")
+			},
+			func(ctx context.Context, from, to time.Time) ([]Leaderboard, error) {
+				return []Leaderboard{opened}, nil
+			},
+		)
+
+		err := notifyScheduleFunc(ctx, from, to)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildNotifyScheduleJob(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		startAt = time.Now()
+		lastRun = startAt.Add(-time.Minute)
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var calls int
+		job := BuildNotifyScheduleJob(time.Minute, func(ctx context.Context, from, to time.Time) error {
+			calls++
+			assert.Equal(t, lastRun, from)
+			assert.Equal(t, startAt, to)
+			return nil
+		})
+
+		assert.Equal(t, ScheduleEventsJobName, job.Name)
+		assert.Equal(t, time.Minute, job.Interval)
+
+		err := job.Func(ctx, scheduler.Run{StartedAt: startAt, LastRunAt: lastRun})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("First Run", func(t *testing.T) {
+		job := BuildNotifyScheduleJob(time.Minute, func(ctx context.Context, from, to time.Time) error {
+			t.Fail()
+			return nil
+		})
+
+		err := job.Func(ctx, scheduler.Run{StartedAt: startAt})
+		assert.NoError(t, err)
+	})
+}
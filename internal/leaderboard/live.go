@@ -0,0 +1,127 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+const (
+	MinLiveTop = 1
+	MaxLiveTop = 100
+)
+
+var ErrInvalidLiveTop = errors.New("invalid live top")
+
+// Changes pushed to a live ranking watcher. The first update is a snapshot with both the top and the player's rank.
+// Afterwards, a nil `Top` or `Player` means it didn't change
+type RankingUpdate struct {
+	Snapshot bool   // Whether it's the first update, sent once the watch starts
+	Top      []Rank // Leaderboard top, in the ranking order
+	Player   *Rank  // Player's rank. On the snapshot, nil when the player isn't ranked yet
+}
+
+// Leaderboard top and player's rank as last pushed
+type standing struct {
+	top    []Rank
+	player *Rank
+}
+
+func (w *standing) load(ctx context.Context, lb Leaderboard, playerID string, top int64, getRankingFunc StorageGetRankingFunc, getPlayerRankFunc StorageGetPlayerRankFunc) error {
+	ranking, err := getRankingFunc(ctx, lb, 0, top)
+	if err != nil {
+		return err
+	}
+	w.top = ranking
+
+	if playerID == "" {
+		return nil
+	}
+
+	rank, err := getPlayerRankFunc(ctx, lb, playerID)
+	switch {
+	case errors.Is(err, ErrPlayerRankNotFound):
+		w.player = nil
+	case err != nil:
+		return err
+	default:
+		w.player = &rank
+	}
+
+	return nil
+}
+
+// Rank changes are coalesced, so the standing is loaded at most once every `interval` however many ranks change
+func BuildWatchRankingFunc(
+	interval time.Duration,
+	subscriberRankChanges SubscriberRankChanges,
+	getRankingFunc StorageGetRankingFunc,
+	getPlayerRankFunc StorageGetPlayerRankFunc,
+) WatchRankingFunc {
+	return func(ctx context.Context, lb Leaderboard, playerID string, top int64, push func(update RankingUpdate) error) error {
+		if top < MinLiveTop || top > MaxLiveTop {
+			return ErrInvalidLiveTop
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// Subscribed before the snapshot is loaded, so a change made meanwhile is not missed
+		changes := subscriberRankChanges(ctx, lb.ID)
+
+		var current standing
+		if err := current.load(ctx, lb, playerID, top, getRankingFunc, getPlayerRankFunc); err != nil {
+			return err
+		}
+
+		if err := push(RankingUpdate{Snapshot: true, Top: current.top, Player: current.player}); err != nil {
+			return err
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		changed := false
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case _, ok := <-changes:
+				if !ok {
+					return nil
+				}
+				changed = true
+				continue
+			case <-ticker.C:
+				if !changed {
+					continue
+				}
+			}
+
+			changed = false
+
+			var next standing
+			if err := next.load(ctx, lb, playerID, top, getRankingFunc, getPlayerRankFunc); err != nil {
+				return err
+			}
+
+			var update RankingUpdate
+			if !slices.Equal(next.top, current.top) {
+				update.Top = next.top
+			}
+			if next.player != nil && (current.player == nil || *next.player != *current.player) {
+				update.Player = next.player
+			}
+			current = next
+
+			if update.Top == nil && update.Player == nil {
+				continue
+			}
+
+			if err := push(update); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWatchRankingFunc(t *testing.T) {
+	var (
+		lb       = Leaderboard{ID: uuid.NewString(), GameID: uuid.NewString()}
+		playerID = uuid.NewString()
+	)
+
+	// Ranking changed by the test, served to the watch
+	type ranking struct {
+		mu     sync.Mutex
+		top    []Rank
+		player *Rank
+	}
+
+	newStorage := func(r *ranking) (StorageGetRankingFunc, StorageGetPlayerRankFunc) {
+		getRankingFunc := func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			return r.top, nil
+		}
+
+		getPlayerRankFunc := func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			if r.player == nil {
+				return Rank{}, ErrPlayerRankNotFound
+			}
+
+			return *r.player, nil
+		}
+
+		return getRankingFunc, getPlayerRankFunc
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			changes     = make(chan RankChange)
+			r           = &ranking{top: []Rank{{PlayerID: "a", Position: 0, Value: 10}}}
+			updates     = make(chan RankingUpdate)
+		)
+		defer cancel()
+
+		getRankingFunc, getPlayerRankFunc := newStorage(r)
+		watchFunc := BuildWatchRankingFunc(
+			time.Millisecond,
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				assert.Equal(t, lb.ID, leaderboardID)
+				return changes
+			},
+			getRankingFunc,
+			getPlayerRankFunc,
+		)
+
+		done := make(chan error)
+		go func() {
+			done <- watchFunc(ctx, lb, playerID, 10, func(update RankingUpdate) error {
+				updates <- update
+				return nil
+			})
+		}()
+
+		snapshot := <-updates
+		assert.True(t, snapshot.Snapshot)
+		assert.Equal(t, r.top, snapshot.Top)
+		assert.Nil(t, snapshot.Player)
+
+		// The player enters the top
+		r.mu.Lock()
+		r.player = &Rank{PlayerID: playerID, Position: 0, Value: 20}
+		r.top = []Rank{*r.player, {PlayerID: "a", Position: 1, Value: 10}}
+		r.mu.Unlock()
+		changes <- RankChange{LeaderboardID: lb.ID, PlayerID: playerID, Value: 20}
+
+		update := <-updates
+		assert.False(t, update.Snapshot)
+		assert.Len(t, update.Top, 2)
+		assert.Equal(t, playerID, update.Player.PlayerID)
+
+		// Someone out of the top changes, so neither the top nor the player's rank do
+		changes <- RankChange{LeaderboardID: lb.ID, PlayerID: "b", Value: 1}
+
+		// Only the player's rank changes
+		r.mu.Lock()
+		r.player = &Rank{PlayerID: playerID, Position: 0, Value: 30}
+		r.top = []Rank{*r.player, {PlayerID: "a", Position: 1, Value: 10}}
+		r.mu.Unlock()
+		changes <- RankChange{LeaderboardID: lb.ID, PlayerID: playerID, Value: 10}
+
+		update = <-updates
+		assert.Equal(t, float64(30), update.Player.Value)
+
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("Invalid Top", func(t *testing.T) {
+		watchFunc := BuildWatchRankingFunc(time.Millisecond, nil, nil, nil)
+
+		err := watchFunc(context.Background(), lb, playerID, MaxLiveTop+1, nil)
+		assert.ErrorIs(t, err, ErrInvalidLiveTop)
+	})
+
+	t.Run("Push Error", func(t *testing.T) {
+		var (
+			r   = &ranking{}
+			err = errors.New("any error")
+		)
+
+		getRankingFunc, getPlayerRankFunc := newStorage(r)
+		watchFunc := BuildWatchRankingFunc(
+			time.Millisecond,
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				return make(chan RankChange)
+			},
+			getRankingFunc,
+			getPlayerRankFunc,
+		)
+
+		watchErr := watchFunc(context.Background(), lb, "", 10, func(update RankingUpdate) error {
+			return err
+		})
+		assert.ErrorIs(t, watchErr, err)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		err := errors.New("any error")
+
+		watchFunc := BuildWatchRankingFunc(
+			time.Millisecond,
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				return make(chan RankChange)
+			},
+			func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
+				return nil, err
+			},
+			nil,
+		)
+
+		watchErr := watchFunc(context.Background(), lb, "", 10, nil)
+		assert.ErrorIs(t, watchErr, err)
+	})
+}
@@ -0,0 +1,14 @@
+package leaderboard
+
+import "context"
+
+type (
+	// Notify a leaderboard lifecycle event, e.g. its creation
+	NotifierLifecycleEvent func(ctx context.Context, event string, leaderboard Leaderboard) error
+
+	// Notify a value submitted to a player's rank
+	NotifierRankChange func(ctx context.Context, change RankChange) error
+
+	// Receive the rank changes of a leaderboard until the context is done, when the channel is closed
+	SubscriberRankChanges func(ctx context.Context, leaderboardID string) <-chan RankChange
+)
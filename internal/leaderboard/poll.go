@@ -0,0 +1,164 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MinPollTimeout = time.Second
+	MaxPollTimeout = 30 * time.Second
+)
+
+var (
+	ErrInvalidPollTimeout = errors.New("invalid poll timeout")
+	ErrInvalidRankCursor  = errors.New("invalid rank cursor")
+)
+
+// Cursor value of a player that isn't ranked yet
+const unrankedCursor = "-"
+
+// Player's rank compared to the one the poller last saw
+type RankDelta struct {
+	Changed       bool    // Whether the rank differs from the cursor's. False when the poll timed out
+	Rank          *Rank   // Player's current rank. Nil when the player isn't ranked yet
+	PositionDelta int64   // Positions climbed since the cursor, negative when the player dropped
+	ValueDelta    float64 // Value gained since the cursor
+	Cursor        string  // Cursor of the current rank, for the next poll
+}
+
+// Opaque snapshot of the player's rank, handed to the clients to poll the changes after it
+func EncodeRankCursor(rank *Rank) string {
+	data := unrankedCursor
+	if rank != nil {
+		data = fmt.Sprintf("%d|%s", rank.Position, strconv.FormatFloat(rank.Value, 'g', -1, 64))
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(data))
+}
+
+// Rank held by the cursor. Only its position and value are set, and it's nil when the player wasn't ranked
+func DecodeRankCursor(cursor string) (*Rank, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidRankCursor
+	}
+
+	if string(data) == unrankedCursor {
+		return nil, nil
+	}
+
+	position, value, ok := strings.Cut(string(data), "|")
+	if !ok {
+		return nil, ErrInvalidRankCursor
+	}
+
+	var rank Rank
+	if rank.Position, err = strconv.ParseInt(position, 10, 64); err != nil {
+		return nil, ErrInvalidRankCursor
+	}
+
+	if rank.Value, err = strconv.ParseFloat(value, 64); err != nil {
+		return nil, ErrInvalidRankCursor
+	}
+
+	return &rank, nil
+}
+
+func newRankDelta(previous, current *Rank) RankDelta {
+	delta := RankDelta{Rank: current, Cursor: EncodeRankCursor(current)}
+
+	switch {
+	case previous == nil && current == nil:
+	case previous == nil || current == nil:
+		delta.Changed = true
+	default:
+		delta.Changed = previous.Position != current.Position || previous.Value != current.Value
+		delta.PositionDelta = previous.Position - current.Position
+		delta.ValueDelta = current.Value - previous.Value
+	}
+
+	return delta
+}
+
+func loadPlayerRank(ctx context.Context, lb Leaderboard, playerID string, getPlayerRankFunc StorageGetPlayerRankFunc) (*Rank, error) {
+	rank, err := getPlayerRankFunc(ctx, lb, playerID)
+	switch {
+	case errors.Is(err, ErrPlayerRankNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return &rank, nil
+}
+
+// The rank is loaded again on every change to the leaderboard, as the other players' changes move the player's position too.
+// It's also loaded when the poll times out, so the changes applied by other instances are not missed
+func BuildPollPlayerRankFunc(subscriberRankChanges SubscriberRankChanges, getPlayerRankFunc StorageGetPlayerRankFunc) PollPlayerRankFunc {
+	return func(ctx context.Context, lb Leaderboard, playerID, cursor string, timeout time.Duration) (RankDelta, error) {
+		if timeout < MinPollTimeout || timeout > MaxPollTimeout {
+			return RankDelta{}, ErrInvalidPollTimeout
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// Subscribed before the rank is loaded, so a change made meanwhile is not missed
+		changes := subscriberRankChanges(ctx, lb.ID)
+
+		current, err := loadPlayerRank(ctx, lb, playerID, getPlayerRankFunc)
+		if err != nil {
+			return RankDelta{}, err
+		}
+
+		// Without a cursor there's nothing to compare to, so the current rank is returned right away
+		if cursor == "" {
+			return RankDelta{Changed: true, Rank: current, Cursor: EncodeRankCursor(current)}, nil
+		}
+
+		previous, err := DecodeRankCursor(cursor)
+		if err != nil {
+			return RankDelta{}, err
+		}
+
+		if delta := newRankDelta(previous, current); delta.Changed {
+			return delta, nil
+		}
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return RankDelta{}, ctx.Err()
+			case <-timer.C:
+				current, err := loadPlayerRank(ctx, lb, playerID, getPlayerRankFunc)
+				if err != nil {
+					return RankDelta{}, err
+				}
+
+				return newRankDelta(previous, current), nil
+			case _, ok := <-changes:
+				if !ok {
+					return RankDelta{}, ctx.Err()
+				}
+
+				current, err := loadPlayerRank(ctx, lb, playerID, getPlayerRankFunc)
+				if err != nil {
+					return RankDelta{}, err
+				}
+
+				if delta := newRankDelta(previous, current); delta.Changed {
+					return delta, nil
+				}
+			}
+		}
+	}
+}
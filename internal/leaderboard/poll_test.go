@@ -0,0 +1,163 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankCursor(t *testing.T) {
+	t.Run("Ranked", func(t *testing.T) {
+		rank, err := DecodeRankCursor(EncodeRankCursor(&Rank{PlayerID: uuid.NewString(), Position: 3, Value: 12.5}))
+		assert.NoError(t, err)
+		assert.Equal(t, &Rank{Position: 3, Value: 12.5}, rank)
+	})
+
+	t.Run("Unranked", func(t *testing.T) {
+		rank, err := DecodeRankCursor(EncodeRankCursor(nil))
+		assert.NoError(t, err)
+		assert.Nil(t, rank)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, cursor := range []string{"%%%", "MTI", "YXxi"} {
+			_, err := DecodeRankCursor(cursor)
+			assert.ErrorIs(t, err, ErrInvalidRankCursor, cursor)
+		}
+	})
+}
+
+func TestBuildPollPlayerRankFunc(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		lb       = Leaderboard{ID: uuid.NewString(), GameID: uuid.NewString()}
+		playerID = uuid.NewString()
+	)
+
+	t.Run("Without Cursor", func(t *testing.T) {
+		rank := Rank{PlayerID: playerID, Position: 2, Value: 30}
+
+		pollFunc := BuildPollPlayerRankFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				return make(chan RankChange)
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+				return rank, nil
+			},
+		)
+
+		delta, err := pollFunc(ctx, lb, playerID, "", time.Second)
+		assert.NoError(t, err)
+		assert.True(t, delta.Changed)
+		assert.Equal(t, &rank, delta.Rank)
+		assert.Equal(t, EncodeRankCursor(&rank), delta.Cursor)
+	})
+
+	t.Run("Changed Since The Cursor", func(t *testing.T) {
+		pollFunc := BuildPollPlayerRankFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				return make(chan RankChange)
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+				return Rank{PlayerID: playerID, Position: 1, Value: 40}, nil
+			},
+		)
+
+		delta, err := pollFunc(ctx, lb, playerID, EncodeRankCursor(&Rank{Position: 4, Value: 10}), time.Second)
+		assert.NoError(t, err)
+		assert.True(t, delta.Changed)
+		assert.Equal(t, int64(3), delta.PositionDelta)
+		assert.Equal(t, 30.0, delta.ValueDelta)
+	})
+
+	t.Run("Waits For A Change", func(t *testing.T) {
+		var (
+			changes = make(chan RankChange, 2)
+			calls   = 0
+		)
+
+		changes <- RankChange{LeaderboardID: lb.ID, PlayerID: uuid.NewString()}
+		changes <- RankChange{LeaderboardID: lb.ID, PlayerID: playerID}
+
+		pollFunc := BuildPollPlayerRankFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				assert.Equal(t, lb.ID, leaderboardID)
+				return changes
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+				calls++
+				if calls < 3 {
+					return Rank{PlayerID: playerID, Position: 0, Value: 10}, nil
+				}
+
+				return Rank{PlayerID: playerID, Position: 1, Value: 10}, nil
+			},
+		)
+
+		delta, err := pollFunc(ctx, lb, playerID, EncodeRankCursor(&Rank{Position: 0, Value: 10}), time.Second)
+		assert.NoError(t, err)
+		assert.True(t, delta.Changed)
+		assert.Equal(t, int64(-1), delta.PositionDelta)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		cursor := EncodeRankCursor(nil)
+
+		pollFunc := BuildPollPlayerRankFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				return make(chan RankChange)
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+				return Rank{}, ErrPlayerRankNotFound
+			},
+		)
+
+		delta, err := pollFunc(ctx, lb, playerID, cursor, MinPollTimeout)
+		assert.NoError(t, err)
+		assert.False(t, delta.Changed)
+		assert.Nil(t, delta.Rank)
+		assert.Equal(t, cursor, delta.Cursor)
+	})
+
+	t.Run("Invalid Timeout", func(t *testing.T) {
+		pollFunc := BuildPollPlayerRankFunc(nil, nil)
+
+		_, err := pollFunc(ctx, lb, playerID, "", MaxPollTimeout+time.Second)
+		assert.ErrorIs(t, err, ErrInvalidPollTimeout)
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		pollFunc := BuildPollPlayerRankFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				return make(chan RankChange)
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+				return Rank{}, nil
+			},
+		)
+
+		_, err := pollFunc(ctx, lb, playerID, "%%%", time.Second)
+		assert.ErrorIs(t, err, ErrInvalidRankCursor)
+	})
+
+	t.Run("Get Player Rank Error", func(t *testing.T) {
+		errExpected := errors.New("any error")
+
+		pollFunc := BuildPollPlayerRankFunc(
+			func(ctx context.Context, leaderboardID string) <-chan RankChange {
+				return make(chan RankChange)
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+				return Rank{}, errExpected
+			},
+		)
+
+		_, err := pollFunc(ctx, lb, playerID, "", time.Second)
+		assert.ErrorIs(t, err, errExpected)
+	})
+}
@@ -9,6 +9,7 @@ var (
 	ErrLeaderboardClosed  = errors.New("leaderboard closed")
 	ErrInvalidPageNumber  = errors.New("invalid page number")
 	ErrInvalidLimitNumber = errors.New("invalid limit number")
+	ErrPlayerRankNotFound = errors.New("player rank not found")
 )
 
 const (
@@ -24,13 +25,35 @@ type Rank struct {
 	Value         float64
 }
 
-func BuildUpsertPlayerRankFunc(upsertPlayerRankValueFunc StorageUpsertPlayerRankValueFunc) UpsertPlayerRankFunc {
+// Value submitted to a player's rank, before it's aggregated
+type RankChange struct {
+	LeaderboardID string
+	GameID        string
+	PlayerID      string
+	Value         float64
+}
+
+func BuildUpsertPlayerRankFunc(notifierRankChange NotifierRankChange, upsertPlayerRankValueFunc StorageUpsertPlayerRankValueFunc) UpsertPlayerRankFunc {
 	return func(ctx context.Context, lb Leaderboard, playerID string, value float64) error {
 		if lb.Closed() {
 			return ErrLeaderboardClosed
 		}
 
-		return upsertPlayerRankValueFunc(ctx, lb, playerID, value)
+		if err := upsertPlayerRankValueFunc(ctx, lb, playerID, value); err != nil {
+			return err
+		}
+
+		if notifierRankChange != nil {
+			return notifierRankChange(ctx, RankChange{LeaderboardID: lb.ID, GameID: lb.GameID, PlayerID: playerID, Value: value})
+		}
+
+		return nil
+	}
+}
+
+func BuildGetPlayerRankFunc(getPlayerRankFunc StorageGetPlayerRankFunc) GetPlayerRankFunc {
+	return func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+		return getPlayerRankFunc(ctx, lb, playerID)
 	}
 }
 
@@ -44,6 +67,6 @@ func BuildRankingFunc(getRankingFunc StorageGetRankingFunc) RankingFunc {
 			return nil, ErrInvalidLimitNumber
 		}
 
-		return getRankingFunc(ctx, lb.ID, lb.Ordering, page, limit)
+		return getRankingFunc(ctx, lb, page, limit)
 	}
 }
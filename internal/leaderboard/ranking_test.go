@@ -27,7 +27,32 @@ func TestBuildUpsertPlayerRankFunc(t *testing.T) {
 			AggregationMode: AggregationModeInc,
 		}
 
-		upsertPlayerRankFunc := BuildUpsertPlayerRankFunc(func(ctx context.Context, leaderboard Leaderboard, playerID string, value float64) error {
+		var notified RankChange
+
+		upsertPlayerRankFunc := BuildUpsertPlayerRankFunc(
+			func(ctx context.Context, change RankChange) error {
+				notified = change
+				return nil
+			},
+			func(ctx context.Context, leaderboard Leaderboard, playerID string, value float64) error {
+				return nil
+			},
+		)
+
+		value := rand.Float64()
+		err := upsertPlayerRankFunc(ctx, lb, playerID, value)
+		assert.NoError(t, err)
+		assert.Equal(t, RankChange{LeaderboardID: leaderboardID, GameID: gameID, PlayerID: playerID, Value: value}, notified)
+	})
+
+	t.Run("Without Notifier", func(t *testing.T) {
+		lb := Leaderboard{
+			ID:              leaderboardID,
+			GameID:          gameID,
+			AggregationMode: AggregationModeInc,
+		}
+
+		upsertPlayerRankFunc := BuildUpsertPlayerRankFunc(nil, func(ctx context.Context, leaderboard Leaderboard, playerID string, value float64) error {
 			return nil
 		})
 
@@ -42,9 +67,15 @@ func TestBuildUpsertPlayerRankFunc(t *testing.T) {
 			AggregationMode: "INVALID",
 		}
 
-		upsertPlayerRankFunc := BuildUpsertPlayerRankFunc(func(ctx context.Context, leaderboard Leaderboard, playerID string, value float64) error {
-			return ErrInvalidAggregationMode
-		})
+		upsertPlayerRankFunc := BuildUpsertPlayerRankFunc(
+			func(ctx context.Context, change RankChange) error {
+				t.Fatal("rank change notified")
+				return nil
+			},
+			func(ctx context.Context, leaderboard Leaderboard, playerID string, value float64) error {
+				return ErrInvalidAggregationMode
+			},
+		)
 
 		err := upsertPlayerRankFunc(ctx, lb, playerID, rand.Float64())
 		assert.ErrorIs(t, err, ErrInvalidAggregationMode)
@@ -53,13 +84,42 @@ func TestBuildUpsertPlayerRankFunc(t *testing.T) {
 	t.Run("Leaderboard Closed", func(t *testing.T) {
 		lb := Leaderboard{EndAt: time.Now().Add(-24 * time.Hour)}
 
-		upsertPlayerRankFunc := BuildUpsertPlayerRankFunc(nil)
+		upsertPlayerRankFunc := BuildUpsertPlayerRankFunc(nil, nil)
 
 		err := upsertPlayerRankFunc(ctx, lb, playerID, rand.Float64())
 		assert.ErrorIs(t, err, ErrLeaderboardClosed)
 	})
 }
 
+func TestBuildGetPlayerRankFunc(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		lb       = Leaderboard{ID: uuid.NewString(), Ordering: OrderingDesc}
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		getPlayerRankFunc := BuildGetPlayerRankFunc(func(ctx context.Context, l Leaderboard, id string) (Rank, error) {
+			assert.Equal(t, lb.ID, l.ID)
+			assert.Equal(t, playerID, id)
+			return Rank{PlayerID: id, Position: 3, Value: 10}, nil
+		})
+
+		rank, err := getPlayerRankFunc(ctx, lb, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, Rank{PlayerID: playerID, Position: 3, Value: 10}, rank)
+	})
+
+	t.Run("Player Rank Not Found", func(t *testing.T) {
+		getPlayerRankFunc := BuildGetPlayerRankFunc(func(ctx context.Context, l Leaderboard, id string) (Rank, error) {
+			return Rank{}, ErrPlayerRankNotFound
+		})
+
+		_, err := getPlayerRankFunc(ctx, lb, playerID)
+		assert.ErrorIs(t, err, ErrPlayerRankNotFound)
+	})
+}
+
 func TestBuildRankingFunc(t *testing.T) {
 	ctx := context.Background()
 
@@ -69,7 +129,7 @@ func TestBuildRankingFunc(t *testing.T) {
 			Ordering: OrderingAsc,
 		}
 
-		rankingFunc := BuildRankingFunc(func(ctx context.Context, leaderboardID, ordering string, page, limit int64) ([]Rank, error) {
+		rankingFunc := BuildRankingFunc(func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
 			return make([]Rank, 0), nil
 		})
 
@@ -119,7 +179,7 @@ func TestBuildRankingFunc(t *testing.T) {
 			Ordering: "INVALID",
 		}
 
-		rankingFunc := BuildRankingFunc(func(ctx context.Context, leaderboardID, ordering string, page, limit int64) ([]Rank, error) {
+		rankingFunc := BuildRankingFunc(func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
 			return nil, ErrInvalidOrdering
 		})
 
@@ -133,7 +193,7 @@ func TestBuildRankingFunc(t *testing.T) {
 			Ordering: OrderingAsc,
 		}
 
-		rankingFunc := BuildRankingFunc(func(ctx context.Context, leaderboardID, ordering string, page, limit int64) ([]Rank, error) {
+		rankingFunc := BuildRankingFunc(func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
 			return nil, errors.New("any error")
 		})
 
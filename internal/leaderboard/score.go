@@ -0,0 +1,197 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+// Scores read from the history at a time while the ranking is recomputed
+const ScoreHistoryPageSize = 1000
+
+// Channels the scores are submitted through
+const (
+	SourceAPI       = "API"       // REST API, used by the game clients
+	SourceGRPC      = "GRPC"      // gRPC API, used by the game servers
+	SourceMQTT      = "MQTT"      // MQTT broker
+	SourceIngestion = "INGESTION" // Ingestion broker
+)
+
+var Sources = []string{SourceAPI, SourceGRPC, SourceMQTT, SourceIngestion}
+
+const (
+	MaxDeviceHashLength   = 128 // Characters the device hash of a fingerprint can have
+	MaxBuildVersionLength = 64  // Characters the build version of a fingerprint can have
+	MaxRegionLength       = 64  // Characters the region of a fingerprint can have
+)
+
+var ErrInvalidFingerprint = errors.New("fingerprint must have a device hash up to 128 characters, and a build version and region up to 64")
+
+// Client a submission came from. Every field is optional
+type Fingerprint struct {
+	DeviceHash   string // Hash of the device ID, computed by the client so the ID itself never leaves the device
+	BuildVersion string // Version of the client build
+	Region       string // Region the submission came from, derived from its IP
+}
+
+func (f Fingerprint) Validate() error {
+	if utf8.RuneCountInString(f.DeviceHash) > MaxDeviceHashLength ||
+		utf8.RuneCountInString(f.BuildVersion) > MaxBuildVersionLength ||
+		utf8.RuneCountInString(f.Region) > MaxRegionLength {
+		return ErrInvalidFingerprint
+	}
+
+	return nil
+}
+
+// Value submitted to a leaderboard, kept on the score history as it was submitted
+type Score struct {
+	SubmittedAt   time.Time   // Time the score was submitted
+	ID            string      // Score ID
+	LeaderboardID string      // Leaderboard the score was submitted to
+	GameID        string      // Game the leaderboard belongs to
+	PlayerID      string      // Player that submitted the score
+	Value         float64     // Value submitted, before it's aggregated
+	Source        string      // Channel the score was submitted through. Empty for the scores applied by the platform, e.g. on a merge
+	Fingerprint   Fingerprint // Client the score was submitted from. Empty when the submission carried none
+}
+
+type sourceKey struct{}
+
+// Tags the submissions made with the context with the channel they came through
+func WithSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// Channel the submission made with the context came through. Empty when it wasn't tagged
+func SourceFromContext(ctx context.Context) string {
+	source, _ := ctx.Value(sourceKey{}).(string)
+	return source
+}
+
+type fingerprintKey struct{}
+
+// Tags the submissions made with the context with the client they came from
+func WithFingerprint(ctx context.Context, f Fingerprint) context.Context {
+	return context.WithValue(ctx, fingerprintKey{}, f)
+}
+
+// Client the submission made with the context came from. Empty when it wasn't tagged
+func FingerprintFromContext(ctx context.Context) Fingerprint {
+	f, _ := ctx.Value(fingerprintKey{}).(Fingerprint)
+	return f
+}
+
+// Player's rank rebuilt from the score history
+type RebuiltRank struct {
+	UpdatedAt time.Time // Time of the score that last changed the rank value
+	PlayerID  string    // Player's ID
+	Value     float64   // Value aggregated from every score of the player
+}
+
+// Outcome of a ranking recomputation
+type Recomputation struct {
+	Scores  int64 // Scores read from the history
+	Players int   // Players on the rebuilt ranking
+}
+
+// Aggregates the value on the current one, returning whether it changed
+func aggregate(aggregationMode string, current, value float64) (float64, bool, error) {
+	switch aggregationMode {
+	case AggregationModeInc:
+		return current + value, true, nil
+	case AggregationModeMax:
+		return max(current, value), value > current, nil
+	case AggregationModeMin:
+		return min(current, value), value < current, nil
+	default:
+		return 0, false, ErrInvalidAggregationMode
+	}
+}
+
+// Aggregates the score on the player's rank rebuilt so far, as the ranking did when it was submitted.
+// The scores must be applied oldest first
+func ApplyScore(ranks map[string]RebuiltRank, aggregationMode string, score Score) error {
+	rank, exists := ranks[score.PlayerID]
+	if !exists {
+		ranks[score.PlayerID] = RebuiltRank{UpdatedAt: score.SubmittedAt, PlayerID: score.PlayerID, Value: score.Value}
+		return nil
+	}
+
+	value, changed, err := aggregate(aggregationMode, rank.Value, score.Value)
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		ranks[score.PlayerID] = RebuiltRank{UpdatedAt: score.SubmittedAt, PlayerID: score.PlayerID, Value: value}
+	}
+
+	return nil
+}
+
+// Records every score on the history once the player's rank is updated. A score whose rank update fails
+// is not recorded, so the history only holds the scores the ranking was built from
+func BuildRecordScoreFunc(storageRecordScoreFunc StorageRecordScoreFunc, storageUpsertPlayerRankValueFunc StorageUpsertPlayerRankValueFunc) StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb Leaderboard, playerID string, value float64) error {
+		if err := storageUpsertPlayerRankValueFunc(ctx, lb, playerID, value); err != nil {
+			return err
+		}
+
+		return storageRecordScoreFunc(ctx, Score{
+			SubmittedAt:   time.Now().UTC(),
+			LeaderboardID: lb.ID,
+			GameID:        lb.GameID,
+			PlayerID:      playerID,
+			Value:         value,
+			Source:        SourceFromContext(ctx),
+			Fingerprint:   FingerprintFromContext(ctx),
+		})
+	}
+}
+
+// Replays the leaderboard's score history with its current aggregation mode and replaces the ranking with the result.
+// The whole ranking is rebuilt in memory before it replaces the current one, which is kept until then.
+// Scores submitted while it runs may be left out of the rebuilt ranking
+func BuildRecomputeRankingFunc(storageListScoresFunc StorageListScoresFunc, storageReplaceRankingFunc StorageReplaceRankingFunc) RecomputeRankingFunc {
+	return func(ctx context.Context, lb Leaderboard) (Recomputation, error) {
+		var (
+			recomputation Recomputation
+			ranks         = make(map[string]RebuiltRank)
+			after         Score
+		)
+
+		for {
+			scores, err := storageListScoresFunc(ctx, lb.ID, after, ScoreHistoryPageSize)
+			if err != nil {
+				return Recomputation{}, err
+			}
+
+			for _, score := range scores {
+				if err := ApplyScore(ranks, lb.AggregationMode, score); err != nil {
+					return Recomputation{}, err
+				}
+			}
+
+			recomputation.Scores += int64(len(scores))
+			if len(scores) < ScoreHistoryPageSize {
+				break
+			}
+
+			after = scores[len(scores)-1]
+		}
+
+		rebuilt := make([]RebuiltRank, 0, len(ranks))
+		for _, rank := range ranks {
+			rebuilt = append(rebuilt, rank)
+		}
+
+		if err := storageReplaceRankingFunc(ctx, lb, rebuilt); err != nil {
+			return Recomputation{}, err
+		}
+
+		recomputation.Players = len(rebuilt)
+		return recomputation, nil
+	}
+}
@@ -0,0 +1,168 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRecordScoreFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		lb       = Leaderboard{ID: uuid.NewString(), GameID: uuid.NewString(), AggregationMode: AggregationModeInc}
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var recorded Score
+
+		upsertFunc := BuildRecordScoreFunc(
+			func(ctx context.Context, score Score) error {
+				recorded = score
+				return nil
+			},
+			func(ctx context.Context, leaderboard Leaderboard, player string, value float64) error {
+				return nil
+			},
+		)
+
+		fingerprint := Fingerprint{DeviceHash: "f00d", BuildVersion: "1.2.0", Region: "BR"}
+		err := upsertFunc(WithFingerprint(WithSource(ctx, SourceAPI), fingerprint), lb, playerID, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, SourceAPI, recorded.Source)
+		assert.Equal(t, fingerprint, recorded.Fingerprint)
+		assert.Equal(t, lb.ID, recorded.LeaderboardID)
+		assert.Equal(t, lb.GameID, recorded.GameID)
+		assert.Equal(t, playerID, recorded.PlayerID)
+		assert.Equal(t, float64(10), recorded.Value)
+		assert.False(t, recorded.SubmittedAt.IsZero())
+	})
+
+	t.Run("Upsert Error", func(t *testing.T) {
+		errUpsert := errors.New("any error")
+
+		upsertFunc := BuildRecordScoreFunc(
+			func(ctx context.Context, score Score) error {
+				assert.Fail(t, "recorded a score not applied")
+				return nil
+			},
+			func(ctx context.Context, leaderboard Leaderboard, player string, value float64) error {
+				return errUpsert
+			},
+		)
+
+		err := upsertFunc(ctx, lb, playerID, 10)
+		assert.ErrorIs(t, err, errUpsert)
+	})
+}
+
+func TestFingerprintValidate(t *testing.T) {
+	assert.NoError(t, Fingerprint{}.Validate())
+	assert.NoError(t, Fingerprint{DeviceHash: strings.Repeat("a", MaxDeviceHashLength), BuildVersion: "1.2.0", Region: "BR"}.Validate())
+	assert.ErrorIs(t, Fingerprint{DeviceHash: strings.Repeat("a", MaxDeviceHashLength+1)}.Validate(), ErrInvalidFingerprint)
+	assert.ErrorIs(t, Fingerprint{Region: strings.Repeat("a", MaxRegionLength+1)}.Validate(), ErrInvalidFingerprint)
+}
+
+func TestBuildRecomputeRankingFunc(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		start = time.Now().UTC()
+	)
+
+	// Scores `a` submitted 1, 5, 3, ... and `b` 2, 2, 2, ..., enough for more than one page
+	history := make([]Score, 0, ScoreHistoryPageSize+500)
+	for i := range cap(history) {
+		score := Score{SubmittedAt: start.Add(time.Duration(i) * time.Second), ID: fmt.Sprintf("%06d", i), PlayerID: "b", Value: 2}
+		if i%2 == 0 {
+			score.PlayerID, score.Value = "a", float64([]int{1, 5, 3}[(i/2)%3])
+		}
+
+		history = append(history, score)
+	}
+
+	listScoresFunc := func(ctx context.Context, leaderboardID string, after Score, limit int) ([]Score, error) {
+		i, _ := slices.BinarySearchFunc(history, after.ID, func(s Score, id string) int { return strings.Compare(s.ID, id) })
+		if after.ID != "" {
+			i++
+		}
+
+		return history[min(i, len(history)):min(i+limit, len(history))], nil
+	}
+
+	recompute := func(t *testing.T, aggregationMode string) map[string]RebuiltRank {
+		rebuilt := make(map[string]RebuiltRank)
+		recomputeRankingFunc := BuildRecomputeRankingFunc(listScoresFunc, func(ctx context.Context, lb Leaderboard, ranks []RebuiltRank) error {
+			for _, rank := range ranks {
+				rebuilt[rank.PlayerID] = rank
+			}
+
+			return nil
+		})
+
+		recomputation, err := recomputeRankingFunc(ctx, Leaderboard{AggregationMode: aggregationMode})
+		assert.NoError(t, err)
+		assert.Equal(t, Recomputation{Scores: int64(len(history)), Players: 2}, recomputation)
+
+		return rebuilt
+	}
+
+	t.Run("Inc", func(t *testing.T) {
+		rebuilt := recompute(t, AggregationModeInc)
+
+		var a, b float64
+		for _, score := range history {
+			if score.PlayerID == "a" {
+				a += score.Value
+			} else {
+				b += score.Value
+			}
+		}
+
+		assert.Equal(t, a, rebuilt["a"].Value)
+		assert.Equal(t, b, rebuilt["b"].Value)
+		assert.Equal(t, history[len(history)-1].SubmittedAt, rebuilt["b"].UpdatedAt)
+	})
+
+	t.Run("Max", func(t *testing.T) {
+		rebuilt := recompute(t, AggregationModeMax)
+
+		// The rank only changes when `a` first submits 5
+		assert.Equal(t, RebuiltRank{UpdatedAt: history[2].SubmittedAt, PlayerID: "a", Value: 5}, rebuilt["a"])
+		assert.Equal(t, RebuiltRank{UpdatedAt: history[1].SubmittedAt, PlayerID: "b", Value: 2}, rebuilt["b"])
+	})
+
+	t.Run("Min", func(t *testing.T) {
+		rebuilt := recompute(t, AggregationModeMin)
+
+		assert.Equal(t, RebuiltRank{UpdatedAt: history[0].SubmittedAt, PlayerID: "a", Value: 1}, rebuilt["a"])
+	})
+
+	t.Run("Invalid Aggregation Mode", func(t *testing.T) {
+		recomputeRankingFunc := BuildRecomputeRankingFunc(listScoresFunc, func(ctx context.Context, lb Leaderboard, ranks []RebuiltRank) error {
+			assert.Fail(t, "replaced the ranking")
+			return nil
+		})
+
+		_, err := recomputeRankingFunc(ctx, Leaderboard{AggregationMode: "any"})
+		assert.ErrorIs(t, err, ErrInvalidAggregationMode)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		errStorage := errors.New("any error")
+
+		recomputeRankingFunc := BuildRecomputeRankingFunc(listScoresFunc, func(ctx context.Context, lb Leaderboard, ranks []RebuiltRank) error {
+			return errStorage
+		})
+
+		_, err := recomputeRankingFunc(ctx, Leaderboard{AggregationMode: AggregationModeInc})
+		assert.ErrorIs(t, err, errStorage)
+	})
+}
@@ -1,6 +1,9 @@
 package leaderboard
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type (
 	// Storage function that is responsible for creating the leaderboard
@@ -15,6 +18,54 @@ type (
 	// Updates the player's rank value using the value provided
 	StorageUpsertPlayerRankValueFunc func(ctx context.Context, leaderboard Leaderboard, playerID string, value float64) error
 
-	// Get the leaderboard ranking paginated
-	StorageGetRankingFunc func(ctx context.Context, leaderboardID, ordering string, page, limit int64) ([]Rank, error)
+	// Get the leaderboard ranking paginated, sorted by the leaderboard ordering
+	StorageGetRankingFunc func(ctx context.Context, leaderboard Leaderboard, page, limit int64) ([]Rank, error)
+
+	// Get a player's position and value on the leaderboard ranking
+	StorageGetPlayerRankFunc func(ctx context.Context, leaderboard Leaderboard, playerID string) (Rank, error)
+
+	// Removes the player's rank from the leaderboard ranking
+	StorageDeletePlayerRankFunc func(ctx context.Context, leaderboard Leaderboard, playerID string) error
+
+	// Lists the game's leaderboards not deleted
+	StorageListLeaderboardsFunc func(ctx context.Context, gameID string) ([]Leaderboard, error)
+
+	// Lists the leaderboards not deleted that start or end within the `(from, to]` interval
+	StorageListLeaderboardsScheduledBetweenFunc func(ctx context.Context, from, to time.Time) ([]Leaderboard, error)
+
+	// Appends the score to the leaderboard's score history
+	StorageRecordScoreFunc func(ctx context.Context, score Score) error
+
+	// Lists the leaderboard's scores in the order they were submitted, starting after the given one. The zero score starts from the first
+	StorageListScoresFunc func(ctx context.Context, leaderboardID string, after Score, limit int) ([]Score, error)
+
+	// Replaces the whole leaderboard ranking at once, so it's never read partially rebuilt
+	StorageReplaceRankingFunc func(ctx context.Context, leaderboard Leaderboard, ranks []RebuiltRank) error
+
+	// Cached top positions of the leaderboard ranking. Not ok when they aren't cached, with the generation still set,
+	// so the positions read afterwards are cached against it
+	StorageGetCachedTopFunc func(ctx context.Context, leaderboard Leaderboard) (CachedTop, bool, error)
+
+	// Caches the top positions of the leaderboard ranking for the ttl, replacing the ones cached. Nothing is cached
+	// once the ranking's generation moved past the top's one
+	StorageCacheTopFunc func(ctx context.Context, leaderboard Leaderboard, top CachedTop, ttl time.Duration) error
+
+	// What the player's rank update is checked against to tell whether it changes the cached top positions.
+	// Not ok when they aren't cached
+	StorageGetCachedTopBoundFunc func(ctx context.Context, leaderboard Leaderboard, playerID string) (CachedTopBound, bool, error)
+
+	// Drops the cached top positions of the leaderboard ranking and bumps its generation
+	StorageInvalidateCachedTopFunc func(ctx context.Context, leaderboard Leaderboard) error
+
+	// Top view of the leaderboard ranking. Not ok when there's none, e.g. once it expired or was dropped, with the
+	// version still set, so it's rebuilt against it
+	StorageGetTopViewFunc func(ctx context.Context, leaderboard Leaderboard) (TopView, bool, error)
+
+	// Saves the top view with the next version, only when the stored version is still the view's one. The ttl is set
+	// when there's no view stored, so an updated view is still rebuilt when it would. Not saved when another save or
+	// drop came first
+	StorageSaveTopViewFunc func(ctx context.Context, leaderboard Leaderboard, view TopView, ttl time.Duration) (bool, error)
+
+	// Drops the top view of the leaderboard ranking and bumps its version, so it's rebuilt on the next read
+	StorageDropTopViewFunc func(ctx context.Context, leaderboard Leaderboard) error
 )
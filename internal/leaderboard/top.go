@@ -0,0 +1,89 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+)
+
+type (
+	// Drops what's derived from the leaderboard ranking, e.g. its cached top positions
+	DropTopFunc func(ctx context.Context, leaderboard Leaderboard) error
+
+	// Drops what's derived from the rankings of every leaderboard of the game
+	DropGameTopsFunc func(ctx context.Context, gameID string) error
+)
+
+// Whether the value moves a ranked player towards the top of the ranking
+func (l Leaderboard) improves(value float64) bool {
+	switch l.AggregationMode {
+	case AggregationModeMax:
+		return l.Ordering == OrderingDesc
+	case AggregationModeMin:
+		return l.Ordering == OrderingAsc
+	default:
+		return (value > 0 && l.Ordering == OrderingDesc) || (value < 0 && l.Ordering == OrderingAsc)
+	}
+}
+
+// Whether the value reaches the last of the top positions. Ties are ordered by the storage, so reaching it is enough
+func (l Leaderboard) reaches(value, bound float64) bool {
+	if l.Ordering == OrderingAsc {
+		return value <= bound
+	}
+
+	return value >= bound
+}
+
+// Whether the player isn't ranked yet, read before the value is applied. A value that can't move a ranked player towards
+// the top, e.g. on the `MAX` leaderboards in ascending order, still ranks a new player wherever it lands, so it's only
+// read for those values
+func (l Leaderboard) unranked(ctx context.Context, storageGetPlayerRankFunc StorageGetPlayerRankFunc, playerID string, value float64) (bool, error) {
+	if l.improves(value) {
+		return false, nil
+	}
+
+	_, err := storageGetPlayerRankFunc(ctx, l, playerID)
+	if errors.Is(err, ErrPlayerRankNotFound) {
+		return true, nil
+	}
+
+	return false, err
+}
+
+// Whether a value applied to a player off the top positions, all taken, may move them onto the top. A new player takes
+// the value as it is, as does a player the value moves towards the top, except on the `INC` leaderboards, where the
+// resulting value is only known once applied. For those, `readBack` tells to read the player's rank back to find out
+func (l Leaderboard) mayReach(value, bound float64, unranked bool) (reach, readBack bool) {
+	switch {
+	case unranked:
+		return l.reaches(value, bound), false
+	case !l.improves(value):
+		return false, false
+	case l.AggregationMode == AggregationModeInc:
+		return true, true
+	default:
+		return l.reaches(value, bound), false
+	}
+}
+
+// Drops what's derived from the ranking of every leaderboard of the game, e.g. once a player's ranks are erased from all
+// of them at once. Every leaderboard is dropped even when one of them fails
+func BuildDropGameTopsFunc(storageListLeaderboardsFunc StorageListLeaderboardsFunc, dropTopFuncs ...DropTopFunc) DropGameTopsFunc {
+	return func(ctx context.Context, gameID string) error {
+		leaderboards, err := storageListLeaderboardsFunc(ctx, gameID)
+		if err != nil {
+			return err
+		}
+
+		errList := make([]error, 0)
+		for _, lb := range leaderboards {
+			for _, dropTopFunc := range dropTopFuncs {
+				if err := dropTopFunc(ctx, lb); err != nil {
+					errList = append(errList, err)
+				}
+			}
+		}
+
+		return errors.Join(errList...)
+	}
+}
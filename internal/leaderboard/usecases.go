@@ -1,6 +1,9 @@
 package leaderboard
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type (
 	// Create a leaderboard and return it's id
@@ -15,6 +18,26 @@ type (
 	// Set or update the player's rank
 	UpsertPlayerRankFunc func(ctx context.Context, leaderboard Leaderboard, playerID string, value float64) error
 
+	// Get the player's rank on the leaderboard
+	GetPlayerRankFunc func(ctx context.Context, leaderboard Leaderboard, playerID string) (Rank, error)
+
 	// Leaderboard ranking paginated
 	RankingFunc func(ctx context.Context, leaderboard Leaderboard, page, limit int64) ([]Rank, error)
+
+	// Push the leaderboard top and the player's rank as they change, until the context is done or the push fails.
+	// The player is optional
+	WatchRankingFunc func(ctx context.Context, leaderboard Leaderboard, playerID string, top int64, push func(update RankingUpdate) error) error
+
+	// Wait up to the timeout for the player's rank to differ from the cursor's, returning how it changed.
+	// Without a cursor, the current rank is returned right away
+	PollPlayerRankFunc func(ctx context.Context, leaderboard Leaderboard, playerID, cursor string, timeout time.Duration) (RankDelta, error)
+
+	// Push the leaderboard rank changes until it closes, when a `CLOSED` event is pushed, the context is done or the push fails
+	StreamEventsFunc func(ctx context.Context, leaderboard Leaderboard, push func(event StreamEvent) error) error
+
+	// Notify the leaderboards opened or closed within the `(from, to]` interval
+	NotifyScheduleFunc func(ctx context.Context, from, to time.Time) error
+
+	// Rebuild the leaderboard ranking from its score history
+	RecomputeRankingFunc func(ctx context.Context, leaderboard Leaderboard) (Recomputation, error)
 )
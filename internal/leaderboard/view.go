@@ -0,0 +1,238 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// Times a ranking write tries applying itself to the top view when concurrent changes keep saving it first
+const topViewSaveAttempts = 3
+
+var (
+	ErrInvalidTopViewSize    = errors.New("top view size must be from one to the max limit")
+	ErrInvalidTopViewRefresh = errors.New("top view refresh must be greater than zero")
+)
+
+// How many positions each top view holds, and how often it's rebuilt from the ranking
+type TopViewConfig struct {
+	Size    int64         // Top positions on the view, up to `MaxLimitNumber`
+	Refresh time.Duration // Time before the view is rebuilt from the ranking, however many writes were applied to it
+}
+
+func (c TopViewConfig) validate() error {
+	errList := make([]error, 0)
+
+	if c.Size < MinLimitNumber || c.Size > MaxLimitNumber {
+		errList = append(errList, ErrInvalidTopViewSize)
+	}
+
+	if c.Refresh <= 0 {
+		errList = append(errList, ErrInvalidTopViewRefresh)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Precomputed top positions of a leaderboard ranking, updated by each ranking write reaching them
+type TopView struct {
+	Ranks   []Rank
+	Version int64 // Bumped on each save and drop, so concurrent changes don't overwrite each other
+}
+
+// Moves the player to its rank on the view, or out of it once past the view size. The rank that fills the position the
+// player left is only read when the view was full
+func (v TopView) place(ctx context.Context, lb Leaderboard, size int64, rank Rank, storageGetRankingFunc StorageGetRankingFunc) (TopView, error) {
+	full := int64(len(v.Ranks)) == size
+
+	ranks := slices.DeleteFunc(slices.Clone(v.Ranks), func(r Rank) bool { return r.PlayerID == rank.PlayerID })
+	switch {
+	case rank.Position < size:
+		ranks = slices.Insert(ranks, int(min(rank.Position, int64(len(ranks)))), rank)
+		ranks = ranks[:min(int64(len(ranks)), size)]
+	case full && len(ranks) < len(v.Ranks):
+		last, err := storageGetRankingFunc(ctx, lb, size-1, 1)
+		if err != nil {
+			return TopView{}, err
+		}
+
+		for _, r := range last {
+			if !slices.ContainsFunc(ranks, func(o Rank) bool { return o.PlayerID == r.PlayerID }) {
+				ranks = append(ranks, r)
+			}
+		}
+	}
+
+	for i := range ranks {
+		ranks[i].Position = int64(i)
+	}
+
+	return TopView{Ranks: ranks, Version: v.Version}, nil
+}
+
+// Serves the ranking pages within the top view from it, rebuilding it from the ranking once it's gone. The rebuilt view
+// is only saved while its version is still the one read before the ranking, so a ranking write made meanwhile is never
+// overwritten. The other pages are read from `next`
+func BuildTopViewRankingFunc(
+	config TopViewConfig,
+	storageGetTopViewFunc StorageGetTopViewFunc,
+	storageSaveTopViewFunc StorageSaveTopViewFunc,
+	storageGetRankingFunc StorageGetRankingFunc,
+	next StorageGetRankingFunc,
+) (StorageGetRankingFunc, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
+		start, end := page*limit, (page+1)*limit
+		if end > config.Size {
+			return next(ctx, lb, page, limit)
+		}
+
+		view, ok, err := storageGetTopViewFunc(ctx, lb)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			if view.Ranks, err = storageGetRankingFunc(ctx, lb, 0, config.Size); err != nil {
+				return nil, err
+			}
+
+			// When a ranking write comes first, the positions read are still served, but left to the next read to rebuild
+			if _, err := storageSaveTopViewFunc(ctx, lb, view, config.Refresh); err != nil {
+				return nil, err
+			}
+		}
+
+		ranks := view.Ranks
+		return ranks[min(start, int64(len(ranks))):min(end, int64(len(ranks)))], nil
+	}, nil
+}
+
+// Applies a ranking write to the top view, retrying while concurrent changes save it first, and dropping it once they
+// keep doing so. While there's no view, it's dropped anyway, so a rebuild reading the ranking from before the write
+// isn't saved. `change` tells whether the write changed the view
+func applyTopView(
+	ctx context.Context,
+	lb Leaderboard,
+	config TopViewConfig,
+	storageGetTopViewFunc StorageGetTopViewFunc,
+	storageSaveTopViewFunc StorageSaveTopViewFunc,
+	storageDropTopViewFunc StorageDropTopViewFunc,
+	change func(view TopView) (TopView, bool, error),
+) error {
+	for range topViewSaveAttempts {
+		view, ok, err := storageGetTopViewFunc(ctx, lb)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return storageDropTopViewFunc(ctx, lb)
+		}
+
+		view, changed, err := change(view)
+		if err != nil || !changed {
+			return err
+		}
+
+		saved, err := storageSaveTopViewFunc(ctx, lb, view, config.Refresh)
+		if err != nil || saved {
+			return err
+		}
+	}
+
+	return storageDropTopViewFunc(ctx, lb)
+}
+
+// Wraps the rank update to apply it to the top view, reading the player's resulting rank back when the update may
+// reach the view
+func BuildUpdateTopViewFunc(
+	config TopViewConfig,
+	storageGetTopViewFunc StorageGetTopViewFunc,
+	storageSaveTopViewFunc StorageSaveTopViewFunc,
+	storageDropTopViewFunc StorageDropTopViewFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageGetRankingFunc StorageGetRankingFunc,
+	next StorageUpsertPlayerRankValueFunc,
+) StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb Leaderboard, playerID string, value float64) error {
+		unranked, err := lb.unranked(ctx, storageGetPlayerRankFunc, playerID, value)
+		if err != nil {
+			return err
+		}
+
+		if err := next(ctx, lb, playerID, value); err != nil {
+			return err
+		}
+
+		return applyTopView(ctx, lb, config, storageGetTopViewFunc, storageSaveTopViewFunc, storageDropTopViewFunc, func(view TopView) (TopView, bool, error) {
+			var (
+				full     = int64(len(view.Ranks)) == config.Size
+				included = slices.ContainsFunc(view.Ranks, func(r Rank) bool { return r.PlayerID == playerID })
+			)
+
+			// A value that can't move a player off the view onto it leaves the view as it is
+			if full && !included {
+				if reach, _ := lb.mayReach(value, view.Ranks[len(view.Ranks)-1].Value, unranked); !reach {
+					return view, false, nil
+				}
+			}
+
+			rank, err := storageGetPlayerRankFunc(ctx, lb, playerID)
+			if err != nil {
+				return view, false, err
+			}
+
+			if !included && rank.Position >= config.Size {
+				return view, false, nil
+			}
+
+			view, err = view.place(ctx, lb, config.Size, rank, storageGetRankingFunc)
+			return view, err == nil, err
+		})
+	}
+}
+
+// Wraps the rank removal to take the player out of the top view, reading the rank that takes the freed position
+func BuildRemoveFromTopViewFunc(
+	config TopViewConfig,
+	storageGetTopViewFunc StorageGetTopViewFunc,
+	storageSaveTopViewFunc StorageSaveTopViewFunc,
+	storageDropTopViewFunc StorageDropTopViewFunc,
+	storageGetRankingFunc StorageGetRankingFunc,
+	next StorageDeletePlayerRankFunc,
+) StorageDeletePlayerRankFunc {
+	return func(ctx context.Context, lb Leaderboard, playerID string) error {
+		if err := next(ctx, lb, playerID); err != nil {
+			return err
+		}
+
+		return applyTopView(ctx, lb, config, storageGetTopViewFunc, storageSaveTopViewFunc, storageDropTopViewFunc, func(view TopView) (TopView, bool, error) {
+			if !slices.ContainsFunc(view.Ranks, func(r Rank) bool { return r.PlayerID == playerID }) {
+				return view, false, nil
+			}
+
+			view, err := view.place(ctx, lb, config.Size, Rank{PlayerID: playerID, Position: config.Size}, storageGetRankingFunc)
+			return view, err == nil, err
+		})
+	}
+}
+
+// Wraps the ranking replacement to drop the top view, rebuilt from the replaced ranking on the next read
+func BuildDropTopViewOnReplaceFunc(storageDropTopViewFunc StorageDropTopViewFunc, next StorageReplaceRankingFunc) StorageReplaceRankingFunc {
+	return func(ctx context.Context, lb Leaderboard, ranks []RebuiltRank) error {
+		if err := next(ctx, lb, ranks); err != nil {
+			return err
+		}
+
+		return storageDropTopViewFunc(ctx, lb)
+	}
+}
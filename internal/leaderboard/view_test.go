@@ -0,0 +1,449 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTopViewRankingFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		lb     = Leaderboard{ID: "leaderboard", GameID: "game", AggregationMode: AggregationModeMax, Ordering: OrderingDesc}
+		config = TopViewConfig{Size: 4, Refresh: time.Minute}
+	)
+
+	ranking := []Rank{
+		{LeaderboardID: lb.ID, PlayerID: "a", Position: 0, Value: 50},
+		{LeaderboardID: lb.ID, PlayerID: "b", Position: 1, Value: 40},
+		{LeaderboardID: lb.ID, PlayerID: "c", Position: 2, Value: 30},
+	}
+
+	var (
+		view      TopView
+		viewOk    bool
+		storeHits int
+		nextHits  int
+	)
+
+	getRanking, err := BuildTopViewRankingFunc(
+		config,
+		func(ctx context.Context, lb Leaderboard) (TopView, bool, error) {
+			return view, viewOk, nil
+		},
+		func(ctx context.Context, lb Leaderboard, v TopView, ttl time.Duration) (bool, error) {
+			view, viewOk = TopView{Ranks: v.Ranks, Version: v.Version + 1}, true
+			return true, nil
+		},
+		func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
+			storeHits++
+			return ranking, nil
+		},
+		func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
+			nextHits++
+			return nil, nil
+		},
+	)
+	assert.NoError(t, err)
+
+	t.Run("Built On Read", func(t *testing.T) {
+		ranks, err := getRanking(ctx, lb, 0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, ranking[:2], ranks)
+		assert.Equal(t, 1, storeHits)
+		assert.Equal(t, int64(1), view.Version)
+	})
+
+	t.Run("Served From The View", func(t *testing.T) {
+		ranks, err := getRanking(ctx, lb, 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, ranking[2:], ranks)
+		assert.Equal(t, 1, storeHits)
+	})
+
+	t.Run("Past The View", func(t *testing.T) {
+		_, err := getRanking(ctx, lb, 0, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, storeHits)
+		assert.Equal(t, 1, nextHits)
+	})
+
+	t.Run("Rebuild Saved Only Over The Version Read", func(t *testing.T) {
+		var savedVersion int64
+
+		getRanking, err := BuildTopViewRankingFunc(
+			config,
+			func(ctx context.Context, lb Leaderboard) (TopView, bool, error) {
+				return TopView{Version: 3}, false, nil
+			},
+			func(ctx context.Context, lb Leaderboard, v TopView, ttl time.Duration) (bool, error) {
+				savedVersion = v.Version
+				return false, nil
+			},
+			func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
+				return ranking, nil
+			},
+			nil,
+		)
+		assert.NoError(t, err)
+
+		ranks, err := getRanking(ctx, lb, 0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, ranking[:2], ranks)
+		assert.Equal(t, int64(3), savedVersion)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildTopViewRankingFunc(TopViewConfig{}, nil, nil, nil, nil)
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidTopViewSize)
+		assert.ErrorIs(t, err, ErrInvalidTopViewRefresh)
+	})
+}
+
+func TestBuildUpdateTopViewFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		config = TopViewConfig{Size: 3, Refresh: time.Minute}
+		lb     = Leaderboard{AggregationMode: AggregationModeInc, Ordering: OrderingDesc}
+	)
+
+	top := []Rank{
+		{PlayerID: "a", Position: 0, Value: 50},
+		{PlayerID: "b", Position: 1, Value: 40},
+		{PlayerID: "c", Position: 2, Value: 30},
+	}
+
+	type result struct {
+		view      TopView
+		rankReads int
+		dropped   bool
+	}
+
+	run := func(lb Leaderboard, ranks []Rank, value float64, unranked bool, rank Rank, next Rank, conflicts int) (result, error) {
+		var (
+			res     = result{view: TopView{Ranks: ranks, Version: 1}}
+			save    = 0
+			written = false
+		)
+
+		upsert := BuildUpdateTopViewFunc(
+			config,
+			func(ctx context.Context, lb Leaderboard) (TopView, bool, error) {
+				return res.view, ranks != nil, nil
+			},
+			func(ctx context.Context, lb Leaderboard, view TopView, ttl time.Duration) (bool, error) {
+				if save++; save <= conflicts {
+					return false, nil
+				}
+
+				res.view = TopView{Ranks: view.Ranks, Version: view.Version + 1}
+				return true, nil
+			},
+			func(ctx context.Context, lb Leaderboard) error {
+				res.dropped = true
+				return nil
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) (Rank, error) {
+				if !written {
+					if unranked {
+						return Rank{}, ErrPlayerRankNotFound
+					}
+
+					return Rank{PlayerID: playerID}, nil
+				}
+
+				res.rankReads++
+				return rank, nil
+			},
+			func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
+				return []Rank{next}, nil
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string, value float64) error {
+				written = true
+				return nil
+			},
+		)
+
+		err := upsert(ctx, lb, rank.PlayerID, value)
+		return res, err
+	}
+
+	var (
+		maxAsc  = Leaderboard{AggregationMode: AggregationModeMax, Ordering: OrderingAsc}
+		minDesc = Leaderboard{AggregationMode: AggregationModeMin, Ordering: OrderingDesc}
+	)
+
+	ascTop := []Rank{
+		{PlayerID: "a", Position: 0, Value: 10},
+		{PlayerID: "b", Position: 1, Value: 20},
+		{PlayerID: "c", Position: 2, Value: 30},
+	}
+
+	players := func(view TopView) []string {
+		ids := make([]string, 0, len(view.Ranks))
+		for i, r := range view.Ranks {
+			assert.Equal(t, int64(i), r.Position)
+			ids = append(ids, r.PlayerID)
+		}
+
+		return ids
+	}
+
+	t.Run("Enters The View", func(t *testing.T) {
+		res, err := run(lb, top, 15, false, Rank{PlayerID: "d", Position: 1, Value: 45}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "d", "b"}, players(res.view))
+		assert.Equal(t, int64(2), res.view.Version)
+	})
+
+	t.Run("Moves Within The View", func(t *testing.T) {
+		res, err := run(lb, top, 30, false, Rank{PlayerID: "c", Position: 0, Value: 60}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c", "a", "b"}, players(res.view))
+	})
+
+	t.Run("Leaves The View", func(t *testing.T) {
+		res, err := run(lb, top, -30, false, Rank{PlayerID: "a", Position: 5, Value: 20}, Rank{PlayerID: "e", Position: 2, Value: 25}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b", "c", "e"}, players(res.view))
+	})
+
+	t.Run("Below A Full View", func(t *testing.T) {
+		res, err := run(lb, top, -5, false, Rank{PlayerID: "d", Position: 5}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Zero(t, res.rankReads)
+		assert.Equal(t, int64(1), res.view.Version)
+	})
+
+	t.Run("Not Reaching A Full View", func(t *testing.T) {
+		maxDesc := Leaderboard{AggregationMode: AggregationModeMax, Ordering: OrderingDesc}
+
+		res, err := run(maxDesc, top, 29, false, Rank{PlayerID: "d", Position: 3}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Zero(t, res.rankReads)
+	})
+
+	t.Run("Increment Below A Full View", func(t *testing.T) {
+		res, err := run(lb, top, 5, false, Rank{PlayerID: "d", Position: 3, Value: 10}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res.rankReads)
+		assert.Equal(t, int64(1), res.view.Version)
+	})
+
+	t.Run("Ranked Player Below A Full MAX Ascending View", func(t *testing.T) {
+		res, err := run(maxAsc, ascTop, 15, false, Rank{PlayerID: "d", Position: 1, Value: 10}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Zero(t, res.rankReads)
+		assert.Equal(t, int64(1), res.view.Version)
+	})
+
+	t.Run("New Player Enters A Full MAX Ascending View", func(t *testing.T) {
+		res, err := run(maxAsc, ascTop, 15, true, Rank{PlayerID: "d", Position: 1, Value: 15}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "d", "b"}, players(res.view))
+	})
+
+	t.Run("New Player Below A Full MAX Ascending View", func(t *testing.T) {
+		res, err := run(maxAsc, ascTop, 35, true, Rank{PlayerID: "d", Position: 3, Value: 35}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Zero(t, res.rankReads)
+	})
+
+	t.Run("Ranked Player Below A Full MIN Descending View", func(t *testing.T) {
+		res, err := run(minDesc, top, 45, false, Rank{PlayerID: "d", Position: 1, Value: 60}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Zero(t, res.rankReads)
+		assert.Equal(t, int64(1), res.view.Version)
+	})
+
+	t.Run("New Player Enters A Full MIN Descending View", func(t *testing.T) {
+		res, err := run(minDesc, top, 45, true, Rank{PlayerID: "d", Position: 1, Value: 45}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "d", "b"}, players(res.view))
+	})
+
+	t.Run("New Player Below A Full MIN Descending View", func(t *testing.T) {
+		res, err := run(minDesc, top, 25, true, Rank{PlayerID: "d", Position: 3, Value: 25}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Zero(t, res.rankReads)
+	})
+
+	t.Run("Not Built", func(t *testing.T) {
+		res, err := run(lb, nil, 15, false, Rank{PlayerID: "d", Position: 1, Value: 45}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.True(t, res.dropped)
+		assert.Zero(t, res.rankReads)
+	})
+
+	t.Run("View Not Full", func(t *testing.T) {
+		res, err := run(lb, top[:2], -5, false, Rank{PlayerID: "d", Position: 2, Value: -5}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "d"}, players(res.view))
+	})
+
+	t.Run("Saved After A Conflict", func(t *testing.T) {
+		res, err := run(lb, top, 15, false, Rank{PlayerID: "d", Position: 1, Value: 45}, Rank{}, topViewSaveAttempts-1)
+		assert.NoError(t, err)
+		assert.False(t, res.dropped)
+		assert.Equal(t, topViewSaveAttempts, res.rankReads)
+	})
+
+	t.Run("Dropped After Conflicts", func(t *testing.T) {
+		res, err := run(lb, top, 15, false, Rank{PlayerID: "d", Position: 1, Value: 45}, Rank{}, topViewSaveAttempts)
+		assert.NoError(t, err)
+		assert.True(t, res.dropped)
+	})
+
+	t.Run("View Not Changed In Place", func(t *testing.T) {
+		ranks := slices.Clone(top)
+
+		_, err := run(lb, ranks, 30, false, Rank{PlayerID: "c", Position: 0, Value: 60}, Rank{}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, top, ranks)
+	})
+
+	t.Run("Upsert Error", func(t *testing.T) {
+		upsertErr := errors.New("any storage error")
+
+		upsert := BuildUpdateTopViewFunc(config, nil, nil, nil, nil, nil, func(ctx context.Context, lb Leaderboard, playerID string, value float64) error {
+			return upsertErr
+		})
+
+		assert.ErrorIs(t, upsert(ctx, lb, "player", 1), upsertErr)
+	})
+}
+
+func TestBuildRemoveFromTopViewFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		config = TopViewConfig{Size: 3, Refresh: time.Minute}
+		lb     = Leaderboard{AggregationMode: AggregationModeMax, Ordering: OrderingDesc}
+	)
+
+	top := []Rank{
+		{PlayerID: "a", Position: 0, Value: 50},
+		{PlayerID: "b", Position: 1, Value: 40},
+		{PlayerID: "c", Position: 2, Value: 30},
+	}
+
+	type result struct {
+		view         TopView
+		rankingReads int
+		dropped      bool
+	}
+
+	run := func(ranks []Rank, playerID string, next Rank) (result, error) {
+		res := result{view: TopView{Ranks: ranks, Version: 1}}
+
+		remove := BuildRemoveFromTopViewFunc(
+			config,
+			func(ctx context.Context, lb Leaderboard) (TopView, bool, error) {
+				return res.view, ranks != nil, nil
+			},
+			func(ctx context.Context, lb Leaderboard, view TopView, ttl time.Duration) (bool, error) {
+				res.view = TopView{Ranks: view.Ranks, Version: view.Version + 1}
+				return true, nil
+			},
+			func(ctx context.Context, lb Leaderboard) error {
+				res.dropped = true
+				return nil
+			},
+			func(ctx context.Context, lb Leaderboard, page, limit int64) ([]Rank, error) {
+				res.rankingReads++
+				return []Rank{next}, nil
+			},
+			func(ctx context.Context, lb Leaderboard, playerID string) error {
+				return nil
+			},
+		)
+
+		err := remove(ctx, lb, playerID)
+		return res, err
+	}
+
+	players := func(view TopView) []string {
+		ids := make([]string, 0, len(view.Ranks))
+		for i, r := range view.Ranks {
+			assert.Equal(t, int64(i), r.Position)
+			ids = append(ids, r.PlayerID)
+		}
+
+		return ids
+	}
+
+	t.Run("Removed From A Full View", func(t *testing.T) {
+		res, err := run(top, "a", Rank{PlayerID: "d", Position: 2, Value: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b", "c", "d"}, players(res.view))
+		assert.Equal(t, int64(2), res.view.Version)
+	})
+
+	t.Run("Removed From A View Not Full", func(t *testing.T) {
+		res, err := run(top[:2], "a", Rank{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b"}, players(res.view))
+		assert.Zero(t, res.rankingReads)
+	})
+
+	t.Run("Off The View", func(t *testing.T) {
+		res, err := run(top, "d", Rank{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res.view.Version)
+		assert.Zero(t, res.rankingReads)
+	})
+
+	t.Run("Not Built", func(t *testing.T) {
+		res, err := run(nil, "a", Rank{})
+		assert.NoError(t, err)
+		assert.True(t, res.dropped)
+	})
+
+	t.Run("Delete Error", func(t *testing.T) {
+		deleteErr := errors.New("any storage error")
+
+		remove := BuildRemoveFromTopViewFunc(config, nil, nil, nil, nil, func(ctx context.Context, lb Leaderboard, playerID string) error {
+			return deleteErr
+		})
+
+		assert.ErrorIs(t, remove(ctx, lb, "player"), deleteErr)
+	})
+}
+
+func TestBuildDropTopViewOnReplaceFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		lb      = Leaderboard{ID: "leaderboard"}
+		dropped bool
+	)
+
+	drop := func(ctx context.Context, lb Leaderboard) error {
+		dropped = true
+		return nil
+	}
+
+	t.Run("Dropped", func(t *testing.T) {
+		replace := BuildDropTopViewOnReplaceFunc(drop, func(ctx context.Context, lb Leaderboard, ranks []RebuiltRank) error {
+			return nil
+		})
+
+		assert.NoError(t, replace(ctx, lb, nil))
+		assert.True(t, dropped)
+	})
+
+	t.Run("Replace Error", func(t *testing.T) {
+		dropped = false
+		replaceErr := errors.New("any storage error")
+
+		replace := BuildDropTopViewOnReplaceFunc(drop, func(ctx context.Context, lb Leaderboard, ranks []RebuiltRank) error {
+			return replaceErr
+		})
+
+		assert.ErrorIs(t, replace(ctx, lb, nil), replaceErr)
+		assert.False(t, dropped)
+	})
+}
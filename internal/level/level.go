@@ -0,0 +1,482 @@
+package level
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Most characters on a curve name
+const MaxNameLength = 64
+
+// Highest level a curve can reach, so the formulas stay within a range a game can show
+const MaxLevelLimit = 10000
+
+// How the XP needed to reach each level is defined
+const (
+	KindTable       = "TABLE"       // Total XP of each level listed one by one
+	KindLinear      = "LINEAR"      // Every level needs the same XP as the previous one
+	KindExponential = "EXPONENTIAL" // Every level needs the XP of the previous one times the growth
+)
+
+var Kinds = []string{
+	KindTable,
+	KindLinear,
+	KindExponential,
+}
+
+var (
+	ErrValidationError    = errors.New("validation error")
+	ErrInvalidName        = errors.New("name must have between 1 and 64 characters")
+	ErrInvalidGameID      = errors.New("invalid game id")
+	ErrMissingStatisticID = errors.New("missing xp statistic id")
+	ErrInvalidKind        = errors.New("kind must be one of TABLE, LINEAR or EXPONENTIAL")
+	ErrInvalidThresholds  = errors.New("the table curve requires between 1 and 9999 thresholds, positive and increasing")
+	ErrInvalidBase        = errors.New("the formula curves require a base greater than 0")
+	ErrInvalidGrowth      = errors.New("the exponential curve requires a growth greater than 1")
+	ErrInvalidMaxLevel    = errors.New("the formula curves require a max level between 2 and 10000")
+	ErrCurveOverflow      = errors.New("the xp of the max level is too large")
+	ErrStatisticNotSum    = errors.New("the xp statistic must use the SUM aggregation mode")
+
+	ErrInvalidCurveID  = errors.New("invalid curve id")
+	ErrCurveNotFound   = errors.New("level curve not found")
+	ErrInvalidPlayerID = errors.New("invalid player id")
+)
+
+type (
+	NewCurveData struct {
+		GameID      string    // Game the curve belongs to
+		Name        string    // Curve name, e.g. the account level or the season pass
+		StatisticID string    // Statistic whose value is the player's XP
+		Kind        string    // Curve kind, one of `TABLE`, `LINEAR` or `EXPONENTIAL`
+		Thresholds  []float64 // Total XP to reach each level from level 2 on. Only on `TABLE`
+		Base        float64   // XP to go from level 1 to level 2. Only on `LINEAR` and `EXPONENTIAL`
+		Growth      float64   // Multiplier of the XP of each level over the previous one. Only on `EXPONENTIAL`
+		MaxLevel    int64     // Highest level. Only on `LINEAR` and `EXPONENTIAL`, as the table ends on its last threshold
+	}
+
+	Curve struct {
+		CreatedAt   time.Time // Time the curve was created
+		ID          string    // Curve ID, assigned by the storage
+		GameID      string    // Game the curve belongs to
+		Name        string    // Curve name, e.g. the account level or the season pass
+		StatisticID string    // Statistic whose value is the player's XP
+		Kind        string    // Curve kind, one of `TABLE`, `LINEAR` or `EXPONENTIAL`
+		Thresholds  []float64 // Total XP to reach each level from level 2 on. Only on `TABLE`
+		Base        float64   // XP to go from level 1 to level 2. Only on `LINEAR` and `EXPONENTIAL`
+		Growth      float64   // Multiplier of the XP of each level over the previous one. Only on `EXPONENTIAL`
+		MaxLevel    int64     // Highest level. Only on `LINEAR` and `EXPONENTIAL`
+	}
+
+	// Player's level on a curve, from their XP
+	PlayerLevel struct {
+		CurveID       string   // Curve the level is on
+		PlayerID      string   // Player's ID
+		XP            float64  // Player's XP
+		Level         int64    // Player's level, starting at 1
+		LevelXP       float64  // Total XP to reach the player's level
+		NextLevelXP   *float64 // Total XP to reach the next level. nil on the max level
+		XPToNextLevel *float64 // XP the player still needs to reach the next level. nil on the max level
+	}
+
+	// Levels gained by a player on a single progression update
+	LevelUp struct {
+		OccurredAt    time.Time // Time the player's XP was updated
+		Curve         Curve     // Curve the player leveled up on
+		PlayerID      string    // Player's ID
+		PreviousLevel int64     // Player's level before the update
+		Level         int64     // Player's level after the update
+		XP            float64   // Player's XP after the update
+	}
+)
+
+func (d NewCurveData) validate() error {
+	errList := make([]error, 0)
+
+	if name := strings.TrimSpace(d.Name); name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		errList = append(errList, ErrInvalidName)
+	}
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if d.StatisticID == "" {
+		errList = append(errList, ErrMissingStatisticID)
+	}
+
+	switch d.Kind {
+	case KindTable:
+		if !validThresholds(d.Thresholds) {
+			errList = append(errList, ErrInvalidThresholds)
+		}
+	case KindLinear, KindExponential:
+		validFormula := true
+		if !(d.Base > 0) || math.IsInf(d.Base, 0) {
+			errList = append(errList, ErrInvalidBase)
+			validFormula = false
+		}
+
+		if d.Kind == KindExponential && (!(d.Growth > 1) || math.IsInf(d.Growth, 0)) {
+			errList = append(errList, ErrInvalidGrowth)
+			validFormula = false
+		}
+
+		if d.MaxLevel < 2 || d.MaxLevel > MaxLevelLimit {
+			errList = append(errList, ErrInvalidMaxLevel)
+			validFormula = false
+		}
+
+		c := Curve{Kind: d.Kind, Base: d.Base, Growth: d.Growth, MaxLevel: d.MaxLevel}
+		if validFormula && math.IsInf(c.threshold(c.MaxLevel), 0) {
+			errList = append(errList, ErrCurveOverflow)
+		}
+	default:
+		errList = append(errList, ErrInvalidKind)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// The thresholds must be positive, finite and increasing
+func validThresholds(thresholds []float64) bool {
+	if len(thresholds) == 0 || len(thresholds) >= MaxLevelLimit {
+		return false
+	}
+
+	previous := 0.0
+	for _, t := range thresholds {
+		if !(t > previous) || math.IsInf(t, 0) {
+			return false
+		}
+		previous = t
+	}
+
+	return true
+}
+
+// Keeps only the fields of the curve's kind
+func (d NewCurveData) normalize() NewCurveData {
+	d.Name = strings.TrimSpace(d.Name)
+	switch d.Kind {
+	case KindTable:
+		d.Base, d.Growth, d.MaxLevel = 0, 0, 0
+	case KindLinear:
+		d.Thresholds, d.Growth = nil, 0
+	case KindExponential:
+		d.Thresholds = nil
+	}
+
+	return d
+}
+
+func (c Curve) maxLevel() int64 {
+	if c.Kind == KindTable {
+		return int64(len(c.Thresholds)) + 1
+	}
+
+	return c.MaxLevel
+}
+
+// Total XP to reach the level
+func (c Curve) threshold(level int64) float64 {
+	if level <= 1 {
+		return 0
+	}
+
+	switch c.Kind {
+	case KindTable:
+		return c.Thresholds[level-2]
+	case KindExponential:
+		return c.Base * (math.Pow(c.Growth, float64(level-1)) - 1) / (c.Growth - 1)
+	default:
+		return c.Base * float64(level-1)
+	}
+}
+
+// Level reached with the XP, starting at 1
+func (c Curve) level(xp float64) int64 {
+	switch {
+	case xp < c.threshold(2):
+		return 1
+	case xp >= c.threshold(c.maxLevel()):
+		return c.maxLevel()
+	}
+
+	var level int64
+	switch c.Kind {
+	case KindTable:
+		level = int64(sort.Search(len(c.Thresholds), func(i int) bool { return c.Thresholds[i] > xp })) + 1
+	case KindExponential:
+		level = int64(math.Floor(math.Log(xp*(c.Growth-1)/c.Base+1)/math.Log(c.Growth))) + 1
+	default:
+		level = int64(math.Floor(xp/c.Base)) + 1
+	}
+
+	level = min(max(level, 1), c.maxLevel())
+
+	// The formulas are solved on floats, so the level is checked against its thresholds
+	for level > 1 && c.threshold(level) > xp {
+		level--
+	}
+
+	for level < c.maxLevel() && c.threshold(level+1) <= xp {
+		level++
+	}
+
+	return level
+}
+
+func (c Curve) playerLevel(playerID string, xp float64) PlayerLevel {
+	l := PlayerLevel{
+		CurveID:  c.ID,
+		PlayerID: playerID,
+		XP:       xp,
+		Level:    c.level(xp),
+	}
+	l.LevelXP = c.threshold(l.Level)
+
+	if l.Level < c.maxLevel() {
+		next := c.threshold(l.Level + 1)
+		toNext := next - xp
+		l.NextLevelXP, l.XPToNextLevel = &next, &toNext
+	}
+
+	return l
+}
+
+// The statistic must belong to the game and add up the values sent, as the XP only goes up by the amounts earned
+func BuildCreateFunc(getStatisticFunc statistic.GetByIDAndGameIDFunc, storageCreateCurveFunc StorageCreateCurveFunc) CreateFunc {
+	return func(ctx context.Context, data NewCurveData) (Curve, error) {
+		if err := data.validate(); err != nil {
+			return Curve{}, err
+		}
+
+		data = data.normalize()
+		s, err := getStatisticFunc(ctx, data.StatisticID, data.GameID)
+		if err != nil {
+			return Curve{}, err
+		}
+
+		if s.AggregationMode != statistic.AggregationModeSum {
+			return Curve{}, ErrStatisticNotSum
+		}
+
+		return storageCreateCurveFunc(ctx, data)
+	}
+}
+
+func BuildGetByIDAndGameIDFunc(storageGetCurveFunc StorageGetCurveFunc) GetByIDAndGameIDFunc {
+	return func(ctx context.Context, id, gameID string) (Curve, error) {
+		if id == "" {
+			return Curve{}, ErrInvalidCurveID
+		}
+
+		return storageGetCurveFunc(ctx, id, gameID)
+	}
+}
+
+func BuildListFunc(storageListCurvesFunc StorageListCurvesFunc) ListFunc {
+	return func(ctx context.Context, gameID string) ([]Curve, error) {
+		return storageListCurvesFunc(ctx, gameID)
+	}
+}
+
+func BuildDeleteFunc(storageDeleteCurveFunc StorageDeleteCurveFunc) DeleteFunc {
+	return func(ctx context.Context, id, gameID string) error {
+		if id == "" {
+			return ErrInvalidCurveID
+		}
+
+		return storageDeleteCurveFunc(ctx, id, gameID)
+	}
+}
+
+// A player without progression on the statistic has its initial value as XP
+func playerXP(ctx context.Context, c Curve, playerID string, getStatisticFunc statistic.GetByIDAndGameIDFunc, getPlayerProgressionFunc statistic.GetPlayerProgressionFunc) (float64, error) {
+	progression, err := getPlayerProgressionFunc(ctx, c.StatisticID, playerID)
+	if err == nil && progression.CurrentValue != nil {
+		return *progression.CurrentValue, nil
+	}
+
+	if err != nil && !errors.Is(err, statistic.ErrPlayerStatisticNotFound) {
+		return 0, err
+	}
+
+	s, err := getStatisticFunc(ctx, c.StatisticID, c.GameID)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.InitialValue == nil {
+		return 0, nil
+	}
+
+	return *s.InitialValue, nil
+}
+
+func BuildGetPlayerLevelFunc(
+	getStatisticFunc statistic.GetByIDAndGameIDFunc,
+	getPlayerProgressionFunc statistic.GetPlayerProgressionFunc,
+	storageGetCurveFunc StorageGetCurveFunc,
+) GetPlayerLevelFunc {
+	return func(ctx context.Context, gameID, curveID, playerID string) (PlayerLevel, error) {
+		if curveID == "" {
+			return PlayerLevel{}, ErrInvalidCurveID
+		}
+
+		if playerID == "" {
+			return PlayerLevel{}, ErrInvalidPlayerID
+		}
+
+		c, err := storageGetCurveFunc(ctx, curveID, gameID)
+		if err != nil {
+			return PlayerLevel{}, err
+		}
+
+		xp, err := playerXP(ctx, c, playerID, getStatisticFunc, getPlayerProgressionFunc)
+		if err != nil {
+			return PlayerLevel{}, err
+		}
+
+		return c.playerLevel(playerID, xp), nil
+	}
+}
+
+func BuildListPlayerLevelsFunc(
+	getStatisticFunc statistic.GetByIDAndGameIDFunc,
+	getPlayerProgressionFunc statistic.GetPlayerProgressionFunc,
+	storageListCurvesFunc StorageListCurvesFunc,
+) ListPlayerLevelsFunc {
+	return func(ctx context.Context, gameID, playerID string) ([]PlayerLevel, error) {
+		if playerID == "" {
+			return nil, ErrInvalidPlayerID
+		}
+
+		curves, err := storageListCurvesFunc(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		levels := make([]PlayerLevel, len(curves))
+		for i, c := range curves {
+			xp, err := playerXP(ctx, c, playerID, getStatisticFunc, getPlayerProgressionFunc)
+			if err != nil {
+				return nil, err
+			}
+
+			levels[i] = c.playerLevel(playerID, xp)
+		}
+
+		return levels, nil
+	}
+}
+
+// Level-ups of the curves on the statistic made by adding the value to the player's XP
+func levelUps(curves []Curve, s statistic.Statistic, progression statistic.PlayerProgression, value float64) []LevelUp {
+	if progression.CurrentValue == nil {
+		return nil
+	}
+
+	var (
+		xp       = *progression.CurrentValue
+		ups      = make([]LevelUp, 0)
+		previous = xp - value
+	)
+	for _, c := range curves {
+		if c.StatisticID != s.ID {
+			continue
+		}
+
+		if from, to := c.level(previous), c.level(xp); to > from {
+			ups = append(ups, LevelUp{
+				OccurredAt:    progression.UpdatedAt,
+				Curve:         c,
+				PlayerID:      progression.PlayerID,
+				PreviousLevel: from,
+				Level:         to,
+				XP:            xp,
+			})
+		}
+	}
+
+	return ups
+}
+
+// Wraps the progression update to notify the level-ups it made on the curves of the statistic.
+// The XP statistics add up the values sent, so the XP before the update is the current one minus the value added,
+// without reading it beforehand. The curves are only listed for the statistics that add up.
+// Like the progression notifier, a failed notification fails the update after it's applied
+func BuildNotifyLevelUpsFunc(
+	notifierLevelUp NotifierLevelUp,
+	storageListCurvesFunc StorageListCurvesFunc,
+	next statistic.StorageUpdatePlayerProgressionFunc,
+) statistic.StorageUpdatePlayerProgressionFunc {
+	return func(ctx context.Context, s statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+		progression, updates, err := next(ctx, s, playerID, value)
+		if err != nil || s.AggregationMode != statistic.AggregationModeSum {
+			return progression, updates, err
+		}
+
+		curves, err := storageListCurvesFunc(ctx, s.GameID)
+		if err != nil {
+			return progression, updates, err
+		}
+
+		for _, up := range levelUps(curves, s, progression, value) {
+			if err := notifierLevelUp(ctx, up); err != nil {
+				return progression, updates, err
+			}
+		}
+
+		return progression, updates, nil
+	}
+}
+
+// Same as `BuildNotifyLevelUpsFunc`, for the updates of many progressions at once.
+// The curves are listed once per game on the batch
+func BuildNotifyBulkLevelUpsFunc(
+	notifierLevelUp NotifierLevelUp,
+	storageListCurvesFunc StorageListCurvesFunc,
+	next statistic.StorageBulkUpdatePlayerProgressionFunc,
+) statistic.StorageBulkUpdatePlayerProgressionFunc {
+	return func(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]statistic.PlayerProgression, []statistic.PlayerProgressionUpdates, error) {
+		progressions, updates, err := next(ctx, changes)
+		if err != nil {
+			return progressions, updates, err
+		}
+
+		curvesByGame := make(map[string][]Curve)
+		for i, change := range changes {
+			if change.Statistic.AggregationMode != statistic.AggregationModeSum {
+				continue
+			}
+
+			curves, ok := curvesByGame[change.Statistic.GameID]
+			if !ok {
+				if curves, err = storageListCurvesFunc(ctx, change.Statistic.GameID); err != nil {
+					return progressions, updates, err
+				}
+				curvesByGame[change.Statistic.GameID] = curves
+			}
+
+			for _, up := range levelUps(curves, change.Statistic, progressions[i], change.Value) {
+				if err := notifierLevelUp(ctx, up); err != nil {
+					return progressions, updates, err
+				}
+			}
+		}
+
+		return progressions, updates, nil
+	}
+}
@@ -0,0 +1,267 @@
+package level
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurveLevel(t *testing.T) {
+	t.Run("Table", func(t *testing.T) {
+		c := Curve{Kind: KindTable, Thresholds: []float64{100, 250, 500}}
+		assert.Equal(t, int64(1), c.level(-10))
+		assert.Equal(t, int64(1), c.level(99))
+		assert.Equal(t, int64(2), c.level(100))
+		assert.Equal(t, int64(3), c.level(499))
+		assert.Equal(t, int64(4), c.level(500))
+		assert.Equal(t, int64(4), c.level(1e9))
+	})
+
+	t.Run("Linear", func(t *testing.T) {
+		c := Curve{Kind: KindLinear, Base: 100, MaxLevel: 50}
+		assert.Equal(t, int64(1), c.level(0))
+		assert.Equal(t, int64(2), c.level(100))
+		assert.Equal(t, int64(10), c.level(999))
+		assert.Equal(t, int64(50), c.level(1e300))
+	})
+
+	t.Run("Exponential", func(t *testing.T) {
+		// 100 to level 2, 200 more to level 3, 400 more to level 4
+		c := Curve{Kind: KindExponential, Base: 100, Growth: 2, MaxLevel: 60}
+		assert.Equal(t, int64(1), c.level(99.9))
+		assert.Equal(t, int64(2), c.level(100))
+		assert.Equal(t, int64(2), c.level(299))
+		assert.Equal(t, int64(3), c.level(300))
+		assert.Equal(t, int64(4), c.level(700))
+		assert.Equal(t, int64(60), c.level(1e300))
+	})
+
+	t.Run("Player Level", func(t *testing.T) {
+		c := Curve{ID: "account", Kind: KindTable, Thresholds: []float64{100, 250}}
+
+		l := c.playerLevel("player", 180)
+		assert.Equal(t, int64(2), l.Level)
+		assert.Equal(t, float64(100), l.LevelXP)
+		assert.Equal(t, float64(250), *l.NextLevelXP)
+		assert.Equal(t, float64(70), *l.XPToNextLevel)
+
+		l = c.playerLevel("player", 300)
+		assert.Equal(t, int64(3), l.Level)
+		assert.Nil(t, l.NextLevelXP)
+		assert.Nil(t, l.XPToNextLevel)
+	})
+}
+
+func TestBuildCreateFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		getStatistic = func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+			return statistic.Statistic{ID: id, GameID: gameID, AggregationMode: statistic.AggregationModeSum}, nil
+		}
+		create = func(ctx context.Context, data NewCurveData) (Curve, error) {
+			return Curve{
+				ID:          uuid.NewString(),
+				GameID:      data.GameID,
+				Name:        data.Name,
+				StatisticID: data.StatisticID,
+				Kind:        data.Kind,
+				Thresholds:  data.Thresholds,
+				Base:        data.Base,
+				Growth:      data.Growth,
+				MaxLevel:    data.MaxLevel,
+			}, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		c, err := BuildCreateFunc(getStatistic, create)(ctx, NewCurveData{
+			GameID:      "game",
+			Name:        " Account ",
+			StatisticID: "xp",
+			Kind:        KindLinear,
+			Base:        100,
+			Growth:      3,
+			MaxLevel:    50,
+			Thresholds:  []float64{1},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Account", c.Name)
+		assert.Zero(t, c.Growth)
+		assert.Nil(t, c.Thresholds)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildCreateFunc(nil, nil)(ctx, NewCurveData{Kind: "QUADRATIC"})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidName)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrMissingStatisticID)
+		assert.ErrorIs(t, err, ErrInvalidKind)
+
+		_, err = BuildCreateFunc(nil, nil)(ctx, NewCurveData{GameID: "game", Name: "a", StatisticID: "xp", Kind: KindTable, Thresholds: []float64{100, 100}})
+		assert.ErrorIs(t, err, ErrInvalidThresholds)
+
+		_, err = BuildCreateFunc(nil, nil)(ctx, NewCurveData{GameID: "game", Name: "a", StatisticID: "xp", Kind: KindExponential, Growth: 1, MaxLevel: 1})
+		assert.ErrorIs(t, err, ErrInvalidBase)
+		assert.ErrorIs(t, err, ErrInvalidGrowth)
+		assert.ErrorIs(t, err, ErrInvalidMaxLevel)
+
+		_, err = BuildCreateFunc(nil, nil)(ctx, NewCurveData{GameID: "game", Name: "a", StatisticID: "xp", Kind: KindExponential, Base: 100, Growth: 10, MaxLevel: MaxLevelLimit})
+		assert.ErrorIs(t, err, ErrCurveOverflow)
+	})
+
+	t.Run("Statistic Not Sum", func(t *testing.T) {
+		getStatistic := func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+			return statistic.Statistic{ID: id, GameID: gameID, AggregationMode: statistic.AggregationModeMax}, nil
+		}
+
+		_, err := BuildCreateFunc(getStatistic, create)(ctx, NewCurveData{GameID: "game", Name: "a", StatisticID: "xp", Kind: KindTable, Thresholds: []float64{100}})
+		assert.ErrorIs(t, err, ErrStatisticNotSum)
+	})
+}
+
+func TestBuildGetPlayerLevelFunc(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		initial  = float64(150)
+		curve    = Curve{ID: "account", GameID: "game", StatisticID: "xp", Kind: KindTable, Thresholds: []float64{100, 250}}
+		getCurve = func(ctx context.Context, id, gameID string) (Curve, error) {
+			if id != curve.ID {
+				return Curve{}, ErrCurveNotFound
+			}
+			return curve, nil
+		}
+		getStatistic = func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+			return statistic.Statistic{ID: id, GameID: gameID, InitialValue: &initial}, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		xp := float64(260)
+		getProgression := func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+			return statistic.PlayerProgression{StatisticID: statisticID, PlayerID: playerID, CurrentValue: &xp}, nil
+		}
+
+		l, err := BuildGetPlayerLevelFunc(getStatistic, getProgression, getCurve)(ctx, "game", "account", "player")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), l.Level)
+		assert.Equal(t, xp, l.XP)
+	})
+
+	t.Run("Without Progression", func(t *testing.T) {
+		getProgression := func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+			return statistic.PlayerProgression{}, statistic.ErrPlayerStatisticNotFound
+		}
+
+		l, err := BuildGetPlayerLevelFunc(getStatistic, getProgression, getCurve)(ctx, "game", "account", "player")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), l.Level)
+		assert.Equal(t, initial, l.XP)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := BuildGetPlayerLevelFunc(nil, nil, nil)(ctx, "game", "", "player")
+		assert.ErrorIs(t, err, ErrInvalidCurveID)
+
+		_, err = BuildGetPlayerLevelFunc(nil, nil, nil)(ctx, "game", "account", "")
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+
+		_, err = BuildGetPlayerLevelFunc(nil, nil, getCurve)(ctx, "game", "season", "player")
+		assert.ErrorIs(t, err, ErrCurveNotFound)
+	})
+}
+
+func TestBuildNotifyLevelUpsFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		now    = time.Now().UTC()
+		curves = []Curve{
+			{ID: "account", GameID: "game", StatisticID: "xp", Kind: KindTable, Thresholds: []float64{100, 250, 500}},
+			{ID: "pass", GameID: "game", StatisticID: "pass-xp", Kind: KindLinear, Base: 10, MaxLevel: 100},
+		}
+		list   = func(ctx context.Context, gameID string) ([]Curve, error) { return curves, nil }
+		xp     = statistic.Statistic{ID: "xp", GameID: "game", AggregationMode: statistic.AggregationModeSum}
+		update = func(current float64) statistic.StorageUpdatePlayerProgressionFunc {
+			return func(ctx context.Context, s statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+				return statistic.PlayerProgression{UpdatedAt: now, PlayerID: playerID, StatisticID: s.ID, CurrentValue: &current}, statistic.PlayerProgressionUpdates{}, nil
+			}
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var ups []LevelUp
+		notify := func(ctx context.Context, up LevelUp) error {
+			ups = append(ups, up)
+			return nil
+		}
+
+		_, _, err := BuildNotifyLevelUpsFunc(notify, list, update(300))(ctx, xp, "player", 220)
+		assert.NoError(t, err)
+		assert.Equal(t, []LevelUp{{OccurredAt: now, Curve: curves[0], PlayerID: "player", PreviousLevel: 1, Level: 3, XP: 300}}, ups)
+
+		ups = nil
+		_, _, err = BuildNotifyLevelUpsFunc(notify, list, update(300))(ctx, xp, "player", 10)
+		assert.NoError(t, err)
+		assert.Empty(t, ups)
+	})
+
+	t.Run("Not Sum", func(t *testing.T) {
+		list := func(ctx context.Context, gameID string) ([]Curve, error) {
+			t.Fail()
+			return nil, nil
+		}
+
+		s := statistic.Statistic{ID: "xp", GameID: "game", AggregationMode: statistic.AggregationModeMax}
+		_, _, err := BuildNotifyLevelUpsFunc(nil, list, update(300))(ctx, s, "player", 300)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		errNotify := errors.New("broker unavailable")
+		notify := func(ctx context.Context, up LevelUp) error { return errNotify }
+
+		_, _, err := BuildNotifyLevelUpsFunc(notify, list, update(600))(ctx, xp, "player", 600)
+		assert.ErrorIs(t, err, errNotify)
+	})
+
+	t.Run("Bulk", func(t *testing.T) {
+		var ups []LevelUp
+		notify := func(ctx context.Context, up LevelUp) error {
+			ups = append(ups, up)
+			return nil
+		}
+
+		var listed int
+		list := func(ctx context.Context, gameID string) ([]Curve, error) {
+			listed++
+			return curves, nil
+		}
+
+		bulkUpdate := func(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]statistic.PlayerProgression, []statistic.PlayerProgressionUpdates, error) {
+			current := []float64{120, 40}
+			progressions := make([]statistic.PlayerProgression, len(changes))
+			for i, change := range changes {
+				progressions[i] = statistic.PlayerProgression{UpdatedAt: now, PlayerID: change.PlayerID, StatisticID: change.Statistic.ID, CurrentValue: &current[i]}
+			}
+			return progressions, make([]statistic.PlayerProgressionUpdates, len(changes)), nil
+		}
+
+		passXP := statistic.Statistic{ID: "pass-xp", GameID: "game", AggregationMode: statistic.AggregationModeSum}
+		_, _, err := BuildNotifyBulkLevelUpsFunc(notify, list, bulkUpdate)(ctx, []statistic.PlayerProgressionChange{
+			{Statistic: xp, PlayerID: "player", Value: 30},
+			{Statistic: passXP, PlayerID: "other", Value: 40},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, listed)
+		assert.Equal(t, []LevelUp{
+			{OccurredAt: now, Curve: curves[0], PlayerID: "player", PreviousLevel: 1, Level: 2, XP: 120},
+			{OccurredAt: now, Curve: curves[1], PlayerID: "other", PreviousLevel: 1, Level: 5, XP: 40},
+		}, ups)
+	})
+}
@@ -0,0 +1,8 @@
+package level
+
+import "context"
+
+type (
+	// Notify the levels gained by a player
+	NotifierLevelUp func(ctx context.Context, up LevelUp) error
+)
@@ -0,0 +1,17 @@
+package level
+
+import "context"
+
+type (
+	// Stores a new curve, returning it with its ID
+	StorageCreateCurveFunc func(ctx context.Context, data NewCurveData) (Curve, error)
+
+	// Get a curve by its id and game id
+	StorageGetCurveFunc func(ctx context.Context, id, gameID string) (Curve, error)
+
+	// Lists the curves of the game, oldest first
+	StorageListCurvesFunc func(ctx context.Context, gameID string) ([]Curve, error)
+
+	// Removes the curve of the game
+	StorageDeleteCurveFunc func(ctx context.Context, id, gameID string) error
+)
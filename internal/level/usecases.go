@@ -0,0 +1,23 @@
+package level
+
+import "context"
+
+type (
+	// Creates a level curve of the game
+	CreateFunc func(ctx context.Context, data NewCurveData) (Curve, error)
+
+	// Get a curve by its id and game id
+	GetByIDAndGameIDFunc func(ctx context.Context, id, gameID string) (Curve, error)
+
+	// Lists the curves of the game, oldest first
+	ListFunc func(ctx context.Context, gameID string) ([]Curve, error)
+
+	// Removes the curve of the game
+	DeleteFunc func(ctx context.Context, id, gameID string) error
+
+	// Get the player's level on the curve
+	GetPlayerLevelFunc func(ctx context.Context, gameID, curveID, playerID string) (PlayerLevel, error)
+
+	// Lists the player's levels on each of the game's curves, in the order of the curves
+	ListPlayerLevelsFunc func(ctx context.Context, gameID, playerID string) ([]PlayerLevel, error)
+)
@@ -0,0 +1,323 @@
+package merge
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Kinds of the player's data merged
+const (
+	DataRanks                = "RANKS"                 // Player's entries on the leaderboards rankings
+	DataStatisticProgression = "STATISTIC_PROGRESSION" // Player's progression on the statistics
+	DataQuestProgression     = "QUEST_PROGRESSION"     // Player's progression on the quests and their tasks
+)
+
+const (
+	// Actor registered on the audit log for every merge, as it's requested with the admin token
+	AuditActor = "admin"
+	// Method registered on the audit log for every merge
+	AuditMethod = "MERGE"
+	// Route registered on the audit log for every merge, so it's listed with the other requests on the game's players
+	AuditRoute = "/api/v1/players/:playerId/merge"
+)
+
+var (
+	ErrValidationError       = errors.New("validation error")
+	ErrInvalidGameID         = errors.New("invalid game id")
+	ErrInvalidSourcePlayerID = errors.New("invalid source player id")
+	ErrInvalidTargetPlayerID = errors.New("invalid target player id")
+	ErrSamePlayer            = errors.New("a player can't be merged into itself")
+)
+
+type (
+	// Player's data of a kind combined into the target player's one
+	Merge struct {
+		Data    string // Kind of the data merged, e.g. `DataRanks`
+		Records int64  // Records of the source player combined into the target player's ones
+	}
+
+	// Proof that a player was merged into another one
+	Receipt struct {
+		ID             string    // Receipt ID, the ID of the audit entry recording the merge
+		GameID         string    // Game both players belong to
+		SourcePlayerID string    // Player merged, left without ranks, statistic nor quest progression
+		TargetPlayerID string    // Player kept, holding the data of both
+		MergedAt       time.Time // Time the merge completed
+		Merges         []Merge   // Data combined of each kind
+	}
+)
+
+func validate(gameID, sourcePlayerID, targetPlayerID string) error {
+	errList := make([]error, 0)
+
+	if gameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if sourcePlayerID == "" {
+		errList = append(errList, ErrInvalidSourcePlayerID)
+	}
+
+	if targetPlayerID == "" {
+		errList = append(errList, ErrInvalidTargetPlayerID)
+	}
+
+	if sourcePlayerID != "" && sourcePlayerID == targetPlayerID {
+		errList = append(errList, ErrSamePlayer)
+	}
+
+	if len(errList) > 0 {
+		errList = slices.Insert(errList, 0, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Each rank of the source player is sent to the target one as a submission, so the leaderboard's aggregation mode
+// decides the value kept: the values are added up on `INC` and the best one is kept on `MAX` and `MIN`. The source
+// rank is removed right after, so a failed merge can be retried without adding it twice. The ranks on the soft deleted
+// leaderboards are removed with the source player's remaining ones
+func BuildMergeRanksFunc(
+	storageListLeaderboardsFunc StorageListLeaderboardsFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageUpsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc,
+	storageDeletePlayerRankFunc StorageDeletePlayerRankFunc,
+	storageErasePlayerRanksFunc privacy.StorageErasePlayerFunc,
+) MergeDataFunc {
+	return func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (Merge, error) {
+		leaderboards, err := storageListLeaderboardsFunc(ctx, gameID)
+		if err != nil {
+			return Merge{}, err
+		}
+
+		merge := Merge{Data: DataRanks}
+		for _, lb := range leaderboards {
+			rank, err := storageGetPlayerRankFunc(ctx, lb, sourcePlayerID)
+			if errors.Is(err, leaderboard.ErrPlayerRankNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return Merge{}, err
+			}
+
+			if err := storageUpsertPlayerRankValueFunc(ctx, lb, targetPlayerID, rank.Value); err != nil {
+				return Merge{}, err
+			}
+
+			if err := storageDeletePlayerRankFunc(ctx, lb, sourcePlayerID); err != nil {
+				return Merge{}, err
+			}
+
+			merge.Records++
+		}
+
+		if _, err := storageErasePlayerRanksFunc(ctx, gameID, sourcePlayerID); err != nil {
+			return Merge{}, err
+		}
+
+		return merge, nil
+	}
+}
+
+// Value to send to the target player's progression so it ends up combined with the source one's
+func statisticMergeValue(st statistic.Statistic, progression statistic.PlayerProgression) float64 {
+	var initialValue float64
+	if st.InitialValue != nil {
+		initialValue = *st.InitialValue
+	}
+
+	switch st.AggregationMode {
+	case statistic.AggregationModeSum:
+		return *progression.CurrentValue - initialValue
+	case statistic.AggregationModeSub:
+		return initialValue - *progression.CurrentValue
+	default:
+		return *progression.CurrentValue
+	}
+}
+
+// The source player's progression on each statistic is sent to the target one as a submission, so it's combined by
+// the statistic's aggregation mode: what the source player added or subtracted from the initial value is added or
+// subtracted from the target's value on `SUM` and `SUB`, and the best value is kept on `MAX` and `MIN`. The landmarks
+// and goal the combined value reaches are completed and notified as on any other submission. Each source progression
+// is removed right after it's combined, so a failed merge can be retried without adding it twice. The ones on the soft
+// deleted statistics are removed with the source player's remaining ones
+func BuildMergeStatisticsFunc(
+	storageListStatisticsFunc StorageListStatisticsFunc,
+	storageGetPlayerProgressionFunc StorageGetPlayerProgressionFunc,
+	upsertPlayerProgressionFunc statistic.UpsertPlayerProgressionFunc,
+	storageDeletePlayerProgressionFunc StorageDeletePlayerProgressionFunc,
+	storageErasePlayerStatisticsFunc privacy.StorageErasePlayerFunc,
+) MergeDataFunc {
+	return func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (Merge, error) {
+		statistics, err := storageListStatisticsFunc(ctx, gameID)
+		if err != nil {
+			return Merge{}, err
+		}
+
+		merge := Merge{Data: DataStatisticProgression}
+		for _, st := range statistics {
+			progression, err := storageGetPlayerProgressionFunc(ctx, st.ID, sourcePlayerID)
+			if errors.Is(err, statistic.ErrPlayerStatisticNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return Merge{}, err
+			}
+
+			if progression.CurrentValue != nil {
+				if err := upsertPlayerProgressionFunc(ctx, st, targetPlayerID, statisticMergeValue(st, progression)); err != nil {
+					return Merge{}, err
+				}
+			}
+
+			if err := storageDeletePlayerProgressionFunc(ctx, st.ID, sourcePlayerID); err != nil {
+				return Merge{}, err
+			}
+
+			merge.Records++
+		}
+
+		if _, err := storageErasePlayerStatisticsFunc(ctx, gameID, sourcePlayerID); err != nil {
+			return Merge{}, err
+		}
+
+		return merge, nil
+	}
+}
+
+// Tasks of the progression not completed yet
+func pendingTasks(progression quest.PlayerQuestProgression, taskIDs []string) []string {
+	pending := slices.Clone(taskIDs)
+	for _, taskProgression := range progression.TasksProgression {
+		if !taskProgression.CompletedAt.IsZero() {
+			pending = slices.DeleteFunc(pending, func(id string) bool { return id == taskProgression.Task.ID })
+		}
+	}
+
+	return pending
+}
+
+// The target player starts each quest the source player started, and completes every task the source player completed.
+// Only the tasks started can be completed, so they're sent again until the ones depending on them are started too.
+// The target player ends up with the tasks completed by either player, the furthest state of both, completing the
+// quest when its required tasks are. The source progressions, including the ones on the soft deleted quests, are only
+// removed once every one was combined
+func BuildMergeQuestsFunc(
+	storageListQuestsFunc StorageListQuestsFunc,
+	storageGetPlayerQuestProgressionFunc StorageGetPlayerQuestProgressionFunc,
+	storageStartQuestForPlayerFunc quest.StorageStartQuestForPlayerFunc,
+	storageUpdatePlayerQuestProgressionFunc quest.StorageUpdatePlayerQuestProgressionFunc,
+	storageErasePlayerQuestsFunc privacy.StorageErasePlayerFunc,
+) MergeDataFunc {
+	return func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (Merge, error) {
+		quests, err := storageListQuestsFunc(ctx, gameID)
+		if err != nil {
+			return Merge{}, err
+		}
+
+		merge := Merge{Data: DataQuestProgression}
+		for _, q := range quests {
+			source, err := storageGetPlayerQuestProgressionFunc(ctx, q, sourcePlayerID)
+			if errors.Is(err, quest.ErrPlayerNotStartedTheQuest) {
+				continue
+			}
+
+			if err != nil {
+				return Merge{}, err
+			}
+
+			target, err := storageStartQuestForPlayerFunc(ctx, q, targetPlayerID)
+			if errors.Is(err, quest.ErrPlayerAlreadyStartedTheQuest) {
+				target, err = storageGetPlayerQuestProgressionFunc(ctx, q, targetPlayerID)
+			}
+
+			if err != nil {
+				return Merge{}, err
+			}
+
+			completed := make([]string, 0, len(source.TasksProgression))
+			for _, taskProgression := range source.TasksProgression {
+				if !taskProgression.CompletedAt.IsZero() {
+					completed = append(completed, taskProgression.Task.ID)
+				}
+			}
+
+			pending := pendingTasks(target, completed)
+			for len(pending) > 0 {
+				target, err = storageUpdatePlayerQuestProgressionFunc(ctx, q, pending, targetPlayerID)
+				if err != nil {
+					return Merge{}, err
+				}
+
+				remaining := pendingTasks(target, pending)
+				if len(remaining) == len(pending) {
+					break
+				}
+
+				pending = remaining
+			}
+
+			merge.Records++
+		}
+
+		if _, err := storageErasePlayerQuestsFunc(ctx, gameID, sourcePlayerID); err != nil {
+			return Merge{}, err
+		}
+
+		return merge, nil
+	}
+}
+
+// Each kind of data is merged in order, stopping on the first failure. The merge is only recorded on the audit log
+// once all of them succeed
+func BuildMergePlayersFunc(recordAuditEntryFunc audit.RecordFunc, mergeDataFuncs ...MergeDataFunc) MergePlayersFunc {
+	return func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (Receipt, error) {
+		if err := validate(gameID, sourcePlayerID, targetPlayerID); err != nil {
+			return Receipt{}, err
+		}
+
+		merges := make([]Merge, 0, len(mergeDataFuncs))
+		for _, mergeDataFunc := range mergeDataFuncs {
+			merge, err := mergeDataFunc(ctx, gameID, sourcePlayerID, targetPlayerID)
+			if err != nil {
+				return Receipt{}, err
+			}
+
+			merges = append(merges, merge)
+		}
+
+		receipt := Receipt{
+			GameID:         gameID,
+			SourcePlayerID: sourcePlayerID,
+			TargetPlayerID: targetPlayerID,
+			MergedAt:       time.Now().UTC(),
+			Merges:         merges,
+		}
+
+		entry, err := recordAuditEntryFunc(ctx, audit.NewEntryData{
+			GameID:      gameID,
+			Actor:       AuditActor,
+			Method:      AuditMethod,
+			Route:       AuditRoute,
+			ResourceIDs: map[string]string{"playerId": sourcePlayerID, "targetPlayerId": targetPlayerID},
+			RequestedAt: receipt.MergedAt,
+		})
+		if err != nil {
+			return Receipt{}, err
+		}
+		receipt.ID = entry.ID
+
+		return receipt, nil
+	}
+}
@@ -0,0 +1,360 @@
+package merge
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMergeRanksFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		leaderboards = []leaderboard.Leaderboard{
+			{ID: "kills", AggregationMode: leaderboard.AggregationModeInc},
+			{ID: "best-lap", AggregationMode: leaderboard.AggregationModeMin},
+			{ID: "weekly", AggregationMode: leaderboard.AggregationModeMax},
+		}
+		ranks = map[string]map[string]float64{
+			"kills":    {"old": 10, "new": 5},
+			"best-lap": {"old": 61.5},
+			"weekly":   {"new": 3},
+		}
+		erased string
+	)
+
+	merge, err := BuildMergeRanksFunc(
+		func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+			return leaderboards, nil
+		},
+		func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			value, ok := ranks[lb.ID][playerID]
+			if !ok {
+				return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+			}
+
+			return leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Value: value}, nil
+		},
+		func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			current, ok := ranks[lb.ID][playerID]
+			switch {
+			case !ok:
+				ranks[lb.ID][playerID] = value
+			case lb.AggregationMode == leaderboard.AggregationModeInc:
+				ranks[lb.ID][playerID] = current + value
+			case lb.AggregationMode == leaderboard.AggregationModeMin:
+				ranks[lb.ID][playerID] = min(current, value)
+			default:
+				ranks[lb.ID][playerID] = max(current, value)
+			}
+
+			return nil
+		},
+		func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+			delete(ranks[lb.ID], playerID)
+			return nil
+		},
+		func(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+			erased = playerID
+			return privacy.Erasure{Data: privacy.DataRanks}, nil
+		},
+	)(ctx, "game", "old", "new")
+	assert.NoError(t, err)
+	assert.Equal(t, Merge{Data: DataRanks, Records: 2}, merge)
+	assert.Equal(t, map[string]map[string]float64{
+		"kills":    {"new": 15},
+		"best-lap": {"new": 61.5},
+		"weekly":   {"new": 3},
+	}, ranks)
+	assert.Equal(t, "old", erased)
+}
+
+func TestBuildMergeStatisticsFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		initialValue = float64(100)
+		statistics   = []statistic.Statistic{
+			{ID: "xp", AggregationMode: statistic.AggregationModeSum},
+			{ID: "health", AggregationMode: statistic.AggregationModeSub, InitialValue: &initialValue},
+			{ID: "level", AggregationMode: statistic.AggregationModeMax},
+			{ID: "deaths", AggregationMode: statistic.AggregationModeSum},
+		}
+		values  = map[string]float64{"xp": 30, "health": 80, "level": 7}
+		sent    = make(map[string]float64)
+		deleted []string
+	)
+
+	merge, err := BuildMergeStatisticsFunc(
+		func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+			return statistics, nil
+		},
+		func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+			value, ok := values[statisticID]
+			if !ok {
+				return statistic.PlayerProgression{}, statistic.ErrPlayerStatisticNotFound
+			}
+
+			return statistic.PlayerProgression{StatisticID: statisticID, PlayerID: playerID, CurrentValue: &value}, nil
+		},
+		func(ctx context.Context, st statistic.Statistic, playerID string, value float64) error {
+			assert.Equal(t, "new", playerID)
+			sent[st.ID] = value
+			return nil
+		},
+		func(ctx context.Context, statisticID, playerID string) error {
+			assert.Equal(t, "old", playerID)
+			deleted = append(deleted, statisticID)
+			return nil
+		},
+		func(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+			return privacy.Erasure{Data: privacy.DataStatisticProgression}, nil
+		},
+	)(ctx, "game", "old", "new")
+	assert.NoError(t, err)
+	assert.Equal(t, Merge{Data: DataStatisticProgression, Records: 3}, merge)
+	assert.Equal(t, map[string]float64{"xp": 30, "health": 20, "level": 7}, sent)
+	assert.Equal(t, []string{"xp", "health", "level"}, deleted)
+
+	t.Run("Upsert Error", func(t *testing.T) {
+		errUpsert := errors.New("upsert")
+
+		_, err := BuildMergeStatisticsFunc(
+			func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+				return statistics, nil
+			},
+			func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+				value := values["xp"]
+				return statistic.PlayerProgression{CurrentValue: &value}, nil
+			},
+			func(ctx context.Context, st statistic.Statistic, playerID string, value float64) error {
+				return errUpsert
+			},
+			func(ctx context.Context, statisticID, playerID string) error {
+				t.Fatal("the source progression must be kept when it isn't combined")
+				return nil
+			},
+			func(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+				t.Fatal("the source progressions must be kept when the merge fails")
+				return privacy.Erasure{}, nil
+			},
+		)(ctx, "game", "old", "new")
+		assert.ErrorIs(t, err, errUpsert)
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		var (
+			errUpsert   = errors.New("upsert")
+			progression = map[string]map[string]float64{
+				"xp":     {"old": 30, "new": 10},
+				"health": {"old": 80},
+				"level":  {"old": 7},
+			}
+			failed bool
+		)
+
+		merge := BuildMergeStatisticsFunc(
+			func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+				return statistics, nil
+			},
+			func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+				value, ok := progression[statisticID][playerID]
+				if !ok {
+					return statistic.PlayerProgression{}, statistic.ErrPlayerStatisticNotFound
+				}
+
+				return statistic.PlayerProgression{StatisticID: statisticID, PlayerID: playerID, CurrentValue: &value}, nil
+			},
+			func(ctx context.Context, st statistic.Statistic, playerID string, value float64) error {
+				if st.ID == "level" && !failed {
+					failed = true
+					return errUpsert
+				}
+
+				if st.AggregationMode == statistic.AggregationModeMax {
+					progression[st.ID][playerID] = max(progression[st.ID][playerID], value)
+					return nil
+				}
+
+				if _, ok := progression[st.ID][playerID]; !ok && st.InitialValue != nil {
+					progression[st.ID][playerID] = *st.InitialValue
+				}
+
+				if st.AggregationMode == statistic.AggregationModeSub {
+					value = -value
+				}
+
+				progression[st.ID][playerID] += value
+				return nil
+			},
+			func(ctx context.Context, statisticID, playerID string) error {
+				delete(progression[statisticID], playerID)
+				return nil
+			},
+			func(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+				return privacy.Erasure{Data: privacy.DataStatisticProgression}, nil
+			},
+		)
+
+		_, err := merge(ctx, "game", "old", "new")
+		assert.ErrorIs(t, err, errUpsert)
+
+		result, err := merge(ctx, "game", "old", "new")
+		assert.NoError(t, err)
+		assert.Equal(t, Merge{Data: DataStatisticProgression, Records: 1}, result)
+		assert.Equal(t, map[string]map[string]float64{
+			"xp":     {"new": 40},
+			"health": {"new": 80},
+			"level":  {"new": 7},
+		}, progression)
+	})
+}
+
+func TestBuildMergeQuestsFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+		q   = quest.Quest{
+			ID: "tutorial",
+			Tasks: []quest.Task{
+				{ID: "move"},
+				{ID: "jump", DependsOn: []string{"move"}},
+				{ID: "shoot"},
+			},
+		}
+		now          = time.Now()
+		progressions = map[string]quest.PlayerQuestProgression{
+			"old": {PlayerID: "old", Quest: q, TasksProgression: []quest.PlayerTaskProgression{
+				{Task: q.Tasks[0], CompletedAt: now},
+				{Task: q.Tasks[1], CompletedAt: now},
+				{Task: q.Tasks[2]},
+			}},
+		}
+		updates int
+	)
+
+	// Only the tasks started are completed, and the ones depending on them are started once they are
+	startReady := func(p *quest.PlayerQuestProgression) {
+		for _, task := range q.Tasks {
+			started := slices.ContainsFunc(p.TasksProgression, func(tp quest.PlayerTaskProgression) bool { return tp.Task.ID == task.ID })
+			ready := !slices.ContainsFunc(task.DependsOn, func(id string) bool {
+				return !slices.ContainsFunc(p.TasksProgression, func(tp quest.PlayerTaskProgression) bool {
+					return tp.Task.ID == id && !tp.CompletedAt.IsZero()
+				})
+			})
+
+			if !started && ready {
+				p.TasksProgression = append(p.TasksProgression, quest.PlayerTaskProgression{Task: task})
+			}
+		}
+	}
+
+	merge, err := BuildMergeQuestsFunc(
+		func(ctx context.Context, gameID string) ([]quest.Quest, error) {
+			return []quest.Quest{q, {ID: "untouched"}}, nil
+		},
+		func(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error) {
+			p, ok := progressions[playerID]
+			if !ok || q.ID != "tutorial" {
+				return quest.PlayerQuestProgression{}, quest.ErrPlayerNotStartedTheQuest
+			}
+
+			return p, nil
+		},
+		func(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error) {
+			p := quest.PlayerQuestProgression{PlayerID: playerID, Quest: q}
+			startReady(&p)
+			progressions[playerID] = p
+			return p, nil
+		},
+		func(ctx context.Context, q quest.Quest, tasksCompleted []string, playerID string) (quest.PlayerQuestProgression, error) {
+			updates++
+
+			p := progressions[playerID]
+			p.TasksProgression = slices.Clone(p.TasksProgression)
+			for i, tp := range p.TasksProgression {
+				if slices.Contains(tasksCompleted, tp.Task.ID) {
+					p.TasksProgression[i].CompletedAt = now
+				}
+			}
+			startReady(&p)
+
+			progressions[playerID] = p
+			return p, nil
+		},
+		func(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+			return privacy.Erasure{Data: privacy.DataQuestProgression, Records: 1}, nil
+		},
+	)(ctx, "game", "old", "new")
+	assert.NoError(t, err)
+	assert.Equal(t, Merge{Data: DataQuestProgression, Records: 1}, merge)
+	assert.Equal(t, 2, updates)
+
+	completed := make([]string, 0)
+	for _, tp := range progressions["new"].TasksProgression {
+		if !tp.CompletedAt.IsZero() {
+			completed = append(completed, tp.Task.ID)
+		}
+	}
+	assert.ElementsMatch(t, []string{"move", "jump"}, completed)
+}
+
+func TestBuildMergePlayersFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var recorded audit.NewEntryData
+
+		receipt, err := BuildMergePlayersFunc(
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				recorded = data
+				return audit.Entry{ID: "entry"}, nil
+			},
+			func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (Merge, error) {
+				return Merge{Data: DataRanks, Records: 2}, nil
+			},
+			func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (Merge, error) {
+				return Merge{Data: DataStatisticProgression, Records: 1}, nil
+			},
+		)(ctx, "game", "old", "new")
+		assert.NoError(t, err)
+		assert.Equal(t, "entry", receipt.ID)
+		assert.Equal(t, []Merge{{Data: DataRanks, Records: 2}, {Data: DataStatisticProgression, Records: 1}}, receipt.Merges)
+		assert.Equal(t, AuditMethod, recorded.Method)
+		assert.Equal(t, AuditRoute, recorded.Route)
+		assert.Equal(t, map[string]string{"playerId": "old", "targetPlayerId": "new"}, recorded.ResourceIDs)
+	})
+
+	t.Run("Merge Error", func(t *testing.T) {
+		errMerge := errors.New("merge")
+
+		_, err := BuildMergePlayersFunc(
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				t.Fatal("a failed merge must not be recorded")
+				return audit.Entry{}, nil
+			},
+			func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (Merge, error) {
+				return Merge{}, errMerge
+			},
+		)(ctx, "game", "old", "new")
+		assert.ErrorIs(t, err, errMerge)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildMergePlayersFunc(nil)(ctx, "", "same", "same")
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrSamePlayer)
+
+		_, err = BuildMergePlayersFunc(nil)(ctx, "game", "", "")
+		assert.ErrorIs(t, err, ErrInvalidSourcePlayerID)
+		assert.ErrorIs(t, err, ErrInvalidTargetPlayerID)
+	})
+}
@@ -0,0 +1,11 @@
+package merge
+
+import "context"
+
+type (
+	// Combines the source player's data of a kind into the target player's one, removing it from the source player
+	MergeDataFunc func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (Merge, error)
+
+	// Merges the source player into the target one, returning the receipt of the merge
+	MergePlayersFunc func(ctx context.Context, gameID, sourcePlayerID, targetPlayerID string) (Receipt, error)
+)
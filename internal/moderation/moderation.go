@@ -0,0 +1,699 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+const (
+	MaxReasonLength = 512    // Characters the reason of an action can have
+	MaxBulkRemovals = 10_000 // Entries a single bulk removal or rollback can change. Larger ones must be split in narrower windows
+	MaxDeviceUsages = 100    // Devices listed at a time, the most recently seen first
+
+	// Scores read from the history at a time while a bulk removal or rollback looks for the entries
+	scoresBatchSize = 1000
+)
+
+// Kinds of action
+const (
+	ActionAdjust     = "ADJUST"      // The entry's value was set
+	ActionRemove     = "REMOVE"      // The entry was removed
+	ActionBulkRemove = "BULK_REMOVE" // The entries a source submitted to within a window were removed
+	ActionRollback   = "ROLLBACK"    // The scores submitted to a leaderboard within a window were reverted
+)
+
+const (
+	// Actor registered on the audit log for every action, as they're taken with the admin token
+	AuditActor = "admin"
+	// Route registered on the audit log for the actions on an entry, so they're listed with the other requests on the leaderboards
+	AuditEntryRoute = "/api/v1/leaderboards/:leaderboardId/ranking/:playerId"
+	// Route registered on the audit log for the bulk removals, so they're listed with the other requests on the leaderboards
+	AuditBulkRoute = "/api/v1/leaderboards/ranking"
+	// Route registered on the audit log for the rollbacks, so they're listed with the other requests on the leaderboard
+	AuditRollbackRoute = "/api/v1/leaderboards/:leaderboardId/ranking"
+)
+
+var (
+	ErrValidationError      = errors.New("validation error")
+	ErrInvalidGameID        = errors.New("invalid game id")
+	ErrInvalidLeaderboardID = errors.New("invalid leaderboard id")
+	ErrInvalidPlayerID      = errors.New("invalid player id")
+	ErrInvalidReason        = errors.New("reason must have between 1 and 512 characters")
+	ErrInvalidValue         = errors.New("value must be a finite number")
+	ErrInvalidSource        = errors.New("source must be one of API, GRPC, MQTT or INGESTION")
+	ErrInvalidWindow        = errors.New("window must end after it starts")
+	ErrInvalidDeviceFilter  = errors.New("device hash or player id must be set")
+
+	ErrEntryNotFound  = errors.New("entry not found")
+	ErrTooManyEntries = errors.New("action over 10000 entries")
+	ErrNoScores       = errors.New("no scores submitted within the window")
+)
+
+type (
+	// Entry removed by a bulk removal
+	RemovedEntry struct {
+		LeaderboardID string  // Leaderboard the entry was on
+		PlayerID      string  // Player of the entry
+		Value         float64 // Entry's value when it was removed
+	}
+
+	// Entry recomputed by a rollback
+	RecomputedEntry struct {
+		PlayerID string   // Player of the entry
+		Previous *float64 // Entry's value before the rollback. Nil when the player wasn't ranked
+		Value    *float64 // Entry's value rebuilt from the scores kept. Nil when no score was kept, so the entry was removed
+	}
+
+	// Action taken by a moderator on the leaderboards entries
+	Action struct {
+		ID            string            // Action ID, the ID of the audit entry recording it
+		CreatedAt     time.Time         // Time the action was taken
+		GameID        string            // Game the leaderboards belong to
+		Kind          string            // One of `ADJUST`, `REMOVE`, `BULK_REMOVE` or `ROLLBACK`
+		Reason        string            // Why the action was taken
+		LeaderboardID string            // Leaderboard of the entry adjusted or removed, or of the scores reverted. Empty on the bulk removals
+		PlayerID      string            // Player of the entry adjusted or removed, or whose scores were reverted. Empty on the bulk removals and the rollbacks of every player
+		Previous      *float64          // Entry's value before the action. Nil when the player wasn't ranked, on the bulk removals and the rollbacks
+		Value         *float64          // Value the entry was set to. Only set on the adjustments
+		Source        string            // Channel whose entries were removed. Only set on the bulk removals
+		From          time.Time         // Start of the window whose entries were removed or scores reverted. Only set on the bulk removals and the rollbacks
+		To            time.Time         // End of the window whose entries were removed or scores reverted. Only set on the bulk removals and the rollbacks
+		Entries       []RemovedEntry    // Entries removed. Only set on the bulk removals
+		Scores        int64             // Scores reverted. Only set on the rollbacks
+		Recomputed    []RecomputedEntry // Entries recomputed. Only set on the rollbacks
+	}
+
+	// Player's entry on a leaderboard, as a moderator inspects it
+	Entry struct {
+		Rank    *leaderboard.Rank // Player's rank. Nil when the player isn't ranked
+		Actions []Action          // Actions taken on the entry, oldest first
+	}
+
+	AdjustData struct {
+		GameID        string  // Game the leaderboard belongs to
+		LeaderboardID string  // Leaderboard of the entry
+		PlayerID      string  // Player of the entry
+		Value         float64 // Value the entry is set to
+		Reason        string  // Why the entry is adjusted, up to 512 characters
+	}
+
+	RemoveData struct {
+		GameID        string // Game the leaderboard belongs to
+		LeaderboardID string // Leaderboard of the entry
+		PlayerID      string // Player of the entry
+		Reason        string // Why the entry is removed, up to 512 characters
+	}
+
+	// Which devices are listed. At least one of the fields must be set
+	DeviceUsageFilter struct {
+		DeviceHash string // Keep only the players that submitted from the device
+		PlayerID   string // Keep only the devices the player submitted from
+	}
+
+	// Device a player submitted scores from, as recorded on the score history
+	DeviceUsage struct {
+		DeviceHash   string    // Device the scores were submitted from
+		PlayerID     string    // Player the scores were submitted for
+		BuildVersion string    // Client build of the latest score
+		Region       string    // Region of the latest score
+		FirstSeenAt  time.Time // Time of the first score
+		LastSeenAt   time.Time // Time of the latest score
+		Scores       int64     // Scores submitted
+	}
+
+	RollbackData struct {
+		GameID        string    // Game the leaderboard belongs to
+		LeaderboardID string    // Leaderboard the scores were submitted to
+		PlayerID      string    // Player whose scores are reverted. Every player's when empty
+		From          time.Time // Scores submitted from this time on
+		To            time.Time // Scores submitted before this time
+		Reason        string    // Why the scores are reverted, up to 512 characters
+	}
+
+	BulkRemoveData struct {
+		GameID string    // Game the leaderboards belong to
+		Source string    // Channel the scores were submitted through, one of `leaderboard.Sources`
+		From   time.Time // Scores submitted from this time on
+		To     time.Time // Scores submitted before this time
+		Reason string    // Why the entries are removed, up to 512 characters
+	}
+)
+
+func validateReason(reason string) error {
+	if n := utf8.RuneCountInString(reason); n == 0 || n > MaxReasonLength {
+		return ErrInvalidReason
+	}
+
+	return nil
+}
+
+func validateEntry(gameID, leaderboardID, playerID, reason string) []error {
+	errList := make([]error, 0)
+
+	if gameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if leaderboardID == "" {
+		errList = append(errList, ErrInvalidLeaderboardID)
+	}
+
+	if playerID == "" {
+		errList = append(errList, ErrInvalidPlayerID)
+	}
+
+	if err := validateReason(reason); err != nil {
+		errList = append(errList, err)
+	}
+
+	return errList
+}
+
+func (d AdjustData) validate() error {
+	errList := validateEntry(d.GameID, d.LeaderboardID, d.PlayerID, d.Reason)
+
+	if math.IsNaN(d.Value) || math.IsInf(d.Value, 0) {
+		errList = append(errList, ErrInvalidValue)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+func (d RemoveData) validate() error {
+	errList := validateEntry(d.GameID, d.LeaderboardID, d.PlayerID, d.Reason)
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+func (d BulkRemoveData) validate() error {
+	errList := make([]error, 0)
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if !slices.Contains(leaderboard.Sources, d.Source) {
+		errList = append(errList, ErrInvalidSource)
+	}
+
+	if d.From.IsZero() || !d.To.After(d.From) {
+		errList = append(errList, ErrInvalidWindow)
+	}
+
+	if err := validateReason(d.Reason); err != nil {
+		errList = append(errList, err)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+func (d RollbackData) validate() error {
+	errList := make([]error, 0)
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if d.LeaderboardID == "" {
+		errList = append(errList, ErrInvalidLeaderboardID)
+	}
+
+	if d.From.IsZero() || !d.To.After(d.From) {
+		errList = append(errList, ErrInvalidWindow)
+	}
+
+	if err := validateReason(d.Reason); err != nil {
+		errList = append(errList, err)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Player's value on the leaderboard, nil when the player isn't ranked
+func currentValue(ctx context.Context, storageGetPlayerRankFunc StorageGetPlayerRankFunc, lb leaderboard.Leaderboard, playerID string) (*float64, error) {
+	rank, err := storageGetPlayerRankFunc(ctx, lb, playerID)
+	if errors.Is(err, leaderboard.ErrPlayerRankNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rank.Value, nil
+}
+
+// Goes through the leaderboard's whole score history, in the order it was submitted
+func eachScore(ctx context.Context, storageListScoresFunc StorageListScoresFunc, leaderboardID string, fn func(score leaderboard.Score) error) error {
+	var after leaderboard.Score
+	for {
+		scores, err := storageListScoresFunc(ctx, leaderboardID, after, scoresBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, score := range scores {
+			if err := fn(score); err != nil {
+				return err
+			}
+		}
+
+		if len(scores) < scoresBatchSize {
+			return nil
+		}
+
+		after = scores[len(scores)-1]
+	}
+}
+
+// Records the action on the game's audit log, taking the ID of its entry, and stores it
+func record(ctx context.Context, recordAuditEntryFunc audit.RecordFunc, storageCreateActionFunc StorageCreateActionFunc, action Action) (Action, error) {
+	data := audit.NewEntryData{
+		GameID:      action.GameID,
+		Actor:       AuditActor,
+		Method:      action.Kind,
+		Route:       AuditEntryRoute,
+		ResourceIDs: map[string]string{"leaderboardId": action.LeaderboardID, "playerId": action.PlayerID},
+		RequestedAt: action.CreatedAt,
+	}
+	switch action.Kind {
+	case ActionBulkRemove:
+		data.Route = AuditBulkRoute
+		data.ResourceIDs = map[string]string{"source": action.Source}
+	case ActionRollback:
+		data.Route = AuditRollbackRoute
+		data.ResourceIDs = map[string]string{"leaderboardId": action.LeaderboardID}
+		if action.PlayerID != "" {
+			data.ResourceIDs["playerId"] = action.PlayerID
+		}
+	}
+
+	entry, err := recordAuditEntryFunc(ctx, data)
+	if err != nil {
+		return Action{}, err
+	}
+
+	action.ID = entry.ID
+	if err := storageCreateActionFunc(ctx, action); err != nil {
+		return Action{}, err
+	}
+
+	return action, nil
+}
+
+func BuildInspectFunc(
+	storageGetLeaderboardFunc StorageGetLeaderboardFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageListEntryActionsFunc StorageListEntryActionsFunc,
+) InspectFunc {
+	return func(ctx context.Context, gameID, leaderboardID, playerID string) (Entry, error) {
+		if playerID == "" {
+			return Entry{}, ErrInvalidPlayerID
+		}
+
+		lb, err := storageGetLeaderboardFunc(ctx, leaderboardID, gameID)
+		if err != nil {
+			return Entry{}, err
+		}
+
+		var entry Entry
+		rank, err := storageGetPlayerRankFunc(ctx, lb, playerID)
+		switch {
+		case errors.Is(err, leaderboard.ErrPlayerRankNotFound):
+		case err != nil:
+			return Entry{}, err
+		default:
+			entry.Rank = &rank
+		}
+
+		if entry.Actions, err = storageListEntryActionsFunc(ctx, gameID, leaderboardID, playerID); err != nil {
+			return Entry{}, err
+		}
+
+		return entry, nil
+	}
+}
+
+// The entry is removed and ranked again with the value, so it's set regardless of the leaderboard's aggregation mode.
+// A submission applied in between is overwritten
+func BuildAdjustFunc(
+	recordAuditEntryFunc audit.RecordFunc,
+	storageGetLeaderboardFunc StorageGetLeaderboardFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageDeletePlayerRankFunc StorageDeletePlayerRankFunc,
+	storageUpsertPlayerRankValueFunc StorageUpsertPlayerRankValueFunc,
+	storageCreateActionFunc StorageCreateActionFunc,
+) AdjustFunc {
+	return func(ctx context.Context, data AdjustData) (Action, error) {
+		if err := data.validate(); err != nil {
+			return Action{}, err
+		}
+
+		lb, err := storageGetLeaderboardFunc(ctx, data.LeaderboardID, data.GameID)
+		if err != nil {
+			return Action{}, err
+		}
+
+		previous, err := currentValue(ctx, storageGetPlayerRankFunc, lb, data.PlayerID)
+		if err != nil {
+			return Action{}, err
+		}
+
+		if previous != nil {
+			if err := storageDeletePlayerRankFunc(ctx, lb, data.PlayerID); err != nil {
+				return Action{}, err
+			}
+		}
+
+		if err := storageUpsertPlayerRankValueFunc(ctx, lb, data.PlayerID, data.Value); err != nil {
+			return Action{}, err
+		}
+
+		return record(ctx, recordAuditEntryFunc, storageCreateActionFunc, Action{
+			CreatedAt:     time.Now().UTC(),
+			GameID:        data.GameID,
+			Kind:          ActionAdjust,
+			Reason:        data.Reason,
+			LeaderboardID: data.LeaderboardID,
+			PlayerID:      data.PlayerID,
+			Previous:      previous,
+			Value:         &data.Value,
+		})
+	}
+}
+
+func BuildRemoveFunc(
+	recordAuditEntryFunc audit.RecordFunc,
+	storageGetLeaderboardFunc StorageGetLeaderboardFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageDeletePlayerRankFunc StorageDeletePlayerRankFunc,
+	storageCreateActionFunc StorageCreateActionFunc,
+) RemoveFunc {
+	return func(ctx context.Context, data RemoveData) (Action, error) {
+		if err := data.validate(); err != nil {
+			return Action{}, err
+		}
+
+		lb, err := storageGetLeaderboardFunc(ctx, data.LeaderboardID, data.GameID)
+		if err != nil {
+			return Action{}, err
+		}
+
+		previous, err := currentValue(ctx, storageGetPlayerRankFunc, lb, data.PlayerID)
+		if err != nil {
+			return Action{}, err
+		}
+
+		if previous == nil {
+			return Action{}, ErrEntryNotFound
+		}
+
+		if err := storageDeletePlayerRankFunc(ctx, lb, data.PlayerID); err != nil {
+			return Action{}, err
+		}
+
+		return record(ctx, recordAuditEntryFunc, storageCreateActionFunc, Action{
+			CreatedAt:     time.Now().UTC(),
+			GameID:        data.GameID,
+			Kind:          ActionRemove,
+			Reason:        data.Reason,
+			LeaderboardID: data.LeaderboardID,
+			PlayerID:      data.PlayerID,
+			Previous:      previous,
+		})
+	}
+}
+
+// The entries are found on the score history, failing before removing any of them when there are more than `MaxBulkRemovals`.
+// The entries of the leaderboards deleted since, and of the players not ranked anymore, are skipped. When a removal fails,
+// the ones removed so far are still recorded, so sending it again removes the rest
+func BuildBulkRemoveFunc(
+	recordAuditEntryFunc audit.RecordFunc,
+	storageListScoresBySourceFunc StorageListScoresBySourceFunc,
+	storageGetLeaderboardFunc StorageGetLeaderboardFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageDeletePlayerRankFunc StorageDeletePlayerRankFunc,
+	storageCreateActionFunc StorageCreateActionFunc,
+) BulkRemoveFunc {
+	type entryKey struct{ leaderboardID, playerID string }
+
+	return func(ctx context.Context, data BulkRemoveData) (Action, error) {
+		if err := data.validate(); err != nil {
+			return Action{}, err
+		}
+
+		var (
+			keys  = make([]entryKey, 0)
+			found = make(map[entryKey]bool)
+			after leaderboard.Score
+		)
+		for {
+			scores, err := storageListScoresBySourceFunc(ctx, data.GameID, data.Source, data.From, data.To, after, scoresBatchSize)
+			if err != nil {
+				return Action{}, err
+			}
+
+			for _, score := range scores {
+				key := entryKey{leaderboardID: score.LeaderboardID, playerID: score.PlayerID}
+				if found[key] {
+					continue
+				}
+
+				if len(keys) == MaxBulkRemovals {
+					return Action{}, ErrTooManyEntries
+				}
+
+				found[key] = true
+				keys = append(keys, key)
+			}
+
+			if len(scores) < scoresBatchSize {
+				break
+			}
+
+			after = scores[len(scores)-1]
+		}
+
+		var (
+			removed      = make([]RemovedEntry, 0)
+			leaderboards = make(map[string]*leaderboard.Leaderboard)
+			removeErr    error
+		)
+		for _, key := range keys {
+			lb, ok := leaderboards[key.leaderboardID]
+			if !ok {
+				got, err := storageGetLeaderboardFunc(ctx, key.leaderboardID, data.GameID)
+				switch {
+				case errors.Is(err, leaderboard.ErrLeaderboardNotFound):
+				case err != nil:
+					removeErr = err
+				default:
+					lb = &got
+				}
+
+				if removeErr != nil {
+					break
+				}
+
+				leaderboards[key.leaderboardID] = lb
+			}
+
+			if lb == nil {
+				continue
+			}
+
+			value, err := currentValue(ctx, storageGetPlayerRankFunc, *lb, key.playerID)
+			if err != nil {
+				removeErr = err
+				break
+			}
+
+			if value == nil {
+				continue
+			}
+
+			if err := storageDeletePlayerRankFunc(ctx, *lb, key.playerID); err != nil {
+				removeErr = err
+				break
+			}
+
+			removed = append(removed, RemovedEntry{LeaderboardID: key.leaderboardID, PlayerID: key.playerID, Value: *value})
+		}
+
+		if removeErr != nil && len(removed) == 0 {
+			return Action{}, removeErr
+		}
+
+		action, err := record(ctx, recordAuditEntryFunc, storageCreateActionFunc, Action{
+			CreatedAt: time.Now().UTC(),
+			GameID:    data.GameID,
+			Kind:      ActionBulkRemove,
+			Reason:    data.Reason,
+			Source:    data.Source,
+			From:      data.From.UTC(),
+			To:        data.To.UTC(),
+			Entries:   removed,
+		})
+
+		return action, errors.Join(removeErr, err)
+	}
+}
+
+// The history of the players that submitted scores within the window is replayed with the leaderboard's aggregation mode,
+// leaving those scores out, and their entries are set to the values rebuilt. A player left without scores is removed from
+// the ranking. The scores are only deleted from the history once every entry is set, so a rollback that failed halfway can
+// be sent again. Scores submitted while it runs may be overwritten
+func BuildRollbackFunc(
+	recordAuditEntryFunc audit.RecordFunc,
+	storageGetLeaderboardFunc StorageGetLeaderboardFunc,
+	storageListScoresFunc StorageListScoresFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageDeletePlayerRankFunc StorageDeletePlayerRankFunc,
+	storageUpsertPlayerRankValueFunc StorageUpsertPlayerRankValueFunc,
+	storageDeleteScoresFunc StorageDeleteScoresFunc,
+	storageCreateActionFunc StorageCreateActionFunc,
+) RollbackFunc {
+	return func(ctx context.Context, data RollbackData) (Action, error) {
+		if err := data.validate(); err != nil {
+			return Action{}, err
+		}
+
+		lb, err := storageGetLeaderboardFunc(ctx, data.LeaderboardID, data.GameID)
+		if err != nil {
+			return Action{}, err
+		}
+
+		// The players with scores within the window are found first, so only their scores are replayed
+		var (
+			players  = make([]string, 0)
+			affected = make(map[string]bool)
+			reverted int64
+		)
+		err = eachScore(ctx, storageListScoresFunc, lb.ID, func(score leaderboard.Score) error {
+			if data.PlayerID != "" && score.PlayerID != data.PlayerID {
+				return nil
+			}
+
+			if score.SubmittedAt.Before(data.From) || !score.SubmittedAt.Before(data.To) {
+				return nil
+			}
+
+			reverted++
+			if affected[score.PlayerID] {
+				return nil
+			}
+
+			if len(players) == MaxBulkRemovals {
+				return ErrTooManyEntries
+			}
+
+			affected[score.PlayerID] = true
+			players = append(players, score.PlayerID)
+			return nil
+		})
+		if err != nil {
+			return Action{}, err
+		}
+
+		if reverted == 0 {
+			return Action{}, ErrNoScores
+		}
+
+		kept := make(map[string]leaderboard.RebuiltRank, len(players))
+		err = eachScore(ctx, storageListScoresFunc, lb.ID, func(score leaderboard.Score) error {
+			if !affected[score.PlayerID] || (!score.SubmittedAt.Before(data.From) && score.SubmittedAt.Before(data.To)) {
+				return nil
+			}
+
+			return leaderboard.ApplyScore(kept, lb.AggregationMode, score)
+		})
+		if err != nil {
+			return Action{}, err
+		}
+
+		recomputed := make([]RecomputedEntry, 0, len(players))
+		for _, playerID := range players {
+			previous, err := currentValue(ctx, storageGetPlayerRankFunc, lb, playerID)
+			if err != nil {
+				return Action{}, err
+			}
+
+			if previous != nil {
+				if err := storageDeletePlayerRankFunc(ctx, lb, playerID); err != nil {
+					return Action{}, err
+				}
+			}
+
+			entry := RecomputedEntry{PlayerID: playerID, Previous: previous}
+			if rank, ok := kept[playerID]; ok {
+				if err := storageUpsertPlayerRankValueFunc(ctx, lb, playerID, rank.Value); err != nil {
+					return Action{}, err
+				}
+
+				entry.Value = &rank.Value
+			}
+
+			recomputed = append(recomputed, entry)
+		}
+
+		if err := storageDeleteScoresFunc(ctx, lb.ID, data.PlayerID, data.From, data.To); err != nil {
+			return Action{}, err
+		}
+
+		return record(ctx, recordAuditEntryFunc, storageCreateActionFunc, Action{
+			CreatedAt:     time.Now().UTC(),
+			GameID:        data.GameID,
+			Kind:          ActionRollback,
+			Reason:        data.Reason,
+			LeaderboardID: data.LeaderboardID,
+			PlayerID:      data.PlayerID,
+			From:          data.From.UTC(),
+			To:            data.To.UTC(),
+			Scores:        reverted,
+			Recomputed:    recomputed,
+		})
+	}
+}
+
+func BuildListFunc(storageListActionsFunc StorageListActionsFunc) ListFunc {
+	return func(ctx context.Context, gameID string) ([]Action, error) {
+		if gameID == "" {
+			return nil, ErrInvalidGameID
+		}
+
+		return storageListActionsFunc(ctx, gameID)
+	}
+}
+
+func BuildListDeviceUsageFunc(storageListDeviceUsageFunc StorageListDeviceUsageFunc) ListDeviceUsageFunc {
+	return func(ctx context.Context, gameID string, filter DeviceUsageFilter) ([]DeviceUsage, error) {
+		if filter.DeviceHash == "" && filter.PlayerID == "" {
+			return nil, ErrInvalidDeviceFilter
+		}
+
+		return storageListDeviceUsageFunc(ctx, gameID, filter, MaxDeviceUsages)
+	}
+}
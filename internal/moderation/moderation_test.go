@@ -0,0 +1,409 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func value(v float64) *float64 {
+	return &v
+}
+
+// Ranks kept as the storage keeps them, by leaderboard and player
+type ranking map[string]map[string]float64
+
+func (r ranking) getLeaderboard(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+	if _, ok := r[id]; !ok {
+		return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+	}
+
+	return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+}
+
+func (r ranking) getRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+	v, ok := r[lb.ID][playerID]
+	if !ok {
+		return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+	}
+
+	return leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Value: v}, nil
+}
+
+func (r ranking) deleteRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+	delete(r[lb.ID], playerID)
+	return nil
+}
+
+func (r ranking) upsertRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+	r[lb.ID][playerID] += value
+	return nil
+}
+
+func recordAuditEntry(entries *[]audit.NewEntryData) audit.RecordFunc {
+	return func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+		*entries = append(*entries, data)
+		return audit.Entry{ID: uuid.NewString()}, nil
+	}
+}
+
+func TestBuildInspectFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		ranks   = ranking{"season": {"alice": 10}}
+		actions = []Action{{ID: "action", Kind: ActionAdjust}}
+	)
+
+	inspect := BuildInspectFunc(ranks.getLeaderboard, ranks.getRank, func(ctx context.Context, gameID, leaderboardID, playerID string) ([]Action, error) {
+		return actions, nil
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		entry, err := inspect(ctx, "game", "season", "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, &leaderboard.Rank{LeaderboardID: "season", PlayerID: "alice", Value: 10}, entry.Rank)
+		assert.Equal(t, actions, entry.Actions)
+	})
+
+	t.Run("Not Ranked", func(t *testing.T) {
+		entry, err := inspect(ctx, "game", "season", "bob")
+		assert.NoError(t, err)
+		assert.Nil(t, entry.Rank)
+		assert.Equal(t, actions, entry.Actions)
+	})
+
+	t.Run("Leaderboard Not Found", func(t *testing.T) {
+		_, err := inspect(ctx, "game", "weekly", "alice")
+		assert.ErrorIs(t, err, leaderboard.ErrLeaderboardNotFound)
+	})
+
+	t.Run("Invalid Player", func(t *testing.T) {
+		_, err := inspect(ctx, "game", "season", "")
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+	})
+}
+
+func TestBuildAdjustFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		ranks   = ranking{"season": {"alice": 10}}
+		entries []audit.NewEntryData
+		created []Action
+	)
+
+	adjust := BuildAdjustFunc(recordAuditEntry(&entries), ranks.getLeaderboard, ranks.getRank, ranks.deleteRank, ranks.upsertRank, func(ctx context.Context, action Action) error {
+		created = append(created, action)
+		return nil
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		action, err := adjust(ctx, AdjustData{GameID: "game", LeaderboardID: "season", PlayerID: "alice", Value: 4, Reason: "exploit"})
+		assert.NoError(t, err)
+		assert.Equal(t, 4.0, ranks["season"]["alice"])
+		assert.Equal(t, ActionAdjust, action.Kind)
+		assert.Equal(t, value(10), action.Previous)
+		assert.Equal(t, value(4), action.Value)
+		assert.NotEmpty(t, action.ID)
+		assert.Equal(t, []Action{action}, created)
+		assert.Equal(t, AuditEntryRoute, entries[0].Route)
+		assert.Equal(t, ActionAdjust, entries[0].Method)
+		assert.Equal(t, map[string]string{"leaderboardId": "season", "playerId": "alice"}, entries[0].ResourceIDs)
+	})
+
+	t.Run("Not Ranked", func(t *testing.T) {
+		action, err := adjust(ctx, AdjustData{GameID: "game", LeaderboardID: "season", PlayerID: "bob", Value: 7, Reason: "lost submission"})
+		assert.NoError(t, err)
+		assert.Equal(t, 7.0, ranks["season"]["bob"])
+		assert.Nil(t, action.Previous)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := adjust(ctx, AdjustData{Value: 1, Reason: strings.Repeat("a", MaxReasonLength+1)})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrInvalidLeaderboardID)
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+		assert.ErrorIs(t, err, ErrInvalidReason)
+	})
+}
+
+func TestBuildRemoveFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		ranks   = ranking{"season": {"alice": 10}}
+		entries []audit.NewEntryData
+	)
+
+	remove := BuildRemoveFunc(recordAuditEntry(&entries), ranks.getLeaderboard, ranks.getRank, ranks.deleteRank, func(ctx context.Context, action Action) error {
+		return nil
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		action, err := remove(ctx, RemoveData{GameID: "game", LeaderboardID: "season", PlayerID: "alice", Reason: "cheating"})
+		assert.NoError(t, err)
+		assert.NotContains(t, ranks["season"], "alice")
+		assert.Equal(t, ActionRemove, action.Kind)
+		assert.Equal(t, value(10), action.Previous)
+		assert.Nil(t, action.Value)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("Entry Not Found", func(t *testing.T) {
+		_, err := remove(ctx, RemoveData{GameID: "game", LeaderboardID: "season", PlayerID: "alice", Reason: "cheating"})
+		assert.ErrorIs(t, err, ErrEntryNotFound)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := remove(ctx, RemoveData{GameID: "game", LeaderboardID: "season", PlayerID: "alice"})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidReason)
+	})
+}
+
+func TestBuildBulkRemoveFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		from   = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		to     = from.Add(time.Hour)
+		scores = []leaderboard.Score{
+			{ID: "1", LeaderboardID: "season", PlayerID: "alice"},
+			{ID: "2", LeaderboardID: "season", PlayerID: "alice"},
+			{ID: "3", LeaderboardID: "weekly", PlayerID: "alice"},
+			{ID: "4", LeaderboardID: "season", PlayerID: "bob"},
+			{ID: "5", LeaderboardID: "deleted", PlayerID: "bob"},
+			{ID: "6", LeaderboardID: "season", PlayerID: "carol"},
+		}
+		listScores = func(ctx context.Context, gameID, source string, from, to time.Time, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+			return scores, nil
+		}
+		data = BulkRemoveData{GameID: "game", Source: leaderboard.SourceMQTT, From: from, To: to, Reason: "compromised broker"}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			ranks   = ranking{"season": {"alice": 10, "bob": 5, "dave": 1}, "weekly": {"alice": 3}}
+			entries []audit.NewEntryData
+		)
+
+		action, err := BuildBulkRemoveFunc(recordAuditEntry(&entries), listScores, ranks.getLeaderboard, ranks.getRank, ranks.deleteRank, func(ctx context.Context, action Action) error {
+			return nil
+		})(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, ActionBulkRemove, action.Kind)
+		assert.Equal(t, leaderboard.SourceMQTT, action.Source)
+		assert.Equal(t, from, action.From)
+		assert.Equal(t, to, action.To)
+		assert.Equal(t, []RemovedEntry{
+			{LeaderboardID: "season", PlayerID: "alice", Value: 10},
+			{LeaderboardID: "weekly", PlayerID: "alice", Value: 3},
+			{LeaderboardID: "season", PlayerID: "bob", Value: 5},
+		}, action.Entries)
+		assert.Equal(t, ranking{"season": {"dave": 1}, "weekly": {}}, ranks)
+		assert.Equal(t, AuditBulkRoute, entries[0].Route)
+		assert.Equal(t, map[string]string{"source": leaderboard.SourceMQTT}, entries[0].ResourceIDs)
+	})
+
+	t.Run("Partial Removal", func(t *testing.T) {
+		var (
+			ranks      = ranking{"season": {"alice": 10, "bob": 5}, "weekly": {"alice": 3}}
+			errUnknown = errors.New("unknown error")
+			created    []Action
+		)
+
+		action, err := BuildBulkRemoveFunc(
+			recordAuditEntry(new([]audit.NewEntryData)),
+			listScores,
+			ranks.getLeaderboard,
+			ranks.getRank,
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+				if lb.ID == "weekly" {
+					return errUnknown
+				}
+
+				return ranks.deleteRank(ctx, lb, playerID)
+			},
+			func(ctx context.Context, action Action) error {
+				created = append(created, action)
+				return nil
+			},
+		)(ctx, data)
+		assert.ErrorIs(t, err, errUnknown)
+		assert.Equal(t, []RemovedEntry{{LeaderboardID: "season", PlayerID: "alice", Value: 10}}, action.Entries)
+		assert.Equal(t, []Action{action}, created)
+	})
+
+	t.Run("Too Many Entries", func(t *testing.T) {
+		ranks := ranking{"season": {}}
+		calls := 0
+
+		_, err := BuildBulkRemoveFunc(
+			nil,
+			func(ctx context.Context, gameID, source string, from, to time.Time, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+				page := make([]leaderboard.Score, limit)
+				for i := range page {
+					page[i] = leaderboard.Score{LeaderboardID: "season", PlayerID: uuid.NewString()}
+				}
+
+				calls++
+				return page, nil
+			},
+			ranks.getLeaderboard,
+			ranks.getRank,
+			ranks.deleteRank,
+			nil,
+		)(ctx, data)
+		assert.ErrorIs(t, err, ErrTooManyEntries)
+		assert.Equal(t, MaxBulkRemovals/scoresBatchSize+1, calls)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildBulkRemoveFunc(nil, nil, nil, nil, nil, nil)(ctx, BulkRemoveData{GameID: "game", Source: "FTP", From: to, To: from, Reason: "broken"})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidSource)
+		assert.ErrorIs(t, err, ErrInvalidWindow)
+	})
+}
+
+func TestBuildRollbackFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		from   = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		to     = from.Add(time.Hour)
+		scores = []leaderboard.Score{
+			{SubmittedAt: from.Add(-time.Minute), LeaderboardID: "season", PlayerID: "alice", Value: 5},
+			{SubmittedAt: from, LeaderboardID: "season", PlayerID: "alice", Value: 12},
+			{SubmittedAt: from.Add(time.Minute), LeaderboardID: "season", PlayerID: "bob", Value: 4},
+			{SubmittedAt: to, LeaderboardID: "season", PlayerID: "carol", Value: 9},
+		}
+		listScores = func(ctx context.Context, leaderboardID string, after leaderboard.Score, limit int) ([]leaderboard.Score, error) {
+			return scores, nil
+		}
+		data = RollbackData{GameID: "game", LeaderboardID: "season", From: from, To: to, Reason: "double XP exploit"}
+	)
+
+	rollback := func(ranks ranking, entries *[]audit.NewEntryData, deleteScores StorageDeleteScoresFunc) RollbackFunc {
+		getLeaderboard := func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			lb, err := ranks.getLeaderboard(ctx, id, gameID)
+			lb.AggregationMode = leaderboard.AggregationModeInc
+			return lb, err
+		}
+
+		return BuildRollbackFunc(recordAuditEntry(entries), getLeaderboard, listScores, ranks.getRank, ranks.deleteRank, ranks.upsertRank, deleteScores, func(ctx context.Context, action Action) error {
+			return nil
+		})
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			ranks   = ranking{"season": {"alice": 17, "bob": 4, "carol": 9}}
+			entries []audit.NewEntryData
+			deleted []string
+		)
+
+		action, err := rollback(ranks, &entries, func(ctx context.Context, leaderboardID, playerID string, from, to time.Time) error {
+			deleted = append(deleted, leaderboardID+"/"+playerID)
+			return nil
+		})(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, ActionRollback, action.Kind)
+		assert.Equal(t, int64(2), action.Scores)
+		assert.Equal(t, []RecomputedEntry{{PlayerID: "alice", Previous: value(17), Value: value(5)}, {PlayerID: "bob", Previous: value(4)}}, action.Recomputed)
+		assert.Equal(t, ranking{"season": {"alice": 5, "carol": 9}}, ranks)
+		assert.Equal(t, []string{"season/"}, deleted)
+		assert.Equal(t, AuditRollbackRoute, entries[0].Route)
+		assert.Equal(t, map[string]string{"leaderboardId": "season"}, entries[0].ResourceIDs)
+	})
+
+	t.Run("Single Player", func(t *testing.T) {
+		var (
+			ranks   = ranking{"season": {"alice": 17, "bob": 4}}
+			entries []audit.NewEntryData
+		)
+
+		data := data
+		data.PlayerID = "alice"
+
+		action, err := rollback(ranks, &entries, func(ctx context.Context, leaderboardID, playerID string, from, to time.Time) error {
+			assert.Equal(t, "alice", playerID)
+			return nil
+		})(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), action.Scores)
+		assert.Equal(t, ranking{"season": {"alice": 5, "bob": 4}}, ranks)
+		assert.Equal(t, map[string]string{"leaderboardId": "season", "playerId": "alice"}, entries[0].ResourceIDs)
+	})
+
+	t.Run("Delete Scores Error", func(t *testing.T) {
+		var (
+			ranks      = ranking{"season": {"alice": 17, "bob": 4}}
+			errUnknown = errors.New("unknown error")
+			entries    []audit.NewEntryData
+		)
+
+		rollbackFunc := rollback(ranks, &entries, func(ctx context.Context, leaderboardID, playerID string, from, to time.Time) error {
+			return errUnknown
+		})
+
+		_, err := rollbackFunc(ctx, data)
+		assert.ErrorIs(t, err, errUnknown)
+		assert.Empty(t, entries)
+
+		// The scores are still on the history, so sending it again recomputes the same values
+		_, err = rollbackFunc(ctx, data)
+		assert.ErrorIs(t, err, errUnknown)
+		assert.Equal(t, ranking{"season": {"alice": 5}}, ranks)
+	})
+
+	t.Run("No Scores", func(t *testing.T) {
+		data := data
+		data.From, data.To = to.Add(time.Hour), to.Add(2*time.Hour)
+
+		_, err := rollback(ranking{"season": {}}, new([]audit.NewEntryData), nil)(ctx, data)
+		assert.ErrorIs(t, err, ErrNoScores)
+	})
+
+	t.Run("Leaderboard Not Found", func(t *testing.T) {
+		_, err := rollback(ranking{}, new([]audit.NewEntryData), nil)(ctx, data)
+		assert.ErrorIs(t, err, leaderboard.ErrLeaderboardNotFound)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildRollbackFunc(nil, nil, nil, nil, nil, nil, nil, nil)(ctx, RollbackData{GameID: "game", From: to, To: from})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidLeaderboardID)
+		assert.ErrorIs(t, err, ErrInvalidWindow)
+		assert.ErrorIs(t, err, ErrInvalidReason)
+	})
+}
+
+func TestBuildListFunc(t *testing.T) {
+	actions, err := BuildListFunc(func(ctx context.Context, gameID string) ([]Action, error) {
+		return []Action{{GameID: gameID}}, nil
+	})(context.Background(), "game")
+	assert.NoError(t, err)
+	assert.Equal(t, []Action{{GameID: "game"}}, actions)
+
+	_, err = BuildListFunc(nil)(context.Background(), "")
+	assert.ErrorIs(t, err, ErrInvalidGameID)
+}
+
+func TestBuildListDeviceUsageFunc(t *testing.T) {
+	usage, err := BuildListDeviceUsageFunc(func(ctx context.Context, gameID string, filter DeviceUsageFilter, limit int) ([]DeviceUsage, error) {
+		assert.Equal(t, MaxDeviceUsages, limit)
+		return []DeviceUsage{{DeviceHash: filter.DeviceHash, PlayerID: "alice"}}, nil
+	})(context.Background(), "game", DeviceUsageFilter{DeviceHash: "f00d"})
+	assert.NoError(t, err)
+	assert.Equal(t, []DeviceUsage{{DeviceHash: "f00d", PlayerID: "alice"}}, usage)
+
+	_, err = BuildListDeviceUsageFunc(nil)(context.Background(), "game", DeviceUsageFilter{})
+	assert.ErrorIs(t, err, ErrInvalidDeviceFilter)
+}
@@ -0,0 +1,44 @@
+package moderation
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type (
+	// Leaderboard by its id and game id
+	StorageGetLeaderboardFunc func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error)
+
+	// Player's rank on the leaderboard. Fails with `leaderboard.ErrPlayerRankNotFound` when the player isn't ranked
+	StorageGetPlayerRankFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error)
+
+	// Removes the player from the leaderboard ranking
+	StorageDeletePlayerRankFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error
+
+	// Aggregates the value on the player's rank, setting it when the player isn't ranked
+	StorageUpsertPlayerRankValueFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error
+
+	// Scores of the game submitted through the source from `from` until `to`, oldest first, starting after the `after` score
+	StorageListScoresBySourceFunc func(ctx context.Context, gameID, source string, from, to time.Time, after leaderboard.Score, limit int) ([]leaderboard.Score, error)
+
+	// Scores submitted to the leaderboard, oldest first, starting after the `after` score
+	StorageListScoresFunc func(ctx context.Context, leaderboardID string, after leaderboard.Score, limit int) ([]leaderboard.Score, error)
+
+	// Deletes the scores submitted to the leaderboard from `from` until `to` from the history, only the player's unless it's empty
+	StorageDeleteScoresFunc func(ctx context.Context, leaderboardID, playerID string, from, to time.Time) error
+
+	// Devices of the game matching the filter, as recorded on the score history, the most recently seen first, up to `limit`.
+	// The scores without a device hash are left out
+	StorageListDeviceUsageFunc func(ctx context.Context, gameID string, filter DeviceUsageFilter, limit int) ([]DeviceUsage, error)
+
+	// Stores the action
+	StorageCreateActionFunc func(ctx context.Context, action Action) error
+
+	// Actions taken on the player's entry on the leaderboard, including the bulk removals and rollbacks that changed it, oldest first
+	StorageListEntryActionsFunc func(ctx context.Context, gameID, leaderboardID, playerID string) ([]Action, error)
+
+	// Actions taken on the game, oldest first
+	StorageListActionsFunc func(ctx context.Context, gameID string) ([]Action, error)
+)
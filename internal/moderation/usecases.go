@@ -0,0 +1,26 @@
+package moderation
+
+import "context"
+
+type (
+	// Player's entry on the leaderboard, alongside the actions taken on it
+	InspectFunc func(ctx context.Context, gameID, leaderboardID, playerID string) (Entry, error)
+
+	// Sets the value of the player's entry on the leaderboard, ranking the player when they aren't yet
+	AdjustFunc func(ctx context.Context, data AdjustData) (Action, error)
+
+	// Removes the player's entry from the leaderboard
+	RemoveFunc func(ctx context.Context, data RemoveData) (Action, error)
+
+	// Removes every entry the source submitted a score to within the window
+	BulkRemoveFunc func(ctx context.Context, data BulkRemoveData) (Action, error)
+
+	// Reverts the scores submitted to the leaderboard within the window, recomputing the entries from the rest of the history
+	RollbackFunc func(ctx context.Context, data RollbackData) (Action, error)
+
+	// List the devices the players submitted from, by player or device, the most recently seen first
+	ListDeviceUsageFunc func(ctx context.Context, gameID string, filter DeviceUsageFilter) ([]DeviceUsage, error)
+
+	// List the actions taken on the game, oldest first
+	ListFunc func(ctx context.Context, gameID string) ([]Action, error)
+)
@@ -0,0 +1,143 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+const (
+	EventStatisticLandmarkCompleted = "STATISTIC_LANDMARK_COMPLETED"
+	EventStatisticGoalCompleted     = "STATISTIC_GOAL_COMPLETED"
+	EventQuestTaskCompleted         = "QUEST_TASK_COMPLETED"
+	EventQuestCompleted             = "QUEST_COMPLETED"
+)
+
+// Progression completion pushed to a player
+type Notification struct {
+	Event       string    // Completion kind, e.g. `EventStatisticGoalCompleted`
+	GameID      string    // ID of the game the player belongs to
+	PlayerID    string    // Player's ID
+	StatisticID string    // Statistic ID. Only set on the statistic events
+	QuestID     string    // Quest ID. Only set on the quest events
+	TaskID      string    // Task completed. Only set on `EventQuestTaskCompleted`
+	Value       float64   // Landmark or goal value reached. Only set on the statistic events
+	CompletedAt time.Time // Time the player completed it
+}
+
+// Notifications for the goal and landmarks the update made the player reach, in the order they were reached
+func fromStatisticProgressionUpdates(s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) []Notification {
+	notifications := make([]Notification, 0, len(updates.LandmarksJustCompleted)+1)
+	for _, landmark := range updates.LandmarksJustCompleted {
+		notifications = append(notifications, Notification{
+			Event:       EventStatisticLandmarkCompleted,
+			GameID:      s.GameID,
+			PlayerID:    progression.PlayerID,
+			StatisticID: s.ID,
+			Value:       landmark.Value,
+			CompletedAt: landmark.CompletedAt,
+		})
+	}
+
+	if updates.GoalJustCompleted && s.Goal != nil {
+		notifications = append(notifications, Notification{
+			Event:       EventStatisticGoalCompleted,
+			GameID:      s.GameID,
+			PlayerID:    progression.PlayerID,
+			StatisticID: s.ID,
+			Value:       *s.Goal,
+			CompletedAt: updates.GoalCompletedAt,
+		})
+	}
+
+	return notifications
+}
+
+// Notification for the quest lifecycle event. Starting a quest isn't notified, as the player is the one who does it
+func fromQuestLifecycleEvent(event quest.LifecycleEvent) (Notification, bool) {
+	n := Notification{
+		GameID:      event.GameID,
+		PlayerID:    event.PlayerID,
+		QuestID:     event.QuestID,
+		TaskID:      event.TaskID,
+		CompletedAt: event.OccurredAt,
+	}
+
+	switch event.Event {
+	case quest.EventTaskCompleted:
+		n.Event = EventQuestTaskCompleted
+	case quest.EventQuestCompleted:
+		n.Event = EventQuestCompleted
+	default:
+		return Notification{}, false
+	}
+
+	return n, true
+}
+
+// Wraps the statistic progression notifier to also notify the player. The wrapped notifier can be nil, e.g. when the
+// updates are recorded on an outbox, and the player is notified only after it succeeds
+func BuildNotifierStatisticProgressionUpdates(
+	next statistic.NotifierPlayerProgressionUpdates,
+	notifierPlayerNotification NotifierPlayerNotification,
+) statistic.NotifierPlayerProgressionUpdates {
+	return func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+		if next != nil {
+			if err := next(ctx, s, progression, updates); err != nil {
+				return err
+			}
+		}
+
+		for _, n := range fromStatisticProgressionUpdates(s, progression, updates) {
+			if err := notifierPlayerNotification(ctx, n); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Wraps the quest lifecycle notifier to also notify the player. The wrapped notifier can be nil,
+// and the player is notified only after it succeeds
+func BuildNotifierQuestLifecycleEvent(next quest.NotifierLifecycleEvent, notifierPlayerNotification NotifierPlayerNotification) quest.NotifierLifecycleEvent {
+	return func(ctx context.Context, event quest.LifecycleEvent) error {
+		if next != nil {
+			if err := next(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		n, ok := fromQuestLifecycleEvent(event)
+		if !ok {
+			return nil
+		}
+
+		return notifierPlayerNotification(ctx, n)
+	}
+}
+
+func BuildWatchFunc(subscriberPlayerNotifications SubscriberPlayerNotifications) WatchFunc {
+	return func(ctx context.Context, gameID, playerID string, push func(notification Notification) error) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		notifications := subscriberPlayerNotifications(ctx, gameID, playerID)
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case n, ok := <-notifications:
+				if !ok {
+					return nil
+				}
+
+				if err := push(n); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildNotifierStatisticProgressionUpdates(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		goal = 100.0
+		now  = time.Now()
+
+		s           = statistic.Statistic{ID: uuid.NewString(), GameID: uuid.NewString(), Goal: &goal}
+		progression = statistic.PlayerProgression{PlayerID: uuid.NewString(), StatisticID: s.ID}
+		updates     = statistic.PlayerProgressionUpdates{
+			GoalJustCompleted:      true,
+			GoalCompletedAt:        now,
+			LandmarksJustCompleted: []statistic.PlayerProgressionUpdatesLandmark{{Value: 50, CompletedAt: now}},
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			nextCalled    = false
+			notifications = make([]Notification, 0)
+		)
+
+		notifier := BuildNotifierStatisticProgressionUpdates(
+			func(ctx context.Context, statistic statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+				nextCalled = true
+				return nil
+			},
+			func(ctx context.Context, notification Notification) error {
+				notifications = append(notifications, notification)
+				return nil
+			},
+		)
+
+		err := notifier(ctx, s, progression, updates)
+		assert.NoError(t, err)
+		assert.True(t, nextCalled)
+		assert.Equal(t, []Notification{
+			{Event: EventStatisticLandmarkCompleted, GameID: s.GameID, PlayerID: progression.PlayerID, StatisticID: s.ID, Value: 50, CompletedAt: now},
+			{Event: EventStatisticGoalCompleted, GameID: s.GameID, PlayerID: progression.PlayerID, StatisticID: s.ID, Value: goal, CompletedAt: now},
+		}, notifications)
+	})
+
+	t.Run("Without Next", func(t *testing.T) {
+		notified := 0
+
+		notifier := BuildNotifierStatisticProgressionUpdates(nil, func(ctx context.Context, notification Notification) error {
+			notified++
+			return nil
+		})
+
+		err := notifier(ctx, s, progression, updates)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, notified)
+	})
+
+	t.Run("Next Error", func(t *testing.T) {
+		errExpected := errors.New("any error")
+
+		notifier := BuildNotifierStatisticProgressionUpdates(
+			func(ctx context.Context, statistic statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+				return errExpected
+			},
+			func(ctx context.Context, notification Notification) error {
+				assert.Fail(t, "notified the player after the wrapped notifier failed")
+				return nil
+			},
+		)
+
+		err := notifier(ctx, s, progression, updates)
+		assert.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		errExpected := errors.New("any error")
+
+		notifier := BuildNotifierStatisticProgressionUpdates(nil, func(ctx context.Context, notification Notification) error {
+			return errExpected
+		})
+
+		err := notifier(ctx, s, progression, updates)
+		assert.ErrorIs(t, err, errExpected)
+	})
+}
+
+func TestBuildNotifierQuestLifecycleEvent(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		event = quest.LifecycleEvent{
+			OccurredAt: time.Now(),
+			GameID:     uuid.NewString(),
+			PlayerID:   uuid.NewString(),
+			QuestID:    uuid.NewString(),
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			forwarded     = make([]quest.LifecycleEvent, 0)
+			notifications = make([]Notification, 0)
+		)
+
+		notifier := BuildNotifierQuestLifecycleEvent(
+			func(ctx context.Context, event quest.LifecycleEvent) error {
+				forwarded = append(forwarded, event)
+				return nil
+			},
+			func(ctx context.Context, notification Notification) error {
+				notifications = append(notifications, notification)
+				return nil
+			},
+		)
+
+		taskCompleted := event
+		taskCompleted.Event = quest.EventTaskCompleted
+		taskCompleted.TaskID = uuid.NewString()
+
+		questCompleted := event
+		questCompleted.Event = quest.EventQuestCompleted
+
+		questStarted := event
+		questStarted.Event = quest.EventQuestStarted
+
+		for _, e := range []quest.LifecycleEvent{questStarted, taskCompleted, questCompleted} {
+			assert.NoError(t, notifier(ctx, e))
+		}
+
+		assert.Len(t, forwarded, 3)
+		assert.Equal(t, []Notification{
+			{Event: EventQuestTaskCompleted, GameID: event.GameID, PlayerID: event.PlayerID, QuestID: event.QuestID, TaskID: taskCompleted.TaskID, CompletedAt: event.OccurredAt},
+			{Event: EventQuestCompleted, GameID: event.GameID, PlayerID: event.PlayerID, QuestID: event.QuestID, CompletedAt: event.OccurredAt},
+		}, notifications)
+	})
+
+	t.Run("Next Error", func(t *testing.T) {
+		errExpected := errors.New("any error")
+
+		notifier := BuildNotifierQuestLifecycleEvent(
+			func(ctx context.Context, event quest.LifecycleEvent) error {
+				return errExpected
+			},
+			func(ctx context.Context, notification Notification) error {
+				assert.Fail(t, "notified the player after the wrapped notifier failed")
+				return nil
+			},
+		)
+
+		event := event
+		event.Event = quest.EventQuestCompleted
+
+		err := notifier(ctx, event)
+		assert.ErrorIs(t, err, errExpected)
+	})
+}
+
+func TestBuildWatchFunc(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			ctx, cancel   = context.WithCancel(context.Background())
+			notification  = Notification{Event: EventQuestCompleted, GameID: gameID, PlayerID: playerID}
+			notifications = make(chan Notification, 1)
+			pushed        = make([]Notification, 0)
+		)
+		defer cancel()
+
+		notifications <- notification
+
+		watchFunc := BuildWatchFunc(func(ctx context.Context, g, p string) <-chan Notification {
+			assert.Equal(t, gameID, g)
+			assert.Equal(t, playerID, p)
+			return notifications
+		})
+
+		err := watchFunc(ctx, gameID, playerID, func(n Notification) error {
+			pushed = append(pushed, n)
+			cancel()
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []Notification{notification}, pushed)
+	})
+
+	t.Run("Subscription Closed", func(t *testing.T) {
+		notifications := make(chan Notification)
+		close(notifications)
+
+		watchFunc := BuildWatchFunc(func(ctx context.Context, gameID, playerID string) <-chan Notification {
+			return notifications
+		})
+
+		err := watchFunc(context.Background(), gameID, playerID, func(n Notification) error {
+			assert.Fail(t, "pushed from a closed subscription")
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Push Error", func(t *testing.T) {
+		var (
+			notifications = make(chan Notification, 1)
+			errExpected   = errors.New("any error")
+		)
+
+		notifications <- Notification{Event: EventQuestCompleted}
+
+		watchFunc := BuildWatchFunc(func(ctx context.Context, gameID, playerID string) <-chan Notification {
+			return notifications
+		})
+
+		err := watchFunc(context.Background(), gameID, playerID, func(n Notification) error {
+			return errExpected
+		})
+		assert.ErrorIs(t, err, errExpected)
+	})
+}
@@ -0,0 +1,11 @@
+package notification
+
+import "context"
+
+type (
+	// Notify a player of a progression completion
+	NotifierPlayerNotification func(ctx context.Context, notification Notification) error
+
+	// Receive the notifications of a game's player until the context is done, when the channel is closed
+	SubscriberPlayerNotifications func(ctx context.Context, gameID, playerID string) <-chan Notification
+)
@@ -0,0 +1,8 @@
+package notification
+
+import "context"
+
+type (
+	// Push the player's notifications as they happen, until the context is done or the push fails
+	WatchFunc func(ctx context.Context, gameID, playerID string, push func(notification Notification) error) error
+)
@@ -0,0 +1,238 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+const (
+	KindPlayerStatisticProgression = "PLAYER_STATISTIC_PROGRESSION"
+	KindPlayerQuestProgression     = "PLAYER_QUEST_PROGRESSION"
+)
+
+var (
+	ErrUnknownEventKind    = errors.New("unknown event kind")
+	ErrInvalidEventID      = errors.New("invalid event id")
+	ErrInvalidReplayFilter = errors.New("invalid replay filter")
+)
+
+type (
+	// Event recorded alongside the state change that originated it, waiting to be published
+	Event struct {
+		CreatedAt time.Time // Time the event was recorded
+		ID        string    // Event ID, assigned by the storage
+		GameID    string    // Game the event belongs to
+		Kind      string    // Kind of the event, defines the payload format
+		Payload   []byte    // JSON encoded event data
+	}
+
+	PlayerStatisticProgressionPayload struct {
+		Statistic   statistic.Statistic                // Statistic updated
+		Progression statistic.PlayerProgression        // Player progression after the update
+		Updates     statistic.PlayerProgressionUpdates // Goal and landmarks completed by the update
+	}
+
+	PlayerQuestProgressionPayload struct {
+		Progression quest.PlayerQuestProgression // Player progression after the update
+	}
+
+	// Archived events to publish again
+	ReplayFilter struct {
+		GameID string    // Game the events belong to
+		From   time.Time // Events recorded from this time on
+		To     time.Time // Events recorded before this time
+	}
+)
+
+func (f ReplayFilter) validate() error {
+	if f.GameID == "" {
+		return fmt.Errorf("%w: game id is required", ErrInvalidReplayFilter)
+	}
+
+	if !f.To.After(f.From) {
+		return fmt.Errorf("%w: the end of the range must be after its start", ErrInvalidReplayFilter)
+	}
+
+	return nil
+}
+
+func newEvent(kind, gameID string, payload any) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		CreatedAt: time.Now().UTC(),
+		GameID:    gameID,
+		Kind:      kind,
+		Payload:   data,
+	}, nil
+}
+
+// Player the event refers to. Every kind of event carries the player's progression
+func (e Event) PlayerID() (string, error) {
+	var payload struct {
+		Progression struct {
+			PlayerID string
+		}
+	}
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return "", err
+	}
+
+	return payload.Progression.PlayerID, nil
+}
+
+// Copy of the event referring to another player. Only the player ID is replaced, the rest of the payload is kept as is
+func (e Event) WithPlayerID(playerID string) (Event, error) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return Event{}, err
+	}
+
+	var progression map[string]json.RawMessage
+	if err := json.Unmarshal(payload["Progression"], &progression); err != nil {
+		return Event{}, err
+	}
+
+	id, err := json.Marshal(playerID)
+	if err != nil {
+		return Event{}, err
+	}
+	progression["PlayerID"] = id
+
+	if payload["Progression"], err = json.Marshal(progression); err != nil {
+		return Event{}, err
+	}
+
+	if e.Payload, err = json.Marshal(payload); err != nil {
+		return Event{}, err
+	}
+
+	return e, nil
+}
+
+func NewPlayerStatisticProgressionEvent(st statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) (Event, error) {
+	return newEvent(KindPlayerStatisticProgression, st.GameID, PlayerStatisticProgressionPayload{
+		Statistic:   st,
+		Progression: progression,
+		Updates:     updates,
+	})
+}
+
+func NewPlayerQuestProgressionEvent(progression quest.PlayerQuestProgression) (Event, error) {
+	return newEvent(KindPlayerQuestProgression, progression.Quest.GameID, PlayerQuestProgressionPayload{Progression: progression})
+}
+
+func buildPublishFunc(
+	notifierPlayerStatisticProgressionUpdates statistic.NotifierPlayerProgressionUpdates,
+	notifierPlayerQuestProgressionUpdates quest.NotifierPlayerProgressionUpdates,
+) func(ctx context.Context, event Event) error {
+	return func(ctx context.Context, event Event) error {
+		switch event.Kind {
+		case KindPlayerStatisticProgression:
+			var payload PlayerStatisticProgressionPayload
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				return err
+			}
+
+			return notifierPlayerStatisticProgressionUpdates(ctx, payload.Statistic, payload.Progression, payload.Updates)
+		case KindPlayerQuestProgression:
+			var payload PlayerQuestProgressionPayload
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				return err
+			}
+
+			return notifierPlayerQuestProgressionUpdates(ctx, payload.Progression)
+		default:
+			return fmt.Errorf("%w: %s", ErrUnknownEventKind, event.Kind)
+		}
+	}
+}
+
+func BuildRelayFunc(
+	batchSize int,
+	storageListPendingEventsFunc StorageListPendingEventsFunc,
+	storageDeleteEventFunc StorageDeleteEventFunc,
+	storageArchiveEventFunc StorageArchiveEventFunc,
+	notifierPlayerStatisticProgressionUpdates statistic.NotifierPlayerProgressionUpdates,
+	notifierPlayerQuestProgressionUpdates quest.NotifierPlayerProgressionUpdates,
+) RelayFunc {
+	publish := buildPublishFunc(notifierPlayerStatisticProgressionUpdates, notifierPlayerQuestProgressionUpdates)
+
+	return func(ctx context.Context) (int, error) {
+		events, err := storageListPendingEventsFunc(ctx, batchSize)
+		if err != nil {
+			return 0, err
+		}
+
+		// Events are published in the order they were recorded, stopping at the first failure so
+		// the remaining ones are retried on the next run. An event is only removed after being
+		// published, so it can be published again if the removal fails.
+		// Without an archive, the published events are not kept
+		for i, event := range events {
+			if err := publish(ctx, event); err != nil {
+				return i, fmt.Errorf("event %s: %w", event.ID, err)
+			}
+
+			if storageArchiveEventFunc != nil {
+				if err := storageArchiveEventFunc(ctx, event); err != nil {
+					return i, fmt.Errorf("event %s: %w", event.ID, err)
+				}
+			}
+
+			if err := storageDeleteEventFunc(ctx, event.ID); err != nil {
+				return i, fmt.Errorf("event %s: %w", event.ID, err)
+			}
+		}
+
+		return len(events), nil
+	}
+}
+
+func BuildReplayFunc(
+	batchSize int,
+	storageListArchivedEventsFunc StorageListArchivedEventsFunc,
+	notifierPlayerStatisticProgressionUpdates statistic.NotifierPlayerProgressionUpdates,
+	notifierPlayerQuestProgressionUpdates quest.NotifierPlayerProgressionUpdates,
+) ReplayFunc {
+	publish := buildPublishFunc(notifierPlayerStatisticProgressionUpdates, notifierPlayerQuestProgressionUpdates)
+
+	return func(ctx context.Context, filter ReplayFilter) (int, error) {
+		if err := filter.validate(); err != nil {
+			return 0, err
+		}
+
+		var (
+			replayed int
+			after    Event
+		)
+		for {
+			events, err := storageListArchivedEventsFunc(ctx, filter, after, batchSize)
+			if err != nil {
+				return replayed, err
+			}
+
+			for _, event := range events {
+				if err := publish(ctx, event); err != nil {
+					return replayed, fmt.Errorf("event %s: %w", event.ID, err)
+				}
+
+				replayed++
+			}
+
+			if len(events) < batchSize {
+				return replayed, nil
+			}
+
+			after = events[len(events)-1]
+		}
+	}
+}
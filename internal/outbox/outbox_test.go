@@ -0,0 +1,381 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventPlayerID(t *testing.T) {
+	playerID := uuid.NewString()
+
+	statisticEvent, err := NewPlayerStatisticProgressionEvent(statistic.Statistic{}, statistic.PlayerProgression{PlayerID: playerID}, statistic.PlayerProgressionUpdates{})
+	assert.NoError(t, err)
+
+	questEvent, err := NewPlayerQuestProgressionEvent(quest.PlayerQuestProgression{PlayerID: playerID})
+	assert.NoError(t, err)
+
+	for _, event := range []Event{statisticEvent, questEvent} {
+		id, err := event.PlayerID()
+		assert.NoError(t, err)
+		assert.Equal(t, playerID, id)
+	}
+
+	_, err = Event{Payload: []byte(`{`)}.PlayerID()
+	assert.Error(t, err)
+}
+
+func TestEventWithPlayerID(t *testing.T) {
+	var (
+		playerID  = uuid.NewString()
+		pseudonym = uuid.NewString()
+		value     = 10.0
+	)
+
+	event, err := NewPlayerStatisticProgressionEvent(statistic.Statistic{ID: uuid.NewString()}, statistic.PlayerProgression{PlayerID: playerID, CurrentValue: &value}, statistic.PlayerProgressionUpdates{})
+	assert.NoError(t, err)
+
+	anonymized, err := event.WithPlayerID(pseudonym)
+	assert.NoError(t, err)
+	assert.Equal(t, event.Kind, anonymized.Kind)
+
+	id, err := anonymized.PlayerID()
+	assert.NoError(t, err)
+	assert.Equal(t, pseudonym, id)
+
+	var original, payload PlayerStatisticProgressionPayload
+	assert.NoError(t, json.Unmarshal(event.Payload, &original))
+	assert.NoError(t, json.Unmarshal(anonymized.Payload, &payload))
+
+	original.Progression.PlayerID = pseudonym
+	assert.Equal(t, original, payload)
+
+	_, err = Event{Payload: []byte(`{"Progression":1}`)}.WithPlayerID(pseudonym)
+	assert.Error(t, err)
+}
+
+func TestBuildRelayFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		st = statistic.Statistic{
+			ID:              uuid.NewString(),
+			GameID:          uuid.NewString(),
+			Name:            "Kills",
+			AggregationMode: statistic.AggregationModeSum,
+		}
+		statisticProgression = statistic.PlayerProgression{PlayerID: uuid.NewString(), StatisticID: st.ID}
+		statisticUpdates     = statistic.PlayerProgressionUpdates{GoalJustCompleted: true}
+
+		questProgression = quest.PlayerQuestProgression{
+			PlayerID: uuid.NewString(),
+			Quest:    quest.Quest{ID: uuid.NewString(), GameID: uuid.NewString(), Name: "Tutorial"},
+		}
+	)
+
+	statisticEvent, err := NewPlayerStatisticProgressionEvent(st, statisticProgression, statisticUpdates)
+	assert.NoError(t, err)
+	statisticEvent.ID = uuid.NewString()
+
+	questEvent, err := NewPlayerQuestProgressionEvent(questProgression)
+	assert.NoError(t, err)
+	questEvent.ID = uuid.NewString()
+
+	listPendingEvents := func(ctx context.Context, limit int) ([]Event, error) {
+		return []Event{statisticEvent, questEvent}, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			deleted                []string
+			statisticNotifications []statistic.PlayerProgressionUpdates
+			questNotifications     []quest.PlayerQuestProgression
+		)
+
+		relayFunc := BuildRelayFunc(
+			10,
+			func(ctx context.Context, limit int) ([]Event, error) {
+				assert.Equal(t, 10, limit)
+				return []Event{statisticEvent, questEvent}, nil
+			},
+			func(ctx context.Context, id string) error {
+				deleted = append(deleted, id)
+				return nil
+			},
+			nil,
+			func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+				assert.Equal(t, st.ID, s.ID)
+				assert.Equal(t, statisticProgression.PlayerID, progression.PlayerID)
+				statisticNotifications = append(statisticNotifications, updates)
+				return nil
+			},
+			func(ctx context.Context, progression quest.PlayerQuestProgression) error {
+				questNotifications = append(questNotifications, progression)
+				return nil
+			},
+		)
+
+		published, err := relayFunc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, published)
+		assert.Equal(t, []string{statisticEvent.ID, questEvent.ID}, deleted)
+		assert.Equal(t, []statistic.PlayerProgressionUpdates{statisticUpdates}, statisticNotifications)
+		assert.Len(t, questNotifications, 1)
+		assert.Equal(t, questProgression.Quest.ID, questNotifications[0].Quest.ID)
+	})
+
+	t.Run("List Pending Events Error", func(t *testing.T) {
+		errList := errors.New("any error")
+
+		relayFunc := BuildRelayFunc(
+			10,
+			func(ctx context.Context, limit int) ([]Event, error) {
+				return nil, errList
+			},
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+
+		published, err := relayFunc(ctx)
+		assert.ErrorIs(t, err, errList)
+		assert.Zero(t, published)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		var (
+			errNotifier = errors.New("any error")
+			deleted     []string
+		)
+
+		relayFunc := BuildRelayFunc(
+			10,
+			listPendingEvents,
+			func(ctx context.Context, id string) error {
+				deleted = append(deleted, id)
+				return nil
+			},
+			nil,
+			func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+				return nil
+			},
+			func(ctx context.Context, progression quest.PlayerQuestProgression) error {
+				return errNotifier
+			},
+		)
+
+		published, err := relayFunc(ctx)
+		assert.ErrorIs(t, err, errNotifier)
+		assert.Equal(t, 1, published)
+		assert.Equal(t, []string{statisticEvent.ID}, deleted)
+	})
+
+	t.Run("Delete Event Error", func(t *testing.T) {
+		errDelete := errors.New("any error")
+
+		relayFunc := BuildRelayFunc(
+			10,
+			listPendingEvents,
+			func(ctx context.Context, id string) error {
+				return errDelete
+			},
+			nil,
+			func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+				return nil
+			},
+			nil,
+		)
+
+		published, err := relayFunc(ctx)
+		assert.ErrorIs(t, err, errDelete)
+		assert.Zero(t, published)
+	})
+
+	t.Run("Archived", func(t *testing.T) {
+		var archived []string
+
+		relayFunc := BuildRelayFunc(
+			10,
+			listPendingEvents,
+			func(ctx context.Context, id string) error {
+				return nil
+			},
+			func(ctx context.Context, event Event) error {
+				archived = append(archived, event.ID)
+				return nil
+			},
+			func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+				return nil
+			},
+			func(ctx context.Context, progression quest.PlayerQuestProgression) error {
+				return nil
+			},
+		)
+
+		published, err := relayFunc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, published)
+		assert.Equal(t, []string{statisticEvent.ID, questEvent.ID}, archived)
+	})
+
+	t.Run("Archive Event Error", func(t *testing.T) {
+		var (
+			errArchive = errors.New("any error")
+			deleted    []string
+		)
+
+		relayFunc := BuildRelayFunc(
+			10,
+			listPendingEvents,
+			func(ctx context.Context, id string) error {
+				deleted = append(deleted, id)
+				return nil
+			},
+			func(ctx context.Context, event Event) error {
+				return errArchive
+			},
+			func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+				return nil
+			},
+			nil,
+		)
+
+		published, err := relayFunc(ctx)
+		assert.ErrorIs(t, err, errArchive)
+		assert.Zero(t, published)
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("Unknown Event Kind", func(t *testing.T) {
+		relayFunc := BuildRelayFunc(
+			10,
+			func(ctx context.Context, limit int) ([]Event, error) {
+				return []Event{{ID: uuid.NewString(), Kind: "ANY"}}, nil
+			},
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+
+		published, err := relayFunc(ctx)
+		assert.ErrorIs(t, err, ErrUnknownEventKind)
+		assert.Zero(t, published)
+	})
+}
+
+func TestBuildReplayFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		gameID = uuid.NewString()
+		filter = ReplayFilter{GameID: gameID, From: time.Now().Add(-time.Hour), To: time.Now()}
+
+		st = statistic.Statistic{ID: uuid.NewString(), GameID: gameID}
+	)
+
+	newEvents := func(n int) []Event {
+		events := make([]Event, n)
+		for i := range events {
+			event, err := NewPlayerStatisticProgressionEvent(st, statistic.PlayerProgression{PlayerID: uuid.NewString()}, statistic.PlayerProgressionUpdates{})
+			assert.NoError(t, err)
+
+			event.ID = uuid.NewString()
+			events[i] = event
+		}
+
+		return events
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			events   = newEvents(3)
+			afters   []string
+			notified int
+		)
+
+		replayFunc := BuildReplayFunc(
+			2,
+			func(ctx context.Context, f ReplayFilter, after Event, limit int) ([]Event, error) {
+				assert.Equal(t, filter, f)
+				assert.Equal(t, 2, limit)
+
+				afters = append(afters, after.ID)
+				if after.ID == "" {
+					return events[:2], nil
+				}
+
+				return events[2:], nil
+			},
+			func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+				assert.Equal(t, st.ID, s.ID)
+				notified++
+				return nil
+			},
+			nil,
+		)
+
+		replayed, err := replayFunc(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, replayed)
+		assert.Equal(t, 3, notified)
+		assert.Equal(t, []string{"", events[1].ID}, afters)
+	})
+
+	t.Run("Invalid Filter", func(t *testing.T) {
+		replayFunc := BuildReplayFunc(2, nil, nil, nil)
+
+		_, err := replayFunc(ctx, ReplayFilter{From: filter.From, To: filter.To})
+		assert.ErrorIs(t, err, ErrInvalidReplayFilter)
+
+		_, err = replayFunc(ctx, ReplayFilter{GameID: gameID, From: filter.To, To: filter.From})
+		assert.ErrorIs(t, err, ErrInvalidReplayFilter)
+	})
+
+	t.Run("List Archived Events Error", func(t *testing.T) {
+		errList := errors.New("any error")
+
+		replayFunc := BuildReplayFunc(
+			2,
+			func(ctx context.Context, f ReplayFilter, after Event, limit int) ([]Event, error) {
+				return nil, errList
+			},
+			nil,
+			nil,
+		)
+
+		replayed, err := replayFunc(ctx, filter)
+		assert.ErrorIs(t, err, errList)
+		assert.Zero(t, replayed)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		var (
+			errNotifier = errors.New("any error")
+			events      = newEvents(2)
+		)
+
+		replayFunc := BuildReplayFunc(
+			10,
+			func(ctx context.Context, f ReplayFilter, after Event, limit int) ([]Event, error) {
+				return events, nil
+			},
+			func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+				return errNotifier
+			},
+			nil,
+		)
+
+		replayed, err := replayFunc(ctx, filter)
+		assert.ErrorIs(t, err, errNotifier)
+		assert.Zero(t, replayed)
+	})
+}
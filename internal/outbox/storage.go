@@ -0,0 +1,18 @@
+package outbox
+
+import "context"
+
+type (
+	// Lists up to `limit` events waiting to be published, oldest first
+	StorageListPendingEventsFunc func(ctx context.Context, limit int) ([]Event, error)
+
+	// Removes a published event
+	StorageDeleteEventFunc func(ctx context.Context, id string) error
+
+	// Keeps a published event to be replayed. Archiving the same event twice keeps a single copy
+	StorageArchiveEventFunc func(ctx context.Context, event Event) error
+
+	// Lists up to `limit` archived events matching the filter, oldest first, recorded after the `after` event.
+	// A zero `after` starts from the oldest event
+	StorageListArchivedEventsFunc func(ctx context.Context, filter ReplayFilter, after Event, limit int) ([]Event, error)
+)
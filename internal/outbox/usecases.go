@@ -0,0 +1,11 @@
+package outbox
+
+import "context"
+
+type (
+	// Publishes a batch of pending events, returning how many were published
+	RelayFunc func(ctx context.Context) (int, error)
+
+	// Publishes again the archived events matching the filter, in the order they were recorded, returning how many were published
+	ReplayFunc func(ctx context.Context, filter ReplayFilter) (int, error)
+)
@@ -0,0 +1,204 @@
+package overview
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Player lookups sent at once on each part of the overview, e.g. the player's rank on each leaderboard
+const MaxConcurrentFetches = 8
+
+var (
+	ErrMissingGameID   = errors.New("missing game id")
+	ErrInvalidPlayerID = errors.New("invalid player id")
+)
+
+type (
+	// Player's rank on an active leaderboard
+	LeaderboardRank struct {
+		Leaderboard leaderboard.Leaderboard // Leaderboard the player is ranked on
+		Rank        leaderboard.Rank        // Player's rank
+	}
+
+	// Player's progression on a statistic
+	StatisticProgression struct {
+		Statistic   statistic.Statistic         // Statistic the player progressed on
+		Progression statistic.PlayerProgression // Player's progression
+	}
+
+	// Everything the player did on the game, for the game's profile screens
+	Overview struct {
+		GameID       string                          // Game the player belongs to
+		PlayerID     string                          // Player's ID
+		Ranks        []LeaderboardRank               // Ranks on the open leaderboards, in the order the game lists them
+		Statistics   []StatisticProgression          // Progressions on the statistics, in the order the game lists them
+		ActiveQuests []quest.PlayerQuestProgression  // Quests started and not completed yet, in the order the game lists them
+		Achievements []achievement.PlayerAchievement // Achievements unlocked, in the order they were unlocked. Nil when they're disabled
+	}
+)
+
+// Runs the fetches concurrently, up to `limit` at a time, returning the first error.
+// The remaining fetches are canceled once one fails
+func fetchAll(ctx context.Context, limit int, fetches ...func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		slots    = make(chan struct{}, limit)
+	)
+
+	for _, fetch := range fetches {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func(fetch func(ctx context.Context) error) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if err := fetch(ctx); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(fetch)
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// Looks up the player on each item concurrently, keeping the order of the items. The items the lookup
+// doesn't find the player on are left out
+func lookupEach[I, O any](ctx context.Context, items []I, lookup func(ctx context.Context, item I) (O, bool, error)) ([]O, error) {
+	var (
+		results = make([]O, len(items))
+		found   = make([]bool, len(items))
+		fetches = make([]func(ctx context.Context) error, len(items))
+	)
+
+	for i, item := range items {
+		fetches[i] = func(ctx context.Context) (err error) {
+			results[i], found[i], err = lookup(ctx, item)
+			return err
+		}
+	}
+
+	if err := fetchAll(ctx, MaxConcurrentFetches, fetches...); err != nil {
+		return nil, err
+	}
+
+	kept := make([]O, 0, len(items))
+	for i, ok := range found {
+		if ok {
+			kept = append(kept, results[i])
+		}
+	}
+
+	return kept, nil
+}
+
+// The ranks, statistic progressions, active quests and achievements are fetched concurrently, alongside the lookups of
+// each part. The achievements are left out when `listPlayerAchievementsFunc` is nil, as they're disabled
+func BuildGetFunc(
+	storageListLeaderboardsFunc StorageListLeaderboardsFunc,
+	storageGetPlayerRankFunc StorageGetPlayerRankFunc,
+	storageListStatisticsFunc StorageListStatisticsFunc,
+	storageGetPlayerProgressionFunc StorageGetPlayerProgressionFunc,
+	storageListQuestsFunc StorageListQuestsFunc,
+	storageGetPlayerQuestProgressionFunc StorageGetPlayerQuestProgressionFunc,
+	listPlayerAchievementsFunc achievement.ListPlayerAchievementsFunc,
+) GetFunc {
+	return func(ctx context.Context, gameID, playerID string) (Overview, error) {
+		if gameID == "" {
+			return Overview{}, ErrMissingGameID
+		}
+
+		if playerID == "" {
+			return Overview{}, ErrInvalidPlayerID
+		}
+
+		overview := Overview{GameID: gameID, PlayerID: playerID}
+
+		fetchRanks := func(ctx context.Context) error {
+			leaderboards, err := storageListLeaderboardsFunc(ctx, gameID)
+			if err != nil {
+				return err
+			}
+
+			open := make([]leaderboard.Leaderboard, 0, len(leaderboards))
+			for _, lb := range leaderboards {
+				if !lb.Closed() {
+					open = append(open, lb)
+				}
+			}
+
+			overview.Ranks, err = lookupEach(ctx, open, func(ctx context.Context, lb leaderboard.Leaderboard) (LeaderboardRank, bool, error) {
+				rank, err := storageGetPlayerRankFunc(ctx, lb, playerID)
+				if errors.Is(err, leaderboard.ErrPlayerRankNotFound) {
+					return LeaderboardRank{}, false, nil
+				}
+
+				return LeaderboardRank{Leaderboard: lb, Rank: rank}, err == nil, err
+			})
+			return err
+		}
+
+		fetchStatistics := func(ctx context.Context) error {
+			statistics, err := storageListStatisticsFunc(ctx, gameID)
+			if err != nil {
+				return err
+			}
+
+			overview.Statistics, err = lookupEach(ctx, statistics, func(ctx context.Context, s statistic.Statistic) (StatisticProgression, bool, error) {
+				progression, err := storageGetPlayerProgressionFunc(ctx, s.ID, playerID)
+				if errors.Is(err, statistic.ErrPlayerStatisticNotFound) {
+					return StatisticProgression{}, false, nil
+				}
+
+				return StatisticProgression{Statistic: s, Progression: progression}, err == nil, err
+			})
+			return err
+		}
+
+		fetchQuests := func(ctx context.Context) error {
+			quests, err := storageListQuestsFunc(ctx, gameID)
+			if err != nil {
+				return err
+			}
+
+			overview.ActiveQuests, err = lookupEach(ctx, quests, func(ctx context.Context, q quest.Quest) (quest.PlayerQuestProgression, bool, error) {
+				progression, err := storageGetPlayerQuestProgressionFunc(ctx, q, playerID)
+				if errors.Is(err, quest.ErrPlayerNotStartedTheQuest) {
+					return quest.PlayerQuestProgression{}, false, nil
+				}
+
+				return progression, err == nil && progression.CompletedAt.IsZero(), err
+			})
+			return err
+		}
+
+		fetches := []func(ctx context.Context) error{fetchRanks, fetchStatistics, fetchQuests}
+		if listPlayerAchievementsFunc != nil {
+			fetches = append(fetches, func(ctx context.Context) (err error) {
+				overview.Achievements, err = listPlayerAchievementsFunc(ctx, gameID, playerID)
+				return err
+			})
+		}
+
+		if err := fetchAll(ctx, len(fetches), fetches...); err != nil {
+			return Overview{}, err
+		}
+
+		return overview, nil
+	}
+}
@@ -0,0 +1,102 @@
+package overview
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildGetFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+		now = time.Now()
+
+		listLeaderboards = func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+			return []leaderboard.Leaderboard{
+				{ID: "season", StartAt: now.Add(-time.Hour)},
+				{ID: "unranked", StartAt: now.Add(-time.Hour)},
+				{ID: "ended", StartAt: now.Add(-2 * time.Hour), EndAt: now.Add(-time.Hour)},
+			}, nil
+		}
+		getPlayerRank = func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			if lb.ID != "season" {
+				return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+			}
+
+			return leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Position: 3}, nil
+		}
+		listStatistics = func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+			return []statistic.Statistic{{ID: "kills"}, {ID: "deaths"}}, nil
+		}
+		getPlayerProgression = func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+			if statisticID != "kills" {
+				return statistic.PlayerProgression{}, statistic.ErrPlayerStatisticNotFound
+			}
+
+			return statistic.PlayerProgression{StatisticID: statisticID, PlayerID: playerID}, nil
+		}
+		listQuests = func(ctx context.Context, gameID string) ([]quest.Quest, error) {
+			return []quest.Quest{{ID: "tutorial"}, {ID: "daily"}, {ID: "weekly"}}, nil
+		}
+		getPlayerQuestProgression = func(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error) {
+			switch q.ID {
+			case "tutorial":
+				return quest.PlayerQuestProgression{Quest: q, PlayerID: playerID, CompletedAt: now}, nil
+			case "daily":
+				return quest.PlayerQuestProgression{Quest: q, PlayerID: playerID}, nil
+			}
+
+			return quest.PlayerQuestProgression{}, quest.ErrPlayerNotStartedTheQuest
+		}
+		listPlayerAchievements = func(ctx context.Context, gameID, playerID string) ([]achievement.PlayerAchievement, error) {
+			return []achievement.PlayerAchievement{{PlayerID: playerID}}, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		overview, err := BuildGetFunc(listLeaderboards, getPlayerRank, listStatistics, getPlayerProgression, listQuests, getPlayerQuestProgression, listPlayerAchievements)(ctx, "game", "player")
+		assert.NoError(t, err)
+		assert.Equal(t, "player", overview.PlayerID)
+
+		assert.Len(t, overview.Ranks, 1)
+		assert.Equal(t, int64(3), overview.Ranks[0].Rank.Position)
+		assert.Equal(t, "season", overview.Ranks[0].Leaderboard.ID)
+
+		assert.Len(t, overview.Statistics, 1)
+		assert.Equal(t, "kills", overview.Statistics[0].Statistic.ID)
+
+		assert.Len(t, overview.ActiveQuests, 1)
+		assert.Equal(t, "daily", overview.ActiveQuests[0].Quest.ID)
+
+		assert.Len(t, overview.Achievements, 1)
+	})
+
+	t.Run("Achievements Disabled", func(t *testing.T) {
+		overview, err := BuildGetFunc(listLeaderboards, getPlayerRank, listStatistics, getPlayerProgression, listQuests, getPlayerQuestProgression, nil)(ctx, "game", "player")
+		assert.NoError(t, err)
+		assert.Nil(t, overview.Achievements)
+	})
+
+	t.Run("Lookup Error", func(t *testing.T) {
+		errDown := errors.New("storage down")
+		failingRank := func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			return leaderboard.Rank{}, errDown
+		}
+
+		_, err := BuildGetFunc(listLeaderboards, failingRank, listStatistics, getPlayerProgression, listQuests, getPlayerQuestProgression, nil)(ctx, "game", "player")
+		assert.ErrorIs(t, err, errDown)
+	})
+
+	t.Run("Invalid Player", func(t *testing.T) {
+		_, err := BuildGetFunc(nil, nil, nil, nil, nil, nil, nil)(ctx, "game", "")
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+	})
+}
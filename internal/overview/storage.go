@@ -0,0 +1,29 @@
+package overview
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+type (
+	// Lists the leaderboards of the game that are not soft deleted
+	StorageListLeaderboardsFunc func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error)
+
+	// Player's rank on the leaderboard. Fails with `leaderboard.ErrPlayerRankNotFound` when the player isn't ranked
+	StorageGetPlayerRankFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error)
+
+	// Lists the statistics of the game that are not soft deleted
+	StorageListStatisticsFunc func(ctx context.Context, gameID string) ([]statistic.Statistic, error)
+
+	// Player's progression on the statistic. Fails with `statistic.ErrPlayerStatisticNotFound` when the player has none
+	StorageGetPlayerProgressionFunc func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error)
+
+	// Lists the quests of the game that are not soft deleted
+	StorageListQuestsFunc func(ctx context.Context, gameID string) ([]quest.Quest, error)
+
+	// Player's progression on the quest. Fails with `quest.ErrPlayerNotStartedTheQuest` when the player didn't start it
+	StorageGetPlayerQuestProgressionFunc func(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error)
+)
@@ -0,0 +1,8 @@
+package overview
+
+import "context"
+
+type (
+	// Gathers the player's ranks, statistic progressions, active quests and achievements on the game
+	GetFunc func(ctx context.Context, gameID, playerID string) (Overview, error)
+)
@@ -0,0 +1,149 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Platforms whose accounts can be linked to a player
+const (
+	PlatformSteam  = "STEAM"  // Steam ID
+	PlatformPSN    = "PSN"    // PlayStation Network account ID
+	PlatformXbox   = "XBOX"   // Xbox user ID
+	PlatformDevice = "DEVICE" // ID of the device the game runs on, for the players without a platform account
+)
+
+var Platforms = []string{
+	PlatformSteam,
+	PlatformPSN,
+	PlatformXbox,
+	PlatformDevice,
+}
+
+const MaxExternalIDLength = 128 // Characters an external account ID can have
+
+var (
+	ErrAccountValidationError = errors.New("account validation error") // Kept apart from the profile validation errors
+	ErrInvalidPlatform        = errors.New("platform must be one of STEAM, PSN, XBOX or DEVICE")
+	ErrInvalidExternalID      = errors.New("external id must have between 1 and 128 characters")
+
+	ErrAccountNotFound              = errors.New("linked account not found")
+	ErrAccountLinkedToAnotherPlayer = errors.New("account already linked to another player")
+)
+
+type (
+	// Account of an external platform linked to a player, so the game finds the player from any of its platforms
+	LinkedAccount struct {
+		LinkedAt   time.Time // Time the account was linked
+		GameID     string    // Game the player belongs to
+		PlayerID   string    // Player the account is linked to
+		Platform   string    // Platform of the account, one of `STEAM`, `PSN`, `XBOX` or `DEVICE`
+		ExternalID string    // Account ID on the platform
+	}
+
+	AccountData struct {
+		GameID     string // Game the player belongs to
+		PlayerID   string // Player the account is linked to
+		Platform   string // Platform of the account, case insensitive
+		ExternalID string // Account ID on the platform
+	}
+)
+
+// Uppercases the platform and trims the external ID, so they're validated and stored the same way
+func normalizeAccount(platform, externalID string) (string, string) {
+	return strings.ToUpper(platform), strings.TrimSpace(externalID)
+}
+
+func validateAccount(platform, externalID string) error {
+	errList := make([]error, 0)
+
+	if !slices.Contains(Platforms, platform) {
+		errList = append(errList, ErrInvalidPlatform)
+	}
+
+	if externalID == "" || utf8.RuneCountInString(externalID) > MaxExternalIDLength {
+		errList = append(errList, ErrInvalidExternalID)
+	}
+
+	return errors.Join(errList...)
+}
+
+func (d AccountData) validate() error {
+	errList := make([]error, 0)
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if d.PlayerID == "" {
+		errList = append(errList, ErrInvalidPlayerID)
+	}
+
+	if err := validateAccount(d.Platform, d.ExternalID); err != nil {
+		errList = append(errList, err)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrAccountValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// An account is linked to a single player of the game. Linking it again to the same player keeps the first link
+func BuildLinkAccountFunc(storageLinkAccountFunc StorageLinkAccountFunc) LinkAccountFunc {
+	return func(ctx context.Context, data AccountData) (LinkedAccount, error) {
+		data.Platform, data.ExternalID = normalizeAccount(data.Platform, data.ExternalID)
+		if err := data.validate(); err != nil {
+			return LinkedAccount{}, err
+		}
+
+		return storageLinkAccountFunc(ctx, LinkedAccount{
+			LinkedAt:   time.Now().UTC(),
+			GameID:     data.GameID,
+			PlayerID:   data.PlayerID,
+			Platform:   data.Platform,
+			ExternalID: data.ExternalID,
+		})
+	}
+}
+
+func BuildUnlinkAccountFunc(storageUnlinkAccountFunc StorageUnlinkAccountFunc) UnlinkAccountFunc {
+	return func(ctx context.Context, gameID, playerID, platform, externalID string) error {
+		if playerID == "" {
+			return ErrInvalidPlayerID
+		}
+
+		platform, externalID = normalizeAccount(platform, externalID)
+		if err := validateAccount(platform, externalID); err != nil {
+			return errors.Join(err, ErrAccountValidationError)
+		}
+
+		return storageUnlinkAccountFunc(ctx, gameID, playerID, platform, externalID)
+	}
+}
+
+func BuildListAccountsFunc(storageListAccountsFunc StorageListAccountsFunc) ListAccountsFunc {
+	return func(ctx context.Context, gameID, playerID string) ([]LinkedAccount, error) {
+		if playerID == "" {
+			return nil, ErrInvalidPlayerID
+		}
+
+		return storageListAccountsFunc(ctx, gameID, playerID)
+	}
+}
+
+func BuildResolveAccountFunc(storageGetAccountFunc StorageGetAccountFunc) ResolveAccountFunc {
+	return func(ctx context.Context, gameID, platform, externalID string) (LinkedAccount, error) {
+		platform, externalID = normalizeAccount(platform, externalID)
+		if err := validateAccount(platform, externalID); err != nil {
+			return LinkedAccount{}, errors.Join(err, ErrAccountValidationError)
+		}
+
+		return storageGetAccountFunc(ctx, gameID, platform, externalID)
+	}
+}
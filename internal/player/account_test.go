@@ -0,0 +1,80 @@
+package player
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildLinkAccountFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var stored LinkedAccount
+		link := BuildLinkAccountFunc(func(ctx context.Context, account LinkedAccount) (LinkedAccount, error) {
+			stored = account
+			return account, nil
+		})
+
+		account, err := link(ctx, AccountData{GameID: "game", PlayerID: "alice", Platform: "steam", ExternalID: " 76561197960287930 "})
+		assert.NoError(t, err)
+		assert.Equal(t, PlatformSteam, account.Platform)
+		assert.Equal(t, "76561197960287930", account.ExternalID)
+		assert.False(t, account.LinkedAt.IsZero())
+		assert.Equal(t, account, stored)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		link := BuildLinkAccountFunc(func(ctx context.Context, account LinkedAccount) (LinkedAccount, error) {
+			t.Fail()
+			return account, nil
+		})
+
+		_, err := link(ctx, AccountData{Platform: "ORIGIN", ExternalID: strings.Repeat("1", MaxExternalIDLength+1)})
+		assert.ErrorIs(t, err, ErrAccountValidationError)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+		assert.ErrorIs(t, err, ErrInvalidPlatform)
+		assert.ErrorIs(t, err, ErrInvalidExternalID)
+	})
+}
+
+func TestBuildResolveAccountFunc(t *testing.T) {
+	ctx := context.Background()
+
+	resolve := BuildResolveAccountFunc(func(ctx context.Context, gameID, platform, externalID string) (LinkedAccount, error) {
+		return LinkedAccount{GameID: gameID, PlayerID: "alice", Platform: platform, ExternalID: externalID}, nil
+	})
+
+	account, err := resolve(ctx, "game", "xbox", "2535405290")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", account.PlayerID)
+	assert.Equal(t, PlatformXbox, account.Platform)
+
+	_, err = resolve(ctx, "game", "XBOX", " ")
+	assert.ErrorIs(t, err, ErrAccountValidationError)
+	assert.ErrorIs(t, err, ErrInvalidExternalID)
+}
+
+func TestBuildUnlinkAndListAccountsFunc(t *testing.T) {
+	ctx := context.Background()
+
+	err := BuildUnlinkAccountFunc(nil)(ctx, "game", "", PlatformPSN, "1")
+	assert.ErrorIs(t, err, ErrInvalidPlayerID)
+
+	err = BuildUnlinkAccountFunc(nil)(ctx, "game", "alice", "ORIGIN", "1")
+	assert.ErrorIs(t, err, ErrInvalidPlatform)
+
+	var unlinked string
+	err = BuildUnlinkAccountFunc(func(ctx context.Context, gameID, playerID, platform, externalID string) error {
+		unlinked = platform + "/" + externalID
+		return nil
+	})(ctx, "game", "alice", "psn", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "PSN/1", unlinked)
+
+	_, err = BuildListAccountsFunc(nil)(ctx, "game", "")
+	assert.ErrorIs(t, err, ErrInvalidPlayerID)
+}
@@ -0,0 +1,295 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MaxDisplayNameLength   = 64  // Characters a display name can have
+	MaxMetadataKeys        = 32  // Keys a profile's metadata can have
+	MaxMetadataKeyLength   = 64  // Characters a metadata key can have
+	MaxMetadataValueLength = 256 // Characters a metadata value can have
+
+	// Most profiles listed at once, e.g. to enrich a ranking page
+	MaxListedProfiles = 500
+
+	MinSearchLength     = 2   // Characters a display name search must have
+	MinSearchPageNumber = 0   // First page of a display name search
+	MinSearchLimit      = 1   // Fewest profiles on a page of a display name search
+	MaxSearchLimit      = 100 // Most profiles on a page of a display name search
+
+	// Characters of a search matched by the storages holding the search prefixes instead of the display names
+	MaxIndexedSearchLength = 16
+)
+
+var (
+	ErrValidationError    = errors.New("validation error")
+	ErrInvalidGameID      = errors.New("invalid game id")
+	ErrInvalidPlayerID    = errors.New("invalid player id")
+	ErrInvalidDisplayName = errors.New("display name must have between 1 and 64 characters")
+	ErrInvalidAvatarURL   = errors.New("avatar url must be an absolute http or https url")
+	ErrInvalidCountry     = errors.New("country must be an ISO 3166-1 alpha-2 code")
+	ErrInvalidMetadata    = errors.New("metadata must have up to 32 non empty keys of up to 64 characters and values of up to 256 characters")
+
+	ErrProfileNotFound      = errors.New("profile not found")
+	ErrProfileAlreadyExists = errors.New("profile already exists")
+	ErrTooManyPlayers       = errors.New("too many players listed at once")
+	ErrInvalidSearch        = errors.New("search must have between 2 and 64 characters")
+	ErrInvalidPageNumber    = errors.New("invalid page number")
+	ErrInvalidLimitNumber   = errors.New("invalid limit number")
+)
+
+type (
+	// Public details of a player, scoped to its game
+	Profile struct {
+		CreatedAt   time.Time         // Time the profile was created
+		UpdatedAt   time.Time         // Last time the profile was changed
+		GameID      string            // Game the player belongs to
+		PlayerID    string            // Player's ID, as known by the game
+		DisplayName string            // Name shown to the other players
+		AvatarURL   string            // Image shown alongside the display name. Empty when none
+		Country     string            // ISO 3166-1 alpha-2 code of the player's country. Empty when unknown
+		Metadata    map[string]string // Game defined details of the player
+	}
+
+	// Details set on a profile when it's created or updated
+	ProfileData struct {
+		GameID      string            // Game the player belongs to
+		PlayerID    string            // Player's ID, as known by the game
+		DisplayName string            // Name shown to the other players
+		AvatarURL   string            // Image shown alongside the display name. Optional
+		Country     string            // ISO 3166-1 alpha-2 code of the player's country, case insensitive. Optional
+		Metadata    map[string]string // Game defined details of the player. Optional
+	}
+)
+
+// Lowercases the display name or search and collapses its spaces, so both are compared the same way
+func NormalizeSearch(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// Parts of the normalized display name starting on each of its words, so a search matches the start of any of them.
+// E.g. `Dark Knight` has the terms `dark knight` and `knight`
+func SearchTerms(displayName string) []string {
+	words := strings.Fields(NormalizeSearch(displayName))
+
+	terms := make([]string, len(words))
+	for i := range words {
+		terms[i] = strings.Join(words[i:], " ")
+	}
+
+	return terms
+}
+
+// Prefixes of the display name search terms, from `MinSearchLength` up to `MaxIndexedSearchLength` characters, for the
+// storages that can only match the display names by equality, e.g. once they're encrypted
+func SearchPrefixes(displayName string) []string {
+	var (
+		prefixes = make([]string, 0)
+		seen     = make(map[string]bool)
+	)
+	for _, term := range SearchTerms(displayName) {
+		runes := []rune(term)
+		for length := MinSearchLength; length <= min(len(runes), MaxIndexedSearchLength); length++ {
+			if prefix := string(runes[:length]); !seen[prefix] {
+				seen[prefix] = true
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+
+	return prefixes
+}
+
+// Part of the normalized search matched against the `SearchPrefixes`. Longer searches match every display name sharing
+// their first `MaxIndexedSearchLength` characters
+func IndexedSearch(search string) string {
+	runes := []rune(search)
+	return string(runes[:min(len(runes), MaxIndexedSearchLength)])
+}
+
+func validCountry(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+
+	for _, r := range country {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validMetadata(metadata map[string]string) bool {
+	if len(metadata) > MaxMetadataKeys {
+		return false
+	}
+
+	for key, value := range metadata {
+		if key == "" || utf8.RuneCountInString(key) > MaxMetadataKeyLength || utf8.RuneCountInString(value) > MaxMetadataValueLength {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d ProfileData) validate() error {
+	errList := make([]error, 0)
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if d.PlayerID == "" {
+		errList = append(errList, ErrInvalidPlayerID)
+	}
+
+	if name := strings.TrimSpace(d.DisplayName); name == "" || utf8.RuneCountInString(name) > MaxDisplayNameLength {
+		errList = append(errList, ErrInvalidDisplayName)
+	}
+
+	if d.AvatarURL != "" {
+		u, err := url.Parse(d.AvatarURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errList = append(errList, ErrInvalidAvatarURL)
+		}
+	}
+
+	if d.Country != "" && !validCountry(d.Country) {
+		errList = append(errList, ErrInvalidCountry)
+	}
+
+	if !validMetadata(d.Metadata) {
+		errList = append(errList, ErrInvalidMetadata)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Trims the display name and uppercases the country, so they're validated and stored the same way
+func (d ProfileData) normalize() ProfileData {
+	d.DisplayName = strings.TrimSpace(d.DisplayName)
+	d.Country = strings.ToUpper(d.Country)
+	if d.Metadata == nil {
+		d.Metadata = make(map[string]string)
+	}
+
+	return d
+}
+
+func (d ProfileData) toProfile(now time.Time) Profile {
+	return Profile{
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		GameID:      d.GameID,
+		PlayerID:    d.PlayerID,
+		DisplayName: d.DisplayName,
+		AvatarURL:   d.AvatarURL,
+		Country:     d.Country,
+		Metadata:    d.Metadata,
+	}
+}
+
+func BuildCreateProfileFunc(storageCreateProfileFunc StorageCreateProfileFunc) CreateProfileFunc {
+	return func(ctx context.Context, data ProfileData) (Profile, error) {
+		data = data.normalize()
+		if err := data.validate(); err != nil {
+			return Profile{}, err
+		}
+
+		profile := data.toProfile(time.Now().UTC())
+		if err := storageCreateProfileFunc(ctx, profile); err != nil {
+			return Profile{}, err
+		}
+
+		return profile, nil
+	}
+}
+
+func BuildGetProfileFunc(storageGetProfileFunc StorageGetProfileFunc) GetProfileFunc {
+	return func(ctx context.Context, gameID, playerID string) (Profile, error) {
+		if playerID == "" {
+			return Profile{}, ErrInvalidPlayerID
+		}
+
+		return storageGetProfileFunc(ctx, gameID, playerID)
+	}
+}
+
+// The creation time is kept, failing with `ErrProfileNotFound` when the player has no profile
+func BuildUpdateProfileFunc(storageUpdateProfileFunc StorageUpdateProfileFunc) UpdateProfileFunc {
+	return func(ctx context.Context, data ProfileData) (Profile, error) {
+		data = data.normalize()
+		if err := data.validate(); err != nil {
+			return Profile{}, err
+		}
+
+		return storageUpdateProfileFunc(ctx, data.toProfile(time.Now().UTC()))
+	}
+}
+
+func BuildDeleteProfileFunc(storageDeleteProfileFunc StorageDeleteProfileFunc) DeleteProfileFunc {
+	return func(ctx context.Context, gameID, playerID string) error {
+		if playerID == "" {
+			return ErrInvalidPlayerID
+		}
+
+		return storageDeleteProfileFunc(ctx, gameID, playerID)
+	}
+}
+
+func BuildListProfilesFunc(storageListProfilesFunc StorageListProfilesFunc) ListProfilesFunc {
+	return func(ctx context.Context, gameID string, playerIDs []string) (map[string]Profile, error) {
+		if len(playerIDs) > MaxListedProfiles {
+			return nil, ErrTooManyPlayers
+		}
+
+		profiles := make(map[string]Profile, len(playerIDs))
+		if len(playerIDs) == 0 {
+			return profiles, nil
+		}
+
+		data, err := storageListProfilesFunc(ctx, gameID, playerIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, profile := range data {
+			profiles[profile.PlayerID] = profile
+		}
+
+		return profiles, nil
+	}
+}
+
+// The search is normalized before reaching the storage. Profiles whose display name starts with it come first
+func BuildSearchProfilesFunc(storageSearchProfilesFunc StorageSearchProfilesFunc) SearchProfilesFunc {
+	return func(ctx context.Context, gameID, search string, page, limit int) ([]Profile, error) {
+		search = NormalizeSearch(search)
+		if length := utf8.RuneCountInString(search); length < MinSearchLength || length > MaxDisplayNameLength {
+			return nil, ErrInvalidSearch
+		}
+
+		if page < MinSearchPageNumber {
+			return nil, ErrInvalidPageNumber
+		}
+
+		if limit < MinSearchLimit || limit > MaxSearchLimit {
+			return nil, ErrInvalidLimitNumber
+		}
+
+		return storageSearchProfilesFunc(ctx, gameID, search, page*limit, limit)
+	}
+}
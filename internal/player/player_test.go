@@ -0,0 +1,203 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCreateProfileFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var stored Profile
+		create := BuildCreateProfileFunc(func(ctx context.Context, profile Profile) error {
+			stored = profile
+			return nil
+		})
+
+		profile, err := create(ctx, ProfileData{
+			GameID:      uuid.NewString(),
+			PlayerID:    uuid.NewString(),
+			DisplayName: "  Player One ",
+			AvatarURL:   "https://cdn.example.com/avatar.png",
+			Country:     "br",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Player One", profile.DisplayName)
+		assert.Equal(t, "BR", profile.Country)
+		assert.NotNil(t, profile.Metadata)
+		assert.False(t, profile.CreatedAt.IsZero())
+		assert.Equal(t, profile.CreatedAt, profile.UpdatedAt)
+		assert.Equal(t, profile, stored)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		create := BuildCreateProfileFunc(func(ctx context.Context, profile Profile) error {
+			t.Fail()
+			return nil
+		})
+
+		metadata := make(map[string]string)
+		for i := range MaxMetadataKeys + 1 {
+			metadata[strings.Repeat("k", i+1)] = "v"
+		}
+
+		_, err := create(ctx, ProfileData{
+			DisplayName: strings.Repeat("a", MaxDisplayNameLength+1),
+			AvatarURL:   "ftp://cdn.example.com/avatar.png",
+			Country:     "BRA",
+			Metadata:    metadata,
+		})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+		assert.ErrorIs(t, err, ErrInvalidDisplayName)
+		assert.ErrorIs(t, err, ErrInvalidAvatarURL)
+		assert.ErrorIs(t, err, ErrInvalidCountry)
+		assert.ErrorIs(t, err, ErrInvalidMetadata)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		create := BuildCreateProfileFunc(func(ctx context.Context, profile Profile) error {
+			return ErrProfileAlreadyExists
+		})
+
+		_, err := create(ctx, ProfileData{GameID: uuid.NewString(), PlayerID: uuid.NewString(), DisplayName: "Player"})
+		assert.ErrorIs(t, err, ErrProfileAlreadyExists)
+	})
+}
+
+func TestBuildUpdateProfileFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		update := BuildUpdateProfileFunc(func(ctx context.Context, profile Profile) (Profile, error) {
+			return profile, nil
+		})
+
+		profile, err := update(ctx, ProfileData{GameID: uuid.NewString(), PlayerID: uuid.NewString(), DisplayName: "Player", Metadata: map[string]string{"clan": "red"}})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"clan": "red"}, profile.Metadata)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		update := BuildUpdateProfileFunc(func(ctx context.Context, profile Profile) (Profile, error) {
+			t.Fail()
+			return profile, nil
+		})
+
+		_, err := update(ctx, ProfileData{GameID: uuid.NewString(), PlayerID: uuid.NewString(), DisplayName: " "})
+		assert.ErrorIs(t, err, ErrInvalidDisplayName)
+	})
+}
+
+func TestBuildGetAndDeleteProfileFunc(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := BuildGetProfileFunc(nil)(ctx, uuid.NewString(), "")
+	assert.ErrorIs(t, err, ErrInvalidPlayerID)
+
+	err = BuildDeleteProfileFunc(nil)(ctx, uuid.NewString(), "")
+	assert.ErrorIs(t, err, ErrInvalidPlayerID)
+}
+
+func TestBuildListProfilesFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		list := BuildListProfilesFunc(func(ctx context.Context, gameID string, playerIDs []string) ([]Profile, error) {
+			return []Profile{{GameID: gameID, PlayerID: playerIDs[0], DisplayName: "Player"}}, nil
+		})
+
+		profiles, err := list(ctx, "game", []string{"a", "b"})
+		assert.NoError(t, err)
+		assert.Len(t, profiles, 1)
+		assert.Equal(t, "Player", profiles["a"].DisplayName)
+	})
+
+	t.Run("No Players", func(t *testing.T) {
+		list := BuildListProfilesFunc(func(ctx context.Context, gameID string, playerIDs []string) ([]Profile, error) {
+			t.Fail()
+			return nil, nil
+		})
+
+		profiles, err := list(ctx, "game", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, profiles)
+	})
+
+	t.Run("Too Many Players", func(t *testing.T) {
+		list := BuildListProfilesFunc(nil)
+
+		_, err := list(ctx, "game", make([]string, MaxListedProfiles+1))
+		assert.ErrorIs(t, err, ErrTooManyPlayers)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		list := BuildListProfilesFunc(func(ctx context.Context, gameID string, playerIDs []string) ([]Profile, error) {
+			return nil, errors.New("any error")
+		})
+
+		_, err := list(ctx, "game", []string{"a"})
+		assert.Error(t, err)
+	})
+}
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, "dark knight", NormalizeSearch("  Dark   KNIGHT "))
+	assert.Equal(t, []string{"the dark knight", "dark knight", "knight"}, SearchTerms("The  Dark Knight"))
+	assert.Empty(t, SearchTerms(" "))
+}
+
+func TestSearchPrefixes(t *testing.T) {
+	assert.Equal(t, []string{"ab", "ab ", "ab c", "ab cd", "cd"}, SearchPrefixes("Ab  CD"))
+	assert.Equal(t, []string{"ab", "ab ", "ab a", "ab ab"}, SearchPrefixes("ab ab"))
+	assert.Len(t, SearchPrefixes("abcdefghijklmnopqrstuvwxyz"), MaxIndexedSearchLength-MinSearchLength+1)
+	assert.Empty(t, SearchPrefixes("a"))
+
+	assert.Equal(t, "abcdefghijklmnop", IndexedSearch("abcdefghijklmnopqrstuvwxyz"))
+	assert.Equal(t, "dark k", IndexedSearch("dark k"))
+}
+
+func TestBuildSearchProfilesFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			searched       string
+			offset, limits int
+		)
+		search := BuildSearchProfilesFunc(func(ctx context.Context, gameID, search string, skip, limit int) ([]Profile, error) {
+			searched, offset, limits = search, skip, limit
+			return []Profile{{GameID: gameID, PlayerID: "a", DisplayName: "Dark Knight"}}, nil
+		})
+
+		profiles, err := search(ctx, "game", " Dark  K ", 2, 10)
+		assert.NoError(t, err)
+		assert.Len(t, profiles, 1)
+		assert.Equal(t, "dark k", searched)
+		assert.Equal(t, 20, offset)
+		assert.Equal(t, 10, limits)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		search := BuildSearchProfilesFunc(nil)
+
+		_, err := search(ctx, "game", " a ", 0, 10)
+		assert.ErrorIs(t, err, ErrInvalidSearch)
+
+		_, err = search(ctx, "game", strings.Repeat("a", MaxDisplayNameLength+1), 0, 10)
+		assert.ErrorIs(t, err, ErrInvalidSearch)
+
+		_, err = search(ctx, "game", "dark", MinSearchPageNumber-1, 10)
+		assert.ErrorIs(t, err, ErrInvalidPageNumber)
+
+		_, err = search(ctx, "game", "dark", 0, MaxSearchLimit+1)
+		assert.ErrorIs(t, err, ErrInvalidLimitNumber)
+	})
+}
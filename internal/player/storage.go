@@ -0,0 +1,37 @@
+package player
+
+import "context"
+
+type (
+	// Stores a new profile, failing with `ErrProfileAlreadyExists` when the player already has one
+	StorageCreateProfileFunc func(ctx context.Context, profile Profile) error
+
+	// Get the profile of a player of the game
+	StorageGetProfileFunc func(ctx context.Context, gameID, playerID string) (Profile, error)
+
+	// Replaces the display name, avatar, country, metadata and update time of the profile, returning it
+	StorageUpdateProfileFunc func(ctx context.Context, profile Profile) (Profile, error)
+
+	// Removes the profile of a player of the game
+	StorageDeleteProfileFunc func(ctx context.Context, gameID, playerID string) error
+
+	// Lists the profiles of the players of the game. Players without a profile are left out
+	StorageListProfilesFunc func(ctx context.Context, gameID string, playerIDs []string) ([]Profile, error)
+
+	// Lists the profiles of the game matching the normalized search, skipping the first `offset` ones.
+	// The profiles with a search term starting with the search come first
+	StorageSearchProfilesFunc func(ctx context.Context, gameID, search string, offset, limit int) ([]Profile, error)
+
+	// Stores the link, returning the stored one when the account is already linked to the player
+	// and failing with `ErrAccountLinkedToAnotherPlayer` when it's linked to another one
+	StorageLinkAccountFunc func(ctx context.Context, account LinkedAccount) (LinkedAccount, error)
+
+	// Removes the link of the account from the player, failing with `ErrAccountNotFound` when it isn't linked to the player
+	StorageUnlinkAccountFunc func(ctx context.Context, gameID, playerID, platform, externalID string) error
+
+	// Lists the accounts linked to the player, oldest first
+	StorageListAccountsFunc func(ctx context.Context, gameID, playerID string) ([]LinkedAccount, error)
+
+	// Get the link of the account, failing with `ErrAccountNotFound` when it isn't linked to any player of the game
+	StorageGetAccountFunc func(ctx context.Context, gameID, platform, externalID string) (LinkedAccount, error)
+)
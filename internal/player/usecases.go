@@ -0,0 +1,36 @@
+package player
+
+import "context"
+
+type (
+	// Creates the profile of a player of the game
+	CreateProfileFunc func(ctx context.Context, data ProfileData) (Profile, error)
+
+	// Get the profile of a player of the game
+	GetProfileFunc func(ctx context.Context, gameID, playerID string) (Profile, error)
+
+	// Replaces the display name, avatar, country and metadata of the profile of a player of the game
+	UpdateProfileFunc func(ctx context.Context, data ProfileData) (Profile, error)
+
+	// Removes the profile of a player of the game
+	DeleteProfileFunc func(ctx context.Context, gameID, playerID string) error
+
+	// Lists the profiles of the players of the game, by player ID. Players without a profile are left out
+	ListProfilesFunc func(ctx context.Context, gameID string, playerIDs []string) (map[string]Profile, error)
+
+	// Searches the profiles of the players of the game by display name, paginated. A profile matches when a word of its
+	// display name starts with the search or, depending on the storage, when its words loosely match the search's
+	SearchProfilesFunc func(ctx context.Context, gameID, search string, page, limit int) ([]Profile, error)
+
+	// Links an account of an external platform to a player of the game
+	LinkAccountFunc func(ctx context.Context, data AccountData) (LinkedAccount, error)
+
+	// Removes the link of an external platform account from a player of the game
+	UnlinkAccountFunc func(ctx context.Context, gameID, playerID, platform, externalID string) error
+
+	// Lists the external platform accounts linked to a player of the game, oldest first
+	ListAccountsFunc func(ctx context.Context, gameID, playerID string) ([]LinkedAccount, error)
+
+	// Finds the player of the game an external platform account is linked to
+	ResolveAccountFunc func(ctx context.Context, gameID, platform, externalID string) (LinkedAccount, error)
+)
@@ -0,0 +1,91 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidTimeout   = errors.New("presence timeout must be greater than zero")
+	ErrInvalidRetention = errors.New("presence retention must not be shorter than the timeout")
+	ErrInvalidGameID    = errors.New("invalid game id")
+	ErrInvalidPlayerID  = errors.New("invalid player id")
+)
+
+// Player's presence on a game
+type Presence struct {
+	GameID     string    // Game the player belongs to
+	PlayerID   string    // Player's ID
+	Online     bool      // Whether the player sent a heartbeat within the presence timeout
+	LastSeenAt time.Time // Time of the player's last heartbeat. Zero when the player wasn't seen within the retention
+}
+
+// The player is online until `timeout` goes by without a heartbeat, and its last heartbeat is kept for `retention`
+func BuildHeartbeatFunc(timeout, retention time.Duration, storageRecordHeartbeatFunc StorageRecordHeartbeatFunc) (HeartbeatFunc, error) {
+	if timeout <= 0 {
+		return nil, ErrInvalidTimeout
+	}
+
+	if retention < timeout {
+		return nil, ErrInvalidRetention
+	}
+
+	return func(ctx context.Context, gameID, playerID string) (Presence, error) {
+		if gameID == "" {
+			return Presence{}, ErrInvalidGameID
+		}
+
+		if playerID == "" {
+			return Presence{}, ErrInvalidPlayerID
+		}
+
+		p := Presence{GameID: gameID, PlayerID: playerID, Online: true, LastSeenAt: time.Now().UTC()}
+		if err := storageRecordHeartbeatFunc(ctx, p, timeout, retention); err != nil {
+			return Presence{}, err
+		}
+
+		return p, nil
+	}, nil
+}
+
+// Players never seen, or not seen within the retention, are offline
+func BuildGetFunc(storageListPresencesFunc StorageListPresencesFunc) GetFunc {
+	return func(ctx context.Context, gameID, playerID string) (Presence, error) {
+		if playerID == "" {
+			return Presence{}, ErrInvalidPlayerID
+		}
+
+		presences, err := storageListPresencesFunc(ctx, gameID, []string{playerID})
+		if err != nil {
+			return Presence{}, err
+		}
+
+		if len(presences) == 0 {
+			return Presence{GameID: gameID, PlayerID: playerID}, nil
+		}
+
+		return presences[0], nil
+	}
+}
+
+// Only the players seen within the retention are keyed
+func BuildListFunc(storageListPresencesFunc StorageListPresencesFunc) ListFunc {
+	return func(ctx context.Context, gameID string, playerIDs []string) (map[string]Presence, error) {
+		byPlayer := make(map[string]Presence, len(playerIDs))
+		if len(playerIDs) == 0 {
+			return byPlayer, nil
+		}
+
+		presences, err := storageListPresencesFunc(ctx, gameID, playerIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range presences {
+			byPlayer[p.PlayerID] = p
+		}
+
+		return byPlayer, nil
+	}
+}
@@ -0,0 +1,103 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildHeartbeatFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var recorded Presence
+		heartbeat, err := BuildHeartbeatFunc(time.Minute, time.Hour, func(ctx context.Context, p Presence, timeout, retention time.Duration) error {
+			assert.Equal(t, time.Minute, timeout)
+			assert.Equal(t, time.Hour, retention)
+
+			recorded = p
+			return nil
+		})
+		assert.NoError(t, err)
+
+		p, err := heartbeat(ctx, "game", "alice")
+		assert.NoError(t, err)
+		assert.True(t, p.Online)
+		assert.False(t, p.LastSeenAt.IsZero())
+		assert.Equal(t, recorded, p)
+	})
+
+	t.Run("Invalid Config", func(t *testing.T) {
+		_, err := BuildHeartbeatFunc(0, time.Hour, nil)
+		assert.ErrorIs(t, err, ErrInvalidTimeout)
+
+		_, err = BuildHeartbeatFunc(time.Hour, time.Minute, nil)
+		assert.ErrorIs(t, err, ErrInvalidRetention)
+	})
+
+	t.Run("Invalid Player", func(t *testing.T) {
+		heartbeat, err := BuildHeartbeatFunc(time.Minute, time.Hour, nil)
+		assert.NoError(t, err)
+
+		_, err = heartbeat(ctx, "game", "")
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		storageErr := errors.New("any storage error")
+		heartbeat, err := BuildHeartbeatFunc(time.Minute, time.Hour, func(ctx context.Context, p Presence, timeout, retention time.Duration) error {
+			return storageErr
+		})
+		assert.NoError(t, err)
+
+		_, err = heartbeat(ctx, "game", "alice")
+		assert.ErrorIs(t, err, storageErr)
+	})
+}
+
+func TestBuildGetFunc(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		lastSeen = time.Now().UTC()
+		get      = BuildGetFunc(func(ctx context.Context, gameID string, playerIDs []string) ([]Presence, error) {
+			if playerIDs[0] == "alice" {
+				return []Presence{{GameID: gameID, PlayerID: "alice", Online: true, LastSeenAt: lastSeen}}, nil
+			}
+
+			return nil, nil
+		})
+	)
+
+	p, err := get(ctx, "game", "alice")
+	assert.NoError(t, err)
+	assert.True(t, p.Online)
+	assert.Equal(t, lastSeen, p.LastSeenAt)
+
+	p, err = get(ctx, "game", "bob")
+	assert.NoError(t, err)
+	assert.Equal(t, Presence{GameID: "game", PlayerID: "bob"}, p)
+
+	_, err = get(ctx, "game", "")
+	assert.ErrorIs(t, err, ErrInvalidPlayerID)
+}
+
+func TestBuildListFunc(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		list = BuildListFunc(func(ctx context.Context, gameID string, playerIDs []string) ([]Presence, error) {
+			return []Presence{{GameID: gameID, PlayerID: "alice", Online: true, LastSeenAt: time.Now()}}, nil
+		})
+	)
+
+	presences, err := list(ctx, "game", []string{"alice", "bob"})
+	assert.NoError(t, err)
+	assert.Len(t, presences, 1)
+	assert.True(t, presences["alice"].Online)
+
+	presences, err = list(ctx, "game", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, presences)
+}
@@ -0,0 +1,14 @@
+package presence
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Marks the player online for `timeout` and keeps its last heartbeat for `retention`
+	StorageRecordHeartbeatFunc func(ctx context.Context, presence Presence, timeout, retention time.Duration) error
+
+	// Lists the presence of the players seen within the retention, leaving the others out
+	StorageListPresencesFunc func(ctx context.Context, gameID string, playerIDs []string) ([]Presence, error)
+)
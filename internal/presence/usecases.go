@@ -0,0 +1,14 @@
+package presence
+
+import "context"
+
+type (
+	// Records a heartbeat of the player, marking it online
+	HeartbeatFunc func(ctx context.Context, gameID, playerID string) (Presence, error)
+
+	// Gets the player's presence
+	GetFunc func(ctx context.Context, gameID, playerID string) (Presence, error)
+
+	// Lists the presence of each of the players seen within the retention, keyed by player id
+	ListFunc func(ctx context.Context, gameID string, playerIDs []string) (map[string]Presence, error)
+)
@@ -0,0 +1,106 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Method registered on the audit log for every player anonymized
+	AnonymizationAuditMethod = "ANONYMIZE"
+	// Route registered on the audit log for every player anonymized. The player is identified by its digest
+	AnonymizationAuditRoute = "/api/v1/players/:playerId/anonymize"
+)
+
+// Prefix of every pseudonym, so anonymized players can be told apart from the game's own player IDs
+const PseudonymPrefix = "anonymous-"
+
+// Player's data whose identifiers were replaced on a storage
+type Anonymization struct {
+	Data    string // Kind of the data anonymized, e.g. `DataRanks`
+	Records int64  // Records now referring to the pseudonym
+}
+
+// Proof that the player's data was anonymized. Like the erasure receipt, it never holds the player ID
+type AnonymizationReceipt struct {
+	ID             string          // Receipt ID, the ID of the audit entry recording the anonymization
+	GameID         string          // Game the player's data belonged to
+	PlayerDigest   string          // Digest of the player ID, see `PlayerDigest`
+	Pseudonym      string          // Player ID that replaced the player's one on every storage
+	RequestedBy    string          // Subject of the credential that requested the anonymization
+	AnonymizedAt   time.Time       // Time the anonymization completed
+	Anonymizations []Anonymization // Data anonymized on each storage
+}
+
+// Random player ID, not derived from the player's one, so it can't be traced back to the player
+func NewPseudonym() string {
+	return PseudonymPrefix + uuid.NewString()
+}
+
+// The player's identifiers are replaced by a new pseudonym on every storage, keeping their ranks and progressions,
+// so the leaderboards and the statistic and quest aggregates stay the same. Every storage is anonymized even when
+// one of them fails, but the receipt is only recorded and the anonymization only notified once all of them succeed.
+// A retry picks a new pseudonym, so the data anonymized before the failure keeps the previous one.
+// The notifier is optional
+func BuildAnonymizePlayerFunc(notifierPlayerAnonymized NotifierPlayerAnonymized, recordAuditEntryFunc audit.RecordFunc, storageAnonymizePlayerFuncs ...StorageAnonymizePlayerFunc) AnonymizePlayerFunc {
+	return func(ctx context.Context, gameID, playerID, requestedBy string) (AnonymizationReceipt, error) {
+		if playerID == "" {
+			return AnonymizationReceipt{}, ErrMissingPlayerID
+		}
+
+		var (
+			pseudonym      = NewPseudonym()
+			anonymizations = make([]Anonymization, 0, len(storageAnonymizePlayerFuncs))
+			errList        = make([]error, 0)
+		)
+
+		for _, storageAnonymizePlayerFunc := range storageAnonymizePlayerFuncs {
+			anonymization, err := storageAnonymizePlayerFunc(ctx, gameID, playerID, pseudonym)
+			if err != nil {
+				errList = append(errList, err)
+				continue
+			}
+
+			anonymizations = append(anonymizations, anonymization)
+		}
+
+		if err := errors.Join(errList...); err != nil {
+			return AnonymizationReceipt{}, err
+		}
+
+		receipt := AnonymizationReceipt{
+			GameID:         gameID,
+			PlayerDigest:   PlayerDigest(gameID, playerID),
+			Pseudonym:      pseudonym,
+			RequestedBy:    requestedBy,
+			AnonymizedAt:   time.Now().UTC(),
+			Anonymizations: anonymizations,
+		}
+
+		entry, err := recordAuditEntryFunc(ctx, audit.NewEntryData{
+			GameID:      gameID,
+			Actor:       requestedBy,
+			Method:      AnonymizationAuditMethod,
+			Route:       AnonymizationAuditRoute,
+			ResourceIDs: map[string]string{"playerId": receipt.PlayerDigest},
+			RequestedAt: receipt.AnonymizedAt,
+		})
+		if err != nil {
+			return AnonymizationReceipt{}, err
+		}
+		receipt.ID = entry.ID
+
+		if notifierPlayerAnonymized != nil {
+			if err := notifierPlayerAnonymized(ctx, playerID, receipt); err != nil {
+				return AnonymizationReceipt{}, err
+			}
+		}
+
+		return receipt, nil
+	}
+}
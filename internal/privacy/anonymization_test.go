@@ -0,0 +1,134 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPseudonym(t *testing.T) {
+	pseudonym := NewPseudonym()
+
+	assert.True(t, strings.HasPrefix(pseudonym, PseudonymPrefix))
+	assert.NotEqual(t, pseudonym, NewPseudonym())
+}
+
+func TestBuildAnonymizePlayerFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		gameID      = uuid.NewString()
+		playerID    = uuid.NewString()
+		requestedBy = "support"
+		entryID     = uuid.NewString()
+
+		ranksAnonymization  = Anonymization{Data: DataRanks, Records: 2}
+		questsAnonymization = Anonymization{Data: DataQuestProgression, Records: 1}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			recorded   audit.NewEntryData
+			notified   AnonymizationReceipt
+			pseudonyms = make([]string, 0)
+		)
+
+		anonymizePlayerFunc := BuildAnonymizePlayerFunc(
+			func(ctx context.Context, player string, receipt AnonymizationReceipt) error {
+				assert.Equal(t, playerID, player)
+				notified = receipt
+				return nil
+			},
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				recorded = data
+				return audit.Entry{ID: entryID}, nil
+			},
+			func(ctx context.Context, game, player, pseudonym string) (Anonymization, error) {
+				assert.Equal(t, gameID, game)
+				assert.Equal(t, playerID, player)
+				pseudonyms = append(pseudonyms, pseudonym)
+				return ranksAnonymization, nil
+			},
+			func(ctx context.Context, game, player, pseudonym string) (Anonymization, error) {
+				pseudonyms = append(pseudonyms, pseudonym)
+				return questsAnonymization, nil
+			},
+		)
+
+		receipt, err := anonymizePlayerFunc(ctx, gameID, playerID, requestedBy)
+		assert.NoError(t, err)
+		assert.Equal(t, entryID, receipt.ID)
+		assert.Equal(t, gameID, receipt.GameID)
+		assert.Equal(t, PlayerDigest(gameID, playerID), receipt.PlayerDigest)
+		assert.Equal(t, []string{receipt.Pseudonym, receipt.Pseudonym}, pseudonyms)
+		assert.NotContains(t, receipt.Pseudonym, playerID)
+		assert.Equal(t, requestedBy, receipt.RequestedBy)
+		assert.Equal(t, []Anonymization{ranksAnonymization, questsAnonymization}, receipt.Anonymizations)
+		assert.Equal(t, receipt, notified)
+
+		assert.Equal(t, gameID, recorded.GameID)
+		assert.Equal(t, requestedBy, recorded.Actor)
+		assert.Equal(t, AnonymizationAuditMethod, recorded.Method)
+		assert.Equal(t, AnonymizationAuditRoute, recorded.Route)
+		assert.Equal(t, map[string]string{"playerId": receipt.PlayerDigest}, recorded.ResourceIDs)
+		assert.Equal(t, receipt.AnonymizedAt, recorded.RequestedAt)
+	})
+
+	t.Run("Missing Player ID", func(t *testing.T) {
+		anonymizePlayerFunc := BuildAnonymizePlayerFunc(nil, nil)
+
+		_, err := anonymizePlayerFunc(ctx, gameID, "", requestedBy)
+		assert.ErrorIs(t, err, ErrMissingPlayerID)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		var (
+			errStorage = errors.New("any error")
+			anonymized = false
+		)
+
+		anonymizePlayerFunc := BuildAnonymizePlayerFunc(
+			func(ctx context.Context, player string, receipt AnonymizationReceipt) error {
+				assert.Fail(t, "notified a failed anonymization")
+				return nil
+			},
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				assert.Fail(t, "recorded a failed anonymization")
+				return audit.Entry{}, nil
+			},
+			func(ctx context.Context, game, player, pseudonym string) (Anonymization, error) {
+				return Anonymization{}, errStorage
+			},
+			func(ctx context.Context, game, player, pseudonym string) (Anonymization, error) {
+				anonymized = true
+				return questsAnonymization, nil
+			},
+		)
+
+		_, err := anonymizePlayerFunc(ctx, gameID, playerID, requestedBy)
+		assert.ErrorIs(t, err, errStorage)
+		assert.True(t, anonymized)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		errNotifier := errors.New("any error")
+
+		anonymizePlayerFunc := BuildAnonymizePlayerFunc(
+			func(ctx context.Context, player string, receipt AnonymizationReceipt) error {
+				return errNotifier
+			},
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				return audit.Entry{ID: entryID}, nil
+			},
+		)
+
+		_, err := anonymizePlayerFunc(ctx, gameID, playerID, requestedBy)
+		assert.ErrorIs(t, err, errNotifier)
+	})
+}
@@ -0,0 +1,11 @@
+package privacy
+
+import "context"
+
+type (
+	// Notify a player's data erasure, so the consumers can erase their own copies of it
+	NotifierPlayerErased func(ctx context.Context, playerID string, receipt Receipt) error
+
+	// Notify a player's data anonymization, so the consumers can replace the player ID on their own copies of it
+	NotifierPlayerAnonymized func(ctx context.Context, playerID string, receipt AnonymizationReceipt) error
+)
@@ -0,0 +1,54 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+)
+
+// Player's records held by a storage, the ones an erasure or an anonymization would affect
+type DataCount struct {
+	Data    string // Kind of the data, e.g. `DataRanks`
+	Records int64  // Records referring to the player
+}
+
+// What erasing or anonymizing the player would affect, without changing anything. Like the receipts, it never holds the player ID
+type Preview struct {
+	GameID       string      // Game the player's data belongs to
+	PlayerDigest string      // Digest of the player ID, see `PlayerDigest`
+	Affected     []DataCount // Records held by each storage
+}
+
+// Counts the player's records on every storage, the same ones `BuildErasePlayerFunc` and `BuildAnonymizePlayerFunc`
+// would change when built with the matching storages. Nothing is recorded on the audit log nor notified
+func BuildPreviewPlayerDataFunc(storageCountPlayerDataFuncs ...StorageCountPlayerDataFunc) PreviewPlayerDataFunc {
+	return func(ctx context.Context, gameID, playerID string) (Preview, error) {
+		if playerID == "" {
+			return Preview{}, ErrMissingPlayerID
+		}
+
+		var (
+			affected = make([]DataCount, 0, len(storageCountPlayerDataFuncs))
+			errList  = make([]error, 0)
+		)
+
+		for _, storageCountPlayerDataFunc := range storageCountPlayerDataFuncs {
+			count, err := storageCountPlayerDataFunc(ctx, gameID, playerID)
+			if err != nil {
+				errList = append(errList, err)
+				continue
+			}
+
+			affected = append(affected, count)
+		}
+
+		if err := errors.Join(errList...); err != nil {
+			return Preview{}, err
+		}
+
+		return Preview{
+			GameID:       gameID,
+			PlayerDigest: PlayerDigest(gameID, playerID),
+			Affected:     affected,
+		}, nil
+	}
+}
@@ -0,0 +1,69 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPreviewPlayerDataFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+
+		ranksCount  = DataCount{Data: DataRanks, Records: 2}
+		questsCount = DataCount{Data: DataQuestProgression, Records: 1}
+	)
+
+	countRanks := func(ctx context.Context, game, player string) (DataCount, error) {
+		assert.Equal(t, gameID, game)
+		assert.Equal(t, playerID, player)
+		return ranksCount, nil
+	}
+	countQuests := func(ctx context.Context, game, player string) (DataCount, error) {
+		return questsCount, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		previewPlayerDataFunc := BuildPreviewPlayerDataFunc(countRanks, countQuests)
+
+		preview, err := previewPlayerDataFunc(ctx, gameID, playerID)
+		assert.NoError(t, err)
+		assert.Equal(t, gameID, preview.GameID)
+		assert.Equal(t, PlayerDigest(gameID, playerID), preview.PlayerDigest)
+		assert.Equal(t, []DataCount{ranksCount, questsCount}, preview.Affected)
+	})
+
+	t.Run("Missing Player ID", func(t *testing.T) {
+		previewPlayerDataFunc := BuildPreviewPlayerDataFunc(countRanks)
+
+		_, err := previewPlayerDataFunc(ctx, gameID, "")
+		assert.ErrorIs(t, err, ErrMissingPlayerID)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		var (
+			errStorage = errors.New("any error")
+			counted    = false
+		)
+
+		previewPlayerDataFunc := BuildPreviewPlayerDataFunc(
+			func(ctx context.Context, game, player string) (DataCount, error) {
+				return DataCount{}, errStorage
+			},
+			func(ctx context.Context, game, player string) (DataCount, error) {
+				counted = true
+				return questsCount, nil
+			},
+		)
+
+		_, err := previewPlayerDataFunc(ctx, gameID, playerID)
+		assert.ErrorIs(t, err, errStorage)
+		assert.True(t, counted)
+	})
+}
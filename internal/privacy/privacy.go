@@ -0,0 +1,124 @@
+package privacy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+)
+
+// Kinds of the player's data erased
+const (
+	DataRanks                = "RANKS"                 // Player's entries on the leaderboards rankings
+	DataStatisticProgression = "STATISTIC_PROGRESSION" // Player's progression on the statistics
+	DataQuestProgression     = "QUEST_PROGRESSION"     // Player's progression on the quests and their tasks
+	DataEventHistory         = "EVENT_HISTORY"         // Player's progression events kept on the outbox and its archive
+	DataDeadLetters          = "DEAD_LETTERS"          // Player's submissions given up by the ingestion
+	DataScoreHistory         = "SCORE_HISTORY"         // Player's scores kept to recompute the leaderboards rankings
+	DataProfile              = "PROFILE"               // Player's profile, with its display name, avatar, country and metadata
+	DataAchievements         = "ACHIEVEMENTS"          // Achievements the player unlocked
+	DataRewardGrants         = "REWARD_GRANTS"         // Rewards granted to the player, pending or not
+	DataLinkedAccounts       = "LINKED_ACCOUNTS"       // External platform accounts linked to the player
+	DataBans                 = "BANS"                  // Player's ban, with the values of the ranks it hides
+	DataTitles               = "TITLES"                // Titles granted to the player
+	DataFriendships          = "FRIENDSHIPS"           // Player's friendships, as kept for the player and for each of their friends
+	DataPresence             = "PRESENCE"              // Player's online status and last heartbeat
+	DataActivity             = "ACTIVITY"              // Player's activity feed, with their personal bests, landmarks, quests completed and rank milestones
+	DataQuarantine           = "QUARANTINE"            // Player's submissions held for review
+	DataAnomalies            = "ANOMALIES"             // Player's ranks flagged as outliers of their leaderboard
+	DataShadowFlags          = "SHADOW_FLAGS"          // Player's flag, with their ranks on the shadow copies of the leaderboards
+	DataTrust                = "TRUST"                 // Player's trust record, with their submissions held for review and released
+)
+
+const (
+	// Method registered on the audit log for every player erased
+	ErasureAuditMethod = "ERASE"
+	// Route registered on the audit log for every player erased. The player is identified by its digest
+	ErasureAuditRoute = "/api/v1/players/:playerId"
+)
+
+var ErrMissingPlayerID = errors.New("missing player id")
+
+// Player's data removed from a storage
+type Erasure struct {
+	Data    string // Kind of the data erased, e.g. `DataRanks`
+	Records int64  // Records removed
+}
+
+// Proof that the player's data was erased. It never holds the player ID, only its digest, so keeping it doesn't
+// retain the player's data. The player ID can still be checked against a receipt by computing its digest again
+type Receipt struct {
+	ID           string    // Receipt ID, the ID of the audit entry recording the erasure
+	GameID       string    // Game the player's data belonged to
+	PlayerDigest string    // Digest of the player ID, see `PlayerDigest`
+	RequestedBy  string    // Subject of the credential that requested the erasure
+	ErasedAt     time.Time // Time the erasure completed
+	Erasures     []Erasure // Data removed from each storage
+}
+
+// Hex encoded SHA-256 digest of the player ID, scoped to its game
+func PlayerDigest(gameID, playerID string) string {
+	digest := sha256.Sum256([]byte(gameID + "/" + playerID))
+	return hex.EncodeToString(digest[:])
+}
+
+// Every storage is erased even when one of them fails, but the receipt is only recorded and the erasure
+// only notified once all of them succeed. The notifier is optional
+func BuildErasePlayerFunc(notifierPlayerErased NotifierPlayerErased, recordAuditEntryFunc audit.RecordFunc, storageErasePlayerFuncs ...StorageErasePlayerFunc) ErasePlayerFunc {
+	return func(ctx context.Context, gameID, playerID, requestedBy string) (Receipt, error) {
+		if playerID == "" {
+			return Receipt{}, ErrMissingPlayerID
+		}
+
+		var (
+			erasures = make([]Erasure, 0, len(storageErasePlayerFuncs))
+			errList  = make([]error, 0)
+		)
+
+		for _, storageErasePlayerFunc := range storageErasePlayerFuncs {
+			erasure, err := storageErasePlayerFunc(ctx, gameID, playerID)
+			if err != nil {
+				errList = append(errList, err)
+				continue
+			}
+
+			erasures = append(erasures, erasure)
+		}
+
+		if err := errors.Join(errList...); err != nil {
+			return Receipt{}, err
+		}
+
+		receipt := Receipt{
+			GameID:       gameID,
+			PlayerDigest: PlayerDigest(gameID, playerID),
+			RequestedBy:  requestedBy,
+			ErasedAt:     time.Now().UTC(),
+			Erasures:     erasures,
+		}
+
+		entry, err := recordAuditEntryFunc(ctx, audit.NewEntryData{
+			GameID:      gameID,
+			Actor:       requestedBy,
+			Method:      ErasureAuditMethod,
+			Route:       ErasureAuditRoute,
+			ResourceIDs: map[string]string{"playerId": receipt.PlayerDigest},
+			RequestedAt: receipt.ErasedAt,
+		})
+		if err != nil {
+			return Receipt{}, err
+		}
+		receipt.ID = entry.ID
+
+		if notifierPlayerErased != nil {
+			if err := notifierPlayerErased(ctx, playerID, receipt); err != nil {
+				return Receipt{}, err
+			}
+		}
+
+		return receipt, nil
+	}
+}
@@ -0,0 +1,166 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayerDigest(t *testing.T) {
+	var (
+		gameID   = uuid.NewString()
+		playerID = uuid.NewString()
+	)
+
+	assert.Equal(t, PlayerDigest(gameID, playerID), PlayerDigest(gameID, playerID))
+	assert.NotEqual(t, PlayerDigest(gameID, playerID), PlayerDigest(uuid.NewString(), playerID))
+	assert.NotContains(t, PlayerDigest(gameID, playerID), playerID)
+	assert.Len(t, PlayerDigest(gameID, playerID), 64)
+}
+
+func TestBuildErasePlayerFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		gameID      = uuid.NewString()
+		playerID    = uuid.NewString()
+		requestedBy = "support"
+		entryID     = uuid.NewString()
+
+		ranksErasure  = Erasure{Data: DataRanks, Records: 2}
+		questsErasure = Erasure{Data: DataQuestProgression, Records: 1}
+	)
+
+	eraseRanks := func(ctx context.Context, game, player string) (Erasure, error) {
+		assert.Equal(t, gameID, game)
+		assert.Equal(t, playerID, player)
+		return ranksErasure, nil
+	}
+	eraseQuests := func(ctx context.Context, game, player string) (Erasure, error) {
+		return questsErasure, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			recorded audit.NewEntryData
+			notified Receipt
+		)
+
+		erasePlayerFunc := BuildErasePlayerFunc(
+			func(ctx context.Context, player string, receipt Receipt) error {
+				assert.Equal(t, playerID, player)
+				notified = receipt
+				return nil
+			},
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				recorded = data
+				return audit.Entry{ID: entryID}, nil
+			},
+			eraseRanks,
+			eraseQuests,
+		)
+
+		receipt, err := erasePlayerFunc(ctx, gameID, playerID, requestedBy)
+		assert.NoError(t, err)
+		assert.Equal(t, entryID, receipt.ID)
+		assert.Equal(t, gameID, receipt.GameID)
+		assert.Equal(t, PlayerDigest(gameID, playerID), receipt.PlayerDigest)
+		assert.Equal(t, requestedBy, receipt.RequestedBy)
+		assert.Equal(t, []Erasure{ranksErasure, questsErasure}, receipt.Erasures)
+		assert.Equal(t, receipt, notified)
+
+		assert.Equal(t, gameID, recorded.GameID)
+		assert.Equal(t, requestedBy, recorded.Actor)
+		assert.Equal(t, ErasureAuditMethod, recorded.Method)
+		assert.Equal(t, ErasureAuditRoute, recorded.Route)
+		assert.Equal(t, map[string]string{"playerId": receipt.PlayerDigest}, recorded.ResourceIDs)
+		assert.Equal(t, receipt.ErasedAt, recorded.RequestedAt)
+	})
+
+	t.Run("Without Notifier", func(t *testing.T) {
+		erasePlayerFunc := BuildErasePlayerFunc(
+			nil,
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				return audit.Entry{ID: entryID}, nil
+			},
+			eraseRanks,
+		)
+
+		receipt, err := erasePlayerFunc(ctx, gameID, playerID, requestedBy)
+		assert.NoError(t, err)
+		assert.Equal(t, []Erasure{ranksErasure}, receipt.Erasures)
+	})
+
+	t.Run("Missing Player ID", func(t *testing.T) {
+		erasePlayerFunc := BuildErasePlayerFunc(nil, nil, eraseRanks)
+
+		_, err := erasePlayerFunc(ctx, gameID, "", requestedBy)
+		assert.ErrorIs(t, err, ErrMissingPlayerID)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		var (
+			errStorage = errors.New("any error")
+			erased     = false
+		)
+
+		erasePlayerFunc := BuildErasePlayerFunc(
+			func(ctx context.Context, player string, receipt Receipt) error {
+				assert.Fail(t, "notified a failed erasure")
+				return nil
+			},
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				assert.Fail(t, "recorded a failed erasure")
+				return audit.Entry{}, nil
+			},
+			func(ctx context.Context, game, player string) (Erasure, error) {
+				return Erasure{}, errStorage
+			},
+			func(ctx context.Context, game, player string) (Erasure, error) {
+				erased = true
+				return questsErasure, nil
+			},
+		)
+
+		_, err := erasePlayerFunc(ctx, gameID, playerID, requestedBy)
+		assert.ErrorIs(t, err, errStorage)
+		assert.True(t, erased)
+	})
+
+	t.Run("Audit Error", func(t *testing.T) {
+		errAudit := errors.New("any error")
+
+		erasePlayerFunc := BuildErasePlayerFunc(
+			nil,
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				return audit.Entry{}, errAudit
+			},
+			eraseRanks,
+		)
+
+		_, err := erasePlayerFunc(ctx, gameID, playerID, requestedBy)
+		assert.ErrorIs(t, err, errAudit)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		errNotifier := errors.New("any error")
+
+		erasePlayerFunc := BuildErasePlayerFunc(
+			func(ctx context.Context, player string, receipt Receipt) error {
+				return errNotifier
+			},
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				return audit.Entry{ID: entryID}, nil
+			},
+			eraseRanks,
+		)
+
+		_, err := erasePlayerFunc(ctx, gameID, playerID, requestedBy)
+		assert.ErrorIs(t, err, errNotifier)
+	})
+}
@@ -0,0 +1,16 @@
+package privacy
+
+import "context"
+
+type (
+	// Permanently removes the player's data of the game held by the storage, returning how many records were removed.
+	// Removing data already erased is a no-op, so a failed erasure can be retried as a whole
+	StorageErasePlayerFunc func(ctx context.Context, gameID, playerID string) (Erasure, error)
+
+	// Replaces the player ID by the pseudonym on the player's data of the game held by the storage, returning how many
+	// records were anonymized. The pseudonym is new, so it never clashes with the data of another player
+	StorageAnonymizePlayerFunc func(ctx context.Context, gameID, playerID, pseudonym string) (Anonymization, error)
+
+	// Counts the player's records of the game held by the storage, the ones its erase and anonymize functions would change
+	StorageCountPlayerDataFunc func(ctx context.Context, gameID, playerID string) (DataCount, error)
+)
@@ -0,0 +1,14 @@
+package privacy
+
+import "context"
+
+type (
+	// Permanently removes the player's data of the game from every storage, returning the receipt of the erasure
+	ErasePlayerFunc func(ctx context.Context, gameID, playerID, requestedBy string) (Receipt, error)
+
+	// Replaces the player ID by a pseudonym on every storage, returning the receipt of the anonymization
+	AnonymizePlayerFunc func(ctx context.Context, gameID, playerID, requestedBy string) (AnonymizationReceipt, error)
+
+	// Counts the player's records on every storage, what an erasure or an anonymization would affect, without changing them
+	PreviewPlayerDataFunc func(ctx context.Context, gameID, playerID string) (Preview, error)
+)
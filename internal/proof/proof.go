@@ -0,0 +1,281 @@
+package proof
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+)
+
+const (
+	MinTopN = 1    // Fewest top positions a rule can cover
+	MaxTopN = 1000 // Most top positions a rule can cover
+
+	// Longest proof reference accepted
+	MaxProofLength = 2048
+
+	// Reason the submissions landing on the top positions are held for review
+	ReasonProofRequired = "PROOF_REQUIRED"
+)
+
+var (
+	ErrValidationError      = errors.New("validation error")
+	ErrInvalidGameID        = errors.New("invalid game id")
+	ErrInvalidLeaderboardID = errors.New("invalid leaderboard id")
+	ErrInvalidTopN          = errors.New("top n must be between 1 and 1000")
+	ErrRuleNotFound         = errors.New("proof rule not found")
+	ErrProofRequired        = errors.New("the submission reaches the leaderboard's top positions and requires a proof")
+	ErrInvalidProof         = errors.New("the proof must have at most 2048 characters")
+)
+
+type (
+	// Top positions of a leaderboard that can only be reached with a proof approved by a moderator
+	Rule struct {
+		UpdatedAt     time.Time // Last time the rule was set
+		GameID        string    // Game the leaderboard belongs to
+		LeaderboardID string    // Leaderboard the rule applies to
+		TopN          int       // Top positions requiring a proof
+	}
+
+	// Rank of a ranking page, either applied or pending for its proof to be approved
+	Entry struct {
+		leaderboard.Rank
+		Pending bool // Whether the rank is a submission waiting for a moderator's approval
+	}
+)
+
+func (r Rule) validate() error {
+	errList := make([]error, 0)
+
+	if r.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if r.LeaderboardID == "" {
+		errList = append(errList, ErrInvalidLeaderboardID)
+	}
+
+	if r.TopN < MinTopN || r.TopN > MaxTopN {
+		errList = append(errList, ErrInvalidTopN)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Player's value on the leaderboard once the submission is applied
+func resulting(lb leaderboard.Leaderboard, current *float64, value float64) float64 {
+	if current == nil {
+		return value
+	}
+
+	switch lb.AggregationMode {
+	case leaderboard.AggregationModeInc:
+		return *current + value
+	case leaderboard.AggregationModeMax:
+		return max(*current, value)
+	case leaderboard.AggregationModeMin:
+		return min(*current, value)
+	}
+
+	return value
+}
+
+// Whether the value ranks ahead of the other on the leaderboard
+func better(lb leaderboard.Leaderboard, value, other float64) bool {
+	if lb.Ordering == leaderboard.OrderingAsc {
+		return value < other
+	}
+
+	return value > other
+}
+
+// Player's current value on the leaderboard, nil when the player isn't ranked
+func currentValue(ctx context.Context, storageGetPlayerRankFunc leaderboard.StorageGetPlayerRankFunc, lb leaderboard.Leaderboard, playerID string) (*float64, error) {
+	rank, err := storageGetPlayerRankFunc(ctx, lb, playerID)
+	switch {
+	case err == nil:
+		return &rank.Value, nil
+	case errors.Is(err, leaderboard.ErrPlayerRankNotFound):
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+// The leaderboard must belong to the game
+func BuildSetRuleFunc(getLeaderboardFunc leaderboard.GetByIDAndGameIDFunc, storageSetRuleFunc StorageSetRuleFunc) SetRuleFunc {
+	return func(ctx context.Context, rule Rule) (Rule, error) {
+		if err := rule.validate(); err != nil {
+			return Rule{}, err
+		}
+
+		if _, err := getLeaderboardFunc(ctx, rule.LeaderboardID, rule.GameID); err != nil {
+			return Rule{}, err
+		}
+
+		rule.UpdatedAt = time.Now().UTC()
+		return storageSetRuleFunc(ctx, rule)
+	}
+}
+
+func BuildGetRuleFunc(storageGetRuleFunc StorageGetRuleFunc) GetRuleFunc {
+	return func(ctx context.Context, gameID, leaderboardID string) (Rule, error) {
+		if leaderboardID == "" {
+			return Rule{}, ErrInvalidLeaderboardID
+		}
+
+		return storageGetRuleFunc(ctx, gameID, leaderboardID)
+	}
+}
+
+func BuildRemoveRuleFunc(storageDeleteRuleFunc StorageDeleteRuleFunc) RemoveRuleFunc {
+	return func(ctx context.Context, gameID, leaderboardID string) error {
+		if leaderboardID == "" {
+			return ErrInvalidLeaderboardID
+		}
+
+		return storageDeleteRuleFunc(ctx, gameID, leaderboardID)
+	}
+}
+
+// A submission lands on the top positions when the player's resulting value beats the one on the last of them, or when
+// they aren't all taken yet. A submission that leaves the player's value as it is never requires a proof, nor do the
+// ones to a closed leaderboard, left for the rank update to reject
+func BuildCheckFunc(
+	storageGetRuleFunc StorageGetRuleFunc,
+	storageGetPlayerRankFunc leaderboard.StorageGetPlayerRankFunc,
+	storageGetRankingFunc leaderboard.StorageGetRankingFunc,
+	holdFunc quarantine.HoldFunc,
+) CheckFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64, proof string) error {
+		if lb.Closed() {
+			return nil
+		}
+
+		rule, err := storageGetRuleFunc(ctx, lb.GameID, lb.ID)
+		if err != nil {
+			if errors.Is(err, ErrRuleNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		current, err := currentValue(ctx, storageGetPlayerRankFunc, lb, playerID)
+		if err != nil {
+			return err
+		}
+
+		result := resulting(lb, current, value)
+		if current != nil && result == *current {
+			return nil
+		}
+
+		// The last top position is the only rank of the page sized one at its index
+		last, err := storageGetRankingFunc(ctx, lb, int64(rule.TopN-1), 1)
+		if err != nil {
+			return err
+		}
+
+		if len(last) > 0 && !better(lb, result, last[0].Value) {
+			return nil
+		}
+
+		if proof == "" {
+			return ErrProofRequired
+		}
+
+		if len(proof) > MaxProofLength {
+			return ErrInvalidProof
+		}
+
+		if _, err := holdFunc(ctx, lb, playerID, value, ReasonProofRequired, proof); err != nil {
+			return err
+		}
+
+		return quarantine.ErrSubmissionQuarantined
+	}
+}
+
+// The pending submissions are placed on the page covering their resulting value, each taking the position of the first
+// rank it beats, after the ones it ties with, while the applied ranks keep theirs. The rank before the page bounds it
+// from above, so a value is placed on a single page. Only the oldest `quarantine.MaxLimit` held submissions are read
+func BuildMergePendingFunc(
+	storageListSubmissionsFunc quarantine.StorageListSubmissionsFunc,
+	storageGetPlayerRankFunc leaderboard.StorageGetPlayerRankFunc,
+	storageGetRankingFunc leaderboard.StorageGetRankingFunc,
+) MergePendingFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, ranks []leaderboard.Rank, page, limit int64) ([]Entry, error) {
+		entries := make([]Entry, len(ranks))
+		for i, r := range ranks {
+			entries[i] = Entry{Rank: r}
+		}
+
+		submissions, err := storageListSubmissionsFunc(ctx, lb.GameID, lb.ID, quarantine.MaxLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		var previous *leaderboard.Rank
+		if page > 0 {
+			before, err := storageGetRankingFunc(ctx, lb, page*limit-1, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(before) == 0 {
+				return entries, nil
+			}
+
+			previous = &before[0]
+		}
+
+		lastPage := int64(len(ranks)) < limit
+		for _, s := range submissions {
+			if s.Reason != ReasonProofRequired {
+				continue
+			}
+
+			current, err := currentValue(ctx, storageGetPlayerRankFunc, lb, s.PlayerID)
+			if err != nil {
+				return nil, err
+			}
+
+			value := resulting(lb, current, s.Value)
+			if previous != nil && !better(lb, previous.Value, value) {
+				continue
+			}
+
+			if !lastPage && better(lb, ranks[len(ranks)-1].Value, value) {
+				continue
+			}
+
+			var position int64
+			if previous != nil {
+				position = previous.Position + 1
+			}
+
+			i := slices.IndexFunc(entries, func(e Entry) bool { return better(lb, value, e.Value) })
+			switch {
+			case i >= 0:
+				position = entries[i].Position
+			case len(ranks) > 0:
+				position = ranks[len(ranks)-1].Position + 1
+				i = len(entries)
+			default:
+				i = len(entries)
+			}
+
+			entries = slices.Insert(entries, i, Entry{Rank: leaderboard.Rank{PlayerID: s.PlayerID, Position: position, Value: value}, Pending: true})
+		}
+
+		return entries, nil
+	}
+}
@@ -0,0 +1,240 @@
+package proof
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResulting(t *testing.T) {
+	var (
+		incLb   = leaderboard.Leaderboard{AggregationMode: leaderboard.AggregationModeInc}
+		maxLb   = leaderboard.Leaderboard{AggregationMode: leaderboard.AggregationModeMax}
+		minLb   = leaderboard.Leaderboard{AggregationMode: leaderboard.AggregationModeMin}
+		current = 100.0
+	)
+
+	assert.Equal(t, 110.0, resulting(incLb, &current, 10))
+	assert.Equal(t, 150.0, resulting(maxLb, &current, 150))
+	assert.Equal(t, 100.0, resulting(maxLb, &current, 50))
+	assert.Equal(t, 60.0, resulting(minLb, &current, 60))
+	assert.Equal(t, 60.0, resulting(minLb, nil, 60))
+}
+
+func TestBuildSetRuleFunc(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		getLeaderboard = func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			if id != "leaderboard" {
+				return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+			}
+
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		}
+		setRule = BuildSetRuleFunc(getLeaderboard, func(ctx context.Context, rule Rule) (Rule, error) {
+			return rule, nil
+		})
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		rule, err := setRule(ctx, Rule{GameID: "game", LeaderboardID: "leaderboard", TopN: 10})
+		assert.NoError(t, err)
+		assert.False(t, rule.UpdatedAt.IsZero())
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := setRule(ctx, Rule{GameID: "game", LeaderboardID: "leaderboard", TopN: MaxTopN + 1})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidTopN)
+	})
+
+	t.Run("Leaderboard Not Found", func(t *testing.T) {
+		_, err := setRule(ctx, Rule{GameID: "game", LeaderboardID: "other", TopN: 10})
+		assert.ErrorIs(t, err, leaderboard.ErrLeaderboardNotFound)
+	})
+}
+
+// Ranking sorted by the leaderboard's ordering, as the storages keep it
+type ranking []leaderboard.Rank
+
+func (r ranking) getRanking(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+	start, end := min(page*limit, int64(len(r))), min((page+1)*limit, int64(len(r)))
+	return r[start:end], nil
+}
+
+func (r ranking) getPlayerRank(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+	for _, rank := range r {
+		if rank.PlayerID == playerID {
+			return rank, nil
+		}
+	}
+
+	return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+}
+
+func TestBuildCheckFunc(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		lb    = leaderboard.Leaderboard{ID: "leaderboard", GameID: "game", AggregationMode: leaderboard.AggregationModeMax, Ordering: leaderboard.OrderingDesc}
+		ranks = ranking{
+			{PlayerID: "alice", Position: 1, Value: 300},
+			{PlayerID: "bob", Position: 2, Value: 200},
+			{PlayerID: "carol", Position: 3, Value: 100},
+		}
+		rule Rule
+		held []quarantine.Submission
+	)
+
+	check := BuildCheckFunc(
+		func(ctx context.Context, gameID, leaderboardID string) (Rule, error) {
+			if rule.TopN == 0 {
+				return Rule{}, ErrRuleNotFound
+			}
+
+			return rule, nil
+		},
+		ranks.getPlayerRank,
+		ranks.getRanking,
+		func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64, reason, proof string) (quarantine.Submission, error) {
+			s := quarantine.Submission{PlayerID: playerID, Value: value, Reason: reason, Proof: proof}
+			held = append(held, s)
+			return s, nil
+		},
+	)
+
+	t.Run("Without Rule", func(t *testing.T) {
+		rule, held = Rule{}, nil
+
+		err := check(ctx, lb, "dave", 1000, "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Outside The Top", func(t *testing.T) {
+		rule, held = Rule{TopN: 2}, nil
+
+		err := check(ctx, lb, "dave", 200, "")
+		assert.NoError(t, err)
+
+		err = check(ctx, lb, "alice", 250, "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Proof Required", func(t *testing.T) {
+		rule, held = Rule{TopN: 2}, nil
+
+		err := check(ctx, lb, "dave", 201, "")
+		assert.ErrorIs(t, err, ErrProofRequired)
+
+		err = check(ctx, lb, "carol", 250, "")
+		assert.ErrorIs(t, err, ErrProofRequired)
+		assert.Empty(t, held)
+	})
+
+	t.Run("Held With Proof", func(t *testing.T) {
+		rule, held = Rule{TopN: 2}, nil
+
+		err := check(ctx, lb, "dave", 201, "replay-1")
+		assert.ErrorIs(t, err, quarantine.ErrSubmissionQuarantined)
+		assert.Equal(t, []quarantine.Submission{{PlayerID: "dave", Value: 201, Reason: ReasonProofRequired, Proof: "replay-1"}}, held)
+	})
+
+	t.Run("Top Not Taken", func(t *testing.T) {
+		rule, held = Rule{TopN: 5}, nil
+
+		err := check(ctx, lb, "dave", 1, "")
+		assert.ErrorIs(t, err, ErrProofRequired)
+	})
+
+	t.Run("Invalid Proof", func(t *testing.T) {
+		rule, held = Rule{TopN: 2}, nil
+
+		err := check(ctx, lb, "dave", 201, string(make([]byte, MaxProofLength+1)))
+		assert.ErrorIs(t, err, ErrInvalidProof)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		storageErr := errors.New("any storage error")
+		check := BuildCheckFunc(
+			func(ctx context.Context, gameID, leaderboardID string) (Rule, error) {
+				return Rule{}, storageErr
+			},
+			nil, nil, nil,
+		)
+
+		err := check(ctx, lb, "dave", 201, "replay-1")
+		assert.ErrorIs(t, err, storageErr)
+	})
+}
+
+func TestBuildMergePendingFunc(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		lb    = leaderboard.Leaderboard{ID: "leaderboard", GameID: "game", AggregationMode: leaderboard.AggregationModeInc, Ordering: leaderboard.OrderingDesc}
+		ranks = ranking{
+			{PlayerID: "alice", Position: 0, Value: 400},
+			{PlayerID: "bob", Position: 1, Value: 300},
+			{PlayerID: "carol", Position: 2, Value: 200},
+			{PlayerID: "dave", Position: 3, Value: 100},
+		}
+		submissions = []quarantine.Submission{
+			{PlayerID: "erin", Value: 500, Reason: ReasonProofRequired},
+			{PlayerID: "dave", Value: 150, Reason: ReasonProofRequired},
+			{PlayerID: "frank", Value: 10_000, Reason: "MAX_SUBMISSION_DELTA"},
+		}
+	)
+
+	merge := BuildMergePendingFunc(
+		func(ctx context.Context, gameID, leaderboardID string, limit int) ([]quarantine.Submission, error) {
+			return submissions, nil
+		},
+		ranks.getPlayerRank,
+		ranks.getRanking,
+	)
+
+	t.Run("First Page", func(t *testing.T) {
+		page, _ := ranks.getRanking(ctx, lb, 0, 2)
+
+		entries, err := merge(ctx, lb, page, 0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []Entry{
+			{Rank: leaderboard.Rank{PlayerID: "erin", Position: 0, Value: 500}, Pending: true},
+			{Rank: leaderboard.Rank{PlayerID: "alice", Position: 0, Value: 400}},
+			{Rank: leaderboard.Rank{PlayerID: "bob", Position: 1, Value: 300}},
+		}, entries)
+	})
+
+	t.Run("Last Page", func(t *testing.T) {
+		page, _ := ranks.getRanking(ctx, lb, 1, 2)
+
+		entries, err := merge(ctx, lb, page, 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []Entry{
+			{Rank: leaderboard.Rank{PlayerID: "dave", Position: 2, Value: 250}, Pending: true},
+			{Rank: leaderboard.Rank{PlayerID: "carol", Position: 2, Value: 200}},
+			{Rank: leaderboard.Rank{PlayerID: "dave", Position: 3, Value: 100}},
+		}, entries)
+	})
+
+	t.Run("Empty Ranking", func(t *testing.T) {
+		entries, err := BuildMergePendingFunc(
+			func(ctx context.Context, gameID, leaderboardID string, limit int) ([]quarantine.Submission, error) {
+				return submissions[:1], nil
+			},
+			ranking{}.getPlayerRank,
+			ranking{}.getRanking,
+		)(ctx, lb, nil, 0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []Entry{{Rank: leaderboard.Rank{PlayerID: "erin", Position: 0, Value: 500}, Pending: true}}, entries)
+	})
+
+	t.Run("Past The Ranking", func(t *testing.T) {
+		entries, err := merge(ctx, lb, nil, 5, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
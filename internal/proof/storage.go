@@ -0,0 +1,14 @@
+package proof
+
+import "context"
+
+type (
+	// Stores the rule, replacing the one the leaderboard already has
+	StorageSetRuleFunc func(ctx context.Context, rule Rule) (Rule, error)
+
+	// Rule of a leaderboard of the game. Fails with `ErrRuleNotFound` when it has none
+	StorageGetRuleFunc func(ctx context.Context, gameID, leaderboardID string) (Rule, error)
+
+	// Removes the rule of a leaderboard of the game. Fails with `ErrRuleNotFound` when it has none
+	StorageDeleteRuleFunc func(ctx context.Context, gameID, leaderboardID string) error
+)
@@ -0,0 +1,26 @@
+package proof
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type (
+	// Sets the top positions of a leaderboard of the game requiring a proof, replacing its current rule
+	SetRuleFunc func(ctx context.Context, rule Rule) (Rule, error)
+
+	// Get the top positions of a leaderboard of the game requiring a proof
+	GetRuleFunc func(ctx context.Context, gameID, leaderboardID string) (Rule, error)
+
+	// Removes the proof requirement of a leaderboard of the game
+	RemoveRuleFunc func(ctx context.Context, gameID, leaderboardID string) error
+
+	// Checks the submission against the leaderboard's rule before it's applied. The ones landing on the top positions
+	// fail with `ErrProofRequired` without a proof, and are held for a moderator's approval with one, failing with
+	// `quarantine.ErrSubmissionQuarantined`
+	CheckFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64, proof string) error
+
+	// Merges the leaderboard's submissions waiting for their proof to be approved into the ranking page
+	MergePendingFunc func(ctx context.Context, lb leaderboard.Leaderboard, ranks []leaderboard.Rank, page, limit int64) ([]Entry, error)
+)
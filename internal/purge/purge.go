@@ -0,0 +1,181 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+	"github.com/gabapcia/gameblitz/internal/scheduler"
+)
+
+const (
+	EntityLeaderboard = "LEADERBOARD"
+	EntityStatistic   = "STATISTIC"
+	EntityQuest       = "QUEST"
+
+	// Method registered on the audit log for every record purged
+	AuditMethod = "PURGE"
+	// Actor registered on the audit log for every record purged
+	AuditActor = "system:purge"
+
+	// Name of the scheduled job purging the records past the retention period
+	JobName = "purge"
+)
+
+var (
+	ErrInvalidRetention = errors.New("invalid retention")
+	ErrMissingGameID    = errors.New("missing game id")
+)
+
+type Record struct {
+	DeletedAt time.Time // Time the record was soft deleted
+	Entity    string    // Kind of the entity purged
+	ID        string    // ID of the entity purged
+	GameID    string    // ID of the game that owned the entity
+}
+
+// Audit log route used for each kind of entity purged
+var auditRoutes = map[string]string{
+	EntityLeaderboard: "/api/v1/leaderboards/:leaderboardId",
+	EntityStatistic:   "/api/v1/statistics/:statisticId",
+	EntityQuest:       "/api/v1/quests/:questId",
+}
+
+// Audit log resource param used for each kind of entity purged
+var auditResourceParams = map[string]string{
+	EntityLeaderboard: "leaderboardId",
+	EntityStatistic:   "statisticId",
+	EntityQuest:       "questId",
+}
+
+func (r Record) auditEntry(purgedAt time.Time) audit.NewEntryData {
+	return audit.NewEntryData{
+		GameID:      r.GameID,
+		Actor:       AuditActor,
+		Method:      AuditMethod,
+		Route:       auditRoutes[r.Entity],
+		ResourceIDs: map[string]string{auditResourceParams[r.Entity]: r.ID},
+		RequestedAt: purgedAt,
+	}
+}
+
+// Removes the records of the game, or of every game when empty, soft deleted before the given time from every storage,
+// recording each one on the audit log. Every storage is purged even when one of them fails
+func purgeRecords(ctx context.Context, recordAuditEntryFunc audit.RecordFunc, storagePurgeFuncs []StoragePurgeFunc, gameID string, purgedAt, deletedBefore time.Time) ([]Record, error) {
+	var (
+		purged  = make([]Record, 0)
+		errList = make([]error, 0)
+	)
+
+	for _, storagePurgeFunc := range storagePurgeFuncs {
+		records, err := storagePurgeFunc(ctx, gameID, deletedBefore)
+		if err != nil {
+			errList = append(errList, err)
+			continue
+		}
+
+		for _, record := range records {
+			if _, err := recordAuditEntryFunc(ctx, record.auditEntry(purgedAt)); err != nil {
+				errList = append(errList, err)
+			}
+		}
+
+		purged = append(purged, records...)
+	}
+
+	return purged, errors.Join(errList...)
+}
+
+func BuildPurgeFunc(retention time.Duration, recordAuditEntryFunc audit.RecordFunc, storagePurgeFuncs ...StoragePurgeFunc) PurgeFunc {
+	return func(ctx context.Context) ([]Record, error) {
+		if retention <= 0 {
+			return nil, ErrInvalidRetention
+		}
+
+		purgedAt := time.Now().UTC()
+		return purgeRecords(ctx, recordAuditEntryFunc, storagePurgeFuncs, "", purgedAt, purgedAt.Add(-retention))
+	}
+}
+
+// Runs the purge on every interval
+func BuildPurgeJob(interval time.Duration, purgeFunc PurgeFunc) scheduler.Job {
+	return scheduler.Job{
+		Name:     JobName,
+		Interval: interval,
+		Func: func(ctx context.Context, run scheduler.Run) error {
+			_, err := purgeFunc(ctx)
+			return err
+		},
+	}
+}
+
+// Purge of a single game, triggered by an operator instead of waiting for the periodic one
+type Report struct {
+	GameID        string    // Game purged
+	DryRun        bool      // Whether the records were only listed, without removing them
+	DeletedBefore time.Time // Records soft deleted before it were purged
+	Records       []Record  // Records purged, or the ones that would be on a dry run
+}
+
+// Counts the records by the kind of the entity, e.g. `EntityLeaderboard`. Every kind is present, even without records
+func (r Report) Counts() map[string]int {
+	counts := map[string]int{EntityLeaderboard: 0, EntityStatistic: 0, EntityQuest: 0}
+	for _, record := range r.Records {
+		counts[record.Entity]++
+	}
+
+	return counts
+}
+
+// Same as the periodic purge, restricted to the game and with the retention picked on each call. The records purged
+// before a storage failure are reported alongside the error
+func BuildPurgeGameFunc(recordAuditEntryFunc audit.RecordFunc, storagePurgeFuncs ...StoragePurgeFunc) PurgeGameFunc {
+	return func(ctx context.Context, gameID string, retention time.Duration) (Report, error) {
+		if gameID == "" {
+			return Report{}, ErrMissingGameID
+		}
+
+		if retention <= 0 {
+			return Report{}, ErrInvalidRetention
+		}
+
+		purgedAt := time.Now().UTC()
+		report := Report{GameID: gameID, DeletedBefore: purgedAt.Add(-retention)}
+
+		records, err := purgeRecords(ctx, recordAuditEntryFunc, storagePurgeFuncs, gameID, purgedAt, report.DeletedBefore)
+		report.Records = records
+
+		return report, err
+	}
+}
+
+// Lists the records `BuildPurgeGameFunc` would purge, without removing nor recording anything
+func BuildPreviewPurgeGameFunc(storageListSoftDeletedFuncs ...StorageListSoftDeletedFunc) PurgeGameFunc {
+	return func(ctx context.Context, gameID string, retention time.Duration) (Report, error) {
+		if gameID == "" {
+			return Report{}, ErrMissingGameID
+		}
+
+		if retention <= 0 {
+			return Report{}, ErrInvalidRetention
+		}
+
+		var (
+			report  = Report{GameID: gameID, DryRun: true, DeletedBefore: time.Now().UTC().Add(-retention), Records: make([]Record, 0)}
+			errList = make([]error, 0)
+		)
+
+		for _, storageListSoftDeletedFunc := range storageListSoftDeletedFuncs {
+			records, err := storageListSoftDeletedFunc(ctx, gameID, report.DeletedBefore)
+			if err != nil {
+				errList = append(errList, err)
+				continue
+			}
+
+			report.Records = append(report.Records, records...)
+		}
+
+		return report, errors.Join(errList...)
+	}
+}
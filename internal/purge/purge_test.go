@@ -0,0 +1,227 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/audit"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPurgeFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		retention = 24 * time.Hour
+
+		leaderboardRecord = Record{
+			DeletedAt: time.Now().Add(-48 * time.Hour),
+			Entity:    EntityLeaderboard,
+			ID:        uuid.NewString(),
+			GameID:    uuid.NewString(),
+		}
+		questRecord = Record{
+			DeletedAt: time.Now().Add(-72 * time.Hour),
+			Entity:    EntityQuest,
+			ID:        uuid.NewString(),
+			GameID:    uuid.NewString(),
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			deletedBefore time.Time
+			recorded      = make([]audit.NewEntryData, 0)
+		)
+
+		purgeFunc := BuildPurgeFunc(
+			retention,
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				recorded = append(recorded, data)
+				return audit.Entry{}, nil
+			},
+			func(ctx context.Context, game string, before time.Time) ([]Record, error) {
+				assert.Empty(t, game)
+				deletedBefore = before
+				return []Record{leaderboardRecord}, nil
+			},
+			func(ctx context.Context, game string, before time.Time) ([]Record, error) {
+				return []Record{questRecord}, nil
+			},
+		)
+
+		purged, err := purgeFunc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []Record{leaderboardRecord, questRecord}, purged)
+		assert.WithinDuration(t, time.Now().Add(-retention), deletedBefore, time.Minute)
+
+		assert.Len(t, recorded, 2)
+		assert.Equal(t, leaderboardRecord.GameID, recorded[0].GameID)
+		assert.Equal(t, AuditMethod, recorded[0].Method)
+		assert.Equal(t, AuditActor, recorded[0].Actor)
+		assert.Equal(t, "/api/v1/leaderboards/:leaderboardId", recorded[0].Route)
+		assert.Equal(t, map[string]string{"leaderboardId": leaderboardRecord.ID}, recorded[0].ResourceIDs)
+		assert.Equal(t, map[string]string{"questId": questRecord.ID}, recorded[1].ResourceIDs)
+	})
+
+	t.Run("Invalid Retention", func(t *testing.T) {
+		purgeFunc := BuildPurgeFunc(
+			0,
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				return audit.Entry{}, nil
+			},
+		)
+
+		_, err := purgeFunc(ctx)
+		assert.ErrorIs(t, err, ErrInvalidRetention)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		errStorage := errors.New("any error")
+
+		purgeFunc := BuildPurgeFunc(
+			retention,
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				return audit.Entry{}, nil
+			},
+			func(ctx context.Context, game string, before time.Time) ([]Record, error) {
+				return nil, errStorage
+			},
+			func(ctx context.Context, game string, before time.Time) ([]Record, error) {
+				return []Record{questRecord}, nil
+			},
+		)
+
+		purged, err := purgeFunc(ctx)
+		assert.ErrorIs(t, err, errStorage)
+		assert.Equal(t, []Record{questRecord}, purged)
+	})
+
+	t.Run("Audit Error", func(t *testing.T) {
+		errAudit := errors.New("any error")
+
+		purgeFunc := BuildPurgeFunc(
+			retention,
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				return audit.Entry{}, errAudit
+			},
+			func(ctx context.Context, game string, before time.Time) ([]Record, error) {
+				return []Record{leaderboardRecord}, nil
+			},
+		)
+
+		purged, err := purgeFunc(ctx)
+		assert.ErrorIs(t, err, errAudit)
+		assert.Equal(t, []Record{leaderboardRecord}, purged)
+	})
+}
+
+func TestBuildPurgeGameFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		gameID    = uuid.NewString()
+		retention = 24 * time.Hour
+
+		leaderboardRecord = Record{
+			DeletedAt: time.Now().Add(-48 * time.Hour),
+			Entity:    EntityLeaderboard,
+			ID:        uuid.NewString(),
+			GameID:    gameID,
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		recorded := make([]audit.NewEntryData, 0)
+
+		purgeGameFunc := BuildPurgeGameFunc(
+			func(ctx context.Context, data audit.NewEntryData) (audit.Entry, error) {
+				recorded = append(recorded, data)
+				return audit.Entry{}, nil
+			},
+			func(ctx context.Context, game string, before time.Time) ([]Record, error) {
+				assert.Equal(t, gameID, game)
+				return []Record{leaderboardRecord}, nil
+			},
+		)
+
+		report, err := purgeGameFunc(ctx, gameID, retention)
+		assert.NoError(t, err)
+		assert.Equal(t, gameID, report.GameID)
+		assert.False(t, report.DryRun)
+		assert.WithinDuration(t, time.Now().Add(-retention), report.DeletedBefore, time.Minute)
+		assert.Equal(t, []Record{leaderboardRecord}, report.Records)
+		assert.Equal(t, map[string]int{EntityLeaderboard: 1, EntityStatistic: 0, EntityQuest: 0}, report.Counts())
+
+		if assert.Len(t, recorded, 1) {
+			assert.Equal(t, map[string]string{"leaderboardId": leaderboardRecord.ID}, recorded[0].ResourceIDs)
+		}
+	})
+
+	t.Run("Missing Game ID", func(t *testing.T) {
+		_, err := BuildPurgeGameFunc(nil)(ctx, "", retention)
+		assert.ErrorIs(t, err, ErrMissingGameID)
+	})
+
+	t.Run("Invalid Retention", func(t *testing.T) {
+		_, err := BuildPurgeGameFunc(nil)(ctx, gameID, -time.Hour)
+		assert.ErrorIs(t, err, ErrInvalidRetention)
+	})
+}
+
+func TestBuildPreviewPurgeGameFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		gameID    = uuid.NewString()
+		retention = 24 * time.Hour
+
+		questRecord = Record{
+			DeletedAt: time.Now().Add(-72 * time.Hour),
+			Entity:    EntityQuest,
+			ID:        uuid.NewString(),
+			GameID:    gameID,
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		previewPurgeGameFunc := BuildPreviewPurgeGameFunc(
+			func(ctx context.Context, game string, before time.Time) ([]Record, error) {
+				assert.Equal(t, gameID, game)
+				assert.WithinDuration(t, time.Now().Add(-retention), before, time.Minute)
+				return []Record{questRecord}, nil
+			},
+		)
+
+		report, err := previewPurgeGameFunc(ctx, gameID, retention)
+		assert.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, []Record{questRecord}, report.Records)
+		assert.Equal(t, map[string]int{EntityLeaderboard: 0, EntityStatistic: 0, EntityQuest: 1}, report.Counts())
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		var (
+			errStorage = errors.New("any error")
+			listed     = false
+		)
+
+		previewPurgeGameFunc := BuildPreviewPurgeGameFunc(
+			func(ctx context.Context, game string, before time.Time) ([]Record, error) {
+				return nil, errStorage
+			},
+			func(ctx context.Context, game string, before time.Time) ([]Record, error) {
+				listed = true
+				return []Record{questRecord}, nil
+			},
+		)
+
+		_, err := previewPurgeGameFunc(ctx, gameID, retention)
+		assert.ErrorIs(t, err, errStorage)
+		assert.True(t, listed)
+	})
+}
@@ -0,0 +1,15 @@
+package purge
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Permanently removes the records of the game soft deleted before the given time, returning the ones removed.
+	// Every game is purged when the game ID is empty
+	StoragePurgeFunc func(ctx context.Context, gameID string, deletedBefore time.Time) ([]Record, error)
+
+	// Lists the records of the game soft deleted before the given time, the ones its purge function would remove
+	StorageListSoftDeletedFunc func(ctx context.Context, gameID string, deletedBefore time.Time) ([]Record, error)
+)
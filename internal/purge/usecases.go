@@ -0,0 +1,14 @@
+package purge
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Permanently removes the records soft deleted for longer than the retention period
+	PurgeFunc func(ctx context.Context) ([]Record, error)
+
+	// Permanently removes the game's records soft deleted for longer than the given retention, reporting them
+	PurgeGameFunc func(ctx context.Context, gameID string, retention time.Duration) (Report, error)
+)
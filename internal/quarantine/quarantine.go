@@ -0,0 +1,109 @@
+package quarantine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+const (
+	MinLimit = 1   // Fewest submissions listed at once
+	MaxLimit = 100 // Most submissions listed at once
+)
+
+var (
+	ErrInvalidSubmissionID = errors.New("invalid quarantined submission id")
+	ErrInvalidLimit        = errors.New("limit must be between 1 and 100")
+	ErrSubmissionNotFound  = errors.New("quarantined submission not found")
+
+	// Returned instead of applying a submission held for review
+	ErrSubmissionQuarantined = errors.New("submission held for review")
+)
+
+// Rank submission held for review instead of applied, until a moderator releases or discards it
+type Submission struct {
+	HeldAt        time.Time // Time the submission was held
+	ID            string    // Submission ID, assigned by the storage
+	GameID        string    // Game the leaderboard belongs to
+	LeaderboardID string    // Leaderboard the value was submitted to
+	PlayerID      string    // Player who submitted the value
+	Value         float64   // Value submitted
+	Reason        string    // Why the submission was held, e.g. `MAX_SUBMISSION_DELTA`
+	Proof         string    // Proof reference attached to the submission, e.g. a replay ID or URL. Empty when it has none
+}
+
+func BuildHoldFunc(storageCreateSubmissionFunc StorageCreateSubmissionFunc) HoldFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64, reason, proof string) (Submission, error) {
+		return storageCreateSubmissionFunc(ctx, Submission{
+			HeldAt:        time.Now().UTC(),
+			GameID:        lb.GameID,
+			LeaderboardID: lb.ID,
+			PlayerID:      playerID,
+			Value:         value,
+			Reason:        reason,
+			Proof:         proof,
+		})
+	}
+}
+
+func BuildListFunc(storageListSubmissionsFunc StorageListSubmissionsFunc) ListFunc {
+	return func(ctx context.Context, gameID, leaderboardID string, limit int) ([]Submission, error) {
+		if limit < MinLimit || limit > MaxLimit {
+			return nil, ErrInvalidLimit
+		}
+
+		return storageListSubmissionsFunc(ctx, gameID, leaderboardID, limit)
+	}
+}
+
+// Applies the value past every check that held it, even when the leaderboard closed meanwhile, as it was submitted while
+// it was open. The submission is removed only once applied, so a failure leaves it on the queue to be released again.
+// The notifier can be nil when the rank changes are not published
+func BuildReleaseFunc(
+	getLeaderboardFunc leaderboard.GetByIDAndGameIDFunc,
+	notifierRankChange leaderboard.NotifierRankChange,
+	storageGetSubmissionFunc StorageGetSubmissionFunc,
+	storageDeleteSubmissionFunc StorageDeleteSubmissionFunc,
+	upsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc,
+) ReleaseFunc {
+	return func(ctx context.Context, gameID, id string) (Submission, error) {
+		if id == "" {
+			return Submission{}, ErrInvalidSubmissionID
+		}
+
+		s, err := storageGetSubmissionFunc(ctx, gameID, id)
+		if err != nil {
+			return Submission{}, err
+		}
+
+		lb, err := getLeaderboardFunc(ctx, s.LeaderboardID, s.GameID)
+		if err != nil {
+			return Submission{}, err
+		}
+
+		if err := upsertPlayerRankValueFunc(ctx, lb, s.PlayerID, s.Value); err != nil {
+			return Submission{}, err
+		}
+
+		if notifierRankChange != nil {
+			change := leaderboard.RankChange{LeaderboardID: lb.ID, GameID: lb.GameID, PlayerID: s.PlayerID, Value: s.Value}
+			if err := notifierRankChange(ctx, change); err != nil {
+				return Submission{}, err
+			}
+		}
+
+		return s, storageDeleteSubmissionFunc(ctx, gameID, id)
+	}
+}
+
+func BuildDiscardFunc(storageDeleteSubmissionFunc StorageDeleteSubmissionFunc) DiscardFunc {
+	return func(ctx context.Context, gameID, id string) error {
+		if id == "" {
+			return ErrInvalidSubmissionID
+		}
+
+		return storageDeleteSubmissionFunc(ctx, gameID, id)
+	}
+}
@@ -0,0 +1,126 @@
+package quarantine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildHoldFunc(t *testing.T) {
+	hold := BuildHoldFunc(func(ctx context.Context, s Submission) (Submission, error) {
+		s.ID = "submission"
+		return s, nil
+	})
+
+	s, err := hold(context.Background(), leaderboard.Leaderboard{ID: "leaderboard", GameID: "game"}, "alice", 100, "PROOF_REQUIRED", "replay-1")
+	assert.NoError(t, err)
+	assert.False(t, s.HeldAt.IsZero())
+	assert.Equal(t, Submission{HeldAt: s.HeldAt, ID: "submission", GameID: "game", LeaderboardID: "leaderboard", PlayerID: "alice", Value: 100, Reason: "PROOF_REQUIRED", Proof: "replay-1"}, s)
+}
+
+func TestBuildListFunc(t *testing.T) {
+	list := BuildListFunc(func(ctx context.Context, gameID, leaderboardID string, limit int) ([]Submission, error) {
+		return []Submission{{GameID: gameID, LeaderboardID: leaderboardID}}, nil
+	})
+
+	submissions, err := list(context.Background(), "game", "leaderboard", 10)
+	assert.NoError(t, err)
+	assert.Len(t, submissions, 1)
+
+	_, err = list(context.Background(), "game", "", MaxLimit+1)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
+
+func TestBuildReleaseFunc(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		queue    map[string]Submission
+		applied  []float64
+		notified []leaderboard.RankChange
+		getLb    = func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			if id != "leaderboard" {
+				return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+			}
+
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		}
+	)
+
+	reset := func() {
+		queue = map[string]Submission{
+			"held":    {ID: "held", GameID: "game", LeaderboardID: "leaderboard", PlayerID: "alice", Value: 100},
+			"deleted": {ID: "deleted", GameID: "game", LeaderboardID: "other", PlayerID: "alice", Value: 100},
+		}
+		applied = nil
+		notified = nil
+	}
+
+	release := BuildReleaseFunc(
+		getLb,
+		func(ctx context.Context, change leaderboard.RankChange) error {
+			notified = append(notified, change)
+			return nil
+		},
+		func(ctx context.Context, gameID, id string) (Submission, error) {
+			s, ok := queue[id]
+			if !ok {
+				return Submission{}, ErrSubmissionNotFound
+			}
+
+			return s, nil
+		},
+		func(ctx context.Context, gameID, id string) error {
+			delete(queue, id)
+			return nil
+		},
+		func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			applied = append(applied, value)
+			return nil
+		},
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		reset()
+
+		s, err := release(ctx, "game", "held")
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", s.PlayerID)
+		assert.Equal(t, []float64{100}, applied)
+		assert.Equal(t, []leaderboard.RankChange{{LeaderboardID: "leaderboard", GameID: "game", PlayerID: "alice", Value: 100}}, notified)
+		assert.NotContains(t, queue, "held")
+	})
+
+	t.Run("Leaderboard Deleted", func(t *testing.T) {
+		reset()
+
+		_, err := release(ctx, "game", "deleted")
+		assert.ErrorIs(t, err, leaderboard.ErrLeaderboardNotFound)
+		assert.Contains(t, queue, "deleted")
+		assert.Empty(t, applied)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		reset()
+
+		_, err := release(ctx, "game", "unknown")
+		assert.ErrorIs(t, err, ErrSubmissionNotFound)
+
+		_, err = release(ctx, "game", "")
+		assert.ErrorIs(t, err, ErrInvalidSubmissionID)
+	})
+}
+
+func TestBuildDiscardFunc(t *testing.T) {
+	discard := BuildDiscardFunc(func(ctx context.Context, gameID, id string) error {
+		return ErrSubmissionNotFound
+	})
+
+	err := discard(context.Background(), "game", "submission")
+	assert.ErrorIs(t, err, ErrSubmissionNotFound)
+
+	err = discard(context.Background(), "game", "")
+	assert.ErrorIs(t, err, ErrInvalidSubmissionID)
+}
@@ -0,0 +1,17 @@
+package quarantine
+
+import "context"
+
+type (
+	// Stores the submission, returning it with its ID
+	StorageCreateSubmissionFunc func(ctx context.Context, s Submission) (Submission, error)
+
+	// Submission of the game by its ID. Fails with `ErrSubmissionNotFound` when there's none
+	StorageGetSubmissionFunc func(ctx context.Context, gameID, id string) (Submission, error)
+
+	// Lists up to `limit` submissions of the game, oldest first. Only the leaderboard's ones unless its ID is empty
+	StorageListSubmissionsFunc func(ctx context.Context, gameID, leaderboardID string, limit int) ([]Submission, error)
+
+	// Removes the submission of the game. Fails with `ErrSubmissionNotFound` when there's none
+	StorageDeleteSubmissionFunc func(ctx context.Context, gameID, id string) error
+)
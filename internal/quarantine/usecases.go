@@ -0,0 +1,21 @@
+package quarantine
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type (
+	// Holds the player's submission for review, with the reason it was held and the proof attached to it, if any
+	HoldFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64, reason, proof string) (Submission, error)
+
+	// Lists up to `limit` submissions of the game held for review, oldest first. Only the leaderboard's ones unless its ID is empty
+	ListFunc func(ctx context.Context, gameID, leaderboardID string, limit int) ([]Submission, error)
+
+	// Applies the held submission to the leaderboard and removes it from the queue, returning it
+	ReleaseFunc func(ctx context.Context, gameID, id string) (Submission, error)
+
+	// Removes the held submission from the queue without applying it
+	DiscardFunc func(ctx context.Context, gameID, id string) error
+)
@@ -1,8 +1,56 @@
 package quest
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+const (
+	EventQuestStarted   = "QUEST_STARTED"
+	EventTaskCompleted  = "TASK_COMPLETED"
+	EventQuestCompleted = "QUEST_COMPLETED"
+	EventQuestExpired   = "QUEST_EXPIRED"
+)
 
 type (
+	// Player quest lifecycle change
+	LifecycleEvent struct {
+		OccurredAt time.Time // Time the event happened
+		Event      string    // Event kind, e.g. `EventQuestStarted`
+		GameID     string    // ID of the game responsible for the quest
+		PlayerID   string    // Player's ID
+		QuestID    string    // Quest ID
+		TaskID     string    // Task completed. Only set on `EventTaskCompleted`
+	}
+
 	// Notify player progression updates
 	NotifierPlayerProgressionUpdates func(ctx context.Context, progression PlayerQuestProgression) error
+
+	// Notify a player quest lifecycle event
+	NotifierLifecycleEvent func(ctx context.Context, event LifecycleEvent) error
 )
+
+func newLifecycleEvent(event string, progression PlayerQuestProgression, taskID string) LifecycleEvent {
+	return LifecycleEvent{
+		OccurredAt: time.Now().UTC(),
+		Event:      event,
+		GameID:     progression.Quest.GameID,
+		PlayerID:   progression.PlayerID,
+		QuestID:    progression.Quest.ID,
+		TaskID:     taskID,
+	}
+}
+
+// Events for the tasks completed by the update, followed by the quest completion when the update concluded it
+func progressionLifecycleEvents(progression PlayerQuestProgression, tasksCompleted []string) []LifecycleEvent {
+	events := make([]LifecycleEvent, 0, len(tasksCompleted)+1)
+	for _, taskID := range tasksCompleted {
+		events = append(events, newLifecycleEvent(EventTaskCompleted, progression, taskID))
+	}
+
+	if !progression.CompletedAt.IsZero() {
+		events = append(events, newLifecycleEvent(EventQuestCompleted, progression, ""))
+	}
+
+	return events
+}
@@ -50,9 +50,23 @@ func (p PlayerQuestProgression) applyRuleToActiveTasks(data string) ([]string, e
 	return tasksCompleted, nil
 }
 
-func BuildStartQuestForPlayerFunc(storageStartQuestForPlayerFunc StorageStartQuestForPlayerFunc) StartQuestForPlayerFunc {
+// The notifier can be nil when the lifecycle events are not published
+func BuildStartQuestForPlayerFunc(notifierLifecycleEvent NotifierLifecycleEvent, storageStartQuestForPlayerFunc StorageStartQuestForPlayerFunc) StartQuestForPlayerFunc {
 	return func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
-		return storageStartQuestForPlayerFunc(ctx, quest, playerID)
+		if quest.Expired() {
+			return PlayerQuestProgression{}, ErrQuestExpired
+		}
+
+		progression, err := storageStartQuestForPlayerFunc(ctx, quest, playerID)
+		if err != nil {
+			return PlayerQuestProgression{}, err
+		}
+
+		if notifierLifecycleEvent != nil {
+			return progression, notifierLifecycleEvent(ctx, newLifecycleEvent(EventQuestStarted, progression, ""))
+		}
+
+		return progression, nil
 	}
 }
 
@@ -62,12 +76,19 @@ func BuildGetPlayerQuestProgression(storageGetPlayerQuestProgressionFunc Storage
 	}
 }
 
+// The updates notifier can be nil when the storage already records the updates to be published, e.g. on an outbox,
+// and the lifecycle notifier when the lifecycle events are not published
 func BuildUpdatePlayerQuestProgressionFunc(
+	notifierLifecycleEvent NotifierLifecycleEvent,
 	notifierPlayerProgressionUpdates NotifierPlayerProgressionUpdates,
 	storageGetPlayerQuestProgressionFunc StorageGetPlayerQuestProgressionFunc,
 	storageUpdatePlayerQuestProgressionFunc StorageUpdatePlayerQuestProgressionFunc,
 ) UpdatePlayerQuestProgressionFunc {
 	return func(ctx context.Context, quest Quest, playerID, taskDataToCheck string) (PlayerQuestProgression, error) {
+		if quest.Expired() {
+			return PlayerQuestProgression{}, ErrQuestExpired
+		}
+
 		previousProgression, err := storageGetPlayerQuestProgressionFunc(ctx, quest, playerID)
 		if err != nil {
 			return PlayerQuestProgression{}, err
@@ -91,8 +112,18 @@ func BuildUpdatePlayerQuestProgressionFunc(
 			return PlayerQuestProgression{}, err
 		}
 
-		if err = notifierPlayerProgressionUpdates(ctx, playerProgression); err != nil {
-			return PlayerQuestProgression{}, err
+		if notifierPlayerProgressionUpdates != nil {
+			if err = notifierPlayerProgressionUpdates(ctx, playerProgression); err != nil {
+				return PlayerQuestProgression{}, err
+			}
+		}
+
+		if notifierLifecycleEvent != nil {
+			for _, event := range progressionLifecycleEvents(playerProgression, tasksCompleted) {
+				if err := notifierLifecycleEvent(ctx, event); err != nil {
+					return PlayerQuestProgression{}, err
+				}
+			}
 		}
 
 		return playerProgression, nil
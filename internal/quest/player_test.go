@@ -60,7 +60,7 @@ func TestBuildStartQuestForPlayerFunc(t *testing.T) {
 	)
 
 	t.Run("OK", func(t *testing.T) {
-		startQuestForPlayerFunc := BuildStartQuestForPlayerFunc(func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
+		startQuestForPlayerFunc := BuildStartQuestForPlayerFunc(nil, func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
 			return PlayerQuestProgression{Quest: quest, PlayerID: playerID}, nil
 		})
 
@@ -71,8 +71,30 @@ func TestBuildStartQuestForPlayerFunc(t *testing.T) {
 		assert.Equal(t, quest.ID, playerProgression.Quest.ID)
 	})
 
+	t.Run("OK With Lifecycle Notifier", func(t *testing.T) {
+		var notified LifecycleEvent
+
+		startQuestForPlayerFunc := BuildStartQuestForPlayerFunc(
+			func(ctx context.Context, event LifecycleEvent) error {
+				notified = event
+				return nil
+			},
+			func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
+				return PlayerQuestProgression{Quest: quest, PlayerID: playerID}, nil
+			},
+		)
+
+		_, err := startQuestForPlayerFunc(ctx, quest, playerID)
+		assert.NoError(t, err)
+
+		assert.Equal(t, EventQuestStarted, notified.Event)
+		assert.Equal(t, playerID, notified.PlayerID)
+		assert.Equal(t, quest.ID, notified.QuestID)
+		assert.Empty(t, notified.TaskID)
+	})
+
 	t.Run("Quest Already Started For Player", func(t *testing.T) {
-		startQuestForPlayerFunc := BuildStartQuestForPlayerFunc(func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
+		startQuestForPlayerFunc := BuildStartQuestForPlayerFunc(nil, func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
 			return PlayerQuestProgression{}, ErrPlayerAlreadyStartedTheQuest
 		})
 
@@ -81,7 +103,7 @@ func TestBuildStartQuestForPlayerFunc(t *testing.T) {
 	})
 
 	t.Run("Quest Not Found", func(t *testing.T) {
-		startQuestForPlayerFunc := BuildStartQuestForPlayerFunc(func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
+		startQuestForPlayerFunc := BuildStartQuestForPlayerFunc(nil, func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
 			return PlayerQuestProgression{}, ErrQuestNotFound
 		})
 
@@ -90,13 +112,23 @@ func TestBuildStartQuestForPlayerFunc(t *testing.T) {
 	})
 
 	t.Run("Random Error", func(t *testing.T) {
-		startQuestForPlayerFunc := BuildStartQuestForPlayerFunc(func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
+		startQuestForPlayerFunc := BuildStartQuestForPlayerFunc(nil, func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
 			return PlayerQuestProgression{}, errors.New("ant error")
 		})
 
 		_, err := startQuestForPlayerFunc(ctx, quest, playerID)
 		assert.Error(t, err)
 	})
+
+	t.Run("Quest Expired", func(t *testing.T) {
+		startQuestForPlayerFunc := BuildStartQuestForPlayerFunc(nil, func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
+			t.Fail()
+			return PlayerQuestProgression{}, nil
+		})
+
+		_, err := startQuestForPlayerFunc(ctx, Quest{ID: quest.ID, EndAt: time.Now().Add(-time.Minute)}, playerID)
+		assert.ErrorIs(t, err, ErrQuestExpired)
+	})
 }
 
 func TestBuildGetPlayerQuestProgression(t *testing.T) {
@@ -155,6 +187,7 @@ func TestBuildUpdatePlayerQuestProgressionFunc(t *testing.T) {
 		}
 
 		updatePlayerQuestProgressionFunc := BuildUpdatePlayerQuestProgressionFunc(
+			nil,
 			func(ctx context.Context, progression PlayerQuestProgression) error {
 				return nil
 			},
@@ -195,6 +228,39 @@ func TestBuildUpdatePlayerQuestProgressionFunc(t *testing.T) {
 		}
 	})
 
+	t.Run("All Tasks Completed Without Notifier", func(t *testing.T) {
+		quest := Quest{
+			ID:     uuid.NewString(),
+			GameID: uuid.NewString(),
+			Tasks: []Task{
+				{ID: uuid.NewString(), Rule: `{"==": [{"var": "fields.bool"}, true]}`},
+			},
+		}
+
+		updatePlayerQuestProgressionFunc := BuildUpdatePlayerQuestProgressionFunc(
+			nil,
+			nil,
+			func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
+				return PlayerQuestProgression{
+					PlayerID:         playerID,
+					Quest:            quest,
+					TasksProgression: []PlayerTaskProgression{{Task: quest.Tasks[0]}},
+				}, nil
+			},
+			func(ctx context.Context, quest Quest, tasksCompleted []string, playerID string) (PlayerQuestProgression, error) {
+				return PlayerQuestProgression{
+					PlayerID:         playerID,
+					Quest:            quest,
+					TasksProgression: []PlayerTaskProgression{{Task: quest.Tasks[0], CompletedAt: time.Now()}},
+				}, nil
+			},
+		)
+
+		progression, err := updatePlayerQuestProgressionFunc(ctx, quest, playerID, `{"fields": {"bool": true}}`)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, progression.TasksProgression[0].CompletedAt)
+	})
+
 	t.Run("Nothing Completed", func(t *testing.T) {
 		quest := Quest{
 			ID:     uuid.NewString(),
@@ -205,6 +271,7 @@ func TestBuildUpdatePlayerQuestProgressionFunc(t *testing.T) {
 		}
 
 		updatePlayerQuestProgressionFunc := BuildUpdatePlayerQuestProgressionFunc(
+			nil,
 			nil,
 			func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
 				progression := make([]PlayerTaskProgression, len(quest.Tasks))
@@ -242,6 +309,7 @@ func TestBuildUpdatePlayerQuestProgressionFunc(t *testing.T) {
 		}
 
 		updatePlayerQuestProgressionFunc := BuildUpdatePlayerQuestProgressionFunc(
+			nil,
 			nil,
 			func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
 				return PlayerQuestProgression{}, errors.New("any error")
@@ -266,6 +334,7 @@ func TestBuildUpdatePlayerQuestProgressionFunc(t *testing.T) {
 		}
 
 		updatePlayerQuestProgressionFunc := BuildUpdatePlayerQuestProgressionFunc(
+			nil,
 			nil,
 			func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
 				progression := make([]PlayerTaskProgression, len(quest.Tasks))
@@ -301,6 +370,7 @@ func TestBuildUpdatePlayerQuestProgressionFunc(t *testing.T) {
 		}
 
 		updatePlayerQuestProgressionFunc := BuildUpdatePlayerQuestProgressionFunc(
+			nil,
 			func(ctx context.Context, progression PlayerQuestProgression) error {
 				return errors.New("any error")
 			},
@@ -336,4 +406,73 @@ func TestBuildUpdatePlayerQuestProgressionFunc(t *testing.T) {
 		assert.Empty(t, progression.PlayerID)
 		assert.Empty(t, progression.Quest.ID)
 	})
+	t.Run("Quest Expired", func(t *testing.T) {
+		quest := Quest{
+			ID:     uuid.NewString(),
+			GameID: uuid.NewString(),
+			Tasks: []Task{
+				{ID: uuid.NewString(), Rule: `{"==": [{"var": "fields.bool"}, true]}`},
+			},
+			EndAt: time.Now().Add(-time.Minute),
+		}
+
+		updatePlayerQuestProgressionFunc := BuildUpdatePlayerQuestProgressionFunc(
+			nil,
+			nil,
+			func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
+				t.Fail()
+				return PlayerQuestProgression{}, nil
+			},
+			nil,
+		)
+
+		_, err := updatePlayerQuestProgressionFunc(ctx, quest, playerID, `{"fields": {"bool": true}}`)
+		assert.ErrorIs(t, err, ErrQuestExpired)
+	})
+
+	t.Run("Lifecycle Events", func(t *testing.T) {
+		quest := Quest{
+			ID:     uuid.NewString(),
+			GameID: uuid.NewString(),
+			Tasks: []Task{
+				{ID: uuid.NewString(), Rule: `{"==": [{"var": "fields.bool"}, true]}`},
+				{ID: uuid.NewString(), Rule: `{"==": [{"var": "fields.bool"}, true]}`},
+			},
+		}
+
+		notified := make([]LifecycleEvent, 0)
+
+		updatePlayerQuestProgressionFunc := BuildUpdatePlayerQuestProgressionFunc(
+			func(ctx context.Context, event LifecycleEvent) error {
+				notified = append(notified, event)
+				return nil
+			},
+			nil,
+			func(ctx context.Context, quest Quest, playerID string) (PlayerQuestProgression, error) {
+				return PlayerQuestProgression{
+					PlayerID:         playerID,
+					Quest:            quest,
+					TasksProgression: []PlayerTaskProgression{{Task: quest.Tasks[0]}, {Task: quest.Tasks[1]}},
+				}, nil
+			},
+			func(ctx context.Context, quest Quest, tasksCompleted []string, playerID string) (PlayerQuestProgression, error) {
+				return PlayerQuestProgression{PlayerID: playerID, Quest: quest, CompletedAt: time.Now()}, nil
+			},
+		)
+
+		_, err := updatePlayerQuestProgressionFunc(ctx, quest, playerID, `{"fields": {"bool": true}}`)
+		assert.NoError(t, err)
+
+		assert.Len(t, notified, 3)
+		assert.Equal(t, EventTaskCompleted, notified[0].Event)
+		assert.Equal(t, quest.Tasks[0].ID, notified[0].TaskID)
+		assert.Equal(t, EventTaskCompleted, notified[1].Event)
+		assert.Equal(t, quest.Tasks[1].ID, notified[1].TaskID)
+		assert.Equal(t, EventQuestCompleted, notified[2].Event)
+		for _, event := range notified {
+			assert.Equal(t, quest.GameID, event.GameID)
+			assert.Equal(t, quest.ID, event.QuestID)
+			assert.Equal(t, playerID, event.PlayerID)
+		}
+	})
 }
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"slices"
 	"time"
+
+	"github.com/gabapcia/gameblitz/internal/scheduler"
 )
 
 var (
@@ -16,14 +18,19 @@ var (
 	ErrInvalidQuestID                     = errors.New("invalid quest id")
 	ErrQuestNotFound                      = errors.New("quest not found")
 	ErrQuestWithoutTasks                  = errors.New("a quest task list must not be empty")
+	ErrQuestEndDateInThePast              = errors.New("quest end date must be in the future")
+	ErrQuestExpired                       = errors.New("quest expired")
 )
 
+const ExpiryJobName = "quest-expiry"
+
 type NewQuestData struct {
 	GameID          string        // ID of the game responsible for the quest
 	Name            string        // Quest name
 	Description     string        // Quest details
 	Tasks           []NewTaskData // Quest task list
 	TasksValidators []string      // Quest task list success validation data
+	EndAt           time.Time     // Time that the quest expires for the players that didn't complete it. Zero means it never expires
 }
 
 type Quest struct {
@@ -35,6 +42,7 @@ type Quest struct {
 	Name        string    // Quest name
 	Description string    // Quest details
 	Tasks       []Task    // Quest task list
+	EndAt       time.Time // Time that the quest expires for the players that didn't complete it. Zero means it never expires
 }
 
 func (q NewQuestData) validate() error {
@@ -48,6 +56,10 @@ func (q NewQuestData) validate() error {
 		errList = append(errList, ErrQuestMissingGameID)
 	}
 
+	if !q.EndAt.IsZero() && !q.EndAt.After(time.Now()) {
+		errList = append(errList, ErrQuestEndDateInThePast)
+	}
+
 	if len(q.Tasks) == 0 {
 		errList = append(errList, ErrQuestWithoutTasks)
 	} else if len(q.Tasks) != len(q.TasksValidators) {
@@ -81,6 +93,15 @@ func (q NewQuestData) validate() error {
 	return errors.Join(errList...)
 }
 
+func (q Quest) Expired() bool {
+	return !q.EndAt.IsZero() && !time.Now().Before(q.EndAt)
+}
+
+// Checks if the time is within the `(from, to]` interval
+func within(t, from, to time.Time) bool {
+	return !t.IsZero() && t.After(from) && !t.After(to)
+}
+
 func BuildCreateQuestFunc(storageCreateQuestFunc StorageCreateQuestFunc) CreateQuestFunc {
 	return func(ctx context.Context, data NewQuestData) (Quest, error) {
 		if err := data.validate(); err != nil {
@@ -102,3 +123,54 @@ func BuildSoftDeleteQuestFunc(storageSoftDeleteQuestFunc StorageSoftDeleteQuestF
 		return storageSoftDeleteQuestFunc(ctx, questID, gameID)
 	}
 }
+
+// Quests expire on their end date, without any request, so the players that started them and didn't complete them
+// are notified by checking the quests that ended within the interval
+func BuildNotifyExpiredFunc(
+	notifierLifecycleEvent NotifierLifecycleEvent,
+	storageListQuestsEndedBetweenFunc StorageListQuestsEndedBetweenFunc,
+	storageListPlayersWithQuestUnfinishedFunc StorageListPlayersWithQuestUnfinishedFunc,
+) NotifyExpiredFunc {
+	return func(ctx context.Context, from, to time.Time) error {
+		quests, err := storageListQuestsEndedBetweenFunc(ctx, from, to)
+		if err != nil {
+			return err
+		}
+
+		for _, quest := range quests {
+			if !within(quest.EndAt, from, to) {
+				continue
+			}
+
+			playerIDs, err := storageListPlayersWithQuestUnfinishedFunc(ctx, quest)
+			if err != nil {
+				return err
+			}
+
+			for _, playerID := range playerIDs {
+				event := newLifecycleEvent(EventQuestExpired, PlayerQuestProgression{PlayerID: playerID, Quest: quest}, "")
+				if err := notifierLifecycleEvent(ctx, event); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// Notifies the quests expired since the job's last run, on any instance. The first run only marks
+// the start, as the quests expired before it are not notified
+func BuildNotifyExpiredJob(interval time.Duration, notifyExpiredFunc NotifyExpiredFunc) scheduler.Job {
+	return scheduler.Job{
+		Name:     ExpiryJobName,
+		Interval: interval,
+		Func: func(ctx context.Context, run scheduler.Run) error {
+			if run.LastRunAt.IsZero() {
+				return nil
+			}
+
+			return notifyExpiredFunc(ctx, run.LastRunAt, run.StartedAt)
+		},
+	}
+}
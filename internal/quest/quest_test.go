@@ -1,7 +1,12 @@
 package quest
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/scheduler"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -76,4 +81,150 @@ func TestQuestValidate(t *testing.T) {
 		// Task errors
 		assert.NotErrorIs(t, err, ErrTaskValidationError)
 	})
+
+	t.Run("End Date In The Past", func(t *testing.T) {
+		quest := NewQuestData{
+			GameID: uuid.NewString(),
+			Name:   "Test Quest",
+			Tasks: []NewTaskData{
+				{Name: "Test Task", Rule: `{">": [{"var": "killed.terrorists"}, 150]}`},
+			},
+			TasksValidators: []string{
+				`{"killed": {"terrorists": 200}}`,
+			},
+			EndAt: time.Now().Add(-time.Minute),
+		}
+
+		err := quest.validate()
+		assert.ErrorIs(t, err, ErrQuestValidationError)
+		assert.ErrorIs(t, err, ErrQuestEndDateInThePast)
+	})
+}
+
+func TestQuestExpired(t *testing.T) {
+	assert.False(t, Quest{}.Expired())
+	assert.False(t, Quest{EndAt: time.Now().Add(time.Minute)}.Expired())
+	assert.True(t, Quest{EndAt: time.Now().Add(-time.Minute)}.Expired())
+}
+
+func TestBuildNotifyExpiredFunc(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		to   = time.Now()
+		from = to.Add(-time.Minute)
+
+		expired = Quest{ID: uuid.NewString(), GameID: uuid.NewString(), EndAt: to.Add(-time.Second)}
+		// Listed by the storage, but ending before the interval
+		outside = Quest{ID: uuid.NewString(), GameID: uuid.NewString(), EndAt: from}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		notified := make([]LifecycleEvent, 0)
+
+		notifyExpiredFunc := BuildNotifyExpiredFunc(
+			func(ctx context.Context, event LifecycleEvent) error {
+				notified = append(notified, event)
+				return nil
+			},
+			func(ctx context.Context, endedFrom, endedTo time.Time) ([]Quest, error) {
+				assert.Equal(t, from, endedFrom)
+				assert.Equal(t, to, endedTo)
+				return []Quest{expired, outside}, nil
+			},
+			func(ctx context.Context, quest Quest) ([]string, error) {
+				assert.Equal(t, expired.ID, quest.ID)
+				return []string{"alice", "bob"}, nil
+			},
+		)
+
+		err := notifyExpiredFunc(ctx, from, to)
+		assert.NoError(t, err)
+
+		if assert.Len(t, notified, 2) {
+			assert.Equal(t, "alice", notified[0].PlayerID)
+			assert.Equal(t, "bob", notified[1].PlayerID)
+		}
+		for _, event := range notified {
+			assert.Equal(t, EventQuestExpired, event.Event)
+			assert.Equal(t, expired.GameID, event.GameID)
+			assert.Equal(t, expired.ID, event.QuestID)
+			assert.Empty(t, event.TaskID)
+		}
+	})
+
+	t.Run("List Quests Error", func(t *testing.T) {
+		notifyExpiredFunc := BuildNotifyExpiredFunc(nil, func(ctx context.Context, from, to time.Time) ([]Quest, error) {
+			return nil, errors.New("any error")
+		}, nil)
+
+		err := notifyExpiredFunc(ctx, from, to)
+		assert.Error(t, err)
+	})
+
+	t.Run("List Players Error", func(t *testing.T) {
+		notifyExpiredFunc := BuildNotifyExpiredFunc(
+			nil,
+			func(ctx context.Context, from, to time.Time) ([]Quest, error) {
+				return []Quest{expired}, nil
+			},
+			func(ctx context.Context, quest Quest) ([]string, error) {
+				return nil, errors.New("any error")
+			},
+		)
+
+		err := notifyExpiredFunc(ctx, from, to)
+		assert.Error(t, err)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		notifyExpiredFunc := BuildNotifyExpiredFunc(
+			func(ctx context.Context, event LifecycleEvent) error {
+				return errors.New("any error")
+			},
+			func(ctx context.Context, from, to time.Time) ([]Quest, error) {
+				return []Quest{expired}, nil
+			},
+			func(ctx context.Context, quest Quest) ([]string, error) {
+				return []string{"alice"}, nil
+			},
+		)
+
+		err := notifyExpiredFunc(ctx, from, to)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildNotifyExpiredJob(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		startAt = time.Now()
+		lastRun = startAt.Add(-time.Minute)
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var calls int
+		job := BuildNotifyExpiredJob(time.Minute, func(ctx context.Context, from, to time.Time) error {
+			calls++
+			assert.Equal(t, lastRun, from)
+			assert.Equal(t, startAt, to)
+			return nil
+		})
+
+		assert.Equal(t, ExpiryJobName, job.Name)
+		assert.Equal(t, time.Minute, job.Interval)
+
+		err := job.Func(ctx, scheduler.Run{StartedAt: startAt, LastRunAt: lastRun})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("First Run", func(t *testing.T) {
+		job := BuildNotifyExpiredJob(time.Minute, func(ctx context.Context, from, to time.Time) error {
+			t.Fail()
+			return nil
+		})
+
+		err := job.Func(ctx, scheduler.Run{StartedAt: startAt})
+		assert.NoError(t, err)
+	})
 }
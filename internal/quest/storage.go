@@ -1,6 +1,9 @@
 package quest
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type (
 	// Creates a quest and its tasks
@@ -22,4 +25,10 @@ type (
 	// starts player tasks that were previously pending waiting for these completions.
 	// It also marks the player quest as complete if all required tasks are completed.
 	StorageUpdatePlayerQuestProgressionFunc func(ctx context.Context, quest Quest, tasksCompleted []string, playerID string) (PlayerQuestProgression, error)
+
+	// Lists the quests not deleted that end within the `(from, to]` interval
+	StorageListQuestsEndedBetweenFunc func(ctx context.Context, from, to time.Time) ([]Quest, error)
+
+	// Lists the IDs of the players that started the quest and didn't complete it
+	StorageListPlayersWithQuestUnfinishedFunc func(ctx context.Context, quest Quest) ([]string, error)
 )
@@ -1,6 +1,9 @@
 package quest
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type (
 	// Creates a quest and its tasks
@@ -21,4 +24,7 @@ type (
 	// Apply `taskDataToCheck` to all active tasks, check if it meets your conditions and update the completion of tasks that do.
 	// When all the required tasks are marked as completed, the quest will also be automatically marked as completed
 	UpdatePlayerQuestProgressionFunc func(ctx context.Context, quest Quest, playerID, taskDataToCheck string) (PlayerQuestProgression, error)
+
+	// Notify the players that didn't complete the quests expired within the `(from, to]` interval
+	NotifyExpiredFunc func(ctx context.Context, from, to time.Time) error
 )
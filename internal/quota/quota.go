@@ -0,0 +1,242 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Length of the window the requests per minute are counted on
+const window = time.Minute
+
+var (
+	ErrMissingGameID           = errors.New("missing game id")
+	ErrInvalidQuota            = errors.New("quota limits must not be negative")
+	ErrQuotaNotFound           = errors.New("quota not found")
+	ErrRateLimited             = errors.New("request rate limit exceeded")
+	ErrLeaderboardLimitReached = errors.New("leaderboard limit reached")
+	ErrStatisticLimitReached   = errors.New("statistic limit reached")
+)
+
+type (
+	// Limits of a game. A zero limit means unlimited
+	Quota struct {
+		GameID            string    // Game the quota belongs to
+		RequestsPerMinute int64     // Requests the game can send per minute, on each instance of the API
+		MaxLeaderboards   int64     // Leaderboards the game can keep, not counting the deleted ones
+		MaxStatistics     int64     // Statistics the game can keep, not counting the deleted ones
+		Default           bool      // Whether the game has no quota of its own, so the default one applies
+		UpdatedAt         time.Time // Last time the quota was set. Zero for the default one
+	}
+
+	// Quota of a game alongside how much of it the game uses
+	Status struct {
+		Quota        Quota // Quota of the game
+		Leaderboards int64 // Leaderboards the game keeps, not counting the deleted ones
+		Statistics   int64 // Statistics the game keeps, not counting the deleted ones
+	}
+)
+
+func (q Quota) validate() error {
+	if q.GameID == "" {
+		return ErrMissingGameID
+	}
+
+	if q.RequestsPerMinute < 0 || q.MaxLeaderboards < 0 || q.MaxStatistics < 0 {
+		return ErrInvalidQuota
+	}
+
+	return nil
+}
+
+func BuildSetFunc(storageSetQuotaFunc StorageSetQuotaFunc) SetFunc {
+	return func(ctx context.Context, quota Quota) (Quota, error) {
+		if err := quota.validate(); err != nil {
+			return Quota{}, err
+		}
+
+		quota.Default = false
+		quota.UpdatedAt = time.Now().UTC()
+		if err := storageSetQuotaFunc(ctx, quota); err != nil {
+			return Quota{}, err
+		}
+
+		return quota, nil
+	}
+}
+
+// Returns the quota of the game, falling back to the default one. Its game ID is ignored
+func BuildGetFunc(defaultQuota Quota, storageGetQuotaFunc StorageGetQuotaFunc) GetFunc {
+	return func(ctx context.Context, gameID string) (Quota, error) {
+		if gameID == "" {
+			return Quota{}, ErrMissingGameID
+		}
+
+		quota, err := storageGetQuotaFunc(ctx, gameID)
+		if errors.Is(err, ErrQuotaNotFound) {
+			defaultQuota.GameID = gameID
+			defaultQuota.Default = true
+			defaultQuota.UpdatedAt = time.Time{}
+			return defaultQuota, nil
+		}
+
+		return quota, err
+	}
+}
+
+func BuildStatusFunc(getFunc GetFunc, storageListLeaderboardsFunc StorageListLeaderboardsFunc, storageListStatisticsFunc StorageListStatisticsFunc) StatusFunc {
+	return func(ctx context.Context, gameID string) (Status, error) {
+		quota, err := getFunc(ctx, gameID)
+		if err != nil {
+			return Status{}, err
+		}
+
+		leaderboards, err := storageListLeaderboardsFunc(ctx, gameID)
+		if err != nil {
+			return Status{}, err
+		}
+
+		statistics, err := storageListStatisticsFunc(ctx, gameID)
+		if err != nil {
+			return Status{}, err
+		}
+
+		return Status{Quota: quota, Leaderboards: int64(len(leaderboards)), Statistics: int64(len(statistics))}, nil
+	}
+}
+
+// Checks the game's leaderboards against its limit. Requests creating leaderboards at once may each pass the check,
+// so the limit can be exceeded by the ones in flight
+func BuildCheckLeaderboardsFunc(getFunc GetFunc, storageListLeaderboardsFunc StorageListLeaderboardsFunc) CheckFunc {
+	return func(ctx context.Context, gameID string) error {
+		quota, err := getFunc(ctx, gameID)
+		if err != nil || quota.MaxLeaderboards == 0 {
+			return err
+		}
+
+		leaderboards, err := storageListLeaderboardsFunc(ctx, gameID)
+		if err != nil {
+			return err
+		}
+
+		if int64(len(leaderboards)) >= quota.MaxLeaderboards {
+			return ErrLeaderboardLimitReached
+		}
+
+		return nil
+	}
+}
+
+// Checks the game's statistics against its limit. Requests creating statistics at once may each pass the check,
+// so the limit can be exceeded by the ones in flight
+func BuildCheckStatisticsFunc(getFunc GetFunc, storageListStatisticsFunc StorageListStatisticsFunc) CheckFunc {
+	return func(ctx context.Context, gameID string) error {
+		quota, err := getFunc(ctx, gameID)
+		if err != nil || quota.MaxStatistics == 0 {
+			return err
+		}
+
+		statistics, err := storageListStatisticsFunc(ctx, gameID)
+		if err != nil {
+			return err
+		}
+
+		if int64(len(statistics)) >= quota.MaxStatistics {
+			return ErrStatisticLimitReached
+		}
+
+		return nil
+	}
+}
+
+type (
+	cachedQuota struct {
+		quota     Quota
+		fetchedAt time.Time
+	}
+
+	requestWindow struct {
+		start    time.Time
+		requests int64
+	}
+)
+
+// Limits the requests of each game per minute, counting them on fixed windows in memory. Each instance of the API
+// counts its own requests, and the quotas are kept for `quotaTTL` before read again, so a new quota takes up
+// to that long to apply on the other instances
+type Limiter struct {
+	mu      sync.Mutex
+	quotas  map[string]cachedQuota
+	windows map[string]requestWindow
+
+	quotaTTL time.Duration
+	getFunc  GetFunc
+}
+
+func NewLimiter(quotaTTL time.Duration, getFunc GetFunc) *Limiter {
+	return &Limiter{
+		quotas:   make(map[string]cachedQuota),
+		windows:  make(map[string]requestWindow),
+		quotaTTL: quotaTTL,
+		getFunc:  getFunc,
+	}
+}
+
+func (l *Limiter) quota(ctx context.Context, gameID string, now time.Time) (Quota, error) {
+	l.mu.Lock()
+	cached, ok := l.quotas[gameID]
+	l.mu.Unlock()
+
+	if ok && now.Sub(cached.fetchedAt) < l.quotaTTL {
+		return cached.quota, nil
+	}
+
+	quota, err := l.getFunc(ctx, gameID)
+	if err != nil {
+		return Quota{}, err
+	}
+
+	l.mu.Lock()
+	l.quotas[gameID] = cachedQuota{quota: quota, fetchedAt: now}
+	l.mu.Unlock()
+
+	return quota, nil
+}
+
+// Drops the cached quota of the game, so its next request reads it again. Meant to be called after it's set
+func (l *Limiter) Forget(gameID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.quotas, gameID)
+}
+
+// Counts the request of the game on the current window. Matches `AllowFunc`
+func (l *Limiter) Allow(ctx context.Context, gameID string) (time.Duration, error) {
+	now := time.Now()
+
+	quota, err := l.quota(ctx, gameID, now)
+	if err != nil || quota.RequestsPerMinute == 0 {
+		return 0, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	start := now.Truncate(window)
+
+	w := l.windows[gameID]
+	if !w.start.Equal(start) {
+		w = requestWindow{start: start}
+	}
+
+	if w.requests >= quota.RequestsPerMinute {
+		return start.Add(window).Sub(now), ErrRateLimited
+	}
+
+	w.requests++
+	l.windows[gameID] = w
+
+	return 0, nil
+}
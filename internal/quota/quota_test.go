@@ -0,0 +1,187 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSetFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var stored Quota
+		set := BuildSetFunc(func(ctx context.Context, quota Quota) error {
+			stored = quota
+			return nil
+		})
+
+		quota, err := set(ctx, Quota{GameID: "game", RequestsPerMinute: 60, MaxLeaderboards: 5, Default: true})
+		assert.NoError(t, err)
+		assert.False(t, quota.Default)
+		assert.False(t, quota.UpdatedAt.IsZero())
+		assert.Equal(t, quota, stored)
+	})
+
+	t.Run("Invalid Quota", func(t *testing.T) {
+		set := BuildSetFunc(func(ctx context.Context, quota Quota) error {
+			t.Fail()
+			return nil
+		})
+
+		_, err := set(ctx, Quota{RequestsPerMinute: 60})
+		assert.ErrorIs(t, err, ErrMissingGameID)
+
+		_, err = set(ctx, Quota{GameID: "game", MaxStatistics: -1})
+		assert.ErrorIs(t, err, ErrInvalidQuota)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		set := BuildSetFunc(func(ctx context.Context, quota Quota) error {
+			return errors.New("any error")
+		})
+
+		_, err := set(ctx, Quota{GameID: "game"})
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildGetFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		defaultQuota = Quota{RequestsPerMinute: 100, MaxLeaderboards: 10, MaxStatistics: 20}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		stored := Quota{GameID: "game", RequestsPerMinute: 5, UpdatedAt: time.Now().UTC()}
+		get := BuildGetFunc(defaultQuota, func(ctx context.Context, gameID string) (Quota, error) {
+			return stored, nil
+		})
+
+		quota, err := get(ctx, "game")
+		assert.NoError(t, err)
+		assert.Equal(t, stored, quota)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		get := BuildGetFunc(defaultQuota, func(ctx context.Context, gameID string) (Quota, error) {
+			return Quota{}, ErrQuotaNotFound
+		})
+
+		quota, err := get(ctx, "game")
+		assert.NoError(t, err)
+		assert.Equal(t, Quota{GameID: "game", RequestsPerMinute: 100, MaxLeaderboards: 10, MaxStatistics: 20, Default: true}, quota)
+	})
+
+	t.Run("Missing Game ID", func(t *testing.T) {
+		get := BuildGetFunc(defaultQuota, nil)
+
+		_, err := get(ctx, "")
+		assert.ErrorIs(t, err, ErrMissingGameID)
+	})
+}
+
+func TestBuildCheckFuncs(t *testing.T) {
+	var (
+		ctx              = context.Background()
+		listLeaderboards = func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+			return make([]leaderboard.Leaderboard, 2), nil
+		}
+		listStatistics = func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+			return make([]statistic.Statistic, 2), nil
+		}
+		getFunc = func(quota Quota) GetFunc {
+			return func(ctx context.Context, gameID string) (Quota, error) { return quota, nil }
+		}
+	)
+
+	t.Run("Under The Limit", func(t *testing.T) {
+		get := getFunc(Quota{MaxLeaderboards: 3, MaxStatistics: 3})
+
+		assert.NoError(t, BuildCheckLeaderboardsFunc(get, listLeaderboards)(ctx, "game"))
+		assert.NoError(t, BuildCheckStatisticsFunc(get, listStatistics)(ctx, "game"))
+	})
+
+	t.Run("Limit Reached", func(t *testing.T) {
+		get := getFunc(Quota{MaxLeaderboards: 2, MaxStatistics: 1})
+
+		assert.ErrorIs(t, BuildCheckLeaderboardsFunc(get, listLeaderboards)(ctx, "game"), ErrLeaderboardLimitReached)
+		assert.ErrorIs(t, BuildCheckStatisticsFunc(get, listStatistics)(ctx, "game"), ErrStatisticLimitReached)
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		get := getFunc(Quota{})
+		fail := func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+			t.Fail()
+			return nil, nil
+		}
+
+		assert.NoError(t, BuildCheckLeaderboardsFunc(get, fail)(ctx, "game"))
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		status, err := BuildStatusFunc(getFunc(Quota{GameID: "game", MaxLeaderboards: 5}), listLeaderboards, listStatistics)(ctx, "game")
+		assert.NoError(t, err)
+		assert.Equal(t, Status{Quota: Quota{GameID: "game", MaxLeaderboards: 5}, Leaderboards: 2, Statistics: 2}, status)
+	})
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Rate Limited", func(t *testing.T) {
+		gets := 0
+		limiter := NewLimiter(time.Hour, func(ctx context.Context, gameID string) (Quota, error) {
+			gets++
+			return Quota{GameID: gameID, RequestsPerMinute: 2}, nil
+		})
+
+		gameID := uuid.NewString()
+		for range 2 {
+			_, err := limiter.Allow(ctx, gameID)
+			assert.NoError(t, err)
+		}
+
+		retryAfter, err := limiter.Allow(ctx, gameID)
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.Greater(t, retryAfter, time.Duration(0))
+		assert.LessOrEqual(t, retryAfter, time.Minute)
+
+		// Other games have their own window
+		_, err = limiter.Allow(ctx, uuid.NewString())
+		assert.NoError(t, err)
+
+		// The quota is cached until forgotten
+		assert.Equal(t, 2, gets)
+		limiter.Forget(gameID)
+		_, _ = limiter.Allow(ctx, gameID)
+		assert.Equal(t, 3, gets)
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		limiter := NewLimiter(time.Hour, func(ctx context.Context, gameID string) (Quota, error) {
+			return Quota{GameID: gameID}, nil
+		})
+
+		for range 100 {
+			_, err := limiter.Allow(ctx, "game")
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("Quota Error", func(t *testing.T) {
+		limiter := NewLimiter(time.Hour, func(ctx context.Context, gameID string) (Quota, error) {
+			return Quota{}, errors.New("any error")
+		})
+
+		_, err := limiter.Allow(ctx, "game")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,22 @@
+package quota
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+type (
+	// Replaces the quota of the game, creating it when it has none
+	StorageSetQuotaFunc func(ctx context.Context, quota Quota) error
+
+	// Quota of the game. Fails with `ErrQuotaNotFound` when it has none
+	StorageGetQuotaFunc func(ctx context.Context, gameID string) (Quota, error)
+
+	// Lists the leaderboards of the game that are not soft deleted
+	StorageListLeaderboardsFunc func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error)
+
+	// Lists the statistics of the game that are not soft deleted
+	StorageListStatisticsFunc func(ctx context.Context, gameID string) ([]statistic.Statistic, error)
+)
@@ -0,0 +1,24 @@
+package quota
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Replaces the quota of the game
+	SetFunc func(ctx context.Context, quota Quota) (Quota, error)
+
+	// Quota of the game, the default one when it has none of its own
+	GetFunc func(ctx context.Context, gameID string) (Quota, error)
+
+	// Quota of the game alongside how much of it the game uses
+	StatusFunc func(ctx context.Context, gameID string) (Status, error)
+
+	// Fails with `ErrLeaderboardLimitReached` or `ErrStatisticLimitReached` when the game can't create another one
+	CheckFunc func(ctx context.Context, gameID string) error
+
+	// Counts a request of the game. Fails with `ErrRateLimited` when it's over its limit,
+	// alongside the time until it can send requests again
+	AllowFunc func(ctx context.Context, gameID string) (time.Duration, error)
+)
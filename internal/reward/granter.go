@@ -0,0 +1,9 @@
+package reward
+
+import "context"
+
+type (
+	// Delivers the rewards of the grant to the game's inventory or economy service. The grant ID
+	// is the same on every attempt, so the service can tell a retry from a new grant
+	GranterFunc func(ctx context.Context, grant Grant) error
+)
@@ -0,0 +1,463 @@
+package reward
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+)
+
+// Progressions that grant rewards
+const (
+	SourceLeaderboard = "LEADERBOARD" // The player finishes a leaderboard on one of the rule's positions
+	SourceQuest       = "QUEST"       // The player completes a quest
+	SourceAchievement = "ACHIEVEMENT" // The player unlocks an achievement
+)
+
+var Sources = []string{
+	SourceLeaderboard,
+	SourceQuest,
+	SourceAchievement,
+}
+
+const (
+	GrantStatusPending = "PENDING"
+	GrantStatusGranted = "GRANTED"
+	GrantStatusFailed  = "FAILED"
+)
+
+const (
+	MaxRewardsPerRule = 20   // Rewards a single rule can grant
+	MaxPrizePosition  = 1000 // Lowest leaderboard position a prize can reach
+
+	MinListLimit = 1
+	MaxListLimit = 500
+)
+
+var (
+	ErrValidationError    = errors.New("validation error")
+	ErrInvalidGameID      = errors.New("invalid game id")
+	ErrInvalidSource      = errors.New("source must be one of LEADERBOARD, QUEST or ACHIEVEMENT")
+	ErrMissingSourceID    = errors.New("missing source id")
+	ErrInvalidPositions   = errors.New("leaderboard prizes must go from a position to another between 1 and 1000")
+	ErrUnexpectedPosition = errors.New("only leaderboard prizes have positions")
+	ErrInvalidRewards     = errors.New("a rule must have between 1 and 20 rewards, each with an item and a positive quantity")
+
+	ErrInvalidRuleID      = errors.New("invalid rule id")
+	ErrRuleNotFound       = errors.New("reward rule not found")
+	ErrGrantNotFound      = errors.New("reward grant not found")
+	ErrGrantNotFailed     = errors.New("only failed reward grants can be retried")
+	ErrInvalidLimit       = errors.New("invalid limit")
+	ErrInvalidMaxAttempts = errors.New("max attempts must be positive")
+)
+
+type (
+	// Item granted to the player, as known by the game's economy
+	Reward struct {
+		Item     string // Item ID, e.g. a currency or a skin
+		Quantity int64  // Units of the item granted
+	}
+
+	NewRuleData struct {
+		GameID       string   // Game the rule belongs to
+		Source       string   // Progression that grants the rewards, one of `LEADERBOARD`, `QUEST` or `ACHIEVEMENT`
+		SourceID     string   // Leaderboard, quest or achievement that grants the rewards
+		FromPosition int64    // First leaderboard position granted. Only on `LEADERBOARD`
+		ToPosition   int64    // Last leaderboard position granted. Only on `LEADERBOARD`
+		Rewards      []Reward // Rewards granted
+	}
+
+	// Rewards granted to the players making a progression
+	Rule struct {
+		CreatedAt    time.Time // Time the rule was created
+		ID           string    // Rule ID, assigned by the storage
+		GameID       string    // Game the rule belongs to
+		Source       string    // Progression that grants the rewards, one of `LEADERBOARD`, `QUEST` or `ACHIEVEMENT`
+		SourceID     string    // Leaderboard, quest or achievement that grants the rewards
+		FromPosition int64     // First leaderboard position granted. Only on `LEADERBOARD`
+		ToPosition   int64     // Last leaderboard position granted. Only on `LEADERBOARD`
+		Rewards      []Reward  // Rewards granted
+	}
+
+	// Progression made by a player, matched against the rules of its game
+	Event struct {
+		Source   string // Progression kind, one of `LEADERBOARD`, `QUEST` or `ACHIEVEMENT`
+		GameID   string // Game the player belongs to
+		SourceID string // Leaderboard finished, quest completed or achievement unlocked
+		PlayerID string // Player's ID
+		Position int64  // Final position on the leaderboard. Only on `LEADERBOARD`
+	}
+
+	// Rewards of a rule granted to a player, kept as the audit of what was granted
+	Grant struct {
+		CreatedAt     time.Time // Time the grant was queued
+		UpdatedAt     time.Time // Last time the grant was attempted or retried
+		NextAttemptAt time.Time // Time the grant is due to be attempted
+		GrantedAt     time.Time // Time the granter accepted the grant. Zero until then
+		ID            string    // Derived from the rule and the player, so the same rule never grants a player twice
+		GameID        string    // Game the player belongs to
+		PlayerID      string    // Player the rewards are granted to
+		RuleID        string    // Rule that granted the rewards
+		Source        string    // Progression that granted the rewards
+		SourceID      string    // Leaderboard, quest or achievement that granted the rewards
+		Position      int64     // Final position on the leaderboard. Only on `LEADERBOARD`
+		Rewards       []Reward  // Rewards granted
+		Status        string    // Grant status, one of `PENDING`, `GRANTED` or `FAILED`
+		Attempts      int       // Attempts made since the grant was queued or last retried
+		LastError     string    // Error of the last failed attempt. Empty when none failed
+	}
+
+	// Wait before the next attempt, given how many attempts already failed
+	RetryDelayFunc func(attempts int) time.Duration
+)
+
+// ID of the grant of the rule to the player. It's the same whenever the rule matches the player,
+// so storing the grant is idempotent and the granter can use it as an idempotency key
+func GrantID(ruleID, playerID string) string {
+	digest := sha256.Sum256([]byte(ruleID + "/" + playerID))
+	return hex.EncodeToString(digest[:16])
+}
+
+func (d NewRuleData) validate() error {
+	errList := make([]error, 0)
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if !slices.Contains(Sources, d.Source) {
+		errList = append(errList, ErrInvalidSource)
+	}
+
+	if d.SourceID == "" {
+		errList = append(errList, ErrMissingSourceID)
+	}
+
+	if d.Source == SourceLeaderboard {
+		if d.FromPosition < 1 || d.ToPosition < d.FromPosition || d.ToPosition > MaxPrizePosition {
+			errList = append(errList, ErrInvalidPositions)
+		}
+	} else if d.FromPosition != 0 || d.ToPosition != 0 {
+		errList = append(errList, ErrUnexpectedPosition)
+	}
+
+	validRewards := len(d.Rewards) > 0 && len(d.Rewards) <= MaxRewardsPerRule
+	for _, r := range d.Rewards {
+		if r.Item == "" || r.Quantity <= 0 {
+			validRewards = false
+		}
+	}
+	if !validRewards {
+		errList = append(errList, ErrInvalidRewards)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+func (r Rule) matches(event Event) bool {
+	if r.Source == SourceLeaderboard {
+		return event.Position >= r.FromPosition && event.Position <= r.ToPosition
+	}
+
+	return true
+}
+
+func newGrants(rules []Rule, event Event, now time.Time) []Grant {
+	grants := make([]Grant, 0)
+	for _, rule := range rules {
+		if !rule.matches(event) {
+			continue
+		}
+
+		grants = append(grants, Grant{
+			CreatedAt:     now,
+			UpdatedAt:     now,
+			NextAttemptAt: now,
+			ID:            GrantID(rule.ID, event.PlayerID),
+			GameID:        event.GameID,
+			PlayerID:      event.PlayerID,
+			RuleID:        rule.ID,
+			Source:        rule.Source,
+			SourceID:      rule.SourceID,
+			Position:      event.Position,
+			Rewards:       rule.Rewards,
+			Status:        GrantStatusPending,
+		})
+	}
+
+	return grants
+}
+
+// The leaderboard, quest or achievement must belong to the game. Without achievements,
+// `getAchievementFunc` is nil and the rules can't be set on them
+func BuildCreateRuleFunc(
+	getLeaderboardFunc leaderboard.GetByIDAndGameIDFunc,
+	getQuestFunc quest.GetQuestByIDAndGameIDFunc,
+	getAchievementFunc achievement.GetByIDAndGameIDFunc,
+	storageCreateRuleFunc StorageCreateRuleFunc,
+) CreateRuleFunc {
+	return func(ctx context.Context, data NewRuleData) (Rule, error) {
+		if err := data.validate(); err != nil {
+			return Rule{}, err
+		}
+
+		var err error
+		switch data.Source {
+		case SourceLeaderboard:
+			_, err = getLeaderboardFunc(ctx, data.SourceID, data.GameID)
+		case SourceQuest:
+			_, err = getQuestFunc(ctx, data.SourceID, data.GameID)
+		case SourceAchievement:
+			if getAchievementFunc == nil {
+				return Rule{}, errors.Join(ErrInvalidSource, ErrValidationError)
+			}
+
+			_, err = getAchievementFunc(ctx, data.SourceID, data.GameID)
+		}
+		if err != nil {
+			return Rule{}, err
+		}
+
+		return storageCreateRuleFunc(ctx, data)
+	}
+}
+
+func BuildListRulesFunc(storageListRulesFunc StorageListRulesFunc) ListRulesFunc {
+	return func(ctx context.Context, gameID string) ([]Rule, error) {
+		return storageListRulesFunc(ctx, gameID)
+	}
+}
+
+func BuildDeleteRuleFunc(storageDeleteRuleFunc StorageDeleteRuleFunc) DeleteRuleFunc {
+	return func(ctx context.Context, id, gameID string) error {
+		if id == "" {
+			return ErrInvalidRuleID
+		}
+
+		return storageDeleteRuleFunc(ctx, id, gameID)
+	}
+}
+
+// Queuing the same event again is a no-op, as the rules already granted to the player are skipped
+func BuildEnqueueFunc(storageListSourceRulesFunc StorageListSourceRulesFunc, storageCreateGrantsFunc StorageCreateGrantsFunc) EnqueueFunc {
+	return func(ctx context.Context, event Event) ([]Grant, error) {
+		rules, err := storageListSourceRulesFunc(ctx, event.GameID, event.Source, event.SourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		grants := newGrants(rules, event, time.Now().UTC())
+		if len(grants) == 0 {
+			return grants, nil
+		}
+
+		return storageCreateGrantsFunc(ctx, grants)
+	}
+}
+
+// Grants are sent one after the other and retried with the delay given by `retryDelayFunc` until the granter accepts
+// them or they reach `maxAttempts`, when they're marked as failed until retried
+func BuildGrantDueFunc(
+	maxAttempts, batchSize int,
+	lease time.Duration,
+	retryDelayFunc RetryDelayFunc,
+	granterFunc GranterFunc,
+	storageClaimDueGrantsFunc StorageClaimDueGrantsFunc,
+	storageUpdateGrantFunc StorageUpdateGrantFunc,
+) (GrantDueFunc, error) {
+	if maxAttempts <= 0 {
+		return nil, ErrInvalidMaxAttempts
+	}
+
+	return func(ctx context.Context) (int, error) {
+		grants, err := storageClaimDueGrantsFunc(ctx, time.Now().UTC(), lease, batchSize)
+		if err != nil {
+			return 0, err
+		}
+
+		for i, grant := range grants {
+			err := granterFunc(ctx, grant)
+
+			// Interrupted attempts aren't recorded, the grant is retried once its lease expires
+			if ctx.Err() != nil {
+				return i, ctx.Err()
+			}
+
+			now := time.Now().UTC()
+			grant.UpdatedAt = now
+			grant.Attempts++
+
+			switch {
+			case err == nil:
+				grant.Status = GrantStatusGranted
+				grant.GrantedAt = now
+				grant.LastError = ""
+			case grant.Attempts >= maxAttempts:
+				grant.Status = GrantStatusFailed
+				grant.LastError = err.Error()
+			default:
+				grant.NextAttemptAt = now.Add(retryDelayFunc(grant.Attempts))
+				grant.LastError = err.Error()
+			}
+
+			if err := storageUpdateGrantFunc(ctx, grant); err != nil {
+				return i, err
+			}
+		}
+
+		return len(grants), nil
+	}, nil
+}
+
+func BuildListGrantsFunc(storageListGrantsFunc StorageListGrantsFunc) ListGrantsFunc {
+	return func(ctx context.Context, gameID, playerID string, limit int) ([]Grant, error) {
+		if limit < MinListLimit || limit > MaxListLimit {
+			return nil, ErrInvalidLimit
+		}
+
+		return storageListGrantsFunc(ctx, gameID, playerID, limit)
+	}
+}
+
+// The grant keeps its ID, so the granter still sees it as the same grant
+func BuildRetryGrantFunc(storageGetGrantFunc StorageGetGrantFunc, storageUpdateGrantFunc StorageUpdateGrantFunc) RetryGrantFunc {
+	return func(ctx context.Context, id, gameID string) (Grant, error) {
+		grant, err := storageGetGrantFunc(ctx, id, gameID)
+		if err != nil {
+			return Grant{}, err
+		}
+
+		if grant.Status != GrantStatusFailed {
+			return Grant{}, ErrGrantNotFailed
+		}
+
+		now := time.Now().UTC()
+		grant.Status = GrantStatusPending
+		grant.UpdatedAt = now
+		grant.NextAttemptAt = now
+		grant.Attempts = 0
+
+		if err := storageUpdateGrantFunc(ctx, grant); err != nil {
+			return Grant{}, err
+		}
+
+		return grant, nil
+	}
+}
+
+// Wraps the leaderboard lifecycle notifier to also grant the prizes of a leaderboard once it closes, to the players
+// on the positions of its rules. The wrapped notifier can be nil, and the prizes are queued only after it succeeds
+func BuildNotifierLeaderboardLifecycleEvent(
+	next leaderboard.NotifierLifecycleEvent,
+	rankingFunc leaderboard.RankingFunc,
+	storageListSourceRulesFunc StorageListSourceRulesFunc,
+	storageCreateGrantsFunc StorageCreateGrantsFunc,
+) leaderboard.NotifierLifecycleEvent {
+	return func(ctx context.Context, event string, lb leaderboard.Leaderboard) error {
+		if next != nil {
+			if err := next(ctx, event, lb); err != nil {
+				return err
+			}
+		}
+
+		if event != leaderboard.EventClosed {
+			return nil
+		}
+
+		rules, err := storageListSourceRulesFunc(ctx, lb.GameID, SourceLeaderboard, lb.ID)
+		if err != nil {
+			return err
+		}
+
+		var lastPosition int64
+		for _, rule := range rules {
+			lastPosition = max(lastPosition, rule.ToPosition)
+		}
+
+		var (
+			now    = time.Now().UTC()
+			grants = make([]Grant, 0)
+		)
+		for page := int64(leaderboard.MinPageNumber); page*leaderboard.MaxLimitNumber < lastPosition; page++ {
+			ranks, err := rankingFunc(ctx, lb, page, leaderboard.MaxLimitNumber)
+			if err != nil {
+				return err
+			}
+
+			for _, rank := range ranks {
+				grants = append(grants, newGrants(rules, Event{
+					Source:   SourceLeaderboard,
+					GameID:   lb.GameID,
+					SourceID: lb.ID,
+					PlayerID: rank.PlayerID,
+					Position: rank.Position,
+				}, now)...)
+			}
+
+			if len(ranks) < leaderboard.MaxLimitNumber {
+				break
+			}
+		}
+
+		if len(grants) == 0 {
+			return nil
+		}
+
+		_, err = storageCreateGrantsFunc(ctx, grants)
+		return err
+	}
+}
+
+// Wraps the quest lifecycle notifier to also grant the rewards of the quests the player completed.
+// The wrapped notifier can be nil, and the rewards are queued only after it succeeds
+func BuildNotifierQuestLifecycleEvent(next quest.NotifierLifecycleEvent, enqueueFunc EnqueueFunc) quest.NotifierLifecycleEvent {
+	return func(ctx context.Context, event quest.LifecycleEvent) error {
+		if next != nil {
+			if err := next(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if event.Event != quest.EventQuestCompleted {
+			return nil
+		}
+
+		_, err := enqueueFunc(ctx, Event{
+			Source:   SourceQuest,
+			GameID:   event.GameID,
+			SourceID: event.QuestID,
+			PlayerID: event.PlayerID,
+		})
+		return err
+	}
+}
+
+// Wraps the achievement notifier to also grant the rewards of the achievements the player unlocked.
+// The wrapped notifier can be nil, and the rewards are queued only after it succeeds
+func BuildNotifierAchievementUnlocked(next achievement.NotifierAchievementUnlocked, enqueueFunc EnqueueFunc) achievement.NotifierAchievementUnlocked {
+	return func(ctx context.Context, unlocked achievement.PlayerAchievement) error {
+		if next != nil {
+			if err := next(ctx, unlocked); err != nil {
+				return err
+			}
+		}
+
+		_, err := enqueueFunc(ctx, Event{
+			Source:   SourceAchievement,
+			GameID:   unlocked.Achievement.GameID,
+			SourceID: unlocked.Achievement.ID,
+			PlayerID: unlocked.PlayerID,
+		})
+		return err
+	}
+}
@@ -0,0 +1,234 @@
+package reward
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/achievement"
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCreateRuleFunc(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		getLeaderboard = func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		}
+		getQuest = func(ctx context.Context, id, gameID string) (quest.Quest, error) {
+			return quest.Quest{}, quest.ErrQuestNotFound
+		}
+		create = func(ctx context.Context, data NewRuleData) (Rule, error) {
+			return Rule{ID: "rule", GameID: data.GameID, Source: data.Source, SourceID: data.SourceID}, nil
+		}
+		rewards = []Reward{{Item: "gold", Quantity: 100}}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		rule, err := BuildCreateRuleFunc(getLeaderboard, getQuest, nil, create)(ctx, NewRuleData{
+			GameID:       "game",
+			Source:       SourceLeaderboard,
+			SourceID:     "season",
+			FromPosition: 1,
+			ToPosition:   10,
+			Rewards:      rewards,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "rule", rule.ID)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildCreateRuleFunc(nil, nil, nil, nil)(ctx, NewRuleData{Source: "LEVEL"})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrInvalidSource)
+		assert.ErrorIs(t, err, ErrMissingSourceID)
+		assert.ErrorIs(t, err, ErrInvalidRewards)
+
+		_, err = BuildCreateRuleFunc(nil, nil, nil, nil)(ctx, NewRuleData{GameID: "game", Source: SourceLeaderboard, SourceID: "season", FromPosition: 5, ToPosition: 1, Rewards: rewards})
+		assert.ErrorIs(t, err, ErrInvalidPositions)
+
+		_, err = BuildCreateRuleFunc(nil, nil, nil, nil)(ctx, NewRuleData{GameID: "game", Source: SourceQuest, SourceID: "tutorial", ToPosition: 1, Rewards: rewards})
+		assert.ErrorIs(t, err, ErrUnexpectedPosition)
+
+		_, err = BuildCreateRuleFunc(nil, nil, nil, nil)(ctx, NewRuleData{GameID: "game", Source: SourceQuest, SourceID: "tutorial", Rewards: []Reward{{Item: "gold"}}})
+		assert.ErrorIs(t, err, ErrInvalidRewards)
+	})
+
+	t.Run("Source Not Found", func(t *testing.T) {
+		_, err := BuildCreateRuleFunc(getLeaderboard, getQuest, nil, create)(ctx, NewRuleData{GameID: "game", Source: SourceQuest, SourceID: "tutorial", Rewards: rewards})
+		assert.ErrorIs(t, err, quest.ErrQuestNotFound)
+	})
+
+	t.Run("Achievements Disabled", func(t *testing.T) {
+		_, err := BuildCreateRuleFunc(getLeaderboard, getQuest, nil, create)(ctx, NewRuleData{GameID: "game", Source: SourceAchievement, SourceID: "centurion", Rewards: rewards})
+		assert.ErrorIs(t, err, ErrInvalidSource)
+	})
+}
+
+func TestBuildEnqueueFunc(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		rules = []Rule{
+			{ID: "top", GameID: "game", Source: SourceLeaderboard, SourceID: "season", FromPosition: 1, ToPosition: 3, Rewards: []Reward{{Item: "gold", Quantity: 100}}},
+			{ID: "rest", GameID: "game", Source: SourceLeaderboard, SourceID: "season", FromPosition: 4, ToPosition: 10, Rewards: []Reward{{Item: "gold", Quantity: 10}}},
+		}
+		listRules = func(ctx context.Context, gameID, source, sourceID string) ([]Rule, error) {
+			return rules, nil
+		}
+		created = func(ctx context.Context, grants []Grant) ([]Grant, error) {
+			return grants, nil
+		}
+	)
+
+	grants, err := BuildEnqueueFunc(listRules, created)(ctx, Event{Source: SourceLeaderboard, GameID: "game", SourceID: "season", PlayerID: "player", Position: 2})
+	assert.NoError(t, err)
+	assert.Len(t, grants, 1)
+	assert.Equal(t, GrantID("top", "player"), grants[0].ID)
+	assert.Equal(t, GrantStatusPending, grants[0].Status)
+	assert.Equal(t, int64(2), grants[0].Position)
+
+	grants, err = BuildEnqueueFunc(listRules, created)(ctx, Event{Source: SourceLeaderboard, GameID: "game", SourceID: "season", PlayerID: "player", Position: 11})
+	assert.NoError(t, err)
+	assert.Empty(t, grants)
+}
+
+func TestBuildGrantDueFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		errDown = errors.New("economy service down")
+	)
+
+	claim := func(grants ...Grant) StorageClaimDueGrantsFunc {
+		return func(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Grant, error) {
+			return grants, nil
+		}
+	}
+
+	t.Run("Granted", func(t *testing.T) {
+		var updated Grant
+		grantDueFunc, err := BuildGrantDueFunc(3, 10, time.Minute, func(int) time.Duration { return time.Second },
+			func(ctx context.Context, grant Grant) error { return nil },
+			claim(Grant{ID: "1", Status: GrantStatusPending}),
+			func(ctx context.Context, grant Grant) error { updated = grant; return nil },
+		)
+		assert.NoError(t, err)
+
+		n, err := grantDueFunc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, GrantStatusGranted, updated.Status)
+		assert.Equal(t, 1, updated.Attempts)
+		assert.False(t, updated.GrantedAt.IsZero())
+	})
+
+	t.Run("Retried", func(t *testing.T) {
+		var updated Grant
+		grantDueFunc, err := BuildGrantDueFunc(3, 10, time.Minute, func(int) time.Duration { return time.Hour },
+			func(ctx context.Context, grant Grant) error { return errDown },
+			claim(Grant{ID: "1", Status: GrantStatusPending}),
+			func(ctx context.Context, grant Grant) error { updated = grant; return nil },
+		)
+		assert.NoError(t, err)
+
+		_, err = grantDueFunc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, GrantStatusPending, updated.Status)
+		assert.Equal(t, errDown.Error(), updated.LastError)
+		assert.True(t, updated.NextAttemptAt.After(time.Now().Add(59*time.Minute)))
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		var updated Grant
+		grantDueFunc, err := BuildGrantDueFunc(3, 10, time.Minute, func(int) time.Duration { return time.Hour },
+			func(ctx context.Context, grant Grant) error { return errDown },
+			claim(Grant{ID: "1", Status: GrantStatusPending, Attempts: 2}),
+			func(ctx context.Context, grant Grant) error { updated = grant; return nil },
+		)
+		assert.NoError(t, err)
+
+		_, err = grantDueFunc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, GrantStatusFailed, updated.Status)
+		assert.Equal(t, 3, updated.Attempts)
+	})
+
+	t.Run("Invalid Max Attempts", func(t *testing.T) {
+		_, err := BuildGrantDueFunc(0, 10, time.Minute, nil, nil, nil, nil)
+		assert.ErrorIs(t, err, ErrInvalidMaxAttempts)
+	})
+}
+
+func TestBuildRetryGrantFunc(t *testing.T) {
+	ctx := context.Background()
+
+	get := func(status string) StorageGetGrantFunc {
+		return func(ctx context.Context, id, gameID string) (Grant, error) {
+			return Grant{ID: id, GameID: gameID, Status: status, Attempts: 8}, nil
+		}
+	}
+	update := func(ctx context.Context, grant Grant) error { return nil }
+
+	grant, err := BuildRetryGrantFunc(get(GrantStatusFailed), update)(ctx, "1", "game")
+	assert.NoError(t, err)
+	assert.Equal(t, GrantStatusPending, grant.Status)
+	assert.Equal(t, 0, grant.Attempts)
+
+	_, err = BuildRetryGrantFunc(get(GrantStatusGranted), update)(ctx, "1", "game")
+	assert.ErrorIs(t, err, ErrGrantNotFailed)
+}
+
+func TestBuildNotifierLeaderboardLifecycleEvent(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		lb        = leaderboard.Leaderboard{ID: "season", GameID: "game"}
+		listRules = func(ctx context.Context, gameID, source, sourceID string) ([]Rule, error) {
+			return []Rule{{ID: "top", Source: SourceLeaderboard, SourceID: sourceID, FromPosition: 1, ToPosition: 2}}, nil
+		}
+		ranking = func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+			return []leaderboard.Rank{{PlayerID: "a", Position: 1}, {PlayerID: "b", Position: 2}, {PlayerID: "c", Position: 3}}, nil
+		}
+	)
+
+	var created []Grant
+	notifier := BuildNotifierLeaderboardLifecycleEvent(nil, ranking, listRules, func(ctx context.Context, grants []Grant) ([]Grant, error) {
+		created = grants
+		return grants, nil
+	})
+
+	err := notifier(ctx, leaderboard.EventOpened, lb)
+	assert.NoError(t, err)
+	assert.Empty(t, created)
+
+	err = notifier(ctx, leaderboard.EventClosed, lb)
+	assert.NoError(t, err)
+	assert.Len(t, created, 2)
+	assert.Equal(t, "a", created[0].PlayerID)
+	assert.Equal(t, "b", created[1].PlayerID)
+}
+
+func TestBuildNotifiers(t *testing.T) {
+	ctx := context.Background()
+
+	var events []Event
+	enqueue := func(ctx context.Context, event Event) ([]Grant, error) {
+		events = append(events, event)
+		return nil, nil
+	}
+
+	questNotifier := BuildNotifierQuestLifecycleEvent(nil, enqueue)
+	assert.NoError(t, questNotifier(ctx, quest.LifecycleEvent{Event: quest.EventQuestStarted, GameID: "game", QuestID: "tutorial", PlayerID: "player"}))
+	assert.NoError(t, questNotifier(ctx, quest.LifecycleEvent{Event: quest.EventQuestCompleted, GameID: "game", QuestID: "tutorial", PlayerID: "player"}))
+
+	achievementNotifier := BuildNotifierAchievementUnlocked(nil, enqueue)
+	assert.NoError(t, achievementNotifier(ctx, achievement.PlayerAchievement{Achievement: achievement.Achievement{ID: "centurion", GameID: "game"}, PlayerID: "player"}))
+
+	assert.Equal(t, []Event{
+		{Source: SourceQuest, GameID: "game", SourceID: "tutorial", PlayerID: "player"},
+		{Source: SourceAchievement, GameID: "game", SourceID: "centurion", PlayerID: "player"},
+	}, events)
+}
@@ -0,0 +1,36 @@
+package reward
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Stores a new reward rule, returning it with its ID
+	StorageCreateRuleFunc func(ctx context.Context, data NewRuleData) (Rule, error)
+
+	// Lists the reward rules of the game, oldest first
+	StorageListRulesFunc func(ctx context.Context, gameID string) ([]Rule, error)
+
+	// Lists the reward rules of the game set on the leaderboard, quest or achievement
+	StorageListSourceRulesFunc func(ctx context.Context, gameID, source, sourceID string) ([]Rule, error)
+
+	// Removes the reward rule of the game
+	StorageDeleteRuleFunc func(ctx context.Context, id, gameID string) error
+
+	// Stores the grants, skipping the ones whose ID already exists, and returns the ones stored
+	StorageCreateGrantsFunc func(ctx context.Context, grants []Grant) ([]Grant, error)
+
+	// Lists up to `limit` pending grants due by `now`, oldest first, moving their next attempt `lease` ahead,
+	// so the same grant isn't sent twice at the same time
+	StorageClaimDueGrantsFunc func(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Grant, error)
+
+	// Saves the status, attempts and next attempt time of the grant
+	StorageUpdateGrantFunc func(ctx context.Context, grant Grant) error
+
+	// Get a grant by its id and game id
+	StorageGetGrantFunc func(ctx context.Context, id, gameID string) (Grant, error)
+
+	// Lists up to `limit` grants of the game, newest first. An empty player lists the grants of every player
+	StorageListGrantsFunc func(ctx context.Context, gameID, playerID string, limit int) ([]Grant, error)
+)
@@ -0,0 +1,26 @@
+package reward
+
+import "context"
+
+type (
+	// Creates a reward rule of the game
+	CreateRuleFunc func(ctx context.Context, data NewRuleData) (Rule, error)
+
+	// Lists the reward rules of the game, oldest first
+	ListRulesFunc func(ctx context.Context, gameID string) ([]Rule, error)
+
+	// Removes the reward rule of the game. The grants already queued by it are kept
+	DeleteRuleFunc func(ctx context.Context, id, gameID string) error
+
+	// Queues a grant of the rewards of each rule matching the event, returning the ones just queued
+	EnqueueFunc func(ctx context.Context, event Event) ([]Grant, error)
+
+	// Sends a batch of due grants to the granter, returning how many were attempted
+	GrantDueFunc func(ctx context.Context) (int, error)
+
+	// Lists up to `limit` grants of the game, newest first. The player is optional
+	ListGrantsFunc func(ctx context.Context, gameID, playerID string, limit int) ([]Grant, error)
+
+	// Queues a failed grant of the game again, with a new round of attempts
+	RetryGrantFunc func(ctx context.Context, id, gameID string) (Grant, error)
+)
@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidJobName     = errors.New("invalid job name")
+	ErrInvalidJobInterval = errors.New("invalid job interval")
+	ErrMissingJobFunc     = errors.New("missing job func")
+	ErrJobAlreadyExists   = errors.New("job already exists")
+)
+
+// Lock of a job, held by the instance running it until `LockedUntil`
+type Lock struct {
+	Job         string    // Name of the job
+	Owner       string    // Instance holding the lock
+	LockedUntil time.Time // Time the lock expires, so another instance can take it
+	LastRunAt   time.Time // Time the last successful run started. Zero when the job never ran
+}
+
+// Run of a job
+type Run struct {
+	StartedAt time.Time // Time the run started
+	LastRunAt time.Time // Time the last successful run started, on any instance. Zero when the job never ran
+}
+
+// Runs the job once. A failed run isn't recorded, so the next one covers its period again
+type JobFunc func(ctx context.Context, run Run) error
+
+type Job struct {
+	Name     string        // Job name, unique among the registered jobs, e.g. `purge`
+	Interval time.Duration // Time between the runs
+	Func     JobFunc       // Job logic
+}
+
+func (j Job) validate() error {
+	errList := make([]error, 0)
+
+	if j.Name == "" {
+		errList = append(errList, ErrInvalidJobName)
+	}
+
+	if j.Interval <= 0 {
+		errList = append(errList, ErrInvalidJobInterval)
+	}
+
+	if j.Func == nil {
+		errList = append(errList, ErrMissingJobFunc)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Runs the registered jobs on their intervals. Each run takes the job's lock for its interval, so on a fleet
+// only one instance runs a job per interval: the one holding it keeps renewing it, and another one takes over
+// once it expires
+type Scheduler struct {
+	mu   sync.Mutex
+	jobs []Job
+
+	owner                  string
+	storageAcquireLockFunc StorageAcquireLockFunc
+	storageRecordRunFunc   StorageRecordRunFunc
+}
+
+// Creates a scheduler running the jobs as the given owner, which must be unique per instance, e.g. its hostname
+func New(owner string, storageAcquireLockFunc StorageAcquireLockFunc, storageRecordRunFunc StorageRecordRunFunc) *Scheduler {
+	return &Scheduler{
+		owner:                  owner,
+		storageAcquireLockFunc: storageAcquireLockFunc,
+		storageRecordRunFunc:   storageRecordRunFunc,
+	}
+}
+
+// Adds the job to the ones run by `Run`. Must be called before it
+func (s *Scheduler) Register(job Job) error {
+	if err := job.validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, j := range s.jobs {
+		if j.Name == job.Name {
+			return ErrJobAlreadyExists
+		}
+	}
+
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+// Registered jobs, in registration order
+func (s *Scheduler) Jobs() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]Job, len(s.jobs))
+	copy(jobs, s.jobs)
+
+	return jobs
+}
+
+// Runs the job when its lock is taken, recording the run once it succeeds. Returns whether it ran
+func (s *Scheduler) RunJob(ctx context.Context, job Job) (bool, error) {
+	now := time.Now().UTC()
+
+	lock, acquired, err := s.storageAcquireLockFunc(ctx, job.Name, s.owner, now, now.Add(job.Interval))
+	if err != nil || !acquired {
+		return false, err
+	}
+
+	if err := job.Func(ctx, Run{StartedAt: now, LastRunAt: lock.LastRunAt}); err != nil {
+		return true, err
+	}
+
+	return true, s.storageRecordRunFunc(ctx, job.Name, s.owner, now)
+}
+
+// Runs every registered job right away and then on each of its intervals, until the context is canceled.
+// `onRun` is called after each run of the instance, with its duration and error. Optional
+func (s *Scheduler) Run(ctx context.Context, onRun func(job string, duration time.Duration, err error)) {
+	var wg sync.WaitGroup
+	for _, job := range s.Jobs() {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+
+			ticker := time.NewTicker(job.Interval)
+			defer ticker.Stop()
+
+			for {
+				start := time.Now()
+				ran, err := s.RunJob(ctx, job)
+				if (ran || err != nil) && onRun != nil {
+					onRun(job.Name, time.Since(start), err)
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(job)
+	}
+
+	wg.Wait()
+}
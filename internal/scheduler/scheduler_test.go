@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	noop := func(ctx context.Context, run Run) error { return nil }
+
+	t.Run("OK", func(t *testing.T) {
+		s := New(uuid.NewString(), nil, nil)
+
+		err := s.Register(Job{Name: "a", Interval: time.Minute, Func: noop})
+		assert.NoError(t, err)
+
+		err = s.Register(Job{Name: "b", Interval: time.Hour, Func: noop})
+		assert.NoError(t, err)
+
+		jobs := s.Jobs()
+		if assert.Len(t, jobs, 2) {
+			assert.Equal(t, "a", jobs[0].Name)
+			assert.Equal(t, "b", jobs[1].Name)
+		}
+	})
+
+	t.Run("Invalid Job", func(t *testing.T) {
+		s := New(uuid.NewString(), nil, nil)
+
+		err := s.Register(Job{})
+		assert.ErrorIs(t, err, ErrInvalidJobName)
+		assert.ErrorIs(t, err, ErrInvalidJobInterval)
+		assert.ErrorIs(t, err, ErrMissingJobFunc)
+		assert.Empty(t, s.Jobs())
+	})
+
+	t.Run("Already Exists", func(t *testing.T) {
+		s := New(uuid.NewString(), nil, nil)
+
+		err := s.Register(Job{Name: "a", Interval: time.Minute, Func: noop})
+		assert.NoError(t, err)
+
+		err = s.Register(Job{Name: "a", Interval: time.Hour, Func: noop})
+		assert.ErrorIs(t, err, ErrJobAlreadyExists)
+	})
+}
+
+func TestRunJob(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		owner   = uuid.NewString()
+		lastRun = time.Now().Add(-time.Hour).UTC()
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			ranAt    time.Time
+			recorded time.Time
+		)
+
+		s := New(
+			owner,
+			func(ctx context.Context, job, lockOwner string, now, until time.Time) (Lock, bool, error) {
+				assert.Equal(t, "a", job)
+				assert.Equal(t, owner, lockOwner)
+				assert.Equal(t, time.Minute, until.Sub(now))
+				return Lock{Job: job, Owner: lockOwner, LockedUntil: until, LastRunAt: lastRun}, true, nil
+			},
+			func(ctx context.Context, job, lockOwner string, at time.Time) error {
+				recorded = at
+				return nil
+			},
+		)
+
+		ran, err := s.RunJob(ctx, Job{Name: "a", Interval: time.Minute, Func: func(ctx context.Context, run Run) error {
+			assert.Equal(t, lastRun, run.LastRunAt)
+			ranAt = run.StartedAt
+			return nil
+		}})
+		assert.NoError(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, ranAt, recorded)
+	})
+
+	t.Run("Lock Held", func(t *testing.T) {
+		s := New(
+			owner,
+			func(ctx context.Context, job, owner string, now, until time.Time) (Lock, bool, error) {
+				return Lock{Job: job}, false, nil
+			},
+			nil,
+		)
+
+		ran, err := s.RunJob(ctx, Job{Name: "a", Interval: time.Minute, Func: func(ctx context.Context, run Run) error {
+			t.Fail()
+			return nil
+		}})
+		assert.NoError(t, err)
+		assert.False(t, ran)
+	})
+
+	t.Run("Lock Error", func(t *testing.T) {
+		s := New(
+			owner,
+			func(ctx context.Context, job, owner string, now, until time.Time) (Lock, bool, error) {
+				return Lock{}, false, errors.New("any error")
+			},
+			nil,
+		)
+
+		ran, err := s.RunJob(ctx, Job{Name: "a", Interval: time.Minute, Func: func(ctx context.Context, run Run) error {
+			t.Fail()
+			return nil
+		}})
+		assert.Error(t, err)
+		assert.False(t, ran)
+	})
+
+	t.Run("Job Error", func(t *testing.T) {
+		s := New(
+			owner,
+			func(ctx context.Context, job, owner string, now, until time.Time) (Lock, bool, error) {
+				return Lock{Job: job, Owner: owner, LockedUntil: until}, true, nil
+			},
+			func(ctx context.Context, job, owner string, at time.Time) error {
+				t.Fail()
+				return nil
+			},
+		)
+
+		ran, err := s.RunJob(ctx, Job{Name: "a", Interval: time.Minute, Func: func(ctx context.Context, run Run) error {
+			return errors.New("any error")
+		}})
+		assert.Error(t, err)
+		assert.True(t, ran)
+	})
+}
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var (
+		mu   sync.Mutex
+		runs = make(map[string]int)
+	)
+
+	s := New(
+		uuid.NewString(),
+		func(ctx context.Context, job, owner string, now, until time.Time) (Lock, bool, error) {
+			return Lock{Job: job, Owner: owner, LockedUntil: until}, true, nil
+		},
+		func(ctx context.Context, job, owner string, at time.Time) error {
+			return nil
+		},
+	)
+
+	for _, name := range []string{"a", "b"} {
+		err := s.Register(Job{Name: name, Interval: time.Hour, Func: func(ctx context.Context, run Run) error { return nil }})
+		assert.NoError(t, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(ctx, func(job string, duration time.Duration, err error) {
+			assert.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			runs[job]++
+			if len(runs) == 2 {
+				cancel()
+			}
+		})
+	}()
+
+	// Every job runs once right away
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+
+	assert.Equal(t, map[string]int{"a": 1, "b": 1}, runs)
+}
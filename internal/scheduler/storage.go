@@ -0,0 +1,15 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Takes the job's lock for the owner until the given time, unless another owner holds it past `now`.
+	// Returns the lock with the job's last successful run, and whether it was taken
+	StorageAcquireLockFunc func(ctx context.Context, job, owner string, now, until time.Time) (Lock, bool, error)
+
+	// Records the start of the job's last successful run, when the owner still holds its lock
+	StorageRecordRunFunc func(ctx context.Context, job, owner string, ranAt time.Time) error
+)
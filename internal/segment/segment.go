@@ -0,0 +1,522 @@
+package segment
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Most characters on a segment name
+const MaxNameLength = 64
+
+// Resources whose players can be restricted to the members of a segment
+const (
+	ResourceLeaderboard = "LEADERBOARD" // Only the members are ranked on the leaderboard
+	ResourceQuest       = "QUEST"       // Only the members can start the quest
+)
+
+var ResourceTypes = []string{
+	ResourceLeaderboard,
+	ResourceQuest,
+}
+
+var (
+	ErrValidationError            = errors.New("validation error")
+	ErrInvalidName                = errors.New("name must have between 1 and 64 characters")
+	ErrInvalidGameID              = errors.New("invalid game id")
+	ErrMissingRules               = errors.New("a segment requires at least one rule")
+	ErrInvalidCountry             = errors.New("countries must be ISO 3166-1 alpha-2 codes")
+	ErrMissingStatisticID         = errors.New("the statistic value range requires a statistic id")
+	ErrMissingValueRange          = errors.New("the statistic rule requires a minimum or a maximum value")
+	ErrInvalidValueRange          = errors.New("the minimum value must not be greater than the maximum value")
+	ErrInvalidInstallRange        = errors.New("installed after must be before installed before")
+	ErrProfileRulesUnavailable    = errors.New("the country and install date rules require the player profiles")
+	ErrInvalidSegmentID           = errors.New("invalid segment id")
+	ErrSegmentNotFound            = errors.New("segment not found")
+	ErrInvalidPlayerID            = errors.New("invalid player id")
+	ErrInvalidResourceType        = errors.New("resource type must be one of LEADERBOARD or QUEST")
+	ErrInvalidResourceID          = errors.New("invalid resource id")
+	ErrEligibilityNotFound        = errors.New("eligibility not found")
+	ErrPlayerNotEligible          = errors.New("player not eligible")
+	ErrEligibilityValidationError = errors.New("eligibility validation error")
+)
+
+type (
+	// Conditions a player must meet to be a member of a segment. Every rule set must match
+	Rules struct {
+		Countries       []string  // Countries of the player's profile, any of them. Empty to match any country
+		StatisticID     string    // Statistic used as the player's level. Empty to match any level
+		MinValue        *float64  // Lowest value of the statistic, inclusive. Nil for no lower bound
+		MaxValue        *float64  // Highest value of the statistic, inclusive. Nil for no upper bound
+		InstalledAfter  time.Time // Players whose profile was created at or after it. Zero for no lower bound
+		InstalledBefore time.Time // Players whose profile was created before it. Zero for no upper bound
+	}
+
+	NewSegmentData struct {
+		GameID      string // Game the segment belongs to
+		Name        string // Cohort name
+		Description string // Segment details
+		Rules       Rules  // Conditions of the members
+	}
+
+	// Named cohort of the players of a game, matched by rules
+	Segment struct {
+		CreatedAt   time.Time // Time the segment was created
+		ID          string    // Segment ID, assigned by the storage
+		GameID      string    // Game the segment belongs to
+		Name        string    // Cohort name
+		Description string    // Segment details
+		Rules       Rules     // Conditions of the members
+	}
+
+	// Restricts a leaderboard or a quest to the members of a segment
+	Eligibility struct {
+		UpdatedAt    time.Time // Last time the eligibility was set
+		GameID       string    // Game the resource belongs to
+		ResourceType string    // Resource restricted, one of `LEADERBOARD` or `QUEST`
+		ResourceID   string    // ID of the leaderboard or the quest
+		SegmentID    string    // Segment whose members are eligible
+	}
+
+	// Player details the rules are matched against
+	candidate struct {
+		profile *player.Profile     // Nil when the player has no profile
+		values  map[string]*float64 // Player's values by statistic ID. Nil when the player has no progression on it
+	}
+)
+
+func (r Rules) usesProfile() bool {
+	return len(r.Countries) > 0 || !r.InstalledAfter.IsZero() || !r.InstalledBefore.IsZero()
+}
+
+func (r Rules) matches(c candidate) bool {
+	if r.usesProfile() {
+		if c.profile == nil {
+			return false
+		}
+
+		if len(r.Countries) > 0 && !slices.Contains(r.Countries, c.profile.Country) {
+			return false
+		}
+
+		if !r.InstalledAfter.IsZero() && c.profile.CreatedAt.Before(r.InstalledAfter) {
+			return false
+		}
+
+		if !r.InstalledBefore.IsZero() && !c.profile.CreatedAt.Before(r.InstalledBefore) {
+			return false
+		}
+	}
+
+	if r.StatisticID != "" {
+		value := c.values[r.StatisticID]
+		if value == nil {
+			return false
+		}
+
+		if r.MinValue != nil && *value < *r.MinValue {
+			return false
+		}
+
+		if r.MaxValue != nil && *value > *r.MaxValue {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validCountry(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+
+	for _, r := range country {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Trims the name and uppercases the countries, so they're validated and matched the same way as the profiles'
+func (d NewSegmentData) normalize() NewSegmentData {
+	d.Name = strings.TrimSpace(d.Name)
+
+	countries := make([]string, len(d.Rules.Countries))
+	for i, country := range d.Rules.Countries {
+		countries[i] = strings.ToUpper(country)
+	}
+	d.Rules.Countries = countries
+
+	return d
+}
+
+func (d NewSegmentData) validate() error {
+	errList := make([]error, 0)
+
+	if d.Name == "" || utf8.RuneCountInString(d.Name) > MaxNameLength {
+		errList = append(errList, ErrInvalidName)
+	}
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	r := d.Rules
+	if !r.usesProfile() && r.StatisticID == "" && r.MinValue == nil && r.MaxValue == nil {
+		errList = append(errList, ErrMissingRules)
+	}
+
+	if slices.ContainsFunc(r.Countries, func(country string) bool { return !validCountry(country) }) {
+		errList = append(errList, ErrInvalidCountry)
+	}
+
+	switch {
+	case r.StatisticID == "" && (r.MinValue != nil || r.MaxValue != nil):
+		errList = append(errList, ErrMissingStatisticID)
+	case r.StatisticID != "" && r.MinValue == nil && r.MaxValue == nil:
+		errList = append(errList, ErrMissingValueRange)
+	case r.MinValue != nil && r.MaxValue != nil && *r.MinValue > *r.MaxValue:
+		errList = append(errList, ErrInvalidValueRange)
+	}
+
+	if !r.InstalledAfter.IsZero() && !r.InstalledBefore.IsZero() && !r.InstalledAfter.Before(r.InstalledBefore) {
+		errList = append(errList, ErrInvalidInstallRange)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+func (e Eligibility) validate() error {
+	errList := make([]error, 0)
+
+	if e.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if !slices.Contains(ResourceTypes, e.ResourceType) {
+		errList = append(errList, ErrInvalidResourceType)
+	}
+
+	if e.ResourceID == "" {
+		errList = append(errList, ErrInvalidResourceID)
+	}
+
+	if e.SegmentID == "" {
+		errList = append(errList, ErrInvalidSegmentID)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrEligibilityValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Reads the details of the players the rules of the segments need: the profiles once, and the value of each statistic
+// the segments refer to. The profiles reader can only be nil when none of the segments has a profile rule
+func candidates(
+	ctx context.Context,
+	gameID string,
+	segments []Segment,
+	playerIDs []string,
+	listProfilesFunc player.ListProfilesFunc,
+	getPlayerProgressionFunc statistic.GetPlayerProgressionFunc,
+) (map[string]candidate, error) {
+	var (
+		usesProfile  = false
+		statisticIDs = make([]string, 0)
+	)
+	for _, s := range segments {
+		usesProfile = usesProfile || s.Rules.usesProfile()
+		if s.Rules.StatisticID != "" && !slices.Contains(statisticIDs, s.Rules.StatisticID) {
+			statisticIDs = append(statisticIDs, s.Rules.StatisticID)
+		}
+	}
+
+	var profiles map[string]player.Profile
+	if usesProfile {
+		if listProfilesFunc == nil {
+			return nil, ErrProfileRulesUnavailable
+		}
+
+		var err error
+		if profiles, err = listProfilesFunc(ctx, gameID, playerIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	byPlayer := make(map[string]candidate, len(playerIDs))
+	for _, playerID := range playerIDs {
+		c := candidate{values: make(map[string]*float64, len(statisticIDs))}
+		if profile, ok := profiles[playerID]; ok {
+			c.profile = &profile
+		}
+
+		for _, statisticID := range statisticIDs {
+			progression, err := getPlayerProgressionFunc(ctx, statisticID, playerID)
+			switch {
+			case errors.Is(err, statistic.ErrPlayerStatisticNotFound):
+			case err != nil:
+				return nil, err
+			default:
+				c.values[statisticID] = progression.CurrentValue
+			}
+		}
+
+		byPlayer[playerID] = c
+	}
+
+	return byPlayer, nil
+}
+
+// The statistic the value range refers to must belong to the game. The country and install date rules are rejected
+// when the profiles reader is nil, as the player profiles are disabled
+func BuildCreateFunc(listProfilesFunc player.ListProfilesFunc, getStatisticFunc statistic.GetByIDAndGameIDFunc, storageCreateSegmentFunc StorageCreateSegmentFunc) CreateFunc {
+	return func(ctx context.Context, data NewSegmentData) (Segment, error) {
+		data = data.normalize()
+		if err := data.validate(); err != nil {
+			return Segment{}, err
+		}
+
+		if data.Rules.usesProfile() && listProfilesFunc == nil {
+			return Segment{}, ErrProfileRulesUnavailable
+		}
+
+		if data.Rules.StatisticID != "" {
+			if _, err := getStatisticFunc(ctx, data.Rules.StatisticID, data.GameID); err != nil {
+				return Segment{}, err
+			}
+		}
+
+		return storageCreateSegmentFunc(ctx, data)
+	}
+}
+
+func BuildGetByIDAndGameIDFunc(storageGetSegmentFunc StorageGetSegmentFunc) GetByIDAndGameIDFunc {
+	return func(ctx context.Context, id, gameID string) (Segment, error) {
+		if id == "" {
+			return Segment{}, ErrInvalidSegmentID
+		}
+
+		return storageGetSegmentFunc(ctx, id, gameID)
+	}
+}
+
+func BuildListFunc(storageListSegmentsFunc StorageListSegmentsFunc) ListFunc {
+	return func(ctx context.Context, gameID string) ([]Segment, error) {
+		return storageListSegmentsFunc(ctx, gameID)
+	}
+}
+
+func BuildDeleteFunc(storageDeleteSegmentFunc StorageDeleteSegmentFunc) DeleteFunc {
+	return func(ctx context.Context, id, gameID string) error {
+		if id == "" {
+			return ErrInvalidSegmentID
+		}
+
+		return storageDeleteSegmentFunc(ctx, id, gameID)
+	}
+}
+
+// The players are kept in the given order. The profiles reader can be nil when the player profiles are disabled
+func BuildFilterMembersFunc(listProfilesFunc player.ListProfilesFunc, getPlayerProgressionFunc statistic.GetPlayerProgressionFunc, storageGetSegmentFunc StorageGetSegmentFunc) FilterMembersFunc {
+	return func(ctx context.Context, gameID, segmentID string, playerIDs []string) ([]string, error) {
+		if segmentID == "" {
+			return nil, ErrInvalidSegmentID
+		}
+
+		s, err := storageGetSegmentFunc(ctx, segmentID, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		members := make([]string, 0, len(playerIDs))
+		if len(playerIDs) == 0 {
+			return members, nil
+		}
+
+		byPlayer, err := candidates(ctx, gameID, []Segment{s}, playerIDs, listProfilesFunc, getPlayerProgressionFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, playerID := range playerIDs {
+			if s.Rules.matches(byPlayer[playerID]) {
+				members = append(members, playerID)
+			}
+		}
+
+		return members, nil
+	}
+}
+
+func BuildIsMemberFunc(filterMembersFunc FilterMembersFunc) IsMemberFunc {
+	return func(ctx context.Context, gameID, segmentID, playerID string) (bool, error) {
+		if playerID == "" {
+			return false, ErrInvalidPlayerID
+		}
+
+		members, err := filterMembersFunc(ctx, gameID, segmentID, []string{playerID})
+		if err != nil {
+			return false, err
+		}
+
+		return len(members) > 0, nil
+	}
+}
+
+// The profiles reader can be nil when the player profiles are disabled
+func BuildListPlayerSegmentsFunc(listProfilesFunc player.ListProfilesFunc, getPlayerProgressionFunc statistic.GetPlayerProgressionFunc, storageListSegmentsFunc StorageListSegmentsFunc) ListPlayerSegmentsFunc {
+	return func(ctx context.Context, gameID, playerID string) ([]Segment, error) {
+		if playerID == "" {
+			return nil, ErrInvalidPlayerID
+		}
+
+		segments, err := storageListSegmentsFunc(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		joined := make([]Segment, 0)
+		if len(segments) == 0 {
+			return joined, nil
+		}
+
+		byPlayer, err := candidates(ctx, gameID, segments, []string{playerID}, listProfilesFunc, getPlayerProgressionFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range segments {
+			if s.Rules.matches(byPlayer[playerID]) {
+				joined = append(joined, s)
+			}
+		}
+
+		return joined, nil
+	}
+}
+
+// The leaderboard or quest restricted and the segment must belong to the game
+func BuildSetEligibilityFunc(
+	getLeaderboardFunc leaderboard.GetByIDAndGameIDFunc,
+	getQuestFunc quest.GetQuestByIDAndGameIDFunc,
+	storageGetSegmentFunc StorageGetSegmentFunc,
+	storageSetEligibilityFunc StorageSetEligibilityFunc,
+) SetEligibilityFunc {
+	return func(ctx context.Context, eligibility Eligibility) (Eligibility, error) {
+		if err := eligibility.validate(); err != nil {
+			return Eligibility{}, err
+		}
+
+		var err error
+		switch eligibility.ResourceType {
+		case ResourceLeaderboard:
+			_, err = getLeaderboardFunc(ctx, eligibility.ResourceID, eligibility.GameID)
+		case ResourceQuest:
+			_, err = getQuestFunc(ctx, eligibility.ResourceID, eligibility.GameID)
+		}
+		if err != nil {
+			return Eligibility{}, err
+		}
+
+		if _, err := storageGetSegmentFunc(ctx, eligibility.SegmentID, eligibility.GameID); err != nil {
+			return Eligibility{}, err
+		}
+
+		eligibility.UpdatedAt = time.Now().UTC()
+		return storageSetEligibilityFunc(ctx, eligibility)
+	}
+}
+
+func BuildGetEligibilityFunc(storageGetEligibilityFunc StorageGetEligibilityFunc) GetEligibilityFunc {
+	return func(ctx context.Context, gameID, resourceType, resourceID string) (Eligibility, error) {
+		if resourceID == "" {
+			return Eligibility{}, ErrInvalidResourceID
+		}
+
+		return storageGetEligibilityFunc(ctx, gameID, resourceType, resourceID)
+	}
+}
+
+func BuildRemoveEligibilityFunc(storageDeleteEligibilityFunc StorageDeleteEligibilityFunc) RemoveEligibilityFunc {
+	return func(ctx context.Context, gameID, resourceType, resourceID string) error {
+		if resourceID == "" {
+			return ErrInvalidResourceID
+		}
+
+		return storageDeleteEligibilityFunc(ctx, gameID, resourceType, resourceID)
+	}
+}
+
+// Whether the player can take part on the resource. Resources without eligibility are open to every player,
+// and so are the ones whose segment was removed meanwhile
+func eligible(ctx context.Context, gameID, resourceType, resourceID, playerID string, storageGetEligibilityFunc StorageGetEligibilityFunc, isMemberFunc IsMemberFunc) (bool, error) {
+	e, err := storageGetEligibilityFunc(ctx, gameID, resourceType, resourceID)
+	if err != nil {
+		if errors.Is(err, ErrEligibilityNotFound) {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	member, err := isMemberFunc(ctx, gameID, e.SegmentID, playerID)
+	if err != nil {
+		if errors.Is(err, ErrSegmentNotFound) {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	return member, nil
+}
+
+// Wraps the rank update to reject the submissions of the players out of the leaderboard's segment with `ErrPlayerNotEligible`
+func BuildRejectIneligibleRankFunc(storageGetEligibilityFunc StorageGetEligibilityFunc, isMemberFunc IsMemberFunc, next leaderboard.StorageUpsertPlayerRankValueFunc) leaderboard.StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		ok, err := eligible(ctx, lb.GameID, ResourceLeaderboard, lb.ID, playerID, storageGetEligibilityFunc, isMemberFunc)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return ErrPlayerNotEligible
+		}
+
+		return next(ctx, lb, playerID, value)
+	}
+}
+
+// Wraps the quest start to reject the players out of the quest's segment with `ErrPlayerNotEligible`.
+// The players who started the quest before the restriction keep progressing on it
+func BuildRejectIneligibleQuestFunc(storageGetEligibilityFunc StorageGetEligibilityFunc, isMemberFunc IsMemberFunc, next quest.StartQuestForPlayerFunc) quest.StartQuestForPlayerFunc {
+	return func(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error) {
+		ok, err := eligible(ctx, q.GameID, ResourceQuest, q.ID, playerID, storageGetEligibilityFunc, isMemberFunc)
+		if err != nil {
+			return quest.PlayerQuestProgression{}, err
+		}
+
+		if !ok {
+			return quest.PlayerQuestProgression{}, ErrPlayerNotEligible
+		}
+
+		return next(ctx, q, playerID)
+	}
+}
@@ -0,0 +1,299 @@
+package segment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/player"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func value(v float64) *float64 { return &v }
+
+func TestBuildCreateFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		listProfiles = func(ctx context.Context, gameID string, playerIDs []string) (map[string]player.Profile, error) {
+			return nil, nil
+		}
+		getStatistic = func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+			return statistic.Statistic{ID: id, GameID: gameID}, nil
+		}
+		create = func(ctx context.Context, data NewSegmentData) (Segment, error) {
+			return Segment{ID: uuid.NewString(), GameID: data.GameID, Name: data.Name, Rules: data.Rules}, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		s, err := BuildCreateFunc(listProfiles, getStatistic, create)(ctx, NewSegmentData{
+			GameID: "game",
+			Name:   " Veterans ",
+			Rules:  Rules{Countries: []string{"br"}, StatisticID: "level", MinValue: value(10)},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Veterans", s.Name)
+		assert.Equal(t, []string{"BR"}, s.Rules.Countries)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildCreateFunc(nil, nil, nil)(ctx, NewSegmentData{})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidName)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrMissingRules)
+
+		_, err = BuildCreateFunc(nil, nil, nil)(ctx, NewSegmentData{GameID: "game", Name: "a", Rules: Rules{Countries: []string{"B1"}, MinValue: value(1)}})
+		assert.ErrorIs(t, err, ErrInvalidCountry)
+		assert.ErrorIs(t, err, ErrMissingStatisticID)
+
+		_, err = BuildCreateFunc(nil, nil, nil)(ctx, NewSegmentData{GameID: "game", Name: "a", Rules: Rules{StatisticID: "level"}})
+		assert.ErrorIs(t, err, ErrMissingValueRange)
+
+		_, err = BuildCreateFunc(nil, nil, nil)(ctx, NewSegmentData{GameID: "game", Name: "a", Rules: Rules{StatisticID: "level", MinValue: value(2), MaxValue: value(1)}})
+		assert.ErrorIs(t, err, ErrInvalidValueRange)
+
+		now := time.Now()
+		_, err = BuildCreateFunc(nil, nil, nil)(ctx, NewSegmentData{GameID: "game", Name: "a", Rules: Rules{InstalledAfter: now, InstalledBefore: now}})
+		assert.ErrorIs(t, err, ErrInvalidInstallRange)
+	})
+
+	t.Run("Profiles Disabled", func(t *testing.T) {
+		_, err := BuildCreateFunc(nil, getStatistic, create)(ctx, NewSegmentData{GameID: "game", Name: "Brazil", Rules: Rules{Countries: []string{"BR"}}})
+		assert.ErrorIs(t, err, ErrProfileRulesUnavailable)
+
+		_, err = BuildCreateFunc(nil, getStatistic, create)(ctx, NewSegmentData{GameID: "game", Name: "Pros", Rules: Rules{StatisticID: "level", MinValue: value(50)}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Statistic Not Found", func(t *testing.T) {
+		getStatistic := func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+			return statistic.Statistic{}, statistic.ErrStatisticNotFound
+		}
+
+		_, err := BuildCreateFunc(listProfiles, getStatistic, create)(ctx, NewSegmentData{GameID: "game", Name: "Pros", Rules: Rules{StatisticID: "level", MinValue: value(50)}})
+		assert.ErrorIs(t, err, statistic.ErrStatisticNotFound)
+	})
+}
+
+func TestMembership(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		installedAt = time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+		segments    = []Segment{
+			{ID: "brazil", GameID: "game", Rules: Rules{Countries: []string{"BR"}}},
+			{ID: "pros", GameID: "game", Rules: Rules{StatisticID: "level", MinValue: value(50)}},
+			{ID: "newcomers", GameID: "game", Rules: Rules{InstalledAfter: installedAt.Add(-time.Hour), StatisticID: "level", MaxValue: value(10)}},
+		}
+		listProfiles = func(ctx context.Context, gameID string, playerIDs []string) (map[string]player.Profile, error) {
+			profiles := map[string]player.Profile{
+				"ana":  {PlayerID: "ana", Country: "BR", CreatedAt: installedAt},
+				"joao": {PlayerID: "joao", Country: "PT", CreatedAt: installedAt.Add(-48 * time.Hour)},
+			}
+
+			found := make(map[string]player.Profile)
+			for _, id := range playerIDs {
+				if p, ok := profiles[id]; ok {
+					found[id] = p
+				}
+			}
+
+			return found, nil
+		}
+		getProgression = func(ctx context.Context, statisticID, playerID string) (statistic.PlayerProgression, error) {
+			switch playerID {
+			case "ana":
+				return statistic.PlayerProgression{PlayerID: playerID, StatisticID: statisticID, CurrentValue: value(5)}, nil
+			case "joao":
+				return statistic.PlayerProgression{PlayerID: playerID, StatisticID: statisticID, CurrentValue: value(80)}, nil
+			}
+
+			return statistic.PlayerProgression{}, statistic.ErrPlayerStatisticNotFound
+		}
+		getSegment = func(ctx context.Context, id, gameID string) (Segment, error) {
+			for _, s := range segments {
+				if s.ID == id && s.GameID == gameID {
+					return s, nil
+				}
+			}
+
+			return Segment{}, ErrSegmentNotFound
+		}
+		listSegments = func(ctx context.Context, gameID string) ([]Segment, error) {
+			return segments, nil
+		}
+	)
+
+	filterMembers := BuildFilterMembersFunc(listProfiles, getProgression, getSegment)
+
+	t.Run("Filter Members", func(t *testing.T) {
+		members, err := filterMembers(ctx, "game", "brazil", []string{"joao", "ana", "unknown"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ana"}, members)
+
+		members, err = filterMembers(ctx, "game", "pros", []string{"joao", "ana", "unknown"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"joao"}, members)
+
+		_, err = filterMembers(ctx, "game", "missing", []string{"ana"})
+		assert.ErrorIs(t, err, ErrSegmentNotFound)
+	})
+
+	t.Run("Is Member", func(t *testing.T) {
+		member, err := BuildIsMemberFunc(filterMembers)(ctx, "game", "newcomers", "ana")
+		assert.NoError(t, err)
+		assert.True(t, member)
+
+		member, err = BuildIsMemberFunc(filterMembers)(ctx, "game", "newcomers", "joao")
+		assert.NoError(t, err)
+		assert.False(t, member)
+
+		_, err = BuildIsMemberFunc(filterMembers)(ctx, "game", "newcomers", "")
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+	})
+
+	t.Run("List Player Segments", func(t *testing.T) {
+		joined, err := BuildListPlayerSegmentsFunc(listProfiles, getProgression, listSegments)(ctx, "game", "ana")
+		assert.NoError(t, err)
+		assert.Equal(t, []Segment{segments[0], segments[2]}, joined)
+
+		joined, err = BuildListPlayerSegmentsFunc(listProfiles, getProgression, listSegments)(ctx, "game", "unknown")
+		assert.NoError(t, err)
+		assert.Empty(t, joined)
+	})
+
+	t.Run("Profiles Disabled", func(t *testing.T) {
+		_, err := BuildFilterMembersFunc(nil, getProgression, getSegment)(ctx, "game", "brazil", []string{"ana"})
+		assert.ErrorIs(t, err, ErrProfileRulesUnavailable)
+
+		members, err := BuildFilterMembersFunc(nil, getProgression, getSegment)(ctx, "game", "pros", []string{"joao"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"joao"}, members)
+	})
+}
+
+func TestBuildSetEligibilityFunc(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		getLeaderboard = func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		}
+		getQuest = func(ctx context.Context, id, gameID string) (quest.Quest, error) {
+			return quest.Quest{}, quest.ErrQuestNotFound
+		}
+		getSegment = func(ctx context.Context, id, gameID string) (Segment, error) {
+			return Segment{ID: id, GameID: gameID}, nil
+		}
+		set = func(ctx context.Context, eligibility Eligibility) (Eligibility, error) {
+			return eligibility, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		e, err := BuildSetEligibilityFunc(getLeaderboard, getQuest, getSegment, set)(ctx, Eligibility{
+			GameID:       "game",
+			ResourceType: ResourceLeaderboard,
+			ResourceID:   "season",
+			SegmentID:    "brazil",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "brazil", e.SegmentID)
+		assert.False(t, e.UpdatedAt.IsZero())
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildSetEligibilityFunc(nil, nil, nil, nil)(ctx, Eligibility{ResourceType: "ACHIEVEMENT"})
+		assert.ErrorIs(t, err, ErrEligibilityValidationError)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrInvalidResourceType)
+		assert.ErrorIs(t, err, ErrInvalidResourceID)
+		assert.ErrorIs(t, err, ErrInvalidSegmentID)
+	})
+
+	t.Run("Quest Not Found", func(t *testing.T) {
+		_, err := BuildSetEligibilityFunc(getLeaderboard, getQuest, getSegment, set)(ctx, Eligibility{
+			GameID:       "game",
+			ResourceType: ResourceQuest,
+			ResourceID:   "daily",
+			SegmentID:    "brazil",
+		})
+		assert.ErrorIs(t, err, quest.ErrQuestNotFound)
+	})
+
+	t.Run("Segment Not Found", func(t *testing.T) {
+		getSegment := func(ctx context.Context, id, gameID string) (Segment, error) {
+			return Segment{}, ErrSegmentNotFound
+		}
+
+		_, err := BuildSetEligibilityFunc(getLeaderboard, getQuest, getSegment, set)(ctx, Eligibility{
+			GameID:       "game",
+			ResourceType: ResourceLeaderboard,
+			ResourceID:   "season",
+			SegmentID:    "brazil",
+		})
+		assert.ErrorIs(t, err, ErrSegmentNotFound)
+	})
+}
+
+func TestBuildRejectIneligibleFuncs(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		errStorage     = errors.New("storage error")
+		getEligibility = func(ctx context.Context, gameID, resourceType, resourceID string) (Eligibility, error) {
+			if resourceID != "restricted" {
+				return Eligibility{}, ErrEligibilityNotFound
+			}
+
+			return Eligibility{GameID: gameID, ResourceType: resourceType, ResourceID: resourceID, SegmentID: "brazil"}, nil
+		}
+		isMember = func(ctx context.Context, gameID, segmentID, playerID string) (bool, error) {
+			return playerID == "ana", nil
+		}
+		upsert = func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			return nil
+		}
+		start = func(ctx context.Context, q quest.Quest, playerID string) (quest.PlayerQuestProgression, error) {
+			return quest.PlayerQuestProgression{PlayerID: playerID}, nil
+		}
+	)
+
+	t.Run("Rank", func(t *testing.T) {
+		reject := BuildRejectIneligibleRankFunc(getEligibility, isMember, upsert)
+
+		assert.NoError(t, reject(ctx, leaderboard.Leaderboard{ID: "restricted", GameID: "game"}, "ana", 1))
+		assert.NoError(t, reject(ctx, leaderboard.Leaderboard{ID: "open", GameID: "game"}, "joao", 1))
+		assert.ErrorIs(t, reject(ctx, leaderboard.Leaderboard{ID: "restricted", GameID: "game"}, "joao", 1), ErrPlayerNotEligible)
+	})
+
+	t.Run("Quest", func(t *testing.T) {
+		reject := BuildRejectIneligibleQuestFunc(getEligibility, isMember, start)
+
+		_, err := reject(ctx, quest.Quest{ID: "restricted", GameID: "game"}, "ana")
+		assert.NoError(t, err)
+
+		_, err = reject(ctx, quest.Quest{ID: "restricted", GameID: "game"}, "joao")
+		assert.ErrorIs(t, err, ErrPlayerNotEligible)
+	})
+
+	t.Run("Segment Removed", func(t *testing.T) {
+		isMember := func(ctx context.Context, gameID, segmentID, playerID string) (bool, error) {
+			return false, ErrSegmentNotFound
+		}
+
+		assert.NoError(t, BuildRejectIneligibleRankFunc(getEligibility, isMember, upsert)(ctx, leaderboard.Leaderboard{ID: "restricted", GameID: "game"}, "joao", 1))
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		getEligibility := func(ctx context.Context, gameID, resourceType, resourceID string) (Eligibility, error) {
+			return Eligibility{}, errStorage
+		}
+
+		assert.ErrorIs(t, BuildRejectIneligibleRankFunc(getEligibility, isMember, upsert)(ctx, leaderboard.Leaderboard{ID: "open", GameID: "game"}, "ana", 1), errStorage)
+	})
+}
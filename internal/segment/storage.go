@@ -0,0 +1,26 @@
+package segment
+
+import "context"
+
+type (
+	// Stores a new segment, returning it with its ID
+	StorageCreateSegmentFunc func(ctx context.Context, data NewSegmentData) (Segment, error)
+
+	// Get a segment by its id and game id
+	StorageGetSegmentFunc func(ctx context.Context, id, gameID string) (Segment, error)
+
+	// Lists the segments of the game, oldest first
+	StorageListSegmentsFunc func(ctx context.Context, gameID string) ([]Segment, error)
+
+	// Removes the segment of the game alongside the eligibilities restricted to it
+	StorageDeleteSegmentFunc func(ctx context.Context, id, gameID string) error
+
+	// Stores the eligibility, replacing the one the resource already has
+	StorageSetEligibilityFunc func(ctx context.Context, eligibility Eligibility) (Eligibility, error)
+
+	// Get the eligibility of a resource of the game, failing with `ErrEligibilityNotFound` when it's open to every player
+	StorageGetEligibilityFunc func(ctx context.Context, gameID, resourceType, resourceID string) (Eligibility, error)
+
+	// Removes the eligibility of a resource of the game, failing with `ErrEligibilityNotFound` when it has none
+	StorageDeleteEligibilityFunc func(ctx context.Context, gameID, resourceType, resourceID string) error
+)
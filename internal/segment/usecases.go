@@ -0,0 +1,35 @@
+package segment
+
+import "context"
+
+type (
+	// Creates a segment of the game
+	CreateFunc func(ctx context.Context, data NewSegmentData) (Segment, error)
+
+	// Get a segment by its id and game id
+	GetByIDAndGameIDFunc func(ctx context.Context, id, gameID string) (Segment, error)
+
+	// Lists the segments of the game, oldest first
+	ListFunc func(ctx context.Context, gameID string) ([]Segment, error)
+
+	// Removes the segment of the game alongside the eligibilities restricted to it
+	DeleteFunc func(ctx context.Context, id, gameID string) error
+
+	// Keeps only the players that are members of the segment
+	FilterMembersFunc func(ctx context.Context, gameID, segmentID string, playerIDs []string) ([]string, error)
+
+	// Whether the player is a member of the segment
+	IsMemberFunc func(ctx context.Context, gameID, segmentID, playerID string) (bool, error)
+
+	// Lists the segments of the game the player is a member of, oldest first
+	ListPlayerSegmentsFunc func(ctx context.Context, gameID, playerID string) ([]Segment, error)
+
+	// Restricts a leaderboard or a quest of the game to the members of a segment
+	SetEligibilityFunc func(ctx context.Context, eligibility Eligibility) (Eligibility, error)
+
+	// Get the segment a leaderboard or a quest of the game is restricted to
+	GetEligibilityFunc func(ctx context.Context, gameID, resourceType, resourceID string) (Eligibility, error)
+
+	// Opens a leaderboard or a quest of the game to every player again
+	RemoveEligibilityFunc func(ctx context.Context, gameID, resourceType, resourceID string) error
+)
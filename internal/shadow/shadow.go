@@ -0,0 +1,427 @@
+package shadow
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+const MaxReasonLength = 512 // Characters the reason of a flag can have
+
+var (
+	ErrValidationError = errors.New("validation error")
+	ErrInvalidGameID   = errors.New("invalid game id")
+	ErrInvalidPlayerID = errors.New("invalid player id")
+	ErrInvalidReason   = errors.New("reason must have up to 512 characters")
+
+	ErrFlagNotFound         = errors.New("flag not found")
+	ErrPlayerAlreadyFlagged = errors.New("player already flagged")
+)
+
+type (
+	// Player's value on the shadow copy of a leaderboard, seen only by the player while flagged
+	ShadowRank struct {
+		LeaderboardID string   // Leaderboard the rank is a shadow of
+		Value         float64  // Player's value on the shadow copy
+		Previous      *float64 // Player's value on the ranking when the shadow rank was added. Nil when the player wasn't ranked
+	}
+
+	NewFlagData struct {
+		GameID   string // Game the player belongs to
+		PlayerID string // Player flagged
+		Reason   string // Why the player was flagged, up to 512 characters
+	}
+
+	// Player suspected of cheating, whose submissions only reach the shadow copies of the leaderboards until cleared
+	Flag struct {
+		FlaggedAt   time.Time    // Time the player was flagged
+		GameID      string       // Game the player belongs to
+		PlayerID    string       // Player flagged
+		Reason      string       // Why the player was flagged
+		ShadowRanks []ShadowRank // Player's ranks on the shadow copies of the leaderboards
+	}
+)
+
+// Flag's shadow rank on the leaderboard, nil when it has none there
+func (f Flag) shadowRank(leaderboardID string) *ShadowRank {
+	i := slices.IndexFunc(f.ShadowRanks, func(r ShadowRank) bool { return r.LeaderboardID == leaderboardID })
+	if i < 0 {
+		return nil
+	}
+
+	return &f.ShadowRanks[i]
+}
+
+func (d NewFlagData) validate() error {
+	errList := make([]error, 0)
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if d.PlayerID == "" {
+		errList = append(errList, ErrInvalidPlayerID)
+	}
+
+	if utf8.RuneCountInString(d.Reason) > MaxReasonLength {
+		errList = append(errList, ErrInvalidReason)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Player's value on the leaderboard once the submission is applied
+func resulting(lb leaderboard.Leaderboard, current *float64, value float64) float64 {
+	if current == nil {
+		return value
+	}
+
+	switch lb.AggregationMode {
+	case leaderboard.AggregationModeInc:
+		return *current + value
+	case leaderboard.AggregationModeMax:
+		return max(*current, value)
+	case leaderboard.AggregationModeMin:
+		return min(*current, value)
+	}
+
+	return value
+}
+
+// Whether the value ranks ahead of the other on the leaderboard
+func better(lb leaderboard.Leaderboard, value, other float64) bool {
+	if lb.Ordering == leaderboard.OrderingAsc {
+		return value < other
+	}
+
+	return value > other
+}
+
+// Position the value would take on the leaderboard ranking, after the ranks it ties with. The ranking is read one rank
+// at a time, doubling the position until the value beats the rank on it and bisecting the last interval from there
+func position(ctx context.Context, storageGetRankingFunc leaderboard.StorageGetRankingFunc, lb leaderboard.Leaderboard, value float64) (int64, error) {
+	beats := func(position int64) (bool, error) {
+		ranks, err := storageGetRankingFunc(ctx, lb, position, 1)
+		if err != nil {
+			return false, err
+		}
+
+		return len(ranks) == 0 || better(lb, value, ranks[0].Value), nil
+	}
+
+	// The value never beats the rank on `low`, and always beats the one on `high`
+	low, high := int64(-1), int64(0)
+	for {
+		ok, err := beats(high)
+		if err != nil {
+			return 0, err
+		}
+
+		if ok {
+			break
+		}
+
+		low, high = high, 2*high+1
+	}
+
+	for high-low > 1 {
+		middle := low + (high-low)/2
+
+		ok, err := beats(middle)
+		if err != nil {
+			return 0, err
+		}
+
+		if ok {
+			high = middle
+		} else {
+			low = middle
+		}
+	}
+
+	return high, nil
+}
+
+// The flag is stored before the ranks are moved, so the player's submissions reach the shadow copies while they're moved
+// aside. Every rank is added to the flag before it leaves the ranking, so a failure halfway never loses it. When it fails
+// after the flag is stored, clearing the flag and flagging the player again moves the remaining ranks
+func BuildFlagFunc(
+	storageListLeaderboardsFunc StorageListLeaderboardsFunc,
+	storageGetPlayerRankFunc leaderboard.StorageGetPlayerRankFunc,
+	storageDeletePlayerRankFunc StorageDeletePlayerRankFunc,
+	storageCreateFlagFunc StorageCreateFlagFunc,
+	storageAddShadowRankFunc StorageAddShadowRankFunc,
+) FlagFunc {
+	return func(ctx context.Context, data NewFlagData) (Flag, error) {
+		if err := data.validate(); err != nil {
+			return Flag{}, err
+		}
+
+		f := Flag{
+			FlaggedAt: time.Now().UTC(),
+			GameID:    data.GameID,
+			PlayerID:  data.PlayerID,
+			Reason:    data.Reason,
+		}
+
+		if err := storageCreateFlagFunc(ctx, f); err != nil {
+			return Flag{}, err
+		}
+
+		leaderboards, err := storageListLeaderboardsFunc(ctx, data.GameID)
+		if err != nil {
+			return Flag{}, err
+		}
+
+		ranked := make([]leaderboard.Leaderboard, 0)
+		for _, lb := range leaderboards {
+			rank, err := storageGetPlayerRankFunc(ctx, lb, data.PlayerID)
+			if errors.Is(err, leaderboard.ErrPlayerRankNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return Flag{}, err
+			}
+
+			shadowRank := ShadowRank{LeaderboardID: lb.ID, Value: rank.Value, Previous: &rank.Value}
+			if err := storageAddShadowRankFunc(ctx, data.GameID, data.PlayerID, shadowRank); err != nil {
+				return Flag{}, err
+			}
+
+			ranked = append(ranked, lb)
+			f.ShadowRanks = append(f.ShadowRanks, shadowRank)
+		}
+
+		for _, lb := range ranked {
+			if err := storageDeletePlayerRankFunc(ctx, lb, data.PlayerID); err != nil {
+				return Flag{}, err
+			}
+		}
+
+		return f, nil
+	}
+}
+
+// The flag is removed before its ranks are put back, so the player's submissions reach the rankings again right away.
+// The ranks are put back through the leaderboard's aggregation, so the ones submitted in the meantime are kept, and the
+// ones of the leaderboards deleted in the meantime are dropped. When a rank can't be put back, the flag is stored again
+// with the ranks remaining
+func BuildClearFunc(
+	storageGetLeaderboardFunc StorageGetLeaderboardFunc,
+	storageUpsertPlayerRankValueFunc leaderboard.StorageUpsertPlayerRankValueFunc,
+	storageCreateFlagFunc StorageCreateFlagFunc,
+	storageDeleteFlagFunc StorageDeleteFlagFunc,
+) ClearFunc {
+	restore := func(ctx context.Context, f Flag, shadowRank ShadowRank, merge bool) error {
+		value := shadowRank.Previous
+		if merge {
+			value = &shadowRank.Value
+		}
+
+		if value == nil {
+			return nil
+		}
+
+		lb, err := storageGetLeaderboardFunc(ctx, shadowRank.LeaderboardID, f.GameID)
+		if errors.Is(err, leaderboard.ErrLeaderboardNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return storageUpsertPlayerRankValueFunc(ctx, lb, f.PlayerID, *value)
+	}
+
+	return func(ctx context.Context, gameID, playerID string, merge bool) (Flag, error) {
+		if playerID == "" {
+			return Flag{}, ErrInvalidPlayerID
+		}
+
+		f, err := storageDeleteFlagFunc(ctx, gameID, playerID)
+		if err != nil {
+			return Flag{}, err
+		}
+
+		for i, shadowRank := range f.ShadowRanks {
+			if err := restore(ctx, f, shadowRank, merge); err != nil {
+				remaining := f
+				remaining.ShadowRanks = f.ShadowRanks[i:]
+
+				return Flag{}, errors.Join(err, storageCreateFlagFunc(ctx, remaining))
+			}
+		}
+
+		return f, nil
+	}
+}
+
+func BuildGetFunc(storageGetFlagFunc StorageGetFlagFunc) GetFunc {
+	return func(ctx context.Context, gameID, playerID string) (Flag, error) {
+		if playerID == "" {
+			return Flag{}, ErrInvalidPlayerID
+		}
+
+		return storageGetFlagFunc(ctx, gameID, playerID)
+	}
+}
+
+func BuildListFunc(storageListFlagsFunc StorageListFlagsFunc) ListFunc {
+	return func(ctx context.Context, gameID string) ([]Flag, error) {
+		return storageListFlagsFunc(ctx, gameID)
+	}
+}
+
+// Wraps the rank update to apply the submissions of the flagged players to their shadow ranks instead. A shadow rank
+// starts from the player's value on the ranking, when the flag has none on the leaderboard yet. The submission reaches
+// the ranking when the flag is cleared concurrently
+func BuildRouteFlaggedFunc(
+	storageGetFlagFunc StorageGetFlagFunc,
+	storageGetPlayerRankFunc leaderboard.StorageGetPlayerRankFunc,
+	storageSetShadowRankFunc StorageSetShadowRankFunc,
+	next leaderboard.StorageUpsertPlayerRankValueFunc,
+) leaderboard.StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		f, err := storageGetFlagFunc(ctx, lb.GameID, playerID)
+		if errors.Is(err, ErrFlagNotFound) {
+			return next(ctx, lb, playerID, value)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		shadowRank := ShadowRank{LeaderboardID: lb.ID}
+		if current := f.shadowRank(lb.ID); current != nil {
+			shadowRank.Value = resulting(lb, &current.Value, value)
+		} else {
+			rank, err := storageGetPlayerRankFunc(ctx, lb, playerID)
+			switch {
+			case err == nil:
+				shadowRank.Previous = &rank.Value
+			case !errors.Is(err, leaderboard.ErrPlayerRankNotFound):
+				return err
+			}
+
+			shadowRank.Value = resulting(lb, shadowRank.Previous, value)
+		}
+
+		err = storageSetShadowRankFunc(ctx, lb.GameID, playerID, shadowRank)
+		if errors.Is(err, ErrFlagNotFound) {
+			return next(ctx, lb, playerID, value)
+		}
+
+		return err
+	}
+}
+
+// Wraps the player's rank read to answer with the shadow rank of the flagged players, at the position its value would
+// take on the ranking
+func BuildShowShadowRankFunc(
+	storageGetFlagFunc StorageGetFlagFunc,
+	storageGetRankingFunc leaderboard.StorageGetRankingFunc,
+	next leaderboard.StorageGetPlayerRankFunc,
+) leaderboard.StorageGetPlayerRankFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+		f, err := storageGetFlagFunc(ctx, lb.GameID, playerID)
+		if errors.Is(err, ErrFlagNotFound) {
+			return next(ctx, lb, playerID)
+		}
+
+		if err != nil {
+			return leaderboard.Rank{}, err
+		}
+
+		shadowRank := f.shadowRank(lb.ID)
+		if shadowRank == nil {
+			return next(ctx, lb, playerID)
+		}
+
+		p, err := position(ctx, storageGetRankingFunc, lb, shadowRank.Value)
+		if err != nil {
+			return leaderboard.Rank{}, err
+		}
+
+		return leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Position: p, Value: shadowRank.Value}, nil
+	}
+}
+
+// The shadow rank takes the position its value would take on the ranking, pushing the ranks from there one position
+// down. When it lands on a page before, the page starts with the last rank of the one before it
+func BuildMergeViewerFunc(storageGetFlagFunc StorageGetFlagFunc, storageGetRankingFunc leaderboard.StorageGetRankingFunc) MergeViewerFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, ranks []leaderboard.Rank, page, limit int64) ([]leaderboard.Rank, error) {
+		f, err := storageGetFlagFunc(ctx, lb.GameID, playerID)
+		if errors.Is(err, ErrFlagNotFound) {
+			return ranks, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		shadowRank := f.shadowRank(lb.ID)
+		if shadowRank == nil {
+			return ranks, nil
+		}
+
+		p, err := position(ctx, storageGetRankingFunc, lb, shadowRank.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		first := page * limit
+		if p >= first+limit {
+			return ranks, nil
+		}
+
+		merged := make([]leaderboard.Rank, 0, len(ranks)+1)
+		if p < first {
+			before, err := storageGetRankingFunc(ctx, lb, first-1, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(before) == 0 {
+				return ranks, nil
+			}
+
+			merged = append(merged, before[0])
+		}
+
+		for _, r := range ranks {
+			if r.Position >= p {
+				break
+			}
+
+			merged = append(merged, r)
+		}
+
+		if p >= first {
+			merged = append(merged, leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Position: p, Value: shadowRank.Value})
+		}
+
+		for _, r := range ranks {
+			if r.Position >= p {
+				merged = append(merged, r)
+			}
+		}
+
+		for i := range merged {
+			if merged[i].Position >= p && merged[i].PlayerID != playerID {
+				merged[i].Position++
+			}
+		}
+
+		return merged[:min(int64(len(merged)), limit)], nil
+	}
+}
@@ -0,0 +1,304 @@
+package shadow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ranking sorted by the leaderboard ordering, read as the storage pages it
+func rankingFunc(values ...float64) leaderboard.StorageGetRankingFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, page, limit int64) ([]leaderboard.Rank, error) {
+		ranks := make([]leaderboard.Rank, 0)
+		for i := page * limit; i < min(page*limit+limit, int64(len(values))); i++ {
+			ranks = append(ranks, leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: string(rune('a' + i)), Position: i, Value: values[i]})
+		}
+
+		return ranks, nil
+	}
+}
+
+func value(v float64) *float64 {
+	return &v
+}
+
+func TestBuildFlagFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		leaderboards = []leaderboard.Leaderboard{{ID: "season", GameID: "game"}, {ID: "weekly", GameID: "game"}}
+		listFunc     = func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+			return leaderboards, nil
+		}
+		getRankFunc = func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			if lb.ID == "weekly" {
+				return leaderboard.Rank{}, leaderboard.ErrPlayerRankNotFound
+			}
+
+			return leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Value: 42}, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			created Flag
+			added   []ShadowRank
+			deleted []string
+		)
+
+		f, err := BuildFlagFunc(
+			listFunc,
+			getRankFunc,
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error {
+				deleted = append(deleted, lb.ID)
+				return nil
+			},
+			func(ctx context.Context, f Flag) error {
+				created = f
+				return nil
+			},
+			func(ctx context.Context, gameID, playerID string, rank ShadowRank) error {
+				added = append(added, rank)
+				return nil
+			},
+		)(ctx, NewFlagData{GameID: "game", PlayerID: "suspect", Reason: "score spike"})
+		assert.NoError(t, err)
+		assert.Empty(t, created.ShadowRanks)
+		assert.Equal(t, []ShadowRank{{LeaderboardID: "season", Value: 42, Previous: value(42)}}, added)
+		assert.Equal(t, []string{"season"}, deleted)
+		assert.Equal(t, added, f.ShadowRanks)
+	})
+
+	t.Run("Already Flagged", func(t *testing.T) {
+		_, err := BuildFlagFunc(listFunc, getRankFunc, nil, func(ctx context.Context, f Flag) error {
+			return ErrPlayerAlreadyFlagged
+		}, nil)(ctx, NewFlagData{GameID: "game", PlayerID: "suspect"})
+		assert.ErrorIs(t, err, ErrPlayerAlreadyFlagged)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildFlagFunc(nil, nil, nil, nil, nil)(ctx, NewFlagData{Reason: strings.Repeat("a", MaxReasonLength+1)})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+		assert.ErrorIs(t, err, ErrInvalidReason)
+	})
+}
+
+func TestBuildClearFunc(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		flag = Flag{GameID: "game", PlayerID: "suspect", ShadowRanks: []ShadowRank{
+			{LeaderboardID: "season", Value: 900, Previous: value(100)},
+			{LeaderboardID: "weekly", Value: 50},
+			{LeaderboardID: "deleted", Value: 10, Previous: value(5)},
+		}}
+		getLeaderboardFunc = func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			if id == "deleted" {
+				return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+			}
+
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		}
+		deleteFunc = func(ctx context.Context, gameID, playerID string) (Flag, error) {
+			return flag, nil
+		}
+	)
+
+	t.Run("Merge", func(t *testing.T) {
+		restored := make(map[string]float64)
+		f, err := BuildClearFunc(getLeaderboardFunc, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			restored[lb.ID] = value
+			return nil
+		}, nil, deleteFunc)(ctx, "game", "suspect", true)
+		assert.NoError(t, err)
+		assert.Equal(t, flag, f)
+		assert.Equal(t, map[string]float64{"season": 900, "weekly": 50}, restored)
+	})
+
+	t.Run("Discard", func(t *testing.T) {
+		restored := make(map[string]float64)
+		_, err := BuildClearFunc(getLeaderboardFunc, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			restored[lb.ID] = value
+			return nil
+		}, nil, deleteFunc)(ctx, "game", "suspect", false)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]float64{"season": 100}, restored)
+	})
+
+	t.Run("Restore Failed", func(t *testing.T) {
+		var (
+			errUpsert = errors.New("upsert failed")
+			stored    Flag
+		)
+
+		_, err := BuildClearFunc(getLeaderboardFunc, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			if lb.ID == "weekly" {
+				return errUpsert
+			}
+
+			return nil
+		}, func(ctx context.Context, f Flag) error {
+			stored = f
+			return nil
+		}, deleteFunc)(ctx, "game", "suspect", true)
+		assert.ErrorIs(t, err, errUpsert)
+		assert.Equal(t, flag.ShadowRanks[1:], stored.ShadowRanks)
+	})
+
+	t.Run("Invalid Player", func(t *testing.T) {
+		_, err := BuildClearFunc(nil, nil, nil, nil)(ctx, "game", "", true)
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+	})
+}
+
+func TestBuildRouteFlaggedFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		lb      = leaderboard.Leaderboard{ID: "season", GameID: "game", AggregationMode: leaderboard.AggregationModeInc}
+		getFlag = func(ctx context.Context, gameID, playerID string) (Flag, error) {
+			if playerID != "suspect" {
+				return Flag{}, ErrFlagNotFound
+			}
+
+			return Flag{GameID: gameID, PlayerID: playerID, ShadowRanks: []ShadowRank{{LeaderboardID: "season", Value: 30, Previous: value(10)}}}, nil
+		}
+		getRankFunc = func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			return leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Value: 70}, nil
+		}
+	)
+
+	t.Run("Not Flagged", func(t *testing.T) {
+		applied := false
+		err := BuildRouteFlaggedFunc(getFlag, nil, nil, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			applied = true
+			return nil
+		})(ctx, lb, "honest", 5)
+		assert.NoError(t, err)
+		assert.True(t, applied)
+	})
+
+	t.Run("Shadow Rank", func(t *testing.T) {
+		var set ShadowRank
+		err := BuildRouteFlaggedFunc(getFlag, nil, func(ctx context.Context, gameID, playerID string, rank ShadowRank) error {
+			set = rank
+			return nil
+		}, nil)(ctx, lb, "suspect", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, ShadowRank{LeaderboardID: "season", Value: 35}, set)
+	})
+
+	t.Run("New Shadow Rank", func(t *testing.T) {
+		var set ShadowRank
+		err := BuildRouteFlaggedFunc(getFlag, getRankFunc, func(ctx context.Context, gameID, playerID string, rank ShadowRank) error {
+			set = rank
+			return nil
+		}, nil)(ctx, leaderboard.Leaderboard{ID: "weekly", GameID: "game", AggregationMode: leaderboard.AggregationModeMax}, "suspect", 50)
+		assert.NoError(t, err)
+		assert.Equal(t, ShadowRank{LeaderboardID: "weekly", Value: 70, Previous: value(70)}, set)
+	})
+
+	t.Run("Cleared Concurrently", func(t *testing.T) {
+		applied := false
+		err := BuildRouteFlaggedFunc(getFlag, nil, func(ctx context.Context, gameID, playerID string, rank ShadowRank) error {
+			return ErrFlagNotFound
+		}, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			applied = true
+			return nil
+		})(ctx, lb, "suspect", 5)
+		assert.NoError(t, err)
+		assert.True(t, applied)
+	})
+}
+
+func TestBuildShowShadowRankFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		lb      = leaderboard.Leaderboard{ID: "season", GameID: "game", Ordering: leaderboard.OrderingDesc}
+		getFlag = func(ctx context.Context, gameID, playerID string) (Flag, error) {
+			return Flag{GameID: gameID, PlayerID: playerID, ShadowRanks: []ShadowRank{{LeaderboardID: "season", Value: 55}}}, nil
+		}
+	)
+
+	t.Run("Shadow Rank", func(t *testing.T) {
+		for _, c := range []struct {
+			ranking  []float64
+			expected int64
+		}{
+			{ranking: nil, expected: 0},
+			{ranking: []float64{100}, expected: 1},
+			{ranking: []float64{10}, expected: 0},
+			{ranking: []float64{100, 90, 80, 70, 60, 55, 50, 40, 30, 20}, expected: 6},
+			{ranking: []float64{100, 90, 80, 70, 60, 55, 55, 55}, expected: 8},
+		} {
+			rank, err := BuildShowShadowRankFunc(getFlag, rankingFunc(c.ranking...), nil)(ctx, lb, "suspect")
+			assert.NoError(t, err)
+			assert.Equal(t, leaderboard.Rank{LeaderboardID: "season", PlayerID: "suspect", Position: c.expected, Value: 55}, rank)
+		}
+	})
+
+	t.Run("Not Flagged", func(t *testing.T) {
+		rank, err := BuildShowShadowRankFunc(func(ctx context.Context, gameID, playerID string) (Flag, error) {
+			return Flag{}, ErrFlagNotFound
+		}, nil, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+			return leaderboard.Rank{PlayerID: playerID, Position: 3}, nil
+		})(ctx, lb, "honest")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), rank.Position)
+	})
+}
+
+func TestBuildMergeViewerFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		lb      = leaderboard.Leaderboard{ID: "season", GameID: "game", Ordering: leaderboard.OrderingDesc}
+		values  = []float64{100, 90, 80, 70, 60, 50}
+		ranking = rankingFunc(values...)
+		getFlag = func(ctx context.Context, gameID, playerID string) (Flag, error) {
+			return Flag{GameID: gameID, PlayerID: playerID, ShadowRanks: []ShadowRank{{LeaderboardID: "season", Value: 75}}}, nil
+		}
+		page = func(page, limit int64) []leaderboard.Rank {
+			ranks, _ := ranking(ctx, lb, page, limit)
+			return ranks
+		}
+	)
+
+	t.Run("On The Page", func(t *testing.T) {
+		ranks, err := BuildMergeViewerFunc(getFlag, ranking)(ctx, lb, "suspect", page(0, 4), 0, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, []leaderboard.Rank{
+			{LeaderboardID: "season", PlayerID: "a", Position: 0, Value: 100},
+			{LeaderboardID: "season", PlayerID: "b", Position: 1, Value: 90},
+			{LeaderboardID: "season", PlayerID: "c", Position: 2, Value: 80},
+			{LeaderboardID: "season", PlayerID: "suspect", Position: 3, Value: 75},
+		}, ranks)
+	})
+
+	t.Run("Page Before", func(t *testing.T) {
+		ranks, err := BuildMergeViewerFunc(getFlag, ranking)(ctx, lb, "suspect", page(1, 4), 1, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, []leaderboard.Rank{
+			{LeaderboardID: "season", PlayerID: "d", Position: 4, Value: 70},
+			{LeaderboardID: "season", PlayerID: "e", Position: 5, Value: 60},
+			{LeaderboardID: "season", PlayerID: "f", Position: 6, Value: 50},
+		}, ranks)
+	})
+
+	t.Run("Page After", func(t *testing.T) {
+		ranks, err := BuildMergeViewerFunc(getFlag, ranking)(ctx, lb, "suspect", page(0, 2), 0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, page(0, 2), ranks)
+	})
+
+	t.Run("Not Flagged", func(t *testing.T) {
+		ranks, err := BuildMergeViewerFunc(func(ctx context.Context, gameID, playerID string) (Flag, error) {
+			return Flag{}, ErrFlagNotFound
+		}, ranking)(ctx, lb, "honest", page(0, 4), 0, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, page(0, 4), ranks)
+	})
+}
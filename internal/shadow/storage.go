@@ -0,0 +1,38 @@
+package shadow
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type (
+	// Stores the flag. Fails with `ErrPlayerAlreadyFlagged` when the player is flagged on the game already
+	StorageCreateFlagFunc func(ctx context.Context, f Flag) error
+
+	// Player's flag. Fails with `ErrFlagNotFound` when the player isn't flagged
+	StorageGetFlagFunc func(ctx context.Context, gameID, playerID string) (Flag, error)
+
+	// Adds the shadow rank to the player's flag, keeping the one the flag has on its leaderboard already.
+	// Fails with `ErrFlagNotFound` when the player isn't flagged
+	StorageAddShadowRankFunc func(ctx context.Context, gameID, playerID string, rank ShadowRank) error
+
+	// Sets the value of the flag's shadow rank on the leaderboard, adding the rank when the flag has none there.
+	// The previous value of a rank already there is kept. Fails with `ErrFlagNotFound` when the player isn't flagged
+	StorageSetShadowRankFunc func(ctx context.Context, gameID, playerID string, rank ShadowRank) error
+
+	// Removes the player's flag at once, returning it. Fails with `ErrFlagNotFound` when the player isn't flagged
+	StorageDeleteFlagFunc func(ctx context.Context, gameID, playerID string) (Flag, error)
+
+	// Lists the flags of the game, oldest first
+	StorageListFlagsFunc func(ctx context.Context, gameID string) ([]Flag, error)
+
+	// Lists the leaderboards of the game that are not soft deleted
+	StorageListLeaderboardsFunc func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error)
+
+	// Leaderboard by its id and game id
+	StorageGetLeaderboardFunc func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error)
+
+	// Removes the player from the leaderboard ranking
+	StorageDeletePlayerRankFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) error
+)
@@ -0,0 +1,25 @@
+package shadow
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+)
+
+type (
+	// Flags the player on the game, moving their ranks to the shadow copies of the leaderboards
+	FlagFunc func(ctx context.Context, data NewFlagData) (Flag, error)
+
+	// Clears the player's flag. Merging puts the shadow ranks on the rankings, otherwise the ranks the player had when
+	// flagged are put back. Returns the flag cleared
+	ClearFunc func(ctx context.Context, gameID, playerID string, merge bool) (Flag, error)
+
+	// Get the player's flag
+	GetFunc func(ctx context.Context, gameID, playerID string) (Flag, error)
+
+	// List the flags of the game, oldest first
+	ListFunc func(ctx context.Context, gameID string) ([]Flag, error)
+
+	// Places the shadow rank of the player viewing the ranking page on it, when the player is flagged
+	MergeViewerFunc func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, ranks []leaderboard.Rank, page, limit int64) ([]leaderboard.Rank, error)
+)
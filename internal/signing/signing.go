@@ -0,0 +1,142 @@
+package signing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Fewest bytes of the signing key, the size of the HMAC-SHA256 output
+	MinKeySize = 32
+
+	// Random bytes of each nonce
+	nonceSize = 32
+)
+
+var (
+	ErrInvalidKey      = errors.New("signing key must have at least 32 bytes")
+	ErrInvalidNonceTTL = errors.New("nonce ttl must be greater than zero")
+	ErrInvalidGameID   = errors.New("invalid game id")
+	ErrInvalidPlayerID = errors.New("invalid player id")
+
+	ErrMissingSignature = errors.New("the submission must carry a nonce and its signature")
+	ErrInvalidSignature = errors.New("invalid submission signature")
+	ErrNonceNotFound    = errors.New("nonce not found, it expired, was already used or was issued to another player")
+)
+
+type (
+	// Single-use value issued to a player, signed alongside the next submission
+	Nonce struct {
+		Value     string    // Random value, URL safe
+		GameID    string    // Game the nonce was issued on
+		PlayerID  string    // Player the nonce was issued to
+		ExpiresAt time.Time // Time the nonce can no longer be used
+	}
+
+	// Score submission carrying the nonce it was signed with
+	Submission struct {
+		GameID        string  // Game the leaderboard belongs to
+		LeaderboardID string  // Leaderboard the value is submitted to
+		PlayerID      string  // Player submitting the value
+		Value         float64 // Value submitted
+		Nonce         string  // Nonce issued to the player
+		Signature     string  // Hex encoded HMAC-SHA256 of the submission, see `Sign`
+	}
+)
+
+// Game's own signing key, derived from the master key so a game can't sign the submissions of another one
+func GameKey(key []byte, gameID string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(gameID))
+	return mac.Sum(nil)
+}
+
+// Hex encoded HMAC-SHA256, with the game's key, of the player ID, leaderboard ID, value and nonce, joined by new lines.
+// The value is written on its shortest decimal representation, e.g. `120.5`
+func Sign(gameKey []byte, playerID, leaderboardID string, value float64, nonce string) string {
+	mac := hmac.New(sha256.New, gameKey)
+	mac.Write([]byte(strings.Join([]string{playerID, leaderboardID, strconv.FormatFloat(value, 'f', -1, 64), nonce}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Base64 encoded signing key of the game, handed to the game so its clients can sign their submissions
+func BuildGetGameKeyFunc(key []byte) (GetGameKeyFunc, error) {
+	if len(key) < MinKeySize {
+		return nil, ErrInvalidKey
+	}
+
+	return func(ctx context.Context, gameID string) (string, error) {
+		if gameID == "" {
+			return "", ErrInvalidGameID
+		}
+
+		return base64.StdEncoding.EncodeToString(GameKey(key, gameID)), nil
+	}, nil
+}
+
+// Each nonce can be used once, within `ttl` from its issue
+func BuildIssueNonceFunc(ttl time.Duration, storageCreateNonceFunc StorageCreateNonceFunc) (IssueNonceFunc, error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidNonceTTL
+	}
+
+	return func(ctx context.Context, gameID, playerID string) (Nonce, error) {
+		if gameID == "" {
+			return Nonce{}, ErrInvalidGameID
+		}
+
+		if playerID == "" {
+			return Nonce{}, ErrInvalidPlayerID
+		}
+
+		value := make([]byte, nonceSize)
+		if _, err := rand.Read(value); err != nil {
+			return Nonce{}, err
+		}
+
+		nonce := Nonce{
+			Value:     base64.RawURLEncoding.EncodeToString(value),
+			GameID:    gameID,
+			PlayerID:  playerID,
+			ExpiresAt: time.Now().UTC().Add(ttl),
+		}
+		if err := storageCreateNonceFunc(ctx, nonce, ttl); err != nil {
+			return Nonce{}, err
+		}
+
+		return nonce, nil
+	}, nil
+}
+
+// The signature is checked before the nonce is used, so a submission with a wrong signature doesn't spend it
+func BuildVerifyFunc(key []byte, storageConsumeNonceFunc StorageConsumeNonceFunc) (VerifyFunc, error) {
+	if len(key) < MinKeySize {
+		return nil, ErrInvalidKey
+	}
+
+	return func(ctx context.Context, s Submission) error {
+		if s.Nonce == "" || s.Signature == "" {
+			return ErrMissingSignature
+		}
+
+		signature, err := hex.DecodeString(s.Signature)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+
+		expected, _ := hex.DecodeString(Sign(GameKey(key, s.GameID), s.PlayerID, s.LeaderboardID, s.Value, s.Nonce))
+		if !hmac.Equal(signature, expected) {
+			return ErrInvalidSignature
+		}
+
+		return storageConsumeNonceFunc(ctx, s.GameID, s.PlayerID, s.Nonce)
+	}, nil
+}
@@ -0,0 +1,179 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testKey = bytes.Repeat([]byte{7}, MinKeySize)
+
+func TestGameKey(t *testing.T) {
+	assert.Len(t, GameKey(testKey, "game"), 32)
+	assert.Equal(t, GameKey(testKey, "game"), GameKey(testKey, "game"))
+	assert.NotEqual(t, GameKey(testKey, "game"), GameKey(testKey, "other game"))
+}
+
+func TestSign(t *testing.T) {
+	key := GameKey(testKey, "game")
+
+	signature := Sign(key, "alice", "leaderboard", 120.5, "nonce")
+	assert.Len(t, signature, 64)
+	assert.Equal(t, signature, Sign(key, "alice", "leaderboard", 120.5, "nonce"))
+	assert.NotEqual(t, signature, Sign(key, "alice", "leaderboard", 1205, "nonce"))
+	assert.NotEqual(t, signature, Sign(key, "alice", "leaderboard", 120.5, "other nonce"))
+	assert.NotEqual(t, signature, Sign(key, "bob", "leaderboard", 120.5, "nonce"))
+}
+
+func TestBuildGetGameKeyFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		getGameKey, err := BuildGetGameKeyFunc(testKey)
+		assert.NoError(t, err)
+
+		key, err := getGameKey(ctx, "game")
+		assert.NoError(t, err)
+		assert.Equal(t, base64.StdEncoding.EncodeToString(GameKey(testKey, "game")), key)
+	})
+
+	t.Run("Invalid Key", func(t *testing.T) {
+		_, err := BuildGetGameKeyFunc([]byte("short"))
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("Invalid Game", func(t *testing.T) {
+		getGameKey, err := BuildGetGameKeyFunc(testKey)
+		assert.NoError(t, err)
+
+		_, err = getGameKey(ctx, "")
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+	})
+}
+
+func TestBuildIssueNonceFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		var created Nonce
+		issue, err := BuildIssueNonceFunc(time.Minute, func(ctx context.Context, nonce Nonce, ttl time.Duration) error {
+			assert.Equal(t, time.Minute, ttl)
+
+			created = nonce
+			return nil
+		})
+		assert.NoError(t, err)
+
+		nonce, err := issue(ctx, "game", "alice")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, nonce.Value)
+		assert.Equal(t, "game", nonce.GameID)
+		assert.Equal(t, "alice", nonce.PlayerID)
+		assert.True(t, nonce.ExpiresAt.After(time.Now()))
+		assert.Equal(t, created, nonce)
+
+		other, err := issue(ctx, "game", "alice")
+		assert.NoError(t, err)
+		assert.NotEqual(t, nonce.Value, other.Value)
+	})
+
+	t.Run("Invalid TTL", func(t *testing.T) {
+		_, err := BuildIssueNonceFunc(0, nil)
+		assert.ErrorIs(t, err, ErrInvalidNonceTTL)
+	})
+
+	t.Run("Invalid Player", func(t *testing.T) {
+		issue, err := BuildIssueNonceFunc(time.Minute, nil)
+		assert.NoError(t, err)
+
+		_, err = issue(ctx, "game", "")
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		storageErr := errors.New("any storage error")
+		issue, err := BuildIssueNonceFunc(time.Minute, func(ctx context.Context, nonce Nonce, ttl time.Duration) error {
+			return storageErr
+		})
+		assert.NoError(t, err)
+
+		_, err = issue(ctx, "game", "alice")
+		assert.ErrorIs(t, err, storageErr)
+	})
+}
+
+func TestBuildVerifyFunc(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		consumed []string
+	)
+
+	verify, err := BuildVerifyFunc(testKey, func(ctx context.Context, gameID, playerID, nonce string) error {
+		for _, n := range consumed {
+			if n == nonce {
+				return ErrNonceNotFound
+			}
+		}
+
+		consumed = append(consumed, nonce)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	submission := Submission{GameID: "game", LeaderboardID: "leaderboard", PlayerID: "alice", Value: 120.5, Nonce: "nonce"}
+	submission.Signature = Sign(GameKey(testKey, "game"), "alice", "leaderboard", 120.5, "nonce")
+
+	t.Run("Invalid Key", func(t *testing.T) {
+		_, err := BuildVerifyFunc([]byte("short"), nil)
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("Missing Signature", func(t *testing.T) {
+		s := submission
+		s.Signature = ""
+
+		err := verify(ctx, s)
+		assert.ErrorIs(t, err, ErrMissingSignature)
+	})
+
+	t.Run("Tampered Value", func(t *testing.T) {
+		s := submission
+		s.Value = 9999
+
+		err := verify(ctx, s)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+		assert.Empty(t, consumed)
+	})
+
+	t.Run("Another Game's Key", func(t *testing.T) {
+		s := submission
+		s.Signature = Sign(GameKey(testKey, "other game"), "alice", "leaderboard", 120.5, "nonce")
+
+		err := verify(ctx, s)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("Malformed Signature", func(t *testing.T) {
+		s := submission
+		s.Signature = "not hex"
+
+		err := verify(ctx, s)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		err := verify(ctx, submission)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"nonce"}, consumed)
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		err := verify(ctx, submission)
+		assert.ErrorIs(t, err, ErrNonceNotFound)
+	})
+}
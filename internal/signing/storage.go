@@ -0,0 +1,15 @@
+package signing
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Keeps the nonce until it's used or `ttl` goes by
+	StorageCreateNonceFunc func(ctx context.Context, nonce Nonce, ttl time.Duration) error
+
+	// Removes the nonce issued to the player at once, so it's used once even by concurrent submissions.
+	// Fails with `ErrNonceNotFound` when there's no such nonce for the player
+	StorageConsumeNonceFunc func(ctx context.Context, gameID, playerID, nonce string) error
+)
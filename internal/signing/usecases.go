@@ -0,0 +1,14 @@
+package signing
+
+import "context"
+
+type (
+	// Base64 encoded key the game's clients sign their submissions with
+	GetGameKeyFunc func(ctx context.Context, gameID string) (string, error)
+
+	// Issues a nonce to the player, to be signed alongside their next submission
+	IssueNonceFunc func(ctx context.Context, gameID, playerID string) (Nonce, error)
+
+	// Checks the submission's signature and uses its nonce, failing when either is invalid
+	VerifyFunc func(ctx context.Context, s Submission) error
+)
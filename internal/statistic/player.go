@@ -23,6 +23,11 @@ type (
 	}
 )
 
+// Checks if the update made the player reach the goal or any landmark
+func (u PlayerProgressionUpdates) HasCompletions() bool {
+	return u.GoalJustCompleted || len(u.LandmarksJustCompleted) > 0
+}
+
 type (
 	PlayerProgressionLandmark struct {
 		Value       float64   // Landmark value
@@ -41,8 +46,16 @@ type (
 		GoalCompletedAt time.Time                   // Time the player reached the goal
 		Landmarks       []PlayerProgressionLandmark // Landmarks player progression
 	}
+
+	// Value to be aggregated on the player progression
+	PlayerProgressionChange struct {
+		Statistic Statistic // Statistic the player progressed on
+		PlayerID  string    // Player's ID
+		Value     float64   // Value aggregated on the player progression
+	}
 )
 
+// The notifier can be nil when the storage already records the updates to be published, e.g. on an outbox
 func BuildUpsertPlayerProgressionFunc(
 	notifierPlayerProgressionUpdates NotifierPlayerProgressionUpdates,
 	storageUpdatePlayerProgressionFunc StorageUpdatePlayerProgressionFunc,
@@ -53,7 +66,7 @@ func BuildUpsertPlayerProgressionFunc(
 			return err
 		}
 
-		if len(playerProgressionUpdates.LandmarksJustCompleted) > 0 || playerProgressionUpdates.GoalJustCompleted {
+		if notifierPlayerProgressionUpdates != nil && playerProgressionUpdates.HasCompletions() {
 			return notifierPlayerProgressionUpdates(ctx, statistic, playerProgression, playerProgressionUpdates)
 		}
 
@@ -61,6 +74,78 @@ func BuildUpsertPlayerProgressionFunc(
 	}
 }
 
+// Combines two values sent to the same progression into one with the same effect
+func mergeValues(aggregationMode string, a, b float64) float64 {
+	switch aggregationMode {
+	case AggregationModeMax:
+		return max(a, b)
+	case AggregationModeMin:
+		return min(a, b)
+	default:
+		return a + b
+	}
+}
+
+// Changes on the same player progression are merged before being stored, so each progression is written once.
+// The notifier can be nil when the storage already records the updates to be published, e.g. on an outbox
+func BuildBulkUpsertPlayerProgressionFunc(
+	notifierPlayerProgressionUpdates NotifierPlayerProgressionUpdates,
+	storageBulkUpdatePlayerProgressionFunc StorageBulkUpdatePlayerProgressionFunc,
+) BulkUpsertPlayerProgressionFunc {
+	type key struct{ statisticID, playerID string }
+
+	return func(ctx context.Context, changes []PlayerProgressionChange) []error {
+		var (
+			errs    = make([]error, len(changes))
+			merged  = make([]PlayerProgressionChange, 0, len(changes))
+			indexes = make(map[key]int)
+			owners  = make([]int, len(changes)) // Merged change index of each change
+		)
+		for i, change := range changes {
+			k := key{statisticID: change.Statistic.ID, playerID: change.PlayerID}
+
+			j, ok := indexes[k]
+			if !ok {
+				j = len(merged)
+				indexes[k] = j
+				merged = append(merged, change)
+			} else {
+				merged[j].Value = mergeValues(change.Statistic.AggregationMode, merged[j].Value, change.Value)
+			}
+
+			owners[i] = j
+		}
+
+		if len(merged) == 0 {
+			return errs
+		}
+
+		playerProgressions, playerProgressionsUpdates, err := storageBulkUpdatePlayerProgressionFunc(ctx, merged)
+		if err != nil {
+			for i := range errs {
+				errs[i] = err
+			}
+
+			return errs
+		}
+
+		mergedErrs := make([]error, len(merged))
+		if notifierPlayerProgressionUpdates != nil {
+			for j, change := range merged {
+				if playerProgressionsUpdates[j].HasCompletions() {
+					mergedErrs[j] = notifierPlayerProgressionUpdates(ctx, change.Statistic, playerProgressions[j], playerProgressionsUpdates[j])
+				}
+			}
+		}
+
+		for i, j := range owners {
+			errs[i] = mergedErrs[j]
+		}
+
+		return errs
+	}
+}
+
 func BuildGetPlayerProgression(storageGetPlayerProgressionFunc StorageGetPlayerProgressionFunc) GetPlayerProgressionFunc {
 	return func(ctx context.Context, statisticID, playerID string) (PlayerProgression, error) {
 		return storageGetPlayerProgressionFunc(ctx, statisticID, playerID)
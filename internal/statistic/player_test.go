@@ -2,6 +2,7 @@ package statistic
 
 import (
 	"context"
+	"errors"
 	"math/rand"
 	"testing"
 
@@ -98,6 +99,22 @@ func TestBuildUpsertPlayerProgressionFunc(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("OK With Goal Completed Without Notifier", func(t *testing.T) {
+		updatePlayerProgressionFunc := BuildUpsertPlayerProgressionFunc(
+			nil,
+			func(ctx context.Context, statistic Statistic, playerID string, value float64) (PlayerProgression, PlayerProgressionUpdates, error) {
+				return PlayerProgression{}, PlayerProgressionUpdates{GoalJustCompleted: true}, nil
+			},
+		)
+
+		statistic := Statistic{
+			AggregationMode: AggregationModeSum,
+		}
+
+		err := updatePlayerProgressionFunc(ctx, statistic, playerID, rand.Float64())
+		assert.NoError(t, err)
+	})
+
 	t.Run("Invalid Aggregation Mode", func(t *testing.T) {
 		var (
 			updatePlayerProgressionFunc = BuildUpsertPlayerProgressionFunc(
@@ -114,3 +131,100 @@ func TestBuildUpsertPlayerProgressionFunc(t *testing.T) {
 		assert.ErrorIs(t, err, ErrInvalidAggregationMode)
 	})
 }
+
+func TestBuildBulkUpsertPlayerProgressionFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+
+		sumStatistic = Statistic{ID: uuid.NewString(), AggregationMode: AggregationModeSum}
+		maxStatistic = Statistic{ID: uuid.NewString(), AggregationMode: AggregationModeMax}
+
+		playerID = uuid.NewString()
+	)
+
+	t.Run("OK Merging Changes On The Same Progression", func(t *testing.T) {
+		var stored []PlayerProgressionChange
+
+		bulkUpsertPlayerProgressionFunc := BuildBulkUpsertPlayerProgressionFunc(
+			nil,
+			func(ctx context.Context, changes []PlayerProgressionChange) ([]PlayerProgression, []PlayerProgressionUpdates, error) {
+				stored = changes
+				return make([]PlayerProgression, len(changes)), make([]PlayerProgressionUpdates, len(changes)), nil
+			},
+		)
+
+		errs := bulkUpsertPlayerProgressionFunc(ctx, []PlayerProgressionChange{
+			{Statistic: sumStatistic, PlayerID: playerID, Value: 1},
+			{Statistic: maxStatistic, PlayerID: playerID, Value: 5},
+			{Statistic: sumStatistic, PlayerID: playerID, Value: 2},
+			{Statistic: maxStatistic, PlayerID: playerID, Value: 3},
+		})
+		assert.Equal(t, []error{nil, nil, nil, nil}, errs)
+		assert.Equal(t, []PlayerProgressionChange{
+			{Statistic: sumStatistic, PlayerID: playerID, Value: 3},
+			{Statistic: maxStatistic, PlayerID: playerID, Value: 5},
+		}, stored)
+	})
+
+	t.Run("OK Notifying Completions", func(t *testing.T) {
+		notified := 0
+
+		bulkUpsertPlayerProgressionFunc := BuildBulkUpsertPlayerProgressionFunc(
+			func(ctx context.Context, statistic Statistic, progression PlayerProgression, updates PlayerProgressionUpdates) error {
+				notified++
+				return nil
+			},
+			func(ctx context.Context, changes []PlayerProgressionChange) ([]PlayerProgression, []PlayerProgressionUpdates, error) {
+				return make([]PlayerProgression, len(changes)), []PlayerProgressionUpdates{{GoalJustCompleted: true}, {}}, nil
+			},
+		)
+
+		errs := bulkUpsertPlayerProgressionFunc(ctx, []PlayerProgressionChange{
+			{Statistic: sumStatistic, PlayerID: playerID, Value: 1},
+			{Statistic: maxStatistic, PlayerID: playerID, Value: 1},
+		})
+		assert.Equal(t, []error{nil, nil}, errs)
+		assert.Equal(t, 1, notified)
+	})
+
+	t.Run("Notifier Error", func(t *testing.T) {
+		var (
+			errNotifier = errors.New("any error")
+
+			bulkUpsertPlayerProgressionFunc = BuildBulkUpsertPlayerProgressionFunc(
+				func(ctx context.Context, statistic Statistic, progression PlayerProgression, updates PlayerProgressionUpdates) error {
+					return errNotifier
+				},
+				func(ctx context.Context, changes []PlayerProgressionChange) ([]PlayerProgression, []PlayerProgressionUpdates, error) {
+					return make([]PlayerProgression, len(changes)), []PlayerProgressionUpdates{{GoalJustCompleted: true}, {}}, nil
+				},
+			)
+		)
+
+		errs := bulkUpsertPlayerProgressionFunc(ctx, []PlayerProgressionChange{
+			{Statistic: sumStatistic, PlayerID: playerID, Value: 1},
+			{Statistic: maxStatistic, PlayerID: playerID, Value: 1},
+			{Statistic: sumStatistic, PlayerID: playerID, Value: 1},
+		})
+		assert.Equal(t, []error{errNotifier, nil, errNotifier}, errs)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		var (
+			errStorage = errors.New("any error")
+
+			bulkUpsertPlayerProgressionFunc = BuildBulkUpsertPlayerProgressionFunc(
+				nil,
+				func(ctx context.Context, changes []PlayerProgressionChange) ([]PlayerProgression, []PlayerProgressionUpdates, error) {
+					return nil, nil, errStorage
+				},
+			)
+		)
+
+		errs := bulkUpsertPlayerProgressionFunc(ctx, []PlayerProgressionChange{
+			{Statistic: sumStatistic, PlayerID: playerID, Value: 1},
+			{Statistic: maxStatistic, PlayerID: playerID, Value: 1},
+		})
+		assert.Equal(t, []error{errStorage, errStorage}, errs)
+	})
+}
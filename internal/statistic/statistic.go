@@ -8,12 +8,13 @@ import (
 )
 
 var (
-	ErrStatisticValidation    = errors.New("invalid statistic")
-	ErrInvalidStatisticID     = errors.New("invalid id")
-	ErrInvalidName            = errors.New("invalid name")
-	ErrMissingGameID          = errors.New("missing game id")
-	ErrInvalidAggregationMode = errors.New("invalid aggregation mode")
-	ErrStatisticNotFound      = errors.New("statistic not found")
+	ErrStatisticValidation       = errors.New("invalid statistic")
+	ErrInvalidStatisticID        = errors.New("invalid id")
+	ErrInvalidName               = errors.New("invalid name")
+	ErrMissingGameID             = errors.New("missing game id")
+	ErrInvalidAggregationMode    = errors.New("invalid aggregation mode")
+	ErrStatisticNotFound         = errors.New("statistic not found")
+	ErrStatisticNameAlreadyInUse = errors.New("statistic name already in use")
 )
 
 const (
@@ -92,6 +93,12 @@ func BuildGetStatisticByIDAndGameID(storageGetStatisticByIDAndGameID StorageGetS
 	}
 }
 
+func BuildListStatisticsFunc(storageListStatisticsFunc StorageListStatisticsFunc) ListFunc {
+	return func(ctx context.Context, gameID string) ([]Statistic, error) {
+		return storageListStatisticsFunc(ctx, gameID)
+	}
+}
+
 func BuildSoftDeleteStatistic(storageSoftDeleteStatistic StorageSoftDeleteStatistic) SoftDeleteByIDAndGameIDFunc {
 	return func(ctx context.Context, id, gameID string) error {
 		return storageSoftDeleteStatistic(ctx, id, gameID)
@@ -9,12 +9,19 @@ type (
 	// Get statistic by is and game id
 	StorageGetStatisticByIDAndGameID func(ctx context.Context, id, gameID string) (Statistic, error)
 
+	// List the game's statistics not deleted
+	StorageListStatisticsFunc func(ctx context.Context, gameID string) ([]Statistic, error)
+
 	// Soft delete a statistic by id and game id
 	StorageSoftDeleteStatistic func(ctx context.Context, id, gameID string) error
 
 	// Updates the player statistic progression using the provided value
 	StorageUpdatePlayerProgressionFunc func(ctx context.Context, statistic Statistic, playerID string, value float64) (PlayerProgression, PlayerProgressionUpdates, error)
 
+	// Updates the players statistic progression using the provided values, applying every change or none of them.
+	// The progressions and updates are returned in the same order as the changes
+	StorageBulkUpdatePlayerProgressionFunc func(ctx context.Context, changes []PlayerProgressionChange) ([]PlayerProgression, []PlayerProgressionUpdates, error)
+
 	// Get player progression by statistic id and player id
 	StorageGetPlayerProgressionFunc func(ctx context.Context, statisticID, playerID string) (PlayerProgression, error)
 )
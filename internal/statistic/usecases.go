@@ -9,12 +9,18 @@ type (
 	// Get statistic by is and game id
 	GetByIDAndGameIDFunc func(ctx context.Context, id, gameID string) (Statistic, error)
 
+	// List the game's statistics, skipping the deleted ones
+	ListFunc func(ctx context.Context, gameID string) ([]Statistic, error)
+
 	// Soft delete a statistic by id and game id
 	SoftDeleteByIDAndGameIDFunc func(ctx context.Context, id, gameID string) error
 
 	// Update player statistic progression using the provided value
 	UpsertPlayerProgressionFunc func(ctx context.Context, statistic Statistic, playerID string, value float64) error
 
+	// Update many players statistic progression at once, returning the error of each change in the same order
+	BulkUpsertPlayerProgressionFunc func(ctx context.Context, changes []PlayerProgressionChange) []error
+
 	// Get player progression by statistic id and player id
 	GetPlayerProgressionFunc func(ctx context.Context, statisticID, playerID string) (PlayerProgression, error)
 )
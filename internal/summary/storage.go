@@ -0,0 +1,20 @@
+package summary
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+type (
+	// Lists the leaderboards of the game that are not soft deleted
+	StorageListLeaderboardsFunc func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error)
+
+	// Lists the statistics of the game that are not soft deleted
+	StorageListStatisticsFunc func(ctx context.Context, gameID string) ([]statistic.Statistic, error)
+
+	// Lists the quests of the game that are not soft deleted
+	StorageListQuestsFunc func(ctx context.Context, gameID string) ([]quest.Quest, error)
+)
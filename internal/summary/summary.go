@@ -0,0 +1,98 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/usage"
+)
+
+const (
+	// Days of score submissions on the summary, today included
+	SubmissionDays = 7
+	// Most frequent error codes on the summary, over the same days
+	TopErrorsLimit = 5
+)
+
+var ErrMissingGameID = errors.New("missing game id")
+
+type (
+	// Score submissions of a game on a day
+	DailySubmissions struct {
+		Day              time.Time // Start of the day, in UTC
+		ScoreSubmissions int64     // Rank and statistic progression updates
+	}
+
+	// Overview of a game, for the internal dashboards
+	Summary struct {
+		GameID             string             // Game summarized
+		ActiveLeaderboards int                // Leaderboards accepting scores right now
+		ActiveStatistics   int                // Statistics not deleted
+		ActiveQuests       int                // Quests not deleted
+		UsageTracked       bool               // Whether the submissions and errors below are tracked
+		Submissions        []DailySubmissions // Score submissions of each of the last days, oldest first, including the days without any
+		TopErrors          []usage.ErrorCount // Most frequent errors answered to the game over the same days, most frequent first
+	}
+)
+
+// Summarizes the game. The submissions and errors are left empty when `reportUsageFunc` is nil, as the usage isn't tracked
+func BuildSummarizeFunc(
+	storageListLeaderboardsFunc StorageListLeaderboardsFunc,
+	storageListStatisticsFunc StorageListStatisticsFunc,
+	storageListQuestsFunc StorageListQuestsFunc,
+	reportUsageFunc usage.ReportFunc,
+) SummarizeFunc {
+	return func(ctx context.Context, gameID string) (Summary, error) {
+		if gameID == "" {
+			return Summary{}, ErrMissingGameID
+		}
+
+		leaderboards, err := storageListLeaderboardsFunc(ctx, gameID)
+		if err != nil {
+			return Summary{}, err
+		}
+
+		statistics, err := storageListStatisticsFunc(ctx, gameID)
+		if err != nil {
+			return Summary{}, err
+		}
+
+		quests, err := storageListQuestsFunc(ctx, gameID)
+		if err != nil {
+			return Summary{}, err
+		}
+
+		summary := Summary{GameID: gameID, ActiveStatistics: len(statistics), ActiveQuests: len(quests)}
+		for _, lb := range leaderboards {
+			if !lb.Closed() {
+				summary.ActiveLeaderboards++
+			}
+		}
+
+		if reportUsageFunc == nil {
+			return summary, nil
+		}
+
+		to := usage.Day(time.Now())
+		report, err := reportUsageFunc(ctx, gameID, to.AddDate(0, 0, -(SubmissionDays-1)), to)
+		if err != nil {
+			return Summary{}, err
+		}
+
+		submissions := make(map[time.Time]int64, len(report.Days))
+		for _, d := range report.Days {
+			submissions[d.Day] = d.ScoreSubmissions
+		}
+
+		summary.UsageTracked = true
+		summary.Submissions = make([]DailySubmissions, SubmissionDays)
+		for i := range summary.Submissions {
+			day := report.From.AddDate(0, 0, i)
+			summary.Submissions[i] = DailySubmissions{Day: day, ScoreSubmissions: submissions[day]}
+		}
+		summary.TopErrors = report.Errors.Top(TopErrorsLimit)
+
+		return summary, nil
+	}
+}
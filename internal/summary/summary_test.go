@@ -0,0 +1,107 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quest"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+	"github.com/gabapcia/gameblitz/internal/usage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSummarizeFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		gameID = uuid.NewString()
+		now    = time.Now()
+		today  = usage.Day(now)
+	)
+
+	listLeaderboards := func(ctx context.Context, gameID string) ([]leaderboard.Leaderboard, error) {
+		return []leaderboard.Leaderboard{
+			{ID: uuid.NewString(), StartAt: now.Add(-time.Hour)},
+			{ID: uuid.NewString(), StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour)},
+			{ID: uuid.NewString(), StartAt: now.Add(-2 * time.Hour), EndAt: now.Add(-time.Hour)},
+			{ID: uuid.NewString(), StartAt: now.Add(time.Hour)},
+		}, nil
+	}
+
+	listStatistics := func(ctx context.Context, gameID string) ([]statistic.Statistic, error) {
+		return []statistic.Statistic{{ID: uuid.NewString()}}, nil
+	}
+
+	listQuests := func(ctx context.Context, gameID string) ([]quest.Quest, error) {
+		return []quest.Quest{{ID: uuid.NewString()}, {ID: uuid.NewString()}}, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var requestedFrom, requestedTo time.Time
+
+		summarize := BuildSummarizeFunc(listLeaderboards, listStatistics, listQuests, func(ctx context.Context, gameID string, from, to time.Time) (usage.Report, error) {
+			requestedFrom, requestedTo = from, to
+			return usage.Report{
+				GameID: gameID,
+				From:   from,
+				To:     to,
+				Errors: usage.ErrorCounts{"1.2": 3, "0.0": 1},
+				Days: []usage.DailyUsage{
+					{Day: from, ScoreSubmissions: 4},
+					{Day: to, ScoreSubmissions: 2},
+				},
+			}, nil
+		})
+
+		summary, err := summarize(ctx, gameID)
+		assert.NoError(t, err)
+
+		assert.Equal(t, today.AddDate(0, 0, -6), requestedFrom)
+		assert.Equal(t, today, requestedTo)
+
+		assert.Equal(t, gameID, summary.GameID)
+		assert.Equal(t, 2, summary.ActiveLeaderboards)
+		assert.Equal(t, 1, summary.ActiveStatistics)
+		assert.Equal(t, 2, summary.ActiveQuests)
+		assert.True(t, summary.UsageTracked)
+		assert.Equal(t, []usage.ErrorCount{{Code: "1.2", Count: 3}, {Code: "0.0", Count: 1}}, summary.TopErrors)
+
+		if assert.Len(t, summary.Submissions, SubmissionDays) {
+			assert.Equal(t, DailySubmissions{Day: requestedFrom, ScoreSubmissions: 4}, summary.Submissions[0])
+			assert.Equal(t, DailySubmissions{Day: requestedFrom.AddDate(0, 0, 1)}, summary.Submissions[1])
+			assert.Equal(t, DailySubmissions{Day: today, ScoreSubmissions: 2}, summary.Submissions[SubmissionDays-1])
+		}
+	})
+
+	t.Run("Usage Not Tracked", func(t *testing.T) {
+		summary, err := BuildSummarizeFunc(listLeaderboards, listStatistics, listQuests, nil)(ctx, gameID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, summary.ActiveLeaderboards)
+		assert.False(t, summary.UsageTracked)
+		assert.Nil(t, summary.Submissions)
+		assert.Nil(t, summary.TopErrors)
+	})
+
+	t.Run("Missing Game ID", func(t *testing.T) {
+		_, err := BuildSummarizeFunc(nil, nil, nil, nil)(ctx, "")
+		assert.ErrorIs(t, err, ErrMissingGameID)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		errStorage := errors.New("any error")
+
+		summarize := BuildSummarizeFunc(
+			listLeaderboards,
+			func(ctx context.Context, gameID string) ([]statistic.Statistic, error) { return nil, errStorage },
+			listQuests,
+			nil,
+		)
+
+		_, err := summarize(ctx, gameID)
+		assert.ErrorIs(t, err, errStorage)
+	})
+}
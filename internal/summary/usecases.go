@@ -0,0 +1,8 @@
+package summary
+
+import "context"
+
+type (
+	// Counts the game's active leaderboards, statistics and quests alongside its recent submissions and errors
+	SummarizeFunc func(ctx context.Context, gameID string) (Summary, error)
+)
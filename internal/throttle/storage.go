@@ -0,0 +1,12 @@
+package throttle
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Counts a submission of the player on the window starting at `window`, returning how many the player sent on it.
+	// The count is kept at least until the window ends
+	StorageIncrementSubmissionsFunc func(ctx context.Context, gameID, playerID string, window time.Time) (int64, error)
+)
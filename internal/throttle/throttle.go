@@ -0,0 +1,66 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Length of the window the submissions per minute are counted on
+const Window = time.Minute
+
+var (
+	ErrInvalidLimit          = errors.New("submission limit must be positive")
+	ErrSubmissionRateLimited = errors.New("player submission rate limit exceeded")
+)
+
+// Time left until the window `now` is on ends, when the player can submit again
+func RetryAfter(now time.Time) time.Duration {
+	return now.Truncate(Window).Add(Window).Sub(now)
+}
+
+// Caps the submissions of each player on fixed one-minute windows, shared by every instance through the storage.
+// The rejected submissions are counted as well, so a player spraying them stays limited until the window ends
+func BuildCheckFunc(limit int64, storageIncrementSubmissionsFunc StorageIncrementSubmissionsFunc) (CheckFunc, error) {
+	if limit <= 0 {
+		return nil, ErrInvalidLimit
+	}
+
+	return func(ctx context.Context, gameID, playerID string) error {
+		count, err := storageIncrementSubmissionsFunc(ctx, gameID, playerID, time.Now().Truncate(Window))
+		if err != nil {
+			return err
+		}
+
+		if count > limit {
+			return ErrSubmissionRateLimited
+		}
+
+		return nil
+	}, nil
+}
+
+// Wraps the rank update to count the submission against the player's limit before applying it
+func BuildLimitRankFunc(checkFunc CheckFunc, next leaderboard.StorageUpsertPlayerRankValueFunc) leaderboard.StorageUpsertPlayerRankValueFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		if err := checkFunc(ctx, lb.GameID, playerID); err != nil {
+			return err
+		}
+
+		return next(ctx, lb, playerID, value)
+	}
+}
+
+// Wraps the statistic progression update to count the submission against the player's limit before applying it
+func BuildLimitProgressionFunc(checkFunc CheckFunc, next statistic.StorageUpdatePlayerProgressionFunc) statistic.StorageUpdatePlayerProgressionFunc {
+	return func(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+		if err := checkFunc(ctx, st.GameID, playerID); err != nil {
+			return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+		}
+
+		return next(ctx, st, playerID, value)
+	}
+}
@@ -0,0 +1,99 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 30, 45, 0, time.UTC)
+	assert.Equal(t, 15*time.Second, RetryAfter(now))
+	assert.Equal(t, Window, RetryAfter(now.Truncate(Window)))
+}
+
+func TestBuildCheckFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		_, err := BuildCheckFunc(0, nil)
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		counts := make(map[string]int64)
+		checkFunc, err := BuildCheckFunc(2, func(ctx context.Context, gameID, playerID string, window time.Time) (int64, error) {
+			assert.Equal(t, window, window.Truncate(Window))
+
+			counts[playerID]++
+			return counts[playerID], nil
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, checkFunc(ctx, "game", "alice"))
+		assert.NoError(t, checkFunc(ctx, "game", "alice"))
+		assert.ErrorIs(t, checkFunc(ctx, "game", "alice"), ErrSubmissionRateLimited)
+		assert.NoError(t, checkFunc(ctx, "game", "bob"))
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		storageErr := errors.New("any error")
+		checkFunc, err := BuildCheckFunc(2, func(ctx context.Context, gameID, playerID string, window time.Time) (int64, error) {
+			return 0, storageErr
+		})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, checkFunc(ctx, "game", "alice"), storageErr)
+	})
+}
+
+func TestBuildLimitFuncs(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		checked   []string
+		checkFunc = func(ctx context.Context, gameID, playerID string) error {
+			checked = append(checked, gameID+"/"+playerID)
+			if playerID == "spammer" {
+				return ErrSubmissionRateLimited
+			}
+
+			return nil
+		}
+	)
+
+	t.Run("Rank", func(t *testing.T) {
+		var applied []string
+		upsertFunc := BuildLimitRankFunc(checkFunc, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+			applied = append(applied, playerID)
+			return nil
+		})
+
+		lb := leaderboard.Leaderboard{ID: "season", GameID: "game"}
+		assert.NoError(t, upsertFunc(ctx, lb, "alice", 10))
+		assert.ErrorIs(t, upsertFunc(ctx, lb, "spammer", 10), ErrSubmissionRateLimited)
+		assert.Equal(t, []string{"alice"}, applied)
+	})
+
+	t.Run("Progression", func(t *testing.T) {
+		var applied []string
+		updateFunc := BuildLimitProgressionFunc(checkFunc, func(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+			applied = append(applied, playerID)
+			return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, nil
+		})
+
+		st := statistic.Statistic{ID: "kills", GameID: "game"}
+		_, _, err := updateFunc(ctx, st, "alice", 1)
+		assert.NoError(t, err)
+
+		_, _, err = updateFunc(ctx, st, "spammer", 1)
+		assert.ErrorIs(t, err, ErrSubmissionRateLimited)
+		assert.Equal(t, []string{"alice"}, applied)
+	})
+
+	assert.Equal(t, []string{"game/alice", "game/spammer", "game/alice", "game/spammer"}, checked)
+}
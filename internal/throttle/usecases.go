@@ -0,0 +1,8 @@
+package throttle
+
+import "context"
+
+type (
+	// Counts a submission of the player, failing with `ErrSubmissionRateLimited` when the player is over the limit
+	CheckFunc func(ctx context.Context, gameID, playerID string) error
+)
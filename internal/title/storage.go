@@ -0,0 +1,23 @@
+package title
+
+import "context"
+
+type (
+	// Stores a new title, returning it with its ID
+	StorageCreateTitleFunc func(ctx context.Context, data NewTitleData) (Title, error)
+
+	// Get a title by its id and game id
+	StorageGetTitleFunc func(ctx context.Context, id, gameID string) (Title, error)
+
+	// Lists the titles of the game, oldest first
+	StorageListTitlesFunc func(ctx context.Context, gameID string) ([]Title, error)
+
+	// Removes the title of the game alongside its grants
+	StorageDeleteTitleFunc func(ctx context.Context, id, gameID string) error
+
+	// Records the grants, skipping the titles the player already has, and returns the ones recorded
+	StorageGrantTitlesFunc func(ctx context.Context, grants []Grant) ([]Grant, error)
+
+	// Lists the grants of the players, oldest first
+	StorageListGrantsFunc func(ctx context.Context, gameID string, playerIDs []string) ([]Grant, error)
+)
@@ -0,0 +1,424 @@
+package title
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+// Most characters on a title name, as it's shown alongside the player's name
+const MaxNameLength = 64
+
+// Progressions that grant a title
+const (
+	TriggerStatisticLandmark = "STATISTIC_LANDMARK" // The player reaches a landmark of a statistic
+	TriggerRank              = "RANK"               // The player ranks on the top positions of a leaderboard
+)
+
+var TriggerKinds = []string{
+	TriggerStatisticLandmark,
+	TriggerRank,
+}
+
+var (
+	ErrValidationError      = errors.New("validation error")
+	ErrInvalidName          = errors.New("name must have between 1 and 64 characters")
+	ErrInvalidGameID        = errors.New("invalid game id")
+	ErrInvalidBadgeURL      = errors.New("badge url must be an absolute http or https url")
+	ErrInvalidTrigger       = errors.New("trigger kind must be one of STATISTIC_LANDMARK or RANK")
+	ErrMissingStatisticID   = errors.New("the statistic landmark trigger requires a statistic id")
+	ErrMissingLeaderboardID = errors.New("the rank trigger requires a leaderboard id")
+	ErrInvalidTop           = errors.New("the rank trigger requires a top of at least 1")
+	ErrUnknownLandmark      = errors.New("the landmark is not one of the statistic's landmarks")
+
+	ErrInvalidTitleID  = errors.New("invalid title id")
+	ErrTitleNotFound   = errors.New("title not found")
+	ErrInvalidPlayerID = errors.New("invalid player id")
+)
+
+type (
+	// Progression that grants a title. Only the fields of its kind are set
+	Trigger struct {
+		Kind          string  // Trigger kind, one of `STATISTIC_LANDMARK` or `RANK`
+		StatisticID   string  // Statistic whose landmark grants the title
+		Landmark      float64 // Landmark of the statistic that grants the title
+		LeaderboardID string  // Leaderboard whose top positions grant the title
+		Top           int64   // Positions of the leaderboard that grant the title, e.g. 10 for the top 10
+	}
+
+	NewTitleData struct {
+		GameID      string  // Game the title belongs to
+		Name        string  // Title shown alongside the player's name
+		Description string  // Title details
+		BadgeURL    string  // Badge image shown alongside the title. Optional
+		Trigger     Trigger // Progression that grants the title
+	}
+
+	Title struct {
+		CreatedAt   time.Time // Time the title was created
+		UpdatedAt   time.Time // Last time the title was changed
+		ID          string    // Title ID, assigned by the storage
+		GameID      string    // Game the title belongs to
+		Name        string    // Title shown alongside the player's name
+		Description string    // Title details
+		BadgeURL    string    // Badge image shown alongside the title. Empty when none
+		Trigger     Trigger   // Progression that grants the title
+	}
+
+	// Title granted to a player, as kept on the storage
+	Grant struct {
+		TitleID   string    // Title granted
+		GameID    string    // Game the title belongs to
+		PlayerID  string    // Player the title was granted to
+		GrantedAt time.Time // Time the player made the progression that granted it
+	}
+
+	// Title granted to a player, alongside its details
+	PlayerTitle struct {
+		Title     Title     // Title granted
+		PlayerID  string    // Player the title was granted to
+		GrantedAt time.Time // Time the player made the progression that granted it
+	}
+
+	// Progression made by a player, matched against the triggers of its game's titles
+	TriggerEvent struct {
+		OccurredAt    time.Time // Time the player made the progression
+		Kind          string    // Trigger kind, one of `STATISTIC_LANDMARK` or `RANK`
+		GameID        string    // Game the player belongs to
+		PlayerID      string    // Player's ID
+		StatisticID   string    // Statistic whose landmark was reached. Only set on `STATISTIC_LANDMARK`
+		Landmark      float64   // Landmark reached. Only set on `STATISTIC_LANDMARK`
+		LeaderboardID string    // Leaderboard the player is ranked on. Only set on `RANK`
+		Position      int64     // Player's position on the leaderboard, starting at 0. Only set on `RANK`
+	}
+)
+
+func (d NewTitleData) validate() error {
+	errList := make([]error, 0)
+
+	if name := strings.TrimSpace(d.Name); name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		errList = append(errList, ErrInvalidName)
+	}
+
+	if d.GameID == "" {
+		errList = append(errList, ErrInvalidGameID)
+	}
+
+	if d.BadgeURL != "" {
+		u, err := url.Parse(d.BadgeURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errList = append(errList, ErrInvalidBadgeURL)
+		}
+	}
+
+	switch d.Trigger.Kind {
+	case TriggerStatisticLandmark:
+		if d.Trigger.StatisticID == "" {
+			errList = append(errList, ErrMissingStatisticID)
+		}
+	case TriggerRank:
+		if d.Trigger.LeaderboardID == "" {
+			errList = append(errList, ErrMissingLeaderboardID)
+		}
+
+		if d.Trigger.Top < 1 {
+			errList = append(errList, ErrInvalidTop)
+		}
+	default:
+		errList = append(errList, ErrInvalidTrigger)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Keeps only the fields of the trigger's kind
+func (t Trigger) normalize() Trigger {
+	switch t.Kind {
+	case TriggerStatisticLandmark:
+		return Trigger{Kind: t.Kind, StatisticID: t.StatisticID, Landmark: t.Landmark}
+	case TriggerRank:
+		return Trigger{Kind: t.Kind, LeaderboardID: t.LeaderboardID, Top: t.Top}
+	}
+
+	return t
+}
+
+func (t Trigger) matches(event TriggerEvent) bool {
+	switch t.Kind {
+	case TriggerStatisticLandmark:
+		return event.Kind == t.Kind && event.StatisticID == t.StatisticID && event.Landmark == t.Landmark
+	case TriggerRank:
+		return event.Kind == t.Kind && event.LeaderboardID == t.LeaderboardID && event.Position < t.Top
+	}
+
+	return false
+}
+
+// The statistic or leaderboard the trigger refers to must belong to the game, and the landmark must be one of the statistic's
+func BuildCreateFunc(getStatisticFunc statistic.GetByIDAndGameIDFunc, getLeaderboardFunc leaderboard.GetByIDAndGameIDFunc, storageCreateTitleFunc StorageCreateTitleFunc) CreateFunc {
+	return func(ctx context.Context, data NewTitleData) (Title, error) {
+		if err := data.validate(); err != nil {
+			return Title{}, err
+		}
+
+		data.Name = strings.TrimSpace(data.Name)
+		data.Trigger = data.Trigger.normalize()
+		switch data.Trigger.Kind {
+		case TriggerStatisticLandmark:
+			s, err := getStatisticFunc(ctx, data.Trigger.StatisticID, data.GameID)
+			if err != nil {
+				return Title{}, err
+			}
+
+			if !slices.Contains(s.Landmarks, data.Trigger.Landmark) {
+				return Title{}, ErrUnknownLandmark
+			}
+		case TriggerRank:
+			if _, err := getLeaderboardFunc(ctx, data.Trigger.LeaderboardID, data.GameID); err != nil {
+				return Title{}, err
+			}
+		}
+
+		return storageCreateTitleFunc(ctx, data)
+	}
+}
+
+func BuildGetByIDAndGameIDFunc(storageGetTitleFunc StorageGetTitleFunc) GetByIDAndGameIDFunc {
+	return func(ctx context.Context, id, gameID string) (Title, error) {
+		if id == "" {
+			return Title{}, ErrInvalidTitleID
+		}
+
+		return storageGetTitleFunc(ctx, id, gameID)
+	}
+}
+
+func BuildListFunc(storageListTitlesFunc StorageListTitlesFunc) ListFunc {
+	return func(ctx context.Context, gameID string) ([]Title, error) {
+		return storageListTitlesFunc(ctx, gameID)
+	}
+}
+
+func BuildDeleteFunc(storageDeleteTitleFunc StorageDeleteTitleFunc) DeleteFunc {
+	return func(ctx context.Context, id, gameID string) error {
+		if id == "" {
+			return ErrInvalidTitleID
+		}
+
+		return storageDeleteTitleFunc(ctx, id, gameID)
+	}
+}
+
+// Joins the grants with their titles, leaving out the ones of a title being deleted
+func playerTitles(titles []Title, grants []Grant) []PlayerTitle {
+	byID := make(map[string]Title, len(titles))
+	for _, t := range titles {
+		byID[t.ID] = t
+	}
+
+	granted := make([]PlayerTitle, 0, len(grants))
+	for _, grant := range grants {
+		t, ok := byID[grant.TitleID]
+		if !ok {
+			continue
+		}
+
+		granted = append(granted, PlayerTitle{Title: t, PlayerID: grant.PlayerID, GrantedAt: grant.GrantedAt})
+	}
+
+	return granted
+}
+
+func BuildListPlayerTitlesFunc(storageListTitlesFunc StorageListTitlesFunc, storageListGrantsFunc StorageListGrantsFunc) ListPlayerTitlesFunc {
+	return func(ctx context.Context, gameID, playerID string) ([]PlayerTitle, error) {
+		if playerID == "" {
+			return nil, ErrInvalidPlayerID
+		}
+
+		grants, err := storageListGrantsFunc(ctx, gameID, []string{playerID})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(grants) == 0 {
+			return make([]PlayerTitle, 0), nil
+		}
+
+		titles, err := storageListTitlesFunc(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		return playerTitles(titles, grants), nil
+	}
+}
+
+// The players without titles are left out of the result
+func BuildListPlayersTitlesFunc(storageListTitlesFunc StorageListTitlesFunc, storageListGrantsFunc StorageListGrantsFunc) ListPlayersTitlesFunc {
+	return func(ctx context.Context, gameID string, playerIDs []string) (map[string][]PlayerTitle, error) {
+		byPlayer := make(map[string][]PlayerTitle)
+		if len(playerIDs) == 0 {
+			return byPlayer, nil
+		}
+
+		grants, err := storageListGrantsFunc(ctx, gameID, playerIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(grants) == 0 {
+			return byPlayer, nil
+		}
+
+		titles, err := storageListTitlesFunc(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range playerTitles(titles, grants) {
+			byPlayer[t.PlayerID] = append(byPlayer[t.PlayerID], t)
+		}
+
+		return byPlayer, nil
+	}
+}
+
+// Records the grants of the titles triggered by the event, returning the ones the player didn't have yet
+func grant(ctx context.Context, titles []Title, event TriggerEvent, storageGrantTitlesFunc StorageGrantTitlesFunc) ([]PlayerTitle, error) {
+	var (
+		triggered = make(map[string]Title)
+		grants    = make([]Grant, 0)
+	)
+	for _, t := range titles {
+		if !t.Trigger.matches(event) {
+			continue
+		}
+
+		triggered[t.ID] = t
+		grants = append(grants, Grant{
+			TitleID:   t.ID,
+			GameID:    event.GameID,
+			PlayerID:  event.PlayerID,
+			GrantedAt: event.OccurredAt,
+		})
+	}
+
+	if len(grants) == 0 {
+		return nil, nil
+	}
+
+	recorded, err := storageGrantTitlesFunc(ctx, grants)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := make([]PlayerTitle, len(recorded))
+	for i, g := range recorded {
+		granted[i] = PlayerTitle{Title: triggered[g.TitleID], PlayerID: g.PlayerID, GrantedAt: g.GrantedAt}
+	}
+
+	return granted, nil
+}
+
+// Granting a title the player already has is a no-op, so the same event can be handled again safely.
+// A title is kept once granted, even after the player drops out of the top positions that granted it
+func BuildGrantFunc(storageListTitlesFunc StorageListTitlesFunc, storageGrantTitlesFunc StorageGrantTitlesFunc) GrantFunc {
+	return func(ctx context.Context, event TriggerEvent) ([]PlayerTitle, error) {
+		titles, err := storageListTitlesFunc(ctx, event.GameID)
+		if err != nil {
+			return nil, err
+		}
+
+		return grant(ctx, titles, event, storageGrantTitlesFunc)
+	}
+}
+
+// Wraps the statistic progression notifier to also grant the titles of the landmarks the player reached.
+// The wrapped notifier can be nil, and the titles are granted only after it succeeds
+func BuildNotifierStatisticProgressionUpdates(next statistic.NotifierPlayerProgressionUpdates, grantFunc GrantFunc) statistic.NotifierPlayerProgressionUpdates {
+	return func(ctx context.Context, s statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+		if next != nil {
+			if err := next(ctx, s, progression, updates); err != nil {
+				return err
+			}
+		}
+
+		for _, landmark := range updates.LandmarksJustCompleted {
+			_, err := grantFunc(ctx, TriggerEvent{
+				OccurredAt:  landmark.CompletedAt,
+				Kind:        TriggerStatisticLandmark,
+				GameID:      s.GameID,
+				PlayerID:    progression.PlayerID,
+				StatisticID: s.ID,
+				Landmark:    landmark.Value,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Wraps the rank change notifier to also grant the titles of the leaderboard's top positions the player reached.
+// The player's position is only read when the leaderboard has titles, as it's a read on every submission.
+// The wrapped notifier can be nil, and the titles are granted only after it succeeds
+func BuildNotifierRankChange(
+	next leaderboard.NotifierRankChange,
+	getLeaderboardFunc leaderboard.GetByIDAndGameIDFunc,
+	getPlayerRankFunc leaderboard.GetPlayerRankFunc,
+	storageListTitlesFunc StorageListTitlesFunc,
+	storageGrantTitlesFunc StorageGrantTitlesFunc,
+) leaderboard.NotifierRankChange {
+	return func(ctx context.Context, change leaderboard.RankChange) error {
+		if next != nil {
+			if err := next(ctx, change); err != nil {
+				return err
+			}
+		}
+
+		titles, err := storageListTitlesFunc(ctx, change.GameID)
+		if err != nil {
+			return err
+		}
+
+		hasRankTitles := slices.ContainsFunc(titles, func(t Title) bool {
+			return t.Trigger.Kind == TriggerRank && t.Trigger.LeaderboardID == change.LeaderboardID
+		})
+		if !hasRankTitles {
+			return nil
+		}
+
+		lb, err := getLeaderboardFunc(ctx, change.LeaderboardID, change.GameID)
+		if err != nil {
+			return err
+		}
+
+		rank, err := getPlayerRankFunc(ctx, lb, change.PlayerID)
+		if err != nil {
+			return err
+		}
+
+		_, err = grant(ctx, titles, TriggerEvent{
+			OccurredAt:    time.Now().UTC(),
+			Kind:          TriggerRank,
+			GameID:        change.GameID,
+			PlayerID:      change.PlayerID,
+			LeaderboardID: change.LeaderboardID,
+			Position:      rank.Position,
+		}, storageGrantTitlesFunc)
+		return err
+	}
+}
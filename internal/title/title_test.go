@@ -0,0 +1,245 @@
+package title
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCreateFunc(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		getStatistic = func(ctx context.Context, id, gameID string) (statistic.Statistic, error) {
+			return statistic.Statistic{ID: id, GameID: gameID, Landmarks: []float64{10, 100}}, nil
+		}
+		getLeaderboard = func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+		}
+		create = func(ctx context.Context, data NewTitleData) (Title, error) {
+			return Title{ID: uuid.NewString(), GameID: data.GameID, Name: data.Name, BadgeURL: data.BadgeURL, Trigger: data.Trigger}, nil
+		}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		title, err := BuildCreateFunc(getStatistic, getLeaderboard, create)(ctx, NewTitleData{
+			GameID:  "game",
+			Name:    " Slayer ",
+			Trigger: Trigger{Kind: TriggerStatisticLandmark, StatisticID: "kills", Landmark: 100, Top: 3},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Slayer", title.Name)
+		assert.Equal(t, Trigger{Kind: TriggerStatisticLandmark, StatisticID: "kills", Landmark: 100}, title.Trigger)
+
+		title, err = BuildCreateFunc(getStatistic, getLeaderboard, create)(ctx, NewTitleData{
+			GameID:   "game",
+			Name:     "Champion",
+			BadgeURL: "https://cdn.example.com/champion.png",
+			Trigger:  Trigger{Kind: TriggerRank, LeaderboardID: "season", Top: 10, StatisticID: "ignored"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, Trigger{Kind: TriggerRank, LeaderboardID: "season", Top: 10}, title.Trigger)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildCreateFunc(nil, nil, nil)(ctx, NewTitleData{BadgeURL: "champion.png", Trigger: Trigger{Kind: "LEVEL"}})
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidName)
+		assert.ErrorIs(t, err, ErrInvalidGameID)
+		assert.ErrorIs(t, err, ErrInvalidBadgeURL)
+		assert.ErrorIs(t, err, ErrInvalidTrigger)
+
+		_, err = BuildCreateFunc(nil, nil, nil)(ctx, NewTitleData{GameID: "game", Name: "a", Trigger: Trigger{Kind: TriggerRank}})
+		assert.ErrorIs(t, err, ErrMissingLeaderboardID)
+		assert.ErrorIs(t, err, ErrInvalidTop)
+	})
+
+	t.Run("Unknown Landmark", func(t *testing.T) {
+		_, err := BuildCreateFunc(getStatistic, getLeaderboard, create)(ctx, NewTitleData{
+			GameID:  "game",
+			Name:    "Hunter",
+			Trigger: Trigger{Kind: TriggerStatisticLandmark, StatisticID: "kills", Landmark: 50},
+		})
+		assert.ErrorIs(t, err, ErrUnknownLandmark)
+	})
+
+	t.Run("Leaderboard Not Found", func(t *testing.T) {
+		getLeaderboard := func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+			return leaderboard.Leaderboard{}, leaderboard.ErrLeaderboardNotFound
+		}
+
+		_, err := BuildCreateFunc(getStatistic, getLeaderboard, create)(ctx, NewTitleData{
+			GameID:  "game",
+			Name:    "Champion",
+			Trigger: Trigger{Kind: TriggerRank, LeaderboardID: "season", Top: 1},
+		})
+		assert.ErrorIs(t, err, leaderboard.ErrLeaderboardNotFound)
+	})
+}
+
+func TestBuildGrantFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		now    = time.Now().UTC()
+		titles = []Title{
+			{ID: "kills-100", GameID: "game", Trigger: Trigger{Kind: TriggerStatisticLandmark, StatisticID: "kills", Landmark: 100}},
+			{ID: "top-1", GameID: "game", Trigger: Trigger{Kind: TriggerRank, LeaderboardID: "season", Top: 1}},
+			{ID: "top-10", GameID: "game", Trigger: Trigger{Kind: TriggerRank, LeaderboardID: "season", Top: 10}},
+		}
+		list = func(ctx context.Context, gameID string) ([]Title, error) { return titles, nil }
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		grant := BuildGrantFunc(list, func(ctx context.Context, grants []Grant) ([]Grant, error) { return grants, nil })
+
+		granted, err := grant(ctx, TriggerEvent{OccurredAt: now, Kind: TriggerRank, GameID: "game", PlayerID: "player", LeaderboardID: "season", Position: 4})
+		assert.NoError(t, err)
+		assert.Equal(t, []PlayerTitle{{Title: titles[2], PlayerID: "player", GrantedAt: now}}, granted)
+
+		granted, err = grant(ctx, TriggerEvent{OccurredAt: now, Kind: TriggerRank, GameID: "game", PlayerID: "player", LeaderboardID: "season", Position: 0})
+		assert.NoError(t, err)
+		assert.Len(t, granted, 2)
+	})
+
+	t.Run("Already Granted", func(t *testing.T) {
+		grant := BuildGrantFunc(list, func(ctx context.Context, grants []Grant) ([]Grant, error) { return nil, nil })
+
+		granted, err := grant(ctx, TriggerEvent{Kind: TriggerStatisticLandmark, GameID: "game", PlayerID: "player", StatisticID: "kills", Landmark: 100})
+		assert.NoError(t, err)
+		assert.Empty(t, granted)
+	})
+
+	t.Run("No Match", func(t *testing.T) {
+		grant := BuildGrantFunc(list, func(ctx context.Context, grants []Grant) ([]Grant, error) {
+			t.Fail()
+			return nil, nil
+		})
+
+		granted, err := grant(ctx, TriggerEvent{Kind: TriggerRank, GameID: "game", PlayerID: "player", LeaderboardID: "season", Position: 10})
+		assert.NoError(t, err)
+		assert.Empty(t, granted)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		grant := BuildGrantFunc(list, func(ctx context.Context, grants []Grant) ([]Grant, error) {
+			return nil, errors.New("any error")
+		})
+
+		_, err := grant(ctx, TriggerEvent{Kind: TriggerStatisticLandmark, GameID: "game", PlayerID: "player", StatisticID: "kills", Landmark: 100})
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildListPlayersTitlesFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		now    = time.Now().UTC()
+		titles = func(ctx context.Context, gameID string) ([]Title, error) {
+			return []Title{{ID: "a", Name: "A"}, {ID: "b", Name: "B"}}, nil
+		}
+		grants = func(ctx context.Context, gameID string, playerIDs []string) ([]Grant, error) {
+			return []Grant{
+				{TitleID: "a", PlayerID: "alice", GrantedAt: now},
+				{TitleID: "deleted", PlayerID: "alice"},
+				{TitleID: "b", PlayerID: "alice", GrantedAt: now},
+				{TitleID: "a", PlayerID: "bob", GrantedAt: now},
+			}, nil
+		}
+	)
+
+	t.Run("Players", func(t *testing.T) {
+		byPlayer, err := BuildListPlayersTitlesFunc(titles, grants)(ctx, "game", []string{"alice", "bob", "carol"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]PlayerTitle{
+			"alice": {{Title: Title{ID: "a", Name: "A"}, PlayerID: "alice", GrantedAt: now}, {Title: Title{ID: "b", Name: "B"}, PlayerID: "alice", GrantedAt: now}},
+			"bob":   {{Title: Title{ID: "a", Name: "A"}, PlayerID: "bob", GrantedAt: now}},
+		}, byPlayer)
+	})
+
+	t.Run("Player", func(t *testing.T) {
+		granted, err := BuildListPlayerTitlesFunc(titles, grants)(ctx, "game", "alice")
+		assert.NoError(t, err)
+		assert.Len(t, granted, 3)
+
+		_, err = BuildListPlayerTitlesFunc(titles, grants)(ctx, "game", "")
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+	})
+}
+
+func TestBuildNotifiers(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		now    = time.Now().UTC()
+		titles = []Title{{ID: "top-3", GameID: "game", Trigger: Trigger{Kind: TriggerRank, LeaderboardID: "season", Top: 3}}}
+		list   = func(ctx context.Context, gameID string) ([]Title, error) { return titles, nil }
+	)
+
+	t.Run("Statistic Landmarks", func(t *testing.T) {
+		var events []TriggerEvent
+		notify := BuildNotifierStatisticProgressionUpdates(nil, func(ctx context.Context, event TriggerEvent) ([]PlayerTitle, error) {
+			events = append(events, event)
+			return nil, nil
+		})
+
+		err := notify(ctx, statistic.Statistic{ID: "kills", GameID: "game"}, statistic.PlayerProgression{PlayerID: "player"}, statistic.PlayerProgressionUpdates{
+			LandmarksJustCompleted: []statistic.PlayerProgressionUpdatesLandmark{{Value: 10, CompletedAt: now}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []TriggerEvent{{OccurredAt: now, Kind: TriggerStatisticLandmark, GameID: "game", PlayerID: "player", StatisticID: "kills", Landmark: 10}}, events)
+	})
+
+	t.Run("Rank Change", func(t *testing.T) {
+		var granted []Grant
+		notify := BuildNotifierRankChange(
+			nil,
+			func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				return leaderboard.Leaderboard{ID: id, GameID: gameID}, nil
+			},
+			func(ctx context.Context, lb leaderboard.Leaderboard, playerID string) (leaderboard.Rank, error) {
+				return leaderboard.Rank{LeaderboardID: lb.ID, PlayerID: playerID, Position: 2}, nil
+			},
+			list,
+			func(ctx context.Context, grants []Grant) ([]Grant, error) {
+				granted = append(granted, grants...)
+				return grants, nil
+			},
+		)
+
+		assert.NoError(t, notify(ctx, leaderboard.RankChange{LeaderboardID: "season", GameID: "game", PlayerID: "player", Value: 10}))
+		assert.Len(t, granted, 1)
+		assert.Equal(t, "top-3", granted[0].TitleID)
+		assert.Equal(t, "player", granted[0].PlayerID)
+	})
+
+	t.Run("Rank Change Without Titles", func(t *testing.T) {
+		notify := BuildNotifierRankChange(
+			nil,
+			func(ctx context.Context, id, gameID string) (leaderboard.Leaderboard, error) {
+				t.Fail()
+				return leaderboard.Leaderboard{}, nil
+			},
+			nil,
+			list,
+			nil,
+		)
+
+		assert.NoError(t, notify(ctx, leaderboard.RankChange{LeaderboardID: "other", GameID: "game", PlayerID: "player"}))
+	})
+
+	t.Run("Wrapped Notifier Error", func(t *testing.T) {
+		notify := BuildNotifierRankChange(func(ctx context.Context, change leaderboard.RankChange) error {
+			return errors.New("any error")
+		}, nil, nil, func(ctx context.Context, gameID string) ([]Title, error) {
+			t.Fail()
+			return nil, nil
+		}, nil)
+
+		assert.Error(t, notify(ctx, leaderboard.RankChange{LeaderboardID: "season", GameID: "game"}))
+	})
+}
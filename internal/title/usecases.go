@@ -0,0 +1,26 @@
+package title
+
+import "context"
+
+type (
+	// Creates a title of the game
+	CreateFunc func(ctx context.Context, data NewTitleData) (Title, error)
+
+	// Get a title by its id and game id
+	GetByIDAndGameIDFunc func(ctx context.Context, id, gameID string) (Title, error)
+
+	// Lists the titles of the game, oldest first
+	ListFunc func(ctx context.Context, gameID string) ([]Title, error)
+
+	// Removes the title of the game alongside its grants
+	DeleteFunc func(ctx context.Context, id, gameID string) error
+
+	// Lists the titles granted to the player, oldest grant first
+	ListPlayerTitlesFunc func(ctx context.Context, gameID, playerID string) ([]PlayerTitle, error)
+
+	// Lists the titles granted to each of the players, oldest grant first, keyed by player id
+	ListPlayersTitlesFunc func(ctx context.Context, gameID string, playerIDs []string) (map[string][]PlayerTitle, error)
+
+	// Grants the game's titles triggered by the event to the player, returning the ones just granted
+	GrantFunc func(ctx context.Context, event TriggerEvent) ([]PlayerTitle, error)
+)
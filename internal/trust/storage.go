@@ -0,0 +1,17 @@
+package trust
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Player's record, created as first seen at `seenAt` when they have none
+	StorageTrackPlayerFunc func(ctx context.Context, gameID, playerID string, seenAt time.Time) (Record, error)
+
+	// Adds to the player's flagged and cleared submissions, creating their record as first seen at `seenAt` when they have none
+	StorageIncrementFunc func(ctx context.Context, gameID, playerID string, flagged, cleared int64, seenAt time.Time) error
+
+	// Player's record. Fails with `ErrRecordNotFound` when the player has none
+	StorageGetRecordFunc func(ctx context.Context, gameID, playerID string) (Record, error)
+)
@@ -0,0 +1,191 @@
+package trust
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+)
+
+const (
+	LevelLow    = "LOW"    // Stricter delta limits, and the submissions over them are always held for review
+	LevelNormal = "NORMAL" // The delta rules as they're set
+	LevelHigh   = "HIGH"   // The delta rules are skipped
+
+	MinScore     = 0   // Lowest score a player can have
+	MaxScore     = 100 // Highest score a player can have
+	InitialScore = 50  // Score of a player just seen
+
+	AgeBonusPerDay = 1  // Points each day since the player was first seen adds
+	MaxAgeBonus    = 30 // Most points the player's age adds
+	FlaggedPenalty = 10 // Points each submission held for review takes
+	ClearedBonus   = 10 // Points each held submission released gives back
+)
+
+var (
+	ErrValidationError    = errors.New("validation error")
+	ErrInvalidThresholds  = errors.New("the low threshold must be lower than the high one, both between 0 and 100")
+	ErrInvalidDeltaFactor = errors.New("the low trust delta factor must be greater than zero and up to one")
+
+	ErrInvalidGameID   = errors.New("invalid game id")
+	ErrInvalidPlayerID = errors.New("invalid player id")
+	ErrRecordNotFound  = errors.New("trust record not found")
+)
+
+// How the players' scores map to their levels, and how the low level tightens the delta rules
+type Config struct {
+	LowThreshold   float64 // Scores below it are `LOW`
+	HighThreshold  float64 // Scores from it on are `HIGH`
+	LowDeltaFactor float64 // Factor the delta limits are scaled by for the `LOW` players, from zero to one
+}
+
+// What the trust score is computed from
+type Record struct {
+	FirstSeenAt time.Time // Time the player's first submission was checked
+	GameID      string    // Game the player belongs to
+	PlayerID    string    // Player the record is of
+	Flagged     int64     // Player's submissions held for review
+	Cleared     int64     // Player's held submissions released
+}
+
+// Player's trust, computed from their record
+type Trust struct {
+	Record
+	Score float64 // From `MinScore` to `MaxScore`
+	Level string  // One of `LOW`, `NORMAL` or `HIGH`
+}
+
+// Player's level, and the delta factor it implies, as the submissions made with a context are checked
+type Assessment struct {
+	Level       string  // One of `LOW`, `NORMAL` or `HIGH`
+	DeltaFactor float64 // Factor the delta limits are scaled by. One unless the level is `LOW`
+}
+
+func (c Config) validate() error {
+	errList := make([]error, 0)
+
+	if c.LowThreshold < MinScore || c.HighThreshold > MaxScore || c.LowThreshold >= c.HighThreshold {
+		errList = append(errList, ErrInvalidThresholds)
+	}
+
+	if c.LowDeltaFactor <= 0 || c.LowDeltaFactor > 1 {
+		errList = append(errList, ErrInvalidDeltaFactor)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Starts from the initial score, adding a point for each full day since the player was first seen, up to the age
+// bonus, and the held submissions' outcomes. A submission held and released cancels out, while a discarded one
+// keeps its penalty
+func (r Record) score(now time.Time) float64 {
+	days := math.Floor(now.Sub(r.FirstSeenAt).Hours() / 24)
+	score := InitialScore +
+		math.Min(math.Max(days, 0)*AgeBonusPerDay, MaxAgeBonus) +
+		float64(r.Cleared*ClearedBonus) -
+		float64(r.Flagged*FlaggedPenalty)
+
+	return math.Min(math.Max(score, MinScore), MaxScore)
+}
+
+func (c Config) level(score float64) string {
+	switch {
+	case score < c.LowThreshold:
+		return LevelLow
+	case score >= c.HighThreshold:
+		return LevelHigh
+	default:
+		return LevelNormal
+	}
+}
+
+func (c Config) trust(r Record, now time.Time) Trust {
+	score := r.score(now)
+	return Trust{Record: r, Score: score, Level: c.level(score)}
+}
+
+type assessmentKey struct{}
+
+// Tags the submissions made with the context with the player's trust
+func WithAssessment(ctx context.Context, a Assessment) context.Context {
+	return context.WithValue(ctx, assessmentKey{}, a)
+}
+
+// Player's trust as the submission made with the context is checked. Not ok when it wasn't assessed
+func AssessmentFromContext(ctx context.Context) (Assessment, bool) {
+	a, ok := ctx.Value(assessmentKey{}).(Assessment)
+	return a, ok
+}
+
+// Wraps the rank update to assess the player's trust before the delta rules check the submission. The player's
+// record is created on their first submission, so their age counts from it
+func BuildAssessFunc(config Config, storageTrackPlayerFunc StorageTrackPlayerFunc, next leaderboard.StorageUpsertPlayerRankValueFunc) (leaderboard.StorageUpsertPlayerRankValueFunc, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		now := time.Now().UTC()
+		record, err := storageTrackPlayerFunc(ctx, lb.GameID, playerID, now)
+		if err != nil {
+			return err
+		}
+
+		a := Assessment{Level: config.trust(record, now).Level, DeltaFactor: 1}
+		if a.Level == LevelLow {
+			a.DeltaFactor = config.LowDeltaFactor
+		}
+
+		return next(WithAssessment(ctx, a), lb, playerID, value)
+	}, nil
+}
+
+// Wraps the hold to count the submissions held against the player
+func BuildRecordHoldFunc(storageIncrementFunc StorageIncrementFunc, next quarantine.HoldFunc) quarantine.HoldFunc {
+	return func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64, reason, proof string) (quarantine.Submission, error) {
+		s, err := next(ctx, lb, playerID, value, reason, proof)
+		if err != nil {
+			return quarantine.Submission{}, err
+		}
+
+		return s, storageIncrementFunc(ctx, s.GameID, s.PlayerID, 1, 0, s.HeldAt)
+	}
+}
+
+// Wraps the release to count the submissions released in the player's favor
+func BuildRecordReleaseFunc(storageIncrementFunc StorageIncrementFunc, next quarantine.ReleaseFunc) quarantine.ReleaseFunc {
+	return func(ctx context.Context, gameID, id string) (quarantine.Submission, error) {
+		s, err := next(ctx, gameID, id)
+		if err != nil {
+			return quarantine.Submission{}, err
+		}
+
+		return s, storageIncrementFunc(ctx, s.GameID, s.PlayerID, 0, 1, time.Now().UTC())
+	}
+}
+
+func BuildGetFunc(config Config, storageGetRecordFunc StorageGetRecordFunc) GetFunc {
+	return func(ctx context.Context, gameID, playerID string) (Trust, error) {
+		if gameID == "" {
+			return Trust{}, ErrInvalidGameID
+		}
+
+		if playerID == "" {
+			return Trust{}, ErrInvalidPlayerID
+		}
+
+		record, err := storageGetRecordFunc(ctx, gameID, playerID)
+		if err != nil {
+			return Trust{}, err
+		}
+
+		return config.trust(record, time.Now().UTC()), nil
+	}
+}
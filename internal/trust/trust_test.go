@@ -0,0 +1,164 @@
+package trust
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/leaderboard"
+	"github.com/gabapcia/gameblitz/internal/quarantine"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var config = Config{LowThreshold: 30, HighThreshold: 80, LowDeltaFactor: 0.5}
+
+func TestRecordScore(t *testing.T) {
+	now := time.Now().UTC()
+
+	assert.Equal(t, 50.0, Record{FirstSeenAt: now}.score(now))
+	assert.Equal(t, 60.0, Record{FirstSeenAt: now.Add(-10*24*time.Hour - time.Hour)}.score(now))
+	assert.Equal(t, 80.0, Record{FirstSeenAt: now.Add(-365 * 24 * time.Hour)}.score(now))
+	assert.Equal(t, 50.0, Record{FirstSeenAt: now, Flagged: 2, Cleared: 2}.score(now))
+	assert.Equal(t, 0.0, Record{FirstSeenAt: now, Flagged: 10}.score(now))
+	assert.Equal(t, 100.0, Record{FirstSeenAt: now, Cleared: 10}.score(now))
+}
+
+func TestBuildAssessFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		lb      = leaderboard.Leaderboard{ID: "leaderboard", GameID: "game"}
+		records = make(map[string]Record)
+		seen    Assessment
+	)
+
+	track := func(ctx context.Context, gameID, playerID string, seenAt time.Time) (Record, error) {
+		r, ok := records[playerID]
+		if !ok {
+			r = Record{FirstSeenAt: seenAt, GameID: gameID, PlayerID: playerID}
+			records[playerID] = r
+		}
+
+		return r, nil
+	}
+	next := func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64) error {
+		seen, _ = AssessmentFromContext(ctx)
+		return nil
+	}
+
+	assess, err := BuildAssessFunc(config, track, next)
+	assert.NoError(t, err)
+
+	t.Run("New Player", func(t *testing.T) {
+		assert.NoError(t, assess(ctx, lb, "alice", 10))
+		assert.Equal(t, Assessment{Level: LevelNormal, DeltaFactor: 1}, seen)
+		assert.Contains(t, records, "alice")
+	})
+
+	t.Run("Low Trust", func(t *testing.T) {
+		records["bob"] = Record{FirstSeenAt: time.Now(), Flagged: 3}
+
+		assert.NoError(t, assess(ctx, lb, "bob", 10))
+		assert.Equal(t, Assessment{Level: LevelLow, DeltaFactor: 0.5}, seen)
+	})
+
+	t.Run("High Trust", func(t *testing.T) {
+		records["carol"] = Record{FirstSeenAt: time.Now().Add(-60 * 24 * time.Hour)}
+
+		assert.NoError(t, assess(ctx, lb, "carol", 10))
+		assert.Equal(t, Assessment{Level: LevelHigh, DeltaFactor: 1}, seen)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		storageErr := errors.New("any storage error")
+		assess, err := BuildAssessFunc(config, func(ctx context.Context, gameID, playerID string, seenAt time.Time) (Record, error) {
+			return Record{}, storageErr
+		}, next)
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, assess(ctx, lb, "alice", 10), storageErr)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		_, err := BuildAssessFunc(Config{LowThreshold: 80, HighThreshold: 30}, track, next)
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidThresholds)
+		assert.ErrorIs(t, err, ErrInvalidDeltaFactor)
+	})
+}
+
+func TestBuildRecordOutcomeFuncs(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		lb      = leaderboard.Leaderboard{ID: "leaderboard", GameID: "game"}
+		flagged int64
+		cleared int64
+	)
+
+	increment := func(ctx context.Context, gameID, playerID string, f, c int64, seenAt time.Time) error {
+		flagged += f
+		cleared += c
+		return nil
+	}
+
+	hold := BuildRecordHoldFunc(increment, func(ctx context.Context, lb leaderboard.Leaderboard, playerID string, value float64, reason, proof string) (quarantine.Submission, error) {
+		if value < 0 {
+			return quarantine.Submission{}, errors.New("any storage error")
+		}
+
+		return quarantine.Submission{HeldAt: time.Now(), GameID: lb.GameID, LeaderboardID: lb.ID, PlayerID: playerID, Value: value}, nil
+	})
+	release := BuildRecordReleaseFunc(increment, func(ctx context.Context, gameID, id string) (quarantine.Submission, error) {
+		if id == "" {
+			return quarantine.Submission{}, quarantine.ErrInvalidSubmissionID
+		}
+
+		return quarantine.Submission{GameID: gameID, PlayerID: "alice"}, nil
+	})
+
+	_, err := hold(ctx, lb, "alice", 10, "MAX_SUBMISSION_DELTA", "")
+	assert.NoError(t, err)
+
+	_, err = hold(ctx, lb, "alice", -1, "MAX_SUBMISSION_DELTA", "")
+	assert.Error(t, err)
+
+	_, err = release(ctx, "game", "submission")
+	assert.NoError(t, err)
+
+	_, err = release(ctx, "game", "")
+	assert.ErrorIs(t, err, quarantine.ErrInvalidSubmissionID)
+
+	assert.Equal(t, int64(1), flagged)
+	assert.Equal(t, int64(1), cleared)
+}
+
+func TestBuildGetFunc(t *testing.T) {
+	var (
+		ctx = context.Background()
+		get = BuildGetFunc(config, func(ctx context.Context, gameID, playerID string) (Record, error) {
+			if playerID != "alice" {
+				return Record{}, ErrRecordNotFound
+			}
+
+			return Record{FirstSeenAt: time.Now(), GameID: gameID, PlayerID: playerID, Flagged: 3}, nil
+		})
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		trust, err := get(ctx, "game", "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, 20.0, trust.Score)
+		assert.Equal(t, LevelLow, trust.Level)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := get(ctx, "game", "bob")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("Invalid Player", func(t *testing.T) {
+		_, err := get(ctx, "game", "")
+		assert.ErrorIs(t, err, ErrInvalidPlayerID)
+	})
+}
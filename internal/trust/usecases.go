@@ -0,0 +1,8 @@
+package trust
+
+import "context"
+
+type (
+	// Get the player's trust, computed as of now
+	GetFunc func(ctx context.Context, gameID, playerID string) (Trust, error)
+)
@@ -0,0 +1,18 @@
+package usage
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Adds the counters to the ones already stored for each game and day
+	StorageAddUsageFunc func(ctx context.Context, usage []DailyUsage) error
+
+	// Lists the daily counters of the game from `from` to `to`, both inclusive, oldest first.
+	// Days without any usage are not returned
+	StorageListUsageFunc func(ctx context.Context, gameID string, from, to time.Time) ([]DailyUsage, error)
+
+	// Measures the data the game keeps on the storage
+	StorageGameFootprintFunc func(ctx context.Context, gameID string) (Footprint, error)
+)
@@ -0,0 +1,238 @@
+package usage
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Longest period a report can span
+const MaxReportPeriod = 366 * 24 * time.Hour
+
+var (
+	ErrMissingGameID = errors.New("missing game id")
+	ErrInvalidPeriod = errors.New("usage period must not end before it starts nor span more than 366 days")
+)
+
+type (
+	// Usage of a game on a day
+	DailyUsage struct {
+		Day              time.Time   // Start of the day, in UTC
+		GameID           string      // Game the usage belongs to
+		Requests         int64       // Requests handled for the game
+		ScoreSubmissions int64       // Rank and statistic progression updates, also counted as requests
+		Errors           ErrorCounts // Requests answered with an error, also counted as requests. Nil without any
+	}
+
+	// Data a game keeps on the storage
+	Footprint struct {
+		Records int64 // Number of stored records
+		Bytes   int64 // Size of the stored records, in bytes
+	}
+
+	// Usage of a game over a period
+	Report struct {
+		GameID           string       // Game the usage belongs to
+		From             time.Time    // First day of the period
+		To               time.Time    // Last day of the period
+		Requests         int64        // Requests handled on the period
+		ScoreSubmissions int64        // Score submissions handled on the period
+		Errors           ErrorCounts  // Requests answered with an error on the period
+		Days             []DailyUsage // Usage of each day with any, oldest first
+		Footprint        Footprint    // Data the game currently keeps on the storage
+	}
+)
+
+// Requests answered with an error, by the code of the error, e.g. `1.2`
+type ErrorCounts map[string]int64
+
+// Adds the counts of the other ones, returning the result since a nil map is allocated
+func (e ErrorCounts) add(other ErrorCounts) ErrorCounts {
+	if len(other) == 0 {
+		return e
+	}
+
+	if e == nil {
+		e = make(ErrorCounts, len(other))
+	}
+
+	for code, count := range other {
+		e[code] += count
+	}
+
+	return e
+}
+
+type ErrorCount struct {
+	Code  string // Code of the error
+	Count int64  // Requests answered with it
+}
+
+// The most frequent errors, at most `n`, most frequent first. Ties are ordered by code
+func (e ErrorCounts) Top(n int) []ErrorCount {
+	top := make([]ErrorCount, 0, len(e))
+	for code, count := range e {
+		top = append(top, ErrorCount{Code: code, Count: count})
+	}
+
+	slices.SortFunc(top, func(a, b ErrorCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Code, b.Code)
+	})
+
+	return top[:min(n, len(top))]
+}
+
+// Start of the day of the time, in UTC
+func Day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+type dailyUsageKey struct {
+	day    time.Time
+	gameID string
+}
+
+// Aggregates the usage in memory, so the storage is written once per game and day on each flush
+// instead of once per request
+type Meter struct {
+	mu      sync.Mutex
+	pending map[dailyUsageKey]DailyUsage
+
+	storageAddUsageFunc StorageAddUsageFunc
+}
+
+func NewMeter(storageAddUsageFunc StorageAddUsageFunc) *Meter {
+	return &Meter{
+		pending:             make(map[dailyUsageKey]DailyUsage),
+		storageAddUsageFunc: storageAddUsageFunc,
+	}
+}
+
+// Counts a request of the game on the current day. Matches `RecordFunc`
+func (m *Meter) Record(gameID string, scoreSubmission bool, errorCode string) {
+	if gameID == "" {
+		return
+	}
+
+	u := DailyUsage{Day: Day(time.Now()), GameID: gameID, Requests: 1}
+	if scoreSubmission {
+		u.ScoreSubmissions = 1
+	}
+
+	if errorCode != "" {
+		u.Errors = ErrorCounts{errorCode: 1}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.add(u)
+}
+
+func (m *Meter) add(u DailyUsage) {
+	key := dailyUsageKey{day: u.Day, gameID: u.GameID}
+
+	pending, ok := m.pending[key]
+	if !ok {
+		pending = DailyUsage{Day: u.Day, GameID: u.GameID}
+	}
+
+	pending.Requests += u.Requests
+	pending.ScoreSubmissions += u.ScoreSubmissions
+	pending.Errors = pending.Errors.add(u.Errors)
+	m.pending[key] = pending
+}
+
+// Writes the aggregated usage to the storage. On failure the usage is kept to be written on the next flush
+func (m *Meter) Flush(ctx context.Context) error {
+	m.mu.Lock()
+	pending := m.pending
+	m.pending = make(map[dailyUsageKey]DailyUsage)
+	m.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	usage := make([]DailyUsage, 0, len(pending))
+	for _, u := range pending {
+		usage = append(usage, u)
+	}
+
+	if err := m.storageAddUsageFunc(ctx, usage); err != nil {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		for _, u := range usage {
+			m.add(u)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Flushes the usage on every interval until the context is canceled, flushing it one last time before returning.
+// `onError` is called with each failed flush. Optional
+func (m *Meter) Run(ctx context.Context, interval time.Duration, onError func(err error)) {
+	flush := func(ctx context.Context) {
+		if err := m.Flush(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flush(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
+
+func BuildReportFunc(storageListUsageFunc StorageListUsageFunc, storageGameFootprintFunc StorageGameFootprintFunc) ReportFunc {
+	return func(ctx context.Context, gameID string, from, to time.Time) (Report, error) {
+		if gameID == "" {
+			return Report{}, ErrMissingGameID
+		}
+
+		from, to = Day(from), Day(to)
+		if to.Before(from) || to.Sub(from) >= MaxReportPeriod {
+			return Report{}, ErrInvalidPeriod
+		}
+
+		days, err := storageListUsageFunc(ctx, gameID, from, to)
+		if err != nil {
+			return Report{}, err
+		}
+
+		report := Report{GameID: gameID, From: from, To: to, Days: days}
+		for _, d := range days {
+			report.Requests += d.Requests
+			report.ScoreSubmissions += d.ScoreSubmissions
+			report.Errors = report.Errors.add(d.Errors)
+		}
+
+		// Storages that can't measure the data they keep leave the footprint empty
+		if storageGameFootprintFunc != nil {
+			if report.Footprint, err = storageGameFootprintFunc(ctx, gameID); err != nil {
+				return Report{}, err
+			}
+		}
+
+		return report, nil
+	}
+}
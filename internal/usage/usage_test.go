@@ -0,0 +1,173 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeter(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		var (
+			gameID  = uuid.NewString()
+			flushed []DailyUsage
+		)
+
+		meter := NewMeter(func(ctx context.Context, usage []DailyUsage) error {
+			flushed = append(flushed, usage...)
+			return nil
+		})
+
+		meter.Record(gameID, false, "")
+		meter.Record(gameID, true, "")
+		meter.Record(gameID, false, "1.2")
+		meter.Record(gameID, false, "1.2")
+		meter.Record("", true, "")
+
+		err := meter.Flush(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, flushed, 1)
+		assert.Equal(t, gameID, flushed[0].GameID)
+		assert.Equal(t, Day(time.Now()), flushed[0].Day)
+		assert.Equal(t, int64(4), flushed[0].Requests)
+		assert.Equal(t, int64(1), flushed[0].ScoreSubmissions)
+		assert.Equal(t, ErrorCounts{"1.2": 2}, flushed[0].Errors)
+
+		// Nothing left to write
+		flushed = nil
+		err = meter.Flush(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, flushed)
+	})
+
+	t.Run("Kept On Storage Error", func(t *testing.T) {
+		var (
+			gameID   = uuid.NewString()
+			fail     = true
+			flushed  []DailyUsage
+			errStore = errors.New("any error")
+		)
+
+		meter := NewMeter(func(ctx context.Context, usage []DailyUsage) error {
+			if fail {
+				return errStore
+			}
+
+			flushed = append(flushed, usage...)
+			return nil
+		})
+
+		meter.Record(gameID, true, "")
+		meter.Record(gameID, false, "0.0")
+
+		err := meter.Flush(context.Background())
+		assert.ErrorIs(t, err, errStore)
+
+		meter.Record(gameID, false, "0.0")
+		fail = false
+
+		err = meter.Flush(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, flushed, 1)
+		assert.Equal(t, int64(3), flushed[0].Requests)
+		assert.Equal(t, int64(1), flushed[0].ScoreSubmissions)
+		assert.Equal(t, ErrorCounts{"0.0": 2}, flushed[0].Errors)
+	})
+
+	t.Run("Flush On Cancel", func(t *testing.T) {
+		flushed := make(chan []DailyUsage, 1)
+
+		meter := NewMeter(func(ctx context.Context, usage []DailyUsage) error {
+			flushed <- usage
+			return ctx.Err()
+		})
+		meter.Record(uuid.NewString(), false, "")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		meter.Run(ctx, time.Hour, func(err error) { t.Error(err) })
+		assert.Len(t, <-flushed, 1)
+	})
+}
+
+func TestBuildReportFunc(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		gameID = uuid.NewString()
+		today  = Day(time.Now())
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		report := BuildReportFunc(
+			func(ctx context.Context, gameID string, from, to time.Time) ([]DailyUsage, error) {
+				return []DailyUsage{
+					{Day: from, GameID: gameID, Requests: 10, ScoreSubmissions: 3, Errors: ErrorCounts{"1.2": 2}},
+					{Day: to, GameID: gameID, Requests: 5, ScoreSubmissions: 1, Errors: ErrorCounts{"1.2": 1, "0.0": 1}},
+				}, nil
+			},
+			func(ctx context.Context, gameID string) (Footprint, error) {
+				return Footprint{Records: 2, Bytes: 512}, nil
+			},
+		)
+
+		got, err := report(ctx, gameID, today.Add(-48*time.Hour+time.Minute), today.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, today.Add(-48*time.Hour), got.From)
+		assert.Equal(t, today, got.To)
+		assert.Equal(t, int64(15), got.Requests)
+		assert.Equal(t, int64(4), got.ScoreSubmissions)
+		assert.Equal(t, ErrorCounts{"1.2": 3, "0.0": 1}, got.Errors)
+		assert.Len(t, got.Days, 2)
+		assert.Equal(t, Footprint{Records: 2, Bytes: 512}, got.Footprint)
+	})
+
+	t.Run("Without Footprint", func(t *testing.T) {
+		report := BuildReportFunc(func(ctx context.Context, gameID string, from, to time.Time) ([]DailyUsage, error) {
+			return nil, nil
+		}, nil)
+
+		got, err := report(ctx, gameID, today, today)
+		assert.NoError(t, err)
+		assert.Zero(t, got.Footprint)
+	})
+
+	t.Run("Invalid Period", func(t *testing.T) {
+		report := BuildReportFunc(nil, nil)
+
+		_, err := report(ctx, gameID, today, today.Add(-24*time.Hour))
+		assert.ErrorIs(t, err, ErrInvalidPeriod)
+
+		_, err = report(ctx, gameID, today.Add(-MaxReportPeriod), today)
+		assert.ErrorIs(t, err, ErrInvalidPeriod)
+	})
+
+	t.Run("Missing Game ID", func(t *testing.T) {
+		report := BuildReportFunc(nil, nil)
+
+		_, err := report(ctx, "", today, today)
+		assert.ErrorIs(t, err, ErrMissingGameID)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		errStore := errors.New("any error")
+		report := BuildReportFunc(func(ctx context.Context, gameID string, from, to time.Time) ([]DailyUsage, error) {
+			return nil, errStore
+		}, nil)
+
+		_, err := report(ctx, gameID, today, today)
+		assert.ErrorIs(t, err, errStore)
+	})
+}
+
+func TestErrorCountsTop(t *testing.T) {
+	errorCounts := ErrorCounts{"1.2": 3, "0.0": 1, "2.1": 3, "4.0": 5}
+
+	assert.Equal(t, []ErrorCount{{Code: "4.0", Count: 5}, {Code: "1.2", Count: 3}}, errorCounts.Top(2))
+	assert.Len(t, errorCounts.Top(10), 4)
+	assert.Empty(t, ErrorCounts(nil).Top(5))
+}
@@ -0,0 +1,15 @@
+package usage
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Counts a request handled for the game, also counting it as a score submission when `scoreSubmission` is set
+	// and as an error with the code when it's not empty
+	RecordFunc func(gameID string, scoreSubmission bool, errorCode string)
+
+	// Sums the usage of the game from `from` to `to`, both inclusive, and measures its storage footprint
+	ReportFunc func(ctx context.Context, gameID string, from, to time.Time) (Report, error)
+)
@@ -0,0 +1,7 @@
+package webhook
+
+import "context"
+
+// Posts the body to the url with the given headers, returning the response status code.
+// An error means no response was received
+type SenderPostFunc func(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Stores a new webhook, returning it with its ID
+	StorageCreateWebhookFunc func(ctx context.Context, data NewWebhookData) (Webhook, error)
+
+	// Lists the webhooks of the game, oldest first
+	StorageListWebhooksFunc func(ctx context.Context, gameID string) ([]Webhook, error)
+
+	// Get a webhook by its id
+	StorageGetWebhookFunc func(ctx context.Context, id string) (Webhook, error)
+
+	// Removes the webhook of the game alongside its deliveries
+	StorageDeleteWebhookFunc func(ctx context.Context, id, gameID string) error
+
+	// Enables the webhook of the game again, resetting its consecutive failures
+	StorageEnableWebhookFunc func(ctx context.Context, id, gameID string) (Webhook, error)
+
+	// Replaces the filter of the webhook of the game
+	StorageUpdateWebhookFilterFunc func(ctx context.Context, id, gameID string, filter Filter) (Webhook, error)
+
+	// Records the result of a delivery attempt on the webhook. A success resets its consecutive failures,
+	// while a failure increments them and disables the webhook once they reach `disableAfter`
+	StorageRecordWebhookAttemptFunc func(ctx context.Context, id string, succeeded bool, disableAfter int) error
+
+	// Stores the deliveries waiting to be sent, assigning their IDs
+	StorageCreateDeliveriesFunc func(ctx context.Context, deliveries []Delivery) error
+
+	// Lists up to `limit` pending deliveries due by `now`, oldest first, moving their next attempt `lease` ahead,
+	// so the same delivery isn't sent twice at the same time
+	StorageClaimDueDeliveriesFunc func(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error)
+
+	// Saves the status, attempts and next attempt time of the delivery
+	StorageUpdateDeliveryFunc func(ctx context.Context, delivery Delivery) error
+
+	// Lists up to `limit` deliveries of the webhook, newest first
+	StorageListDeliveriesFunc func(ctx context.Context, webhookID string, limit int) ([]Delivery, error)
+)
@@ -0,0 +1,29 @@
+package webhook
+
+import "context"
+
+type (
+	// Registers a webhook receiving the events of the game
+	CreateWebhookFunc func(ctx context.Context, data NewWebhookData) (Webhook, error)
+
+	// Lists the webhooks of the game
+	ListWebhooksFunc func(ctx context.Context, gameID string) ([]Webhook, error)
+
+	// Removes the webhook of the game alongside its deliveries
+	DeleteWebhookFunc func(ctx context.Context, id, gameID string) error
+
+	// Enables a webhook disabled after failing too many times in a row
+	EnableWebhookFunc func(ctx context.Context, id, gameID string) (Webhook, error)
+
+	// Replaces the events and resources the webhook of the game subscribes to
+	UpdateWebhookFilterFunc func(ctx context.Context, id, gameID string, filter Filter) (Webhook, error)
+
+	// Queues a delivery of the event to each enabled webhook of its game subscribed to it
+	EnqueueFunc func(ctx context.Context, event Event) error
+
+	// Sends a batch of due deliveries, returning how many were attempted
+	DeliverFunc func(ctx context.Context) (int, error)
+
+	// Lists up to `limit` deliveries of the webhook, newest first
+	ListDeliveriesFunc func(ctx context.Context, webhookID string, limit int) ([]Delivery, error)
+)
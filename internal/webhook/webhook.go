@@ -0,0 +1,381 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DeliveryStatusPending   = "PENDING"
+	DeliveryStatusSucceeded = "SUCCEEDED"
+	DeliveryStatusFailed    = "FAILED"
+)
+
+// Headers sent on every delivery, so the receiver can identify and verify it
+const (
+	HeaderEvent     = "X-Gameblitz-Event"
+	HeaderDelivery  = "X-Gameblitz-Delivery"
+	HeaderTimestamp = "X-Gameblitz-Timestamp"
+	HeaderSignature = "X-Gameblitz-Signature"
+)
+
+// Kinds of resources an event refers to
+const (
+	ResourceLeaderboard = "LEADERBOARD"
+	ResourceStatistic   = "STATISTIC"
+	ResourceQuest       = "QUEST"
+)
+
+const signaturePrefix = "sha256="
+
+var (
+	ErrInvalidWebhookID    = errors.New("invalid webhook id")
+	ErrWebhookNotFound     = errors.New("webhook not found")
+	ErrInvalidWebhookURL   = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidFilter       = errors.New("webhook filter must list known event types and non empty resource ids")
+	ErrUnexpectedStatus    = errors.New("unexpected response status")
+	ErrInvalidMaxAttempts  = errors.New("max attempts must be greater than zero")
+	ErrInvalidDisableAfter = errors.New("disable after must be greater than zero")
+	ErrInvalidLimit        = errors.New("limit must be greater than zero")
+)
+
+type (
+	// Endpoint of the game that receives its events
+	Webhook struct {
+		CreatedAt           time.Time // Time the webhook was registered
+		UpdatedAt           time.Time // Last time the webhook was changed
+		DisabledAt          time.Time // Time the webhook was disabled after failing too many times in a row. Zero while enabled
+		ID                  string    // Webhook ID, assigned by the storage
+		GameID              string    // Game the webhook belongs to
+		URL                 string    // Endpoint the events are posted to
+		Secret              string    // Key of the deliveries signature, shared with the receiver
+		Filter              Filter    // Events the webhook receives
+		ConsecutiveFailures int       // Failed attempts since the last successful one
+	}
+
+	// Events and resources a webhook subscribes to. An empty list subscribes to all of them.
+	// Resource lists only narrow the events of their own kind, e.g. `LeaderboardIDs` doesn't affect the quest events
+	Filter struct {
+		Events         []string // Event types delivered
+		LeaderboardIDs []string // Leaderboards whose events are delivered
+		StatisticIDs   []string // Statistics whose events are delivered
+		QuestIDs       []string // Quests whose events are delivered
+	}
+
+	NewWebhookData struct {
+		GameID string // Game the webhook belongs to
+		URL    string // Endpoint the events are posted to
+		Secret string // Key of the deliveries signature, generated on creation
+		Filter Filter // Events the webhook receives
+	}
+
+	// Event published by the game, sent to its webhooks
+	Event struct {
+		Type        string // Event type, e.g. `gameblitz.statistic.player_progression`
+		GameID      string // Game the event belongs to
+		Resource    string // Kind of the resource the event refers to, one of `LEADERBOARD`, `STATISTIC` or `QUEST`. Empty when none
+		ResourceID  string // ID of the resource the event refers to
+		ContentType string // Media type of the payload
+		Payload     []byte // Encoded event, sent as the request body
+	}
+
+	// Result of a single request to the webhook
+	Attempt struct {
+		AttemptedAt time.Time     // Time the request was sent
+		Duration    time.Duration // Time waiting for the response
+		StatusCode  int           // Response status code. Zero when no response was received
+		Error       string        // Why the attempt failed. Empty when it succeeded
+	}
+
+	// Event sent to a webhook, retried until it succeeds or fails too many times
+	Delivery struct {
+		CreatedAt     time.Time // Time the delivery was queued
+		NextAttemptAt time.Time // Time the delivery is due while pending
+		ID            string    // Delivery ID, assigned by the storage
+		WebhookID     string    // Webhook the event is sent to
+		GameID        string    // Game the event belongs to
+		Event         string    // Event type
+		ContentType   string    // Media type of the payload
+		Payload       []byte    // Encoded event, sent as the request body
+		Status        string    // Delivery status, one of `PENDING`, `SUCCEEDED` or `FAILED`
+		Attempts      []Attempt // Requests sent, oldest first
+	}
+
+	// Wait before the next attempt, given how many attempts already failed
+	RetryDelayFunc func(attempts int) time.Duration
+)
+
+// Signs the payload with HMAC-SHA256, as `sha256=<hex digest>`. The timestamp, in unix seconds,
+// is signed alongside the payload as `<timestamp>.<payload>`, so the receiver can reject replayed deliveries
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+func validateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	return nil
+}
+
+func (f Filter) validate(eventTypes []string) error {
+	for _, event := range f.Events {
+		if !slices.Contains(eventTypes, event) {
+			return ErrInvalidFilter
+		}
+	}
+
+	for _, ids := range [][]string{f.LeaderboardIDs, f.StatisticIDs, f.QuestIDs} {
+		if slices.Contains(ids, "") {
+			return ErrInvalidFilter
+		}
+	}
+
+	return nil
+}
+
+// Whether the webhook subscribes to the event
+func (f Filter) matches(event Event) bool {
+	if len(f.Events) > 0 && !slices.Contains(f.Events, event.Type) {
+		return false
+	}
+
+	var ids []string
+	switch event.Resource {
+	case ResourceLeaderboard:
+		ids = f.LeaderboardIDs
+	case ResourceStatistic:
+		ids = f.StatisticIDs
+	case ResourceQuest:
+		ids = f.QuestIDs
+	}
+
+	return len(ids) == 0 || slices.Contains(ids, event.ResourceID)
+}
+
+// `eventTypes` lists the event types a filter can subscribe to
+func BuildCreateWebhookFunc(eventTypes []string, storageCreateWebhookFunc StorageCreateWebhookFunc) CreateWebhookFunc {
+	return func(ctx context.Context, data NewWebhookData) (Webhook, error) {
+		if err := validateURL(data.URL); err != nil {
+			return Webhook{}, err
+		}
+
+		if err := data.Filter.validate(eventTypes); err != nil {
+			return Webhook{}, err
+		}
+
+		secret, err := newSecret()
+		if err != nil {
+			return Webhook{}, err
+		}
+		data.Secret = secret
+
+		return storageCreateWebhookFunc(ctx, data)
+	}
+}
+
+func BuildListWebhooksFunc(storageListWebhooksFunc StorageListWebhooksFunc) ListWebhooksFunc {
+	return func(ctx context.Context, gameID string) ([]Webhook, error) {
+		return storageListWebhooksFunc(ctx, gameID)
+	}
+}
+
+func BuildDeleteWebhookFunc(storageDeleteWebhookFunc StorageDeleteWebhookFunc) DeleteWebhookFunc {
+	return func(ctx context.Context, id, gameID string) error {
+		return storageDeleteWebhookFunc(ctx, id, gameID)
+	}
+}
+
+func BuildEnableWebhookFunc(storageEnableWebhookFunc StorageEnableWebhookFunc) EnableWebhookFunc {
+	return func(ctx context.Context, id, gameID string) (Webhook, error) {
+		return storageEnableWebhookFunc(ctx, id, gameID)
+	}
+}
+
+// `eventTypes` lists the event types a filter can subscribe to
+func BuildUpdateWebhookFilterFunc(eventTypes []string, storageUpdateWebhookFilterFunc StorageUpdateWebhookFilterFunc) UpdateWebhookFilterFunc {
+	return func(ctx context.Context, id, gameID string, filter Filter) (Webhook, error) {
+		if err := filter.validate(eventTypes); err != nil {
+			return Webhook{}, err
+		}
+
+		return storageUpdateWebhookFilterFunc(ctx, id, gameID, filter)
+	}
+}
+
+func BuildEnqueueFunc(storageListWebhooksFunc StorageListWebhooksFunc, storageCreateDeliveriesFunc StorageCreateDeliveriesFunc) EnqueueFunc {
+	return func(ctx context.Context, event Event) error {
+		webhooks, err := storageListWebhooksFunc(ctx, event.GameID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		deliveries := make([]Delivery, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			if !webhook.DisabledAt.IsZero() || !webhook.Filter.matches(event) {
+				continue
+			}
+
+			deliveries = append(deliveries, Delivery{
+				CreatedAt:     now,
+				NextAttemptAt: now,
+				WebhookID:     webhook.ID,
+				GameID:        event.GameID,
+				Event:         event.Type,
+				ContentType:   event.ContentType,
+				Payload:       event.Payload,
+				Status:        DeliveryStatusPending,
+				Attempts:      make([]Attempt, 0),
+			})
+		}
+
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		return storageCreateDeliveriesFunc(ctx, deliveries)
+	}
+}
+
+// Sends the delivery once, returning whether the webhook accepted it
+func send(ctx context.Context, senderPostFunc SenderPostFunc, webhook Webhook, delivery Delivery) (Attempt, bool) {
+	attempt := Attempt{AttemptedAt: time.Now().UTC()}
+
+	headers := map[string]string{
+		"Content-Type":  delivery.ContentType,
+		HeaderEvent:     delivery.Event,
+		HeaderDelivery:  delivery.ID,
+		HeaderTimestamp: strconv.FormatInt(attempt.AttemptedAt.Unix(), 10),
+		HeaderSignature: Sign(webhook.Secret, attempt.AttemptedAt, delivery.Payload),
+	}
+
+	statusCode, err := senderPostFunc(ctx, webhook.URL, headers, delivery.Payload)
+	attempt.Duration = time.Since(attempt.AttemptedAt)
+	attempt.StatusCode = statusCode
+
+	switch {
+	case err != nil:
+		attempt.Error = err.Error()
+	case statusCode < 200 || statusCode > 299:
+		attempt.Error = fmt.Sprintf("%s: %d", ErrUnexpectedStatus, statusCode)
+	}
+
+	return attempt, attempt.Error == ""
+}
+
+// Deliveries are sent concurrently and retried with the delay given by `retryDelayFunc` until they succeed or reach `maxAttempts`.
+// Webhooks are disabled once `disableAfter` attempts fail in a row, and the pending deliveries of a disabled webhook are given up
+func BuildDeliverFunc(
+	maxAttempts, disableAfter, batchSize int,
+	lease time.Duration,
+	retryDelayFunc RetryDelayFunc,
+	senderPostFunc SenderPostFunc,
+	storageClaimDueDeliveriesFunc StorageClaimDueDeliveriesFunc,
+	storageGetWebhookFunc StorageGetWebhookFunc,
+	storageUpdateDeliveryFunc StorageUpdateDeliveryFunc,
+	storageRecordWebhookAttemptFunc StorageRecordWebhookAttemptFunc,
+) (DeliverFunc, error) {
+	if maxAttempts <= 0 {
+		return nil, ErrInvalidMaxAttempts
+	}
+
+	if disableAfter <= 0 {
+		return nil, ErrInvalidDisableAfter
+	}
+
+	deliver := func(ctx context.Context, delivery Delivery) error {
+		webhook, err := storageGetWebhookFunc(ctx, delivery.WebhookID)
+		if err != nil && !errors.Is(err, ErrWebhookNotFound) {
+			return err
+		}
+
+		if err != nil || !webhook.DisabledAt.IsZero() {
+			delivery.Status = DeliveryStatusFailed
+			return storageUpdateDeliveryFunc(ctx, delivery)
+		}
+
+		attempt, succeeded := send(ctx, senderPostFunc, webhook, delivery)
+
+		// Interrupted attempts aren't recorded, the delivery is retried once its lease expires
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		delivery.Attempts = append(delivery.Attempts, attempt)
+
+		switch {
+		case succeeded:
+			delivery.Status = DeliveryStatusSucceeded
+		case len(delivery.Attempts) >= maxAttempts:
+			delivery.Status = DeliveryStatusFailed
+		default:
+			delivery.NextAttemptAt = attempt.AttemptedAt.Add(retryDelayFunc(len(delivery.Attempts)))
+		}
+
+		if err := storageUpdateDeliveryFunc(ctx, delivery); err != nil {
+			return err
+		}
+
+		return storageRecordWebhookAttemptFunc(ctx, webhook.ID, succeeded, disableAfter)
+	}
+
+	return func(ctx context.Context) (int, error) {
+		deliveries, err := storageClaimDueDeliveriesFunc(ctx, time.Now().UTC(), lease, batchSize)
+		if err != nil {
+			return 0, err
+		}
+
+		var (
+			wg   sync.WaitGroup
+			errs = make([]error, len(deliveries))
+		)
+		for i, delivery := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = deliver(ctx, delivery)
+			}()
+		}
+		wg.Wait()
+
+		return len(deliveries), errors.Join(errs...)
+	}, nil
+}
+
+func BuildListDeliveriesFunc(storageListDeliveriesFunc StorageListDeliveriesFunc) ListDeliveriesFunc {
+	return func(ctx context.Context, webhookID string, limit int) ([]Delivery, error) {
+		if limit <= 0 {
+			return nil, ErrInvalidLimit
+		}
+
+		return storageListDeliveriesFunc(ctx, webhookID, limit)
+	}
+}
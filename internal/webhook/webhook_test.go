@@ -0,0 +1,391 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	var (
+		secret    = "secret"
+		timestamp = time.Unix(1700000000, 0)
+		payload   = []byte(`{"id":"1"}`)
+	)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(`1700000000.{"id":"1"}`))
+
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), Sign(secret, timestamp, payload))
+	assert.NotEqual(t, Sign(secret, timestamp, payload), Sign("other", timestamp, payload))
+	assert.NotEqual(t, Sign(secret, timestamp, payload), Sign(secret, timestamp.Add(time.Second), payload))
+}
+
+var testEventTypes = []string{"gameblitz.leaderboard.lifecycle", "gameblitz.quest.lifecycle"}
+
+func TestBuildCreateWebhookFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		data := NewWebhookData{GameID: uuid.NewString(), URL: "https://example.com/events"}
+
+		createWebhookFunc := BuildCreateWebhookFunc(testEventTypes, func(ctx context.Context, data NewWebhookData) (Webhook, error) {
+			return Webhook{ID: uuid.NewString(), GameID: data.GameID, URL: data.URL, Secret: data.Secret}, nil
+		})
+
+		webhook, err := createWebhookFunc(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, data.URL, webhook.URL)
+		assert.Len(t, webhook.Secret, 64)
+	})
+
+	t.Run("Invalid URL", func(t *testing.T) {
+		createWebhookFunc := BuildCreateWebhookFunc(testEventTypes, func(ctx context.Context, data NewWebhookData) (Webhook, error) {
+			assert.Fail(t, "must not be called")
+			return Webhook{}, nil
+		})
+
+		for _, u := range []string{"", "example.com", "ftp://example.com", "https://", "://example.com"} {
+			_, err := createWebhookFunc(ctx, NewWebhookData{GameID: uuid.NewString(), URL: u})
+			assert.ErrorIs(t, err, ErrInvalidWebhookURL, u)
+		}
+	})
+
+	t.Run("Invalid Filter", func(t *testing.T) {
+		createWebhookFunc := BuildCreateWebhookFunc(testEventTypes, func(ctx context.Context, data NewWebhookData) (Webhook, error) {
+			assert.Fail(t, "must not be called")
+			return Webhook{}, nil
+		})
+
+		for _, filter := range []Filter{{Events: []string{"unknown"}}, {LeaderboardIDs: []string{""}}, {QuestIDs: []string{uuid.NewString(), ""}}} {
+			_, err := createWebhookFunc(ctx, NewWebhookData{GameID: uuid.NewString(), URL: "https://example.com", Filter: filter})
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+		}
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		storageErr := errors.New("any error")
+
+		createWebhookFunc := BuildCreateWebhookFunc(testEventTypes, func(ctx context.Context, data NewWebhookData) (Webhook, error) {
+			return Webhook{}, storageErr
+		})
+
+		_, err := createWebhookFunc(ctx, NewWebhookData{GameID: uuid.NewString(), URL: "http://example.com"})
+		assert.ErrorIs(t, err, storageErr)
+	})
+}
+
+func TestBuildUpdateWebhookFilterFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		filter := Filter{Events: []string{"gameblitz.quest.lifecycle"}, QuestIDs: []string{uuid.NewString()}}
+
+		updateWebhookFilterFunc := BuildUpdateWebhookFilterFunc(testEventTypes, func(ctx context.Context, id, gameID string, f Filter) (Webhook, error) {
+			return Webhook{ID: id, GameID: gameID, Filter: f}, nil
+		})
+
+		webhook, err := updateWebhookFilterFunc(ctx, uuid.NewString(), uuid.NewString(), filter)
+		assert.NoError(t, err)
+		assert.Equal(t, filter, webhook.Filter)
+	})
+
+	t.Run("Invalid Filter", func(t *testing.T) {
+		updateWebhookFilterFunc := BuildUpdateWebhookFilterFunc(testEventTypes, func(ctx context.Context, id, gameID string, f Filter) (Webhook, error) {
+			assert.Fail(t, "must not be called")
+			return Webhook{}, nil
+		})
+
+		_, err := updateWebhookFilterFunc(ctx, uuid.NewString(), uuid.NewString(), Filter{StatisticIDs: []string{""}})
+		assert.ErrorIs(t, err, ErrInvalidFilter)
+	})
+}
+
+func TestBuildEnqueueFunc(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		event = Event{Type: "gameblitz.leaderboard.lifecycle", GameID: uuid.NewString(), Resource: ResourceLeaderboard, ResourceID: uuid.NewString(), ContentType: "application/json", Payload: []byte(`{}`)}
+	)
+
+	t.Run("OK", func(t *testing.T) {
+		var (
+			enabled  = Webhook{ID: uuid.NewString(), GameID: event.GameID}
+			disabled = Webhook{ID: uuid.NewString(), GameID: event.GameID, DisabledAt: time.Now()}
+			created  []Delivery
+		)
+
+		enqueueFunc := BuildEnqueueFunc(
+			func(ctx context.Context, gameID string) ([]Webhook, error) {
+				assert.Equal(t, event.GameID, gameID)
+				return []Webhook{enabled, disabled}, nil
+			},
+			func(ctx context.Context, deliveries []Delivery) error {
+				created = deliveries
+				return nil
+			},
+		)
+
+		err := enqueueFunc(ctx, event)
+		assert.NoError(t, err)
+		assert.Len(t, created, 1)
+		assert.Equal(t, enabled.ID, created[0].WebhookID)
+		assert.Equal(t, event.Type, created[0].Event)
+		assert.Equal(t, event.ContentType, created[0].ContentType)
+		assert.Equal(t, DeliveryStatusPending, created[0].Status)
+		assert.False(t, created[0].NextAttemptAt.IsZero())
+	})
+
+	t.Run("Filtered", func(t *testing.T) {
+		var (
+			subscribed = []Webhook{
+				{ID: uuid.NewString(), GameID: event.GameID, Filter: Filter{Events: []string{event.Type}}},
+				{ID: uuid.NewString(), GameID: event.GameID, Filter: Filter{LeaderboardIDs: []string{uuid.NewString(), event.ResourceID}}},
+				{ID: uuid.NewString(), GameID: event.GameID, Filter: Filter{QuestIDs: []string{uuid.NewString()}}},
+			}
+			unsubscribed = []Webhook{
+				{ID: uuid.NewString(), GameID: event.GameID, Filter: Filter{Events: []string{"gameblitz.quest.lifecycle"}}},
+				{ID: uuid.NewString(), GameID: event.GameID, Filter: Filter{LeaderboardIDs: []string{uuid.NewString()}}},
+			}
+			created []Delivery
+		)
+
+		enqueueFunc := BuildEnqueueFunc(
+			func(ctx context.Context, gameID string) ([]Webhook, error) {
+				return append(subscribed, unsubscribed...), nil
+			},
+			func(ctx context.Context, deliveries []Delivery) error {
+				created = deliveries
+				return nil
+			},
+		)
+
+		err := enqueueFunc(ctx, event)
+		assert.NoError(t, err)
+		assert.Len(t, created, len(subscribed))
+		for i, webhook := range subscribed {
+			assert.Equal(t, webhook.ID, created[i].WebhookID)
+		}
+	})
+
+	t.Run("No Webhooks", func(t *testing.T) {
+		enqueueFunc := BuildEnqueueFunc(
+			func(ctx context.Context, gameID string) ([]Webhook, error) { return nil, nil },
+			func(ctx context.Context, deliveries []Delivery) error {
+				assert.Fail(t, "must not be called")
+				return nil
+			},
+		)
+
+		err := enqueueFunc(ctx, event)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Storage Error", func(t *testing.T) {
+		storageErr := errors.New("any error")
+
+		enqueueFunc := BuildEnqueueFunc(
+			func(ctx context.Context, gameID string) ([]Webhook, error) { return nil, storageErr },
+			nil,
+		)
+
+		err := enqueueFunc(ctx, event)
+		assert.ErrorIs(t, err, storageErr)
+	})
+}
+
+func TestBuildDeliverFunc(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		webhook = Webhook{ID: uuid.NewString(), GameID: uuid.NewString(), URL: "https://example.com", Secret: "secret"}
+
+		retryDelay = func(attempts int) time.Duration { return time.Duration(attempts) * time.Minute }
+
+		newDelivery = func(attempts int) Delivery {
+			return Delivery{
+				ID:          uuid.NewString(),
+				WebhookID:   webhook.ID,
+				GameID:      webhook.GameID,
+				Event:       "gameblitz.leaderboard.lifecycle",
+				ContentType: "application/json",
+				Payload:     []byte(`{}`),
+				Status:      DeliveryStatusPending,
+				Attempts:    make([]Attempt, attempts),
+			}
+		}
+
+		claim = func(deliveries ...Delivery) StorageClaimDueDeliveriesFunc {
+			return func(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error) {
+				return deliveries, nil
+			}
+		}
+		getWebhook = func(ctx context.Context, id string) (Webhook, error) { return webhook, nil }
+	)
+
+	// Deliveries saved by their ID and the attempt results recorded on the webhook
+	type recorder struct {
+		mu       sync.Mutex
+		updated  map[string]Delivery
+		attempts []bool
+	}
+
+	build := func(t *testing.T, sender SenderPostFunc, getWebhookFunc StorageGetWebhookFunc, deliveries ...Delivery) (DeliverFunc, *recorder) {
+		r := &recorder{updated: make(map[string]Delivery)}
+
+		deliverFunc, err := BuildDeliverFunc(
+			3, 10, 10, time.Minute, retryDelay, sender, claim(deliveries...), getWebhookFunc,
+			func(ctx context.Context, delivery Delivery) error {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				r.updated[delivery.ID] = delivery
+				return nil
+			},
+			func(ctx context.Context, id string, succeeded bool, disableAfter int) error {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				assert.Equal(t, webhook.ID, id)
+				assert.Equal(t, 10, disableAfter)
+				r.attempts = append(r.attempts, succeeded)
+				return nil
+			},
+		)
+		assert.NoError(t, err)
+
+		return deliverFunc, r
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		delivery := newDelivery(0)
+
+		deliverFunc, r := build(t, func(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+			assert.Equal(t, webhook.URL, url)
+			assert.Equal(t, delivery.Event, headers[HeaderEvent])
+			assert.Equal(t, delivery.ID, headers[HeaderDelivery])
+			assert.Equal(t, delivery.ContentType, headers["Content-Type"])
+
+			timestamp, err := strconv.ParseInt(headers[HeaderTimestamp], 10, 64)
+			assert.NoError(t, err)
+			assert.Equal(t, Sign(webhook.Secret, time.Unix(timestamp, 0), body), headers[HeaderSignature])
+			return 204, nil
+		}, getWebhook, delivery)
+
+		n, err := deliverFunc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		updated := r.updated[delivery.ID]
+		assert.Equal(t, []bool{true}, r.attempts)
+		assert.Equal(t, DeliveryStatusSucceeded, updated.Status)
+		assert.Len(t, updated.Attempts, 1)
+		assert.Equal(t, 204, updated.Attempts[0].StatusCode)
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		delivery := newDelivery(1)
+
+		deliverFunc, r := build(t, func(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+			return 500, nil
+		}, getWebhook, delivery)
+
+		_, err := deliverFunc(ctx)
+		assert.NoError(t, err)
+
+		updated := r.updated[delivery.ID]
+		assert.Equal(t, []bool{false}, r.attempts)
+		assert.Equal(t, DeliveryStatusPending, updated.Status)
+		assert.Len(t, updated.Attempts, 2)
+		assert.Contains(t, updated.Attempts[1].Error, ErrUnexpectedStatus.Error())
+		assert.Equal(t, updated.Attempts[1].AttemptedAt.Add(2*time.Minute), updated.NextAttemptAt)
+	})
+
+	t.Run("Give Up", func(t *testing.T) {
+		delivery := newDelivery(2)
+
+		deliverFunc, r := build(t, func(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+			return 0, errors.New("any error")
+		}, getWebhook, delivery)
+
+		_, err := deliverFunc(ctx)
+		assert.NoError(t, err)
+
+		updated := r.updated[delivery.ID]
+		assert.Equal(t, []bool{false}, r.attempts)
+		assert.Equal(t, DeliveryStatusFailed, updated.Status)
+		assert.Len(t, updated.Attempts, 3)
+		assert.Equal(t, "any error", updated.Attempts[2].Error)
+	})
+
+	t.Run("Webhook Disabled", func(t *testing.T) {
+		delivery := newDelivery(0)
+
+		deliverFunc, r := build(t, func(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+			assert.Fail(t, "must not be called")
+			return 0, nil
+		}, func(ctx context.Context, id string) (Webhook, error) {
+			disabled := webhook
+			disabled.DisabledAt = time.Now()
+			return disabled, nil
+		}, delivery)
+
+		_, err := deliverFunc(ctx)
+		assert.NoError(t, err)
+
+		updated := r.updated[delivery.ID]
+		assert.Empty(t, r.attempts)
+		assert.Equal(t, DeliveryStatusFailed, updated.Status)
+		assert.Empty(t, updated.Attempts)
+	})
+
+	t.Run("Webhook Error", func(t *testing.T) {
+		storageErr := errors.New("any error")
+
+		deliverFunc, _ := build(t, nil, func(ctx context.Context, id string) (Webhook, error) {
+			return Webhook{}, storageErr
+		}, newDelivery(0), newDelivery(0))
+
+		n, err := deliverFunc(ctx)
+		assert.ErrorIs(t, err, storageErr)
+		assert.Equal(t, 2, n)
+	})
+
+	t.Run("Invalid Config", func(t *testing.T) {
+		_, err := BuildDeliverFunc(0, 1, 1, time.Minute, retryDelay, nil, nil, nil, nil, nil)
+		assert.ErrorIs(t, err, ErrInvalidMaxAttempts)
+
+		_, err = BuildDeliverFunc(1, 0, 1, time.Minute, retryDelay, nil, nil, nil, nil, nil)
+		assert.ErrorIs(t, err, ErrInvalidDisableAfter)
+	})
+}
+
+func TestBuildListDeliveriesFunc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		webhookID := uuid.NewString()
+
+		listDeliveriesFunc := BuildListDeliveriesFunc(func(ctx context.Context, id string, limit int) ([]Delivery, error) {
+			assert.Equal(t, webhookID, id)
+			assert.Equal(t, 10, limit)
+			return []Delivery{{WebhookID: id}}, nil
+		})
+
+		deliveries, err := listDeliveriesFunc(ctx, webhookID, 10)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		listDeliveriesFunc := BuildListDeliveriesFunc(nil)
+
+		_, err := listDeliveriesFunc(ctx, uuid.NewString(), 0)
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+	})
+}
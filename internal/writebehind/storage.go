@@ -0,0 +1,25 @@
+package writebehind
+
+import (
+	"context"
+
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+type (
+	// Queues the change, returning once it's persisted, so it survives a restart until removed
+	StorageAppendFunc func(ctx context.Context, change statistic.PlayerProgressionChange) error
+
+	// Oldest changes queued, up to `limit`, oldest first
+	StoragePeekFunc func(ctx context.Context, limit int) ([]Entry, error)
+
+	// Removes the changes queued up to the sequence, inclusive
+	StorageRemoveFunc func(ctx context.Context, upTo int64) error
+
+	// Number of changes queued
+	StorageCountFunc func(ctx context.Context) (int64, error)
+
+	// Sequence of the last change of the queue recorded by the statistic storage along with its bulk write. Zero when
+	// none was
+	StorageGetCheckpointFunc func(ctx context.Context, queueID string) (int64, error)
+)
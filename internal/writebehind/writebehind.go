@@ -0,0 +1,253 @@
+package writebehind
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gabapcia/gameblitz/internal/privacy"
+	"github.com/gabapcia/gameblitz/internal/statistic"
+)
+
+var (
+	ErrValidationError   = errors.New("validation error")
+	ErrInvalidBatchSize  = errors.New("batch size must be greater than zero")
+	ErrInvalidMaxPending = errors.New("max pending changes must be greater than zero")
+	ErrInvalidQueueID    = errors.New("queue ID must not be empty")
+)
+
+// Change waiting on the queue to be written
+type Entry struct {
+	Sequence int64                             // Position on the queue, increasing with each change
+	Change   statistic.PlayerProgressionChange // Change to be written
+}
+
+type Config struct {
+	QueueID    string // Identifies the queue to the statistic storage, which records the last change written from it
+	BatchSize  int    // Most changes written on each bulk write
+	MaxPending int64  // Most changes queued at once. Once reached, the updates are written right away
+}
+
+func (c Config) validate() error {
+	errList := make([]error, 0)
+
+	if c.QueueID == "" {
+		errList = append(errList, ErrInvalidQueueID)
+	}
+
+	if c.BatchSize <= 0 {
+		errList = append(errList, ErrInvalidBatchSize)
+	}
+
+	if c.MaxPending <= 0 {
+		errList = append(errList, ErrInvalidMaxPending)
+	}
+
+	if len(errList) > 0 {
+		errList = append(errList, ErrValidationError)
+	}
+
+	return errors.Join(errList...)
+}
+
+// Last change of a queue written to the statistic storage
+type Checkpoint struct {
+	QueueID  string // Queue the changes were written from
+	Sequence int64  // Sequence of the last change written
+}
+
+type checkpointKey struct{}
+
+// Tags the bulk write made with the context with the checkpoint the statistic storage records along with the changes,
+// within the same transaction
+func WithCheckpoint(ctx context.Context, checkpoint Checkpoint) context.Context {
+	return context.WithValue(ctx, checkpointKey{}, checkpoint)
+}
+
+// Checkpoint the bulk write made with the context records. False when it wasn't tagged
+func CheckpointFromContext(ctx context.Context) (Checkpoint, bool) {
+	checkpoint, ok := ctx.Value(checkpointKey{}).(Checkpoint)
+	return checkpoint, ok
+}
+
+// Acknowledges the statistic updates once they're queued, writing them to the statistic storage in bulk on each flush
+// instead of once per update. The aggregation modes don't depend on the order the values are applied in, so the
+// updates written right away while the queue is full, and the merged ones, end on the same progression
+type Buffer struct {
+	config  Config
+	flushMu sync.Mutex // A single flush at a time, so no change is written twice
+
+	storageAppendFunc StorageAppendFunc
+	storagePeekFunc   StoragePeekFunc
+	storageRemoveFunc StorageRemoveFunc
+	storageCountFunc  StorageCountFunc
+
+	storageGetCheckpointFunc StorageGetCheckpointFunc
+
+	notifierPlayerProgressionUpdates       statistic.NotifierPlayerProgressionUpdates
+	storageBulkUpdatePlayerProgressionFunc statistic.StorageBulkUpdatePlayerProgressionFunc
+	storageUpdatePlayerProgressionFunc     statistic.StorageUpdatePlayerProgressionFunc
+}
+
+// The notifier can be nil when the statistic storage already records the updates to be published, e.g. on an outbox.
+// The single update is used while the queue is full. The bulk update must record the checkpoint tagged on its context
+// within the same transaction as the changes, and `storageGetCheckpointFunc` read it back
+func NewBuffer(
+	config Config,
+	storageAppendFunc StorageAppendFunc,
+	storagePeekFunc StoragePeekFunc,
+	storageRemoveFunc StorageRemoveFunc,
+	storageCountFunc StorageCountFunc,
+	storageGetCheckpointFunc StorageGetCheckpointFunc,
+	notifierPlayerProgressionUpdates statistic.NotifierPlayerProgressionUpdates,
+	storageBulkUpdatePlayerProgressionFunc statistic.StorageBulkUpdatePlayerProgressionFunc,
+	storageUpdatePlayerProgressionFunc statistic.StorageUpdatePlayerProgressionFunc,
+) (*Buffer, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return &Buffer{
+		config:                                 config,
+		storageAppendFunc:                      storageAppendFunc,
+		storagePeekFunc:                        storagePeekFunc,
+		storageRemoveFunc:                      storageRemoveFunc,
+		storageCountFunc:                       storageCountFunc,
+		storageGetCheckpointFunc:               storageGetCheckpointFunc,
+		notifierPlayerProgressionUpdates:       notifierPlayerProgressionUpdates,
+		storageBulkUpdatePlayerProgressionFunc: storageBulkUpdatePlayerProgressionFunc,
+		storageUpdatePlayerProgressionFunc:     storageUpdatePlayerProgressionFunc,
+	}, nil
+}
+
+// Queues the update, so the progression returned is empty and its completions are only notified once it's flushed.
+// While the queue is full, the update is written right away instead. Matches `statistic.StorageUpdatePlayerProgressionFunc`
+func (b *Buffer) Update(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+	pending, err := b.storageCountFunc(ctx)
+	if err != nil {
+		return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, err
+	}
+
+	if pending >= b.config.MaxPending {
+		return b.storageUpdatePlayerProgressionFunc(ctx, st, playerID, value)
+	}
+
+	change := statistic.PlayerProgressionChange{Statistic: st, PlayerID: playerID, Value: value}
+	return statistic.PlayerProgression{}, statistic.PlayerProgressionUpdates{}, b.storageAppendFunc(ctx, change)
+}
+
+// Writes the queued changes, `BatchSize` at a time, until the queue is drained. The changes of a failed write are
+// kept to be written on the next flush, while the ones written are removed even if notifying their completions fails.
+// Each bulk write records the sequence of its last change along with the changes, so a change written but not
+// removed, e.g. the process stopped in between, is only removed on the next flush instead of written twice
+func (b *Buffer) Flush(ctx context.Context) error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	written, err := b.storageGetCheckpointFunc(ctx, b.config.QueueID)
+	if err != nil {
+		return err
+	}
+
+	notifyErrs := make([]error, 0)
+	for {
+		entries, err := b.storagePeekFunc(ctx, b.config.BatchSize)
+		if err != nil {
+			return errors.Join(append(notifyErrs, err)...)
+		}
+
+		if len(entries) == 0 {
+			return errors.Join(notifyErrs...)
+		}
+
+		last := entries[len(entries)-1].Sequence
+
+		changes := make([]statistic.PlayerProgressionChange, 0, len(entries))
+		for _, entry := range entries {
+			if entry.Sequence > written {
+				changes = append(changes, entry.Change)
+			}
+		}
+
+		if len(changes) > 0 {
+			// The bulk update reports both the write and the notifications failures for each change, so the write's is
+			// told apart here
+			var writeErr error
+			bulkUpsertPlayerProgressionFunc := statistic.BuildBulkUpsertPlayerProgressionFunc(
+				b.notifierPlayerProgressionUpdates,
+				func(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]statistic.PlayerProgression, []statistic.PlayerProgressionUpdates, error) {
+					progressions, updates, err := b.storageBulkUpdatePlayerProgressionFunc(ctx, changes)
+					writeErr = err
+					return progressions, updates, err
+				},
+			)
+
+			errs := bulkUpsertPlayerProgressionFunc(WithCheckpoint(ctx, Checkpoint{QueueID: b.config.QueueID, Sequence: last}), changes)
+			if writeErr != nil {
+				return errors.Join(append(notifyErrs, writeErr)...)
+			}
+
+			for _, err := range errs {
+				if err != nil {
+					notifyErrs = append(notifyErrs, err)
+				}
+			}
+
+			written = last
+		}
+
+		if err := b.storageRemoveFunc(ctx, last); err != nil {
+			return errors.Join(append(notifyErrs, err)...)
+		}
+
+		if len(entries) < b.config.BatchSize {
+			return errors.Join(notifyErrs...)
+		}
+	}
+}
+
+// Flushes the queue on every interval until the context is canceled, flushing it one last time before returning.
+// `onError` is called with each failed flush. Optional
+func (b *Buffer) Run(ctx context.Context, interval time.Duration, onError func(err error)) {
+	flush := func(ctx context.Context) {
+		if err := b.Flush(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flush(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
+
+// Erases the player's changes from the queue between two flushes, so a flush already holding them doesn't write them
+// back once the statistic storage is erased. The queue must be erased before the statistic storage
+func (b *Buffer) BuildErasePlayerFunc(storageErasePlayerFunc privacy.StorageErasePlayerFunc) privacy.StorageErasePlayerFunc {
+	return func(ctx context.Context, gameID, playerID string) (privacy.Erasure, error) {
+		b.flushMu.Lock()
+		defer b.flushMu.Unlock()
+
+		return storageErasePlayerFunc(ctx, gameID, playerID)
+	}
+}
+
+// Anonymizes the player's changes on the queue between two flushes, so a flush already holding them doesn't write them
+// to the player ID once the statistic storage is anonymized. The queue must be anonymized before the statistic storage
+func (b *Buffer) BuildAnonymizePlayerFunc(storageAnonymizePlayerFunc privacy.StorageAnonymizePlayerFunc) privacy.StorageAnonymizePlayerFunc {
+	return func(ctx context.Context, gameID, playerID, pseudonym string) (privacy.Anonymization, error) {
+		b.flushMu.Lock()
+		defer b.flushMu.Unlock()
+
+		return storageAnonymizePlayerFunc(ctx, gameID, playerID, pseudonym)
+	}
+}
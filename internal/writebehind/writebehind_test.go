@@ -0,0 +1,200 @@
+package writebehind
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gabapcia/gameblitz/internal/statistic"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Queue kept in memory, for the tests only
+type queue struct {
+	entries    []Entry
+	next       int64
+	checkpoint int64 // Recorded by the bulk writes that call `record`
+}
+
+func (q *queue) append(ctx context.Context, change statistic.PlayerProgressionChange) error {
+	q.next++
+	q.entries = append(q.entries, Entry{Sequence: q.next, Change: change})
+	return nil
+}
+
+func (q *queue) peek(ctx context.Context, limit int) ([]Entry, error) {
+	return q.entries[:min(limit, len(q.entries))], nil
+}
+
+func (q *queue) remove(ctx context.Context, upTo int64) error {
+	for len(q.entries) > 0 && q.entries[0].Sequence <= upTo {
+		q.entries = q.entries[1:]
+	}
+
+	return nil
+}
+
+func (q *queue) count(ctx context.Context) (int64, error) {
+	return int64(len(q.entries)), nil
+}
+
+func (q *queue) getCheckpoint(ctx context.Context, queueID string) (int64, error) {
+	return q.checkpoint, nil
+}
+
+func (q *queue) record(ctx context.Context) {
+	if checkpoint, ok := CheckpointFromContext(ctx); ok {
+		q.checkpoint = checkpoint.Sequence
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		st     = statistic.Statistic{ID: "statistic", GameID: "game", AggregationMode: statistic.AggregationModeSum}
+		config = Config{QueueID: "queue", BatchSize: 2, MaxPending: 3}
+	)
+
+	newBuffer := func(q *queue, bulk statistic.StorageBulkUpdatePlayerProgressionFunc, single statistic.StorageUpdatePlayerProgressionFunc) *Buffer {
+		buffer, err := NewBuffer(config, q.append, q.peek, q.remove, q.count, q.getCheckpoint, nil, bulk, single)
+		assert.NoError(t, err)
+		return buffer
+	}
+
+	t.Run("Queued And Flushed", func(t *testing.T) {
+		var (
+			q       = &queue{}
+			written = make(map[string]float64)
+			writes  int
+		)
+
+		buffer := newBuffer(q, func(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]statistic.PlayerProgression, []statistic.PlayerProgressionUpdates, error) {
+			writes++
+			q.record(ctx)
+			for _, change := range changes {
+				written[change.PlayerID] += change.Value
+			}
+
+			return make([]statistic.PlayerProgression, len(changes)), make([]statistic.PlayerProgressionUpdates, len(changes)), nil
+		}, nil)
+
+		for _, playerID := range []string{"alice", "alice", "bob"} {
+			progression, updates, err := buffer.Update(ctx, st, playerID, 10)
+			assert.NoError(t, err)
+			assert.Empty(t, progression)
+			assert.False(t, updates.HasCompletions())
+		}
+		assert.Empty(t, written)
+
+		assert.NoError(t, buffer.Flush(ctx))
+		assert.Equal(t, map[string]float64{"alice": 20, "bob": 10}, written)
+		assert.Equal(t, 2, writes)
+		assert.Equal(t, int64(3), q.checkpoint)
+		assert.Empty(t, q.entries)
+	})
+
+	t.Run("Written Changes Only Removed", func(t *testing.T) {
+		var (
+			q          = &queue{checkpoint: 3}
+			written    []float64
+			checkpoint Checkpoint
+		)
+
+		buffer := newBuffer(q, func(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]statistic.PlayerProgression, []statistic.PlayerProgressionUpdates, error) {
+			checkpoint, _ = CheckpointFromContext(ctx)
+			for _, change := range changes {
+				written = append(written, change.Value)
+			}
+
+			return make([]statistic.PlayerProgression, len(changes)), make([]statistic.PlayerProgressionUpdates, len(changes)), nil
+		}, nil)
+
+		// The first three were written before the process stopped, ahead of removing them
+		for value := range 4 {
+			assert.NoError(t, q.append(ctx, statistic.PlayerProgressionChange{Statistic: st, PlayerID: "alice", Value: float64(value)}))
+		}
+
+		assert.NoError(t, buffer.Flush(ctx))
+		assert.Equal(t, []float64{3}, written)
+		assert.Equal(t, Checkpoint{QueueID: "queue", Sequence: 4}, checkpoint)
+		assert.Empty(t, q.entries)
+	})
+
+	t.Run("Written Right Away While Full", func(t *testing.T) {
+		var (
+			q      = &queue{}
+			direct int
+		)
+
+		buffer := newBuffer(q, nil, func(ctx context.Context, st statistic.Statistic, playerID string, value float64) (statistic.PlayerProgression, statistic.PlayerProgressionUpdates, error) {
+			direct++
+			return statistic.PlayerProgression{PlayerID: playerID}, statistic.PlayerProgressionUpdates{GoalJustCompleted: true}, nil
+		})
+
+		for range 3 {
+			_, _, err := buffer.Update(ctx, st, "alice", 10)
+			assert.NoError(t, err)
+		}
+
+		progression, updates, err := buffer.Update(ctx, st, "alice", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", progression.PlayerID)
+		assert.True(t, updates.GoalJustCompleted)
+		assert.Equal(t, 1, direct)
+		assert.Len(t, q.entries, 3)
+	})
+
+	t.Run("Kept On Storage Error", func(t *testing.T) {
+		var (
+			q        = &queue{}
+			errStore = errors.New("any error")
+		)
+
+		buffer := newBuffer(q, func(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]statistic.PlayerProgression, []statistic.PlayerProgressionUpdates, error) {
+			return nil, nil, errStore
+		}, nil)
+
+		_, _, err := buffer.Update(ctx, st, "alice", 10)
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, buffer.Flush(ctx), errStore)
+		assert.Len(t, q.entries, 1)
+	})
+
+	t.Run("Removed On Notify Error", func(t *testing.T) {
+		var (
+			q         = &queue{}
+			errNotify = errors.New("any error")
+			goal      = 10.0
+		)
+
+		buffer, err := NewBuffer(config, q.append, q.peek, q.remove, q.count, q.getCheckpoint,
+			func(ctx context.Context, st statistic.Statistic, progression statistic.PlayerProgression, updates statistic.PlayerProgressionUpdates) error {
+				return errNotify
+			},
+			func(ctx context.Context, changes []statistic.PlayerProgressionChange) ([]statistic.PlayerProgression, []statistic.PlayerProgressionUpdates, error) {
+				updates := []statistic.PlayerProgressionUpdates{{GoalJustCompleted: true}}
+				return []statistic.PlayerProgression{{GoalValue: &goal}}, updates, nil
+			},
+			nil,
+		)
+		assert.NoError(t, err)
+
+		_, _, err = buffer.Update(ctx, st, "alice", 10)
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, buffer.Flush(ctx), errNotify)
+		assert.Empty(t, q.entries)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		q := &queue{}
+
+		_, err := NewBuffer(Config{}, q.append, q.peek, q.remove, q.count, q.getCheckpoint, nil, nil, nil)
+		assert.ErrorIs(t, err, ErrValidationError)
+		assert.ErrorIs(t, err, ErrInvalidQueueID)
+		assert.ErrorIs(t, err, ErrInvalidBatchSize)
+		assert.ErrorIs(t, err, ErrInvalidMaxPending)
+	})
+}